@@ -0,0 +1,10 @@
+package enums
+
+type ArchiveStatus string
+
+const (
+	ArchiveStatusPending    ArchiveStatus = "PENDING"
+	ArchiveStatusProcessing ArchiveStatus = "PROCESSING"
+	ArchiveStatusCompleted  ArchiveStatus = "COMPLETED"
+	ArchiveStatusFailed     ArchiveStatus = "FAILED"
+)
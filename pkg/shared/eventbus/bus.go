@@ -0,0 +1,103 @@
+package eventbus
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	eventspb "github.com/Ernestgio/Hangout-Planner/pkg/shared/proto/gen/go/events"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// SchemaVersion is stamped on every envelope.
+const SchemaVersion = 1
+
+const (
+	SubjectHangoutEvents = "hangout.events.v1"
+	SubjectFileEvents    = "file.events.v1"
+)
+
+const (
+	EventHangoutCreated       = "hangout.created"
+	EventHangoutStatusChanged = "hangout.status_changed"
+	EventHangoutDeleted       = "hangout.deleted"
+	EventMemoryUploaded       = "memory.uploaded"
+	EventFileUploadProcessed  = "file.upload_processed"
+	EventFileDeleted          = "file.deleted"
+)
+
+// Publisher is what services depend on to emit domain events.
+type Publisher interface {
+	Publish(ctx context.Context, payload proto.Message) error
+}
+
+type EnvelopeHandler func(ctx context.Context, event *eventspb.EventEnvelope) error
+
+type Bus struct {
+	transport Transport
+	source    string
+}
+
+// NewBus creates a bus on top of transport. source names the publishing
+// service and is copied into every envelope.
+func NewBus(transport Transport, source string) *Bus {
+	return &Bus{transport: transport, source: source}
+}
+
+// Publish wraps payload in an envelope and sends it on the subject of its
+// aggregate, keyed by the aggregate ID.
+func (b *Bus) Publish(ctx context.Context, payload proto.Message) error {
+	env := &eventspb.EventEnvelope{
+		Id:            uuid.NewString(),
+		SchemaVersion: SchemaVersion,
+		Source:        b.source,
+		OccurredAt:    timestamppb.New(time.Now().UTC()),
+	}
+
+	var subject, key string
+	switch p := payload.(type) {
+	case *eventspb.HangoutCreated:
+		subject, key, env.Type = SubjectHangoutEvents, p.GetHangoutId(), EventHangoutCreated
+		env.Payload = &eventspb.EventEnvelope_HangoutCreated{HangoutCreated: p}
+	case *eventspb.HangoutStatusChanged:
+		subject, key, env.Type = SubjectHangoutEvents, p.GetHangoutId(), EventHangoutStatusChanged
+		env.Payload = &eventspb.EventEnvelope_HangoutStatusChanged{HangoutStatusChanged: p}
+	case *eventspb.HangoutDeleted:
+		subject, key, env.Type = SubjectHangoutEvents, p.GetHangoutId(), EventHangoutDeleted
+		env.Payload = &eventspb.EventEnvelope_HangoutDeleted{HangoutDeleted: p}
+	case *eventspb.MemoryUploaded:
+		subject, key, env.Type = SubjectHangoutEvents, p.GetHangoutId(), EventMemoryUploaded
+		env.Payload = &eventspb.EventEnvelope_MemoryUploaded{MemoryUploaded: p}
+	case *eventspb.FileUploadProcessed:
+		subject, key, env.Type = SubjectFileEvents, p.GetMemoryId(), EventFileUploadProcessed
+		env.Payload = &eventspb.EventEnvelope_FileUploadProcessed{FileUploadProcessed: p}
+	case *eventspb.FileDeleted:
+		subject, key, env.Type = SubjectFileEvents, p.GetMemoryId(), EventFileDeleted
+		env.Payload = &eventspb.EventEnvelope_FileDeleted{FileDeleted: p}
+	default:
+		return fmt.Errorf("%w: %T", ErrUnknownEvent, payload)
+	}
+
+	data, err := proto.Marshal(env)
+	if err != nil {
+		return err
+	}
+	return b.transport.Publish(ctx, Message{Subject: subject, Key: key, Data: data})
+}
+
+// Subscribe decodes envelopes arriving on subject and passes them to handler.
+func (b *Bus) Subscribe(subject, group string, handler EnvelopeHandler) (Subscription, error) {
+	return b.transport.Subscribe(subject, group, func(ctx context.Context, msg Message) error {
+		var env eventspb.EventEnvelope
+		if err := proto.Unmarshal(msg.Data, &env); err != nil {
+			return err
+		}
+		return handler(ctx, &env)
+	})
+}
+
+func (b *Bus) Close() error {
+	return b.transport.Close()
+}
@@ -0,0 +1,139 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	eventspb "github.com/Ernestgio/Hangout-Planner/pkg/shared/proto/gen/go/events"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+type recordingTransport struct {
+	published []Message
+	handler   Handler
+}
+
+func (r *recordingTransport) Publish(ctx context.Context, msg Message) error {
+	r.published = append(r.published, msg)
+	return nil
+}
+
+func (r *recordingTransport) Subscribe(subject, group string, handler Handler) (Subscription, error) {
+	r.handler = handler
+	return nil, nil
+}
+
+func (r *recordingTransport) Close() error { return nil }
+
+func TestBus_PublishWrapsPayload(t *testing.T) {
+	tests := []struct {
+		name      string
+		payload   proto.Message
+		subject   string
+		key       string
+		eventType string
+	}{
+		{"hangout created", &eventspb.HangoutCreated{HangoutId: "h1"}, SubjectHangoutEvents, "h1", EventHangoutCreated},
+		{"hangout status changed", &eventspb.HangoutStatusChanged{HangoutId: "h1"}, SubjectHangoutEvents, "h1", EventHangoutStatusChanged},
+		{"hangout deleted", &eventspb.HangoutDeleted{HangoutId: "h1"}, SubjectHangoutEvents, "h1", EventHangoutDeleted},
+		{"memory uploaded", &eventspb.MemoryUploaded{HangoutId: "h1", MemoryId: "m1"}, SubjectHangoutEvents, "h1", EventMemoryUploaded},
+		{"file upload processed", &eventspb.FileUploadProcessed{MemoryId: "m1"}, SubjectFileEvents, "m1", EventFileUploadProcessed},
+		{"file deleted", &eventspb.FileDeleted{MemoryId: "m1"}, SubjectFileEvents, "m1", EventFileDeleted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := &recordingTransport{}
+			bus := NewBus(transport, "test-service")
+
+			if err := bus.Publish(context.Background(), tt.payload); err != nil {
+				t.Fatalf("publish: %v", err)
+			}
+			if len(transport.published) != 1 {
+				t.Fatalf("published %d messages, want 1", len(transport.published))
+			}
+			msg := transport.published[0]
+			if msg.Subject != tt.subject || msg.Key != tt.key {
+				t.Errorf("subject/key = %q/%q, want %q/%q", msg.Subject, msg.Key, tt.subject, tt.key)
+			}
+
+			var env eventspb.EventEnvelope
+			if err := proto.Unmarshal(msg.Data, &env); err != nil {
+				t.Fatalf("unmarshal: %v", err)
+			}
+			if env.GetType() != tt.eventType {
+				t.Errorf("type = %q, want %q", env.GetType(), tt.eventType)
+			}
+			if env.GetId() == "" || env.GetOccurredAt() == nil {
+				t.Error("envelope id and occurred_at must be set")
+			}
+			if env.GetSchemaVersion() != SchemaVersion || env.GetSource() != "test-service" {
+				t.Errorf("schema/source = %d/%q", env.GetSchemaVersion(), env.GetSource())
+			}
+		})
+	}
+}
+
+func TestBus_PublishUnknownPayload(t *testing.T) {
+	bus := NewBus(&recordingTransport{}, "test-service")
+
+	err := bus.Publish(context.Background(), wrapperspb.String("nope"))
+	if !errors.Is(err, ErrUnknownEvent) {
+		t.Errorf("error = %v, want %v", err, ErrUnknownEvent)
+	}
+}
+
+func TestBus_SubscribeDecodesEnvelope(t *testing.T) {
+	transport := &recordingTransport{}
+	bus := NewBus(transport, "test-service")
+
+	var got *eventspb.EventEnvelope
+	if _, err := bus.Subscribe(SubjectFileEvents, "g", func(ctx context.Context, env *eventspb.EventEnvelope) error {
+		got = env
+		return nil
+	}); err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+
+	payload := &eventspb.FileDeleted{FileId: "f1", MemoryId: "m1", Reason: eventspb.FileDeletionReason_FILE_DELETION_REASON_PURGED}
+	if err := bus.Publish(context.Background(), payload); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+	if err := transport.handler(context.Background(), transport.published[0]); err != nil {
+		t.Fatalf("handler: %v", err)
+	}
+	if !proto.Equal(got.GetFileDeleted(), payload) {
+		t.Errorf("payload = %v, want %v", got.GetFileDeleted(), payload)
+	}
+
+	if err := transport.handler(context.Background(), Message{Data: []byte{0xff}}); err == nil {
+		t.Error("expected error for malformed message")
+	}
+}
+
+func TestBus_EndToEndOverMemoryTransport(t *testing.T) {
+	transport := NewMemoryTransport(4, nil)
+	bus := NewBus(transport, "file-service")
+
+	received := make(chan *eventspb.EventEnvelope, 1)
+	if _, err := bus.Subscribe(SubjectFileEvents, "hangout-service", func(ctx context.Context, env *eventspb.EventEnvelope) error {
+		received <- env
+		return nil
+	}); err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+
+	if err := bus.Publish(context.Background(), &eventspb.FileUploadProcessed{FileId: "f1", MemoryId: "m1"}); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+	if err := bus.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+
+	env := <-received
+	if env.GetFileUploadProcessed().GetFileId() != "f1" || env.GetSource() != "file-service" {
+		t.Errorf("unexpected envelope %v", env)
+	}
+}
@@ -0,0 +1,163 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// MemoryTransport is an in-process Transport.
+type MemoryTransport struct {
+	mu         sync.Mutex
+	subjects   map[string]map[string]*memoryGroup
+	closed     bool
+	bufferSize int
+	onError    func(Message, error)
+	wg         sync.WaitGroup
+}
+
+type memoryGroup struct {
+	members []*memorySubscription
+	next    int
+}
+
+type memoryDelivery struct {
+	ctx context.Context
+	msg Message
+}
+
+type memorySubscription struct {
+	transport *MemoryTransport
+	subject   string
+	group     string
+	queue     chan memoryDelivery
+	removed   bool
+}
+
+// NewMemoryTransport creates an in-memory transport. onError, when set,
+// receives messages whose handler returned an error.
+func NewMemoryTransport(bufferSize int, onError func(Message, error)) *MemoryTransport {
+	return &MemoryTransport{
+		subjects:   make(map[string]map[string]*memoryGroup),
+		bufferSize: bufferSize,
+		onError:    onError,
+	}
+}
+
+func (t *MemoryTransport) Publish(ctx context.Context, msg Message) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.closed {
+		return ErrTransportClosed
+	}
+
+	// Handlers run after the publishing request has returned, so they keep
+	// the context values (trace, logging) but not its cancellation.
+	delivery := memoryDelivery{ctx: context.WithoutCancel(ctx), msg: msg}
+
+	var errs []error
+	for group, g := range t.subjects[msg.Subject] {
+		if len(g.members) == 0 {
+			continue
+		}
+		targets := g.members
+		if group != "" {
+			targets = []*memorySubscription{g.members[g.next%len(g.members)]}
+			g.next++
+		}
+		for _, sub := range targets {
+			select {
+			case sub.queue <- delivery:
+			default:
+				errs = append(errs, ErrBufferFull)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (t *MemoryTransport) Subscribe(subject, group string, handler Handler) (Subscription, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.closed {
+		return nil, ErrTransportClosed
+	}
+
+	sub := &memorySubscription{
+		transport: t,
+		subject:   subject,
+		group:     group,
+		queue:     make(chan memoryDelivery, t.bufferSize),
+	}
+
+	groups, ok := t.subjects[subject]
+	if !ok {
+		groups = make(map[string]*memoryGroup)
+		t.subjects[subject] = groups
+	}
+	g, ok := groups[group]
+	if !ok {
+		g = &memoryGroup{}
+		groups[group] = g
+	}
+	g.members = append(g.members, sub)
+
+	t.wg.Add(1)
+	go func() {
+		defer t.wg.Done()
+		for d := range sub.queue {
+			if err := handler(d.ctx, d.msg); err != nil && t.onError != nil {
+				t.onError(d.msg, err)
+			}
+		}
+	}()
+
+	return sub, nil
+}
+
+// Close stops accepting messages and waits until every queued message has
+// been handled.
+func (t *MemoryTransport) Close() error {
+	t.mu.Lock()
+	if t.closed {
+		t.mu.Unlock()
+		return nil
+	}
+	t.closed = true
+	for _, groups := range t.subjects {
+		for _, g := range groups {
+			for _, sub := range g.members {
+				sub.removed = true
+				close(sub.queue)
+			}
+			g.members = nil
+		}
+	}
+	t.mu.Unlock()
+
+	t.wg.Wait()
+	return nil
+}
+
+func (s *memorySubscription) Unsubscribe() error {
+	t := s.transport
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if s.removed {
+		return nil
+	}
+	s.removed = true
+
+	g := t.subjects[s.subject][s.group]
+	for i, member := range g.members {
+		if member == s {
+			g.members = append(g.members[:i], g.members[i+1:]...)
+			break
+		}
+	}
+	close(s.queue)
+	return nil
+}
@@ -0,0 +1,183 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func collect(t *testing.T, transport *MemoryTransport, subject, group string) (*[]Message, *sync.Mutex) {
+	t.Helper()
+	var mu sync.Mutex
+	got := []Message{}
+	_, err := transport.Subscribe(subject, group, func(ctx context.Context, msg Message) error {
+		mu.Lock()
+		defer mu.Unlock()
+		got = append(got, msg)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+	return &got, &mu
+}
+
+func TestMemoryTransport_GroupsSplitAndBroadcast(t *testing.T) {
+	transport := NewMemoryTransport(16, nil)
+
+	a, muA := collect(t, transport, "s", "workers")
+	b, muB := collect(t, transport, "s", "workers")
+	c, muC := collect(t, transport, "s", "")
+	other, muOther := collect(t, transport, "other", "")
+
+	for i := 0; i < 4; i++ {
+		if err := transport.Publish(context.Background(), Message{Subject: "s", Data: []byte{byte(i)}}); err != nil {
+			t.Fatalf("publish: %v", err)
+		}
+	}
+	if err := transport.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+
+	muA.Lock()
+	muB.Lock()
+	muC.Lock()
+	muOther.Lock()
+	defer muA.Unlock()
+	defer muB.Unlock()
+	defer muC.Unlock()
+	defer muOther.Unlock()
+
+	if len(*a) != 2 || len(*b) != 2 {
+		t.Errorf("group members got %d and %d messages, want 2 each", len(*a), len(*b))
+	}
+	if len(*c) != 4 {
+		t.Errorf("ungrouped subscriber got %d messages, want 4", len(*c))
+	}
+	if len(*other) != 0 {
+		t.Errorf("other subject got %d messages, want 0", len(*other))
+	}
+}
+
+func TestMemoryTransport_HandlerErrorsReported(t *testing.T) {
+	handlerErr := errors.New("boom")
+	reported := make(chan error, 1)
+	transport := NewMemoryTransport(1, func(msg Message, err error) { reported <- err })
+
+	_, err := transport.Subscribe("s", "g", func(ctx context.Context, msg Message) error { return handlerErr })
+	if err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+	if err := transport.Publish(context.Background(), Message{Subject: "s"}); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+
+	select {
+	case err := <-reported:
+		if !errors.Is(err, handlerErr) {
+			t.Errorf("reported %v, want %v", err, handlerErr)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("handler error was not reported")
+	}
+	_ = transport.Close()
+}
+
+func TestMemoryTransport_BufferFull(t *testing.T) {
+	transport := NewMemoryTransport(1, nil)
+	release := make(chan struct{})
+	started := make(chan struct{}, 1)
+
+	_, err := transport.Subscribe("s", "g", func(ctx context.Context, msg Message) error {
+		started <- struct{}{}
+		<-release
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+
+	if err := transport.Publish(context.Background(), Message{Subject: "s"}); err != nil {
+		t.Fatalf("first publish: %v", err)
+	}
+	<-started
+	if err := transport.Publish(context.Background(), Message{Subject: "s"}); err != nil {
+		t.Fatalf("second publish: %v", err)
+	}
+	if err := transport.Publish(context.Background(), Message{Subject: "s"}); !errors.Is(err, ErrBufferFull) {
+		t.Errorf("third publish error = %v, want %v", err, ErrBufferFull)
+	}
+
+	close(release)
+	_ = transport.Close()
+}
+
+func TestMemoryTransport_UnsubscribeAndClose(t *testing.T) {
+	transport := NewMemoryTransport(4, nil)
+	got, mu := collect(t, transport, "s", "")
+
+	sub, err := transport.Subscribe("s", "", func(ctx context.Context, msg Message) error {
+		t.Error("unsubscribed handler was called")
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+	if err := sub.Unsubscribe(); err != nil {
+		t.Fatalf("unsubscribe: %v", err)
+	}
+	if err := sub.Unsubscribe(); err != nil {
+		t.Fatalf("second unsubscribe: %v", err)
+	}
+
+	if err := transport.Publish(context.Background(), Message{Subject: "s"}); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+	if err := transport.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+
+	mu.Lock()
+	if len(*got) != 1 {
+		t.Errorf("got %d messages, want 1", len(*got))
+	}
+	mu.Unlock()
+
+	if err := transport.Publish(context.Background(), Message{Subject: "s"}); !errors.Is(err, ErrTransportClosed) {
+		t.Errorf("publish after close error = %v, want %v", err, ErrTransportClosed)
+	}
+	if _, err := transport.Subscribe("s", "", nil); !errors.Is(err, ErrTransportClosed) {
+		t.Errorf("subscribe after close error = %v, want %v", err, ErrTransportClosed)
+	}
+	if err := sub.Unsubscribe(); err != nil {
+		t.Errorf("unsubscribe after close: %v", err)
+	}
+}
+
+func TestMemoryTransport_DetachesCancellation(t *testing.T) {
+	transport := NewMemoryTransport(1, nil)
+	gate := make(chan struct{})
+	done := make(chan error, 1)
+	_, err := transport.Subscribe("s", "", func(ctx context.Context, msg Message) error {
+		<-gate
+		done <- ctx.Err()
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	if err := transport.Publish(ctx, Message{Subject: "s"}); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+	cancel()
+	close(gate)
+
+	if err := <-done; err != nil {
+		t.Errorf("handler context error = %v, want nil", err)
+	}
+	_ = transport.Close()
+}
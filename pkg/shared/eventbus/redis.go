@@ -0,0 +1,273 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	redisKeyField  = "key"
+	redisDataField = "data"
+
+	// redisReadBlock bounds each blocking read, so Unsubscribe and Close
+	// return within it.
+	redisReadBlock = time.Second
+	// redisClaimIdle is how long a message may sit unacknowledged before
+	// another consumer of the group takes it over.
+	redisClaimIdle = time.Minute
+	redisReadCount = 16
+	// redisRetryDelay is the pause after a failed read, so an unreachable
+	// server is not hammered.
+	redisRetryDelay = time.Second
+)
+
+// RedisTransport carries messages over Redis streams, one stream per subject, so
+// publishers and subscribers in different processes meet on the same server.
+type RedisTransport struct {
+	client    redis.UniversalClient
+	ownClient bool
+	maxLen    int64
+	consumer  string
+	onError   func(Message, error)
+
+	mu     sync.Mutex
+	closed bool
+	subs   map[*redisSubscription]struct{}
+
+	readBlock  time.Duration
+	claimIdle  time.Duration
+	retryDelay time.Duration
+}
+
+type redisSubscription struct {
+	transport *RedisTransport
+	cancel    context.CancelFunc
+	done      chan struct{}
+}
+
+// NewRedisTransport creates a transport on client.
+func NewRedisTransport(client redis.UniversalClient, maxLen int64, onError func(Message, error)) *RedisTransport {
+	host, _ := os.Hostname()
+	return &RedisTransport{
+		client:     client,
+		maxLen:     maxLen,
+		consumer:   host + "-" + uuid.NewString(),
+		onError:    onError,
+		subs:       make(map[*redisSubscription]struct{}),
+		readBlock:  redisReadBlock,
+		claimIdle:  redisClaimIdle,
+		retryDelay: redisRetryDelay,
+	}
+}
+
+func (t *RedisTransport) Publish(ctx context.Context, msg Message) error {
+	t.mu.Lock()
+	closed := t.closed
+	t.mu.Unlock()
+	if closed {
+		return ErrTransportClosed
+	}
+
+	return t.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: msg.Subject,
+		MaxLen: t.maxLen,
+		Approx: t.maxLen > 0,
+		Values: map[string]any{redisKeyField: msg.Key, redisDataField: msg.Data},
+	}).Err()
+}
+
+func (t *RedisTransport) Subscribe(subject, group string, handler Handler) (Subscription, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.closed {
+		return nil, ErrTransportClosed
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	sub := &redisSubscription{transport: t, cancel: cancel, done: make(chan struct{})}
+
+	var run func()
+	if group != "" {
+		// a group created here starts at the end of the stream; the group
+		// keeps its position across restarts from then on
+		err := t.client.XGroupCreateMkStream(ctx, subject, group, "$").Err()
+		if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+			cancel()
+			return nil, err
+		}
+		run = func() { t.readGroup(ctx, subject, group, handler) }
+	} else {
+		last, err := t.lastID(ctx, subject)
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+		run = func() { t.readAll(ctx, subject, last, handler) }
+	}
+
+	t.subs[sub] = struct{}{}
+	go func() {
+		defer close(sub.done)
+		run()
+	}()
+	return sub, nil
+}
+
+// Close stops every subscription and waits for handlers in flight.
+func (t *RedisTransport) Close() error {
+	t.mu.Lock()
+	if t.closed {
+		t.mu.Unlock()
+		return nil
+	}
+	t.closed = true
+	subs := t.subs
+	t.subs = nil
+	t.mu.Unlock()
+
+	for sub := range subs {
+		sub.stop()
+	}
+	if t.ownClient {
+		return t.client.Close()
+	}
+	return nil
+}
+
+func (s *redisSubscription) Unsubscribe() error {
+	t := s.transport
+	t.mu.Lock()
+	delete(t.subs, s)
+	t.mu.Unlock()
+
+	s.stop()
+	return nil
+}
+
+func (s *redisSubscription) stop() {
+	s.cancel()
+	<-s.done
+}
+
+func (t *RedisTransport) readGroup(ctx context.Context, subject, group string, handler Handler) {
+	var lastClaim time.Time
+	for ctx.Err() == nil {
+		if time.Since(lastClaim) >= t.claimIdle {
+			lastClaim = time.Now()
+			t.claim(ctx, subject, group, handler)
+		}
+
+		streams, err := t.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+			Group:    group,
+			Consumer: t.consumer,
+			Streams:  []string{subject, ">"},
+			Count:    redisReadCount,
+			Block:    t.readBlock,
+		}).Result()
+		if err != nil {
+			t.pauseAfter(ctx, err)
+			continue
+		}
+		for _, stream := range streams {
+			for _, entry := range stream.Messages {
+				t.handle(subject, entry, handler)
+				t.client.XAck(context.Background(), subject, group, entry.ID)
+			}
+		}
+	}
+}
+
+// claim takes over the messages other consumers of the group read but never
+// acknowledged, for instance because their process stopped.
+func (t *RedisTransport) claim(ctx context.Context, subject, group string, handler Handler) {
+	start := "0-0"
+	for ctx.Err() == nil {
+		entries, next, err := t.client.XAutoClaim(ctx, &redis.XAutoClaimArgs{
+			Stream:   subject,
+			Group:    group,
+			Consumer: t.consumer,
+			MinIdle:  t.claimIdle,
+			Start:    start,
+			Count:    redisReadCount,
+		}).Result()
+		if err != nil {
+			return
+		}
+		for _, entry := range entries {
+			t.handle(subject, entry, handler)
+			t.client.XAck(context.Background(), subject, group, entry.ID)
+		}
+		if next == "0-0" || len(entries) == 0 {
+			return
+		}
+		start = next
+	}
+}
+
+func (t *RedisTransport) readAll(ctx context.Context, subject, last string, handler Handler) {
+	for ctx.Err() == nil {
+		streams, err := t.client.XRead(ctx, &redis.XReadArgs{
+			Streams: []string{subject, last},
+			Count:   redisReadCount,
+			Block:   t.readBlock,
+		}).Result()
+		if err != nil {
+			t.pauseAfter(ctx, err)
+			continue
+		}
+		for _, stream := range streams {
+			for _, entry := range stream.Messages {
+				t.handle(subject, entry, handler)
+				last = entry.ID
+			}
+		}
+	}
+}
+
+// lastID returns the ID of the newest entry of the stream, so a reader
+// starting from it only sees what is published afterwards.
+func (t *RedisTransport) lastID(ctx context.Context, subject string) (string, error) {
+	entries, err := t.client.XRevRangeN(ctx, subject, "+", "-", 1).Result()
+	if err != nil {
+		return "", err
+	}
+	if len(entries) == 0 {
+		return "0-0", nil
+	}
+	return entries[0].ID, nil
+}
+
+// handle runs handler for one entry.
+func (t *RedisTransport) handle(subject string, entry redis.XMessage, handler Handler) {
+	msg := Message{Subject: subject}
+	if key, ok := entry.Values[redisKeyField].(string); ok {
+		msg.Key = key
+	}
+	if data, ok := entry.Values[redisDataField].(string); ok {
+		msg.Data = []byte(data)
+	}
+
+	if err := handler(context.Background(), msg); err != nil && t.onError != nil {
+		t.onError(msg, err)
+	}
+}
+
+// pauseAfter waits before the next read when err is a failure rather than a
+// read that timed out without messages.
+func (t *RedisTransport) pauseAfter(ctx context.Context, err error) {
+	if errors.Is(err, redis.Nil) || ctx.Err() != nil {
+		return
+	}
+	select {
+	case <-ctx.Done():
+	case <-time.After(t.retryDelay):
+	}
+}
@@ -0,0 +1,204 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+// newRedisTransport returns a transport on server with its own client, as
+// a separate process would have.
+func newRedisTransport(t *testing.T, server *miniredis.Miniredis, onError func(Message, error)) *RedisTransport {
+	t.Helper()
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	transport := NewRedisTransport(client, 100, onError)
+	transport.readBlock = 20 * time.Millisecond
+	t.Cleanup(func() {
+		_ = transport.Close()
+		_ = client.Close()
+	})
+	return transport
+}
+
+type received struct {
+	mu   sync.Mutex
+	msgs []Message
+}
+
+func (r *received) handler(ctx context.Context, msg Message) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.msgs = append(r.msgs, msg)
+	return nil
+}
+
+func (r *received) count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.msgs)
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met in time")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestRedisTransport_DeliversBetweenProcesses(t *testing.T) {
+	server := miniredis.RunT(t)
+	publisher := newRedisTransport(t, server, nil)
+	workerA := newRedisTransport(t, server, nil)
+	workerB := newRedisTransport(t, server, nil)
+	listener := newRedisTransport(t, server, nil)
+
+	var a, b, all received
+	if _, err := workerA.Subscribe("s", "workers", a.handler); err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+	if _, err := workerB.Subscribe("s", "workers", b.handler); err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+	if _, err := listener.Subscribe("s", "", all.handler); err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+
+	for i := 0; i < 4; i++ {
+		msg := Message{Subject: "s", Key: "k", Data: []byte{byte(i)}}
+		if err := publisher.Publish(context.Background(), msg); err != nil {
+			t.Fatalf("publish: %v", err)
+		}
+	}
+
+	waitFor(t, func() bool { return a.count()+b.count() == 4 && all.count() == 4 })
+
+	all.mu.Lock()
+	defer all.mu.Unlock()
+	for i, msg := range all.msgs {
+		if msg.Subject != "s" || msg.Key != "k" || len(msg.Data) != 1 || msg.Data[0] != byte(i) {
+			t.Errorf("message %d = %+v", i, msg)
+		}
+	}
+}
+
+func TestRedisTransport_UngroupedStartsAtSubscribe(t *testing.T) {
+	server := miniredis.RunT(t)
+	transport := newRedisTransport(t, server, nil)
+
+	if err := transport.Publish(context.Background(), Message{Subject: "s", Data: []byte("old")}); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+	var got received
+	if _, err := transport.Subscribe("s", "", got.handler); err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+	if err := transport.Publish(context.Background(), Message{Subject: "s", Data: []byte("new")}); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+
+	waitFor(t, func() bool { return got.count() == 1 })
+	got.mu.Lock()
+	defer got.mu.Unlock()
+	if data := string(got.msgs[0].Data); data != "new" {
+		t.Errorf("got %q, want the message published after subscribing", data)
+	}
+}
+
+func TestRedisTransport_ClaimsMessagesOfStoppedConsumers(t *testing.T) {
+	server := miniredis.RunT(t)
+	transport := newRedisTransport(t, server, nil)
+	transport.claimIdle = 10 * time.Millisecond
+
+	// another instance joined the group, read a message and stopped before
+	// acknowledging it
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	defer client.Close()
+	ctx := context.Background()
+	if err := client.XGroupCreateMkStream(ctx, "s", "workers", "$").Err(); err != nil {
+		t.Fatalf("create group: %v", err)
+	}
+	if err := transport.Publish(ctx, Message{Subject: "s", Data: []byte("orphan")}); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+	if err := client.XReadGroup(ctx, &redis.XReadGroupArgs{Group: "workers", Consumer: "gone", Streams: []string{"s", ">"}}).Err(); err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	time.Sleep(2 * transport.claimIdle)
+
+	var got received
+	if _, err := transport.Subscribe("s", "workers", got.handler); err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+
+	waitFor(t, func() bool { return got.count() == 1 })
+	waitFor(t, func() bool {
+		pending, err := client.XPending(ctx, "s", "workers").Result()
+		return err == nil && pending.Count == 0
+	})
+}
+
+func TestRedisTransport_HandlerErrorsReported(t *testing.T) {
+	server := miniredis.RunT(t)
+	handlerErr := errors.New("boom")
+	reported := make(chan error, 1)
+	transport := newRedisTransport(t, server, func(msg Message, err error) { reported <- err })
+
+	_, err := transport.Subscribe("s", "g", func(ctx context.Context, msg Message) error { return handlerErr })
+	if err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+	if err := transport.Publish(context.Background(), Message{Subject: "s"}); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+
+	select {
+	case err := <-reported:
+		if !errors.Is(err, handlerErr) {
+			t.Errorf("reported %v, want %v", err, handlerErr)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("handler error was not reported")
+	}
+}
+
+func TestRedisTransport_UnsubscribeAndClose(t *testing.T) {
+	server := miniredis.RunT(t)
+	transport := newRedisTransport(t, server, nil)
+
+	sub, err := transport.Subscribe("s", "g", func(ctx context.Context, msg Message) error {
+		t.Error("unsubscribed handler was called")
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+	if err := sub.Unsubscribe(); err != nil {
+		t.Fatalf("unsubscribe: %v", err)
+	}
+	if err := sub.Unsubscribe(); err != nil {
+		t.Fatalf("second unsubscribe: %v", err)
+	}
+	if err := transport.Publish(context.Background(), Message{Subject: "s"}); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+	time.Sleep(3 * transport.readBlock)
+
+	if err := transport.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	if err := transport.Publish(context.Background(), Message{Subject: "s"}); !errors.Is(err, ErrTransportClosed) {
+		t.Errorf("publish after close error = %v, want %v", err, ErrTransportClosed)
+	}
+	if _, err := transport.Subscribe("s", "", nil); !errors.Is(err, ErrTransportClosed) {
+		t.Errorf("subscribe after close error = %v, want %v", err, ErrTransportClosed)
+	}
+}
@@ -0,0 +1,75 @@
+// Package eventbus carries domain events between services.
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+var (
+	ErrTransportClosed = errors.New("event transport is closed")
+	ErrBufferFull      = errors.New("event subscriber buffer is full")
+	ErrUnknownEvent    = errors.New("unknown event payload")
+	ErrUnknownDriver   = errors.New("unknown event bus driver")
+)
+
+// DriverMemory selects MemoryTransport, which only delivers within one process.
+const (
+	DriverMemory = "memory"
+	DriverRedis  = "redis"
+)
+
+// Message is the unit a Transport moves.
+type Message struct {
+	Subject string
+	Key     string
+	Data    []byte
+}
+
+type Handler func(ctx context.Context, msg Message) error
+
+// Transport is the adapter interface for message brokers.
+type Transport interface {
+	Publish(ctx context.Context, msg Message) error
+	Subscribe(subject, group string, handler Handler) (Subscription, error)
+	Close() error
+}
+
+type Subscription interface {
+	Unsubscribe() error
+}
+
+// Config selects and configures the Transport built by NewTransport.
+type Config struct {
+	Driver string
+	// BufferSize is the queue length of each in-memory subscription.
+	BufferSize int
+	// RedisAddr, RedisPassword and RedisDB locate the server for DriverRedis.
+	RedisAddr     string
+	RedisPassword string
+	RedisDB       int
+	// StreamMaxLen caps each Redis stream, oldest entries are trimmed first.
+	StreamMaxLen int64
+}
+
+// NewTransport builds the Transport for a configured driver.
+func NewTransport(cfg Config, onError func(Message, error)) (Transport, error) {
+	switch cfg.Driver {
+	case DriverMemory:
+		return NewMemoryTransport(cfg.BufferSize, onError), nil
+	case DriverRedis:
+		client := redis.NewClient(&redis.Options{
+			Addr:     cfg.RedisAddr,
+			Password: cfg.RedisPassword,
+			DB:       cfg.RedisDB,
+		})
+		transport := NewRedisTransport(client, cfg.StreamMaxLen, onError)
+		transport.ownClient = true
+		return transport, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownDriver, cfg.Driver)
+	}
+}
@@ -0,0 +1,33 @@
+package eventbus
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+)
+
+func TestNewTransport(t *testing.T) {
+	transport, err := NewTransport(Config{Driver: DriverMemory, BufferSize: 1}, nil)
+	if err != nil {
+		t.Fatalf("memory driver: %v", err)
+	}
+	if _, ok := transport.(*MemoryTransport); !ok {
+		t.Errorf("memory driver returned %T", transport)
+	}
+	_ = transport.Close()
+
+	server := miniredis.RunT(t)
+	transport, err = NewTransport(Config{Driver: DriverRedis, RedisAddr: server.Addr(), StreamMaxLen: 10}, nil)
+	if err != nil {
+		t.Fatalf("redis driver: %v", err)
+	}
+	if _, ok := transport.(*RedisTransport); !ok {
+		t.Errorf("redis driver returned %T", transport)
+	}
+	_ = transport.Close()
+
+	if _, err := NewTransport(Config{Driver: "carrier-pigeon"}, nil); !errors.Is(err, ErrUnknownDriver) {
+		t.Errorf("error = %v, want %v", err, ErrUnknownDriver)
+	}
+}
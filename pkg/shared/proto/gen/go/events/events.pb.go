@@ -0,0 +1,783 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.33.2
+// source: events/events.proto
+
+package eventspb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type FileDeletionReason int32
+
+const (
+	FileDeletionReason_FILE_DELETION_REASON_UNSPECIFIED FileDeletionReason = 0
+	FileDeletionReason_FILE_DELETION_REASON_DELETED     FileDeletionReason = 1
+	FileDeletionReason_FILE_DELETION_REASON_PURGED      FileDeletionReason = 2
+)
+
+// Enum value maps for FileDeletionReason.
+var (
+	FileDeletionReason_name = map[int32]string{
+		0: "FILE_DELETION_REASON_UNSPECIFIED",
+		1: "FILE_DELETION_REASON_DELETED",
+		2: "FILE_DELETION_REASON_PURGED",
+	}
+	FileDeletionReason_value = map[string]int32{
+		"FILE_DELETION_REASON_UNSPECIFIED": 0,
+		"FILE_DELETION_REASON_DELETED":     1,
+		"FILE_DELETION_REASON_PURGED":      2,
+	}
+)
+
+func (x FileDeletionReason) Enum() *FileDeletionReason {
+	p := new(FileDeletionReason)
+	*p = x
+	return p
+}
+
+func (x FileDeletionReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FileDeletionReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_events_events_proto_enumTypes[0].Descriptor()
+}
+
+func (FileDeletionReason) Type() protoreflect.EnumType {
+	return &file_events_events_proto_enumTypes[0]
+}
+
+func (x FileDeletionReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FileDeletionReason.Descriptor instead.
+func (FileDeletionReason) EnumDescriptor() ([]byte, []int) {
+	return file_events_events_proto_rawDescGZIP(), []int{0}
+}
+
+type EventEnvelope struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	SchemaVersion uint32                 `protobuf:"varint,3,opt,name=schema_version,json=schemaVersion,proto3" json:"schema_version,omitempty"`
+	Source        string                 `protobuf:"bytes,4,opt,name=source,proto3" json:"source,omitempty"`
+	OccurredAt    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*EventEnvelope_HangoutCreated
+	//	*EventEnvelope_HangoutStatusChanged
+	//	*EventEnvelope_HangoutDeleted
+	//	*EventEnvelope_MemoryUploaded
+	//	*EventEnvelope_FileUploadProcessed
+	//	*EventEnvelope_FileDeleted
+	Payload       isEventEnvelope_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EventEnvelope) Reset() {
+	*x = EventEnvelope{}
+	mi := &file_events_events_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EventEnvelope) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventEnvelope) ProtoMessage() {}
+
+func (x *EventEnvelope) ProtoReflect() protoreflect.Message {
+	mi := &file_events_events_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventEnvelope.ProtoReflect.Descriptor instead.
+func (*EventEnvelope) Descriptor() ([]byte, []int) {
+	return file_events_events_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *EventEnvelope) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *EventEnvelope) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *EventEnvelope) GetSchemaVersion() uint32 {
+	if x != nil {
+		return x.SchemaVersion
+	}
+	return 0
+}
+
+func (x *EventEnvelope) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *EventEnvelope) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+func (x *EventEnvelope) GetPayload() isEventEnvelope_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *EventEnvelope) GetHangoutCreated() *HangoutCreated {
+	if x != nil {
+		if x, ok := x.Payload.(*EventEnvelope_HangoutCreated); ok {
+			return x.HangoutCreated
+		}
+	}
+	return nil
+}
+
+func (x *EventEnvelope) GetHangoutStatusChanged() *HangoutStatusChanged {
+	if x != nil {
+		if x, ok := x.Payload.(*EventEnvelope_HangoutStatusChanged); ok {
+			return x.HangoutStatusChanged
+		}
+	}
+	return nil
+}
+
+func (x *EventEnvelope) GetHangoutDeleted() *HangoutDeleted {
+	if x != nil {
+		if x, ok := x.Payload.(*EventEnvelope_HangoutDeleted); ok {
+			return x.HangoutDeleted
+		}
+	}
+	return nil
+}
+
+func (x *EventEnvelope) GetMemoryUploaded() *MemoryUploaded {
+	if x != nil {
+		if x, ok := x.Payload.(*EventEnvelope_MemoryUploaded); ok {
+			return x.MemoryUploaded
+		}
+	}
+	return nil
+}
+
+func (x *EventEnvelope) GetFileUploadProcessed() *FileUploadProcessed {
+	if x != nil {
+		if x, ok := x.Payload.(*EventEnvelope_FileUploadProcessed); ok {
+			return x.FileUploadProcessed
+		}
+	}
+	return nil
+}
+
+func (x *EventEnvelope) GetFileDeleted() *FileDeleted {
+	if x != nil {
+		if x, ok := x.Payload.(*EventEnvelope_FileDeleted); ok {
+			return x.FileDeleted
+		}
+	}
+	return nil
+}
+
+type isEventEnvelope_Payload interface {
+	isEventEnvelope_Payload()
+}
+
+type EventEnvelope_HangoutCreated struct {
+	HangoutCreated *HangoutCreated `protobuf:"bytes,10,opt,name=hangout_created,json=hangoutCreated,proto3,oneof"`
+}
+
+type EventEnvelope_HangoutStatusChanged struct {
+	HangoutStatusChanged *HangoutStatusChanged `protobuf:"bytes,11,opt,name=hangout_status_changed,json=hangoutStatusChanged,proto3,oneof"`
+}
+
+type EventEnvelope_HangoutDeleted struct {
+	HangoutDeleted *HangoutDeleted `protobuf:"bytes,12,opt,name=hangout_deleted,json=hangoutDeleted,proto3,oneof"`
+}
+
+type EventEnvelope_MemoryUploaded struct {
+	MemoryUploaded *MemoryUploaded `protobuf:"bytes,13,opt,name=memory_uploaded,json=memoryUploaded,proto3,oneof"`
+}
+
+type EventEnvelope_FileUploadProcessed struct {
+	FileUploadProcessed *FileUploadProcessed `protobuf:"bytes,14,opt,name=file_upload_processed,json=fileUploadProcessed,proto3,oneof"`
+}
+
+type EventEnvelope_FileDeleted struct {
+	FileDeleted *FileDeleted `protobuf:"bytes,15,opt,name=file_deleted,json=fileDeleted,proto3,oneof"`
+}
+
+func (*EventEnvelope_HangoutCreated) isEventEnvelope_Payload() {}
+
+func (*EventEnvelope_HangoutStatusChanged) isEventEnvelope_Payload() {}
+
+func (*EventEnvelope_HangoutDeleted) isEventEnvelope_Payload() {}
+
+func (*EventEnvelope_MemoryUploaded) isEventEnvelope_Payload() {}
+
+func (*EventEnvelope_FileUploadProcessed) isEventEnvelope_Payload() {}
+
+func (*EventEnvelope_FileDeleted) isEventEnvelope_Payload() {}
+
+type HangoutCreated struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	HangoutId     string                 `protobuf:"bytes,1,opt,name=hangout_id,json=hangoutId,proto3" json:"hangout_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Title         string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	Date          *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=date,proto3" json:"date,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HangoutCreated) Reset() {
+	*x = HangoutCreated{}
+	mi := &file_events_events_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HangoutCreated) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HangoutCreated) ProtoMessage() {}
+
+func (x *HangoutCreated) ProtoReflect() protoreflect.Message {
+	mi := &file_events_events_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HangoutCreated.ProtoReflect.Descriptor instead.
+func (*HangoutCreated) Descriptor() ([]byte, []int) {
+	return file_events_events_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *HangoutCreated) GetHangoutId() string {
+	if x != nil {
+		return x.HangoutId
+	}
+	return ""
+}
+
+func (x *HangoutCreated) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *HangoutCreated) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *HangoutCreated) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *HangoutCreated) GetDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Date
+	}
+	return nil
+}
+
+type HangoutStatusChanged struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	HangoutId     string                 `protobuf:"bytes,1,opt,name=hangout_id,json=hangoutId,proto3" json:"hangout_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	FromStatus    string                 `protobuf:"bytes,3,opt,name=from_status,json=fromStatus,proto3" json:"from_status,omitempty"`
+	ToStatus      string                 `protobuf:"bytes,4,opt,name=to_status,json=toStatus,proto3" json:"to_status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HangoutStatusChanged) Reset() {
+	*x = HangoutStatusChanged{}
+	mi := &file_events_events_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HangoutStatusChanged) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HangoutStatusChanged) ProtoMessage() {}
+
+func (x *HangoutStatusChanged) ProtoReflect() protoreflect.Message {
+	mi := &file_events_events_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HangoutStatusChanged.ProtoReflect.Descriptor instead.
+func (*HangoutStatusChanged) Descriptor() ([]byte, []int) {
+	return file_events_events_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *HangoutStatusChanged) GetHangoutId() string {
+	if x != nil {
+		return x.HangoutId
+	}
+	return ""
+}
+
+func (x *HangoutStatusChanged) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *HangoutStatusChanged) GetFromStatus() string {
+	if x != nil {
+		return x.FromStatus
+	}
+	return ""
+}
+
+func (x *HangoutStatusChanged) GetToStatus() string {
+	if x != nil {
+		return x.ToStatus
+	}
+	return ""
+}
+
+type HangoutDeleted struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	HangoutId     string                 `protobuf:"bytes,1,opt,name=hangout_id,json=hangoutId,proto3" json:"hangout_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HangoutDeleted) Reset() {
+	*x = HangoutDeleted{}
+	mi := &file_events_events_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HangoutDeleted) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HangoutDeleted) ProtoMessage() {}
+
+func (x *HangoutDeleted) ProtoReflect() protoreflect.Message {
+	mi := &file_events_events_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HangoutDeleted.ProtoReflect.Descriptor instead.
+func (*HangoutDeleted) Descriptor() ([]byte, []int) {
+	return file_events_events_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *HangoutDeleted) GetHangoutId() string {
+	if x != nil {
+		return x.HangoutId
+	}
+	return ""
+}
+
+func (x *HangoutDeleted) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type MemoryUploaded struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MemoryId      string                 `protobuf:"bytes,1,opt,name=memory_id,json=memoryId,proto3" json:"memory_id,omitempty"`
+	HangoutId     string                 `protobuf:"bytes,2,opt,name=hangout_id,json=hangoutId,proto3" json:"hangout_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Name          string                 `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MemoryUploaded) Reset() {
+	*x = MemoryUploaded{}
+	mi := &file_events_events_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MemoryUploaded) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MemoryUploaded) ProtoMessage() {}
+
+func (x *MemoryUploaded) ProtoReflect() protoreflect.Message {
+	mi := &file_events_events_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MemoryUploaded.ProtoReflect.Descriptor instead.
+func (*MemoryUploaded) Descriptor() ([]byte, []int) {
+	return file_events_events_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *MemoryUploaded) GetMemoryId() string {
+	if x != nil {
+		return x.MemoryId
+	}
+	return ""
+}
+
+func (x *MemoryUploaded) GetHangoutId() string {
+	if x != nil {
+		return x.HangoutId
+	}
+	return ""
+}
+
+func (x *MemoryUploaded) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *MemoryUploaded) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type FileUploadProcessed struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileId        string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	MemoryId      string                 `protobuf:"bytes,2,opt,name=memory_id,json=memoryId,proto3" json:"memory_id,omitempty"`
+	FileSize      int64                  `protobuf:"varint,3,opt,name=file_size,json=fileSize,proto3" json:"file_size,omitempty"`
+	MimeType      string                 `protobuf:"bytes,4,opt,name=mime_type,json=mimeType,proto3" json:"mime_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileUploadProcessed) Reset() {
+	*x = FileUploadProcessed{}
+	mi := &file_events_events_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileUploadProcessed) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileUploadProcessed) ProtoMessage() {}
+
+func (x *FileUploadProcessed) ProtoReflect() protoreflect.Message {
+	mi := &file_events_events_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileUploadProcessed.ProtoReflect.Descriptor instead.
+func (*FileUploadProcessed) Descriptor() ([]byte, []int) {
+	return file_events_events_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *FileUploadProcessed) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+func (x *FileUploadProcessed) GetMemoryId() string {
+	if x != nil {
+		return x.MemoryId
+	}
+	return ""
+}
+
+func (x *FileUploadProcessed) GetFileSize() int64 {
+	if x != nil {
+		return x.FileSize
+	}
+	return 0
+}
+
+func (x *FileUploadProcessed) GetMimeType() string {
+	if x != nil {
+		return x.MimeType
+	}
+	return ""
+}
+
+type FileDeleted struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileId        string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	MemoryId      string                 `protobuf:"bytes,2,opt,name=memory_id,json=memoryId,proto3" json:"memory_id,omitempty"`
+	Reason        FileDeletionReason     `protobuf:"varint,3,opt,name=reason,proto3,enum=events.v1.FileDeletionReason" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileDeleted) Reset() {
+	*x = FileDeleted{}
+	mi := &file_events_events_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileDeleted) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileDeleted) ProtoMessage() {}
+
+func (x *FileDeleted) ProtoReflect() protoreflect.Message {
+	mi := &file_events_events_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileDeleted.ProtoReflect.Descriptor instead.
+func (*FileDeleted) Descriptor() ([]byte, []int) {
+	return file_events_events_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *FileDeleted) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+func (x *FileDeleted) GetMemoryId() string {
+	if x != nil {
+		return x.MemoryId
+	}
+	return ""
+}
+
+func (x *FileDeleted) GetReason() FileDeletionReason {
+	if x != nil {
+		return x.Reason
+	}
+	return FileDeletionReason_FILE_DELETION_REASON_UNSPECIFIED
+}
+
+var File_events_events_proto protoreflect.FileDescriptor
+
+const file_events_events_proto_rawDesc = "" +
+	"\n" +
+	"\x13events/events.proto\x12\tevents.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xf8\x04\n" +
+	"\rEventEnvelope\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12%\n" +
+	"\x0eschema_version\x18\x03 \x01(\rR\rschemaVersion\x12\x16\n" +
+	"\x06source\x18\x04 \x01(\tR\x06source\x12;\n" +
+	"\voccurred_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\x12D\n" +
+	"\x0fhangout_created\x18\n" +
+	" \x01(\v2\x19.events.v1.HangoutCreatedH\x00R\x0ehangoutCreated\x12W\n" +
+	"\x16hangout_status_changed\x18\v \x01(\v2\x1f.events.v1.HangoutStatusChangedH\x00R\x14hangoutStatusChanged\x12D\n" +
+	"\x0fhangout_deleted\x18\f \x01(\v2\x19.events.v1.HangoutDeletedH\x00R\x0ehangoutDeleted\x12D\n" +
+	"\x0fmemory_uploaded\x18\r \x01(\v2\x19.events.v1.MemoryUploadedH\x00R\x0ememoryUploaded\x12T\n" +
+	"\x15file_upload_processed\x18\x0e \x01(\v2\x1e.events.v1.FileUploadProcessedH\x00R\x13fileUploadProcessed\x12;\n" +
+	"\ffile_deleted\x18\x0f \x01(\v2\x16.events.v1.FileDeletedH\x00R\vfileDeletedB\t\n" +
+	"\apayload\"\xa6\x01\n" +
+	"\x0eHangoutCreated\x12\x1d\n" +
+	"\n" +
+	"hangout_id\x18\x01 \x01(\tR\thangoutId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12.\n" +
+	"\x04date\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x04date\"\x8c\x01\n" +
+	"\x14HangoutStatusChanged\x12\x1d\n" +
+	"\n" +
+	"hangout_id\x18\x01 \x01(\tR\thangoutId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1f\n" +
+	"\vfrom_status\x18\x03 \x01(\tR\n" +
+	"fromStatus\x12\x1b\n" +
+	"\tto_status\x18\x04 \x01(\tR\btoStatus\"H\n" +
+	"\x0eHangoutDeleted\x12\x1d\n" +
+	"\n" +
+	"hangout_id\x18\x01 \x01(\tR\thangoutId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"y\n" +
+	"\x0eMemoryUploaded\x12\x1b\n" +
+	"\tmemory_id\x18\x01 \x01(\tR\bmemoryId\x12\x1d\n" +
+	"\n" +
+	"hangout_id\x18\x02 \x01(\tR\thangoutId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04name\x18\x04 \x01(\tR\x04name\"\x85\x01\n" +
+	"\x13FileUploadProcessed\x12\x17\n" +
+	"\afile_id\x18\x01 \x01(\tR\x06fileId\x12\x1b\n" +
+	"\tmemory_id\x18\x02 \x01(\tR\bmemoryId\x12\x1b\n" +
+	"\tfile_size\x18\x03 \x01(\x03R\bfileSize\x12\x1b\n" +
+	"\tmime_type\x18\x04 \x01(\tR\bmimeType\"z\n" +
+	"\vFileDeleted\x12\x17\n" +
+	"\afile_id\x18\x01 \x01(\tR\x06fileId\x12\x1b\n" +
+	"\tmemory_id\x18\x02 \x01(\tR\bmemoryId\x125\n" +
+	"\x06reason\x18\x03 \x01(\x0e2\x1d.events.v1.FileDeletionReasonR\x06reason*}\n" +
+	"\x12FileDeletionReason\x12$\n" +
+	" FILE_DELETION_REASON_UNSPECIFIED\x10\x00\x12 \n" +
+	"\x1cFILE_DELETION_REASON_DELETED\x10\x01\x12\x1f\n" +
+	"\x1bFILE_DELETION_REASON_PURGED\x10\x02BNZLgithub.com/Ernestgio/Hangout-Planner/pkg/shared/proto/gen/go/events;eventspbb\x06proto3"
+
+var (
+	file_events_events_proto_rawDescOnce sync.Once
+	file_events_events_proto_rawDescData []byte
+)
+
+func file_events_events_proto_rawDescGZIP() []byte {
+	file_events_events_proto_rawDescOnce.Do(func() {
+		file_events_events_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_events_events_proto_rawDesc), len(file_events_events_proto_rawDesc)))
+	})
+	return file_events_events_proto_rawDescData
+}
+
+var file_events_events_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_events_events_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_events_events_proto_goTypes = []any{
+	(FileDeletionReason)(0),       // 0: events.v1.FileDeletionReason
+	(*EventEnvelope)(nil),         // 1: events.v1.EventEnvelope
+	(*HangoutCreated)(nil),        // 2: events.v1.HangoutCreated
+	(*HangoutStatusChanged)(nil),  // 3: events.v1.HangoutStatusChanged
+	(*HangoutDeleted)(nil),        // 4: events.v1.HangoutDeleted
+	(*MemoryUploaded)(nil),        // 5: events.v1.MemoryUploaded
+	(*FileUploadProcessed)(nil),   // 6: events.v1.FileUploadProcessed
+	(*FileDeleted)(nil),           // 7: events.v1.FileDeleted
+	(*timestamppb.Timestamp)(nil), // 8: google.protobuf.Timestamp
+}
+var file_events_events_proto_depIdxs = []int32{
+	8, // 0: events.v1.EventEnvelope.occurred_at:type_name -> google.protobuf.Timestamp
+	2, // 1: events.v1.EventEnvelope.hangout_created:type_name -> events.v1.HangoutCreated
+	3, // 2: events.v1.EventEnvelope.hangout_status_changed:type_name -> events.v1.HangoutStatusChanged
+	4, // 3: events.v1.EventEnvelope.hangout_deleted:type_name -> events.v1.HangoutDeleted
+	5, // 4: events.v1.EventEnvelope.memory_uploaded:type_name -> events.v1.MemoryUploaded
+	6, // 5: events.v1.EventEnvelope.file_upload_processed:type_name -> events.v1.FileUploadProcessed
+	7, // 6: events.v1.EventEnvelope.file_deleted:type_name -> events.v1.FileDeleted
+	8, // 7: events.v1.HangoutCreated.date:type_name -> google.protobuf.Timestamp
+	0, // 8: events.v1.FileDeleted.reason:type_name -> events.v1.FileDeletionReason
+	9, // [9:9] is the sub-list for method output_type
+	9, // [9:9] is the sub-list for method input_type
+	9, // [9:9] is the sub-list for extension type_name
+	9, // [9:9] is the sub-list for extension extendee
+	0, // [0:9] is the sub-list for field type_name
+}
+
+func init() { file_events_events_proto_init() }
+func file_events_events_proto_init() {
+	if File_events_events_proto != nil {
+		return
+	}
+	file_events_events_proto_msgTypes[0].OneofWrappers = []any{
+		(*EventEnvelope_HangoutCreated)(nil),
+		(*EventEnvelope_HangoutStatusChanged)(nil),
+		(*EventEnvelope_HangoutDeleted)(nil),
+		(*EventEnvelope_MemoryUploaded)(nil),
+		(*EventEnvelope_FileUploadProcessed)(nil),
+		(*EventEnvelope_FileDeleted)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_events_events_proto_rawDesc), len(file_events_events_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_events_events_proto_goTypes,
+		DependencyIndexes: file_events_events_proto_depIdxs,
+		EnumInfos:         file_events_events_proto_enumTypes,
+		MessageInfos:      file_events_events_proto_msgTypes,
+	}.Build()
+	File_events_events_proto = out.File
+	file_events_events_proto_goTypes = nil
+	file_events_events_proto_depIdxs = nil
+}
@@ -22,6 +22,61 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type ArchiveStatus int32
+
+const (
+	ArchiveStatus_ARCHIVE_STATUS_UNSPECIFIED ArchiveStatus = 0
+	ArchiveStatus_ARCHIVE_STATUS_PENDING     ArchiveStatus = 1
+	ArchiveStatus_ARCHIVE_STATUS_PROCESSING  ArchiveStatus = 2
+	ArchiveStatus_ARCHIVE_STATUS_COMPLETED   ArchiveStatus = 3
+	ArchiveStatus_ARCHIVE_STATUS_FAILED      ArchiveStatus = 4
+)
+
+// Enum value maps for ArchiveStatus.
+var (
+	ArchiveStatus_name = map[int32]string{
+		0: "ARCHIVE_STATUS_UNSPECIFIED",
+		1: "ARCHIVE_STATUS_PENDING",
+		2: "ARCHIVE_STATUS_PROCESSING",
+		3: "ARCHIVE_STATUS_COMPLETED",
+		4: "ARCHIVE_STATUS_FAILED",
+	}
+	ArchiveStatus_value = map[string]int32{
+		"ARCHIVE_STATUS_UNSPECIFIED": 0,
+		"ARCHIVE_STATUS_PENDING":     1,
+		"ARCHIVE_STATUS_PROCESSING":  2,
+		"ARCHIVE_STATUS_COMPLETED":   3,
+		"ARCHIVE_STATUS_FAILED":      4,
+	}
+)
+
+func (x ArchiveStatus) Enum() *ArchiveStatus {
+	p := new(ArchiveStatus)
+	*p = x
+	return p
+}
+
+func (x ArchiveStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ArchiveStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_file_file_messages_proto_enumTypes[0].Descriptor()
+}
+
+func (ArchiveStatus) Type() protoreflect.EnumType {
+	return &file_file_file_messages_proto_enumTypes[0]
+}
+
+func (x ArchiveStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ArchiveStatus.Descriptor instead.
+func (ArchiveStatus) EnumDescriptor() ([]byte, []int) {
+	return file_file_file_messages_proto_rawDescGZIP(), []int{0}
+}
+
 type FileWithURL struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -706,6 +761,390 @@ func (x *DeleteFileResponse) GetSuccess() bool {
 	return false
 }
 
+type Archive struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Status         ArchiveStatus          `protobuf:"varint,2,opt,name=status,proto3,enum=file.v1.ArchiveStatus" json:"status,omitempty"`
+	TotalFiles     int32                  `protobuf:"varint,3,opt,name=total_files,json=totalFiles,proto3" json:"total_files,omitempty"`
+	ProcessedFiles int32                  `protobuf:"varint,4,opt,name=processed_files,json=processedFiles,proto3" json:"processed_files,omitempty"`
+	ArchiveSize    int64                  `protobuf:"varint,5,opt,name=archive_size,json=archiveSize,proto3" json:"archive_size,omitempty"`
+	DownloadUrl    string                 `protobuf:"bytes,6,opt,name=download_url,json=downloadUrl,proto3" json:"download_url,omitempty"`
+	UrlExpiresAt   int64                  `protobuf:"varint,7,opt,name=url_expires_at,json=urlExpiresAt,proto3" json:"url_expires_at,omitempty"`
+	Error          string                 `protobuf:"bytes,8,opt,name=error,proto3" json:"error,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	CompletedAt    *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	ExpiresAt      *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Archive) Reset() {
+	*x = Archive{}
+	mi := &file_file_file_messages_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Archive) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Archive) ProtoMessage() {}
+
+func (x *Archive) ProtoReflect() protoreflect.Message {
+	mi := &file_file_file_messages_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Archive.ProtoReflect.Descriptor instead.
+func (*Archive) Descriptor() ([]byte, []int) {
+	return file_file_file_messages_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Archive) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Archive) GetStatus() ArchiveStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ArchiveStatus_ARCHIVE_STATUS_UNSPECIFIED
+}
+
+func (x *Archive) GetTotalFiles() int32 {
+	if x != nil {
+		return x.TotalFiles
+	}
+	return 0
+}
+
+func (x *Archive) GetProcessedFiles() int32 {
+	if x != nil {
+		return x.ProcessedFiles
+	}
+	return 0
+}
+
+func (x *Archive) GetArchiveSize() int64 {
+	if x != nil {
+		return x.ArchiveSize
+	}
+	return 0
+}
+
+func (x *Archive) GetDownloadUrl() string {
+	if x != nil {
+		return x.DownloadUrl
+	}
+	return ""
+}
+
+func (x *Archive) GetUrlExpiresAt() int64 {
+	if x != nil {
+		return x.UrlExpiresAt
+	}
+	return 0
+}
+
+func (x *Archive) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *Archive) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Archive) GetCompletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return nil
+}
+
+func (x *Archive) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type ArchiveDocument struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Content       []byte                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ArchiveDocument) Reset() {
+	*x = ArchiveDocument{}
+	mi := &file_file_file_messages_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ArchiveDocument) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArchiveDocument) ProtoMessage() {}
+
+func (x *ArchiveDocument) ProtoReflect() protoreflect.Message {
+	mi := &file_file_file_messages_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArchiveDocument.ProtoReflect.Descriptor instead.
+func (*ArchiveDocument) Descriptor() ([]byte, []int) {
+	return file_file_file_messages_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ArchiveDocument) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ArchiveDocument) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+type CreateArchiveRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	BaseStoragePath string                 `protobuf:"bytes,1,opt,name=base_storage_path,json=baseStoragePath,proto3" json:"base_storage_path,omitempty"`
+	ArchiveName     string                 `protobuf:"bytes,2,opt,name=archive_name,json=archiveName,proto3" json:"archive_name,omitempty"`
+	MemoryIds       []string               `protobuf:"bytes,3,rep,name=memory_ids,json=memoryIds,proto3" json:"memory_ids,omitempty"`
+	Documents       []*ArchiveDocument     `protobuf:"bytes,4,rep,name=documents,proto3" json:"documents,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CreateArchiveRequest) Reset() {
+	*x = CreateArchiveRequest{}
+	mi := &file_file_file_messages_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateArchiveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateArchiveRequest) ProtoMessage() {}
+
+func (x *CreateArchiveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_file_messages_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateArchiveRequest.ProtoReflect.Descriptor instead.
+func (*CreateArchiveRequest) Descriptor() ([]byte, []int) {
+	return file_file_file_messages_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CreateArchiveRequest) GetBaseStoragePath() string {
+	if x != nil {
+		return x.BaseStoragePath
+	}
+	return ""
+}
+
+func (x *CreateArchiveRequest) GetArchiveName() string {
+	if x != nil {
+		return x.ArchiveName
+	}
+	return ""
+}
+
+func (x *CreateArchiveRequest) GetMemoryIds() []string {
+	if x != nil {
+		return x.MemoryIds
+	}
+	return nil
+}
+
+func (x *CreateArchiveRequest) GetDocuments() []*ArchiveDocument {
+	if x != nil {
+		return x.Documents
+	}
+	return nil
+}
+
+type CreateArchiveResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Archive       *Archive               `protobuf:"bytes,1,opt,name=archive,proto3" json:"archive,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateArchiveResponse) Reset() {
+	*x = CreateArchiveResponse{}
+	mi := &file_file_file_messages_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateArchiveResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateArchiveResponse) ProtoMessage() {}
+
+func (x *CreateArchiveResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_file_file_messages_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateArchiveResponse.ProtoReflect.Descriptor instead.
+func (*CreateArchiveResponse) Descriptor() ([]byte, []int) {
+	return file_file_file_messages_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *CreateArchiveResponse) GetArchive() *Archive {
+	if x != nil {
+		return x.Archive
+	}
+	return nil
+}
+
+type GetArchiveStatusRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ArchiveId       string                 `protobuf:"bytes,1,opt,name=archive_id,json=archiveId,proto3" json:"archive_id,omitempty"`
+	BaseStoragePath string                 `protobuf:"bytes,2,opt,name=base_storage_path,json=baseStoragePath,proto3" json:"base_storage_path,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetArchiveStatusRequest) Reset() {
+	*x = GetArchiveStatusRequest{}
+	mi := &file_file_file_messages_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetArchiveStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetArchiveStatusRequest) ProtoMessage() {}
+
+func (x *GetArchiveStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_file_messages_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetArchiveStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetArchiveStatusRequest) Descriptor() ([]byte, []int) {
+	return file_file_file_messages_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetArchiveStatusRequest) GetArchiveId() string {
+	if x != nil {
+		return x.ArchiveId
+	}
+	return ""
+}
+
+func (x *GetArchiveStatusRequest) GetBaseStoragePath() string {
+	if x != nil {
+		return x.BaseStoragePath
+	}
+	return ""
+}
+
+type GetArchiveStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Archive       *Archive               `protobuf:"bytes,1,opt,name=archive,proto3" json:"archive,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetArchiveStatusResponse) Reset() {
+	*x = GetArchiveStatusResponse{}
+	mi := &file_file_file_messages_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetArchiveStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetArchiveStatusResponse) ProtoMessage() {}
+
+func (x *GetArchiveStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_file_file_messages_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetArchiveStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetArchiveStatusResponse) Descriptor() ([]byte, []int) {
+	return file_file_file_messages_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetArchiveStatusResponse) GetArchive() *Archive {
+	if x != nil {
+		return x.Archive
+	}
+	return nil
+}
+
 var File_file_file_messages_proto protoreflect.FileDescriptor
 
 const file_file_file_messages_proto_rawDesc = "" +
@@ -758,7 +1197,46 @@ const file_file_file_messages_proto_rawDesc = "" +
 	"\x11DeleteFileRequest\x12\x1b\n" +
 	"\tmemory_id\x18\x01 \x01(\tR\bmemoryId\".\n" +
 	"\x12DeleteFileResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccessBJZHgithub.com/Ernestgio/Hangout-Planner/pkg/shared/proto/gen/go/file;filepbb\x06proto3"
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\xca\x03\n" +
+	"\aArchive\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12.\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x16.file.v1.ArchiveStatusR\x06status\x12\x1f\n" +
+	"\vtotal_files\x18\x03 \x01(\x05R\n" +
+	"totalFiles\x12'\n" +
+	"\x0fprocessed_files\x18\x04 \x01(\x05R\x0eprocessedFiles\x12!\n" +
+	"\farchive_size\x18\x05 \x01(\x03R\varchiveSize\x12!\n" +
+	"\fdownload_url\x18\x06 \x01(\tR\vdownloadUrl\x12$\n" +
+	"\x0eurl_expires_at\x18\a \x01(\x03R\furlExpiresAt\x12\x14\n" +
+	"\x05error\x18\b \x01(\tR\x05error\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12=\n" +
+	"\fcompleted_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt\x129\n" +
+	"\n" +
+	"expires_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"?\n" +
+	"\x0fArchiveDocument\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\acontent\x18\x02 \x01(\fR\acontent\"\xbc\x01\n" +
+	"\x14CreateArchiveRequest\x12*\n" +
+	"\x11base_storage_path\x18\x01 \x01(\tR\x0fbaseStoragePath\x12!\n" +
+	"\farchive_name\x18\x02 \x01(\tR\varchiveName\x12\x1d\n" +
+	"\n" +
+	"memory_ids\x18\x03 \x03(\tR\tmemoryIds\x126\n" +
+	"\tdocuments\x18\x04 \x03(\v2\x18.file.v1.ArchiveDocumentR\tdocuments\"C\n" +
+	"\x15CreateArchiveResponse\x12*\n" +
+	"\aarchive\x18\x01 \x01(\v2\x10.file.v1.ArchiveR\aarchive\"d\n" +
+	"\x17GetArchiveStatusRequest\x12\x1d\n" +
+	"\n" +
+	"archive_id\x18\x01 \x01(\tR\tarchiveId\x12*\n" +
+	"\x11base_storage_path\x18\x02 \x01(\tR\x0fbaseStoragePath\"F\n" +
+	"\x18GetArchiveStatusResponse\x12*\n" +
+	"\aarchive\x18\x01 \x01(\v2\x10.file.v1.ArchiveR\aarchive*\xa3\x01\n" +
+	"\rArchiveStatus\x12\x1e\n" +
+	"\x1aARCHIVE_STATUS_UNSPECIFIED\x10\x00\x12\x1a\n" +
+	"\x16ARCHIVE_STATUS_PENDING\x10\x01\x12\x1d\n" +
+	"\x19ARCHIVE_STATUS_PROCESSING\x10\x02\x12\x1c\n" +
+	"\x18ARCHIVE_STATUS_COMPLETED\x10\x03\x12\x19\n" +
+	"\x15ARCHIVE_STATUS_FAILED\x10\x04BJZHgithub.com/Ernestgio/Hangout-Planner/pkg/shared/proto/gen/go/file;filepbb\x06proto3"
 
 var (
 	file_file_file_messages_proto_rawDescOnce sync.Once
@@ -772,36 +1250,51 @@ func file_file_file_messages_proto_rawDescGZIP() []byte {
 	return file_file_file_messages_proto_rawDescData
 }
 
-var file_file_file_messages_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_file_file_messages_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_file_file_messages_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
 var file_file_file_messages_proto_goTypes = []any{
-	(*FileWithURL)(nil),                 // 0: file.v1.FileWithURL
-	(*FileUploadIntent)(nil),            // 1: file.v1.FileUploadIntent
-	(*GenerateUploadURLsRequest)(nil),   // 2: file.v1.GenerateUploadURLsRequest
-	(*GenerateUploadURLsResponse)(nil),  // 3: file.v1.GenerateUploadURLsResponse
-	(*PresignedUploadURL)(nil),          // 4: file.v1.PresignedUploadURL
-	(*ConfirmUploadRequest)(nil),        // 5: file.v1.ConfirmUploadRequest
-	(*ConfirmUploadResponse)(nil),       // 6: file.v1.ConfirmUploadResponse
-	(*GetFileByMemoryIDRequest)(nil),    // 7: file.v1.GetFileByMemoryIDRequest
-	(*GetFileByMemoryIDResponse)(nil),   // 8: file.v1.GetFileByMemoryIDResponse
-	(*GetFilesByMemoryIDsRequest)(nil),  // 9: file.v1.GetFilesByMemoryIDsRequest
-	(*GetFilesByMemoryIDsResponse)(nil), // 10: file.v1.GetFilesByMemoryIDsResponse
-	(*DeleteFileRequest)(nil),           // 11: file.v1.DeleteFileRequest
-	(*DeleteFileResponse)(nil),          // 12: file.v1.DeleteFileResponse
-	nil,                                 // 13: file.v1.GetFilesByMemoryIDsResponse.FilesEntry
-	(*timestamppb.Timestamp)(nil),       // 14: google.protobuf.Timestamp
+	(ArchiveStatus)(0),                  // 0: file.v1.ArchiveStatus
+	(*FileWithURL)(nil),                 // 1: file.v1.FileWithURL
+	(*FileUploadIntent)(nil),            // 2: file.v1.FileUploadIntent
+	(*GenerateUploadURLsRequest)(nil),   // 3: file.v1.GenerateUploadURLsRequest
+	(*GenerateUploadURLsResponse)(nil),  // 4: file.v1.GenerateUploadURLsResponse
+	(*PresignedUploadURL)(nil),          // 5: file.v1.PresignedUploadURL
+	(*ConfirmUploadRequest)(nil),        // 6: file.v1.ConfirmUploadRequest
+	(*ConfirmUploadResponse)(nil),       // 7: file.v1.ConfirmUploadResponse
+	(*GetFileByMemoryIDRequest)(nil),    // 8: file.v1.GetFileByMemoryIDRequest
+	(*GetFileByMemoryIDResponse)(nil),   // 9: file.v1.GetFileByMemoryIDResponse
+	(*GetFilesByMemoryIDsRequest)(nil),  // 10: file.v1.GetFilesByMemoryIDsRequest
+	(*GetFilesByMemoryIDsResponse)(nil), // 11: file.v1.GetFilesByMemoryIDsResponse
+	(*DeleteFileRequest)(nil),           // 12: file.v1.DeleteFileRequest
+	(*DeleteFileResponse)(nil),          // 13: file.v1.DeleteFileResponse
+	(*Archive)(nil),                     // 14: file.v1.Archive
+	(*ArchiveDocument)(nil),             // 15: file.v1.ArchiveDocument
+	(*CreateArchiveRequest)(nil),        // 16: file.v1.CreateArchiveRequest
+	(*CreateArchiveResponse)(nil),       // 17: file.v1.CreateArchiveResponse
+	(*GetArchiveStatusRequest)(nil),     // 18: file.v1.GetArchiveStatusRequest
+	(*GetArchiveStatusResponse)(nil),    // 19: file.v1.GetArchiveStatusResponse
+	nil,                                 // 20: file.v1.GetFilesByMemoryIDsResponse.FilesEntry
+	(*timestamppb.Timestamp)(nil),       // 21: google.protobuf.Timestamp
 }
 var file_file_file_messages_proto_depIdxs = []int32{
-	14, // 0: file.v1.FileWithURL.created_at:type_name -> google.protobuf.Timestamp
-	1,  // 1: file.v1.GenerateUploadURLsRequest.files:type_name -> file.v1.FileUploadIntent
-	4,  // 2: file.v1.GenerateUploadURLsResponse.urls:type_name -> file.v1.PresignedUploadURL
-	0,  // 3: file.v1.GetFileByMemoryIDResponse.file:type_name -> file.v1.FileWithURL
-	13, // 4: file.v1.GetFilesByMemoryIDsResponse.files:type_name -> file.v1.GetFilesByMemoryIDsResponse.FilesEntry
-	0,  // 5: file.v1.GetFilesByMemoryIDsResponse.FilesEntry.value:type_name -> file.v1.FileWithURL
-	6,  // [6:6] is the sub-list for method output_type
-	6,  // [6:6] is the sub-list for method input_type
-	6,  // [6:6] is the sub-list for extension type_name
-	6,  // [6:6] is the sub-list for extension extendee
-	0,  // [0:6] is the sub-list for field type_name
+	21, // 0: file.v1.FileWithURL.created_at:type_name -> google.protobuf.Timestamp
+	2,  // 1: file.v1.GenerateUploadURLsRequest.files:type_name -> file.v1.FileUploadIntent
+	5,  // 2: file.v1.GenerateUploadURLsResponse.urls:type_name -> file.v1.PresignedUploadURL
+	1,  // 3: file.v1.GetFileByMemoryIDResponse.file:type_name -> file.v1.FileWithURL
+	20, // 4: file.v1.GetFilesByMemoryIDsResponse.files:type_name -> file.v1.GetFilesByMemoryIDsResponse.FilesEntry
+	0,  // 5: file.v1.Archive.status:type_name -> file.v1.ArchiveStatus
+	21, // 6: file.v1.Archive.created_at:type_name -> google.protobuf.Timestamp
+	21, // 7: file.v1.Archive.completed_at:type_name -> google.protobuf.Timestamp
+	21, // 8: file.v1.Archive.expires_at:type_name -> google.protobuf.Timestamp
+	15, // 9: file.v1.CreateArchiveRequest.documents:type_name -> file.v1.ArchiveDocument
+	14, // 10: file.v1.CreateArchiveResponse.archive:type_name -> file.v1.Archive
+	14, // 11: file.v1.GetArchiveStatusResponse.archive:type_name -> file.v1.Archive
+	1,  // 12: file.v1.GetFilesByMemoryIDsResponse.FilesEntry.value:type_name -> file.v1.FileWithURL
+	13, // [13:13] is the sub-list for method output_type
+	13, // [13:13] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
 }
 
 func init() { file_file_file_messages_proto_init() }
@@ -814,13 +1307,14 @@ func file_file_file_messages_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_file_file_messages_proto_rawDesc), len(file_file_file_messages_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   14,
+			NumEnums:      1,
+			NumMessages:   20,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
 		GoTypes:           file_file_file_messages_proto_goTypes,
 		DependencyIndexes: file_file_file_messages_proto_depIdxs,
+		EnumInfos:         file_file_file_messages_proto_enumTypes,
 		MessageInfos:      file_file_file_messages_proto_msgTypes,
 	}.Build()
 	File_file_file_messages_proto = out.File
@@ -24,14 +24,16 @@ var File_file_file_service_proto protoreflect.FileDescriptor
 
 const file_file_file_service_proto_rawDesc = "" +
 	"\n" +
-	"\x17file/file_service.proto\x12\afile.v1\x1a\x18file/file_messages.proto2\xc1\x03\n" +
+	"\x17file/file_service.proto\x12\afile.v1\x1a\x18file/file_messages.proto2\xea\x04\n" +
 	"\vFileService\x12]\n" +
 	"\x12GenerateUploadURLs\x12\".file.v1.GenerateUploadURLsRequest\x1a#.file.v1.GenerateUploadURLsResponse\x12N\n" +
 	"\rConfirmUpload\x12\x1d.file.v1.ConfirmUploadRequest\x1a\x1e.file.v1.ConfirmUploadResponse\x12Z\n" +
 	"\x11GetFileByMemoryID\x12!.file.v1.GetFileByMemoryIDRequest\x1a\".file.v1.GetFileByMemoryIDResponse\x12`\n" +
 	"\x13GetFilesByMemoryIDs\x12#.file.v1.GetFilesByMemoryIDsRequest\x1a$.file.v1.GetFilesByMemoryIDsResponse\x12E\n" +
 	"\n" +
-	"DeleteFile\x12\x1a.file.v1.DeleteFileRequest\x1a\x1b.file.v1.DeleteFileResponseBJZHgithub.com/Ernestgio/Hangout-Planner/pkg/shared/proto/gen/go/file;filepbb\x06proto3"
+	"DeleteFile\x12\x1a.file.v1.DeleteFileRequest\x1a\x1b.file.v1.DeleteFileResponse\x12N\n" +
+	"\rCreateArchive\x12\x1d.file.v1.CreateArchiveRequest\x1a\x1e.file.v1.CreateArchiveResponse\x12W\n" +
+	"\x10GetArchiveStatus\x12 .file.v1.GetArchiveStatusRequest\x1a!.file.v1.GetArchiveStatusResponseBJZHgithub.com/Ernestgio/Hangout-Planner/pkg/shared/proto/gen/go/file;filepbb\x06proto3"
 
 var file_file_file_service_proto_goTypes = []any{
 	(*GenerateUploadURLsRequest)(nil),   // 0: file.v1.GenerateUploadURLsRequest
@@ -39,28 +41,36 @@ var file_file_file_service_proto_goTypes = []any{
 	(*GetFileByMemoryIDRequest)(nil),    // 2: file.v1.GetFileByMemoryIDRequest
 	(*GetFilesByMemoryIDsRequest)(nil),  // 3: file.v1.GetFilesByMemoryIDsRequest
 	(*DeleteFileRequest)(nil),           // 4: file.v1.DeleteFileRequest
-	(*GenerateUploadURLsResponse)(nil),  // 5: file.v1.GenerateUploadURLsResponse
-	(*ConfirmUploadResponse)(nil),       // 6: file.v1.ConfirmUploadResponse
-	(*GetFileByMemoryIDResponse)(nil),   // 7: file.v1.GetFileByMemoryIDResponse
-	(*GetFilesByMemoryIDsResponse)(nil), // 8: file.v1.GetFilesByMemoryIDsResponse
-	(*DeleteFileResponse)(nil),          // 9: file.v1.DeleteFileResponse
+	(*CreateArchiveRequest)(nil),        // 5: file.v1.CreateArchiveRequest
+	(*GetArchiveStatusRequest)(nil),     // 6: file.v1.GetArchiveStatusRequest
+	(*GenerateUploadURLsResponse)(nil),  // 7: file.v1.GenerateUploadURLsResponse
+	(*ConfirmUploadResponse)(nil),       // 8: file.v1.ConfirmUploadResponse
+	(*GetFileByMemoryIDResponse)(nil),   // 9: file.v1.GetFileByMemoryIDResponse
+	(*GetFilesByMemoryIDsResponse)(nil), // 10: file.v1.GetFilesByMemoryIDsResponse
+	(*DeleteFileResponse)(nil),          // 11: file.v1.DeleteFileResponse
+	(*CreateArchiveResponse)(nil),       // 12: file.v1.CreateArchiveResponse
+	(*GetArchiveStatusResponse)(nil),    // 13: file.v1.GetArchiveStatusResponse
 }
 var file_file_file_service_proto_depIdxs = []int32{
-	0, // 0: file.v1.FileService.GenerateUploadURLs:input_type -> file.v1.GenerateUploadURLsRequest
-	1, // 1: file.v1.FileService.ConfirmUpload:input_type -> file.v1.ConfirmUploadRequest
-	2, // 2: file.v1.FileService.GetFileByMemoryID:input_type -> file.v1.GetFileByMemoryIDRequest
-	3, // 3: file.v1.FileService.GetFilesByMemoryIDs:input_type -> file.v1.GetFilesByMemoryIDsRequest
-	4, // 4: file.v1.FileService.DeleteFile:input_type -> file.v1.DeleteFileRequest
-	5, // 5: file.v1.FileService.GenerateUploadURLs:output_type -> file.v1.GenerateUploadURLsResponse
-	6, // 6: file.v1.FileService.ConfirmUpload:output_type -> file.v1.ConfirmUploadResponse
-	7, // 7: file.v1.FileService.GetFileByMemoryID:output_type -> file.v1.GetFileByMemoryIDResponse
-	8, // 8: file.v1.FileService.GetFilesByMemoryIDs:output_type -> file.v1.GetFilesByMemoryIDsResponse
-	9, // 9: file.v1.FileService.DeleteFile:output_type -> file.v1.DeleteFileResponse
-	5, // [5:10] is the sub-list for method output_type
-	0, // [0:5] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	0,  // 0: file.v1.FileService.GenerateUploadURLs:input_type -> file.v1.GenerateUploadURLsRequest
+	1,  // 1: file.v1.FileService.ConfirmUpload:input_type -> file.v1.ConfirmUploadRequest
+	2,  // 2: file.v1.FileService.GetFileByMemoryID:input_type -> file.v1.GetFileByMemoryIDRequest
+	3,  // 3: file.v1.FileService.GetFilesByMemoryIDs:input_type -> file.v1.GetFilesByMemoryIDsRequest
+	4,  // 4: file.v1.FileService.DeleteFile:input_type -> file.v1.DeleteFileRequest
+	5,  // 5: file.v1.FileService.CreateArchive:input_type -> file.v1.CreateArchiveRequest
+	6,  // 6: file.v1.FileService.GetArchiveStatus:input_type -> file.v1.GetArchiveStatusRequest
+	7,  // 7: file.v1.FileService.GenerateUploadURLs:output_type -> file.v1.GenerateUploadURLsResponse
+	8,  // 8: file.v1.FileService.ConfirmUpload:output_type -> file.v1.ConfirmUploadResponse
+	9,  // 9: file.v1.FileService.GetFileByMemoryID:output_type -> file.v1.GetFileByMemoryIDResponse
+	10, // 10: file.v1.FileService.GetFilesByMemoryIDs:output_type -> file.v1.GetFilesByMemoryIDsResponse
+	11, // 11: file.v1.FileService.DeleteFile:output_type -> file.v1.DeleteFileResponse
+	12, // 12: file.v1.FileService.CreateArchive:output_type -> file.v1.CreateArchiveResponse
+	13, // 13: file.v1.FileService.GetArchiveStatus:output_type -> file.v1.GetArchiveStatusResponse
+	7,  // [7:14] is the sub-list for method output_type
+	0,  // [0:7] is the sub-list for method input_type
+	0,  // [0:0] is the sub-list for extension type_name
+	0,  // [0:0] is the sub-list for extension extendee
+	0,  // [0:0] is the sub-list for field type_name
 }
 
 func init() { file_file_file_service_proto_init() }
@@ -24,6 +24,8 @@ const (
 	FileService_GetFileByMemoryID_FullMethodName   = "/file.v1.FileService/GetFileByMemoryID"
 	FileService_GetFilesByMemoryIDs_FullMethodName = "/file.v1.FileService/GetFilesByMemoryIDs"
 	FileService_DeleteFile_FullMethodName          = "/file.v1.FileService/DeleteFile"
+	FileService_CreateArchive_FullMethodName       = "/file.v1.FileService/CreateArchive"
+	FileService_GetArchiveStatus_FullMethodName    = "/file.v1.FileService/GetArchiveStatus"
 )
 
 // FileServiceClient is the client API for FileService service.
@@ -35,6 +37,8 @@ type FileServiceClient interface {
 	GetFileByMemoryID(ctx context.Context, in *GetFileByMemoryIDRequest, opts ...grpc.CallOption) (*GetFileByMemoryIDResponse, error)
 	GetFilesByMemoryIDs(ctx context.Context, in *GetFilesByMemoryIDsRequest, opts ...grpc.CallOption) (*GetFilesByMemoryIDsResponse, error)
 	DeleteFile(ctx context.Context, in *DeleteFileRequest, opts ...grpc.CallOption) (*DeleteFileResponse, error)
+	CreateArchive(ctx context.Context, in *CreateArchiveRequest, opts ...grpc.CallOption) (*CreateArchiveResponse, error)
+	GetArchiveStatus(ctx context.Context, in *GetArchiveStatusRequest, opts ...grpc.CallOption) (*GetArchiveStatusResponse, error)
 }
 
 type fileServiceClient struct {
@@ -95,6 +99,26 @@ func (c *fileServiceClient) DeleteFile(ctx context.Context, in *DeleteFileReques
 	return out, nil
 }
 
+func (c *fileServiceClient) CreateArchive(ctx context.Context, in *CreateArchiveRequest, opts ...grpc.CallOption) (*CreateArchiveResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateArchiveResponse)
+	err := c.cc.Invoke(ctx, FileService_CreateArchive_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileServiceClient) GetArchiveStatus(ctx context.Context, in *GetArchiveStatusRequest, opts ...grpc.CallOption) (*GetArchiveStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetArchiveStatusResponse)
+	err := c.cc.Invoke(ctx, FileService_GetArchiveStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // FileServiceServer is the server API for FileService service.
 // All implementations must embed UnimplementedFileServiceServer
 // for forward compatibility.
@@ -104,6 +128,8 @@ type FileServiceServer interface {
 	GetFileByMemoryID(context.Context, *GetFileByMemoryIDRequest) (*GetFileByMemoryIDResponse, error)
 	GetFilesByMemoryIDs(context.Context, *GetFilesByMemoryIDsRequest) (*GetFilesByMemoryIDsResponse, error)
 	DeleteFile(context.Context, *DeleteFileRequest) (*DeleteFileResponse, error)
+	CreateArchive(context.Context, *CreateArchiveRequest) (*CreateArchiveResponse, error)
+	GetArchiveStatus(context.Context, *GetArchiveStatusRequest) (*GetArchiveStatusResponse, error)
 	mustEmbedUnimplementedFileServiceServer()
 }
 
@@ -129,6 +155,12 @@ func (UnimplementedFileServiceServer) GetFilesByMemoryIDs(context.Context, *GetF
 func (UnimplementedFileServiceServer) DeleteFile(context.Context, *DeleteFileRequest) (*DeleteFileResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method DeleteFile not implemented")
 }
+func (UnimplementedFileServiceServer) CreateArchive(context.Context, *CreateArchiveRequest) (*CreateArchiveResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateArchive not implemented")
+}
+func (UnimplementedFileServiceServer) GetArchiveStatus(context.Context, *GetArchiveStatusRequest) (*GetArchiveStatusResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetArchiveStatus not implemented")
+}
 func (UnimplementedFileServiceServer) mustEmbedUnimplementedFileServiceServer() {}
 func (UnimplementedFileServiceServer) testEmbeddedByValue()                     {}
 
@@ -240,6 +272,42 @@ func _FileService_DeleteFile_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _FileService_CreateArchive_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateArchiveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).CreateArchive(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_CreateArchive_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).CreateArchive(ctx, req.(*CreateArchiveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileService_GetArchiveStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetArchiveStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileServiceServer).GetArchiveStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileService_GetArchiveStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileServiceServer).GetArchiveStatus(ctx, req.(*GetArchiveStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // FileService_ServiceDesc is the grpc.ServiceDesc for FileService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -267,6 +335,14 @@ var FileService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteFile",
 			Handler:    _FileService_DeleteFile_Handler,
 		},
+		{
+			MethodName: "CreateArchive",
+			Handler:    _FileService_CreateArchive_Handler,
+		},
+		{
+			MethodName: "GetArchiveStatus",
+			Handler:    _FileService_GetArchiveStatus_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "file/file_service.proto",
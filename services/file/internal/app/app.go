@@ -12,6 +12,7 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/Ernestgio/Hangout-Planner/pkg/shared/eventbus"
 	filepb "github.com/Ernestgio/Hangout-Planner/pkg/shared/proto/gen/go/file"
 	"github.com/Ernestgio/Hangout-Planner/services/file/internal/apperrors"
 	"github.com/Ernestgio/Hangout-Planner/services/file/internal/config"
@@ -19,6 +20,7 @@ import (
 	"github.com/Ernestgio/Hangout-Planner/services/file/internal/constants/logmsg"
 	"github.com/Ernestgio/Hangout-Planner/services/file/internal/db"
 	"github.com/Ernestgio/Hangout-Planner/services/file/internal/handlers"
+	"github.com/Ernestgio/Hangout-Planner/services/file/internal/jobs"
 	"github.com/Ernestgio/Hangout-Planner/services/file/internal/logger"
 	"github.com/Ernestgio/Hangout-Planner/services/file/internal/otel"
 	"github.com/Ernestgio/Hangout-Planner/services/file/internal/repository"
@@ -42,6 +44,9 @@ type App struct {
 	tracerProvider *otel.TracerProvider
 	meterProvider  *otel.MeterProvider
 	metrics        *otel.Metrics
+	purgeJob       *jobs.PurgeJob
+	archiveJob     *jobs.ArchiveJob
+	eventBus       *eventbus.Bus
 	closer         func() error
 	cfg            *config.Config
 }
@@ -118,6 +123,7 @@ func NewApp(ctx context.Context, cfg *config.Config) (*App, error) {
 
 	// Repository Layer (after metrics to pass metrics)
 	repo := repository.NewMemoryFileRepository(dbConn, metricsRecorder)
+	archiveRepo := repository.NewArchiveRepository(dbConn, metricsRecorder)
 
 	// Storage Layer (after OTEL to pass metrics)
 	s3Client, err := storage.NewS3Client(ctx, cfg.S3Config, metricsRecorder)
@@ -136,11 +142,43 @@ func NewApp(ctx context.Context, cfg *config.Config) (*App, error) {
 		return nil, err
 	}
 
+	// Event bus
+	transport, err := eventbus.NewTransport(eventbus.Config{
+		Driver:        cfg.EventBus.Driver,
+		BufferSize:    cfg.EventBus.BufferSize,
+		RedisAddr:     cfg.EventBus.RedisAddr,
+		RedisPassword: cfg.EventBus.RedisPassword,
+		RedisDB:       cfg.EventBus.RedisDB,
+		StreamMaxLen:  int64(cfg.EventBus.StreamMaxLen),
+	}, func(msg eventbus.Message, err error) {
+		logger.Error(context.Background(), logmsg.EventHandlerFailed, err, slog.String("subject", msg.Subject))
+	})
+	if err != nil {
+		logger.Error(ctx, logmsg.EventBusInitFailed, err, slog.String("driver", cfg.EventBus.Driver))
+		_ = dbCloser()
+		if tracerProvider != nil {
+			_ = tracerProvider.Shutdown(ctx)
+		}
+		if meterProvider != nil {
+			_ = meterProvider.Shutdown(ctx)
+		}
+		return nil, err
+	}
+	eventBus := eventbus.NewBus(transport, constants.EventBusSourceName)
+	if cfg.EventBus.Driver == eventbus.DriverMemory {
+		logger.Warn(ctx, logmsg.EventBusInProcessOnly)
+	}
+
 	// Initialize service
-	fileService := services.NewFileService(dbConn, repo, s3Client, fileValidator, metricsRecorder)
+	fileService := services.NewFileService(dbConn, repo, s3Client, fileValidator, eventBus, metricsRecorder)
+	archiveService := services.NewArchiveService(archiveRepo, repo, s3Client, metricsRecorder)
+
+	// Background jobs
+	purgeJob := jobs.NewPurgeJob(fileService, cfg.Retention)
+	archiveJob := jobs.NewArchiveJob(archiveService, cfg.Archive)
 
 	// Initialize handler
-	fileHandler := handlers.NewFileHandler(fileService)
+	fileHandler := handlers.NewFileHandler(fileService, archiveService)
 
 	// Setup network listener
 	addr := fmt.Sprintf(":%s", cfg.AppPort)
@@ -208,6 +246,9 @@ func NewApp(ctx context.Context, cfg *config.Config) (*App, error) {
 		tracerProvider: tracerProvider,
 		meterProvider:  meterProvider,
 		metrics:        metrics,
+		purgeJob:       purgeJob,
+		archiveJob:     archiveJob,
+		eventBus:       eventBus,
 		closer:         dbCloser,
 		cfg:            cfg,
 	}, nil
@@ -223,6 +264,9 @@ func (a *App) Start() error {
 		slog.String("s3_bucket", a.cfg.S3Config.BucketName),
 	)
 
+	a.purgeJob.Start(ctx)
+	a.archiveJob.Start(ctx)
+
 	errChan := make(chan error, 1)
 	go func() {
 		if err := a.server.Serve(a.listener); err != nil {
@@ -269,6 +313,13 @@ func (a *App) Shutdown() error {
 		a.server.Stop()
 	}
 
+	a.purgeJob.Stop()
+	a.archiveJob.Stop()
+
+	if err := a.eventBus.Close(); err != nil {
+		logger.Error(ctx, logmsg.EventBusCloseFailed, err)
+	}
+
 	// Shutdown tracer provider to flush pending spans
 	if a.tracerProvider != nil {
 		if err := a.tracerProvider.Shutdown(ctx); err != nil {
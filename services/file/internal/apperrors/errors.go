@@ -12,12 +12,24 @@ var ErrFileUploadFailed = errors.New("file upload failed")
 var ErrFileDeleteFailed = errors.New("file deletion failed")
 var ErrPresignedDownloadURLFailed = errors.New("failed to generate presigned download URL")
 var ErrPresignedUploadURLFailed = errors.New("failed to generate presigned upload URL")
+var ErrFileDownloadFailed = errors.New("file download failed")
+var ErrStorageObjectNotFound = errors.New("storage object not found")
 
 var ErrInvalidMemoryID = errors.New("invalid memory ID")
 var ErrFileNotFound = errors.New("file not found")
 var ErrFileStatusUpdateFailed = errors.New("failed to update file status")
 var ErrFileCreationFailed = errors.New("failed to create file records")
 
+// Archive errors
+var ErrInvalidArchiveID = errors.New("invalid archive ID")
+var ErrArchiveNotFound = errors.New("archive not found")
+var ErrArchiveExpired = errors.New("archive has expired")
+var ErrNoFilesToArchive = errors.New("no uploaded files to archive")
+var ErrInvalidArchiveDocument = errors.New("invalid archive document")
+var ErrArchiveBuildFailed = errors.New("archive could not be built, request a new one")
+var ErrInvalidBaseStoragePath = errors.New("invalid base storage path")
+var ErrArchiveCreationFailed = errors.New("failed to create archive")
+
 // File validation errors
 var ErrInvalidFileSize = errors.New("invalid file size")
 var ErrFileTooLarge = errors.New("file too large")
@@ -0,0 +1,38 @@
+package config
+
+import (
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/file/internal/constants"
+)
+
+type ArchiveConfig struct {
+	PollIntervalSeconds int
+	StaleMinutes        int
+	RetentionHours      int
+	PurgeBatchSize      int
+}
+
+func NewArchiveConfig() *ArchiveConfig {
+	return &ArchiveConfig{
+		PollIntervalSeconds: getEnvInt("ARCHIVE_POLL_INTERVAL_SECONDS", constants.DefaultArchivePollIntervalSeconds),
+		StaleMinutes:        getEnvInt("ARCHIVE_STALE_MINUTES", constants.DefaultArchiveStaleMinutes),
+		RetentionHours:      getEnvInt("ARCHIVE_RETENTION_HOURS", constants.DefaultArchiveRetentionHours),
+		PurgeBatchSize:      getEnvInt("ARCHIVE_PURGE_BATCH_SIZE", constants.DefaultArchivePurgeBatchSize),
+	}
+}
+
+func (c *ArchiveConfig) GetPollInterval() time.Duration {
+	return time.Duration(c.PollIntervalSeconds) * time.Second
+}
+
+// GetStaleAfter is how long an archive may go without progress before
+// another run picks it up again.
+func (c *ArchiveConfig) GetStaleAfter() time.Duration {
+	return time.Duration(c.StaleMinutes) * time.Minute
+}
+
+// GetRetention is how long a completed archive stays downloadable.
+func (c *ArchiveConfig) GetRetention() time.Duration {
+	return time.Duration(c.RetentionHours) * time.Hour
+}
@@ -0,0 +1,61 @@
+package config
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/file/internal/constants"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewArchiveConfig_TableDriven(t *testing.T) {
+	orig := map[string]*string{}
+	keys := []string{"ARCHIVE_POLL_INTERVAL_SECONDS", "ARCHIVE_STALE_MINUTES", "ARCHIVE_RETENTION_HOURS", "ARCHIVE_PURGE_BATCH_SIZE"}
+	for _, k := range keys {
+		if v, ok := os.LookupEnv(k); ok {
+			vv := v
+			orig[k] = &vv
+		} else {
+			orig[k] = nil
+		}
+	}
+	defer func() {
+		for k, v := range orig {
+			if v == nil {
+				_ = os.Unsetenv(k)
+			} else {
+				_ = os.Setenv(k, *v)
+			}
+		}
+	}()
+
+	tests := []struct {
+		name             string
+		env              map[string]string
+		wantPollSeconds  int
+		wantStaleMinutes int
+		wantRetentionHrs int
+		wantBatchSize    int
+	}{
+		{name: "defaults", env: map[string]string{}, wantPollSeconds: constants.DefaultArchivePollIntervalSeconds, wantStaleMinutes: constants.DefaultArchiveStaleMinutes, wantRetentionHrs: constants.DefaultArchiveRetentionHours, wantBatchSize: constants.DefaultArchivePurgeBatchSize},
+		{name: "custom values", env: map[string]string{"ARCHIVE_POLL_INTERVAL_SECONDS": "2", "ARCHIVE_STALE_MINUTES": "3", "ARCHIVE_RETENTION_HOURS": "48", "ARCHIVE_PURGE_BATCH_SIZE": "7"}, wantPollSeconds: 2, wantStaleMinutes: 3, wantRetentionHrs: 48, wantBatchSize: 7},
+		{name: "invalid values", env: map[string]string{"ARCHIVE_POLL_INTERVAL_SECONDS": "bad", "ARCHIVE_STALE_MINUTES": "bad", "ARCHIVE_RETENTION_HOURS": "bad", "ARCHIVE_PURGE_BATCH_SIZE": "bad"}, wantPollSeconds: constants.DefaultArchivePollIntervalSeconds, wantStaleMinutes: constants.DefaultArchiveStaleMinutes, wantRetentionHrs: constants.DefaultArchiveRetentionHours, wantBatchSize: constants.DefaultArchivePurgeBatchSize},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for k := range orig {
+				_ = os.Unsetenv(k)
+			}
+			for k, v := range tt.env {
+				_ = os.Setenv(k, v)
+			}
+			cfg := NewArchiveConfig()
+			require.Equal(t, tt.wantBatchSize, cfg.PurgeBatchSize)
+			require.Equal(t, time.Duration(tt.wantPollSeconds)*time.Second, cfg.GetPollInterval())
+			require.Equal(t, time.Duration(tt.wantStaleMinutes)*time.Minute, cfg.GetStaleAfter())
+			require.Equal(t, time.Duration(tt.wantRetentionHrs)*time.Hour, cfg.GetRetention())
+		})
+	}
+}
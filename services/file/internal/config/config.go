@@ -17,6 +17,9 @@ type Config struct {
 	S3Config   *S3Config
 	OTELConfig *OTELConfig
 	MTLSConfig *MTLSConfig
+	Retention  *RetentionConfig
+	Archive    *ArchiveConfig
+	EventBus   *EventBusConfig
 }
 
 func Load() (*Config, error) {
@@ -32,6 +35,9 @@ func Load() (*Config, error) {
 		S3Config:   NewS3Config(),
 		OTELConfig: NewOTELConfig(),
 		MTLSConfig: NewMTLSConfig(),
+		Retention:  NewRetentionConfig(),
+		Archive:    NewArchiveConfig(),
+		EventBus:   NewEventBusConfig(),
 	}
 
 	if cfg.AppPort == "" {
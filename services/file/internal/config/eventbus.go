@@ -0,0 +1,24 @@
+package config
+
+import "github.com/Ernestgio/Hangout-Planner/services/file/internal/constants"
+
+// EventBusConfig selects the event bus driver.
+type EventBusConfig struct {
+	Driver        string
+	BufferSize    int
+	StreamMaxLen  int
+	RedisAddr     string
+	RedisPassword string
+	RedisDB       int
+}
+
+func NewEventBusConfig() *EventBusConfig {
+	return &EventBusConfig{
+		Driver:        getEnv("EVENT_BUS_DRIVER", constants.DefaultEventBusDriver),
+		BufferSize:    getEnvInt("EVENT_BUS_BUFFER_SIZE", constants.DefaultEventBusBufferSize),
+		StreamMaxLen:  getEnvInt("EVENT_BUS_STREAM_MAX_LEN", constants.DefaultEventBusStreamMaxLen),
+		RedisAddr:     getEnv("REDIS_ADDR", constants.DefaultRedisAddr),
+		RedisPassword: getEnv("REDIS_PASSWORD", ""),
+		RedisDB:       getEnvInt("REDIS_DB", 0),
+	}
+}
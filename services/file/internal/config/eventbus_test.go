@@ -0,0 +1,63 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/Ernestgio/Hangout-Planner/services/file/internal/constants"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewEventBusConfig_TableDriven(t *testing.T) {
+	tests := []struct {
+		name           string
+		env            map[string]string
+		wantDriver     string
+		wantBufferSize int
+		wantMaxLen     int
+		wantRedisAddr  string
+		wantRedisDB    int
+	}{
+		{
+			name:           "defaults",
+			env:            map[string]string{},
+			wantDriver:     constants.DefaultEventBusDriver,
+			wantBufferSize: constants.DefaultEventBusBufferSize,
+			wantMaxLen:     constants.DefaultEventBusStreamMaxLen,
+			wantRedisAddr:  constants.DefaultRedisAddr,
+		},
+		{
+			name:           "custom values",
+			env:            map[string]string{"EVENT_BUS_DRIVER": "redis", "EVENT_BUS_BUFFER_SIZE": "32", "EVENT_BUS_STREAM_MAX_LEN": "500", "REDIS_ADDR": "redis:6379", "REDIS_DB": "2"},
+			wantDriver:     "redis",
+			wantBufferSize: 32,
+			wantMaxLen:     500,
+			wantRedisAddr:  "redis:6379",
+			wantRedisDB:    2,
+		},
+		{
+			name:           "invalid numbers",
+			env:            map[string]string{"EVENT_BUS_BUFFER_SIZE": "bad", "EVENT_BUS_STREAM_MAX_LEN": "bad", "REDIS_DB": "bad"},
+			wantDriver:     constants.DefaultEventBusDriver,
+			wantBufferSize: constants.DefaultEventBusBufferSize,
+			wantMaxLen:     constants.DefaultEventBusStreamMaxLen,
+			wantRedisAddr:  constants.DefaultRedisAddr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, k := range []string{"EVENT_BUS_DRIVER", "EVENT_BUS_BUFFER_SIZE", "EVENT_BUS_STREAM_MAX_LEN", "REDIS_ADDR", "REDIS_PASSWORD", "REDIS_DB"} {
+				t.Setenv(k, "")
+			}
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			cfg := NewEventBusConfig()
+			require.Equal(t, tt.wantDriver, cfg.Driver)
+			require.Equal(t, tt.wantBufferSize, cfg.BufferSize)
+			require.Equal(t, tt.wantMaxLen, cfg.StreamMaxLen)
+			require.Equal(t, tt.wantRedisAddr, cfg.RedisAddr)
+			require.Equal(t, tt.wantRedisDB, cfg.RedisDB)
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package config
+
+import (
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/file/internal/constants"
+)
+
+type RetentionConfig struct {
+	RetentionHours       int
+	PurgeIntervalMinutes int
+	PurgeBatchSize       int
+}
+
+func NewRetentionConfig() *RetentionConfig {
+	return &RetentionConfig{
+		RetentionHours:       getEnvInt("FILE_RETENTION_HOURS", constants.DefaultFileRetentionHours),
+		PurgeIntervalMinutes: getEnvInt("FILE_PURGE_INTERVAL_MINUTES", constants.DefaultPurgeIntervalMinutes),
+		PurgeBatchSize:       getEnvInt("FILE_PURGE_BATCH_SIZE", constants.DefaultPurgeBatchSize),
+	}
+}
+
+func (c *RetentionConfig) GetRetention() time.Duration {
+	return time.Duration(c.RetentionHours) * time.Hour
+}
+
+func (c *RetentionConfig) GetPurgeInterval() time.Duration {
+	return time.Duration(c.PurgeIntervalMinutes) * time.Minute
+}
@@ -0,0 +1,61 @@
+package config
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/file/internal/constants"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRetentionConfig_TableDriven(t *testing.T) {
+	orig := map[string]*string{}
+	keys := []string{"FILE_RETENTION_HOURS", "FILE_PURGE_INTERVAL_MINUTES", "FILE_PURGE_BATCH_SIZE"}
+	for _, k := range keys {
+		if v, ok := os.LookupEnv(k); ok {
+			vv := v
+			orig[k] = &vv
+		} else {
+			orig[k] = nil
+		}
+	}
+	defer func() {
+		for k, v := range orig {
+			if v == nil {
+				_ = os.Unsetenv(k)
+			} else {
+				_ = os.Setenv(k, *v)
+			}
+		}
+	}()
+
+	tests := []struct {
+		name               string
+		env                map[string]string
+		wantRetentionHrs   int
+		wantIntervalMin    int
+		wantPurgeBatchSize int
+	}{
+		{name: "defaults", env: map[string]string{}, wantRetentionHrs: constants.DefaultFileRetentionHours, wantIntervalMin: constants.DefaultPurgeIntervalMinutes, wantPurgeBatchSize: constants.DefaultPurgeBatchSize},
+		{name: "custom values", env: map[string]string{"FILE_RETENTION_HOURS": "24", "FILE_PURGE_INTERVAL_MINUTES": "5", "FILE_PURGE_BATCH_SIZE": "10"}, wantRetentionHrs: 24, wantIntervalMin: 5, wantPurgeBatchSize: 10},
+		{name: "invalid values", env: map[string]string{"FILE_RETENTION_HOURS": "bad", "FILE_PURGE_INTERVAL_MINUTES": "bad", "FILE_PURGE_BATCH_SIZE": "bad"}, wantRetentionHrs: constants.DefaultFileRetentionHours, wantIntervalMin: constants.DefaultPurgeIntervalMinutes, wantPurgeBatchSize: constants.DefaultPurgeBatchSize},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for k := range orig {
+				_ = os.Unsetenv(k)
+			}
+			for k, v := range tt.env {
+				_ = os.Setenv(k, v)
+			}
+			cfg := NewRetentionConfig()
+			require.Equal(t, tt.wantRetentionHrs, cfg.RetentionHours)
+			require.Equal(t, tt.wantIntervalMin, cfg.PurgeIntervalMinutes)
+			require.Equal(t, tt.wantPurgeBatchSize, cfg.PurgeBatchSize)
+			require.Equal(t, time.Duration(tt.wantRetentionHrs)*time.Hour, cfg.GetRetention())
+			require.Equal(t, time.Duration(tt.wantIntervalMin)*time.Minute, cfg.GetPurgeInterval())
+		})
+	}
+}
@@ -24,6 +24,32 @@ const (
 	MaxFileSize                  = 10 * 1024 * 1024 // 10MB in bytes
 	DefaultPresignedURLExpiryMin = 15
 
+	// Retention Config - Default values constants
+	DefaultFileRetentionHours   = 168 // 7 days
+	DefaultPurgeIntervalMinutes = 60
+	DefaultPurgeBatchSize       = 100
+
+	// Archive Config - Default values constants
+	DefaultArchivePollIntervalSeconds = 5
+	DefaultArchiveStaleMinutes        = 10
+	DefaultArchiveRetentionHours      = 24
+	DefaultArchivePurgeBatchSize      = 50
+	DefaultArchiveName                = "memories"
+	ArchiveExtension                  = ".zip"
+	ArchiveContentType                = "application/zip"
+	ArchiveDocumentContentType        = "application/octet-stream"
+	ArchiveDocumentsDir               = "documents"
+	ArchivePartSize                   = 5 * 1024 * 1024 // S3 minimum multipart part size
+	MaxArchiveNameLength              = 100
+	MaxArchiveEntryNameLength         = 255
+
+	// Event Bus Config - Default values constants
+	DefaultEventBusDriver       = "memory"
+	DefaultEventBusBufferSize   = 256
+	DefaultEventBusStreamMaxLen = 10000
+	DefaultRedisAddr            = "localhost:6379"
+	EventBusSourceName          = "file-service"
+
 	// Application Timeouts
 	GracefulShutdownTimeout = 10 // seconds
 
@@ -46,6 +72,11 @@ const (
 	MetricOpGetFile           = "get_file"
 	MetricOpGetFilesBatch     = "get_files_batch"
 	MetricOpDeleteFile        = "delete_file"
+	MetricOpPurgeFiles        = "purge_files"
+	MetricOpCreateArchive     = "create_archive"
+	MetricOpGetArchiveStatus  = "get_archive_status"
+	MetricOpBuildArchive      = "build_archive"
+	MetricOpPurgeArchives     = "purge_archives"
 
 	// Metrics Constants - Status labels
 	MetricStatusSuccess = "success"
@@ -56,6 +87,8 @@ const (
 	MetricS3OpPresignUpload   = "presign_upload_url"
 	MetricS3OpPresignDownload = "presign_download_url"
 	MetricS3OpDelete          = "delete_object"
+	MetricS3OpDownload        = "get_object"
+	MetricS3OpMultipartUpload = "multipart_upload"
 
 	// Metrics Constants - DB Operation labels
 	MetricDBOpInsert = "insert"
@@ -26,6 +26,30 @@ const (
 	S3ConnectionFailed = "S3 client initialization failed"
 )
 
+// Purge Job
+const (
+	PurgeJobCompleted = "purged expired files"
+	PurgeJobFailed    = "failed to purge expired files"
+)
+
+// Archive Job
+const (
+	ArchiveBuildCompleted = "built memory archive"
+	ArchiveBuildFailed    = "failed to build memory archive"
+	ArchivePurgeCompleted = "purged expired archives"
+	ArchivePurgeFailed    = "failed to purge expired archives"
+)
+
+// Event Bus
+const (
+	EventBusInitFailed  = "failed to initialize event bus"
+	EventPublishFailed  = "failed to publish domain event"
+	EventHandlerFailed  = "domain event handler failed"
+	EventBusCloseFailed = "failed to close event bus"
+
+	EventBusInProcessOnly = "event bus uses the memory driver, events are not delivered to other services"
+)
+
 // Network & gRPC Server
 const (
 	NetworkListenerFailed     = "failed to create network listener"
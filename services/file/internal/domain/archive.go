@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Archive is a ZIP of memory files built in the background.
+type Archive struct {
+	ID              uuid.UUID `gorm:"primaryKey;type:char(36)"`
+	BaseStoragePath string    `gorm:"type:varchar(500);not null;index"`
+	StoragePath     string    `gorm:"type:varchar(500);not null"`
+	Status          string    `gorm:"type:varchar(50);not null;index:idx_archives_status_updated_at,priority:1"`
+	TotalFiles      int       `gorm:"not null"`
+	ProcessedFiles  int       `gorm:"not null;default:0"`
+	ArchiveSize     int64     `gorm:"not null;default:0"`
+	ErrorMessage    *string   `gorm:"type:varchar(500)"`
+	CreatedAt       time.Time
+	UpdatedAt       time.Time `gorm:"index:idx_archives_status_updated_at,priority:2"`
+	CompletedAt     *time.Time
+	ExpiresAt       *time.Time `gorm:"index"`
+
+	Entries   []ArchiveEntry    `gorm:"foreignKey:ArchiveID"`
+	Documents []ArchiveDocument `gorm:"foreignKey:ArchiveID"`
+}
+
+// ArchiveEntry is one file of an archive and its path inside the ZIP.
+type ArchiveEntry struct {
+	ArchiveID uuid.UUID `gorm:"primaryKey;type:char(36)"`
+	FileID    uuid.UUID `gorm:"primaryKey;type:char(36)"`
+	EntryName string    `gorm:"type:varchar(255);not null"`
+}
+
+// ArchiveDocument is a generated file sent with the archive request.
+type ArchiveDocument struct {
+	ArchiveID   uuid.UUID `gorm:"primaryKey;type:char(36)"`
+	EntryName   string    `gorm:"primaryKey;type:varchar(255)"`
+	StoragePath string    `gorm:"type:varchar(500);not null"`
+}
+
+func (archive *Archive) BeforeCreate(tx *gorm.DB) (err error) {
+	if archive.ID == uuid.Nil {
+		archive.ID = uuid.New()
+	}
+	return
+}
@@ -20,10 +20,20 @@ func mapErrorToGRPCStatus(err error) error {
 		errors.Is(err, apperrors.ErrInvalidFilename),
 		errors.Is(err, apperrors.ErrInvalidFileExtension),
 		errors.Is(err, apperrors.ErrInvalidMimeType),
-		errors.Is(err, apperrors.ErrInvalidMemoryID):
+		errors.Is(err, apperrors.ErrInvalidMemoryID),
+		errors.Is(err, apperrors.ErrInvalidArchiveID),
+		errors.Is(err, apperrors.ErrInvalidBaseStoragePath),
+		errors.Is(err, apperrors.ErrNoFilesToArchive),
+		errors.Is(err, apperrors.ErrInvalidArchiveDocument):
 		return status.Error(codes.InvalidArgument, err.Error())
 	}
 
+	switch {
+	case errors.Is(err, apperrors.ErrArchiveNotFound),
+		errors.Is(err, apperrors.ErrArchiveExpired):
+		return status.Error(codes.NotFound, err.Error())
+	}
+
 	switch {
 	case errors.Is(err, apperrors.ErrFileUploadFailed),
 		errors.Is(err, apperrors.ErrFileDeleteFailed),
@@ -34,7 +44,8 @@ func mapErrorToGRPCStatus(err error) error {
 
 	switch {
 	case errors.Is(err, apperrors.ErrFileCreationFailed),
-		errors.Is(err, apperrors.ErrFileStatusUpdateFailed):
+		errors.Is(err, apperrors.ErrFileStatusUpdateFailed),
+		errors.Is(err, apperrors.ErrArchiveCreationFailed):
 		return status.Error(codes.Internal, err.Error())
 	}
 
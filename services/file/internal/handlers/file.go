@@ -9,12 +9,14 @@ import (
 
 type FileHandler struct {
 	filepb.UnimplementedFileServiceServer
-	fileService services.FileService
+	fileService    services.FileService
+	archiveService services.ArchiveService
 }
 
-func NewFileHandler(fileService services.FileService) *FileHandler {
+func NewFileHandler(fileService services.FileService, archiveService services.ArchiveService) *FileHandler {
 	return &FileHandler{
-		fileService: fileService,
+		fileService:    fileService,
+		archiveService: archiveService,
 	}
 }
 
@@ -57,3 +59,19 @@ func (h *FileHandler) DeleteFile(ctx context.Context, req *filepb.DeleteFileRequ
 	}
 	return resp, nil
 }
+
+func (h *FileHandler) CreateArchive(ctx context.Context, req *filepb.CreateArchiveRequest) (*filepb.CreateArchiveResponse, error) {
+	resp, err := h.archiveService.CreateArchive(ctx, req)
+	if err != nil {
+		return nil, mapErrorToGRPCStatus(err)
+	}
+	return resp, nil
+}
+
+func (h *FileHandler) GetArchiveStatus(ctx context.Context, req *filepb.GetArchiveStatusRequest) (*filepb.GetArchiveStatusResponse, error) {
+	resp, err := h.archiveService.GetArchiveStatus(ctx, req)
+	if err != nil {
+		return nil, mapErrorToGRPCStatus(err)
+	}
+	return resp, nil
+}
@@ -0,0 +1,91 @@
+package jobs
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/file/internal/config"
+	"github.com/Ernestgio/Hangout-Planner/services/file/internal/constants/logmsg"
+	"github.com/Ernestgio/Hangout-Planner/services/file/internal/logger"
+	"github.com/Ernestgio/Hangout-Planner/services/file/internal/services"
+)
+
+// ArchiveJob builds requested memory archives and removes them once their
+// download window has ended.
+type ArchiveJob struct {
+	archiveService services.ArchiveService
+	cfg            *config.ArchiveConfig
+	cancel         context.CancelFunc
+	wg             sync.WaitGroup
+}
+
+func NewArchiveJob(archiveService services.ArchiveService, cfg *config.ArchiveConfig) *ArchiveJob {
+	return &ArchiveJob{
+		archiveService: archiveService,
+		cfg:            cfg,
+	}
+}
+
+func (j *ArchiveJob) Start(ctx context.Context) {
+	ctx, j.cancel = context.WithCancel(ctx)
+	j.wg.Add(1)
+
+	go func() {
+		defer j.wg.Done()
+
+		ticker := time.NewTicker(j.cfg.GetPollInterval())
+		defer ticker.Stop()
+
+		for {
+			j.RunOnce(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// RunOnce builds pending archives until none are left, then purges expired ones.
+func (j *ArchiveJob) RunOnce(ctx context.Context) {
+	for ctx.Err() == nil {
+		staleBefore := time.Now().Add(-j.cfg.GetStaleAfter())
+		claimed, err := j.archiveService.ProcessNextArchive(ctx, staleBefore, j.cfg.GetRetention())
+		if !claimed {
+			if err != nil {
+				logger.Error(ctx, logmsg.ArchiveBuildFailed, err)
+			}
+			break
+		}
+		// ProcessNextArchive logs failed builds itself
+		if err != nil {
+			continue
+		}
+		logger.Info(ctx, logmsg.ArchiveBuildCompleted)
+	}
+
+	for ctx.Err() == nil {
+		purged, err := j.archiveService.PurgeExpiredArchives(ctx, time.Now(), j.cfg.PurgeBatchSize)
+		if err != nil {
+			logger.Error(ctx, logmsg.ArchivePurgeFailed, err)
+			return
+		}
+		if purged > 0 {
+			logger.Info(ctx, logmsg.ArchivePurgeCompleted, slog.Int("archives_purged", purged))
+		}
+		if purged < j.cfg.PurgeBatchSize {
+			return
+		}
+	}
+}
+
+func (j *ArchiveJob) Stop() {
+	if j.cancel != nil {
+		j.cancel()
+	}
+	j.wg.Wait()
+}
@@ -0,0 +1,76 @@
+package jobs
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/file/internal/config"
+	"github.com/Ernestgio/Hangout-Planner/services/file/internal/constants/logmsg"
+	"github.com/Ernestgio/Hangout-Planner/services/file/internal/logger"
+	"github.com/Ernestgio/Hangout-Planner/services/file/internal/services"
+)
+
+// PurgeJob periodically removes soft-deleted files whose retention window has expired.
+type PurgeJob struct {
+	fileService services.FileService
+	cfg         *config.RetentionConfig
+	cancel      context.CancelFunc
+	wg          sync.WaitGroup
+}
+
+func NewPurgeJob(fileService services.FileService, cfg *config.RetentionConfig) *PurgeJob {
+	return &PurgeJob{
+		fileService: fileService,
+		cfg:         cfg,
+	}
+}
+
+func (j *PurgeJob) Start(ctx context.Context) {
+	ctx, j.cancel = context.WithCancel(ctx)
+	j.wg.Add(1)
+
+	go func() {
+		defer j.wg.Done()
+
+		ticker := time.NewTicker(j.cfg.GetPurgeInterval())
+		defer ticker.Stop()
+
+		for {
+			j.RunOnce(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// RunOnce purges expired files in batches until none are left.
+func (j *PurgeJob) RunOnce(ctx context.Context) {
+	before := time.Now().Add(-j.cfg.GetRetention())
+
+	for ctx.Err() == nil {
+		purged, err := j.fileService.PurgeDeletedFiles(ctx, before, j.cfg.PurgeBatchSize)
+		if err != nil {
+			logger.Error(ctx, logmsg.PurgeJobFailed, err)
+			return
+		}
+		if purged > 0 {
+			logger.Info(ctx, logmsg.PurgeJobCompleted, slog.Int("files_purged", purged))
+		}
+		if purged < j.cfg.PurgeBatchSize {
+			return
+		}
+	}
+}
+
+func (j *PurgeJob) Stop() {
+	if j.cancel != nil {
+		j.cancel()
+	}
+	j.wg.Wait()
+}
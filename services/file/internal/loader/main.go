@@ -11,6 +11,9 @@ import (
 func main() {
 	stmts, err := gormschema.New("mysql").Load(
 		&domain.MemoryFile{},
+		&domain.Archive{},
+		&domain.ArchiveEntry{},
+		&domain.ArchiveDocument{},
 	)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "failed to load gorm schema: %v\n", err)
@@ -0,0 +1,120 @@
+package mapper
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"unicode"
+
+	"github.com/Ernestgio/Hangout-Planner/pkg/shared/enums"
+	filepb "github.com/Ernestgio/Hangout-Planner/pkg/shared/proto/gen/go/file"
+	"github.com/Ernestgio/Hangout-Planner/services/file/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/file/internal/domain"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var archiveStatusToProto = map[enums.ArchiveStatus]filepb.ArchiveStatus{
+	enums.ArchiveStatusPending:    filepb.ArchiveStatus_ARCHIVE_STATUS_PENDING,
+	enums.ArchiveStatusProcessing: filepb.ArchiveStatus_ARCHIVE_STATUS_PROCESSING,
+	enums.ArchiveStatusCompleted:  filepb.ArchiveStatus_ARCHIVE_STATUS_COMPLETED,
+	enums.ArchiveStatusFailed:     filepb.ArchiveStatus_ARCHIVE_STATUS_FAILED,
+}
+
+// ToArchive maps an archive to its proto form. downloadURL is only set once
+// the archive has been completed.
+func ToArchive(archive *domain.Archive, downloadURL string, urlExpiresAt int64) *filepb.Archive {
+	result := &filepb.Archive{
+		Id:             archive.ID.String(),
+		Status:         archiveStatusToProto[enums.ArchiveStatus(archive.Status)],
+		TotalFiles:     int32(archive.TotalFiles),
+		ProcessedFiles: int32(archive.ProcessedFiles),
+		ArchiveSize:    archive.ArchiveSize,
+		DownloadUrl:    downloadURL,
+		UrlExpiresAt:   urlExpiresAt,
+		CreatedAt:      timestamppb.New(archive.CreatedAt),
+	}
+	if archive.ErrorMessage != nil {
+		result.Error = *archive.ErrorMessage
+	}
+	if archive.CompletedAt != nil {
+		result.CompletedAt = timestamppb.New(*archive.CompletedAt)
+	}
+	if archive.ExpiresAt != nil {
+		result.ExpiresAt = timestamppb.New(*archive.ExpiresAt)
+	}
+	return result
+}
+
+// SanitizeArchiveName turns a requested name into a safe file name without
+// extension.
+func SanitizeArchiveName(name string) string {
+	name = strings.TrimSuffix(strings.TrimSpace(name), constants.ArchiveExtension)
+	name = strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || unicode.IsControl(r) {
+			return '_'
+		}
+		return r
+	}, name)
+	name = strings.Trim(name, ". ")
+
+	if runes := []rune(name); len(runes) > constants.MaxArchiveNameLength {
+		name = strings.TrimSpace(string(runes[:constants.MaxArchiveNameLength]))
+	}
+	if name == "" {
+		return constants.DefaultArchiveName
+	}
+	return name
+}
+
+// BuildArchiveStoragePath places the archive under its own directory so
+// archives with the same name never overwrite each other.
+func BuildArchiveStoragePath(basePath, archiveID, name string) string {
+	return path.Join(basePath, archiveID, name+constants.ArchiveExtension)
+}
+
+// BuildArchiveDocumentStoragePath keeps a document's content next to the
+// archive until the archive is purged.
+func BuildArchiveDocumentStoragePath(basePath, archiveID, name string) string {
+	return path.Join(basePath, archiveID, constants.ArchiveDocumentsDir, name)
+}
+
+// SanitizeArchiveDocumentName returns the base name of a document inside the ZIP.
+func SanitizeArchiveDocumentName(name string) string {
+	name = path.Base(strings.ReplaceAll(strings.TrimSpace(name), "\\", "/"))
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return '_'
+		}
+		return r
+	}, name)
+	if name == "." || name == "/" || name == ".." || len(name) > constants.MaxArchiveEntryNameLength {
+		return ""
+	}
+	return name
+}
+
+// BuildArchiveEntryNames returns the name of each file inside the ZIP, in the
+// order given.
+func BuildArchiveEntryNames(files []*domain.MemoryFile, reserved ...string) []string {
+	names := make([]string, 0, len(files))
+	seen := make(map[string]int, len(files)+len(reserved))
+	for _, name := range reserved {
+		seen[strings.ToLower(name)]++
+	}
+	for _, file := range files {
+		name := path.Base(strings.ReplaceAll(file.OriginalName, "\\", "/"))
+		if name == "." || name == "/" || name == ".." {
+			name = file.ID.String() + path.Ext(file.StoragePath)
+		}
+
+		candidate := name
+		for seen[strings.ToLower(candidate)] > 0 {
+			seen[strings.ToLower(name)]++
+			ext := path.Ext(name)
+			candidate = fmt.Sprintf("%s (%d)%s", strings.TrimSuffix(name, ext), seen[strings.ToLower(name)], ext)
+		}
+		seen[strings.ToLower(candidate)]++
+		names = append(names, candidate)
+	}
+	return names
+}
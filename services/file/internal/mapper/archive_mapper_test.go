@@ -0,0 +1,169 @@
+package mapper_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	filepb "github.com/Ernestgio/Hangout-Planner/pkg/shared/proto/gen/go/file"
+	"github.com/Ernestgio/Hangout-Planner/services/file/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/file/internal/mapper"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToArchive(t *testing.T) {
+	now := time.Date(2024, 12, 31, 23, 59, 59, 0, time.UTC)
+	id := uuid.MustParse("11111111-1111-1111-1111-111111111111")
+	message := "storage unavailable"
+
+	tests := []struct {
+		name     string
+		archive  *domain.Archive
+		url      string
+		expires  int64
+		expected *filepb.Archive
+	}{
+		{
+			name:    "pending",
+			archive: &domain.Archive{ID: id, Status: "PENDING", TotalFiles: 3, CreatedAt: now},
+			expected: &filepb.Archive{
+				Id:         id.String(),
+				Status:     filepb.ArchiveStatus_ARCHIVE_STATUS_PENDING,
+				TotalFiles: 3,
+			},
+		},
+		{
+			name: "completed with url",
+			archive: &domain.Archive{
+				ID: id, Status: "COMPLETED", TotalFiles: 3, ProcessedFiles: 3, ArchiveSize: 4096,
+				CreatedAt: now, CompletedAt: &now, ExpiresAt: &now,
+			},
+			url:     "https://s3.example.com/a.zip",
+			expires: 1735689599,
+			expected: &filepb.Archive{
+				Id:             id.String(),
+				Status:         filepb.ArchiveStatus_ARCHIVE_STATUS_COMPLETED,
+				TotalFiles:     3,
+				ProcessedFiles: 3,
+				ArchiveSize:    4096,
+				DownloadUrl:    "https://s3.example.com/a.zip",
+				UrlExpiresAt:   1735689599,
+			},
+		},
+		{
+			name:    "failed",
+			archive: &domain.Archive{ID: id, Status: "FAILED", TotalFiles: 3, ProcessedFiles: 1, ErrorMessage: &message, CreatedAt: now},
+			expected: &filepb.Archive{
+				Id:             id.String(),
+				Status:         filepb.ArchiveStatus_ARCHIVE_STATUS_FAILED,
+				TotalFiles:     3,
+				ProcessedFiles: 1,
+				Error:          message,
+			},
+		},
+		{
+			name:    "unknown status",
+			archive: &domain.Archive{ID: id, Status: "BOGUS", CreatedAt: now},
+			expected: &filepb.Archive{
+				Id:     id.String(),
+				Status: filepb.ArchiveStatus_ARCHIVE_STATUS_UNSPECIFIED,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := mapper.ToArchive(tt.archive, tt.url, tt.expires)
+			require.Equal(t, tt.expected.Id, got.Id)
+			require.Equal(t, tt.expected.Status, got.Status)
+			require.Equal(t, tt.expected.TotalFiles, got.TotalFiles)
+			require.Equal(t, tt.expected.ProcessedFiles, got.ProcessedFiles)
+			require.Equal(t, tt.expected.ArchiveSize, got.ArchiveSize)
+			require.Equal(t, tt.expected.DownloadUrl, got.DownloadUrl)
+			require.Equal(t, tt.expected.UrlExpiresAt, got.UrlExpiresAt)
+			require.Equal(t, tt.expected.Error, got.Error)
+			require.Equal(t, now.Unix(), got.CreatedAt.AsTime().Unix())
+			require.Equal(t, tt.archive.CompletedAt != nil, got.CompletedAt != nil)
+			require.Equal(t, tt.archive.ExpiresAt != nil, got.ExpiresAt != nil)
+		})
+	}
+}
+
+func TestSanitizeArchiveName(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "plain", input: "Beach Trip", expected: "Beach Trip"},
+		{name: "strips extension", input: "Beach Trip.zip", expected: "Beach Trip"},
+		{name: "replaces separators", input: "../etc/passwd", expected: "_etc_passwd"},
+		{name: "replaces control characters", input: "a\nb", expected: "a_b"},
+		{name: "empty falls back", input: "   ", expected: "memories"},
+		{name: "dots only falls back", input: "..", expected: "memories"},
+		{name: "truncates long names", input: strings.Repeat("x", 150), expected: strings.Repeat("x", 100)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, mapper.SanitizeArchiveName(tt.input))
+		})
+	}
+}
+
+func TestBuildArchiveStoragePath(t *testing.T) {
+	require.Equal(t, "hangouts/h1/archives/a1/Beach.zip", mapper.BuildArchiveStoragePath("hangouts/h1/archives", "a1", "Beach"))
+}
+
+func TestBuildArchiveDocumentStoragePath(t *testing.T) {
+	require.Equal(t, "users/u1/exports/a1/documents/export.json", mapper.BuildArchiveDocumentStoragePath("users/u1/exports", "a1", "export.json"))
+}
+
+func TestSanitizeArchiveDocumentName(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "plain", input: "export.json", expected: "export.json"},
+		{name: "directories are stripped", input: "../data/export.json", expected: "export.json"},
+		{name: "backslashes are stripped", input: `dir\export.json`, expected: "export.json"},
+		{name: "replaces control characters", input: "a\nb.json", expected: "a_b.json"},
+		{name: "empty", input: "  ", expected: ""},
+		{name: "dots only", input: "..", expected: ""},
+		{name: "too long", input: strings.Repeat("x", 256), expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, mapper.SanitizeArchiveDocumentName(tt.input))
+		})
+	}
+}
+
+func TestBuildArchiveEntryNames(t *testing.T) {
+	file := func(name string) *domain.MemoryFile {
+		return &domain.MemoryFile{ID: uuid.MustParse("11111111-1111-1111-1111-111111111111"), OriginalName: name, StoragePath: "p/" + name}
+	}
+
+	tests := []struct {
+		name     string
+		files    []*domain.MemoryFile
+		reserved []string
+		expected []string
+	}{
+		{name: "unique names", files: []*domain.MemoryFile{file("a.jpg"), file("b.jpg")}, expected: []string{"a.jpg", "b.jpg"}},
+		{name: "duplicates get suffix", files: []*domain.MemoryFile{file("a.jpg"), file("a.jpg"), file("A.JPG")}, expected: []string{"a.jpg", "a (2).jpg", "A (3).JPG"}},
+		{name: "suffix collides with existing name", files: []*domain.MemoryFile{file("a (2).jpg"), file("a.jpg"), file("a.jpg")}, expected: []string{"a (2).jpg", "a.jpg", "a (3).jpg"}},
+		{name: "directories are stripped", files: []*domain.MemoryFile{file("dir/a.jpg"), file(`dir\b.jpg`)}, expected: []string{"a.jpg", "b.jpg"}},
+		{name: "reserved names get suffix", files: []*domain.MemoryFile{file("export.json"), file("a.jpg")}, reserved: []string{"Export.json"}, expected: []string{"export (2).json", "a.jpg"}},
+		{name: "empty input", files: nil, expected: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, mapper.BuildArchiveEntryNames(tt.files, tt.reserved...))
+		})
+	}
+}
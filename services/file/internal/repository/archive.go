@@ -0,0 +1,272 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/pkg/shared/enums"
+	"github.com/Ernestgio/Hangout-Planner/services/file/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/file/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/file/internal/otel"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ArchiveRepository interface {
+	Create(ctx context.Context, archive *domain.Archive) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Archive, error)
+	ClaimNext(ctx context.Context, staleBefore time.Time) (*domain.Archive, error)
+	UpdateProgress(ctx context.Context, id uuid.UUID, processedFiles int) error
+	MarkCompleted(ctx context.Context, id uuid.UUID, archiveSize int64, completedAt time.Time, expiresAt time.Time) error
+	MarkFailed(ctx context.Context, id uuid.UUID, message string, expiresAt time.Time) error
+	GetExpiredBefore(ctx context.Context, before time.Time, limit int) ([]*domain.Archive, error)
+	HardDelete(ctx context.Context, ids []uuid.UUID) error
+}
+
+type archiveRepository struct {
+	db      *gorm.DB
+	metrics *otel.MetricsRecorder
+}
+
+func NewArchiveRepository(db *gorm.DB, metrics *otel.MetricsRecorder) ArchiveRepository {
+	return &archiveRepository{db: db, metrics: metrics}
+}
+
+// Create stores the archive together with its entries.
+func (r *archiveRepository) Create(ctx context.Context, archive *domain.Archive) error {
+	ctx, span := otel.StartRepositorySpan(ctx, "CreateArchive",
+		attribute.String("db.operation", "insert"),
+		attribute.String("db.table", "archives"),
+		attribute.Int("archive.entries.count", len(archive.Entries)),
+	)
+	defer span.End()
+
+	start := time.Now()
+	err := r.db.WithContext(ctx).Create(archive).Error
+	r.metrics.RecordDBOperation(ctx, constants.MetricDBOpInsert, time.Since(start), 1+len(archive.Entries))
+
+	if err != nil {
+		return span.RecordErrorWithStatus(err)
+	}
+
+	span.SetAttributes(attribute.String("archive.id", archive.ID.String()))
+	span.SetStatusOk()
+	return nil
+}
+
+func (r *archiveRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Archive, error) {
+	ctx, span := otel.StartRepositorySpan(ctx, "GetArchiveByID",
+		attribute.String("db.operation", "select"),
+		attribute.String("db.table", "archives"),
+		attribute.String("archive.id", id.String()),
+	)
+	defer span.End()
+
+	start := time.Now()
+	var archive domain.Archive
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&archive).Error
+	r.metrics.RecordDBOperation(ctx, constants.MetricDBOpSelect, time.Since(start), 1)
+
+	if err != nil {
+		return nil, span.RecordErrorWithStatus(err)
+	}
+
+	span.SetStatusOk()
+	return &archive, nil
+}
+
+// ClaimNext marks the oldest pending archive as processing and returns it with its
+// entries and documents.
+func (r *archiveRepository) ClaimNext(ctx context.Context, staleBefore time.Time) (*domain.Archive, error) {
+	ctx, span := otel.StartRepositorySpan(ctx, "ClaimNextArchive",
+		attribute.String("db.operation", "update"),
+		attribute.String("db.table", "archives"),
+	)
+	defer span.End()
+
+	start := time.Now()
+	var archive domain.Archive
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? OR (status = ? AND updated_at < ?)", enums.ArchiveStatusPending, enums.ArchiveStatusProcessing, staleBefore).
+			Order("created_at asc").
+			First(&archive).Error
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Model(&archive).Updates(map[string]any{
+			"status":          string(enums.ArchiveStatusProcessing),
+			"processed_files": 0,
+		}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("archive_id = ?", archive.ID).Order("entry_name asc").Find(&archive.Entries).Error; err != nil {
+			return err
+		}
+		return tx.Where("archive_id = ?", archive.ID).Order("entry_name asc").Find(&archive.Documents).Error
+	})
+	r.metrics.RecordDBOperation(ctx, constants.MetricDBOpUpdate, time.Since(start), 1)
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		span.SetStatusOk()
+		return nil, nil
+	}
+	if err != nil {
+		return nil, span.RecordErrorWithStatus(err)
+	}
+
+	span.SetAttributes(attribute.String("archive.id", archive.ID.String()))
+	span.SetStatusOk()
+	return &archive, nil
+}
+
+// UpdateProgress records how many files have been written.
+func (r *archiveRepository) UpdateProgress(ctx context.Context, id uuid.UUID, processedFiles int) error {
+	ctx, span := otel.StartRepositorySpan(ctx, "UpdateArchiveProgress",
+		attribute.String("db.operation", "update"),
+		attribute.String("db.table", "archives"),
+		attribute.String("archive.id", id.String()),
+		attribute.Int("archive.processed_files", processedFiles),
+	)
+	defer span.End()
+
+	start := time.Now()
+	err := r.db.WithContext(ctx).
+		Model(&domain.Archive{}).
+		Where("id = ?", id).
+		Update("processed_files", processedFiles).Error
+	r.metrics.RecordDBOperation(ctx, constants.MetricDBOpUpdate, time.Since(start), 1)
+
+	if err != nil {
+		return span.RecordErrorWithStatus(err)
+	}
+
+	span.SetStatusOk()
+	return nil
+}
+
+func (r *archiveRepository) MarkCompleted(ctx context.Context, id uuid.UUID, archiveSize int64, completedAt time.Time, expiresAt time.Time) error {
+	ctx, span := otel.StartRepositorySpan(ctx, "MarkArchiveCompleted",
+		attribute.String("db.operation", "update"),
+		attribute.String("db.table", "archives"),
+		attribute.String("archive.id", id.String()),
+		attribute.Int64("archive.size", archiveSize),
+	)
+	defer span.End()
+
+	start := time.Now()
+	err := r.db.WithContext(ctx).
+		Model(&domain.Archive{}).
+		Where("id = ?", id).
+		Updates(map[string]any{
+			"status":       string(enums.ArchiveStatusCompleted),
+			"archive_size": archiveSize,
+			"completed_at": completedAt,
+			"expires_at":   expiresAt,
+		}).Error
+	r.metrics.RecordDBOperation(ctx, constants.MetricDBOpUpdate, time.Since(start), 1)
+
+	if err != nil {
+		return span.RecordErrorWithStatus(err)
+	}
+
+	span.SetStatusOk()
+	return nil
+}
+
+// MarkFailed records why the build failed. expiresAt lets the purge job clean
+// up failed archives as well.
+func (r *archiveRepository) MarkFailed(ctx context.Context, id uuid.UUID, message string, expiresAt time.Time) error {
+	ctx, span := otel.StartRepositorySpan(ctx, "MarkArchiveFailed",
+		attribute.String("db.operation", "update"),
+		attribute.String("db.table", "archives"),
+		attribute.String("archive.id", id.String()),
+	)
+	defer span.End()
+
+	start := time.Now()
+	err := r.db.WithContext(ctx).
+		Model(&domain.Archive{}).
+		Where("id = ?", id).
+		Updates(map[string]any{
+			"status":        string(enums.ArchiveStatusFailed),
+			"error_message": message,
+			"expires_at":    expiresAt,
+		}).Error
+	r.metrics.RecordDBOperation(ctx, constants.MetricDBOpUpdate, time.Since(start), 1)
+
+	if err != nil {
+		return span.RecordErrorWithStatus(err)
+	}
+
+	span.SetStatusOk()
+	return nil
+}
+
+// GetExpiredBefore returns finished archives whose expiry is before the
+// given time, oldest first, with their documents.
+func (r *archiveRepository) GetExpiredBefore(ctx context.Context, before time.Time, limit int) ([]*domain.Archive, error) {
+	ctx, span := otel.StartRepositorySpan(ctx, "GetExpiredArchives",
+		attribute.String("db.operation", "select"),
+		attribute.String("db.table", "archives"),
+		attribute.Int("db.limit", limit),
+	)
+	defer span.End()
+
+	start := time.Now()
+	var archives []*domain.Archive
+	err := r.db.WithContext(ctx).
+		Preload("Documents").
+		Where("expires_at IS NOT NULL AND expires_at < ?", before).
+		Order("expires_at asc").
+		Limit(limit).
+		Find(&archives).Error
+	r.metrics.RecordDBOperation(ctx, constants.MetricDBOpSelect, time.Since(start), len(archives))
+
+	if err != nil {
+		return nil, span.RecordErrorWithStatus(err)
+	}
+
+	span.SetAttributes(attribute.Int("archives.found", len(archives)))
+	span.SetStatusOk()
+	return archives, nil
+}
+
+// HardDelete removes the archives with their entries and documents.
+func (r *archiveRepository) HardDelete(ctx context.Context, ids []uuid.UUID) error {
+	ctx, span := otel.StartRepositorySpan(ctx, "HardDeleteArchives",
+		attribute.String("db.operation", "delete"),
+		attribute.String("db.table", "archives"),
+		attribute.Int("archive.ids.count", len(ids)),
+	)
+	defer span.End()
+
+	if len(ids) == 0 {
+		span.SetStatusOk()
+		return nil
+	}
+
+	start := time.Now()
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("archive_id IN ?", ids).Delete(&domain.ArchiveEntry{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("archive_id IN ?", ids).Delete(&domain.ArchiveDocument{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id IN ?", ids).Delete(&domain.Archive{}).Error
+	})
+	r.metrics.RecordDBOperation(ctx, constants.MetricDBOpDelete, time.Since(start), len(ids))
+
+	if err != nil {
+		return span.RecordErrorWithStatus(err)
+	}
+
+	span.SetStatusOk()
+	return nil
+}
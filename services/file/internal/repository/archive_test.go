@@ -0,0 +1,332 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Ernestgio/Hangout-Planner/services/file/internal/domain"
+	repo "github.com/Ernestgio/Hangout-Planner/services/file/internal/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+var archiveCols = []string{"id", "base_storage_path", "storage_path", "status", "total_files", "processed_files", "archive_size", "error_message", "created_at", "updated_at", "completed_at", "expires_at"}
+
+func TestArchiveCreate_TableDriven(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		prepare   func(sqlmock.Sqlmock)
+		wantError bool
+	}{
+		{
+			name: "success with entries",
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("INSERT INTO .*archives.*").WillReturnResult(sqlmock.NewResult(1, 1))
+				m.ExpectExec("INSERT INTO .*archive_entries.*").WillReturnResult(sqlmock.NewResult(1, 2))
+				m.ExpectCommit()
+			},
+		},
+		{
+			name: "insert error",
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("INSERT INTO .*archives.*").WillReturnError(errors.New("insert failed"))
+				m.ExpectRollback()
+			},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newDBWithRegexp(t)
+			r := repo.NewArchiveRepository(db, nil)
+			tt.prepare(mock)
+			archive := &domain.Archive{
+				BaseStoragePath: "hangouts/h1/archives",
+				Status:          "PENDING",
+				TotalFiles:      2,
+				Entries: []domain.ArchiveEntry{
+					{FileID: uuid.New(), EntryName: "a.png"},
+					{FileID: uuid.New(), EntryName: "b.png"},
+				},
+			}
+			err := r.Create(ctx, archive)
+			if tt.wantError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.NotEqual(t, uuid.Nil, archive.ID)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestArchiveGetByID_TableDriven(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		prepare   func(sqlmock.Sqlmock, uuid.UUID)
+		wantError bool
+	}{
+		{
+			name: "found",
+			prepare: func(m sqlmock.Sqlmock, id uuid.UUID) {
+				m.ExpectQuery("SELECT .* FROM .*archives.* WHERE id = ").
+					WithArgs(id, sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows(archiveCols).AddRow(id, "base", "base/x.zip", "COMPLETED", 2, 2, 1024, nil, time.Now(), time.Now(), time.Now(), time.Now()))
+			},
+		},
+		{
+			name: "query error",
+			prepare: func(m sqlmock.Sqlmock, id uuid.UUID) {
+				m.ExpectQuery("SELECT .* FROM .*archives.*").WillReturnError(errors.New("query failed"))
+			},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newDBWithRegexp(t)
+			r := repo.NewArchiveRepository(db, nil)
+			id := uuid.New()
+			tt.prepare(mock, id)
+			a, err := r.GetByID(ctx, id)
+			if tt.wantError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, id, a.ID)
+				require.Equal(t, int64(1024), a.ArchiveSize)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestArchiveClaimNext_TableDriven(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		prepare   func(sqlmock.Sqlmock, uuid.UUID)
+		wantNil   bool
+		wantError bool
+	}{
+		{
+			name: "claims pending archive",
+			prepare: func(m sqlmock.Sqlmock, id uuid.UUID) {
+				m.ExpectBegin()
+				m.ExpectQuery("SELECT .* FROM .*archives.* FOR UPDATE SKIP LOCKED").
+					WillReturnRows(sqlmock.NewRows(archiveCols).AddRow(id, "base", "base/x.zip", "PENDING", 1, 0, 0, nil, time.Now(), time.Now(), nil, nil))
+				m.ExpectExec("UPDATE .*archives.*").WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectQuery("SELECT .* FROM .*archive_entries.*").
+					WithArgs(id).
+					WillReturnRows(sqlmock.NewRows([]string{"archive_id", "file_id", "entry_name"}).AddRow(id, uuid.New(), "a.png"))
+				m.ExpectQuery("SELECT .* FROM .*archive_documents.*").
+					WithArgs(id).
+					WillReturnRows(sqlmock.NewRows([]string{"archive_id", "entry_name", "storage_path"}).AddRow(id, "export.json", "base/docs/export.json"))
+				m.ExpectCommit()
+			},
+		},
+		{
+			name: "nothing to claim",
+			prepare: func(m sqlmock.Sqlmock, id uuid.UUID) {
+				m.ExpectBegin()
+				m.ExpectQuery("SELECT .* FROM .*archives.*").WillReturnRows(sqlmock.NewRows(archiveCols))
+				m.ExpectRollback()
+			},
+			wantNil: true,
+		},
+		{
+			name: "update error",
+			prepare: func(m sqlmock.Sqlmock, id uuid.UUID) {
+				m.ExpectBegin()
+				m.ExpectQuery("SELECT .* FROM .*archives.*").
+					WillReturnRows(sqlmock.NewRows(archiveCols).AddRow(id, "base", "base/x.zip", "PENDING", 1, 0, 0, nil, time.Now(), time.Now(), nil, nil))
+				m.ExpectExec("UPDATE .*archives.*").WillReturnError(errors.New("update failed"))
+				m.ExpectRollback()
+			},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newDBWithRegexp(t)
+			r := repo.NewArchiveRepository(db, nil)
+			id := uuid.New()
+			tt.prepare(mock, id)
+			a, err := r.ClaimNext(ctx, time.Now().Add(-time.Minute))
+			switch {
+			case tt.wantError:
+				require.Error(t, err)
+			case tt.wantNil:
+				require.NoError(t, err)
+				require.Nil(t, a)
+			default:
+				require.NoError(t, err)
+				require.Equal(t, "PROCESSING", a.Status)
+				require.Len(t, a.Entries, 1)
+				require.Len(t, a.Documents, 1)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestArchiveUpdates_TableDriven(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.New()
+
+	tests := []struct {
+		name      string
+		call      func(repo.ArchiveRepository) error
+		fail      bool
+		wantError bool
+	}{
+		{name: "update progress", call: func(r repo.ArchiveRepository) error { return r.UpdateProgress(ctx, id, 3) }},
+		{name: "update progress error", call: func(r repo.ArchiveRepository) error { return r.UpdateProgress(ctx, id, 3) }, fail: true, wantError: true},
+		{name: "mark completed", call: func(r repo.ArchiveRepository) error {
+			return r.MarkCompleted(ctx, id, 2048, time.Now(), time.Now().Add(time.Hour))
+		}},
+		{name: "mark completed error", call: func(r repo.ArchiveRepository) error {
+			return r.MarkCompleted(ctx, id, 2048, time.Now(), time.Now().Add(time.Hour))
+		}, fail: true, wantError: true},
+		{name: "mark failed", call: func(r repo.ArchiveRepository) error { return r.MarkFailed(ctx, id, "boom", time.Now()) }},
+		{name: "mark failed error", call: func(r repo.ArchiveRepository) error { return r.MarkFailed(ctx, id, "boom", time.Now()) }, fail: true, wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newDBWithRegexp(t)
+			r := repo.NewArchiveRepository(db, nil)
+			mock.ExpectBegin()
+			if tt.fail {
+				mock.ExpectExec("UPDATE .*archives.*").WillReturnError(errors.New("update failed"))
+				mock.ExpectRollback()
+			} else {
+				mock.ExpectExec("UPDATE .*archives.*").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			}
+			err := tt.call(r)
+			if tt.wantError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestArchiveGetExpiredBefore_TableDriven(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		prepare   func(sqlmock.Sqlmock)
+		wantCount int
+		wantError bool
+	}{
+		{
+			name: "found",
+			prepare: func(m sqlmock.Sqlmock) {
+				idA, idB := uuid.New(), uuid.New()
+				rows := sqlmock.NewRows(archiveCols).
+					AddRow(idA, "base", "base/a.zip", "COMPLETED", 1, 1, 10, nil, time.Now(), time.Now(), time.Now(), time.Now()).
+					AddRow(idB, "base", "base/b.zip", "COMPLETED", 1, 1, 10, nil, time.Now(), time.Now(), time.Now(), time.Now())
+				m.ExpectQuery("SELECT .* FROM .*archives.* WHERE expires_at IS NOT NULL").WithArgs(AnyTime{}, 10).WillReturnRows(rows)
+				m.ExpectQuery("SELECT .* FROM .*archive_documents.* WHERE .*archive_id.* IN").
+					WithArgs(idA, idB).
+					WillReturnRows(sqlmock.NewRows([]string{"archive_id", "entry_name", "storage_path"}).AddRow(idA, "export.json", "base/docs/export.json"))
+			},
+			wantCount: 2,
+		},
+		{
+			name: "query error",
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT .* FROM .*archives.*").WillReturnError(errors.New("query failed"))
+			},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newDBWithRegexp(t)
+			r := repo.NewArchiveRepository(db, nil)
+			tt.prepare(mock)
+			archives, err := r.GetExpiredBefore(ctx, time.Now(), 10)
+			if tt.wantError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, archives, tt.wantCount)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestArchiveHardDelete_TableDriven(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		ids       []uuid.UUID
+		prepare   func(sqlmock.Sqlmock)
+		wantError bool
+	}{
+		{
+			name:    "empty ids",
+			ids:     nil,
+			prepare: func(m sqlmock.Sqlmock) {},
+		},
+		{
+			name: "deletes entries, documents and archives",
+			ids:  []uuid.UUID{uuid.New()},
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("DELETE FROM .*archive_entries.*").WillReturnResult(sqlmock.NewResult(0, 2))
+				m.ExpectExec("DELETE FROM .*archive_documents.*").WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectExec("DELETE FROM .*archives.*").WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectCommit()
+			},
+		},
+		{
+			name: "entry delete error",
+			ids:  []uuid.UUID{uuid.New()},
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("DELETE FROM .*archive_entries.*").WillReturnError(errors.New("delete failed"))
+				m.ExpectRollback()
+			},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newDBWithRegexp(t)
+			r := repo.NewArchiveRepository(db, nil)
+			tt.prepare(mock)
+			err := r.HardDelete(ctx, tt.ids)
+			if tt.wantError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
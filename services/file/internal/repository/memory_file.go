@@ -17,8 +17,11 @@ type MemoryFileRepository interface {
 	CreateBatch(ctx context.Context, files []*domain.MemoryFile) error
 	GetByMemoryID(ctx context.Context, memoryID uuid.UUID) (*domain.MemoryFile, error)
 	GetByMemoryIDs(ctx context.Context, memoryIDs []uuid.UUID) ([]*domain.MemoryFile, error)
+	GetByIDs(ctx context.Context, fileIDs []uuid.UUID) ([]*domain.MemoryFile, error)
 	UpdateStatusBatch(ctx context.Context, fileIDs []uuid.UUID, status string) error
 	Delete(ctx context.Context, memoryID uuid.UUID) error
+	GetDeletedBefore(ctx context.Context, before time.Time, limit int) ([]*domain.MemoryFile, error)
+	HardDelete(ctx context.Context, fileIDs []uuid.UUID) error
 }
 
 type memoryFileRepository struct {
@@ -93,6 +96,26 @@ func (r *memoryFileRepository) GetByMemoryIDs(ctx context.Context, memoryIDs []u
 	return files, nil
 }
 
+func (r *memoryFileRepository) GetByIDs(ctx context.Context, fileIDs []uuid.UUID) ([]*domain.MemoryFile, error) {
+	ctx, span := otel.StartRepositorySpan(ctx, "GetByIDs",
+		attribute.String("db.operation", "select"),
+		attribute.String("db.table", "memory_files"),
+		attribute.Int("file.ids.count", len(fileIDs)),
+	)
+	defer span.End()
+
+	start := time.Now()
+	var files []*domain.MemoryFile
+	if err := r.db.WithContext(ctx).Where("id IN ?", fileIDs).Find(&files).Error; err != nil {
+		r.metrics.RecordDBOperation(ctx, constants.MetricDBOpSelect, time.Since(start), len(fileIDs))
+		return nil, span.RecordErrorWithStatus(err)
+	}
+	r.metrics.RecordDBOperation(ctx, constants.MetricDBOpSelect, time.Since(start), len(fileIDs))
+	span.SetAttributes(attribute.Int("files.found", len(files)))
+	span.SetStatusOk()
+	return files, nil
+}
+
 func (r *memoryFileRepository) UpdateStatusBatch(ctx context.Context, fileIDs []uuid.UUID, status string) error {
 	ctx, span := otel.StartRepositorySpan(ctx, "UpdateStatusBatch",
 		attribute.String("db.operation", "update"),
@@ -136,3 +159,55 @@ func (r *memoryFileRepository) Delete(ctx context.Context, memoryID uuid.UUID) e
 	span.SetStatusOk()
 	return nil
 }
+
+func (r *memoryFileRepository) GetDeletedBefore(ctx context.Context, before time.Time, limit int) ([]*domain.MemoryFile, error) {
+	ctx, span := otel.StartRepositorySpan(ctx, "GetDeletedBefore",
+		attribute.String("db.operation", "select"),
+		attribute.String("db.table", "memory_files"),
+		attribute.Int("db.limit", limit),
+	)
+	defer span.End()
+
+	start := time.Now()
+	var files []*domain.MemoryFile
+	err := r.db.WithContext(ctx).
+		Unscoped().
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+		Order("deleted_at asc").
+		Limit(limit).
+		Find(&files).Error
+	r.metrics.RecordDBOperation(ctx, constants.MetricDBOpSelect, time.Since(start), len(files))
+
+	if err != nil {
+		return nil, span.RecordErrorWithStatus(err)
+	}
+
+	span.SetAttributes(attribute.Int("files.found", len(files)))
+	span.SetStatusOk()
+	return files, nil
+}
+
+func (r *memoryFileRepository) HardDelete(ctx context.Context, fileIDs []uuid.UUID) error {
+	ctx, span := otel.StartRepositorySpan(ctx, "HardDelete",
+		attribute.String("db.operation", "delete"),
+		attribute.String("db.table", "memory_files"),
+		attribute.Int("file.ids.count", len(fileIDs)),
+	)
+	defer span.End()
+
+	if len(fileIDs) == 0 {
+		span.SetStatusOk()
+		return nil
+	}
+
+	start := time.Now()
+	err := r.db.WithContext(ctx).Unscoped().Where("id IN ?", fileIDs).Delete(&domain.MemoryFile{}).Error
+	r.metrics.RecordDBOperation(ctx, constants.MetricDBOpDelete, time.Since(start), len(fileIDs))
+
+	if err != nil {
+		return span.RecordErrorWithStatus(err)
+	}
+
+	span.SetStatusOk()
+	return nil
+}
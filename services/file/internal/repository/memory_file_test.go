@@ -169,6 +169,55 @@ func TestGetByMemoryIDs_TableDriven(t *testing.T) {
 	}
 }
 
+func TestGetByIDs_TableDriven(t *testing.T) {
+	ctx := context.Background()
+	cols := []string{"id", "original_name", "file_extension", "storage_path", "file_size", "mime_type", "file_status", "created_at", "deleted_at", "memory_id"}
+
+	tests := []struct {
+		name      string
+		ids       []uuid.UUID
+		prepare   func(sqlmock.Sqlmock, []uuid.UUID)
+		wantCount int
+		wantError bool
+	}{
+		{
+			name: "found",
+			ids:  []uuid.UUID{uuid.New(), uuid.New()},
+			prepare: func(m sqlmock.Sqlmock, ids []uuid.UUID) {
+				rows := sqlmock.NewRows(cols).
+					AddRow(ids[0], "a.png", "png", "/p/a.png", 100, "image/png", "UPLOADED", time.Now(), nil, uuid.New()).
+					AddRow(ids[1], "b.png", "png", "/p/b.png", 200, "image/png", "UPLOADED", time.Now(), nil, uuid.New())
+				m.ExpectQuery("SELECT .* FROM .*memory_files.* WHERE id IN").WithArgs(ids[0], ids[1]).WillReturnRows(rows)
+			},
+			wantCount: 2,
+		},
+		{
+			name: "query error",
+			ids:  []uuid.UUID{uuid.New()},
+			prepare: func(m sqlmock.Sqlmock, ids []uuid.UUID) {
+				m.ExpectQuery("SELECT .* FROM .*memory_files.*").WillReturnError(errors.New("query failed"))
+			},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newDBWithRegexp(t)
+			r := repo.NewMemoryFileRepository(db, nil)
+			tt.prepare(mock, tt.ids)
+			files, err := r.GetByIDs(ctx, tt.ids)
+			if tt.wantError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, files, tt.wantCount)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
 func TestUpdateStatusBatch_TableDriven(t *testing.T) {
 	ctx := context.Background()
 
@@ -314,3 +363,108 @@ func TestWithTx_TableDriven(t *testing.T) {
 		})
 	}
 }
+
+func TestGetDeletedBefore_TableDriven(t *testing.T) {
+	ctx := context.Background()
+	before := time.Now()
+
+	tests := []struct {
+		name      string
+		prepare   func(sqlmock.Sqlmock)
+		wantCount int
+		wantError bool
+	}{
+		{
+			name: "found",
+			prepare: func(m sqlmock.Sqlmock) {
+				cols := []string{"id", "storage_path", "deleted_at", "memory_id"}
+				m.ExpectQuery("SELECT .* FROM .*memory_files.* WHERE deleted_at IS NOT NULL AND deleted_at < .* ORDER BY deleted_at asc LIMIT .*").
+					WithArgs(before, 10).
+					WillReturnRows(sqlmock.NewRows(cols).
+						AddRow(uuid.New().String(), "a.png", time.Now(), uuid.New().String()).
+						AddRow(uuid.New().String(), "b.png", time.Now(), uuid.New().String()))
+			},
+			wantCount: 2,
+		},
+		{
+			name: "query error",
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT .* FROM .*memory_files.*").
+					WithArgs(before, 10).
+					WillReturnError(errors.New("select failed"))
+			},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newDBWithRegexp(t)
+			r := repo.NewMemoryFileRepository(db, nil)
+			tt.prepare(mock)
+			files, err := r.GetDeletedBefore(ctx, before, 10)
+			if tt.wantError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, files, tt.wantCount)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestHardDelete_TableDriven(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		ids       []uuid.UUID
+		prepare   func(sqlmock.Sqlmock, []uuid.UUID)
+		wantError bool
+	}{
+		{
+			name: "success",
+			ids:  []uuid.UUID{uuid.New()},
+			prepare: func(m sqlmock.Sqlmock, ids []uuid.UUID) {
+				m.ExpectBegin()
+				m.ExpectExec("DELETE FROM .*memory_files.* WHERE id IN .*").
+					WithArgs(ids[0]).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectCommit()
+			},
+		},
+		{
+			name:    "empty ids",
+			ids:     []uuid.UUID{},
+			prepare: func(m sqlmock.Sqlmock, ids []uuid.UUID) {},
+		},
+		{
+			name: "delete error",
+			ids:  []uuid.UUID{uuid.New()},
+			prepare: func(m sqlmock.Sqlmock, ids []uuid.UUID) {
+				m.ExpectBegin()
+				m.ExpectExec("DELETE FROM .*memory_files.*").
+					WithArgs(ids[0]).
+					WillReturnError(errors.New("delete failed"))
+				m.ExpectRollback()
+			},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newDBWithRegexp(t)
+			r := repo.NewMemoryFileRepository(db, nil)
+			tt.prepare(mock, tt.ids)
+			err := r.HardDelete(ctx, tt.ids)
+			if tt.wantError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
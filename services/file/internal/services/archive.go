@@ -0,0 +1,438 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/pkg/shared/enums"
+	filepb "github.com/Ernestgio/Hangout-Planner/pkg/shared/proto/gen/go/file"
+	"github.com/Ernestgio/Hangout-Planner/services/file/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/file/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/file/internal/constants/logmsg"
+	"github.com/Ernestgio/Hangout-Planner/services/file/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/file/internal/logger"
+	"github.com/Ernestgio/Hangout-Planner/services/file/internal/mapper"
+	"github.com/Ernestgio/Hangout-Planner/services/file/internal/otel"
+	"github.com/Ernestgio/Hangout-Planner/services/file/internal/repository"
+	"github.com/Ernestgio/Hangout-Planner/services/file/internal/storage"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"gorm.io/gorm"
+)
+
+type ArchiveService interface {
+	CreateArchive(ctx context.Context, req *filepb.CreateArchiveRequest) (*filepb.CreateArchiveResponse, error)
+	GetArchiveStatus(ctx context.Context, req *filepb.GetArchiveStatusRequest) (*filepb.GetArchiveStatusResponse, error)
+	ProcessNextArchive(ctx context.Context, staleBefore time.Time, retention time.Duration) (bool, error)
+	PurgeExpiredArchives(ctx context.Context, before time.Time, batchSize int) (int, error)
+}
+
+type archiveService struct {
+	archiveRepo repository.ArchiveRepository
+	fileRepo    repository.MemoryFileRepository
+	storage     storage.Storage
+	metrics     *otel.MetricsRecorder
+}
+
+func NewArchiveService(archiveRepo repository.ArchiveRepository, fileRepo repository.MemoryFileRepository, storage storage.Storage, metrics *otel.MetricsRecorder) ArchiveService {
+	return &archiveService{
+		archiveRepo: archiveRepo,
+		fileRepo:    fileRepo,
+		storage:     storage,
+		metrics:     metrics,
+	}
+}
+
+// CreateArchive records a pending archive of the uploaded files of the given
+// memories and of the documents sent with the request.
+func (s *archiveService) CreateArchive(ctx context.Context, req *filepb.CreateArchiveRequest) (*filepb.CreateArchiveResponse, error) {
+	ctx, span := otel.StartServiceSpan(ctx, "CreateArchive",
+		attribute.Int("memory.ids.count", len(req.MemoryIds)),
+		attribute.String("base_storage_path", req.BaseStoragePath),
+	)
+	defer span.End()
+
+	recordMetrics := s.metrics.StartOperation(ctx, constants.MetricOpCreateArchive)
+
+	if req.BaseStoragePath == "" {
+		recordMetrics(apperrors.ErrInvalidBaseStoragePath)
+		return nil, span.RecordErrorWithStatus(apperrors.ErrInvalidBaseStoragePath)
+	}
+
+	documentNames := make([]string, 0, len(req.Documents))
+	seenDocuments := make(map[string]bool, len(req.Documents))
+	for _, document := range req.Documents {
+		name := mapper.SanitizeArchiveDocumentName(document.Name)
+		if name == "" || seenDocuments[strings.ToLower(name)] {
+			recordMetrics(apperrors.ErrInvalidArchiveDocument)
+			return nil, span.RecordErrorWithStatus(apperrors.ErrInvalidArchiveDocument)
+		}
+		seenDocuments[strings.ToLower(name)] = true
+		documentNames = append(documentNames, name)
+	}
+
+	memoryIDs := make([]uuid.UUID, 0, len(req.MemoryIds))
+	for _, idStr := range req.MemoryIds {
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			recordMetrics(apperrors.ErrInvalidMemoryID)
+			return nil, span.RecordErrorWithStatus(apperrors.ErrInvalidMemoryID)
+		}
+		memoryIDs = append(memoryIDs, id)
+	}
+	if len(memoryIDs) == 0 && len(documentNames) == 0 {
+		recordMetrics(apperrors.ErrNoFilesToArchive)
+		return nil, span.RecordErrorWithStatus(apperrors.ErrNoFilesToArchive)
+	}
+
+	var found []*domain.MemoryFile
+	if len(memoryIDs) > 0 {
+		var err error
+		found, err = s.fileRepo.GetByMemoryIDs(ctx, memoryIDs)
+		if err != nil {
+			recordMetrics(apperrors.ErrArchiveCreationFailed)
+			return nil, span.RecordErrorWithStatus(apperrors.ErrArchiveCreationFailed)
+		}
+	}
+
+	// Keep the order the memories were requested in; uploads that were never
+	// confirmed have no object to copy.
+	byMemoryID := make(map[uuid.UUID]*domain.MemoryFile, len(found))
+	for _, file := range found {
+		if file.FileStatus == string(enums.FileUploadStatusUploaded) {
+			byMemoryID[file.MemoryID] = file
+		}
+	}
+	files := make([]*domain.MemoryFile, 0, len(byMemoryID))
+	for _, id := range memoryIDs {
+		if file, ok := byMemoryID[id]; ok {
+			files = append(files, file)
+			delete(byMemoryID, id)
+		}
+	}
+	if len(files) == 0 && len(documentNames) == 0 {
+		recordMetrics(apperrors.ErrNoFilesToArchive)
+		return nil, span.RecordErrorWithStatus(apperrors.ErrNoFilesToArchive)
+	}
+
+	names := mapper.BuildArchiveEntryNames(files, documentNames...)
+	archive := &domain.Archive{
+		ID:              uuid.New(),
+		BaseStoragePath: req.BaseStoragePath,
+		Status:          string(enums.ArchiveStatusPending),
+		TotalFiles:      len(files) + len(documentNames),
+		Entries:         make([]domain.ArchiveEntry, 0, len(files)),
+		Documents:       make([]domain.ArchiveDocument, 0, len(documentNames)),
+	}
+	archive.StoragePath = mapper.BuildArchiveStoragePath(req.BaseStoragePath, archive.ID.String(), mapper.SanitizeArchiveName(req.ArchiveName))
+	for i, file := range files {
+		archive.Entries = append(archive.Entries, domain.ArchiveEntry{
+			ArchiveID: archive.ID,
+			FileID:    file.ID,
+			EntryName: names[i],
+		})
+	}
+
+	for i, document := range req.Documents {
+		storagePath := mapper.BuildArchiveDocumentStoragePath(req.BaseStoragePath, archive.ID.String(), documentNames[i])
+		if err := s.storage.Upload(ctx, storagePath, bytes.NewReader(document.Content), constants.ArchiveDocumentContentType); err != nil {
+			s.deleteDocuments(ctx, archive.Documents)
+			recordMetrics(apperrors.ErrArchiveCreationFailed)
+			return nil, span.RecordErrorWithStatus(apperrors.ErrArchiveCreationFailed)
+		}
+		archive.Documents = append(archive.Documents, domain.ArchiveDocument{
+			ArchiveID:   archive.ID,
+			EntryName:   documentNames[i],
+			StoragePath: storagePath,
+		})
+	}
+
+	if err := s.archiveRepo.Create(ctx, archive); err != nil {
+		s.deleteDocuments(ctx, archive.Documents)
+		recordMetrics(apperrors.ErrArchiveCreationFailed)
+		return nil, span.RecordErrorWithStatus(apperrors.ErrArchiveCreationFailed)
+	}
+
+	recordMetrics(nil)
+	span.SetAttributes(
+		attribute.String("archive.id", archive.ID.String()),
+		attribute.Int("archive.total_files", archive.TotalFiles),
+	)
+	span.SetStatusOk()
+	return &filepb.CreateArchiveResponse{
+		Archive: mapper.ToArchive(archive, "", 0),
+	}, nil
+}
+
+// GetArchiveStatus returns the progress of an archive, with a download URL once it
+// has been built.
+func (s *archiveService) GetArchiveStatus(ctx context.Context, req *filepb.GetArchiveStatusRequest) (*filepb.GetArchiveStatusResponse, error) {
+	ctx, span := otel.StartServiceSpan(ctx, "GetArchiveStatus",
+		attribute.String("archive.id", req.ArchiveId),
+	)
+	defer span.End()
+
+	recordMetrics := s.metrics.StartOperation(ctx, constants.MetricOpGetArchiveStatus)
+
+	archiveID, err := uuid.Parse(req.ArchiveId)
+	if err != nil {
+		recordMetrics(apperrors.ErrInvalidArchiveID)
+		return nil, span.RecordErrorWithStatus(apperrors.ErrInvalidArchiveID)
+	}
+
+	archive, err := s.archiveRepo.GetByID(ctx, archiveID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			err = apperrors.ErrArchiveNotFound
+		}
+		recordMetrics(err)
+		return nil, span.RecordErrorWithStatus(err)
+	}
+	if archive.BaseStoragePath != req.BaseStoragePath {
+		recordMetrics(apperrors.ErrArchiveNotFound)
+		return nil, span.RecordErrorWithStatus(apperrors.ErrArchiveNotFound)
+	}
+
+	var downloadURL string
+	var urlExpiresAt int64
+	if archive.Status == string(enums.ArchiveStatusCompleted) {
+		if archive.ExpiresAt != nil && archive.ExpiresAt.Before(time.Now()) {
+			recordMetrics(apperrors.ErrArchiveExpired)
+			return nil, span.RecordErrorWithStatus(apperrors.ErrArchiveExpired)
+		}
+
+		downloadURL, err = s.storage.GeneratePresignedDownloadURL(ctx, archive.StoragePath)
+		if err != nil {
+			recordMetrics(err)
+			return nil, span.RecordErrorWithStatus(err)
+		}
+		urlExpiresAt = mapper.GetExpiresAtUnix(s.storage.GetPresignedURLExpiry())
+	}
+
+	recordMetrics(nil)
+	span.SetAttributes(attribute.String("archive.status", archive.Status))
+	span.SetStatusOk()
+	return &filepb.GetArchiveStatusResponse{
+		Archive: mapper.ToArchive(archive, downloadURL, urlExpiresAt),
+	}, nil
+}
+
+// ProcessNextArchive builds the oldest pending archive, streaming each file from
+// storage into a ZIP that is written back to storage.
+func (s *archiveService) ProcessNextArchive(ctx context.Context, staleBefore time.Time, retention time.Duration) (bool, error) {
+	ctx, span := otel.StartServiceSpan(ctx, "ProcessNextArchive")
+	defer span.End()
+
+	archive, err := s.archiveRepo.ClaimNext(ctx, staleBefore)
+	if err != nil {
+		return false, span.RecordErrorWithStatus(err)
+	}
+	if archive == nil {
+		span.SetStatusOk()
+		return false, nil
+	}
+
+	span.SetAttributes(
+		attribute.String("archive.id", archive.ID.String()),
+		attribute.Int("archive.total_files", archive.TotalFiles),
+	)
+	recordMetrics := s.metrics.StartOperation(ctx, constants.MetricOpBuildArchive)
+
+	size, err := s.buildArchive(ctx, archive)
+	if err == nil {
+		now := time.Now()
+		err = s.archiveRepo.MarkCompleted(ctx, archive.ID, size, now, now.Add(retention))
+	}
+
+	recordMetrics(err)
+	if err != nil {
+		if ctx.Err() == nil {
+			// the cause may name storage paths, so clients only get a fixed message
+			logger.Error(ctx, logmsg.ArchiveBuildFailed, err, slog.String("archive.id", archive.ID.String()))
+			_ = s.archiveRepo.MarkFailed(ctx, archive.ID, apperrors.ErrArchiveBuildFailed.Error(), time.Now().Add(retention))
+		}
+		return true, span.RecordErrorWithStatus(err)
+	}
+
+	span.SetAttributes(attribute.Int64("archive.size", size))
+	span.SetStatusOk()
+	return true, nil
+}
+
+func (s *archiveService) buildArchive(ctx context.Context, archive *domain.Archive) (int64, error) {
+	fileIDs := make([]uuid.UUID, 0, len(archive.Entries))
+	for _, entry := range archive.Entries {
+		fileIDs = append(fileIDs, entry.FileID)
+	}
+	byID := make(map[uuid.UUID]*domain.MemoryFile, len(fileIDs))
+	if len(fileIDs) > 0 {
+		files, err := s.fileRepo.GetByIDs(ctx, fileIDs)
+		if err != nil {
+			return 0, err
+		}
+		for _, file := range files {
+			byID[file.ID] = file
+		}
+	}
+
+	writer, err := s.storage.NewWriter(ctx, archive.StoragePath, constants.ArchiveContentType)
+	if err != nil {
+		return 0, err
+	}
+	counter := &countingWriter{w: writer}
+	zw := zip.NewWriter(counter)
+
+	fail := func(err error) (int64, error) {
+		_ = writer.Abort()
+		return 0, err
+	}
+
+	processed := 0
+	for _, document := range archive.Documents {
+		if err := s.writeDocument(ctx, zw, document); err != nil {
+			return fail(err)
+		}
+		processed++
+		if err := s.archiveRepo.UpdateProgress(ctx, archive.ID, processed); err != nil {
+			return fail(err)
+		}
+	}
+	for _, entry := range archive.Entries {
+		if file, ok := byID[entry.FileID]; ok {
+			if err := s.writeEntry(ctx, zw, entry.EntryName, file); err != nil {
+				return fail(err)
+			}
+		}
+		processed++
+		if err := s.archiveRepo.UpdateProgress(ctx, archive.ID, processed); err != nil {
+			return fail(err)
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return fail(err)
+	}
+	if err := writer.Close(); err != nil {
+		return fail(err)
+	}
+	return counter.n, nil
+}
+
+func (s *archiveService) writeEntry(ctx context.Context, zw *zip.Writer, name string, file *domain.MemoryFile) error {
+	reader, err := s.storage.Download(ctx, file.StoragePath)
+	if errors.Is(err, apperrors.ErrStorageObjectNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer func() { _ = reader.Close() }()
+
+	// Images are already compressed, so entries are stored as-is.
+	w, err := zw.CreateHeader(&zip.FileHeader{
+		Name:     name,
+		Method:   zip.Store,
+		Modified: file.CreatedAt,
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(w, reader); err != nil {
+		return apperrors.ErrFileDownloadFailed
+	}
+	return nil
+}
+
+// writeDocument copies a stored document into the ZIP.
+func (s *archiveService) writeDocument(ctx context.Context, zw *zip.Writer, document domain.ArchiveDocument) error {
+	reader, err := s.storage.Download(ctx, document.StoragePath)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = reader.Close() }()
+
+	w, err := zw.CreateHeader(&zip.FileHeader{
+		Name:     document.EntryName,
+		Method:   zip.Deflate,
+		Modified: time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(w, reader); err != nil {
+		return apperrors.ErrFileDownloadFailed
+	}
+	return nil
+}
+
+// deleteDocuments removes stored documents of an archive that was never recorded.
+func (s *archiveService) deleteDocuments(ctx context.Context, documents []domain.ArchiveDocument) {
+	for _, document := range documents {
+		_ = s.storage.Delete(ctx, document.StoragePath)
+	}
+}
+
+// PurgeExpiredArchives removes completed and failed archives once they
+// expire, together with their objects and documents.
+func (s *archiveService) PurgeExpiredArchives(ctx context.Context, before time.Time, batchSize int) (int, error) {
+	ctx, span := otel.StartServiceSpan(ctx, "PurgeExpiredArchives",
+		attribute.Int("batch.size", batchSize),
+	)
+	defer span.End()
+
+	recordMetrics := s.metrics.StartOperation(ctx, constants.MetricOpPurgeArchives)
+
+	archives, err := s.archiveRepo.GetExpiredBefore(ctx, before, batchSize)
+	if err != nil {
+		recordMetrics(err)
+		return 0, span.RecordErrorWithStatus(err)
+	}
+
+	purgedIDs := make([]uuid.UUID, 0, len(archives))
+	for _, archive := range archives {
+		if err := s.deleteObjects(ctx, archive); err != nil {
+			// Keep the record so the objects are retried on the next run.
+			continue
+		}
+		purgedIDs = append(purgedIDs, archive.ID)
+	}
+
+	if err := s.archiveRepo.HardDelete(ctx, purgedIDs); err != nil {
+		recordMetrics(apperrors.ErrFileDeleteFailed)
+		return 0, span.RecordErrorWithStatus(apperrors.ErrFileDeleteFailed)
+	}
+
+	recordMetrics(nil)
+	span.SetAttributes(attribute.Int("archives.purged", len(purgedIDs)))
+	span.SetStatusOk()
+	return len(purgedIDs), nil
+}
+
+// deleteObjects removes the archive's ZIP and stored documents.
+func (s *archiveService) deleteObjects(ctx context.Context, archive *domain.Archive) error {
+	err := s.storage.Delete(ctx, archive.StoragePath)
+	for _, document := range archive.Documents {
+		if deleteErr := s.storage.Delete(ctx, document.StoragePath); err == nil {
+			err = deleteErr
+		}
+	}
+	return err
+}
+
+// countingWriter tracks the size of the ZIP as it is written.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,687 @@
+package services_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	filepb "github.com/Ernestgio/Hangout-Planner/pkg/shared/proto/gen/go/file"
+	"github.com/Ernestgio/Hangout-Planner/services/file/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/file/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/file/internal/services"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+type MockArchiveRepository struct {
+	mock.Mock
+}
+
+func (m *MockArchiveRepository) Create(ctx context.Context, archive *domain.Archive) error {
+	args := m.Called(ctx, archive)
+	return args.Error(0)
+}
+
+func (m *MockArchiveRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Archive, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Archive), args.Error(1)
+}
+
+func (m *MockArchiveRepository) ClaimNext(ctx context.Context, staleBefore time.Time) (*domain.Archive, error) {
+	args := m.Called(ctx, staleBefore)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Archive), args.Error(1)
+}
+
+func (m *MockArchiveRepository) UpdateProgress(ctx context.Context, id uuid.UUID, processedFiles int) error {
+	args := m.Called(ctx, id, processedFiles)
+	return args.Error(0)
+}
+
+func (m *MockArchiveRepository) MarkCompleted(ctx context.Context, id uuid.UUID, archiveSize int64, completedAt time.Time, expiresAt time.Time) error {
+	args := m.Called(ctx, id, archiveSize, completedAt, expiresAt)
+	return args.Error(0)
+}
+
+func (m *MockArchiveRepository) MarkFailed(ctx context.Context, id uuid.UUID, message string, expiresAt time.Time) error {
+	args := m.Called(ctx, id, message, expiresAt)
+	return args.Error(0)
+}
+
+func (m *MockArchiveRepository) GetExpiredBefore(ctx context.Context, before time.Time, limit int) ([]*domain.Archive, error) {
+	args := m.Called(ctx, before, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Archive), args.Error(1)
+}
+
+func (m *MockArchiveRepository) HardDelete(ctx context.Context, ids []uuid.UUID) error {
+	args := m.Called(ctx, ids)
+	return args.Error(0)
+}
+
+// memoryWriter collects an uploaded object in memory.
+type memoryWriter struct {
+	bytes.Buffer
+	closed  bool
+	aborted bool
+}
+
+func (w *memoryWriter) Close() error {
+	w.closed = true
+	return nil
+}
+
+func (w *memoryWriter) Abort() error {
+	w.aborted = true
+	return nil
+}
+
+func TestArchiveService_CreateArchive(t *testing.T) {
+	ctx := context.Background()
+	memA, memB, memC := uuid.New(), uuid.New(), uuid.New()
+	fileA := &domain.MemoryFile{ID: uuid.New(), MemoryID: memA, OriginalName: "beach.jpg", FileStatus: "UPLOADED"}
+	fileB := &domain.MemoryFile{ID: uuid.New(), MemoryID: memB, OriginalName: "beach.jpg", FileStatus: "UPLOADED"}
+	pending := &domain.MemoryFile{ID: uuid.New(), MemoryID: memC, OriginalName: "pending.jpg", FileStatus: "PENDING"}
+
+	tests := []struct {
+		name        string
+		req         *filepb.CreateArchiveRequest
+		setup       func(*MockArchiveRepository, *MockMemoryFileRepository)
+		wantEntries []string
+		wantError   error
+	}{
+		{
+			name: "creates pending archive in request order",
+			req:  &filepb.CreateArchiveRequest{BaseStoragePath: "hangouts/h1/archives", ArchiveName: "Trip", MemoryIds: []string{memB.String(), memA.String(), memC.String()}},
+			setup: func(archives *MockArchiveRepository, files *MockMemoryFileRepository) {
+				files.On("GetByMemoryIDs", mock.Anything, []uuid.UUID{memB, memA, memC}).Return([]*domain.MemoryFile{fileA, fileB, pending}, nil)
+				archives.On("Create", mock.Anything, mock.AnythingOfType("*domain.Archive")).Return(nil)
+			},
+			wantEntries: []string{"beach.jpg", "beach (2).jpg"},
+		},
+		{
+			name:      "missing base path",
+			req:       &filepb.CreateArchiveRequest{MemoryIds: []string{memA.String()}},
+			setup:     func(*MockArchiveRepository, *MockMemoryFileRepository) {},
+			wantError: apperrors.ErrInvalidBaseStoragePath,
+		},
+		{
+			name:      "invalid memory id",
+			req:       &filepb.CreateArchiveRequest{BaseStoragePath: "base", MemoryIds: []string{"bad"}},
+			setup:     func(*MockArchiveRepository, *MockMemoryFileRepository) {},
+			wantError: apperrors.ErrInvalidMemoryID,
+		},
+		{
+			name:      "no memories",
+			req:       &filepb.CreateArchiveRequest{BaseStoragePath: "base"},
+			setup:     func(*MockArchiveRepository, *MockMemoryFileRepository) {},
+			wantError: apperrors.ErrNoFilesToArchive,
+		},
+		{
+			name: "no uploaded files",
+			req:  &filepb.CreateArchiveRequest{BaseStoragePath: "base", MemoryIds: []string{memC.String()}},
+			setup: func(archives *MockArchiveRepository, files *MockMemoryFileRepository) {
+				files.On("GetByMemoryIDs", mock.Anything, []uuid.UUID{memC}).Return([]*domain.MemoryFile{pending}, nil)
+			},
+			wantError: apperrors.ErrNoFilesToArchive,
+		},
+		{
+			name: "lookup error",
+			req:  &filepb.CreateArchiveRequest{BaseStoragePath: "base", MemoryIds: []string{memA.String()}},
+			setup: func(archives *MockArchiveRepository, files *MockMemoryFileRepository) {
+				files.On("GetByMemoryIDs", mock.Anything, []uuid.UUID{memA}).Return(nil, errors.New("db error"))
+			},
+			wantError: apperrors.ErrArchiveCreationFailed,
+		},
+		{
+			name: "create error",
+			req:  &filepb.CreateArchiveRequest{BaseStoragePath: "base", MemoryIds: []string{memA.String()}},
+			setup: func(archives *MockArchiveRepository, files *MockMemoryFileRepository) {
+				files.On("GetByMemoryIDs", mock.Anything, []uuid.UUID{memA}).Return([]*domain.MemoryFile{fileA}, nil)
+				archives.On("Create", mock.Anything, mock.AnythingOfType("*domain.Archive")).Return(errors.New("db error"))
+			},
+			wantError: apperrors.ErrArchiveCreationFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			archives := new(MockArchiveRepository)
+			files := new(MockMemoryFileRepository)
+			tt.setup(archives, files)
+			svc := services.NewArchiveService(archives, files, nil, nil)
+			resp, err := svc.CreateArchive(ctx, tt.req)
+			if tt.wantError != nil {
+				require.ErrorIs(t, err, tt.wantError)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, filepb.ArchiveStatus_ARCHIVE_STATUS_PENDING, resp.Archive.Status)
+				require.Equal(t, int32(len(tt.wantEntries)), resp.Archive.TotalFiles)
+
+				created := archives.Calls[0].Arguments.Get(1).(*domain.Archive)
+				require.Equal(t, "hangouts/h1/archives/"+created.ID.String()+"/Trip.zip", created.StoragePath)
+				names := make([]string, 0, len(created.Entries))
+				for _, entry := range created.Entries {
+					names = append(names, entry.EntryName)
+				}
+				require.Equal(t, tt.wantEntries, names)
+				require.Equal(t, fileB.ID, created.Entries[0].FileID)
+			}
+			archives.AssertExpectations(t)
+			files.AssertExpectations(t)
+		})
+	}
+}
+
+func TestArchiveService_CreateArchiveWithDocuments(t *testing.T) {
+	ctx := context.Background()
+	memA := uuid.New()
+	fileA := &domain.MemoryFile{ID: uuid.New(), MemoryID: memA, OriginalName: "export.json", FileStatus: "UPLOADED"}
+	document := &filepb.ArchiveDocument{Name: "export.json", Content: []byte(`{"profile":{}}`)}
+
+	tests := []struct {
+		name          string
+		req           *filepb.CreateArchiveRequest
+		setup         func(*MockArchiveRepository, *MockMemoryFileRepository, *MockStorage)
+		wantDocuments []string
+		wantEntries   []string
+		wantError     error
+	}{
+		{
+			name: "stores documents without memories",
+			req:  &filepb.CreateArchiveRequest{BaseStoragePath: "base", ArchiveName: "export", Documents: []*filepb.ArchiveDocument{document}},
+			setup: func(archives *MockArchiveRepository, files *MockMemoryFileRepository, store *MockStorage) {
+				store.On("Upload", mock.Anything, mock.MatchedBy(func(path string) bool {
+					return strings.HasPrefix(path, "base/") && strings.HasSuffix(path, "/documents/export.json")
+				}), mock.Anything, "application/octet-stream").Return(nil)
+				archives.On("Create", mock.Anything, mock.AnythingOfType("*domain.Archive")).Return(nil)
+			},
+			wantDocuments: []string{"export.json"},
+			wantEntries:   []string{},
+		},
+		{
+			name: "memory files do not take document names",
+			req:  &filepb.CreateArchiveRequest{BaseStoragePath: "base", MemoryIds: []string{memA.String()}, Documents: []*filepb.ArchiveDocument{document}},
+			setup: func(archives *MockArchiveRepository, files *MockMemoryFileRepository, store *MockStorage) {
+				files.On("GetByMemoryIDs", mock.Anything, []uuid.UUID{memA}).Return([]*domain.MemoryFile{fileA}, nil)
+				store.On("Upload", mock.Anything, mock.Anything, mock.Anything, "application/octet-stream").Return(nil)
+				archives.On("Create", mock.Anything, mock.AnythingOfType("*domain.Archive")).Return(nil)
+			},
+			wantDocuments: []string{"export.json"},
+			wantEntries:   []string{"export (2).json"},
+		},
+		{
+			name:      "invalid document name",
+			req:       &filepb.CreateArchiveRequest{BaseStoragePath: "base", Documents: []*filepb.ArchiveDocument{{Name: "../"}}},
+			setup:     func(*MockArchiveRepository, *MockMemoryFileRepository, *MockStorage) {},
+			wantError: apperrors.ErrInvalidArchiveDocument,
+		},
+		{
+			name:      "duplicate document name",
+			req:       &filepb.CreateArchiveRequest{BaseStoragePath: "base", Documents: []*filepb.ArchiveDocument{document, {Name: "EXPORT.json"}}},
+			setup:     func(*MockArchiveRepository, *MockMemoryFileRepository, *MockStorage) {},
+			wantError: apperrors.ErrInvalidArchiveDocument,
+		},
+		{
+			name: "upload error",
+			req:  &filepb.CreateArchiveRequest{BaseStoragePath: "base", Documents: []*filepb.ArchiveDocument{document}},
+			setup: func(archives *MockArchiveRepository, files *MockMemoryFileRepository, store *MockStorage) {
+				store.On("Upload", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(errors.New("s3 error"))
+			},
+			wantError: apperrors.ErrArchiveCreationFailed,
+		},
+		{
+			name: "create error removes stored documents",
+			req:  &filepb.CreateArchiveRequest{BaseStoragePath: "base", Documents: []*filepb.ArchiveDocument{document}},
+			setup: func(archives *MockArchiveRepository, files *MockMemoryFileRepository, store *MockStorage) {
+				store.On("Upload", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				archives.On("Create", mock.Anything, mock.AnythingOfType("*domain.Archive")).Return(errors.New("db error"))
+				store.On("Delete", mock.Anything, mock.MatchedBy(func(path string) bool {
+					return strings.HasSuffix(path, "/documents/export.json")
+				})).Return(nil)
+			},
+			wantError: apperrors.ErrArchiveCreationFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			archives := new(MockArchiveRepository)
+			files := new(MockMemoryFileRepository)
+			store := new(MockStorage)
+			tt.setup(archives, files, store)
+			svc := services.NewArchiveService(archives, files, store, nil)
+			resp, err := svc.CreateArchive(ctx, tt.req)
+			if tt.wantError != nil {
+				require.ErrorIs(t, err, tt.wantError)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, int32(len(tt.wantDocuments)+len(tt.wantEntries)), resp.Archive.TotalFiles)
+
+				created := archives.Calls[0].Arguments.Get(1).(*domain.Archive)
+				documents := make([]string, 0, len(created.Documents))
+				for _, document := range created.Documents {
+					documents = append(documents, document.EntryName)
+					require.Equal(t, "base/"+created.ID.String()+"/documents/"+document.EntryName, document.StoragePath)
+				}
+				require.Equal(t, tt.wantDocuments, documents)
+				entries := make([]string, 0, len(created.Entries))
+				for _, entry := range created.Entries {
+					entries = append(entries, entry.EntryName)
+				}
+				require.Equal(t, tt.wantEntries, entries)
+			}
+			archives.AssertExpectations(t)
+			files.AssertExpectations(t)
+			store.AssertExpectations(t)
+		})
+	}
+}
+
+func TestArchiveService_GetArchiveStatus(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.New()
+	future := time.Now().Add(time.Hour)
+	past := time.Now().Add(-time.Hour)
+	processing := &domain.Archive{ID: id, BaseStoragePath: "base", Status: "PROCESSING", TotalFiles: 4, ProcessedFiles: 2}
+	completed := &domain.Archive{ID: id, BaseStoragePath: "base", StoragePath: "base/a.zip", Status: "COMPLETED", CompletedAt: &past, ExpiresAt: &future}
+	expired := &domain.Archive{ID: id, BaseStoragePath: "base", StoragePath: "base/a.zip", Status: "COMPLETED", CompletedAt: &past, ExpiresAt: &past}
+
+	tests := []struct {
+		name      string
+		req       *filepb.GetArchiveStatusRequest
+		setup     func(*MockArchiveRepository, *MockStorage)
+		wantURL   string
+		wantError error
+	}{
+		{
+			name: "in progress",
+			req:  &filepb.GetArchiveStatusRequest{ArchiveId: id.String(), BaseStoragePath: "base"},
+			setup: func(archives *MockArchiveRepository, store *MockStorage) {
+				archives.On("GetByID", mock.Anything, id).Return(processing, nil)
+			},
+		},
+		{
+			name: "completed with download url",
+			req:  &filepb.GetArchiveStatusRequest{ArchiveId: id.String(), BaseStoragePath: "base"},
+			setup: func(archives *MockArchiveRepository, store *MockStorage) {
+				archives.On("GetByID", mock.Anything, id).Return(completed, nil)
+				store.On("GeneratePresignedDownloadURL", mock.Anything, "base/a.zip").Return("https://s3/a.zip", nil)
+				store.On("GetPresignedURLExpiry").Return(15 * time.Minute)
+			},
+			wantURL: "https://s3/a.zip",
+		},
+		{
+			name: "expired",
+			req:  &filepb.GetArchiveStatusRequest{ArchiveId: id.String(), BaseStoragePath: "base"},
+			setup: func(archives *MockArchiveRepository, store *MockStorage) {
+				archives.On("GetByID", mock.Anything, id).Return(expired, nil)
+			},
+			wantError: apperrors.ErrArchiveExpired,
+		},
+		{
+			name: "other owner",
+			req:  &filepb.GetArchiveStatusRequest{ArchiveId: id.String(), BaseStoragePath: "other"},
+			setup: func(archives *MockArchiveRepository, store *MockStorage) {
+				archives.On("GetByID", mock.Anything, id).Return(processing, nil)
+			},
+			wantError: apperrors.ErrArchiveNotFound,
+		},
+		{
+			name: "not found",
+			req:  &filepb.GetArchiveStatusRequest{ArchiveId: id.String(), BaseStoragePath: "base"},
+			setup: func(archives *MockArchiveRepository, store *MockStorage) {
+				archives.On("GetByID", mock.Anything, id).Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantError: apperrors.ErrArchiveNotFound,
+		},
+		{
+			name:      "invalid id",
+			req:       &filepb.GetArchiveStatusRequest{ArchiveId: "bad", BaseStoragePath: "base"},
+			setup:     func(*MockArchiveRepository, *MockStorage) {},
+			wantError: apperrors.ErrInvalidArchiveID,
+		},
+		{
+			name: "presign error",
+			req:  &filepb.GetArchiveStatusRequest{ArchiveId: id.String(), BaseStoragePath: "base"},
+			setup: func(archives *MockArchiveRepository, store *MockStorage) {
+				archives.On("GetByID", mock.Anything, id).Return(completed, nil)
+				store.On("GeneratePresignedDownloadURL", mock.Anything, "base/a.zip").Return("", apperrors.ErrPresignedDownloadURLFailed)
+			},
+			wantError: apperrors.ErrPresignedDownloadURLFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			archives := new(MockArchiveRepository)
+			store := new(MockStorage)
+			tt.setup(archives, store)
+			svc := services.NewArchiveService(archives, nil, store, nil)
+			resp, err := svc.GetArchiveStatus(ctx, tt.req)
+			if tt.wantError != nil {
+				require.ErrorIs(t, err, tt.wantError)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.wantURL, resp.Archive.DownloadUrl)
+				require.Equal(t, tt.wantURL != "", resp.Archive.UrlExpiresAt > 0)
+			}
+			archives.AssertExpectations(t)
+			store.AssertExpectations(t)
+		})
+	}
+}
+
+func TestArchiveService_ProcessNextArchive(t *testing.T) {
+	ctx := context.Background()
+	staleBefore := time.Now().Add(-10 * time.Minute)
+	archiveID := uuid.New()
+	fileA := &domain.MemoryFile{ID: uuid.New(), StoragePath: "p/a.jpg", CreatedAt: time.Now()}
+	fileB := &domain.MemoryFile{ID: uuid.New(), StoragePath: "p/b.jpg", CreatedAt: time.Now()}
+	deletedID := uuid.New()
+	newArchive := func() *domain.Archive {
+		return &domain.Archive{
+			ID:          archiveID,
+			StoragePath: "base/x.zip",
+			Status:      "PROCESSING",
+			TotalFiles:  3,
+			Entries: []domain.ArchiveEntry{
+				{ArchiveID: archiveID, FileID: fileA.ID, EntryName: "a.jpg"},
+				{ArchiveID: archiveID, FileID: deletedID, EntryName: "gone.jpg"},
+				{ArchiveID: archiveID, FileID: fileB.ID, EntryName: "b.jpg"},
+			},
+		}
+	}
+	ids := []uuid.UUID{fileA.ID, deletedID, fileB.ID}
+	body := func(s string) io.ReadCloser { return io.NopCloser(strings.NewReader(s)) }
+
+	tests := []struct {
+		name        string
+		setup       func(*MockArchiveRepository, *MockMemoryFileRepository, *MockStorage, *memoryWriter)
+		wantClaimed bool
+		wantEntries []string
+		wantAborted bool
+		wantError   bool
+	}{
+		{
+			name: "nothing to build",
+			setup: func(archives *MockArchiveRepository, files *MockMemoryFileRepository, store *MockStorage, w *memoryWriter) {
+				archives.On("ClaimNext", mock.Anything, staleBefore).Return(nil, nil)
+			},
+		},
+		{
+			name: "builds zip and skips deleted files",
+			setup: func(archives *MockArchiveRepository, files *MockMemoryFileRepository, store *MockStorage, w *memoryWriter) {
+				archives.On("ClaimNext", mock.Anything, staleBefore).Return(newArchive(), nil)
+				files.On("GetByIDs", mock.Anything, ids).Return([]*domain.MemoryFile{fileA, fileB}, nil)
+				store.On("NewWriter", mock.Anything, "base/x.zip", "application/zip").Return(w, nil)
+				store.On("Download", mock.Anything, "p/a.jpg").Return(body("aaa"), nil)
+				store.On("Download", mock.Anything, "p/b.jpg").Return(body("bbbb"), nil)
+				archives.On("UpdateProgress", mock.Anything, archiveID, 1).Return(nil)
+				archives.On("UpdateProgress", mock.Anything, archiveID, 2).Return(nil)
+				archives.On("UpdateProgress", mock.Anything, archiveID, 3).Return(nil)
+				archives.On("MarkCompleted", mock.Anything, archiveID, mock.AnythingOfType("int64"), mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).Return(nil)
+			},
+			wantClaimed: true,
+			wantEntries: []string{"a.jpg", "b.jpg"},
+		},
+		{
+			name: "skips objects missing from storage",
+			setup: func(archives *MockArchiveRepository, files *MockMemoryFileRepository, store *MockStorage, w *memoryWriter) {
+				archives.On("ClaimNext", mock.Anything, staleBefore).Return(newArchive(), nil)
+				files.On("GetByIDs", mock.Anything, ids).Return([]*domain.MemoryFile{fileA, fileB}, nil)
+				store.On("NewWriter", mock.Anything, "base/x.zip", "application/zip").Return(w, nil)
+				store.On("Download", mock.Anything, "p/a.jpg").Return(nil, apperrors.ErrStorageObjectNotFound)
+				store.On("Download", mock.Anything, "p/b.jpg").Return(body("bbbb"), nil)
+				archives.On("UpdateProgress", mock.Anything, archiveID, mock.Anything).Return(nil)
+				archives.On("MarkCompleted", mock.Anything, archiveID, mock.AnythingOfType("int64"), mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).Return(nil)
+			},
+			wantClaimed: true,
+			wantEntries: []string{"b.jpg"},
+		},
+		{
+			name: "download error fails the archive",
+			setup: func(archives *MockArchiveRepository, files *MockMemoryFileRepository, store *MockStorage, w *memoryWriter) {
+				archives.On("ClaimNext", mock.Anything, staleBefore).Return(newArchive(), nil)
+				files.On("GetByIDs", mock.Anything, ids).Return([]*domain.MemoryFile{fileA, fileB}, nil)
+				store.On("NewWriter", mock.Anything, "base/x.zip", "application/zip").Return(w, nil)
+				store.On("Download", mock.Anything, "p/a.jpg").Return(nil, apperrors.ErrFileDownloadFailed)
+				archives.On("MarkFailed", mock.Anything, archiveID, apperrors.ErrArchiveBuildFailed.Error(), mock.AnythingOfType("time.Time")).Return(nil)
+			},
+			wantClaimed: true,
+			wantAborted: true,
+			wantError:   true,
+		},
+		{
+			name: "writer error fails the archive",
+			setup: func(archives *MockArchiveRepository, files *MockMemoryFileRepository, store *MockStorage, w *memoryWriter) {
+				archives.On("ClaimNext", mock.Anything, staleBefore).Return(newArchive(), nil)
+				files.On("GetByIDs", mock.Anything, ids).Return([]*domain.MemoryFile{fileA, fileB}, nil)
+				store.On("NewWriter", mock.Anything, "base/x.zip", "application/zip").Return(nil, apperrors.ErrFileUploadFailed)
+				archives.On("MarkFailed", mock.Anything, archiveID, apperrors.ErrArchiveBuildFailed.Error(), mock.AnythingOfType("time.Time")).Return(nil)
+			},
+			wantClaimed: true,
+			wantError:   true,
+		},
+		{
+			name: "claim error",
+			setup: func(archives *MockArchiveRepository, files *MockMemoryFileRepository, store *MockStorage, w *memoryWriter) {
+				archives.On("ClaimNext", mock.Anything, staleBefore).Return(nil, errors.New("db error"))
+			},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			archives := new(MockArchiveRepository)
+			files := new(MockMemoryFileRepository)
+			store := new(MockStorage)
+			w := &memoryWriter{}
+			tt.setup(archives, files, store, w)
+			svc := services.NewArchiveService(archives, files, store, nil)
+			claimed, err := svc.ProcessNextArchive(ctx, staleBefore, 24*time.Hour)
+			require.Equal(t, tt.wantClaimed, claimed)
+			require.Equal(t, tt.wantAborted, w.aborted)
+			if tt.wantError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			if tt.wantEntries != nil {
+				require.True(t, w.closed)
+				zr, err := zip.NewReader(bytes.NewReader(w.Bytes()), int64(w.Len()))
+				require.NoError(t, err)
+				names := make([]string, 0, len(zr.File))
+				for _, f := range zr.File {
+					require.Equal(t, zip.Store, f.Method)
+					names = append(names, f.Name)
+				}
+				require.Equal(t, tt.wantEntries, names)
+
+				size := archives.Calls[len(archives.Calls)-1].Arguments.Get(2).(int64)
+				require.Equal(t, int64(w.Len()), size)
+			}
+			archives.AssertExpectations(t)
+			files.AssertExpectations(t)
+			store.AssertExpectations(t)
+		})
+	}
+}
+
+func TestArchiveService_ProcessNextArchiveWithDocuments(t *testing.T) {
+	ctx := context.Background()
+	staleBefore := time.Now().Add(-10 * time.Minute)
+	archiveID := uuid.New()
+	file := &domain.MemoryFile{ID: uuid.New(), StoragePath: "p/a.jpg", CreatedAt: time.Now()}
+	body := func(s string) io.ReadCloser { return io.NopCloser(strings.NewReader(s)) }
+	newArchive := func(entries ...domain.ArchiveEntry) *domain.Archive {
+		return &domain.Archive{
+			ID:          archiveID,
+			StoragePath: "base/x.zip",
+			Status:      "PROCESSING",
+			TotalFiles:  1 + len(entries),
+			Entries:     entries,
+			Documents:   []domain.ArchiveDocument{{ArchiveID: archiveID, EntryName: "export.json", StoragePath: "base/docs/export.json"}},
+		}
+	}
+
+	t.Run("writes documents before files", func(t *testing.T) {
+		archives := new(MockArchiveRepository)
+		files := new(MockMemoryFileRepository)
+		store := new(MockStorage)
+		w := &memoryWriter{}
+		archives.On("ClaimNext", mock.Anything, staleBefore).Return(newArchive(domain.ArchiveEntry{ArchiveID: archiveID, FileID: file.ID, EntryName: "a.jpg"}), nil)
+		files.On("GetByIDs", mock.Anything, []uuid.UUID{file.ID}).Return([]*domain.MemoryFile{file}, nil)
+		store.On("NewWriter", mock.Anything, "base/x.zip", "application/zip").Return(w, nil)
+		store.On("Download", mock.Anything, "base/docs/export.json").Return(body(`{"profile":{}}`), nil)
+		store.On("Download", mock.Anything, "p/a.jpg").Return(body("aaa"), nil)
+		archives.On("UpdateProgress", mock.Anything, archiveID, 1).Return(nil)
+		archives.On("UpdateProgress", mock.Anything, archiveID, 2).Return(nil)
+		archives.On("MarkCompleted", mock.Anything, archiveID, mock.AnythingOfType("int64"), mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).Return(nil)
+		svc := services.NewArchiveService(archives, files, store, nil)
+
+		claimed, err := svc.ProcessNextArchive(ctx, staleBefore, 24*time.Hour)
+
+		require.NoError(t, err)
+		require.True(t, claimed)
+		zr, err := zip.NewReader(bytes.NewReader(w.Bytes()), int64(w.Len()))
+		require.NoError(t, err)
+		require.Len(t, zr.File, 2)
+		require.Equal(t, "export.json", zr.File[0].Name)
+		require.Equal(t, zip.Deflate, zr.File[0].Method)
+		require.Equal(t, "a.jpg", zr.File[1].Name)
+		rc, err := zr.File[0].Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		require.Equal(t, `{"profile":{}}`, string(content))
+		archives.AssertExpectations(t)
+		files.AssertExpectations(t)
+		store.AssertExpectations(t)
+	})
+
+	t.Run("missing document fails the archive", func(t *testing.T) {
+		archives := new(MockArchiveRepository)
+		store := new(MockStorage)
+		w := &memoryWriter{}
+		archives.On("ClaimNext", mock.Anything, staleBefore).Return(newArchive(), nil)
+		store.On("NewWriter", mock.Anything, "base/x.zip", "application/zip").Return(w, nil)
+		store.On("Download", mock.Anything, "base/docs/export.json").Return(nil, apperrors.ErrStorageObjectNotFound)
+		archives.On("MarkFailed", mock.Anything, archiveID, apperrors.ErrArchiveBuildFailed.Error(), mock.AnythingOfType("time.Time")).Return(nil)
+		svc := services.NewArchiveService(archives, new(MockMemoryFileRepository), store, nil)
+
+		claimed, err := svc.ProcessNextArchive(ctx, staleBefore, 24*time.Hour)
+
+		require.Error(t, err)
+		require.True(t, claimed)
+		require.True(t, w.aborted)
+		archives.AssertExpectations(t)
+		store.AssertExpectations(t)
+	})
+}
+
+func TestArchiveService_PurgeExpiredArchives(t *testing.T) {
+	ctx := context.Background()
+	before := time.Now()
+	archiveA := &domain.Archive{ID: uuid.New(), StoragePath: "base/a.zip"}
+	archiveB := &domain.Archive{ID: uuid.New(), StoragePath: "base/b.zip"}
+	withDocument := &domain.Archive{ID: uuid.New(), StoragePath: "base/c.zip", Documents: []domain.ArchiveDocument{{EntryName: "export.json", StoragePath: "base/c/documents/export.json"}}}
+	dbError := errors.New("db error")
+
+	tests := []struct {
+		name       string
+		setup      func(*MockArchiveRepository, *MockStorage)
+		wantPurged int
+		wantError  error
+	}{
+		{
+			name: "purges objects and records",
+			setup: func(archives *MockArchiveRepository, store *MockStorage) {
+				archives.On("GetExpiredBefore", mock.Anything, before, 10).Return([]*domain.Archive{archiveA, archiveB}, nil)
+				store.On("Delete", mock.Anything, "base/a.zip").Return(nil)
+				store.On("Delete", mock.Anything, "base/b.zip").Return(nil)
+				archives.On("HardDelete", mock.Anything, []uuid.UUID{archiveA.ID, archiveB.ID}).Return(nil)
+			},
+			wantPurged: 2,
+		},
+		{
+			name: "keeps record when object delete fails",
+			setup: func(archives *MockArchiveRepository, store *MockStorage) {
+				archives.On("GetExpiredBefore", mock.Anything, before, 10).Return([]*domain.Archive{archiveA, archiveB}, nil)
+				store.On("Delete", mock.Anything, "base/a.zip").Return(errors.New("s3 error"))
+				store.On("Delete", mock.Anything, "base/b.zip").Return(nil)
+				archives.On("HardDelete", mock.Anything, []uuid.UUID{archiveB.ID}).Return(nil)
+			},
+			wantPurged: 1,
+		},
+		{
+			name: "purges documents with the archive",
+			setup: func(archives *MockArchiveRepository, store *MockStorage) {
+				archives.On("GetExpiredBefore", mock.Anything, before, 10).Return([]*domain.Archive{withDocument}, nil)
+				store.On("Delete", mock.Anything, "base/c.zip").Return(nil)
+				store.On("Delete", mock.Anything, "base/c/documents/export.json").Return(nil)
+				archives.On("HardDelete", mock.Anything, []uuid.UUID{withDocument.ID}).Return(nil)
+			},
+			wantPurged: 1,
+		},
+		{
+			name: "keeps record when document delete fails",
+			setup: func(archives *MockArchiveRepository, store *MockStorage) {
+				archives.On("GetExpiredBefore", mock.Anything, before, 10).Return([]*domain.Archive{withDocument}, nil)
+				store.On("Delete", mock.Anything, "base/c.zip").Return(nil)
+				store.On("Delete", mock.Anything, "base/c/documents/export.json").Return(errors.New("s3 error"))
+				archives.On("HardDelete", mock.Anything, []uuid.UUID{}).Return(nil)
+			},
+		},
+		{
+			name: "select error",
+			setup: func(archives *MockArchiveRepository, store *MockStorage) {
+				archives.On("GetExpiredBefore", mock.Anything, before, 10).Return(nil, dbError)
+			},
+			wantError: dbError,
+		},
+		{
+			name: "hard delete error",
+			setup: func(archives *MockArchiveRepository, store *MockStorage) {
+				archives.On("GetExpiredBefore", mock.Anything, before, 10).Return([]*domain.Archive{archiveA}, nil)
+				store.On("Delete", mock.Anything, "base/a.zip").Return(nil)
+				archives.On("HardDelete", mock.Anything, []uuid.UUID{archiveA.ID}).Return(dbError)
+			},
+			wantError: apperrors.ErrFileDeleteFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			archives := new(MockArchiveRepository)
+			store := new(MockStorage)
+			tt.setup(archives, store)
+			svc := services.NewArchiveService(archives, nil, store, nil)
+			purged, err := svc.PurgeExpiredArchives(ctx, before, 10)
+			if tt.wantError != nil {
+				require.ErrorIs(t, err, tt.wantError)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.wantPurged, purged)
+			}
+			archives.AssertExpectations(t)
+			store.AssertExpectations(t)
+		})
+	}
+}
@@ -2,12 +2,18 @@ package services
 
 import (
 	"context"
+	"log/slog"
+	"time"
 
 	"github.com/Ernestgio/Hangout-Planner/pkg/shared/enums"
+	"github.com/Ernestgio/Hangout-Planner/pkg/shared/eventbus"
+	eventspb "github.com/Ernestgio/Hangout-Planner/pkg/shared/proto/gen/go/events"
 	filepb "github.com/Ernestgio/Hangout-Planner/pkg/shared/proto/gen/go/file"
 	"github.com/Ernestgio/Hangout-Planner/services/file/internal/apperrors"
 	"github.com/Ernestgio/Hangout-Planner/services/file/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/file/internal/constants/logmsg"
 	"github.com/Ernestgio/Hangout-Planner/services/file/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/file/internal/logger"
 	"github.com/Ernestgio/Hangout-Planner/services/file/internal/mapper"
 	"github.com/Ernestgio/Hangout-Planner/services/file/internal/otel"
 	"github.com/Ernestgio/Hangout-Planner/services/file/internal/repository"
@@ -15,6 +21,7 @@ import (
 	"github.com/Ernestgio/Hangout-Planner/services/file/internal/validator"
 	"github.com/google/uuid"
 	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/protobuf/proto"
 	"gorm.io/gorm"
 )
 
@@ -24,6 +31,7 @@ type FileService interface {
 	GetFileByMemoryID(ctx context.Context, req *filepb.GetFileByMemoryIDRequest) (*filepb.GetFileByMemoryIDResponse, error)
 	GetFilesByMemoryIDs(ctx context.Context, req *filepb.GetFilesByMemoryIDsRequest) (*filepb.GetFilesByMemoryIDsResponse, error)
 	DeleteFile(ctx context.Context, req *filepb.DeleteFileRequest) (*filepb.DeleteFileResponse, error)
+	PurgeDeletedFiles(ctx context.Context, before time.Time, batchSize int) (int, error)
 }
 
 type fileService struct {
@@ -31,15 +39,17 @@ type fileService struct {
 	fileRepo      repository.MemoryFileRepository
 	storage       storage.Storage
 	fileValidator validator.FileValidator
+	events        eventbus.Publisher
 	metrics       *otel.MetricsRecorder
 }
 
-func NewFileService(db *gorm.DB, repo repository.MemoryFileRepository, storage storage.Storage, fileValidator validator.FileValidator, metrics *otel.MetricsRecorder) FileService {
+func NewFileService(db *gorm.DB, repo repository.MemoryFileRepository, storage storage.Storage, fileValidator validator.FileValidator, events eventbus.Publisher, metrics *otel.MetricsRecorder) FileService {
 	return &fileService{
 		db:            db,
 		fileRepo:      repo,
 		storage:       storage,
 		fileValidator: fileValidator,
+		events:        events,
 		metrics:       metrics,
 	}
 }
@@ -133,6 +143,8 @@ func (s *fileService) ConfirmUpload(ctx context.Context, req *filepb.ConfirmUplo
 		return nil, span.RecordErrorWithStatus(err)
 	}
 
+	s.publishUploadProcessed(ctx, fileIDs)
+
 	span.SetStatusOk()
 	return &filepb.ConfirmUploadResponse{
 		Success: true,
@@ -244,8 +256,10 @@ func (s *fileService) DeleteFile(ctx context.Context, req *filepb.DeleteFileRequ
 		return nil, span.RecordErrorWithStatus(apperrors.ErrInvalidMemoryID)
 	}
 
+	// The object is kept in storage until the retention window expires;
+	// PurgeDeletedFiles removes it together with the record.
 	err = s.db.Transaction(func(tx *gorm.DB) error {
-		if err := s.fileRepo.WithTx(tx).Delete(ctx, file.ID); err != nil {
+		if err := s.fileRepo.WithTx(tx).Delete(ctx, file.MemoryID); err != nil {
 			return apperrors.ErrFileDeleteFailed
 		}
 		return nil
@@ -256,10 +270,11 @@ func (s *fileService) DeleteFile(ctx context.Context, req *filepb.DeleteFileRequ
 		return nil, span.RecordErrorWithStatus(err)
 	}
 
-	if err := s.storage.Delete(ctx, file.StoragePath); err != nil {
-		recordMetrics(apperrors.ErrFileDeleteFailed)
-		return nil, span.RecordErrorWithStatus(apperrors.ErrFileDeleteFailed)
-	}
+	s.publishEvent(ctx, &eventspb.FileDeleted{
+		FileId:   file.ID.String(),
+		MemoryId: file.MemoryID.String(),
+		Reason:   eventspb.FileDeletionReason_FILE_DELETION_REASON_DELETED,
+	})
 
 	recordMetrics(nil)
 	span.SetAttributes(attribute.String("file.id", file.ID.String()))
@@ -268,3 +283,70 @@ func (s *fileService) DeleteFile(ctx context.Context, req *filepb.DeleteFileRequ
 		Success: true,
 	}, nil
 }
+
+func (s *fileService) PurgeDeletedFiles(ctx context.Context, before time.Time, batchSize int) (int, error) {
+	ctx, span := otel.StartServiceSpan(ctx, "PurgeDeletedFiles",
+		attribute.Int("batch.size", batchSize),
+	)
+	defer span.End()
+
+	recordMetrics := s.metrics.StartOperation(ctx, constants.MetricOpPurgeFiles)
+
+	files, err := s.fileRepo.GetDeletedBefore(ctx, before, batchSize)
+	if err != nil {
+		recordMetrics(err)
+		return 0, span.RecordErrorWithStatus(err)
+	}
+
+	purgedIDs := make([]uuid.UUID, 0, len(files))
+	purged := make([]*domain.MemoryFile, 0, len(files))
+	for _, file := range files {
+		if err := s.storage.Delete(ctx, file.StoragePath); err != nil {
+			// Keep the record so the object is retried on the next run.
+			continue
+		}
+		purgedIDs = append(purgedIDs, file.ID)
+		purged = append(purged, file)
+	}
+
+	if err := s.fileRepo.HardDelete(ctx, purgedIDs); err != nil {
+		recordMetrics(apperrors.ErrFileDeleteFailed)
+		return 0, span.RecordErrorWithStatus(apperrors.ErrFileDeleteFailed)
+	}
+
+	for _, file := range purged {
+		s.publishEvent(ctx, &eventspb.FileDeleted{
+			FileId:   file.ID.String(),
+			MemoryId: file.MemoryID.String(),
+			Reason:   eventspb.FileDeletionReason_FILE_DELETION_REASON_PURGED,
+		})
+	}
+
+	recordMetrics(nil)
+	span.SetAttributes(attribute.Int("files.purged", len(purgedIDs)))
+	span.SetStatusOk()
+	return len(purgedIDs), nil
+}
+
+// publishUploadProcessed announces confirmed uploads.
+func (s *fileService) publishUploadProcessed(ctx context.Context, fileIDs []uuid.UUID) {
+	files, err := s.fileRepo.GetByIDs(ctx, fileIDs)
+	if err != nil {
+		logger.Error(ctx, logmsg.EventPublishFailed, err, slog.Int("file.ids.count", len(fileIDs)))
+		return
+	}
+	for _, file := range files {
+		s.publishEvent(ctx, &eventspb.FileUploadProcessed{
+			FileId:   file.ID.String(),
+			MemoryId: file.MemoryID.String(),
+			FileSize: file.FileSize,
+			MimeType: file.MimeType,
+		})
+	}
+}
+
+func (s *fileService) publishEvent(ctx context.Context, payload proto.Message) {
+	if err := s.events.Publish(ctx, payload); err != nil {
+		logger.Error(ctx, logmsg.EventPublishFailed, err, slog.String("event.payload", string(payload.ProtoReflect().Descriptor().Name())))
+	}
+}
@@ -9,14 +9,17 @@ import (
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/Ernestgio/Hangout-Planner/pkg/shared/enums"
+	eventspb "github.com/Ernestgio/Hangout-Planner/pkg/shared/proto/gen/go/events"
 	filepb "github.com/Ernestgio/Hangout-Planner/pkg/shared/proto/gen/go/file"
 	"github.com/Ernestgio/Hangout-Planner/services/file/internal/apperrors"
 	"github.com/Ernestgio/Hangout-Planner/services/file/internal/domain"
 	"github.com/Ernestgio/Hangout-Planner/services/file/internal/repository"
 	"github.com/Ernestgio/Hangout-Planner/services/file/internal/services"
+	"github.com/Ernestgio/Hangout-Planner/services/file/internal/storage"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
 )
@@ -75,6 +78,14 @@ func (m *MockMemoryFileRepository) GetByMemoryIDs(ctx context.Context, memoryIDs
 	return args.Get(0).([]*domain.MemoryFile), args.Error(1)
 }
 
+func (m *MockMemoryFileRepository) GetByIDs(ctx context.Context, fileIDs []uuid.UUID) ([]*domain.MemoryFile, error) {
+	args := m.Called(ctx, fileIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.MemoryFile), args.Error(1)
+}
+
 func (m *MockMemoryFileRepository) UpdateStatusBatch(ctx context.Context, fileIDs []uuid.UUID, status string) error {
 	args := m.Called(ctx, fileIDs, status)
 	return args.Error(0)
@@ -85,6 +96,19 @@ func (m *MockMemoryFileRepository) Delete(ctx context.Context, memoryID uuid.UUI
 	return args.Error(0)
 }
 
+func (m *MockMemoryFileRepository) GetDeletedBefore(ctx context.Context, before time.Time, limit int) ([]*domain.MemoryFile, error) {
+	args := m.Called(ctx, before, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.MemoryFile), args.Error(1)
+}
+
+func (m *MockMemoryFileRepository) HardDelete(ctx context.Context, fileIDs []uuid.UUID) error {
+	args := m.Called(ctx, fileIDs)
+	return args.Error(0)
+}
+
 type MockStorage struct {
 	mock.Mock
 }
@@ -114,6 +138,22 @@ func (m *MockStorage) GetPresignedURLExpiry() time.Duration {
 	return args.Get(0).(time.Duration)
 }
 
+func (m *MockStorage) Download(ctx context.Context, path string) (io.ReadCloser, error) {
+	args := m.Called(ctx, path)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(io.ReadCloser), args.Error(1)
+}
+
+func (m *MockStorage) NewWriter(ctx context.Context, path string, contentType string) (storage.ObjectWriter, error) {
+	args := m.Called(ctx, path, contentType)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(storage.ObjectWriter), args.Error(1)
+}
+
 type MockFileValidator struct {
 	mock.Mock
 }
@@ -133,6 +173,19 @@ func (m *MockFileValidator) IsExtensionAllowed(extension string) bool {
 	return args.Bool(0)
 }
 
+type MockEventPublisher struct {
+	mock.Mock
+}
+
+func (m *MockEventPublisher) Publish(ctx context.Context, payload proto.Message) error {
+	args := m.Called(ctx, payload)
+	return args.Error(0)
+}
+
+func eventPayload(want proto.Message) interface{} {
+	return mock.MatchedBy(func(got proto.Message) bool { return proto.Equal(got, want) })
+}
+
 func TestFileService_GenerateUploadURLs(t *testing.T) {
 	ctx := context.Background()
 	memoryID := uuid.New()
@@ -237,7 +290,7 @@ func TestFileService_GenerateUploadURLs(t *testing.T) {
 			store := new(MockStorage)
 			val := new(MockFileValidator)
 			tt.setup(repo, store, val, sqlMock)
-			svc := services.NewFileService(db, repo, store, val, nil)
+			svc := services.NewFileService(db, repo, store, val, new(MockEventPublisher), nil)
 			resp, err := svc.GenerateUploadURLs(ctx, tt.req)
 			if tt.wantError != nil {
 				require.Error(t, err)
@@ -258,12 +311,15 @@ func TestFileService_GenerateUploadURLs(t *testing.T) {
 func TestFileService_ConfirmUpload(t *testing.T) {
 	ctx := context.Background()
 	fileID := uuid.New()
+	memoryID := uuid.New()
 	dbError := errors.New("db error")
+	uploaded := &domain.MemoryFile{ID: fileID, MemoryID: memoryID, FileSize: 1024, MimeType: "image/png"}
+	processed := &eventspb.FileUploadProcessed{FileId: fileID.String(), MemoryId: memoryID.String(), FileSize: 1024, MimeType: "image/png"}
 
 	tests := []struct {
 		name      string
 		req       *filepb.ConfirmUploadRequest
-		setup     func(*MockMemoryFileRepository, sqlmock.Sqlmock)
+		setup     func(*MockMemoryFileRepository, *MockEventPublisher, sqlmock.Sqlmock)
 		wantError error
 	}{
 		{
@@ -271,11 +327,26 @@ func TestFileService_ConfirmUpload(t *testing.T) {
 			req: &filepb.ConfirmUploadRequest{
 				FileIds: []string{fileID.String()},
 			},
-			setup: func(repo *MockMemoryFileRepository, sqlMock sqlmock.Sqlmock) {
+			setup: func(repo *MockMemoryFileRepository, events *MockEventPublisher, sqlMock sqlmock.Sqlmock) {
 				sqlMock.ExpectBegin()
 				repo.On("WithTx", mock.Anything).Return(repo)
 				repo.On("UpdateStatusBatch", mock.Anything, []uuid.UUID{fileID}, string(enums.FileUploadStatusUploaded)).Return(nil)
 				sqlMock.ExpectCommit()
+				repo.On("GetByIDs", mock.Anything, []uuid.UUID{fileID}).Return([]*domain.MemoryFile{uploaded}, nil)
+				events.On("Publish", mock.Anything, eventPayload(processed)).Return(nil)
+			},
+		},
+		{
+			name: "publish failure does not fail confirm",
+			req: &filepb.ConfirmUploadRequest{
+				FileIds: []string{fileID.String()},
+			},
+			setup: func(repo *MockMemoryFileRepository, events *MockEventPublisher, sqlMock sqlmock.Sqlmock) {
+				sqlMock.ExpectBegin()
+				repo.On("WithTx", mock.Anything).Return(repo)
+				repo.On("UpdateStatusBatch", mock.Anything, []uuid.UUID{fileID}, string(enums.FileUploadStatusUploaded)).Return(nil)
+				sqlMock.ExpectCommit()
+				repo.On("GetByIDs", mock.Anything, []uuid.UUID{fileID}).Return(nil, dbError)
 			},
 		},
 		{
@@ -283,7 +354,7 @@ func TestFileService_ConfirmUpload(t *testing.T) {
 			req: &filepb.ConfirmUploadRequest{
 				FileIds: []string{"invalid-uuid"},
 			},
-			setup:     func(repo *MockMemoryFileRepository, sqlMock sqlmock.Sqlmock) {},
+			setup:     func(repo *MockMemoryFileRepository, events *MockEventPublisher, sqlMock sqlmock.Sqlmock) {},
 			wantError: apperrors.ErrInvalidMemoryID,
 		},
 		{
@@ -291,7 +362,7 @@ func TestFileService_ConfirmUpload(t *testing.T) {
 			req: &filepb.ConfirmUploadRequest{
 				FileIds: []string{fileID.String()},
 			},
-			setup: func(repo *MockMemoryFileRepository, sqlMock sqlmock.Sqlmock) {
+			setup: func(repo *MockMemoryFileRepository, events *MockEventPublisher, sqlMock sqlmock.Sqlmock) {
 				sqlMock.ExpectBegin()
 				repo.On("WithTx", mock.Anything).Return(repo)
 				repo.On("UpdateStatusBatch", mock.Anything, []uuid.UUID{fileID}, string(enums.FileUploadStatusUploaded)).Return(dbError)
@@ -305,8 +376,9 @@ func TestFileService_ConfirmUpload(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			db, sqlMock := setupDB(t)
 			repo := new(MockMemoryFileRepository)
-			tt.setup(repo, sqlMock)
-			svc := services.NewFileService(db, repo, nil, nil, nil)
+			events := new(MockEventPublisher)
+			tt.setup(repo, events, sqlMock)
+			svc := services.NewFileService(db, repo, nil, nil, events, nil)
 			resp, err := svc.ConfirmUpload(ctx, tt.req)
 			if tt.wantError != nil {
 				require.Error(t, err)
@@ -318,6 +390,7 @@ func TestFileService_ConfirmUpload(t *testing.T) {
 				require.True(t, resp.Success)
 			}
 			repo.AssertExpectations(t)
+			events.AssertExpectations(t)
 		})
 	}
 }
@@ -394,7 +467,7 @@ func TestFileService_GetFileByMemoryID(t *testing.T) {
 			repo := new(MockMemoryFileRepository)
 			store := new(MockStorage)
 			tt.setup(repo, store)
-			svc := services.NewFileService(db, repo, store, nil, nil)
+			svc := services.NewFileService(db, repo, store, nil, new(MockEventPublisher), nil)
 			resp, err := svc.GetFileByMemoryID(ctx, tt.req)
 			if tt.wantError != nil {
 				require.Error(t, err)
@@ -479,7 +552,7 @@ func TestFileService_GetFilesByMemoryIDs(t *testing.T) {
 			repo := new(MockMemoryFileRepository)
 			store := new(MockStorage)
 			tt.setup(repo, store)
-			svc := services.NewFileService(db, repo, store, nil, nil)
+			svc := services.NewFileService(db, repo, store, nil, new(MockEventPublisher), nil)
 			resp, err := svc.GetFilesByMemoryIDs(ctx, tt.req)
 			if tt.wantError != nil {
 				require.Error(t, err)
@@ -505,7 +578,7 @@ func TestFileService_DeleteFile(t *testing.T) {
 	tests := []struct {
 		name      string
 		req       *filepb.DeleteFileRequest
-		setup     func(*MockMemoryFileRepository, *MockStorage, sqlmock.Sqlmock)
+		setup     func(*MockMemoryFileRepository, *MockEventPublisher, sqlmock.Sqlmock)
 		wantError error
 	}{
 		{
@@ -513,7 +586,7 @@ func TestFileService_DeleteFile(t *testing.T) {
 			req: &filepb.DeleteFileRequest{
 				MemoryId: memoryID.String(),
 			},
-			setup: func(repo *MockMemoryFileRepository, store *MockStorage, sqlMock sqlmock.Sqlmock) {
+			setup: func(repo *MockMemoryFileRepository, events *MockEventPublisher, sqlMock sqlmock.Sqlmock) {
 				repo.On("GetByMemoryID", mock.Anything, memoryID).Return(&domain.MemoryFile{
 					ID:          fileID,
 					MemoryID:    memoryID,
@@ -521,9 +594,13 @@ func TestFileService_DeleteFile(t *testing.T) {
 				}, nil)
 				sqlMock.ExpectBegin()
 				repo.On("WithTx", mock.Anything).Return(repo)
-				repo.On("Delete", mock.Anything, fileID).Return(nil)
+				repo.On("Delete", mock.Anything, memoryID).Return(nil)
 				sqlMock.ExpectCommit()
-				store.On("Delete", mock.Anything, "path/photo.jpg").Return(nil)
+				events.On("Publish", mock.Anything, eventPayload(&eventspb.FileDeleted{
+					FileId:   fileID.String(),
+					MemoryId: memoryID.String(),
+					Reason:   eventspb.FileDeletionReason_FILE_DELETION_REASON_DELETED,
+				})).Return(nil)
 			},
 		},
 		{
@@ -531,7 +608,7 @@ func TestFileService_DeleteFile(t *testing.T) {
 			req: &filepb.DeleteFileRequest{
 				MemoryId: "invalid-uuid",
 			},
-			setup:     func(repo *MockMemoryFileRepository, store *MockStorage, sqlMock sqlmock.Sqlmock) {},
+			setup:     func(repo *MockMemoryFileRepository, events *MockEventPublisher, sqlMock sqlmock.Sqlmock) {},
 			wantError: apperrors.ErrInvalidMemoryID,
 		},
 		{
@@ -539,7 +616,7 @@ func TestFileService_DeleteFile(t *testing.T) {
 			req: &filepb.DeleteFileRequest{
 				MemoryId: memoryID.String(),
 			},
-			setup: func(repo *MockMemoryFileRepository, store *MockStorage, sqlMock sqlmock.Sqlmock) {
+			setup: func(repo *MockMemoryFileRepository, events *MockEventPublisher, sqlMock sqlmock.Sqlmock) {
 				repo.On("GetByMemoryID", mock.Anything, memoryID).Return(nil, gorm.ErrRecordNotFound)
 			},
 			wantError: apperrors.ErrInvalidMemoryID,
@@ -549,7 +626,7 @@ func TestFileService_DeleteFile(t *testing.T) {
 			req: &filepb.DeleteFileRequest{
 				MemoryId: memoryID.String(),
 			},
-			setup: func(repo *MockMemoryFileRepository, store *MockStorage, sqlMock sqlmock.Sqlmock) {
+			setup: func(repo *MockMemoryFileRepository, events *MockEventPublisher, sqlMock sqlmock.Sqlmock) {
 				repo.On("GetByMemoryID", mock.Anything, memoryID).Return(&domain.MemoryFile{
 					ID:          fileID,
 					MemoryID:    memoryID,
@@ -557,27 +634,100 @@ func TestFileService_DeleteFile(t *testing.T) {
 				}, nil)
 				sqlMock.ExpectBegin()
 				repo.On("WithTx", mock.Anything).Return(repo)
-				repo.On("Delete", mock.Anything, fileID).Return(dbError)
+				repo.On("Delete", mock.Anything, memoryID).Return(dbError)
 				sqlMock.ExpectRollback()
 			},
 			wantError: apperrors.ErrFileDeleteFailed,
 		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, sqlMock := setupDB(t)
+			repo := new(MockMemoryFileRepository)
+			events := new(MockEventPublisher)
+			tt.setup(repo, events, sqlMock)
+			svc := services.NewFileService(db, repo, nil, nil, events, nil)
+			resp, err := svc.DeleteFile(ctx, tt.req)
+			if tt.wantError != nil {
+				require.Error(t, err)
+				require.ErrorIs(t, err, tt.wantError)
+				require.Nil(t, resp)
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, resp)
+				require.True(t, resp.Success)
+			}
+			repo.AssertExpectations(t)
+			events.AssertExpectations(t)
+		})
+	}
+}
+
+func TestFileService_PurgeDeletedFiles(t *testing.T) {
+	ctx := context.Background()
+	before := time.Now()
+	fileA := &domain.MemoryFile{ID: uuid.New(), MemoryID: uuid.New(), StoragePath: "path/a.jpg"}
+	fileB := &domain.MemoryFile{ID: uuid.New(), MemoryID: uuid.New(), StoragePath: "path/b.jpg"}
+	purgedEvent := func(file *domain.MemoryFile) interface{} {
+		return eventPayload(&eventspb.FileDeleted{
+			FileId:   file.ID.String(),
+			MemoryId: file.MemoryID.String(),
+			Reason:   eventspb.FileDeletionReason_FILE_DELETION_REASON_PURGED,
+		})
+	}
+	dbError := errors.New("db error")
+
+	tests := []struct {
+		name       string
+		setup      func(*MockMemoryFileRepository, *MockStorage, *MockEventPublisher)
+		wantPurged int
+		wantError  error
+	}{
+		{
+			name: "purges objects and records",
+			setup: func(repo *MockMemoryFileRepository, store *MockStorage, events *MockEventPublisher) {
+				repo.On("GetDeletedBefore", mock.Anything, before, 10).Return([]*domain.MemoryFile{fileA, fileB}, nil)
+				store.On("Delete", mock.Anything, "path/a.jpg").Return(nil)
+				store.On("Delete", mock.Anything, "path/b.jpg").Return(nil)
+				repo.On("HardDelete", mock.Anything, []uuid.UUID{fileA.ID, fileB.ID}).Return(nil)
+				events.On("Publish", mock.Anything, purgedEvent(fileA)).Return(nil)
+				events.On("Publish", mock.Anything, purgedEvent(fileB)).Return(errors.New("bus error"))
+			},
+			wantPurged: 2,
+		},
 		{
-			name: "delete from storage error",
-			req: &filepb.DeleteFileRequest{
-				MemoryId: memoryID.String(),
+			name: "keeps record when object delete fails",
+			setup: func(repo *MockMemoryFileRepository, store *MockStorage, events *MockEventPublisher) {
+				repo.On("GetDeletedBefore", mock.Anything, before, 10).Return([]*domain.MemoryFile{fileA, fileB}, nil)
+				store.On("Delete", mock.Anything, "path/a.jpg").Return(errors.New("s3 error"))
+				store.On("Delete", mock.Anything, "path/b.jpg").Return(nil)
+				repo.On("HardDelete", mock.Anything, []uuid.UUID{fileB.ID}).Return(nil)
+				events.On("Publish", mock.Anything, purgedEvent(fileB)).Return(nil)
+			},
+			wantPurged: 1,
+		},
+		{
+			name: "nothing to purge",
+			setup: func(repo *MockMemoryFileRepository, store *MockStorage, events *MockEventPublisher) {
+				repo.On("GetDeletedBefore", mock.Anything, before, 10).Return([]*domain.MemoryFile{}, nil)
+				repo.On("HardDelete", mock.Anything, []uuid.UUID{}).Return(nil)
 			},
-			setup: func(repo *MockMemoryFileRepository, store *MockStorage, sqlMock sqlmock.Sqlmock) {
-				repo.On("GetByMemoryID", mock.Anything, memoryID).Return(&domain.MemoryFile{
-					ID:          fileID,
-					MemoryID:    memoryID,
-					StoragePath: "path/photo.jpg",
-				}, nil)
-				sqlMock.ExpectBegin()
-				repo.On("WithTx", mock.Anything).Return(repo)
-				repo.On("Delete", mock.Anything, fileID).Return(nil)
-				sqlMock.ExpectCommit()
-				store.On("Delete", mock.Anything, "path/photo.jpg").Return(dbError)
+			wantPurged: 0,
+		},
+		{
+			name: "select error",
+			setup: func(repo *MockMemoryFileRepository, store *MockStorage, events *MockEventPublisher) {
+				repo.On("GetDeletedBefore", mock.Anything, before, 10).Return(nil, dbError)
+			},
+			wantError: dbError,
+		},
+		{
+			name: "hard delete error",
+			setup: func(repo *MockMemoryFileRepository, store *MockStorage, events *MockEventPublisher) {
+				repo.On("GetDeletedBefore", mock.Anything, before, 10).Return([]*domain.MemoryFile{fileA}, nil)
+				store.On("Delete", mock.Anything, "path/a.jpg").Return(nil)
+				repo.On("HardDelete", mock.Anything, []uuid.UUID{fileA.ID}).Return(dbError)
 			},
 			wantError: apperrors.ErrFileDeleteFailed,
 		},
@@ -585,23 +735,23 @@ func TestFileService_DeleteFile(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			db, sqlMock := setupDB(t)
+			db, _ := setupDB(t)
 			repo := new(MockMemoryFileRepository)
 			store := new(MockStorage)
-			tt.setup(repo, store, sqlMock)
-			svc := services.NewFileService(db, repo, store, nil, nil)
-			resp, err := svc.DeleteFile(ctx, tt.req)
+			events := new(MockEventPublisher)
+			tt.setup(repo, store, events)
+			svc := services.NewFileService(db, repo, store, nil, events, nil)
+			purged, err := svc.PurgeDeletedFiles(ctx, before, 10)
 			if tt.wantError != nil {
 				require.Error(t, err)
 				require.ErrorIs(t, err, tt.wantError)
-				require.Nil(t, resp)
 			} else {
 				require.NoError(t, err)
-				require.NotNil(t, resp)
-				require.True(t, resp.Success)
+				require.Equal(t, tt.wantPurged, purged)
 			}
 			repo.AssertExpectations(t)
 			store.AssertExpectations(t)
+			events.AssertExpectations(t)
 		})
 	}
 }
@@ -5,6 +5,7 @@ import (
 	"context"
 	"crypto/md5"
 	"encoding/base64"
+	"errors"
 	"io"
 	"time"
 
@@ -100,6 +101,25 @@ func (s *S3Client) Delete(ctx context.Context, path string) error {
 	return nil
 }
 
+// Download opens the object for reading. The caller must close the reader.
+func (s *S3Client) Download(ctx context.Context, path string) (io.ReadCloser, error) {
+	start := time.Now()
+	out, err := s.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucketName),
+		Key:    aws.String(path),
+	})
+	s.metrics.RecordS3Operation(ctx, constants.MetricS3OpDownload, time.Since(start))
+	if err != nil {
+		var noSuchKey *types.NoSuchKey
+		if errors.As(err, &noSuchKey) {
+			return nil, apperrors.ErrStorageObjectNotFound
+		}
+		return nil, apperrors.ErrFileDownloadFailed
+	}
+
+	return out.Body, nil
+}
+
 func (s *S3Client) GeneratePresignedDownloadURL(ctx context.Context, path string) (string, error) {
 	start := time.Now()
 	presignClient := s.newPresignClient()
@@ -0,0 +1,125 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/file/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/file/internal/constants"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// s3Writer uploads an object as a multipart upload, sending a part each time the
+// buffer reaches constants.ArchivePartSize.
+type s3Writer struct {
+	ctx      context.Context
+	client   *S3Client
+	path     string
+	uploadID *string
+	buf      bytes.Buffer
+	parts    []types.CompletedPart
+	start    time.Time
+	closed   bool
+}
+
+// NewWriter starts a multipart upload for the object at path.
+func (s *S3Client) NewWriter(ctx context.Context, path string, contentType string) (ObjectWriter, error) {
+	out, err := s.client.CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{
+		Bucket:               aws.String(s.bucketName),
+		Key:                  aws.String(path),
+		ContentType:          aws.String(contentType),
+		ServerSideEncryption: types.ServerSideEncryptionAes256,
+	})
+	if err != nil {
+		return nil, apperrors.ErrFileUploadFailed
+	}
+
+	return &s3Writer{
+		ctx:      ctx,
+		client:   s,
+		path:     path,
+		uploadID: out.UploadId,
+		start:    time.Now(),
+	}, nil
+}
+
+func (w *s3Writer) Write(p []byte) (int, error) {
+	if w.closed {
+		return 0, apperrors.ErrFileUploadFailed
+	}
+
+	n, _ := w.buf.Write(p)
+	for w.buf.Len() >= constants.ArchivePartSize {
+		if err := w.uploadPart(w.buf.Next(constants.ArchivePartSize)); err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+// Close uploads the remaining buffer as the last part and completes the upload.
+func (w *s3Writer) Close() error {
+	if w.closed {
+		return nil
+	}
+
+	if w.buf.Len() > 0 || len(w.parts) == 0 {
+		if err := w.uploadPart(w.buf.Bytes()); err != nil {
+			return err
+		}
+		w.buf.Reset()
+	}
+
+	w.closed = true
+	_, err := w.client.client.CompleteMultipartUpload(w.ctx, &s3.CompleteMultipartUploadInput{
+		Bucket:          aws.String(w.client.bucketName),
+		Key:             aws.String(w.path),
+		UploadId:        w.uploadID,
+		MultipartUpload: &types.CompletedMultipartUpload{Parts: w.parts},
+	})
+	w.client.metrics.RecordS3Operation(w.ctx, constants.MetricS3OpMultipartUpload, time.Since(w.start))
+	if err != nil {
+		return apperrors.ErrFileUploadFailed
+	}
+	return nil
+}
+
+// Abort discards the parts uploaded so far. It is a no-op after Close.
+func (w *s3Writer) Abort() error {
+	if w.closed {
+		return nil
+	}
+
+	w.closed = true
+	// The build may be aborted because its context was cancelled; the parts
+	// still need to be released.
+	_, err := w.client.client.AbortMultipartUpload(context.WithoutCancel(w.ctx), &s3.AbortMultipartUploadInput{
+		Bucket:   aws.String(w.client.bucketName),
+		Key:      aws.String(w.path),
+		UploadId: w.uploadID,
+	})
+	if err != nil {
+		return apperrors.ErrFileDeleteFailed
+	}
+	return nil
+}
+
+func (w *s3Writer) uploadPart(data []byte) error {
+	partNumber := aws.Int32(int32(len(w.parts) + 1))
+	out, err := w.client.client.UploadPart(w.ctx, &s3.UploadPartInput{
+		Bucket:     aws.String(w.client.bucketName),
+		Key:        aws.String(w.path),
+		UploadId:   w.uploadID,
+		PartNumber: partNumber,
+		Body:       bytes.NewReader(data),
+	})
+	if err != nil {
+		return apperrors.ErrFileUploadFailed
+	}
+
+	w.parts = append(w.parts, types.CompletedPart{ETag: out.ETag, PartNumber: partNumber})
+	return nil
+}
@@ -12,4 +12,12 @@ type Storage interface {
 	GeneratePresignedDownloadURL(ctx context.Context, path string) (string, error)
 	GeneratePresignedUploadURL(ctx context.Context, path string, contentType string) (string, error)
 	GetPresignedURLExpiry() time.Duration
+	Download(ctx context.Context, path string) (io.ReadCloser, error)
+	NewWriter(ctx context.Context, path string, contentType string) (ObjectWriter, error)
+}
+
+// ObjectWriter streams an object of unknown size into storage.
+type ObjectWriter interface {
+	io.WriteCloser
+	Abort() error
 }
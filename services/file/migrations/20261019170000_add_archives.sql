@@ -0,0 +1,27 @@
+-- Create "archives" table
+CREATE TABLE `archives` (
+  `id` char(36) NOT NULL,
+  `base_storage_path` varchar(500) NOT NULL,
+  `storage_path` varchar(500) NOT NULL,
+  `status` varchar(50) NOT NULL,
+  `total_files` bigint NOT NULL,
+  `processed_files` bigint NOT NULL DEFAULT 0,
+  `archive_size` bigint NOT NULL DEFAULT 0,
+  `error_message` varchar(500) NULL,
+  `created_at` datetime(3) NULL,
+  `updated_at` datetime(3) NULL,
+  `completed_at` datetime(3) NULL,
+  `expires_at` datetime(3) NULL,
+  PRIMARY KEY (`id`),
+  INDEX `idx_archives_base_storage_path` (`base_storage_path`),
+  INDEX `idx_archives_expires_at` (`expires_at`),
+  INDEX `idx_archives_status_updated_at` (`status`, `updated_at`)
+) CHARSET utf8mb4 COLLATE utf8mb4_0900_ai_ci;
+-- Create "archive_entries" table
+CREATE TABLE `archive_entries` (
+  `archive_id` char(36) NOT NULL,
+  `file_id` char(36) NOT NULL,
+  `entry_name` varchar(255) NOT NULL,
+  PRIMARY KEY (`archive_id`, `file_id`),
+  CONSTRAINT `fk_archives_entries` FOREIGN KEY (`archive_id`) REFERENCES `archives` (`id`) ON UPDATE NO ACTION ON DELETE NO ACTION
+) CHARSET utf8mb4 COLLATE utf8mb4_0900_ai_ci;
@@ -0,0 +1,8 @@
+-- Create "archive_documents" table
+CREATE TABLE `archive_documents` (
+  `archive_id` char(36) NOT NULL,
+  `entry_name` varchar(255) NOT NULL,
+  `storage_path` varchar(500) NOT NULL,
+  PRIMARY KEY (`archive_id`, `entry_name`),
+  CONSTRAINT `fk_archives_documents` FOREIGN KEY (`archive_id`) REFERENCES `archives` (`id`) ON UPDATE NO ACTION ON DELETE NO ACTION
+) CHARSET utf8mb4 COLLATE utf8mb4_0900_ai_ci;
@@ -0,0 +1,2 @@
+-- Replace the causes stored for earlier failed builds with the fixed message
+UPDATE `archives` SET `error_message` = "archive could not be built, request a new one" WHERE `error_message` IS NOT NULL;
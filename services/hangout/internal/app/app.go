@@ -10,21 +10,31 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/Ernestgio/Hangout-Planner/pkg/shared/eventbus"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/config"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants/logmsg"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/db"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domainevents"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/grpc"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/handlers"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/http/response"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/http/validator"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/jobs"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/logger"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/mailer"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/middlewares"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/notify"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/oidc"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/otel"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/pubsub"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/ratelimit"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/repository"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/router"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/services"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/signing"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/utils"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/webhook"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	"golang.org/x/crypto/bcrypt"
@@ -35,6 +45,17 @@ type App struct {
 	server       *echo.Echo
 	db           *gorm.DB
 	fileClient   grpc.FileService
+	purgeJob     *jobs.TrashPurgeJob
+	cleanupJob   *jobs.IdempotencyCleanupJob
+	sessionJob   *jobs.SessionCleanupJob
+	webhookJob   *jobs.WebhookDeliveryJob
+	reminderJob  *jobs.ReminderJob
+	exportJob    *jobs.DataExportJob
+	signingJob   *jobs.SigningKeyRotationJob
+	broker       pubsub.Broker
+	eventBus     *eventbus.Bus
+	rateLimits   ratelimit.Store
+	fileEvents   *domainevents.FileEventConsumer
 	closer       func() error
 	cfg          *config.Config
 	tracerCloser func(context.Context) error
@@ -125,29 +146,195 @@ func NewApp(ctx context.Context, cfg *config.Config) (app *App, err error) {
 	}()
 	log.Printf(logmsg.FileServiceClientInitialized, cfg.GRPCClientConfig.FileServiceURL)
 
+	// Event bus shared with the other services
+	transport, err := eventbus.NewTransport(eventbus.Config{
+		Driver:        cfg.EventBusConfig.Driver,
+		BufferSize:    cfg.EventBusConfig.BufferSize,
+		RedisAddr:     cfg.RedisConfig.Addr,
+		RedisPassword: cfg.RedisConfig.Password,
+		RedisDB:       cfg.RedisConfig.DB,
+		StreamMaxLen:  int64(cfg.EventBusConfig.StreamMaxLen),
+	}, func(msg eventbus.Message, err error) {
+		log.Printf(logmsg.EventHandlerFailed, msg.Subject, err)
+	})
+	if err != nil {
+		log.Printf(logmsg.EventBusInitFailed, err)
+		return nil, err
+	}
+	eventBus := eventbus.NewBus(transport, constants.EventBusSourceName)
+	if cfg.EventBusConfig.Driver == eventbus.DriverMemory {
+		log.Println(logmsg.EventBusInProcessOnly)
+	}
+	defer func() {
+		if err != nil {
+			if closeErr := eventBus.Close(); closeErr != nil {
+				log.Printf(logmsg.EventBusCloseFailed, closeErr)
+			}
+		}
+	}()
+
+	// Auth rate limits
+	rateLimitStore, err := ratelimit.NewStore(cfg.AuthRateLimit, cfg.RedisConfig)
+	if err != nil {
+		log.Printf(logmsg.RateLimitStoreInitFailed, err)
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			if closeErr := rateLimitStore.Close(); closeErr != nil {
+				log.Printf(logmsg.RateLimitStoreCloseFailed, closeErr)
+			}
+		}
+	}()
+	authGuard := ratelimit.NewGuard(rateLimitStore, cfg.AuthRateLimit)
+
 	// Initialize utils
 	responseBuilder := response.NewBuilder(cfg.Env == constants.ProductionEnv)
-	jwtUtils := utils.NewJWTUtils(cfg.JwtConfig)
+	keyRing := signing.NewKeyRing()
+	jwtUtils := utils.NewJWTUtils(cfg.JwtConfig, keyRing)
 	bcryptUtils := utils.NewBcryptUtils(bcrypt.DefaultCost)
+	passwordPolicy := utils.NewPasswordPolicy(cfg.PasswordConfig)
+	actionTokens := utils.NewActionTokenUtils(cfg.JwtConfig)
+	mailSender, err := mailer.NewSender(cfg.MailConfig, cfg.SMTPConfig)
+	if err != nil {
+		log.Printf(logmsg.MailSenderInitFailed, err)
+		return nil, err
+	}
 
 	// Repository Layer
 	userRepo := repository.NewUserRepository(dbConn, metricsRecorder)
 	hangoutRepo := repository.NewHangoutRepository(dbConn, metricsRecorder)
 	activityRepo := repository.NewActivityRepository(dbConn, metricsRecorder)
 	memoryRepo := repository.NewMemoryRepository(dbConn, metricsRecorder)
+	idempotencyRepo := repository.NewIdempotencyKeyRepository(dbConn, metricsRecorder)
+	webhookRepo := repository.NewWebhookRepository(dbConn, metricsRecorder)
+	reminderRepo := repository.NewReminderRepository(dbConn, metricsRecorder)
+	notificationRepo := repository.NewNotificationRepository(dbConn, metricsRecorder)
+	commentRepo := repository.NewCommentRepository(dbConn, metricsRecorder)
+	albumRepo := repository.NewAlbumRepository(dbConn, metricsRecorder)
+	shareLinkRepo := repository.NewShareLinkRepository(dbConn, metricsRecorder)
+	signingKeyRepo := repository.NewSigningKeyRepository(dbConn, metricsRecorder)
+	identityRepo := repository.NewUserIdentityRepository(dbConn, metricsRecorder)
+	mfaRepo := repository.NewMFARepository(dbConn, metricsRecorder)
+	sessionRepo := repository.NewSessionRepository(dbConn, metricsRecorder)
+	tokenRepo := repository.NewPersonalAccessTokenRepository(dbConn, metricsRecorder)
+	exportRepo := repository.NewDataExportRepository(dbConn, metricsRecorder)
+	auditRepo := repository.NewAdminAuditLogRepository(dbConn, metricsRecorder)
 
 	// Service Layer
+	sealer, err := signing.NewSealer(cfg.JwtConfig.JWTSecret, constants.SigningKeySealPurpose)
+	if err != nil {
+		log.Printf(logmsg.SigningKeyInitFailed, err)
+		return nil, err
+	}
+	signingKeyService := services.NewSigningKeyService(signingKeyRepo, keyRing, sealer, cfg.JwtConfig, metricsRecorder)
+	// load the key ring before serving, otherwise no token can be signed or verified
+	if _, err = signingKeyService.Rotate(ctx); err != nil {
+		log.Printf(logmsg.SigningKeyInitFailed, err)
+		return nil, err
+	}
+
+	totpSealer, err := signing.NewSealer(cfg.JwtConfig.JWTSecret, constants.TOTPSecretSealPurpose)
+	if err != nil {
+		log.Printf(logmsg.MFAInitFailed, err)
+		return nil, err
+	}
+
+	// External sign in providers; the PKCE verifier and nonce travel in a sealed state
+	oidcRegistry, err := oidc.NewRegistry(cfg.OIDCConfig)
+	if err != nil {
+		log.Printf(logmsg.OIDCInitFailed, err)
+		return nil, err
+	}
+	stateSealer, err := signing.NewSealer(cfg.JwtConfig.JWTSecret, constants.OIDCStateSealPurpose)
+	if err != nil {
+		log.Printf(logmsg.OIDCInitFailed, err)
+		return nil, err
+	}
+	oidcStates := oidc.NewStateCodec(stateSealer, cfg.OIDCConfig.GetStateTTL())
+
+	webhookSender := webhook.NewSender(cfg.WebhookConfig.GetRequestTimeout(), cfg.WebhookConfig.AllowPrivateTargets)
+	webhookService := services.NewWebhookService(webhookRepo, webhookSender, cfg.WebhookConfig, metricsRecorder)
+
+	// Notification channels; reminders use the configured subset, event
+	// notifications queue their emails for the reminder job
+	emailChannel := notify.NewEmailChannel(mailSender)
+	emailQueueChannel := notify.NewEmailQueueChannel(notificationRepo)
+	inboxChannel := notify.NewInboxChannel(notificationRepo)
+	reminderChannels, err := notify.SelectChannels(cfg.ReminderConfig.Channels, emailChannel, inboxChannel)
+	if err != nil {
+		log.Printf(logmsg.ReminderChannelsInvalid, err)
+		return nil, err
+	}
+	notificationService := services.NewNotificationService(notificationRepo, hangoutRepo, userRepo, []notify.Channel{emailQueueChannel, inboxChannel}, metricsRecorder)
+
+	// Hangout events go to open event streams, webhook subscriptions, the inbox and the event bus
+	broker := pubsub.NewInMemoryBroker(cfg.EventsConfig.SubscriberBufferSize)
+	events := pubsub.NewFanoutPublisher(broker, webhookService, notificationService, domainevents.NewBusPublisher(eventBus))
+
 	userService := services.NewUserService(dbConn, userRepo, bcryptUtils, metricsRecorder)
-	authService := services.NewAuthService(userService, jwtUtils, bcryptUtils, metricsRecorder)
-	hangoutService := services.NewHangoutService(dbConn, hangoutRepo, activityRepo, metricsRecorder)
+	sessionService := services.NewSessionService(sessionRepo, jwtUtils, cfg.JwtConfig, cfg.SessionConfig, metricsRecorder)
+	tokenService := services.NewPersonalAccessTokenService(tokenRepo, metricsRecorder)
+	mfaService := services.NewMFAService(dbConn, mfaRepo, userRepo, totpSealer, authGuard, cfg.MFAConfig, metricsRecorder)
+	authService := services.NewAuthService(userService, mfaService, sessionService, bcryptUtils, passwordPolicy, actionTokens, mailSender, cfg.AccountConfig, cfg.MFAConfig, metricsRecorder)
+	accountService := services.NewAccountService(userRepo, userService, authService, sessionService, bcryptUtils, passwordPolicy, mailSender, metricsRecorder)
+	identityService := services.NewIdentityService(dbConn, identityRepo, userRepo, oidcRegistry, oidcStates, authService, metricsRecorder)
+	hangoutService := services.NewHangoutService(dbConn, hangoutRepo, activityRepo, metricsRecorder, events)
 	activityService := services.NewActivityService(dbConn, activityRepo, metricsRecorder)
-	memoryService := services.NewMemoryService(dbConn, memoryRepo, hangoutRepo, fileClient, metricsRecorder)
+	memoryService := services.NewMemoryService(dbConn, memoryRepo, hangoutRepo, fileClient, metricsRecorder, events)
+	trashService := services.NewTrashService(dbConn, hangoutRepo, memoryRepo, fileClient, cfg.TrashConfig, metricsRecorder)
+	idempotencyService := services.NewIdempotencyService(idempotencyRepo, cfg.IdempotencyConfig, metricsRecorder)
+	commentService := services.NewCommentService(commentRepo, hangoutRepo, metricsRecorder, events)
+	albumService := services.NewAlbumService(dbConn, albumRepo, memoryRepo, hangoutRepo, metricsRecorder)
+	shareLinkService := services.NewShareLinkService(shareLinkRepo, hangoutRepo, albumRepo, memoryRepo, fileClient, bcryptUtils, cfg.ShareConfig, metricsRecorder)
+	exportService := services.NewDataExportService(exportRepo, userRepo, hangoutRepo, activityRepo, memoryRepo, fileClient, cfg.DataExportConfig, metricsRecorder)
+	reminderService := services.NewReminderService(hangoutRepo, reminderRepo, notificationRepo, notify.WithPreferences(notificationService, reminderChannels...), emailChannel, cfg.ReminderConfig, metricsRecorder)
+	adminService := services.NewAdminService(dbConn, userRepo, hangoutRepo, memoryRepo, auditRepo, sessionService, authService, metricsRecorder)
+	// admins are bootstrapped from the configuration, there is no route to promote one
+	promoted, err := adminService.PromoteAdmins(ctx, cfg.AdminConfig.Emails)
+	if err != nil {
+		log.Printf(logmsg.AdminPromotionFailed, err)
+		return nil, err
+	}
+	if promoted > 0 {
+		log.Printf(logmsg.AdminPromotionCompleted, promoted)
+	}
+
+	// Event bus consumers
+	fileEvents := domainevents.NewFileEventConsumer(eventBus, memoryService)
+	if err = fileEvents.Start(); err != nil {
+		log.Printf(logmsg.EventBusInitFailed, err)
+		return nil, err
+	}
+
+	// Background jobs
+	purgeJob := jobs.NewTrashPurgeJob(trashService, cfg.TrashConfig.GetPurgeInterval())
+	cleanupJob := jobs.NewIdempotencyCleanupJob(idempotencyService, cfg.IdempotencyConfig.GetCleanupInterval())
+	sessionJob := jobs.NewSessionCleanupJob(sessionService, cfg.SessionConfig.GetCleanupInterval())
+	webhookJob := jobs.NewWebhookDeliveryJob(webhookService, cfg.WebhookConfig.GetDispatchInterval())
+	reminderJob := jobs.NewReminderJob(reminderService, cfg.ReminderConfig.GetInterval())
+	exportJob := jobs.NewDataExportJob(exportService, cfg.DataExportConfig.GetInterval())
+	signingJob := jobs.NewSigningKeyRotationJob(signingKeyService, time.Duration(constants.SigningKeyRefreshSeconds)*time.Second)
 
 	// handler Layer
-	authHandler := handlers.NewAuthHandler(authService, responseBuilder)
+	authHandler := handlers.NewAuthHandler(authService, identityService, responseBuilder)
+	mfaHandler := handlers.NewMFAHandler(mfaService, responseBuilder)
+	sessionHandler := handlers.NewSessionHandler(sessionService, responseBuilder)
+	tokenHandler := handlers.NewPersonalAccessTokenHandler(tokenService, responseBuilder)
+	accountHandler := handlers.NewAccountHandler(accountService, responseBuilder)
+	exportHandler := handlers.NewDataExportHandler(exportService, responseBuilder)
+	adminHandler := handlers.NewAdminHandler(adminService, responseBuilder)
 	hangoutHandler := handlers.NewHangoutHandler(hangoutService, responseBuilder)
 	activityHandler := handlers.NewActivityHandler(activityService, responseBuilder)
 	memoryHandler := handlers.NewMemoryHandler(memoryService, responseBuilder)
+	trashHandler := handlers.NewTrashHandler(trashService, responseBuilder)
+	eventsHandler := handlers.NewEventsHandler(hangoutService, broker, cfg.EventsConfig, responseBuilder)
+	webhookHandler := handlers.NewWebhookHandler(webhookService, responseBuilder)
+	notificationHandler := handlers.NewNotificationHandler(notificationService, responseBuilder)
+	commentHandler := handlers.NewCommentHandler(commentService, responseBuilder)
+	albumHandler := handlers.NewAlbumHandler(albumService, responseBuilder)
+	shareLinkHandler := handlers.NewShareLinkHandler(shareLinkService, responseBuilder)
+	jwksHandler := handlers.NewJWKSHandler(keyRing)
 
 	// Server Setup
 	e := echo.New()
@@ -161,12 +348,23 @@ func NewApp(ctx context.Context, cfg *config.Config) (app *App, err error) {
 	e.Use(middlewares.TracingMiddleware(cfg.AppName))
 	e.Use(middlewares.MetricsMiddleware(metricsRecorder))
 
-	router.NewRouter(e, cfg, responseBuilder, authHandler, hangoutHandler, activityHandler, memoryHandler)
+	router.NewRouter(e, cfg, responseBuilder, authHandler, mfaHandler, hangoutHandler, activityHandler, memoryHandler, trashHandler, eventsHandler, webhookHandler, notificationHandler, commentHandler, albumHandler, shareLinkHandler, jwksHandler, sessionHandler, tokenHandler, accountHandler, exportHandler, adminHandler, jwtUtils, idempotencyService, sessionService, tokenService, adminService, authGuard, metricsRecorder)
 
 	return &App{
 		server:       e,
 		db:           dbConn,
 		fileClient:   fileClient,
+		purgeJob:     purgeJob,
+		cleanupJob:   cleanupJob,
+		sessionJob:   sessionJob,
+		webhookJob:   webhookJob,
+		reminderJob:  reminderJob,
+		exportJob:    exportJob,
+		signingJob:   signingJob,
+		broker:       broker,
+		eventBus:     eventBus,
+		rateLimits:   rateLimitStore,
+		fileEvents:   fileEvents,
 		closer:       dbCloser,
 		cfg:          cfg,
 		tracerCloser: tracerProvider.Shutdown,
@@ -175,6 +373,14 @@ func NewApp(ctx context.Context, cfg *config.Config) (app *App, err error) {
 }
 
 func (a *App) Start() error {
+	a.purgeJob.Start(context.Background())
+	a.cleanupJob.Start(context.Background())
+	a.sessionJob.Start(context.Background())
+	a.webhookJob.Start(context.Background())
+	a.reminderJob.Start(context.Background())
+	a.exportJob.Start(context.Background())
+	a.signingJob.Start(context.Background())
+
 	errChan := make(chan error, 1)
 	go func() {
 		addr := ":" + a.cfg.AppPort
@@ -201,10 +407,31 @@ func (a *App) Shutdown() error {
 	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
 	defer cancel()
 
+	// end open event streams first, otherwise Shutdown waits on them until the timeout
+	if err := a.broker.Close(); err != nil {
+		log.Printf(logmsg.EventBrokerCloseFailed, err)
+	}
+
 	if err := a.server.Shutdown(ctx); err != nil {
 		return err
 	}
 
+	a.purgeJob.Stop()
+	a.cleanupJob.Stop()
+	a.sessionJob.Stop()
+	a.webhookJob.Stop()
+	a.reminderJob.Stop()
+	a.exportJob.Stop()
+	a.signingJob.Stop()
+
+	a.fileEvents.Stop()
+	if err := a.eventBus.Close(); err != nil {
+		log.Printf(logmsg.EventBusCloseFailed, err)
+	}
+	if err := a.rateLimits.Close(); err != nil {
+		log.Printf(logmsg.RateLimitStoreCloseFailed, err)
+	}
+
 	if a.tracerCloser != nil {
 		if err := a.tracerCloser(ctx); err != nil {
 			log.Printf(logmsg.OTELShutdownFailed, err)
@@ -8,6 +8,7 @@ var ErrDbPasswordRequired = errors.New("DB_PASSWORD required in production")
 
 // http errors
 var ErrInvalidPayload = errors.New("invalid payload")
+var ErrRequestBodyTooLarge = errors.New("request body is too large")
 
 // business errors
 
@@ -16,8 +17,60 @@ var ErrUserAlreadyExists = errors.New("user with that email already exists")
 var ErrInvalidCredentials = errors.New("invalid credentials")
 var ErrUserNotFound = errors.New("user not found")
 var ErrUnauthorized = errors.New("Unauthorized")
+var ErrWeakPassword = errors.New("password does not meet the password policy")
+var ErrInvalidActionToken = errors.New("invalid or expired token")
+var ErrEmailUnchanged = errors.New("new email is the same as the current one")
+var ErrEmailAlreadyVerified = errors.New("email is already verified")
+var ErrRecentSignInRequired = errors.New("sign in again to confirm this change")
+var ErrUnknownMailDriver = errors.New("unknown mail driver")
+var ErrUnknownRateLimitStore = errors.New("unknown rate limit store")
+var ErrAccountLocked = errors.New("too many failed sign in attempts, try again later")
+var ErrUnknownSigningAlgorithm = errors.New("unknown JWT signing algorithm")
+var ErrNoSigningKey = errors.New("no active JWT signing key")
 
-//pagination error
+// two-factor authentication
+var ErrInvalidMFACode = errors.New("invalid authentication code")
+var ErrMFAAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+var ErrMFANotEnabled = errors.New("two-factor authentication is not enabled")
+var ErrMFAEnrollmentNotFound = errors.New("no two-factor enrollment in progress, start a new one")
+
+// sessions
+var ErrSessionNotFound = errors.New("session not found")
+var ErrSessionRevoked = errors.New("session has been signed out")
+var ErrInvalidSessionID = errors.New("invalid session ID")
+
+// personal access tokens
+var ErrInvalidPersonalAccessToken = errors.New("invalid or expired personal access token")
+var ErrInsufficientScope = errors.New("the token does not have the scope this route requires")
+var ErrPersonalAccessTokenLimitReached = errors.New("maximum number of personal access tokens reached")
+var ErrPersonalAccessTokenNotFound = errors.New("personal access token not found")
+var ErrInvalidPersonalAccessTokenID = errors.New("invalid personal access token ID")
+
+// data exports
+var ErrDataExportInProgress = errors.New("a data export is already in progress")
+var ErrDataExportNotFound = errors.New("data export not found")
+var ErrInvalidDataExportID = errors.New("invalid data export ID")
+
+// admin
+var ErrAdminRequired = errors.New("this route requires an admin account")
+var ErrAccountDisabled = errors.New("this account has been disabled")
+var ErrCannotModerateSelf = errors.New("admins cannot disable or reset their own account")
+var ErrAdminReasonRequired = errors.New("admin actions require a reason of at most 500 characters")
+var ErrInvalidUserID = errors.New("invalid user ID")
+
+// external sign in
+var ErrInvalidOIDCProvider = errors.New("invalid OIDC provider configuration")
+var ErrUnknownOIDCProvider = errors.New("unknown sign in provider")
+var ErrOIDCProviderUnavailable = errors.New("sign in provider is unavailable")
+var ErrInvalidOIDCState = errors.New("invalid or expired sign in state")
+var ErrOIDCExchangeFailed = errors.New("sign in with the provider failed")
+var ErrOIDCEmailRequired = errors.New("the sign in provider did not share an email address")
+var ErrIdentityEmailInUse = errors.New("an account with this email already exists, sign in and link the provider from your account")
+var ErrIdentityAlreadyLinked = errors.New("this provider account is already linked")
+var ErrIdentityNotFound = errors.New("no identity linked for this provider")
+var ErrLastSignInMethod = errors.New("cannot unlink the only way to sign in, set a password first")
+
+// pagination error
 var ErrInvalidCursorPagination = errors.New("invalid cursor pagination")
 
 // hangout
@@ -27,13 +80,75 @@ var ErrSanitizeDescription = errors.New("failed to sanitize description")
 var ErrInvalidHangoutID = errors.New("invalid hangout ID")
 var ErrInvalidPagination = errors.New("invalid pagination")
 var ErrInvalidActivityIDs = errors.New("one or more activity IDs are invalid or not found")
+var ErrHangoutFieldRequired = errors.New("title, date and status cannot be null or empty")
+var ErrInvalidHangoutDate = errors.New("invalid hangout date")
+var ErrInvalidRSVPDeadline = errors.New("RSVP deadline must be a valid date before the hangout date")
+var ErrInvalidHangoutStatus = errors.New("invalid hangout status")
+var ErrBatchTooLarge = errors.New("batch exceeds the maximum number of operations")
+var ErrInvalidBatchOperation = errors.New("batch operation is missing required fields")
 
 var ErrInvalidActivityID = errors.New("invalid activity ID")
 
+// concurrency
+var ErrIfMatchRequired = errors.New("If-Match header is required")
+var ErrInvalidIfMatch = errors.New("invalid If-Match header")
+var ErrPreconditionFailed = errors.New("resource has been modified, fetch the latest version and retry")
+
+// idempotency
+var ErrInvalidIdempotencyKey = errors.New("invalid Idempotency-Key header")
+var ErrIdempotencyKeyReused = errors.New("Idempotency-Key was already used with a different request")
+var ErrIdempotencyKeyInProgress = errors.New("a request with this Idempotency-Key is still being processed")
+
+// events
+var ErrEventBrokerClosed = errors.New("event broker is closed")
+
+// webhooks
+var ErrInvalidWebhookID = errors.New("invalid webhook ID")
+var ErrInvalidWebhookURL = errors.New("webhook URL must be an absolute http or https URL")
+var ErrWebhookTargetNotAllowed = errors.New("webhook URL must not point to a private or loopback address")
+var ErrWebhookLimitReached = errors.New("maximum number of webhooks reached")
+var ErrWebhookUnexpectedStatus = errors.New("webhook endpoint returned a non-2xx status")
+
+// notifications
+var ErrInvalidNotificationID = errors.New("invalid notification ID")
+var ErrUnknownNotificationChannel = errors.New("unknown notification channel")
+
+// comments
+var ErrInvalidCommentID = errors.New("invalid comment ID")
+var ErrInvalidCommentParent = errors.New("replies must reference a top-level comment of the same hangout")
+var ErrInvalidMention = errors.New("only participants of the hangout can be mentioned")
+
 // file & memory errors
 var ErrInvalidMemoryID = errors.New("invalid memory ID")
 var ErrTooManyFiles = errors.New("too many files")
 var ErrMemoryNotFound = errors.New("memory not found")
+var ErrHangoutDeleted = errors.New("hangout is deleted, restore the hangout first")
+var ErrInvalidMemoryCaption = errors.New("caption must be at most 500 characters")
+var ErrInvalidMemoryTags = errors.New("tags must be 1 to 50 characters and at most 20 per memory")
+var ErrInvalidPersonTag = errors.New("only participants of the hangout can be tagged")
+var ErrInvalidReaction = errors.New("reaction must be a single emoji")
+var ErrInvalidOrderKey = errors.New("invalid order key")
+
+// albums
+var ErrInvalidAlbumID = errors.New("invalid album ID")
+var ErrAlbumNameTaken = errors.New("an album with this name already exists in the hangout")
+var ErrInvalidAlbumCover = errors.New("cover must be a memory of the album")
+var ErrInvalidMemoryPlacement = errors.New("after_id and before_id must be other memories of the target album, in order")
+
+// archives
+var ErrInvalidArchiveID = errors.New("invalid archive ID")
+var ErrNoMemoriesToArchive = errors.New("the hangout has no uploaded memories to archive")
+var ErrArchiveNotFound = errors.New("archive not found or expired")
+
+// share links
+var ErrInvalidShareLinkID = errors.New("invalid share link ID")
+var ErrInvalidShareExpiry = errors.New("expires_at must be in the future and within the maximum share link lifetime")
+var ErrShareLinkLimitReached = errors.New("maximum number of share links reached for this hangout")
+var ErrShareLinkNotFound = errors.New("share link not found")
+var ErrShareLinkExpired = errors.New("share link has expired")
+var ErrSharePasswordRequired = errors.New("share link password is required")
+var ErrInvalidSharePassword = errors.New("invalid share link password")
+var ErrTooManyRequests = errors.New("too many requests, try again later")
 
 // tls errors
 var ErrLoadTLSConfig = errors.New("failed to load mTLS config")
@@ -5,7 +5,18 @@ import (
 	"github.com/google/uuid"
 )
 
+// TokenCustomClaims are carried by access tokens.
 type TokenCustomClaims struct {
-	UserID uuid.UUID `json:"userId"`
+	UserID    uuid.UUID `json:"userId"`
+	SessionID uuid.UUID `json:"sid"`
+	jwt.RegisteredClaims
+}
+
+// ActionTokenClaims are carried by the tokens in email verification and password
+// reset links.
+type ActionTokenClaims struct {
+	UserID      uuid.UUID `json:"userId"`
+	Purpose     string    `json:"purpose"`
+	Fingerprint string    `json:"fp"`
 	jwt.RegisteredClaims
 }
@@ -0,0 +1,85 @@
+// Package cache holds small in-process caches.
+package cache
+
+import (
+	"sync"
+	"time"
+)
+
+type entry[V any] struct {
+	value   V
+	expires time.Time
+}
+
+// TTL is a map whose entries expire a fixed time after they are set.
+type TTL[K comparable, V any] struct {
+	mu        sync.Mutex
+	ttl       time.Duration
+	now       func() time.Time
+	entries   map[K]entry[V]
+	lastSweep time.Time
+}
+
+// NewTTL returns an empty cache. now is the clock used to expire entries.
+func NewTTL[K comparable, V any](ttl time.Duration, now func() time.Time) *TTL[K, V] {
+	return &TTL[K, V]{
+		ttl:       ttl,
+		now:       now,
+		entries:   make(map[K]entry[V]),
+		lastSweep: now(),
+	}
+}
+
+func (c *TTL[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	if !c.now().Before(e.expires) {
+		delete(c.entries, key)
+		var zero V
+		return zero, false
+	}
+	return e.value, true
+}
+
+func (c *TTL[K, V]) Set(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	c.sweep(now)
+
+	c.entries[key] = entry[V]{value: value, expires: now.Add(c.ttl)}
+}
+
+func (c *TTL[K, V]) Delete(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, key)
+}
+
+// Len returns the number of entries, expired ones not yet dropped included.
+func (c *TTL[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.entries)
+}
+
+// sweep must be called with mu held.
+func (c *TTL[K, V]) sweep(now time.Time) {
+	if now.Sub(c.lastSweep) < c.ttl {
+		return
+	}
+	c.lastSweep = now
+	for key, e := range c.entries {
+		if !now.Before(e.expires) {
+			delete(c.entries, key)
+		}
+	}
+}
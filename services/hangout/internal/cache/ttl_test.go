@@ -0,0 +1,78 @@
+package cache_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/cache"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func TestTTL_GetSet(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+	c := cache.NewTTL[string, int](time.Minute, clock.Now)
+
+	_, ok := c.Get("a")
+	require.False(t, ok)
+
+	c.Set("a", 1)
+	value, ok := c.Get("a")
+	require.True(t, ok)
+	require.Equal(t, 1, value)
+
+	clock.now = clock.now.Add(59 * time.Second)
+	_, ok = c.Get("a")
+	require.True(t, ok)
+
+	clock.now = clock.now.Add(time.Second)
+	_, ok = c.Get("a")
+	require.False(t, ok)
+	require.Equal(t, 0, c.Len())
+}
+
+func TestTTL_SetRefreshesExpiry(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+	c := cache.NewTTL[string, int](time.Minute, clock.Now)
+
+	c.Set("a", 1)
+	clock.now = clock.now.Add(30 * time.Second)
+	c.Set("a", 2)
+	clock.now = clock.now.Add(45 * time.Second)
+
+	value, ok := c.Get("a")
+	require.True(t, ok)
+	require.Equal(t, 2, value)
+}
+
+func TestTTL_Delete(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+	c := cache.NewTTL[string, int](time.Minute, clock.Now)
+
+	c.Set("a", 1)
+	c.Delete("a")
+	c.Delete("missing")
+
+	_, ok := c.Get("a")
+	require.False(t, ok)
+}
+
+func TestTTL_SweepDropsExpiredEntries(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+	c := cache.NewTTL[string, int](time.Minute, clock.Now)
+
+	c.Set("a", 1)
+	c.Set("b", 2)
+	require.Equal(t, 2, c.Len())
+
+	clock.now = clock.now.Add(2 * time.Minute)
+	c.Set("c", 3)
+	require.Equal(t, 1, c.Len())
+}
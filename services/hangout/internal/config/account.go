@@ -0,0 +1,53 @@
+package config
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+)
+
+// AccountConfig controls the email verification and password reset emails.
+type AccountConfig struct {
+	EmailVerificationTTLHours int
+	PasswordResetTTLMinutes   int
+	VerifyEmailURL            string
+	ResetPasswordURL          string
+}
+
+func NewAccountConfig() *AccountConfig {
+	return &AccountConfig{
+		EmailVerificationTTLHours: getEnvInt("EMAIL_VERIFICATION_TTL_HOURS", constants.DefaultEmailVerificationTTLHours),
+		PasswordResetTTLMinutes:   getEnvInt("PASSWORD_RESET_TTL_MINUTES", constants.DefaultPasswordResetTTLMinutes),
+		VerifyEmailURL:            getEnv("VERIFY_EMAIL_URL", constants.DefaultVerifyEmailURL),
+		ResetPasswordURL:          getEnv("RESET_PASSWORD_URL", constants.DefaultResetPasswordURL),
+	}
+}
+
+func (c *AccountConfig) GetEmailVerificationTTL() time.Duration {
+	return time.Duration(c.EmailVerificationTTLHours) * time.Hour
+}
+
+func (c *AccountConfig) GetPasswordResetTTL() time.Duration {
+	return time.Duration(c.PasswordResetTTLMinutes) * time.Minute
+}
+
+func (c *AccountConfig) VerifyEmailLink(token string) string {
+	return withToken(c.VerifyEmailURL, token)
+}
+
+func (c *AccountConfig) ResetPasswordLink(token string) string {
+	return withToken(c.ResetPasswordURL, token)
+}
+
+// withToken adds the token to the query of base, keeping any query it already has.
+func withToken(base string, token string) string {
+	u, err := url.Parse(base)
+	if err != nil {
+		return base + "?" + url.Values{constants.ActionTokenQueryParam: {token}}.Encode()
+	}
+	q := u.Query()
+	q.Set(constants.ActionTokenQueryParam, token)
+	u.RawQuery = q.Encode()
+	return u.String()
+}
@@ -0,0 +1,70 @@
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/config"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAccountConfig(t *testing.T) {
+	keys := []string{"EMAIL_VERIFICATION_TTL_HOURS", "PASSWORD_RESET_TTL_MINUTES", "VERIFY_EMAIL_URL", "RESET_PASSWORD_URL"}
+
+	tests := []struct {
+		name     string
+		env      map[string]string
+		expected config.AccountConfig
+	}{
+		{
+			name: "WithEnvVars",
+			env: map[string]string{
+				"EMAIL_VERIFICATION_TTL_HOURS": "24",
+				"PASSWORD_RESET_TTL_MINUTES":   "15",
+				"VERIFY_EMAIL_URL":             "https://app.example.com/verify",
+				"RESET_PASSWORD_URL":           "https://app.example.com/reset",
+			},
+			expected: config.AccountConfig{
+				EmailVerificationTTLHours: 24,
+				PasswordResetTTLMinutes:   15,
+				VerifyEmailURL:            "https://app.example.com/verify",
+				ResetPasswordURL:          "https://app.example.com/reset",
+			},
+		},
+		{
+			name: "WithoutEnvVars_UseDefaults",
+			env:  map[string]string{},
+			expected: config.AccountConfig{
+				EmailVerificationTTLHours: constants.DefaultEmailVerificationTTLHours,
+				PasswordResetTTLMinutes:   constants.DefaultPasswordResetTTLMinutes,
+				VerifyEmailURL:            constants.DefaultVerifyEmailURL,
+				ResetPasswordURL:          constants.DefaultResetPasswordURL,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range keys {
+				t.Setenv(key, tt.env[key])
+			}
+
+			cfg := config.NewAccountConfig()
+
+			require.Equal(t, tt.expected, *cfg)
+			require.Equal(t, time.Duration(tt.expected.EmailVerificationTTLHours)*time.Hour, cfg.GetEmailVerificationTTL())
+			require.Equal(t, time.Duration(tt.expected.PasswordResetTTLMinutes)*time.Minute, cfg.GetPasswordResetTTL())
+		})
+	}
+}
+
+func TestAccountConfig_Links(t *testing.T) {
+	cfg := &config.AccountConfig{
+		VerifyEmailURL:   "https://app.example.com/verify",
+		ResetPasswordURL: "https://app.example.com/account?tab=reset",
+	}
+
+	require.Equal(t, "https://app.example.com/verify?token=abc.def", cfg.VerifyEmailLink("abc.def"))
+	require.Equal(t, "https://app.example.com/account?tab=reset&token=a%2Bb", cfg.ResetPasswordLink("a+b"))
+}
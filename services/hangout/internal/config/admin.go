@@ -0,0 +1,12 @@
+package config
+
+// AdminConfig lists the emails of the accounts promoted to admin at startup.
+type AdminConfig struct {
+	Emails []string
+}
+
+func NewAdminConfig() *AdminConfig {
+	return &AdminConfig{
+		Emails: getEnvList("ADMIN_EMAILS", nil),
+	}
+}
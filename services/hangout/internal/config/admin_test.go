@@ -0,0 +1,37 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAdminConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      string
+		expected config.AdminConfig
+	}{
+		{
+			name:     "WithEnvVars",
+			env:      " admin@example.com, ,ops@example.com ",
+			expected: config.AdminConfig{Emails: []string{"admin@example.com", "ops@example.com"}},
+		},
+		{
+			name:     "WithoutEnvVars_NoAdmins",
+			env:      "",
+			expected: config.AdminConfig{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("ADMIN_EMAILS", tt.env)
+
+			cfg := config.NewAdminConfig()
+
+			require.Equal(t, tt.expected, *cfg)
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package config
+
+import (
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+)
+
+// AuthRateLimitConfig throttles the auth routes.
+type AuthRateLimitConfig struct {
+	Store                string
+	IPRatePerMinute      int
+	IPBurst              int
+	AccountRatePerMinute int
+	AccountBurst         int
+	MaxFailedAttempts    int
+	FailureWindowMinutes int
+	LockoutBaseSeconds   int
+	LockoutMaxMinutes    int
+}
+
+func NewAuthRateLimitConfig() *AuthRateLimitConfig {
+	return &AuthRateLimitConfig{
+		Store:                getEnv("AUTH_RATE_LIMIT_STORE", constants.DefaultRateLimitStore),
+		IPRatePerMinute:      getEnvInt("AUTH_RATE_LIMIT_IP_PER_MINUTE", constants.DefaultAuthIPRatePerMinute),
+		IPBurst:              getEnvInt("AUTH_RATE_LIMIT_IP_BURST", constants.DefaultAuthIPBurst),
+		AccountRatePerMinute: getEnvInt("AUTH_RATE_LIMIT_ACCOUNT_PER_MINUTE", constants.DefaultAuthAccountRatePerMinute),
+		AccountBurst:         getEnvInt("AUTH_RATE_LIMIT_ACCOUNT_BURST", constants.DefaultAuthAccountBurst),
+		MaxFailedAttempts:    getEnvInt("AUTH_LOCKOUT_MAX_FAILED_ATTEMPTS", constants.DefaultAuthMaxFailedAttempts),
+		FailureWindowMinutes: getEnvInt("AUTH_LOCKOUT_FAILURE_WINDOW_MINUTES", constants.DefaultAuthFailureWindowMinutes),
+		LockoutBaseSeconds:   getEnvInt("AUTH_LOCKOUT_BASE_SECONDS", constants.DefaultAuthLockoutBaseSeconds),
+		LockoutMaxMinutes:    getEnvInt("AUTH_LOCKOUT_MAX_MINUTES", constants.DefaultAuthLockoutMaxMinutes),
+	}
+}
+
+// GetIPRate returns the sustained number of requests allowed per second.
+func (c *AuthRateLimitConfig) GetIPRate() float64 {
+	return float64(c.IPRatePerMinute) / 60
+}
+
+// GetAccountRate returns the sustained number of requests allowed per second.
+func (c *AuthRateLimitConfig) GetAccountRate() float64 {
+	return float64(c.AccountRatePerMinute) / 60
+}
+
+func (c *AuthRateLimitConfig) GetFailureWindow() time.Duration {
+	return time.Duration(c.FailureWindowMinutes) * time.Minute
+}
+
+func (c *AuthRateLimitConfig) GetLockoutBase() time.Duration {
+	return time.Duration(c.LockoutBaseSeconds) * time.Second
+}
+
+func (c *AuthRateLimitConfig) GetLockoutMax() time.Duration {
+	return time.Duration(c.LockoutMaxMinutes) * time.Minute
+}
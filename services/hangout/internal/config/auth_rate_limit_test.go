@@ -0,0 +1,105 @@
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/config"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAuthRateLimitConfig(t *testing.T) {
+	keys := []string{
+		"AUTH_RATE_LIMIT_STORE",
+		"AUTH_RATE_LIMIT_IP_PER_MINUTE",
+		"AUTH_RATE_LIMIT_IP_BURST",
+		"AUTH_RATE_LIMIT_ACCOUNT_PER_MINUTE",
+		"AUTH_RATE_LIMIT_ACCOUNT_BURST",
+		"AUTH_LOCKOUT_MAX_FAILED_ATTEMPTS",
+		"AUTH_LOCKOUT_FAILURE_WINDOW_MINUTES",
+		"AUTH_LOCKOUT_BASE_SECONDS",
+		"AUTH_LOCKOUT_MAX_MINUTES",
+	}
+	defaults := config.AuthRateLimitConfig{
+		Store:                constants.DefaultRateLimitStore,
+		IPRatePerMinute:      constants.DefaultAuthIPRatePerMinute,
+		IPBurst:              constants.DefaultAuthIPBurst,
+		AccountRatePerMinute: constants.DefaultAuthAccountRatePerMinute,
+		AccountBurst:         constants.DefaultAuthAccountBurst,
+		MaxFailedAttempts:    constants.DefaultAuthMaxFailedAttempts,
+		FailureWindowMinutes: constants.DefaultAuthFailureWindowMinutes,
+		LockoutBaseSeconds:   constants.DefaultAuthLockoutBaseSeconds,
+		LockoutMaxMinutes:    constants.DefaultAuthLockoutMaxMinutes,
+	}
+
+	tests := []struct {
+		name     string
+		env      map[string]string
+		expected config.AuthRateLimitConfig
+	}{
+		{
+			name: "WithEnvVars",
+			env: map[string]string{
+				"AUTH_RATE_LIMIT_STORE":               "redis",
+				"AUTH_RATE_LIMIT_IP_PER_MINUTE":       "60",
+				"AUTH_RATE_LIMIT_IP_BURST":            "30",
+				"AUTH_RATE_LIMIT_ACCOUNT_PER_MINUTE":  "6",
+				"AUTH_RATE_LIMIT_ACCOUNT_BURST":       "3",
+				"AUTH_LOCKOUT_MAX_FAILED_ATTEMPTS":    "3",
+				"AUTH_LOCKOUT_FAILURE_WINDOW_MINUTES": "10",
+				"AUTH_LOCKOUT_BASE_SECONDS":           "30",
+				"AUTH_LOCKOUT_MAX_MINUTES":            "120",
+			},
+			expected: config.AuthRateLimitConfig{
+				Store:                "redis",
+				IPRatePerMinute:      60,
+				IPBurst:              30,
+				AccountRatePerMinute: 6,
+				AccountBurst:         3,
+				MaxFailedAttempts:    3,
+				FailureWindowMinutes: 10,
+				LockoutBaseSeconds:   30,
+				LockoutMaxMinutes:    120,
+			},
+		},
+		{
+			name:     "WithoutEnvVars_UseDefaults",
+			env:      map[string]string{},
+			expected: defaults,
+		},
+		{
+			name:     "InvalidEnvVars_UseDefaults",
+			env:      map[string]string{"AUTH_RATE_LIMIT_IP_PER_MINUTE": "abc", "AUTH_LOCKOUT_BASE_SECONDS": "abc"},
+			expected: defaults,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range keys {
+				t.Setenv(key, tt.env[key])
+			}
+
+			cfg := config.NewAuthRateLimitConfig()
+
+			require.Equal(t, tt.expected, *cfg)
+		})
+	}
+}
+
+func TestAuthRateLimitConfig_Durations(t *testing.T) {
+	cfg := &config.AuthRateLimitConfig{
+		IPRatePerMinute:      30,
+		AccountRatePerMinute: 6,
+		FailureWindowMinutes: 15,
+		LockoutBaseSeconds:   60,
+		LockoutMaxMinutes:    60,
+	}
+
+	require.InDelta(t, 0.5, cfg.GetIPRate(), 1e-9)
+	require.InDelta(t, 0.1, cfg.GetAccountRate(), 1e-9)
+	require.Equal(t, 15*time.Minute, cfg.GetFailureWindow())
+	require.Equal(t, time.Minute, cfg.GetLockoutBase())
+	require.Equal(t, time.Hour, cfg.GetLockoutMax())
+}
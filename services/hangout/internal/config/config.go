@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
@@ -11,14 +12,32 @@ import (
 )
 
 type Config struct {
-	Env              string
-	AppName          string
-	AppPort          string
-	DBConfig         *DBConfig
-	JwtConfig        *JwtConfig
-	GRPCClientConfig *GRPCClientConfig
-	OTELConfig       *OTELConfig
-	BcryptCost       int
+	Env               string
+	AppName           string
+	AppPort           string
+	DBConfig          *DBConfig
+	JwtConfig         *JwtConfig
+	GRPCClientConfig  *GRPCClientConfig
+	OTELConfig        *OTELConfig
+	TrashConfig       *TrashConfig
+	IdempotencyConfig *IdempotencyConfig
+	EventsConfig      *EventsConfig
+	WebhookConfig     *WebhookConfig
+	EventBusConfig    *EventBusConfig
+	ReminderConfig    *ReminderConfig
+	DataExportConfig  *DataExportConfig
+	SMTPConfig        *SMTPConfig
+	MailConfig        *MailConfig
+	ShareConfig       *ShareConfig
+	PasswordConfig    *PasswordPolicyConfig
+	AccountConfig     *AccountConfig
+	RedisConfig       *RedisConfig
+	AuthRateLimit     *AuthRateLimitConfig
+	OIDCConfig        *OIDCConfig
+	MFAConfig         *MFAConfig
+	SessionConfig     *SessionConfig
+	AdminConfig       *AdminConfig
+	BcryptCost        int
 }
 
 func Load() (*Config, error) {
@@ -27,14 +46,32 @@ func Load() (*Config, error) {
 	}
 
 	cfg := &Config{
-		Env:              getEnv("ENV", constants.DevEnv),
-		AppName:          getEnv("APP_NAME", constants.DefaultAppName),
-		AppPort:          getEnv("APP_PORT", constants.DefaultAppPort),
-		DBConfig:         NewDBConfig(),
-		JwtConfig:        NewJwtConfig(),
-		GRPCClientConfig: NewGRPCClientConfig(),
-		OTELConfig:       NewOTELConfig(),
-		BcryptCost:       bcrypt.DefaultCost,
+		Env:               getEnv("ENV", constants.DevEnv),
+		AppName:           getEnv("APP_NAME", constants.DefaultAppName),
+		AppPort:           getEnv("APP_PORT", constants.DefaultAppPort),
+		DBConfig:          NewDBConfig(),
+		JwtConfig:         NewJwtConfig(),
+		GRPCClientConfig:  NewGRPCClientConfig(),
+		OTELConfig:        NewOTELConfig(),
+		TrashConfig:       NewTrashConfig(),
+		IdempotencyConfig: NewIdempotencyConfig(),
+		EventsConfig:      NewEventsConfig(),
+		WebhookConfig:     NewWebhookConfig(),
+		EventBusConfig:    NewEventBusConfig(),
+		ReminderConfig:    NewReminderConfig(),
+		DataExportConfig:  NewDataExportConfig(),
+		SMTPConfig:        NewSMTPConfig(),
+		MailConfig:        NewMailConfig(),
+		ShareConfig:       NewShareConfig(),
+		PasswordConfig:    NewPasswordPolicyConfig(),
+		AccountConfig:     NewAccountConfig(),
+		RedisConfig:       NewRedisConfig(),
+		AuthRateLimit:     NewAuthRateLimitConfig(),
+		OIDCConfig:        NewOIDCConfig(),
+		MFAConfig:         NewMFAConfig(),
+		SessionConfig:     NewSessionConfig(),
+		AdminConfig:       NewAdminConfig(),
+		BcryptCost:        bcrypt.DefaultCost,
 	}
 
 	if cfg.AppPort == "" {
@@ -61,3 +98,38 @@ func getEnvInt(key string, def int) int {
 	}
 	return def
 }
+
+// getEnvList reads a comma separated list, dropping empty items.
+func getEnvList(key string, def []string) []string {
+	v := os.Getenv(key)
+	if v == "" {
+		return def
+	}
+	var items []string
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	if len(items) == 0 {
+		return def
+	}
+	return items
+}
+
+// getEnvIntList reads a comma separated list of integers.
+func getEnvIntList(key string, def []int) []int {
+	items := getEnvList(key, nil)
+	if items == nil {
+		return def
+	}
+	values := make([]int, len(items))
+	for i, item := range items {
+		value, err := strconv.Atoi(item)
+		if err != nil {
+			return def
+		}
+		values[i] = value
+	}
+	return values
+}
@@ -0,0 +1,29 @@
+package config
+
+import (
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+)
+
+type DataExportConfig struct {
+	IntervalSeconds int
+	BatchSize       int
+	RetentionHours  int
+}
+
+func NewDataExportConfig() *DataExportConfig {
+	return &DataExportConfig{
+		IntervalSeconds: getEnvInt("DATA_EXPORT_INTERVAL_SECONDS", constants.DefaultDataExportIntervalSeconds),
+		BatchSize:       getEnvInt("DATA_EXPORT_BATCH_SIZE", constants.DefaultDataExportBatchSize),
+		RetentionHours:  getEnvInt("DATA_EXPORT_RETENTION_HOURS", constants.DefaultDataExportRetentionHours),
+	}
+}
+
+func (c *DataExportConfig) GetInterval() time.Duration {
+	return time.Duration(c.IntervalSeconds) * time.Second
+}
+
+func (c *DataExportConfig) GetRetention() time.Duration {
+	return time.Duration(c.RetentionHours) * time.Hour
+}
@@ -0,0 +1,71 @@
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/config"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDataExportConfig(t *testing.T) {
+	keys := []string{
+		"DATA_EXPORT_INTERVAL_SECONDS",
+		"DATA_EXPORT_BATCH_SIZE",
+		"DATA_EXPORT_RETENTION_HOURS",
+	}
+
+	defaults := config.DataExportConfig{
+		IntervalSeconds: constants.DefaultDataExportIntervalSeconds,
+		BatchSize:       constants.DefaultDataExportBatchSize,
+		RetentionHours:  constants.DefaultDataExportRetentionHours,
+	}
+
+	tests := []struct {
+		name     string
+		env      map[string]string
+		expected config.DataExportConfig
+	}{
+		{
+			name: "WithEnvVars",
+			env: map[string]string{
+				"DATA_EXPORT_INTERVAL_SECONDS": "10",
+				"DATA_EXPORT_BATCH_SIZE":       "3",
+				"DATA_EXPORT_RETENTION_HOURS":  "24",
+			},
+			expected: config.DataExportConfig{
+				IntervalSeconds: 10,
+				BatchSize:       3,
+				RetentionHours:  24,
+			},
+		},
+		{
+			name: "InvalidValues_UseDefaults",
+			env: map[string]string{
+				"DATA_EXPORT_BATCH_SIZE":      "many",
+				"DATA_EXPORT_RETENTION_HOURS": "a week",
+			},
+			expected: defaults,
+		},
+		{
+			name:     "WithoutEnvVars_UseDefaults",
+			env:      map[string]string{},
+			expected: defaults,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range keys {
+				t.Setenv(key, tt.env[key])
+			}
+
+			cfg := config.NewDataExportConfig()
+
+			require.Equal(t, tt.expected, *cfg)
+			require.Equal(t, time.Duration(tt.expected.IntervalSeconds)*time.Second, cfg.GetInterval())
+			require.Equal(t, time.Duration(tt.expected.RetentionHours)*time.Hour, cfg.GetRetention())
+		})
+	}
+}
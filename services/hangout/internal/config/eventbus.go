@@ -0,0 +1,18 @@
+package config
+
+import "github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+
+// EventBusConfig selects the event bus driver.
+type EventBusConfig struct {
+	Driver       string
+	BufferSize   int
+	StreamMaxLen int
+}
+
+func NewEventBusConfig() *EventBusConfig {
+	return &EventBusConfig{
+		Driver:       getEnv("EVENT_BUS_DRIVER", constants.DefaultEventBusDriver),
+		BufferSize:   getEnvInt("EVENT_BUS_BUFFER_SIZE", constants.DefaultEventBusBufferSize),
+		StreamMaxLen: getEnvInt("EVENT_BUS_STREAM_MAX_LEN", constants.DefaultEventBusStreamMaxLen),
+	}
+}
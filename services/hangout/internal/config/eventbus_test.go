@@ -0,0 +1,55 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/config"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewEventBusConfig(t *testing.T) {
+	tests := []struct {
+		name           string
+		env            map[string]string
+		expectedDriver string
+		expectedBuffer int
+		expectedMaxLen int
+	}{
+		{
+			name:           "WithEnvVars",
+			env:            map[string]string{"EVENT_BUS_DRIVER": "redis", "EVENT_BUS_BUFFER_SIZE": "64", "EVENT_BUS_STREAM_MAX_LEN": "500"},
+			expectedDriver: "redis",
+			expectedBuffer: 64,
+			expectedMaxLen: 500,
+		},
+		{
+			name:           "WithoutEnvVars_UseDefaults",
+			env:            map[string]string{},
+			expectedDriver: constants.DefaultEventBusDriver,
+			expectedBuffer: constants.DefaultEventBusBufferSize,
+			expectedMaxLen: constants.DefaultEventBusStreamMaxLen,
+		},
+		{
+			name:           "InvalidEnvVars_UseDefaults",
+			env:            map[string]string{"EVENT_BUS_BUFFER_SIZE": "abc", "EVENT_BUS_STREAM_MAX_LEN": "many"},
+			expectedDriver: constants.DefaultEventBusDriver,
+			expectedBuffer: constants.DefaultEventBusBufferSize,
+			expectedMaxLen: constants.DefaultEventBusStreamMaxLen,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("EVENT_BUS_DRIVER", tt.env["EVENT_BUS_DRIVER"])
+			t.Setenv("EVENT_BUS_BUFFER_SIZE", tt.env["EVENT_BUS_BUFFER_SIZE"])
+			t.Setenv("EVENT_BUS_STREAM_MAX_LEN", tt.env["EVENT_BUS_STREAM_MAX_LEN"])
+
+			cfg := config.NewEventBusConfig()
+
+			require.Equal(t, tt.expectedDriver, cfg.Driver)
+			require.Equal(t, tt.expectedBuffer, cfg.BufferSize)
+			require.Equal(t, tt.expectedMaxLen, cfg.StreamMaxLen)
+		})
+	}
+}
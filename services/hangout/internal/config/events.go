@@ -0,0 +1,23 @@
+package config
+
+import (
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+)
+
+type EventsConfig struct {
+	HeartbeatIntervalSeconds int
+	SubscriberBufferSize     int
+}
+
+func NewEventsConfig() *EventsConfig {
+	return &EventsConfig{
+		HeartbeatIntervalSeconds: getEnvInt("EVENTS_HEARTBEAT_INTERVAL_SECONDS", constants.DefaultEventsHeartbeatIntervalSeconds),
+		SubscriberBufferSize:     getEnvInt("EVENTS_SUBSCRIBER_BUFFER_SIZE", constants.DefaultEventsSubscriberBufferSize),
+	}
+}
+
+func (c *EventsConfig) GetHeartbeatInterval() time.Duration {
+	return time.Duration(c.HeartbeatIntervalSeconds) * time.Second
+}
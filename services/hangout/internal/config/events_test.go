@@ -0,0 +1,51 @@
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/config"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewEventsConfig(t *testing.T) {
+	tests := []struct {
+		name              string
+		env               map[string]string
+		expectedHeartbeat int
+		expectedBuffer    int
+	}{
+		{
+			name:              "WithEnvVars",
+			env:               map[string]string{"EVENTS_HEARTBEAT_INTERVAL_SECONDS": "5", "EVENTS_SUBSCRIBER_BUFFER_SIZE": "8"},
+			expectedHeartbeat: 5,
+			expectedBuffer:    8,
+		},
+		{
+			name:              "WithoutEnvVars_UseDefaults",
+			env:               map[string]string{},
+			expectedHeartbeat: constants.DefaultEventsHeartbeatIntervalSeconds,
+			expectedBuffer:    constants.DefaultEventsSubscriberBufferSize,
+		},
+		{
+			name:              "InvalidEnvVars_UseDefaults",
+			env:               map[string]string{"EVENTS_HEARTBEAT_INTERVAL_SECONDS": "abc", "EVENTS_SUBSCRIBER_BUFFER_SIZE": "abc"},
+			expectedHeartbeat: constants.DefaultEventsHeartbeatIntervalSeconds,
+			expectedBuffer:    constants.DefaultEventsSubscriberBufferSize,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("EVENTS_HEARTBEAT_INTERVAL_SECONDS", tt.env["EVENTS_HEARTBEAT_INTERVAL_SECONDS"])
+			t.Setenv("EVENTS_SUBSCRIBER_BUFFER_SIZE", tt.env["EVENTS_SUBSCRIBER_BUFFER_SIZE"])
+
+			cfg := config.NewEventsConfig()
+
+			require.Equal(t, tt.expectedHeartbeat, cfg.HeartbeatIntervalSeconds)
+			require.Equal(t, tt.expectedBuffer, cfg.SubscriberBufferSize)
+			require.Equal(t, time.Duration(tt.expectedHeartbeat)*time.Second, cfg.GetHeartbeatInterval())
+		})
+	}
+}
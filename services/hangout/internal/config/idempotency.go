@@ -0,0 +1,27 @@
+package config
+
+import (
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+)
+
+type IdempotencyConfig struct {
+	KeyTTLHours            int
+	CleanupIntervalMinutes int
+}
+
+func NewIdempotencyConfig() *IdempotencyConfig {
+	return &IdempotencyConfig{
+		KeyTTLHours:            getEnvInt("IDEMPOTENCY_KEY_TTL_HOURS", constants.DefaultIdempotencyKeyTTLHours),
+		CleanupIntervalMinutes: getEnvInt("IDEMPOTENCY_CLEANUP_INTERVAL_MINUTES", constants.DefaultIdempotencyCleanupIntervalMinutes),
+	}
+}
+
+func (c *IdempotencyConfig) GetKeyTTL() time.Duration {
+	return time.Duration(c.KeyTTLHours) * time.Hour
+}
+
+func (c *IdempotencyConfig) GetCleanupInterval() time.Duration {
+	return time.Duration(c.CleanupIntervalMinutes) * time.Minute
+}
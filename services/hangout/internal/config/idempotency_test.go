@@ -0,0 +1,52 @@
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/config"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewIdempotencyConfig(t *testing.T) {
+	tests := []struct {
+		name             string
+		env              map[string]string
+		expectedTTL      int
+		expectedInterval int
+	}{
+		{
+			name:             "WithEnvVars",
+			env:              map[string]string{"IDEMPOTENCY_KEY_TTL_HOURS": "48", "IDEMPOTENCY_CLEANUP_INTERVAL_MINUTES": "5"},
+			expectedTTL:      48,
+			expectedInterval: 5,
+		},
+		{
+			name:             "WithoutEnvVars_UseDefaults",
+			env:              map[string]string{},
+			expectedTTL:      constants.DefaultIdempotencyKeyTTLHours,
+			expectedInterval: constants.DefaultIdempotencyCleanupIntervalMinutes,
+		},
+		{
+			name:             "InvalidEnvVars_UseDefaults",
+			env:              map[string]string{"IDEMPOTENCY_KEY_TTL_HOURS": "abc", "IDEMPOTENCY_CLEANUP_INTERVAL_MINUTES": "abc"},
+			expectedTTL:      constants.DefaultIdempotencyKeyTTLHours,
+			expectedInterval: constants.DefaultIdempotencyCleanupIntervalMinutes,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("IDEMPOTENCY_KEY_TTL_HOURS", tt.env["IDEMPOTENCY_KEY_TTL_HOURS"])
+			t.Setenv("IDEMPOTENCY_CLEANUP_INTERVAL_MINUTES", tt.env["IDEMPOTENCY_CLEANUP_INTERVAL_MINUTES"])
+
+			cfg := config.NewIdempotencyConfig()
+
+			require.Equal(t, tt.expectedTTL, cfg.KeyTTLHours)
+			require.Equal(t, tt.expectedInterval, cfg.CleanupIntervalMinutes)
+			require.Equal(t, time.Duration(tt.expectedTTL)*time.Hour, cfg.GetKeyTTL())
+			require.Equal(t, time.Duration(tt.expectedInterval)*time.Minute, cfg.GetCleanupInterval())
+		})
+	}
+}
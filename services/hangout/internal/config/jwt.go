@@ -1,15 +1,40 @@
 package config
 
-import "github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+import (
+	"time"
 
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+)
+
+// JwtConfig controls access tokens.
 type JwtConfig struct {
 	JWTSecret          string
 	JWTExpirationHours int
+	JWTAlgorithm       string
+	JWTKeyRotationDays int
+	JWTAcceptHS256     bool
 }
 
 func NewJwtConfig() *JwtConfig {
 	return &JwtConfig{
 		JWTSecret:          getEnv("JWT_SECRET", ""),
 		JWTExpirationHours: getEnvInt("JWT_EXPIRATION_HOURS", constants.DefaultJWTExpirationHours),
+		JWTAlgorithm:       getEnv("JWT_ALGORITHM", constants.DefaultJWTAlgorithm),
+		JWTKeyRotationDays: getEnvInt("JWT_KEY_ROTATION_DAYS", constants.DefaultJWTKeyRotationDays),
+		JWTAcceptHS256:     getEnv("JWT_ACCEPT_HS256", "true") == "true",
 	}
 }
+
+func (c *JwtConfig) GetExpiration() time.Duration {
+	return time.Duration(c.JWTExpirationHours) * time.Hour
+}
+
+func (c *JwtConfig) GetKeyRotation() time.Duration {
+	return time.Duration(c.JWTKeyRotationDays) * 24 * time.Hour
+}
+
+// UsesKeyPairs reports whether tokens are signed with rotating key pairs
+// rather than the shared secret. An unset algorithm means HS256.
+func (c *JwtConfig) UsesKeyPairs() bool {
+	return c.JWTAlgorithm != "" && c.JWTAlgorithm != constants.JWTAlgorithmHS256
+}
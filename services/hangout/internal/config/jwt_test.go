@@ -3,6 +3,7 @@ package config_test
 import (
 	"os"
 	"testing"
+	"time"
 
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/config"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
@@ -62,3 +63,49 @@ func TestNewJwtConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestNewJwtConfig_Signing(t *testing.T) {
+	keys := []string{"JWT_ALGORITHM", "JWT_KEY_ROTATION_DAYS", "JWT_ACCEPT_HS256"}
+
+	tests := []struct {
+		name             string
+		env              map[string]string
+		expectedAlg      string
+		expectedRotation int
+		expectedAccept   bool
+		expectedKeyPairs bool
+	}{
+		{
+			name:             "WithEnvVars",
+			env:              map[string]string{"JWT_ALGORITHM": "EdDSA", "JWT_KEY_ROTATION_DAYS": "7", "JWT_ACCEPT_HS256": "false"},
+			expectedAlg:      constants.JWTAlgorithmEdDSA,
+			expectedRotation: 7,
+			expectedAccept:   false,
+			expectedKeyPairs: true,
+		},
+		{
+			name:             "WithoutEnvVars_UseDefaults",
+			env:              map[string]string{},
+			expectedAlg:      constants.DefaultJWTAlgorithm,
+			expectedRotation: constants.DefaultJWTKeyRotationDays,
+			expectedAccept:   true,
+			expectedKeyPairs: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range keys {
+				t.Setenv(key, tt.env[key])
+			}
+
+			cfg := config.NewJwtConfig()
+
+			require.Equal(t, tt.expectedAlg, cfg.JWTAlgorithm)
+			require.Equal(t, tt.expectedRotation, cfg.JWTKeyRotationDays)
+			require.Equal(t, tt.expectedAccept, cfg.JWTAcceptHS256)
+			require.Equal(t, tt.expectedKeyPairs, cfg.UsesKeyPairs())
+			require.Equal(t, time.Duration(tt.expectedRotation)*24*time.Hour, cfg.GetKeyRotation())
+		})
+	}
+}
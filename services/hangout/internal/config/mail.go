@@ -0,0 +1,17 @@
+package config
+
+import "github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+
+// MailConfig selects how outgoing mail is delivered: smtp, or console and file for
+// local development.
+type MailConfig struct {
+	Driver  string
+	FileDir string
+}
+
+func NewMailConfig() *MailConfig {
+	return &MailConfig{
+		Driver:  getEnv("MAIL_DRIVER", constants.DefaultMailDriver),
+		FileDir: getEnv("MAIL_FILE_DIR", constants.DefaultMailFileDir),
+	}
+}
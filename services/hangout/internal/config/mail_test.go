@@ -0,0 +1,39 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/config"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMailConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      map[string]string
+		expected config.MailConfig
+	}{
+		{
+			name:     "WithEnvVars",
+			env:      map[string]string{"MAIL_DRIVER": constants.MailDriverFile, "MAIL_FILE_DIR": "/var/mail/outbox"},
+			expected: config.MailConfig{Driver: constants.MailDriverFile, FileDir: "/var/mail/outbox"},
+		},
+		{
+			name:     "WithoutEnvVars_UseDefaults",
+			env:      map[string]string{},
+			expected: config.MailConfig{Driver: constants.DefaultMailDriver, FileDir: constants.DefaultMailFileDir},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("MAIL_DRIVER", tt.env["MAIL_DRIVER"])
+			t.Setenv("MAIL_FILE_DIR", tt.env["MAIL_FILE_DIR"])
+
+			cfg := config.NewMailConfig()
+
+			require.Equal(t, tt.expected, *cfg)
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package config
+
+import (
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+)
+
+// MFAConfig controls two-factor authentication.
+type MFAConfig struct {
+	TOTPIssuer          string
+	ChallengeTTLMinutes int
+	RecoveryCodeCount   int
+}
+
+func NewMFAConfig() *MFAConfig {
+	return &MFAConfig{
+		TOTPIssuer:          getEnv("MFA_TOTP_ISSUER", constants.DefaultTOTPIssuer),
+		ChallengeTTLMinutes: getEnvInt("MFA_CHALLENGE_TTL_MINUTES", constants.DefaultMFAChallengeTTLMinutes),
+		RecoveryCodeCount:   getEnvInt("MFA_RECOVERY_CODE_COUNT", constants.DefaultRecoveryCodeCount),
+	}
+}
+
+func (c *MFAConfig) GetChallengeTTL() time.Duration {
+	return time.Duration(c.ChallengeTTLMinutes) * time.Minute
+}
@@ -0,0 +1,56 @@
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/config"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMFAConfig(t *testing.T) {
+	keys := []string{"MFA_TOTP_ISSUER", "MFA_CHALLENGE_TTL_MINUTES", "MFA_RECOVERY_CODE_COUNT"}
+
+	tests := []struct {
+		name     string
+		env      map[string]string
+		expected config.MFAConfig
+	}{
+		{
+			name: "WithEnvVars",
+			env: map[string]string{
+				"MFA_TOTP_ISSUER":           "Hangouts Staging",
+				"MFA_CHALLENGE_TTL_MINUTES": "3",
+				"MFA_RECOVERY_CODE_COUNT":   "8",
+			},
+			expected: config.MFAConfig{
+				TOTPIssuer:          "Hangouts Staging",
+				ChallengeTTLMinutes: 3,
+				RecoveryCodeCount:   8,
+			},
+		},
+		{
+			name: "WithoutEnvVars_UseDefaults",
+			env:  map[string]string{},
+			expected: config.MFAConfig{
+				TOTPIssuer:          constants.DefaultTOTPIssuer,
+				ChallengeTTLMinutes: constants.DefaultMFAChallengeTTLMinutes,
+				RecoveryCodeCount:   constants.DefaultRecoveryCodeCount,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range keys {
+				t.Setenv(key, tt.env[key])
+			}
+
+			cfg := config.NewMFAConfig()
+
+			require.Equal(t, tt.expected, *cfg)
+			require.Equal(t, time.Duration(tt.expected.ChallengeTTLMinutes)*time.Minute, cfg.GetChallengeTTL())
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package config
+
+import (
+	"strings"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+)
+
+// OIDCProviderConfig describes one external sign in provider.
+type OIDCProviderConfig struct {
+	Name         string
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+	AuthURL      string
+	TokenURL     string
+	UserInfoURL  string
+}
+
+// OIDCConfig lists the enabled providers.
+type OIDCConfig struct {
+	Providers       []OIDCProviderConfig
+	RedirectURL     string
+	StateTTLMinutes int
+}
+
+// NewOIDCConfig reads the providers named in OIDC_PROVIDERS.
+func NewOIDCConfig() *OIDCConfig {
+	names := getEnvList("OIDC_PROVIDERS", nil)
+	providers := make([]OIDCProviderConfig, 0, len(names))
+	for _, name := range names {
+		name = strings.ToLower(name)
+		prefix := "OIDC_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_")) + "_"
+
+		issuer := getEnv(prefix+"ISSUER", "")
+		var defaultScopes []string
+		if issuer != "" {
+			defaultScopes = strings.Split(constants.DefaultOIDCScopes, ",")
+		}
+
+		providers = append(providers, OIDCProviderConfig{
+			Name:         name,
+			Issuer:       issuer,
+			ClientID:     getEnv(prefix+"CLIENT_ID", ""),
+			ClientSecret: getEnv(prefix+"CLIENT_SECRET", ""),
+			Scopes:       getEnvList(prefix+"SCOPES", defaultScopes),
+			AuthURL:      getEnv(prefix+"AUTH_URL", ""),
+			TokenURL:     getEnv(prefix+"TOKEN_URL", ""),
+			UserInfoURL:  getEnv(prefix+"USERINFO_URL", ""),
+		})
+	}
+
+	return &OIDCConfig{
+		Providers:       providers,
+		RedirectURL:     getEnv("OIDC_REDIRECT_URL", constants.DefaultOIDCRedirectURL),
+		StateTTLMinutes: getEnvInt("OIDC_STATE_TTL_MINUTES", constants.DefaultOIDCStateTTLMinutes),
+	}
+}
+
+func (c *OIDCConfig) GetStateTTL() time.Duration {
+	return time.Duration(c.StateTTLMinutes) * time.Minute
+}
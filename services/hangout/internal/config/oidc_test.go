@@ -0,0 +1,96 @@
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/config"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewOIDCConfig(t *testing.T) {
+	keys := []string{
+		"OIDC_PROVIDERS", "OIDC_REDIRECT_URL", "OIDC_STATE_TTL_MINUTES",
+		"OIDC_GOOGLE_ISSUER", "OIDC_GOOGLE_CLIENT_ID", "OIDC_GOOGLE_CLIENT_SECRET", "OIDC_GOOGLE_SCOPES",
+		"OIDC_GITHUB_CLIENT_ID", "OIDC_GITHUB_CLIENT_SECRET", "OIDC_GITHUB_SCOPES",
+		"OIDC_GITHUB_AUTH_URL", "OIDC_GITHUB_TOKEN_URL", "OIDC_GITHUB_USERINFO_URL",
+		"OIDC_MOCK_IDP_ISSUER", "OIDC_MOCK_IDP_CLIENT_ID",
+	}
+
+	tests := []struct {
+		name     string
+		env      map[string]string
+		expected config.OIDCConfig
+	}{
+		{
+			name: "WithEnvVars",
+			env: map[string]string{
+				"OIDC_PROVIDERS":            "Google, github,mock-idp",
+				"OIDC_REDIRECT_URL":         "https://app.example.com/auth/callback",
+				"OIDC_STATE_TTL_MINUTES":    "5",
+				"OIDC_GOOGLE_ISSUER":        "https://accounts.google.com",
+				"OIDC_GOOGLE_CLIENT_ID":     "google-id",
+				"OIDC_GOOGLE_CLIENT_SECRET": "google-secret",
+				"OIDC_GITHUB_CLIENT_ID":     "github-id",
+				"OIDC_GITHUB_CLIENT_SECRET": "github-secret",
+				"OIDC_GITHUB_SCOPES":        "read:user,user:email",
+				"OIDC_GITHUB_AUTH_URL":      "https://github.com/login/oauth/authorize",
+				"OIDC_GITHUB_TOKEN_URL":     "https://github.com/login/oauth/access_token",
+				"OIDC_GITHUB_USERINFO_URL":  "https://api.github.com/user",
+				"OIDC_MOCK_IDP_ISSUER":      "http://localhost:8081",
+				"OIDC_MOCK_IDP_CLIENT_ID":   "mock-id",
+			},
+			expected: config.OIDCConfig{
+				Providers: []config.OIDCProviderConfig{
+					{
+						Name:         "google",
+						Issuer:       "https://accounts.google.com",
+						ClientID:     "google-id",
+						ClientSecret: "google-secret",
+						Scopes:       []string{"openid", "email", "profile"},
+					},
+					{
+						Name:         "github",
+						ClientID:     "github-id",
+						ClientSecret: "github-secret",
+						Scopes:       []string{"read:user", "user:email"},
+						AuthURL:      "https://github.com/login/oauth/authorize",
+						TokenURL:     "https://github.com/login/oauth/access_token",
+						UserInfoURL:  "https://api.github.com/user",
+					},
+					{
+						Name:     "mock-idp",
+						Issuer:   "http://localhost:8081",
+						ClientID: "mock-id",
+						Scopes:   []string{"openid", "email", "profile"},
+					},
+				},
+				RedirectURL:     "https://app.example.com/auth/callback",
+				StateTTLMinutes: 5,
+			},
+		},
+		{
+			name: "WithoutEnvVars_UseDefaults",
+			env:  map[string]string{},
+			expected: config.OIDCConfig{
+				Providers:       []config.OIDCProviderConfig{},
+				RedirectURL:     constants.DefaultOIDCRedirectURL,
+				StateTTLMinutes: constants.DefaultOIDCStateTTLMinutes,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range keys {
+				t.Setenv(key, tt.env[key])
+			}
+
+			cfg := config.NewOIDCConfig()
+
+			require.Equal(t, tt.expected, *cfg)
+			require.Equal(t, time.Duration(tt.expected.StateTTLMinutes)*time.Minute, cfg.GetStateTTL())
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package config
+
+import "github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+
+// PasswordPolicyConfig sets the rules new passwords must follow.
+type PasswordPolicyConfig struct {
+	MinLength        int
+	RequireUppercase bool
+	RequireLowercase bool
+	RequireDigit     bool
+	RequireSymbol    bool
+	CheckBreached    bool
+}
+
+func NewPasswordPolicyConfig() *PasswordPolicyConfig {
+	return &PasswordPolicyConfig{
+		MinLength:        getEnvInt("PASSWORD_MIN_LENGTH", constants.DefaultPasswordMinLength),
+		RequireUppercase: getEnv("PASSWORD_REQUIRE_UPPERCASE", "false") == "true",
+		RequireLowercase: getEnv("PASSWORD_REQUIRE_LOWERCASE", "false") == "true",
+		RequireDigit:     getEnv("PASSWORD_REQUIRE_DIGIT", "false") == "true",
+		RequireSymbol:    getEnv("PASSWORD_REQUIRE_SYMBOL", "false") == "true",
+		CheckBreached:    getEnv("PASSWORD_CHECK_BREACHED", "true") == "true",
+	}
+}
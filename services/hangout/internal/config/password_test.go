@@ -0,0 +1,66 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/config"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPasswordPolicyConfig(t *testing.T) {
+	keys := []string{"PASSWORD_MIN_LENGTH", "PASSWORD_REQUIRE_UPPERCASE", "PASSWORD_REQUIRE_LOWERCASE", "PASSWORD_REQUIRE_DIGIT", "PASSWORD_REQUIRE_SYMBOL", "PASSWORD_CHECK_BREACHED"}
+
+	tests := []struct {
+		name     string
+		env      map[string]string
+		expected config.PasswordPolicyConfig
+	}{
+		{
+			name: "WithEnvVars",
+			env: map[string]string{
+				"PASSWORD_MIN_LENGTH":        "14",
+				"PASSWORD_REQUIRE_UPPERCASE": "true",
+				"PASSWORD_REQUIRE_LOWERCASE": "true",
+				"PASSWORD_REQUIRE_DIGIT":     "true",
+				"PASSWORD_REQUIRE_SYMBOL":    "true",
+				"PASSWORD_CHECK_BREACHED":    "false",
+			},
+			expected: config.PasswordPolicyConfig{
+				MinLength:        14,
+				RequireUppercase: true,
+				RequireLowercase: true,
+				RequireDigit:     true,
+				RequireSymbol:    true,
+			},
+		},
+		{
+			name: "WithoutEnvVars_UseDefaults",
+			env:  map[string]string{},
+			expected: config.PasswordPolicyConfig{
+				MinLength:     constants.DefaultPasswordMinLength,
+				CheckBreached: true,
+			},
+		},
+		{
+			name: "InvalidEnvVars_UseDefaults",
+			env:  map[string]string{"PASSWORD_MIN_LENGTH": "abc", "PASSWORD_REQUIRE_DIGIT": "yes"},
+			expected: config.PasswordPolicyConfig{
+				MinLength:     constants.DefaultPasswordMinLength,
+				CheckBreached: true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range keys {
+				t.Setenv(key, tt.env[key])
+			}
+
+			cfg := config.NewPasswordPolicyConfig()
+
+			require.Equal(t, tt.expected, *cfg)
+		})
+	}
+}
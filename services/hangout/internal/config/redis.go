@@ -0,0 +1,16 @@
+package config
+
+// RedisConfig points at a Redis compatible server.
+type RedisConfig struct {
+	Addr     string
+	Password string
+	DB       int
+}
+
+func NewRedisConfig() *RedisConfig {
+	return &RedisConfig{
+		Addr:     getEnv("REDIS_ADDR", "localhost:6379"),
+		Password: getEnv("REDIS_PASSWORD", ""),
+		DB:       getEnvInt("REDIS_DB", 0),
+	}
+}
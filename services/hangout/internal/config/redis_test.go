@@ -0,0 +1,44 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRedisConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      map[string]string
+		expected config.RedisConfig
+	}{
+		{
+			name:     "WithEnvVars",
+			env:      map[string]string{"REDIS_ADDR": "redis:6380", "REDIS_PASSWORD": "secret", "REDIS_DB": "2"},
+			expected: config.RedisConfig{Addr: "redis:6380", Password: "secret", DB: 2},
+		},
+		{
+			name:     "WithoutEnvVars_UseDefaults",
+			env:      map[string]string{},
+			expected: config.RedisConfig{Addr: "localhost:6379", Password: "", DB: 0},
+		},
+		{
+			name:     "InvalidDB_UseDefault",
+			env:      map[string]string{"REDIS_DB": "abc"},
+			expected: config.RedisConfig{Addr: "localhost:6379", Password: "", DB: 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("REDIS_ADDR", tt.env["REDIS_ADDR"])
+			t.Setenv("REDIS_PASSWORD", tt.env["REDIS_PASSWORD"])
+			t.Setenv("REDIS_DB", tt.env["REDIS_DB"])
+
+			cfg := config.NewRedisConfig()
+
+			require.Equal(t, tt.expected, *cfg)
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package config
+
+import (
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+)
+
+type ReminderConfig struct {
+	IntervalSeconds   int
+	OffsetsMinutes    []int
+	RSVPOffsetMinutes int
+	BatchSize         int
+	MaxAttempts       int
+	RetryDelaySeconds int
+	Channels          []string
+}
+
+func NewReminderConfig() *ReminderConfig {
+	return &ReminderConfig{
+		IntervalSeconds:   getEnvInt("REMINDER_INTERVAL_SECONDS", constants.DefaultReminderIntervalSeconds),
+		OffsetsMinutes:    getEnvIntList("REMINDER_OFFSETS_MINUTES", constants.DefaultReminderOffsetsMinutes),
+		RSVPOffsetMinutes: getEnvInt("REMINDER_RSVP_OFFSET_MINUTES", constants.DefaultReminderRSVPOffsetMinutes),
+		BatchSize:         getEnvInt("REMINDER_BATCH_SIZE", constants.DefaultReminderBatchSize),
+		MaxAttempts:       getEnvInt("REMINDER_MAX_ATTEMPTS", constants.DefaultReminderMaxAttempts),
+		RetryDelaySeconds: getEnvInt("REMINDER_RETRY_DELAY_SECONDS", constants.DefaultReminderRetryDelaySeconds),
+		Channels:          getEnvList("REMINDER_CHANNELS", constants.DefaultReminderChannels),
+	}
+}
+
+func (c *ReminderConfig) GetInterval() time.Duration {
+	return time.Duration(c.IntervalSeconds) * time.Second
+}
+
+func (c *ReminderConfig) GetRetryDelay() time.Duration {
+	return time.Duration(c.RetryDelaySeconds) * time.Second
+}
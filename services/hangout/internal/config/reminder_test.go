@@ -0,0 +1,87 @@
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/config"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewReminderConfig(t *testing.T) {
+	keys := []string{
+		"REMINDER_INTERVAL_SECONDS",
+		"REMINDER_OFFSETS_MINUTES",
+		"REMINDER_RSVP_OFFSET_MINUTES",
+		"REMINDER_BATCH_SIZE",
+		"REMINDER_MAX_ATTEMPTS",
+		"REMINDER_RETRY_DELAY_SECONDS",
+		"REMINDER_CHANNELS",
+	}
+
+	defaults := config.ReminderConfig{
+		IntervalSeconds:   constants.DefaultReminderIntervalSeconds,
+		OffsetsMinutes:    constants.DefaultReminderOffsetsMinutes,
+		RSVPOffsetMinutes: constants.DefaultReminderRSVPOffsetMinutes,
+		BatchSize:         constants.DefaultReminderBatchSize,
+		MaxAttempts:       constants.DefaultReminderMaxAttempts,
+		RetryDelaySeconds: constants.DefaultReminderRetryDelaySeconds,
+		Channels:          constants.DefaultReminderChannels,
+	}
+
+	tests := []struct {
+		name     string
+		env      map[string]string
+		expected config.ReminderConfig
+	}{
+		{
+			name: "WithEnvVars",
+			env: map[string]string{
+				"REMINDER_INTERVAL_SECONDS":    "30",
+				"REMINDER_OFFSETS_MINUTES":     "2880, 180,15",
+				"REMINDER_RSVP_OFFSET_MINUTES": "0",
+				"REMINDER_BATCH_SIZE":          "10",
+				"REMINDER_MAX_ATTEMPTS":        "2",
+				"REMINDER_RETRY_DELAY_SECONDS": "90",
+				"REMINDER_CHANNELS":            "inbox",
+			},
+			expected: config.ReminderConfig{
+				IntervalSeconds:   30,
+				OffsetsMinutes:    []int{2880, 180, 15},
+				RSVPOffsetMinutes: 0,
+				BatchSize:         10,
+				MaxAttempts:       2,
+				RetryDelaySeconds: 90,
+				Channels:          []string{"inbox"},
+			},
+		},
+		{
+			name: "InvalidOffsets_UseDefaults",
+			env: map[string]string{
+				"REMINDER_OFFSETS_MINUTES": "60,soon",
+				"REMINDER_CHANNELS":        " , ",
+			},
+			expected: defaults,
+		},
+		{
+			name:     "WithoutEnvVars_UseDefaults",
+			env:      map[string]string{},
+			expected: defaults,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range keys {
+				t.Setenv(key, tt.env[key])
+			}
+
+			cfg := config.NewReminderConfig()
+
+			require.Equal(t, tt.expected, *cfg)
+			require.Equal(t, time.Duration(tt.expected.IntervalSeconds)*time.Second, cfg.GetInterval())
+			require.Equal(t, time.Duration(tt.expected.RetryDelaySeconds)*time.Second, cfg.GetRetryDelay())
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package config
+
+import (
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+)
+
+// SessionConfig controls signed in sessions.
+type SessionConfig struct {
+	CacheTTLSeconds        int
+	CleanupIntervalMinutes int
+}
+
+func NewSessionConfig() *SessionConfig {
+	return &SessionConfig{
+		CacheTTLSeconds:        getEnvInt("SESSION_CACHE_TTL_SECONDS", constants.DefaultSessionCacheTTLSeconds),
+		CleanupIntervalMinutes: getEnvInt("SESSION_CLEANUP_INTERVAL_MINUTES", constants.DefaultSessionCleanupIntervalMinutes),
+	}
+}
+
+func (c *SessionConfig) GetCacheTTL() time.Duration {
+	return time.Duration(c.CacheTTLSeconds) * time.Second
+}
+
+func (c *SessionConfig) GetCleanupInterval() time.Duration {
+	return time.Duration(c.CleanupIntervalMinutes) * time.Minute
+}
@@ -0,0 +1,54 @@
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/config"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSessionConfig(t *testing.T) {
+	keys := []string{"SESSION_CACHE_TTL_SECONDS", "SESSION_CLEANUP_INTERVAL_MINUTES"}
+
+	tests := []struct {
+		name     string
+		env      map[string]string
+		expected config.SessionConfig
+	}{
+		{
+			name: "WithEnvVars",
+			env: map[string]string{
+				"SESSION_CACHE_TTL_SECONDS":        "10",
+				"SESSION_CLEANUP_INTERVAL_MINUTES": "15",
+			},
+			expected: config.SessionConfig{
+				CacheTTLSeconds:        10,
+				CleanupIntervalMinutes: 15,
+			},
+		},
+		{
+			name: "WithoutEnvVars_UseDefaults",
+			env:  map[string]string{},
+			expected: config.SessionConfig{
+				CacheTTLSeconds:        constants.DefaultSessionCacheTTLSeconds,
+				CleanupIntervalMinutes: constants.DefaultSessionCleanupIntervalMinutes,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range keys {
+				t.Setenv(key, tt.env[key])
+			}
+
+			cfg := config.NewSessionConfig()
+
+			require.Equal(t, tt.expected, *cfg)
+			require.Equal(t, time.Duration(tt.expected.CacheTTLSeconds)*time.Second, cfg.GetCacheTTL())
+			require.Equal(t, time.Duration(tt.expected.CleanupIntervalMinutes)*time.Minute, cfg.GetCleanupInterval())
+		})
+	}
+}
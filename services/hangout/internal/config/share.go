@@ -0,0 +1,31 @@
+package config
+
+import (
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+)
+
+// ShareConfig limits the public share link routes.
+type ShareConfig struct {
+	RateLimitPerMinute int
+	RateLimitBurst     int
+	MaxExpiryDays      int
+}
+
+func NewShareConfig() *ShareConfig {
+	return &ShareConfig{
+		RateLimitPerMinute: getEnvInt("SHARE_RATE_LIMIT_PER_MINUTE", constants.DefaultShareRateLimitPerMinute),
+		RateLimitBurst:     getEnvInt("SHARE_RATE_LIMIT_BURST", constants.DefaultShareRateLimitBurst),
+		MaxExpiryDays:      getEnvInt("SHARE_MAX_EXPIRY_DAYS", constants.DefaultShareMaxExpiryDays),
+	}
+}
+
+// GetRateLimit returns the sustained number of requests allowed per second.
+func (c *ShareConfig) GetRateLimit() float64 {
+	return float64(c.RateLimitPerMinute) / 60
+}
+
+func (c *ShareConfig) GetMaxExpiry() time.Duration {
+	return time.Duration(c.MaxExpiryDays) * 24 * time.Hour
+}
@@ -0,0 +1,58 @@
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/config"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewShareConfig(t *testing.T) {
+	tests := []struct {
+		name              string
+		env               map[string]string
+		expectedPerMinute int
+		expectedBurst     int
+		expectedExpiry    int
+	}{
+		{
+			name:              "WithEnvVars",
+			env:               map[string]string{"SHARE_RATE_LIMIT_PER_MINUTE": "120", "SHARE_RATE_LIMIT_BURST": "20", "SHARE_MAX_EXPIRY_DAYS": "7"},
+			expectedPerMinute: 120,
+			expectedBurst:     20,
+			expectedExpiry:    7,
+		},
+		{
+			name:              "WithoutEnvVars_UseDefaults",
+			env:               map[string]string{},
+			expectedPerMinute: constants.DefaultShareRateLimitPerMinute,
+			expectedBurst:     constants.DefaultShareRateLimitBurst,
+			expectedExpiry:    constants.DefaultShareMaxExpiryDays,
+		},
+		{
+			name:              "InvalidEnvVars_UseDefaults",
+			env:               map[string]string{"SHARE_RATE_LIMIT_PER_MINUTE": "abc", "SHARE_RATE_LIMIT_BURST": "abc", "SHARE_MAX_EXPIRY_DAYS": "abc"},
+			expectedPerMinute: constants.DefaultShareRateLimitPerMinute,
+			expectedBurst:     constants.DefaultShareRateLimitBurst,
+			expectedExpiry:    constants.DefaultShareMaxExpiryDays,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("SHARE_RATE_LIMIT_PER_MINUTE", tt.env["SHARE_RATE_LIMIT_PER_MINUTE"])
+			t.Setenv("SHARE_RATE_LIMIT_BURST", tt.env["SHARE_RATE_LIMIT_BURST"])
+			t.Setenv("SHARE_MAX_EXPIRY_DAYS", tt.env["SHARE_MAX_EXPIRY_DAYS"])
+
+			cfg := config.NewShareConfig()
+
+			require.Equal(t, tt.expectedPerMinute, cfg.RateLimitPerMinute)
+			require.Equal(t, tt.expectedBurst, cfg.RateLimitBurst)
+			require.Equal(t, tt.expectedExpiry, cfg.MaxExpiryDays)
+			require.InDelta(t, float64(tt.expectedPerMinute)/60, cfg.GetRateLimit(), 1e-9)
+			require.Equal(t, time.Duration(tt.expectedExpiry)*24*time.Hour, cfg.GetMaxExpiry())
+		})
+	}
+}
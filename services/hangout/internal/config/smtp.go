@@ -0,0 +1,36 @@
+package config
+
+import (
+	"net"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+)
+
+type SMTPConfig struct {
+	Host           string
+	Port           string
+	Username       string
+	Password       string
+	From           string
+	TimeoutSeconds int
+}
+
+func NewSMTPConfig() *SMTPConfig {
+	return &SMTPConfig{
+		Host:           getEnv("SMTP_HOST", constants.DefaultSMTPHost),
+		Port:           getEnv("SMTP_PORT", constants.DefaultSMTPPort),
+		Username:       getEnv("SMTP_USERNAME", ""),
+		Password:       getEnv("SMTP_PASSWORD", ""),
+		From:           getEnv("SMTP_FROM", constants.DefaultSMTPFrom),
+		TimeoutSeconds: getEnvInt("SMTP_TIMEOUT_SECONDS", constants.DefaultSMTPTimeoutSeconds),
+	}
+}
+
+func (c *SMTPConfig) GetAddress() string {
+	return net.JoinHostPort(c.Host, c.Port)
+}
+
+func (c *SMTPConfig) GetTimeout() time.Duration {
+	return time.Duration(c.TimeoutSeconds) * time.Second
+}
@@ -0,0 +1,67 @@
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/config"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSMTPConfig(t *testing.T) {
+	keys := []string{"SMTP_HOST", "SMTP_PORT", "SMTP_USERNAME", "SMTP_PASSWORD", "SMTP_FROM", "SMTP_TIMEOUT_SECONDS"}
+
+	tests := []struct {
+		name            string
+		env             map[string]string
+		expected        config.SMTPConfig
+		expectedAddress string
+	}{
+		{
+			name: "WithEnvVars",
+			env: map[string]string{
+				"SMTP_HOST":            "smtp.example.com",
+				"SMTP_PORT":            "587",
+				"SMTP_USERNAME":        "mailer",
+				"SMTP_PASSWORD":        "secret",
+				"SMTP_FROM":            "Hangouts <hello@example.com>",
+				"SMTP_TIMEOUT_SECONDS": "3",
+			},
+			expected: config.SMTPConfig{
+				Host:           "smtp.example.com",
+				Port:           "587",
+				Username:       "mailer",
+				Password:       "secret",
+				From:           "Hangouts <hello@example.com>",
+				TimeoutSeconds: 3,
+			},
+			expectedAddress: "smtp.example.com:587",
+		},
+		{
+			name: "WithoutEnvVars_UseDefaults",
+			env:  map[string]string{},
+			expected: config.SMTPConfig{
+				Host:           constants.DefaultSMTPHost,
+				Port:           constants.DefaultSMTPPort,
+				From:           constants.DefaultSMTPFrom,
+				TimeoutSeconds: constants.DefaultSMTPTimeoutSeconds,
+			},
+			expectedAddress: constants.DefaultSMTPHost + ":" + constants.DefaultSMTPPort,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range keys {
+				t.Setenv(key, tt.env[key])
+			}
+
+			cfg := config.NewSMTPConfig()
+
+			require.Equal(t, tt.expected, *cfg)
+			require.Equal(t, tt.expectedAddress, cfg.GetAddress())
+			require.Equal(t, time.Duration(tt.expected.TimeoutSeconds)*time.Second, cfg.GetTimeout())
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package config
+
+import (
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+)
+
+type TrashConfig struct {
+	RetentionDays        int
+	PurgeIntervalMinutes int
+	PurgeBatchSize       int
+}
+
+func NewTrashConfig() *TrashConfig {
+	return &TrashConfig{
+		RetentionDays:        getEnvInt("TRASH_RETENTION_DAYS", constants.DefaultTrashRetentionDays),
+		PurgeIntervalMinutes: getEnvInt("TRASH_PURGE_INTERVAL_MINUTES", constants.DefaultTrashPurgeIntervalMinutes),
+		PurgeBatchSize:       getEnvInt("TRASH_PURGE_BATCH_SIZE", constants.DefaultTrashPurgeBatchSize),
+	}
+}
+
+func (c *TrashConfig) GetRetention() time.Duration {
+	return time.Duration(c.RetentionDays) * 24 * time.Hour
+}
+
+func (c *TrashConfig) GetPurgeInterval() time.Duration {
+	return time.Duration(c.PurgeIntervalMinutes) * time.Minute
+}
@@ -0,0 +1,58 @@
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/config"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTrashConfig(t *testing.T) {
+	tests := []struct {
+		name              string
+		env               map[string]string
+		expectedRetention int
+		expectedInterval  int
+		expectedBatchSize int
+	}{
+		{
+			name:              "WithEnvVars",
+			env:               map[string]string{"TRASH_RETENTION_DAYS": "7", "TRASH_PURGE_INTERVAL_MINUTES": "15", "TRASH_PURGE_BATCH_SIZE": "10"},
+			expectedRetention: 7,
+			expectedInterval:  15,
+			expectedBatchSize: 10,
+		},
+		{
+			name:              "WithoutEnvVars_UseDefaults",
+			env:               map[string]string{},
+			expectedRetention: constants.DefaultTrashRetentionDays,
+			expectedInterval:  constants.DefaultTrashPurgeIntervalMinutes,
+			expectedBatchSize: constants.DefaultTrashPurgeBatchSize,
+		},
+		{
+			name:              "InvalidEnvVars_UseDefaults",
+			env:               map[string]string{"TRASH_RETENTION_DAYS": "abc", "TRASH_PURGE_INTERVAL_MINUTES": "abc", "TRASH_PURGE_BATCH_SIZE": "abc"},
+			expectedRetention: constants.DefaultTrashRetentionDays,
+			expectedInterval:  constants.DefaultTrashPurgeIntervalMinutes,
+			expectedBatchSize: constants.DefaultTrashPurgeBatchSize,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("TRASH_RETENTION_DAYS", tt.env["TRASH_RETENTION_DAYS"])
+			t.Setenv("TRASH_PURGE_INTERVAL_MINUTES", tt.env["TRASH_PURGE_INTERVAL_MINUTES"])
+			t.Setenv("TRASH_PURGE_BATCH_SIZE", tt.env["TRASH_PURGE_BATCH_SIZE"])
+
+			cfg := config.NewTrashConfig()
+
+			require.Equal(t, tt.expectedRetention, cfg.RetentionDays)
+			require.Equal(t, tt.expectedInterval, cfg.PurgeIntervalMinutes)
+			require.Equal(t, tt.expectedBatchSize, cfg.PurgeBatchSize)
+			require.Equal(t, time.Duration(tt.expectedRetention)*24*time.Hour, cfg.GetRetention())
+			require.Equal(t, time.Duration(tt.expectedInterval)*time.Minute, cfg.GetPurgeInterval())
+		})
+	}
+}
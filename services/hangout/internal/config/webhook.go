@@ -0,0 +1,48 @@
+package config
+
+import (
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+)
+
+type WebhookConfig struct {
+	DispatchIntervalSeconds int
+	DispatchBatchSize       int
+	MaxAttempts             int
+	BackoffBaseSeconds      int
+	BackoffMaxSeconds       int
+	RequestTimeoutSeconds   int
+	AllowPrivateTargets     bool
+}
+
+func NewWebhookConfig() *WebhookConfig {
+	return &WebhookConfig{
+		DispatchIntervalSeconds: getEnvInt("WEBHOOK_DISPATCH_INTERVAL_SECONDS", constants.DefaultWebhookDispatchIntervalSeconds),
+		DispatchBatchSize:       getEnvInt("WEBHOOK_DISPATCH_BATCH_SIZE", constants.DefaultWebhookDispatchBatchSize),
+		MaxAttempts:             getEnvInt("WEBHOOK_MAX_ATTEMPTS", constants.DefaultWebhookMaxAttempts),
+		BackoffBaseSeconds:      getEnvInt("WEBHOOK_BACKOFF_BASE_SECONDS", constants.DefaultWebhookBackoffBaseSeconds),
+		BackoffMaxSeconds:       getEnvInt("WEBHOOK_BACKOFF_MAX_SECONDS", constants.DefaultWebhookBackoffMaxSeconds),
+		RequestTimeoutSeconds:   getEnvInt("WEBHOOK_REQUEST_TIMEOUT_SECONDS", constants.DefaultWebhookRequestTimeoutSeconds),
+		AllowPrivateTargets:     getEnv("WEBHOOK_ALLOW_PRIVATE_TARGETS", "false") == "true",
+	}
+}
+
+func (c *WebhookConfig) GetDispatchInterval() time.Duration {
+	return time.Duration(c.DispatchIntervalSeconds) * time.Second
+}
+
+func (c *WebhookConfig) GetRequestTimeout() time.Duration {
+	return time.Duration(c.RequestTimeoutSeconds) * time.Second
+}
+
+// GetBackoff returns the delay before the next attempt once a delivery has
+// failed attempts times: the base delay doubled per failure, capped at the max.
+func (c *WebhookConfig) GetBackoff(attempts int) time.Duration {
+	backoff := time.Duration(c.BackoffBaseSeconds) * time.Second
+	limit := time.Duration(c.BackoffMaxSeconds) * time.Second
+	for i := 1; i < attempts && backoff < limit; i++ {
+		backoff *= 2
+	}
+	return min(backoff, limit)
+}
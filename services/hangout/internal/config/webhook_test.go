@@ -0,0 +1,86 @@
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/config"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewWebhookConfig(t *testing.T) {
+	keys := []string{
+		"WEBHOOK_DISPATCH_INTERVAL_SECONDS",
+		"WEBHOOK_DISPATCH_BATCH_SIZE",
+		"WEBHOOK_MAX_ATTEMPTS",
+		"WEBHOOK_BACKOFF_BASE_SECONDS",
+		"WEBHOOK_BACKOFF_MAX_SECONDS",
+		"WEBHOOK_REQUEST_TIMEOUT_SECONDS",
+		"WEBHOOK_ALLOW_PRIVATE_TARGETS",
+	}
+
+	tests := []struct {
+		name     string
+		env      map[string]string
+		expected config.WebhookConfig
+	}{
+		{
+			name: "WithEnvVars",
+			env: map[string]string{
+				"WEBHOOK_DISPATCH_INTERVAL_SECONDS": "1",
+				"WEBHOOK_DISPATCH_BATCH_SIZE":       "10",
+				"WEBHOOK_MAX_ATTEMPTS":              "3",
+				"WEBHOOK_BACKOFF_BASE_SECONDS":      "2",
+				"WEBHOOK_BACKOFF_MAX_SECONDS":       "60",
+				"WEBHOOK_REQUEST_TIMEOUT_SECONDS":   "4",
+				"WEBHOOK_ALLOW_PRIVATE_TARGETS":     "true",
+			},
+			expected: config.WebhookConfig{
+				DispatchIntervalSeconds: 1,
+				DispatchBatchSize:       10,
+				MaxAttempts:             3,
+				BackoffBaseSeconds:      2,
+				BackoffMaxSeconds:       60,
+				RequestTimeoutSeconds:   4,
+				AllowPrivateTargets:     true,
+			},
+		},
+		{
+			name: "WithoutEnvVars_UseDefaults",
+			env:  map[string]string{},
+			expected: config.WebhookConfig{
+				DispatchIntervalSeconds: constants.DefaultWebhookDispatchIntervalSeconds,
+				DispatchBatchSize:       constants.DefaultWebhookDispatchBatchSize,
+				MaxAttempts:             constants.DefaultWebhookMaxAttempts,
+				BackoffBaseSeconds:      constants.DefaultWebhookBackoffBaseSeconds,
+				BackoffMaxSeconds:       constants.DefaultWebhookBackoffMaxSeconds,
+				RequestTimeoutSeconds:   constants.DefaultWebhookRequestTimeoutSeconds,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range keys {
+				t.Setenv(key, tt.env[key])
+			}
+
+			cfg := config.NewWebhookConfig()
+
+			require.Equal(t, tt.expected, *cfg)
+			require.Equal(t, time.Duration(tt.expected.DispatchIntervalSeconds)*time.Second, cfg.GetDispatchInterval())
+			require.Equal(t, time.Duration(tt.expected.RequestTimeoutSeconds)*time.Second, cfg.GetRequestTimeout())
+		})
+	}
+}
+
+func TestWebhookConfig_GetBackoff(t *testing.T) {
+	cfg := &config.WebhookConfig{BackoffBaseSeconds: 30, BackoffMaxSeconds: 300}
+
+	require.Equal(t, 30*time.Second, cfg.GetBackoff(1))
+	require.Equal(t, 60*time.Second, cfg.GetBackoff(2))
+	require.Equal(t, 240*time.Second, cfg.GetBackoff(4))
+	require.Equal(t, 300*time.Second, cfg.GetBackoff(5))
+	require.Equal(t, 300*time.Second, cfg.GetBackoff(100))
+}
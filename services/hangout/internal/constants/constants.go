@@ -16,6 +16,112 @@ const (
 
 	// JWT Config - Default environment variable values constants
 	DefaultJWTExpirationHours = 1
+	JWTAlgorithmHS256         = "HS256"
+	JWTAlgorithmRS256         = "RS256"
+	JWTAlgorithmEdDSA         = "EdDSA"
+	DefaultJWTAlgorithm       = JWTAlgorithmHS256
+	DefaultJWTKeyRotationDays = 30
+	RSAKeyBits                = 2048
+	// new signing keys are published this long before they sign, so that
+	// every instance and cached JWKS knows them first
+	SigningKeyPublishLeadMinutes = 60
+	SigningKeyRefreshSeconds     = 60
+	JWKSCacheControl             = "public, max-age=900"
+	SigningKeySealPurpose        = "signing-key-encryption"
+
+	// Trash Config - Default environment variable values constants
+	DefaultTrashRetentionDays        = 30
+	DefaultTrashPurgeIntervalMinutes = 60
+	DefaultTrashPurgeBatchSize       = 50
+
+	// Idempotency Config - Default environment variable values constants
+	DefaultIdempotencyKeyTTLHours            = 24
+	DefaultIdempotencyCleanupIntervalMinutes = 60
+
+	// Events Config - Default environment variable values constants
+	DefaultEventsHeartbeatIntervalSeconds = 15
+	DefaultEventsSubscriberBufferSize     = 32
+
+	// Event Bus Config - Default environment variable values constants
+	DefaultEventBusDriver       = "memory"
+	DefaultEventBusBufferSize   = 256
+	DefaultEventBusStreamMaxLen = 10000
+	EventBusSourceName          = "hangout-service"
+	EventBusConsumerGroup       = "hangout-service"
+
+	// Webhook Config - Default environment variable values constants
+	DefaultWebhookDispatchIntervalSeconds = 5
+	DefaultWebhookDispatchBatchSize       = 50
+	DefaultWebhookMaxAttempts             = 8
+	DefaultWebhookBackoffBaseSeconds      = 30
+	DefaultWebhookBackoffMaxSeconds       = 6 * 60 * 60
+	DefaultWebhookRequestTimeoutSeconds   = 10
+
+	// Reminder Config - Default environment variable values constants
+	DefaultReminderIntervalSeconds   = 60
+	DefaultReminderRSVPOffsetMinutes = 24 * 60
+	DefaultReminderBatchSize         = 50
+	DefaultReminderMaxAttempts       = 5
+	DefaultReminderRetryDelaySeconds = 5 * 60
+
+	// Data Export Config - Default environment variable values constants
+	DefaultDataExportIntervalSeconds = 30
+	DefaultDataExportBatchSize       = 10
+	DefaultDataExportRetentionHours  = 7 * 24
+
+	// SMTP Config - Default environment variable values constants
+	DefaultSMTPHost           = "mailpit"
+	DefaultSMTPPort           = "1025"
+	DefaultSMTPFrom           = "Hangout Planner <no-reply@hangout.local>"
+	DefaultSMTPTimeoutSeconds = 10
+
+	// Mail Config - Default environment variable values constants
+	MailDriverSMTP     = "smtp"
+	MailDriverConsole  = "console"
+	MailDriverFile     = "file"
+	DefaultMailDriver  = MailDriverSMTP
+	DefaultMailFileDir = "tmp/mail"
+
+	// Password Policy Config - Default environment variable values constants
+	DefaultPasswordMinLength = 10
+
+	// Account Config - Default environment variable values constants
+	DefaultEmailVerificationTTLHours = 48
+	DefaultPasswordResetTTLMinutes   = 30
+	DefaultVerifyEmailURL            = "http://localhost:3000/verify-email"
+	DefaultResetPasswordURL          = "http://localhost:3000/reset-password"
+
+	// MFA Config - Default environment variable values constants
+	DefaultTOTPIssuer             = "Hangout Planner"
+	DefaultMFAChallengeTTLMinutes = 5
+	DefaultRecoveryCodeCount      = 10
+
+	// Session Config - Default environment variable values constants
+	DefaultSessionCacheTTLSeconds        = 30
+	DefaultSessionCleanupIntervalMinutes = 60
+
+	// OIDC Config - Default environment variable values constants
+	DefaultOIDCScopes          = "openid,email,profile"
+	DefaultOIDCRedirectURL     = "http://localhost:3000/auth/callback"
+	DefaultOIDCStateTTLMinutes = 10
+
+	// Auth Rate Limit Config - Default environment variable values constants
+	RateLimitStoreMemory            = "memory"
+	RateLimitStoreRedis             = "redis"
+	DefaultRateLimitStore           = RateLimitStoreMemory
+	DefaultAuthIPRatePerMinute      = 20
+	DefaultAuthIPBurst              = 10
+	DefaultAuthAccountRatePerMinute = 10
+	DefaultAuthAccountBurst         = 5
+	DefaultAuthMaxFailedAttempts    = 5
+	DefaultAuthFailureWindowMinutes = 15
+	DefaultAuthLockoutBaseSeconds   = 60
+	DefaultAuthLockoutMaxMinutes    = 60
+
+	// Share Config - Default environment variable values constants
+	DefaultShareRateLimitPerMinute = 30
+	DefaultShareRateLimitBurst     = 10
+	DefaultShareMaxExpiryDays      = 90
 
 	// DB Config - Default values constants
 	DefaultDBCharset = "utf8mb4"
@@ -27,12 +133,38 @@ const (
 	GracefulShutdownTimeout = 10 // seconds
 
 	// routes constants
-	HealthCheckRoute = "/healthz"
-	SwaggerRoute     = "/swagger/*"
-	AuthRoutes       = "/auth"
-	HangoutRoutes    = "/hangouts"
-	ActivityRoutes   = "/activities"
-	MemoryRoutes     = "/memories"
+	HealthCheckRoute   = "/healthz"
+	SwaggerRoute       = "/swagger/*"
+	AuthRoutes         = "/auth"
+	MeRoutes           = "/me"
+	HangoutRoutes      = "/hangouts"
+	ActivityRoutes     = "/activities"
+	MemoryRoutes       = "/memories"
+	TrashRoutes        = "/trash"
+	WebhookRoutes      = "/webhooks"
+	NotificationRoutes = "/notifications"
+	CommentRoutes      = "/comments"
+	AlbumRoutes        = "/albums"
+	ShareLinkRoutes    = "/share-links"
+	PublicShareRoutes  = "/public/shares"
+	AdminRoutes        = "/admin"
+	JWKSRoute          = "/.well-known/jwks.json"
+
+	// header constants
+	IdempotencyKeyHeader      = "Idempotency-Key"
+	IdempotencyReplayedHeader = "Idempotent-Replayed"
+	MaxIdempotencyKeyLength   = 255
+	MaxIdempotentBodyBytes    = 1 << 20
+	ETagHeader                = "ETag"
+	IfMatchHeader             = "If-Match"
+	IfNoneMatchHeader         = "If-None-Match"
+	MIMEApplicationMergePatch = "application/merge-patch+json"
+	MIMETextEventStream       = "text/event-stream"
+	WebhookEventHeader        = "X-Hangout-Event"
+	WebhookDeliveryHeader     = "X-Hangout-Delivery"
+	WebhookTimestampHeader    = "X-Hangout-Timestamp"
+	WebhookSignatureHeader    = "X-Hangout-Signature"
+	SharePasswordHeader       = "X-Share-Password"
 
 	//Status constants
 	SuccessStatus = "success"
@@ -40,14 +172,55 @@ const (
 	HealthCheckOK = "OK"
 
 	// message constants
-	UserSignedUpSuccessfully = "User created successfully."
-	UserSignedInSuccessfully = "User signed in successfully."
+	UserSignedUpSuccessfully  = "User created successfully."
+	UserSignedInSuccessfully  = "User signed in successfully."
+	EmailVerifiedSuccessfully = "Email verified successfully."
+	VerificationEmailSent     = "Verification email sent."
+	PasswordResetEmailSent    = "If an account exists for that email, a password reset link has been sent."
+	PasswordResetSuccessfully = "Password reset successfully."
+
+	ProfileRetrievedSuccessfully = "Profile retrieved successfully."
+	ProfileUpdatedSuccessfully   = "Profile updated successfully."
+	PasswordChangedSuccessfully  = "Password changed. Other devices have been signed out."
+	EmailChangedSuccessfully     = "Email changed. Confirm the new address with the link we sent to it."
+	AccountDeletedSuccessfully   = "Account deleted."
+
+	OIDCProvidersRetrievedSuccessfully        = "Sign in providers retrieved successfully."
+	OIDCAuthorizationStarted                  = "Authorization started."
+	IdentityLinkedSuccessfully                = "Identity linked successfully."
+	IdentitiesRetrievedSuccessfully           = "Identities retrieved successfully."
+	IdentityUnlinkedSuccessfully              = "Identity unlinked successfully."
+	MFAChallengeRequired                      = "Enter the code from your authenticator app to finish signing in."
+	TOTPEnrollmentStarted                     = "Scan the QR code and confirm with a code from your authenticator app."
+	TOTPEnabledSuccessfully                   = "Two-factor authentication enabled. Store the recovery codes somewhere safe."
+	TOTPDisabledSuccessfully                  = "Two-factor authentication disabled."
+	RecoveryCodesRegenerated                  = "Recovery codes regenerated. The previous codes no longer work."
+	MFAStatusRetrievedSuccessfully            = "Two-factor authentication status retrieved successfully."
+	SessionsRetrievedSuccessfully             = "Sessions retrieved successfully."
+	SessionRevokedSuccessfully                = "Session signed out successfully."
+	SessionsRevokedSuccessfully               = "Signed out of all sessions."
+	PersonalAccessTokenCreatedSuccessfully    = "Personal access token created successfully."
+	PersonalAccessTokensRetrievedSuccessfully = "Personal access tokens retrieved successfully."
+	PersonalAccessTokenRevokedSuccessfully    = "Personal access token revoked successfully."
+	DataExportRequestedSuccessfully           = "Data export requested. Check its status to download it when ready."
+	DataExportRetrievedSuccessfully           = "Data export retrieved successfully."
+	AdminUsersRetrievedSuccessfully           = "Users retrieved successfully."
+	AdminUserDisabledSuccessfully             = "User disabled successfully."
+	AdminUserEnabledSuccessfully              = "User enabled successfully."
+	AdminPasswordResetForcedSuccessfully      = "Password reset forced. The user has been signed out and sent a reset link."
+	AdminHangoutRetrievedSuccessfully         = "Hangout retrieved successfully."
+	AdminMemoryHiddenSuccessfully             = "Memory hidden successfully."
+	AdminMemoryUnhiddenSuccessfully           = "Memory unhidden successfully."
+	AdminMemoryRemovedSuccessfully            = "Memory removed successfully."
+	AdminAuditLogsRetrievedSuccessfully       = "Audit logs retrieved successfully."
 
 	HangoutCreatedSuccessfully    = "Hangout created successfully."
 	HangoutUpdatedSuccessfully    = "Hangout updated successfully."
 	HangoutRetrievedSuccessfully  = "Hangout retrieved successfully."
 	HangoutDeletedSuccessfully    = "Hangout deleted successfully."
 	HangoutsRetrievedSuccessfully = "Hangouts retrieved successfully."
+	HangoutRestoredSuccessfully   = "Hangout restored successfully."
+	HangoutBatchProcessed         = "Hangout batch processed."
 
 	ActivityCreatedSuccessfully     = "Activity created successfully."
 	ActivityUpdatedSuccessfully     = "Activity updated successfully."
@@ -66,16 +239,211 @@ const (
 	SortDirectionDesc = "desc"
 	SortByCreatedAt   = "created_at"
 	SortByDate        = "date"
+	SortByPosition    = "position"
 
 	// File upload constants
 	MaxFilePerUpload = 10
 
+	// Memory annotation constants
+	MaxMemoryCaptionLength = 500
+	MaxMemoryTagLength     = 50
+	MaxMemoryTags          = 20
+	MaxMemoryPersonTags    = 20
+	MaxReactionEmojiRunes  = 8
+
+	// Album constants
+	MaxAlbumNameLength = 100
+	NoAlbumFilter      = "none"
+
+	// Hangout event constants
+	EventHangoutCreated       = "hangout.created"
+	EventHangoutUpdated       = "hangout.updated"
+	EventHangoutStatusChanged = "hangout.status_changed"
+	EventHangoutDeleted       = "hangout.deleted"
+	EventMemoryCreated        = "memory.created"
+	EventMemoryProcessed      = "memory.processed"
+	EventCommentCreated       = "comment.created"
+	EventStreamExpired        = "stream.expired"
+	EventWebhookTest          = "webhook.test"
+
+	// Webhook constants
+	MaxWebhooksPerUser          = 10
+	WebhookSecretPrefix         = "whsec_"
+	WebhookSignaturePrefix      = "sha256="
+	WebhookUserAgent            = "Hangout-Planner-Webhooks/1.0"
+	MaxWebhookErrorLength       = 500
+	WebhookDeliveryPending      = "pending"
+	WebhookDeliverySucceeded    = "succeeded"
+	WebhookDeliveryDeadLettered = "dead_letter"
+
+	// Rate limit constants
+	MaxRateLimitBodyBytes = 64 << 10
+
+	// Share link constants
+	MaxShareLinksPerHangout    = 20
+	ShareTokenPrefix           = "shr_"
+	ShareScopeHangout          = "hangout"
+	ShareScopeAlbum            = "album"
+	ShareAccessGranted         = "granted"
+	ShareAccessPasswordMissing = "password_missing"
+	ShareAccessPasswordInvalid = "password_invalid"
+	ShareAccessExpired         = "expired"
+	ShareAccessRevoked         = "revoked"
+	MaxShareUserAgentLength    = 255
+
+	// Account constants
+	ActionTokenEmailVerification = "email_verification"
+	ActionTokenPasswordReset     = "password_reset"
+	ActionTokenMFAChallenge      = "mfa_challenge"
+	ActionTokenQueryParam        = "token"
+	MaxPasswordBytes             = 72
+	DeletedUserEmailFormat       = "deleted-%s@deleted.invalid"
+
+	// MFA constants
+	TOTPSecretSealPurpose = "totp-secret"
+	TOTPSecretBytes       = 20
+	TOTPDigits            = 6
+	TOTPPeriodSeconds     = 30
+	TOTPAllowedSkewSteps  = 1
+	RecoveryCodeLength    = 10
+	MFALockoutKeyPrefix   = "mfa:"
+
+	// Session constants
+	SessionTouchIntervalSeconds = 60
+	MaxSessionUserAgentLength   = 255
+	RecentSignInMinutes         = 10
+
+	// Personal access token constants
+	PersonalAccessTokenPrefix       = "hpat_"
+	MaxPersonalAccessTokensPerUser  = 20
+	PersonalAccessTokenTouchSeconds = 60
+	ScopeHangoutsRead               = "hangouts:read"
+	ScopeHangoutsWrite              = "hangouts:write"
+	ScopeActivitiesRead             = "activities:read"
+	ScopeActivitiesWrite            = "activities:write"
+	ScopeMemoriesRead               = "memories:read"
+	ScopeMemoriesWrite              = "memories:write"
+	ScopeCommentsRead               = "comments:read"
+	ScopeCommentsWrite              = "comments:write"
+
+	// OIDC constants
+	OIDCStateSealPurpose      = "oidc-state"
+	OIDCRequestTimeoutSeconds = 10
+	MaxOIDCUserInfoBytes      = 1 << 20
+	OIDCBindingCookie         = "oidc_binding"
+
+	// Reminder constants
+	ReminderKindHangoutStart  = "hangout_start"
+	ReminderKindRSVPDeadline  = "rsvp_deadline"
+	ReminderPending           = "pending"
+	ReminderSent              = "sent"
+	ReminderFailed            = "failed"
+	ReminderCancelled         = "cancelled"
+	ReminderClaimLeaseSeconds = 120
+	MaxReminderErrorLength    = 500
+
+	// Data export constants
+	DataExportPending           = "pending"
+	DataExportProcessing        = "processing"
+	DataExportCompleted         = "completed"
+	DataExportFailed            = "failed"
+	DataExportClaimLeaseSeconds = 300
+	DataExportStoragePath       = "users/%s/exports"
+	DataExportArchiveName       = "hangout-planner-export-%s"
+	DataExportFileName          = "hangout-planner-export-%s.json"
+
+	// Admin constants
+	RoleUser                  = "user"
+	RoleAdmin                 = "admin"
+	MaxAdminReasonLength      = 500
+	MaxAdminSearchQueryLength = 255
+	AuditTargetUser           = "user"
+	AuditTargetHangout        = "hangout"
+	AuditTargetMemory         = "memory"
+	AuditActionSearchUsers    = "user.search"
+	AuditActionDisableUser    = "user.disable"
+	AuditActionEnableUser     = "user.enable"
+	AuditActionResetPassword  = "user.password_reset"
+	AuditActionViewHangout    = "hangout.view"
+	AuditActionHideMemory     = "memory.hide"
+	AuditActionUnhideMemory   = "memory.unhide"
+	AuditActionRemoveMemory   = "memory.remove"
+
+	// Notification constants
+	NotificationChannelEmail    = "email"
+	NotificationChannelInbox    = "inbox"
+	NotificationHangoutReminder = "hangout.reminder"
+	NotificationRSVPDeadline    = "hangout.rsvp_deadline"
+	NotificationStatusChanged   = "hangout.status_changed"
+	NotificationMemoryCreated   = "memory.created"
+	NotificationCommentCreated  = "comment.created"
+	NotificationCommentMention  = "comment.mention"
+
+	// Hangout batch constants
+	MaxHangoutBatchSize     = 50
+	BatchOpCreate           = "create"
+	BatchOpUpdateStatus     = "update_status"
+	BatchOpAddActivities    = "add_activities"
+	BatchOpRemoveActivities = "remove_activities"
+	BatchOpDelete           = "delete"
+	BatchResultSucceeded    = "succeeded"
+	BatchResultFailed       = "failed"
+
 	// Memory message constants
 	MemoryRetrievedSuccessfully     = "Memory retrieved successfully."
 	MemoriesRetrievedSuccessfully   = "Memories retrieved successfully."
 	MemoryDeletedSuccessfully       = "Memory deleted successfully."
 	UploadURLsGeneratedSuccessfully = "Upload URLs generated successfully."
 	UploadConfirmedSuccessfully     = "Upload confirmed successfully."
+	MemoryRestoredSuccessfully      = "Memory restored successfully."
+	MemoryUpdatedSuccessfully       = "Memory updated successfully."
+	ReactionAddedSuccessfully       = "Reaction added successfully."
+	ReactionRemovedSuccessfully     = "Reaction removed successfully."
+	MemoryMovedSuccessfully         = "Memory moved successfully."
+
+	// Album message constants
+	AlbumCreatedSuccessfully    = "Album created successfully."
+	AlbumUpdatedSuccessfully    = "Album updated successfully."
+	AlbumDeletedSuccessfully    = "Album deleted successfully."
+	AlbumsRetrievedSuccessfully = "Albums retrieved successfully."
+
+	// Archive message constants
+	ArchiveRequestedSuccessfully = "Archive requested successfully."
+	ArchiveRetrievedSuccessfully = "Archive retrieved successfully."
+
+	// Share link message constants
+	ShareLinkCreatedSuccessfully           = "Share link created successfully."
+	ShareLinksRetrievedSuccessfully        = "Share links retrieved successfully."
+	ShareLinkRevokedSuccessfully           = "Share link revoked successfully."
+	ShareLinkAccessesRetrievedSuccessfully = "Share link accesses retrieved successfully."
+	SharedHangoutRetrievedSuccessfully     = "Shared hangout retrieved successfully."
+	SharedMemoriesRetrievedSuccessfully    = "Shared memories retrieved successfully."
+
+	// Webhook message constants
+	WebhookCreatedSuccessfully             = "Webhook created successfully."
+	WebhookRetrievedSuccessfully           = "Webhook retrieved successfully."
+	WebhooksRetrievedSuccessfully          = "Webhooks retrieved successfully."
+	WebhookDeletedSuccessfully             = "Webhook deleted successfully."
+	WebhookDeliveriesRetrievedSuccessfully = "Webhook deliveries retrieved successfully."
+	WebhookTestEventQueued                 = "Webhook test event queued."
+
+	// Notification message constants
+	NotificationsRetrievedSuccessfully           = "Notifications retrieved successfully."
+	NotificationUpdatedSuccessfully              = "Notification updated successfully."
+	NotificationsMarkedReadSuccessfully          = "Notifications marked as read."
+	UnreadNotificationCountRetrievedSuccessfully = "Unread notification count retrieved successfully."
+	NotificationPreferencesRetrievedSuccessfully = "Notification preferences retrieved successfully."
+	NotificationPreferencesUpdatedSuccessfully   = "Notification preferences updated successfully."
+
+	// Comment message constants
+	CommentCreatedSuccessfully    = "Comment created successfully."
+	CommentUpdatedSuccessfully    = "Comment updated successfully."
+	CommentDeletedSuccessfully    = "Comment deleted successfully."
+	CommentsRetrievedSuccessfully = "Comments retrieved successfully."
+
+	// Trash message constants
+	DeletedHangoutsRetrievedSuccessfully = "Deleted hangouts retrieved successfully."
+	DeletedMemoriesRetrievedSuccessfully = "Deleted memories retrieved successfully."
 
 	// grpc client default configs
 	DefaultFileServiceURL = "file:9001"
@@ -89,3 +457,21 @@ const (
 	DefaultOTELUseStdout      = "false"
 	DefaultTraceSampleRate    = 1.0
 )
+
+// Values that cannot be constants
+var (
+	DefaultReminderOffsetsMinutes = []int{24 * 60, 60}
+	DefaultReminderChannels       = []string{NotificationChannelEmail, NotificationChannelInbox}
+
+	// NotificationTypes lists the types users can set preferences for.
+	NotificationTypes = []string{
+		NotificationHangoutReminder,
+		NotificationRSVPDeadline,
+		NotificationStatusChanged,
+		NotificationMemoryCreated,
+		NotificationCommentCreated,
+		NotificationCommentMention,
+	}
+	// EmailNotificationTypes are sent by email unless the user opts out.
+	EmailNotificationTypes = []string{NotificationHangoutReminder, NotificationRSVPDeadline}
+)
@@ -21,6 +21,122 @@ const (
 	FileServiceClientInitFailed  = "Failed to initialize file service client: %v"
 )
 
+// Trash purge job
+const (
+	TrashPurgeCompleted = "Purged expired trash: %d memories, %d hangouts"
+	TrashPurgeFailed    = "Failed to purge expired trash: %v"
+)
+
+// Idempotency key cleanup job
+const (
+	IdempotencyCleanupCompleted = "Removed %d expired idempotency keys"
+	IdempotencyCleanupFailed    = "Failed to remove expired idempotency keys: %v"
+	IdempotencyCompleteFailed   = "Failed to store response for idempotency key: %v"
+	IdempotencyReleaseFailed    = "Failed to release idempotency key: %v"
+)
+
+// Session cleanup job and session tracking
+const (
+	SessionCleanupCompleted = "Removed %d expired sessions"
+	SessionCleanupFailed    = "Failed to remove expired sessions: %v"
+	SessionTouchFailed      = "Failed to update last use of session %s: %v"
+)
+
+// Personal access tokens
+const (
+	PersonalAccessTokenTouchFailed = "Failed to update last use of personal access token %s: %v"
+)
+
+// Data export job
+const (
+	DataExportProcessFailed    = "Failed to build data exports: %v"
+	DataExportProcessCompleted = "Data exports built: %d succeeded, %d failed"
+	DataExportBuildFailed      = "Failed to build data export %s: %v"
+	DataExportArchiveFailed    = "Failed to build media archive for data export %s: %s"
+	DataExportPurgeFailed      = "Failed to remove expired data exports: %v"
+	DataExportPurgeCompleted   = "Removed %d expired data exports"
+)
+
+// Admin bootstrap
+const (
+	AdminPromotionFailed    = "Failed to promote admin accounts: %v"
+	AdminPromotionCompleted = "Promoted %d accounts to admin"
+)
+
+// Account emails
+const (
+	MailSenderInitFailed     = "Failed to initialize mail sender: %v"
+	VerificationEmailFailed  = "Failed to send verification email to user %s: %v"
+	PasswordResetEmailFailed = "Failed to send password reset email to user %s: %v"
+	EmailChangeNoticeFailed  = "Failed to send email change notice to user %s: %v"
+)
+
+// Auth rate limiting
+const (
+	RateLimitStoreInitFailed  = "Failed to initialize rate limit store: %v"
+	RateLimitStoreCloseFailed = "Failed to close rate limit store: %v"
+	RateLimitCheckFailed      = "Rate limit check failed, allowing request: %v"
+	SignInAttemptRecordFailed = "Failed to record sign in attempt: %v"
+	AccountLockedOut          = "Account locked for %s after repeated failed sign ins"
+)
+
+// JWT signing keys
+const (
+	SigningKeyInitFailed     = "Failed to initialize JWT signing keys: %v"
+	SigningKeyRotationFailed = "Failed to rotate JWT signing keys: %v"
+	SigningKeyCreated        = "Created JWT signing key %s (%s), active from %s"
+	SigningKeyInvalid        = "Skipping JWT signing key %s: %v"
+	SigningKeyVerifyOnly     = "JWT signing key %s can only verify tokens, its private key cannot be opened: %v"
+)
+
+// Two-factor authentication
+const (
+	MFAInitFailed = "Failed to initialize two-factor authentication: %v"
+)
+
+// External sign in
+const (
+	OIDCInitFailed = "Failed to initialize OIDC providers: %v"
+)
+
+// Share links
+const (
+	ShareAccessRecordFailed = "Failed to record access to share link %s: %v"
+)
+
+// Hangout events
+const (
+	EventPublishFailed     = "Failed to publish %s event for hangout %s: %v"
+	EventBrokerCloseFailed = "Failed to close event broker: %v"
+)
+
+// Event bus
+const (
+	EventBusInitFailed  = "Failed to initialize event bus: %v"
+	EventHandlerFailed  = "Failed to handle event on %s: %v"
+	EventBusCloseFailed = "Failed to close event bus: %v"
+
+	EventBusInProcessOnly = "Event bus uses the memory driver, events from other services are not received"
+)
+
+// Webhook delivery job
+const (
+	WebhookDeliveryCompleted = "Webhook deliveries attempted: %d succeeded, %d failed"
+	WebhookDeliveryFailed    = "Failed to deliver webhooks: %v"
+)
+
+// Reminder job
+const (
+	ReminderChannelsInvalid = "Invalid reminder channels: %v"
+	ReminderScheduleFailed  = "Failed to schedule reminders: %v"
+	ReminderScheduled       = "Reminders scheduled: %d"
+	ReminderSendFailed      = "Failed to send reminders: %v"
+	ReminderSendCompleted   = "Reminders attempted: %d sent, %d failed"
+
+	NotificationEmailSendFailed    = "Failed to send notification emails: %v"
+	NotificationEmailSendCompleted = "Notification emails attempted: %d sent, %d failed"
+)
+
 // otel constants
 const (
 	OTELTracerProviderInitFailed = "Failed to initialize OTEL tracer provider: %v"
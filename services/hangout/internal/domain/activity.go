@@ -10,6 +10,7 @@ import (
 type Activity struct {
 	ID        uuid.UUID `gorm:"primaryKey;type:char(36)"`
 	Name      string    `gorm:"type:varchar(255);uniqueIndex;not null"`
+	Version   int64     `gorm:"not null;default:1"`
 	CreatedAt time.Time
 	UpdatedAt time.Time
 	DeletedAt gorm.DeletedAt `gorm:"index"`
@@ -22,5 +23,6 @@ type Activity struct {
 
 func (activity *Activity) BeforeCreate(tx *gorm.DB) (err error) {
 	activity.ID = uuid.New()
+	activity.Version = 1
 	return
 }
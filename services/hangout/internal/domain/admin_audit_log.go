@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AdminAuditLog records one action taken by an admin.
+type AdminAuditLog struct {
+	ID         uuid.UUID `gorm:"primaryKey;type:char(36)"`
+	Action     string    `gorm:"type:varchar(50);not null"`
+	TargetType string    `gorm:"type:varchar(20);not null"`
+	// Target is the ID of the user, hangout or memory acted on, or the
+	// query of a user search.
+	Target    string `gorm:"type:varchar(255);not null;index"`
+	Reason    string `gorm:"type:varchar(500);not null"`
+	CreatedAt time.Time
+
+	ActorID uuid.UUID `gorm:"type:char(36);not null;index"`
+	Actor   User      `gorm:"foreignKey:ActorID"`
+}
+
+func (log *AdminAuditLog) BeforeCreate(tx *gorm.DB) (err error) {
+	log.ID = uuid.New()
+	return
+}
@@ -0,0 +1,25 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Album groups memories of a hangout.
+type Album struct {
+	ID            uuid.UUID  `gorm:"primaryKey;type:char(36)"`
+	Name          string     `gorm:"type:varchar(100);not null"`
+	CoverMemoryID *uuid.UUID `gorm:"type:char(36)"`
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+
+	HangoutID uuid.UUID `gorm:"type:char(36);not null;index"`
+	Hangout   Hangout   `gorm:"foreignKey:HangoutID"`
+}
+
+func (album *Album) BeforeCreate(tx *gorm.DB) (err error) {
+	album.ID = uuid.New()
+	return
+}
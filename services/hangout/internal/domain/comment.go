@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Comment is a message on a hangout.
+type Comment struct {
+	ID        uuid.UUID  `gorm:"primaryKey;type:char(36)"`
+	Body      string     `gorm:"type:text;not null"`
+	ParentID  *uuid.UUID `gorm:"type:char(36);index"`
+	EditedAt  *time.Time
+	CreatedAt time.Time `gorm:"index:idx_comments_hangout_created,priority:2"`
+	UpdatedAt time.Time
+
+	HangoutID uuid.UUID `gorm:"type:char(36);not null;index:idx_comments_hangout_created,priority:1"`
+	Hangout   Hangout   `gorm:"foreignKey:HangoutID"`
+
+	UserID uuid.UUID `gorm:"type:char(36);not null"`
+	User   User      `gorm:"foreignKey:UserID"`
+
+	Mentions []CommentMention `gorm:"foreignKey:CommentID"`
+}
+
+func (comment *Comment) BeforeCreate(tx *gorm.DB) (err error) {
+	comment.ID = uuid.New()
+	return
+}
+
+// CommentMention records a user mentioned in a comment.
+type CommentMention struct {
+	CommentID uuid.UUID `gorm:"primaryKey;type:char(36)"`
+	UserID    uuid.UUID `gorm:"primaryKey;type:char(36);index"`
+
+	User User `gorm:"foreignKey:UserID"`
+}
@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DataExport is a copy of a user's data requested from /me/exports.
+type DataExport struct {
+	ID           uuid.UUID `gorm:"primaryKey;type:char(36)"`
+	Status       string    `gorm:"type:varchar(20);not null;index"`
+	ArchiveID    *string   `gorm:"type:char(36)"`
+	LastError    string    `gorm:"type:varchar(500)"`
+	ClaimedUntil *time.Time
+	CompletedAt  *time.Time
+	ExpiresAt    time.Time `gorm:"not null;index"`
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+
+	UserID uuid.UUID `gorm:"type:char(36);not null;index"`
+	User   User      `gorm:"foreignKey:UserID"`
+}
+
+func (export *DataExport) BeforeCreate(tx *gorm.DB) (err error) {
+	export.ID = uuid.New()
+	return
+}
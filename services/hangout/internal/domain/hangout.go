@@ -9,14 +9,16 @@ import (
 )
 
 type Hangout struct {
-	ID          uuid.UUID           `gorm:"primaryKey;type:char(36)"`
-	Title       string              `gorm:"type:varchar(255);not null" json:"title"`
-	Description *string             `gorm:"type:text" json:"description"`
-	Date        time.Time           `gorm:"not null" json:"date"`
-	Status      enums.HangoutStatus `gorm:"type:varchar(50);not null" json:"status"`
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
-	DeletedAt   gorm.DeletedAt `gorm:"index"`
+	ID           uuid.UUID           `gorm:"primaryKey;type:char(36)"`
+	Title        string              `gorm:"type:varchar(255);not null" json:"title"`
+	Description  *string             `gorm:"type:text" json:"description"`
+	Date         time.Time           `gorm:"not null" json:"date"`
+	RSVPDeadline *time.Time          `json:"rsvp_deadline"`
+	Status       enums.HangoutStatus `gorm:"type:varchar(50);not null" json:"status"`
+	Version      int64               `gorm:"not null;default:1"`
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	DeletedAt    gorm.DeletedAt `gorm:"index"`
 
 	UserID *uuid.UUID `gorm:"type:char(36)"`
 	User   User       `gorm:"foreignKey:UserID"`
@@ -26,5 +28,6 @@ type Hangout struct {
 
 func (hangout *Hangout) BeforeCreate(tx *gorm.DB) (err error) {
 	hangout.ID = uuid.New()
+	hangout.Version = 1
 	return
 }
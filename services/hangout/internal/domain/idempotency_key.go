@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// IdempotencyKey stores the outcome of a request sent with an Idempotency-Key header
+// so that retries of the same request can be answered without running it again.
+// A zero ResponseStatus means the original request is still in flight.
+type IdempotencyKey struct {
+	ID             uuid.UUID `gorm:"primaryKey;type:char(36)"`
+	Key            string    `gorm:"type:varchar(255);not null;uniqueIndex:idx_idempotency_user_key,priority:2"`
+	RequestHash    string    `gorm:"type:char(64);not null"`
+	ResponseStatus int       `gorm:"not null;default:0"`
+	// ResponseHeaders holds the headers a replay repeats, such as ETag.
+	ResponseHeaders map[string]string `gorm:"serializer:json;type:text"`
+	ResponseBody    []byte            `gorm:"type:mediumblob"`
+	ExpiresAt       time.Time         `gorm:"not null;index"`
+	CreatedAt       time.Time
+
+	UserID uuid.UUID `gorm:"type:char(36);not null;uniqueIndex:idx_idempotency_user_key,priority:1"`
+	User   User      `gorm:"foreignKey:UserID"`
+}
+
+func (key *IdempotencyKey) BeforeCreate(tx *gorm.DB) (err error) {
+	key.ID = uuid.New()
+	return
+}
+
+func (key *IdempotencyKey) IsCompleted() bool {
+	return key.ResponseStatus != 0
+}
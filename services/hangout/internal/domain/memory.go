@@ -8,9 +8,12 @@ import (
 )
 
 type Memory struct {
-	ID        uuid.UUID  `gorm:"primaryKey;type:char(36)"`
-	Name      string     `gorm:"type:varchar(255);not null;uniqueIndex:idx_hangout_name,priority:2"`
-	FileID    *uuid.UUID `gorm:"type:char(36);index"`
+	ID      uuid.UUID  `gorm:"primaryKey;type:char(36)"`
+	Name    string     `gorm:"type:varchar(255);not null;uniqueIndex:idx_hangout_name,priority:2"`
+	Caption *string    `gorm:"type:varchar(500)"`
+	FileID  *uuid.UUID `gorm:"type:char(36);index"`
+	// HiddenAt is set while an admin has hidden the memory.
+	HiddenAt  *time.Time
 	CreatedAt time.Time
 	UpdatedAt time.Time
 	DeletedAt gorm.DeletedAt `gorm:"index"`
@@ -20,9 +23,44 @@ type Memory struct {
 
 	UserID uuid.UUID `gorm:"type:char(36);not null"`
 	User   User      `gorm:"foreignKey:UserID"`
+
+	// Position is a fractional index key (see package ordering) giving the manual
+	// order of the memory within its album, or within the loose memories of the
+	// hangout when AlbumID is nil.
+	AlbumID  *uuid.UUID `gorm:"type:char(36);index:idx_memories_album_position,priority:1"`
+	Album    *Album     `gorm:"foreignKey:AlbumID"`
+	Position string     `gorm:"type:varchar(64) CHARACTER SET ascii COLLATE ascii_bin;not null;index:idx_memories_album_position,priority:2"`
+
+	Tags       []MemoryTag       `gorm:"foreignKey:MemoryID"`
+	PersonTags []MemoryPersonTag `gorm:"foreignKey:MemoryID"`
+	Reactions  []MemoryReaction  `gorm:"foreignKey:MemoryID"`
 }
 
 func (memory *Memory) BeforeCreate(tx *gorm.DB) (err error) {
 	memory.ID = uuid.New()
 	return
 }
+
+// MemoryTag is a free-form label on a memory.
+type MemoryTag struct {
+	MemoryID uuid.UUID `gorm:"primaryKey;type:char(36)"`
+	Tag      string    `gorm:"primaryKey;type:varchar(50);index"`
+}
+
+// MemoryPersonTag records a participant of the hangout who appears in a memory.
+type MemoryPersonTag struct {
+	MemoryID uuid.UUID `gorm:"primaryKey;type:char(36)"`
+	UserID   uuid.UUID `gorm:"primaryKey;type:char(36);index"`
+
+	User User `gorm:"foreignKey:UserID"`
+}
+
+// MemoryReaction is one emoji reaction of a user.
+type MemoryReaction struct {
+	MemoryID  uuid.UUID `gorm:"primaryKey;type:char(36)"`
+	UserID    uuid.UUID `gorm:"primaryKey;type:char(36);index"`
+	Emoji     string    `gorm:"primaryKey;type:varchar(32)"`
+	CreatedAt time.Time
+
+	User User `gorm:"foreignKey:UserID"`
+}
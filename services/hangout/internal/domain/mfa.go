@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TOTPCredential is a user's authenticator app secret, stored encrypted.
+type TOTPCredential struct {
+	ID           uuid.UUID `gorm:"primaryKey;type:char(36)"`
+	Secret       []byte    `gorm:"type:blob;not null"`
+	ConfirmedAt  *time.Time
+	LastUsedStep int64 `gorm:"not null;default:0"`
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+
+	UserID uuid.UUID `gorm:"type:char(36);not null;uniqueIndex"`
+	User   User      `gorm:"foreignKey:UserID"`
+}
+
+func (credential *TOTPCredential) BeforeCreate(tx *gorm.DB) (err error) {
+	if credential.ID == uuid.Nil {
+		credential.ID = uuid.New()
+	}
+	return
+}
+
+// RecoveryCode is a one-time code that replaces the authenticator app when
+// it is lost. Only the SHA-256 of the code is stored.
+type RecoveryCode struct {
+	ID        uuid.UUID `gorm:"primaryKey;type:char(36)"`
+	CodeHash  string    `gorm:"type:char(64);not null;index:idx_recovery_codes_user_code,priority:2"`
+	UsedAt    *time.Time
+	CreatedAt time.Time
+
+	UserID uuid.UUID `gorm:"type:char(36);not null;index:idx_recovery_codes_user_code,priority:1"`
+	User   User      `gorm:"foreignKey:UserID"`
+}
+
+func (code *RecoveryCode) BeforeCreate(tx *gorm.DB) (err error) {
+	code.ID = uuid.New()
+	return
+}
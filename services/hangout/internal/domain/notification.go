@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Notification is an entry in a user's in-app inbox.
+type Notification struct {
+	ID        uuid.UUID `gorm:"primaryKey;type:char(36)"`
+	Type      string    `gorm:"type:varchar(64);not null"`
+	Title     string    `gorm:"type:varchar(255);not null"`
+	Body      string    `gorm:"type:text"`
+	ReadAt    *time.Time
+	CreatedAt time.Time `gorm:"index:idx_notifications_user_created,priority:2"`
+
+	HangoutID *uuid.UUID `gorm:"type:char(36);index"`
+	Hangout   *Hangout   `gorm:"foreignKey:HangoutID"`
+
+	UserID uuid.UUID `gorm:"type:char(36);not null;index:idx_notifications_user_created,priority:1"`
+	User   User      `gorm:"foreignKey:UserID"`
+}
+
+func (notification *Notification) BeforeCreate(tx *gorm.DB) (err error) {
+	notification.ID = uuid.New()
+	return
+}
+
+// NotificationPreference records on which channels a user wants to receive a
+// notification type. Types without a row use the service defaults.
+type NotificationPreference struct {
+	UserID    uuid.UUID `gorm:"primaryKey;type:char(36)"`
+	Type      string    `gorm:"primaryKey;type:varchar(64)"`
+	Email     bool      `gorm:"not null"`
+	Inbox     bool      `gorm:"not null"`
+	UpdatedAt time.Time
+
+	User User `gorm:"foreignKey:UserID"`
+}
+
+// NotificationEmail is an event notification waiting to be mailed.
+type NotificationEmail struct {
+	ID            uuid.UUID  `gorm:"primaryKey;type:char(36)"`
+	Type          string     `gorm:"type:varchar(64);not null"`
+	Subject       string     `gorm:"type:varchar(255);not null"`
+	Body          string     `gorm:"type:text"`
+	Status        string     `gorm:"type:varchar(20);not null;index:idx_notification_emails_due,priority:1"`
+	Attempts      int        `gorm:"not null;default:0"`
+	NextAttemptAt *time.Time `gorm:"index:idx_notification_emails_due,priority:2"`
+	LastError     string     `gorm:"type:varchar(500)"`
+	SentAt        *time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+
+	HangoutID *uuid.UUID `gorm:"type:char(36);index"`
+	Hangout   *Hangout   `gorm:"foreignKey:HangoutID"`
+
+	UserID uuid.UUID `gorm:"type:char(36);not null;index"`
+	User   User      `gorm:"foreignKey:UserID"`
+}
+
+func (email *NotificationEmail) BeforeCreate(tx *gorm.DB) (err error) {
+	email.ID = uuid.New()
+	return
+}
@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PersonalAccessToken lets scripts call the API as its owner without a password.
+type PersonalAccessToken struct {
+	ID         uuid.UUID `gorm:"primaryKey;type:char(36)"`
+	Name       string    `gorm:"type:varchar(100);not null"`
+	TokenHash  string    `gorm:"type:char(64);not null;uniqueIndex"`
+	Scopes     string    `gorm:"type:varchar(255);not null"`
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	CreatedAt  time.Time
+
+	UserID uuid.UUID `gorm:"type:char(36);not null;index"`
+	User   User      `gorm:"foreignKey:UserID"`
+}
+
+func (token *PersonalAccessToken) BeforeCreate(tx *gorm.DB) (err error) {
+	token.ID = uuid.New()
+	return
+}
+
+func (token *PersonalAccessToken) ScopeList() []string {
+	return strings.Split(token.Scopes, ",")
+}
+
+func (token *PersonalAccessToken) HasScope(scope string) bool {
+	return slices.Contains(token.ScopeList(), scope)
+}
+
+func (token *PersonalAccessToken) Expired(now time.Time) bool {
+	return token.ExpiresAt != nil && !now.Before(*token.ExpiresAt)
+}
@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Reminder is one notification the scheduler owes a user for a hangout.
+type Reminder struct {
+	ID                uuid.UUID  `gorm:"primaryKey;type:char(36)"`
+	Kind              string     `gorm:"type:varchar(32);not null;uniqueIndex:idx_reminders_schedule,priority:3"`
+	OffsetMinutes     int        `gorm:"not null;uniqueIndex:idx_reminders_schedule,priority:4"`
+	DueAt             time.Time  `gorm:"not null;uniqueIndex:idx_reminders_schedule,priority:5"`
+	Status            string     `gorm:"type:varchar(20);not null;index:idx_reminders_due,priority:1"`
+	Attempts          int        `gorm:"not null;default:0"`
+	NextAttemptAt     *time.Time `gorm:"index:idx_reminders_due,priority:2"`
+	DeliveredChannels string     `gorm:"type:varchar(255);not null;default:''"`
+	LastError         string     `gorm:"type:varchar(500)"`
+	SentAt            *time.Time
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+
+	HangoutID uuid.UUID `gorm:"type:char(36);not null;uniqueIndex:idx_reminders_schedule,priority:1"`
+	Hangout   Hangout   `gorm:"foreignKey:HangoutID"`
+
+	UserID uuid.UUID `gorm:"type:char(36);not null;uniqueIndex:idx_reminders_schedule,priority:2"`
+	User   User      `gorm:"foreignKey:UserID"`
+}
+
+func (reminder *Reminder) BeforeCreate(tx *gorm.DB) (err error) {
+	reminder.ID = uuid.New()
+	return
+}
+
+func (reminder *Reminder) DeliveredTo(channel string) bool {
+	return slices.Contains(strings.Split(reminder.DeliveredChannels, ","), channel)
+}
+
+func (reminder *Reminder) MarkDelivered(channel string) {
+	if reminder.DeliveredChannels == "" {
+		reminder.DeliveredChannels = channel
+		return
+	}
+	reminder.DeliveredChannels += "," + channel
+}
@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Session is a signed in device.
+type Session struct {
+	ID         uuid.UUID `gorm:"primaryKey;type:char(36)"`
+	UserAgent  string    `gorm:"type:varchar(255);not null"`
+	IPAddress  string    `gorm:"type:varchar(45);not null"`
+	LastUsedAt time.Time `gorm:"not null"`
+	ExpiresAt  time.Time `gorm:"not null;index"`
+	RevokedAt  *time.Time
+	CreatedAt  time.Time
+
+	UserID uuid.UUID `gorm:"type:char(36);not null;index"`
+	User   User      `gorm:"foreignKey:UserID"`
+}
+
+func (session *Session) BeforeCreate(tx *gorm.DB) (err error) {
+	session.ID = uuid.New()
+	return
+}
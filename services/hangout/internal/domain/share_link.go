@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ShareLink gives view-only access to a hangout, or one of its albums, to anyone
+// holding the token.
+type ShareLink struct {
+	ID             uuid.UUID `gorm:"primaryKey;type:char(36)"`
+	TokenHash      string    `gorm:"type:char(64);not null;uniqueIndex"`
+	Scope          string    `gorm:"type:varchar(20);not null"`
+	PasswordHash   *string   `gorm:"type:varchar(255)"`
+	ExpiresAt      *time.Time
+	RevokedAt      *time.Time
+	AccessCount    int `gorm:"not null;default:0"`
+	LastAccessedAt *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+
+	HangoutID   uuid.UUID  `gorm:"type:char(36);not null;index"`
+	Hangout     Hangout    `gorm:"foreignKey:HangoutID"`
+	AlbumID     *uuid.UUID `gorm:"type:char(36);index"`
+	Album       *Album     `gorm:"foreignKey:AlbumID"`
+	CreatedByID uuid.UUID  `gorm:"type:char(36);not null"`
+	CreatedBy   User       `gorm:"foreignKey:CreatedByID"`
+}
+
+func (link *ShareLink) BeforeCreate(tx *gorm.DB) (err error) {
+	link.ID = uuid.New()
+	return
+}
+
+// HasPassword reports whether visitors must send the link's password.
+func (link *ShareLink) HasPassword() bool {
+	return link.PasswordHash != nil
+}
+
+// IsExpired reports whether the link had expired at now.
+func (link *ShareLink) IsExpired(now time.Time) bool {
+	return link.ExpiresAt != nil && !now.Before(*link.ExpiresAt)
+}
+
+// ShareLinkAccess audits one request made with a share link, whether or not
+// access was granted.
+type ShareLinkAccess struct {
+	ID        uuid.UUID `gorm:"primaryKey;type:char(36)"`
+	Outcome   string    `gorm:"type:varchar(20);not null"`
+	IPAddress string    `gorm:"type:varchar(45);not null"`
+	UserAgent string    `gorm:"type:varchar(255);not null"`
+	CreatedAt time.Time `gorm:"index:idx_share_link_accesses_link_created,priority:2"`
+
+	ShareLinkID uuid.UUID `gorm:"type:char(36);not null;index:idx_share_link_accesses_link_created,priority:1"`
+	ShareLink   ShareLink `gorm:"foreignKey:ShareLinkID"`
+}
+
+func (access *ShareLinkAccess) BeforeCreate(tx *gorm.DB) (err error) {
+	access.ID = uuid.New()
+	return
+}
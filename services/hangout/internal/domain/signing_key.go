@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// SigningKey is a key pair for access tokens, identified in tokens by its ID as
+// the kid header.
+type SigningKey struct {
+	ID          uuid.UUID  `gorm:"primaryKey;type:char(36)"`
+	Algorithm   string     `gorm:"type:varchar(16);not null"`
+	PublicKey   []byte     `gorm:"type:blob;not null"`
+	PrivateKey  []byte     `gorm:"type:blob;not null"`
+	ActivatesAt time.Time  `gorm:"not null;index"`
+	RetiresAt   *time.Time `gorm:"index"`
+	CreatedAt   time.Time
+}
+
+func (key *SigningKey) BeforeCreate(tx *gorm.DB) (err error) {
+	if key.ID == uuid.Nil {
+		key.ID = uuid.New()
+	}
+	return
+}
@@ -8,13 +8,22 @@ import (
 )
 
 type User struct {
-	ID        uuid.UUID `gorm:"primaryKey;type:char(36)"`
-	Name      string    `gorm:"type:varchar(255);not null"`
-	Email     string    `gorm:"type:varchar(255);uniqueIndex;not null"`
-	Password  string    `gorm:"type:varchar(255);not null"`
-	CreatedAt time.Time
-	UpdatedAt time.Time
-	DeletedAt gorm.DeletedAt `gorm:"index"`
+	ID       uuid.UUID `gorm:"primaryKey;type:char(36)"`
+	Name     string    `gorm:"type:varchar(255);not null"`
+	Email    string    `gorm:"type:varchar(255);uniqueIndex;not null"`
+	Password string    `gorm:"type:varchar(255);not null"`
+	// EmailVerifiedAt is nil until the user follows the link in the
+	// verification email.
+	EmailVerifiedAt *time.Time
+	// PendingEmail is the address the user asked to change to.
+	PendingEmail *string `gorm:"type:varchar(255)"`
+	// Role is RoleUser or RoleAdmin. Admins can use the /admin routes.
+	Role string `gorm:"type:varchar(20);not null;default:user"`
+	// DisabledAt is set while an admin has disabled the account.
+	DisabledAt *time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	DeletedAt  gorm.DeletedAt `gorm:"index"`
 
 	Hangouts []*Hangout
 	Memories []*Memory
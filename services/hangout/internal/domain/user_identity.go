@@ -0,0 +1,25 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// UserIdentity links a user to an account at an external sign in provider.
+type UserIdentity struct {
+	ID        uuid.UUID `gorm:"primaryKey;type:char(36)"`
+	Provider  string    `gorm:"type:varchar(64);not null;uniqueIndex:idx_user_identities_provider_subject,priority:1;uniqueIndex:idx_user_identities_user_provider,priority:2"`
+	Subject   string    `gorm:"type:varchar(255);not null;uniqueIndex:idx_user_identities_provider_subject,priority:2"`
+	Email     string    `gorm:"type:varchar(255)"`
+	CreatedAt time.Time
+
+	UserID uuid.UUID `gorm:"type:char(36);not null;uniqueIndex:idx_user_identities_user_provider,priority:1"`
+	User   User      `gorm:"foreignKey:UserID"`
+}
+
+func (identity *UserIdentity) BeforeCreate(tx *gorm.DB) (err error) {
+	identity.ID = uuid.New()
+	return
+}
@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// WebhookSubscription posts the owner's hangout events whose type is listed in
+// EventTypes (comma separated) to URL, signed with Secret.
+type WebhookSubscription struct {
+	ID         uuid.UUID `gorm:"primaryKey;type:char(36)"`
+	URL        string    `gorm:"type:varchar(2048);not null"`
+	Secret     string    `gorm:"type:varchar(255);not null"`
+	EventTypes string    `gorm:"type:varchar(512);not null"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+
+	UserID uuid.UUID `gorm:"type:char(36);not null;index"`
+	User   User      `gorm:"foreignKey:UserID"`
+}
+
+func (subscription *WebhookSubscription) BeforeCreate(tx *gorm.DB) (err error) {
+	subscription.ID = uuid.New()
+	return
+}
+
+func (subscription *WebhookSubscription) EventTypeList() []string {
+	return strings.Split(subscription.EventTypes, ",")
+}
+
+func (subscription *WebhookSubscription) Subscribes(eventType string) bool {
+	return slices.Contains(subscription.EventTypeList(), eventType)
+}
+
+// WebhookDelivery is one event queued for a subscription.
+type WebhookDelivery struct {
+	ID             uuid.UUID  `gorm:"primaryKey;type:char(36)"`
+	EventID        string     `gorm:"type:char(36);not null"`
+	EventType      string     `gorm:"type:varchar(64);not null"`
+	Payload        []byte     `gorm:"type:mediumblob;not null"`
+	Status         string     `gorm:"type:varchar(20);not null;index:idx_webhook_deliveries_due,priority:1"`
+	Attempts       int        `gorm:"not null;default:0"`
+	NextAttemptAt  *time.Time `gorm:"index:idx_webhook_deliveries_due,priority:2"`
+	LastAttemptAt  *time.Time
+	ResponseStatus *int
+	LastError      string `gorm:"type:varchar(500)"`
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+
+	SubscriptionID uuid.UUID           `gorm:"type:char(36);not null;index"`
+	Subscription   WebhookSubscription `gorm:"foreignKey:SubscriptionID"`
+}
+
+func (delivery *WebhookDelivery) BeforeCreate(tx *gorm.DB) (err error) {
+	delivery.ID = uuid.New()
+	return
+}
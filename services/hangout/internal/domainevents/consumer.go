@@ -0,0 +1,60 @@
+package domainevents
+
+import (
+	"context"
+
+	"github.com/Ernestgio/Hangout-Planner/pkg/shared/eventbus"
+	eventspb "github.com/Ernestgio/Hangout-Planner/pkg/shared/proto/gen/go/events"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/services"
+	"github.com/google/uuid"
+)
+
+type Subscriber interface {
+	Subscribe(subject, group string, handler eventbus.EnvelopeHandler) (eventbus.Subscription, error)
+}
+
+// FileEventConsumer reacts to events published by the file service.
+type FileEventConsumer struct {
+	bus           Subscriber
+	memoryService services.MemoryService
+	subscription  eventbus.Subscription
+}
+
+func NewFileEventConsumer(bus Subscriber, memoryService services.MemoryService) *FileEventConsumer {
+	return &FileEventConsumer{
+		bus:           bus,
+		memoryService: memoryService,
+	}
+}
+
+func (c *FileEventConsumer) Start() error {
+	subscription, err := c.bus.Subscribe(eventbus.SubjectFileEvents, constants.EventBusConsumerGroup, c.Handle)
+	if err != nil {
+		return err
+	}
+	c.subscription = subscription
+	return nil
+}
+
+func (c *FileEventConsumer) Stop() {
+	if c.subscription != nil {
+		_ = c.subscription.Unsubscribe()
+	}
+}
+
+// Handle dispatches one envelope.
+func (c *FileEventConsumer) Handle(ctx context.Context, event *eventspb.EventEnvelope) error {
+	switch payload := event.GetPayload().(type) {
+	case *eventspb.EventEnvelope_FileUploadProcessed:
+		processed := payload.FileUploadProcessed
+		memoryID, err := uuid.Parse(processed.GetMemoryId())
+		if err != nil {
+			return apperrors.ErrInvalidMemoryID
+		}
+		return c.memoryService.HandleUploadProcessed(ctx, memoryID, processed.GetFileSize(), processed.GetMimeType())
+	default:
+		return nil
+	}
+}
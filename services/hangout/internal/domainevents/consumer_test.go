@@ -0,0 +1,155 @@
+package domainevents_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Ernestgio/Hangout-Planner/pkg/shared/eventbus"
+	eventspb "github.com/Ernestgio/Hangout-Planner/pkg/shared/proto/gen/go/events"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domainevents"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockMemoryService struct {
+	mock.Mock
+}
+
+func (m *MockMemoryService) GenerateUploadURLs(ctx context.Context, userID uuid.UUID, hangoutID uuid.UUID, req *dto.GenerateUploadURLsRequest) (*dto.MemoryUploadResponse, error) {
+	panic("not used")
+}
+
+func (m *MockMemoryService) ConfirmUpload(ctx context.Context, userID uuid.UUID, req *dto.ConfirmUploadRequest) error {
+	panic("not used")
+}
+
+func (m *MockMemoryService) GetMemory(ctx context.Context, userID uuid.UUID, memoryID uuid.UUID) (*dto.MemoryResponse, error) {
+	panic("not used")
+}
+
+func (m *MockMemoryService) ListMemories(ctx context.Context, userID uuid.UUID, hangoutID uuid.UUID, filter *dto.MemoryFilter, pagination *dto.CursorPagination) (*dto.PaginatedMemories, error) {
+	panic("not used")
+}
+
+func (m *MockMemoryService) PatchMemory(ctx context.Context, userID uuid.UUID, memoryID uuid.UUID, req *dto.PatchMemoryRequest) (*dto.MemoryResponse, error) {
+	panic("not used")
+}
+
+func (m *MockMemoryService) AddReaction(ctx context.Context, userID uuid.UUID, memoryID uuid.UUID, emoji string) ([]dto.MemoryReactionResponse, error) {
+	panic("not used")
+}
+
+func (m *MockMemoryService) RemoveReaction(ctx context.Context, userID uuid.UUID, memoryID uuid.UUID, emoji string) ([]dto.MemoryReactionResponse, error) {
+	panic("not used")
+}
+
+func (m *MockMemoryService) DeleteMemory(ctx context.Context, userID uuid.UUID, memoryID uuid.UUID) error {
+	panic("not used")
+}
+
+func (m *MockMemoryService) CreateArchive(ctx context.Context, userID uuid.UUID, hangoutID uuid.UUID) (*dto.ArchiveResponse, error) {
+	panic("not used")
+}
+
+func (m *MockMemoryService) GetArchive(ctx context.Context, userID uuid.UUID, hangoutID uuid.UUID, archiveID uuid.UUID) (*dto.ArchiveResponse, error) {
+	panic("not used")
+}
+
+func (m *MockMemoryService) HandleUploadProcessed(ctx context.Context, memoryID uuid.UUID, fileSize int64, mimeType string) error {
+	args := m.Called(ctx, memoryID, fileSize, mimeType)
+	return args.Error(0)
+}
+
+func TestFileEventConsumer_Handle(t *testing.T) {
+	ctx := context.Background()
+	memoryID := uuid.New()
+	serviceErr := errors.New("service error")
+
+	processed := func(memoryID string) *eventspb.EventEnvelope {
+		return &eventspb.EventEnvelope{Payload: &eventspb.EventEnvelope_FileUploadProcessed{
+			FileUploadProcessed: &eventspb.FileUploadProcessed{FileId: uuid.NewString(), MemoryId: memoryID, FileSize: 2048, MimeType: "image/png"},
+		}}
+	}
+
+	tests := []struct {
+		name    string
+		event   *eventspb.EventEnvelope
+		setup   func(*MockMemoryService)
+		wantErr error
+	}{
+		{
+			name:  "upload processed",
+			event: processed(memoryID.String()),
+			setup: func(m *MockMemoryService) {
+				m.On("HandleUploadProcessed", mock.Anything, memoryID, int64(2048), "image/png").Return(nil)
+			},
+		},
+		{
+			name:  "service error is returned",
+			event: processed(memoryID.String()),
+			setup: func(m *MockMemoryService) {
+				m.On("HandleUploadProcessed", mock.Anything, memoryID, int64(2048), "image/png").Return(serviceErr)
+			},
+			wantErr: serviceErr,
+		},
+		{
+			name:    "invalid memory id",
+			event:   processed("not-a-uuid"),
+			setup:   func(m *MockMemoryService) {},
+			wantErr: apperrors.ErrInvalidMemoryID,
+		},
+		{
+			name: "other payloads are skipped",
+			event: &eventspb.EventEnvelope{Payload: &eventspb.EventEnvelope_FileDeleted{
+				FileDeleted: &eventspb.FileDeleted{MemoryId: memoryID.String()},
+			}},
+			setup: func(m *MockMemoryService) {},
+		},
+		{
+			name:  "unknown payload is skipped",
+			event: &eventspb.EventEnvelope{},
+			setup: func(m *MockMemoryService) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			memoryService := new(MockMemoryService)
+			tt.setup(memoryService)
+			consumer := domainevents.NewFileEventConsumer(nil, memoryService)
+
+			err := consumer.Handle(ctx, tt.event)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			memoryService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestFileEventConsumer_ReceivesFromBus(t *testing.T) {
+	memoryID := uuid.New()
+	bus := eventbus.NewBus(eventbus.NewMemoryTransport(4, nil), "file-service")
+
+	handled := make(chan struct{})
+	memoryService := new(MockMemoryService)
+	memoryService.On("HandleUploadProcessed", mock.Anything, memoryID, int64(10), "image/gif").
+		Run(func(mock.Arguments) { close(handled) }).
+		Return(nil)
+
+	consumer := domainevents.NewFileEventConsumer(bus, memoryService)
+	require.NoError(t, consumer.Start())
+
+	require.NoError(t, bus.Publish(context.Background(), &eventspb.FileUploadProcessed{MemoryId: memoryID.String(), FileSize: 10, MimeType: "image/gif"}))
+	<-handled
+
+	consumer.Stop()
+	require.NoError(t, bus.Close())
+	memoryService.AssertExpectations(t)
+}
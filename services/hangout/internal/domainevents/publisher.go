@@ -0,0 +1,79 @@
+// Package domainevents connects the hangout service to the shared event bus.
+package domainevents
+
+import (
+	"context"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/pkg/shared/eventbus"
+	eventspb "github.com/Ernestgio/Hangout-Planner/pkg/shared/proto/gen/go/events"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/pubsub"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type busPublisher struct {
+	bus eventbus.Publisher
+}
+
+// NewBusPublisher returns a pubsub.Publisher that forwards the hangout events
+// other services consume to the event bus.
+func NewBusPublisher(bus eventbus.Publisher) pubsub.Publisher {
+	return &busPublisher{bus: bus}
+}
+
+func (p *busPublisher) Publish(ctx context.Context, _ string, event pubsub.Event) error {
+	payload := toPayload(event)
+	if payload == nil {
+		return nil
+	}
+	return p.bus.Publish(ctx, payload)
+}
+
+func toPayload(event pubsub.Event) proto.Message {
+	hangoutID := event.HangoutID.String()
+	userID := event.UserID.String()
+
+	switch event.Type {
+	case constants.EventHangoutCreated:
+		data, ok := event.Data.(*dto.HangoutDetailResponse)
+		if !ok {
+			return nil
+		}
+		return &eventspb.HangoutCreated{
+			HangoutId: hangoutID,
+			UserId:    userID,
+			Title:     data.Title,
+			Status:    string(data.Status),
+			Date:      timestamppb.New(time.Time(data.Date)),
+		}
+	case constants.EventHangoutStatusChanged:
+		data, ok := event.Data.(dto.HangoutStatusChangedEvent)
+		if !ok {
+			return nil
+		}
+		return &eventspb.HangoutStatusChanged{
+			HangoutId:  hangoutID,
+			UserId:     userID,
+			FromStatus: string(data.From),
+			ToStatus:   string(data.To),
+		}
+	case constants.EventHangoutDeleted:
+		return &eventspb.HangoutDeleted{HangoutId: hangoutID, UserId: userID}
+	case constants.EventMemoryCreated:
+		data, ok := event.Data.(*dto.MemoryCreatedEvent)
+		if !ok {
+			return nil
+		}
+		return &eventspb.MemoryUploaded{
+			MemoryId:  data.ID.String(),
+			HangoutId: hangoutID,
+			UserId:    userID,
+			Name:      data.Name,
+		}
+	default:
+		return nil
+	}
+}
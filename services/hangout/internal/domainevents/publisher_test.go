@@ -0,0 +1,103 @@
+package domainevents_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/pkg/shared/enums"
+	eventspb "github.com/Ernestgio/Hangout-Planner/pkg/shared/proto/gen/go/events"
+	"github.com/Ernestgio/Hangout-Planner/pkg/shared/types"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domainevents"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/pubsub"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type recordingBus struct {
+	published []proto.Message
+}
+
+func (b *recordingBus) Publish(ctx context.Context, payload proto.Message) error {
+	b.published = append(b.published, payload)
+	return nil
+}
+
+func TestBusPublisher_Publish(t *testing.T) {
+	hangoutID := uuid.New()
+	userID := uuid.New()
+	memoryID := uuid.New()
+	date := time.Date(2025, 10, 5, 15, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		eventType string
+		data      any
+		want      proto.Message
+	}{
+		{
+			name:      "hangout created",
+			eventType: constants.EventHangoutCreated,
+			data:      &dto.HangoutDetailResponse{ID: hangoutID, Title: "Picnic", Status: enums.StatusPlanning, Date: types.JSONTime(date)},
+			want: &eventspb.HangoutCreated{
+				HangoutId: hangoutID.String(), UserId: userID.String(), Title: "Picnic",
+				Status: string(enums.StatusPlanning), Date: timestamppb.New(date),
+			},
+		},
+		{
+			name:      "status changed",
+			eventType: constants.EventHangoutStatusChanged,
+			data:      dto.HangoutStatusChangedEvent{From: enums.StatusPlanning, To: enums.StatusConfirmed},
+			want: &eventspb.HangoutStatusChanged{
+				HangoutId: hangoutID.String(), UserId: userID.String(),
+				FromStatus: string(enums.StatusPlanning), ToStatus: string(enums.StatusConfirmed),
+			},
+		},
+		{
+			name:      "hangout deleted",
+			eventType: constants.EventHangoutDeleted,
+			data:      dto.HangoutDeletedEvent{ID: hangoutID},
+			want:      &eventspb.HangoutDeleted{HangoutId: hangoutID.String(), UserId: userID.String()},
+		},
+		{
+			name:      "memory created",
+			eventType: constants.EventMemoryCreated,
+			data:      &dto.MemoryCreatedEvent{ID: memoryID, Name: "photo.jpg", HangoutID: hangoutID},
+			want: &eventspb.MemoryUploaded{
+				MemoryId: memoryID.String(), HangoutId: hangoutID.String(), UserId: userID.String(), Name: "photo.jpg",
+			},
+		},
+		{
+			name:      "hangout updated is not forwarded",
+			eventType: constants.EventHangoutUpdated,
+			data:      &dto.HangoutDetailResponse{ID: hangoutID},
+		},
+		{
+			name:      "unexpected data is not forwarded",
+			eventType: constants.EventHangoutCreated,
+			data:      "not a hangout",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bus := &recordingBus{}
+			publisher := domainevents.NewBusPublisher(bus)
+
+			event := pubsub.NewHangoutEvent(hangoutID, tt.eventType, tt.data)
+			event.UserID = userID
+			require.NoError(t, publisher.Publish(context.Background(), pubsub.HangoutTopic(hangoutID), event))
+
+			if tt.want == nil {
+				require.Empty(t, bus.published)
+				return
+			}
+			require.Len(t, bus.published, 1)
+			require.True(t, proto.Equal(tt.want, bus.published[0]), "got %v", bus.published[0])
+		})
+	}
+}
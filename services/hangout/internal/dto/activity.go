@@ -24,6 +24,7 @@ type ActivityDetailResponse struct {
 	Name         string         `json:"name"`
 	HangoutCount int64          `json:"hangout_count"`
 	CreatedAt    types.JSONTime `json:"created_at"`
+	Version      int64          `json:"version"`
 }
 
 type ActivityTagResponse struct {
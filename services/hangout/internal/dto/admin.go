@@ -0,0 +1,52 @@
+package dto
+
+import (
+	"github.com/Ernestgio/Hangout-Planner/pkg/shared/types"
+	"github.com/google/uuid"
+)
+
+// AdminActionRequest carries the reason recorded in the audit log for an
+// admin action.
+type AdminActionRequest struct {
+	Reason string `json:"reason" validate:"required,max=500"`
+}
+
+type AdminUserResponse struct {
+	ID            uuid.UUID      `json:"id"`
+	Name          string         `json:"name"`
+	Email         string         `json:"email"`
+	EmailVerified bool           `json:"email_verified"`
+	Role          string         `json:"role" enums:"user,admin"`
+	Disabled      bool           `json:"disabled"`
+	DisabledAt    types.JSONTime `json:"disabled_at"`
+	CreatedAt     types.JSONTime `json:"created_at"`
+}
+
+type PaginatedAdminUsers struct {
+	Data       []*AdminUserResponse `json:"data"`
+	NextCursor *uuid.UUID           `json:"next_cursor"`
+	HasMore    bool                 `json:"has_more"`
+}
+
+// AdminAuditLogFilter narrows the audit log to one admin or one target.
+type AdminAuditLogFilter struct {
+	ActorID *uuid.UUID
+	Target  string
+}
+
+type AdminAuditLogResponse struct {
+	ID         uuid.UUID      `json:"id"`
+	ActorID    uuid.UUID      `json:"actor_id"`
+	ActorEmail string         `json:"actor_email"`
+	Action     string         `json:"action"`
+	TargetType string         `json:"target_type" enums:"user,hangout,memory"`
+	Target     string         `json:"target"`
+	Reason     string         `json:"reason"`
+	CreatedAt  types.JSONTime `json:"created_at"`
+}
+
+type PaginatedAdminAuditLogs struct {
+	Data       []*AdminAuditLogResponse `json:"data"`
+	NextCursor *uuid.UUID               `json:"next_cursor"`
+	HasMore    bool                     `json:"has_more"`
+}
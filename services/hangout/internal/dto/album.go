@@ -0,0 +1,39 @@
+package dto
+
+import (
+	"github.com/Ernestgio/Hangout-Planner/pkg/shared/types"
+	"github.com/google/uuid"
+)
+
+type CreateAlbumRequest struct {
+	Name string `json:"name" validate:"required,max=100"`
+}
+
+// PatchAlbumRequest is a JSON Merge Patch document.
+type PatchAlbumRequest struct {
+	Name          Nullable[string]    `json:"name" swaggertype:"string"`
+	CoverMemoryID Nullable[uuid.UUID] `json:"cover_memory_id" swaggertype:"string"`
+}
+
+type AlbumResponse struct {
+	ID            uuid.UUID      `json:"id"`
+	HangoutID     uuid.UUID      `json:"hangout_id"`
+	Name          string         `json:"name"`
+	CoverMemoryID *uuid.UUID     `json:"cover_memory_id"`
+	CreatedAt     types.JSONTime `json:"created_at"`
+	UpdatedAt     types.JSONTime `json:"updated_at"`
+}
+
+// MoveMemoryRequest places a memory in an album, or among the loose memories of
+// the hangout when album_id is null.
+type MoveMemoryRequest struct {
+	AlbumID  *uuid.UUID `json:"album_id"`
+	AfterID  *uuid.UUID `json:"after_id"`
+	BeforeID *uuid.UUID `json:"before_id"`
+}
+
+type MemoryPlacementResponse struct {
+	ID       uuid.UUID  `json:"id"`
+	AlbumID  *uuid.UUID `json:"album_id"`
+	Position string     `json:"position"`
+}
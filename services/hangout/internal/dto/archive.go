@@ -0,0 +1,24 @@
+package dto
+
+import (
+	"github.com/Ernestgio/Hangout-Planner/pkg/shared/types"
+	"github.com/google/uuid"
+)
+
+// ArchiveResponse reports the progress of a ZIP export. download_url is set
+// once the status is completed and stops working at url_expires_at (Unix
+// seconds); poll the archive again for a fresh one until expires_at.
+type ArchiveResponse struct {
+	ID             uuid.UUID       `json:"id"`
+	Status         string          `json:"status" enums:"pending,processing,completed,failed"`
+	TotalFiles     int             `json:"total_files"`
+	ProcessedFiles int             `json:"processed_files"`
+	Progress       int             `json:"progress"`
+	ArchiveSize    int64           `json:"archive_size"`
+	DownloadURL    *string         `json:"download_url"`
+	URLExpiresAt   *int64          `json:"url_expires_at"`
+	Error          *string         `json:"error"`
+	CreatedAt      types.JSONTime  `json:"created_at"`
+	CompletedAt    *types.JSONTime `json:"completed_at"`
+	ExpiresAt      *types.JSONTime `json:"expires_at"`
+}
@@ -9,8 +9,27 @@ type SignUpRequest struct {
 type SignInRequest struct {
 	Email    string `json:"email" validate:"required,email"`
 	Password string `json:"password" validate:"required"`
+
+	Client SessionClient `json:"-" swaggerignore:"true"`
 }
 
+// SignInResponse carries the access token, or an MFA challenge token when
+// the account has two-factor authentication and a code is still needed.
 type SignInResponse struct {
-	Token string `json:"token"`
+	Token       string `json:"token,omitempty"`
+	MFARequired bool   `json:"mfa_required,omitempty"`
+	MFAToken    string `json:"mfa_token,omitempty"`
+}
+
+type VerifyEmailRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+type ResetPasswordRequest struct {
+	Token    string `json:"token" validate:"required"`
+	Password string `json:"password" validate:"required"`
 }
@@ -0,0 +1,51 @@
+package dto
+
+import (
+	"github.com/Ernestgio/Hangout-Planner/pkg/shared/types"
+	"github.com/google/uuid"
+)
+
+type CreateCommentRequest struct {
+	Body     string      `json:"body" validate:"required,max=5000"`
+	ParentID *uuid.UUID  `json:"parent_id"`
+	Mentions []uuid.UUID `json:"mentions" validate:"max=20"`
+}
+
+type UpdateCommentRequest struct {
+	Body     string      `json:"body" validate:"required,max=5000"`
+	Mentions []uuid.UUID `json:"mentions" validate:"max=20"`
+}
+
+type CommentAuthorResponse struct {
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
+}
+
+type CommentResponse struct {
+	ID        uuid.UUID             `json:"id"`
+	HangoutID uuid.UUID             `json:"hangout_id"`
+	ParentID  *uuid.UUID            `json:"parent_id"`
+	Body      string                `json:"body"`
+	Author    CommentAuthorResponse `json:"author"`
+	Mentions  []uuid.UUID           `json:"mentions"`
+	Edited    bool                  `json:"edited"`
+	EditedAt  types.JSONTime        `json:"edited_at"`
+	CreatedAt types.JSONTime        `json:"created_at"`
+	Replies   []*CommentResponse    `json:"replies,omitempty"`
+}
+
+type PaginatedComments struct {
+	Data       []*CommentResponse `json:"data"`
+	NextCursor *uuid.UUID         `json:"next_cursor"`
+	HasMore    bool               `json:"has_more"`
+}
+
+type CommentCreatedEvent struct {
+	ID         uuid.UUID      `json:"id"`
+	HangoutID  uuid.UUID      `json:"hangout_id"`
+	ParentID   *uuid.UUID     `json:"parent_id"`
+	AuthorID   uuid.UUID      `json:"author_id"`
+	AuthorName string         `json:"author_name"`
+	Mentions   []uuid.UUID    `json:"mentions"`
+	CreatedAt  types.JSONTime `json:"created_at"`
+}
@@ -0,0 +1,39 @@
+package dto
+
+import (
+	"github.com/Ernestgio/Hangout-Planner/pkg/shared/types"
+	"github.com/google/uuid"
+)
+
+// DataExportResponse describes an export requested from /me/exports.
+type DataExportResponse struct {
+	ID          uuid.UUID        `json:"id"`
+	Status      string           `json:"status" enums:"pending,processing,completed,failed"`
+	Archive     *ArchiveResponse `json:"archive"`
+	Error       *string          `json:"error"`
+	CreatedAt   types.JSONTime   `json:"created_at"`
+	CompletedAt *types.JSONTime  `json:"completed_at"`
+	ExpiresAt   types.JSONTime   `json:"expires_at"`
+}
+
+// DataExportDocument is the JSON file written into a data export's archive.
+type DataExportDocument struct {
+	ExportedAt types.JSONTime             `json:"exported_at"`
+	Profile    UserResponse               `json:"profile"`
+	Hangouts   []HangoutDetailResponse    `json:"hangouts"`
+	Activities []ActivityListItemResponse `json:"activities"`
+	Memories   []DataExportMemory         `json:"memories"`
+}
+
+// DataExportMemory is a memory's metadata.
+type DataExportMemory struct {
+	ID        uuid.UUID                `json:"id"`
+	Name      string                   `json:"name"`
+	Caption   *string                  `json:"caption"`
+	HangoutID uuid.UUID                `json:"hangout_id"`
+	AlbumID   *uuid.UUID               `json:"album_id"`
+	Tags      []string                 `json:"tags"`
+	People    []uuid.UUID              `json:"people"`
+	Reactions []MemoryReactionResponse `json:"reactions"`
+	CreatedAt types.JSONTime           `json:"created_at"`
+}
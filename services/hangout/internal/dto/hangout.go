@@ -7,29 +7,52 @@ import (
 )
 
 type CreateHangoutRequest struct {
-	Title       string              `json:"title" validate:"required"`
-	Description *string             `json:"description"`
-	Date        string              `json:"date" validate:"required,datetime=2006-01-02 15:04:05.000"`
-	Status      enums.HangoutStatus `json:"status" validate:"oneof=PLANNING CONFIRMED EXECUTED CANCELLED"`
-	ActivityIDs []uuid.UUID         `json:"activity_ids" validate:"dive,uuid"`
+	Title        string              `json:"title" validate:"required"`
+	Description  *string             `json:"description"`
+	Date         string              `json:"date" validate:"required,datetime=2006-01-02 15:04:05.000"`
+	RSVPDeadline *string             `json:"rsvp_deadline" validate:"omitempty,datetime=2006-01-02 15:04:05.000"`
+	Status       enums.HangoutStatus `json:"status" validate:"oneof=PLANNING CONFIRMED EXECUTED CANCELLED"`
+	ActivityIDs  []uuid.UUID         `json:"activity_ids" validate:"dive,uuid"`
 }
 
 type UpdateHangoutRequest struct {
-	Title       string              `json:"title" validate:"required"`
-	Description *string             `json:"description"`
-	Date        string              `json:"date" validate:"required,datetime=2006-01-02 15:04:05.000"`
-	Status      enums.HangoutStatus `json:"status" validate:"required,oneof=PLANNING CONFIRMED EXECUTED CANCELLED"`
-	ActivityIDs []uuid.UUID         `json:"activities" validate:"dive,uuid"`
+	Title        string              `json:"title" validate:"required"`
+	Description  *string             `json:"description"`
+	Date         string              `json:"date" validate:"required,datetime=2006-01-02 15:04:05.000"`
+	RSVPDeadline *string             `json:"rsvp_deadline" validate:"omitempty,datetime=2006-01-02 15:04:05.000"`
+	Status       enums.HangoutStatus `json:"status" validate:"required,oneof=PLANNING CONFIRMED EXECUTED CANCELLED"`
+	ActivityIDs  []uuid.UUID         `json:"activities" validate:"dive,uuid"`
+}
+
+// PatchHangoutRequest is a JSON Merge Patch document.
+type PatchHangoutRequest struct {
+	Title        Nullable[string]              `json:"title" swaggertype:"string"`
+	Description  Nullable[string]              `json:"description" swaggertype:"string"`
+	Date         Nullable[string]              `json:"date" swaggertype:"string" example:"2025-12-01 18:30:00.000"`
+	RSVPDeadline Nullable[string]              `json:"rsvp_deadline" swaggertype:"string" example:"2025-11-28 18:00:00.000"`
+	Status       Nullable[enums.HangoutStatus] `json:"status" swaggertype:"string" enums:"PLANNING,CONFIRMED,EXECUTED,CANCELLED"`
+	ActivityIDs  Nullable[[]uuid.UUID]         `json:"activities" swaggertype:"array,string"`
 }
 
 type HangoutDetailResponse struct {
-	ID          uuid.UUID             `json:"id"`
-	Title       string                `json:"title"`
-	Description *string               `json:"description"`
-	Date        types.JSONTime        `json:"date"`
-	Status      enums.HangoutStatus   `json:"status"`
-	CreatedAt   types.JSONTime        `json:"created_at"`
-	Activities  []ActivityTagResponse `json:"activities"`
+	ID           uuid.UUID             `json:"id"`
+	Title        string                `json:"title"`
+	Description  *string               `json:"description"`
+	Date         types.JSONTime        `json:"date"`
+	RSVPDeadline *types.JSONTime       `json:"rsvp_deadline"`
+	Status       enums.HangoutStatus   `json:"status"`
+	CreatedAt    types.JSONTime        `json:"created_at"`
+	Activities   []ActivityTagResponse `json:"activities"`
+	Version      int64                 `json:"version"`
+}
+
+type HangoutStatusChangedEvent struct {
+	From enums.HangoutStatus `json:"from"`
+	To   enums.HangoutStatus `json:"to"`
+}
+
+type HangoutDeletedEvent struct {
+	ID uuid.UUID `json:"id"`
 }
 
 type HangoutListItemResponse struct {
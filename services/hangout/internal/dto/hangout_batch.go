@@ -0,0 +1,39 @@
+package dto
+
+import (
+	"github.com/Ernestgio/Hangout-Planner/pkg/shared/enums"
+	"github.com/google/uuid"
+)
+
+type BatchHangoutRequest struct {
+	// Atomic runs every operation in one transaction; the first failure rolls
+	// back the whole batch. Otherwise each operation is committed on its own
+	// and reported individually.
+	Atomic     bool                    `json:"atomic"`
+	Operations []BatchHangoutOperation `json:"operations" validate:"required,min=1,dive"`
+}
+
+type BatchHangoutOperation struct {
+	Op          string                `json:"op" validate:"required,oneof=create update_status add_activities remove_activities delete"`
+	HangoutID   *uuid.UUID            `json:"hangout_id"`
+	Version     *int64                `json:"version"`
+	Hangout     *CreateHangoutRequest `json:"hangout"`
+	Status      enums.HangoutStatus   `json:"status"`
+	ActivityIDs []uuid.UUID           `json:"activity_ids" validate:"dive,uuid"`
+}
+
+type BatchHangoutResult struct {
+	Index     int                    `json:"index"`
+	Op        string                 `json:"op"`
+	HangoutID *uuid.UUID             `json:"hangout_id,omitempty"`
+	Status    string                 `json:"status"`
+	Error     string                 `json:"error,omitempty"`
+	Hangout   *HangoutDetailResponse `json:"hangout,omitempty"`
+}
+
+type BatchHangoutResponse struct {
+	Atomic    bool                 `json:"atomic"`
+	Succeeded int                  `json:"succeeded"`
+	Failed    int                  `json:"failed"`
+	Results   []BatchHangoutResult `json:"results"`
+}
@@ -0,0 +1,31 @@
+package dto
+
+import "time"
+
+type OIDCProvidersResponse struct {
+	Providers []string `json:"providers"`
+}
+
+// OIDCAuthorizeResponse carries the URL to send the user to.
+type OIDCAuthorizeResponse struct {
+	AuthorizationURL string `json:"authorization_url"`
+	State            string `json:"state"`
+
+	Binding string `json:"-" swaggerignore:"true"`
+}
+
+// OIDCCallbackRequest is the code and state the provider appended to the redirect
+// URL.
+type OIDCCallbackRequest struct {
+	Code  string `json:"code" validate:"required"`
+	State string `json:"state" validate:"required"`
+
+	Binding string        `json:"-" swaggerignore:"true"`
+	Client  SessionClient `json:"-" swaggerignore:"true"`
+}
+
+type IdentityResponse struct {
+	Provider  string    `json:"provider"`
+	Email     string    `json:"email"`
+	CreatedAt time.Time `json:"created_at"`
+}
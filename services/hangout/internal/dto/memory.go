@@ -13,15 +13,64 @@ type CreateMemoriesRequest struct {
 }
 
 type MemoryResponse struct {
+	ID         uuid.UUID                `json:"id"`
+	Name       string                   `json:"name"`
+	Caption    *string                  `json:"caption"`
+	HangoutID  uuid.UUID                `json:"hangout_id"`
+	AlbumID    *uuid.UUID               `json:"album_id"`
+	Position   string                   `json:"position"`
+	UploadedBy uuid.UUID                `json:"uploaded_by"`
+	Tags       []string                 `json:"tags"`
+	People     []uuid.UUID              `json:"people"`
+	Reactions  []MemoryReactionResponse `json:"reactions"`
+	FileURL    string                   `json:"file_url"`
+	FileSize   int64                    `json:"file_size"`
+	MimeType   string                   `json:"mime_type"`
+	CreatedAt  types.JSONTime           `json:"created_at"`
+}
+
+// MemoryReactionResponse counts the reactions with one emoji.
+type MemoryReactionResponse struct {
+	Emoji   string `json:"emoji"`
+	Count   int    `json:"count"`
+	Reacted bool   `json:"reacted"`
+}
+
+// MemoryFilter narrows a memory listing.
+type MemoryFilter struct {
+	Tag        string
+	PersonID   *uuid.UUID
+	UploaderID *uuid.UUID
+	AlbumID    *uuid.UUID
+	NoAlbum    bool
+}
+
+// PatchMemoryRequest is a JSON Merge Patch document.
+type PatchMemoryRequest struct {
+	Caption Nullable[string]      `json:"caption" swaggertype:"string"`
+	Tags    Nullable[[]string]    `json:"tags" swaggertype:"array,string"`
+	People  Nullable[[]uuid.UUID] `json:"people" swaggertype:"array,string"`
+}
+
+type MemoryReactionRequest struct {
+	Emoji string `json:"emoji" validate:"required"`
+}
+
+type MemoryCreatedEvent struct {
 	ID        uuid.UUID      `json:"id"`
 	Name      string         `json:"name"`
 	HangoutID uuid.UUID      `json:"hangout_id"`
-	FileURL   string         `json:"file_url"`
-	FileSize  int64          `json:"file_size"`
-	MimeType  string         `json:"mime_type"`
 	CreatedAt types.JSONTime `json:"created_at"`
 }
 
+type MemoryProcessedEvent struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	HangoutID uuid.UUID `json:"hangout_id"`
+	FileSize  int64     `json:"file_size"`
+	MimeType  string    `json:"mime_type"`
+}
+
 type PaginatedMemories struct {
 	Data       []MemoryResponse `json:"data"`
 	NextCursor *uuid.UUID       `json:"next_cursor"`
@@ -0,0 +1,32 @@
+package dto
+
+// MFAChallengeRequest finishes a sign in with the challenge token from the
+// first step and a code from the authenticator app or a recovery code.
+type MFAChallengeRequest struct {
+	MFAToken string `json:"mfa_token" validate:"required"`
+	Code     string `json:"code" validate:"required,max=32"`
+
+	Client SessionClient `json:"-" swaggerignore:"true"`
+}
+
+// MFACodeRequest carries an authenticator app or recovery code.
+type MFACodeRequest struct {
+	Code string `json:"code" validate:"required,max=32"`
+}
+
+// TOTPEnrollmentResponse carries the secret for manual entry and the
+// otpauth:// URI to render as a QR code.
+type TOTPEnrollmentResponse struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+// RecoveryCodesResponse is the only time recovery codes are shown.
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+type MFAStatusResponse struct {
+	TOTPEnabled            bool  `json:"totp_enabled"`
+	RecoveryCodesRemaining int64 `json:"recovery_codes_remaining"`
+}
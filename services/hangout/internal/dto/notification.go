@@ -0,0 +1,47 @@
+package dto
+
+import (
+	"github.com/Ernestgio/Hangout-Planner/pkg/shared/types"
+	"github.com/google/uuid"
+)
+
+type NotificationResponse struct {
+	ID        uuid.UUID      `json:"id"`
+	Type      string         `json:"type"`
+	Title     string         `json:"title"`
+	Body      string         `json:"body"`
+	HangoutID *uuid.UUID     `json:"hangout_id"`
+	Read      bool           `json:"read"`
+	ReadAt    types.JSONTime `json:"read_at"`
+	CreatedAt types.JSONTime `json:"created_at"`
+}
+
+type PaginatedNotifications struct {
+	Data       []*NotificationResponse `json:"data"`
+	NextCursor *uuid.UUID              `json:"next_cursor"`
+	HasMore    bool                    `json:"has_more"`
+}
+
+type UnreadNotificationCountResponse struct {
+	Unread int64 `json:"unread"`
+}
+
+type MarkAllNotificationsReadResponse struct {
+	Updated int64 `json:"updated"`
+}
+
+type NotificationPreferenceRequest struct {
+	Type  string `json:"type" validate:"required,oneof=hangout.reminder hangout.rsvp_deadline hangout.status_changed memory.created comment.created comment.mention"`
+	Email bool   `json:"email"`
+	Inbox bool   `json:"inbox"`
+}
+
+type UpdateNotificationPreferencesRequest struct {
+	Preferences []NotificationPreferenceRequest `json:"preferences" validate:"required,min=1,dive"`
+}
+
+type NotificationPreferenceResponse struct {
+	Type  string `json:"type"`
+	Email bool   `json:"email"`
+	Inbox bool   `json:"inbox"`
+}
@@ -0,0 +1,28 @@
+package dto
+
+import "encoding/json"
+
+// Nullable distinguishes a field that is absent from a JSON document from one
+// that is explicitly set to null, as required by JSON Merge Patch (RFC 7396).
+type Nullable[T any] struct {
+	Set   bool
+	Null  bool
+	Value T
+}
+
+func (n *Nullable[T]) UnmarshalJSON(data []byte) error {
+	n.Set = true
+	if string(data) == "null" {
+		n.Null = true
+		var zero T
+		n.Value = zero
+		return nil
+	}
+	n.Null = false
+	return json.Unmarshal(data, &n.Value)
+}
+
+// Present reports whether the field carries a non-null value.
+func (n Nullable[T]) Present() bool {
+	return n.Set && !n.Null
+}
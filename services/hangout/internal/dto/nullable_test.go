@@ -0,0 +1,71 @@
+package dto_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNullable_UnmarshalJSON(t *testing.T) {
+	type payload struct {
+		Name dto.Nullable[string] `json:"name"`
+	}
+
+	testCases := []struct {
+		name          string
+		body          string
+		expectSet     bool
+		expectNull    bool
+		expectPresent bool
+		expectValue   string
+		expectError   bool
+	}{
+		{
+			name: "field absent",
+			body: `{}`,
+		},
+		{
+			name:       "field explicitly null",
+			body:       `{"name": null}`,
+			expectSet:  true,
+			expectNull: true,
+		},
+		{
+			name:          "field set to value",
+			body:          `{"name": "hiking"}`,
+			expectSet:     true,
+			expectPresent: true,
+			expectValue:   "hiking",
+		},
+		{
+			name:          "field set to empty string",
+			body:          `{"name": ""}`,
+			expectSet:     true,
+			expectPresent: true,
+		},
+		{
+			name:        "field has wrong type",
+			body:        `{"name": 42}`,
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var p payload
+			err := json.Unmarshal([]byte(tc.body), &p)
+
+			if tc.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectSet, p.Name.Set)
+			require.Equal(t, tc.expectNull, p.Name.Null)
+			require.Equal(t, tc.expectPresent, p.Name.Present())
+			require.Equal(t, tc.expectValue, p.Name.Value)
+		})
+	}
+}
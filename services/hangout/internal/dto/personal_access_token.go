@@ -0,0 +1,30 @@
+package dto
+
+import (
+	"github.com/Ernestgio/Hangout-Planner/pkg/shared/types"
+	"github.com/google/uuid"
+)
+
+// CreatePersonalAccessTokenRequest leaves ExpiresInDays unset for a token
+// that never expires.
+type CreatePersonalAccessTokenRequest struct {
+	Name          string   `json:"name" validate:"required,max=100"`
+	Scopes        []string `json:"scopes" validate:"required,min=1,dive,oneof=hangouts:read hangouts:write activities:read activities:write memories:read memories:write comments:read comments:write"`
+	ExpiresInDays *int     `json:"expires_in_days" validate:"omitempty,min=1,max=365"`
+}
+
+type PersonalAccessTokenResponse struct {
+	ID         uuid.UUID      `json:"id"`
+	Name       string         `json:"name"`
+	Scopes     []string       `json:"scopes"`
+	ExpiresAt  types.JSONTime `json:"expires_at"`
+	LastUsedAt types.JSONTime `json:"last_used_at"`
+	CreatedAt  types.JSONTime `json:"created_at"`
+}
+
+// PersonalAccessTokenCreatedResponse is the only response that includes the
+// token itself.
+type PersonalAccessTokenCreatedResponse struct {
+	PersonalAccessTokenResponse
+	Token string `json:"token"`
+}
@@ -0,0 +1,98 @@
+package dto
+
+import (
+	"github.com/Ernestgio/Hangout-Planner/pkg/shared/enums"
+	"github.com/Ernestgio/Hangout-Planner/pkg/shared/types"
+	"github.com/google/uuid"
+)
+
+// CreateShareLinkRequest shares the whole hangout, or only one of its albums
+// when album_id is set. Links without expires_at stay valid until revoked.
+type CreateShareLinkRequest struct {
+	AlbumID   *uuid.UUID `json:"album_id"`
+	Password  *string    `json:"password" validate:"omitempty,min=8,max=72"`
+	ExpiresAt *string    `json:"expires_at" validate:"omitempty,datetime=2006-01-02 15:04:05.000"`
+}
+
+type ShareLinkResponse struct {
+	ID               uuid.UUID      `json:"id"`
+	HangoutID        uuid.UUID      `json:"hangout_id"`
+	AlbumID          *uuid.UUID     `json:"album_id"`
+	Scope            string         `json:"scope"`
+	PasswordRequired bool           `json:"password_required"`
+	ExpiresAt        types.JSONTime `json:"expires_at"`
+	RevokedAt        types.JSONTime `json:"revoked_at"`
+	AccessCount      int            `json:"access_count"`
+	LastAccessedAt   types.JSONTime `json:"last_accessed_at"`
+	CreatedAt        types.JSONTime `json:"created_at"`
+}
+
+// ShareLinkCreatedResponse is the only response that includes the token.
+type ShareLinkCreatedResponse struct {
+	ShareLinkResponse
+	Token string `json:"token"`
+}
+
+type ShareLinkAccessResponse struct {
+	ID        uuid.UUID      `json:"id"`
+	Outcome   string         `json:"outcome"`
+	IPAddress string         `json:"ip_address"`
+	UserAgent string         `json:"user_agent"`
+	CreatedAt types.JSONTime `json:"created_at"`
+}
+
+type PaginatedShareLinkAccesses struct {
+	Data       []ShareLinkAccessResponse `json:"data"`
+	NextCursor *uuid.UUID                `json:"next_cursor"`
+	HasMore    bool                      `json:"has_more"`
+}
+
+// ShareAccess is a request made with a share link.
+type ShareAccess struct {
+	Token     string
+	Password  string
+	IPAddress string
+	UserAgent string
+}
+
+// SharedHangoutResponse is the view-only summary served on a share link.
+type SharedHangoutResponse struct {
+	Scope     string                `json:"scope"`
+	Hangout   SharedHangoutSummary  `json:"hangout"`
+	Albums    []SharedAlbumResponse `json:"albums"`
+	ExpiresAt types.JSONTime        `json:"expires_at"`
+}
+
+type SharedHangoutSummary struct {
+	Title       string                `json:"title"`
+	Description *string               `json:"description"`
+	Date        types.JSONTime        `json:"date"`
+	Status      enums.HangoutStatus   `json:"status"`
+	Activities  []ActivityTagResponse `json:"activities"`
+}
+
+type SharedAlbumResponse struct {
+	ID            uuid.UUID  `json:"id"`
+	Name          string     `json:"name"`
+	CoverMemoryID *uuid.UUID `json:"cover_memory_id"`
+}
+
+// SharedMemoryResponse leaves out who uploaded, is tagged in or reacted to
+// the memory. FileURL is a short-lived download URL.
+type SharedMemoryResponse struct {
+	ID        uuid.UUID      `json:"id"`
+	Name      string         `json:"name"`
+	Caption   *string        `json:"caption"`
+	AlbumID   *uuid.UUID     `json:"album_id"`
+	Tags      []string       `json:"tags"`
+	FileURL   string         `json:"file_url"`
+	FileSize  int64          `json:"file_size"`
+	MimeType  string         `json:"mime_type"`
+	CreatedAt types.JSONTime `json:"created_at"`
+}
+
+type PaginatedSharedMemories struct {
+	Data       []SharedMemoryResponse `json:"data"`
+	NextCursor *uuid.UUID             `json:"next_cursor"`
+	HasMore    bool                   `json:"has_more"`
+}
@@ -0,0 +1,36 @@
+package dto
+
+import (
+	"github.com/Ernestgio/Hangout-Planner/pkg/shared/enums"
+	"github.com/Ernestgio/Hangout-Planner/pkg/shared/types"
+	"github.com/google/uuid"
+)
+
+type DeletedHangoutResponse struct {
+	ID        uuid.UUID           `json:"id"`
+	Title     string              `json:"title"`
+	Date      types.JSONTime      `json:"date"`
+	Status    enums.HangoutStatus `json:"status"`
+	DeletedAt types.JSONTime      `json:"deleted_at"`
+	PurgeAt   types.JSONTime      `json:"purge_at"`
+}
+
+type PaginatedDeletedHangouts struct {
+	Data       []*DeletedHangoutResponse `json:"data"`
+	NextCursor *uuid.UUID                `json:"next_cursor"`
+	HasMore    bool                      `json:"has_more"`
+}
+
+type DeletedMemoryResponse struct {
+	ID        uuid.UUID      `json:"id"`
+	Name      string         `json:"name"`
+	HangoutID uuid.UUID      `json:"hangout_id"`
+	DeletedAt types.JSONTime `json:"deleted_at"`
+	PurgeAt   types.JSONTime `json:"purge_at"`
+}
+
+type PaginatedDeletedMemories struct {
+	Data       []*DeletedMemoryResponse `json:"data"`
+	NextCursor *uuid.UUID               `json:"next_cursor"`
+	HasMore    bool                     `json:"has_more"`
+}
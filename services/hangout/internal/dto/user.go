@@ -11,7 +11,34 @@ type CreateUserRequest struct {
 }
 
 type UserResponse struct {
-	ID    uuid.UUID `json:"id"`
-	Name  string    `json:"name"`
-	Email string    `json:"email"`
+	ID            uuid.UUID `json:"id"`
+	Name          string    `json:"name"`
+	Email         string    `json:"email"`
+	EmailVerified bool      `json:"email_verified"`
+	PendingEmail  *string   `json:"pending_email,omitempty"`
+}
+
+type UpdateProfileRequest struct {
+	Name string `json:"name" validate:"required,max=255"`
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" validate:"required"`
+	NewPassword     string `json:"new_password" validate:"required"`
+
+	Client SessionClient `json:"-" swaggerignore:"true"`
+}
+
+// ChangeEmailRequest and DeleteAccountRequest need the current password.
+type ChangeEmailRequest struct {
+	Email    string `json:"email" validate:"required,email,max=255"`
+	Password string `json:"password"`
+
+	SessionID uuid.UUID `json:"-" swaggerignore:"true"`
+}
+
+type DeleteAccountRequest struct {
+	Password string `json:"password"`
+
+	SessionID uuid.UUID `json:"-" swaggerignore:"true"`
 }
@@ -0,0 +1,47 @@
+package dto
+
+import (
+	"github.com/Ernestgio/Hangout-Planner/pkg/shared/types"
+	"github.com/google/uuid"
+)
+
+type CreateWebhookRequest struct {
+	URL        string   `json:"url" validate:"required,max=2048"`
+	EventTypes []string `json:"event_types" validate:"required,min=1,dive,oneof=hangout.created hangout.updated hangout.status_changed hangout.deleted memory.created memory.processed comment.created"`
+}
+
+type WebhookResponse struct {
+	ID         uuid.UUID      `json:"id"`
+	URL        string         `json:"url"`
+	EventTypes []string       `json:"event_types"`
+	CreatedAt  types.JSONTime `json:"created_at"`
+}
+
+// WebhookCreatedResponse is the only response that includes the signing secret.
+type WebhookCreatedResponse struct {
+	WebhookResponse
+	Secret string `json:"secret"`
+}
+
+type WebhookDeliveryResponse struct {
+	ID             uuid.UUID      `json:"id"`
+	EventID        string         `json:"event_id"`
+	EventType      string         `json:"event_type"`
+	Status         string         `json:"status"`
+	Attempts       int            `json:"attempts"`
+	ResponseStatus *int           `json:"response_status"`
+	LastError      string         `json:"last_error,omitempty"`
+	NextAttemptAt  types.JSONTime `json:"next_attempt_at"`
+	LastAttemptAt  types.JSONTime `json:"last_attempt_at"`
+	CreatedAt      types.JSONTime `json:"created_at"`
+}
+
+type PaginatedWebhookDeliveries struct {
+	Data       []*WebhookDeliveryResponse `json:"data"`
+	NextCursor *uuid.UUID                 `json:"next_cursor"`
+	HasMore    bool                       `json:"has_more"`
+}
+
+type WebhookTestEvent struct {
+	WebhookID uuid.UUID `json:"webhook_id"`
+}
@@ -22,6 +22,8 @@ type FileService interface {
 	GetFileByMemoryID(ctx context.Context, memoryID string) (*filepb.FileWithURL, error)
 	GetFilesByMemoryIDs(ctx context.Context, memoryIDs []string) (map[string]*filepb.FileWithURL, error)
 	DeleteFile(ctx context.Context, memoryID string) error
+	CreateArchive(ctx context.Context, baseStoragePath string, archiveName string, memoryIDs []string, documents []*filepb.ArchiveDocument) (*filepb.Archive, error)
+	GetArchiveStatus(ctx context.Context, archiveID string, baseStoragePath string) (*filepb.Archive, error)
 	Close() error
 }
 
@@ -104,6 +106,32 @@ func (c *fileServiceClient) DeleteFile(ctx context.Context, memoryID string) err
 	return err
 }
 
+func (c *fileServiceClient) CreateArchive(ctx context.Context, baseStoragePath string, archiveName string, memoryIDs []string, documents []*filepb.ArchiveDocument) (*filepb.Archive, error) {
+	req := &filepb.CreateArchiveRequest{
+		BaseStoragePath: baseStoragePath,
+		ArchiveName:     archiveName,
+		MemoryIds:       memoryIDs,
+		Documents:       documents,
+	}
+	resp, err := c.client.CreateArchive(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Archive, nil
+}
+
+func (c *fileServiceClient) GetArchiveStatus(ctx context.Context, archiveID string, baseStoragePath string) (*filepb.Archive, error) {
+	req := &filepb.GetArchiveStatusRequest{
+		ArchiveId:       archiveID,
+		BaseStoragePath: baseStoragePath,
+	}
+	resp, err := c.client.GetArchiveStatus(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Archive, nil
+}
+
 func (c *fileServiceClient) Close() error {
 	return c.conn.Close()
 }
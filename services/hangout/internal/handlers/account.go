@@ -0,0 +1,191 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/http/request"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/http/response"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/services"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type AccountHandler interface {
+	GetProfile(c echo.Context) error
+	UpdateProfile(c echo.Context) error
+	ChangePassword(c echo.Context) error
+	ChangeEmail(c echo.Context) error
+	DeleteAccount(c echo.Context) error
+}
+
+type accountHandler struct {
+	accountService  services.AccountService
+	responseBuilder *response.Builder
+}
+
+func NewAccountHandler(accountService services.AccountService, responseBuilder *response.Builder) AccountHandler {
+	return &accountHandler{
+		accountService:  accountService,
+		responseBuilder: responseBuilder,
+	}
+}
+
+// @Summary      Get profile
+// @Description  Get the profile of the signed in user
+// @Tags         Me
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.StandardResponse{data=dto.UserResponse}
+// @Failure      401  {object}  response.StandardResponse
+// @Failure      404  {object}  response.StandardResponse
+// @Failure      500  {object}  response.StandardResponse
+// @Router       /me [get]
+func (h *accountHandler) GetProfile(c echo.Context) error {
+	userID := c.Get("user_id").(uuid.UUID)
+	ctx := c.Request().Context()
+	profile, err := h.accountService.GetProfile(ctx, userID)
+	if err != nil {
+		return h.accountError(c, err)
+	}
+	return c.JSON(http.StatusOK, h.responseBuilder.Success(constants.ProfileRetrievedSuccessfully, profile))
+}
+
+// @Summary      Update profile
+// @Description  Change the display name of the signed in user
+// @Tags         Me
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        profile  body      dto.UpdateProfileRequest  true  "Profile"
+// @Success      200      {object}  response.StandardResponse{data=dto.UserResponse}
+// @Failure      400      {object}  response.StandardResponse
+// @Failure      401      {object}  response.StandardResponse
+// @Failure      500      {object}  response.StandardResponse
+// @Router       /me [put]
+func (h *accountHandler) UpdateProfile(c echo.Context) error {
+	req, err := request.BindAndValidate[dto.UpdateProfileRequest](c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(apperrors.ErrInvalidPayload))
+	}
+
+	userID := c.Get("user_id").(uuid.UUID)
+	ctx := c.Request().Context()
+	profile, err := h.accountService.UpdateProfile(ctx, userID, req)
+	if err != nil {
+		return h.accountError(c, err)
+	}
+	return c.JSON(http.StatusOK, h.responseBuilder.Success(constants.ProfileUpdatedSuccessfully, profile))
+}
+
+// @Summary      Change password
+// @Description  Change the password after checking the current one. Every session is signed out and a token for a new session on this device is returned.
+// @Tags         Me
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        password  body      dto.ChangePasswordRequest  true  "Current and new password"
+// @Success      200       {object}  response.StandardResponse{data=dto.SignInResponse}
+// @Failure      400       {object}  response.StandardResponse
+// @Failure      401       {object}  response.StandardResponse
+// @Failure      500       {object}  response.StandardResponse
+// @Router       /me/password [post]
+func (h *accountHandler) ChangePassword(c echo.Context) error {
+	req, err := request.BindAndValidate[dto.ChangePasswordRequest](c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(apperrors.ErrInvalidPayload))
+	}
+	req.Client = sessionClientFromRequest(c)
+
+	userID := c.Get("user_id").(uuid.UUID)
+	ctx := c.Request().Context()
+	token, err := h.accountService.ChangePassword(ctx, userID, req)
+	if err != nil {
+		return h.accountError(c, err)
+	}
+	return c.JSON(http.StatusOK, h.responseBuilder.Success(constants.PasswordChangedSuccessfully, token))
+}
+
+// @Summary      Change email
+// @Description  Request a change of the email address after checking the current password, or a sign in within the last few minutes for accounts without one. The email changes once the link sent to the new address is followed, and the old address is told about the request.
+// @Tags         Me
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        email  body      dto.ChangeEmailRequest  true  "New email and current password"
+// @Success      200    {object}  response.StandardResponse{data=dto.UserResponse}
+// @Failure      400    {object}  response.StandardResponse
+// @Failure      401    {object}  response.StandardResponse
+// @Failure      409    {object}  response.StandardResponse
+// @Failure      500    {object}  response.StandardResponse
+// @Router       /me/email [post]
+func (h *accountHandler) ChangeEmail(c echo.Context) error {
+	req, err := request.BindAndValidate[dto.ChangeEmailRequest](c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(apperrors.ErrInvalidPayload))
+	}
+
+	req.SessionID = currentSessionID(c)
+
+	userID := c.Get("user_id").(uuid.UUID)
+	ctx := c.Request().Context()
+	profile, err := h.accountService.ChangeEmail(ctx, userID, req)
+	if err != nil {
+		return h.accountError(c, err)
+	}
+	return c.JSON(http.StatusOK, h.responseBuilder.Success(constants.EmailChangedSuccessfully, profile))
+}
+
+// @Summary      Delete account
+// @Description  Delete the account and sign out everywhere after checking the current password, or a sign in within the last few minutes for accounts without one. Hangouts, activities and memories go to the trash and are purged with their files once the trash retention has passed.
+// @Tags         Me
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        confirmation  body      dto.DeleteAccountRequest  true  "Current password"
+// @Success      200           {object}  response.StandardResponse
+// @Failure      400           {object}  response.StandardResponse
+// @Failure      401           {object}  response.StandardResponse
+// @Failure      500           {object}  response.StandardResponse
+// @Router       /me [delete]
+func (h *accountHandler) DeleteAccount(c echo.Context) error {
+	req, err := request.BindAndValidate[dto.DeleteAccountRequest](c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(apperrors.ErrInvalidPayload))
+	}
+
+	req.SessionID = currentSessionID(c)
+
+	userID := c.Get("user_id").(uuid.UUID)
+	ctx := c.Request().Context()
+	if err := h.accountService.DeleteAccount(ctx, userID, req); err != nil {
+		return h.accountError(c, err)
+	}
+	return c.JSON(http.StatusOK, h.responseBuilder.Success(constants.AccountDeletedSuccessfully, nil))
+}
+
+func (h *accountHandler) accountError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, apperrors.ErrInvalidCredentials), errors.Is(err, apperrors.ErrWeakPassword), errors.Is(err, apperrors.ErrEmailUnchanged):
+		return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(err))
+	case errors.Is(err, apperrors.ErrRecentSignInRequired):
+		return c.JSON(http.StatusUnauthorized, h.responseBuilder.Error(err))
+	case errors.Is(err, apperrors.ErrAccountDisabled):
+		return c.JSON(http.StatusForbidden, h.responseBuilder.Error(err))
+	case errors.Is(err, apperrors.ErrUserNotFound):
+		return c.JSON(http.StatusNotFound, h.responseBuilder.Error(err))
+	case errors.Is(err, apperrors.ErrUserAlreadyExists):
+		return c.JSON(http.StatusConflict, h.responseBuilder.Error(err))
+	default:
+		return c.JSON(http.StatusInternalServerError, h.responseBuilder.Error(err))
+	}
+}
+
+// currentSessionID is uuid.Nil for personal access tokens, which have no session.
+func currentSessionID(c echo.Context) uuid.UUID {
+	sessionID, _ := c.Get("session_id").(uuid.UUID)
+	return sessionID
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/http/etag"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/http/request"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/http/response"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/services"
@@ -65,7 +66,10 @@ func (h *activityHandler) CreateActivity(c echo.Context) error {
 // @Tags         Activities
 // @Produce      json
 // @Param        activity_id path string true "Activity ID"
+// @Param        If-None-Match header string false "ETag from a previous response"
 // @Success      200 {object} response.StandardResponse{data=dto.ActivityDetailResponse} "Activity fetched successfully"
+// @Header       200 {string} ETag "Version tag of the activity"
+// @Success      304 "Activity not modified"
 // @Failure      400 {object} response.StandardResponse "Invalid activity ID"
 // @Failure      401 {object} response.StandardResponse "Unauthorized"
 // @Failure      500 {object} response.StandardResponse "Internal server error"
@@ -86,6 +90,10 @@ func (h *activityHandler) GetActivityByID(c echo.Context) error {
 		}
 		return c.JSON(http.StatusInternalServerError, h.responseBuilder.Error(err))
 	}
+	etag.SetHeader(c, activity.Version)
+	if etag.NotModified(c, activity.Version) {
+		return c.NoContent(http.StatusNotModified)
+	}
 	return c.JSON(http.StatusOK, h.responseBuilder.Success(constants.ActivityRetrievedSuccessfully, activity))
 }
 
@@ -115,10 +123,14 @@ func (h *activityHandler) GetAllActivities(c echo.Context) error {
 // @Produce      json
 // @Param        activity_id path string true "Activity ID"
 // @Param        activity body dto.UpdateActivityRequest true "Activity update data"
+// @Param        If-Match header string true "ETag of the activity being updated"
 // @Success      200 {object} response.StandardResponse{data=dto.ActivityDetailResponse} "Activity updated successfully"
+// @Header       200 {string} ETag "Version tag of the updated activity"
 // @Failure      400 {object} response.StandardResponse "Invalid request payload"
 // @Failure      401 {object} response.StandardResponse "Unauthorized"
 // @Failure      404 {object} response.StandardResponse "resource not found"
+// @Failure      412 {object} response.StandardResponse "Activity was modified by another request"
+// @Failure      428 {object} response.StandardResponse "If-Match header is required"
 // @Failure      500 {object} response.StandardResponse "Internal server error"
 // @Security     BearerAuth
 // @Router       /activities/{activity_id} [put]
@@ -132,15 +144,23 @@ func (h *activityHandler) UpdateActivity(c echo.Context) error {
 	if err != nil {
 		return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(apperrors.ErrInvalidPayload))
 	}
+	version, err := etag.IfMatchVersion(c)
+	if err != nil {
+		return c.JSON(ifMatchErrorStatus(err), h.responseBuilder.Error(err))
+	}
 	userID := c.Get("user_id").(uuid.UUID)
 	ctx := c.Request().Context()
-	activity, err := h.activityService.UpdateActivity(ctx, activityID, userID, req)
+	activity, err := h.activityService.UpdateActivity(ctx, activityID, userID, version, req)
 	if err != nil {
 		if err == apperrors.ErrNotFound {
 			return c.JSON(http.StatusNotFound, h.responseBuilder.Error(err))
 		}
+		if err == apperrors.ErrPreconditionFailed {
+			return c.JSON(http.StatusPreconditionFailed, h.responseBuilder.Error(err))
+		}
 		return c.JSON(http.StatusInternalServerError, h.responseBuilder.Error(err))
 	}
+	etag.SetHeader(c, activity.Version)
 	return c.JSON(http.StatusOK, h.responseBuilder.Success(constants.ActivityUpdatedSuccessfully, activity))
 }
 
@@ -149,10 +169,13 @@ func (h *activityHandler) UpdateActivity(c echo.Context) error {
 // @Tags         Activities
 // @Produce      json
 // @Param        activity_id path string true "Activity ID"
+// @Param        If-Match header string true "ETag of the activity being deleted"
 // @Success      200 {object} response.StandardResponse "Activity deleted successfully"
 // @Failure      400 {object} response.StandardResponse "Invalid activity ID"
 // @Failure      401 {object} response.StandardResponse "Unauthorized"
 // @Failure      404 {object} response.StandardResponse "resource not found"
+// @Failure      412 {object} response.StandardResponse "Activity was modified by another request"
+// @Failure      428 {object} response.StandardResponse "If-Match header is required"
 // @Failure      500 {object} response.StandardResponse "Internal server error"
 // @Security     BearerAuth
 // @Router       /activities/{activity_id} [delete]
@@ -162,13 +185,20 @@ func (h *activityHandler) DeleteActivity(c echo.Context) error {
 	if err != nil {
 		return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(apperrors.ErrInvalidActivityID))
 	}
+	version, err := etag.IfMatchVersion(c)
+	if err != nil {
+		return c.JSON(ifMatchErrorStatus(err), h.responseBuilder.Error(err))
+	}
 	userID := c.Get("user_id").(uuid.UUID)
 	ctx := c.Request().Context()
-	err = h.activityService.DeleteActivity(ctx, activityID, userID)
+	err = h.activityService.DeleteActivity(ctx, activityID, userID, version)
 	if err != nil {
 		if err == apperrors.ErrNotFound {
 			return c.JSON(http.StatusNotFound, h.responseBuilder.Error(err))
 		}
+		if err == apperrors.ErrPreconditionFailed {
+			return c.JSON(http.StatusPreconditionFailed, h.responseBuilder.Error(err))
+		}
 		return c.JSON(http.StatusInternalServerError, h.responseBuilder.Error(err))
 	}
 	return c.JSON(http.StatusOK, h.responseBuilder.Success(constants.ActivityDeletedSuccessfully, nil))
@@ -0,0 +1,325 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/http/request"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/http/response"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/services"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type AdminHandler interface {
+	SearchUsers(c echo.Context) error
+	DisableUser(c echo.Context) error
+	EnableUser(c echo.Context) error
+	ForcePasswordReset(c echo.Context) error
+	GetHangout(c echo.Context) error
+	HideMemory(c echo.Context) error
+	UnhideMemory(c echo.Context) error
+	RemoveMemory(c echo.Context) error
+	ListAuditLogs(c echo.Context) error
+}
+
+type adminHandler struct {
+	adminService    services.AdminService
+	responseBuilder *response.Builder
+}
+
+func NewAdminHandler(adminService services.AdminService, responseBuilder *response.Builder) AdminHandler {
+	return &adminHandler{
+		adminService:    adminService,
+		responseBuilder: responseBuilder,
+	}
+}
+
+// @Summary      Search users
+// @Description  Search accounts by email or name, newest first. An empty query lists every account. The search is recorded in the audit log with the reason.
+// @Tags         Admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        q         query     string  false  "Part of the email or name"
+// @Param        reason    query     string  true   "Why the search is needed"
+// @Param        after_id  query     string  false  "Cursor for pagination (user ID)"
+// @Param        limit     query     int     false  "Limit for pagination"
+// @Success      200       {object}  response.StandardResponse{data=dto.PaginatedAdminUsers}
+// @Failure      400       {object}  response.StandardResponse
+// @Failure      401       {object}  response.StandardResponse
+// @Failure      403       {object}  response.StandardResponse
+// @Failure      500       {object}  response.StandardResponse
+// @Router       /admin/users [get]
+func (h *adminHandler) SearchUsers(c echo.Context) error {
+	pagination, err := cursorPaginationFromQuery(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(err))
+	}
+
+	actorID := c.Get("user_id").(uuid.UUID)
+	ctx := c.Request().Context()
+	users, err := h.adminService.SearchUsers(ctx, actorID, c.QueryParam("q"), c.QueryParam("reason"), pagination)
+	if err != nil {
+		return c.JSON(adminErrorStatus(err), h.responseBuilder.Error(err))
+	}
+	return c.JSON(http.StatusOK, h.responseBuilder.Success(constants.AdminUsersRetrievedSuccessfully, users))
+}
+
+// @Summary      Disable a user
+// @Description  Block the account from signing in, sign it out on every device and stop its personal access tokens. Admins cannot disable their own account.
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        user_id  path      string                  true  "User ID"
+// @Param        action   body      dto.AdminActionRequest  true  "Reason for the audit log"
+// @Success      200      {object}  response.StandardResponse{data=dto.AdminUserResponse}
+// @Failure      400      {object}  response.StandardResponse
+// @Failure      401      {object}  response.StandardResponse
+// @Failure      403      {object}  response.StandardResponse
+// @Failure      404      {object}  response.StandardResponse
+// @Failure      500      {object}  response.StandardResponse
+// @Router       /admin/users/{user_id}/disable [post]
+func (h *adminHandler) DisableUser(c echo.Context) error {
+	userID, req, err := adminUserAction(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(err))
+	}
+
+	actorID := c.Get("user_id").(uuid.UUID)
+	ctx := c.Request().Context()
+	user, err := h.adminService.DisableUser(ctx, actorID, userID, req.Reason)
+	if err != nil {
+		return c.JSON(adminErrorStatus(err), h.responseBuilder.Error(err))
+	}
+	return c.JSON(http.StatusOK, h.responseBuilder.Success(constants.AdminUserDisabledSuccessfully, user))
+}
+
+// @Summary      Enable a user
+// @Description  Let a disabled account sign in again.
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        user_id  path      string                  true  "User ID"
+// @Param        action   body      dto.AdminActionRequest  true  "Reason for the audit log"
+// @Success      200      {object}  response.StandardResponse{data=dto.AdminUserResponse}
+// @Failure      400      {object}  response.StandardResponse
+// @Failure      401      {object}  response.StandardResponse
+// @Failure      403      {object}  response.StandardResponse
+// @Failure      404      {object}  response.StandardResponse
+// @Failure      500      {object}  response.StandardResponse
+// @Router       /admin/users/{user_id}/enable [post]
+func (h *adminHandler) EnableUser(c echo.Context) error {
+	userID, req, err := adminUserAction(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(err))
+	}
+
+	actorID := c.Get("user_id").(uuid.UUID)
+	ctx := c.Request().Context()
+	user, err := h.adminService.EnableUser(ctx, actorID, userID, req.Reason)
+	if err != nil {
+		return c.JSON(adminErrorStatus(err), h.responseBuilder.Error(err))
+	}
+	return c.JSON(http.StatusOK, h.responseBuilder.Success(constants.AdminUserEnabledSuccessfully, user))
+}
+
+// @Summary      Force a password reset
+// @Description  Clear the account's password, sign it out on every device and email a reset link. Until the link is used the account can only sign in through a linked provider.
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        user_id  path      string                  true  "User ID"
+// @Param        action   body      dto.AdminActionRequest  true  "Reason for the audit log"
+// @Success      200      {object}  response.StandardResponse
+// @Failure      400      {object}  response.StandardResponse
+// @Failure      401      {object}  response.StandardResponse
+// @Failure      403      {object}  response.StandardResponse
+// @Failure      404      {object}  response.StandardResponse
+// @Failure      500      {object}  response.StandardResponse
+// @Router       /admin/users/{user_id}/password-reset [post]
+func (h *adminHandler) ForcePasswordReset(c echo.Context) error {
+	userID, req, err := adminUserAction(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(err))
+	}
+
+	actorID := c.Get("user_id").(uuid.UUID)
+	ctx := c.Request().Context()
+	if err := h.adminService.ForcePasswordReset(ctx, actorID, userID, req.Reason); err != nil {
+		return c.JSON(adminErrorStatus(err), h.responseBuilder.Error(err))
+	}
+	return c.JSON(http.StatusOK, h.responseBuilder.Success(constants.AdminPasswordResetForcedSuccessfully, nil))
+}
+
+// @Summary      View a hangout
+// @Description  Get any hangout with its activities, whoever owns it. The view is recorded in the audit log with the reason.
+// @Tags         Admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        hangout_id  path      string  true  "Hangout ID"
+// @Param        reason      query     string  true  "Why the hangout is viewed"
+// @Success      200         {object}  response.StandardResponse{data=dto.HangoutDetailResponse}
+// @Failure      400         {object}  response.StandardResponse
+// @Failure      401         {object}  response.StandardResponse
+// @Failure      403         {object}  response.StandardResponse
+// @Failure      404         {object}  response.StandardResponse
+// @Failure      500         {object}  response.StandardResponse
+// @Router       /admin/hangouts/{hangout_id} [get]
+func (h *adminHandler) GetHangout(c echo.Context) error {
+	hangoutID, err := uuid.Parse(c.Param("hangout_id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(apperrors.ErrInvalidHangoutID))
+	}
+
+	actorID := c.Get("user_id").(uuid.UUID)
+	ctx := c.Request().Context()
+	hangout, err := h.adminService.GetHangout(ctx, actorID, hangoutID, c.QueryParam("reason"))
+	if err != nil {
+		return c.JSON(adminErrorStatus(err), h.responseBuilder.Error(err))
+	}
+	return c.JSON(http.StatusOK, h.responseBuilder.Success(constants.AdminHangoutRetrievedSuccessfully, hangout))
+}
+
+// @Summary      Hide a memory
+// @Description  Take a memory out of its hangout's gallery, archives and share links. The memory and its file are kept for review.
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        memory_id  path      string                  true  "Memory ID"
+// @Param        action     body      dto.AdminActionRequest  true  "Reason for the audit log"
+// @Success      200        {object}  response.StandardResponse
+// @Failure      400        {object}  response.StandardResponse
+// @Failure      401        {object}  response.StandardResponse
+// @Failure      403        {object}  response.StandardResponse
+// @Failure      404        {object}  response.StandardResponse
+// @Failure      500        {object}  response.StandardResponse
+// @Router       /admin/memories/{memory_id}/hide [post]
+func (h *adminHandler) HideMemory(c echo.Context) error {
+	return h.memoryAction(c, h.adminService.HideMemory, constants.AdminMemoryHiddenSuccessfully)
+}
+
+// @Summary      Unhide a memory
+// @Description  Show a hidden memory in its hangout again.
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        memory_id  path      string                  true  "Memory ID"
+// @Param        action     body      dto.AdminActionRequest  true  "Reason for the audit log"
+// @Success      200        {object}  response.StandardResponse
+// @Failure      400        {object}  response.StandardResponse
+// @Failure      401        {object}  response.StandardResponse
+// @Failure      403        {object}  response.StandardResponse
+// @Failure      404        {object}  response.StandardResponse
+// @Failure      500        {object}  response.StandardResponse
+// @Router       /admin/memories/{memory_id}/unhide [post]
+func (h *adminHandler) UnhideMemory(c echo.Context) error {
+	return h.memoryAction(c, h.adminService.UnhideMemory, constants.AdminMemoryUnhiddenSuccessfully)
+}
+
+// @Summary      Remove a memory
+// @Description  Hide a memory and move it to the trash, where it is purged with its file once the retention has passed.
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        memory_id  path      string                  true  "Memory ID"
+// @Param        action     body      dto.AdminActionRequest  true  "Reason for the audit log"
+// @Success      200        {object}  response.StandardResponse
+// @Failure      400        {object}  response.StandardResponse
+// @Failure      401        {object}  response.StandardResponse
+// @Failure      403        {object}  response.StandardResponse
+// @Failure      404        {object}  response.StandardResponse
+// @Failure      500        {object}  response.StandardResponse
+// @Router       /admin/memories/{memory_id} [delete]
+func (h *adminHandler) RemoveMemory(c echo.Context) error {
+	return h.memoryAction(c, h.adminService.RemoveMemory, constants.AdminMemoryRemovedSuccessfully)
+}
+
+// @Summary      List audit log entries
+// @Description  List admin actions, newest first, optionally for one admin or one target.
+// @Tags         Admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        actor_id  query     string  false  "Only actions by this admin"
+// @Param        target    query     string  false  "Only actions on this user, hangout or memory ID, or this search query"
+// @Param        after_id  query     string  false  "Cursor for pagination (entry ID)"
+// @Param        limit     query     int     false  "Limit for pagination"
+// @Success      200       {object}  response.StandardResponse{data=dto.PaginatedAdminAuditLogs}
+// @Failure      400       {object}  response.StandardResponse
+// @Failure      401       {object}  response.StandardResponse
+// @Failure      403       {object}  response.StandardResponse
+// @Failure      500       {object}  response.StandardResponse
+// @Router       /admin/audit-logs [get]
+func (h *adminHandler) ListAuditLogs(c echo.Context) error {
+	filter := &dto.AdminAuditLogFilter{Target: c.QueryParam("target")}
+	if actorIDStr := c.QueryParam("actor_id"); actorIDStr != "" {
+		actorID, err := uuid.Parse(actorIDStr)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(apperrors.ErrInvalidUserID))
+		}
+		filter.ActorID = &actorID
+	}
+	pagination, err := cursorPaginationFromQuery(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(err))
+	}
+
+	ctx := c.Request().Context()
+	entries, err := h.adminService.ListAuditLogs(ctx, filter, pagination)
+	if err != nil {
+		return c.JSON(adminErrorStatus(err), h.responseBuilder.Error(err))
+	}
+	return c.JSON(http.StatusOK, h.responseBuilder.Success(constants.AdminAuditLogsRetrievedSuccessfully, entries))
+}
+
+// adminUserAction parses the target user and the reason of a user action.
+func adminUserAction(c echo.Context) (uuid.UUID, *dto.AdminActionRequest, error) {
+	userID, err := uuid.Parse(c.Param("user_id"))
+	if err != nil {
+		return uuid.Nil, nil, apperrors.ErrInvalidUserID
+	}
+	req, err := request.BindAndValidate[dto.AdminActionRequest](c)
+	if err != nil {
+		return uuid.Nil, nil, apperrors.ErrAdminReasonRequired
+	}
+	return userID, req, nil
+}
+
+func (h *adminHandler) memoryAction(c echo.Context, action func(ctx context.Context, actorID uuid.UUID, memoryID uuid.UUID, reason string) error, message string) error {
+	memoryID, err := uuid.Parse(c.Param("memory_id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(apperrors.ErrInvalidMemoryID))
+	}
+	req, err := request.BindAndValidate[dto.AdminActionRequest](c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(apperrors.ErrAdminReasonRequired))
+	}
+
+	actorID := c.Get("user_id").(uuid.UUID)
+	if err := action(c.Request().Context(), actorID, memoryID, req.Reason); err != nil {
+		return c.JSON(adminErrorStatus(err), h.responseBuilder.Error(err))
+	}
+	return c.JSON(http.StatusOK, h.responseBuilder.Success(message, nil))
+}
+
+func adminErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, apperrors.ErrAdminReasonRequired), errors.Is(err, apperrors.ErrInvalidCursorPagination):
+		return http.StatusBadRequest
+	case errors.Is(err, apperrors.ErrCannotModerateSelf):
+		return http.StatusForbidden
+	case errors.Is(err, apperrors.ErrUserNotFound), errors.Is(err, apperrors.ErrNotFound), errors.Is(err, apperrors.ErrMemoryNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
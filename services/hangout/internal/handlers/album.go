@@ -0,0 +1,261 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/http/request"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/http/response"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/http/sanitizer"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/services"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+type AlbumHandler interface {
+	ListAlbums(c echo.Context) error
+	CreateAlbum(c echo.Context) error
+	PatchAlbum(c echo.Context) error
+	DeleteAlbum(c echo.Context) error
+	MoveMemory(c echo.Context) error
+}
+
+type albumHandler struct {
+	albumService    services.AlbumService
+	responseBuilder *response.Builder
+}
+
+func NewAlbumHandler(albumService services.AlbumService, responseBuilder *response.Builder) AlbumHandler {
+	return &albumHandler{
+		albumService:    albumService,
+		responseBuilder: responseBuilder,
+	}
+}
+
+// @Summary      List Albums
+// @Description  Lists the albums of a hangout, oldest first. Only participants of the hangout can list albums.
+// @Tags         Albums
+// @Produce      json
+// @Param        hangout_id path string true "Hangout ID"
+// @Success      200 {object} response.StandardResponse{data=[]dto.AlbumResponse} "Albums retrieved successfully"
+// @Failure      400 {object} response.StandardResponse "Invalid hangout ID"
+// @Failure      401 {object} response.StandardResponse "Unauthorized"
+// @Failure      404 {object} response.StandardResponse "Hangout not found"
+// @Failure      500 {object} response.StandardResponse "Internal server error"
+// @Security     BearerAuth
+// @Router       /hangouts/{hangout_id}/albums [get]
+func (h *albumHandler) ListAlbums(c echo.Context) error {
+	hangoutID, err := uuid.Parse(c.Param("hangout_id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(apperrors.ErrInvalidHangoutID))
+	}
+
+	userID := c.Get("user_id").(uuid.UUID)
+	ctx := c.Request().Context()
+
+	albums, err := h.albumService.ListAlbums(ctx, userID, hangoutID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.JSON(http.StatusNotFound, h.responseBuilder.Error(apperrors.ErrNotFound))
+		}
+		return c.JSON(http.StatusInternalServerError, h.responseBuilder.Error(err))
+	}
+
+	return c.JSON(http.StatusOK, h.responseBuilder.Success(constants.AlbumsRetrievedSuccessfully, albums))
+}
+
+// @Summary      Create Album
+// @Description  Creates a named album in a hangout. Names are unique within a hangout, ignoring case. Only the organizer of the hangout can create albums.
+// @Tags         Albums
+// @Accept       json
+// @Produce      json
+// @Param        hangout_id path string true "Hangout ID"
+// @Param        album body dto.CreateAlbumRequest true "Album"
+// @Success      201 {object} response.StandardResponse{data=dto.AlbumResponse} "Album created successfully"
+// @Failure      400 {object} response.StandardResponse "Invalid request payload"
+// @Failure      401 {object} response.StandardResponse "Unauthorized"
+// @Failure      403 {object} response.StandardResponse "Not the organizer of the hangout"
+// @Failure      404 {object} response.StandardResponse "Hangout not found"
+// @Failure      409 {object} response.StandardResponse "Album name already taken"
+// @Failure      500 {object} response.StandardResponse "Internal server error"
+// @Security     BearerAuth
+// @Router       /hangouts/{hangout_id}/albums [post]
+func (h *albumHandler) CreateAlbum(c echo.Context) error {
+	hangoutID, err := uuid.Parse(c.Param("hangout_id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(apperrors.ErrInvalidHangoutID))
+	}
+
+	req, err := request.BindAndValidate[dto.CreateAlbumRequest](c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(apperrors.ErrInvalidPayload))
+	}
+
+	req.Name = sanitizer.SanitizeString(strings.TrimSpace(req.Name))
+	if req.Name == "" {
+		return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(apperrors.ErrInvalidPayload))
+	}
+
+	userID := c.Get("user_id").(uuid.UUID)
+	ctx := c.Request().Context()
+
+	album, err := h.albumService.CreateAlbum(ctx, userID, hangoutID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return c.JSON(http.StatusNotFound, h.responseBuilder.Error(apperrors.ErrNotFound))
+		case errors.Is(err, apperrors.ErrForbidden):
+			return c.JSON(http.StatusForbidden, h.responseBuilder.Error(err))
+		case errors.Is(err, apperrors.ErrAlbumNameTaken):
+			return c.JSON(http.StatusConflict, h.responseBuilder.Error(err))
+		}
+		return c.JSON(http.StatusInternalServerError, h.responseBuilder.Error(err))
+	}
+
+	return c.JSON(http.StatusCreated, h.responseBuilder.Success(constants.AlbumCreatedSuccessfully, album))
+}
+
+// @Summary      Patch Album
+// @Description  Applies a JSON Merge Patch (RFC 7396) to the name and cover of an album. The cover must be a memory of the album; null removes it. Only the organizer of the hangout can change albums.
+// @Tags         Albums
+// @Accept       application/merge-patch+json
+// @Produce      json
+// @Param        album_id path string true "Album ID"
+// @Param        album body dto.PatchAlbumRequest true "Merge patch document"
+// @Success      200 {object} response.StandardResponse{data=dto.AlbumResponse} "Album updated successfully"
+// @Failure      400 {object} response.StandardResponse "Invalid request payload or cover"
+// @Failure      401 {object} response.StandardResponse "Unauthorized"
+// @Failure      403 {object} response.StandardResponse "Not the organizer of the hangout"
+// @Failure      404 {object} response.StandardResponse "Album not found"
+// @Failure      409 {object} response.StandardResponse "Album name already taken"
+// @Failure      415 {object} response.StandardResponse "Unsupported content type"
+// @Failure      500 {object} response.StandardResponse "Internal server error"
+// @Security     BearerAuth
+// @Router       /albums/{album_id} [patch]
+func (h *albumHandler) PatchAlbum(c echo.Context) error {
+	albumID, err := uuid.Parse(c.Param("album_id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(apperrors.ErrInvalidAlbumID))
+	}
+
+	req, err := request.BindMergePatch[dto.PatchAlbumRequest](c)
+	if err != nil {
+		if errors.Is(err, echo.ErrUnsupportedMediaType) {
+			return c.JSON(http.StatusUnsupportedMediaType, h.responseBuilder.Error(err))
+		}
+		return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(apperrors.ErrInvalidPayload))
+	}
+
+	if req.Name.Set {
+		req.Name.Value = sanitizer.SanitizeString(strings.TrimSpace(req.Name.Value))
+		if req.Name.Null || req.Name.Value == "" || utf8.RuneCountInString(req.Name.Value) > constants.MaxAlbumNameLength {
+			return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(apperrors.ErrInvalidPayload))
+		}
+	}
+
+	userID := c.Get("user_id").(uuid.UUID)
+	ctx := c.Request().Context()
+
+	album, err := h.albumService.PatchAlbum(ctx, userID, albumID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return c.JSON(http.StatusNotFound, h.responseBuilder.Error(apperrors.ErrNotFound))
+		case errors.Is(err, apperrors.ErrForbidden):
+			return c.JSON(http.StatusForbidden, h.responseBuilder.Error(err))
+		case errors.Is(err, apperrors.ErrInvalidAlbumCover):
+			return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(err))
+		case errors.Is(err, apperrors.ErrAlbumNameTaken):
+			return c.JSON(http.StatusConflict, h.responseBuilder.Error(err))
+		}
+		return c.JSON(http.StatusInternalServerError, h.responseBuilder.Error(err))
+	}
+
+	return c.JSON(http.StatusOK, h.responseBuilder.Success(constants.AlbumUpdatedSuccessfully, album))
+}
+
+// @Summary      Delete Album
+// @Description  Deletes an album. Its memories are kept and appended, in album order, to the memories outside any album. Share links for the album are revoked. Only the organizer of the hangout can delete albums.
+// @Tags         Albums
+// @Produce      json
+// @Param        album_id path string true "Album ID"
+// @Success      200 {object} response.StandardResponse "Album deleted successfully"
+// @Failure      400 {object} response.StandardResponse "Invalid album ID"
+// @Failure      401 {object} response.StandardResponse "Unauthorized"
+// @Failure      403 {object} response.StandardResponse "Not the organizer of the hangout"
+// @Failure      404 {object} response.StandardResponse "Album not found"
+// @Failure      500 {object} response.StandardResponse "Internal server error"
+// @Security     BearerAuth
+// @Router       /albums/{album_id} [delete]
+func (h *albumHandler) DeleteAlbum(c echo.Context) error {
+	albumID, err := uuid.Parse(c.Param("album_id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(apperrors.ErrInvalidAlbumID))
+	}
+
+	userID := c.Get("user_id").(uuid.UUID)
+	ctx := c.Request().Context()
+
+	if err := h.albumService.DeleteAlbum(ctx, userID, albumID); err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return c.JSON(http.StatusNotFound, h.responseBuilder.Error(apperrors.ErrNotFound))
+		case errors.Is(err, apperrors.ErrForbidden):
+			return c.JSON(http.StatusForbidden, h.responseBuilder.Error(err))
+		}
+		return c.JSON(http.StatusInternalServerError, h.responseBuilder.Error(err))
+	}
+
+	return c.JSON(http.StatusOK, h.responseBuilder.Success(constants.AlbumDeletedSuccessfully, nil))
+}
+
+// @Summary      Move Memory
+// @Description  Moves a memory into an album, or out of any album when album_id is null, and places it between after_id and before_id. Give one of them to place the memory next to another memory, or neither to append it. Only the organizer of the hangout can arrange memories.
+// @Tags         Albums
+// @Accept       json
+// @Produce      json
+// @Param        memory_id path string true "Memory ID"
+// @Param        placement body dto.MoveMemoryRequest true "Target album and neighbours"
+// @Success      200 {object} response.StandardResponse{data=dto.MemoryPlacementResponse} "Memory moved successfully"
+// @Failure      400 {object} response.StandardResponse "Invalid request payload, album or neighbours"
+// @Failure      401 {object} response.StandardResponse "Unauthorized"
+// @Failure      403 {object} response.StandardResponse "Not the organizer of the hangout"
+// @Failure      404 {object} response.StandardResponse "Memory not found"
+// @Failure      500 {object} response.StandardResponse "Internal server error"
+// @Security     BearerAuth
+// @Router       /memories/{memory_id}/move [post]
+func (h *albumHandler) MoveMemory(c echo.Context) error {
+	memoryID, err := uuid.Parse(c.Param("memory_id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(apperrors.ErrInvalidMemoryID))
+	}
+
+	req, err := request.BindAndValidate[dto.MoveMemoryRequest](c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(apperrors.ErrInvalidPayload))
+	}
+
+	userID := c.Get("user_id").(uuid.UUID)
+	ctx := c.Request().Context()
+
+	placement, err := h.albumService.MoveMemory(ctx, userID, memoryID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, apperrors.ErrMemoryNotFound):
+			return c.JSON(http.StatusNotFound, h.responseBuilder.Error(err))
+		case errors.Is(err, apperrors.ErrForbidden):
+			return c.JSON(http.StatusForbidden, h.responseBuilder.Error(err))
+		case errors.Is(err, apperrors.ErrInvalidAlbumID), errors.Is(err, apperrors.ErrInvalidMemoryPlacement):
+			return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(err))
+		}
+		return c.JSON(http.StatusInternalServerError, h.responseBuilder.Error(err))
+	}
+
+	return c.JSON(http.StatusOK, h.responseBuilder.Success(constants.MemoryMovedSuccessfully, placement))
+}
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
@@ -10,22 +11,37 @@ import (
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/http/response"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/mapper"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/services"
+	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 )
 
 type AuthHandler interface {
 	SignUp(c echo.Context) error
 	SignIn(c echo.Context) error
+	MFAChallenge(c echo.Context) error
+	VerifyEmail(c echo.Context) error
+	ResendVerification(c echo.Context) error
+	ForgotPassword(c echo.Context) error
+	ResetPassword(c echo.Context) error
+	ListOIDCProviders(c echo.Context) error
+	OIDCAuthorize(c echo.Context) error
+	OIDCCallback(c echo.Context) error
+	ListIdentities(c echo.Context) error
+	LinkIdentityAuthorize(c echo.Context) error
+	LinkIdentity(c echo.Context) error
+	UnlinkIdentity(c echo.Context) error
 }
 
 type authHandler struct {
 	authService     services.AuthService
+	identityService services.IdentityService
 	responseBuilder *response.Builder
 }
 
-func NewAuthHandler(authService services.AuthService, responseBuilder *response.Builder) AuthHandler {
+func NewAuthHandler(authService services.AuthService, identityService services.IdentityService, responseBuilder *response.Builder) AuthHandler {
 	return &authHandler{
 		authService:     authService,
+		identityService: identityService,
 		responseBuilder: responseBuilder,
 	}
 }
@@ -39,6 +55,7 @@ func NewAuthHandler(authService services.AuthService, responseBuilder *response.
 // @Success      201   {object}  response.StandardResponse{data=dto.UserResponse}
 // @Failure      400   {object}  response.StandardResponse
 // @Failure      409   {object}  response.StandardResponse
+// @Failure      429   {object}  response.StandardResponse
 // @Failure      500   {object}  response.StandardResponse
 // @Router       /auth/signup [post]
 func (ac *authHandler) SignUp(c echo.Context) error {
@@ -49,8 +66,10 @@ func (ac *authHandler) SignUp(c echo.Context) error {
 	ctx := c.Request().Context()
 	user, err := ac.authService.SignUser(ctx, req)
 	if err != nil {
-		switch err {
-		case apperrors.ErrUserAlreadyExists:
+		switch {
+		case errors.Is(err, apperrors.ErrWeakPassword):
+			return c.JSON(http.StatusBadRequest, ac.responseBuilder.Error(err))
+		case errors.Is(err, apperrors.ErrUserAlreadyExists):
 			return c.JSON(http.StatusConflict, ac.responseBuilder.Error(err))
 		default:
 			return c.JSON(http.StatusInternalServerError, ac.responseBuilder.Error(err))
@@ -61,7 +80,7 @@ func (ac *authHandler) SignUp(c echo.Context) error {
 }
 
 // @Summary      Sign in
-// @Description  Authenticate a user and return a JWT token
+// @Description  Authenticate a user and return a JWT token. With two-factor authentication enabled the response has mfa_required and an mfa_token for /auth/mfa/challenge instead.
 // @Tags         auth
 // @Accept       json
 // @Produce      json
@@ -69,6 +88,8 @@ func (ac *authHandler) SignUp(c echo.Context) error {
 // @Success      200          {object}  response.StandardResponse{data=dto.SignInResponse}
 // @Failure      400          {object}  response.StandardResponse
 // @Failure      401          {object}  response.StandardResponse
+// @Failure      403          {object}  response.StandardResponse
+// @Failure      429          {object}  response.StandardResponse
 // @Failure      500          {object}  response.StandardResponse
 // @Router       /auth/signin [post]
 func (ac *authHandler) SignIn(c echo.Context) error {
@@ -76,15 +97,370 @@ func (ac *authHandler) SignIn(c echo.Context) error {
 	if err != nil {
 		return c.JSON(http.StatusBadRequest, ac.responseBuilder.Error(apperrors.ErrInvalidPayload))
 	}
+	req.Client = sessionClientFromRequest(c)
 	ctx := c.Request().Context()
 	token, err := ac.authService.SignInUser(ctx, req)
 	if err != nil {
 		switch err {
 		case apperrors.ErrInvalidCredentials:
 			return c.JSON(http.StatusUnauthorized, ac.responseBuilder.Error(err))
+		case apperrors.ErrAccountDisabled:
+			return c.JSON(http.StatusForbidden, ac.responseBuilder.Error(err))
+		default:
+			return c.JSON(http.StatusInternalServerError, ac.responseBuilder.Error(err))
+		}
+	}
+	if token.MFARequired {
+		return c.JSON(http.StatusOK, ac.responseBuilder.Success(constants.MFAChallengeRequired, token))
+	}
+	return c.JSON(http.StatusOK, ac.responseBuilder.Success(constants.UserSignedInSuccessfully, token))
+}
+
+// @Summary      Complete two-factor sign in
+// @Description  Exchange the MFA challenge token from sign in and an authenticator app or recovery code for a JWT token
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        challenge  body      dto.MFAChallengeRequest  true  "Challenge token and code"
+// @Success      200        {object}  response.StandardResponse{data=dto.SignInResponse}
+// @Failure      400        {object}  response.StandardResponse
+// @Failure      401        {object}  response.StandardResponse
+// @Failure      403        {object}  response.StandardResponse
+// @Failure      429        {object}  response.StandardResponse
+// @Failure      500        {object}  response.StandardResponse
+// @Router       /auth/mfa/challenge [post]
+func (ac *authHandler) MFAChallenge(c echo.Context) error {
+	req, err := request.BindAndValidate[dto.MFAChallengeRequest](c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ac.responseBuilder.Error(apperrors.ErrInvalidPayload))
+	}
+	req.Client = sessionClientFromRequest(c)
+	ctx := c.Request().Context()
+	token, err := ac.authService.VerifyMFAChallenge(ctx, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, apperrors.ErrInvalidActionToken), errors.Is(err, apperrors.ErrInvalidMFACode), errors.Is(err, apperrors.ErrMFANotEnabled):
+			return c.JSON(http.StatusUnauthorized, ac.responseBuilder.Error(err))
+		case errors.Is(err, apperrors.ErrAccountDisabled):
+			return c.JSON(http.StatusForbidden, ac.responseBuilder.Error(err))
+		case errors.Is(err, apperrors.ErrAccountLocked):
+			return c.JSON(http.StatusTooManyRequests, ac.responseBuilder.Error(err))
+		default:
+			return c.JSON(http.StatusInternalServerError, ac.responseBuilder.Error(err))
+		}
+	}
+	return c.JSON(http.StatusOK, ac.responseBuilder.Success(constants.UserSignedInSuccessfully, token))
+}
+
+// @Summary      Verify email
+// @Description  Confirm the user's email address with the token from the verification email
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        token  body      dto.VerifyEmailRequest  true  "Verification token"
+// @Success      200    {object}  response.StandardResponse
+// @Failure      400    {object}  response.StandardResponse
+// @Failure      429    {object}  response.StandardResponse
+// @Failure      500    {object}  response.StandardResponse
+// @Router       /auth/verify-email [post]
+func (ac *authHandler) VerifyEmail(c echo.Context) error {
+	req, err := request.BindAndValidate[dto.VerifyEmailRequest](c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ac.responseBuilder.Error(apperrors.ErrInvalidPayload))
+	}
+	ctx := c.Request().Context()
+	if err := ac.authService.VerifyEmail(ctx, req.Token); err != nil {
+		switch {
+		case errors.Is(err, apperrors.ErrInvalidActionToken):
+			return c.JSON(http.StatusBadRequest, ac.responseBuilder.Error(err))
+		default:
+			return c.JSON(http.StatusInternalServerError, ac.responseBuilder.Error(err))
+		}
+	}
+	return c.JSON(http.StatusOK, ac.responseBuilder.Success(constants.EmailVerifiedSuccessfully, nil))
+}
+
+// @Summary      Resend verification email
+// @Description  Send a new verification email to the authenticated user
+// @Tags         auth
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.StandardResponse
+// @Failure      401  {object}  response.StandardResponse
+// @Failure      409  {object}  response.StandardResponse
+// @Failure      429  {object}  response.StandardResponse
+// @Failure      500  {object}  response.StandardResponse
+// @Router       /auth/resend-verification [post]
+func (ac *authHandler) ResendVerification(c echo.Context) error {
+	userID := c.Get("user_id").(uuid.UUID)
+	ctx := c.Request().Context()
+	if err := ac.authService.ResendVerification(ctx, userID); err != nil {
+		switch {
+		case errors.Is(err, apperrors.ErrEmailAlreadyVerified):
+			return c.JSON(http.StatusConflict, ac.responseBuilder.Error(err))
+		default:
+			return c.JSON(http.StatusInternalServerError, ac.responseBuilder.Error(err))
+		}
+	}
+	return c.JSON(http.StatusOK, ac.responseBuilder.Success(constants.VerificationEmailSent, nil))
+}
+
+// @Summary      Forgot password
+// @Description  Email a password reset link. The response is the same whether or not an account uses the email.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        email  body      dto.ForgotPasswordRequest  true  "Account email"
+// @Success      200    {object}  response.StandardResponse
+// @Failure      400    {object}  response.StandardResponse
+// @Failure      429    {object}  response.StandardResponse
+// @Failure      500    {object}  response.StandardResponse
+// @Router       /auth/forgot-password [post]
+func (ac *authHandler) ForgotPassword(c echo.Context) error {
+	req, err := request.BindAndValidate[dto.ForgotPasswordRequest](c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ac.responseBuilder.Error(apperrors.ErrInvalidPayload))
+	}
+	ctx := c.Request().Context()
+	if err := ac.authService.ForgotPassword(ctx, req.Email); err != nil {
+		return c.JSON(http.StatusInternalServerError, ac.responseBuilder.Error(err))
+	}
+	return c.JSON(http.StatusOK, ac.responseBuilder.Success(constants.PasswordResetEmailSent, nil))
+}
+
+// @Summary      Reset password
+// @Description  Set a new password with the token from the password reset email
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        reset  body      dto.ResetPasswordRequest  true  "Reset token and new password"
+// @Success      200    {object}  response.StandardResponse
+// @Failure      400    {object}  response.StandardResponse
+// @Failure      429    {object}  response.StandardResponse
+// @Failure      500    {object}  response.StandardResponse
+// @Router       /auth/reset-password [post]
+func (ac *authHandler) ResetPassword(c echo.Context) error {
+	req, err := request.BindAndValidate[dto.ResetPasswordRequest](c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ac.responseBuilder.Error(apperrors.ErrInvalidPayload))
+	}
+	ctx := c.Request().Context()
+	if err := ac.authService.ResetPassword(ctx, req); err != nil {
+		switch {
+		case errors.Is(err, apperrors.ErrInvalidActionToken), errors.Is(err, apperrors.ErrWeakPassword):
+			return c.JSON(http.StatusBadRequest, ac.responseBuilder.Error(err))
 		default:
 			return c.JSON(http.StatusInternalServerError, ac.responseBuilder.Error(err))
 		}
 	}
+	return c.JSON(http.StatusOK, ac.responseBuilder.Success(constants.PasswordResetSuccessfully, nil))
+}
+
+// @Summary      List sign in providers
+// @Description  List the configured external sign in providers
+// @Tags         auth
+// @Produce      json
+// @Success      200  {object}  response.StandardResponse{data=dto.OIDCProvidersResponse}
+// @Failure      429  {object}  response.StandardResponse
+// @Router       /auth/oidc/providers [get]
+func (ac *authHandler) ListOIDCProviders(c echo.Context) error {
+	providers := &dto.OIDCProvidersResponse{Providers: ac.identityService.Providers()}
+	return c.JSON(http.StatusOK, ac.responseBuilder.Success(constants.OIDCProvidersRetrievedSuccessfully, providers))
+}
+
+// @Summary      Start external sign in
+// @Description  Return the provider authorization URL and the state to send back with the callback. The callback must come from the same browser, which holds the oidc_binding cookie set here.
+// @Tags         auth
+// @Produce      json
+// @Param        provider  path      string  true  "Provider name"
+// @Success      200       {object}  response.StandardResponse{data=dto.OIDCAuthorizeResponse}
+// @Failure      404       {object}  response.StandardResponse
+// @Failure      429       {object}  response.StandardResponse
+// @Failure      502       {object}  response.StandardResponse
+// @Failure      500       {object}  response.StandardResponse
+// @Router       /auth/oidc/{provider}/authorize [get]
+func (ac *authHandler) OIDCAuthorize(c echo.Context) error {
+	ctx := c.Request().Context()
+	resp, err := ac.identityService.Authorize(ctx, c.Param("provider"), nil)
+	if err != nil {
+		return ac.identityError(c, err)
+	}
+	setOIDCBindingCookie(c, resp.Binding)
+	return c.JSON(http.StatusOK, ac.responseBuilder.Success(constants.OIDCAuthorizationStarted, resp))
+}
+
+// @Summary      Complete external sign in
+// @Description  Exchange the provider code for a JWT token, creating or linking the account on first sign in. With two-factor authentication enabled the response has mfa_required and an mfa_token for /auth/mfa/challenge instead.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        callback  body      dto.OIDCCallbackRequest  true  "Provider code and state"
+// @Success      200       {object}  response.StandardResponse{data=dto.SignInResponse}
+// @Failure      400       {object}  response.StandardResponse
+// @Failure      401       {object}  response.StandardResponse
+// @Failure      403       {object}  response.StandardResponse
+// @Failure      404       {object}  response.StandardResponse
+// @Failure      409       {object}  response.StandardResponse
+// @Failure      429       {object}  response.StandardResponse
+// @Failure      502       {object}  response.StandardResponse
+// @Failure      500       {object}  response.StandardResponse
+// @Router       /auth/oidc/callback [post]
+func (ac *authHandler) OIDCCallback(c echo.Context) error {
+	req, err := request.BindAndValidate[dto.OIDCCallbackRequest](c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ac.responseBuilder.Error(apperrors.ErrInvalidPayload))
+	}
+	req.Binding = oidcBindingFromRequest(c)
+	req.Client = sessionClientFromRequest(c)
+	ctx := c.Request().Context()
+	token, err := ac.identityService.SignIn(ctx, req)
+	if err != nil {
+		return ac.identityError(c, err)
+	}
 	return c.JSON(http.StatusOK, ac.responseBuilder.Success(constants.UserSignedInSuccessfully, token))
 }
+
+// @Summary      List linked identities
+// @Description  List the external sign in identities linked to the authenticated user
+// @Tags         auth
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.StandardResponse{data=[]dto.IdentityResponse}
+// @Failure      401  {object}  response.StandardResponse
+// @Failure      429  {object}  response.StandardResponse
+// @Failure      500  {object}  response.StandardResponse
+// @Router       /auth/identities [get]
+func (ac *authHandler) ListIdentities(c echo.Context) error {
+	userID := c.Get("user_id").(uuid.UUID)
+	ctx := c.Request().Context()
+	identities, err := ac.identityService.ListIdentities(ctx, userID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ac.responseBuilder.Error(err))
+	}
+	return c.JSON(http.StatusOK, ac.responseBuilder.Success(constants.IdentitiesRetrievedSuccessfully, identities))
+}
+
+// @Summary      Start identity linking
+// @Description  Return the provider authorization URL for linking a provider account to the authenticated user. The callback must come from the same browser, which holds the oidc_binding cookie set here.
+// @Tags         auth
+// @Produce      json
+// @Security     BearerAuth
+// @Param        provider  path      string  true  "Provider name"
+// @Success      200       {object}  response.StandardResponse{data=dto.OIDCAuthorizeResponse}
+// @Failure      401       {object}  response.StandardResponse
+// @Failure      404       {object}  response.StandardResponse
+// @Failure      429       {object}  response.StandardResponse
+// @Failure      502       {object}  response.StandardResponse
+// @Failure      500       {object}  response.StandardResponse
+// @Router       /auth/identities/{provider}/authorize [get]
+func (ac *authHandler) LinkIdentityAuthorize(c echo.Context) error {
+	userID := c.Get("user_id").(uuid.UUID)
+	ctx := c.Request().Context()
+	resp, err := ac.identityService.Authorize(ctx, c.Param("provider"), &userID)
+	if err != nil {
+		return ac.identityError(c, err)
+	}
+	setOIDCBindingCookie(c, resp.Binding)
+	return c.JSON(http.StatusOK, ac.responseBuilder.Success(constants.OIDCAuthorizationStarted, resp))
+}
+
+// @Summary      Link identity
+// @Description  Complete linking a provider account to the authenticated user
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        callback  body      dto.OIDCCallbackRequest  true  "Provider code and state"
+// @Success      200       {object}  response.StandardResponse{data=dto.IdentityResponse}
+// @Failure      400       {object}  response.StandardResponse
+// @Failure      401       {object}  response.StandardResponse
+// @Failure      404       {object}  response.StandardResponse
+// @Failure      409       {object}  response.StandardResponse
+// @Failure      429       {object}  response.StandardResponse
+// @Failure      502       {object}  response.StandardResponse
+// @Failure      500       {object}  response.StandardResponse
+// @Router       /auth/identities/callback [post]
+func (ac *authHandler) LinkIdentity(c echo.Context) error {
+	req, err := request.BindAndValidate[dto.OIDCCallbackRequest](c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ac.responseBuilder.Error(apperrors.ErrInvalidPayload))
+	}
+	req.Binding = oidcBindingFromRequest(c)
+	userID := c.Get("user_id").(uuid.UUID)
+	ctx := c.Request().Context()
+	identity, err := ac.identityService.LinkIdentity(ctx, userID, req)
+	if err != nil {
+		return ac.identityError(c, err)
+	}
+	return c.JSON(http.StatusOK, ac.responseBuilder.Success(constants.IdentityLinkedSuccessfully, identity))
+}
+
+// @Summary      Unlink identity
+// @Description  Remove a linked provider account. The last sign in method of an account cannot be removed.
+// @Tags         auth
+// @Produce      json
+// @Security     BearerAuth
+// @Param        provider  path      string  true  "Provider name"
+// @Success      200       {object}  response.StandardResponse
+// @Failure      401       {object}  response.StandardResponse
+// @Failure      404       {object}  response.StandardResponse
+// @Failure      409       {object}  response.StandardResponse
+// @Failure      429       {object}  response.StandardResponse
+// @Failure      500       {object}  response.StandardResponse
+// @Router       /auth/identities/{provider} [delete]
+func (ac *authHandler) UnlinkIdentity(c echo.Context) error {
+	userID := c.Get("user_id").(uuid.UUID)
+	ctx := c.Request().Context()
+	if err := ac.identityService.UnlinkIdentity(ctx, userID, c.Param("provider")); err != nil {
+		return ac.identityError(c, err)
+	}
+	return c.JSON(http.StatusOK, ac.responseBuilder.Success(constants.IdentityUnlinkedSuccessfully, nil))
+}
+
+func (ac *authHandler) identityError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, apperrors.ErrUnknownOIDCProvider), errors.Is(err, apperrors.ErrIdentityNotFound):
+		return c.JSON(http.StatusNotFound, ac.responseBuilder.Error(err))
+	case errors.Is(err, apperrors.ErrInvalidOIDCState), errors.Is(err, apperrors.ErrOIDCEmailRequired):
+		return c.JSON(http.StatusBadRequest, ac.responseBuilder.Error(err))
+	case errors.Is(err, apperrors.ErrOIDCExchangeFailed):
+		return c.JSON(http.StatusUnauthorized, ac.responseBuilder.Error(err))
+	case errors.Is(err, apperrors.ErrAccountDisabled):
+		return c.JSON(http.StatusForbidden, ac.responseBuilder.Error(err))
+	case errors.Is(err, apperrors.ErrOIDCProviderUnavailable):
+		return c.JSON(http.StatusBadGateway, ac.responseBuilder.Error(err))
+	case errors.Is(err, apperrors.ErrIdentityEmailInUse), errors.Is(err, apperrors.ErrIdentityAlreadyLinked), errors.Is(err, apperrors.ErrLastSignInMethod):
+		return c.JSON(http.StatusConflict, ac.responseBuilder.Error(err))
+	default:
+		return c.JSON(http.StatusInternalServerError, ac.responseBuilder.Error(err))
+	}
+}
+
+// setOIDCBindingCookie hands the flow's binding to the browser that started it.
+func setOIDCBindingCookie(c echo.Context, binding string) {
+	c.SetCookie(&http.Cookie{
+		Name:     constants.OIDCBindingCookie,
+		Value:    binding,
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   c.Scheme() == "https",
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// oidcBindingFromRequest returns the binding cookie of the flow being
+// completed, or an empty string, which no state matches.
+func oidcBindingFromRequest(c echo.Context) string {
+	cookie, err := c.Cookie(constants.OIDCBindingCookie)
+	if err != nil {
+		return ""
+	}
+	return cookie.Value
+}
+
+func sessionClientFromRequest(c echo.Context) dto.SessionClient {
+	return dto.SessionClient{
+		IPAddress: c.RealIP(),
+		UserAgent: c.Request().UserAgent(),
+	}
+}
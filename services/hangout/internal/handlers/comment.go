@@ -0,0 +1,220 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/http/request"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/http/response"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/http/sanitizer"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/services"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+type CommentHandler interface {
+	ListComments(c echo.Context) error
+	CreateComment(c echo.Context) error
+	UpdateComment(c echo.Context) error
+	DeleteComment(c echo.Context) error
+}
+
+type commentHandler struct {
+	commentService  services.CommentService
+	responseBuilder *response.Builder
+}
+
+func NewCommentHandler(commentService services.CommentService, responseBuilder *response.Builder) CommentHandler {
+	return &commentHandler{
+		commentService:  commentService,
+		responseBuilder: responseBuilder,
+	}
+}
+
+// @Summary      List Comments
+// @Description  Lists the top-level comments of a hangout, oldest first, each with all of its replies. Only participants of the hangout can read comments.
+// @Tags         Comments
+// @Produce      json
+// @Param        hangout_id path string true "Hangout ID"
+// @Param        after_id query string false "Cursor for pagination (top-level comment ID)"
+// @Param        limit query int false "Limit for pagination"
+// @Success      200 {object} response.StandardResponse{data=dto.PaginatedComments} "Comments retrieved successfully"
+// @Failure      400 {object} response.StandardResponse "Invalid hangout ID or cursor"
+// @Failure      401 {object} response.StandardResponse "Unauthorized"
+// @Failure      404 {object} response.StandardResponse "Hangout not found"
+// @Failure      500 {object} response.StandardResponse "Internal server error"
+// @Security     BearerAuth
+// @Router       /hangouts/{hangout_id}/comments [get]
+func (h *commentHandler) ListComments(c echo.Context) error {
+	hangoutID, err := uuid.Parse(c.Param("hangout_id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(apperrors.ErrInvalidHangoutID))
+	}
+
+	pagination, err := cursorPaginationFromQuery(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(err))
+	}
+
+	userID := c.Get("user_id").(uuid.UUID)
+	ctx := c.Request().Context()
+
+	comments, err := h.commentService.ListComments(ctx, userID, hangoutID, pagination)
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return c.JSON(http.StatusNotFound, h.responseBuilder.Error(apperrors.ErrNotFound))
+		case errors.Is(err, apperrors.ErrInvalidCursorPagination):
+			return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(err))
+		}
+		return c.JSON(http.StatusInternalServerError, h.responseBuilder.Error(err))
+	}
+
+	return c.JSON(http.StatusOK, h.responseBuilder.Success(constants.CommentsRetrievedSuccessfully, comments))
+}
+
+// @Summary      Create Comment
+// @Description  Posts a comment on a hangout. The body is Markdown and is stored as sanitized HTML. Set parent_id to reply to a top-level comment; replies cannot be replied to. Mentioned users must be participants of the hangout.
+// @Tags         Comments
+// @Accept       json
+// @Produce      json
+// @Param        hangout_id path string true "Hangout ID"
+// @Param        comment body dto.CreateCommentRequest true "Comment"
+// @Success      201 {object} response.StandardResponse{data=dto.CommentResponse} "Comment created successfully"
+// @Failure      400 {object} response.StandardResponse "Invalid request payload, parent comment or mention"
+// @Failure      401 {object} response.StandardResponse "Unauthorized"
+// @Failure      404 {object} response.StandardResponse "Hangout not found"
+// @Failure      500 {object} response.StandardResponse "Internal server error"
+// @Security     BearerAuth
+// @Router       /hangouts/{hangout_id}/comments [post]
+func (h *commentHandler) CreateComment(c echo.Context) error {
+	hangoutID, err := uuid.Parse(c.Param("hangout_id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(apperrors.ErrInvalidHangoutID))
+	}
+
+	req, err := request.BindAndValidate[dto.CreateCommentRequest](c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(apperrors.ErrInvalidPayload))
+	}
+
+	sanitizedBodyHTML, err := sanitizer.SanitizeMarkdown(req.Body)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, h.responseBuilder.Error(apperrors.ErrSanitizeDescription))
+	}
+	if strings.TrimSpace(sanitizedBodyHTML) == "" {
+		return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(apperrors.ErrInvalidPayload))
+	}
+	req.Body = sanitizedBodyHTML
+
+	userID := c.Get("user_id").(uuid.UUID)
+	ctx := c.Request().Context()
+
+	comment, err := h.commentService.CreateComment(ctx, userID, hangoutID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return c.JSON(http.StatusNotFound, h.responseBuilder.Error(apperrors.ErrNotFound))
+		case errors.Is(err, apperrors.ErrInvalidCommentParent),
+			errors.Is(err, apperrors.ErrInvalidMention):
+			return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(err))
+		}
+		return c.JSON(http.StatusInternalServerError, h.responseBuilder.Error(err))
+	}
+
+	return c.JSON(http.StatusCreated, h.responseBuilder.Success(constants.CommentCreatedSuccessfully, comment))
+}
+
+// @Summary      Update Comment
+// @Description  Replaces the body and mentions of a comment. Only the author can edit a comment.
+// @Tags         Comments
+// @Accept       json
+// @Produce      json
+// @Param        comment_id path string true "Comment ID"
+// @Param        comment body dto.UpdateCommentRequest true "Comment"
+// @Success      200 {object} response.StandardResponse{data=dto.CommentResponse} "Comment updated successfully"
+// @Failure      400 {object} response.StandardResponse "Invalid request payload or mention"
+// @Failure      401 {object} response.StandardResponse "Unauthorized"
+// @Failure      403 {object} response.StandardResponse "Not the author of the comment"
+// @Failure      404 {object} response.StandardResponse "Comment not found"
+// @Failure      500 {object} response.StandardResponse "Internal server error"
+// @Security     BearerAuth
+// @Router       /comments/{comment_id} [put]
+func (h *commentHandler) UpdateComment(c echo.Context) error {
+	commentID, err := uuid.Parse(c.Param("comment_id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(apperrors.ErrInvalidCommentID))
+	}
+
+	req, err := request.BindAndValidate[dto.UpdateCommentRequest](c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(apperrors.ErrInvalidPayload))
+	}
+
+	sanitizedBodyHTML, err := sanitizer.SanitizeMarkdown(req.Body)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, h.responseBuilder.Error(apperrors.ErrSanitizeDescription))
+	}
+	if strings.TrimSpace(sanitizedBodyHTML) == "" {
+		return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(apperrors.ErrInvalidPayload))
+	}
+	req.Body = sanitizedBodyHTML
+
+	userID := c.Get("user_id").(uuid.UUID)
+	ctx := c.Request().Context()
+
+	comment, err := h.commentService.UpdateComment(ctx, userID, commentID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return c.JSON(http.StatusNotFound, h.responseBuilder.Error(apperrors.ErrNotFound))
+		case errors.Is(err, apperrors.ErrForbidden):
+			return c.JSON(http.StatusForbidden, h.responseBuilder.Error(err))
+		case errors.Is(err, apperrors.ErrInvalidMention):
+			return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(err))
+		}
+		return c.JSON(http.StatusInternalServerError, h.responseBuilder.Error(err))
+	}
+
+	return c.JSON(http.StatusOK, h.responseBuilder.Success(constants.CommentUpdatedSuccessfully, comment))
+}
+
+// @Summary      Delete Comment
+// @Description  Permanently deletes a comment and its replies. The author and the owner of the hangout can delete a comment.
+// @Tags         Comments
+// @Produce      json
+// @Param        comment_id path string true "Comment ID"
+// @Success      200 {object} response.StandardResponse "Comment deleted successfully"
+// @Failure      400 {object} response.StandardResponse "Invalid comment ID"
+// @Failure      401 {object} response.StandardResponse "Unauthorized"
+// @Failure      403 {object} response.StandardResponse "Not allowed to delete the comment"
+// @Failure      404 {object} response.StandardResponse "Comment not found"
+// @Failure      500 {object} response.StandardResponse "Internal server error"
+// @Security     BearerAuth
+// @Router       /comments/{comment_id} [delete]
+func (h *commentHandler) DeleteComment(c echo.Context) error {
+	commentID, err := uuid.Parse(c.Param("comment_id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(apperrors.ErrInvalidCommentID))
+	}
+
+	userID := c.Get("user_id").(uuid.UUID)
+	ctx := c.Request().Context()
+
+	if err := h.commentService.DeleteComment(ctx, userID, commentID); err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return c.JSON(http.StatusNotFound, h.responseBuilder.Error(apperrors.ErrNotFound))
+		case errors.Is(err, apperrors.ErrForbidden):
+			return c.JSON(http.StatusForbidden, h.responseBuilder.Error(err))
+		}
+		return c.JSON(http.StatusInternalServerError, h.responseBuilder.Error(err))
+	}
+
+	return c.JSON(http.StatusOK, h.responseBuilder.Success(constants.CommentDeletedSuccessfully, nil))
+}
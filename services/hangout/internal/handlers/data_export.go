@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/http/response"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/services"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type DataExportHandler interface {
+	RequestExport(c echo.Context) error
+	GetExport(c echo.Context) error
+}
+
+type dataExportHandler struct {
+	exportService   services.DataExportService
+	responseBuilder *response.Builder
+}
+
+func NewDataExportHandler(exportService services.DataExportService, responseBuilder *response.Builder) DataExportHandler {
+	return &dataExportHandler{
+		exportService:   exportService,
+		responseBuilder: responseBuilder,
+	}
+}
+
+// @Summary      Request a data export
+// @Description  Queue a copy of the user's profile, hangouts, activities and memories. The export is built in the background; poll it for its status. Only one export is built at a time.
+// @Tags         Me
+// @Produce      json
+// @Security     BearerAuth
+// @Success      202  {object}  response.StandardResponse{data=dto.DataExportResponse}
+// @Failure      401  {object}  response.StandardResponse
+// @Failure      409  {object}  response.StandardResponse
+// @Failure      500  {object}  response.StandardResponse
+// @Router       /me/exports [post]
+func (h *dataExportHandler) RequestExport(c echo.Context) error {
+	userID := c.Get("user_id").(uuid.UUID)
+	ctx := c.Request().Context()
+	export, err := h.exportService.RequestExport(ctx, userID)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrDataExportInProgress) {
+			return c.JSON(http.StatusConflict, h.responseBuilder.Error(err))
+		}
+		return c.JSON(http.StatusInternalServerError, h.responseBuilder.Error(err))
+	}
+	return c.JSON(http.StatusAccepted, h.responseBuilder.Success(constants.DataExportRequestedSuccessfully, export))
+}
+
+// @Summary      Get a data export
+// @Description  Get the status of a data export. Once it is completed, archive holds the presigned download URL of a ZIP with the JSON document and the memory files.
+// @Tags         Me
+// @Produce      json
+// @Security     BearerAuth
+// @Param        export_id  path      string  true  "Export ID"
+// @Success      200        {object}  response.StandardResponse{data=dto.DataExportResponse}
+// @Failure      400        {object}  response.StandardResponse
+// @Failure      401        {object}  response.StandardResponse
+// @Failure      404        {object}  response.StandardResponse
+// @Failure      500        {object}  response.StandardResponse
+// @Router       /me/exports/{export_id} [get]
+func (h *dataExportHandler) GetExport(c echo.Context) error {
+	exportID, err := uuid.Parse(c.Param("export_id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(apperrors.ErrInvalidDataExportID))
+	}
+
+	userID := c.Get("user_id").(uuid.UUID)
+	ctx := c.Request().Context()
+	export, err := h.exportService.GetExport(ctx, userID, exportID)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrDataExportNotFound) {
+			return c.JSON(http.StatusNotFound, h.responseBuilder.Error(err))
+		}
+		return c.JSON(http.StatusInternalServerError, h.responseBuilder.Error(err))
+	}
+	return c.JSON(http.StatusOK, h.responseBuilder.Success(constants.DataExportRetrievedSuccessfully, export))
+}
@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/config"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/http/response"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/pubsub"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/services"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+type EventsHandler interface {
+	StreamHangoutEvents(c echo.Context) error
+}
+
+type eventsHandler struct {
+	hangoutService  services.HangoutService
+	subscriber      pubsub.Subscriber
+	cfg             *config.EventsConfig
+	responseBuilder *response.Builder
+}
+
+func NewEventsHandler(hangoutService services.HangoutService, subscriber pubsub.Subscriber, cfg *config.EventsConfig, responseBuilder *response.Builder) EventsHandler {
+	return &eventsHandler{
+		hangoutService:  hangoutService,
+		subscriber:      subscriber,
+		cfg:             cfg,
+		responseBuilder: responseBuilder,
+	}
+}
+
+// @Summary      Stream Hangout Events
+// @Description  Opens a server-sent events stream of changes to a hangout (hangout.updated, hangout.status_changed, hangout.deleted, memory.created, memory.processed). The stream sends a keep-alive comment periodically, and ends with a stream.expired event when the access token expires or after hangout.deleted.
+// @Tags         Hangouts
+// @Produce      text/event-stream
+// @Param        hangout_id path string true "Hangout ID"
+// @Success      200 {object} pubsub.Event "Event stream"
+// @Failure      400 {object} response.StandardResponse "Invalid hangout ID"
+// @Failure      401 {object} response.StandardResponse "Unauthorized"
+// @Failure      404 {object} response.StandardResponse "Hangout not found"
+// @Failure      500 {object} response.StandardResponse "Internal server error"
+// @Security     BearerAuth
+// @Router       /hangouts/{hangout_id}/events [get]
+func (h *eventsHandler) StreamHangoutEvents(c echo.Context) error {
+	hangoutID, err := uuid.Parse(c.Param("hangout_id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(apperrors.ErrInvalidHangoutID))
+	}
+
+	userID := c.Get("user_id").(uuid.UUID)
+	ctx := c.Request().Context()
+
+	if _, err := h.hangoutService.GetHangoutByID(ctx, hangoutID, userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.JSON(http.StatusNotFound, h.responseBuilder.Error(apperrors.ErrNotFound))
+		}
+		return c.JSON(http.StatusInternalServerError, h.responseBuilder.Error(err))
+	}
+
+	events, cancel, err := h.subscriber.Subscribe(ctx, pubsub.HangoutTopic(hangoutID))
+	if err != nil {
+		return c.JSON(http.StatusServiceUnavailable, h.responseBuilder.Error(err))
+	}
+	defer cancel()
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, constants.MIMETextEventStream)
+	res.Header().Set(echo.HeaderCacheControl, "no-cache")
+	res.Header().Set(echo.HeaderConnection, "keep-alive")
+	res.Header().Set("X-Accel-Buffering", "no")
+	res.WriteHeader(http.StatusOK)
+	res.Flush()
+
+	heartbeat := time.NewTicker(h.cfg.GetHeartbeatInterval())
+	defer heartbeat.Stop()
+
+	// a nil channel never fires, so tokens without an expiry keep the stream open
+	var expired <-chan time.Time
+	if expiresAt, ok := c.Get("token_expires_at").(time.Time); ok {
+		timer := time.NewTimer(time.Until(expiresAt))
+		defer timer.Stop()
+		expired = timer.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(res, ": keep-alive\n\n"); err != nil {
+				return nil
+			}
+			res.Flush()
+
+		case <-expired:
+			_ = writeServerSentEvent(res, pubsub.NewHangoutEvent(hangoutID, constants.EventStreamExpired, nil))
+			return nil
+
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+			if err := writeServerSentEvent(res, event); err != nil {
+				return nil
+			}
+			if event.Type == constants.EventHangoutDeleted {
+				return nil
+			}
+		}
+	}
+}
+
+func writeServerSentEvent(res *echo.Response, event pubsub.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(res, "id: %s\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data); err != nil {
+		return err
+	}
+	res.Flush()
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/http/etag"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/http/request"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/http/response"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/http/sanitizer"
@@ -20,9 +21,11 @@ import (
 type HangoutHandler interface {
 	CreateHangout(c echo.Context) error
 	UpdateHangout(c echo.Context) error
+	PatchHangout(c echo.Context) error
 	GetHangoutByID(c echo.Context) error
 	DeleteHangout(c echo.Context) error
 	GetHangoutsByUserID(c echo.Context) error
+	BatchHangouts(c echo.Context) error
 }
 
 type hangoutHandler struct {
@@ -43,9 +46,12 @@ func NewHangoutHandler(hangoutService services.HangoutService, responseBuilder *
 // @Accept       json
 // @Produce      json
 // @Param        hangout body dto.CreateHangoutRequest true "Hangout creation data"
+// @Param        Idempotency-Key header string false "Unique key to safely retry the request"
 // @Success      201 {object} response.StandardResponse{data=dto.HangoutDetailResponse} "Hangout created successfully"
 // @Failure      400 {object} response.StandardResponse "Invalid request payload"
 // @Failure      401 {object} response.StandardResponse "Unauthorized"
+// @Failure      409 {object} response.StandardResponse "Request with the same Idempotency-Key is still in progress"
+// @Failure      422 {object} response.StandardResponse "Idempotency-Key reused with a different request"
 // @Failure      500 {object} response.StandardResponse "Internal server error"
 // @Security     BearerAuth
 // @Router       /hangouts/ [post]
@@ -70,6 +76,9 @@ func (h *hangoutHandler) CreateHangout(c echo.Context) error {
 	hangout, err := h.hangoutService.CreateHangout(ctx, userID, req)
 
 	if err != nil {
+		if err == apperrors.ErrInvalidRSVPDeadline {
+			return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(err))
+		}
 		return c.JSON(http.StatusInternalServerError, h.responseBuilder.Error(err))
 	}
 
@@ -83,10 +92,14 @@ func (h *hangoutHandler) CreateHangout(c echo.Context) error {
 // @Produce      json
 // @Param        hangout_id path string true "Hangout ID"
 // @Param        hangout body dto.UpdateHangoutRequest true "Hangout update data"
+// @Param        If-Match header string true "ETag of the hangout being updated"
 // @Success      200 {object} response.StandardResponse{data=dto.HangoutDetailResponse} "Hangout updated successfully"
+// @Header       200 {string} ETag "Version tag of the updated hangout"
 // @Failure      400 {object} response.StandardResponse "Invalid request payload"
 // @Failure      401 {object} response.StandardResponse "Unauthorized"
 // @Failure      404 {object} response.StandardResponse "resource not found"
+// @Failure      412 {object} response.StandardResponse "Hangout was modified by another request"
+// @Failure      428 {object} response.StandardResponse "If-Match header is required"
 // @Failure      500 {object} response.StandardResponse "Internal server error"
 // @Security     BearerAuth
 // @Router       /hangouts/{hangout_id} [put]
@@ -113,15 +126,99 @@ func (h *hangoutHandler) UpdateHangout(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(apperrors.ErrInvalidHangoutID))
 	}
 
-	hangout, err := h.hangoutService.UpdateHangout(ctx, hangoutId, userID, req)
+	version, err := etag.IfMatchVersion(c)
+	if err != nil {
+		return c.JSON(ifMatchErrorStatus(err), h.responseBuilder.Error(err))
+	}
+
+	hangout, err := h.hangoutService.UpdateHangout(ctx, hangoutId, userID, version, req)
 
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return c.JSON(http.StatusNotFound, h.responseBuilder.Error(apperrors.ErrNotFound))
 		}
+		if err == apperrors.ErrPreconditionFailed {
+			return c.JSON(http.StatusPreconditionFailed, h.responseBuilder.Error(err))
+		}
+		if err == apperrors.ErrInvalidRSVPDeadline {
+			return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(err))
+		}
 		return c.JSON(http.StatusInternalServerError, h.responseBuilder.Error(err))
 	}
 
+	etag.SetHeader(c, hangout.Version)
+	return c.JSON(http.StatusOK, h.responseBuilder.Success(constants.HangoutUpdatedSuccessfully, hangout))
+}
+
+// @Summary      Patch Hangout
+// @Description  Partially updates a hangout using JSON Merge Patch (RFC 7396). Omitted fields are left untouched, null clears the description, and activities replaces the whole activity set (null or [] removes all of them).
+// @Tags         Hangouts
+// @Accept       application/merge-patch+json
+// @Accept       json
+// @Produce      json
+// @Param        hangout_id path string true "Hangout ID"
+// @Param        hangout body dto.PatchHangoutRequest true "Merge patch document"
+// @Param        If-Match header string true "ETag of the hangout being updated"
+// @Success      200 {object} response.StandardResponse{data=dto.HangoutDetailResponse} "Hangout updated successfully"
+// @Header       200 {string} ETag "Version tag of the updated hangout"
+// @Failure      400 {object} response.StandardResponse "Invalid request payload"
+// @Failure      401 {object} response.StandardResponse "Unauthorized"
+// @Failure      404 {object} response.StandardResponse "resource not found"
+// @Failure      412 {object} response.StandardResponse "Hangout was modified by another request"
+// @Failure      415 {object} response.StandardResponse "Unsupported content type"
+// @Failure      428 {object} response.StandardResponse "If-Match header is required"
+// @Failure      500 {object} response.StandardResponse "Internal server error"
+// @Security     BearerAuth
+// @Router       /hangouts/{hangout_id} [patch]
+func (h *hangoutHandler) PatchHangout(c echo.Context) error {
+	req, err := request.BindMergePatch[dto.PatchHangoutRequest](c)
+	if err != nil {
+		if errors.Is(err, echo.ErrUnsupportedMediaType) {
+			return c.JSON(http.StatusUnsupportedMediaType, h.responseBuilder.Error(err))
+		}
+		return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(apperrors.ErrInvalidPayload))
+	}
+
+	if req.Title.Present() {
+		req.Title.Value = sanitizer.SanitizeString(strings.TrimSpace(req.Title.Value))
+	}
+	if req.Description.Present() {
+		sanitizedDescriptionHTML, err := sanitizer.SanitizeMarkdown(req.Description.Value)
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, h.responseBuilder.Error(apperrors.ErrSanitizeDescription))
+		}
+		req.Description.Value = sanitizedDescriptionHTML
+	}
+
+	userID := c.Get("user_id").(uuid.UUID)
+	ctx := c.Request().Context()
+
+	hangoutId, err := uuid.Parse(c.Param("hangout_id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(apperrors.ErrInvalidHangoutID))
+	}
+
+	version, err := etag.IfMatchVersion(c)
+	if err != nil {
+		return c.JSON(ifMatchErrorStatus(err), h.responseBuilder.Error(err))
+	}
+
+	hangout, err := h.hangoutService.PatchHangout(ctx, hangoutId, userID, version, req)
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.JSON(http.StatusNotFound, h.responseBuilder.Error(apperrors.ErrNotFound))
+		}
+		switch err {
+		case apperrors.ErrPreconditionFailed:
+			return c.JSON(http.StatusPreconditionFailed, h.responseBuilder.Error(err))
+		case apperrors.ErrHangoutFieldRequired, apperrors.ErrInvalidHangoutDate, apperrors.ErrInvalidRSVPDeadline, apperrors.ErrInvalidHangoutStatus, apperrors.ErrInvalidActivityIDs:
+			return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(err))
+		}
+		return c.JSON(http.StatusInternalServerError, h.responseBuilder.Error(err))
+	}
+
+	etag.SetHeader(c, hangout.Version)
 	return c.JSON(http.StatusOK, h.responseBuilder.Success(constants.HangoutUpdatedSuccessfully, hangout))
 }
 
@@ -131,7 +228,10 @@ func (h *hangoutHandler) UpdateHangout(c echo.Context) error {
 // @Accept       json
 // @Produce      json
 // @Param        hangout_id path string true "Hangout ID"
+// @Param        If-None-Match header string false "ETag from a previous response"
 // @Success      200 {object} response.StandardResponse{data=dto.HangoutDetailResponse} "Hangout retrieved successfully"
+// @Header       200 {string} ETag "Version tag of the hangout"
+// @Success      304 "Hangout not modified"
 // @Failure      400 {object} response.StandardResponse "Invalid Hangout ID"
 // @Failure      401 {object} response.StandardResponse "Unauthorized"
 // @Failure      404 {object} response.StandardResponse "resource not found"
@@ -154,19 +254,27 @@ func (h *hangoutHandler) GetHangoutByID(c echo.Context) error {
 		}
 		return c.JSON(http.StatusInternalServerError, h.responseBuilder.Error(err))
 	}
+
+	etag.SetHeader(c, hangout.Version)
+	if etag.NotModified(c, hangout.Version) {
+		return c.NoContent(http.StatusNotModified)
+	}
 	return c.JSON(http.StatusOK, h.responseBuilder.Success(constants.HangoutRetrievedSuccessfully, hangout))
 }
 
 // @Summary      Delete Hangout
-// @Description  Deletes a hangout by its ID for the authenticated user.
+// @Description  Moves a hangout and its memories to the trash for the authenticated user.
 // @Tags         Hangouts
 // @Accept       json
 // @Produce      json
 // @Param        hangout_id path string true "Hangout ID"
+// @Param        If-Match header string true "ETag of the hangout being deleted"
 // @Success      200 {object} response.StandardResponse "Hangout deleted successfully"
 // @Failure      400 {object} response.StandardResponse "Invalid Hangout ID"
 // @Failure      401 {object} response.StandardResponse "Unauthorized"
 // @Failure      404 {object} response.StandardResponse "resource not found"
+// @Failure      412 {object} response.StandardResponse "Hangout was modified by another request"
+// @Failure      428 {object} response.StandardResponse "If-Match header is required"
 // @Failure      500 {object} response.StandardResponse "Internal server error"
 // @Security     BearerAuth
 // @Router       /hangouts/{hangout_id} [delete]
@@ -179,11 +287,19 @@ func (h *hangoutHandler) DeleteHangout(c echo.Context) error {
 	userID := c.Get("user_id").(uuid.UUID)
 	ctx := c.Request().Context()
 
-	err = h.hangoutService.DeleteHangout(ctx, hangoutId, userID)
+	version, err := etag.IfMatchVersion(c)
+	if err != nil {
+		return c.JSON(ifMatchErrorStatus(err), h.responseBuilder.Error(err))
+	}
+
+	err = h.hangoutService.DeleteHangout(ctx, hangoutId, userID, version)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return c.JSON(http.StatusNotFound, h.responseBuilder.Error(apperrors.ErrNotFound))
 		}
+		if err == apperrors.ErrPreconditionFailed {
+			return c.JSON(http.StatusPreconditionFailed, h.responseBuilder.Error(err))
+		}
 		return c.JSON(http.StatusInternalServerError, h.responseBuilder.Error(err))
 	}
 	return c.JSON(http.StatusOK, h.responseBuilder.Success(constants.HangoutDeletedSuccessfully, nil))
@@ -219,3 +335,73 @@ func (h *hangoutHandler) GetHangoutsByUserID(c echo.Context) error {
 	return c.JSON(http.StatusOK, h.responseBuilder.Success(constants.HangoutsRetrievedSuccessfully, hangouts))
 
 }
+
+// @Summary      Batch Hangout Operations
+// @Description  Runs up to 50 create, update_status, add_activities, remove_activities or delete operations. With atomic=true the batch runs in one transaction and the first failure rolls everything back; otherwise every operation is committed on its own and reported in results.
+// @Tags         Hangouts
+// @Accept       json
+// @Produce      json
+// @Param        batch body dto.BatchHangoutRequest true "Batch operations"
+// @Param        Idempotency-Key header string false "Unique key to safely retry the request"
+// @Success      200 {object} response.StandardResponse{data=dto.BatchHangoutResponse} "Batch processed"
+// @Failure      400 {object} response.StandardResponse "Invalid request payload or batch too large"
+// @Failure      401 {object} response.StandardResponse "Unauthorized"
+// @Failure      404 {object} response.StandardResponse "A hangout in an atomic batch was not found"
+// @Failure      409 {object} response.StandardResponse "Request with the same Idempotency-Key is still in progress"
+// @Failure      412 {object} response.StandardResponse "A hangout in an atomic batch was modified by another request"
+// @Failure      422 {object} response.StandardResponse "Idempotency-Key reused with a different request"
+// @Failure      500 {object} response.StandardResponse "Internal server error"
+// @Security     BearerAuth
+// @Router       /hangouts/batch [post]
+func (h *hangoutHandler) BatchHangouts(c echo.Context) error {
+	req, err := request.BindAndValidate[dto.BatchHangoutRequest](c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(apperrors.ErrInvalidPayload))
+	}
+
+	for i := range req.Operations {
+		create := req.Operations[i].Hangout
+		if create == nil {
+			continue
+		}
+		create.Title = sanitizer.SanitizeString(strings.TrimSpace(create.Title))
+		if create.Description != nil {
+			sanitizedDescriptionHTML, err := sanitizer.SanitizeMarkdown(*create.Description)
+			if err != nil {
+				return c.JSON(http.StatusInternalServerError, h.responseBuilder.Error(apperrors.ErrSanitizeDescription))
+			}
+			create.Description = &sanitizedDescriptionHTML
+		}
+	}
+
+	userID := c.Get("user_id").(uuid.UUID)
+	ctx := c.Request().Context()
+
+	res, err := h.hangoutService.BatchHangouts(ctx, userID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return c.JSON(http.StatusNotFound, h.responseBuilder.Error(err))
+		case errors.Is(err, apperrors.ErrPreconditionFailed):
+			return c.JSON(http.StatusPreconditionFailed, h.responseBuilder.Error(err))
+		case errors.Is(err, apperrors.ErrBatchTooLarge),
+			errors.Is(err, apperrors.ErrInvalidBatchOperation),
+			errors.Is(err, apperrors.ErrInvalidActivityIDs),
+			errors.Is(err, apperrors.ErrInvalidHangoutStatus),
+			errors.Is(err, apperrors.ErrInvalidHangoutDate),
+			errors.Is(err, apperrors.ErrInvalidRSVPDeadline):
+			return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(err))
+		}
+		return c.JSON(http.StatusInternalServerError, h.responseBuilder.Error(err))
+	}
+
+	return c.JSON(http.StatusOK, h.responseBuilder.Success(constants.HangoutBatchProcessed, res))
+}
+
+// ifMatchErrorStatus maps an If-Match parsing error to its HTTP status.
+func ifMatchErrorStatus(err error) int {
+	if err == apperrors.ErrIfMatchRequired {
+		return http.StatusPreconditionRequired
+	}
+	return http.StatusBadRequest
+}
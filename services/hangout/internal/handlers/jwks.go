@@ -0,0 +1,32 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/signing"
+	"github.com/labstack/echo/v4"
+)
+
+type JWKSHandler interface {
+	GetJWKS(c echo.Context) error
+}
+
+type jwksHandler struct {
+	keys *signing.KeyRing
+}
+
+func NewJWKSHandler(keys *signing.KeyRing) JWKSHandler {
+	return &jwksHandler{keys: keys}
+}
+
+// @Summary      Get JSON Web Key Set
+// @Description  Returns the public keys that verify access tokens, including keys published ahead of activation and retired keys whose tokens have not expired yet. Tokens carry the key ID in the kid header. The response is a plain RFC 7517 key set, not wrapped in the standard response.
+// @Tags         Auth
+// @Produce      json
+// @Success      200 {object} signing.JWKSet "JSON Web Key Set"
+// @Router       /.well-known/jwks.json [get]
+func (h *jwksHandler) GetJWKS(c echo.Context) error {
+	c.Response().Header().Set(echo.HeaderCacheControl, constants.JWKSCacheControl)
+	return c.JSON(http.StatusOK, h.keys.JWKS())
+}
@@ -1,17 +1,21 @@
 package handlers
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/http/request"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/http/response"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/http/sanitizer"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/services"
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
 )
 
 type MemoryHandler interface {
@@ -19,7 +23,12 @@ type MemoryHandler interface {
 	ConfirmUpload(c echo.Context) error
 	GetMemory(c echo.Context) error
 	ListMemories(c echo.Context) error
+	PatchMemory(c echo.Context) error
+	AddReaction(c echo.Context) error
+	RemoveReaction(c echo.Context) error
 	DeleteMemory(c echo.Context) error
+	CreateArchive(c echo.Context) error
+	GetArchive(c echo.Context) error
 }
 
 type memoryHandler struct {
@@ -42,10 +51,13 @@ func NewMemoryHandler(memoryService services.MemoryService, responseBuilder *res
 // @Produce      json
 // @Param        hangout_id path string true "Hangout ID"
 // @Param        request body dto.GenerateUploadURLsRequest true "Files to upload (hangout_id not needed in body)"
+// @Param        Idempotency-Key header string false "Unique key to safely retry the request"
 // @Success      201 {object} response.StandardResponse{data=dto.MemoryUploadResponse} "Upload URLs generated successfully"
 // @Failure      400 {object} response.StandardResponse "Invalid request payload"
 // @Failure      401 {object} response.StandardResponse "Unauthorized"
 // @Failure      404 {object} response.StandardResponse "Hangout not found"
+// @Failure      409 {object} response.StandardResponse "Request with the same Idempotency-Key is still in progress"
+// @Failure      422 {object} response.StandardResponse "Idempotency-Key reused with a different request"
 // @Failure      500 {object} response.StandardResponse "Internal server error"
 // @Security     BearerAuth
 // @Router       /hangouts/{hangout_id}/memories/upload-urls [post]
@@ -109,7 +121,7 @@ func (h *memoryHandler) ConfirmUpload(c echo.Context) error {
 }
 
 // @Summary      Get Memory
-// @Description  Retrieves a single memory by ID
+// @Description  Retrieves a single memory by ID, with its caption, tags, tagged people and reaction counts
 // @Tags         Memories
 // @Produce      json
 // @Param        memory_id path string true "Memory ID"
@@ -141,15 +153,20 @@ func (h *memoryHandler) GetMemory(c echo.Context) error {
 }
 
 // @Summary      List Memories
-// @Description  Lists all memories for a hangout with cursor pagination
+// @Description  Lists all memories for a hangout with cursor pagination, optionally scoped to an album
 // @Tags         Memories
 // @Produce      json
 // @Param        hangout_id path string true "Hangout ID"
 // @Param        after_id query string false "Cursor for pagination (memory ID)"
 // @Param        limit query int false "Limit for pagination"
+// @Param        sort_by query string false "Sort field (created_at/position); position is the manual order set by the organizer"
 // @Param        sort_dir query string false "Sort direction (asc/desc)"
+// @Param        album_id query string false "Only memories of this album, or none for memories outside any album"
+// @Param        tag query string false "Only memories with this tag"
+// @Param        person_id query string false "Only memories in which this user is tagged"
+// @Param        uploader_id query string false "Only memories uploaded by this user"
 // @Success      200 {object} response.StandardResponse{data=dto.PaginatedMemories} "Memories retrieved successfully"
-// @Failure      400 {object} response.StandardResponse "Invalid hangout ID"
+// @Failure      400 {object} response.StandardResponse "Invalid hangout ID or filter"
 // @Failure      500 {object} response.StandardResponse "Internal server error"
 // @Security     BearerAuth
 // @Router       /hangouts/{hangout_id}/memories [get]
@@ -175,14 +192,48 @@ func (h *memoryHandler) ListMemories(c echo.Context) error {
 		}
 	}
 
+	if sortBy := c.QueryParam("sort_by"); sortBy != "" {
+		pagination.SortBy = sortBy
+	}
+
 	if sortDir := c.QueryParam("sort_dir"); sortDir != "" {
 		pagination.SortDir = sortDir
 	}
 
+	filter := &dto.MemoryFilter{Tag: c.QueryParam("tag")}
+
+	switch albumIDStr := c.QueryParam("album_id"); albumIDStr {
+	case "":
+	case constants.NoAlbumFilter:
+		filter.NoAlbum = true
+	default:
+		albumID, err := uuid.Parse(albumIDStr)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(apperrors.ErrInvalidAlbumID))
+		}
+		filter.AlbumID = &albumID
+	}
+
+	if personIDStr := c.QueryParam("person_id"); personIDStr != "" {
+		personID, err := uuid.Parse(personIDStr)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(apperrors.ErrInvalidPayload))
+		}
+		filter.PersonID = &personID
+	}
+
+	if uploaderIDStr := c.QueryParam("uploader_id"); uploaderIDStr != "" {
+		uploaderID, err := uuid.Parse(uploaderIDStr)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(apperrors.ErrInvalidPayload))
+		}
+		filter.UploaderID = &uploaderID
+	}
+
 	userID := c.Get("user_id").(uuid.UUID)
 	ctx := c.Request().Context()
 
-	memories, err := h.memoryService.ListMemories(ctx, userID, hangoutID, pagination)
+	memories, err := h.memoryService.ListMemories(ctx, userID, hangoutID, filter, pagination)
 	if err != nil {
 		if err == apperrors.ErrInvalidHangoutID {
 			return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(err))
@@ -193,8 +244,141 @@ func (h *memoryHandler) ListMemories(c echo.Context) error {
 	return c.JSON(http.StatusOK, h.responseBuilder.Success(constants.MemoriesRetrievedSuccessfully, memories))
 }
 
+// @Summary      Patch Memory
+// @Description  Applies a JSON Merge Patch (RFC 7396) to the caption, tags and tagged people of a memory. Tags are lowercased and a leading # is dropped; tags and people replace the whole set. Only participants of the hangout can be tagged. The uploader and the owner of the hangout can annotate a memory.
+// @Tags         Memories
+// @Accept       application/merge-patch+json
+// @Produce      json
+// @Param        memory_id path string true "Memory ID"
+// @Param        memory body dto.PatchMemoryRequest true "Merge patch document"
+// @Success      200 {object} response.StandardResponse{data=dto.MemoryResponse} "Memory updated successfully"
+// @Failure      400 {object} response.StandardResponse "Invalid request payload, caption, tags or people"
+// @Failure      401 {object} response.StandardResponse "Unauthorized"
+// @Failure      403 {object} response.StandardResponse "Not allowed to annotate the memory"
+// @Failure      404 {object} response.StandardResponse "Memory not found"
+// @Failure      415 {object} response.StandardResponse "Unsupported content type"
+// @Failure      500 {object} response.StandardResponse "Internal server error"
+// @Security     BearerAuth
+// @Router       /memories/{memory_id} [patch]
+func (h *memoryHandler) PatchMemory(c echo.Context) error {
+	memoryID, err := uuid.Parse(c.Param("memory_id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(apperrors.ErrInvalidMemoryID))
+	}
+
+	req, err := request.BindMergePatch[dto.PatchMemoryRequest](c)
+	if err != nil {
+		if errors.Is(err, echo.ErrUnsupportedMediaType) {
+			return c.JSON(http.StatusUnsupportedMediaType, h.responseBuilder.Error(err))
+		}
+		return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(apperrors.ErrInvalidPayload))
+	}
+
+	if req.Caption.Present() {
+		req.Caption.Value = sanitizer.SanitizeString(strings.TrimSpace(req.Caption.Value))
+	}
+
+	userID := c.Get("user_id").(uuid.UUID)
+	ctx := c.Request().Context()
+
+	memory, err := h.memoryService.PatchMemory(ctx, userID, memoryID, req)
+	if err != nil {
+		switch err {
+		case apperrors.ErrMemoryNotFound:
+			return c.JSON(http.StatusNotFound, h.responseBuilder.Error(err))
+		case apperrors.ErrForbidden:
+			return c.JSON(http.StatusForbidden, h.responseBuilder.Error(err))
+		case apperrors.ErrInvalidMemoryCaption, apperrors.ErrInvalidMemoryTags, apperrors.ErrInvalidPersonTag:
+			return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(err))
+		}
+		return c.JSON(http.StatusInternalServerError, h.responseBuilder.Error(err))
+	}
+
+	return c.JSON(http.StatusOK, h.responseBuilder.Success(constants.MemoryUpdatedSuccessfully, memory))
+}
+
+// @Summary      Add Reaction
+// @Description  Reacts to a memory with a single emoji. Reacting twice with the same emoji has no effect. Any participant of the hangout can react.
+// @Tags         Memories
+// @Accept       json
+// @Produce      json
+// @Param        memory_id path string true "Memory ID"
+// @Param        reaction body dto.MemoryReactionRequest true "Reaction"
+// @Success      200 {object} response.StandardResponse{data=[]dto.MemoryReactionResponse} "Reaction added successfully"
+// @Failure      400 {object} response.StandardResponse "Invalid memory ID or emoji"
+// @Failure      401 {object} response.StandardResponse "Unauthorized"
+// @Failure      404 {object} response.StandardResponse "Memory not found"
+// @Failure      500 {object} response.StandardResponse "Internal server error"
+// @Security     BearerAuth
+// @Router       /memories/{memory_id}/reactions [post]
+func (h *memoryHandler) AddReaction(c echo.Context) error {
+	memoryID, err := uuid.Parse(c.Param("memory_id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(apperrors.ErrInvalidMemoryID))
+	}
+
+	req, err := request.BindAndValidate[dto.MemoryReactionRequest](c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(apperrors.ErrInvalidPayload))
+	}
+
+	userID := c.Get("user_id").(uuid.UUID)
+	ctx := c.Request().Context()
+
+	reactions, err := h.memoryService.AddReaction(ctx, userID, memoryID, req.Emoji)
+	if err != nil {
+		switch err {
+		case apperrors.ErrMemoryNotFound:
+			return c.JSON(http.StatusNotFound, h.responseBuilder.Error(err))
+		case apperrors.ErrInvalidReaction:
+			return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(err))
+		}
+		return c.JSON(http.StatusInternalServerError, h.responseBuilder.Error(err))
+	}
+
+	return c.JSON(http.StatusOK, h.responseBuilder.Success(constants.ReactionAddedSuccessfully, reactions))
+}
+
+// @Summary      Remove Reaction
+// @Description  Takes back the authenticated user's reaction with the given emoji.
+// @Tags         Memories
+// @Produce      json
+// @Param        memory_id path string true "Memory ID"
+// @Param        emoji query string true "Emoji of the reaction"
+// @Success      200 {object} response.StandardResponse{data=[]dto.MemoryReactionResponse} "Reaction removed successfully"
+// @Failure      400 {object} response.StandardResponse "Invalid memory ID or missing emoji"
+// @Failure      401 {object} response.StandardResponse "Unauthorized"
+// @Failure      404 {object} response.StandardResponse "Memory not found"
+// @Failure      500 {object} response.StandardResponse "Internal server error"
+// @Security     BearerAuth
+// @Router       /memories/{memory_id}/reactions [delete]
+func (h *memoryHandler) RemoveReaction(c echo.Context) error {
+	memoryID, err := uuid.Parse(c.Param("memory_id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(apperrors.ErrInvalidMemoryID))
+	}
+
+	emoji := c.QueryParam("emoji")
+	if emoji == "" {
+		return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(apperrors.ErrInvalidReaction))
+	}
+
+	userID := c.Get("user_id").(uuid.UUID)
+	ctx := c.Request().Context()
+
+	reactions, err := h.memoryService.RemoveReaction(ctx, userID, memoryID, emoji)
+	if err != nil {
+		if err == apperrors.ErrMemoryNotFound {
+			return c.JSON(http.StatusNotFound, h.responseBuilder.Error(err))
+		}
+		return c.JSON(http.StatusInternalServerError, h.responseBuilder.Error(err))
+	}
+
+	return c.JSON(http.StatusOK, h.responseBuilder.Success(constants.ReactionRemovedSuccessfully, reactions))
+}
+
 // @Summary      Delete Memory
-// @Description  Deletes a memory and its associated file
+// @Description  Moves a memory to the trash; its file is removed once the retention window expires
 // @Tags         Memories
 // @Produce      json
 // @Param        memory_id path string true "Memory ID"
@@ -224,3 +408,78 @@ func (h *memoryHandler) DeleteMemory(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, h.responseBuilder.Success(constants.MemoryDeletedSuccessfully, nil))
 }
+
+// @Summary      Create Memory Archive
+// @Description  Starts building a ZIP archive of every memory of the hangout that is not in the trash. The archive is built in the background; poll it for progress and a download URL. Only participants of the hangout can export its memories.
+// @Tags         Memories
+// @Produce      json
+// @Param        hangout_id path string true "Hangout ID"
+// @Success      202 {object} response.StandardResponse{data=dto.ArchiveResponse} "Archive requested successfully"
+// @Failure      400 {object} response.StandardResponse "Invalid hangout ID or no uploaded memories"
+// @Failure      401 {object} response.StandardResponse "Unauthorized"
+// @Failure      404 {object} response.StandardResponse "Hangout not found"
+// @Failure      500 {object} response.StandardResponse "Internal server error"
+// @Security     BearerAuth
+// @Router       /hangouts/{hangout_id}/archives [post]
+func (h *memoryHandler) CreateArchive(c echo.Context) error {
+	hangoutID, err := uuid.Parse(c.Param("hangout_id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(apperrors.ErrInvalidHangoutID))
+	}
+
+	userID := c.Get("user_id").(uuid.UUID)
+	ctx := c.Request().Context()
+
+	archive, err := h.memoryService.CreateArchive(ctx, userID, hangoutID)
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return c.JSON(http.StatusNotFound, h.responseBuilder.Error(apperrors.ErrNotFound))
+		case errors.Is(err, apperrors.ErrNoMemoriesToArchive):
+			return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(err))
+		}
+		return c.JSON(http.StatusInternalServerError, h.responseBuilder.Error(err))
+	}
+
+	return c.JSON(http.StatusAccepted, h.responseBuilder.Success(constants.ArchiveRequestedSuccessfully, archive))
+}
+
+// @Summary      Get Memory Archive
+// @Description  Returns the progress of a memory archive. Once completed it includes a short-lived download URL; request the archive again for a fresh URL until it expires.
+// @Tags         Memories
+// @Produce      json
+// @Param        hangout_id path string true "Hangout ID"
+// @Param        archive_id path string true "Archive ID"
+// @Success      200 {object} response.StandardResponse{data=dto.ArchiveResponse} "Archive retrieved successfully"
+// @Failure      400 {object} response.StandardResponse "Invalid hangout or archive ID"
+// @Failure      401 {object} response.StandardResponse "Unauthorized"
+// @Failure      404 {object} response.StandardResponse "Hangout or archive not found, or archive expired"
+// @Failure      500 {object} response.StandardResponse "Internal server error"
+// @Security     BearerAuth
+// @Router       /hangouts/{hangout_id}/archives/{archive_id} [get]
+func (h *memoryHandler) GetArchive(c echo.Context) error {
+	hangoutID, err := uuid.Parse(c.Param("hangout_id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(apperrors.ErrInvalidHangoutID))
+	}
+	archiveID, err := uuid.Parse(c.Param("archive_id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(apperrors.ErrInvalidArchiveID))
+	}
+
+	userID := c.Get("user_id").(uuid.UUID)
+	ctx := c.Request().Context()
+
+	archive, err := h.memoryService.GetArchive(ctx, userID, hangoutID, archiveID)
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return c.JSON(http.StatusNotFound, h.responseBuilder.Error(apperrors.ErrNotFound))
+		case errors.Is(err, apperrors.ErrArchiveNotFound):
+			return c.JSON(http.StatusNotFound, h.responseBuilder.Error(err))
+		}
+		return c.JSON(http.StatusInternalServerError, h.responseBuilder.Error(err))
+	}
+
+	return c.JSON(http.StatusOK, h.responseBuilder.Success(constants.ArchiveRetrievedSuccessfully, archive))
+}
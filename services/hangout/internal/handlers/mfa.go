@@ -0,0 +1,175 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/http/request"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/http/response"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/services"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type MFAHandler interface {
+	GetStatus(c echo.Context) error
+	EnrollTOTP(c echo.Context) error
+	ConfirmTOTP(c echo.Context) error
+	DisableTOTP(c echo.Context) error
+	RegenerateRecoveryCodes(c echo.Context) error
+}
+
+type mfaHandler struct {
+	mfaService      services.MFAService
+	responseBuilder *response.Builder
+}
+
+func NewMFAHandler(mfaService services.MFAService, responseBuilder *response.Builder) MFAHandler {
+	return &mfaHandler{
+		mfaService:      mfaService,
+		responseBuilder: responseBuilder,
+	}
+}
+
+// @Summary      Get two-factor status
+// @Description  Report whether two-factor authentication is enabled and how many recovery codes are left
+// @Tags         auth
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.StandardResponse{data=dto.MFAStatusResponse}
+// @Failure      401  {object}  response.StandardResponse
+// @Failure      429  {object}  response.StandardResponse
+// @Failure      500  {object}  response.StandardResponse
+// @Router       /auth/mfa [get]
+func (h *mfaHandler) GetStatus(c echo.Context) error {
+	userID := c.Get("user_id").(uuid.UUID)
+	ctx := c.Request().Context()
+	status, err := h.mfaService.Status(ctx, userID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, h.responseBuilder.Error(err))
+	}
+	return c.JSON(http.StatusOK, h.responseBuilder.Success(constants.MFAStatusRetrievedSuccessfully, status))
+}
+
+// @Summary      Start TOTP enrollment
+// @Description  Create a new authenticator app secret. Render provisioning_uri as a QR code, then confirm with a code. Starting again replaces an unfinished enrollment.
+// @Tags         auth
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.StandardResponse{data=dto.TOTPEnrollmentResponse}
+// @Failure      401  {object}  response.StandardResponse
+// @Failure      409  {object}  response.StandardResponse
+// @Failure      429  {object}  response.StandardResponse
+// @Failure      500  {object}  response.StandardResponse
+// @Router       /auth/mfa/totp [post]
+func (h *mfaHandler) EnrollTOTP(c echo.Context) error {
+	userID := c.Get("user_id").(uuid.UUID)
+	ctx := c.Request().Context()
+	enrollment, err := h.mfaService.EnrollTOTP(ctx, userID)
+	if err != nil {
+		return h.mfaError(c, err)
+	}
+	return c.JSON(http.StatusOK, h.responseBuilder.Success(constants.TOTPEnrollmentStarted, enrollment))
+}
+
+// @Summary      Confirm TOTP enrollment
+// @Description  Enable two-factor authentication with a code from the authenticator app. The response has the recovery codes, which are not shown again.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        code  body      dto.MFACodeRequest  true  "Authenticator app code"
+// @Success      200   {object}  response.StandardResponse{data=dto.RecoveryCodesResponse}
+// @Failure      400   {object}  response.StandardResponse
+// @Failure      401   {object}  response.StandardResponse
+// @Failure      404   {object}  response.StandardResponse
+// @Failure      409   {object}  response.StandardResponse
+// @Failure      429   {object}  response.StandardResponse
+// @Failure      500   {object}  response.StandardResponse
+// @Router       /auth/mfa/totp/confirm [post]
+func (h *mfaHandler) ConfirmTOTP(c echo.Context) error {
+	req, err := request.BindAndValidate[dto.MFACodeRequest](c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(apperrors.ErrInvalidPayload))
+	}
+	userID := c.Get("user_id").(uuid.UUID)
+	ctx := c.Request().Context()
+	codes, err := h.mfaService.ConfirmTOTP(ctx, userID, req.Code)
+	if err != nil {
+		return h.mfaError(c, err)
+	}
+	return c.JSON(http.StatusOK, h.responseBuilder.Success(constants.TOTPEnabledSuccessfully, codes))
+}
+
+// @Summary      Disable two-factor authentication
+// @Description  Remove the authenticator app and recovery codes. Needs a current code or a recovery code.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        code  body      dto.MFACodeRequest  true  "Authenticator app or recovery code"
+// @Success      200   {object}  response.StandardResponse
+// @Failure      400   {object}  response.StandardResponse
+// @Failure      401   {object}  response.StandardResponse
+// @Failure      404   {object}  response.StandardResponse
+// @Failure      429   {object}  response.StandardResponse
+// @Failure      500   {object}  response.StandardResponse
+// @Router       /auth/mfa/totp/disable [post]
+func (h *mfaHandler) DisableTOTP(c echo.Context) error {
+	req, err := request.BindAndValidate[dto.MFACodeRequest](c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(apperrors.ErrInvalidPayload))
+	}
+	userID := c.Get("user_id").(uuid.UUID)
+	ctx := c.Request().Context()
+	if err := h.mfaService.DisableTOTP(ctx, userID, req.Code); err != nil {
+		return h.mfaError(c, err)
+	}
+	return c.JSON(http.StatusOK, h.responseBuilder.Success(constants.TOTPDisabledSuccessfully, nil))
+}
+
+// @Summary      Regenerate recovery codes
+// @Description  Replace all recovery codes. Needs a current code or a recovery code.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        code  body      dto.MFACodeRequest  true  "Authenticator app or recovery code"
+// @Success      200   {object}  response.StandardResponse{data=dto.RecoveryCodesResponse}
+// @Failure      400   {object}  response.StandardResponse
+// @Failure      401   {object}  response.StandardResponse
+// @Failure      404   {object}  response.StandardResponse
+// @Failure      429   {object}  response.StandardResponse
+// @Failure      500   {object}  response.StandardResponse
+// @Router       /auth/mfa/recovery-codes [post]
+func (h *mfaHandler) RegenerateRecoveryCodes(c echo.Context) error {
+	req, err := request.BindAndValidate[dto.MFACodeRequest](c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(apperrors.ErrInvalidPayload))
+	}
+	userID := c.Get("user_id").(uuid.UUID)
+	ctx := c.Request().Context()
+	codes, err := h.mfaService.RegenerateRecoveryCodes(ctx, userID, req.Code)
+	if err != nil {
+		return h.mfaError(c, err)
+	}
+	return c.JSON(http.StatusOK, h.responseBuilder.Success(constants.RecoveryCodesRegenerated, codes))
+}
+
+func (h *mfaHandler) mfaError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, apperrors.ErrInvalidMFACode):
+		return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(err))
+	case errors.Is(err, apperrors.ErrMFANotEnabled), errors.Is(err, apperrors.ErrMFAEnrollmentNotFound):
+		return c.JSON(http.StatusNotFound, h.responseBuilder.Error(err))
+	case errors.Is(err, apperrors.ErrMFAAlreadyEnabled):
+		return c.JSON(http.StatusConflict, h.responseBuilder.Error(err))
+	case errors.Is(err, apperrors.ErrAccountLocked):
+		return c.JSON(http.StatusTooManyRequests, h.responseBuilder.Error(err))
+	default:
+		return c.JSON(http.StatusInternalServerError, h.responseBuilder.Error(err))
+	}
+}
@@ -0,0 +1,218 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/http/request"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/http/response"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/services"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+type NotificationHandler interface {
+	ListNotifications(c echo.Context) error
+	GetUnreadCount(c echo.Context) error
+	MarkRead(c echo.Context) error
+	MarkUnread(c echo.Context) error
+	MarkAllRead(c echo.Context) error
+	GetPreferences(c echo.Context) error
+	UpdatePreferences(c echo.Context) error
+}
+
+type notificationHandler struct {
+	notificationService services.NotificationService
+	responseBuilder     *response.Builder
+}
+
+func NewNotificationHandler(notificationService services.NotificationService, responseBuilder *response.Builder) NotificationHandler {
+	return &notificationHandler{
+		notificationService: notificationService,
+		responseBuilder:     responseBuilder,
+	}
+}
+
+// @Summary      List Notifications
+// @Description  Lists the notifications of the authenticated user, newest first.
+// @Tags         Notifications
+// @Produce      json
+// @Param        unread_only query bool false "Only return unread notifications"
+// @Param        after_id query string false "Cursor for pagination (notification ID)"
+// @Param        limit query int false "Limit for pagination"
+// @Success      200 {object} response.StandardResponse{data=dto.PaginatedNotifications} "Notifications retrieved successfully"
+// @Failure      400 {object} response.StandardResponse "Invalid cursor"
+// @Failure      401 {object} response.StandardResponse "Unauthorized"
+// @Failure      500 {object} response.StandardResponse "Internal server error"
+// @Security     BearerAuth
+// @Router       /notifications/ [get]
+func (h *notificationHandler) ListNotifications(c echo.Context) error {
+	pagination, err := cursorPaginationFromQuery(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(err))
+	}
+	unreadOnly, _ := strconv.ParseBool(c.QueryParam("unread_only"))
+
+	userID := c.Get("user_id").(uuid.UUID)
+	ctx := c.Request().Context()
+
+	notifications, err := h.notificationService.ListNotifications(ctx, userID, unreadOnly, pagination)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrInvalidCursorPagination) {
+			return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(err))
+		}
+		return c.JSON(http.StatusInternalServerError, h.responseBuilder.Error(err))
+	}
+
+	return c.JSON(http.StatusOK, h.responseBuilder.Success(constants.NotificationsRetrievedSuccessfully, notifications))
+}
+
+// @Summary      Get Unread Notification Count
+// @Description  Returns how many notifications of the authenticated user are unread.
+// @Tags         Notifications
+// @Produce      json
+// @Success      200 {object} response.StandardResponse{data=dto.UnreadNotificationCountResponse} "Unread notification count retrieved successfully"
+// @Failure      401 {object} response.StandardResponse "Unauthorized"
+// @Failure      500 {object} response.StandardResponse "Internal server error"
+// @Security     BearerAuth
+// @Router       /notifications/unread-count [get]
+func (h *notificationHandler) GetUnreadCount(c echo.Context) error {
+	userID := c.Get("user_id").(uuid.UUID)
+	ctx := c.Request().Context()
+
+	count, err := h.notificationService.GetUnreadCount(ctx, userID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, h.responseBuilder.Error(err))
+	}
+
+	return c.JSON(http.StatusOK, h.responseBuilder.Success(constants.UnreadNotificationCountRetrievedSuccessfully, count))
+}
+
+// @Summary      Mark Notification Read
+// @Description  Marks a notification as read. Marking a read notification again keeps its original read time.
+// @Tags         Notifications
+// @Produce      json
+// @Param        notification_id path string true "Notification ID"
+// @Success      200 {object} response.StandardResponse{data=dto.NotificationResponse} "Notification updated successfully"
+// @Failure      400 {object} response.StandardResponse "Invalid notification ID"
+// @Failure      401 {object} response.StandardResponse "Unauthorized"
+// @Failure      404 {object} response.StandardResponse "Notification not found"
+// @Failure      500 {object} response.StandardResponse "Internal server error"
+// @Security     BearerAuth
+// @Router       /notifications/{notification_id}/read [post]
+func (h *notificationHandler) MarkRead(c echo.Context) error {
+	return h.setRead(c, h.notificationService.MarkRead)
+}
+
+// @Summary      Mark Notification Unread
+// @Description  Marks a notification as unread.
+// @Tags         Notifications
+// @Produce      json
+// @Param        notification_id path string true "Notification ID"
+// @Success      200 {object} response.StandardResponse{data=dto.NotificationResponse} "Notification updated successfully"
+// @Failure      400 {object} response.StandardResponse "Invalid notification ID"
+// @Failure      401 {object} response.StandardResponse "Unauthorized"
+// @Failure      404 {object} response.StandardResponse "Notification not found"
+// @Failure      500 {object} response.StandardResponse "Internal server error"
+// @Security     BearerAuth
+// @Router       /notifications/{notification_id}/unread [post]
+func (h *notificationHandler) MarkUnread(c echo.Context) error {
+	return h.setRead(c, h.notificationService.MarkUnread)
+}
+
+func (h *notificationHandler) setRead(c echo.Context, update func(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*dto.NotificationResponse, error)) error {
+	notificationID, err := uuid.Parse(c.Param("notification_id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(apperrors.ErrInvalidNotificationID))
+	}
+
+	userID := c.Get("user_id").(uuid.UUID)
+	ctx := c.Request().Context()
+
+	notification, err := update(ctx, notificationID, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.JSON(http.StatusNotFound, h.responseBuilder.Error(apperrors.ErrNotFound))
+		}
+		return c.JSON(http.StatusInternalServerError, h.responseBuilder.Error(err))
+	}
+
+	return c.JSON(http.StatusOK, h.responseBuilder.Success(constants.NotificationUpdatedSuccessfully, notification))
+}
+
+// @Summary      Mark All Notifications Read
+// @Description  Marks every unread notification of the authenticated user as read.
+// @Tags         Notifications
+// @Produce      json
+// @Success      200 {object} response.StandardResponse{data=dto.MarkAllNotificationsReadResponse} "Notifications marked as read"
+// @Failure      401 {object} response.StandardResponse "Unauthorized"
+// @Failure      500 {object} response.StandardResponse "Internal server error"
+// @Security     BearerAuth
+// @Router       /notifications/read-all [post]
+func (h *notificationHandler) MarkAllRead(c echo.Context) error {
+	userID := c.Get("user_id").(uuid.UUID)
+	ctx := c.Request().Context()
+
+	marked, err := h.notificationService.MarkAllRead(ctx, userID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, h.responseBuilder.Error(err))
+	}
+
+	return c.JSON(http.StatusOK, h.responseBuilder.Success(constants.NotificationsMarkedReadSuccessfully, marked))
+}
+
+// @Summary      Get Notification Preferences
+// @Description  Returns, for every notification type, whether it is sent by email and to the in-app inbox. Reminders are emailed by default; other types only go to the inbox.
+// @Tags         Notifications
+// @Produce      json
+// @Success      200 {object} response.StandardResponse{data=[]dto.NotificationPreferenceResponse} "Notification preferences retrieved successfully"
+// @Failure      401 {object} response.StandardResponse "Unauthorized"
+// @Failure      500 {object} response.StandardResponse "Internal server error"
+// @Security     BearerAuth
+// @Router       /notifications/preferences [get]
+func (h *notificationHandler) GetPreferences(c echo.Context) error {
+	userID := c.Get("user_id").(uuid.UUID)
+	ctx := c.Request().Context()
+
+	preferences, err := h.notificationService.GetPreferences(ctx, userID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, h.responseBuilder.Error(err))
+	}
+
+	return c.JSON(http.StatusOK, h.responseBuilder.Success(constants.NotificationPreferencesRetrievedSuccessfully, preferences))
+}
+
+// @Summary      Update Notification Preferences
+// @Description  Sets the channels for the listed notification types. Types that are not listed keep their current preferences.
+// @Tags         Notifications
+// @Accept       json
+// @Produce      json
+// @Param        preferences body dto.UpdateNotificationPreferencesRequest true "Notification preferences"
+// @Success      200 {object} response.StandardResponse{data=[]dto.NotificationPreferenceResponse} "Notification preferences updated successfully"
+// @Failure      400 {object} response.StandardResponse "Invalid request payload"
+// @Failure      401 {object} response.StandardResponse "Unauthorized"
+// @Failure      500 {object} response.StandardResponse "Internal server error"
+// @Security     BearerAuth
+// @Router       /notifications/preferences [put]
+func (h *notificationHandler) UpdatePreferences(c echo.Context) error {
+	req, err := request.BindAndValidate[dto.UpdateNotificationPreferencesRequest](c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(apperrors.ErrInvalidPayload))
+	}
+
+	userID := c.Get("user_id").(uuid.UUID)
+	ctx := c.Request().Context()
+
+	preferences, err := h.notificationService.UpdatePreferences(ctx, userID, req)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, h.responseBuilder.Error(err))
+	}
+
+	return c.JSON(http.StatusOK, h.responseBuilder.Success(constants.NotificationPreferencesUpdatedSuccessfully, preferences))
+}
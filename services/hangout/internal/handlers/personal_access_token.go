@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/http/request"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/http/response"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/services"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type PersonalAccessTokenHandler interface {
+	CreateToken(c echo.Context) error
+	ListTokens(c echo.Context) error
+	RevokeToken(c echo.Context) error
+}
+
+type personalAccessTokenHandler struct {
+	tokenService    services.PersonalAccessTokenService
+	responseBuilder *response.Builder
+}
+
+func NewPersonalAccessTokenHandler(tokenService services.PersonalAccessTokenService, responseBuilder *response.Builder) PersonalAccessTokenHandler {
+	return &personalAccessTokenHandler{
+		tokenService:    tokenService,
+		responseBuilder: responseBuilder,
+	}
+}
+
+// @Summary      Create a personal access token
+// @Description  Create a token for scripts. Send it as "Authorization: Bearer <token>" to the hangout, activity, memory, album and comment routes its scopes cover; a write scope also grants read. The token is only returned by this endpoint.
+// @Tags         Me
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        token  body      dto.CreatePersonalAccessTokenRequest  true  "Token name, scopes and lifetime"
+// @Success      201    {object}  response.StandardResponse{data=dto.PersonalAccessTokenCreatedResponse}
+// @Failure      400    {object}  response.StandardResponse
+// @Failure      401    {object}  response.StandardResponse
+// @Failure      409    {object}  response.StandardResponse
+// @Failure      500    {object}  response.StandardResponse
+// @Router       /me/tokens [post]
+func (h *personalAccessTokenHandler) CreateToken(c echo.Context) error {
+	req, err := request.BindAndValidate[dto.CreatePersonalAccessTokenRequest](c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(apperrors.ErrInvalidPayload))
+	}
+
+	userID := c.Get("user_id").(uuid.UUID)
+	ctx := c.Request().Context()
+	token, err := h.tokenService.CreateToken(ctx, userID, req)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrPersonalAccessTokenLimitReached) {
+			return c.JSON(http.StatusConflict, h.responseBuilder.Error(err))
+		}
+		return c.JSON(http.StatusInternalServerError, h.responseBuilder.Error(err))
+	}
+	return c.JSON(http.StatusCreated, h.responseBuilder.Success(constants.PersonalAccessTokenCreatedSuccessfully, token))
+}
+
+// @Summary      List personal access tokens
+// @Description  List the user's personal access tokens, newest first. Tokens themselves are never returned again.
+// @Tags         Me
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.StandardResponse{data=[]dto.PersonalAccessTokenResponse}
+// @Failure      401  {object}  response.StandardResponse
+// @Failure      500  {object}  response.StandardResponse
+// @Router       /me/tokens [get]
+func (h *personalAccessTokenHandler) ListTokens(c echo.Context) error {
+	userID := c.Get("user_id").(uuid.UUID)
+	ctx := c.Request().Context()
+	tokens, err := h.tokenService.ListTokens(ctx, userID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, h.responseBuilder.Error(err))
+	}
+	return c.JSON(http.StatusOK, h.responseBuilder.Success(constants.PersonalAccessTokensRetrievedSuccessfully, tokens))
+}
+
+// @Summary      Revoke a personal access token
+// @Description  Delete a personal access token. It stops working immediately.
+// @Tags         Me
+// @Produce      json
+// @Security     BearerAuth
+// @Param        token_id  path      string  true  "Token ID"
+// @Success      200       {object}  response.StandardResponse
+// @Failure      400       {object}  response.StandardResponse
+// @Failure      401       {object}  response.StandardResponse
+// @Failure      404       {object}  response.StandardResponse
+// @Failure      500       {object}  response.StandardResponse
+// @Router       /me/tokens/{token_id} [delete]
+func (h *personalAccessTokenHandler) RevokeToken(c echo.Context) error {
+	tokenID, err := uuid.Parse(c.Param("token_id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(apperrors.ErrInvalidPersonalAccessTokenID))
+	}
+
+	userID := c.Get("user_id").(uuid.UUID)
+	ctx := c.Request().Context()
+	if err := h.tokenService.RevokeToken(ctx, userID, tokenID); err != nil {
+		if errors.Is(err, apperrors.ErrPersonalAccessTokenNotFound) {
+			return c.JSON(http.StatusNotFound, h.responseBuilder.Error(err))
+		}
+		return c.JSON(http.StatusInternalServerError, h.responseBuilder.Error(err))
+	}
+	return c.JSON(http.StatusOK, h.responseBuilder.Success(constants.PersonalAccessTokenRevokedSuccessfully, nil))
+}
@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/http/response"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/services"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type SessionHandler interface {
+	ListSessions(c echo.Context) error
+	RevokeSession(c echo.Context) error
+	RevokeAllSessions(c echo.Context) error
+}
+
+type sessionHandler struct {
+	sessionService  services.SessionService
+	responseBuilder *response.Builder
+}
+
+func NewSessionHandler(sessionService services.SessionService, responseBuilder *response.Builder) SessionHandler {
+	return &sessionHandler{
+		sessionService:  sessionService,
+		responseBuilder: responseBuilder,
+	}
+}
+
+// @Summary      List sessions
+// @Description  List the devices the user is signed in on, most recently used first. The session of the calling token is marked current.
+// @Tags         Me
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.StandardResponse{data=[]dto.SessionResponse}
+// @Failure      401  {object}  response.StandardResponse
+// @Failure      500  {object}  response.StandardResponse
+// @Router       /me/sessions [get]
+func (h *sessionHandler) ListSessions(c echo.Context) error {
+	userID := c.Get("user_id").(uuid.UUID)
+	sessionID := c.Get("session_id").(uuid.UUID)
+	ctx := c.Request().Context()
+	sessions, err := h.sessionService.ListSessions(ctx, userID, sessionID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, h.responseBuilder.Error(err))
+	}
+	return c.JSON(http.StatusOK, h.responseBuilder.Success(constants.SessionsRetrievedSuccessfully, sessions))
+}
+
+// @Summary      Revoke a session
+// @Description  Sign out one device. Its tokens stop working within the session cache TTL.
+// @Tags         Me
+// @Produce      json
+// @Security     BearerAuth
+// @Param        session_id  path      string  true  "Session ID"
+// @Success      200         {object}  response.StandardResponse
+// @Failure      400         {object}  response.StandardResponse
+// @Failure      401         {object}  response.StandardResponse
+// @Failure      404         {object}  response.StandardResponse
+// @Failure      500         {object}  response.StandardResponse
+// @Router       /me/sessions/{session_id} [delete]
+func (h *sessionHandler) RevokeSession(c echo.Context) error {
+	sessionID, err := uuid.Parse(c.Param("session_id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(apperrors.ErrInvalidSessionID))
+	}
+
+	userID := c.Get("user_id").(uuid.UUID)
+	ctx := c.Request().Context()
+	if err := h.sessionService.RevokeSession(ctx, userID, sessionID); err != nil {
+		if errors.Is(err, apperrors.ErrSessionNotFound) {
+			return c.JSON(http.StatusNotFound, h.responseBuilder.Error(err))
+		}
+		return c.JSON(http.StatusInternalServerError, h.responseBuilder.Error(err))
+	}
+	return c.JSON(http.StatusOK, h.responseBuilder.Success(constants.SessionRevokedSuccessfully, nil))
+}
+
+// @Summary      Sign out everywhere
+// @Description  Revoke every session of the user, the calling one included.
+// @Tags         Me
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.StandardResponse{data=dto.SessionsRevokedResponse}
+// @Failure      401  {object}  response.StandardResponse
+// @Failure      500  {object}  response.StandardResponse
+// @Router       /me/sessions [delete]
+func (h *sessionHandler) RevokeAllSessions(c echo.Context) error {
+	userID := c.Get("user_id").(uuid.UUID)
+	ctx := c.Request().Context()
+	revoked, err := h.sessionService.RevokeAllSessions(ctx, userID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, h.responseBuilder.Error(err))
+	}
+	return c.JSON(http.StatusOK, h.responseBuilder.Success(constants.SessionsRevokedSuccessfully, &dto.SessionsRevokedResponse{Revoked: revoked}))
+}
@@ -0,0 +1,288 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/http/request"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/http/response"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/services"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+type ShareLinkHandler interface {
+	CreateShareLink(c echo.Context) error
+	ListShareLinks(c echo.Context) error
+	RevokeShareLink(c echo.Context) error
+	ListAccesses(c echo.Context) error
+	GetSharedHangout(c echo.Context) error
+	ListSharedMemories(c echo.Context) error
+}
+
+type shareLinkHandler struct {
+	shareLinkService services.ShareLinkService
+	responseBuilder  *response.Builder
+}
+
+func NewShareLinkHandler(shareLinkService services.ShareLinkService, responseBuilder *response.Builder) ShareLinkHandler {
+	return &shareLinkHandler{
+		shareLinkService: shareLinkService,
+		responseBuilder:  responseBuilder,
+	}
+}
+
+// @Summary      Create Share Link
+// @Description  Creates a view-only link to the hangout, or to one of its albums when album_id is set. The token is only returned once. Links can be protected with a password and can expire. Only the organizer of the hangout can create links.
+// @Tags         Share Links
+// @Accept       json
+// @Produce      json
+// @Param        hangout_id path string true "Hangout ID"
+// @Param        share_link body dto.CreateShareLinkRequest true "Share link"
+// @Success      201 {object} response.StandardResponse{data=dto.ShareLinkCreatedResponse} "Share link created successfully"
+// @Failure      400 {object} response.StandardResponse "Invalid request payload, album or expiry"
+// @Failure      401 {object} response.StandardResponse "Unauthorized"
+// @Failure      403 {object} response.StandardResponse "Not the organizer of the hangout"
+// @Failure      404 {object} response.StandardResponse "Hangout not found"
+// @Failure      409 {object} response.StandardResponse "Too many active share links"
+// @Failure      500 {object} response.StandardResponse "Internal server error"
+// @Security     BearerAuth
+// @Router       /hangouts/{hangout_id}/share-links [post]
+func (h *shareLinkHandler) CreateShareLink(c echo.Context) error {
+	hangoutID, err := uuid.Parse(c.Param("hangout_id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(apperrors.ErrInvalidHangoutID))
+	}
+
+	req, err := request.BindAndValidate[dto.CreateShareLinkRequest](c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(apperrors.ErrInvalidPayload))
+	}
+
+	userID := c.Get("user_id").(uuid.UUID)
+	ctx := c.Request().Context()
+
+	link, err := h.shareLinkService.CreateShareLink(ctx, userID, hangoutID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return c.JSON(http.StatusNotFound, h.responseBuilder.Error(apperrors.ErrNotFound))
+		case errors.Is(err, apperrors.ErrForbidden):
+			return c.JSON(http.StatusForbidden, h.responseBuilder.Error(err))
+		case errors.Is(err, apperrors.ErrInvalidAlbumID), errors.Is(err, apperrors.ErrInvalidShareExpiry):
+			return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(err))
+		case errors.Is(err, apperrors.ErrShareLinkLimitReached):
+			return c.JSON(http.StatusConflict, h.responseBuilder.Error(err))
+		}
+		return c.JSON(http.StatusInternalServerError, h.responseBuilder.Error(err))
+	}
+
+	return c.JSON(http.StatusCreated, h.responseBuilder.Success(constants.ShareLinkCreatedSuccessfully, link))
+}
+
+// @Summary      List Share Links
+// @Description  Lists the share links of a hangout, including revoked and expired ones, newest first. Only the organizer of the hangout can list links.
+// @Tags         Share Links
+// @Produce      json
+// @Param        hangout_id path string true "Hangout ID"
+// @Success      200 {object} response.StandardResponse{data=[]dto.ShareLinkResponse} "Share links retrieved successfully"
+// @Failure      400 {object} response.StandardResponse "Invalid hangout ID"
+// @Failure      401 {object} response.StandardResponse "Unauthorized"
+// @Failure      403 {object} response.StandardResponse "Not the organizer of the hangout"
+// @Failure      404 {object} response.StandardResponse "Hangout not found"
+// @Failure      500 {object} response.StandardResponse "Internal server error"
+// @Security     BearerAuth
+// @Router       /hangouts/{hangout_id}/share-links [get]
+func (h *shareLinkHandler) ListShareLinks(c echo.Context) error {
+	hangoutID, err := uuid.Parse(c.Param("hangout_id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(apperrors.ErrInvalidHangoutID))
+	}
+
+	userID := c.Get("user_id").(uuid.UUID)
+	ctx := c.Request().Context()
+
+	links, err := h.shareLinkService.ListShareLinks(ctx, userID, hangoutID)
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return c.JSON(http.StatusNotFound, h.responseBuilder.Error(apperrors.ErrNotFound))
+		case errors.Is(err, apperrors.ErrForbidden):
+			return c.JSON(http.StatusForbidden, h.responseBuilder.Error(err))
+		}
+		return c.JSON(http.StatusInternalServerError, h.responseBuilder.Error(err))
+	}
+
+	return c.JSON(http.StatusOK, h.responseBuilder.Success(constants.ShareLinksRetrievedSuccessfully, links))
+}
+
+// @Summary      Revoke Share Link
+// @Description  Revokes a share link so it stops working immediately. The link and its access log are kept. Only the organizer of the hangout can revoke links.
+// @Tags         Share Links
+// @Produce      json
+// @Param        share_link_id path string true "Share link ID"
+// @Success      200 {object} response.StandardResponse "Share link revoked successfully"
+// @Failure      400 {object} response.StandardResponse "Invalid share link ID"
+// @Failure      401 {object} response.StandardResponse "Unauthorized"
+// @Failure      403 {object} response.StandardResponse "Not the organizer of the hangout"
+// @Failure      404 {object} response.StandardResponse "Share link not found"
+// @Failure      500 {object} response.StandardResponse "Internal server error"
+// @Security     BearerAuth
+// @Router       /share-links/{share_link_id} [delete]
+func (h *shareLinkHandler) RevokeShareLink(c echo.Context) error {
+	shareLinkID, err := uuid.Parse(c.Param("share_link_id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(apperrors.ErrInvalidShareLinkID))
+	}
+
+	userID := c.Get("user_id").(uuid.UUID)
+	ctx := c.Request().Context()
+
+	if err := h.shareLinkService.RevokeShareLink(ctx, userID, shareLinkID); err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return c.JSON(http.StatusNotFound, h.responseBuilder.Error(apperrors.ErrNotFound))
+		case errors.Is(err, apperrors.ErrForbidden):
+			return c.JSON(http.StatusForbidden, h.responseBuilder.Error(err))
+		}
+		return c.JSON(http.StatusInternalServerError, h.responseBuilder.Error(err))
+	}
+
+	return c.JSON(http.StatusOK, h.responseBuilder.Success(constants.ShareLinkRevokedSuccessfully, nil))
+}
+
+// @Summary      List Share Link Accesses
+// @Description  Lists the requests made with a share link, newest first, including denied ones. Outcome is granted, password_missing, password_invalid, expired or revoked. Only the organizer of the hangout can list accesses.
+// @Tags         Share Links
+// @Produce      json
+// @Param        share_link_id path string true "Share link ID"
+// @Param        after_id query string false "Cursor for pagination (access ID)"
+// @Param        limit query int false "Limit for pagination"
+// @Success      200 {object} response.StandardResponse{data=dto.PaginatedShareLinkAccesses} "Share link accesses retrieved successfully"
+// @Failure      400 {object} response.StandardResponse "Invalid share link ID or cursor"
+// @Failure      401 {object} response.StandardResponse "Unauthorized"
+// @Failure      403 {object} response.StandardResponse "Not the organizer of the hangout"
+// @Failure      404 {object} response.StandardResponse "Share link not found"
+// @Failure      500 {object} response.StandardResponse "Internal server error"
+// @Security     BearerAuth
+// @Router       /share-links/{share_link_id}/accesses [get]
+func (h *shareLinkHandler) ListAccesses(c echo.Context) error {
+	shareLinkID, err := uuid.Parse(c.Param("share_link_id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(apperrors.ErrInvalidShareLinkID))
+	}
+
+	pagination, err := cursorPaginationFromQuery(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(err))
+	}
+	userID := c.Get("user_id").(uuid.UUID)
+	ctx := c.Request().Context()
+
+	accesses, err := h.shareLinkService.ListAccesses(ctx, userID, shareLinkID, pagination)
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return c.JSON(http.StatusNotFound, h.responseBuilder.Error(apperrors.ErrNotFound))
+		case errors.Is(err, apperrors.ErrForbidden):
+			return c.JSON(http.StatusForbidden, h.responseBuilder.Error(err))
+		case errors.Is(err, apperrors.ErrInvalidCursorPagination):
+			return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(err))
+		}
+		return c.JSON(http.StatusInternalServerError, h.responseBuilder.Error(err))
+	}
+
+	return c.JSON(http.StatusOK, h.responseBuilder.Success(constants.ShareLinkAccessesRetrievedSuccessfully, accesses))
+}
+
+// @Summary      Get Shared Hangout
+// @Description  Returns the view-only summary of the hangout or album behind a share link. No account is needed; password protected links need the X-Share-Password header. Requests are rate limited per IP.
+// @Tags         Public Shares
+// @Produce      json
+// @Param        token path string true "Share link token"
+// @Param        X-Share-Password header string false "Password of the share link"
+// @Success      200 {object} response.StandardResponse{data=dto.SharedHangoutResponse} "Shared hangout retrieved successfully"
+// @Failure      401 {object} response.StandardResponse "Password missing or invalid"
+// @Failure      404 {object} response.StandardResponse "Share link not found or revoked"
+// @Failure      410 {object} response.StandardResponse "Share link expired"
+// @Failure      429 {object} response.StandardResponse "Too many requests"
+// @Failure      500 {object} response.StandardResponse "Internal server error"
+// @Router       /public/shares/{token} [get]
+func (h *shareLinkHandler) GetSharedHangout(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	shared, err := h.shareLinkService.GetSharedHangout(ctx, shareAccessFromRequest(c))
+	if err != nil {
+		return h.publicShareError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, h.responseBuilder.Success(constants.SharedHangoutRetrievedSuccessfully, shared))
+}
+
+// @Summary      List Shared Memories
+// @Description  Lists the memories behind a share link in gallery order, with short-lived download URLs. Album links only list their album. No account is needed; password protected links need the X-Share-Password header. Requests are rate limited per IP.
+// @Tags         Public Shares
+// @Produce      json
+// @Param        token path string true "Share link token"
+// @Param        X-Share-Password header string false "Password of the share link"
+// @Param        album_id query string false "Only memories of this album"
+// @Param        after_id query string false "Cursor for pagination (memory ID)"
+// @Param        limit query int false "Limit for pagination"
+// @Success      200 {object} response.StandardResponse{data=dto.PaginatedSharedMemories} "Shared memories retrieved successfully"
+// @Failure      400 {object} response.StandardResponse "Invalid album ID or cursor"
+// @Failure      401 {object} response.StandardResponse "Password missing or invalid"
+// @Failure      404 {object} response.StandardResponse "Share link not found or revoked"
+// @Failure      410 {object} response.StandardResponse "Share link expired"
+// @Failure      429 {object} response.StandardResponse "Too many requests"
+// @Failure      500 {object} response.StandardResponse "Internal server error"
+// @Router       /public/shares/{token}/memories [get]
+func (h *shareLinkHandler) ListSharedMemories(c echo.Context) error {
+	var albumID *uuid.UUID
+	if albumIDStr := c.QueryParam("album_id"); albumIDStr != "" {
+		parsed, err := uuid.Parse(albumIDStr)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(apperrors.ErrInvalidAlbumID))
+		}
+		albumID = &parsed
+	}
+
+	pagination, err := cursorPaginationFromQuery(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(err))
+	}
+	ctx := c.Request().Context()
+
+	memories, err := h.shareLinkService.ListSharedMemories(ctx, shareAccessFromRequest(c), albumID, pagination)
+	if err != nil {
+		return h.publicShareError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, h.responseBuilder.Success(constants.SharedMemoriesRetrievedSuccessfully, memories))
+}
+
+func (h *shareLinkHandler) publicShareError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, apperrors.ErrShareLinkNotFound):
+		return c.JSON(http.StatusNotFound, h.responseBuilder.Error(err))
+	case errors.Is(err, apperrors.ErrShareLinkExpired):
+		return c.JSON(http.StatusGone, h.responseBuilder.Error(err))
+	case errors.Is(err, apperrors.ErrSharePasswordRequired), errors.Is(err, apperrors.ErrInvalidSharePassword):
+		return c.JSON(http.StatusUnauthorized, h.responseBuilder.Error(err))
+	case errors.Is(err, apperrors.ErrInvalidCursorPagination):
+		return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(err))
+	}
+	return c.JSON(http.StatusInternalServerError, h.responseBuilder.Error(err))
+}
+
+func shareAccessFromRequest(c echo.Context) *dto.ShareAccess {
+	return &dto.ShareAccess{
+		Token:     c.Param("token"),
+		Password:  c.Request().Header.Get(constants.SharePasswordHeader),
+		IPAddress: c.RealIP(),
+		UserAgent: c.Request().UserAgent(),
+	}
+}
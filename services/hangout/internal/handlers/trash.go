@@ -0,0 +1,190 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/http/response"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/services"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+type TrashHandler interface {
+	ListDeletedHangouts(c echo.Context) error
+	ListDeletedMemories(c echo.Context) error
+	RestoreHangout(c echo.Context) error
+	RestoreMemory(c echo.Context) error
+}
+
+type trashHandler struct {
+	trashService    services.TrashService
+	responseBuilder *response.Builder
+}
+
+func NewTrashHandler(trashService services.TrashService, responseBuilder *response.Builder) TrashHandler {
+	return &trashHandler{
+		trashService:    trashService,
+		responseBuilder: responseBuilder,
+	}
+}
+
+// @Summary      List Deleted Hangouts
+// @Description  Lists hangouts in the trash, most recently deleted first.
+// @Tags         Trash
+// @Produce      json
+// @Param        after_id query string false "Cursor for pagination (hangout ID)"
+// @Param        limit query int false "Limit for pagination"
+// @Success      200 {object} response.StandardResponse{data=dto.PaginatedDeletedHangouts} "Deleted hangouts retrieved successfully"
+// @Failure      400 {object} response.StandardResponse "Invalid cursor"
+// @Failure      401 {object} response.StandardResponse "Unauthorized"
+// @Failure      500 {object} response.StandardResponse "Internal server error"
+// @Security     BearerAuth
+// @Router       /trash/hangouts [get]
+func (h *trashHandler) ListDeletedHangouts(c echo.Context) error {
+	pagination, err := cursorPaginationFromQuery(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(err))
+	}
+
+	userID := c.Get("user_id").(uuid.UUID)
+	ctx := c.Request().Context()
+
+	hangouts, err := h.trashService.ListDeletedHangouts(ctx, userID, pagination)
+	if err != nil {
+		if err == apperrors.ErrInvalidCursorPagination {
+			return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(err))
+		}
+		return c.JSON(http.StatusInternalServerError, h.responseBuilder.Error(err))
+	}
+
+	return c.JSON(http.StatusOK, h.responseBuilder.Success(constants.DeletedHangoutsRetrievedSuccessfully, hangouts))
+}
+
+// @Summary      List Deleted Memories
+// @Description  Lists memories in the trash whose hangout is not deleted, most recently deleted first.
+// @Tags         Trash
+// @Produce      json
+// @Param        after_id query string false "Cursor for pagination (memory ID)"
+// @Param        limit query int false "Limit for pagination"
+// @Success      200 {object} response.StandardResponse{data=dto.PaginatedDeletedMemories} "Deleted memories retrieved successfully"
+// @Failure      400 {object} response.StandardResponse "Invalid cursor"
+// @Failure      401 {object} response.StandardResponse "Unauthorized"
+// @Failure      500 {object} response.StandardResponse "Internal server error"
+// @Security     BearerAuth
+// @Router       /trash/memories [get]
+func (h *trashHandler) ListDeletedMemories(c echo.Context) error {
+	pagination, err := cursorPaginationFromQuery(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(err))
+	}
+
+	userID := c.Get("user_id").(uuid.UUID)
+	ctx := c.Request().Context()
+
+	memories, err := h.trashService.ListDeletedMemories(ctx, userID, pagination)
+	if err != nil {
+		if err == apperrors.ErrInvalidCursorPagination {
+			return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(err))
+		}
+		return c.JSON(http.StatusInternalServerError, h.responseBuilder.Error(err))
+	}
+
+	return c.JSON(http.StatusOK, h.responseBuilder.Success(constants.DeletedMemoriesRetrievedSuccessfully, memories))
+}
+
+// @Summary      Restore Hangout
+// @Description  Restores a deleted hangout together with the memories deleted alongside it.
+// @Tags         Trash
+// @Produce      json
+// @Param        hangout_id path string true "Hangout ID"
+// @Success      200 {object} response.StandardResponse{data=dto.HangoutDetailResponse} "Hangout restored successfully"
+// @Failure      400 {object} response.StandardResponse "Invalid Hangout ID"
+// @Failure      401 {object} response.StandardResponse "Unauthorized"
+// @Failure      404 {object} response.StandardResponse "resource not found"
+// @Failure      500 {object} response.StandardResponse "Internal server error"
+// @Security     BearerAuth
+// @Router       /hangouts/{hangout_id}/restore [post]
+func (h *trashHandler) RestoreHangout(c echo.Context) error {
+	hangoutID, err := uuid.Parse(c.Param("hangout_id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(apperrors.ErrInvalidHangoutID))
+	}
+
+	userID := c.Get("user_id").(uuid.UUID)
+	ctx := c.Request().Context()
+
+	hangout, err := h.trashService.RestoreHangout(ctx, hangoutID, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.JSON(http.StatusNotFound, h.responseBuilder.Error(apperrors.ErrNotFound))
+		}
+		return c.JSON(http.StatusInternalServerError, h.responseBuilder.Error(err))
+	}
+
+	return c.JSON(http.StatusOK, h.responseBuilder.Success(constants.HangoutRestoredSuccessfully, hangout))
+}
+
+// @Summary      Restore Memory
+// @Description  Restores a deleted memory. The memory's hangout must not be in the trash.
+// @Tags         Trash
+// @Produce      json
+// @Param        memory_id path string true "Memory ID"
+// @Success      200 {object} response.StandardResponse "Memory restored successfully"
+// @Failure      400 {object} response.StandardResponse "Invalid memory ID"
+// @Failure      401 {object} response.StandardResponse "Unauthorized"
+// @Failure      404 {object} response.StandardResponse "Memory not found"
+// @Failure      409 {object} response.StandardResponse "Hangout is deleted"
+// @Failure      500 {object} response.StandardResponse "Internal server error"
+// @Security     BearerAuth
+// @Router       /memories/{memory_id}/restore [post]
+func (h *trashHandler) RestoreMemory(c echo.Context) error {
+	memoryID, err := uuid.Parse(c.Param("memory_id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(apperrors.ErrInvalidMemoryID))
+	}
+
+	userID := c.Get("user_id").(uuid.UUID)
+	ctx := c.Request().Context()
+
+	err = h.trashService.RestoreMemory(ctx, memoryID, userID)
+	if err != nil {
+		if err == apperrors.ErrMemoryNotFound {
+			return c.JSON(http.StatusNotFound, h.responseBuilder.Error(err))
+		}
+		if err == apperrors.ErrHangoutDeleted {
+			return c.JSON(http.StatusConflict, h.responseBuilder.Error(err))
+		}
+		return c.JSON(http.StatusInternalServerError, h.responseBuilder.Error(err))
+	}
+
+	return c.JSON(http.StatusOK, h.responseBuilder.Success(constants.MemoryRestoredSuccessfully, nil))
+}
+
+// cursorPaginationFromQuery reads limit and after_id from the query string.
+func cursorPaginationFromQuery(c echo.Context) (*dto.CursorPagination, error) {
+	pagination := &dto.CursorPagination{}
+
+	if limitStr := c.QueryParam("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil {
+			return nil, apperrors.ErrInvalidPagination
+		}
+		pagination.Limit = limit
+	}
+
+	if afterIDStr := c.QueryParam("after_id"); afterIDStr != "" {
+		afterID, err := uuid.Parse(afterIDStr)
+		if err != nil {
+			return nil, apperrors.ErrInvalidPagination
+		}
+		pagination.AfterID = &afterID
+	}
+
+	return pagination, nil
+}
@@ -0,0 +1,232 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/http/request"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/http/response"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/services"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+type WebhookHandler interface {
+	CreateWebhook(c echo.Context) error
+	GetWebhook(c echo.Context) error
+	ListWebhooks(c echo.Context) error
+	DeleteWebhook(c echo.Context) error
+	ListDeliveries(c echo.Context) error
+	SendTestEvent(c echo.Context) error
+}
+
+type webhookHandler struct {
+	webhookService  services.WebhookService
+	responseBuilder *response.Builder
+}
+
+func NewWebhookHandler(webhookService services.WebhookService, responseBuilder *response.Builder) WebhookHandler {
+	return &webhookHandler{
+		webhookService:  webhookService,
+		responseBuilder: responseBuilder,
+	}
+}
+
+// @Summary      Create Webhook
+// @Description  Subscribes a URL to hangout events of the authenticated user. Deliveries are POSTed as JSON and signed with HMAC-SHA256: X-Hangout-Signature is "sha256=" followed by the hex HMAC of "<X-Hangout-Timestamp>.<body>" keyed with the secret. The secret is only returned by this endpoint.
+// @Tags         Webhooks
+// @Accept       json
+// @Produce      json
+// @Param        webhook body dto.CreateWebhookRequest true "Webhook subscription"
+// @Success      201 {object} response.StandardResponse{data=dto.WebhookCreatedResponse} "Webhook created successfully"
+// @Failure      400 {object} response.StandardResponse "Invalid request payload or URL"
+// @Failure      401 {object} response.StandardResponse "Unauthorized"
+// @Failure      409 {object} response.StandardResponse "Maximum number of webhooks reached"
+// @Failure      500 {object} response.StandardResponse "Internal server error"
+// @Security     BearerAuth
+// @Router       /webhooks/ [post]
+func (h *webhookHandler) CreateWebhook(c echo.Context) error {
+	req, err := request.BindAndValidate[dto.CreateWebhookRequest](c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(apperrors.ErrInvalidPayload))
+	}
+
+	userID := c.Get("user_id").(uuid.UUID)
+	ctx := c.Request().Context()
+
+	webhook, err := h.webhookService.CreateWebhook(ctx, userID, req)
+	if err != nil {
+		switch err {
+		case apperrors.ErrInvalidWebhookURL, apperrors.ErrWebhookTargetNotAllowed:
+			return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(err))
+		case apperrors.ErrWebhookLimitReached:
+			return c.JSON(http.StatusConflict, h.responseBuilder.Error(err))
+		default:
+			return c.JSON(http.StatusInternalServerError, h.responseBuilder.Error(err))
+		}
+	}
+
+	return c.JSON(http.StatusCreated, h.responseBuilder.Success(constants.WebhookCreatedSuccessfully, webhook))
+}
+
+// @Summary      Get Webhook
+// @Description  Retrieves a webhook subscription of the authenticated user.
+// @Tags         Webhooks
+// @Produce      json
+// @Param        webhook_id path string true "Webhook ID"
+// @Success      200 {object} response.StandardResponse{data=dto.WebhookResponse} "Webhook retrieved successfully"
+// @Failure      400 {object} response.StandardResponse "Invalid webhook ID"
+// @Failure      401 {object} response.StandardResponse "Unauthorized"
+// @Failure      404 {object} response.StandardResponse "Webhook not found"
+// @Failure      500 {object} response.StandardResponse "Internal server error"
+// @Security     BearerAuth
+// @Router       /webhooks/{webhook_id} [get]
+func (h *webhookHandler) GetWebhook(c echo.Context) error {
+	webhookID, err := uuid.Parse(c.Param("webhook_id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(apperrors.ErrInvalidWebhookID))
+	}
+
+	userID := c.Get("user_id").(uuid.UUID)
+	ctx := c.Request().Context()
+
+	webhook, err := h.webhookService.GetWebhook(ctx, webhookID, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.JSON(http.StatusNotFound, h.responseBuilder.Error(apperrors.ErrNotFound))
+		}
+		return c.JSON(http.StatusInternalServerError, h.responseBuilder.Error(err))
+	}
+
+	return c.JSON(http.StatusOK, h.responseBuilder.Success(constants.WebhookRetrievedSuccessfully, webhook))
+}
+
+// @Summary      List Webhooks
+// @Description  Lists the webhook subscriptions of the authenticated user.
+// @Tags         Webhooks
+// @Produce      json
+// @Success      200 {object} response.StandardResponse{data=[]dto.WebhookResponse} "Webhooks retrieved successfully"
+// @Failure      401 {object} response.StandardResponse "Unauthorized"
+// @Failure      500 {object} response.StandardResponse "Internal server error"
+// @Security     BearerAuth
+// @Router       /webhooks/ [get]
+func (h *webhookHandler) ListWebhooks(c echo.Context) error {
+	userID := c.Get("user_id").(uuid.UUID)
+	ctx := c.Request().Context()
+
+	webhooks, err := h.webhookService.ListWebhooks(ctx, userID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, h.responseBuilder.Error(err))
+	}
+
+	return c.JSON(http.StatusOK, h.responseBuilder.Success(constants.WebhooksRetrievedSuccessfully, webhooks))
+}
+
+// @Summary      Delete Webhook
+// @Description  Deletes a webhook subscription together with its delivery log. Pending deliveries are dropped.
+// @Tags         Webhooks
+// @Produce      json
+// @Param        webhook_id path string true "Webhook ID"
+// @Success      200 {object} response.StandardResponse "Webhook deleted successfully"
+// @Failure      400 {object} response.StandardResponse "Invalid webhook ID"
+// @Failure      401 {object} response.StandardResponse "Unauthorized"
+// @Failure      404 {object} response.StandardResponse "Webhook not found"
+// @Failure      500 {object} response.StandardResponse "Internal server error"
+// @Security     BearerAuth
+// @Router       /webhooks/{webhook_id} [delete]
+func (h *webhookHandler) DeleteWebhook(c echo.Context) error {
+	webhookID, err := uuid.Parse(c.Param("webhook_id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(apperrors.ErrInvalidWebhookID))
+	}
+
+	userID := c.Get("user_id").(uuid.UUID)
+	ctx := c.Request().Context()
+
+	if err := h.webhookService.DeleteWebhook(ctx, webhookID, userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.JSON(http.StatusNotFound, h.responseBuilder.Error(apperrors.ErrNotFound))
+		}
+		return c.JSON(http.StatusInternalServerError, h.responseBuilder.Error(err))
+	}
+
+	return c.JSON(http.StatusOK, h.responseBuilder.Success(constants.WebhookDeletedSuccessfully, nil))
+}
+
+// @Summary      List Webhook Deliveries
+// @Description  Lists delivery attempts of a webhook, newest first. Status is pending, succeeded or dead_letter.
+// @Tags         Webhooks
+// @Produce      json
+// @Param        webhook_id path string true "Webhook ID"
+// @Param        after_id query string false "Cursor for pagination (delivery ID)"
+// @Param        limit query int false "Limit for pagination"
+// @Success      200 {object} response.StandardResponse{data=dto.PaginatedWebhookDeliveries} "Webhook deliveries retrieved successfully"
+// @Failure      400 {object} response.StandardResponse "Invalid webhook ID or cursor"
+// @Failure      401 {object} response.StandardResponse "Unauthorized"
+// @Failure      404 {object} response.StandardResponse "Webhook not found"
+// @Failure      500 {object} response.StandardResponse "Internal server error"
+// @Security     BearerAuth
+// @Router       /webhooks/{webhook_id}/deliveries [get]
+func (h *webhookHandler) ListDeliveries(c echo.Context) error {
+	webhookID, err := uuid.Parse(c.Param("webhook_id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(apperrors.ErrInvalidWebhookID))
+	}
+
+	pagination, err := cursorPaginationFromQuery(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(err))
+	}
+	userID := c.Get("user_id").(uuid.UUID)
+	ctx := c.Request().Context()
+
+	deliveries, err := h.webhookService.ListDeliveries(ctx, webhookID, userID, pagination)
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return c.JSON(http.StatusNotFound, h.responseBuilder.Error(apperrors.ErrNotFound))
+		case errors.Is(err, apperrors.ErrInvalidCursorPagination):
+			return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(err))
+		default:
+			return c.JSON(http.StatusInternalServerError, h.responseBuilder.Error(err))
+		}
+	}
+
+	return c.JSON(http.StatusOK, h.responseBuilder.Success(constants.WebhookDeliveriesRetrievedSuccessfully, deliveries))
+}
+
+// @Summary      Send Test Event
+// @Description  Queues a webhook.test event for the webhook, regardless of its event type filter. Its progress shows up in the delivery log.
+// @Tags         Webhooks
+// @Produce      json
+// @Param        webhook_id path string true "Webhook ID"
+// @Success      202 {object} response.StandardResponse{data=dto.WebhookDeliveryResponse} "Webhook test event queued"
+// @Failure      400 {object} response.StandardResponse "Invalid webhook ID"
+// @Failure      401 {object} response.StandardResponse "Unauthorized"
+// @Failure      404 {object} response.StandardResponse "Webhook not found"
+// @Failure      500 {object} response.StandardResponse "Internal server error"
+// @Security     BearerAuth
+// @Router       /webhooks/{webhook_id}/test [post]
+func (h *webhookHandler) SendTestEvent(c echo.Context) error {
+	webhookID, err := uuid.Parse(c.Param("webhook_id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, h.responseBuilder.Error(apperrors.ErrInvalidWebhookID))
+	}
+
+	userID := c.Get("user_id").(uuid.UUID)
+	ctx := c.Request().Context()
+
+	delivery, err := h.webhookService.SendTestEvent(ctx, webhookID, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.JSON(http.StatusNotFound, h.responseBuilder.Error(apperrors.ErrNotFound))
+		}
+		return c.JSON(http.StatusInternalServerError, h.responseBuilder.Error(err))
+	}
+
+	return c.JSON(http.StatusAccepted, h.responseBuilder.Success(constants.WebhookTestEventQueued, delivery))
+}
@@ -0,0 +1,66 @@
+package etag
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/labstack/echo/v4"
+)
+
+// Format renders a resource version as a strong entity tag.
+func Format(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// IfMatchVersion returns the version the client expects to modify, taken from the If-Match header.
+func IfMatchVersion(c echo.Context) (int64, error) {
+	header := strings.TrimSpace(c.Request().Header.Get(constants.IfMatchHeader))
+	if header == "" {
+		return 0, apperrors.ErrIfMatchRequired
+	}
+
+	version, ok := parse(header)
+	if !ok {
+		return 0, apperrors.ErrInvalidIfMatch
+	}
+	return version, nil
+}
+
+// NotModified reports whether the If-None-Match header already covers the given version.
+func NotModified(c echo.Context, version int64) bool {
+	header := c.Request().Header.Get(constants.IfNoneMatchHeader)
+	if header == "" {
+		return false
+	}
+
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" {
+			return true
+		}
+		if v, ok := parse(candidate); ok && v == version {
+			return true
+		}
+	}
+	return false
+}
+
+// SetHeader writes the ETag header for the given version.
+func SetHeader(c echo.Context, version int64) {
+	c.Response().Header().Set(constants.ETagHeader, Format(version))
+}
+
+func parse(tag string) (int64, bool) {
+	tag = strings.TrimPrefix(tag, "W/")
+	if len(tag) < 2 || tag[0] != '"' || tag[len(tag)-1] != '"' {
+		return 0, false
+	}
+
+	version, err := strconv.ParseInt(tag[1:len(tag)-1], 10, 64)
+	if err != nil || version < 1 {
+		return 0, false
+	}
+	return version, true
+}
@@ -0,0 +1,81 @@
+package etag
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+)
+
+func newContext(headers map[string]string) echo.Context {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	return echo.New().NewContext(req, httptest.NewRecorder())
+}
+
+func TestFormat(t *testing.T) {
+	require.Equal(t, `"3"`, Format(3))
+}
+
+func TestIfMatchVersion(t *testing.T) {
+	testCases := []struct {
+		name            string
+		header          string
+		expectedVersion int64
+		expectedError   error
+	}{
+		{name: "strong tag", header: `"4"`, expectedVersion: 4},
+		{name: "weak tag", header: `W/"7"`, expectedVersion: 7},
+		{name: "missing header", header: "", expectedError: apperrors.ErrIfMatchRequired},
+		{name: "wildcard is not supported", header: "*", expectedError: apperrors.ErrInvalidIfMatch},
+		{name: "unquoted", header: "4", expectedError: apperrors.ErrInvalidIfMatch},
+		{name: "not a number", header: `"abc"`, expectedError: apperrors.ErrInvalidIfMatch},
+		{name: "zero version", header: `"0"`, expectedError: apperrors.ErrInvalidIfMatch},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := newContext(map[string]string{"If-Match": tc.header})
+			version, err := IfMatchVersion(c)
+			if tc.expectedError != nil {
+				require.ErrorIs(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedVersion, version)
+		})
+	}
+}
+
+func TestNotModified(t *testing.T) {
+	testCases := []struct {
+		name     string
+		header   string
+		expected bool
+	}{
+		{name: "no header", header: "", expected: false},
+		{name: "matching tag", header: `"2"`, expected: true},
+		{name: "matching tag in list", header: `"1", W/"2"`, expected: true},
+		{name: "wildcard", header: "*", expected: true},
+		{name: "stale tag", header: `"1"`, expected: false},
+		{name: "malformed tag", header: "2", expected: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := newContext(map[string]string{"If-None-Match": tc.header})
+			require.Equal(t, tc.expected, NotModified(c, 2))
+		})
+	}
+}
+
+func TestSetHeader(t *testing.T) {
+	c := newContext(nil)
+	SetHeader(c, 5)
+	require.Equal(t, `"5"`, c.Response().Header().Get("ETag"))
+}
@@ -1,8 +1,13 @@
 package request
 
 import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime"
 	"net/http"
 
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
 	"github.com/labstack/echo/v4"
 )
 
@@ -19,3 +24,28 @@ func BindAndValidate[T any](c echo.Context) (*T, error) {
 
 	return &req, nil
 }
+
+// BindMergePatch decodes a JSON Merge Patch (RFC 7396) body.
+func BindMergePatch[T any](c echo.Context) (*T, error) {
+	mediaType, _, err := mime.ParseMediaType(c.Request().Header.Get(echo.HeaderContentType))
+	if err != nil || (mediaType != constants.MIMEApplicationMergePatch && mediaType != echo.MIMEApplicationJSON) {
+		return nil, echo.ErrUnsupportedMediaType
+	}
+
+	body, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 || trimmed[0] != '{' {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, "merge patch document must be a JSON object")
+	}
+
+	var req T
+	if err := json.Unmarshal(trimmed, &req); err != nil {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	return &req, nil
+}
@@ -98,3 +98,72 @@ type CustomValidator struct {
 func (cv *CustomValidator) Validate(i interface{}) error {
 	return cv.validator.Struct(i)
 }
+
+type testPatchRequest struct {
+	Name  *string `json:"name"`
+	Email *string `json:"email"`
+}
+
+func TestBindMergePatch(t *testing.T) {
+	testCases := []struct {
+		name         string
+		body         string
+		contentType  string
+		expectStatus int
+		expectedName string
+	}{
+		{
+			name:         "success: merge patch content type",
+			body:         `{"name": "John Doe"}`,
+			contentType:  "application/merge-patch+json",
+			expectedName: "John Doe",
+		},
+		{
+			name:         "success: plain json with charset",
+			body:         `{"name": "John Doe"}`,
+			contentType:  "application/json; charset=utf-8",
+			expectedName: "John Doe",
+		},
+		{
+			name:         "error: unsupported content type",
+			body:         `name=John`,
+			contentType:  echo.MIMEApplicationForm,
+			expectStatus: http.StatusUnsupportedMediaType,
+		},
+		{
+			name:         "error: document is not an object",
+			body:         `null`,
+			contentType:  "application/merge-patch+json",
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "error: malformed json",
+			body:         `{"name": }`,
+			contentType:  "application/merge-patch+json",
+			expectStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPatch, "/", strings.NewReader(tc.body))
+			req.Header.Set(echo.HeaderContentType, tc.contentType)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			result, err := BindMergePatch[testPatchRequest](c)
+
+			if tc.expectStatus != 0 {
+				var httpErr *echo.HTTPError
+				require.ErrorAs(t, err, &httpErr)
+				require.Equal(t, tc.expectStatus, httpErr.Code)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, result.Name)
+			require.Equal(t, tc.expectedName, *result.Name)
+			require.Nil(t, result.Email)
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants/logmsg"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/services"
+)
+
+// DataExportJob periodically builds requested data exports and removes the
+// ones past their retention.
+type DataExportJob struct {
+	exportService services.DataExportService
+	interval      time.Duration
+	cancel        context.CancelFunc
+	wg            sync.WaitGroup
+}
+
+func NewDataExportJob(exportService services.DataExportService, interval time.Duration) *DataExportJob {
+	return &DataExportJob{
+		exportService: exportService,
+		interval:      interval,
+	}
+}
+
+func (j *DataExportJob) Start(ctx context.Context) {
+	ctx, j.cancel = context.WithCancel(ctx)
+	j.wg.Add(1)
+
+	go func() {
+		defer j.wg.Done()
+
+		ticker := time.NewTicker(j.interval)
+		defer ticker.Stop()
+
+		for {
+			j.RunOnce(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// RunOnce builds pending exports, then removes expired ones.
+func (j *DataExportJob) RunOnce(ctx context.Context) {
+	built, failed, err := j.exportService.ProcessPending(ctx)
+	if err != nil {
+		log.Printf(logmsg.DataExportProcessFailed, err)
+	} else if built > 0 || failed > 0 {
+		log.Printf(logmsg.DataExportProcessCompleted, built, failed)
+	}
+
+	deleted, err := j.exportService.PurgeExpired(ctx)
+	if err != nil {
+		log.Printf(logmsg.DataExportPurgeFailed, err)
+		return
+	}
+	if deleted > 0 {
+		log.Printf(logmsg.DataExportPurgeCompleted, deleted)
+	}
+}
+
+func (j *DataExportJob) Stop() {
+	if j.cancel != nil {
+		j.cancel()
+	}
+	j.wg.Wait()
+}
@@ -0,0 +1,66 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants/logmsg"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/services"
+)
+
+// IdempotencyCleanupJob periodically removes idempotency keys whose TTL has passed.
+type IdempotencyCleanupJob struct {
+	idempotencyService services.IdempotencyService
+	interval           time.Duration
+	cancel             context.CancelFunc
+	wg                 sync.WaitGroup
+}
+
+func NewIdempotencyCleanupJob(idempotencyService services.IdempotencyService, interval time.Duration) *IdempotencyCleanupJob {
+	return &IdempotencyCleanupJob{
+		idempotencyService: idempotencyService,
+		interval:           interval,
+	}
+}
+
+func (j *IdempotencyCleanupJob) Start(ctx context.Context) {
+	ctx, j.cancel = context.WithCancel(ctx)
+	j.wg.Add(1)
+
+	go func() {
+		defer j.wg.Done()
+
+		ticker := time.NewTicker(j.interval)
+		defer ticker.Stop()
+
+		for {
+			j.RunOnce(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (j *IdempotencyCleanupJob) RunOnce(ctx context.Context) {
+	deleted, err := j.idempotencyService.PurgeExpired(ctx)
+	if err != nil {
+		log.Printf(logmsg.IdempotencyCleanupFailed, err)
+		return
+	}
+	if deleted > 0 {
+		log.Printf(logmsg.IdempotencyCleanupCompleted, deleted)
+	}
+}
+
+func (j *IdempotencyCleanupJob) Stop() {
+	if j.cancel != nil {
+		j.cancel()
+	}
+	j.wg.Wait()
+}
@@ -0,0 +1,83 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants/logmsg"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/services"
+)
+
+// ReminderJob periodically schedules reminders that have come due and sends
+// them, along with the queued notification emails.
+type ReminderJob struct {
+	reminderService services.ReminderService
+	interval        time.Duration
+	cancel          context.CancelFunc
+	wg              sync.WaitGroup
+}
+
+func NewReminderJob(reminderService services.ReminderService, interval time.Duration) *ReminderJob {
+	return &ReminderJob{
+		reminderService: reminderService,
+		interval:        interval,
+	}
+}
+
+func (j *ReminderJob) Start(ctx context.Context) {
+	ctx, j.cancel = context.WithCancel(ctx)
+	j.wg.Add(1)
+
+	go func() {
+		defer j.wg.Done()
+
+		ticker := time.NewTicker(j.interval)
+		defer ticker.Stop()
+
+		for {
+			j.RunOnce(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// RunOnce schedules before sending so a reminder that became due in this tick goes
+// out without waiting for the next one.
+func (j *ReminderJob) RunOnce(ctx context.Context) {
+	scheduled, err := j.reminderService.ScheduleDue(ctx)
+	if err != nil {
+		log.Printf(logmsg.ReminderScheduleFailed, err)
+	} else if scheduled > 0 {
+		log.Printf(logmsg.ReminderScheduled, scheduled)
+	}
+
+	sent, failed, err := j.reminderService.SendDue(ctx)
+	if err != nil {
+		log.Printf(logmsg.ReminderSendFailed, err)
+	} else if sent > 0 || failed > 0 {
+		log.Printf(logmsg.ReminderSendCompleted, sent, failed)
+	}
+
+	sent, failed, err = j.reminderService.SendQueuedEmails(ctx)
+	if err != nil {
+		log.Printf(logmsg.NotificationEmailSendFailed, err)
+		return
+	}
+	if sent > 0 || failed > 0 {
+		log.Printf(logmsg.NotificationEmailSendCompleted, sent, failed)
+	}
+}
+
+func (j *ReminderJob) Stop() {
+	if j.cancel != nil {
+		j.cancel()
+	}
+	j.wg.Wait()
+}
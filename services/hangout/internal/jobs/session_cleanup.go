@@ -0,0 +1,66 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants/logmsg"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/services"
+)
+
+// SessionCleanupJob periodically removes sessions whose tokens have expired.
+type SessionCleanupJob struct {
+	sessionService services.SessionService
+	interval       time.Duration
+	cancel         context.CancelFunc
+	wg             sync.WaitGroup
+}
+
+func NewSessionCleanupJob(sessionService services.SessionService, interval time.Duration) *SessionCleanupJob {
+	return &SessionCleanupJob{
+		sessionService: sessionService,
+		interval:       interval,
+	}
+}
+
+func (j *SessionCleanupJob) Start(ctx context.Context) {
+	ctx, j.cancel = context.WithCancel(ctx)
+	j.wg.Add(1)
+
+	go func() {
+		defer j.wg.Done()
+
+		ticker := time.NewTicker(j.interval)
+		defer ticker.Stop()
+
+		for {
+			j.RunOnce(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (j *SessionCleanupJob) RunOnce(ctx context.Context) {
+	deleted, err := j.sessionService.PurgeExpired(ctx)
+	if err != nil {
+		log.Printf(logmsg.SessionCleanupFailed, err)
+		return
+	}
+	if deleted > 0 {
+		log.Printf(logmsg.SessionCleanupCompleted, deleted)
+	}
+}
+
+func (j *SessionCleanupJob) Stop() {
+	if j.cancel != nil {
+		j.cancel()
+	}
+	j.wg.Wait()
+}
@@ -0,0 +1,66 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants/logmsg"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/services"
+)
+
+// SigningKeyRotationJob rotates the JWT signing keys when they are due and
+// reloads the key ring, picking up keys created by other instances.
+type SigningKeyRotationJob struct {
+	signingKeyService services.SigningKeyService
+	interval          time.Duration
+	cancel            context.CancelFunc
+	wg                sync.WaitGroup
+}
+
+func NewSigningKeyRotationJob(signingKeyService services.SigningKeyService, interval time.Duration) *SigningKeyRotationJob {
+	return &SigningKeyRotationJob{
+		signingKeyService: signingKeyService,
+		interval:          interval,
+	}
+}
+
+func (j *SigningKeyRotationJob) Start(ctx context.Context) {
+	ctx, j.cancel = context.WithCancel(ctx)
+	j.wg.Add(1)
+
+	go func() {
+		defer j.wg.Done()
+
+		ticker := time.NewTicker(j.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				j.RunOnce(ctx)
+			}
+		}
+	}()
+}
+
+func (j *SigningKeyRotationJob) RunOnce(ctx context.Context) {
+	created, err := j.signingKeyService.Rotate(ctx)
+	if err != nil {
+		log.Printf(logmsg.SigningKeyRotationFailed, err)
+		return
+	}
+	if created != nil {
+		log.Printf(logmsg.SigningKeyCreated, created.ID, created.Algorithm, created.ActivatesAt.Format(time.RFC3339))
+	}
+}
+
+func (j *SigningKeyRotationJob) Stop() {
+	if j.cancel != nil {
+		j.cancel()
+	}
+	j.wg.Wait()
+}
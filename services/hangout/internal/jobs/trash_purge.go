@@ -0,0 +1,66 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants/logmsg"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/services"
+)
+
+// TrashPurgeJob periodically purges hangouts and memories whose trash retention has expired.
+type TrashPurgeJob struct {
+	trashService services.TrashService
+	interval     time.Duration
+	cancel       context.CancelFunc
+	wg           sync.WaitGroup
+}
+
+func NewTrashPurgeJob(trashService services.TrashService, interval time.Duration) *TrashPurgeJob {
+	return &TrashPurgeJob{
+		trashService: trashService,
+		interval:     interval,
+	}
+}
+
+func (j *TrashPurgeJob) Start(ctx context.Context) {
+	ctx, j.cancel = context.WithCancel(ctx)
+	j.wg.Add(1)
+
+	go func() {
+		defer j.wg.Done()
+
+		ticker := time.NewTicker(j.interval)
+		defer ticker.Stop()
+
+		for {
+			j.RunOnce(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (j *TrashPurgeJob) RunOnce(ctx context.Context) {
+	memoriesPurged, hangoutsPurged, err := j.trashService.PurgeExpired(ctx)
+	if err != nil {
+		log.Printf(logmsg.TrashPurgeFailed, err)
+		return
+	}
+	if memoriesPurged > 0 || hangoutsPurged > 0 {
+		log.Printf(logmsg.TrashPurgeCompleted, memoriesPurged, hangoutsPurged)
+	}
+}
+
+func (j *TrashPurgeJob) Stop() {
+	if j.cancel != nil {
+		j.cancel()
+	}
+	j.wg.Wait()
+}
@@ -0,0 +1,66 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants/logmsg"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/services"
+)
+
+// WebhookDeliveryJob periodically sends webhook deliveries that are due.
+type WebhookDeliveryJob struct {
+	webhookService services.WebhookService
+	interval       time.Duration
+	cancel         context.CancelFunc
+	wg             sync.WaitGroup
+}
+
+func NewWebhookDeliveryJob(webhookService services.WebhookService, interval time.Duration) *WebhookDeliveryJob {
+	return &WebhookDeliveryJob{
+		webhookService: webhookService,
+		interval:       interval,
+	}
+}
+
+func (j *WebhookDeliveryJob) Start(ctx context.Context) {
+	ctx, j.cancel = context.WithCancel(ctx)
+	j.wg.Add(1)
+
+	go func() {
+		defer j.wg.Done()
+
+		ticker := time.NewTicker(j.interval)
+		defer ticker.Stop()
+
+		for {
+			j.RunOnce(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (j *WebhookDeliveryJob) RunOnce(ctx context.Context) {
+	succeeded, failed, err := j.webhookService.DeliverDue(ctx)
+	if err != nil {
+		log.Printf(logmsg.WebhookDeliveryFailed, err)
+		return
+	}
+	if succeeded > 0 || failed > 0 {
+		log.Printf(logmsg.WebhookDeliveryCompleted, succeeded, failed)
+	}
+}
+
+func (j *WebhookDeliveryJob) Stop() {
+	if j.cancel != nil {
+		j.cancel()
+	}
+	j.wg.Wait()
+}
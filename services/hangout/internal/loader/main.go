@@ -13,7 +13,30 @@ func main() {
 		&domain.User{},
 		&domain.Hangout{},
 		&domain.Activity{},
+		&domain.Album{},
 		&domain.Memory{},
+		&domain.MemoryTag{},
+		&domain.MemoryPersonTag{},
+		&domain.MemoryReaction{},
+		&domain.IdempotencyKey{},
+		&domain.WebhookSubscription{},
+		&domain.WebhookDelivery{},
+		&domain.Reminder{},
+		&domain.Notification{},
+		&domain.NotificationPreference{},
+		&domain.NotificationEmail{},
+		&domain.Comment{},
+		&domain.CommentMention{},
+		&domain.ShareLink{},
+		&domain.ShareLinkAccess{},
+		&domain.SigningKey{},
+		&domain.UserIdentity{},
+		&domain.TOTPCredential{},
+		&domain.RecoveryCode{},
+		&domain.Session{},
+		&domain.PersonalAccessToken{},
+		&domain.DataExport{},
+		&domain.AdminAuditLog{},
 	)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "failed to load gorm schema: %v\n", err)
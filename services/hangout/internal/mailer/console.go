@@ -0,0 +1,30 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/mail"
+	"sync"
+)
+
+type consoleSender struct {
+	from string
+	mu   sync.Mutex
+	w    io.Writer
+}
+
+// NewConsoleSender prints each message to w instead of sending it.
+func NewConsoleSender(from string, w io.Writer) Sender {
+	return &consoleSender{from: from, w: w}
+}
+
+func (s *consoleSender) Send(ctx context.Context, msg *Message) error {
+	to := &mail.Address{Name: msg.ToName, Address: msg.ToEmail}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err := fmt.Fprintf(s.w, "----- mail -----\nFrom: %s\nTo: %s\nSubject: %s\n\n%s\n----- end mail -----\n",
+		s.from, to.String(), msg.Subject, msg.Body)
+	return err
+}
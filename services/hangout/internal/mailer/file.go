@@ -0,0 +1,38 @@
+package mailer
+
+import (
+	"context"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type fileSender struct {
+	from string
+	dir  string
+}
+
+// NewFileSender writes each message to its own .eml file in dir, which most
+// mail clients can open. The directory is created on first use.
+func NewFileSender(from string, dir string) Sender {
+	return &fileSender{from: from, dir: dir}
+}
+
+func (s *fileSender) Send(ctx context.Context, msg *Message) error {
+	from, err := mail.ParseAddress(s.from)
+	if err != nil {
+		return err
+	}
+	to := &mail.Address{Name: msg.ToName, Address: msg.ToEmail}
+
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	name := now.UTC().Format("20060102T150405.000000000Z") + "-" + uuid.NewString() + ".eml"
+	return os.WriteFile(filepath.Join(s.dir, name), buildEmail(from, to, msg.Subject, msg.Body, now), 0o644)
+}
@@ -0,0 +1,38 @@
+// Package mailer delivers outgoing email.
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/config"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+)
+
+// Message is a plain text email to a single recipient.
+type Message struct {
+	ToName  string
+	ToEmail string
+	Subject string
+	Body    string
+}
+
+type Sender interface {
+	Send(ctx context.Context, msg *Message) error
+}
+
+// NewSender returns the sender selected by cfg.Driver.
+func NewSender(cfg *config.MailConfig, smtpCfg *config.SMTPConfig) (Sender, error) {
+	switch cfg.Driver {
+	case constants.MailDriverSMTP:
+		return NewSMTPSender(smtpCfg), nil
+	case constants.MailDriverConsole:
+		return NewConsoleSender(smtpCfg.From, os.Stdout), nil
+	case constants.MailDriverFile:
+		return NewFileSender(smtpCfg.From, cfg.FileDir), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", apperrors.ErrUnknownMailDriver, cfg.Driver)
+	}
+}
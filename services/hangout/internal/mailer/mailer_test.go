@@ -0,0 +1,180 @@
+package mailer_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/config"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/mailer"
+	"github.com/stretchr/testify/require"
+)
+
+const testFrom = "Hangout Planner <no-reply@hangout.local>"
+
+// smtpSink accepts one SMTP session and returns the commands and message data
+// it received.
+func smtpSink(t *testing.T) (string, string, <-chan []string) {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+
+	received := make(chan []string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var lines []string
+		reader := bufio.NewReader(conn)
+		reply := func(s string) { _, _ = conn.Write([]byte(s + "\r\n")) }
+		reply("220 sink ready")
+		inData := false
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				break
+			}
+			line = strings.TrimRight(line, "\r\n")
+			lines = append(lines, line)
+			switch {
+			case inData && line == ".":
+				inData = false
+				reply("250 queued")
+			case inData:
+			case strings.HasPrefix(line, "EHLO"):
+				reply("250 sink")
+			case strings.HasPrefix(line, "DATA"):
+				inData = true
+				reply("354 go ahead")
+			case strings.HasPrefix(line, "QUIT"):
+				reply("221 bye")
+				received <- lines
+				return
+			default:
+				reply("250 ok")
+			}
+		}
+		received <- lines
+	}()
+
+	host, port, err := net.SplitHostPort(listener.Addr().String())
+	require.NoError(t, err)
+	return host, port, received
+}
+
+func TestSMTPSender_Send(t *testing.T) {
+	host, port, received := smtpSink(t)
+	sender := mailer.NewSMTPSender(&config.SMTPConfig{
+		Host:           host,
+		Port:           port,
+		From:           testFrom,
+		TimeoutSeconds: 5,
+	})
+
+	err := sender.Send(context.Background(), &mailer.Message{
+		ToName:  "Ann",
+		ToEmail: "ann@example.com",
+		Subject: "Reminder: Café night\r\nBcc: evil@example.com",
+		Body:    "Café night starts soon.",
+	})
+	require.NoError(t, err)
+
+	var lines []string
+	select {
+	case lines = <-received:
+	case <-time.After(5 * time.Second):
+		t.Fatal("smtp sink did not receive the message")
+	}
+	session := strings.Join(lines, "\n")
+	require.Contains(t, session, "MAIL FROM:<no-reply@hangout.local>")
+	require.Contains(t, session, "RCPT TO:<ann@example.com>")
+	require.Contains(t, session, `To: "Ann" <ann@example.com>`)
+	require.Contains(t, session, "Subject: =?utf-8?q?")
+	require.Contains(t, session, "Caf=C3=A9 night starts soon.")
+	require.NotContains(t, session, "\nBcc:")
+}
+
+func TestSMTPSender_SendErrors(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	host, port, _ := net.SplitHostPort(listener.Addr().String())
+	require.NoError(t, listener.Close())
+
+	tests := []struct {
+		name string
+		cfg  *config.SMTPConfig
+	}{
+		{name: "invalid sender", cfg: &config.SMTPConfig{Host: host, Port: port, From: "not an address", TimeoutSeconds: 1}},
+		{name: "server unreachable", cfg: &config.SMTPConfig{Host: host, Port: port, From: "a@example.com", TimeoutSeconds: 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := mailer.NewSMTPSender(tt.cfg).Send(context.Background(), &mailer.Message{ToEmail: "ann@example.com"})
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestNewSender(t *testing.T) {
+	smtpCfg := &config.SMTPConfig{From: testFrom}
+
+	for _, driver := range []string{constants.MailDriverSMTP, constants.MailDriverConsole, constants.MailDriverFile} {
+		sender, err := mailer.NewSender(&config.MailConfig{Driver: driver, FileDir: t.TempDir()}, smtpCfg)
+		require.NoError(t, err, driver)
+		require.NotNil(t, sender, driver)
+	}
+
+	sender, err := mailer.NewSender(&config.MailConfig{Driver: "carrier-pigeon"}, smtpCfg)
+	require.ErrorIs(t, err, apperrors.ErrUnknownMailDriver)
+	require.Nil(t, sender)
+}
+
+func TestConsoleSender_Send(t *testing.T) {
+	var out bytes.Buffer
+	sender := mailer.NewConsoleSender(testFrom, &out)
+
+	err := sender.Send(context.Background(), &mailer.Message{
+		ToName:  "Ann",
+		ToEmail: "ann@example.com",
+		Subject: "Verify your email",
+		Body:    "Open https://app.example.com/verify?token=abc to verify.",
+	})
+	require.NoError(t, err)
+	require.Contains(t, out.String(), `To: "Ann" <ann@example.com>`)
+	require.Contains(t, out.String(), "Subject: Verify your email")
+	require.Contains(t, out.String(), "https://app.example.com/verify?token=abc")
+}
+
+func TestFileSender_Send(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "outbox")
+	sender := mailer.NewFileSender(testFrom, dir)
+	msg := &mailer.Message{ToName: "Ann", ToEmail: "ann@example.com", Subject: "Reset your password", Body: "Café"}
+
+	require.NoError(t, sender.Send(context.Background(), msg))
+	require.NoError(t, sender.Send(context.Background(), msg))
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+
+	content, err := os.ReadFile(files[0])
+	require.NoError(t, err)
+	require.Contains(t, string(content), `To: "Ann" <ann@example.com>`)
+	require.Contains(t, string(content), "Caf=C3=A9")
+
+	err = mailer.NewFileSender("not an address", dir).Send(context.Background(), msg)
+	require.Error(t, err)
+}
@@ -0,0 +1,95 @@
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/config"
+)
+
+type smtpSender struct {
+	cfg *config.SMTPConfig
+}
+
+// NewSMTPSender sends mail through the configured SMTP server.
+func NewSMTPSender(cfg *config.SMTPConfig) Sender {
+	return &smtpSender{cfg: cfg}
+}
+
+func (s *smtpSender) Send(ctx context.Context, msg *Message) error {
+	from, err := mail.ParseAddress(s.cfg.From)
+	if err != nil {
+		return err
+	}
+	to := &mail.Address{Name: msg.ToName, Address: msg.ToEmail}
+
+	dialer := net.Dialer{Timeout: s.cfg.GetTimeout()}
+	conn, err := dialer.DialContext(ctx, "tcp", s.cfg.GetAddress())
+	if err != nil {
+		return err
+	}
+	if err := conn.SetDeadline(time.Now().Add(s.cfg.GetTimeout())); err != nil {
+		_ = conn.Close()
+		return err
+	}
+
+	client, err := smtp.NewClient(conn, s.cfg.Host)
+	if err != nil {
+		_ = conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: s.cfg.Host}); err != nil {
+			return err
+		}
+	}
+	if s.cfg.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", s.cfg.Username, s.cfg.Password, s.cfg.Host)); err != nil {
+			return err
+		}
+	}
+
+	if err := client.Mail(from.Address); err != nil {
+		return err
+	}
+	if err := client.Rcpt(to.Address); err != nil {
+		return err
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(buildEmail(from, to, msg.Subject, msg.Body, time.Now())); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+func buildEmail(from *mail.Address, to *mail.Address, subject string, body string, date time.Time) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("From: " + from.String() + "\r\n")
+	buf.WriteString("To: " + to.String() + "\r\n")
+	buf.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", subject) + "\r\n")
+	buf.WriteString("Date: " + date.Format(time.RFC1123Z) + "\r\n")
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: quoted-printable\r\n")
+	buf.WriteString("\r\n")
+
+	qp := quotedprintable.NewWriter(&buf)
+	_, _ = qp.Write([]byte(body))
+	_ = qp.Close()
+	return buf.Bytes()
+}
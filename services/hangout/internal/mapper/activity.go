@@ -16,6 +16,7 @@ func ActivitytoDetailResponseDTO(activity *domain.Activity, hangoutCount int64)
 		Name:         activity.Name,
 		HangoutCount: hangoutCount,
 		CreatedAt:    types.JSONTime(activity.CreatedAt),
+		Version:      activity.Version,
 	}
 }
 func ActivityToListItemResponseDTO(activities []repository.ActivityWithCount) []dto.ActivityListItemResponse {
@@ -0,0 +1,57 @@
+package mapper
+
+import (
+	"github.com/Ernestgio/Hangout-Planner/pkg/shared/types"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
+)
+
+func UserToAdminResponseDTO(user *domain.User) *dto.AdminUserResponse {
+	if user == nil {
+		return nil
+	}
+
+	return &dto.AdminUserResponse{
+		ID:            user.ID,
+		Name:          user.Name,
+		Email:         user.Email,
+		EmailVerified: user.EmailVerifiedAt != nil,
+		Role:          user.Role,
+		Disabled:      user.DisabledAt != nil,
+		DisabledAt:    optionalJSONTime(user.DisabledAt),
+		CreatedAt:     types.JSONTime(user.CreatedAt),
+	}
+}
+
+func UsersToAdminResponseDTOs(users []domain.User) []*dto.AdminUserResponse {
+	responses := make([]*dto.AdminUserResponse, len(users))
+	for i := range users {
+		responses[i] = UserToAdminResponseDTO(&users[i])
+	}
+	return responses
+}
+
+func AdminAuditLogToResponseDTO(entry *domain.AdminAuditLog) *dto.AdminAuditLogResponse {
+	if entry == nil {
+		return nil
+	}
+
+	return &dto.AdminAuditLogResponse{
+		ID:         entry.ID,
+		ActorID:    entry.ActorID,
+		ActorEmail: entry.Actor.Email,
+		Action:     entry.Action,
+		TargetType: entry.TargetType,
+		Target:     entry.Target,
+		Reason:     entry.Reason,
+		CreatedAt:  types.JSONTime(entry.CreatedAt),
+	}
+}
+
+func AdminAuditLogsToResponseDTOs(entries []domain.AdminAuditLog) []*dto.AdminAuditLogResponse {
+	responses := make([]*dto.AdminAuditLogResponse, len(entries))
+	for i := range entries {
+		responses[i] = AdminAuditLogToResponseDTO(&entries[i])
+	}
+	return responses
+}
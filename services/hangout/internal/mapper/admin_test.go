@@ -0,0 +1,117 @@
+package mapper_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/pkg/shared/types"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/mapper"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserToAdminResponseDTO(t *testing.T) {
+	createdAt := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	disabledAt := createdAt.Add(time.Hour)
+
+	testCases := []struct {
+		name     string
+		user     *domain.User
+		wantNil  bool
+		disabled bool
+	}{
+		{name: "nil input", user: nil, wantNil: true},
+		{
+			name: "active user",
+			user: &domain.User{ID: uuid.New(), Name: "Ernest", Email: "ernest@example.com", Role: constants.RoleUser, EmailVerifiedAt: &createdAt, CreatedAt: createdAt},
+		},
+		{
+			name:     "disabled admin",
+			user:     &domain.User{ID: uuid.New(), Name: "Admin", Email: "admin@example.com", Role: constants.RoleAdmin, DisabledAt: &disabledAt, CreatedAt: createdAt},
+			disabled: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := mapper.UserToAdminResponseDTO(tc.user)
+			if tc.wantNil {
+				require.Nil(t, got)
+				return
+			}
+			require.Equal(t, tc.user.ID, got.ID)
+			require.Equal(t, tc.user.Email, got.Email)
+			require.Equal(t, tc.user.Role, got.Role)
+			require.Equal(t, tc.user.EmailVerifiedAt != nil, got.EmailVerified)
+			require.Equal(t, tc.disabled, got.Disabled)
+			require.Equal(t, types.JSONTime(createdAt), got.CreatedAt)
+			if tc.disabled {
+				require.Equal(t, types.JSONTime(disabledAt), got.DisabledAt)
+			} else {
+				require.Equal(t, types.JSONTime{}, got.DisabledAt)
+			}
+		})
+	}
+}
+
+func TestUsersToAdminResponseDTOs(t *testing.T) {
+	users := []domain.User{{ID: uuid.New()}, {ID: uuid.New()}}
+
+	got := mapper.UsersToAdminResponseDTOs(users)
+	require.Len(t, got, 2)
+	require.Equal(t, users[1].ID, got[1].ID)
+}
+
+func TestAdminAuditLogToResponseDTO(t *testing.T) {
+	createdAt := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	actorID := uuid.New()
+
+	testCases := []struct {
+		name    string
+		entry   *domain.AdminAuditLog
+		wantNil bool
+	}{
+		{name: "nil input", entry: nil, wantNil: true},
+		{
+			name: "entry with actor",
+			entry: &domain.AdminAuditLog{
+				ID:         uuid.New(),
+				ActorID:    actorID,
+				Actor:      domain.User{ID: actorID, Email: "admin@example.com"},
+				Action:     constants.AuditActionHideMemory,
+				TargetType: constants.AuditTargetMemory,
+				Target:     uuid.NewString(),
+				Reason:     "reported as spam",
+				CreatedAt:  createdAt,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := mapper.AdminAuditLogToResponseDTO(tc.entry)
+			if tc.wantNil {
+				require.Nil(t, got)
+				return
+			}
+			require.Equal(t, tc.entry.ID, got.ID)
+			require.Equal(t, actorID, got.ActorID)
+			require.Equal(t, "admin@example.com", got.ActorEmail)
+			require.Equal(t, tc.entry.Action, got.Action)
+			require.Equal(t, tc.entry.TargetType, got.TargetType)
+			require.Equal(t, tc.entry.Target, got.Target)
+			require.Equal(t, tc.entry.Reason, got.Reason)
+			require.Equal(t, types.JSONTime(createdAt), got.CreatedAt)
+		})
+	}
+}
+
+func TestAdminAuditLogsToResponseDTOs(t *testing.T) {
+	entries := []domain.AdminAuditLog{{ID: uuid.New()}, {ID: uuid.New()}}
+
+	got := mapper.AdminAuditLogsToResponseDTOs(entries)
+	require.Len(t, got, 2)
+	require.Equal(t, entries[0].ID, got[0].ID)
+}
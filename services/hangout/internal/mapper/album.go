@@ -0,0 +1,42 @@
+package mapper
+
+import (
+	"github.com/Ernestgio/Hangout-Planner/pkg/shared/types"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
+)
+
+func AlbumToResponseDTO(album *domain.Album) *dto.AlbumResponse {
+	if album == nil {
+		return nil
+	}
+
+	return &dto.AlbumResponse{
+		ID:            album.ID,
+		HangoutID:     album.HangoutID,
+		Name:          album.Name,
+		CoverMemoryID: album.CoverMemoryID,
+		CreatedAt:     types.JSONTime(album.CreatedAt),
+		UpdatedAt:     types.JSONTime(album.UpdatedAt),
+	}
+}
+
+func AlbumsToResponseDTOs(albums []domain.Album) []dto.AlbumResponse {
+	responses := make([]dto.AlbumResponse, len(albums))
+	for i := range albums {
+		responses[i] = *AlbumToResponseDTO(&albums[i])
+	}
+	return responses
+}
+
+func MemoryToPlacementDTO(memory *domain.Memory) *dto.MemoryPlacementResponse {
+	if memory == nil {
+		return nil
+	}
+
+	return &dto.MemoryPlacementResponse{
+		ID:       memory.ID,
+		AlbumID:  memory.AlbumID,
+		Position: memory.Position,
+	}
+}
@@ -0,0 +1,46 @@
+package mapper_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/pkg/shared/types"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/mapper"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlbumToResponseDTO(t *testing.T) {
+	require.Nil(t, mapper.AlbumToResponseDTO(nil))
+
+	coverID := uuid.New()
+	now := time.Date(2024, 12, 31, 23, 59, 59, 0, time.UTC)
+	album := &domain.Album{ID: uuid.New(), HangoutID: uuid.New(), Name: "Day 1", CoverMemoryID: &coverID, CreatedAt: now, UpdatedAt: now}
+
+	got := mapper.AlbumToResponseDTO(album)
+	require.Equal(t, album.ID, got.ID)
+	require.Equal(t, album.HangoutID, got.HangoutID)
+	require.Equal(t, "Day 1", got.Name)
+	require.Equal(t, &coverID, got.CoverMemoryID)
+	require.Equal(t, types.JSONTime(now), got.CreatedAt)
+}
+
+func TestAlbumsToResponseDTOs(t *testing.T) {
+	require.Empty(t, mapper.AlbumsToResponseDTOs(nil))
+
+	got := mapper.AlbumsToResponseDTOs([]domain.Album{{Name: "a"}, {Name: "b"}})
+	require.Len(t, got, 2)
+	require.Equal(t, "b", got[1].Name)
+}
+
+func TestMemoryToPlacementDTO(t *testing.T) {
+	require.Nil(t, mapper.MemoryToPlacementDTO(nil))
+
+	albumID := uuid.New()
+	memory := &domain.Memory{ID: uuid.New(), AlbumID: &albumID, Position: "a1"}
+	got := mapper.MemoryToPlacementDTO(memory)
+	require.Equal(t, memory.ID, got.ID)
+	require.Equal(t, &albumID, got.AlbumID)
+	require.Equal(t, "a1", got.Position)
+}
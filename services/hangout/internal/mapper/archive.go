@@ -0,0 +1,53 @@
+package mapper
+
+import (
+	"strings"
+
+	filepb "github.com/Ernestgio/Hangout-Planner/pkg/shared/proto/gen/go/file"
+	"github.com/Ernestgio/Hangout-Planner/pkg/shared/types"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func ArchiveToResponseDTO(archive *filepb.Archive) *dto.ArchiveResponse {
+	if archive == nil {
+		return nil
+	}
+
+	id, _ := uuid.Parse(archive.Id)
+	status := strings.ToLower(strings.TrimPrefix(archive.Status.String(), "ARCHIVE_STATUS_"))
+
+	progress := 0
+	if archive.TotalFiles > 0 {
+		progress = int(archive.ProcessedFiles * 100 / archive.TotalFiles)
+	}
+
+	res := &dto.ArchiveResponse{
+		ID:             id,
+		Status:         status,
+		TotalFiles:     int(archive.TotalFiles),
+		ProcessedFiles: int(archive.ProcessedFiles),
+		Progress:       progress,
+		ArchiveSize:    archive.ArchiveSize,
+		CreatedAt:      types.JSONTime(archive.CreatedAt.AsTime()),
+		CompletedAt:    timestampToJSONTime(archive.CompletedAt),
+		ExpiresAt:      timestampToJSONTime(archive.ExpiresAt),
+	}
+	if archive.DownloadUrl != "" {
+		res.DownloadURL = &archive.DownloadUrl
+		res.URLExpiresAt = &archive.UrlExpiresAt
+	}
+	if archive.Error != "" {
+		res.Error = &archive.Error
+	}
+	return res
+}
+
+func timestampToJSONTime(ts *timestamppb.Timestamp) *types.JSONTime {
+	if ts == nil {
+		return nil
+	}
+	t := types.JSONTime(ts.AsTime())
+	return &t
+}
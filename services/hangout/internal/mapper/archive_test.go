@@ -0,0 +1,71 @@
+package mapper_test
+
+import (
+	"testing"
+	"time"
+
+	filepb "github.com/Ernestgio/Hangout-Planner/pkg/shared/proto/gen/go/file"
+	"github.com/Ernestgio/Hangout-Planner/pkg/shared/types"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/mapper"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestArchiveToResponseDTO(t *testing.T) {
+	require.Nil(t, mapper.ArchiveToResponseDTO(nil))
+
+	id := uuid.New()
+	now := time.Date(2024, 12, 31, 23, 59, 59, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		archive      *filepb.Archive
+		wantStatus   string
+		wantProgress int
+		wantURL      bool
+		wantError    bool
+	}{
+		{
+			name:         "processing",
+			archive:      &filepb.Archive{Id: id.String(), Status: filepb.ArchiveStatus_ARCHIVE_STATUS_PROCESSING, TotalFiles: 3, ProcessedFiles: 1, CreatedAt: timestamppb.New(now)},
+			wantStatus:   "processing",
+			wantProgress: 33,
+		},
+		{
+			name: "completed",
+			archive: &filepb.Archive{
+				Id: id.String(), Status: filepb.ArchiveStatus_ARCHIVE_STATUS_COMPLETED, TotalFiles: 2, ProcessedFiles: 2, ArchiveSize: 2048,
+				DownloadUrl: "https://s3/a.zip", UrlExpiresAt: 1735689599,
+				CreatedAt: timestamppb.New(now), CompletedAt: timestamppb.New(now), ExpiresAt: timestamppb.New(now.Add(time.Hour)),
+			},
+			wantStatus:   "completed",
+			wantProgress: 100,
+			wantURL:      true,
+		},
+		{
+			name:       "failed",
+			archive:    &filepb.Archive{Id: id.String(), Status: filepb.ArchiveStatus_ARCHIVE_STATUS_FAILED, Error: "boom", CreatedAt: timestamppb.New(now)},
+			wantStatus: "failed",
+			wantError:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := mapper.ArchiveToResponseDTO(tt.archive)
+			require.Equal(t, id, got.ID)
+			require.Equal(t, tt.wantStatus, got.Status)
+			require.Equal(t, tt.wantProgress, got.Progress)
+			require.Equal(t, types.JSONTime(now), got.CreatedAt)
+			require.Equal(t, tt.wantURL, got.DownloadURL != nil)
+			require.Equal(t, tt.wantURL, got.URLExpiresAt != nil)
+			require.Equal(t, tt.wantURL, got.CompletedAt != nil)
+			require.Equal(t, tt.wantError, got.Error != nil)
+			if tt.wantURL {
+				require.Equal(t, "https://s3/a.zip", *got.DownloadURL)
+				require.Equal(t, int64(2048), got.ArchiveSize)
+			}
+		})
+	}
+}
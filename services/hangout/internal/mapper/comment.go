@@ -0,0 +1,74 @@
+package mapper
+
+import (
+	"github.com/Ernestgio/Hangout-Planner/pkg/shared/types"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
+	"github.com/google/uuid"
+)
+
+func CommentToResponseDTO(comment *domain.Comment) *dto.CommentResponse {
+	if comment == nil {
+		return nil
+	}
+
+	return &dto.CommentResponse{
+		ID:        comment.ID,
+		HangoutID: comment.HangoutID,
+		ParentID:  comment.ParentID,
+		Body:      comment.Body,
+		Author: dto.CommentAuthorResponse{
+			ID:   comment.UserID,
+			Name: comment.User.Name,
+		},
+		Mentions:  CommentMentionIDs(comment.Mentions),
+		Edited:    comment.EditedAt != nil,
+		EditedAt:  optionalJSONTime(comment.EditedAt),
+		CreatedAt: types.JSONTime(comment.CreatedAt),
+	}
+}
+
+// CommentsToResponseDTOs maps top-level comments and nests each reply under
+// its parent. Replies keep the order they are given in.
+func CommentsToResponseDTOs(comments []domain.Comment, replies []domain.Comment) []*dto.CommentResponse {
+	responses := make([]*dto.CommentResponse, len(comments))
+	byID := make(map[uuid.UUID]*dto.CommentResponse, len(comments))
+	for i := range comments {
+		responses[i] = CommentToResponseDTO(&comments[i])
+		byID[comments[i].ID] = responses[i]
+	}
+
+	for i := range replies {
+		if replies[i].ParentID == nil {
+			continue
+		}
+		if parent, ok := byID[*replies[i].ParentID]; ok {
+			parent.Replies = append(parent.Replies, CommentToResponseDTO(&replies[i]))
+		}
+	}
+	return responses
+}
+
+func CommentToCreatedEventDTO(comment *domain.Comment) *dto.CommentCreatedEvent {
+	if comment == nil {
+		return nil
+	}
+
+	return &dto.CommentCreatedEvent{
+		ID:         comment.ID,
+		HangoutID:  comment.HangoutID,
+		ParentID:   comment.ParentID,
+		AuthorID:   comment.UserID,
+		AuthorName: comment.User.Name,
+		Mentions:   CommentMentionIDs(comment.Mentions),
+		CreatedAt:  types.JSONTime(comment.CreatedAt),
+	}
+}
+
+func CommentMentionIDs(mentions []domain.CommentMention) []uuid.UUID {
+	ids := make([]uuid.UUID, len(mentions))
+	for i := range mentions {
+		ids[i] = mentions[i].UserID
+	}
+	return ids
+}
@@ -0,0 +1,86 @@
+package mapper_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/pkg/shared/types"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/mapper"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommentToResponseDTO(t *testing.T) {
+	createdAt := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	editedAt := createdAt.Add(time.Minute)
+	authorID, mentionedID := uuid.New(), uuid.New()
+	comment := &domain.Comment{
+		ID:        uuid.New(),
+		Body:      "<p>Bring snacks</p>",
+		HangoutID: uuid.New(),
+		UserID:    authorID,
+		User:      domain.User{ID: authorID, Name: "Ada", Email: "ada@example.com"},
+		Mentions:  []domain.CommentMention{{UserID: mentionedID}},
+		CreatedAt: createdAt,
+	}
+
+	require.Nil(t, mapper.CommentToResponseDTO(nil))
+
+	require.Equal(t, &dto.CommentResponse{
+		ID:        comment.ID,
+		HangoutID: comment.HangoutID,
+		Body:      comment.Body,
+		Author:    dto.CommentAuthorResponse{ID: authorID, Name: "Ada"},
+		Mentions:  []uuid.UUID{mentionedID},
+		CreatedAt: types.JSONTime(createdAt),
+	}, mapper.CommentToResponseDTO(comment))
+
+	comment.EditedAt = &editedAt
+	edited := mapper.CommentToResponseDTO(comment)
+	require.True(t, edited.Edited)
+	require.Equal(t, types.JSONTime(editedAt), edited.EditedAt)
+}
+
+func TestCommentsToResponseDTOs(t *testing.T) {
+	first := domain.Comment{ID: uuid.New()}
+	second := domain.Comment{ID: uuid.New()}
+	replies := []domain.Comment{
+		{ID: uuid.New(), ParentID: &second.ID},
+		{ID: uuid.New(), ParentID: &first.ID},
+		{ID: uuid.New(), ParentID: &second.ID},
+		{ID: uuid.New()},
+	}
+
+	responses := mapper.CommentsToResponseDTOs([]domain.Comment{first, second}, replies)
+	require.Len(t, responses, 2)
+	require.Len(t, responses[0].Replies, 1)
+	require.Equal(t, replies[1].ID, responses[0].Replies[0].ID)
+	require.Len(t, responses[1].Replies, 2)
+	require.Equal(t, replies[0].ID, responses[1].Replies[0].ID)
+	require.Equal(t, replies[2].ID, responses[1].Replies[1].ID)
+	require.Empty(t, mapper.CommentsToResponseDTOs(nil, nil))
+}
+
+func TestCommentToCreatedEventDTO(t *testing.T) {
+	parentID, mentionedID := uuid.New(), uuid.New()
+	comment := &domain.Comment{
+		ID:        uuid.New(),
+		HangoutID: uuid.New(),
+		ParentID:  &parentID,
+		UserID:    uuid.New(),
+		User:      domain.User{Name: "Ada"},
+		Mentions:  []domain.CommentMention{{UserID: mentionedID}},
+	}
+
+	require.Nil(t, mapper.CommentToCreatedEventDTO(nil))
+
+	event := mapper.CommentToCreatedEventDTO(comment)
+	require.Equal(t, comment.ID, event.ID)
+	require.Equal(t, comment.HangoutID, event.HangoutID)
+	require.Equal(t, &parentID, event.ParentID)
+	require.Equal(t, comment.UserID, event.AuthorID)
+	require.Equal(t, "Ada", event.AuthorName)
+	require.Equal(t, []uuid.UUID{mentionedID}, event.Mentions)
+}
@@ -0,0 +1,65 @@
+package mapper
+
+import (
+	"time"
+
+	filepb "github.com/Ernestgio/Hangout-Planner/pkg/shared/proto/gen/go/file"
+	"github.com/Ernestgio/Hangout-Planner/pkg/shared/types"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/repository"
+)
+
+func DataExportToResponseDTO(export *domain.DataExport, archive *filepb.Archive) *dto.DataExportResponse {
+	if export == nil {
+		return nil
+	}
+
+	res := &dto.DataExportResponse{
+		ID:        export.ID,
+		Status:    export.Status,
+		Archive:   ArchiveToResponseDTO(archive),
+		CreatedAt: types.JSONTime(export.CreatedAt),
+		ExpiresAt: types.JSONTime(export.ExpiresAt),
+	}
+	if export.LastError != "" {
+		res.Error = &export.LastError
+	}
+	if export.CompletedAt != nil {
+		completedAt := types.JSONTime(*export.CompletedAt)
+		res.CompletedAt = &completedAt
+	}
+	return res
+}
+
+func ToDataExportDocument(user *domain.User, hangouts []domain.Hangout, activities []repository.ActivityWithCount, memories []domain.Memory, exportedAt time.Time) *dto.DataExportDocument {
+	doc := &dto.DataExportDocument{
+		ExportedAt: types.JSONTime(exportedAt),
+		Profile:    *UserToResponseDTO(user),
+		Hangouts:   make([]dto.HangoutDetailResponse, len(hangouts)),
+		Activities: ActivityToListItemResponseDTO(activities),
+		Memories:   make([]dto.DataExportMemory, len(memories)),
+	}
+	for i := range hangouts {
+		doc.Hangouts[i] = *HangoutToDetailResponseDTO(&hangouts[i])
+	}
+	for i := range memories {
+		doc.Memories[i] = memoryToDataExportDTO(&memories[i], user)
+	}
+	return doc
+}
+
+func memoryToDataExportDTO(memory *domain.Memory, user *domain.User) dto.DataExportMemory {
+	res := MemoryToResponseDTO(memory, "", 0, "")
+	return dto.DataExportMemory{
+		ID:        res.ID,
+		Name:      res.Name,
+		Caption:   res.Caption,
+		HangoutID: res.HangoutID,
+		AlbumID:   res.AlbumID,
+		Tags:      res.Tags,
+		People:    res.People,
+		Reactions: MemoryReactionsToResponseDTOs(memory.Reactions, user.ID),
+		CreatedAt: res.CreatedAt,
+	}
+}
@@ -0,0 +1,108 @@
+package mapper_test
+
+import (
+	"testing"
+	"time"
+
+	filepb "github.com/Ernestgio/Hangout-Planner/pkg/shared/proto/gen/go/file"
+	"github.com/Ernestgio/Hangout-Planner/pkg/shared/types"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/mapper"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestDataExportToResponseDTO(t *testing.T) {
+	require.Nil(t, mapper.DataExportToResponseDTO(nil, nil))
+
+	createdAt := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	completedAt := createdAt.Add(time.Minute)
+	expiresAt := createdAt.Add(7 * 24 * time.Hour)
+	completedJSON := types.JSONTime(completedAt)
+	failure := "archive failed"
+
+	tests := []struct {
+		name          string
+		export        *domain.DataExport
+		archive       *filepb.Archive
+		wantArchive   bool
+		wantError     *string
+		wantCompleted *types.JSONTime
+	}{
+		{
+			name:   "pending",
+			export: &domain.DataExport{ID: uuid.New(), Status: constants.DataExportPending, CreatedAt: createdAt, ExpiresAt: expiresAt},
+		},
+		{
+			name:          "completed with archive",
+			export:        &domain.DataExport{ID: uuid.New(), Status: constants.DataExportCompleted, CreatedAt: createdAt, CompletedAt: &completedAt, ExpiresAt: expiresAt},
+			archive:       &filepb.Archive{Id: uuid.NewString(), Status: filepb.ArchiveStatus_ARCHIVE_STATUS_COMPLETED, CreatedAt: timestamppb.New(createdAt)},
+			wantArchive:   true,
+			wantCompleted: &completedJSON,
+		},
+		{
+			name:      "failed",
+			export:    &domain.DataExport{ID: uuid.New(), Status: constants.DataExportFailed, LastError: failure, CreatedAt: createdAt, ExpiresAt: expiresAt},
+			wantError: &failure,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := mapper.DataExportToResponseDTO(tt.export, tt.archive)
+			require.Equal(t, tt.export.ID, got.ID)
+			require.Equal(t, tt.export.Status, got.Status)
+			require.Equal(t, tt.wantArchive, got.Archive != nil)
+			require.Equal(t, tt.wantError, got.Error)
+			require.Equal(t, tt.wantCompleted, got.CompletedAt)
+			require.Equal(t, types.JSONTime(createdAt), got.CreatedAt)
+			require.Equal(t, types.JSONTime(expiresAt), got.ExpiresAt)
+		})
+	}
+}
+
+func TestToDataExportDocument(t *testing.T) {
+	exportedAt := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	user := &domain.User{ID: uuid.New(), Name: "Ann", Email: "ann@example.com"}
+	friendID := uuid.New()
+	hangoutID := uuid.New()
+	memoryID := uuid.New()
+
+	hangouts := []domain.Hangout{{ID: hangoutID, Title: "Picnic", Activities: []*domain.Activity{{ID: uuid.New(), Name: "Hiking"}}}}
+	activities := []repository.ActivityWithCount{{Activity: domain.Activity{ID: uuid.New(), Name: "Hiking"}, HangoutCount: 1}}
+	memories := []domain.Memory{{
+		ID:         memoryID,
+		Name:       "beach.jpg",
+		HangoutID:  hangoutID,
+		UserID:     user.ID,
+		Tags:       []domain.MemoryTag{{MemoryID: memoryID, Tag: "beach"}},
+		PersonTags: []domain.MemoryPersonTag{{MemoryID: memoryID, UserID: friendID}},
+		Reactions: []domain.MemoryReaction{
+			{MemoryID: memoryID, UserID: friendID, Emoji: "🎉", CreatedAt: exportedAt},
+			{MemoryID: memoryID, UserID: user.ID, Emoji: "🎉", CreatedAt: exportedAt},
+		},
+	}}
+
+	doc := mapper.ToDataExportDocument(user, hangouts, activities, memories, exportedAt)
+	require.Equal(t, types.JSONTime(exportedAt), doc.ExportedAt)
+	require.Equal(t, user.Email, doc.Profile.Email)
+	require.Len(t, doc.Hangouts, 1)
+	require.Equal(t, "Picnic", doc.Hangouts[0].Title)
+	require.Len(t, doc.Hangouts[0].Activities, 1)
+	require.Len(t, doc.Activities, 1)
+	require.Equal(t, int64(1), doc.Activities[0].HangoutCount)
+	require.Len(t, doc.Memories, 1)
+	require.Equal(t, "beach.jpg", doc.Memories[0].Name)
+	require.Equal(t, []string{"beach"}, doc.Memories[0].Tags)
+	require.Equal(t, []uuid.UUID{friendID}, doc.Memories[0].People)
+	require.Len(t, doc.Memories[0].Reactions, 1)
+	require.Equal(t, 2, doc.Memories[0].Reactions[0].Count)
+	require.True(t, doc.Memories[0].Reactions[0].Reacted)
+
+	empty := mapper.ToDataExportDocument(user, nil, nil, nil, exportedAt)
+	require.NotNil(t, empty.Hangouts)
+	require.NotNil(t, empty.Memories)
+}
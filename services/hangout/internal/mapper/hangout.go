@@ -6,6 +6,7 @@ import (
 	"github.com/Ernestgio/Hangout-Planner/pkg/shared/constants"
 	"github.com/Ernestgio/Hangout-Planner/pkg/shared/enums"
 	"github.com/Ernestgio/Hangout-Planner/pkg/shared/types"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
 
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
@@ -17,12 +18,21 @@ func HangoutCreateRequestToModel(request *dto.CreateHangoutRequest) (*domain.Han
 		return nil, err
 	}
 
-	return &domain.Hangout{
+	hangout := &domain.Hangout{
 		Title:       request.Title,
 		Description: request.Description,
 		Date:        parsedDate,
 		Status:      request.Status,
-	}, nil
+	}
+	if request.RSVPDeadline != nil {
+		if err := applyRSVPDeadline(hangout, *request.RSVPDeadline); err != nil {
+			return nil, err
+		}
+	}
+	if err := validateRSVPDeadline(hangout); err != nil {
+		return nil, err
+	}
+	return hangout, nil
 }
 
 func ApplyUpdateToHangout(hangout *domain.Hangout, req *dto.UpdateHangoutRequest) error {
@@ -40,9 +50,91 @@ func ApplyUpdateToHangout(hangout *domain.Hangout, req *dto.UpdateHangoutRequest
 		hangout.Description = req.Description
 	}
 
+	if req.RSVPDeadline != nil {
+		if err := applyRSVPDeadline(hangout, *req.RSVPDeadline); err != nil {
+			return err
+		}
+	}
+
+	return validateRSVPDeadline(hangout)
+}
+
+func ApplyPatchToHangout(hangout *domain.Hangout, req *dto.PatchHangoutRequest) error {
+	if req.Title.Set {
+		if req.Title.Null || req.Title.Value == "" {
+			return apperrors.ErrHangoutFieldRequired
+		}
+		hangout.Title = req.Title.Value
+	}
+
+	if req.Description.Set {
+		if req.Description.Null {
+			hangout.Description = nil
+		} else {
+			description := req.Description.Value
+			hangout.Description = &description
+		}
+	}
+
+	if req.Date.Set {
+		if req.Date.Null {
+			return apperrors.ErrHangoutFieldRequired
+		}
+		parsedDate, err := time.Parse(constants.DateFormat, req.Date.Value)
+		if err != nil {
+			return apperrors.ErrInvalidHangoutDate
+		}
+		hangout.Date = parsedDate
+	}
+
+	if req.Status.Set {
+		if req.Status.Null {
+			return apperrors.ErrHangoutFieldRequired
+		}
+		if err := ApplyStatusToHangout(hangout, req.Status.Value); err != nil {
+			return err
+		}
+	}
+
+	if req.RSVPDeadline.Set {
+		if req.RSVPDeadline.Null {
+			hangout.RSVPDeadline = nil
+		} else if err := applyRSVPDeadline(hangout, req.RSVPDeadline.Value); err != nil {
+			return err
+		}
+	}
+
+	return validateRSVPDeadline(hangout)
+}
+
+func applyRSVPDeadline(hangout *domain.Hangout, value string) error {
+	deadline, err := time.Parse(constants.DateFormat, value)
+	if err != nil {
+		return apperrors.ErrInvalidRSVPDeadline
+	}
+	hangout.RSVPDeadline = &deadline
 	return nil
 }
 
+// validateRSVPDeadline checks the deadline against the hangout date once all
+// changes are applied, so moving the date alone can invalidate it.
+func validateRSVPDeadline(hangout *domain.Hangout) error {
+	if hangout.RSVPDeadline != nil && !hangout.RSVPDeadline.Before(hangout.Date) {
+		return apperrors.ErrInvalidRSVPDeadline
+	}
+	return nil
+}
+
+func ApplyStatusToHangout(hangout *domain.Hangout, status enums.HangoutStatus) error {
+	switch status {
+	case enums.StatusPlanning, enums.StatusConfirmed, enums.StatusExecuted, enums.StatusCancelled:
+		hangout.Status = status
+		return nil
+	default:
+		return apperrors.ErrInvalidHangoutStatus
+	}
+}
+
 func HangoutToDetailResponseDTO(hangout *domain.Hangout) *dto.HangoutDetailResponse {
 	if hangout == nil {
 		return nil
@@ -56,14 +148,22 @@ func HangoutToDetailResponseDTO(hangout *domain.Hangout) *dto.HangoutDetailRespo
 		}
 	}
 
+	var rsvpDeadline *types.JSONTime
+	if hangout.RSVPDeadline != nil {
+		deadline := types.JSONTime(*hangout.RSVPDeadline)
+		rsvpDeadline = &deadline
+	}
+
 	return &dto.HangoutDetailResponse{
-		ID:          hangout.ID,
-		Title:       hangout.Title,
-		Description: hangout.Description,
-		Date:        types.JSONTime(hangout.Date),
-		Status:      hangout.Status,
-		CreatedAt:   types.JSONTime(hangout.CreatedAt),
-		Activities:  activityDTOs,
+		ID:           hangout.ID,
+		Title:        hangout.Title,
+		Description:  hangout.Description,
+		Date:         types.JSONTime(hangout.Date),
+		RSVPDeadline: rsvpDeadline,
+		Status:       hangout.Status,
+		CreatedAt:    types.JSONTime(hangout.CreatedAt),
+		Activities:   activityDTOs,
+		Version:      hangout.Version,
 	}
 }
 
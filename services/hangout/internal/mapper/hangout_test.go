@@ -7,6 +7,7 @@ import (
 	"github.com/Ernestgio/Hangout-Planner/pkg/shared/constants"
 	"github.com/Ernestgio/Hangout-Planner/pkg/shared/enums"
 	"github.com/Ernestgio/Hangout-Planner/pkg/shared/types"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/mapper"
@@ -46,6 +47,32 @@ func TestHangoutCreateRequestToModel(t *testing.T) {
 				require.Equal(t, enums.StatusPlanning, hangout.Status)
 			},
 		},
+		{
+			name: "success with rsvp deadline",
+			request: &dto.CreateHangoutRequest{
+				Title:        "Test Hangout",
+				Date:         validTimeStr,
+				RSVPDeadline: stringPtr("2025-10-04 12:00:00.000"),
+				Status:       enums.StatusPlanning,
+			},
+			checkResult: func(t *testing.T, hangout *domain.Hangout, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, hangout.RSVPDeadline)
+				require.Equal(t, parsedTime.Add(-27*time.Hour), *hangout.RSVPDeadline)
+			},
+		},
+		{
+			name: "rsvp deadline after the hangout",
+			request: &dto.CreateHangoutRequest{
+				Date:         validTimeStr,
+				RSVPDeadline: stringPtr("2025-10-06 12:00:00.000"),
+			},
+			expectError: true,
+			checkResult: func(t *testing.T, hangout *domain.Hangout, err error) {
+				require.ErrorIs(t, err, apperrors.ErrInvalidRSVPDeadline)
+				require.Nil(t, hangout)
+			},
+		},
 		{
 			name: "invalid date format",
 			request: &dto.CreateHangoutRequest{
@@ -71,6 +98,7 @@ func TestApplyUpdateToHangout(t *testing.T) {
 	initialDate := time.Now().Add(-24 * time.Hour)
 	newDateStr := "2025-12-25 18:00:00.000"
 	parsedNewDate, _ := time.Parse(constants.DateFormat, newDateStr)
+	rsvpDeadline := parsedNewDate.Add(-48 * time.Hour)
 
 	testCases := []struct {
 		name           string
@@ -100,6 +128,35 @@ func TestApplyUpdateToHangout(t *testing.T) {
 				require.Equal(t, enums.StatusConfirmed, hangout.Status)
 			},
 		},
+		{
+			name: "success: rsvp deadline is kept when omitted",
+			initialHangout: &domain.Hangout{
+				ID:           uuid.New(),
+				Date:         initialDate,
+				RSVPDeadline: &rsvpDeadline,
+			},
+			request: &dto.UpdateHangoutRequest{
+				Title:  "New Title",
+				Date:   newDateStr,
+				Status: enums.StatusConfirmed,
+			},
+			checkResult: func(t *testing.T, hangout *domain.Hangout) {
+				require.Equal(t, rsvpDeadline, *hangout.RSVPDeadline)
+			},
+		},
+		{
+			name: "error: rsvp deadline after the hangout",
+			initialHangout: &domain.Hangout{
+				ID: uuid.New(),
+			},
+			request: &dto.UpdateHangoutRequest{
+				Date:         newDateStr,
+				RSVPDeadline: stringPtr("2025-12-26 18:00:00.000"),
+			},
+			expectError: true,
+			checkResult: func(t *testing.T, hangout *domain.Hangout) {
+			},
+		},
 		{
 			name: "error: invalid date format",
 			initialHangout: &domain.Hangout{
@@ -128,6 +185,158 @@ func TestApplyUpdateToHangout(t *testing.T) {
 	}
 }
 
+func TestApplyPatchToHangout(t *testing.T) {
+	initialDate := time.Now().Add(-24 * time.Hour)
+	newDateStr := "2025-12-25 18:00:00.000"
+	parsedNewDate, _ := time.Parse(constants.DateFormat, newDateStr)
+
+	newHangout := func() *domain.Hangout {
+		return &domain.Hangout{
+			ID:          uuid.New(),
+			Title:       "Old Title",
+			Description: stringPtr("Old Description"),
+			Date:        initialDate,
+			Status:      enums.StatusPlanning,
+		}
+	}
+
+	testCases := []struct {
+		name        string
+		request     *dto.PatchHangoutRequest
+		expectedErr error
+		checkResult func(t *testing.T, hangout *domain.Hangout)
+	}{
+		{
+			name:    "success: empty patch leaves hangout untouched",
+			request: &dto.PatchHangoutRequest{},
+			checkResult: func(t *testing.T, hangout *domain.Hangout) {
+				require.Equal(t, "Old Title", hangout.Title)
+				require.Equal(t, "Old Description", *hangout.Description)
+				require.Equal(t, initialDate, hangout.Date)
+				require.Equal(t, enums.StatusPlanning, hangout.Status)
+			},
+		},
+		{
+			name: "success: only description changes",
+			request: &dto.PatchHangoutRequest{
+				Description: dto.Nullable[string]{Set: true, Value: "New Description"},
+			},
+			checkResult: func(t *testing.T, hangout *domain.Hangout) {
+				require.Equal(t, "Old Title", hangout.Title)
+				require.Equal(t, "New Description", *hangout.Description)
+			},
+		},
+		{
+			name: "success: null clears description",
+			request: &dto.PatchHangoutRequest{
+				Description: dto.Nullable[string]{Set: true, Null: true},
+			},
+			checkResult: func(t *testing.T, hangout *domain.Hangout) {
+				require.Nil(t, hangout.Description)
+			},
+		},
+		{
+			name: "success: all fields set",
+			request: &dto.PatchHangoutRequest{
+				Title:  dto.Nullable[string]{Set: true, Value: "New Title"},
+				Date:   dto.Nullable[string]{Set: true, Value: newDateStr},
+				Status: dto.Nullable[enums.HangoutStatus]{Set: true, Value: enums.StatusConfirmed},
+			},
+			checkResult: func(t *testing.T, hangout *domain.Hangout) {
+				require.Equal(t, "New Title", hangout.Title)
+				require.Equal(t, parsedNewDate, hangout.Date)
+				require.Equal(t, enums.StatusConfirmed, hangout.Status)
+			},
+		},
+		{
+			name: "success: rsvp deadline set and cleared",
+			request: &dto.PatchHangoutRequest{
+				Date:         dto.Nullable[string]{Set: true, Value: newDateStr},
+				RSVPDeadline: dto.Nullable[string]{Set: true, Value: "2025-12-24 18:00:00.000"},
+			},
+			checkResult: func(t *testing.T, hangout *domain.Hangout) {
+				require.Equal(t, parsedNewDate.Add(-24*time.Hour), *hangout.RSVPDeadline)
+
+				err := mapper.ApplyPatchToHangout(hangout, &dto.PatchHangoutRequest{
+					RSVPDeadline: dto.Nullable[string]{Set: true, Null: true},
+				})
+				require.NoError(t, err)
+				require.Nil(t, hangout.RSVPDeadline)
+			},
+		},
+		{
+			name: "error: invalid rsvp deadline",
+			request: &dto.PatchHangoutRequest{
+				RSVPDeadline: dto.Nullable[string]{Set: true, Value: "tomorrow"},
+			},
+			expectedErr: apperrors.ErrInvalidRSVPDeadline,
+		},
+		{
+			name: "error: rsvp deadline not before the hangout",
+			request: &dto.PatchHangoutRequest{
+				RSVPDeadline: dto.Nullable[string]{Set: true, Value: initialDate.Add(time.Hour).Format(constants.DateFormat)},
+			},
+			expectedErr: apperrors.ErrInvalidRSVPDeadline,
+		},
+		{
+			name: "error: null title",
+			request: &dto.PatchHangoutRequest{
+				Title: dto.Nullable[string]{Set: true, Null: true},
+			},
+			expectedErr: apperrors.ErrHangoutFieldRequired,
+		},
+		{
+			name: "error: empty title",
+			request: &dto.PatchHangoutRequest{
+				Title: dto.Nullable[string]{Set: true},
+			},
+			expectedErr: apperrors.ErrHangoutFieldRequired,
+		},
+		{
+			name: "error: null date",
+			request: &dto.PatchHangoutRequest{
+				Date: dto.Nullable[string]{Set: true, Null: true},
+			},
+			expectedErr: apperrors.ErrHangoutFieldRequired,
+		},
+		{
+			name: "error: invalid date format",
+			request: &dto.PatchHangoutRequest{
+				Date: dto.Nullable[string]{Set: true, Value: "invalid-date-format"},
+			},
+			expectedErr: apperrors.ErrInvalidHangoutDate,
+		},
+		{
+			name: "error: null status",
+			request: &dto.PatchHangoutRequest{
+				Status: dto.Nullable[enums.HangoutStatus]{Set: true, Null: true},
+			},
+			expectedErr: apperrors.ErrHangoutFieldRequired,
+		},
+		{
+			name: "error: unknown status",
+			request: &dto.PatchHangoutRequest{
+				Status: dto.Nullable[enums.HangoutStatus]{Set: true, Value: "POSTPONED"},
+			},
+			expectedErr: apperrors.ErrInvalidHangoutStatus,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			hangout := newHangout()
+			err := mapper.ApplyPatchToHangout(hangout, tc.request)
+
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+			} else {
+				require.NoError(t, err)
+				tc.checkResult(t, hangout)
+			}
+		})
+	}
+}
+
 func TestHangoutToDetailResponseDTO(t *testing.T) {
 	hangoutID := uuid.New()
 	activityID1 := uuid.New()
@@ -135,12 +344,13 @@ func TestHangoutToDetailResponseDTO(t *testing.T) {
 	now := time.Now()
 
 	hangoutWithActivities := &domain.Hangout{
-		ID:          hangoutID,
-		Title:       "Detail View",
-		Description: stringPtr("Detailed description."),
-		Date:        now,
-		Status:      enums.StatusExecuted,
-		CreatedAt:   now,
+		ID:           hangoutID,
+		Title:        "Detail View",
+		Description:  stringPtr("Detailed description."),
+		Date:         now,
+		RSVPDeadline: &now,
+		Status:       enums.StatusExecuted,
+		CreatedAt:    now,
 		Activities: []*domain.Activity{
 			{ID: activityID1, Name: "Hiking"},
 			{ID: activityID2, Name: "Coffee"},
@@ -162,6 +372,7 @@ func TestHangoutToDetailResponseDTO(t *testing.T) {
 				require.NotNil(t, res.Description)
 				require.Equal(t, "Detailed description.", *res.Description)
 				require.Equal(t, types.JSONTime(now), res.Date)
+				require.Equal(t, types.JSONTime(now), *res.RSVPDeadline)
 				require.Equal(t, enums.StatusExecuted, res.Status)
 				require.Len(t, res.Activities, 2)
 				require.Equal(t, activityID1, res.Activities[0].ID)
@@ -182,6 +393,7 @@ func TestHangoutToDetailResponseDTO(t *testing.T) {
 			checkResult: func(t *testing.T, res *dto.HangoutDetailResponse) {
 				require.NotNil(t, res)
 				require.Empty(t, res.Activities)
+				require.Nil(t, res.RSVPDeadline)
 			},
 		},
 		{
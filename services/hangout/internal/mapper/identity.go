@@ -0,0 +1,22 @@
+package mapper
+
+import (
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
+)
+
+func IdentityToResponseDTO(identity *domain.UserIdentity) *dto.IdentityResponse {
+	return &dto.IdentityResponse{
+		Provider:  identity.Provider,
+		Email:     identity.Email,
+		CreatedAt: identity.CreatedAt,
+	}
+}
+
+func IdentitiesToResponseDTOs(identities []domain.UserIdentity) []*dto.IdentityResponse {
+	responses := make([]*dto.IdentityResponse, len(identities))
+	for i := range identities {
+		responses[i] = IdentityToResponseDTO(&identities[i])
+	}
+	return responses
+}
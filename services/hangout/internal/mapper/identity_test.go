@@ -0,0 +1,43 @@
+package mapper_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	mapper "github.com/Ernestgio/Hangout-Planner/services/hangout/internal/mapper"
+)
+
+func TestIdentityToResponseDTO(t *testing.T) {
+	identity := &domain.UserIdentity{
+		ID:        uuid.New(),
+		UserID:    uuid.New(),
+		Provider:  "google",
+		Subject:   "1234567890",
+		Email:     "alice@example.com",
+		CreatedAt: time.Now(),
+	}
+
+	resp := mapper.IdentityToResponseDTO(identity)
+
+	assert.Equal(t, identity.Provider, resp.Provider)
+	assert.Equal(t, identity.Email, resp.Email)
+	assert.Equal(t, identity.CreatedAt, resp.CreatedAt)
+}
+
+func TestIdentitiesToResponseDTOs(t *testing.T) {
+	identities := []domain.UserIdentity{
+		{Provider: "github", Email: "alice@users.example.com"},
+		{Provider: "google", Email: "alice@example.com"},
+	}
+
+	resp := mapper.IdentitiesToResponseDTOs(identities)
+
+	assert.Len(t, resp, 2)
+	assert.Equal(t, "github", resp[0].Provider)
+	assert.Equal(t, "google", resp[1].Provider)
+	assert.NotNil(t, mapper.IdentitiesToResponseDTOs(nil))
+}
@@ -1,6 +1,8 @@
 package mapper
 
 import (
+	"slices"
+
 	filepb "github.com/Ernestgio/Hangout-Planner/pkg/shared/proto/gen/go/file"
 	"github.com/Ernestgio/Hangout-Planner/pkg/shared/types"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
@@ -13,14 +15,83 @@ func MemoryToResponseDTO(memory *domain.Memory, fileURL string, fileSize int64,
 		return nil
 	}
 
+	tags := make([]string, len(memory.Tags))
+	for i, tag := range memory.Tags {
+		tags[i] = tag.Tag
+	}
+
+	people := make([]uuid.UUID, len(memory.PersonTags))
+	for i, person := range memory.PersonTags {
+		people[i] = person.UserID
+	}
+
 	return &dto.MemoryResponse{
+		ID:         memory.ID,
+		Name:       memory.Name,
+		Caption:    memory.Caption,
+		HangoutID:  memory.HangoutID,
+		AlbumID:    memory.AlbumID,
+		Position:   memory.Position,
+		UploadedBy: memory.UserID,
+		Tags:       tags,
+		People:     people,
+		Reactions:  []dto.MemoryReactionResponse{},
+		FileURL:    fileURL,
+		FileSize:   fileSize,
+		MimeType:   mimeType,
+		CreatedAt:  types.JSONTime(memory.CreatedAt),
+	}
+}
+
+// MemoryReactionsToResponseDTOs groups reactions by emoji in the order each
+// emoji was first used.
+func MemoryReactionsToResponseDTOs(reactions []domain.MemoryReaction, viewerID uuid.UUID) []dto.MemoryReactionResponse {
+	sorted := slices.Clone(reactions)
+	slices.SortStableFunc(sorted, func(a, b domain.MemoryReaction) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+
+	responses := []dto.MemoryReactionResponse{}
+	index := make(map[string]int)
+	for _, reaction := range sorted {
+		i, ok := index[reaction.Emoji]
+		if !ok {
+			i = len(responses)
+			index[reaction.Emoji] = i
+			responses = append(responses, dto.MemoryReactionResponse{Emoji: reaction.Emoji})
+		}
+		responses[i].Count++
+		if reaction.UserID == viewerID {
+			responses[i].Reacted = true
+		}
+	}
+	return responses
+}
+
+func MemoryToCreatedEventDTO(memory *domain.Memory) *dto.MemoryCreatedEvent {
+	if memory == nil {
+		return nil
+	}
+
+	return &dto.MemoryCreatedEvent{
+		ID:        memory.ID,
+		Name:      memory.Name,
+		HangoutID: memory.HangoutID,
+		CreatedAt: types.JSONTime(memory.CreatedAt),
+	}
+}
+
+func MemoryToProcessedEventDTO(memory *domain.Memory, fileSize int64, mimeType string) *dto.MemoryProcessedEvent {
+	if memory == nil {
+		return nil
+	}
+
+	return &dto.MemoryProcessedEvent{
 		ID:        memory.ID,
 		Name:      memory.Name,
 		HangoutID: memory.HangoutID,
-		FileURL:   fileURL,
 		FileSize:  fileSize,
 		MimeType:  mimeType,
-		CreatedAt: types.JSONTime(memory.CreatedAt),
 	}
 }
 
@@ -7,6 +7,7 @@ import (
 	filepb "github.com/Ernestgio/Hangout-Planner/pkg/shared/proto/gen/go/file"
 	"github.com/Ernestgio/Hangout-Planner/pkg/shared/types"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/mapper"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
@@ -47,6 +48,67 @@ func TestMemoryToResponseDTO_TableDriven(t *testing.T) {
 	}
 }
 
+func TestMemoryToResponseDTO_Annotations(t *testing.T) {
+	caption := "Sunset at the pier"
+	uploaderID := uuid.New()
+	personID := uuid.New()
+	memory := &domain.Memory{
+		ID:         uuid.New(),
+		Caption:    &caption,
+		UserID:     uploaderID,
+		Tags:       []domain.MemoryTag{{Tag: "beach"}, {Tag: "sunset"}},
+		PersonTags: []domain.MemoryPersonTag{{UserID: personID}},
+	}
+
+	got := mapper.MemoryToResponseDTO(memory, "", 0, "")
+	require.Equal(t, &caption, got.Caption)
+	require.Equal(t, uploaderID, got.UploadedBy)
+	require.Equal(t, []string{"beach", "sunset"}, got.Tags)
+	require.Equal(t, []uuid.UUID{personID}, got.People)
+	require.Empty(t, got.Reactions)
+}
+
+func TestMemoryReactionsToResponseDTOs(t *testing.T) {
+	viewerID := uuid.New()
+	otherID := uuid.New()
+	now := time.Now()
+
+	got := mapper.MemoryReactionsToResponseDTOs([]domain.MemoryReaction{
+		{UserID: otherID, Emoji: "🔥", CreatedAt: now.Add(time.Minute)},
+		{UserID: otherID, Emoji: "❤️", CreatedAt: now},
+		{UserID: viewerID, Emoji: "🔥", CreatedAt: now.Add(2 * time.Minute)},
+	}, viewerID)
+
+	require.Equal(t, []dto.MemoryReactionResponse{
+		{Emoji: "❤️", Count: 1, Reacted: false},
+		{Emoji: "🔥", Count: 2, Reacted: true},
+	}, got)
+	require.Empty(t, mapper.MemoryReactionsToResponseDTOs(nil, viewerID))
+}
+
+func TestMemoryToCreatedEventDTO(t *testing.T) {
+	require.Nil(t, mapper.MemoryToCreatedEventDTO(nil))
+
+	memory := &domain.Memory{ID: uuid.New(), Name: "mem1", HangoutID: uuid.New(), CreatedAt: time.Date(2024, 12, 31, 23, 59, 59, 0, time.UTC)}
+	got := mapper.MemoryToCreatedEventDTO(memory)
+	require.Equal(t, memory.ID, got.ID)
+	require.Equal(t, memory.Name, got.Name)
+	require.Equal(t, memory.HangoutID, got.HangoutID)
+	require.Equal(t, types.JSONTime(memory.CreatedAt), got.CreatedAt)
+}
+
+func TestMemoryToProcessedEventDTO(t *testing.T) {
+	require.Nil(t, mapper.MemoryToProcessedEventDTO(nil, 0, ""))
+
+	memory := &domain.Memory{ID: uuid.New(), Name: "mem1", HangoutID: uuid.New()}
+	got := mapper.MemoryToProcessedEventDTO(memory, 2048, "image/jpeg")
+	require.Equal(t, memory.ID, got.ID)
+	require.Equal(t, memory.Name, got.Name)
+	require.Equal(t, memory.HangoutID, got.HangoutID)
+	require.Equal(t, int64(2048), got.FileSize)
+	require.Equal(t, "image/jpeg", got.MimeType)
+}
+
 func TestToMemoryUploadResponse_TableDriven(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -0,0 +1,44 @@
+package mapper
+
+import (
+	"github.com/Ernestgio/Hangout-Planner/pkg/shared/types"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
+)
+
+func NotificationToResponseDTO(notification *domain.Notification) *dto.NotificationResponse {
+	if notification == nil {
+		return nil
+	}
+
+	return &dto.NotificationResponse{
+		ID:        notification.ID,
+		Type:      notification.Type,
+		Title:     notification.Title,
+		Body:      notification.Body,
+		HangoutID: notification.HangoutID,
+		Read:      notification.ReadAt != nil,
+		ReadAt:    optionalJSONTime(notification.ReadAt),
+		CreatedAt: types.JSONTime(notification.CreatedAt),
+	}
+}
+
+func NotificationsToResponseDTOs(notifications []domain.Notification) []*dto.NotificationResponse {
+	responses := make([]*dto.NotificationResponse, len(notifications))
+	for i := range notifications {
+		responses[i] = NotificationToResponseDTO(&notifications[i])
+	}
+	return responses
+}
+
+func NotificationPreferencesToResponseDTOs(preferences []domain.NotificationPreference) []*dto.NotificationPreferenceResponse {
+	responses := make([]*dto.NotificationPreferenceResponse, len(preferences))
+	for i := range preferences {
+		responses[i] = &dto.NotificationPreferenceResponse{
+			Type:  preferences[i].Type,
+			Email: preferences[i].Email,
+			Inbox: preferences[i].Inbox,
+		}
+	}
+	return responses
+}
@@ -0,0 +1,59 @@
+package mapper_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/pkg/shared/types"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/mapper"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationToResponseDTO(t *testing.T) {
+	createdAt := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	readAt := createdAt.Add(time.Hour)
+	hangoutID := uuid.New()
+	notification := &domain.Notification{
+		ID:        uuid.New(),
+		Type:      constants.NotificationStatusChanged,
+		Title:     "Picnic is now confirmed",
+		Body:      "Picnic moved from planning to confirmed.",
+		HangoutID: &hangoutID,
+		CreatedAt: createdAt,
+	}
+
+	require.Nil(t, mapper.NotificationToResponseDTO(nil))
+
+	unread := mapper.NotificationToResponseDTO(notification)
+	require.Equal(t, &dto.NotificationResponse{
+		ID:        notification.ID,
+		Type:      notification.Type,
+		Title:     notification.Title,
+		Body:      notification.Body,
+		HangoutID: &hangoutID,
+		CreatedAt: types.JSONTime(createdAt),
+	}, unread)
+
+	notification.ReadAt = &readAt
+	read := mapper.NotificationToResponseDTO(notification)
+	require.True(t, read.Read)
+	require.Equal(t, types.JSONTime(readAt), read.ReadAt)
+
+	require.Len(t, mapper.NotificationsToResponseDTOs([]domain.Notification{*notification, *notification}), 2)
+}
+
+func TestNotificationPreferencesToResponseDTOs(t *testing.T) {
+	preferences := []domain.NotificationPreference{
+		{Type: constants.NotificationHangoutReminder, Email: true, Inbox: true},
+		{Type: constants.NotificationMemoryCreated, Inbox: true},
+	}
+
+	require.Equal(t, []*dto.NotificationPreferenceResponse{
+		{Type: constants.NotificationHangoutReminder, Email: true, Inbox: true},
+		{Type: constants.NotificationMemoryCreated, Inbox: true},
+	}, mapper.NotificationPreferencesToResponseDTOs(preferences))
+}
@@ -0,0 +1,41 @@
+package mapper
+
+import (
+	"github.com/Ernestgio/Hangout-Planner/pkg/shared/types"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
+)
+
+func PersonalAccessTokenToResponseDTO(token *domain.PersonalAccessToken) *dto.PersonalAccessTokenResponse {
+	if token == nil {
+		return nil
+	}
+
+	return &dto.PersonalAccessTokenResponse{
+		ID:         token.ID,
+		Name:       token.Name,
+		Scopes:     token.ScopeList(),
+		ExpiresAt:  optionalJSONTime(token.ExpiresAt),
+		LastUsedAt: optionalJSONTime(token.LastUsedAt),
+		CreatedAt:  types.JSONTime(token.CreatedAt),
+	}
+}
+
+func PersonalAccessTokensToResponseDTOs(tokens []domain.PersonalAccessToken) []*dto.PersonalAccessTokenResponse {
+	responses := make([]*dto.PersonalAccessTokenResponse, len(tokens))
+	for i := range tokens {
+		responses[i] = PersonalAccessTokenToResponseDTO(&tokens[i])
+	}
+	return responses
+}
+
+func PersonalAccessTokenToCreatedResponseDTO(token *domain.PersonalAccessToken, plaintext string) *dto.PersonalAccessTokenCreatedResponse {
+	if token == nil {
+		return nil
+	}
+
+	return &dto.PersonalAccessTokenCreatedResponse{
+		PersonalAccessTokenResponse: *PersonalAccessTokenToResponseDTO(token),
+		Token:                       plaintext,
+	}
+}
@@ -0,0 +1,60 @@
+package mapper_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/pkg/shared/types"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/mapper"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPersonalAccessTokenToResponseDTO(t *testing.T) {
+	createdAt := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	expiresAt := createdAt.Add(30 * 24 * time.Hour)
+
+	testCases := []struct {
+		name       string
+		token      *domain.PersonalAccessToken
+		wantExpiry types.JSONTime
+		wantUsed   types.JSONTime
+	}{
+		{
+			name: "never used token without expiry",
+			token: &domain.PersonalAccessToken{
+				ID: uuid.New(), Name: "ci", TokenHash: "hash", Scopes: "hangouts:read", CreatedAt: createdAt,
+			},
+		},
+		{
+			name: "used token with expiry",
+			token: &domain.PersonalAccessToken{
+				ID: uuid.New(), Name: "ci", TokenHash: "hash", Scopes: "hangouts:read,memories:write",
+				ExpiresAt: &expiresAt, LastUsedAt: &createdAt, CreatedAt: createdAt,
+			},
+			wantExpiry: types.JSONTime(expiresAt),
+			wantUsed:   types.JSONTime(createdAt),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := mapper.PersonalAccessTokenToResponseDTO(tc.token)
+			require.Equal(t, tc.token.ID, got.ID)
+			require.Equal(t, tc.token.Name, got.Name)
+			require.Equal(t, tc.token.ScopeList(), got.Scopes)
+			require.Equal(t, tc.wantExpiry, got.ExpiresAt)
+			require.Equal(t, tc.wantUsed, got.LastUsedAt)
+			require.Equal(t, types.JSONTime(createdAt), got.CreatedAt)
+
+			created := mapper.PersonalAccessTokenToCreatedResponseDTO(tc.token, "hpat_plain")
+			require.Equal(t, *got, created.PersonalAccessTokenResponse)
+			require.Equal(t, "hpat_plain", created.Token)
+		})
+	}
+
+	require.Nil(t, mapper.PersonalAccessTokenToResponseDTO(nil))
+	require.Nil(t, mapper.PersonalAccessTokenToCreatedResponseDTO(nil, "hpat_plain"))
+	require.Len(t, mapper.PersonalAccessTokensToResponseDTOs([]domain.PersonalAccessToken{{Scopes: "hangouts:read"}, {Scopes: "comments:read"}}), 2)
+}
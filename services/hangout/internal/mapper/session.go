@@ -0,0 +1,27 @@
+package mapper
+
+import (
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
+	"github.com/google/uuid"
+)
+
+func SessionToResponseDTO(session *domain.Session, currentID uuid.UUID) *dto.SessionResponse {
+	return &dto.SessionResponse{
+		ID:         session.ID,
+		UserAgent:  session.UserAgent,
+		IPAddress:  session.IPAddress,
+		CreatedAt:  session.CreatedAt,
+		LastUsedAt: session.LastUsedAt,
+		ExpiresAt:  session.ExpiresAt,
+		Current:    session.ID == currentID,
+	}
+}
+
+func SessionsToResponseDTOs(sessions []domain.Session, currentID uuid.UUID) []*dto.SessionResponse {
+	responses := make([]*dto.SessionResponse, len(sessions))
+	for i := range sessions {
+		responses[i] = SessionToResponseDTO(&sessions[i], currentID)
+	}
+	return responses
+}
@@ -0,0 +1,51 @@
+package mapper_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	mapper "github.com/Ernestgio/Hangout-Planner/services/hangout/internal/mapper"
+)
+
+func TestSessionToResponseDTO(t *testing.T) {
+	now := time.Now()
+	session := &domain.Session{
+		ID:         uuid.New(),
+		UserID:     uuid.New(),
+		UserAgent:  "Mozilla/5.0",
+		IPAddress:  "203.0.113.7",
+		CreatedAt:  now.Add(-time.Hour),
+		LastUsedAt: now,
+		ExpiresAt:  now.Add(23 * time.Hour),
+	}
+
+	resp := mapper.SessionToResponseDTO(session, session.ID)
+
+	assert.Equal(t, session.ID, resp.ID)
+	assert.Equal(t, session.UserAgent, resp.UserAgent)
+	assert.Equal(t, session.IPAddress, resp.IPAddress)
+	assert.Equal(t, session.CreatedAt, resp.CreatedAt)
+	assert.Equal(t, session.LastUsedAt, resp.LastUsedAt)
+	assert.Equal(t, session.ExpiresAt, resp.ExpiresAt)
+	assert.True(t, resp.Current)
+	assert.False(t, mapper.SessionToResponseDTO(session, uuid.New()).Current)
+}
+
+func TestSessionsToResponseDTOs(t *testing.T) {
+	current := uuid.New()
+	sessions := []domain.Session{
+		{ID: current, UserAgent: "Mozilla/5.0"},
+		{ID: uuid.New(), UserAgent: "curl/8.0"},
+	}
+
+	resp := mapper.SessionsToResponseDTOs(sessions, current)
+
+	assert.Len(t, resp, 2)
+	assert.True(t, resp[0].Current)
+	assert.False(t, resp[1].Current)
+	assert.NotNil(t, mapper.SessionsToResponseDTOs(nil, current))
+}
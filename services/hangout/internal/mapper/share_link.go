@@ -0,0 +1,141 @@
+package mapper
+
+import (
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/pkg/shared/constants"
+	"github.com/Ernestgio/Hangout-Planner/pkg/shared/types"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
+	"github.com/google/uuid"
+)
+
+// ShareLinkCreateRequestToModel builds a link without its scope, token or
+// password; those depend on checks the caller makes first.
+func ShareLinkCreateRequestToModel(req *dto.CreateShareLinkRequest, hangoutID uuid.UUID, userID uuid.UUID) (*domain.ShareLink, error) {
+	link := &domain.ShareLink{
+		HangoutID:   hangoutID,
+		CreatedByID: userID,
+	}
+	if req.ExpiresAt != nil {
+		expiresAt, err := time.Parse(constants.DateFormat, *req.ExpiresAt)
+		if err != nil {
+			return nil, apperrors.ErrInvalidShareExpiry
+		}
+		link.ExpiresAt = &expiresAt
+	}
+	return link, nil
+}
+
+func ShareLinkToResponseDTO(link *domain.ShareLink) *dto.ShareLinkResponse {
+	if link == nil {
+		return nil
+	}
+
+	return &dto.ShareLinkResponse{
+		ID:               link.ID,
+		HangoutID:        link.HangoutID,
+		AlbumID:          link.AlbumID,
+		Scope:            link.Scope,
+		PasswordRequired: link.HasPassword(),
+		ExpiresAt:        optionalJSONTime(link.ExpiresAt),
+		RevokedAt:        optionalJSONTime(link.RevokedAt),
+		AccessCount:      link.AccessCount,
+		LastAccessedAt:   optionalJSONTime(link.LastAccessedAt),
+		CreatedAt:        types.JSONTime(link.CreatedAt),
+	}
+}
+
+func ShareLinksToResponseDTOs(links []domain.ShareLink) []dto.ShareLinkResponse {
+	responses := make([]dto.ShareLinkResponse, len(links))
+	for i := range links {
+		responses[i] = *ShareLinkToResponseDTO(&links[i])
+	}
+	return responses
+}
+
+func ShareLinkToCreatedResponseDTO(link *domain.ShareLink, token string) *dto.ShareLinkCreatedResponse {
+	if link == nil {
+		return nil
+	}
+
+	return &dto.ShareLinkCreatedResponse{
+		ShareLinkResponse: *ShareLinkToResponseDTO(link),
+		Token:             token,
+	}
+}
+
+func ShareLinkAccessesToResponseDTOs(accesses []domain.ShareLinkAccess) []dto.ShareLinkAccessResponse {
+	responses := make([]dto.ShareLinkAccessResponse, len(accesses))
+	for i, access := range accesses {
+		responses[i] = dto.ShareLinkAccessResponse{
+			ID:        access.ID,
+			Outcome:   access.Outcome,
+			IPAddress: access.IPAddress,
+			UserAgent: access.UserAgent,
+			CreatedAt: types.JSONTime(access.CreatedAt),
+		}
+	}
+	return responses
+}
+
+// SharedHangoutToResponseDTO builds the public summary of a share link.
+func SharedHangoutToResponseDTO(link *domain.ShareLink, hangout *domain.Hangout, albums []domain.Album) *dto.SharedHangoutResponse {
+	if link == nil || hangout == nil {
+		return nil
+	}
+
+	activities := make([]dto.ActivityTagResponse, len(hangout.Activities))
+	for i, act := range hangout.Activities {
+		activities[i] = dto.ActivityTagResponse{
+			ID:   act.ID,
+			Name: act.Name,
+		}
+	}
+
+	sharedAlbums := make([]dto.SharedAlbumResponse, len(albums))
+	for i, album := range albums {
+		sharedAlbums[i] = dto.SharedAlbumResponse{
+			ID:            album.ID,
+			Name:          album.Name,
+			CoverMemoryID: album.CoverMemoryID,
+		}
+	}
+
+	return &dto.SharedHangoutResponse{
+		Scope: link.Scope,
+		Hangout: dto.SharedHangoutSummary{
+			Title:       hangout.Title,
+			Description: hangout.Description,
+			Date:        types.JSONTime(hangout.Date),
+			Status:      hangout.Status,
+			Activities:  activities,
+		},
+		Albums:    sharedAlbums,
+		ExpiresAt: optionalJSONTime(link.ExpiresAt),
+	}
+}
+
+func MemoryToSharedResponseDTO(memory *domain.Memory, fileURL string, fileSize int64, mimeType string) *dto.SharedMemoryResponse {
+	if memory == nil {
+		return nil
+	}
+
+	tags := make([]string, len(memory.Tags))
+	for i, tag := range memory.Tags {
+		tags[i] = tag.Tag
+	}
+
+	return &dto.SharedMemoryResponse{
+		ID:        memory.ID,
+		Name:      memory.Name,
+		Caption:   memory.Caption,
+		AlbumID:   memory.AlbumID,
+		Tags:      tags,
+		FileURL:   fileURL,
+		FileSize:  fileSize,
+		MimeType:  mimeType,
+		CreatedAt: types.JSONTime(memory.CreatedAt),
+	}
+}
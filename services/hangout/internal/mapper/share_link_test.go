@@ -0,0 +1,134 @@
+package mapper_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/pkg/shared/enums"
+	"github.com/Ernestgio/Hangout-Planner/pkg/shared/types"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/mapper"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShareLinkToResponseDTO(t *testing.T) {
+	createdAt := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	expiresAt := createdAt.Add(24 * time.Hour)
+	passwordHash := "$2a$10$hash"
+	albumID := uuid.New()
+	link := &domain.ShareLink{
+		ID:           uuid.New(),
+		TokenHash:    "hash",
+		Scope:        constants.ShareScopeAlbum,
+		PasswordHash: &passwordHash,
+		ExpiresAt:    &expiresAt,
+		AccessCount:  4,
+		CreatedAt:    createdAt,
+		HangoutID:    uuid.New(),
+		AlbumID:      &albumID,
+	}
+
+	require.Nil(t, mapper.ShareLinkToResponseDTO(nil))
+	require.Nil(t, mapper.ShareLinkToCreatedResponseDTO(nil, "token"))
+
+	got := mapper.ShareLinkToResponseDTO(link)
+	require.Equal(t, link.ID, got.ID)
+	require.Equal(t, link.HangoutID, got.HangoutID)
+	require.Equal(t, &albumID, got.AlbumID)
+	require.Equal(t, constants.ShareScopeAlbum, got.Scope)
+	require.True(t, got.PasswordRequired)
+	require.Equal(t, types.JSONTime(expiresAt), got.ExpiresAt)
+	require.True(t, time.Time(got.RevokedAt).IsZero())
+	require.True(t, time.Time(got.LastAccessedAt).IsZero())
+	require.Equal(t, 4, got.AccessCount)
+	require.Equal(t, types.JSONTime(createdAt), got.CreatedAt)
+
+	created := mapper.ShareLinkToCreatedResponseDTO(link, "shr_token")
+	require.Equal(t, *got, created.ShareLinkResponse)
+	require.Equal(t, "shr_token", created.Token)
+
+	require.Len(t, mapper.ShareLinksToResponseDTOs([]domain.ShareLink{*link, *link}), 2)
+}
+
+func TestSharedHangoutToResponseDTO(t *testing.T) {
+	description := "Beach day"
+	date := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+	coverID := uuid.New()
+	hangout := &domain.Hangout{
+		ID:          uuid.New(),
+		Title:       "Summer",
+		Description: &description,
+		Date:        date,
+		Status:      enums.StatusExecuted,
+		Activities:  []*domain.Activity{{ID: uuid.New(), Name: "Swimming"}},
+	}
+	albums := []domain.Album{{ID: uuid.New(), Name: "Sunset", CoverMemoryID: &coverID}}
+	link := &domain.ShareLink{Scope: constants.ShareScopeHangout}
+
+	require.Nil(t, mapper.SharedHangoutToResponseDTO(nil, hangout, albums))
+	require.Nil(t, mapper.SharedHangoutToResponseDTO(link, nil, albums))
+
+	got := mapper.SharedHangoutToResponseDTO(link, hangout, albums)
+	require.Equal(t, constants.ShareScopeHangout, got.Scope)
+	require.Equal(t, "Summer", got.Hangout.Title)
+	require.Equal(t, &description, got.Hangout.Description)
+	require.Equal(t, types.JSONTime(date), got.Hangout.Date)
+	require.Equal(t, enums.StatusExecuted, got.Hangout.Status)
+	require.Len(t, got.Hangout.Activities, 1)
+	require.Equal(t, "Swimming", got.Hangout.Activities[0].Name)
+	require.Len(t, got.Albums, 1)
+	require.Equal(t, "Sunset", got.Albums[0].Name)
+	require.Equal(t, &coverID, got.Albums[0].CoverMemoryID)
+	require.True(t, time.Time(got.ExpiresAt).IsZero())
+}
+
+func TestMemoryToSharedResponseDTO(t *testing.T) {
+	caption := "Waves"
+	memory := &domain.Memory{
+		ID:      uuid.New(),
+		Name:    "beach.jpg",
+		Caption: &caption,
+		UserID:  uuid.New(),
+		Tags:    []domain.MemoryTag{{Tag: "beach"}},
+	}
+
+	require.Nil(t, mapper.MemoryToSharedResponseDTO(nil, "", 0, ""))
+
+	got := mapper.MemoryToSharedResponseDTO(memory, "https://files.example.com/beach.jpg", 1024, "image/jpeg")
+	require.Equal(t, memory.ID, got.ID)
+	require.Equal(t, "beach.jpg", got.Name)
+	require.Equal(t, &caption, got.Caption)
+	require.Equal(t, []string{"beach"}, got.Tags)
+	require.Equal(t, "https://files.example.com/beach.jpg", got.FileURL)
+	require.EqualValues(t, 1024, got.FileSize)
+	require.Equal(t, "image/jpeg", got.MimeType)
+}
+
+func TestShareLinkCreateRequestToModel(t *testing.T) {
+	hangoutID, userID := uuid.New(), uuid.New()
+
+	t.Run("without expiry", func(t *testing.T) {
+		link, err := mapper.ShareLinkCreateRequestToModel(&dto.CreateShareLinkRequest{}, hangoutID, userID)
+		require.NoError(t, err)
+		require.Equal(t, hangoutID, link.HangoutID)
+		require.Equal(t, userID, link.CreatedByID)
+		require.Nil(t, link.ExpiresAt)
+	})
+
+	t.Run("with expiry", func(t *testing.T) {
+		expiresAt := "2025-06-01 18:30:00.000"
+		link, err := mapper.ShareLinkCreateRequestToModel(&dto.CreateShareLinkRequest{ExpiresAt: &expiresAt}, hangoutID, userID)
+		require.NoError(t, err)
+		require.Equal(t, time.Date(2025, 6, 1, 18, 30, 0, 0, time.UTC), *link.ExpiresAt)
+	})
+
+	t.Run("invalid expiry", func(t *testing.T) {
+		expiresAt := "tomorrow"
+		_, err := mapper.ShareLinkCreateRequestToModel(&dto.CreateShareLinkRequest{ExpiresAt: &expiresAt}, hangoutID, userID)
+		require.ErrorIs(t, err, apperrors.ErrInvalidShareExpiry)
+	})
+}
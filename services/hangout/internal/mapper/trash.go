@@ -0,0 +1,54 @@
+package mapper
+
+import (
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/pkg/shared/types"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
+)
+
+func HangoutToDeletedResponseDTO(hangout *domain.Hangout, retention time.Duration) *dto.DeletedHangoutResponse {
+	if hangout == nil {
+		return nil
+	}
+
+	return &dto.DeletedHangoutResponse{
+		ID:        hangout.ID,
+		Title:     hangout.Title,
+		Date:      types.JSONTime(hangout.Date),
+		Status:    hangout.Status,
+		DeletedAt: types.JSONTime(hangout.DeletedAt.Time),
+		PurgeAt:   types.JSONTime(hangout.DeletedAt.Time.Add(retention)),
+	}
+}
+
+func HangoutsToDeletedResponseDTOs(hangouts []domain.Hangout, retention time.Duration) []*dto.DeletedHangoutResponse {
+	responses := make([]*dto.DeletedHangoutResponse, len(hangouts))
+	for i := range hangouts {
+		responses[i] = HangoutToDeletedResponseDTO(&hangouts[i], retention)
+	}
+	return responses
+}
+
+func MemoryToDeletedResponseDTO(memory *domain.Memory, retention time.Duration) *dto.DeletedMemoryResponse {
+	if memory == nil {
+		return nil
+	}
+
+	return &dto.DeletedMemoryResponse{
+		ID:        memory.ID,
+		Name:      memory.Name,
+		HangoutID: memory.HangoutID,
+		DeletedAt: types.JSONTime(memory.DeletedAt.Time),
+		PurgeAt:   types.JSONTime(memory.DeletedAt.Time.Add(retention)),
+	}
+}
+
+func MemoriesToDeletedResponseDTOs(memories []domain.Memory, retention time.Duration) []*dto.DeletedMemoryResponse {
+	responses := make([]*dto.DeletedMemoryResponse, len(memories))
+	for i := range memories {
+		responses[i] = MemoryToDeletedResponseDTO(&memories[i], retention)
+	}
+	return responses
+}
@@ -0,0 +1,134 @@
+package mapper_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/pkg/shared/enums"
+	"github.com/Ernestgio/Hangout-Planner/pkg/shared/types"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/mapper"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestHangoutToDeletedResponseDTO(t *testing.T) {
+	deletedAt := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	retention := 30 * 24 * time.Hour
+
+	testCases := []struct {
+		name    string
+		hangout *domain.Hangout
+		wantNil bool
+	}{
+		{name: "nil input", hangout: nil, wantNil: true},
+		{
+			name: "deleted hangout",
+			hangout: &domain.Hangout{
+				ID:        uuid.New(),
+				Title:     "Trip",
+				Date:      deletedAt.Add(48 * time.Hour),
+				Status:    enums.StatusPlanning,
+				DeletedAt: gorm.DeletedAt{Time: deletedAt, Valid: true},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := mapper.HangoutToDeletedResponseDTO(tc.hangout, retention)
+			if tc.wantNil {
+				require.Nil(t, got)
+				return
+			}
+			require.Equal(t, tc.hangout.ID, got.ID)
+			require.Equal(t, tc.hangout.Title, got.Title)
+			require.Equal(t, types.JSONTime(tc.hangout.Date), got.Date)
+			require.Equal(t, tc.hangout.Status, got.Status)
+			require.Equal(t, types.JSONTime(deletedAt), got.DeletedAt)
+			require.Equal(t, types.JSONTime(deletedAt.Add(retention)), got.PurgeAt)
+		})
+	}
+}
+
+func TestHangoutsToDeletedResponseDTOs(t *testing.T) {
+	testCases := []struct {
+		name     string
+		hangouts []domain.Hangout
+		wantLen  int
+	}{
+		{name: "nil input", hangouts: nil, wantLen: 0},
+		{name: "multiple hangouts", hangouts: []domain.Hangout{{ID: uuid.New()}, {ID: uuid.New()}}, wantLen: 2},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := mapper.HangoutsToDeletedResponseDTOs(tc.hangouts, time.Hour)
+			require.NotNil(t, got)
+			require.Len(t, got, tc.wantLen)
+			for i := range tc.hangouts {
+				require.Equal(t, tc.hangouts[i].ID, got[i].ID)
+			}
+		})
+	}
+}
+
+func TestMemoryToDeletedResponseDTO(t *testing.T) {
+	deletedAt := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	retention := 7 * 24 * time.Hour
+
+	testCases := []struct {
+		name    string
+		memory  *domain.Memory
+		wantNil bool
+	}{
+		{name: "nil input", memory: nil, wantNil: true},
+		{
+			name: "deleted memory",
+			memory: &domain.Memory{
+				ID:        uuid.New(),
+				Name:      "photo.jpg",
+				HangoutID: uuid.New(),
+				DeletedAt: gorm.DeletedAt{Time: deletedAt, Valid: true},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := mapper.MemoryToDeletedResponseDTO(tc.memory, retention)
+			if tc.wantNil {
+				require.Nil(t, got)
+				return
+			}
+			require.Equal(t, tc.memory.ID, got.ID)
+			require.Equal(t, tc.memory.Name, got.Name)
+			require.Equal(t, tc.memory.HangoutID, got.HangoutID)
+			require.Equal(t, types.JSONTime(deletedAt), got.DeletedAt)
+			require.Equal(t, types.JSONTime(deletedAt.Add(retention)), got.PurgeAt)
+		})
+	}
+}
+
+func TestMemoriesToDeletedResponseDTOs(t *testing.T) {
+	testCases := []struct {
+		name     string
+		memories []domain.Memory
+		wantLen  int
+	}{
+		{name: "nil input", memories: nil, wantLen: 0},
+		{name: "multiple memories", memories: []domain.Memory{{ID: uuid.New()}, {ID: uuid.New()}, {ID: uuid.New()}}, wantLen: 3},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := mapper.MemoriesToDeletedResponseDTOs(tc.memories, time.Hour)
+			require.NotNil(t, got)
+			require.Len(t, got, tc.wantLen)
+			for i := range tc.memories {
+				require.Equal(t, tc.memories[i].ID, got[i].ID)
+			}
+		})
+	}
+}
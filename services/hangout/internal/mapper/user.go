@@ -14,8 +14,10 @@ func CreateUserRequestToModel(request dto.CreateUserRequest) domain.User {
 
 func UserToResponseDTO(user *domain.User) *dto.UserResponse {
 	return &dto.UserResponse{
-		ID:    user.ID,
-		Name:  user.Name,
-		Email: user.Email,
+		ID:            user.ID,
+		Name:          user.Name,
+		Email:         user.Email,
+		EmailVerified: user.EmailVerifiedAt != nil,
+		PendingEmail:  user.PendingEmail,
 	}
 }
@@ -2,6 +2,7 @@ package mapper_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -35,4 +36,18 @@ func TestUserToResponseDTO(t *testing.T) {
 	assert.Equal(t, user.ID, resp.ID)
 	assert.Equal(t, user.Name, resp.Name)
 	assert.Equal(t, user.Email, resp.Email)
+	assert.False(t, resp.EmailVerified)
+}
+
+func TestUserToResponseDTO_EmailVerified(t *testing.T) {
+	verifiedAt := time.Now()
+	user := &domain.User{
+		ID:              uuid.New(),
+		Email:           "carol@example.com",
+		EmailVerifiedAt: &verifiedAt,
+	}
+
+	resp := mapper.UserToResponseDTO(user)
+
+	assert.True(t, resp.EmailVerified)
 }
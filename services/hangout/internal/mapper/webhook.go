@@ -0,0 +1,77 @@
+package mapper
+
+import (
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/pkg/shared/types"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
+)
+
+func WebhookToResponseDTO(subscription *domain.WebhookSubscription) *dto.WebhookResponse {
+	if subscription == nil {
+		return nil
+	}
+
+	return &dto.WebhookResponse{
+		ID:         subscription.ID,
+		URL:        subscription.URL,
+		EventTypes: subscription.EventTypeList(),
+		CreatedAt:  types.JSONTime(subscription.CreatedAt),
+	}
+}
+
+func WebhooksToResponseDTOs(subscriptions []domain.WebhookSubscription) []*dto.WebhookResponse {
+	responses := make([]*dto.WebhookResponse, len(subscriptions))
+	for i := range subscriptions {
+		responses[i] = WebhookToResponseDTO(&subscriptions[i])
+	}
+	return responses
+}
+
+func WebhookToCreatedResponseDTO(subscription *domain.WebhookSubscription) *dto.WebhookCreatedResponse {
+	if subscription == nil {
+		return nil
+	}
+
+	return &dto.WebhookCreatedResponse{
+		WebhookResponse: *WebhookToResponseDTO(subscription),
+		Secret:          subscription.Secret,
+	}
+}
+
+func WebhookDeliveryToResponseDTO(delivery *domain.WebhookDelivery) *dto.WebhookDeliveryResponse {
+	if delivery == nil {
+		return nil
+	}
+
+	return &dto.WebhookDeliveryResponse{
+		ID:             delivery.ID,
+		EventID:        delivery.EventID,
+		EventType:      delivery.EventType,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		ResponseStatus: delivery.ResponseStatus,
+		LastError:      delivery.LastError,
+		NextAttemptAt:  optionalJSONTime(delivery.NextAttemptAt),
+		LastAttemptAt:  optionalJSONTime(delivery.LastAttemptAt),
+		CreatedAt:      types.JSONTime(delivery.CreatedAt),
+	}
+}
+
+func WebhookDeliveriesToResponseDTOs(deliveries []domain.WebhookDelivery) []*dto.WebhookDeliveryResponse {
+	responses := make([]*dto.WebhookDeliveryResponse, len(deliveries))
+	for i := range deliveries {
+		responses[i] = WebhookDeliveryToResponseDTO(&deliveries[i])
+	}
+	return responses
+}
+
+// optionalJSONTime maps a missing time to the zero value, which JSONTime
+// serializes as null.
+func optionalJSONTime(t *time.Time) types.JSONTime {
+	if t == nil {
+		return types.JSONTime{}
+	}
+	return types.JSONTime(*t)
+}
@@ -0,0 +1,85 @@
+package mapper_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/pkg/shared/types"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/mapper"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookToResponseDTO(t *testing.T) {
+	createdAt := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	subscription := &domain.WebhookSubscription{
+		ID:         uuid.New(),
+		URL:        "https://hooks.example.com/in",
+		Secret:     "whsec_secret",
+		EventTypes: "hangout.status_changed,memory.created",
+		CreatedAt:  createdAt,
+	}
+
+	require.Nil(t, mapper.WebhookToResponseDTO(nil))
+	require.Nil(t, mapper.WebhookToCreatedResponseDTO(nil))
+
+	got := mapper.WebhookToResponseDTO(subscription)
+	require.Equal(t, subscription.ID, got.ID)
+	require.Equal(t, subscription.URL, got.URL)
+	require.Equal(t, []string{"hangout.status_changed", "memory.created"}, got.EventTypes)
+	require.Equal(t, types.JSONTime(createdAt), got.CreatedAt)
+
+	created := mapper.WebhookToCreatedResponseDTO(subscription)
+	require.Equal(t, *got, created.WebhookResponse)
+	require.Equal(t, "whsec_secret", created.Secret)
+
+	require.Len(t, mapper.WebhooksToResponseDTOs([]domain.WebhookSubscription{*subscription, *subscription}), 2)
+}
+
+func TestWebhookDeliveryToResponseDTO(t *testing.T) {
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	status := 500
+
+	testCases := []struct {
+		name     string
+		delivery *domain.WebhookDelivery
+		wantNext types.JSONTime
+		wantLast types.JSONTime
+	}{
+		{
+			name: "pending delivery not attempted yet",
+			delivery: &domain.WebhookDelivery{
+				ID: uuid.New(), EventID: "evt", EventType: "memory.created",
+				Status: constants.WebhookDeliveryPending, NextAttemptAt: &now, CreatedAt: now,
+			},
+			wantNext: types.JSONTime(now),
+		},
+		{
+			name: "dead-lettered delivery",
+			delivery: &domain.WebhookDelivery{
+				ID: uuid.New(), EventID: "evt", EventType: "memory.created",
+				Status: constants.WebhookDeliveryDeadLettered, Attempts: 8, ResponseStatus: &status,
+				LastError: "boom", LastAttemptAt: &now, CreatedAt: now,
+			},
+			wantLast: types.JSONTime(now),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := mapper.WebhookDeliveryToResponseDTO(tc.delivery)
+			require.Equal(t, tc.delivery.ID, got.ID)
+			require.Equal(t, tc.delivery.Status, got.Status)
+			require.Equal(t, tc.delivery.Attempts, got.Attempts)
+			require.Equal(t, tc.delivery.ResponseStatus, got.ResponseStatus)
+			require.Equal(t, tc.delivery.LastError, got.LastError)
+			require.Equal(t, tc.wantNext, got.NextAttemptAt)
+			require.Equal(t, tc.wantLast, got.LastAttemptAt)
+		})
+	}
+
+	require.Nil(t, mapper.WebhookDeliveryToResponseDTO(nil))
+	require.Empty(t, mapper.WebhookDeliveriesToResponseDTOs(nil))
+}
@@ -0,0 +1,32 @@
+package middlewares
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/http/response"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/services"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// RequireAdmin lets only enabled admins through.
+func RequireAdmin(adminService services.AdminService, responseBuilder *response.Builder) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			userID, ok := c.Get("user_id").(uuid.UUID)
+			if !ok {
+				return echo.NewHTTPError(http.StatusUnauthorized, apperrors.ErrUnauthorized.Error())
+			}
+
+			if err := adminService.RequireAdmin(c.Request().Context(), userID); err != nil {
+				if errors.Is(err, apperrors.ErrAdminRequired) {
+					return c.JSON(http.StatusForbidden, responseBuilder.Error(err))
+				}
+				return c.JSON(http.StatusInternalServerError, responseBuilder.Error(err))
+			}
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package middlewares
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants/logmsg"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/http/response"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/services"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// replayedHeaders are the response headers stored with a key and sent again on
+// replays.
+var replayedHeaders = []string{echo.HeaderContentType, echo.HeaderLocation, constants.ETagHeader}
+
+// Idempotency replays the stored response when a request is retried with the same
+// Idempotency-Key header.
+func Idempotency(idempotencyService services.IdempotencyService, responseBuilder *response.Builder) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			key := c.Request().Header.Get(constants.IdempotencyKeyHeader)
+			if key == "" {
+				return next(c)
+			}
+			if len(key) > constants.MaxIdempotencyKeyLength {
+				return c.JSON(http.StatusBadRequest, responseBuilder.Error(apperrors.ErrInvalidIdempotencyKey))
+			}
+
+			userID, ok := c.Get("user_id").(uuid.UUID)
+			if !ok {
+				return c.JSON(http.StatusUnauthorized, responseBuilder.Error(apperrors.ErrUnauthorized))
+			}
+
+			body, err := io.ReadAll(io.LimitReader(c.Request().Body, constants.MaxIdempotentBodyBytes+1))
+			if err != nil {
+				return c.JSON(http.StatusBadRequest, responseBuilder.Error(apperrors.ErrInvalidPayload))
+			}
+			if len(body) > constants.MaxIdempotentBodyBytes {
+				return c.JSON(http.StatusRequestEntityTooLarge, responseBuilder.Error(apperrors.ErrRequestBodyTooLarge))
+			}
+			c.Request().Body = io.NopCloser(bytes.NewReader(body))
+
+			ctx := c.Request().Context()
+			record, err := idempotencyService.Begin(ctx, userID, key, requestFingerprint(c.Request(), body))
+			if err != nil {
+				switch err {
+				case apperrors.ErrIdempotencyKeyReused:
+					return c.JSON(http.StatusUnprocessableEntity, responseBuilder.Error(err))
+				case apperrors.ErrIdempotencyKeyInProgress:
+					return c.JSON(http.StatusConflict, responseBuilder.Error(err))
+				default:
+					return c.JSON(http.StatusInternalServerError, responseBuilder.Error(err))
+				}
+			}
+
+			if record.IsCompleted() {
+				header := c.Response().Header()
+				for name, value := range record.ResponseHeaders {
+					header.Set(name, value)
+				}
+				header.Set(constants.IdempotencyReplayedHeader, "true")
+				contentType := header.Get(echo.HeaderContentType)
+				if contentType == "" {
+					contentType = echo.MIMEApplicationJSON
+				}
+				return c.Blob(record.ResponseStatus, contentType, record.ResponseBody)
+			}
+
+			res := c.Response()
+			recorder := &responseRecorder{ResponseWriter: res.Writer}
+			res.Writer = recorder
+			defer func() {
+				if p := recover(); p != nil {
+					res.Writer = recorder.ResponseWriter
+					releaseKey(ctx, idempotencyService, record.ID)
+					panic(p)
+				}
+			}()
+			err = next(c)
+			res.Writer = recorder.ResponseWriter
+
+			// Server errors are not stored so the client can retry them with the same key.
+			if err != nil || res.Status >= http.StatusInternalServerError {
+				releaseKey(ctx, idempotencyService, record.ID)
+				return err
+			}
+
+			headers := make(map[string]string, len(replayedHeaders))
+			for _, name := range replayedHeaders {
+				if value := res.Header().Get(name); value != "" {
+					headers[name] = value
+				}
+			}
+			if completeErr := idempotencyService.Complete(ctx, record.ID, res.Status, headers, recorder.body.Bytes()); completeErr != nil {
+				log.Printf(logmsg.IdempotencyCompleteFailed, completeErr)
+			}
+			return nil
+		}
+	}
+}
+
+func releaseKey(ctx context.Context, idempotencyService services.IdempotencyService, id uuid.UUID) {
+	if err := idempotencyService.Release(ctx, id); err != nil {
+		log.Printf(logmsg.IdempotencyReleaseFailed, err)
+	}
+}
+
+// requestFingerprint identifies the request a key was first used with, so reusing
+// the key for a different target or payload can be rejected.
+func requestFingerprint(req *http.Request, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(req.Method))
+	hash.Write([]byte{0})
+	hash.Write([]byte(req.URL.Path))
+	hash.Write([]byte{0})
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+type responseRecorder struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
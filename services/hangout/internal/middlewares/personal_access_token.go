@@ -0,0 +1,63 @@
+package middlewares
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/http/response"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/services"
+	"github.com/labstack/echo/v4"
+)
+
+// PersonalAccessToken authenticates bearer tokens that carry the personal access
+// token prefix and leaves every other request to JWT, so it must run before JWT.
+func PersonalAccessToken(tokenService services.PersonalAccessTokenService, responseBuilder *response.Builder, readScope string, writeScope string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			bearer, ok := strings.CutPrefix(c.Request().Header.Get(echo.HeaderAuthorization), "Bearer ")
+			if !ok || !strings.HasPrefix(bearer, constants.PersonalAccessTokenPrefix) {
+				return next(c)
+			}
+
+			token, err := tokenService.Authenticate(c.Request().Context(), bearer)
+			if err != nil {
+				if errors.Is(err, apperrors.ErrInvalidPersonalAccessToken) {
+					return c.JSON(http.StatusUnauthorized, responseBuilder.Error(err))
+				}
+				if errors.Is(err, apperrors.ErrAccountDisabled) {
+					return c.JSON(http.StatusForbidden, responseBuilder.Error(err))
+				}
+				return c.JSON(http.StatusInternalServerError, responseBuilder.Error(err))
+			}
+
+			if !allowsMethod(token, c.Request().Method, readScope, writeScope) {
+				return c.JSON(http.StatusForbidden, responseBuilder.Error(apperrors.ErrInsufficientScope))
+			}
+
+			c.Set("user_id", token.UserID)
+			c.Set("token_id", token.ID)
+			c.Set("token_scopes", token.ScopeList())
+			if token.ExpiresAt != nil {
+				c.Set("token_expires_at", *token.ExpiresAt)
+			}
+			return next(c)
+		}
+	}
+}
+
+// authenticatedByToken reports whether PersonalAccessToken already
+// authenticated the request.
+func authenticatedByToken(c echo.Context) bool {
+	return c.Get("token_id") != nil
+}
+
+func allowsMethod(token *domain.PersonalAccessToken, method string, readScope string, writeScope string) bool {
+	if token.HasScope(writeScope) {
+		return true
+	}
+	return (method == http.MethodGet || method == http.MethodHead) && token.HasScope(readScope)
+}
@@ -0,0 +1,133 @@
+package middlewares
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/config"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants/logmsg"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/http/response"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/otel"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/ratelimit"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"golang.org/x/time/rate"
+)
+
+// ShareRateLimit limits the public share link routes per client IP.
+func ShareRateLimit(cfg *config.ShareConfig, responseBuilder *response.Builder) echo.MiddlewareFunc {
+	store := middleware.NewRateLimiterMemoryStoreWithConfig(middleware.RateLimiterMemoryStoreConfig{
+		Rate:  rate.Limit(cfg.GetRateLimit()),
+		Burst: cfg.RateLimitBurst,
+	})
+
+	return middleware.RateLimiterWithConfig(middleware.RateLimiterConfig{
+		Store: store,
+		ErrorHandler: func(c echo.Context, err error) error {
+			return c.JSON(http.StatusForbidden, responseBuilder.Error(apperrors.ErrForbidden))
+		},
+		DenyHandler: func(c echo.Context, identifier string, err error) error {
+			return c.JSON(http.StatusTooManyRequests, responseBuilder.Error(apperrors.ErrTooManyRequests))
+		},
+	})
+}
+
+// AuthRateLimit limits the auth routes per client IP and, when the JSON body has
+// an email, per account.
+func AuthRateLimit(guard *ratelimit.Guard, responseBuilder *response.Builder, metrics *otel.MetricsRecorder) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			decision, err := guard.Allow(c.Request().Context(), c.RealIP(), accountFromBody(c))
+			if err != nil {
+				log.Printf(logmsg.RateLimitCheckFailed, err)
+				return next(c)
+			}
+			if !decision.Allowed {
+				return tooManyRequests(c, decision, apperrors.ErrTooManyRequests, responseBuilder, metrics)
+			}
+			return next(c)
+		}
+	}
+}
+
+// SignInLockout rejects sign in for locked accounts and records the outcome
+// of each attempt: a 401 counts as a failure, a success clears the count.
+func SignInLockout(guard *ratelimit.Guard, responseBuilder *response.Builder, metrics *otel.MetricsRecorder) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			account := accountFromBody(c)
+			if account == "" {
+				return next(c)
+			}
+			ctx := c.Request().Context()
+
+			decision, err := guard.CheckLockout(ctx, account)
+			if err != nil {
+				log.Printf(logmsg.RateLimitCheckFailed, err)
+			} else if !decision.Allowed {
+				return tooManyRequests(c, decision, apperrors.ErrAccountLocked, responseBuilder, metrics)
+			}
+
+			if err := next(c); err != nil {
+				return err
+			}
+
+			switch status := c.Response().Status; {
+			case status == http.StatusUnauthorized:
+				lockout, err := guard.RecordFailure(ctx, account)
+				if err != nil {
+					log.Printf(logmsg.SignInAttemptRecordFailed, err)
+				} else if lockout > 0 {
+					log.Printf(logmsg.AccountLockedOut, lockout)
+				}
+			case status >= http.StatusOK && status < http.StatusMultipleChoices:
+				if err := guard.RecordSuccess(ctx, account); err != nil {
+					log.Printf(logmsg.SignInAttemptRecordFailed, err)
+				}
+			}
+			return nil
+		}
+	}
+}
+
+func tooManyRequests(c echo.Context, decision ratelimit.Decision, err error, responseBuilder *response.Builder, metrics *otel.MetricsRecorder) error {
+	seconds := int(math.Ceil(decision.RetryAfter.Seconds()))
+	c.Response().Header().Set(echo.HeaderRetryAfter, strconv.Itoa(max(seconds, 1)))
+	metrics.RecordRateLimitBlocked(c.Request().Context(), c.Path(), decision.Reason)
+	return c.JSON(http.StatusTooManyRequests, responseBuilder.Error(err))
+}
+
+// accountFromBody reads the email from a JSON body and puts the body back for the
+// handler.
+func accountFromBody(c echo.Context) string {
+	req := c.Request()
+	if req.Body == nil || !strings.HasPrefix(req.Header.Get(echo.HeaderContentType), echo.MIMEApplicationJSON) {
+		return ""
+	}
+	body, err := io.ReadAll(io.LimitReader(req.Body, constants.MaxRateLimitBodyBytes))
+	req.Body = readCloser{Reader: io.MultiReader(bytes.NewReader(body), req.Body), Closer: req.Body}
+	if err != nil {
+		return ""
+	}
+
+	var payload struct {
+		Email string `json:"email"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return ""
+	}
+	return payload.Email
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
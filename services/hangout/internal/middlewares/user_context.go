@@ -1,27 +1,51 @@
 package middlewares
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/auth"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/http/response"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/services"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 )
 
-func UserContextMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
-	return func(c echo.Context) error {
-		userToken, ok := c.Get("userId").(*jwt.Token)
-		if !ok || userToken == nil {
-			return echo.NewHTTPError(http.StatusUnauthorized, apperrors.ErrUnauthorized.Error())
-		}
+// UserContextMiddleware puts the token's user and session on the context after
+// checking that the session has not been signed out.
+func UserContextMiddleware(sessionService services.SessionService, responseBuilder *response.Builder) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if authenticatedByToken(c) {
+				return next(c)
+			}
 
-		claims, ok := userToken.Claims.(*auth.TokenCustomClaims)
-		if !ok || claims == nil {
-			return echo.NewHTTPError(http.StatusUnauthorized, apperrors.ErrUnauthorized.Error())
-		}
+			userToken, ok := c.Get("userId").(*jwt.Token)
+			if !ok || userToken == nil {
+				return echo.NewHTTPError(http.StatusUnauthorized, apperrors.ErrUnauthorized.Error())
+			}
+
+			claims, ok := userToken.Claims.(*auth.TokenCustomClaims)
+			if !ok || claims == nil || claims.SessionID == uuid.Nil {
+				return echo.NewHTTPError(http.StatusUnauthorized, apperrors.ErrUnauthorized.Error())
+			}
 
-		c.Set("user_id", claims.UserID)
-		return next(c)
+			if err := sessionService.Validate(c.Request().Context(), claims.UserID, claims.SessionID); err != nil {
+				if errors.Is(err, apperrors.ErrSessionRevoked) {
+					return c.JSON(http.StatusUnauthorized, responseBuilder.Error(err))
+				}
+				return c.JSON(http.StatusInternalServerError, responseBuilder.Error(err))
+			}
+
+			c.Set("user_id", claims.UserID)
+			c.Set("session_id", claims.SessionID)
+			if claims.ExpiresAt != nil {
+				// long-lived responses such as event streams end when the token does
+				c.Set("token_expires_at", claims.ExpiresAt.Time)
+			}
+			return next(c)
+		}
 	}
 }
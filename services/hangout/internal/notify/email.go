@@ -0,0 +1,31 @@
+package notify
+
+import (
+	"context"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/mailer"
+)
+
+type emailChannel struct {
+	sender mailer.Sender
+}
+
+// NewEmailChannel sends notifications as plain text mail to the address of
+// the user.
+func NewEmailChannel(sender mailer.Sender) Channel {
+	return &emailChannel{sender: sender}
+}
+
+func (c *emailChannel) Name() string {
+	return constants.NotificationChannelEmail
+}
+
+func (c *emailChannel) Send(ctx context.Context, msg *Message) error {
+	return c.sender.Send(ctx, &mailer.Message{
+		ToName:  msg.Name,
+		ToEmail: msg.Email,
+		Subject: msg.Subject,
+		Body:    msg.Body,
+	})
+}
@@ -0,0 +1,37 @@
+package notify
+
+import (
+	"context"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/repository"
+)
+
+type emailQueueChannel struct {
+	repo repository.NotificationRepository
+}
+
+// NewEmailQueueChannel queues notifications for the reminder job to mail, so
+// sending does not wait on the mail server.
+func NewEmailQueueChannel(repo repository.NotificationRepository) Channel {
+	return &emailQueueChannel{repo: repo}
+}
+
+func (c *emailQueueChannel) Name() string {
+	return constants.NotificationChannelEmail
+}
+
+func (c *emailQueueChannel) Send(ctx context.Context, msg *Message) error {
+	now := time.Now()
+	return c.repo.QueueEmail(ctx, &domain.NotificationEmail{
+		Type:          msg.Type,
+		Subject:       msg.Subject,
+		Body:          msg.Body,
+		Status:        constants.ReminderPending,
+		NextAttemptAt: &now,
+		HangoutID:     msg.HangoutID,
+		UserID:        msg.UserID,
+	})
+}
@@ -0,0 +1,32 @@
+package notify
+
+import (
+	"context"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/repository"
+)
+
+type inboxChannel struct {
+	repo repository.NotificationRepository
+}
+
+// NewInboxChannel stores notifications in the user's in-app inbox.
+func NewInboxChannel(repo repository.NotificationRepository) Channel {
+	return &inboxChannel{repo: repo}
+}
+
+func (c *inboxChannel) Name() string {
+	return constants.NotificationChannelInbox
+}
+
+func (c *inboxChannel) Send(ctx context.Context, msg *Message) error {
+	return c.repo.CreateNotification(ctx, &domain.Notification{
+		Type:      msg.Type,
+		Title:     msg.Subject,
+		Body:      msg.Body,
+		HangoutID: msg.HangoutID,
+		UserID:    msg.UserID,
+	})
+}
@@ -0,0 +1,45 @@
+// Package notify delivers user notifications over pluggable channels.
+package notify
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/google/uuid"
+)
+
+type Message struct {
+	Type      string
+	UserID    uuid.UUID
+	Name      string
+	Email     string
+	HangoutID *uuid.UUID
+	Subject   string
+	Body      string
+}
+
+type Channel interface {
+	// Name identifies the channel in configuration and in the record of which
+	// channels a notification was already delivered to.
+	Name() string
+	Send(ctx context.Context, msg *Message) error
+}
+
+// SelectChannels returns the channels named in names, in that order.
+func SelectChannels(names []string, available ...Channel) ([]Channel, error) {
+	byName := make(map[string]Channel, len(available))
+	for _, channel := range available {
+		byName[channel.Name()] = channel
+	}
+
+	channels := make([]Channel, 0, len(names))
+	for _, name := range names {
+		channel, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", apperrors.ErrUnknownNotificationChannel, name)
+		}
+		channels = append(channels, channel)
+	}
+	return channels, nil
+}
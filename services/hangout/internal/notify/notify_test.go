@@ -0,0 +1,198 @@
+package notify_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/mailer"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/notify"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingNotificationRepository only implements the methods the inbox and
+// email queue channels use.
+type recordingNotificationRepository struct {
+	repository.NotificationRepository
+	created []*domain.Notification
+	queued  []*domain.NotificationEmail
+	err     error
+}
+
+func (r *recordingNotificationRepository) CreateNotification(ctx context.Context, notification *domain.Notification) error {
+	r.created = append(r.created, notification)
+	return r.err
+}
+
+func (r *recordingNotificationRepository) QueueEmail(ctx context.Context, email *domain.NotificationEmail) error {
+	r.queued = append(r.queued, email)
+	return r.err
+}
+
+// recordingSender stores the mail it is asked to send.
+type recordingSender struct {
+	sent []*mailer.Message
+	err  error
+}
+
+func (s *recordingSender) Send(ctx context.Context, msg *mailer.Message) error {
+	s.sent = append(s.sent, msg)
+	return s.err
+}
+
+func TestEmailChannel_Send(t *testing.T) {
+	sender := &recordingSender{}
+	channel := notify.NewEmailChannel(sender)
+	require.Equal(t, constants.NotificationChannelEmail, channel.Name())
+
+	msg := &notify.Message{
+		Type:    constants.NotificationHangoutReminder,
+		UserID:  uuid.New(),
+		Name:    "Ann",
+		Email:   "ann@example.com",
+		Subject: "Reminder: Picnic is coming up",
+		Body:    "Picnic starts soon.",
+	}
+	require.NoError(t, channel.Send(context.Background(), msg))
+	require.Equal(t, []*mailer.Message{{
+		ToName:  "Ann",
+		ToEmail: "ann@example.com",
+		Subject: "Reminder: Picnic is coming up",
+		Body:    "Picnic starts soon.",
+	}}, sender.sent)
+
+	sender.err = errors.New("smtp down")
+	require.ErrorIs(t, channel.Send(context.Background(), msg), sender.err)
+}
+
+func TestInboxChannel_Send(t *testing.T) {
+	repo := &recordingNotificationRepository{}
+	channel := notify.NewInboxChannel(repo)
+	hangoutID := uuid.New()
+	msg := &notify.Message{
+		Type:      constants.NotificationHangoutReminder,
+		UserID:    uuid.New(),
+		HangoutID: &hangoutID,
+		Subject:   "Reminder: Picnic is coming up",
+		Body:      "Picnic starts soon.",
+	}
+
+	require.Equal(t, constants.NotificationChannelInbox, channel.Name())
+	require.NoError(t, channel.Send(context.Background(), msg))
+	require.Len(t, repo.created, 1)
+	require.Equal(t, &domain.Notification{
+		Type:      msg.Type,
+		Title:     msg.Subject,
+		Body:      msg.Body,
+		HangoutID: &hangoutID,
+		UserID:    msg.UserID,
+	}, repo.created[0])
+
+	repo.err = errors.New("db error")
+	require.ErrorIs(t, channel.Send(context.Background(), msg), repo.err)
+}
+
+func TestEmailQueueChannel_Send(t *testing.T) {
+	repo := &recordingNotificationRepository{}
+	channel := notify.NewEmailQueueChannel(repo)
+	hangoutID := uuid.New()
+	msg := &notify.Message{
+		Type:      constants.NotificationMemoryCreated,
+		UserID:    uuid.New(),
+		Email:     "ann@example.com",
+		HangoutID: &hangoutID,
+		Subject:   "New memory in Picnic",
+		Body:      "beach.jpg was added to Picnic.",
+	}
+
+	require.Equal(t, constants.NotificationChannelEmail, channel.Name())
+	require.NoError(t, channel.Send(context.Background(), msg))
+	require.Len(t, repo.queued, 1)
+	queued := repo.queued[0]
+	require.NotNil(t, queued.NextAttemptAt)
+	require.WithinDuration(t, time.Now(), *queued.NextAttemptAt, time.Minute)
+	queued.NextAttemptAt = nil
+	require.Equal(t, &domain.NotificationEmail{
+		Type:      msg.Type,
+		Subject:   msg.Subject,
+		Body:      msg.Body,
+		Status:    constants.ReminderPending,
+		HangoutID: &hangoutID,
+		UserID:    msg.UserID,
+	}, queued)
+
+	repo.err = errors.New("db error")
+	require.ErrorIs(t, channel.Send(context.Background(), msg), repo.err)
+}
+
+func TestSelectChannels(t *testing.T) {
+	email := notify.NewEmailChannel(&recordingSender{})
+	inbox := notify.NewInboxChannel(&recordingNotificationRepository{})
+
+	channels, err := notify.SelectChannels([]string{"inbox", "email"}, email, inbox)
+	require.NoError(t, err)
+	require.Equal(t, []notify.Channel{inbox, email}, channels)
+
+	channels, err = notify.SelectChannels([]string{"sms"}, email, inbox)
+	require.ErrorIs(t, err, apperrors.ErrUnknownNotificationChannel)
+	require.Nil(t, channels)
+}
+
+type channelFunc func(ctx context.Context, msg *notify.Message) error
+
+func (f channelFunc) Name() string { return constants.NotificationChannelEmail }
+
+func (f channelFunc) Send(ctx context.Context, msg *notify.Message) error { return f(ctx, msg) }
+
+type preferencesFunc func(userID uuid.UUID, notificationType string, channel string) (bool, error)
+
+func (f preferencesFunc) ChannelEnabled(ctx context.Context, userID uuid.UUID, notificationType string, channel string) (bool, error) {
+	return f(userID, notificationType, channel)
+}
+
+func TestWithPreferences(t *testing.T) {
+	userID := uuid.New()
+	prefsErr := errors.New("db error")
+	msg := &notify.Message{Type: constants.NotificationMemoryCreated, UserID: userID}
+
+	tests := []struct {
+		name     string
+		enabled  bool
+		err      error
+		wantSent bool
+	}{
+		{name: "enabled channel sends", enabled: true, wantSent: true},
+		{name: "disabled channel drops the message"},
+		{name: "preference lookup error", err: prefsErr},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sent := false
+			channel := channelFunc(func(ctx context.Context, got *notify.Message) error {
+				sent = true
+				return nil
+			})
+			prefs := preferencesFunc(func(gotUser uuid.UUID, notificationType string, name string) (bool, error) {
+				require.Equal(t, userID, gotUser)
+				require.Equal(t, constants.NotificationMemoryCreated, notificationType)
+				require.Equal(t, constants.NotificationChannelEmail, name)
+				return tt.enabled, tt.err
+			})
+
+			wrapped := notify.WithPreferences(prefs, channel)
+			require.Len(t, wrapped, 1)
+			require.Equal(t, constants.NotificationChannelEmail, wrapped[0].Name())
+
+			err := wrapped[0].Send(context.Background(), msg)
+			require.ErrorIs(t, err, tt.err)
+			require.Equal(t, tt.wantSent, sent)
+		})
+	}
+}
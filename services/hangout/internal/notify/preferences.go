@@ -0,0 +1,38 @@
+package notify
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Preferences decides whether a user wants a notification type on a channel.
+type Preferences interface {
+	ChannelEnabled(ctx context.Context, userID uuid.UUID, notificationType string, channel string) (bool, error)
+}
+
+type preferenceChannel struct {
+	Channel
+	prefs Preferences
+}
+
+// WithPreferences wraps channels so that a message the user opted out of is
+// dropped instead of sent. A dropped message counts as delivered.
+func WithPreferences(prefs Preferences, channels ...Channel) []Channel {
+	wrapped := make([]Channel, len(channels))
+	for i, channel := range channels {
+		wrapped[i] = &preferenceChannel{Channel: channel, prefs: prefs}
+	}
+	return wrapped
+}
+
+func (c *preferenceChannel) Send(ctx context.Context, msg *Message) error {
+	enabled, err := c.prefs.ChannelEnabled(ctx, msg.UserID, msg.Type, c.Name())
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		return nil
+	}
+	return c.Channel.Send(ctx, msg)
+}
@@ -0,0 +1,219 @@
+package oidc_test
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/signing"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	mockClientID     = "hangout-client"
+	mockClientSecret = "hangout-secret"
+	mockAccessToken  = "mock-access-token"
+)
+
+// mockUser is what the mock provider returns for an authorized code. Claims
+// left empty are not sent.
+type mockUser struct {
+	Subject       string
+	Email         string
+	EmailVerified any
+	Name          string
+	// UserInfo is returned from the userinfo endpoint instead of the claims
+	// above, to imitate providers like GitHub.
+	UserInfo map[string]any
+	// OmitEmailFromIDToken only returns the email from the userinfo
+	// endpoint.
+	OmitEmailFromIDToken bool
+}
+
+type mockGrant struct {
+	challenge   string
+	redirectURI string
+	nonce       string
+	user        mockUser
+}
+
+// mockProvider is a minimal OpenID Connect provider: discovery, JWKS, a
+// token endpoint that checks PKCE and a userinfo endpoint. Codes are issued
+// with Authorize instead of a login page.
+type mockProvider struct {
+	t      *testing.T
+	server *httptest.Server
+	keys   *signing.KeyRing
+	key    signing.Key
+
+	mu     sync.Mutex
+	grants map[string]mockGrant
+	user   mockUser
+}
+
+func newMockProvider(t *testing.T) *mockProvider {
+	t.Helper()
+	private, err := signing.GenerateKeyPair(constants.JWTAlgorithmRS256)
+	require.NoError(t, err)
+
+	p := &mockProvider{
+		t:      t,
+		keys:   signing.NewKeyRing(),
+		key:    signing.Key{ID: "mock-key", Algorithm: constants.JWTAlgorithmRS256, Private: private, Public: private.Public()},
+		grants: map[string]mockGrant{},
+	}
+	p.keys.Replace([]signing.Key{p.key})
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/openid-configuration", p.discovery)
+	mux.HandleFunc("GET /jwks", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, p.keys.JWKS())
+	})
+	mux.HandleFunc("POST /token", p.token)
+	mux.HandleFunc("GET /userinfo", p.userInfo)
+	p.server = httptest.NewServer(mux)
+	t.Cleanup(p.server.Close)
+	return p
+}
+
+func (p *mockProvider) Issuer() string {
+	return p.server.URL
+}
+
+// Authorize plays the user approving the request at authURL and returns the
+// code the provider would append to the redirect URL.
+func (p *mockProvider) Authorize(authURL string, user mockUser) string {
+	p.t.Helper()
+	req, err := http.NewRequest(http.MethodGet, authURL, nil)
+	require.NoError(p.t, err)
+	query := req.URL.Query()
+	require.Equal(p.t, mockClientID, query.Get("client_id"))
+	require.Equal(p.t, "code", query.Get("response_type"))
+	require.Equal(p.t, "S256", query.Get("code_challenge_method"))
+
+	code := "code-" + user.Subject + "-" + query.Get("state")[:8]
+	p.mu.Lock()
+	p.grants[code] = mockGrant{
+		challenge:   query.Get("code_challenge"),
+		redirectURI: query.Get("redirect_uri"),
+		nonce:       query.Get("nonce"),
+		user:        user,
+	}
+	p.mu.Unlock()
+	return code
+}
+
+func (p *mockProvider) discovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{
+		"issuer":                                p.server.URL,
+		"authorization_endpoint":                p.server.URL + "/authorize",
+		"token_endpoint":                        p.server.URL + "/token",
+		"userinfo_endpoint":                     p.server.URL + "/userinfo",
+		"jwks_uri":                              p.server.URL + "/jwks",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{constants.JWTAlgorithmRS256},
+		"code_challenge_methods_supported":      []string{"S256"},
+	})
+}
+
+func (p *mockProvider) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+	clientID, clientSecret, ok := r.BasicAuth()
+	if !ok {
+		clientID, clientSecret = r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+	}
+	if clientID != mockClientID || clientSecret != mockClientSecret {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+
+	p.mu.Lock()
+	grant, ok := p.grants[r.PostForm.Get("code")]
+	delete(p.grants, r.PostForm.Get("code"))
+	p.mu.Unlock()
+
+	sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	switch {
+	case !ok, r.PostForm.Get("grant_type") != "authorization_code",
+		r.PostForm.Get("redirect_uri") != grant.redirectURI,
+		base64.RawURLEncoding.EncodeToString(sum[:]) != grant.challenge:
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	claims := jwt.MapClaims{
+		"iss":   p.server.URL,
+		"aud":   mockClientID,
+		"sub":   grant.user.Subject,
+		"iat":   time.Now().Unix(),
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"nonce": grant.nonce,
+	}
+	if grant.user.Email != "" && !grant.user.OmitEmailFromIDToken {
+		claims["email"] = grant.user.Email
+	}
+	if grant.user.EmailVerified != nil && !grant.user.OmitEmailFromIDToken {
+		claims["email_verified"] = grant.user.EmailVerified
+	}
+	if grant.user.Name != "" {
+		claims["name"] = grant.user.Name
+	}
+	idToken := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	idToken.Header["kid"] = p.key.ID
+	signed, err := idToken.SignedString(p.key.Private)
+	require.NoError(p.t, err)
+
+	p.mu.Lock()
+	p.user = grant.user
+	p.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"access_token": mockAccessToken,
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+		"id_token":     signed,
+	})
+}
+
+func (p *mockProvider) userInfo(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "Bearer "+mockAccessToken {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	p.mu.Lock()
+	user := p.user
+	p.mu.Unlock()
+
+	if user.UserInfo != nil {
+		writeJSON(w, http.StatusOK, user.UserInfo)
+		return
+	}
+	info := map[string]any{"sub": user.Subject}
+	if user.Email != "" {
+		info["email"] = user.Email
+	}
+	if user.EmailVerified != nil {
+		info["email_verified"] = user.EmailVerified
+	}
+	if user.Name != "" {
+		info["name"] = user.Name
+	}
+	writeJSON(w, http.StatusOK, info)
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
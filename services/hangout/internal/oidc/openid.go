@@ -0,0 +1,114 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	gooidc "github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/oauth2"
+)
+
+// openIDProvider signs users in with OpenID Connect.
+type openIDProvider struct {
+	issuer   string
+	oauthCfg *oauth2.Config
+	client   *http.Client
+
+	mu       sync.Mutex
+	provider *gooidc.Provider
+	verifier *gooidc.IDTokenVerifier
+}
+
+type idTokenClaims struct {
+	Email         string       `json:"email"`
+	EmailVerified flexibleBool `json:"email_verified"`
+	Name          string       `json:"name"`
+}
+
+func newOpenIDProvider(issuer string, oauthCfg *oauth2.Config, client *http.Client) *openIDProvider {
+	return &openIDProvider{issuer: issuer, oauthCfg: oauthCfg, client: client}
+}
+
+func (p *openIDProvider) AuthCodeURL(ctx context.Context, state string, verifier string, nonce string) (string, error) {
+	if err := p.discover(ctx); err != nil {
+		return "", err
+	}
+	return p.oauthCfg.AuthCodeURL(state, oauth2.S256ChallengeOption(verifier), gooidc.Nonce(nonce)), nil
+}
+
+func (p *openIDProvider) Exchange(ctx context.Context, code string, verifier string, nonce string) (*Identity, error) {
+	if err := p.discover(ctx); err != nil {
+		return nil, err
+	}
+	ctx = clientContext(ctx, p.client)
+
+	token, err := p.oauthCfg.Exchange(ctx, code, oauth2.VerifierOption(verifier))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", apperrors.ErrOIDCExchangeFailed, err)
+	}
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		return nil, fmt.Errorf("%w: no id_token in token response", apperrors.ErrOIDCExchangeFailed)
+	}
+	idToken, err := p.verifier.Verify(ctx, rawIDToken)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", apperrors.ErrOIDCExchangeFailed, err)
+	}
+	if idToken.Nonce != nonce {
+		return nil, fmt.Errorf("%w: nonce mismatch", apperrors.ErrOIDCExchangeFailed)
+	}
+
+	var claims idTokenClaims
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, fmt.Errorf("%w: %v", apperrors.ErrOIDCExchangeFailed, err)
+	}
+
+	// providers may leave the email out of the ID token and only return it
+	// from the userinfo endpoint
+	if claims.Email == "" && p.provider.UserInfoEndpoint() != "" {
+		info, err := p.provider.UserInfo(ctx, oauth2.StaticTokenSource(token))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", apperrors.ErrOIDCExchangeFailed, err)
+		}
+		if err := info.Claims(&claims); err != nil {
+			return nil, fmt.Errorf("%w: %v", apperrors.ErrOIDCExchangeFailed, err)
+		}
+		if info.Subject != idToken.Subject {
+			return nil, fmt.Errorf("%w: userinfo subject mismatch", apperrors.ErrOIDCExchangeFailed)
+		}
+	}
+
+	return &Identity{
+		Subject:       idToken.Subject,
+		Email:         claims.Email,
+		EmailVerified: bool(claims.EmailVerified),
+		Name:          claims.Name,
+	}, nil
+}
+
+func (p *openIDProvider) discover(ctx context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.provider != nil {
+		return nil
+	}
+
+	// the provider keeps the context to refresh its signing keys, so it must
+	// not end with the request
+	provider, err := gooidc.NewProvider(clientContext(context.WithoutCancel(ctx), p.client), p.issuer)
+	if err != nil {
+		return fmt.Errorf("%w: %v", apperrors.ErrOIDCProviderUnavailable, err)
+	}
+	if provider.Endpoint().AuthURL == "" || provider.Endpoint().TokenURL == "" {
+		return fmt.Errorf("%w: %v", apperrors.ErrOIDCProviderUnavailable, errors.New("discovery document has no authorization or token endpoint"))
+	}
+
+	p.provider = provider
+	p.verifier = provider.Verifier(&gooidc.Config{ClientID: p.oauthCfg.ClientID})
+	p.oauthCfg.Endpoint = provider.Endpoint()
+	return nil
+}
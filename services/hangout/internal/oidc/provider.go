@@ -0,0 +1,60 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/config"
+	"golang.org/x/oauth2"
+)
+
+// Identity is the user an external provider signed in.
+type Identity struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+// Provider runs the authorization code flow with PKCE against one external
+// provider.
+type Provider interface {
+	// AuthCodeURL returns the URL that sends the user to the provider.
+	AuthCodeURL(ctx context.Context, state string, verifier string, nonce string) (string, error)
+	// Exchange redeems the code returned to the redirect URL and returns
+	// the signed in identity.
+	Exchange(ctx context.Context, code string, verifier string, nonce string) (*Identity, error)
+}
+
+func newProvider(cfg config.OIDCProviderConfig, redirectURL string, client *http.Client) Provider {
+	oauthCfg := &oauth2.Config{
+		ClientID:     cfg.ClientID,
+		ClientSecret: cfg.ClientSecret,
+		RedirectURL:  redirectURL,
+		Scopes:       cfg.Scopes,
+	}
+	if cfg.Issuer != "" {
+		return newOpenIDProvider(cfg.Issuer, oauthCfg, client)
+	}
+	oauthCfg.Endpoint = oauth2.Endpoint{AuthURL: cfg.AuthURL, TokenURL: cfg.TokenURL}
+	return newUserInfoProvider(cfg.UserInfoURL, oauthCfg, client)
+}
+
+// clientContext makes oauth2 and go-oidc send requests with client.
+func clientContext(ctx context.Context, client *http.Client) context.Context {
+	return context.WithValue(ctx, oauth2.HTTPClient, client)
+}
+
+// flexibleBool accepts both true and "true"; some providers send
+// email_verified as a string.
+type flexibleBool bool
+
+func (b *flexibleBool) UnmarshalJSON(data []byte) error {
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	*b = flexibleBool(value == true || value == "true")
+	return nil
+}
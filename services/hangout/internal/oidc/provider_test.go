@@ -0,0 +1,212 @@
+package oidc_test
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/config"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/oidc"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+const testRedirectURL = "http://localhost:3000/auth/callback"
+
+func newTestProvider(t *testing.T, providerCfg config.OIDCProviderConfig) oidc.Provider {
+	t.Helper()
+	providerCfg.Name = "mock"
+	providerCfg.ClientID = mockClientID
+	providerCfg.ClientSecret = mockClientSecret
+
+	registry, err := oidc.NewRegistry(&config.OIDCConfig{
+		Providers:   []config.OIDCProviderConfig{providerCfg},
+		RedirectURL: testRedirectURL,
+	})
+	require.NoError(t, err)
+	provider, err := registry.Get("mock")
+	require.NoError(t, err)
+	return provider
+}
+
+func TestOpenIDProvider_AuthCodeURL(t *testing.T) {
+	mock := newMockProvider(t)
+	provider := newTestProvider(t, config.OIDCProviderConfig{Issuer: mock.Issuer(), Scopes: []string{"openid", "email"}})
+
+	authURL, err := provider.AuthCodeURL(context.Background(), "state-value", "verifier-value", "nonce-value")
+	require.NoError(t, err)
+
+	parsed, err := url.Parse(authURL)
+	require.NoError(t, err)
+	require.Equal(t, mock.Issuer()+"/authorize", parsed.Scheme+"://"+parsed.Host+parsed.Path)
+	query := parsed.Query()
+	require.Equal(t, mockClientID, query.Get("client_id"))
+	require.Equal(t, testRedirectURL, query.Get("redirect_uri"))
+	require.Equal(t, "openid email", query.Get("scope"))
+	require.Equal(t, "state-value", query.Get("state"))
+	require.Equal(t, "nonce-value", query.Get("nonce"))
+	require.Equal(t, "S256", query.Get("code_challenge_method"))
+	require.Equal(t, oauth2.S256ChallengeFromVerifier("verifier-value"), query.Get("code_challenge"))
+}
+
+func TestOpenIDProvider_Exchange(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name         string
+		user         mockUser
+		wrongVerify  bool
+		wrongNonce   bool
+		wantIdentity *oidc.Identity
+		wantErr      error
+	}{
+		{
+			name:         "Success",
+			user:         mockUser{Subject: "user-1", Email: "ada@example.com", EmailVerified: true, Name: "Ada"},
+			wantIdentity: &oidc.Identity{Subject: "user-1", Email: "ada@example.com", EmailVerified: true, Name: "Ada"},
+		},
+		{
+			name:         "EmailVerifiedAsString",
+			user:         mockUser{Subject: "user-2", Email: "bob@example.com", EmailVerified: "true"},
+			wantIdentity: &oidc.Identity{Subject: "user-2", Email: "bob@example.com", EmailVerified: true},
+		},
+		{
+			name:         "UnverifiedEmail",
+			user:         mockUser{Subject: "user-3", Email: "cy@example.com", EmailVerified: false},
+			wantIdentity: &oidc.Identity{Subject: "user-3", Email: "cy@example.com"},
+		},
+		{
+			name:         "EmailFromUserInfo",
+			user:         mockUser{Subject: "user-4", Email: "dee@example.com", EmailVerified: true, OmitEmailFromIDToken: true},
+			wantIdentity: &oidc.Identity{Subject: "user-4", Email: "dee@example.com", EmailVerified: true},
+		},
+		{
+			name:        "WrongVerifier",
+			user:        mockUser{Subject: "user-5"},
+			wrongVerify: true,
+			wantErr:     apperrors.ErrOIDCExchangeFailed,
+		},
+		{
+			name:       "WrongNonce",
+			user:       mockUser{Subject: "user-6"},
+			wrongNonce: true,
+			wantErr:    apperrors.ErrOIDCExchangeFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := newMockProvider(t)
+			provider := newTestProvider(t, config.OIDCProviderConfig{Issuer: mock.Issuer(), Scopes: []string{"openid", "email"}})
+
+			verifier, nonce := oauth2.GenerateVerifier(), oauth2.GenerateVerifier()
+			authURL, err := provider.AuthCodeURL(ctx, oauth2.GenerateVerifier(), verifier, nonce)
+			require.NoError(t, err)
+			code := mock.Authorize(authURL, tt.user)
+
+			if tt.wrongVerify {
+				verifier = oauth2.GenerateVerifier()
+			}
+			if tt.wrongNonce {
+				nonce = oauth2.GenerateVerifier()
+			}
+			identity, err := provider.Exchange(ctx, code, verifier, nonce)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				require.Nil(t, identity)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.wantIdentity, identity)
+		})
+	}
+}
+
+func TestOpenIDProvider_CodeIsSingleUse(t *testing.T) {
+	ctx := context.Background()
+	mock := newMockProvider(t)
+	provider := newTestProvider(t, config.OIDCProviderConfig{Issuer: mock.Issuer(), Scopes: []string{"openid"}})
+
+	verifier, nonce := oauth2.GenerateVerifier(), oauth2.GenerateVerifier()
+	authURL, err := provider.AuthCodeURL(ctx, oauth2.GenerateVerifier(), verifier, nonce)
+	require.NoError(t, err)
+	code := mock.Authorize(authURL, mockUser{Subject: "user-1"})
+
+	_, err = provider.Exchange(ctx, code, verifier, nonce)
+	require.NoError(t, err)
+	_, err = provider.Exchange(ctx, code, verifier, nonce)
+	require.ErrorIs(t, err, apperrors.ErrOIDCExchangeFailed)
+}
+
+func TestOpenIDProvider_Unavailable(t *testing.T) {
+	mock := newMockProvider(t)
+	issuer := mock.Issuer()
+	mock.server.Close()
+	provider := newTestProvider(t, config.OIDCProviderConfig{Issuer: issuer})
+
+	_, err := provider.AuthCodeURL(context.Background(), "state", "verifier", "nonce")
+	require.ErrorIs(t, err, apperrors.ErrOIDCProviderUnavailable)
+
+	_, err = provider.Exchange(context.Background(), "code", "verifier", "nonce")
+	require.ErrorIs(t, err, apperrors.ErrOIDCProviderUnavailable)
+}
+
+func TestUserInfoProvider_Exchange(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name         string
+		userInfo     map[string]any
+		wantIdentity *oidc.Identity
+		wantErr      error
+	}{
+		{
+			name:         "GitHubStyle",
+			userInfo:     map[string]any{"id": 583231, "login": "octocat", "email": "octocat@example.com"},
+			wantIdentity: &oidc.Identity{Subject: "583231", Email: "octocat@example.com", Name: "octocat"},
+		},
+		{
+			name:         "PrefersSubAndName",
+			userInfo:     map[string]any{"sub": "abc", "id": 1, "name": "Octo Cat", "login": "octocat", "email_verified": true, "email": "octo@example.com"},
+			wantIdentity: &oidc.Identity{Subject: "abc", Email: "octo@example.com", EmailVerified: true, Name: "Octo Cat"},
+		},
+		{
+			name:         "PrivateEmail",
+			userInfo:     map[string]any{"id": 42, "login": "hidden", "email": nil},
+			wantIdentity: &oidc.Identity{Subject: "42", Name: "hidden"},
+		},
+		{
+			name:     "NoSubject",
+			userInfo: map[string]any{"login": "nobody"},
+			wantErr:  apperrors.ErrOIDCExchangeFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := newMockProvider(t)
+			provider := newTestProvider(t, config.OIDCProviderConfig{
+				AuthURL:     mock.Issuer() + "/authorize",
+				TokenURL:    mock.Issuer() + "/token",
+				UserInfoURL: mock.Issuer() + "/userinfo",
+			})
+
+			verifier := oauth2.GenerateVerifier()
+			authURL, err := provider.AuthCodeURL(ctx, oauth2.GenerateVerifier(), verifier, "ignored")
+			require.NoError(t, err)
+			require.NotContains(t, authURL, "nonce=")
+			code := mock.Authorize(authURL, mockUser{Subject: "gh", UserInfo: tt.userInfo})
+
+			identity, err := provider.Exchange(ctx, code, verifier, "ignored")
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.wantIdentity, identity)
+		})
+	}
+}
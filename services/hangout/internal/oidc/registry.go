@@ -0,0 +1,69 @@
+package oidc
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/config"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+)
+
+// Registry holds the configured sign in providers by name.
+type Registry struct {
+	providers map[string]Provider
+}
+
+// NewRegistry builds a provider for every configured entry.
+func NewRegistry(cfg *config.OIDCConfig) (*Registry, error) {
+	client := &http.Client{Timeout: time.Duration(constants.OIDCRequestTimeoutSeconds) * time.Second}
+
+	r := &Registry{providers: make(map[string]Provider, len(cfg.Providers))}
+	for _, providerCfg := range cfg.Providers {
+		if err := validateProvider(providerCfg); err != nil {
+			return nil, err
+		}
+		if _, ok := r.providers[providerCfg.Name]; ok {
+			return nil, fmt.Errorf("%w: %q is configured twice", apperrors.ErrInvalidOIDCProvider, providerCfg.Name)
+		}
+		r.Register(providerCfg.Name, newProvider(providerCfg, cfg.RedirectURL, client))
+	}
+	return r, nil
+}
+
+// Register adds or replaces the provider with the given name.
+func (r *Registry) Register(name string, provider Provider) {
+	r.providers[name] = provider
+}
+
+func (r *Registry) Get(name string) (Provider, error) {
+	provider, ok := r.providers[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", apperrors.ErrUnknownOIDCProvider, name)
+	}
+	return provider, nil
+}
+
+// Names returns the provider names in alphabetical order.
+func (r *Registry) Names() []string {
+	names := make([]string, 0, len(r.providers))
+	for name := range r.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func validateProvider(cfg config.OIDCProviderConfig) error {
+	switch {
+	case cfg.Name == "":
+		return fmt.Errorf("%w: provider name is empty", apperrors.ErrInvalidOIDCProvider)
+	case cfg.ClientID == "":
+		return fmt.Errorf("%w: %q has no client ID", apperrors.ErrInvalidOIDCProvider, cfg.Name)
+	case cfg.Issuer == "" && (cfg.AuthURL == "" || cfg.TokenURL == "" || cfg.UserInfoURL == ""):
+		return fmt.Errorf("%w: %q needs an issuer or auth, token and userinfo URLs", apperrors.ErrInvalidOIDCProvider, cfg.Name)
+	}
+	return nil
+}
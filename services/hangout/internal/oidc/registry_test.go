@@ -0,0 +1,71 @@
+package oidc_test
+
+import (
+	"testing"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/config"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/oidc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRegistry(t *testing.T) {
+	google := config.OIDCProviderConfig{Name: "google", Issuer: "https://accounts.google.com", ClientID: "id"}
+	github := config.OIDCProviderConfig{
+		Name:        "github",
+		ClientID:    "id",
+		AuthURL:     "https://github.com/login/oauth/authorize",
+		TokenURL:    "https://github.com/login/oauth/access_token",
+		UserInfoURL: "https://api.github.com/user",
+	}
+
+	tests := []struct {
+		name      string
+		providers []config.OIDCProviderConfig
+		wantNames []string
+		wantErr   error
+	}{
+		{name: "Empty", wantNames: []string{}},
+		{name: "IssuerAndEndpoints", providers: []config.OIDCProviderConfig{google, github}, wantNames: []string{"github", "google"}},
+		{
+			name:      "MissingClientID",
+			providers: []config.OIDCProviderConfig{{Name: "google", Issuer: "https://accounts.google.com"}},
+			wantErr:   apperrors.ErrInvalidOIDCProvider,
+		},
+		{
+			name:      "MissingEndpoints",
+			providers: []config.OIDCProviderConfig{{Name: "github", ClientID: "id", AuthURL: github.AuthURL, TokenURL: github.TokenURL}},
+			wantErr:   apperrors.ErrInvalidOIDCProvider,
+		},
+		{
+			name:      "Duplicate",
+			providers: []config.OIDCProviderConfig{google, google},
+			wantErr:   apperrors.ErrInvalidOIDCProvider,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry, err := oidc.NewRegistry(&config.OIDCConfig{Providers: tt.providers})
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.wantNames, registry.Names())
+			for _, name := range tt.wantNames {
+				_, err := registry.Get(name)
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRegistry_Get_Unknown(t *testing.T) {
+	registry, err := oidc.NewRegistry(&config.OIDCConfig{})
+	require.NoError(t, err)
+
+	_, err = registry.Get("myspace")
+	require.ErrorIs(t, err, apperrors.ErrUnknownOIDCProvider)
+}
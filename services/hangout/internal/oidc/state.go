@@ -0,0 +1,86 @@
+package oidc
+
+import (
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/signing"
+	"github.com/google/uuid"
+	"golang.org/x/oauth2"
+)
+
+// State is what the flow needs between sending the user to the provider and the
+// callback.
+type State struct {
+	Provider string `json:"p"`
+	Verifier string `json:"v"`
+	Nonce    string `json:"n"`
+	Binding  string `json:"b"`
+	// UserID is set when a signed in user links a provider, and nil when
+	// the flow signs a user in.
+	UserID    *uuid.UUID `json:"u,omitempty"`
+	ExpiresAt int64      `json:"e"`
+}
+
+// StateCodec creates, seals and opens states.
+type StateCodec struct {
+	sealer *signing.Sealer
+	ttl    time.Duration
+	now    func() time.Time
+}
+
+func NewStateCodec(sealer *signing.Sealer, ttl time.Duration) *StateCodec {
+	return &StateCodec{sealer: sealer, ttl: ttl, now: time.Now}
+}
+
+// New returns a state for provider with a fresh PKCE verifier, nonce and binding.
+func (c *StateCodec) New(provider string, userID *uuid.UUID) *State {
+	return &State{
+		Provider:  provider,
+		Verifier:  oauth2.GenerateVerifier(),
+		Nonce:     oauth2.GenerateVerifier(),
+		Binding:   oauth2.GenerateVerifier(),
+		UserID:    userID,
+		ExpiresAt: c.now().Add(c.ttl).Unix(),
+	}
+}
+
+func (c *StateCodec) Encode(state *State) (string, error) {
+	payload, err := json.Marshal(state)
+	if err != nil {
+		return "", err
+	}
+	sealed, err := c.sealer.Seal(payload)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(sealed), nil
+}
+
+// Decode opens a state returned by the provider and checks it against the binding
+// the calling browser presented.
+func (c *StateCodec) Decode(value string, binding string) (*State, error) {
+	sealed, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, apperrors.ErrInvalidOIDCState
+	}
+	payload, err := c.sealer.Open(sealed)
+	if err != nil {
+		return nil, apperrors.ErrInvalidOIDCState
+	}
+
+	var state State
+	if err := json.Unmarshal(payload, &state); err != nil {
+		return nil, apperrors.ErrInvalidOIDCState
+	}
+	if c.now().Unix() >= state.ExpiresAt {
+		return nil, apperrors.ErrInvalidOIDCState
+	}
+	if state.Binding == "" || subtle.ConstantTimeCompare([]byte(state.Binding), []byte(binding)) != 1 {
+		return nil, apperrors.ErrInvalidOIDCState
+	}
+	return &state, nil
+}
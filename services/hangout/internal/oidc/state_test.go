@@ -0,0 +1,86 @@
+package oidc_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/oidc"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/signing"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+const stateTestSecret = "a-very-long-and-secure-secret-key-for-testing"
+
+func newStateCodec(t *testing.T, secret string, purpose string, ttl time.Duration) *oidc.StateCodec {
+	t.Helper()
+	sealer, err := signing.NewSealer(secret, purpose)
+	require.NoError(t, err)
+	return oidc.NewStateCodec(sealer, ttl)
+}
+
+func TestStateCodec_RoundTrip(t *testing.T) {
+	codec := newStateCodec(t, stateTestSecret, constants.OIDCStateSealPurpose, 10*time.Minute)
+	userID := uuid.New()
+
+	for _, id := range []*uuid.UUID{nil, &userID} {
+		state := codec.New("google", id)
+		require.NotEmpty(t, state.Verifier)
+		require.NotEmpty(t, state.Nonce)
+		require.NotEmpty(t, state.Binding)
+		require.NotEqual(t, state.Verifier, state.Nonce)
+		require.NotEqual(t, state.Nonce, state.Binding)
+
+		encoded, err := codec.Encode(state)
+		require.NoError(t, err)
+		require.NotContains(t, encoded, state.Verifier)
+		require.NotContains(t, encoded, state.Binding)
+
+		decoded, err := codec.Decode(encoded, state.Binding)
+		require.NoError(t, err)
+		require.Equal(t, state, decoded)
+	}
+}
+
+func TestStateCodec_Decode_Invalid(t *testing.T) {
+	codec := newStateCodec(t, stateTestSecret, constants.OIDCStateSealPurpose, 10*time.Minute)
+	state := codec.New("google", nil)
+	valid, err := codec.Encode(state)
+	require.NoError(t, err)
+	// another browser starting its own flow holds a different binding
+	otherClient := codec.New("google", nil).Binding
+
+	expiredCodec := newStateCodec(t, stateTestSecret, constants.OIDCStateSealPurpose, -time.Minute)
+	expired, err := expiredCodec.Encode(expiredCodec.New("google", nil))
+	require.NoError(t, err)
+
+	otherPurpose := newStateCodec(t, stateTestSecret, constants.SigningKeySealPurpose, 10*time.Minute)
+	foreign, err := otherPurpose.Encode(otherPurpose.New("google", nil))
+	require.NoError(t, err)
+
+	tampered := []byte(valid)
+	tampered[len(tampered)/2] ^= 1
+
+	tests := []struct {
+		name    string
+		value   string
+		binding string
+	}{
+		{name: "Expired", value: expired, binding: state.Binding},
+		{name: "OtherPurpose", value: foreign, binding: state.Binding},
+		{name: "Tampered", value: string(tampered), binding: state.Binding},
+		{name: "NotBase64", value: "not base64!", binding: state.Binding},
+		{name: "Empty", value: "", binding: state.Binding},
+		{name: "OtherClient", value: valid, binding: otherClient},
+		{name: "NoBinding", value: valid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := codec.Decode(tt.value, tt.binding)
+			require.ErrorIs(t, err, apperrors.ErrInvalidOIDCState)
+		})
+	}
+}
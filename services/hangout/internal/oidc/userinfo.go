@@ -0,0 +1,98 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"golang.org/x/oauth2"
+)
+
+// userInfoProvider signs users in with plain OAuth2 and reads the user from a
+// userinfo URL, for providers like GitHub that do not issue ID tokens.
+type userInfoProvider struct {
+	userInfoURL string
+	oauthCfg    *oauth2.Config
+	client      *http.Client
+}
+
+func newUserInfoProvider(userInfoURL string, oauthCfg *oauth2.Config, client *http.Client) *userInfoProvider {
+	return &userInfoProvider{userInfoURL: userInfoURL, oauthCfg: oauthCfg, client: client}
+}
+
+func (p *userInfoProvider) AuthCodeURL(ctx context.Context, state string, verifier string, nonce string) (string, error) {
+	return p.oauthCfg.AuthCodeURL(state, oauth2.S256ChallengeOption(verifier)), nil
+}
+
+func (p *userInfoProvider) Exchange(ctx context.Context, code string, verifier string, nonce string) (*Identity, error) {
+	ctx = clientContext(ctx, p.client)
+
+	token, err := p.oauthCfg.Exchange(ctx, code, oauth2.VerifierOption(verifier))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", apperrors.ErrOIDCExchangeFailed, err)
+	}
+
+	info, err := p.fetchUserInfo(ctx, token)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", apperrors.ErrOIDCExchangeFailed, err)
+	}
+
+	identity := &Identity{
+		Subject:       claimString(info, "sub", "id"),
+		Email:         claimString(info, "email"),
+		EmailVerified: info["email_verified"] == true || info["email_verified"] == "true",
+		Name:          claimString(info, "name", "login"),
+	}
+	if identity.Subject == "" {
+		return nil, fmt.Errorf("%w: userinfo has no subject", apperrors.ErrOIDCExchangeFailed)
+	}
+	return identity, nil
+}
+
+func (p *userInfoProvider) fetchUserInfo(ctx context.Context, token *oauth2.Token) (map[string]any, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.userInfoURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.oauthCfg.Client(ctx, token).Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("userinfo returned status %d", resp.StatusCode)
+	}
+
+	decoder := json.NewDecoder(io.LimitReader(resp.Body, constants.MaxOIDCUserInfoBytes))
+	decoder.UseNumber()
+	var info map[string]any
+	if err := decoder.Decode(&info); err != nil {
+		return nil, err
+	}
+	if info == nil {
+		return nil, errors.New("userinfo is empty")
+	}
+	return info, nil
+}
+
+// claimString returns the first of keys that is a non-empty string or number.
+func claimString(info map[string]any, keys ...string) string {
+	for _, key := range keys {
+		switch value := info[key].(type) {
+		case string:
+			if value != "" {
+				return value
+			}
+		case json.Number:
+			return value.String()
+		}
+	}
+	return ""
+}
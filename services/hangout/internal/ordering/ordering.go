@@ -0,0 +1,225 @@
+// Package ordering generates fractional index keys for manually ordered
+// lists. A key sorts byte-wise, and a new key can always be generated between
+// two existing ones, so moving an item only rewrites that item's key.
+//
+// Keys consist of an integer part, whose first character encodes its length,
+// followed by an optional fraction. Appending to the end of a list increments
+// the integer part, which keeps keys short for the common case.
+package ordering
+
+import (
+	"strings"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+)
+
+const (
+	digits          = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+	firstKey        = "a0"
+	smallestInteger = "A00000000000000000000000000"
+)
+
+// KeyBetween returns a key that sorts after a and before b.
+func KeyBetween(a, b string) (string, error) {
+	if a != "" {
+		if err := validateKey(a); err != nil {
+			return "", err
+		}
+	}
+	if b != "" {
+		if err := validateKey(b); err != nil {
+			return "", err
+		}
+	}
+	if a != "" && b != "" && a >= b {
+		return "", apperrors.ErrInvalidOrderKey
+	}
+
+	if a == "" {
+		if b == "" {
+			return firstKey, nil
+		}
+		ib, _ := integerPart(b)
+		fb := b[len(ib):]
+		if ib == smallestInteger {
+			return ib + midpoint("", fb), nil
+		}
+		if ib < b {
+			return ib, nil
+		}
+		res, ok := decrementInteger(ib)
+		if !ok {
+			return "", apperrors.ErrInvalidOrderKey
+		}
+		return res, nil
+	}
+
+	ia, _ := integerPart(a)
+	fa := a[len(ia):]
+	if b == "" {
+		i, ok := incrementInteger(ia)
+		if !ok {
+			return ia + midpoint(fa, ""), nil
+		}
+		return i, nil
+	}
+
+	ib, _ := integerPart(b)
+	fb := b[len(ib):]
+	if ia == ib {
+		return ia + midpoint(fa, fb), nil
+	}
+	i, ok := incrementInteger(ia)
+	if !ok {
+		return "", apperrors.ErrInvalidOrderKey
+	}
+	if i < b {
+		return i, nil
+	}
+	return ia + midpoint(fa, ""), nil
+}
+
+// midpoint returns a fraction between a and b.
+func midpoint(a, b string) string {
+	if b != "" {
+		n := 0
+		for n < len(b) {
+			ca := byte('0')
+			if n < len(a) {
+				ca = a[n]
+			}
+			if ca != b[n] {
+				break
+			}
+			n++
+		}
+		if n > 0 {
+			rest := ""
+			if n < len(a) {
+				rest = a[n:]
+			}
+			return b[:n] + midpoint(rest, b[n:])
+		}
+	}
+
+	digitA := 0
+	if a != "" {
+		digitA = strings.IndexByte(digits, a[0])
+	}
+	digitB := len(digits)
+	if b != "" {
+		digitB = strings.IndexByte(digits, b[0])
+	}
+	if digitB-digitA > 1 {
+		return string(digits[(digitA+digitB+1)/2])
+	}
+	if len(b) > 1 {
+		return b[:1]
+	}
+	rest := ""
+	if a != "" {
+		rest = a[1:]
+	}
+	return string(digits[digitA]) + midpoint(rest, "")
+}
+
+func integerLength(head byte) (int, bool) {
+	switch {
+	case head >= 'a' && head <= 'z':
+		return int(head-'a') + 2, true
+	case head >= 'A' && head <= 'Z':
+		return int('Z'-head) + 2, true
+	}
+	return 0, false
+}
+
+func integerPart(key string) (string, bool) {
+	n, ok := integerLength(key[0])
+	if !ok || n > len(key) {
+		return "", false
+	}
+	return key[:n], true
+}
+
+func validateKey(key string) error {
+	if key == smallestInteger {
+		return apperrors.ErrInvalidOrderKey
+	}
+	i, ok := integerPart(key)
+	if !ok {
+		return apperrors.ErrInvalidOrderKey
+	}
+	for j := 1; j < len(key); j++ {
+		if strings.IndexByte(digits, key[j]) < 0 {
+			return apperrors.ErrInvalidOrderKey
+		}
+	}
+	if strings.HasSuffix(key[len(i):], "0") {
+		return apperrors.ErrInvalidOrderKey
+	}
+	return nil
+}
+
+// incrementInteger returns the next integer part.
+func incrementInteger(x string) (string, bool) {
+	head := x[0]
+	digs := []byte(x[1:])
+	carry := true
+	for i := len(digs) - 1; carry && i >= 0; i-- {
+		d := strings.IndexByte(digits, digs[i]) + 1
+		if d == len(digits) {
+			digs[i] = '0'
+		} else {
+			digs[i] = digits[d]
+			carry = false
+		}
+	}
+	if !carry {
+		return string(head) + string(digs), true
+	}
+	if head == 'Z' {
+		return "a0", true
+	}
+	if head == 'z' {
+		return "", false
+	}
+	next := head + 1
+	if next > 'a' {
+		digs = append(digs, '0')
+	} else {
+		digs = digs[:len(digs)-1]
+	}
+	return string(next) + string(digs), true
+}
+
+// decrementInteger returns the previous integer part.
+func decrementInteger(x string) (string, bool) {
+	head := x[0]
+	digs := []byte(x[1:])
+	borrow := true
+	for i := len(digs) - 1; borrow && i >= 0; i-- {
+		d := strings.IndexByte(digits, digs[i]) - 1
+		if d == -1 {
+			digs[i] = digits[len(digits)-1]
+		} else {
+			digs[i] = digits[d]
+			borrow = false
+		}
+	}
+	if !borrow {
+		return string(head) + string(digs), true
+	}
+	if head == 'a' {
+		return "Z" + string(digits[len(digits)-1]), true
+	}
+	if head == 'A' {
+		return "", false
+	}
+	prev := head - 1
+	if prev < 'Z' {
+		digs = append(digs, digits[len(digits)-1])
+	} else {
+		digs = digs[:len(digs)-1]
+	}
+	return string(prev) + string(digs), true
+}
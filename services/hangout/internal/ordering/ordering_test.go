@@ -0,0 +1,86 @@
+package ordering_test
+
+import (
+	"testing"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/ordering"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyBetween(t *testing.T) {
+	tests := []struct {
+		name string
+		a    string
+		b    string
+		want string
+	}{
+		{name: "empty list", a: "", b: "", want: "a0"},
+		{name: "before first", a: "", b: "a0", want: "Zz"},
+		{name: "after last", a: "a0", b: "", want: "a1"},
+		{name: "integer overflow to next length", a: "az", b: "", want: "b00"},
+		{name: "between adjacent integers", a: "a0", b: "a1", want: "a0V"},
+		{name: "between integers with room", a: "a0", b: "a2", want: "a1"},
+		{name: "between fractions", a: "a0V", b: "a1", want: "a0l"},
+		{name: "between adjacent fractions", a: "a0V", b: "a0W", want: "a0VV"},
+		{name: "before fraction", a: "", b: "a0V", want: "a0"},
+		{name: "legacy backfilled keys", a: "d0001", b: "d0002", want: "d0001V"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ordering.KeyBetween(tt.a, tt.b)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+			if tt.a != "" {
+				require.Less(t, tt.a, got)
+			}
+			if tt.b != "" {
+				require.Less(t, got, tt.b)
+			}
+		})
+	}
+}
+
+func TestKeyBetween_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		a    string
+		b    string
+	}{
+		{name: "a not before b", a: "a1", b: "a0"},
+		{name: "equal keys", a: "a1", b: "a1"},
+		{name: "trailing zero", a: "a10", b: ""},
+		{name: "bad head", a: "0", b: ""},
+		{name: "truncated integer", a: "c1", b: ""},
+		{name: "bad digit", a: "a-", b: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ordering.KeyBetween(tt.a, tt.b)
+			require.ErrorIs(t, err, apperrors.ErrInvalidOrderKey)
+		})
+	}
+}
+
+func TestKeyBetween_RepeatedInserts(t *testing.T) {
+	keys := []string{}
+	last := ""
+	for range 1000 {
+		key, err := ordering.KeyBetween(last, "")
+		require.NoError(t, err)
+		keys = append(keys, key)
+		last = key
+	}
+	require.LessOrEqual(t, len(last), 4)
+
+	lo, hi := keys[0], keys[1]
+	for range 200 {
+		mid, err := ordering.KeyBetween(lo, hi)
+		require.NoError(t, err)
+		require.Less(t, lo, mid)
+		require.Less(t, mid, hi)
+		hi = mid
+	}
+}
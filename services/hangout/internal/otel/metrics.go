@@ -23,8 +23,9 @@ type MeterProvider struct {
 
 type Metrics struct {
 	// Auth metrics
-	AuthCounter  metric.Int64Counter
-	AuthDuration metric.Float64Histogram
+	AuthCounter      metric.Int64Counter
+	AuthDuration     metric.Float64Histogram
+	RateLimitBlocked metric.Int64Counter
 
 	// Handler metrics (RED: Rate, Errors, Duration)
 	RequestCounter  metric.Int64Counter
@@ -123,6 +124,15 @@ func InitMetrics() (*Metrics, error) {
 		return nil, err
 	}
 
+	rateLimitBlocked, err := meter.Int64Counter(
+		"hangout.ratelimit.blocked",
+		metric.WithDescription("Number of requests blocked by rate limits or account lockouts"),
+		metric.WithUnit("{request}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	requestCounter, err := meter.Int64Counter(
 		"hangout.requests.total",
 		metric.WithDescription("Total number of HTTP requests"),
@@ -193,6 +203,7 @@ func InitMetrics() (*Metrics, error) {
 	return &Metrics{
 		AuthCounter:         authCounter,
 		AuthDuration:        authDuration,
+		RateLimitBlocked:    rateLimitBlocked,
 		RequestCounter:      requestCounter,
 		RequestDuration:     requestDuration,
 		ActiveRequests:      activeRequests,
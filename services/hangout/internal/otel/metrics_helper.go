@@ -33,6 +33,18 @@ func (mr *MetricsRecorder) RecordAuth(ctx context.Context, operation string, sta
 	))
 }
 
+// RecordRateLimitBlocked counts a request rejected on route, with reason
+// naming the limit that was hit.
+func (mr *MetricsRecorder) RecordRateLimitBlocked(ctx context.Context, route string, reason string) {
+	if mr == nil || mr.metrics == nil {
+		return
+	}
+	mr.metrics.RateLimitBlocked.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("route", route),
+		attribute.String("reason", reason),
+	))
+}
+
 func (mr *MetricsRecorder) StartRequest(ctx context.Context, domain string, operation string) func(string) {
 	if mr == nil || mr.metrics == nil {
 		return func(string) {}
@@ -0,0 +1,25 @@
+package pubsub
+
+import (
+	"context"
+	"errors"
+)
+
+type fanoutPublisher struct {
+	publishers []Publisher
+}
+
+// NewFanoutPublisher publishes every event to all publishers.
+func NewFanoutPublisher(publishers ...Publisher) Publisher {
+	return &fanoutPublisher{publishers: publishers}
+}
+
+func (p *fanoutPublisher) Publish(ctx context.Context, topic string, event Event) error {
+	var errs []error
+	for _, publisher := range p.publishers {
+		if err := publisher.Publish(ctx, topic, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
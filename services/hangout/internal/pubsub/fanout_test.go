@@ -0,0 +1,38 @@
+package pubsub_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/pubsub"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+type publisherFunc func(ctx context.Context, topic string, event pubsub.Event) error
+
+func (f publisherFunc) Publish(ctx context.Context, topic string, event pubsub.Event) error {
+	return f(ctx, topic, event)
+}
+
+func TestFanoutPublisher_PublishesToAll(t *testing.T) {
+	failure := errors.New("webhooks unavailable")
+	var received []string
+
+	publisher := pubsub.NewFanoutPublisher(
+		publisherFunc(func(_ context.Context, topic string, _ pubsub.Event) error {
+			received = append(received, "first:"+topic)
+			return failure
+		}),
+		publisherFunc(func(_ context.Context, topic string, _ pubsub.Event) error {
+			received = append(received, "second:"+topic)
+			return nil
+		}),
+	)
+
+	err := publisher.Publish(context.Background(), "topic", pubsub.NewHangoutEvent(uuid.New(), "hangout.updated", nil))
+
+	require.ErrorIs(t, err, failure)
+	require.Equal(t, []string{"first:topic", "second:topic"}, received)
+}
@@ -0,0 +1,123 @@
+package pubsub
+
+import (
+	"context"
+	"sync"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+)
+
+type subscription struct {
+	ch   chan Event
+	once sync.Once
+}
+
+func (s *subscription) close() {
+	s.once.Do(func() { close(s.ch) })
+}
+
+type inMemoryBroker struct {
+	mu         sync.RWMutex
+	topics     map[string]map[*subscription]struct{}
+	bufferSize int
+	closed     bool
+}
+
+// NewInMemoryBroker returns a Broker that fans events out to subscribers in the
+// same process.
+func NewInMemoryBroker(bufferSize int) Broker {
+	if bufferSize < 1 {
+		bufferSize = 1
+	}
+	return &inMemoryBroker{
+		topics:     make(map[string]map[*subscription]struct{}),
+		bufferSize: bufferSize,
+	}
+}
+
+func (b *inMemoryBroker) Publish(ctx context.Context, topic string, event Event) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return apperrors.ErrEventBrokerClosed
+	}
+
+	for sub := range b.topics[topic] {
+		select {
+		case sub.ch <- event:
+		default:
+			b.removeLocked(topic, sub)
+		}
+	}
+	return nil
+}
+
+func (b *inMemoryBroker) Subscribe(ctx context.Context, topic string) (<-chan Event, func(), error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return nil, nil, apperrors.ErrEventBrokerClosed
+	}
+
+	sub := &subscription{ch: make(chan Event, b.bufferSize)}
+	if b.topics[topic] == nil {
+		b.topics[topic] = make(map[*subscription]struct{})
+	}
+	b.topics[topic][sub] = struct{}{}
+
+	done := make(chan struct{})
+	var cancelOnce sync.Once
+	cancel := func() {
+		cancelOnce.Do(func() {
+			close(done)
+			b.mu.Lock()
+			b.removeLocked(topic, sub)
+			b.mu.Unlock()
+		})
+	}
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			cancel()
+		case <-done:
+		}
+	}()
+
+	return sub.ch, cancel, nil
+}
+
+func (b *inMemoryBroker) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return nil
+	}
+	b.closed = true
+
+	for topic, subs := range b.topics {
+		for sub := range subs {
+			sub.close()
+		}
+		delete(b.topics, topic)
+	}
+	return nil
+}
+
+func (b *inMemoryBroker) removeLocked(topic string, sub *subscription) {
+	subs, ok := b.topics[topic]
+	if !ok {
+		return
+	}
+	if _, ok := subs[sub]; !ok {
+		return
+	}
+	delete(subs, sub)
+	sub.close()
+	if len(subs) == 0 {
+		delete(b.topics, topic)
+	}
+}
@@ -0,0 +1,122 @@
+package pubsub_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/pubsub"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func receive(t *testing.T, ch <-chan pubsub.Event) (pubsub.Event, bool) {
+	t.Helper()
+	select {
+	case event, ok := <-ch:
+		return event, ok
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for event")
+		return pubsub.Event{}, false
+	}
+}
+
+func TestInMemoryBroker_PublishSubscribe(t *testing.T) {
+	ctx := context.Background()
+	broker := pubsub.NewInMemoryBroker(4)
+	hangoutID := uuid.New()
+	topic := pubsub.HangoutTopic(hangoutID)
+
+	first, cancelFirst, err := broker.Subscribe(ctx, topic)
+	require.NoError(t, err)
+	defer cancelFirst()
+	second, cancelSecond, err := broker.Subscribe(ctx, topic)
+	require.NoError(t, err)
+	defer cancelSecond()
+	other, cancelOther, err := broker.Subscribe(ctx, pubsub.HangoutTopic(uuid.New()))
+	require.NoError(t, err)
+	defer cancelOther()
+
+	event := pubsub.NewHangoutEvent(hangoutID, "hangout.updated", map[string]string{"title": "Picnic"})
+	require.NoError(t, broker.Publish(ctx, topic, event))
+
+	got, ok := receive(t, first)
+	require.True(t, ok)
+	require.Equal(t, event, got)
+
+	got, ok = receive(t, second)
+	require.True(t, ok)
+	require.Equal(t, event.ID, got.ID)
+
+	require.Len(t, other, 0)
+}
+
+func TestInMemoryBroker_CancelClosesChannel(t *testing.T) {
+	broker := pubsub.NewInMemoryBroker(1)
+	topic := pubsub.HangoutTopic(uuid.New())
+
+	events, cancel, err := broker.Subscribe(context.Background(), topic)
+	require.NoError(t, err)
+
+	cancel()
+	cancel()
+
+	_, ok := receive(t, events)
+	require.False(t, ok)
+	require.NoError(t, broker.Publish(context.Background(), topic, pubsub.Event{ID: "late"}))
+}
+
+func TestInMemoryBroker_ContextCancelClosesChannel(t *testing.T) {
+	broker := pubsub.NewInMemoryBroker(1)
+	ctx, cancelCtx := context.WithCancel(context.Background())
+
+	events, cancel, err := broker.Subscribe(ctx, pubsub.HangoutTopic(uuid.New()))
+	require.NoError(t, err)
+	defer cancel()
+
+	cancelCtx()
+
+	_, ok := receive(t, events)
+	require.False(t, ok)
+}
+
+func TestInMemoryBroker_SlowSubscriberIsDisconnected(t *testing.T) {
+	ctx := context.Background()
+	broker := pubsub.NewInMemoryBroker(1)
+	topic := pubsub.HangoutTopic(uuid.New())
+
+	events, cancel, err := broker.Subscribe(ctx, topic)
+	require.NoError(t, err)
+	defer cancel()
+
+	require.NoError(t, broker.Publish(ctx, topic, pubsub.Event{ID: "1"}))
+	require.NoError(t, broker.Publish(ctx, topic, pubsub.Event{ID: "2"}))
+
+	got, ok := receive(t, events)
+	require.True(t, ok)
+	require.Equal(t, "1", got.ID)
+
+	_, ok = receive(t, events)
+	require.False(t, ok)
+}
+
+func TestInMemoryBroker_Close(t *testing.T) {
+	ctx := context.Background()
+	broker := pubsub.NewInMemoryBroker(1)
+	topic := pubsub.HangoutTopic(uuid.New())
+
+	events, cancel, err := broker.Subscribe(ctx, topic)
+	require.NoError(t, err)
+
+	require.NoError(t, broker.Close())
+	require.NoError(t, broker.Close())
+	cancel()
+
+	_, ok := receive(t, events)
+	require.False(t, ok)
+
+	require.ErrorIs(t, broker.Publish(ctx, topic, pubsub.Event{}), apperrors.ErrEventBrokerClosed)
+	_, _, err = broker.Subscribe(ctx, topic)
+	require.ErrorIs(t, err, apperrors.ErrEventBrokerClosed)
+}
@@ -0,0 +1,52 @@
+package pubsub
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Event is a change notification delivered to subscribers of a topic.
+type Event struct {
+	ID        string    `json:"id"`
+	Type      string    `json:"type"`
+	HangoutID uuid.UUID `json:"hangout_id"`
+	// UserID is the hangout owner, used to route per-user deliveries such as
+	// webhooks. Stream clients already know it, so it is not serialized.
+	UserID     uuid.UUID `json:"-"`
+	Data       any       `json:"data,omitempty"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+type Publisher interface {
+	Publish(ctx context.Context, topic string, event Event) error
+}
+
+type Subscriber interface {
+	// Subscribe returns a channel of events for topic and a function that cancels
+	// the subscription.
+	Subscribe(ctx context.Context, topic string) (<-chan Event, func(), error)
+}
+
+// Broker is implemented in-process today and can be swapped for a message
+// broker without touching publishers or subscribers.
+type Broker interface {
+	Publisher
+	Subscriber
+	Close() error
+}
+
+func HangoutTopic(hangoutID uuid.UUID) string {
+	return "hangout:" + hangoutID.String()
+}
+
+func NewHangoutEvent(hangoutID uuid.UUID, eventType string, data any) Event {
+	return Event{
+		ID:         uuid.NewString(),
+		Type:       eventType,
+		HangoutID:  hangoutID,
+		Data:       data,
+		OccurredAt: time.Now().UTC(),
+	}
+}
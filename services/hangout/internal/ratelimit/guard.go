@@ -0,0 +1,122 @@
+package ratelimit
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/config"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/utils"
+)
+
+// Reasons a request was blocked, used in responses and metrics.
+const (
+	ReasonIP      = "ip"
+	ReasonAccount = "account"
+	ReasonLockout = "lockout"
+)
+
+// Decision is the outcome of a rate limit check.
+type Decision struct {
+	Allowed    bool
+	RetryAfter time.Duration
+	Reason     string
+}
+
+// Guard applies the auth rate limits and the sign in lockout on top of a Store.
+type Guard struct {
+	store Store
+	cfg   *config.AuthRateLimitConfig
+}
+
+func NewGuard(store Store, cfg *config.AuthRateLimitConfig) *Guard {
+	return &Guard{store: store, cfg: cfg}
+}
+
+// Allow takes a token from the client IP bucket and, when account is set,
+// from the account bucket.
+func (g *Guard) Allow(ctx context.Context, ip string, account string) (Decision, error) {
+	ok, wait, err := g.store.Take(ctx, "ip:"+ip, Limit{Rate: g.cfg.GetIPRate(), Burst: g.cfg.IPBurst})
+	if err != nil {
+		return Decision{}, err
+	}
+	if !ok {
+		return Decision{RetryAfter: wait, Reason: ReasonIP}, nil
+	}
+
+	if account == "" {
+		return Decision{Allowed: true}, nil
+	}
+	ok, wait, err = g.store.Take(ctx, accountKey(account), Limit{Rate: g.cfg.GetAccountRate(), Burst: g.cfg.AccountBurst})
+	if err != nil {
+		return Decision{}, err
+	}
+	if !ok {
+		return Decision{RetryAfter: wait, Reason: ReasonAccount}, nil
+	}
+	return Decision{Allowed: true}, nil
+}
+
+// CheckLockout blocks sign in for an account locked by RecordFailure.
+func (g *Guard) CheckLockout(ctx context.Context, account string) (Decision, error) {
+	locked, err := g.store.LockedFor(ctx, accountKey(account))
+	if err != nil {
+		return Decision{}, err
+	}
+	if locked > 0 {
+		return Decision{RetryAfter: locked, Reason: ReasonLockout}, nil
+	}
+	return Decision{Allowed: true}, nil
+}
+
+// RecordFailure counts a failed sign in and locks the account once the count
+// reaches MaxFailedAttempts.
+func (g *Guard) RecordFailure(ctx context.Context, account string) (time.Duration, error) {
+	key := accountKey(account)
+	count, err := g.store.AddFailure(ctx, key, g.cfg.GetFailureWindow())
+	if err != nil {
+		return 0, err
+	}
+	if count < g.cfg.MaxFailedAttempts {
+		return 0, nil
+	}
+
+	// lockouts are remembered long enough to escalate past the longest one
+	lockouts, err := g.store.AddFailure(ctx, lockoutKey(key), g.cfg.GetLockoutMax()+g.cfg.GetFailureWindow())
+	if err != nil {
+		return 0, err
+	}
+	lockout := g.lockoutFor(lockouts)
+	if err := g.store.ClearFailures(ctx, key); err != nil {
+		return 0, err
+	}
+	return lockout, g.store.Lock(ctx, key, lockout)
+}
+
+// RecordSuccess forgets the failed attempts and lockouts of an account.
+func (g *Guard) RecordSuccess(ctx context.Context, account string) error {
+	key := accountKey(account)
+	if err := g.store.ClearFailures(ctx, key); err != nil {
+		return err
+	}
+	return g.store.ClearFailures(ctx, lockoutKey(key))
+}
+
+// lockoutFor doubles the base lockout for every recent lockout before this
+// one, up to the maximum.
+func (g *Guard) lockoutFor(lockouts int) time.Duration {
+	lockout := g.cfg.GetLockoutBase()
+	limit := g.cfg.GetLockoutMax()
+	for i := 1; i < lockouts && lockout < limit; i++ {
+		lockout *= 2
+	}
+	return min(lockout, limit)
+}
+
+func accountKey(account string) string {
+	return "account:" + utils.HashToken(strings.ToLower(strings.TrimSpace(account)))
+}
+
+func lockoutKey(accountKey string) string {
+	return "lockouts:" + accountKey
+}
@@ -0,0 +1,132 @@
+package ratelimit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/config"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/ratelimit"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestGuard() (*ratelimit.Guard, *fakeClock) {
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+	cfg := &config.AuthRateLimitConfig{
+		IPRatePerMinute:      60,
+		IPBurst:              5,
+		AccountRatePerMinute: 6,
+		AccountBurst:         2,
+		MaxFailedAttempts:    3,
+		FailureWindowMinutes: 15,
+		LockoutBaseSeconds:   60,
+		LockoutMaxMinutes:    5,
+	}
+	return ratelimit.NewGuard(ratelimit.NewMemoryStore(clock.Now), cfg), clock
+}
+
+func TestGuard_Allow(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("per IP", func(t *testing.T) {
+		guard, _ := newTestGuard()
+		for i := 0; i < 5; i++ {
+			decision, err := guard.Allow(ctx, "1.2.3.4", "")
+			require.NoError(t, err)
+			require.True(t, decision.Allowed)
+		}
+
+		decision, err := guard.Allow(ctx, "1.2.3.4", "")
+		require.NoError(t, err)
+		require.False(t, decision.Allowed)
+		require.Equal(t, ratelimit.ReasonIP, decision.Reason)
+		require.Equal(t, time.Second, decision.RetryAfter)
+	})
+
+	t.Run("per account across IPs", func(t *testing.T) {
+		guard, _ := newTestGuard()
+		for _, ip := range []string{"1.1.1.1", "2.2.2.2"} {
+			decision, err := guard.Allow(ctx, ip, "alice@example.com")
+			require.NoError(t, err)
+			require.True(t, decision.Allowed)
+		}
+
+		// emails are matched case-insensitively
+		decision, err := guard.Allow(ctx, "3.3.3.3", " Alice@Example.com")
+		require.NoError(t, err)
+		require.False(t, decision.Allowed)
+		require.Equal(t, ratelimit.ReasonAccount, decision.Reason)
+		require.Equal(t, 10*time.Second, decision.RetryAfter)
+
+		decision, err = guard.Allow(ctx, "3.3.3.3", "bob@example.com")
+		require.NoError(t, err)
+		require.True(t, decision.Allowed)
+	})
+}
+
+func TestGuard_Lockout(t *testing.T) {
+	ctx := context.Background()
+	const account = "alice@example.com"
+
+	failUntilLocked := func(t *testing.T, guard *ratelimit.Guard) time.Duration {
+		t.Helper()
+		for i := 0; i < 2; i++ {
+			lockout, err := guard.RecordFailure(ctx, account)
+			require.NoError(t, err)
+			require.Zero(t, lockout)
+		}
+		lockout, err := guard.RecordFailure(ctx, account)
+		require.NoError(t, err)
+		return lockout
+	}
+
+	t.Run("locks after max failed attempts and escalates", func(t *testing.T) {
+		guard, clock := newTestGuard()
+
+		decision, err := guard.CheckLockout(ctx, account)
+		require.NoError(t, err)
+		require.True(t, decision.Allowed)
+
+		require.Equal(t, time.Minute, failUntilLocked(t, guard))
+		decision, err = guard.CheckLockout(ctx, account)
+		require.NoError(t, err)
+		require.False(t, decision.Allowed)
+		require.Equal(t, ratelimit.ReasonLockout, decision.Reason)
+		require.Equal(t, time.Minute, decision.RetryAfter)
+
+		clock.now = clock.now.Add(time.Minute)
+		decision, err = guard.CheckLockout(ctx, account)
+		require.NoError(t, err)
+		require.True(t, decision.Allowed)
+
+		require.Equal(t, 2*time.Minute, failUntilLocked(t, guard))
+		clock.now = clock.now.Add(2 * time.Minute)
+		require.Equal(t, 4*time.Minute, failUntilLocked(t, guard))
+		clock.now = clock.now.Add(4 * time.Minute)
+		require.Equal(t, 5*time.Minute, failUntilLocked(t, guard), "capped at the maximum")
+	})
+
+	t.Run("success clears failures and lockout history", func(t *testing.T) {
+		guard, clock := newTestGuard()
+
+		require.Equal(t, time.Minute, failUntilLocked(t, guard))
+		clock.now = clock.now.Add(time.Minute)
+		require.NoError(t, guard.RecordSuccess(ctx, account))
+
+		require.Equal(t, time.Minute, failUntilLocked(t, guard))
+	})
+
+	t.Run("failures outside the window are forgotten", func(t *testing.T) {
+		guard, clock := newTestGuard()
+
+		for i := 0; i < 2; i++ {
+			_, err := guard.RecordFailure(ctx, account)
+			require.NoError(t, err)
+		}
+		clock.now = clock.now.Add(15 * time.Minute)
+
+		lockout, err := guard.RecordFailure(ctx, account)
+		require.NoError(t, err)
+		require.Zero(t, lockout)
+	})
+}
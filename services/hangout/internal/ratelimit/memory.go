@@ -0,0 +1,144 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// sweepInterval is how often the memory store drops expired entries.
+const sweepInterval = time.Minute
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+	// full is when the bucket refills completely, after which it can be
+	// dropped because a new bucket starts full anyway.
+	full time.Time
+}
+
+type failures struct {
+	count   int
+	expires time.Time
+}
+
+type memoryStore struct {
+	mu        sync.Mutex
+	now       func() time.Time
+	buckets   map[string]*bucket
+	failures  map[string]*failures
+	locks     map[string]time.Time
+	lastSweep time.Time
+}
+
+// NewMemoryStore keeps the state in process memory. now is the clock used
+// to refill buckets and expire entries.
+func NewMemoryStore(now func() time.Time) Store {
+	return &memoryStore{
+		now:       now,
+		buckets:   make(map[string]*bucket),
+		failures:  make(map[string]*failures),
+		locks:     make(map[string]time.Time),
+		lastSweep: now(),
+	}
+}
+
+func (s *memoryStore) Take(_ context.Context, key string, limit Limit) (bool, time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	s.sweep(now)
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(limit.Burst), updated: now}
+		s.buckets[key] = b
+	}
+	elapsed := now.Sub(b.updated).Seconds()
+	b.tokens = math.Min(float64(limit.Burst), b.tokens+math.Max(0, elapsed)*limit.Rate)
+	b.updated = now
+
+	if b.tokens < 1 {
+		return false, retryAfter(b.tokens, limit), nil
+	}
+	b.tokens--
+	if limit.Rate > 0 {
+		b.full = now.Add(time.Duration((float64(limit.Burst) - b.tokens) / limit.Rate * float64(time.Second)))
+	} else {
+		b.full = time.Time{}
+	}
+	return true, 0, nil
+}
+
+func (s *memoryStore) AddFailure(_ context.Context, key string, window time.Duration) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	s.sweep(now)
+
+	f, ok := s.failures[key]
+	if !ok || !now.Before(f.expires) {
+		f = &failures{}
+		s.failures[key] = f
+	}
+	f.count++
+	f.expires = now.Add(window)
+	return f.count, nil
+}
+
+func (s *memoryStore) ClearFailures(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.failures, key)
+	return nil
+}
+
+func (s *memoryStore) Lock(_ context.Context, key string, d time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.locks[key] = s.now().Add(d)
+	return nil
+}
+
+func (s *memoryStore) LockedFor(_ context.Context, key string) (time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	until, ok := s.locks[key]
+	if !ok {
+		return 0, nil
+	}
+	remaining := until.Sub(s.now())
+	if remaining <= 0 {
+		delete(s.locks, key)
+		return 0, nil
+	}
+	return remaining, nil
+}
+
+func (s *memoryStore) Close() error {
+	return nil
+}
+
+// sweep drops full buckets, expired failure counts and past locks.
+func (s *memoryStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < sweepInterval {
+		return
+	}
+	s.lastSweep = now
+	for key, b := range s.buckets {
+		if !b.full.IsZero() && !now.Before(b.full) {
+			delete(s.buckets, key)
+		}
+	}
+	for key, f := range s.failures {
+		if !now.Before(f.expires) {
+			delete(s.failures, key)
+		}
+	}
+	for key, until := range s.locks {
+		if !now.Before(until) {
+			delete(s.locks, key)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	bucketKeyPrefix  = "ratelimit:bucket:"
+	failureKeyPrefix = "ratelimit:failures:"
+	lockKeyPrefix    = "ratelimit:lock:"
+)
+
+// takeScript refills and takes from a bucket stored as a hash of the token count
+// and the last update in milliseconds.
+var takeScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+local ttl = tonumber(ARGV[4])
+
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'updated')
+local tokens = tonumber(state[1]) or burst
+local updated = tonumber(state[2]) or now
+
+tokens = math.min(burst, tokens + math.max(0, now - updated) / 1000 * rate)
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'updated', now)
+if ttl > 0 then
+	redis.call('PEXPIRE', KEYS[1], ttl)
+end
+return {allowed, tostring(tokens)}
+`)
+
+type redisStore struct {
+	client redis.UniversalClient
+	now    func() time.Time
+}
+
+// NewRedisStore keeps the state in Redis, or any server that speaks the
+// Redis protocol and runs Lua scripts. now is the clock used to refill
+// buckets, so instances sharing a server should have synced clocks.
+func NewRedisStore(client redis.UniversalClient, now func() time.Time) Store {
+	return &redisStore{client: client, now: now}
+}
+
+func (s *redisStore) Take(ctx context.Context, key string, limit Limit) (bool, time.Duration, error) {
+	// the bucket is full again after burst/rate, so it can expire then
+	var ttl int64
+	if limit.Rate > 0 {
+		ttl = int64(math.Ceil(float64(limit.Burst) / limit.Rate * 1000))
+	}
+
+	res, err := takeScript.Run(ctx, s.client, []string{bucketKeyPrefix + key},
+		limit.Rate, limit.Burst, s.now().UnixMilli(), ttl).Slice()
+	if err != nil {
+		return false, 0, err
+	}
+	if len(res) != 2 {
+		return false, 0, errors.New("unexpected rate limit script result")
+	}
+
+	if allowed, _ := res[0].(int64); allowed == 1 {
+		return true, 0, nil
+	}
+	tokensText, _ := res[1].(string)
+	tokens, err := strconv.ParseFloat(tokensText, 64)
+	if err != nil {
+		return false, 0, err
+	}
+	return false, retryAfter(tokens, limit), nil
+}
+
+func (s *redisStore) AddFailure(ctx context.Context, key string, window time.Duration) (int, error) {
+	pipe := s.client.TxPipeline()
+	incr := pipe.Incr(ctx, failureKeyPrefix+key)
+	pipe.PExpire(ctx, failureKeyPrefix+key, window)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, err
+	}
+	return int(incr.Val()), nil
+}
+
+func (s *redisStore) ClearFailures(ctx context.Context, key string) error {
+	return s.client.Del(ctx, failureKeyPrefix+key).Err()
+}
+
+func (s *redisStore) Lock(ctx context.Context, key string, d time.Duration) error {
+	return s.client.Set(ctx, lockKeyPrefix+key, 1, d).Err()
+}
+
+func (s *redisStore) LockedFor(ctx context.Context, key string) (time.Duration, error) {
+	ttl, err := s.client.PTTL(ctx, lockKeyPrefix+key).Result()
+	if err != nil {
+		return 0, err
+	}
+	// PTTL reports a missing key or one without expiry as negative
+	if ttl < 0 {
+		return 0, nil
+	}
+	return ttl, nil
+}
+
+func (s *redisStore) Close() error {
+	return s.client.Close()
+}
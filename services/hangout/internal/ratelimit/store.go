@@ -0,0 +1,57 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/config"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/redis/go-redis/v9"
+)
+
+// Limit is a token bucket: Burst requests at once, refilled at Rate tokens
+// per second.
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// Store keeps rate limit state.
+type Store interface {
+	// Take removes a token from the bucket at key.
+	Take(ctx context.Context, key string, limit Limit) (bool, time.Duration, error)
+	// AddFailure counts a failure at key and returns the new count.
+	AddFailure(ctx context.Context, key string, window time.Duration) (int, error)
+	ClearFailures(ctx context.Context, key string) error
+	Lock(ctx context.Context, key string, d time.Duration) error
+	// LockedFor returns how long key stays locked, or zero when it is not.
+	LockedFor(ctx context.Context, key string) (time.Duration, error)
+	Close() error
+}
+
+// NewStore builds the store selected by cfg.Store.
+func NewStore(cfg *config.AuthRateLimitConfig, redisCfg *config.RedisConfig) (Store, error) {
+	switch cfg.Store {
+	case constants.RateLimitStoreMemory:
+		return NewMemoryStore(time.Now), nil
+	case constants.RateLimitStoreRedis:
+		client := redis.NewClient(&redis.Options{
+			Addr:     redisCfg.Addr,
+			Password: redisCfg.Password,
+			DB:       redisCfg.DB,
+		})
+		return NewRedisStore(client, time.Now), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", apperrors.ErrUnknownRateLimitStore, cfg.Store)
+	}
+}
+
+// retryAfter returns how long an empty bucket takes to refill one token.
+func retryAfter(tokens float64, limit Limit) time.Duration {
+	if limit.Rate <= 0 {
+		return 0
+	}
+	return time.Duration((1 - tokens) / limit.Rate * float64(time.Second))
+}
@@ -0,0 +1,178 @@
+package ratelimit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/config"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/ratelimit"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClock is shared by a store and the test so time can be moved forward.
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+type testStore struct {
+	store ratelimit.Store
+	// advance moves the store's clock, and for Redis the server's key
+	// expiry, forward by d.
+	advance func(d time.Duration)
+}
+
+func newTestStores(t *testing.T) map[string]testStore {
+	t.Helper()
+
+	memClock := &fakeClock{now: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	redisClock := &fakeClock{now: memClock.now}
+
+	return map[string]testStore{
+		"memory": {
+			store:   ratelimit.NewMemoryStore(memClock.Now),
+			advance: func(d time.Duration) { memClock.now = memClock.now.Add(d) },
+		},
+		"redis": {
+			store: ratelimit.NewRedisStore(client, redisClock.Now),
+			advance: func(d time.Duration) {
+				redisClock.now = redisClock.now.Add(d)
+				server.FastForward(d)
+			},
+		},
+	}
+}
+
+func TestStore_Take(t *testing.T) {
+	ctx := context.Background()
+	limit := ratelimit.Limit{Rate: 1, Burst: 3}
+
+	for name, ts := range newTestStores(t) {
+		t.Run(name, func(t *testing.T) {
+			for i := 0; i < 3; i++ {
+				ok, _, err := ts.store.Take(ctx, "ip:1.2.3.4", limit)
+				require.NoError(t, err)
+				require.True(t, ok, "request %d within burst", i+1)
+			}
+
+			ok, wait, err := ts.store.Take(ctx, "ip:1.2.3.4", limit)
+			require.NoError(t, err)
+			require.False(t, ok)
+			require.Equal(t, time.Second, wait)
+
+			// other keys have their own bucket
+			ok, _, err = ts.store.Take(ctx, "ip:5.6.7.8", limit)
+			require.NoError(t, err)
+			require.True(t, ok)
+
+			ts.advance(500 * time.Millisecond)
+			ok, wait, err = ts.store.Take(ctx, "ip:1.2.3.4", limit)
+			require.NoError(t, err)
+			require.False(t, ok)
+			require.Equal(t, 500*time.Millisecond, wait)
+
+			ts.advance(500 * time.Millisecond)
+			ok, _, err = ts.store.Take(ctx, "ip:1.2.3.4", limit)
+			require.NoError(t, err)
+			require.True(t, ok)
+
+			// a long idle period refills the bucket up to the burst only
+			ts.advance(time.Hour)
+			for i := 0; i < 3; i++ {
+				ok, _, err = ts.store.Take(ctx, "ip:1.2.3.4", limit)
+				require.NoError(t, err)
+				require.True(t, ok)
+			}
+			ok, _, err = ts.store.Take(ctx, "ip:1.2.3.4", limit)
+			require.NoError(t, err)
+			require.False(t, ok)
+		})
+	}
+}
+
+func TestStore_Failures(t *testing.T) {
+	ctx := context.Background()
+	window := 10 * time.Minute
+
+	for name, ts := range newTestStores(t) {
+		t.Run(name, func(t *testing.T) {
+			for want := 1; want <= 3; want++ {
+				count, err := ts.store.AddFailure(ctx, "account:a", window)
+				require.NoError(t, err)
+				require.Equal(t, want, count)
+			}
+
+			// each failure restarts the window
+			ts.advance(window - time.Second)
+			count, err := ts.store.AddFailure(ctx, "account:a", window)
+			require.NoError(t, err)
+			require.Equal(t, 4, count)
+
+			ts.advance(window)
+			count, err = ts.store.AddFailure(ctx, "account:a", window)
+			require.NoError(t, err)
+			require.Equal(t, 1, count)
+
+			require.NoError(t, ts.store.ClearFailures(ctx, "account:a"))
+			count, err = ts.store.AddFailure(ctx, "account:a", window)
+			require.NoError(t, err)
+			require.Equal(t, 1, count)
+		})
+	}
+}
+
+func TestStore_Lock(t *testing.T) {
+	ctx := context.Background()
+
+	for name, ts := range newTestStores(t) {
+		t.Run(name, func(t *testing.T) {
+			locked, err := ts.store.LockedFor(ctx, "account:a")
+			require.NoError(t, err)
+			require.Zero(t, locked)
+
+			require.NoError(t, ts.store.Lock(ctx, "account:a", time.Minute))
+			locked, err = ts.store.LockedFor(ctx, "account:a")
+			require.NoError(t, err)
+			require.Equal(t, time.Minute, locked)
+
+			ts.advance(40 * time.Second)
+			locked, err = ts.store.LockedFor(ctx, "account:a")
+			require.NoError(t, err)
+			require.Equal(t, 20*time.Second, locked)
+
+			ts.advance(20 * time.Second)
+			locked, err = ts.store.LockedFor(ctx, "account:a")
+			require.NoError(t, err)
+			require.Zero(t, locked)
+
+			require.NoError(t, ts.store.Close())
+		})
+	}
+}
+
+func TestNewStore(t *testing.T) {
+	redisCfg := &config.RedisConfig{Addr: "localhost:6379"}
+
+	store, err := ratelimit.NewStore(&config.AuthRateLimitConfig{Store: constants.RateLimitStoreMemory}, redisCfg)
+	require.NoError(t, err)
+	require.NotNil(t, store)
+
+	store, err = ratelimit.NewStore(&config.AuthRateLimitConfig{Store: constants.RateLimitStoreRedis}, redisCfg)
+	require.NoError(t, err)
+	require.NotNil(t, store)
+	require.NoError(t, store.Close())
+
+	_, err = ratelimit.NewStore(&config.AuthRateLimitConfig{Store: "memcached"}, redisCfg)
+	require.ErrorIs(t, err, apperrors.ErrUnknownRateLimitStore)
+}
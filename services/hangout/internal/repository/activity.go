@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/otel"
 	"github.com/google/uuid"
@@ -16,7 +17,7 @@ type ActivityRepository interface {
 	GetActivityByID(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*domain.Activity, int64, error)
 	GetAllActivities(ctx context.Context, userID uuid.UUID) ([]ActivityWithCount, error)
 	UpdateActivity(ctx context.Context, activity *domain.Activity) (*domain.Activity, error)
-	DeleteActivity(ctx context.Context, id uuid.UUID) error
+	DeleteActivity(ctx context.Context, id uuid.UUID, version int64) error
 	GetActivitiesByIDs(ctx context.Context, ids []uuid.UUID) ([]*domain.Activity, error)
 }
 
@@ -55,8 +56,9 @@ func (r *activityRepository) GetActivityByID(ctx context.Context, id uuid.UUID,
 	start := time.Now()
 	err := r.db.WithContext(ctx).
 		Model(&domain.Activity{}).
-		Select("activities.*, COUNT(hangout_activities.hangout_id) as hangout_count").
+		Select("activities.*, COUNT(hangouts.id) as hangout_count").
 		Joins("LEFT JOIN hangout_activities ON hangout_activities.activity_id = activities.id").
+		Joins("LEFT JOIN hangouts ON hangouts.id = hangout_activities.hangout_id AND hangouts.deleted_at IS NULL").
 		Where("activities.id = ?", id).
 		Where("activities.user_id = ?", userID).
 		Group("activities.id").
@@ -78,9 +80,10 @@ func (r *activityRepository) GetAllActivities(ctx context.Context, userID uuid.U
 	start := time.Now()
 	err := r.db.WithContext(ctx).
 		Model(&domain.Activity{}).
-		Select("activities.*, COUNT(hangout_activities.hangout_id) as hangout_count").
+		Select("activities.*, COUNT(hangouts.id) as hangout_count").
 		Where("activities.user_id = ?", userID).
 		Joins("LEFT JOIN hangout_activities ON hangout_activities.activity_id = activities.id").
+		Joins("LEFT JOIN hangouts ON hangouts.id = hangout_activities.hangout_id AND hangouts.deleted_at IS NULL").
 		Group("activities.id").
 		Order("activities.name asc").
 		Find(&results).Error
@@ -93,21 +96,40 @@ func (r *activityRepository) GetAllActivities(ctx context.Context, userID uuid.U
 }
 
 func (r *activityRepository) UpdateActivity(ctx context.Context, activity *domain.Activity) (*domain.Activity, error) {
+	expectedVersion := activity.Version
+	activity.Version = expectedVersion + 1
+	activity.UpdatedAt = time.Now()
+
 	start := time.Now()
-	err := r.db.WithContext(ctx).Save(activity).Error
+	result := r.db.WithContext(ctx).
+		Model(&domain.Activity{}).
+		Where("id = ? AND version = ?", activity.ID, expectedVersion).
+		Updates(activity)
 	r.metrics.RecordDBOperation(ctx, "update", "activities", time.Since(start), 1)
 
-	if err != nil {
-		return nil, err
+	if result.Error != nil {
+		activity.Version = expectedVersion
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		activity.Version = expectedVersion
+		return nil, apperrors.ErrPreconditionFailed
 	}
 	return activity, nil
 }
 
-func (r *activityRepository) DeleteActivity(ctx context.Context, id uuid.UUID) error {
+func (r *activityRepository) DeleteActivity(ctx context.Context, id uuid.UUID, version int64) error {
 	start := time.Now()
-	err := r.db.WithContext(ctx).Where("id = ?", id).Delete(&domain.Activity{}).Error
+	result := r.db.WithContext(ctx).Where("id = ? AND version = ?", id, version).Delete(&domain.Activity{})
 	r.metrics.RecordDBOperation(ctx, "delete", "activities", time.Since(start), 1)
-	return err
+
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return apperrors.ErrPreconditionFailed
+	}
+	return nil
 }
 
 func (r *activityRepository) GetActivitiesByIDs(ctx context.Context, ids []uuid.UUID) ([]*domain.Activity, error) {
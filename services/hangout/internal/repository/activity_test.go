@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Ernestgio/Hangout-Planner/pkg/shared/enums"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/repository"
 	"github.com/google/uuid"
@@ -45,8 +47,8 @@ func TestActivityRepository_CreateActivity(t *testing.T) {
 			name: "success",
 			setupMock: func(mock sqlmock.Sqlmock) {
 				mock.ExpectBegin()
-				mock.ExpectExec("INSERT INTO `activities` (`id`,`name`,`created_at`,`updated_at`,`deleted_at`,`user_id`) VALUES (?,?,?,?,?,?)").
-					WithArgs(sqlmock.AnyArg(), activity.Name, AnyTime{}, AnyTime{}, nil, nil).
+				mock.ExpectExec("INSERT INTO `activities` (`id`,`name`,`version`,`created_at`,`updated_at`,`deleted_at`,`user_id`) VALUES (?,?,?,?,?,?,?)").
+					WithArgs(sqlmock.AnyArg(), activity.Name, int64(1), AnyTime{}, AnyTime{}, nil, nil).
 					WillReturnResult(sqlmock.NewResult(1, 1))
 				mock.ExpectCommit()
 			},
@@ -55,8 +57,8 @@ func TestActivityRepository_CreateActivity(t *testing.T) {
 			name: "database error",
 			setupMock: func(mock sqlmock.Sqlmock) {
 				mock.ExpectBegin()
-				mock.ExpectExec("INSERT INTO `activities` (`id`,`name`,`created_at`,`updated_at`,`deleted_at`,`user_id`) VALUES (?,?,?,?,?,?)").
-					WithArgs(sqlmock.AnyArg(), activity.Name, AnyTime{}, AnyTime{}, nil, nil).
+				mock.ExpectExec("INSERT INTO `activities` (`id`,`name`,`version`,`created_at`,`updated_at`,`deleted_at`,`user_id`) VALUES (?,?,?,?,?,?,?)").
+					WithArgs(sqlmock.AnyArg(), activity.Name, int64(1), AnyTime{}, AnyTime{}, nil, nil).
 					WillReturnError(dbError)
 				mock.ExpectRollback()
 			},
@@ -103,7 +105,7 @@ func TestActivityRepository_GetActivityByID(t *testing.T) {
 			setupMock: func(mock sqlmock.Sqlmock) {
 				rows := sqlmock.NewRows([]string{"id", "name", "hangout_count"}).
 					AddRow(activityID, "Hiking", 5)
-				expectedSQL := "SELECT activities.*, COUNT(hangout_activities.hangout_id) as hangout_count FROM `activities` LEFT JOIN hangout_activities ON hangout_activities.activity_id = activities.id WHERE activities.id = ? AND activities.user_id = ? AND `activities`.`deleted_at` IS NULL GROUP BY `activities`.`id` ORDER BY `activities`.`id` LIMIT ?"
+				expectedSQL := "SELECT activities.*, COUNT(hangouts.id) as hangout_count FROM `activities` LEFT JOIN hangout_activities ON hangout_activities.activity_id = activities.id LEFT JOIN hangouts ON hangouts.id = hangout_activities.hangout_id AND hangouts.deleted_at IS NULL WHERE activities.id = ? AND activities.user_id = ? AND `activities`.`deleted_at` IS NULL GROUP BY `activities`.`id` ORDER BY `activities`.`id` LIMIT ?"
 				mock.ExpectQuery(expectedSQL).
 					WithArgs(activityID, userID, 1).
 					WillReturnRows(rows)
@@ -119,7 +121,7 @@ func TestActivityRepository_GetActivityByID(t *testing.T) {
 		{
 			name: "not found",
 			setupMock: func(mock sqlmock.Sqlmock) {
-				expectedSQL := "SELECT activities.*, COUNT(hangout_activities.hangout_id) as hangout_count FROM `activities` LEFT JOIN hangout_activities ON hangout_activities.activity_id = activities.id WHERE activities.id = ? AND activities.user_id = ? AND `activities`.`deleted_at` IS NULL GROUP BY `activities`.`id` ORDER BY `activities`.`id` LIMIT ?"
+				expectedSQL := "SELECT activities.*, COUNT(hangouts.id) as hangout_count FROM `activities` LEFT JOIN hangout_activities ON hangout_activities.activity_id = activities.id LEFT JOIN hangouts ON hangouts.id = hangout_activities.hangout_id AND hangouts.deleted_at IS NULL WHERE activities.id = ? AND activities.user_id = ? AND `activities`.`deleted_at` IS NULL GROUP BY `activities`.`id` ORDER BY `activities`.`id` LIMIT ?"
 				mock.ExpectQuery(expectedSQL).
 					WithArgs(activityID, userID, 1).
 					WillReturnError(gorm.ErrRecordNotFound)
@@ -134,7 +136,7 @@ func TestActivityRepository_GetActivityByID(t *testing.T) {
 		{
 			name: "database error",
 			setupMock: func(mock sqlmock.Sqlmock) {
-				expectedSQL := "SELECT activities.*, COUNT(hangout_activities.hangout_id) as hangout_count FROM `activities` LEFT JOIN hangout_activities ON hangout_activities.activity_id = activities.id WHERE activities.id = ? AND activities.user_id = ? AND `activities`.`deleted_at` IS NULL GROUP BY `activities`.`id` ORDER BY `activities`.`id` LIMIT ?"
+				expectedSQL := "SELECT activities.*, COUNT(hangouts.id) as hangout_count FROM `activities` LEFT JOIN hangout_activities ON hangout_activities.activity_id = activities.id LEFT JOIN hangouts ON hangouts.id = hangout_activities.hangout_id AND hangouts.deleted_at IS NULL WHERE activities.id = ? AND activities.user_id = ? AND `activities`.`deleted_at` IS NULL GROUP BY `activities`.`id` ORDER BY `activities`.`id` LIMIT ?"
 				mock.ExpectQuery(expectedSQL).
 					WithArgs(activityID, userID, 1).
 					WillReturnError(dbError)
@@ -177,7 +179,7 @@ func TestActivityRepository_GetAllActivities(t *testing.T) {
 				rows := sqlmock.NewRows([]string{"id", "name", "hangout_count"}).
 					AddRow(uuid.New(), "Hiking", 3).
 					AddRow(uuid.New(), "Movies", 10)
-				expectedSQL := "SELECT activities.*, COUNT(hangout_activities.hangout_id) as hangout_count FROM `activities` LEFT JOIN hangout_activities ON hangout_activities.activity_id = activities.id WHERE activities.user_id = ? AND `activities`.`deleted_at` IS NULL GROUP BY `activities`.`id` ORDER BY activities.name asc"
+				expectedSQL := "SELECT activities.*, COUNT(hangouts.id) as hangout_count FROM `activities` LEFT JOIN hangout_activities ON hangout_activities.activity_id = activities.id LEFT JOIN hangouts ON hangouts.id = hangout_activities.hangout_id AND hangouts.deleted_at IS NULL WHERE activities.user_id = ? AND `activities`.`deleted_at` IS NULL GROUP BY `activities`.`id` ORDER BY activities.name asc"
 				mock.ExpectQuery(expectedSQL).WithArgs(userID).WillReturnRows(rows)
 			},
 			checkResult: func(t *testing.T, results []repository.ActivityWithCount, err error) {
@@ -190,7 +192,7 @@ func TestActivityRepository_GetAllActivities(t *testing.T) {
 			name: "success with no results",
 			setupMock: func(mock sqlmock.Sqlmock) {
 				rows := sqlmock.NewRows([]string{"id", "name", "hangout_count"})
-				expectedSQL := "SELECT activities.*, COUNT(hangout_activities.hangout_id) as hangout_count FROM `activities` LEFT JOIN hangout_activities ON hangout_activities.activity_id = activities.id WHERE activities.user_id = ? AND `activities`.`deleted_at` IS NULL GROUP BY `activities`.`id` ORDER BY activities.name asc"
+				expectedSQL := "SELECT activities.*, COUNT(hangouts.id) as hangout_count FROM `activities` LEFT JOIN hangout_activities ON hangout_activities.activity_id = activities.id LEFT JOIN hangouts ON hangouts.id = hangout_activities.hangout_id AND hangouts.deleted_at IS NULL WHERE activities.user_id = ? AND `activities`.`deleted_at` IS NULL GROUP BY `activities`.`id` ORDER BY activities.name asc"
 				mock.ExpectQuery(expectedSQL).WithArgs(userID).WillReturnRows(rows)
 			},
 			checkResult: func(t *testing.T, results []repository.ActivityWithCount, err error) {
@@ -202,7 +204,7 @@ func TestActivityRepository_GetAllActivities(t *testing.T) {
 		{
 			name: "database error",
 			setupMock: func(mock sqlmock.Sqlmock) {
-				expectedSQL := "SELECT activities.*, COUNT(hangout_activities.hangout_id) as hangout_count FROM `activities` LEFT JOIN hangout_activities ON hangout_activities.activity_id = activities.id WHERE activities.user_id = ? AND `activities`.`deleted_at` IS NULL GROUP BY `activities`.`id` ORDER BY activities.name asc"
+				expectedSQL := "SELECT activities.*, COUNT(hangouts.id) as hangout_count FROM `activities` LEFT JOIN hangout_activities ON hangout_activities.activity_id = activities.id LEFT JOIN hangouts ON hangouts.id = hangout_activities.hangout_id AND hangouts.deleted_at IS NULL WHERE activities.user_id = ? AND `activities`.`deleted_at` IS NULL GROUP BY `activities`.`id` ORDER BY activities.name asc"
 				mock.ExpectQuery(expectedSQL).WithArgs(userID).WillReturnError(dbError)
 			},
 			checkResult: func(t *testing.T, results []repository.ActivityWithCount, err error) {
@@ -226,9 +228,38 @@ func TestActivityRepository_GetAllActivities(t *testing.T) {
 	}
 }
 
+func TestActivityRepository_HangoutCountSkipsDeletedHangouts_MySQL(t *testing.T) {
+	db := newMySQLDB(t)
+	ctx := context.Background()
+	activityRepo := repository.NewActivityRepository(db, nil)
+	hangoutRepo := repository.NewHangoutRepository(db, nil)
+
+	user := &domain.User{Name: "Ann", Email: "ann@example.com", Password: "hash"}
+	require.NoError(t, db.Create(user).Error)
+	activity := &domain.Activity{Name: "Hiking", UserID: &user.ID}
+	require.NoError(t, db.Create(activity).Error)
+	kept := &domain.Hangout{Title: "Picnic", Date: time.Now(), Status: enums.StatusPlanning, UserID: &user.ID}
+	trashed := &domain.Hangout{Title: "Trip", Date: time.Now(), Status: enums.StatusPlanning, UserID: &user.ID}
+	for _, hangout := range []*domain.Hangout{kept, trashed} {
+		require.NoError(t, db.Create(hangout).Error)
+		require.NoError(t, db.Exec("INSERT INTO `hangout_activities` (`hangout_id`, `activity_id`) VALUES (?, ?)", hangout.ID, activity.ID).Error)
+	}
+
+	require.NoError(t, hangoutRepo.DeleteHangout(ctx, trashed.ID, trashed.Version))
+
+	_, count, err := activityRepo.GetActivityByID(ctx, activity.ID, user.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count)
+	all, err := activityRepo.GetAllActivities(ctx, user.ID)
+	require.NoError(t, err)
+	require.Len(t, all, 1)
+	require.Equal(t, int64(1), all[0].HangoutCount)
+}
+
 func TestActivityRepository_UpdateActivity(t *testing.T) {
-	activity := &domain.Activity{ID: uuid.New(), Name: "Updated Name"}
+	activity := &domain.Activity{ID: uuid.New(), Name: "Updated Name", Version: 2}
 	activity.CreatedAt = time.Now().Add(-time.Hour)
+	updateSQL := "UPDATE `activities` SET `id`=?,`name`=?,`version`=?,`created_at`=?,`updated_at`=? WHERE (id = ? AND version = ?) AND `activities`.`deleted_at` IS NULL"
 	dbError := errors.New("update failed")
 	ctx := context.Background()
 
@@ -242,8 +273,8 @@ func TestActivityRepository_UpdateActivity(t *testing.T) {
 			name: "success",
 			setupMock: func(mock sqlmock.Sqlmock) {
 				mock.ExpectBegin()
-				mock.ExpectExec("UPDATE `activities` SET `name`=?,`created_at`=?,`updated_at`=?,`deleted_at`=?,`user_id`=? WHERE `activities`.`deleted_at` IS NULL AND `id` = ?").
-					WithArgs(activity.Name, activity.CreatedAt, AnyTime{}, nil, nil, activity.ID).
+				mock.ExpectExec(updateSQL).
+					WithArgs(activity.ID, activity.Name, int64(3), activity.CreatedAt, AnyTime{}, activity.ID, int64(2)).
 					WillReturnResult(sqlmock.NewResult(0, 1))
 				mock.ExpectCommit()
 			},
@@ -253,14 +284,26 @@ func TestActivityRepository_UpdateActivity(t *testing.T) {
 			name: "database error",
 			setupMock: func(mock sqlmock.Sqlmock) {
 				mock.ExpectBegin()
-				mock.ExpectExec("UPDATE `activities` SET `name`=?,`created_at`=?,`updated_at`=?,`deleted_at`=?,`user_id`=? WHERE `activities`.`deleted_at` IS NULL AND `id` = ?").
-					WithArgs(activity.Name, activity.CreatedAt, AnyTime{}, nil, nil, activity.ID).
+				mock.ExpectExec(updateSQL).
+					WithArgs(activity.ID, activity.Name, int64(3), activity.CreatedAt, AnyTime{}, activity.ID, int64(2)).
 					WillReturnError(dbError)
 				mock.ExpectRollback()
 			},
 			expectError: true,
 			expectedErr: dbError,
 		},
+		{
+			name: "version conflict",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(updateSQL).
+					WithArgs(activity.ID, activity.Name, int64(3), activity.CreatedAt, AnyTime{}, activity.ID, int64(2)).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+			expectError: true,
+			expectedErr: apperrors.ErrPreconditionFailed,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -270,16 +313,19 @@ func TestActivityRepository_UpdateActivity(t *testing.T) {
 			tc.setupMock(mock)
 
 			originalUpdatedAt := activity.UpdatedAt
+			activity.Version = 2
 			result, err := repo.UpdateActivity(ctx, activity)
 
 			if tc.expectError {
 				require.Error(t, err)
 				require.Equal(t, tc.expectedErr, err)
 				require.Nil(t, result)
+				require.Equal(t, int64(2), activity.Version)
 			} else {
 				require.NoError(t, err)
 				require.NotNil(t, result)
 				require.NotEqual(t, originalUpdatedAt, result.UpdatedAt)
+				require.Equal(t, int64(3), result.Version)
 			}
 			require.NoError(t, mock.ExpectationsWereMet())
 		})
@@ -301,8 +347,8 @@ func TestActivityRepository_DeleteActivity(t *testing.T) {
 			name: "success",
 			setupMock: func(mock sqlmock.Sqlmock) {
 				mock.ExpectBegin()
-				mock.ExpectExec("UPDATE `activities` SET `deleted_at`=? WHERE id = ? AND `activities`.`deleted_at` IS NULL").
-					WithArgs(AnyTime{}, activityID).
+				mock.ExpectExec("UPDATE `activities` SET `deleted_at`=? WHERE (id = ? AND version = ?) AND `activities`.`deleted_at` IS NULL").
+					WithArgs(AnyTime{}, activityID, int64(1)).
 					WillReturnResult(sqlmock.NewResult(0, 1))
 				mock.ExpectCommit()
 			},
@@ -312,14 +358,26 @@ func TestActivityRepository_DeleteActivity(t *testing.T) {
 			name: "database error",
 			setupMock: func(mock sqlmock.Sqlmock) {
 				mock.ExpectBegin()
-				mock.ExpectExec("UPDATE `activities` SET `deleted_at`=? WHERE id = ? AND `activities`.`deleted_at` IS NULL").
-					WithArgs(AnyTime{}, activityID).
+				mock.ExpectExec("UPDATE `activities` SET `deleted_at`=? WHERE (id = ? AND version = ?) AND `activities`.`deleted_at` IS NULL").
+					WithArgs(AnyTime{}, activityID, int64(1)).
 					WillReturnError(dbError)
 				mock.ExpectRollback()
 			},
 			expectError: true,
 			expectedErr: dbError,
 		},
+		{
+			name: "version conflict",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE `activities` SET `deleted_at`=? WHERE (id = ? AND version = ?) AND `activities`.`deleted_at` IS NULL").
+					WithArgs(AnyTime{}, activityID, int64(1)).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+			expectError: true,
+			expectedErr: apperrors.ErrPreconditionFailed,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -328,7 +386,7 @@ func TestActivityRepository_DeleteActivity(t *testing.T) {
 			repo := repository.NewActivityRepository(db, nil)
 			tc.setupMock(mock)
 
-			err := repo.DeleteActivity(ctx, activityID)
+			err := repo.DeleteActivity(ctx, activityID, 1)
 
 			if tc.expectError {
 				require.Error(t, err)
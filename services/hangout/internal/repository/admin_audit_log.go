@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"gorm.io/gorm"
+)
+
+// AdminAuditLogRepository only inserts and reads, the audit log is never
+// changed once written.
+type AdminAuditLogRepository interface {
+	WithTx(tx *gorm.DB) AdminAuditLogRepository
+	Create(ctx context.Context, entry *domain.AdminAuditLog) error
+	List(ctx context.Context, filter *dto.AdminAuditLogFilter, pagination *dto.CursorPagination) ([]domain.AdminAuditLog, error)
+}
+
+type adminAuditLogRepository struct {
+	db      *gorm.DB
+	metrics *otel.MetricsRecorder
+}
+
+func NewAdminAuditLogRepository(db *gorm.DB, metrics *otel.MetricsRecorder) AdminAuditLogRepository {
+	return &adminAuditLogRepository{db: db, metrics: metrics}
+}
+
+func (r *adminAuditLogRepository) WithTx(tx *gorm.DB) AdminAuditLogRepository {
+	return &adminAuditLogRepository{db: tx, metrics: r.metrics}
+}
+
+func (r *adminAuditLogRepository) Create(ctx context.Context, entry *domain.AdminAuditLog) error {
+	ctx, span := otel.StartRepositorySpan(ctx, "Create",
+		attribute.String("db.operation", "insert"),
+		attribute.String("db.table", "admin_audit_logs"),
+		attribute.String("audit.action", entry.Action),
+		attribute.String("user.id", entry.ActorID.String()),
+	)
+	defer span.End()
+
+	start := time.Now()
+	err := r.db.WithContext(ctx).Create(entry).Error
+	r.metrics.RecordDBOperation(ctx, "insert", "admin_audit_logs", time.Since(start), 1)
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+	} else {
+		span.SetStatusOk()
+	}
+	return err
+}
+
+// List returns the entries newest first with their actor, fetching one
+// extra row so the caller can tell whether there are more.
+func (r *adminAuditLogRepository) List(ctx context.Context, filter *dto.AdminAuditLogFilter, pagination *dto.CursorPagination) ([]domain.AdminAuditLog, error) {
+	ctx, span := otel.StartRepositorySpan(ctx, "List",
+		attribute.String("db.operation", "select"),
+		attribute.String("db.table", "admin_audit_logs"),
+		attribute.Int("pagination.limit", pagination.GetLimit()),
+	)
+	defer span.End()
+
+	start := time.Now()
+	var entries []domain.AdminAuditLog
+
+	query := r.db.WithContext(ctx).Model(&domain.AdminAuditLog{}).Preload("Actor")
+	if filter != nil && filter.ActorID != nil {
+		query = query.Where("actor_id = ?", *filter.ActorID)
+	}
+	if filter != nil && filter.Target != "" {
+		query = query.Where("target = ?", filter.Target)
+	}
+
+	if pagination.AfterID != nil {
+		var cursorItem domain.AdminAuditLog
+		if err := r.db.WithContext(ctx).First(&cursorItem, "id = ?", *pagination.AfterID).Error; err != nil {
+			return nil, apperrors.ErrInvalidCursorPagination
+		}
+
+		query = query.Where(
+			"(created_at < ?) OR (created_at = ? AND id < ?)",
+			cursorItem.CreatedAt, cursorItem.CreatedAt, cursorItem.ID,
+		)
+	}
+
+	err := query.
+		Order("created_at desc, id desc").
+		Limit(pagination.GetLimit() + 1).
+		Find(&entries).Error
+	r.metrics.RecordDBOperation(ctx, "select", "admin_audit_logs", time.Since(start), len(entries))
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("audit.count", len(entries)))
+	span.SetStatusOk()
+	return entries, nil
+}
@@ -0,0 +1,166 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
+	repo "github.com/Ernestgio/Hangout-Planner/services/hangout/internal/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestAdminAuditLogCreate_TableDriven(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		prepare   func(sqlmock.Sqlmock)
+		wantError bool
+	}{
+		{
+			name: "success",
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("INSERT INTO `admin_audit_logs`").WillReturnResult(sqlmock.NewResult(1, 1))
+				m.ExpectCommit()
+			},
+		},
+		{
+			name: "db error",
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("INSERT INTO `admin_audit_logs`").WillReturnError(errors.New("db error"))
+				m.ExpectRollback()
+			},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newDBWithRegexp(t)
+			r := repo.NewAdminAuditLogRepository(db, nil)
+			tt.prepare(mock)
+
+			entry := &domain.AdminAuditLog{
+				ActorID:    uuid.New(),
+				Action:     constants.AuditActionDisableUser,
+				TargetType: constants.AuditTargetUser,
+				Target:     uuid.NewString(),
+				Reason:     "spam",
+			}
+			err := r.Create(ctx, entry)
+			if tt.wantError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.NotEqual(t, uuid.Nil, entry.ID)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestAdminAuditLogWithTx(t *testing.T) {
+	db, mock := newDBWithRegexp(t)
+	r := repo.NewAdminAuditLogRepository(db, nil)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO `admin_audit_logs`").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		return r.WithTx(tx).Create(context.Background(), &domain.AdminAuditLog{ActorID: uuid.New()})
+	})
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAdminAuditLogList_TableDriven(t *testing.T) {
+	ctx := context.Background()
+	actorID := uuid.New()
+	target := uuid.NewString()
+
+	tests := []struct {
+		name   string
+		filter *dto.AdminAuditLogFilter
+		query  string
+		args   []driver.Value
+	}{
+		{
+			name:  "no filter",
+			query: "SELECT \\* FROM `admin_audit_logs` ORDER BY created_at desc, id desc LIMIT \\?",
+			args:  []driver.Value{3},
+		},
+		{
+			name:   "by actor and target",
+			filter: &dto.AdminAuditLogFilter{ActorID: &actorID, Target: target},
+			query:  "SELECT \\* FROM `admin_audit_logs` WHERE actor_id = \\? AND target = \\? ORDER BY created_at desc, id desc LIMIT \\?",
+			args:   []driver.Value{actorID, target, 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newDBWithRegexp(t)
+			r := repo.NewAdminAuditLogRepository(db, nil)
+
+			mock.ExpectQuery(tt.query).
+				WithArgs(tt.args...).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "action", "actor_id"}).AddRow(uuid.New(), constants.AuditActionHideMemory, actorID))
+			mock.ExpectQuery("SELECT \\* FROM `users` WHERE `users`.`id` = \\?").
+				WithArgs(actorID).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "email"}).AddRow(actorID, "admin@example.com"))
+
+			entries, err := r.List(ctx, tt.filter, &dto.CursorPagination{Limit: 2})
+			require.NoError(t, err)
+			require.Len(t, entries, 1)
+			require.Equal(t, "admin@example.com", entries[0].Actor.Email)
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestAdminAuditLogList_Cursor(t *testing.T) {
+	ctx := context.Background()
+	db, mock := newDBWithRegexp(t)
+	r := repo.NewAdminAuditLogRepository(db, nil)
+	afterID := uuid.New()
+	createdAt := time.Now()
+
+	mock.ExpectQuery("SELECT \\* FROM `admin_audit_logs` WHERE id = \\?").
+		WithArgs(afterID, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(afterID, createdAt))
+	mock.ExpectQuery("SELECT \\* FROM `admin_audit_logs` WHERE \\(created_at < \\?\\) OR \\(created_at = \\? AND id < \\?\\) ORDER BY created_at desc, id desc LIMIT \\?").
+		WithArgs(createdAt, createdAt, afterID, constants.DefaultLimit+1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	entries, err := r.List(ctx, nil, &dto.CursorPagination{AfterID: &afterID})
+	require.NoError(t, err)
+	require.Empty(t, entries)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAdminAuditLogList_InvalidCursor(t *testing.T) {
+	ctx := context.Background()
+	db, mock := newDBWithRegexp(t)
+	r := repo.NewAdminAuditLogRepository(db, nil)
+	afterID := uuid.New()
+
+	mock.ExpectQuery("SELECT \\* FROM `admin_audit_logs` WHERE id = \\?").
+		WithArgs(afterID, 1).
+		WillReturnError(gorm.ErrRecordNotFound)
+
+	_, err := r.List(ctx, nil, &dto.CursorPagination{AfterID: &afterID})
+	require.ErrorIs(t, err, apperrors.ErrInvalidCursorPagination)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
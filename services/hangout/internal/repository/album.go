@@ -0,0 +1,158 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/otel"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"gorm.io/gorm"
+)
+
+type AlbumRepository interface {
+	WithTx(tx *gorm.DB) AlbumRepository
+	CreateAlbum(ctx context.Context, album *domain.Album) error
+	GetAlbumByID(ctx context.Context, id uuid.UUID) (*domain.Album, error)
+	GetAlbumsByHangoutID(ctx context.Context, hangoutID uuid.UUID) ([]domain.Album, error)
+	UpdateAlbum(ctx context.Context, album *domain.Album) error
+	DeleteAlbum(ctx context.Context, id uuid.UUID, at time.Time) error
+}
+
+type albumRepository struct {
+	db      *gorm.DB
+	metrics *otel.MetricsRecorder
+}
+
+func NewAlbumRepository(db *gorm.DB, metrics *otel.MetricsRecorder) AlbumRepository {
+	return &albumRepository{db: db, metrics: metrics}
+}
+
+func (r *albumRepository) WithTx(tx *gorm.DB) AlbumRepository {
+	return &albumRepository{db: tx, metrics: r.metrics}
+}
+
+func (r *albumRepository) CreateAlbum(ctx context.Context, album *domain.Album) error {
+	ctx, span := otel.StartRepositorySpan(ctx, "CreateAlbum",
+		attribute.String("db.operation", "insert"),
+		attribute.String("db.table", "albums"),
+		attribute.String("hangout.id", album.HangoutID.String()),
+	)
+	defer span.End()
+
+	start := time.Now()
+	err := r.db.WithContext(ctx).Create(album).Error
+	r.metrics.RecordDBOperation(ctx, "insert", "albums", time.Since(start), 1)
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+		return err
+	}
+
+	span.SetAttributes(attribute.String("album.id", album.ID.String()))
+	span.SetStatusOk()
+	return nil
+}
+
+func (r *albumRepository) GetAlbumByID(ctx context.Context, id uuid.UUID) (*domain.Album, error) {
+	ctx, span := otel.StartRepositorySpan(ctx, "GetAlbumByID",
+		attribute.String("db.operation", "select"),
+		attribute.String("db.table", "albums"),
+		attribute.String("album.id", id.String()),
+	)
+	defer span.End()
+
+	var album domain.Album
+
+	start := time.Now()
+	err := r.db.WithContext(ctx).First(&album, "id = ?", id).Error
+	r.metrics.RecordDBOperation(ctx, "select", "albums", time.Since(start), 1)
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetStatusOk()
+	return &album, nil
+}
+
+// GetAlbumsByHangoutID returns every album of a hangout, oldest first.
+func (r *albumRepository) GetAlbumsByHangoutID(ctx context.Context, hangoutID uuid.UUID) ([]domain.Album, error) {
+	ctx, span := otel.StartRepositorySpan(ctx, "GetAlbumsByHangoutID",
+		attribute.String("db.operation", "select"),
+		attribute.String("db.table", "albums"),
+		attribute.String("hangout.id", hangoutID.String()),
+	)
+	defer span.End()
+
+	var albums []domain.Album
+
+	start := time.Now()
+	err := r.db.WithContext(ctx).
+		Where("hangout_id = ?", hangoutID).
+		Order("created_at asc, id asc").
+		Find(&albums).Error
+	r.metrics.RecordDBOperation(ctx, "select", "albums", time.Since(start), len(albums))
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("album.count", len(albums)))
+	span.SetStatusOk()
+	return albums, nil
+}
+
+// UpdateAlbum saves the name and cover of the album.
+func (r *albumRepository) UpdateAlbum(ctx context.Context, album *domain.Album) error {
+	ctx, span := otel.StartRepositorySpan(ctx, "UpdateAlbum",
+		attribute.String("db.operation", "update"),
+		attribute.String("db.table", "albums"),
+		attribute.String("album.id", album.ID.String()),
+	)
+	defer span.End()
+
+	start := time.Now()
+	err := r.db.WithContext(ctx).Model(&domain.Album{}).Where("id = ?", album.ID).Updates(map[string]any{
+		"name":            album.Name,
+		"cover_memory_id": album.CoverMemoryID,
+	}).Error
+	r.metrics.RecordDBOperation(ctx, "update", "albums", time.Since(start), 1)
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+	} else {
+		span.SetStatusOk()
+	}
+	return err
+}
+
+// DeleteAlbum removes the album and revokes the share links for it at the given
+// time.
+func (r *albumRepository) DeleteAlbum(ctx context.Context, id uuid.UUID, at time.Time) error {
+	ctx, span := otel.StartRepositorySpan(ctx, "DeleteAlbum",
+		attribute.String("db.operation", "delete"),
+		attribute.String("db.table", "albums"),
+		attribute.String("album.id", id.String()),
+	)
+	defer span.End()
+
+	start := time.Now()
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("UPDATE `share_links` SET `revoked_at` = COALESCE(`revoked_at`, ?), `album_id` = NULL WHERE `album_id` = ?", at, id).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", id).Delete(&domain.Album{}).Error
+	})
+	r.metrics.RecordDBOperation(ctx, "delete", "albums", time.Since(start), 1)
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+	} else {
+		span.SetStatusOk()
+	}
+	return err
+}
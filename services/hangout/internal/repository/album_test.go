@@ -0,0 +1,169 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Ernestgio/Hangout-Planner/pkg/shared/enums"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	repo "github.com/Ernestgio/Hangout-Planner/services/hangout/internal/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestAlbumCreateAlbum_TableDriven(t *testing.T) {
+	ctx := context.Background()
+	dbErr := errors.New("db error")
+
+	tests := []struct {
+		name    string
+		execErr error
+	}{
+		{name: "success"},
+		{name: "db error", execErr: dbErr},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newDBWithRegexp(t)
+			r := repo.NewAlbumRepository(db, nil)
+			album := &domain.Album{Name: "Day 1", HangoutID: uuid.New()}
+
+			mock.ExpectBegin()
+			exec := mock.ExpectExec("INSERT INTO `albums`")
+			if tt.execErr != nil {
+				exec.WillReturnError(tt.execErr)
+				mock.ExpectRollback()
+			} else {
+				exec.WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			}
+
+			err := r.CreateAlbum(ctx, album)
+			if tt.execErr != nil {
+				require.ErrorIs(t, err, tt.execErr)
+			} else {
+				require.NoError(t, err)
+				require.NotEqual(t, uuid.Nil, album.ID)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestAlbumGetAlbumByID(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.New()
+
+	t.Run("found", func(t *testing.T) {
+		db, mock := newDBWithRegexp(t)
+		r := repo.NewAlbumRepository(db, nil)
+
+		mock.ExpectQuery("SELECT \\* FROM `albums` WHERE id = \\?").
+			WithArgs(id, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(id, "Day 1"))
+
+		album, err := r.GetAlbumByID(ctx, id)
+		require.NoError(t, err)
+		require.Equal(t, "Day 1", album.Name)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		db, mock := newDBWithRegexp(t)
+		r := repo.NewAlbumRepository(db, nil)
+
+		mock.ExpectQuery("SELECT \\* FROM `albums` WHERE id = \\?").
+			WithArgs(id, 1).
+			WillReturnError(gorm.ErrRecordNotFound)
+
+		album, err := r.GetAlbumByID(ctx, id)
+		require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+		require.Nil(t, album)
+	})
+}
+
+func TestAlbumGetAlbumsByHangoutID(t *testing.T) {
+	ctx := context.Background()
+	hangoutID := uuid.New()
+	db, mock := newDBWithRegexp(t)
+	r := repo.NewAlbumRepository(db, nil)
+
+	mock.ExpectQuery("SELECT \\* FROM `albums` WHERE hangout_id = \\? ORDER BY created_at asc, id asc").
+		WithArgs(hangoutID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(uuid.New(), "Day 1").AddRow(uuid.New(), "Day 2"))
+
+	albums, err := r.GetAlbumsByHangoutID(ctx, hangoutID)
+	require.NoError(t, err)
+	require.Len(t, albums, 2)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAlbumUpdateAlbum(t *testing.T) {
+	ctx := context.Background()
+	coverID := uuid.New()
+	album := &domain.Album{ID: uuid.New(), Name: "Day 2", CoverMemoryID: &coverID}
+	db, mock := newDBWithRegexp(t)
+	r := repo.NewAlbumRepository(db, nil)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE `albums` SET `cover_memory_id`=\\?,`name`=\\?,`updated_at`=\\? WHERE id = \\?").
+		WithArgs(&coverID, "Day 2", AnyTime{}, album.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	require.NoError(t, r.UpdateAlbum(ctx, album))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAlbumDeleteAlbum(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.New()
+	at := time.Now()
+	dbErr := errors.New("db error")
+	db, mock := newDBWithRegexp(t)
+	r := repo.NewAlbumRepository(db, nil)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE `share_links` SET `revoked_at` = COALESCE\\(`revoked_at`, \\?\\), `album_id` = NULL WHERE `album_id` = \\?").WithArgs(at, id).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM `albums` WHERE id = \\?").WithArgs(id).WillReturnError(dbErr)
+	mock.ExpectRollback()
+
+	require.ErrorIs(t, r.DeleteAlbum(ctx, id, at), dbErr)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAlbumDeleteAlbum_MySQL(t *testing.T) {
+	db := newMySQLDB(t)
+	ctx := context.Background()
+	r := repo.NewAlbumRepository(db, nil)
+
+	user := &domain.User{Name: "Ann", Email: "ann@example.com", Password: "hash"}
+	require.NoError(t, db.Create(user).Error)
+	hangout := &domain.Hangout{Title: "Picnic", Date: time.Now(), Status: enums.StatusPlanning, UserID: &user.ID}
+	require.NoError(t, db.Create(hangout).Error)
+	album := &domain.Album{Name: "Day one", HangoutID: hangout.ID}
+	require.NoError(t, db.Create(album).Error)
+	link := &domain.ShareLink{TokenHash: "hash", Scope: constants.ShareScopeAlbum, HangoutID: hangout.ID, AlbumID: &album.ID, CreatedByID: user.ID}
+	require.NoError(t, db.Omit("Hangout", "Album", "CreatedBy").Create(link).Error)
+	access := &domain.ShareLinkAccess{Outcome: constants.ShareAccessGranted, IPAddress: "203.0.113.7", UserAgent: "curl/8", ShareLinkID: link.ID}
+	require.NoError(t, db.Omit("ShareLink").Create(access).Error)
+
+	require.NoError(t, r.DeleteAlbum(ctx, album.ID, time.Now()))
+
+	var albums int64
+	require.NoError(t, db.Model(&domain.Album{}).Where("id = ?", album.ID).Count(&albums).Error)
+	require.Zero(t, albums)
+	var kept domain.ShareLink
+	require.NoError(t, db.First(&kept, "id = ?", link.ID).Error)
+	require.Nil(t, kept.AlbumID)
+	require.NotNil(t, kept.RevokedAt)
+	var accesses int64
+	require.NoError(t, db.Model(&domain.ShareLinkAccess{}).Where("share_link_id = ?", link.ID).Count(&accesses).Error)
+	require.Equal(t, int64(1), accesses)
+}
@@ -0,0 +1,227 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/otel"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"gorm.io/gorm"
+)
+
+type CommentRepository interface {
+	CreateComment(ctx context.Context, comment *domain.Comment) error
+	GetCommentByID(ctx context.Context, id uuid.UUID) (*domain.Comment, error)
+	GetCommentsByHangoutID(ctx context.Context, hangoutID uuid.UUID, pagination *dto.CursorPagination) ([]domain.Comment, error)
+	GetRepliesByParentIDs(ctx context.Context, parentIDs []uuid.UUID) ([]domain.Comment, error)
+	UpdateComment(ctx context.Context, comment *domain.Comment) error
+	DeleteComment(ctx context.Context, id uuid.UUID) error
+}
+
+type commentRepository struct {
+	db      *gorm.DB
+	metrics *otel.MetricsRecorder
+}
+
+func NewCommentRepository(db *gorm.DB, metrics *otel.MetricsRecorder) CommentRepository {
+	return &commentRepository{db: db, metrics: metrics}
+}
+
+// CreateComment inserts the comment together with its mentions.
+func (r *commentRepository) CreateComment(ctx context.Context, comment *domain.Comment) error {
+	ctx, span := otel.StartRepositorySpan(ctx, "CreateComment",
+		attribute.String("db.operation", "insert"),
+		attribute.String("db.table", "comments"),
+		attribute.String("hangout.id", comment.HangoutID.String()),
+	)
+	defer span.End()
+
+	start := time.Now()
+	err := r.db.WithContext(ctx).Create(comment).Error
+	r.metrics.RecordDBOperation(ctx, "insert", "comments", time.Since(start), 1)
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+		return err
+	}
+
+	span.SetAttributes(attribute.String("comment.id", comment.ID.String()))
+	span.SetStatusOk()
+	return nil
+}
+
+func (r *commentRepository) GetCommentByID(ctx context.Context, id uuid.UUID) (*domain.Comment, error) {
+	ctx, span := otel.StartRepositorySpan(ctx, "GetCommentByID",
+		attribute.String("db.operation", "select"),
+		attribute.String("db.table", "comments"),
+		attribute.String("comment.id", id.String()),
+	)
+	defer span.End()
+
+	var comment domain.Comment
+
+	start := time.Now()
+	err := r.db.WithContext(ctx).
+		Preload("User").
+		Preload("Mentions").
+		First(&comment, "id = ?", id).Error
+	r.metrics.RecordDBOperation(ctx, "select", "comments", time.Since(start), 1)
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetStatusOk()
+	return &comment, nil
+}
+
+// GetCommentsByHangoutID returns the top-level comments of a hangout oldest first,
+// fetching one extra row so the caller can tell whether there are more.
+func (r *commentRepository) GetCommentsByHangoutID(ctx context.Context, hangoutID uuid.UUID, pagination *dto.CursorPagination) ([]domain.Comment, error) {
+	ctx, span := otel.StartRepositorySpan(ctx, "GetCommentsByHangoutID",
+		attribute.String("db.operation", "select"),
+		attribute.String("db.table", "comments"),
+		attribute.String("hangout.id", hangoutID.String()),
+		attribute.Int("pagination.limit", pagination.GetLimit()),
+	)
+	defer span.End()
+
+	start := time.Now()
+	var comments []domain.Comment
+
+	query := r.db.WithContext(ctx).Model(&domain.Comment{}).
+		Where("hangout_id = ? AND parent_id IS NULL", hangoutID)
+
+	if pagination.AfterID != nil {
+		var cursorItem domain.Comment
+		if err := r.db.WithContext(ctx).First(&cursorItem, "id = ? AND hangout_id = ? AND parent_id IS NULL", *pagination.AfterID, hangoutID).Error; err != nil {
+			return nil, apperrors.ErrInvalidCursorPagination
+		}
+
+		query = query.Where(
+			"(created_at > ?) OR (created_at = ? AND id > ?)",
+			cursorItem.CreatedAt, cursorItem.CreatedAt, cursorItem.ID,
+		)
+	}
+
+	err := query.
+		Preload("User").
+		Preload("Mentions").
+		Order("created_at asc, id asc").
+		Limit(pagination.GetLimit() + 1).
+		Find(&comments).Error
+	r.metrics.RecordDBOperation(ctx, "select", "comments", time.Since(start), len(comments))
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("comment.count", len(comments)))
+	span.SetStatusOk()
+	return comments, nil
+}
+
+// GetRepliesByParentIDs returns every reply to the given comments, oldest first.
+func (r *commentRepository) GetRepliesByParentIDs(ctx context.Context, parentIDs []uuid.UUID) ([]domain.Comment, error) {
+	if len(parentIDs) == 0 {
+		return []domain.Comment{}, nil
+	}
+
+	ctx, span := otel.StartRepositorySpan(ctx, "GetRepliesByParentIDs",
+		attribute.String("db.operation", "select"),
+		attribute.String("db.table", "comments"),
+		attribute.Int("comment.parent_count", len(parentIDs)),
+	)
+	defer span.End()
+
+	start := time.Now()
+	var replies []domain.Comment
+	err := r.db.WithContext(ctx).
+		Preload("User").
+		Preload("Mentions").
+		Where("parent_id IN ?", parentIDs).
+		Order("created_at asc, id asc").
+		Find(&replies).Error
+	r.metrics.RecordDBOperation(ctx, "select", "comments", time.Since(start), len(replies))
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("comment.count", len(replies)))
+	span.SetStatusOk()
+	return replies, nil
+}
+
+// UpdateComment saves the body and edit time of the comment and replaces its
+// mentions.
+func (r *commentRepository) UpdateComment(ctx context.Context, comment *domain.Comment) error {
+	ctx, span := otel.StartRepositorySpan(ctx, "UpdateComment",
+		attribute.String("db.operation", "update"),
+		attribute.String("db.table", "comments"),
+		attribute.String("comment.id", comment.ID.String()),
+	)
+	defer span.End()
+
+	start := time.Now()
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&domain.Comment{}).Where("id = ?", comment.ID).Updates(map[string]any{
+			"body":      comment.Body,
+			"edited_at": comment.EditedAt,
+		}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("comment_id = ?", comment.ID).Delete(&domain.CommentMention{}).Error; err != nil {
+			return err
+		}
+		if len(comment.Mentions) == 0 {
+			return nil
+		}
+		return tx.Create(&comment.Mentions).Error
+	})
+	r.metrics.RecordDBOperation(ctx, "update", "comments", time.Since(start), 1)
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+		return err
+	}
+
+	span.SetStatusOk()
+	return nil
+}
+
+// DeleteComment permanently removes the comment, its replies and their mentions.
+func (r *commentRepository) DeleteComment(ctx context.Context, id uuid.UUID) error {
+	ctx, span := otel.StartRepositorySpan(ctx, "DeleteComment",
+		attribute.String("db.operation", "delete"),
+		attribute.String("db.table", "comments"),
+		attribute.String("comment.id", id.String()),
+	)
+	defer span.End()
+
+	start := time.Now()
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM `comment_mentions` WHERE `comment_id` IN (SELECT `id` FROM `comments` WHERE `id` = ? OR `parent_id` = ?)", id, id).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM `comments` WHERE `parent_id` = ?", id).Error; err != nil {
+			return err
+		}
+		return tx.Exec("DELETE FROM `comments` WHERE `id` = ?", id).Error
+	})
+	r.metrics.RecordDBOperation(ctx, "delete", "comments", time.Since(start), 1)
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+	} else {
+		span.SetStatusOk()
+	}
+	return err
+}
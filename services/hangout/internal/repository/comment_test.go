@@ -0,0 +1,286 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
+	repo "github.com/Ernestgio/Hangout-Planner/services/hangout/internal/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestCommentCreateComment_TableDriven(t *testing.T) {
+	ctx := context.Background()
+	dbErr := errors.New("db error")
+
+	tests := []struct {
+		name    string
+		execErr error
+	}{
+		{name: "success"},
+		{name: "db error", execErr: dbErr},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newDBWithRegexp(t)
+			r := repo.NewCommentRepository(db, nil)
+			comment := &domain.Comment{
+				Body:      "<p>See you there</p>",
+				HangoutID: uuid.New(),
+				UserID:    uuid.New(),
+				Mentions:  []domain.CommentMention{{UserID: uuid.New()}},
+			}
+
+			mock.ExpectBegin()
+			exec := mock.ExpectExec("INSERT INTO `comments`")
+			if tt.execErr != nil {
+				exec.WillReturnError(tt.execErr)
+				mock.ExpectRollback()
+			} else {
+				exec.WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO `comment_mentions`").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			}
+
+			err := r.CreateComment(ctx, comment)
+			if tt.execErr != nil {
+				require.ErrorIs(t, err, tt.execErr)
+			} else {
+				require.NoError(t, err)
+				require.NotEqual(t, uuid.Nil, comment.ID)
+				require.Equal(t, comment.ID, comment.Mentions[0].CommentID)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestCommentGetCommentByID(t *testing.T) {
+	ctx := context.Background()
+	id, userID, mentionedID := uuid.New(), uuid.New(), uuid.New()
+
+	t.Run("found", func(t *testing.T) {
+		db, mock := newDBWithRegexp(t)
+		r := repo.NewCommentRepository(db, nil)
+
+		mock.ExpectQuery("SELECT \\* FROM `comments` WHERE id = \\?").
+			WithArgs(id, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "body", "user_id"}).AddRow(id, "<p>hi</p>", userID))
+		mock.ExpectQuery("SELECT \\* FROM `comment_mentions` WHERE `comment_mentions`.`comment_id` = \\?").
+			WithArgs(id).
+			WillReturnRows(sqlmock.NewRows([]string{"comment_id", "user_id"}).AddRow(id, mentionedID))
+		mock.ExpectQuery("SELECT \\* FROM `users` WHERE `users`.`id` = \\?").
+			WithArgs(userID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(userID, "Ada"))
+
+		comment, err := r.GetCommentByID(ctx, id)
+		require.NoError(t, err)
+		require.Equal(t, "Ada", comment.User.Name)
+		require.Len(t, comment.Mentions, 1)
+		require.Equal(t, mentionedID, comment.Mentions[0].UserID)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		db, mock := newDBWithRegexp(t)
+		r := repo.NewCommentRepository(db, nil)
+
+		mock.ExpectQuery("SELECT \\* FROM `comments` WHERE id = \\?").
+			WithArgs(id, 1).
+			WillReturnError(gorm.ErrRecordNotFound)
+
+		_, err := r.GetCommentByID(ctx, id)
+		require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestCommentGetCommentsByHangoutID(t *testing.T) {
+	ctx := context.Background()
+	db, mock := newDBWithRegexp(t)
+	r := repo.NewCommentRepository(db, nil)
+	hangoutID := uuid.New()
+
+	mock.ExpectQuery("SELECT \\* FROM `comments` WHERE hangout_id = \\? AND parent_id IS NULL ORDER BY created_at asc, id asc LIMIT \\?").
+		WithArgs(hangoutID, 3).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	comments, err := r.GetCommentsByHangoutID(ctx, hangoutID, &dto.CursorPagination{Limit: 2})
+	require.NoError(t, err)
+	require.Empty(t, comments)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCommentGetCommentsByHangoutID_Cursor(t *testing.T) {
+	ctx := context.Background()
+	db, mock := newDBWithRegexp(t)
+	r := repo.NewCommentRepository(db, nil)
+	hangoutID, afterID := uuid.New(), uuid.New()
+	createdAt := time.Now()
+
+	mock.ExpectQuery("SELECT \\* FROM `comments` WHERE id = \\? AND hangout_id = \\? AND parent_id IS NULL").
+		WithArgs(afterID, hangoutID, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "hangout_id"}).AddRow(afterID, createdAt, hangoutID))
+	mock.ExpectQuery("SELECT \\* FROM `comments` WHERE \\(hangout_id = \\? AND parent_id IS NULL\\) AND \\(\\(created_at > \\?\\) OR \\(created_at = \\? AND id > \\?\\)\\) ORDER BY created_at asc, id asc LIMIT \\?").
+		WithArgs(hangoutID, createdAt, createdAt, afterID, constants.DefaultLimit+1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	comments, err := r.GetCommentsByHangoutID(ctx, hangoutID, &dto.CursorPagination{AfterID: &afterID})
+	require.NoError(t, err)
+	require.Empty(t, comments)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCommentGetCommentsByHangoutID_InvalidCursor(t *testing.T) {
+	ctx := context.Background()
+	db, mock := newDBWithRegexp(t)
+	r := repo.NewCommentRepository(db, nil)
+	hangoutID, afterID := uuid.New(), uuid.New()
+
+	mock.ExpectQuery("SELECT \\* FROM `comments` WHERE id = \\? AND hangout_id = \\? AND parent_id IS NULL").
+		WithArgs(afterID, hangoutID, 1).
+		WillReturnError(gorm.ErrRecordNotFound)
+
+	_, err := r.GetCommentsByHangoutID(ctx, hangoutID, &dto.CursorPagination{AfterID: &afterID})
+	require.ErrorIs(t, err, apperrors.ErrInvalidCursorPagination)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCommentGetRepliesByParentIDs(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("no parents", func(t *testing.T) {
+		db, mock := newDBWithRegexp(t)
+		r := repo.NewCommentRepository(db, nil)
+
+		replies, err := r.GetRepliesByParentIDs(ctx, nil)
+		require.NoError(t, err)
+		require.Empty(t, replies)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("success", func(t *testing.T) {
+		db, mock := newDBWithRegexp(t)
+		r := repo.NewCommentRepository(db, nil)
+		parentA, parentB := uuid.New(), uuid.New()
+
+		mock.ExpectQuery("SELECT \\* FROM `comments` WHERE parent_id IN \\(\\?,\\?\\) ORDER BY created_at asc, id asc").
+			WithArgs(parentA, parentB).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		replies, err := r.GetRepliesByParentIDs(ctx, []uuid.UUID{parentA, parentB})
+		require.NoError(t, err)
+		require.Empty(t, replies)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestCommentUpdateComment_TableDriven(t *testing.T) {
+	ctx := context.Background()
+	dbErr := errors.New("db error")
+	editedAt := time.Now()
+
+	tests := []struct {
+		name     string
+		mentions []domain.CommentMention
+		execErr  error
+	}{
+		{name: "replaces mentions", mentions: []domain.CommentMention{{UserID: uuid.New()}}},
+		{name: "clears mentions"},
+		{name: "db error", execErr: dbErr},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newDBWithRegexp(t)
+			r := repo.NewCommentRepository(db, nil)
+			id := uuid.New()
+			for i := range tt.mentions {
+				tt.mentions[i].CommentID = id
+			}
+			comment := &domain.Comment{ID: id, Body: "<p>edited</p>", EditedAt: &editedAt, Mentions: tt.mentions}
+
+			mock.ExpectBegin()
+			exec := mock.ExpectExec("UPDATE `comments` SET `body`=\\?,`edited_at`=\\?,`updated_at`=\\? WHERE id = \\?").
+				WithArgs(comment.Body, &editedAt, AnyTime{}, id)
+			if tt.execErr != nil {
+				exec.WillReturnError(tt.execErr)
+				mock.ExpectRollback()
+			} else {
+				exec.WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("DELETE FROM `comment_mentions` WHERE comment_id = \\?").
+					WithArgs(id).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				if len(tt.mentions) > 0 {
+					mock.ExpectExec("INSERT INTO `comment_mentions`").WillReturnResult(sqlmock.NewResult(1, 1))
+				}
+				mock.ExpectCommit()
+			}
+
+			err := r.UpdateComment(ctx, comment)
+			if tt.execErr != nil {
+				require.ErrorIs(t, err, tt.execErr)
+			} else {
+				require.NoError(t, err)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestCommentDeleteComment(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.New()
+	dbErr := errors.New("db error")
+
+	testCases := []struct {
+		name        string
+		setupMock   func(mock sqlmock.Sqlmock)
+		expectError bool
+	}{
+		{
+			name: "success",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("DELETE FROM `comment_mentions` WHERE `comment_id` IN (SELECT `id` FROM `comments` WHERE `id` = ? OR `parent_id` = ?)").WithArgs(id, id).WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec("DELETE FROM `comments` WHERE `parent_id` = ?").WithArgs(id).WillReturnResult(sqlmock.NewResult(0, 3))
+				mock.ExpectExec("DELETE FROM `comments` WHERE `id` = ?").WithArgs(id).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "replies delete error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("DELETE FROM `comment_mentions` WHERE `comment_id` IN (SELECT `id` FROM `comments` WHERE `id` = ? OR `parent_id` = ?)").WithArgs(id, id).WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec("DELETE FROM `comments` WHERE `parent_id` = ?").WithArgs(id).WillReturnError(dbErr)
+				mock.ExpectRollback()
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock := setupDB(t)
+			r := repo.NewCommentRepository(db, nil)
+			tc.setupMock(mock)
+			err := r.DeleteComment(ctx, id)
+			if tc.expectError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
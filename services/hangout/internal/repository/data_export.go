@@ -0,0 +1,204 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/otel"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"gorm.io/gorm"
+)
+
+type DataExportRepository interface {
+	Create(ctx context.Context, export *domain.DataExport) error
+	GetByID(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*domain.DataExport, error)
+	GetActiveByUserID(ctx context.Context, userID uuid.UUID) (*domain.DataExport, error)
+	GetDueExports(ctx context.Context, now time.Time, limit int) ([]domain.DataExport, error)
+	ClaimExport(ctx context.Context, id uuid.UUID, now time.Time, leaseUntil time.Time) (bool, error)
+	Update(ctx context.Context, export *domain.DataExport) error
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
+
+type dataExportRepository struct {
+	db      *gorm.DB
+	metrics *otel.MetricsRecorder
+}
+
+func NewDataExportRepository(db *gorm.DB, metrics *otel.MetricsRecorder) DataExportRepository {
+	return &dataExportRepository{db: db, metrics: metrics}
+}
+
+func (r *dataExportRepository) Create(ctx context.Context, export *domain.DataExport) error {
+	ctx, span := otel.StartRepositorySpan(ctx, "Create",
+		attribute.String("db.operation", "insert"),
+		attribute.String("db.table", "data_exports"),
+		attribute.String("user.id", export.UserID.String()),
+	)
+	defer span.End()
+
+	start := time.Now()
+	err := r.db.WithContext(ctx).Create(export).Error
+	r.metrics.RecordDBOperation(ctx, "insert", "data_exports", time.Since(start), 1)
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+	} else {
+		span.SetStatusOk()
+	}
+	return err
+}
+
+func (r *dataExportRepository) GetByID(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*domain.DataExport, error) {
+	ctx, span := otel.StartRepositorySpan(ctx, "GetByID",
+		attribute.String("db.operation", "select"),
+		attribute.String("db.table", "data_exports"),
+		attribute.String("export.id", id.String()),
+		attribute.String("user.id", userID.String()),
+	)
+	defer span.End()
+
+	start := time.Now()
+	var export domain.DataExport
+	err := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).First(&export).Error
+	r.metrics.RecordDBOperation(ctx, "select", "data_exports", time.Since(start), 1)
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetStatusOk()
+	return &export, nil
+}
+
+// GetActiveByUserID returns the user's export that is still pending or
+// processing, if any.
+func (r *dataExportRepository) GetActiveByUserID(ctx context.Context, userID uuid.UUID) (*domain.DataExport, error) {
+	ctx, span := otel.StartRepositorySpan(ctx, "GetActiveByUserID",
+		attribute.String("db.operation", "select"),
+		attribute.String("db.table", "data_exports"),
+		attribute.String("user.id", userID.String()),
+	)
+	defer span.End()
+
+	start := time.Now()
+	var export domain.DataExport
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND status IN ?", userID, []string{constants.DataExportPending, constants.DataExportProcessing}).
+		First(&export).Error
+	r.metrics.RecordDBOperation(ctx, "select", "data_exports", time.Since(start), 1)
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetStatusOk()
+	return &export, nil
+}
+
+// GetDueExports returns pending exports that no instance currently holds a
+// claim on, oldest first.
+func (r *dataExportRepository) GetDueExports(ctx context.Context, now time.Time, limit int) ([]domain.DataExport, error) {
+	ctx, span := otel.StartRepositorySpan(ctx, "GetDueExports",
+		attribute.String("db.operation", "select"),
+		attribute.String("db.table", "data_exports"),
+		attribute.Int("batch.limit", limit),
+	)
+	defer span.End()
+
+	start := time.Now()
+	var exports []domain.DataExport
+	err := r.db.WithContext(ctx).
+		Where("status = ? AND (claimed_until IS NULL OR claimed_until <= ?)", constants.DataExportPending, now).
+		Order("created_at asc").
+		Limit(limit).
+		Find(&exports).Error
+	r.metrics.RecordDBOperation(ctx, "select", "data_exports", time.Since(start), len(exports))
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("export.count", len(exports)))
+	span.SetStatusOk()
+	return exports, nil
+}
+
+// ClaimExport holds a pending export until leaseUntil so other instances skip it
+// while it is being built.
+func (r *dataExportRepository) ClaimExport(ctx context.Context, id uuid.UUID, now time.Time, leaseUntil time.Time) (bool, error) {
+	ctx, span := otel.StartRepositorySpan(ctx, "ClaimExport",
+		attribute.String("db.operation", "update"),
+		attribute.String("db.table", "data_exports"),
+		attribute.String("export.id", id.String()),
+	)
+	defer span.End()
+
+	start := time.Now()
+	result := r.db.WithContext(ctx).Model(&domain.DataExport{}).
+		Where("id = ? AND status = ? AND (claimed_until IS NULL OR claimed_until <= ?)", id, constants.DataExportPending, now).
+		Update("claimed_until", leaseUntil)
+	r.metrics.RecordDBOperation(ctx, "update", "data_exports", time.Since(start), int(result.RowsAffected))
+
+	if result.Error != nil {
+		_ = span.RecordErrorWithStatus(result.Error)
+		return false, result.Error
+	}
+
+	claimed := result.RowsAffected > 0
+	span.SetAttributes(attribute.Bool("export.claimed", claimed))
+	span.SetStatusOk()
+	return claimed, nil
+}
+
+func (r *dataExportRepository) Update(ctx context.Context, export *domain.DataExport) error {
+	ctx, span := otel.StartRepositorySpan(ctx, "Update",
+		attribute.String("db.operation", "update"),
+		attribute.String("db.table", "data_exports"),
+		attribute.String("export.id", export.ID.String()),
+		attribute.String("export.status", export.Status),
+	)
+	defer span.End()
+
+	start := time.Now()
+	err := r.db.WithContext(ctx).Model(export).
+		Select("status", "archive_id", "last_error", "claimed_until", "completed_at", "updated_at").
+		Updates(export).Error
+	r.metrics.RecordDBOperation(ctx, "update", "data_exports", time.Since(start), 1)
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+		return err
+	}
+
+	span.SetStatusOk()
+	return nil
+}
+
+// DeleteExpired removes exports past their expiry, whatever their status,
+// and returns how many were removed.
+func (r *dataExportRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	ctx, span := otel.StartRepositorySpan(ctx, "DeleteExpired",
+		attribute.String("db.operation", "delete"),
+		attribute.String("db.table", "data_exports"),
+	)
+	defer span.End()
+
+	start := time.Now()
+	result := r.db.WithContext(ctx).Where("expires_at <= ?", now).Delete(&domain.DataExport{})
+	r.metrics.RecordDBOperation(ctx, "delete", "data_exports", time.Since(start), int(result.RowsAffected))
+
+	if result.Error != nil {
+		_ = span.RecordErrorWithStatus(result.Error)
+		return 0, result.Error
+	}
+
+	span.SetAttributes(attribute.Int64("export.deleted", result.RowsAffected))
+	span.SetStatusOk()
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,253 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	repo "github.com/Ernestgio/Hangout-Planner/services/hangout/internal/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestDataExportCreate_TableDriven(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		prepare   func(sqlmock.Sqlmock)
+		wantError bool
+	}{
+		{
+			name: "success",
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("INSERT INTO `data_exports`").WillReturnResult(sqlmock.NewResult(1, 1))
+				m.ExpectCommit()
+			},
+		},
+		{
+			name: "db error",
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("INSERT INTO `data_exports`").WillReturnError(errors.New("db error"))
+				m.ExpectRollback()
+			},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newDBWithRegexp(t)
+			r := repo.NewDataExportRepository(db, nil)
+			tt.prepare(mock)
+
+			export := &domain.DataExport{UserID: uuid.New(), Status: constants.DataExportPending, ExpiresAt: time.Now()}
+			err := r.Create(ctx, export)
+			if tt.wantError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.NotEqual(t, uuid.Nil, export.ID)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestDataExportGetByID_TableDriven(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.New()
+	userID := uuid.New()
+
+	tests := []struct {
+		name      string
+		prepare   func(sqlmock.Sqlmock)
+		wantError error
+	}{
+		{
+			name: "found",
+			prepare: func(m sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "status", "user_id"}).
+					AddRow(id.String(), constants.DataExportCompleted, userID.String())
+				m.ExpectQuery("SELECT \\* FROM `data_exports` WHERE id = \\? AND user_id = \\? ORDER BY `data_exports`.`id` LIMIT \\?").
+					WithArgs(id, userID, 1).
+					WillReturnRows(rows)
+			},
+		},
+		{
+			name: "not found",
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT \\* FROM `data_exports` WHERE id = \\? AND user_id = \\?").
+					WithArgs(id, userID, 1).
+					WillReturnError(gorm.ErrRecordNotFound)
+			},
+			wantError: gorm.ErrRecordNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newDBWithRegexp(t)
+			r := repo.NewDataExportRepository(db, nil)
+			tt.prepare(mock)
+
+			export, err := r.GetByID(ctx, id, userID)
+			if tt.wantError != nil {
+				require.ErrorIs(t, err, tt.wantError)
+				require.Nil(t, export)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, constants.DataExportCompleted, export.Status)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestDataExportGetActiveByUserID(t *testing.T) {
+	ctx := context.Background()
+	db, mock := newDBWithRegexp(t)
+	r := repo.NewDataExportRepository(db, nil)
+	userID := uuid.New()
+
+	mock.ExpectQuery("SELECT \\* FROM `data_exports` WHERE user_id = \\? AND status IN \\(\\?,\\?\\) ORDER BY `data_exports`.`id` LIMIT \\?").
+		WithArgs(userID, constants.DataExportPending, constants.DataExportProcessing, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "status", "user_id"}).AddRow(uuid.New(), constants.DataExportPending, userID))
+
+	export, err := r.GetActiveByUserID(ctx, userID)
+	require.NoError(t, err)
+	require.Equal(t, constants.DataExportPending, export.Status)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDataExportGetDueExports(t *testing.T) {
+	ctx := context.Background()
+	db, mock := newDBWithRegexp(t)
+	r := repo.NewDataExportRepository(db, nil)
+	now := time.Now()
+
+	mock.ExpectQuery("SELECT \\* FROM `data_exports` WHERE status = \\? AND \\(claimed_until IS NULL OR claimed_until <= \\?\\) ORDER BY created_at asc LIMIT \\?").
+		WithArgs(constants.DataExportPending, now, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "status", "user_id"}).AddRow(uuid.New(), constants.DataExportPending, uuid.New()))
+
+	exports, err := r.GetDueExports(ctx, now, 10)
+	require.NoError(t, err)
+	require.Len(t, exports, 1)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDataExportClaimExport_TableDriven(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	leaseUntil := now.Add(time.Minute)
+
+	tests := []struct {
+		name        string
+		rows        int64
+		wantClaimed bool
+	}{
+		{name: "claimed", rows: 1, wantClaimed: true},
+		{name: "claimed by another instance", rows: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newDBWithRegexp(t)
+			r := repo.NewDataExportRepository(db, nil)
+			id := uuid.New()
+
+			mock.ExpectBegin()
+			mock.ExpectExec("UPDATE `data_exports` SET `claimed_until`=\\?,`updated_at`=\\? WHERE id = \\? AND status = \\? AND \\(claimed_until IS NULL OR claimed_until <= \\?\\)").
+				WithArgs(leaseUntil, AnyTime{}, id, constants.DataExportPending, now).
+				WillReturnResult(sqlmock.NewResult(0, tt.rows))
+			mock.ExpectCommit()
+
+			claimed, err := r.ClaimExport(ctx, id, now, leaseUntil)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantClaimed, claimed)
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestDataExportUpdate(t *testing.T) {
+	ctx := context.Background()
+	db, mock := newDBWithRegexp(t)
+	r := repo.NewDataExportRepository(db, nil)
+	now := time.Now()
+	archiveID := uuid.NewString()
+	export := &domain.DataExport{
+		ID:          uuid.New(),
+		Status:      constants.DataExportCompleted,
+		ArchiveID:   &archiveID,
+		CompletedAt: &now,
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE `data_exports` SET `status`=\\?,`archive_id`=\\?,`last_error`=\\?,`claimed_until`=\\?,`completed_at`=\\?,`updated_at`=\\? WHERE `id` = \\?").
+		WithArgs(constants.DataExportCompleted, archiveID, "", nil, now, AnyTime{}, export.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	require.NoError(t, r.Update(ctx, export))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDataExportDeleteExpired_TableDriven(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	tests := []struct {
+		name      string
+		prepare   func(sqlmock.Sqlmock)
+		want      int64
+		wantError bool
+	}{
+		{
+			name: "deletes expired",
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("DELETE FROM `data_exports` WHERE expires_at <= \\?").
+					WithArgs(now).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				m.ExpectCommit()
+			},
+			want: 2,
+		},
+		{
+			name: "db error",
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("DELETE FROM `data_exports` WHERE expires_at <= \\?").
+					WithArgs(now).
+					WillReturnError(errors.New("db error"))
+				m.ExpectRollback()
+			},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newDBWithRegexp(t)
+			r := repo.NewDataExportRepository(db, nil)
+			tt.prepare(mock)
+
+			deleted, err := r.DeleteExpired(ctx, now)
+			if tt.wantError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.want, deleted)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/Ernestgio/Hangout-Planner/pkg/shared/enums"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
 	domain "github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
@@ -19,12 +20,21 @@ type HangoutRepository interface {
 	WithTx(tx *gorm.DB) HangoutRepository
 	CreateHangout(ctx context.Context, hangout *domain.Hangout) (*domain.Hangout, error)
 	GetHangoutByID(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*domain.Hangout, error)
+	GetHangoutByIDAnyOwner(ctx context.Context, id uuid.UUID) (*domain.Hangout, error)
 	UpdateHangout(ctx context.Context, hangout *domain.Hangout) (*domain.Hangout, error)
-	DeleteHangout(ctx context.Context, id uuid.UUID) error
+	DeleteHangout(ctx context.Context, id uuid.UUID, version int64) error
 	GetHangoutsByUserID(ctx context.Context, userID uuid.UUID, pagination *dto.CursorPagination) ([]domain.Hangout, error)
 	GetHangoutActivityIDs(ctx context.Context, hangoutID uuid.UUID) ([]uuid.UUID, error)
 	AddHangoutActivities(ctx context.Context, hangoutID uuid.UUID, activityIDs []uuid.UUID) error
 	RemoveHangoutActivities(ctx context.Context, hangoutID uuid.UUID, activityIDs []uuid.UUID) error
+	GetDeletedHangoutByID(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*domain.Hangout, error)
+	GetDeletedHangoutsByUserID(ctx context.Context, userID uuid.UUID, pagination *dto.CursorPagination) ([]domain.Hangout, error)
+	RestoreHangout(ctx context.Context, id uuid.UUID, deletedAt time.Time) error
+	GetHangoutsDeletedBefore(ctx context.Context, before time.Time, limit int) ([]domain.Hangout, error)
+	PurgeHangout(ctx context.Context, id uuid.UUID) error
+	GetHangoutsStartingBetween(ctx context.Context, from time.Time, to time.Time) ([]domain.Hangout, error)
+	GetHangoutsWithRSVPDeadlineBetween(ctx context.Context, from time.Time, to time.Time) ([]domain.Hangout, error)
+	GetAllHangoutsByUserID(ctx context.Context, userID uuid.UUID) ([]domain.Hangout, error)
 }
 
 type hangoutRepository struct {
@@ -85,6 +95,31 @@ func (r *hangoutRepository) GetHangoutByID(ctx context.Context, id uuid.UUID, us
 	return &hangout, nil
 }
 
+// GetHangoutByIDAnyOwner loads a hangout that is not in the trash regardless
+// of who organizes it. Callers must have authorized the access already.
+func (r *hangoutRepository) GetHangoutByIDAnyOwner(ctx context.Context, id uuid.UUID) (*domain.Hangout, error) {
+	ctx, span := otel.StartRepositorySpan(ctx, "GetHangoutByIDAnyOwner",
+		attribute.String("db.operation", "select"),
+		attribute.String("db.table", "hangouts"),
+		attribute.String("hangout.id", id.String()),
+	)
+	defer span.End()
+
+	var hangout domain.Hangout
+
+	start := time.Now()
+	err := r.db.WithContext(ctx).Preload("Activities").First(&hangout, "id = ?", id).Error
+	r.metrics.RecordDBOperation(ctx, "select", "hangouts", time.Since(start), 1)
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetStatusOk()
+	return &hangout, nil
+}
+
 func (r *hangoutRepository) UpdateHangout(ctx context.Context, hangout *domain.Hangout) (*domain.Hangout, error) {
 	ctx, span := otel.StartRepositorySpan(ctx, "UpdateHangout",
 		attribute.String("db.operation", "update"),
@@ -93,23 +128,36 @@ func (r *hangoutRepository) UpdateHangout(ctx context.Context, hangout *domain.H
 	)
 	defer span.End()
 
+	expectedVersion := hangout.Version
+	hangout.Version = expectedVersion + 1
+	hangout.UpdatedAt = time.Now()
+
 	start := time.Now()
-	err := r.db.WithContext(ctx).
+	result := r.db.WithContext(ctx).
 		Model(&domain.Hangout{}).
-		Where("id = ?", hangout.ID).
-		Updates(hangout).Error
+		Where("id = ? AND version = ?", hangout.ID, expectedVersion).
+		Select("title", "description", "date", "rsvp_deadline", "status", "version", "updated_at").
+		Updates(hangout)
 	r.metrics.RecordDBOperation(ctx, "update", "hangouts", time.Since(start), 1)
 
-	if err != nil {
-		_ = span.RecordErrorWithStatus(err)
-		return nil, err
+	if result.Error != nil {
+		hangout.Version = expectedVersion
+		_ = span.RecordErrorWithStatus(result.Error)
+		return nil, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		hangout.Version = expectedVersion
+		_ = span.RecordErrorWithStatus(apperrors.ErrPreconditionFailed)
+		return nil, apperrors.ErrPreconditionFailed
 	}
 
 	span.SetStatusOk()
 	return hangout, nil
 }
 
-func (r *hangoutRepository) DeleteHangout(ctx context.Context, id uuid.UUID) error {
+// DeleteHangout moves the hangout and its memories to the trash.
+func (r *hangoutRepository) DeleteHangout(ctx context.Context, id uuid.UUID, version int64) error {
 	ctx, span := otel.StartRepositorySpan(ctx, "DeleteHangout",
 		attribute.String("db.operation", "delete"),
 		attribute.String("db.table", "hangouts"),
@@ -118,28 +166,22 @@ func (r *hangoutRepository) DeleteHangout(ctx context.Context, id uuid.UUID) err
 	defer span.End()
 
 	start := time.Now()
-	tx := r.db.WithContext(ctx).Begin()
-	if tx.Error != nil {
-		r.metrics.RecordDBOperation(ctx, "delete", "hangouts", time.Since(start), 0)
-		_ = span.RecordErrorWithStatus(tx.Error)
-		return tx.Error
-	}
+	deletedAt := time.Now()
 
-	if err := tx.Exec("DELETE FROM `hangout_activities` WHERE `hangout_id` = ?", id).Error; err != nil {
-		tx.Rollback()
-		r.metrics.RecordDBOperation(ctx, "delete", "hangouts", time.Since(start), 0)
-		_ = span.RecordErrorWithStatus(err)
-		return err
-	}
-
-	if err := tx.Delete(&domain.Hangout{}, "id = ?", id).Error; err != nil {
-		tx.Rollback()
-		r.metrics.RecordDBOperation(ctx, "delete", "hangouts", time.Since(start), 0)
-		_ = span.RecordErrorWithStatus(err)
-		return err
-	}
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("UPDATE `memories` SET `deleted_at` = ? WHERE `hangout_id` = ? AND `deleted_at` IS NULL", deletedAt, id).Error; err != nil {
+			return err
+		}
 
-	err := tx.Commit().Error
+		result := tx.Exec("UPDATE `hangouts` SET `deleted_at` = ? WHERE `id` = ? AND `version` = ? AND `deleted_at` IS NULL", deletedAt, id, version)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return apperrors.ErrPreconditionFailed
+		}
+		return nil
+	})
 	r.metrics.RecordDBOperation(ctx, "delete", "hangouts", time.Since(start), 1)
 
 	if err != nil {
@@ -294,3 +336,254 @@ func (r *hangoutRepository) RemoveHangoutActivities(ctx context.Context, hangout
 	}
 	return err
 }
+
+func (r *hangoutRepository) GetDeletedHangoutByID(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*domain.Hangout, error) {
+	ctx, span := otel.StartRepositorySpan(ctx, "GetDeletedHangoutByID",
+		attribute.String("db.operation", "select"),
+		attribute.String("db.table", "hangouts"),
+		attribute.String("hangout.id", id.String()),
+		attribute.String("user.id", userID.String()),
+	)
+	defer span.End()
+
+	var hangout domain.Hangout
+
+	start := time.Now()
+	err := r.db.WithContext(ctx).Unscoped().
+		First(&hangout, "id = ? AND user_id = ? AND deleted_at IS NOT NULL", id, userID).Error
+	r.metrics.RecordDBOperation(ctx, "select", "hangouts", time.Since(start), 1)
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetStatusOk()
+	return &hangout, nil
+}
+
+func (r *hangoutRepository) GetDeletedHangoutsByUserID(ctx context.Context, userID uuid.UUID, pagination *dto.CursorPagination) ([]domain.Hangout, error) {
+	ctx, span := otel.StartRepositorySpan(ctx, "GetDeletedHangoutsByUserID",
+		attribute.String("db.operation", "select"),
+		attribute.String("db.table", "hangouts"),
+		attribute.String("user.id", userID.String()),
+		attribute.Int("pagination.limit", pagination.GetLimit()),
+	)
+	defer span.End()
+
+	start := time.Now()
+	var hangouts []domain.Hangout
+
+	query := r.db.WithContext(ctx).Unscoped().Model(&domain.Hangout{}).
+		Where("user_id = ? AND deleted_at IS NOT NULL", userID)
+
+	if pagination.AfterID != nil {
+		var cursorItem domain.Hangout
+		if err := r.db.WithContext(ctx).Unscoped().First(&cursorItem, "id = ? AND deleted_at IS NOT NULL", *pagination.AfterID).Error; err != nil {
+			return nil, apperrors.ErrInvalidCursorPagination
+		}
+
+		query = query.Where(
+			"(deleted_at < ?) OR (deleted_at = ? AND id < ?)",
+			cursorItem.DeletedAt.Time, cursorItem.DeletedAt.Time, cursorItem.ID,
+		)
+	}
+
+	err := query.
+		Order("deleted_at desc, id desc").
+		Limit(pagination.GetLimit() + 1).
+		Find(&hangouts).Error
+	r.metrics.RecordDBOperation(ctx, "select", "hangouts", time.Since(start), len(hangouts))
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("hangout.count", len(hangouts)))
+	span.SetStatusOk()
+	return hangouts, nil
+}
+
+// RestoreHangout takes the hangout out of the trash together with the memories
+// that were deleted alongside it.
+func (r *hangoutRepository) RestoreHangout(ctx context.Context, id uuid.UUID, deletedAt time.Time) error {
+	ctx, span := otel.StartRepositorySpan(ctx, "RestoreHangout",
+		attribute.String("db.operation", "update"),
+		attribute.String("db.table", "hangouts"),
+		attribute.String("hangout.id", id.String()),
+	)
+	defer span.End()
+
+	start := time.Now()
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("UPDATE `memories` SET `deleted_at` = NULL WHERE `hangout_id` = ? AND `deleted_at` = ?", id, deletedAt).Error; err != nil {
+			return err
+		}
+		return tx.Exec("UPDATE `hangouts` SET `deleted_at` = NULL WHERE `id` = ?", id).Error
+	})
+	r.metrics.RecordDBOperation(ctx, "update", "hangouts", time.Since(start), 1)
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+	} else {
+		span.SetStatusOk()
+	}
+	return err
+}
+
+func (r *hangoutRepository) GetHangoutsDeletedBefore(ctx context.Context, before time.Time, limit int) ([]domain.Hangout, error) {
+	ctx, span := otel.StartRepositorySpan(ctx, "GetHangoutsDeletedBefore",
+		attribute.String("db.operation", "select"),
+		attribute.String("db.table", "hangouts"),
+		attribute.Int("db.limit", limit),
+	)
+	defer span.End()
+
+	var hangouts []domain.Hangout
+
+	start := time.Now()
+	err := r.db.WithContext(ctx).Unscoped().
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+		Order("deleted_at asc").
+		Limit(limit).
+		Find(&hangouts).Error
+	r.metrics.RecordDBOperation(ctx, "select", "hangouts", time.Since(start), len(hangouts))
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("hangout.count", len(hangouts)))
+	span.SetStatusOk()
+	return hangouts, nil
+}
+
+// PurgeHangout permanently removes the hangout, its activity links, reminders,
+// comments, albums and all of its memories with their tags and reactions.
+func (r *hangoutRepository) PurgeHangout(ctx context.Context, id uuid.UUID) error {
+	ctx, span := otel.StartRepositorySpan(ctx, "PurgeHangout",
+		attribute.String("db.operation", "delete"),
+		attribute.String("db.table", "hangouts"),
+		attribute.String("hangout.id", id.String()),
+	)
+	defer span.End()
+
+	start := time.Now()
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM `hangout_activities` WHERE `hangout_id` = ?", id).Error; err != nil {
+			return err
+		}
+		for _, table := range []string{"memory_tags", "memory_person_tags", "memory_reactions"} {
+			if err := tx.Exec("DELETE FROM `"+table+"` WHERE `memory_id` IN (SELECT `id` FROM `memories` WHERE `hangout_id` = ?)", id).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Exec("DELETE FROM `memories` WHERE `hangout_id` = ?", id).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM `share_link_accesses` WHERE `share_link_id` IN (SELECT `id` FROM `share_links` WHERE `hangout_id` = ?)", id).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM `share_links` WHERE `hangout_id` = ?", id).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM `albums` WHERE `hangout_id` = ?", id).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM `reminders` WHERE `hangout_id` = ?", id).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM `comment_mentions` WHERE `comment_id` IN (SELECT `id` FROM `comments` WHERE `hangout_id` = ?)", id).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM `comments` WHERE `hangout_id` = ?", id).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("UPDATE `notifications` SET `hangout_id` = NULL WHERE `hangout_id` = ?", id).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("UPDATE `notification_emails` SET `hangout_id` = NULL WHERE `hangout_id` = ?", id).Error; err != nil {
+			return err
+		}
+		return tx.Exec("DELETE FROM `hangouts` WHERE `id` = ?", id).Error
+	})
+	r.metrics.RecordDBOperation(ctx, "delete", "hangouts", time.Since(start), 1)
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+	} else {
+		span.SetStatusOk()
+	}
+	return err
+}
+
+// GetHangoutsStartingBetween returns planned or confirmed hangouts whose date
+// falls in (from, to].
+func (r *hangoutRepository) GetHangoutsStartingBetween(ctx context.Context, from time.Time, to time.Time) ([]domain.Hangout, error) {
+	return r.getActiveHangoutsBetween(ctx, "GetHangoutsStartingBetween", "date", from, to)
+}
+
+// GetHangoutsWithRSVPDeadlineBetween returns planned or confirmed hangouts
+// whose RSVP deadline falls in (from, to].
+func (r *hangoutRepository) GetHangoutsWithRSVPDeadlineBetween(ctx context.Context, from time.Time, to time.Time) ([]domain.Hangout, error) {
+	return r.getActiveHangoutsBetween(ctx, "GetHangoutsWithRSVPDeadlineBetween", "rsvp_deadline", from, to)
+}
+
+func (r *hangoutRepository) getActiveHangoutsBetween(ctx context.Context, operation string, column string, from time.Time, to time.Time) ([]domain.Hangout, error) {
+	ctx, span := otel.StartRepositorySpan(ctx, operation,
+		attribute.String("db.operation", "select"),
+		attribute.String("db.table", "hangouts"),
+	)
+	defer span.End()
+
+	var hangouts []domain.Hangout
+
+	start := time.Now()
+	err := r.db.WithContext(ctx).
+		Where("status IN ? AND user_id IS NOT NULL", []enums.HangoutStatus{enums.StatusPlanning, enums.StatusConfirmed}).
+		Where(fmt.Sprintf("`%s` > ? AND `%s` <= ?", column, column), from, to).
+		Order(column + " asc").
+		Find(&hangouts).Error
+	r.metrics.RecordDBOperation(ctx, "select", "hangouts", time.Since(start), len(hangouts))
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("hangout.count", len(hangouts)))
+	span.SetStatusOk()
+	return hangouts, nil
+}
+
+// GetAllHangoutsByUserID returns every hangout of the user that is not in the
+// trash, with its activities loaded, oldest first.
+func (r *hangoutRepository) GetAllHangoutsByUserID(ctx context.Context, userID uuid.UUID) ([]domain.Hangout, error) {
+	ctx, span := otel.StartRepositorySpan(ctx, "GetAllHangoutsByUserID",
+		attribute.String("db.operation", "select"),
+		attribute.String("db.table", "hangouts"),
+		attribute.String("user.id", userID.String()),
+	)
+	defer span.End()
+
+	var hangouts []domain.Hangout
+
+	start := time.Now()
+	err := r.db.WithContext(ctx).
+		Preload("Activities").
+		Where("user_id = ?", userID).
+		Order("created_at asc").
+		Find(&hangouts).Error
+	r.metrics.RecordDBOperation(ctx, "select", "hangouts", time.Since(start), len(hangouts))
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("hangout.count", len(hangouts)))
+	span.SetStatusOk()
+	return hangouts, nil
+}
@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Ernestgio/Hangout-Planner/pkg/shared/enums"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
@@ -48,8 +50,8 @@ func TestHangoutRepository_CreateHangout(t *testing.T) {
 			name: "success",
 			setupMock: func(mock sqlmock.Sqlmock) {
 				mock.ExpectBegin()
-				mock.ExpectExec("INSERT INTO `hangouts` (`id`,`title`,`description`,`date`,`status`,`created_at`,`updated_at`,`deleted_at`,`user_id`) VALUES (?,?,?,?,?,?,?,?,?)").
-					WithArgs(sqlmock.AnyArg(), hangout.Title, hangout.Description, hangout.Date, hangout.Status, AnyTime{}, AnyTime{}, nil, nil).
+				mock.ExpectExec("INSERT INTO `hangouts` (`id`,`title`,`description`,`date`,`rsvp_deadline`,`status`,`version`,`created_at`,`updated_at`,`deleted_at`,`user_id`) VALUES (?,?,?,?,?,?,?,?,?,?,?)").
+					WithArgs(sqlmock.AnyArg(), hangout.Title, hangout.Description, hangout.Date, nil, hangout.Status, int64(1), AnyTime{}, AnyTime{}, nil, nil).
 					WillReturnResult(sqlmock.NewResult(1, 1))
 				mock.ExpectCommit()
 			},
@@ -59,8 +61,8 @@ func TestHangoutRepository_CreateHangout(t *testing.T) {
 			name: "database error",
 			setupMock: func(mock sqlmock.Sqlmock) {
 				mock.ExpectBegin()
-				mock.ExpectExec("INSERT INTO `hangouts` (`id`,`title`,`description`,`date`,`status`,`created_at`,`updated_at`,`deleted_at`,`user_id`) VALUES (?,?,?,?,?,?,?,?,?)").
-					WithArgs(sqlmock.AnyArg(), hangout.Title, hangout.Description, hangout.Date, hangout.Status, AnyTime{}, AnyTime{}, nil, nil).
+				mock.ExpectExec("INSERT INTO `hangouts` (`id`,`title`,`description`,`date`,`rsvp_deadline`,`status`,`version`,`created_at`,`updated_at`,`deleted_at`,`user_id`) VALUES (?,?,?,?,?,?,?,?,?,?,?)").
+					WithArgs(sqlmock.AnyArg(), hangout.Title, hangout.Description, hangout.Date, nil, hangout.Status, int64(1), AnyTime{}, AnyTime{}, nil, nil).
 					WillReturnError(dbError)
 				mock.ExpectRollback()
 			},
@@ -172,14 +174,53 @@ func TestHangoutRepository_GetHangoutByID(t *testing.T) {
 	}
 }
 
+func TestHangoutRepository_GetHangoutByIDAnyOwner(t *testing.T) {
+	hangoutID := uuid.New()
+	ctx := context.Background()
+
+	t.Run("success", func(t *testing.T) {
+		db, mock := setupDB(t)
+		repo := repository.NewHangoutRepository(db, nil)
+
+		mock.ExpectQuery("SELECT * FROM `hangouts` WHERE id = ? AND `hangouts`.`deleted_at` IS NULL ORDER BY `hangouts`.`id` LIMIT ?").
+			WithArgs(hangoutID, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "title", "user_id"}).AddRow(hangoutID, "Test Hangout", uuid.New()))
+		mock.ExpectQuery("SELECT * FROM `hangout_activities` WHERE `hangout_activities`.`hangout_id` = ?").
+			WithArgs(hangoutID).
+			WillReturnRows(sqlmock.NewRows([]string{"hangout_id", "activity_id"}))
+
+		result, err := repo.GetHangoutByIDAnyOwner(ctx, hangoutID)
+		require.NoError(t, err)
+		require.Equal(t, hangoutID, result.ID)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		db, mock := setupDB(t)
+		repo := repository.NewHangoutRepository(db, nil)
+
+		mock.ExpectQuery("SELECT * FROM `hangouts` WHERE id = ? AND `hangouts`.`deleted_at` IS NULL ORDER BY `hangouts`.`id` LIMIT ?").
+			WithArgs(hangoutID, 1).
+			WillReturnError(gorm.ErrRecordNotFound)
+
+		result, err := repo.GetHangoutByIDAnyOwner(ctx, hangoutID)
+		require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+		require.Nil(t, result)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
 func TestHangoutRepository_UpdateHangout(t *testing.T) {
 	hangoutID := uuid.New()
 	ctx := context.Background()
 	dbError := errors.New("update error")
 
+	updateSQL := "UPDATE `hangouts` SET `title`=?,`description`=?,`date`=?,`rsvp_deadline`=?,`status`=?,`version`=?,`updated_at`=? WHERE (id = ? AND version = ?) AND `hangouts`.`deleted_at` IS NULL"
+
 	hangoutToUpdate := &domain.Hangout{
-		ID:    hangoutID,
-		Title: "Updated Title",
+		ID:      hangoutID,
+		Version: 1,
+		Title:   "Updated Title",
 		Description: func(s string) *string {
 			return &s
 		}("New Description"),
@@ -197,8 +238,8 @@ func TestHangoutRepository_UpdateHangout(t *testing.T) {
 			hangout: hangoutToUpdate,
 			setupMock: func(mock sqlmock.Sqlmock, h *domain.Hangout) {
 				mock.ExpectBegin()
-				mock.ExpectExec("UPDATE `hangouts` SET `id`=?,`title`=?,`description`=?,`updated_at`=? WHERE id = ? AND `hangouts`.`deleted_at` IS NULL").
-					WithArgs(h.ID, h.Title, h.Description, AnyTime{}, h.ID).
+				mock.ExpectExec(updateSQL).
+					WithArgs(h.Title, h.Description, h.Date, h.RSVPDeadline, h.Status, int64(2), AnyTime{}, h.ID, int64(1)).
 					WillReturnResult(sqlmock.NewResult(0, 1))
 				mock.ExpectCommit()
 			},
@@ -209,14 +250,39 @@ func TestHangoutRepository_UpdateHangout(t *testing.T) {
 			hangout: hangoutToUpdate,
 			setupMock: func(mock sqlmock.Sqlmock, h *domain.Hangout) {
 				mock.ExpectBegin()
-				mock.ExpectExec("UPDATE `hangouts` SET `id`=?,`title`=?,`description`=?,`updated_at`=? WHERE id = ? AND `hangouts`.`deleted_at` IS NULL").
-					WithArgs(h.ID, h.Title, h.Description, AnyTime{}, h.ID).
+				mock.ExpectExec(updateSQL).
+					WithArgs(h.Title, h.Description, h.Date, h.RSVPDeadline, h.Status, int64(2), AnyTime{}, h.ID, int64(1)).
 					WillReturnError(dbError)
 				mock.ExpectRollback()
 			},
 			expectError: true,
 			expectedErr: dbError,
 		},
+		{
+			name:    "success_clears_description",
+			hangout: &domain.Hangout{ID: hangoutID, Title: "Updated Title"},
+			setupMock: func(mock sqlmock.Sqlmock, h *domain.Hangout) {
+				mock.ExpectBegin()
+				mock.ExpectExec(updateSQL).
+					WithArgs(h.Title, nil, h.Date, nil, h.Status, int64(2), AnyTime{}, h.ID, int64(1)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			expectError: false,
+		},
+		{
+			name:    "version_conflict",
+			hangout: hangoutToUpdate,
+			setupMock: func(mock sqlmock.Sqlmock, h *domain.Hangout) {
+				mock.ExpectBegin()
+				mock.ExpectExec(updateSQL).
+					WithArgs(h.Title, h.Description, h.Date, h.RSVPDeadline, h.Status, int64(2), AnyTime{}, h.ID, int64(1)).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+			expectError: true,
+			expectedErr: apperrors.ErrPreconditionFailed,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -225,13 +291,16 @@ func TestHangoutRepository_UpdateHangout(t *testing.T) {
 			repo := repository.NewHangoutRepository(db, nil)
 			tc.setupMock(mock, tc.hangout)
 
+			tc.hangout.Version = 1
 			result, err := repo.UpdateHangout(ctx, tc.hangout)
 
 			if tc.expectError {
 				require.Error(t, err)
 				require.Equal(t, tc.expectedErr, err)
 				require.Nil(t, result)
+				require.Equal(t, int64(1), tc.hangout.Version)
 			} else {
+				require.Equal(t, int64(2), result.Version)
 				require.NoError(t, err)
 				require.NotNil(t, result)
 				require.Equal(t, tc.hangout.ID, result.ID)
@@ -247,7 +316,8 @@ func TestHangoutRepository_DeleteHangout(t *testing.T) {
 	ctx := context.Background()
 	dbError := errors.New("db error")
 
-	softDeleteSQL := "UPDATE `hangouts` SET `deleted_at`=? WHERE id = ? AND `hangouts`.`deleted_at` IS NULL"
+	trashMemoriesSQL := "UPDATE `memories` SET `deleted_at` = ? WHERE `hangout_id` = ? AND `deleted_at` IS NULL"
+	softDeleteSQL := "UPDATE `hangouts` SET `deleted_at` = ? WHERE `id` = ? AND `version` = ? AND `deleted_at` IS NULL"
 
 	testCases := []struct {
 		name        string
@@ -260,11 +330,11 @@ func TestHangoutRepository_DeleteHangout(t *testing.T) {
 			id:   hangoutID,
 			setupMock: func(mock sqlmock.Sqlmock, id uuid.UUID) {
 				mock.ExpectBegin()
-				mock.ExpectExec("DELETE FROM `hangout_activities` WHERE `hangout_id` = ?").
-					WithArgs(id).
+				mock.ExpectExec(trashMemoriesSQL).
+					WithArgs(AnyTime{}, id).
 					WillReturnResult(sqlmock.NewResult(0, 5))
 				mock.ExpectExec(softDeleteSQL).
-					WithArgs(sqlmock.AnyArg(), id).
+					WithArgs(AnyTime{}, id, int64(3)).
 					WillReturnResult(sqlmock.NewResult(0, 1))
 				mock.ExpectCommit()
 			},
@@ -279,12 +349,12 @@ func TestHangoutRepository_DeleteHangout(t *testing.T) {
 			expectError: true,
 		},
 		{
-			name: "error_on_join_table_delete_triggers_rollback",
+			name: "error_on_memories_soft_delete_triggers_rollback",
 			id:   hangoutID,
 			setupMock: func(mock sqlmock.Sqlmock, id uuid.UUID) {
 				mock.ExpectBegin()
-				mock.ExpectExec("DELETE FROM `hangout_activities` WHERE `hangout_id` = ?").
-					WithArgs(id).
+				mock.ExpectExec(trashMemoriesSQL).
+					WithArgs(AnyTime{}, id).
 					WillReturnError(dbError)
 				mock.ExpectRollback()
 			},
@@ -295,11 +365,11 @@ func TestHangoutRepository_DeleteHangout(t *testing.T) {
 			id:   hangoutID,
 			setupMock: func(mock sqlmock.Sqlmock, id uuid.UUID) {
 				mock.ExpectBegin()
-				mock.ExpectExec("DELETE FROM `hangout_activities` WHERE `hangout_id` = ?").
-					WithArgs(id).
+				mock.ExpectExec(trashMemoriesSQL).
+					WithArgs(AnyTime{}, id).
 					WillReturnResult(sqlmock.NewResult(0, 5))
 				mock.ExpectExec(softDeleteSQL).
-					WithArgs(sqlmock.AnyArg(), id).
+					WithArgs(AnyTime{}, id, int64(3)).
 					WillReturnError(dbError)
 				mock.ExpectRollback()
 			},
@@ -310,16 +380,31 @@ func TestHangoutRepository_DeleteHangout(t *testing.T) {
 			id:   hangoutID,
 			setupMock: func(mock sqlmock.Sqlmock, id uuid.UUID) {
 				mock.ExpectBegin()
-				mock.ExpectExec("DELETE FROM `hangout_activities` WHERE `hangout_id` = ?").
-					WithArgs(id).
+				mock.ExpectExec(trashMemoriesSQL).
+					WithArgs(AnyTime{}, id).
 					WillReturnResult(sqlmock.NewResult(0, 5))
 				mock.ExpectExec(softDeleteSQL).
-					WithArgs(sqlmock.AnyArg(), id).
+					WithArgs(AnyTime{}, id, int64(3)).
 					WillReturnResult(sqlmock.NewResult(0, 1))
 				mock.ExpectCommit().WillReturnError(dbError)
 			},
 			expectError: true,
 		},
+		{
+			name: "version_conflict_triggers_rollback",
+			id:   hangoutID,
+			setupMock: func(mock sqlmock.Sqlmock, id uuid.UUID) {
+				mock.ExpectBegin()
+				mock.ExpectExec(trashMemoriesSQL).
+					WithArgs(AnyTime{}, id).
+					WillReturnResult(sqlmock.NewResult(0, 5))
+				mock.ExpectExec(softDeleteSQL).
+					WithArgs(AnyTime{}, id, int64(3)).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+			expectError: true,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -329,7 +414,7 @@ func TestHangoutRepository_DeleteHangout(t *testing.T) {
 
 			tc.setupMock(mock, tc.id)
 
-			err := repo.DeleteHangout(ctx, tc.id)
+			err := repo.DeleteHangout(ctx, tc.id, 3)
 
 			if tc.expectError {
 				require.Error(t, err)
@@ -651,3 +736,385 @@ func TestHangoutRepository_RemoveHangoutActivities(t *testing.T) {
 		})
 	}
 }
+
+func TestHangoutRepository_GetDeletedHangoutByID(t *testing.T) {
+	hangoutID := uuid.New()
+	userID := uuid.New()
+	ctx := context.Background()
+
+	query := "SELECT * FROM `hangouts` WHERE id = ? AND user_id = ? AND deleted_at IS NOT NULL ORDER BY `hangouts`.`id` LIMIT ?"
+
+	testCases := []struct {
+		name        string
+		setupMock   func(mock sqlmock.Sqlmock)
+		expectError bool
+	}{
+		{
+			name: "success",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "title", "user_id", "deleted_at"}).
+					AddRow(hangoutID, "Trashed", userID, time.Now())
+				mock.ExpectQuery(query).WithArgs(hangoutID, userID, 1).WillReturnRows(rows)
+			},
+		},
+		{
+			name: "not found",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).WithArgs(hangoutID, userID, 1).WillReturnError(gorm.ErrRecordNotFound)
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock := setupDB(t)
+			repo := repository.NewHangoutRepository(db, nil)
+			tc.setupMock(mock)
+			result, err := repo.GetDeletedHangoutByID(ctx, hangoutID, userID)
+			if tc.expectError {
+				require.Error(t, err)
+				require.Nil(t, result)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, hangoutID, result.ID)
+				require.True(t, result.DeletedAt.Valid)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestHangoutRepository_GetDeletedHangoutsByUserID(t *testing.T) {
+	userID := uuid.New()
+	afterID := uuid.New()
+	ctx := context.Background()
+
+	listQuery := "SELECT * FROM `hangouts` WHERE user_id = ? AND deleted_at IS NOT NULL ORDER BY deleted_at desc, id desc LIMIT ?"
+	cursorQuery := "SELECT * FROM `hangouts` WHERE id = ? AND deleted_at IS NOT NULL ORDER BY `hangouts`.`id` LIMIT ?"
+	listAfterQuery := "SELECT * FROM `hangouts` WHERE (user_id = ? AND deleted_at IS NOT NULL) AND ((deleted_at < ?) OR (deleted_at = ? AND id < ?)) ORDER BY deleted_at desc, id desc LIMIT ?"
+
+	testCases := []struct {
+		name        string
+		pagination  *dto.CursorPagination
+		setupMock   func(mock sqlmock.Sqlmock)
+		expectLen   int
+		expectError error
+	}{
+		{
+			name:       "first page",
+			pagination: &dto.CursorPagination{Limit: 1},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "title", "user_id", "deleted_at"}).
+					AddRow(uuid.New(), "A", userID, time.Now()).
+					AddRow(uuid.New(), "B", userID, time.Now())
+				mock.ExpectQuery(listQuery).WithArgs(userID, 2).WillReturnRows(rows)
+			},
+			expectLen: 2,
+		},
+		{
+			name:       "after cursor",
+			pagination: &dto.CursorPagination{Limit: 1, AfterID: &afterID},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				deletedAt := time.Now()
+				mock.ExpectQuery(cursorQuery).WithArgs(afterID, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "deleted_at"}).AddRow(afterID, deletedAt))
+				mock.ExpectQuery(listAfterQuery).WithArgs(userID, deletedAt, deletedAt, afterID, 2).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()))
+			},
+			expectLen: 1,
+		},
+		{
+			name:       "invalid cursor",
+			pagination: &dto.CursorPagination{Limit: 1, AfterID: &afterID},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(cursorQuery).WithArgs(afterID, 1).WillReturnError(gorm.ErrRecordNotFound)
+			},
+			expectError: apperrors.ErrInvalidCursorPagination,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock := setupDB(t)
+			repo := repository.NewHangoutRepository(db, nil)
+			tc.setupMock(mock)
+			result, err := repo.GetDeletedHangoutsByUserID(ctx, userID, tc.pagination)
+			if tc.expectError != nil {
+				require.ErrorIs(t, err, tc.expectError)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, result, tc.expectLen)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestHangoutRepository_RestoreHangout(t *testing.T) {
+	hangoutID := uuid.New()
+	deletedAt := time.Now()
+	ctx := context.Background()
+	dbError := errors.New("db error")
+
+	restoreMemoriesSQL := "UPDATE `memories` SET `deleted_at` = NULL WHERE `hangout_id` = ? AND `deleted_at` = ?"
+	restoreHangoutSQL := "UPDATE `hangouts` SET `deleted_at` = NULL WHERE `id` = ?"
+
+	testCases := []struct {
+		name        string
+		setupMock   func(mock sqlmock.Sqlmock)
+		expectError bool
+	}{
+		{
+			name: "success",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(restoreMemoriesSQL).WithArgs(hangoutID, deletedAt).WillReturnResult(sqlmock.NewResult(0, 3))
+				mock.ExpectExec(restoreHangoutSQL).WithArgs(hangoutID).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "memories restore error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(restoreMemoriesSQL).WithArgs(hangoutID, deletedAt).WillReturnError(dbError)
+				mock.ExpectRollback()
+			},
+			expectError: true,
+		},
+		{
+			name: "hangout restore error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(restoreMemoriesSQL).WithArgs(hangoutID, deletedAt).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(restoreHangoutSQL).WithArgs(hangoutID).WillReturnError(dbError)
+				mock.ExpectRollback()
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock := setupDB(t)
+			repo := repository.NewHangoutRepository(db, nil)
+			tc.setupMock(mock)
+			err := repo.RestoreHangout(ctx, hangoutID, deletedAt)
+			if tc.expectError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestHangoutRepository_GetHangoutsDeletedBefore(t *testing.T) {
+	before := time.Now()
+	ctx := context.Background()
+
+	query := "SELECT * FROM `hangouts` WHERE deleted_at IS NOT NULL AND deleted_at < ? ORDER BY deleted_at asc LIMIT ?"
+
+	t.Run("success", func(t *testing.T) {
+		db, mock := setupDB(t)
+		repo := repository.NewHangoutRepository(db, nil)
+		mock.ExpectQuery(query).WithArgs(before, 5).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "deleted_at"}).AddRow(uuid.New(), before.Add(-time.Hour)))
+		result, err := repo.GetHangoutsDeletedBefore(ctx, before, 5)
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		db, mock := setupDB(t)
+		repo := repository.NewHangoutRepository(db, nil)
+		mock.ExpectQuery(query).WithArgs(before, 5).WillReturnError(errors.New("db error"))
+		result, err := repo.GetHangoutsDeletedBefore(ctx, before, 5)
+		require.Error(t, err)
+		require.Nil(t, result)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestHangoutRepository_PurgeHangout(t *testing.T) {
+	hangoutID := uuid.New()
+	ctx := context.Background()
+	dbError := errors.New("db error")
+
+	testCases := []struct {
+		name        string
+		setupMock   func(mock sqlmock.Sqlmock)
+		expectError bool
+	}{
+		{
+			name: "success",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("DELETE FROM `hangout_activities` WHERE `hangout_id` = ?").WithArgs(hangoutID).WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec("DELETE FROM `memory_tags` WHERE `memory_id` IN (SELECT `id` FROM `memories` WHERE `hangout_id` = ?)").WithArgs(hangoutID).WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec("DELETE FROM `memory_person_tags` WHERE `memory_id` IN (SELECT `id` FROM `memories` WHERE `hangout_id` = ?)").WithArgs(hangoutID).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("DELETE FROM `memory_reactions` WHERE `memory_id` IN (SELECT `id` FROM `memories` WHERE `hangout_id` = ?)").WithArgs(hangoutID).WillReturnResult(sqlmock.NewResult(0, 5))
+				mock.ExpectExec("DELETE FROM `memories` WHERE `hangout_id` = ?").WithArgs(hangoutID).WillReturnResult(sqlmock.NewResult(0, 3))
+				mock.ExpectExec("DELETE FROM `share_link_accesses` WHERE `share_link_id` IN (SELECT `id` FROM `share_links` WHERE `hangout_id` = ?)").WithArgs(hangoutID).WillReturnResult(sqlmock.NewResult(0, 4))
+				mock.ExpectExec("DELETE FROM `share_links` WHERE `hangout_id` = ?").WithArgs(hangoutID).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("DELETE FROM `albums` WHERE `hangout_id` = ?").WithArgs(hangoutID).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("DELETE FROM `reminders` WHERE `hangout_id` = ?").WithArgs(hangoutID).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("DELETE FROM `comment_mentions` WHERE `comment_id` IN (SELECT `id` FROM `comments` WHERE `hangout_id` = ?)").WithArgs(hangoutID).WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec("DELETE FROM `comments` WHERE `hangout_id` = ?").WithArgs(hangoutID).WillReturnResult(sqlmock.NewResult(0, 4))
+				mock.ExpectExec("UPDATE `notifications` SET `hangout_id` = NULL WHERE `hangout_id` = ?").WithArgs(hangoutID).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE `notification_emails` SET `hangout_id` = NULL WHERE `hangout_id` = ?").WithArgs(hangoutID).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("DELETE FROM `hangouts` WHERE `id` = ?").WithArgs(hangoutID).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "memories delete error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("DELETE FROM `hangout_activities` WHERE `hangout_id` = ?").WithArgs(hangoutID).WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec("DELETE FROM `memory_tags` WHERE `memory_id` IN (SELECT `id` FROM `memories` WHERE `hangout_id` = ?)").WithArgs(hangoutID).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("DELETE FROM `memory_person_tags` WHERE `memory_id` IN (SELECT `id` FROM `memories` WHERE `hangout_id` = ?)").WithArgs(hangoutID).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("DELETE FROM `memory_reactions` WHERE `memory_id` IN (SELECT `id` FROM `memories` WHERE `hangout_id` = ?)").WithArgs(hangoutID).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("DELETE FROM `memories` WHERE `hangout_id` = ?").WithArgs(hangoutID).WillReturnError(dbError)
+				mock.ExpectRollback()
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock := setupDB(t)
+			repo := repository.NewHangoutRepository(db, nil)
+			tc.setupMock(mock)
+			err := repo.PurgeHangout(ctx, hangoutID)
+			if tc.expectError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestHangoutRepository_PurgeHangout_MySQL(t *testing.T) {
+	db := newMySQLDB(t)
+	ctx := context.Background()
+	repo := repository.NewHangoutRepository(db, nil)
+
+	user := &domain.User{Name: "Ann", Email: "ann@example.com", Password: "hash"}
+	require.NoError(t, db.Create(user).Error)
+	hangout := &domain.Hangout{Title: "Picnic", Date: time.Now(), Status: enums.StatusPlanning, UserID: &user.ID}
+	require.NoError(t, db.Create(hangout).Error)
+	now := time.Now()
+	email := &domain.NotificationEmail{Type: "hangout_updated", Subject: "Picnic", Status: constants.ReminderPending, NextAttemptAt: &now, HangoutID: &hangout.ID, UserID: user.ID}
+	require.NoError(t, db.Create(email).Error)
+	require.NoError(t, db.Delete(hangout).Error)
+
+	require.NoError(t, repo.PurgeHangout(ctx, hangout.ID))
+
+	var hangouts int64
+	require.NoError(t, db.Unscoped().Model(&domain.Hangout{}).Where("id = ?", hangout.ID).Count(&hangouts).Error)
+	require.Zero(t, hangouts)
+	var kept domain.NotificationEmail
+	require.NoError(t, db.First(&kept, "id = ?", email.ID).Error)
+	require.Nil(t, kept.HangoutID)
+}
+
+func TestHangoutRepository_GetUpcomingHangouts(t *testing.T) {
+	from := time.Now()
+	to := from.Add(24 * time.Hour)
+	ctx := context.Background()
+
+	testCases := []struct {
+		name   string
+		column string
+		call   func(repo repository.HangoutRepository) ([]domain.Hangout, error)
+	}{
+		{
+			name:   "starting between",
+			column: "date",
+			call: func(repo repository.HangoutRepository) ([]domain.Hangout, error) {
+				return repo.GetHangoutsStartingBetween(ctx, from, to)
+			},
+		},
+		{
+			name:   "rsvp deadline between",
+			column: "rsvp_deadline",
+			call: func(repo repository.HangoutRepository) ([]domain.Hangout, error) {
+				return repo.GetHangoutsWithRSVPDeadlineBetween(ctx, from, to)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		query := "SELECT * FROM `hangouts` WHERE (status IN (?,?) AND user_id IS NOT NULL) AND (`" + tc.column + "` > ? AND `" + tc.column + "` <= ?) AND `hangouts`.`deleted_at` IS NULL ORDER BY " + tc.column + " asc"
+
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock := setupDB(t)
+			repo := repository.NewHangoutRepository(db, nil)
+			mock.ExpectQuery(query).WithArgs(enums.StatusPlanning, enums.StatusConfirmed, from, to).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "date"}).AddRow(uuid.New(), from.Add(time.Hour)))
+			result, err := tc.call(repo)
+			require.NoError(t, err)
+			require.Len(t, result, 1)
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+
+		t.Run(tc.name+" db error", func(t *testing.T) {
+			db, mock := setupDB(t)
+			repo := repository.NewHangoutRepository(db, nil)
+			mock.ExpectQuery(query).WillReturnError(errors.New("db error"))
+			result, err := tc.call(repo)
+			require.Error(t, err)
+			require.Nil(t, result)
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestHangoutRepository_GetAllHangoutsByUserID(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	hangoutID := uuid.New()
+	activityID := uuid.New()
+
+	t.Run("success", func(t *testing.T) {
+		db, mock := setupDB(t)
+		repo := repository.NewHangoutRepository(db, nil)
+
+		mock.ExpectQuery("SELECT * FROM `hangouts` WHERE user_id = ? AND `hangouts`.`deleted_at` IS NULL ORDER BY created_at asc").
+			WithArgs(userID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "title", "user_id"}).AddRow(hangoutID, "Picnic", userID))
+		mock.ExpectQuery("SELECT * FROM `hangout_activities` WHERE `hangout_activities`.`hangout_id` = ?").
+			WithArgs(hangoutID).
+			WillReturnRows(sqlmock.NewRows([]string{"hangout_id", "activity_id"}).AddRow(hangoutID, activityID))
+		mock.ExpectQuery("SELECT * FROM `activities` WHERE `activities`.`id` = ? AND `activities`.`deleted_at` IS NULL").
+			WithArgs(activityID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(activityID, "Hiking"))
+
+		result, err := repo.GetAllHangoutsByUserID(ctx, userID)
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		require.Len(t, result[0].Activities, 1)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		db, mock := setupDB(t)
+		repo := repository.NewHangoutRepository(db, nil)
+
+		mock.ExpectQuery("SELECT * FROM `hangouts` WHERE user_id = ? AND `hangouts`.`deleted_at` IS NULL ORDER BY created_at asc").
+			WithArgs(userID).
+			WillReturnError(errors.New("db error"))
+
+		result, err := repo.GetAllHangoutsByUserID(ctx, userID)
+		require.Error(t, err)
+		require.Nil(t, result)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
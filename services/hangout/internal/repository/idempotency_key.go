@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/otel"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IdempotencyKeyRepository interface {
+	CreateIfAbsent(ctx context.Context, key *domain.IdempotencyKey) (bool, error)
+	GetByKey(ctx context.Context, userID uuid.UUID, key string) (*domain.IdempotencyKey, error)
+	SaveResponse(ctx context.Context, id uuid.UUID, status int, headers map[string]string, body []byte) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	DeleteExpired(ctx context.Context, before time.Time) (int64, error)
+}
+
+type idempotencyKeyRepository struct {
+	db      *gorm.DB
+	metrics *otel.MetricsRecorder
+}
+
+func NewIdempotencyKeyRepository(db *gorm.DB, metrics *otel.MetricsRecorder) IdempotencyKeyRepository {
+	return &idempotencyKeyRepository{db: db, metrics: metrics}
+}
+
+// CreateIfAbsent inserts the key unless the user already has one with the same value.
+// It reports whether the row was inserted.
+func (r *idempotencyKeyRepository) CreateIfAbsent(ctx context.Context, key *domain.IdempotencyKey) (bool, error) {
+	ctx, span := otel.StartRepositorySpan(ctx, "CreateIfAbsent",
+		attribute.String("db.operation", "insert"),
+		attribute.String("db.table", "idempotency_keys"),
+		attribute.String("user.id", key.UserID.String()),
+	)
+	defer span.End()
+
+	start := time.Now()
+	result := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(key)
+	r.metrics.RecordDBOperation(ctx, "insert", "idempotency_keys", time.Since(start), int(result.RowsAffected))
+
+	if result.Error != nil {
+		_ = span.RecordErrorWithStatus(result.Error)
+		return false, result.Error
+	}
+
+	created := result.RowsAffected > 0
+	span.SetAttributes(attribute.Bool("idempotency.created", created))
+	span.SetStatusOk()
+	return created, nil
+}
+
+func (r *idempotencyKeyRepository) GetByKey(ctx context.Context, userID uuid.UUID, key string) (*domain.IdempotencyKey, error) {
+	ctx, span := otel.StartRepositorySpan(ctx, "GetByKey",
+		attribute.String("db.operation", "select"),
+		attribute.String("db.table", "idempotency_keys"),
+		attribute.String("user.id", userID.String()),
+	)
+	defer span.End()
+
+	var record domain.IdempotencyKey
+
+	start := time.Now()
+	err := r.db.WithContext(ctx).First(&record, "user_id = ? AND `key` = ?", userID, key).Error
+	r.metrics.RecordDBOperation(ctx, "select", "idempotency_keys", time.Since(start), 1)
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetStatusOk()
+	return &record, nil
+}
+
+func (r *idempotencyKeyRepository) SaveResponse(ctx context.Context, id uuid.UUID, status int, headers map[string]string, body []byte) error {
+	ctx, span := otel.StartRepositorySpan(ctx, "SaveResponse",
+		attribute.String("db.operation", "update"),
+		attribute.String("db.table", "idempotency_keys"),
+		attribute.String("idempotency.id", id.String()),
+		attribute.Int("http.status_code", status),
+	)
+	defer span.End()
+
+	start := time.Now()
+	err := r.db.WithContext(ctx).Model(&domain.IdempotencyKey{}).
+		Where("id = ?", id).
+		Select("response_status", "response_headers", "response_body").
+		Updates(&domain.IdempotencyKey{ResponseStatus: status, ResponseHeaders: headers, ResponseBody: body}).Error
+	r.metrics.RecordDBOperation(ctx, "update", "idempotency_keys", time.Since(start), 1)
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+	} else {
+		span.SetStatusOk()
+	}
+	return err
+}
+
+func (r *idempotencyKeyRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	ctx, span := otel.StartRepositorySpan(ctx, "Delete",
+		attribute.String("db.operation", "delete"),
+		attribute.String("db.table", "idempotency_keys"),
+		attribute.String("idempotency.id", id.String()),
+	)
+	defer span.End()
+
+	start := time.Now()
+	err := r.db.WithContext(ctx).Delete(&domain.IdempotencyKey{}, "id = ?", id).Error
+	r.metrics.RecordDBOperation(ctx, "delete", "idempotency_keys", time.Since(start), 1)
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+	} else {
+		span.SetStatusOk()
+	}
+	return err
+}
+
+func (r *idempotencyKeyRepository) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	ctx, span := otel.StartRepositorySpan(ctx, "DeleteExpired",
+		attribute.String("db.operation", "delete"),
+		attribute.String("db.table", "idempotency_keys"),
+	)
+	defer span.End()
+
+	start := time.Now()
+	result := r.db.WithContext(ctx).Where("expires_at < ?", before).Delete(&domain.IdempotencyKey{})
+	r.metrics.RecordDBOperation(ctx, "delete", "idempotency_keys", time.Since(start), int(result.RowsAffected))
+
+	if result.Error != nil {
+		_ = span.RecordErrorWithStatus(result.Error)
+		return 0, result.Error
+	}
+
+	span.SetAttributes(attribute.Int64("idempotency.deleted", result.RowsAffected))
+	span.SetStatusOk()
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,253 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	repo "github.com/Ernestgio/Hangout-Planner/services/hangout/internal/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestIdempotencyKeyCreateIfAbsent_TableDriven(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		prepare     func(sqlmock.Sqlmock)
+		wantCreated bool
+		wantError   bool
+	}{
+		{
+			name: "created",
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("INSERT INTO `idempotency_keys` .* ON DUPLICATE KEY UPDATE").WillReturnResult(sqlmock.NewResult(1, 1))
+				m.ExpectCommit()
+			},
+			wantCreated: true,
+		},
+		{
+			name: "already exists",
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("INSERT INTO `idempotency_keys` .* ON DUPLICATE KEY UPDATE").WillReturnResult(sqlmock.NewResult(0, 0))
+				m.ExpectCommit()
+			},
+		},
+		{
+			name: "db error",
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("INSERT INTO `idempotency_keys`").WillReturnError(errors.New("db error"))
+				m.ExpectRollback()
+			},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newDBWithRegexp(t)
+			r := repo.NewIdempotencyKeyRepository(db, nil)
+			tt.prepare(mock)
+			created, err := r.CreateIfAbsent(ctx, &domain.IdempotencyKey{Key: "k", UserID: uuid.New(), RequestHash: "h", ExpiresAt: time.Now()})
+			if tt.wantError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.wantCreated, created)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestIdempotencyKeyGetByKey_TableDriven(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		prepare   func(sqlmock.Sqlmock, uuid.UUID)
+		wantError bool
+	}{
+		{
+			name: "found",
+			prepare: func(m sqlmock.Sqlmock, userID uuid.UUID) {
+				cols := []string{"id", "key", "request_hash", "response_status", "response_body", "expires_at", "created_at", "user_id"}
+				m.ExpectQuery("SELECT \\* FROM `idempotency_keys` WHERE user_id = \\? AND `key` = \\?").
+					WithArgs(userID, "k", 1).
+					WillReturnRows(sqlmock.NewRows(cols).AddRow(uuid.New(), "k", "h", 201, []byte(`{}`), time.Now(), time.Now(), userID))
+			},
+		},
+		{
+			name: "not found",
+			prepare: func(m sqlmock.Sqlmock, userID uuid.UUID) {
+				m.ExpectQuery("SELECT .* FROM `idempotency_keys`").WithArgs(userID, "k", 1).WillReturnError(gorm.ErrRecordNotFound)
+			},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newDBWithRegexp(t)
+			r := repo.NewIdempotencyKeyRepository(db, nil)
+			userID := uuid.New()
+			tt.prepare(mock, userID)
+			record, err := r.GetByKey(ctx, userID, "k")
+			if tt.wantError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.True(t, record.IsCompleted())
+				require.Equal(t, 201, record.ResponseStatus)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestIdempotencyKeySaveResponse_TableDriven(t *testing.T) {
+	ctx := context.Background()
+	headers := map[string]string{"ETag": `"3"`}
+	body := []byte(`{"status":"success"}`)
+
+	tests := []struct {
+		name      string
+		prepare   func(sqlmock.Sqlmock, uuid.UUID)
+		wantError bool
+	}{
+		{
+			name: "success",
+			prepare: func(m sqlmock.Sqlmock, id uuid.UUID) {
+				m.ExpectBegin()
+				m.ExpectExec("UPDATE `idempotency_keys` SET `response_status`=\\?,`response_headers`=\\?,`response_body`=\\? WHERE id = \\?").
+					WithArgs(201, `{"ETag":"\"3\""}`, body, id).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectCommit()
+			},
+		},
+		{
+			name: "db error",
+			prepare: func(m sqlmock.Sqlmock, id uuid.UUID) {
+				m.ExpectBegin()
+				m.ExpectExec("UPDATE `idempotency_keys`").WillReturnError(errors.New("db error"))
+				m.ExpectRollback()
+			},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newDBWithRegexp(t)
+			r := repo.NewIdempotencyKeyRepository(db, nil)
+			id := uuid.New()
+			tt.prepare(mock, id)
+			err := r.SaveResponse(ctx, id, 201, headers, body)
+			if tt.wantError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestIdempotencyKeyDelete_TableDriven(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		prepare   func(sqlmock.Sqlmock, uuid.UUID)
+		wantError bool
+	}{
+		{
+			name: "success",
+			prepare: func(m sqlmock.Sqlmock, id uuid.UUID) {
+				m.ExpectBegin()
+				m.ExpectExec("DELETE FROM `idempotency_keys` WHERE id = \\?").WithArgs(id).WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectCommit()
+			},
+		},
+		{
+			name: "db error",
+			prepare: func(m sqlmock.Sqlmock, id uuid.UUID) {
+				m.ExpectBegin()
+				m.ExpectExec("DELETE FROM `idempotency_keys`").WithArgs(id).WillReturnError(errors.New("db error"))
+				m.ExpectRollback()
+			},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newDBWithRegexp(t)
+			r := repo.NewIdempotencyKeyRepository(db, nil)
+			id := uuid.New()
+			tt.prepare(mock, id)
+			err := r.Delete(ctx, id)
+			if tt.wantError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestIdempotencyKeyDeleteExpired_TableDriven(t *testing.T) {
+	ctx := context.Background()
+	before := time.Now()
+
+	tests := []struct {
+		name        string
+		prepare     func(sqlmock.Sqlmock)
+		wantDeleted int64
+		wantError   bool
+	}{
+		{
+			name: "success",
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("DELETE FROM `idempotency_keys` WHERE expires_at < \\?").WithArgs(before).WillReturnResult(sqlmock.NewResult(0, 3))
+				m.ExpectCommit()
+			},
+			wantDeleted: 3,
+		},
+		{
+			name: "db error",
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("DELETE FROM `idempotency_keys`").WithArgs(before).WillReturnError(errors.New("db error"))
+				m.ExpectRollback()
+			},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newDBWithRegexp(t)
+			r := repo.NewIdempotencyKeyRepository(db, nil)
+			tt.prepare(mock)
+			deleted, err := r.DeleteExpired(ctx, before)
+			if tt.wantError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.wantDeleted, deleted)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/google/uuid"
 	"go.opentelemetry.io/otel/attribute"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type MemoryRepository interface {
@@ -21,9 +22,26 @@ type MemoryRepository interface {
 	CreateMemoriesBatch(ctx context.Context, memories []*domain.Memory) error
 	UpdateFileIDs(ctx context.Context, updates map[uuid.UUID]uuid.UUID) error
 	GetMemoryByID(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*domain.Memory, error)
+	GetMemoryByIDAnyOwner(ctx context.Context, id uuid.UUID) (*domain.Memory, error)
 	GetMemoriesByIDs(ctx context.Context, ids []uuid.UUID, userID uuid.UUID) ([]domain.Memory, error)
-	GetMemoriesByHangoutID(ctx context.Context, hangoutID uuid.UUID, pagination *dto.CursorPagination) ([]domain.Memory, error)
+	GetMemoryWithAnnotations(ctx context.Context, id uuid.UUID) (*domain.Memory, error)
+	GetMemoriesByHangoutID(ctx context.Context, hangoutID uuid.UUID, filter *dto.MemoryFilter, pagination *dto.CursorPagination) ([]domain.Memory, error)
+	UpdateMemoryAnnotations(ctx context.Context, memory *domain.Memory) error
+	GetNeighbourPosition(ctx context.Context, hangoutID uuid.UUID, albumID *uuid.UUID, position string, after bool) (string, error)
+	GetMemoriesByAlbumID(ctx context.Context, albumID uuid.UUID) ([]domain.Memory, error)
+	UpdatePlacements(ctx context.Context, albumID *uuid.UUID, positions map[uuid.UUID]string) error
+	AddReaction(ctx context.Context, reaction *domain.MemoryReaction) error
+	RemoveReaction(ctx context.Context, memoryID uuid.UUID, userID uuid.UUID, emoji string) error
 	DeleteMemory(ctx context.Context, id uuid.UUID) error
+	GetDeletedMemoryByID(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*domain.Memory, error)
+	GetDeletedMemoriesByUserID(ctx context.Context, userID uuid.UUID, pagination *dto.CursorPagination) ([]domain.Memory, error)
+	RestoreMemory(ctx context.Context, id uuid.UUID) error
+	SetHiddenAt(ctx context.Context, id uuid.UUID, hiddenAt *time.Time) error
+	GetMemoriesDeletedBefore(ctx context.Context, before time.Time, limit int) ([]domain.Memory, error)
+	GetAllMemoriesByHangoutID(ctx context.Context, hangoutID uuid.UUID) ([]domain.Memory, error)
+	GetAllMemoriesByUserID(ctx context.Context, userID uuid.UUID) ([]domain.Memory, error)
+	GetMemoryIDsByHangoutID(ctx context.Context, hangoutID uuid.UUID) ([]uuid.UUID, error)
+	PurgeMemories(ctx context.Context, ids []uuid.UUID) error
 }
 
 type memoryRepository struct {
@@ -154,6 +172,30 @@ func (r *memoryRepository) GetMemoryByID(ctx context.Context, id uuid.UUID, user
 	return &memory, nil
 }
 
+// GetMemoryByIDAnyOwner loads a memory regardless of its uploader.
+func (r *memoryRepository) GetMemoryByIDAnyOwner(ctx context.Context, id uuid.UUID) (*domain.Memory, error) {
+	ctx, span := otel.StartRepositorySpan(ctx, "GetMemoryByIDAnyOwner",
+		attribute.String("db.operation", "select"),
+		attribute.String("db.table", "memories"),
+		attribute.String("memory.id", id.String()),
+	)
+	defer span.End()
+
+	var memory domain.Memory
+
+	start := time.Now()
+	err := r.db.WithContext(ctx).First(&memory, "id = ?", id).Error
+	r.metrics.RecordDBOperation(ctx, "select", "memories", time.Since(start), 1)
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetStatusOk()
+	return &memory, nil
+}
+
 func (r *memoryRepository) GetMemoriesByIDs(ctx context.Context, ids []uuid.UUID, userID uuid.UUID) ([]domain.Memory, error) {
 	ctx, span := otel.StartRepositorySpan(ctx, "GetMemoriesByIDs",
 		attribute.String("db.operation", "select"),
@@ -179,7 +221,37 @@ func (r *memoryRepository) GetMemoriesByIDs(ctx context.Context, ids []uuid.UUID
 	return memories, nil
 }
 
-func (r *memoryRepository) GetMemoriesByHangoutID(ctx context.Context, hangoutID uuid.UUID, pagination *dto.CursorPagination) ([]domain.Memory, error) {
+// GetMemoryWithAnnotations loads a memory of any uploader together with its tags,
+// people and reactions.
+func (r *memoryRepository) GetMemoryWithAnnotations(ctx context.Context, id uuid.UUID) (*domain.Memory, error) {
+	ctx, span := otel.StartRepositorySpan(ctx, "GetMemoryWithAnnotations",
+		attribute.String("db.operation", "select"),
+		attribute.String("db.table", "memories"),
+		attribute.String("memory.id", id.String()),
+	)
+	defer span.End()
+
+	var memory domain.Memory
+
+	start := time.Now()
+	err := r.db.WithContext(ctx).
+		Preload("PersonTags").
+		Preload("Reactions").
+		Preload("Tags").
+		Where("hidden_at IS NULL").
+		First(&memory, "id = ?", id).Error
+	r.metrics.RecordDBOperation(ctx, "select", "memories", time.Since(start), 1)
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetStatusOk()
+	return &memory, nil
+}
+
+func (r *memoryRepository) GetMemoriesByHangoutID(ctx context.Context, hangoutID uuid.UUID, filter *dto.MemoryFilter, pagination *dto.CursorPagination) ([]domain.Memory, error) {
 	ctx, span := otel.StartRepositorySpan(ctx, "GetMemoriesByHangoutID",
 		attribute.String("db.operation", "select"),
 		attribute.String("db.table", "memories"),
@@ -194,7 +266,30 @@ func (r *memoryRepository) GetMemoriesByHangoutID(ctx context.Context, hangoutID
 	sortByColumn := constants.SortByCreatedAt
 	sortDir := pagination.GetSortDir()
 
-	query := r.db.WithContext(ctx).Model(&domain.Memory{}).Where("hangout_id = ?", hangoutID)
+	query := r.db.WithContext(ctx).Model(&domain.Memory{}).
+		Where("hangout_id = ?", hangoutID).
+		Where("hidden_at IS NULL")
+
+	if filter != nil {
+		if filter.Tag != "" {
+			query = query.Where("id IN (SELECT memory_id FROM memory_tags WHERE tag = ?)", filter.Tag)
+		}
+		if filter.PersonID != nil {
+			query = query.Where("id IN (SELECT memory_id FROM memory_person_tags WHERE user_id = ?)", *filter.PersonID)
+		}
+		if filter.UploaderID != nil {
+			query = query.Where("user_id = ?", *filter.UploaderID)
+		}
+		if filter.AlbumID != nil {
+			query = query.Where("album_id = ?", *filter.AlbumID)
+		} else if filter.NoAlbum {
+			query = query.Where("album_id IS NULL")
+		}
+	}
+
+	if pagination.SortBy == constants.SortByPosition {
+		sortByColumn = constants.SortByPosition
+	}
 
 	if pagination.AfterID != nil {
 		var cursorItem domain.Memory
@@ -202,7 +297,10 @@ func (r *memoryRepository) GetMemoriesByHangoutID(ctx context.Context, hangoutID
 			return nil, apperrors.ErrInvalidCursorPagination
 		}
 
-		cursorValue := cursorItem.CreatedAt
+		var cursorValue any = cursorItem.CreatedAt
+		if sortByColumn == constants.SortByPosition {
+			cursorValue = cursorItem.Position
+		}
 
 		comparisonOp := ">"
 		if sortDir == "desc" {
@@ -215,7 +313,12 @@ func (r *memoryRepository) GetMemoriesByHangoutID(ctx context.Context, hangoutID
 		)
 	}
 
-	query = query.Order(fmt.Sprintf("%s %s, id %s", sortByColumn, sortDir, sortDir)).Limit(limitToFetch)
+	query = query.
+		Preload("PersonTags").
+		Preload("Reactions").
+		Preload("Tags").
+		Order(fmt.Sprintf("%s %s, id %s", sortByColumn, sortDir, sortDir)).
+		Limit(limitToFetch)
 
 	if err := query.Find(&memories).Error; err != nil {
 		r.metrics.RecordDBOperation(ctx, "select", "memories", time.Since(start), 0)
@@ -229,6 +332,220 @@ func (r *memoryRepository) GetMemoriesByHangoutID(ctx context.Context, hangoutID
 	return memories, nil
 }
 
+// UpdateMemoryAnnotations saves the caption of the memory and replaces its
+// tags and people with the ones set on it.
+func (r *memoryRepository) UpdateMemoryAnnotations(ctx context.Context, memory *domain.Memory) error {
+	ctx, span := otel.StartRepositorySpan(ctx, "UpdateMemoryAnnotations",
+		attribute.String("db.operation", "update"),
+		attribute.String("db.table", "memories"),
+		attribute.String("memory.id", memory.ID.String()),
+	)
+	defer span.End()
+
+	start := time.Now()
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&domain.Memory{}).Where("id = ?", memory.ID).Update("caption", memory.Caption).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("memory_id = ?", memory.ID).Delete(&domain.MemoryTag{}).Error; err != nil {
+			return err
+		}
+		if len(memory.Tags) > 0 {
+			if err := tx.Create(&memory.Tags).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Where("memory_id = ?", memory.ID).Delete(&domain.MemoryPersonTag{}).Error; err != nil {
+			return err
+		}
+		if len(memory.PersonTags) == 0 {
+			return nil
+		}
+		return tx.Create(&memory.PersonTags).Error
+	})
+	r.metrics.RecordDBOperation(ctx, "update", "memories", time.Since(start), 1)
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+		return err
+	}
+
+	span.SetStatusOk()
+	return nil
+}
+
+// AddReaction stores the reaction.
+func (r *memoryRepository) AddReaction(ctx context.Context, reaction *domain.MemoryReaction) error {
+	ctx, span := otel.StartRepositorySpan(ctx, "AddReaction",
+		attribute.String("db.operation", "insert"),
+		attribute.String("db.table", "memory_reactions"),
+		attribute.String("memory.id", reaction.MemoryID.String()),
+	)
+	defer span.End()
+
+	start := time.Now()
+	err := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(reaction).Error
+	r.metrics.RecordDBOperation(ctx, "insert", "memory_reactions", time.Since(start), 1)
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+		return err
+	}
+
+	span.SetStatusOk()
+	return nil
+}
+
+func (r *memoryRepository) RemoveReaction(ctx context.Context, memoryID uuid.UUID, userID uuid.UUID, emoji string) error {
+	ctx, span := otel.StartRepositorySpan(ctx, "RemoveReaction",
+		attribute.String("db.operation", "delete"),
+		attribute.String("db.table", "memory_reactions"),
+		attribute.String("memory.id", memoryID.String()),
+		attribute.String("user.id", userID.String()),
+	)
+	defer span.End()
+
+	start := time.Now()
+	err := r.db.WithContext(ctx).
+		Where("memory_id = ? AND user_id = ? AND emoji = ?", memoryID, userID, emoji).
+		Delete(&domain.MemoryReaction{}).Error
+	r.metrics.RecordDBOperation(ctx, "delete", "memory_reactions", time.Since(start), 1)
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+	} else {
+		span.SetStatusOk()
+	}
+	return err
+}
+
+// GetNeighbourPosition returns the position that directly follows (after) or
+// precedes the given one among the memories of an album, or the loose memories of
+// the hangout when albumID is nil.
+func (r *memoryRepository) GetNeighbourPosition(ctx context.Context, hangoutID uuid.UUID, albumID *uuid.UUID, position string, after bool) (string, error) {
+	ctx, span := otel.StartRepositorySpan(ctx, "GetNeighbourPosition",
+		attribute.String("db.operation", "select"),
+		attribute.String("db.table", "memories"),
+		attribute.String("hangout.id", hangoutID.String()),
+		attribute.Bool("position.after", after),
+	)
+	defer span.End()
+
+	query := r.db.WithContext(ctx).Unscoped().Model(&domain.Memory{}).Where("hangout_id = ?", hangoutID)
+	if albumID != nil {
+		query = query.Where("album_id = ?", *albumID)
+	} else {
+		query = query.Where("album_id IS NULL")
+	}
+
+	if after {
+		query = query.Where("position > ?", position).Order("position asc")
+	} else {
+		if position != "" {
+			query = query.Where("position < ?", position)
+		}
+		query = query.Order("position desc")
+	}
+
+	var positions []string
+
+	start := time.Now()
+	err := query.Limit(1).Pluck("position", &positions).Error
+	r.metrics.RecordDBOperation(ctx, "select", "memories", time.Since(start), len(positions))
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+		return "", err
+	}
+
+	span.SetStatusOk()
+	if len(positions) == 0 {
+		return "", nil
+	}
+	return positions[0], nil
+}
+
+// GetMemoriesByAlbumID returns every memory of the album, including trashed
+// ones, in manual order.
+func (r *memoryRepository) GetMemoriesByAlbumID(ctx context.Context, albumID uuid.UUID) ([]domain.Memory, error) {
+	ctx, span := otel.StartRepositorySpan(ctx, "GetMemoriesByAlbumID",
+		attribute.String("db.operation", "select"),
+		attribute.String("db.table", "memories"),
+		attribute.String("album.id", albumID.String()),
+	)
+	defer span.End()
+
+	var memories []domain.Memory
+
+	start := time.Now()
+	err := r.db.WithContext(ctx).Unscoped().
+		Where("album_id = ?", albumID).
+		Order("position asc, id asc").
+		Find(&memories).Error
+	r.metrics.RecordDBOperation(ctx, "select", "memories", time.Since(start), len(memories))
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("memory.count", len(memories)))
+	span.SetStatusOk()
+	return memories, nil
+}
+
+// UpdatePlacements moves the memories into the album (or out of any album when
+// albumID is nil) at the given positions.
+func (r *memoryRepository) UpdatePlacements(ctx context.Context, albumID *uuid.UUID, positions map[uuid.UUID]string) error {
+	ctx, span := otel.StartRepositorySpan(ctx, "UpdatePlacements",
+		attribute.String("db.operation", "update"),
+		attribute.String("db.table", "memories"),
+		attribute.Int("update.count", len(positions)),
+	)
+	defer span.End()
+
+	if len(positions) == 0 {
+		span.SetStatusOk()
+		return nil
+	}
+
+	ids := make([]interface{}, 0, len(positions))
+	caseSQL := "CASE "
+	args := make([]interface{}, 0, len(positions)*2+1)
+
+	for memoryID, position := range positions {
+		caseSQL += "WHEN id = ? THEN ? "
+		args = append(args, memoryID.String(), position)
+		ids = append(ids, memoryID.String())
+	}
+	caseSQL += "END"
+
+	sql := fmt.Sprintf("UPDATE memories SET position = %s, album_id = ? WHERE id IN (?%s)", caseSQL, RepeatPlaceholder(len(ids)-1))
+	args = append(args, albumID)
+	args = append(args, ids...)
+
+	start := time.Now()
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(sql, args...).Error; err != nil {
+			return err
+		}
+		if albumID == nil {
+			return tx.Exec("UPDATE albums SET cover_memory_id = NULL WHERE cover_memory_id IN ?", ids).Error
+		}
+		return tx.Exec("UPDATE albums SET cover_memory_id = NULL WHERE cover_memory_id IN ? AND id <> ?", ids, *albumID).Error
+	})
+	r.metrics.RecordDBOperation(ctx, "update", "memories", time.Since(start), len(positions))
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+	} else {
+		span.SetStatusOk()
+	}
+	return err
+}
+
 func (r *memoryRepository) DeleteMemory(ctx context.Context, id uuid.UUID) error {
 	ctx, span := otel.StartRepositorySpan(ctx, "DeleteMemory",
 		attribute.String("db.operation", "delete"),
@@ -248,3 +565,271 @@ func (r *memoryRepository) DeleteMemory(ctx context.Context, id uuid.UUID) error
 	}
 	return err
 }
+
+func (r *memoryRepository) GetDeletedMemoryByID(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*domain.Memory, error) {
+	ctx, span := otel.StartRepositorySpan(ctx, "GetDeletedMemoryByID",
+		attribute.String("db.operation", "select"),
+		attribute.String("db.table", "memories"),
+		attribute.String("memory.id", id.String()),
+		attribute.String("user.id", userID.String()),
+	)
+	defer span.End()
+
+	var memory domain.Memory
+
+	start := time.Now()
+	err := r.db.WithContext(ctx).Unscoped().
+		First(&memory, "id = ? AND user_id = ? AND deleted_at IS NOT NULL", id, userID).Error
+	r.metrics.RecordDBOperation(ctx, "select", "memories", time.Since(start), 1)
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetStatusOk()
+	return &memory, nil
+}
+
+// GetDeletedMemoriesByUserID lists memories that were trashed on their own.
+func (r *memoryRepository) GetDeletedMemoriesByUserID(ctx context.Context, userID uuid.UUID, pagination *dto.CursorPagination) ([]domain.Memory, error) {
+	ctx, span := otel.StartRepositorySpan(ctx, "GetDeletedMemoriesByUserID",
+		attribute.String("db.operation", "select"),
+		attribute.String("db.table", "memories"),
+		attribute.String("user.id", userID.String()),
+		attribute.Int("pagination.limit", pagination.GetLimit()),
+	)
+	defer span.End()
+
+	start := time.Now()
+	var memories []domain.Memory
+
+	query := r.db.WithContext(ctx).Unscoped().Model(&domain.Memory{}).
+		Select("memories.*").
+		Joins("JOIN hangouts ON hangouts.id = memories.hangout_id AND hangouts.deleted_at IS NULL").
+		Where("memories.user_id = ? AND memories.deleted_at IS NOT NULL", userID)
+
+	if pagination.AfterID != nil {
+		var cursorItem domain.Memory
+		if err := r.db.WithContext(ctx).Unscoped().First(&cursorItem, "id = ? AND deleted_at IS NOT NULL", *pagination.AfterID).Error; err != nil {
+			return nil, apperrors.ErrInvalidCursorPagination
+		}
+
+		query = query.Where(
+			"(memories.deleted_at < ?) OR (memories.deleted_at = ? AND memories.id < ?)",
+			cursorItem.DeletedAt.Time, cursorItem.DeletedAt.Time, cursorItem.ID,
+		)
+	}
+
+	err := query.
+		Order("memories.deleted_at desc, memories.id desc").
+		Limit(pagination.GetLimit() + 1).
+		Find(&memories).Error
+	r.metrics.RecordDBOperation(ctx, "select", "memories", time.Since(start), len(memories))
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("memory.count", len(memories)))
+	span.SetStatusOk()
+	return memories, nil
+}
+
+func (r *memoryRepository) RestoreMemory(ctx context.Context, id uuid.UUID) error {
+	ctx, span := otel.StartRepositorySpan(ctx, "RestoreMemory",
+		attribute.String("db.operation", "update"),
+		attribute.String("db.table", "memories"),
+		attribute.String("memory.id", id.String()),
+	)
+	defer span.End()
+
+	start := time.Now()
+	err := r.db.WithContext(ctx).Exec("UPDATE `memories` SET `deleted_at` = NULL WHERE `id` = ?", id).Error
+	r.metrics.RecordDBOperation(ctx, "update", "memories", time.Since(start), 1)
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+	} else {
+		span.SetStatusOk()
+	}
+	return err
+}
+
+// SetHiddenAt hides the memory at hiddenAt, or shows it again when hiddenAt
+// is nil. It also applies to memories in the trash.
+func (r *memoryRepository) SetHiddenAt(ctx context.Context, id uuid.UUID, hiddenAt *time.Time) error {
+	ctx, span := otel.StartRepositorySpan(ctx, "SetHiddenAt",
+		attribute.String("db.operation", "update"),
+		attribute.String("db.table", "memories"),
+		attribute.String("memory.id", id.String()),
+	)
+	defer span.End()
+
+	start := time.Now()
+	err := r.db.WithContext(ctx).Unscoped().Model(&domain.Memory{}).Where("id = ?", id).Update("hidden_at", hiddenAt).Error
+	r.metrics.RecordDBOperation(ctx, "update", "memories", time.Since(start), 1)
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+	} else {
+		span.SetStatusOk()
+	}
+	return err
+}
+
+func (r *memoryRepository) GetMemoriesDeletedBefore(ctx context.Context, before time.Time, limit int) ([]domain.Memory, error) {
+	ctx, span := otel.StartRepositorySpan(ctx, "GetMemoriesDeletedBefore",
+		attribute.String("db.operation", "select"),
+		attribute.String("db.table", "memories"),
+		attribute.Int("db.limit", limit),
+	)
+	defer span.End()
+
+	var memories []domain.Memory
+
+	start := time.Now()
+	err := r.db.WithContext(ctx).Unscoped().
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+		Order("deleted_at asc").
+		Limit(limit).
+		Find(&memories).Error
+	r.metrics.RecordDBOperation(ctx, "select", "memories", time.Since(start), len(memories))
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("memory.count", len(memories)))
+	span.SetStatusOk()
+	return memories, nil
+}
+
+func (r *memoryRepository) GetAllMemoriesByHangoutID(ctx context.Context, hangoutID uuid.UUID) ([]domain.Memory, error) {
+	ctx, span := otel.StartRepositorySpan(ctx, "GetAllMemoriesByHangoutID",
+		attribute.String("db.operation", "select"),
+		attribute.String("db.table", "memories"),
+		attribute.String("hangout.id", hangoutID.String()),
+	)
+	defer span.End()
+
+	var memories []domain.Memory
+
+	start := time.Now()
+	err := r.db.WithContext(ctx).Unscoped().Where("hangout_id = ?", hangoutID).Find(&memories).Error
+	r.metrics.RecordDBOperation(ctx, "select", "memories", time.Since(start), len(memories))
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("memory.count", len(memories)))
+	span.SetStatusOk()
+	return memories, nil
+}
+
+// GetAllMemoriesByUserID returns every memory the user uploaded that is not
+// in the trash, with its tags, people and reactions loaded, oldest first.
+func (r *memoryRepository) GetAllMemoriesByUserID(ctx context.Context, userID uuid.UUID) ([]domain.Memory, error) {
+	ctx, span := otel.StartRepositorySpan(ctx, "GetAllMemoriesByUserID",
+		attribute.String("db.operation", "select"),
+		attribute.String("db.table", "memories"),
+		attribute.String("user.id", userID.String()),
+	)
+	defer span.End()
+
+	var memories []domain.Memory
+
+	start := time.Now()
+	err := r.db.WithContext(ctx).
+		Preload("Tags").
+		Preload("PersonTags").
+		Preload("Reactions").
+		Where("user_id = ?", userID).
+		Order("created_at asc").
+		Find(&memories).Error
+	r.metrics.RecordDBOperation(ctx, "select", "memories", time.Since(start), len(memories))
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("memory.count", len(memories)))
+	span.SetStatusOk()
+	return memories, nil
+}
+
+// GetMemoryIDsByHangoutID returns the IDs of the hangout's memories that are
+// neither in the trash nor hidden, in gallery order.
+func (r *memoryRepository) GetMemoryIDsByHangoutID(ctx context.Context, hangoutID uuid.UUID) ([]uuid.UUID, error) {
+	ctx, span := otel.StartRepositorySpan(ctx, "GetMemoryIDsByHangoutID",
+		attribute.String("db.operation", "select"),
+		attribute.String("db.table", "memories"),
+		attribute.String("hangout.id", hangoutID.String()),
+	)
+	defer span.End()
+
+	var ids []uuid.UUID
+
+	start := time.Now()
+	err := r.db.WithContext(ctx).Model(&domain.Memory{}).
+		Where("hangout_id = ?", hangoutID).
+		Where("hidden_at IS NULL").
+		Order("position asc, id asc").
+		Pluck("id", &ids).Error
+	r.metrics.RecordDBOperation(ctx, "select", "memories", time.Since(start), len(ids))
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("memory.count", len(ids)))
+	span.SetStatusOk()
+	return ids, nil
+}
+
+// PurgeMemories permanently removes the memories with their tags, people and
+// reactions, and unsets them as album covers.
+func (r *memoryRepository) PurgeMemories(ctx context.Context, ids []uuid.UUID) error {
+	ctx, span := otel.StartRepositorySpan(ctx, "PurgeMemories",
+		attribute.String("db.operation", "delete"),
+		attribute.String("db.table", "memories"),
+		attribute.Int("id.count", len(ids)),
+	)
+	defer span.End()
+
+	if len(ids) == 0 {
+		span.SetStatusOk()
+		return nil
+	}
+
+	start := time.Now()
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("memory_id IN ?", ids).Delete(&domain.MemoryTag{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("memory_id IN ?", ids).Delete(&domain.MemoryPersonTag{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("memory_id IN ?", ids).Delete(&domain.MemoryReaction{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&domain.Album{}).Where("cover_memory_id IN ?", ids).Update("cover_memory_id", nil).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Where("id IN ?", ids).Delete(&domain.Memory{}).Error
+	})
+	r.metrics.RecordDBOperation(ctx, "delete", "memories", time.Since(start), len(ids))
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+	} else {
+		span.SetStatusOk()
+	}
+	return err
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
 	repo "github.com/Ernestgio/Hangout-Planner/services/hangout/internal/repository"
@@ -110,11 +111,62 @@ func TestGetMemoryByID_TableDriven(t *testing.T) {
 	}
 }
 
+func TestGetMemoryByIDAnyOwner_TableDriven(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		prepare   func(sqlmock.Sqlmock, uuid.UUID)
+		wantError bool
+	}{
+		{
+			name: "found",
+			prepare: func(m sqlmock.Sqlmock, id uuid.UUID) {
+				cols := []string{"id", "name", "created_at", "updated_at", "deleted_at", "hangout_id", "user_id"}
+				m.ExpectQuery("SELECT .* FROM .*memories.* WHERE id = .* AND .*deleted_at. IS NULL").WithArgs(id, sqlmock.AnyArg()).WillReturnRows(sqlmock.NewRows(cols).AddRow(id, "nm", time.Now(), time.Now(), nil, uuid.New(), uuid.New()))
+			},
+		},
+		{
+			name: "not found",
+			prepare: func(m sqlmock.Sqlmock, id uuid.UUID) {
+				m.ExpectQuery("SELECT .* FROM .*memories.*").WithArgs(id, sqlmock.AnyArg()).WillReturnError(gorm.ErrRecordNotFound)
+			},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newDBWithRegexp(t)
+			r := repo.NewMemoryRepository(db, nil)
+			id := uuid.New()
+			tt.prepare(mock, id)
+			mem, err := r.GetMemoryByIDAnyOwner(ctx, id)
+			if tt.wantError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, id, mem.ID)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func expectMemoryAnnotationPreloads(m sqlmock.Sqlmock) {
+	m.ExpectQuery("SELECT .* FROM `memory_person_tags`").WillReturnRows(sqlmock.NewRows([]string{"memory_id", "user_id"}))
+	m.ExpectQuery("SELECT .* FROM `memory_reactions`").WillReturnRows(sqlmock.NewRows([]string{"memory_id", "user_id", "emoji"}))
+	m.ExpectQuery("SELECT .* FROM `memory_tags`").WillReturnRows(sqlmock.NewRows([]string{"memory_id", "tag"}))
+}
+
 func TestGetMemoriesByHangoutID_TableDriven(t *testing.T) {
 	ctx := context.Background()
+	personID := uuid.New()
+	uploaderID := uuid.New()
 
 	tests := []struct {
 		name       string
+		filter     *dto.MemoryFilter
 		pagination *dto.CursorPagination
 		prepare    func(sqlmock.Sqlmock, uuid.UUID, *dto.CursorPagination)
 		wantError  bool
@@ -125,6 +177,43 @@ func TestGetMemoriesByHangoutID_TableDriven(t *testing.T) {
 			prepare: func(m sqlmock.Sqlmock, hangoutID uuid.UUID, p *dto.CursorPagination) {
 				cols := []string{"id", "name", "created_at", "updated_at", "deleted_at", "hangout_id", "user_id"}
 				m.ExpectQuery("SELECT .* FROM .*memories.*").WithArgs(hangoutID, sqlmock.AnyArg()).WillReturnRows(sqlmock.NewRows(cols).AddRow(uuid.New(), "a", time.Now(), time.Now(), nil, hangoutID, uuid.New()).AddRow(uuid.New(), "b", time.Now(), time.Now(), nil, hangoutID, uuid.New()))
+				expectMemoryAnnotationPreloads(m)
+			},
+		},
+		{
+			name:       "with filter",
+			filter:     &dto.MemoryFilter{Tag: "beach", PersonID: &personID, UploaderID: &uploaderID},
+			pagination: &dto.CursorPagination{Limit: 2, SortDir: "asc"},
+			prepare: func(m sqlmock.Sqlmock, hangoutID uuid.UUID, p *dto.CursorPagination) {
+				cols := []string{"id", "name", "created_at", "updated_at", "deleted_at", "hangout_id", "user_id"}
+				m.ExpectQuery("SELECT .* FROM `memories` WHERE hangout_id = \\? AND hidden_at IS NULL AND id IN \\(SELECT memory_id FROM memory_tags WHERE tag = \\?\\) AND id IN \\(SELECT memory_id FROM memory_person_tags WHERE user_id = \\?\\) AND user_id = \\?").
+					WithArgs(hangoutID, "beach", personID, uploaderID, sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows(cols).AddRow(uuid.New(), "a", time.Now(), time.Now(), nil, hangoutID, uploaderID))
+				expectMemoryAnnotationPreloads(m)
+			},
+		},
+		{
+			name:       "album in manual order",
+			filter:     &dto.MemoryFilter{AlbumID: &personID},
+			pagination: &dto.CursorPagination{Limit: 2, SortBy: constants.SortByPosition, SortDir: "asc"},
+			prepare: func(m sqlmock.Sqlmock, hangoutID uuid.UUID, p *dto.CursorPagination) {
+				cols := []string{"id", "name", "position", "hangout_id", "user_id"}
+				m.ExpectQuery("SELECT .* FROM `memories` WHERE hangout_id = \\? AND hidden_at IS NULL AND album_id = \\? .*ORDER BY position asc").
+					WithArgs(hangoutID, personID, sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows(cols).AddRow(uuid.New(), "a", "a0", hangoutID, uploaderID))
+				expectMemoryAnnotationPreloads(m)
+			},
+		},
+		{
+			name:       "loose memories",
+			filter:     &dto.MemoryFilter{NoAlbum: true},
+			pagination: &dto.CursorPagination{Limit: 2, SortDir: "asc"},
+			prepare: func(m sqlmock.Sqlmock, hangoutID uuid.UUID, p *dto.CursorPagination) {
+				cols := []string{"id", "name", "hangout_id", "user_id"}
+				m.ExpectQuery("SELECT .* FROM `memories` WHERE hangout_id = \\? AND hidden_at IS NULL AND album_id IS NULL").
+					WithArgs(hangoutID, sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows(cols).AddRow(uuid.New(), "a", hangoutID, uploaderID))
+				expectMemoryAnnotationPreloads(m)
 			},
 		},
 		{
@@ -152,7 +241,7 @@ func TestGetMemoriesByHangoutID_TableDriven(t *testing.T) {
 			r := repo.NewMemoryRepository(db, nil)
 			hid := uuid.New()
 			tt.prepare(mock, hid, tt.pagination)
-			res, err := r.GetMemoriesByHangoutID(ctx, hid, tt.pagination)
+			res, err := r.GetMemoriesByHangoutID(ctx, hid, tt.filter, tt.pagination)
 			if tt.wantError {
 				require.Error(t, err)
 			} else {
@@ -239,9 +328,10 @@ func TestGetMemoriesByHangoutID_WithCursor_TableDriven(t *testing.T) {
 			mock.ExpectQuery("SELECT .* FROM .*memories.*").WithArgs(hid, comp1, comp2, cursorID, sqlmock.AnyArg()).WillReturnRows(
 				sqlmock.NewRows(cols).AddRow(uuid.New(), "r", time.Now(), time.Now(), nil, hid, uuid.New()),
 			)
+			expectMemoryAnnotationPreloads(mock)
 
 			p := &dto.CursorPagination{Limit: 1, SortDir: tc.sortDir, AfterID: &cursorID}
-			res, err := r.GetMemoriesByHangoutID(ctx, hid, p)
+			res, err := r.GetMemoriesByHangoutID(ctx, hid, nil, p)
 			require.NoError(t, err)
 			require.GreaterOrEqual(t, len(res), 1)
 			require.NoError(t, mock.ExpectationsWereMet())
@@ -459,3 +549,745 @@ func TestGetMemoriesByIDs_TableDriven(t *testing.T) {
 		})
 	}
 }
+
+func TestGetDeletedMemoryByID_TableDriven(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		prepare   func(sqlmock.Sqlmock, uuid.UUID, uuid.UUID)
+		wantError bool
+	}{
+		{
+			name: "found",
+			prepare: func(m sqlmock.Sqlmock, id uuid.UUID, userID uuid.UUID) {
+				cols := []string{"id", "name", "created_at", "updated_at", "deleted_at", "hangout_id", "user_id"}
+				m.ExpectQuery("SELECT .* FROM .*memories.* deleted_at IS NOT NULL").WithArgs(id, userID, sqlmock.AnyArg()).WillReturnRows(sqlmock.NewRows(cols).AddRow(id, "nm", time.Now(), time.Now(), time.Now(), uuid.New(), userID))
+			},
+		},
+		{
+			name: "not found",
+			prepare: func(m sqlmock.Sqlmock, id uuid.UUID, userID uuid.UUID) {
+				m.ExpectQuery("SELECT .* FROM .*memories.*").WithArgs(id, userID, sqlmock.AnyArg()).WillReturnError(gorm.ErrRecordNotFound)
+			},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newDBWithRegexp(t)
+			r := repo.NewMemoryRepository(db, nil)
+			id := uuid.New()
+			userID := uuid.New()
+			tt.prepare(mock, id, userID)
+			mem, err := r.GetDeletedMemoryByID(ctx, id, userID)
+			if tt.wantError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, id, mem.ID)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGetDeletedMemoriesByUserID_TableDriven(t *testing.T) {
+	ctx := context.Background()
+	afterID := uuid.New()
+
+	tests := []struct {
+		name       string
+		pagination *dto.CursorPagination
+		prepare    func(sqlmock.Sqlmock, uuid.UUID)
+		wantLen    int
+		wantError  bool
+	}{
+		{
+			name:       "no cursor",
+			pagination: &dto.CursorPagination{Limit: 2},
+			prepare: func(m sqlmock.Sqlmock, userID uuid.UUID) {
+				cols := []string{"id", "name", "created_at", "updated_at", "deleted_at", "hangout_id", "user_id"}
+				m.ExpectQuery("SELECT memories.\\* FROM .*memories.* JOIN hangouts .*").
+					WithArgs(userID, 3).
+					WillReturnRows(sqlmock.NewRows(cols).
+						AddRow(uuid.New(), "a", time.Now(), time.Now(), time.Now(), uuid.New(), userID).
+						AddRow(uuid.New(), "b", time.Now(), time.Now(), time.Now(), uuid.New(), userID))
+			},
+			wantLen: 2,
+		},
+		{
+			name:       "with cursor",
+			pagination: &dto.CursorPagination{Limit: 2, AfterID: &afterID},
+			prepare: func(m sqlmock.Sqlmock, userID uuid.UUID) {
+				cols := []string{"id", "name", "created_at", "updated_at", "deleted_at", "hangout_id", "user_id"}
+				deletedAt := time.Now()
+				m.ExpectQuery("SELECT .* FROM .*memories.*").
+					WithArgs(afterID, 1).
+					WillReturnRows(sqlmock.NewRows(cols).AddRow(afterID, "c", time.Now(), time.Now(), deletedAt, uuid.New(), userID))
+				m.ExpectQuery("SELECT memories.\\* FROM .*memories.* JOIN hangouts .*").
+					WithArgs(userID, deletedAt, deletedAt, afterID, 3).
+					WillReturnRows(sqlmock.NewRows(cols))
+			},
+			wantLen: 0,
+		},
+		{
+			name:       "invalid cursor",
+			pagination: &dto.CursorPagination{Limit: 2, AfterID: &afterID},
+			prepare: func(m sqlmock.Sqlmock, userID uuid.UUID) {
+				m.ExpectQuery("SELECT .* FROM .*memories.*").
+					WithArgs(afterID, 1).
+					WillReturnError(gorm.ErrRecordNotFound)
+			},
+			wantError: true,
+		},
+		{
+			name:       "db error",
+			pagination: &dto.CursorPagination{Limit: 2},
+			prepare: func(m sqlmock.Sqlmock, userID uuid.UUID) {
+				m.ExpectQuery("SELECT memories.\\* FROM .*memories.*").
+					WithArgs(userID, 3).
+					WillReturnError(errors.New("db error"))
+			},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newDBWithRegexp(t)
+			r := repo.NewMemoryRepository(db, nil)
+			userID := uuid.New()
+			tt.prepare(mock, userID)
+			res, err := r.GetDeletedMemoriesByUserID(ctx, userID, tt.pagination)
+			if tt.wantError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, res, tt.wantLen)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestRestoreMemory_TableDriven(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		prepare   func(sqlmock.Sqlmock, uuid.UUID)
+		wantError bool
+	}{
+		{
+			name: "success",
+			prepare: func(m sqlmock.Sqlmock, id uuid.UUID) {
+				m.ExpectExec("UPDATE `memories` SET `deleted_at` = NULL WHERE `id` = ?").WithArgs(id).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "db error",
+			prepare: func(m sqlmock.Sqlmock, id uuid.UUID) {
+				m.ExpectExec("UPDATE `memories` SET `deleted_at` = NULL WHERE `id` = ?").WithArgs(id).WillReturnError(errors.New("db error"))
+			},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := setupDB(t)
+			r := repo.NewMemoryRepository(db, nil)
+			id := uuid.New()
+			tt.prepare(mock, id)
+			err := r.RestoreMemory(ctx, id)
+			if tt.wantError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestSetHiddenAt_TableDriven(t *testing.T) {
+	ctx := context.Background()
+	hiddenAt := time.Now()
+
+	tests := []struct {
+		name      string
+		hiddenAt  *time.Time
+		execErr   error
+		wantError bool
+	}{
+		{name: "hide", hiddenAt: &hiddenAt},
+		{name: "unhide", hiddenAt: nil},
+		{name: "db error", hiddenAt: &hiddenAt, execErr: errors.New("db error"), wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := setupDB(t)
+			r := repo.NewMemoryRepository(db, nil)
+			id := uuid.New()
+
+			var arg any
+			if tt.hiddenAt != nil {
+				arg = *tt.hiddenAt
+			}
+			mock.ExpectBegin()
+			exec := mock.ExpectExec("UPDATE `memories` SET `hidden_at`=?,`updated_at`=? WHERE id = ?").
+				WithArgs(arg, AnyTime{}, id)
+			if tt.execErr != nil {
+				exec.WillReturnError(tt.execErr)
+				mock.ExpectRollback()
+			} else {
+				exec.WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			}
+
+			err := r.SetHiddenAt(ctx, id, tt.hiddenAt)
+			if tt.wantError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGetMemoriesDeletedBefore_TableDriven(t *testing.T) {
+	ctx := context.Background()
+	before := time.Now()
+
+	tests := []struct {
+		name      string
+		prepare   func(sqlmock.Sqlmock)
+		wantLen   int
+		wantError bool
+	}{
+		{
+			name: "success",
+			prepare: func(m sqlmock.Sqlmock) {
+				cols := []string{"id", "name", "created_at", "updated_at", "deleted_at", "hangout_id", "user_id"}
+				m.ExpectQuery("SELECT .* FROM .*memories.* deleted_at < .* ORDER BY deleted_at asc LIMIT").
+					WithArgs(before, 10).
+					WillReturnRows(sqlmock.NewRows(cols).AddRow(uuid.New(), "a", time.Now(), time.Now(), time.Now(), uuid.New(), uuid.New()))
+			},
+			wantLen: 1,
+		},
+		{
+			name: "db error",
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT .* FROM .*memories.*").WithArgs(before, 10).WillReturnError(errors.New("db error"))
+			},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newDBWithRegexp(t)
+			r := repo.NewMemoryRepository(db, nil)
+			tt.prepare(mock)
+			res, err := r.GetMemoriesDeletedBefore(ctx, before, 10)
+			if tt.wantError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, res, tt.wantLen)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGetAllMemoriesByHangoutID_TableDriven(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		prepare   func(sqlmock.Sqlmock, uuid.UUID)
+		wantLen   int
+		wantError bool
+	}{
+		{
+			name: "includes deleted",
+			prepare: func(m sqlmock.Sqlmock, hangoutID uuid.UUID) {
+				cols := []string{"id", "name", "created_at", "updated_at", "deleted_at", "hangout_id", "user_id"}
+				m.ExpectQuery("SELECT \\* FROM `memories` WHERE hangout_id = \\?$").
+					WithArgs(hangoutID).
+					WillReturnRows(sqlmock.NewRows(cols).
+						AddRow(uuid.New(), "a", time.Now(), time.Now(), nil, hangoutID, uuid.New()).
+						AddRow(uuid.New(), "b", time.Now(), time.Now(), time.Now(), hangoutID, uuid.New()))
+			},
+			wantLen: 2,
+		},
+		{
+			name: "db error",
+			prepare: func(m sqlmock.Sqlmock, hangoutID uuid.UUID) {
+				m.ExpectQuery("SELECT .* FROM .*memories.*").WithArgs(hangoutID).WillReturnError(errors.New("db error"))
+			},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newDBWithRegexp(t)
+			r := repo.NewMemoryRepository(db, nil)
+			hangoutID := uuid.New()
+			tt.prepare(mock, hangoutID)
+			res, err := r.GetAllMemoriesByHangoutID(ctx, hangoutID)
+			if tt.wantError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, res, tt.wantLen)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGetAllMemoriesByUserID_TableDriven(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		prepare   func(sqlmock.Sqlmock, uuid.UUID)
+		wantLen   int
+		wantError bool
+	}{
+		{
+			name: "loads annotations",
+			prepare: func(m sqlmock.Sqlmock, userID uuid.UUID) {
+				memoryID := uuid.New()
+				m.ExpectQuery("SELECT \\* FROM `memories` WHERE user_id = \\? AND `memories`.`deleted_at` IS NULL ORDER BY created_at asc").
+					WithArgs(userID).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "hangout_id", "user_id"}).
+						AddRow(memoryID, "a", uuid.New(), userID))
+				m.ExpectQuery("SELECT \\* FROM `memory_person_tags` WHERE `memory_person_tags`.`memory_id` = \\?").
+					WithArgs(memoryID).
+					WillReturnRows(sqlmock.NewRows([]string{"memory_id", "user_id"}))
+				m.ExpectQuery("SELECT \\* FROM `memory_reactions` WHERE `memory_reactions`.`memory_id` = \\?").
+					WithArgs(memoryID).
+					WillReturnRows(sqlmock.NewRows([]string{"memory_id", "user_id", "emoji"}))
+				m.ExpectQuery("SELECT \\* FROM `memory_tags` WHERE `memory_tags`.`memory_id` = \\?").
+					WithArgs(memoryID).
+					WillReturnRows(sqlmock.NewRows([]string{"memory_id", "tag"}).AddRow(memoryID, "beach"))
+			},
+			wantLen: 1,
+		},
+		{
+			name: "db error",
+			prepare: func(m sqlmock.Sqlmock, userID uuid.UUID) {
+				m.ExpectQuery("SELECT .* FROM .*memories.*").WithArgs(userID).WillReturnError(errors.New("db error"))
+			},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newDBWithRegexp(t)
+			r := repo.NewMemoryRepository(db, nil)
+			userID := uuid.New()
+			tt.prepare(mock, userID)
+
+			res, err := r.GetAllMemoriesByUserID(ctx, userID)
+			if tt.wantError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, res, tt.wantLen)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPurgeMemories_TableDriven(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		ids       []uuid.UUID
+		prepare   func(sqlmock.Sqlmock, []uuid.UUID)
+		wantError bool
+	}{
+		{
+			name:    "empty ids",
+			ids:     []uuid.UUID{},
+			prepare: func(m sqlmock.Sqlmock, ids []uuid.UUID) {},
+		},
+		{
+			name: "success",
+			ids:  []uuid.UUID{uuid.New(), uuid.New()},
+			prepare: func(m sqlmock.Sqlmock, ids []uuid.UUID) {
+				m.ExpectBegin()
+				m.ExpectExec("DELETE FROM `memory_tags` WHERE memory_id IN \\(\\?,\\?\\)").WithArgs(ids[0], ids[1]).WillReturnResult(sqlmock.NewResult(0, 3))
+				m.ExpectExec("DELETE FROM `memory_person_tags` WHERE memory_id IN \\(\\?,\\?\\)").WithArgs(ids[0], ids[1]).WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectExec("DELETE FROM `memory_reactions` WHERE memory_id IN \\(\\?,\\?\\)").WithArgs(ids[0], ids[1]).WillReturnResult(sqlmock.NewResult(0, 4))
+				m.ExpectExec("UPDATE `albums` SET `cover_memory_id`=\\?,`updated_at`=\\? WHERE cover_memory_id IN \\(\\?,\\?\\)").WithArgs(nil, AnyTime{}, ids[0], ids[1]).WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectExec("DELETE FROM `memories` WHERE id IN \\(\\?,\\?\\)").WithArgs(ids[0], ids[1]).WillReturnResult(sqlmock.NewResult(0, 2))
+				m.ExpectCommit()
+			},
+		},
+		{
+			name: "db error",
+			ids:  []uuid.UUID{uuid.New()},
+			prepare: func(m sqlmock.Sqlmock, ids []uuid.UUID) {
+				m.ExpectBegin()
+				m.ExpectExec("DELETE FROM `memory_tags`").WithArgs(ids[0]).WillReturnError(errors.New("db error"))
+				m.ExpectRollback()
+			},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newDBWithRegexp(t)
+			r := repo.NewMemoryRepository(db, nil)
+			tt.prepare(mock, tt.ids)
+			err := r.PurgeMemories(ctx, tt.ids)
+			if tt.wantError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGetMemoryWithAnnotations_TableDriven(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		prepare   func(sqlmock.Sqlmock, uuid.UUID)
+		wantError bool
+	}{
+		{
+			name: "success",
+			prepare: func(m sqlmock.Sqlmock, id uuid.UUID) {
+				m.ExpectQuery("SELECT .* FROM `memories` WHERE hidden_at IS NULL AND id = \\?").WithArgs(id, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(id, "m"))
+				m.ExpectQuery("SELECT .* FROM `memory_person_tags`").WithArgs(id).
+					WillReturnRows(sqlmock.NewRows([]string{"memory_id", "user_id"}).AddRow(id, uuid.New()))
+				m.ExpectQuery("SELECT .* FROM `memory_reactions`").WithArgs(id).
+					WillReturnRows(sqlmock.NewRows([]string{"memory_id", "user_id", "emoji"}).AddRow(id, uuid.New(), "🔥"))
+				m.ExpectQuery("SELECT .* FROM `memory_tags`").WithArgs(id).
+					WillReturnRows(sqlmock.NewRows([]string{"memory_id", "tag"}).AddRow(id, "beach"))
+			},
+		},
+		{
+			name: "not found",
+			prepare: func(m sqlmock.Sqlmock, id uuid.UUID) {
+				m.ExpectQuery("SELECT .* FROM `memories` WHERE hidden_at IS NULL AND id = \\?").WithArgs(id, 1).WillReturnError(gorm.ErrRecordNotFound)
+			},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newDBWithRegexp(t)
+			r := repo.NewMemoryRepository(db, nil)
+			id := uuid.New()
+			tt.prepare(mock, id)
+			got, err := r.GetMemoryWithAnnotations(ctx, id)
+			if tt.wantError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, got.PersonTags, 1)
+				require.Len(t, got.Reactions, 1)
+				require.Equal(t, "beach", got.Tags[0].Tag)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUpdateMemoryAnnotations_TableDriven(t *testing.T) {
+	ctx := context.Background()
+	caption := "Sunset"
+
+	tests := []struct {
+		name      string
+		memory    func(id uuid.UUID) *domain.Memory
+		prepare   func(sqlmock.Sqlmock, uuid.UUID)
+		wantError bool
+	}{
+		{
+			name: "replaces tags and people",
+			memory: func(id uuid.UUID) *domain.Memory {
+				return &domain.Memory{
+					ID:         id,
+					Caption:    &caption,
+					Tags:       []domain.MemoryTag{{MemoryID: id, Tag: "beach"}},
+					PersonTags: []domain.MemoryPersonTag{{MemoryID: id, UserID: uuid.New()}},
+				}
+			},
+			prepare: func(m sqlmock.Sqlmock, id uuid.UUID) {
+				m.ExpectBegin()
+				m.ExpectExec("UPDATE `memories` SET `caption`=\\?").WithArgs(&caption, AnyTime{}, id).WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectExec("DELETE FROM `memory_tags` WHERE memory_id = \\?").WithArgs(id).WillReturnResult(sqlmock.NewResult(0, 2))
+				m.ExpectExec("INSERT INTO `memory_tags`").WithArgs(id, "beach").WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectExec("DELETE FROM `memory_person_tags` WHERE memory_id = \\?").WithArgs(id).WillReturnResult(sqlmock.NewResult(0, 0))
+				m.ExpectExec("INSERT INTO `memory_person_tags`").WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectCommit()
+			},
+		},
+		{
+			name: "clears everything",
+			memory: func(id uuid.UUID) *domain.Memory {
+				return &domain.Memory{ID: id}
+			},
+			prepare: func(m sqlmock.Sqlmock, id uuid.UUID) {
+				m.ExpectBegin()
+				m.ExpectExec("UPDATE `memories` SET `caption`=\\?").WithArgs(nil, AnyTime{}, id).WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectExec("DELETE FROM `memory_tags` WHERE memory_id = \\?").WithArgs(id).WillReturnResult(sqlmock.NewResult(0, 2))
+				m.ExpectExec("DELETE FROM `memory_person_tags` WHERE memory_id = \\?").WithArgs(id).WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectCommit()
+			},
+		},
+		{
+			name: "update error",
+			memory: func(id uuid.UUID) *domain.Memory {
+				return &domain.Memory{ID: id}
+			},
+			prepare: func(m sqlmock.Sqlmock, id uuid.UUID) {
+				m.ExpectBegin()
+				m.ExpectExec("UPDATE `memories`").WillReturnError(errors.New("db error"))
+				m.ExpectRollback()
+			},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newDBWithRegexp(t)
+			r := repo.NewMemoryRepository(db, nil)
+			id := uuid.New()
+			tt.prepare(mock, id)
+			err := r.UpdateMemoryAnnotations(ctx, tt.memory(id))
+			if tt.wantError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestMemoryReactions(t *testing.T) {
+	ctx := context.Background()
+	memoryID := uuid.New()
+	userID := uuid.New()
+
+	t.Run("add ignores duplicates", func(t *testing.T) {
+		db, mock := newDBWithRegexp(t)
+		r := repo.NewMemoryRepository(db, nil)
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO `memory_reactions` .* ON DUPLICATE KEY UPDATE").WithArgs(memoryID, userID, "🔥", AnyTime{}).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+		err := r.AddReaction(ctx, &domain.MemoryReaction{MemoryID: memoryID, UserID: userID, Emoji: "🔥", CreatedAt: time.Now()})
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("remove", func(t *testing.T) {
+		db, mock := newDBWithRegexp(t)
+		r := repo.NewMemoryRepository(db, nil)
+		mock.ExpectBegin()
+		mock.ExpectExec("DELETE FROM `memory_reactions` WHERE memory_id = \\? AND user_id = \\? AND emoji = \\?").WithArgs(memoryID, userID, "🔥").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+		require.NoError(t, r.RemoveReaction(ctx, memoryID, userID, "🔥"))
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("remove error", func(t *testing.T) {
+		db, mock := newDBWithRegexp(t)
+		r := repo.NewMemoryRepository(db, nil)
+		mock.ExpectBegin()
+		mock.ExpectExec("DELETE FROM `memory_reactions`").WillReturnError(errors.New("db error"))
+		mock.ExpectRollback()
+		require.Error(t, r.RemoveReaction(ctx, memoryID, userID, "🔥"))
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestGetNeighbourPosition_TableDriven(t *testing.T) {
+	ctx := context.Background()
+	hangoutID := uuid.New()
+	albumID := uuid.New()
+
+	tests := []struct {
+		name     string
+		albumID  *uuid.UUID
+		position string
+		after    bool
+		prepare  func(sqlmock.Sqlmock)
+		want     string
+	}{
+		{
+			name: "last loose position",
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT `position` FROM `memories` WHERE hangout_id = \\? AND album_id IS NULL ORDER BY position desc LIMIT \\?").
+					WithArgs(hangoutID, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"position"}).AddRow("a7"))
+			},
+			want: "a7",
+		},
+		{
+			name:     "next in album",
+			albumID:  &albumID,
+			position: "a1",
+			after:    true,
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT `position` FROM `memories` WHERE hangout_id = \\? AND album_id = \\? AND position > \\? ORDER BY position asc LIMIT \\?").
+					WithArgs(hangoutID, albumID, "a1", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"position"}).AddRow("a2"))
+			},
+			want: "a2",
+		},
+		{
+			name:     "no previous",
+			albumID:  &albumID,
+			position: "a0",
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT `position` FROM `memories` WHERE hangout_id = \\? AND album_id = \\? AND position < \\? ORDER BY position desc LIMIT \\?").
+					WithArgs(hangoutID, albumID, "a0", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"position"}))
+			},
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newDBWithRegexp(t)
+			r := repo.NewMemoryRepository(db, nil)
+			tt.prepare(mock)
+			got, err := r.GetNeighbourPosition(ctx, hangoutID, tt.albumID, tt.position, tt.after)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGetMemoriesByAlbumID(t *testing.T) {
+	ctx := context.Background()
+	albumID := uuid.New()
+	db, mock := newDBWithRegexp(t)
+	r := repo.NewMemoryRepository(db, nil)
+
+	mock.ExpectQuery("SELECT \\* FROM `memories` WHERE album_id = \\? ORDER BY position asc, id asc").
+		WithArgs(albumID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "position"}).AddRow(uuid.New(), "a0").AddRow(uuid.New(), "a1"))
+
+	memories, err := r.GetMemoriesByAlbumID(ctx, albumID)
+	require.NoError(t, err)
+	require.Len(t, memories, 2)
+	require.Equal(t, "a1", memories[1].Position)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetMemoryIDsByHangoutID(t *testing.T) {
+	ctx := context.Background()
+	hangoutID := uuid.New()
+	first, second := uuid.New(), uuid.New()
+	db, mock := newDBWithRegexp(t)
+	r := repo.NewMemoryRepository(db, nil)
+
+	mock.ExpectQuery("SELECT `id` FROM `memories` WHERE hangout_id = \\? AND hidden_at IS NULL AND `memories`.`deleted_at` IS NULL ORDER BY position asc, id asc").
+		WithArgs(hangoutID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(first).AddRow(second))
+
+	ids, err := r.GetMemoryIDsByHangoutID(ctx, hangoutID)
+	require.NoError(t, err)
+	require.Equal(t, []uuid.UUID{first, second}, ids)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdatePlacements_TableDriven(t *testing.T) {
+	ctx := context.Background()
+	memoryID := uuid.MustParse("11111111-1111-1111-1111-111111111111")
+	albumID := uuid.New()
+
+	tests := []struct {
+		name      string
+		albumID   *uuid.UUID
+		positions map[uuid.UUID]string
+		prepare   func(sqlmock.Sqlmock)
+		wantError bool
+	}{
+		{
+			name:      "empty map",
+			positions: map[uuid.UUID]string{},
+			prepare:   func(m sqlmock.Sqlmock) {},
+		},
+		{
+			name:      "into album",
+			albumID:   &albumID,
+			positions: map[uuid.UUID]string{memoryID: "a0V"},
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("UPDATE memories SET position = CASE WHEN id = \\? THEN \\? END, album_id = \\? WHERE id IN \\(\\?\\)").
+					WithArgs(memoryID.String(), "a0V", &albumID, memoryID.String()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectExec("UPDATE albums SET cover_memory_id = NULL WHERE cover_memory_id IN \\(\\?\\) AND id <> \\?").
+					WithArgs(memoryID.String(), albumID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectCommit()
+			},
+		},
+		{
+			name:      "out of albums",
+			positions: map[uuid.UUID]string{memoryID: "a3"},
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("UPDATE memories SET position = CASE").WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectExec("UPDATE albums SET cover_memory_id = NULL WHERE cover_memory_id IN \\(\\?\\)$").
+					WithArgs(memoryID.String()).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				m.ExpectCommit()
+			},
+		},
+		{
+			name:      "update error",
+			positions: map[uuid.UUID]string{memoryID: "a3"},
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("UPDATE memories SET position = CASE").WillReturnError(errors.New("update failed"))
+				m.ExpectRollback()
+			},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newDBWithRegexp(t)
+			r := repo.NewMemoryRepository(db, nil)
+			tt.prepare(mock)
+			err := r.UpdatePlacements(ctx, tt.albumID, tt.positions)
+			if tt.wantError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
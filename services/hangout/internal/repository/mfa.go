@@ -0,0 +1,242 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/otel"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"gorm.io/gorm"
+)
+
+type MFARepository interface {
+	WithTx(tx *gorm.DB) MFARepository
+	GetTOTP(ctx context.Context, userID uuid.UUID) (*domain.TOTPCredential, error)
+	SaveTOTP(ctx context.Context, credential *domain.TOTPCredential) error
+	ConfirmTOTP(ctx context.Context, id uuid.UUID, step int64, confirmedAt time.Time) (int64, error)
+	UseTOTPStep(ctx context.Context, id uuid.UUID, step int64) (int64, error)
+	DeleteTOTP(ctx context.Context, userID uuid.UUID) error
+	ReplaceRecoveryCodes(ctx context.Context, userID uuid.UUID, codes []domain.RecoveryCode) error
+	UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string, usedAt time.Time) (int64, error)
+	CountUnusedRecoveryCodes(ctx context.Context, userID uuid.UUID) (int64, error)
+	DeleteRecoveryCodes(ctx context.Context, userID uuid.UUID) error
+}
+
+type mfaRepository struct {
+	db      *gorm.DB
+	metrics *otel.MetricsRecorder
+}
+
+func NewMFARepository(db *gorm.DB, metrics *otel.MetricsRecorder) MFARepository {
+	return &mfaRepository{db: db, metrics: metrics}
+}
+
+func (r *mfaRepository) WithTx(tx *gorm.DB) MFARepository {
+	return &mfaRepository{db: tx, metrics: r.metrics}
+}
+
+func (r *mfaRepository) GetTOTP(ctx context.Context, userID uuid.UUID) (*domain.TOTPCredential, error) {
+	ctx, span := otel.StartRepositorySpan(ctx, "GetTOTP",
+		attribute.String("db.operation", "select"),
+		attribute.String("db.table", "totp_credentials"),
+		attribute.String("user.id", userID.String()),
+	)
+	defer span.End()
+
+	start := time.Now()
+	var credential domain.TOTPCredential
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).First(&credential).Error
+	r.metrics.RecordDBOperation(ctx, "select", "totp_credentials", time.Since(start), 1)
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetStatusOk()
+	return &credential, nil
+}
+
+// SaveTOTP inserts a new credential or updates an existing one.
+func (r *mfaRepository) SaveTOTP(ctx context.Context, credential *domain.TOTPCredential) error {
+	ctx, span := otel.StartRepositorySpan(ctx, "SaveTOTP",
+		attribute.String("db.operation", "upsert"),
+		attribute.String("db.table", "totp_credentials"),
+		attribute.String("user.id", credential.UserID.String()),
+	)
+	defer span.End()
+
+	start := time.Now()
+	err := r.db.WithContext(ctx).Save(credential).Error
+	r.metrics.RecordDBOperation(ctx, "upsert", "totp_credentials", time.Since(start), 1)
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+	} else {
+		span.SetStatusOk()
+	}
+	return err
+}
+
+// ConfirmTOTP marks an unconfirmed credential as confirmed by the code of step.
+func (r *mfaRepository) ConfirmTOTP(ctx context.Context, id uuid.UUID, step int64, confirmedAt time.Time) (int64, error) {
+	ctx, span := otel.StartRepositorySpan(ctx, "ConfirmTOTP",
+		attribute.String("db.operation", "update"),
+		attribute.String("db.table", "totp_credentials"),
+	)
+	defer span.End()
+
+	start := time.Now()
+	result := r.db.WithContext(ctx).Model(&domain.TOTPCredential{}).
+		Where("id = ? AND confirmed_at IS NULL", id).
+		Updates(map[string]any{"confirmed_at": confirmedAt, "last_used_step": step})
+	r.metrics.RecordDBOperation(ctx, "update", "totp_credentials", time.Since(start), int(result.RowsAffected))
+
+	if result.Error != nil {
+		_ = span.RecordErrorWithStatus(result.Error)
+		return 0, result.Error
+	}
+
+	span.SetStatusOk()
+	return result.RowsAffected, nil
+}
+
+// UseTOTPStep records step as the last used one.
+func (r *mfaRepository) UseTOTPStep(ctx context.Context, id uuid.UUID, step int64) (int64, error) {
+	ctx, span := otel.StartRepositorySpan(ctx, "UseTOTPStep",
+		attribute.String("db.operation", "update"),
+		attribute.String("db.table", "totp_credentials"),
+	)
+	defer span.End()
+
+	start := time.Now()
+	result := r.db.WithContext(ctx).Model(&domain.TOTPCredential{}).
+		Where("id = ? AND last_used_step < ?", id, step).
+		Update("last_used_step", step)
+	r.metrics.RecordDBOperation(ctx, "update", "totp_credentials", time.Since(start), int(result.RowsAffected))
+
+	if result.Error != nil {
+		_ = span.RecordErrorWithStatus(result.Error)
+		return 0, result.Error
+	}
+
+	span.SetStatusOk()
+	return result.RowsAffected, nil
+}
+
+func (r *mfaRepository) DeleteTOTP(ctx context.Context, userID uuid.UUID) error {
+	ctx, span := otel.StartRepositorySpan(ctx, "DeleteTOTP",
+		attribute.String("db.operation", "delete"),
+		attribute.String("db.table", "totp_credentials"),
+		attribute.String("user.id", userID.String()),
+	)
+	defer span.End()
+
+	start := time.Now()
+	result := r.db.WithContext(ctx).Where("user_id = ?", userID).Delete(&domain.TOTPCredential{})
+	r.metrics.RecordDBOperation(ctx, "delete", "totp_credentials", time.Since(start), int(result.RowsAffected))
+
+	if result.Error != nil {
+		_ = span.RecordErrorWithStatus(result.Error)
+		return result.Error
+	}
+
+	span.SetStatusOk()
+	return nil
+}
+
+// ReplaceRecoveryCodes deletes the user's recovery codes and inserts codes.
+func (r *mfaRepository) ReplaceRecoveryCodes(ctx context.Context, userID uuid.UUID, codes []domain.RecoveryCode) error {
+	ctx, span := otel.StartRepositorySpan(ctx, "ReplaceRecoveryCodes",
+		attribute.String("db.operation", "replace"),
+		attribute.String("db.table", "recovery_codes"),
+		attribute.String("user.id", userID.String()),
+		attribute.Int("recovery_codes.count", len(codes)),
+	)
+	defer span.End()
+
+	start := time.Now()
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).Delete(&domain.RecoveryCode{}).Error
+	if err == nil && len(codes) > 0 {
+		err = r.db.WithContext(ctx).Create(&codes).Error
+	}
+	r.metrics.RecordDBOperation(ctx, "replace", "recovery_codes", time.Since(start), len(codes))
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+	} else {
+		span.SetStatusOk()
+	}
+	return err
+}
+
+// UseRecoveryCode marks an unused code of the user as used.
+func (r *mfaRepository) UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string, usedAt time.Time) (int64, error) {
+	ctx, span := otel.StartRepositorySpan(ctx, "UseRecoveryCode",
+		attribute.String("db.operation", "update"),
+		attribute.String("db.table", "recovery_codes"),
+		attribute.String("user.id", userID.String()),
+	)
+	defer span.End()
+
+	start := time.Now()
+	result := r.db.WithContext(ctx).Model(&domain.RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", usedAt)
+	r.metrics.RecordDBOperation(ctx, "update", "recovery_codes", time.Since(start), int(result.RowsAffected))
+
+	if result.Error != nil {
+		_ = span.RecordErrorWithStatus(result.Error)
+		return 0, result.Error
+	}
+
+	span.SetStatusOk()
+	return result.RowsAffected, nil
+}
+
+func (r *mfaRepository) CountUnusedRecoveryCodes(ctx context.Context, userID uuid.UUID) (int64, error) {
+	ctx, span := otel.StartRepositorySpan(ctx, "CountUnusedRecoveryCodes",
+		attribute.String("db.operation", "select"),
+		attribute.String("db.table", "recovery_codes"),
+		attribute.String("user.id", userID.String()),
+	)
+	defer span.End()
+
+	start := time.Now()
+	var count int64
+	err := r.db.WithContext(ctx).Model(&domain.RecoveryCode{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Count(&count).Error
+	r.metrics.RecordDBOperation(ctx, "select", "recovery_codes", time.Since(start), 1)
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+		return 0, err
+	}
+
+	span.SetStatusOk()
+	return count, nil
+}
+
+func (r *mfaRepository) DeleteRecoveryCodes(ctx context.Context, userID uuid.UUID) error {
+	ctx, span := otel.StartRepositorySpan(ctx, "DeleteRecoveryCodes",
+		attribute.String("db.operation", "delete"),
+		attribute.String("db.table", "recovery_codes"),
+		attribute.String("user.id", userID.String()),
+	)
+	defer span.End()
+
+	start := time.Now()
+	result := r.db.WithContext(ctx).Where("user_id = ?", userID).Delete(&domain.RecoveryCode{})
+	r.metrics.RecordDBOperation(ctx, "delete", "recovery_codes", time.Since(start), int(result.RowsAffected))
+
+	if result.Error != nil {
+		_ = span.RecordErrorWithStatus(result.Error)
+		return result.Error
+	}
+
+	span.SetStatusOk()
+	return nil
+}
@@ -0,0 +1,519 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	repo "github.com/Ernestgio/Hangout-Planner/services/hangout/internal/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestMFARepository_WithTx(t *testing.T) {
+	db, _ := newDBWithRegexp(t)
+	r := repo.NewMFARepository(db, nil)
+
+	txRepo := r.WithTx(db.Begin())
+	require.NotNil(t, txRepo)
+	require.NotEqual(t, r, txRepo)
+}
+
+func TestMFAGetTOTP_TableDriven(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	tests := []struct {
+		name      string
+		prepare   func(sqlmock.Sqlmock)
+		wantError error
+	}{
+		{
+			name: "found",
+			prepare: func(m sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "secret", "last_used_step", "user_id"}).
+					AddRow(uuid.New().String(), []byte("sealed"), 10, userID.String())
+				m.ExpectQuery("SELECT \\* FROM `totp_credentials` WHERE user_id = \\? ORDER BY `totp_credentials`.`id` LIMIT \\?").
+					WithArgs(userID, 1).
+					WillReturnRows(rows)
+			},
+		},
+		{
+			name: "not found",
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT \\* FROM `totp_credentials`").WillReturnError(gorm.ErrRecordNotFound)
+			},
+			wantError: gorm.ErrRecordNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newDBWithRegexp(t)
+			r := repo.NewMFARepository(db, nil)
+			tt.prepare(mock)
+
+			credential, err := r.GetTOTP(ctx, userID)
+			if tt.wantError != nil {
+				require.ErrorIs(t, err, tt.wantError)
+				require.Nil(t, credential)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, userID, credential.UserID)
+				require.Equal(t, int64(10), credential.LastUsedStep)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestMFASaveTOTP_TableDriven(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name       string
+		credential *domain.TOTPCredential
+		prepare    func(sqlmock.Sqlmock)
+		wantError  bool
+	}{
+		{
+			name:       "insert",
+			credential: &domain.TOTPCredential{UserID: uuid.New(), Secret: []byte("sealed")},
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("INSERT INTO `totp_credentials`").WillReturnResult(sqlmock.NewResult(1, 1))
+				m.ExpectCommit()
+			},
+		},
+		{
+			name:       "update",
+			credential: &domain.TOTPCredential{ID: uuid.New(), UserID: uuid.New(), Secret: []byte("sealed")},
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("UPDATE `totp_credentials` SET").WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectCommit()
+			},
+		},
+		{
+			name:       "db error",
+			credential: &domain.TOTPCredential{UserID: uuid.New(), Secret: []byte("sealed")},
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("INSERT INTO `totp_credentials`").WillReturnError(errors.New("db error"))
+				m.ExpectRollback()
+			},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newDBWithRegexp(t)
+			r := repo.NewMFARepository(db, nil)
+			tt.prepare(mock)
+
+			err := r.SaveTOTP(ctx, tt.credential)
+			if tt.wantError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.NotEqual(t, uuid.Nil, tt.credential.ID)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestMFAConfirmTOTP_TableDriven(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.New()
+	confirmedAt := time.Now()
+
+	tests := []struct {
+		name        string
+		prepare     func(sqlmock.Sqlmock)
+		wantUpdated int64
+		wantError   bool
+	}{
+		{
+			name: "confirmed",
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("UPDATE `totp_credentials` SET `confirmed_at`=\\?,`last_used_step`=\\?,`updated_at`=\\? WHERE id = \\? AND confirmed_at IS NULL").
+					WithArgs(confirmedAt, int64(42), sqlmock.AnyArg(), id).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectCommit()
+			},
+			wantUpdated: 1,
+		},
+		{
+			name: "already confirmed",
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("UPDATE `totp_credentials`").WillReturnResult(sqlmock.NewResult(0, 0))
+				m.ExpectCommit()
+			},
+		},
+		{
+			name: "db error",
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("UPDATE `totp_credentials`").WillReturnError(errors.New("db error"))
+				m.ExpectRollback()
+			},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newDBWithRegexp(t)
+			r := repo.NewMFARepository(db, nil)
+			tt.prepare(mock)
+
+			updated, err := r.ConfirmTOTP(ctx, id, 42, confirmedAt)
+			if tt.wantError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.wantUpdated, updated)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestMFAUseTOTPStep_TableDriven(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.New()
+
+	tests := []struct {
+		name        string
+		prepare     func(sqlmock.Sqlmock)
+		wantUpdated int64
+		wantError   bool
+	}{
+		{
+			name: "newer step",
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("UPDATE `totp_credentials` SET `last_used_step`=\\?,`updated_at`=\\? WHERE id = \\? AND last_used_step < \\?").
+					WithArgs(int64(42), sqlmock.AnyArg(), id, int64(42)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectCommit()
+			},
+			wantUpdated: 1,
+		},
+		{
+			name: "replayed step",
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("UPDATE `totp_credentials`").WillReturnResult(sqlmock.NewResult(0, 0))
+				m.ExpectCommit()
+			},
+		},
+		{
+			name: "db error",
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("UPDATE `totp_credentials`").WillReturnError(errors.New("db error"))
+				m.ExpectRollback()
+			},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newDBWithRegexp(t)
+			r := repo.NewMFARepository(db, nil)
+			tt.prepare(mock)
+
+			updated, err := r.UseTOTPStep(ctx, id, 42)
+			if tt.wantError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.wantUpdated, updated)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestMFADeleteTOTP_TableDriven(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	tests := []struct {
+		name      string
+		prepare   func(sqlmock.Sqlmock)
+		wantError bool
+	}{
+		{
+			name: "deleted",
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("DELETE FROM `totp_credentials` WHERE user_id = \\?").
+					WithArgs(userID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectCommit()
+			},
+		},
+		{
+			name: "db error",
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("DELETE FROM `totp_credentials`").WillReturnError(errors.New("db error"))
+				m.ExpectRollback()
+			},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newDBWithRegexp(t)
+			r := repo.NewMFARepository(db, nil)
+			tt.prepare(mock)
+
+			err := r.DeleteTOTP(ctx, userID)
+			if tt.wantError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestMFAReplaceRecoveryCodes_TableDriven(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	tests := []struct {
+		name      string
+		codes     []domain.RecoveryCode
+		prepare   func(sqlmock.Sqlmock)
+		wantError bool
+	}{
+		{
+			name:  "replaced",
+			codes: []domain.RecoveryCode{{UserID: userID, CodeHash: "a"}, {UserID: userID, CodeHash: "b"}},
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("DELETE FROM `recovery_codes` WHERE user_id = \\?").
+					WithArgs(userID).
+					WillReturnResult(sqlmock.NewResult(0, 10))
+				m.ExpectCommit()
+				m.ExpectBegin()
+				m.ExpectExec("INSERT INTO `recovery_codes`").WillReturnResult(sqlmock.NewResult(0, 2))
+				m.ExpectCommit()
+			},
+		},
+		{
+			name: "cleared",
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("DELETE FROM `recovery_codes`").WillReturnResult(sqlmock.NewResult(0, 10))
+				m.ExpectCommit()
+			},
+		},
+		{
+			name:  "delete error",
+			codes: []domain.RecoveryCode{{UserID: userID, CodeHash: "a"}},
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("DELETE FROM `recovery_codes`").WillReturnError(errors.New("db error"))
+				m.ExpectRollback()
+			},
+			wantError: true,
+		},
+		{
+			name:  "insert error",
+			codes: []domain.RecoveryCode{{UserID: userID, CodeHash: "a"}},
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("DELETE FROM `recovery_codes`").WillReturnResult(sqlmock.NewResult(0, 0))
+				m.ExpectCommit()
+				m.ExpectBegin()
+				m.ExpectExec("INSERT INTO `recovery_codes`").WillReturnError(errors.New("db error"))
+				m.ExpectRollback()
+			},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newDBWithRegexp(t)
+			r := repo.NewMFARepository(db, nil)
+			tt.prepare(mock)
+
+			err := r.ReplaceRecoveryCodes(ctx, userID, tt.codes)
+			if tt.wantError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestMFAUseRecoveryCode_TableDriven(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	usedAt := time.Now()
+
+	tests := []struct {
+		name        string
+		prepare     func(sqlmock.Sqlmock)
+		wantUpdated int64
+		wantError   bool
+	}{
+		{
+			name: "used",
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("UPDATE `recovery_codes` SET `used_at`=\\? WHERE user_id = \\? AND code_hash = \\? AND used_at IS NULL").
+					WithArgs(usedAt, userID, "hash").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectCommit()
+			},
+			wantUpdated: 1,
+		},
+		{
+			name: "unknown or used",
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("UPDATE `recovery_codes`").WillReturnResult(sqlmock.NewResult(0, 0))
+				m.ExpectCommit()
+			},
+		},
+		{
+			name: "db error",
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("UPDATE `recovery_codes`").WillReturnError(errors.New("db error"))
+				m.ExpectRollback()
+			},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newDBWithRegexp(t)
+			r := repo.NewMFARepository(db, nil)
+			tt.prepare(mock)
+
+			updated, err := r.UseRecoveryCode(ctx, userID, "hash", usedAt)
+			if tt.wantError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.wantUpdated, updated)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestMFACountUnusedRecoveryCodes_TableDriven(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	tests := []struct {
+		name      string
+		prepare   func(sqlmock.Sqlmock)
+		wantCount int64
+		wantError bool
+	}{
+		{
+			name: "counted",
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT count\\(\\*\\) FROM `recovery_codes` WHERE user_id = \\? AND used_at IS NULL").
+					WithArgs(userID).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(7))
+			},
+			wantCount: 7,
+		},
+		{
+			name: "db error",
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT count").WillReturnError(errors.New("db error"))
+			},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newDBWithRegexp(t)
+			r := repo.NewMFARepository(db, nil)
+			tt.prepare(mock)
+
+			count, err := r.CountUnusedRecoveryCodes(ctx, userID)
+			if tt.wantError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.wantCount, count)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestMFADeleteRecoveryCodes_TableDriven(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	tests := []struct {
+		name      string
+		prepare   func(sqlmock.Sqlmock)
+		wantError bool
+	}{
+		{
+			name: "deleted",
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("DELETE FROM `recovery_codes` WHERE user_id = \\?").
+					WithArgs(userID).
+					WillReturnResult(sqlmock.NewResult(0, 10))
+				m.ExpectCommit()
+			},
+		},
+		{
+			name: "db error",
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("DELETE FROM `recovery_codes`").WillReturnError(errors.New("db error"))
+				m.ExpectRollback()
+			},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newDBWithRegexp(t)
+			r := repo.NewMFARepository(db, nil)
+			tt.prepare(mock)
+
+			err := r.DeleteRecoveryCodes(ctx, userID)
+			if tt.wantError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
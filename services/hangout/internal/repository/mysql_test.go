@@ -0,0 +1,61 @@
+package repository_test
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	mysqldriver "github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+// newMySQLDB creates a fresh database on the server in TEST_MYSQL_DSN and
+// applies the migrations to it. The test is skipped when the variable is
+// unset.
+func newMySQLDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	dsn := os.Getenv("TEST_MYSQL_DSN")
+	if dsn == "" {
+		t.Skip("TEST_MYSQL_DSN is not set")
+	}
+
+	cfg, err := mysqldriver.ParseDSN(dsn)
+	require.NoError(t, err)
+	cfg.DBName = ""
+	admin, err := sql.Open("mysql", cfg.FormatDSN())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = admin.Close() })
+
+	name := "hangout_test_" + strings.ToLower(strings.ReplaceAll(t.Name(), "/", "_"))
+	_, err = admin.Exec("DROP DATABASE IF EXISTS `" + name + "`")
+	require.NoError(t, err)
+	_, err = admin.Exec("CREATE DATABASE `" + name + "`")
+	require.NoError(t, err)
+	t.Cleanup(func() { _, _ = admin.Exec("DROP DATABASE IF EXISTS `" + name + "`") })
+
+	cfg.DBName = name
+	cfg.ParseTime = true
+	cfg.MultiStatements = true
+	sqlDB, err := sql.Open("mysql", cfg.FormatDSN())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	files, err := filepath.Glob(filepath.Join("..", "..", "migrations", "*.sql"))
+	require.NoError(t, err)
+	sort.Strings(files)
+	for _, file := range files {
+		migration, err := os.ReadFile(file)
+		require.NoError(t, err)
+		_, err = sqlDB.Exec(string(migration))
+		require.NoError(t, err, filepath.Base(file))
+	}
+
+	db, err := gorm.Open(mysql.New(mysql.Config{Conn: sqlDB}), &gorm.Config{})
+	require.NoError(t, err)
+	return db
+}
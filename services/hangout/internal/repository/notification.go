@@ -0,0 +1,388 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/otel"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type NotificationRepository interface {
+	CreateNotification(ctx context.Context, notification *domain.Notification) error
+	GetNotificationByID(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*domain.Notification, error)
+	GetNotificationsByUserID(ctx context.Context, userID uuid.UUID, unreadOnly bool, pagination *dto.CursorPagination) ([]domain.Notification, error)
+	SetReadAt(ctx context.Context, id uuid.UUID, readAt *time.Time) error
+	MarkAllRead(ctx context.Context, userID uuid.UUID, readAt time.Time) (int64, error)
+	CountUnread(ctx context.Context, userID uuid.UUID) (int64, error)
+
+	GetPreferencesByUserID(ctx context.Context, userID uuid.UUID) ([]domain.NotificationPreference, error)
+	GetPreference(ctx context.Context, userID uuid.UUID, notificationType string) (*domain.NotificationPreference, error)
+	SavePreferences(ctx context.Context, preferences []domain.NotificationPreference) error
+
+	QueueEmail(ctx context.Context, email *domain.NotificationEmail) error
+	GetDueEmails(ctx context.Context, now time.Time, limit int) ([]domain.NotificationEmail, error)
+	ClaimEmail(ctx context.Context, id uuid.UUID, dueAt time.Time, leaseUntil time.Time) (bool, error)
+	UpdateEmail(ctx context.Context, email *domain.NotificationEmail) error
+}
+
+type notificationRepository struct {
+	db      *gorm.DB
+	metrics *otel.MetricsRecorder
+}
+
+func NewNotificationRepository(db *gorm.DB, metrics *otel.MetricsRecorder) NotificationRepository {
+	return &notificationRepository{db: db, metrics: metrics}
+}
+
+func (r *notificationRepository) CreateNotification(ctx context.Context, notification *domain.Notification) error {
+	ctx, span := otel.StartRepositorySpan(ctx, "CreateNotification",
+		attribute.String("db.operation", "insert"),
+		attribute.String("db.table", "notifications"),
+		attribute.String("user.id", notification.UserID.String()),
+	)
+	defer span.End()
+
+	start := time.Now()
+	err := r.db.WithContext(ctx).Create(notification).Error
+	r.metrics.RecordDBOperation(ctx, "insert", "notifications", time.Since(start), 1)
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+		return err
+	}
+
+	span.SetStatusOk()
+	return nil
+}
+
+func (r *notificationRepository) GetNotificationByID(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*domain.Notification, error) {
+	ctx, span := otel.StartRepositorySpan(ctx, "GetNotificationByID",
+		attribute.String("db.operation", "select"),
+		attribute.String("db.table", "notifications"),
+		attribute.String("notification.id", id.String()),
+		attribute.String("user.id", userID.String()),
+	)
+	defer span.End()
+
+	var notification domain.Notification
+
+	start := time.Now()
+	err := r.db.WithContext(ctx).First(&notification, "id = ? AND user_id = ?", id, userID).Error
+	r.metrics.RecordDBOperation(ctx, "select", "notifications", time.Since(start), 1)
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetStatusOk()
+	return &notification, nil
+}
+
+// GetNotificationsByUserID returns the user's notifications newest first,
+// fetching one extra row so the caller can tell whether there are more.
+func (r *notificationRepository) GetNotificationsByUserID(ctx context.Context, userID uuid.UUID, unreadOnly bool, pagination *dto.CursorPagination) ([]domain.Notification, error) {
+	ctx, span := otel.StartRepositorySpan(ctx, "GetNotificationsByUserID",
+		attribute.String("db.operation", "select"),
+		attribute.String("db.table", "notifications"),
+		attribute.String("user.id", userID.String()),
+		attribute.Bool("notification.unread_only", unreadOnly),
+		attribute.Int("pagination.limit", pagination.GetLimit()),
+	)
+	defer span.End()
+
+	start := time.Now()
+	var notifications []domain.Notification
+
+	query := r.db.WithContext(ctx).Model(&domain.Notification{}).
+		Where("user_id = ?", userID)
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+
+	if pagination.AfterID != nil {
+		var cursorItem domain.Notification
+		if err := r.db.WithContext(ctx).First(&cursorItem, "id = ? AND user_id = ?", *pagination.AfterID, userID).Error; err != nil {
+			return nil, apperrors.ErrInvalidCursorPagination
+		}
+
+		query = query.Where(
+			"(created_at < ?) OR (created_at = ? AND id < ?)",
+			cursorItem.CreatedAt, cursorItem.CreatedAt, cursorItem.ID,
+		)
+	}
+
+	err := query.
+		Order("created_at desc, id desc").
+		Limit(pagination.GetLimit() + 1).
+		Find(&notifications).Error
+	r.metrics.RecordDBOperation(ctx, "select", "notifications", time.Since(start), len(notifications))
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("notification.count", len(notifications)))
+	span.SetStatusOk()
+	return notifications, nil
+}
+
+// SetReadAt marks the notification read at readAt, or unread when readAt is nil.
+func (r *notificationRepository) SetReadAt(ctx context.Context, id uuid.UUID, readAt *time.Time) error {
+	ctx, span := otel.StartRepositorySpan(ctx, "SetReadAt",
+		attribute.String("db.operation", "update"),
+		attribute.String("db.table", "notifications"),
+		attribute.String("notification.id", id.String()),
+	)
+	defer span.End()
+
+	start := time.Now()
+	result := r.db.WithContext(ctx).Model(&domain.Notification{}).
+		Where("id = ?", id).
+		Update("read_at", readAt)
+	r.metrics.RecordDBOperation(ctx, "update", "notifications", time.Since(start), int(result.RowsAffected))
+
+	if result.Error != nil {
+		_ = span.RecordErrorWithStatus(result.Error)
+		return result.Error
+	}
+
+	span.SetStatusOk()
+	return nil
+}
+
+func (r *notificationRepository) MarkAllRead(ctx context.Context, userID uuid.UUID, readAt time.Time) (int64, error) {
+	ctx, span := otel.StartRepositorySpan(ctx, "MarkAllRead",
+		attribute.String("db.operation", "update"),
+		attribute.String("db.table", "notifications"),
+		attribute.String("user.id", userID.String()),
+	)
+	defer span.End()
+
+	start := time.Now()
+	result := r.db.WithContext(ctx).Model(&domain.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Update("read_at", readAt)
+	r.metrics.RecordDBOperation(ctx, "update", "notifications", time.Since(start), int(result.RowsAffected))
+
+	if result.Error != nil {
+		_ = span.RecordErrorWithStatus(result.Error)
+		return 0, result.Error
+	}
+
+	span.SetAttributes(attribute.Int64("notification.updated_count", result.RowsAffected))
+	span.SetStatusOk()
+	return result.RowsAffected, nil
+}
+
+func (r *notificationRepository) CountUnread(ctx context.Context, userID uuid.UUID) (int64, error) {
+	ctx, span := otel.StartRepositorySpan(ctx, "CountUnread",
+		attribute.String("db.operation", "select"),
+		attribute.String("db.table", "notifications"),
+		attribute.String("user.id", userID.String()),
+	)
+	defer span.End()
+
+	var count int64
+
+	start := time.Now()
+	err := r.db.WithContext(ctx).Model(&domain.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Count(&count).Error
+	r.metrics.RecordDBOperation(ctx, "select", "notifications", time.Since(start), 1)
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+		return 0, err
+	}
+
+	span.SetStatusOk()
+	return count, nil
+}
+
+func (r *notificationRepository) GetPreferencesByUserID(ctx context.Context, userID uuid.UUID) ([]domain.NotificationPreference, error) {
+	ctx, span := otel.StartRepositorySpan(ctx, "GetPreferencesByUserID",
+		attribute.String("db.operation", "select"),
+		attribute.String("db.table", "notification_preferences"),
+		attribute.String("user.id", userID.String()),
+	)
+	defer span.End()
+
+	start := time.Now()
+	var preferences []domain.NotificationPreference
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).Find(&preferences).Error
+	r.metrics.RecordDBOperation(ctx, "select", "notification_preferences", time.Since(start), len(preferences))
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetStatusOk()
+	return preferences, nil
+}
+
+func (r *notificationRepository) GetPreference(ctx context.Context, userID uuid.UUID, notificationType string) (*domain.NotificationPreference, error) {
+	ctx, span := otel.StartRepositorySpan(ctx, "GetPreference",
+		attribute.String("db.operation", "select"),
+		attribute.String("db.table", "notification_preferences"),
+		attribute.String("user.id", userID.String()),
+		attribute.String("notification.type", notificationType),
+	)
+	defer span.End()
+
+	var preference domain.NotificationPreference
+
+	start := time.Now()
+	err := r.db.WithContext(ctx).First(&preference, "user_id = ? AND type = ?", userID, notificationType).Error
+	r.metrics.RecordDBOperation(ctx, "select", "notification_preferences", time.Since(start), 1)
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetStatusOk()
+	return &preference, nil
+}
+
+// SavePreferences inserts the preferences or overwrites the existing ones for
+// the same user and type.
+func (r *notificationRepository) SavePreferences(ctx context.Context, preferences []domain.NotificationPreference) error {
+	if len(preferences) == 0 {
+		return nil
+	}
+
+	ctx, span := otel.StartRepositorySpan(ctx, "SavePreferences",
+		attribute.String("db.operation", "upsert"),
+		attribute.String("db.table", "notification_preferences"),
+		attribute.Int("notification.preference_count", len(preferences)),
+	)
+	defer span.End()
+
+	start := time.Now()
+	err := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoUpdates: clause.AssignmentColumns([]string{"email", "inbox", "updated_at"})}).
+		Create(&preferences).Error
+	r.metrics.RecordDBOperation(ctx, "upsert", "notification_preferences", time.Since(start), len(preferences))
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+		return err
+	}
+
+	span.SetStatusOk()
+	return nil
+}
+
+func (r *notificationRepository) QueueEmail(ctx context.Context, email *domain.NotificationEmail) error {
+	ctx, span := otel.StartRepositorySpan(ctx, "QueueNotificationEmail",
+		attribute.String("db.operation", "insert"),
+		attribute.String("db.table", "notification_emails"),
+		attribute.String("user.id", email.UserID.String()),
+	)
+	defer span.End()
+
+	start := time.Now()
+	err := r.db.WithContext(ctx).Create(email).Error
+	r.metrics.RecordDBOperation(ctx, "insert", "notification_emails", time.Since(start), 1)
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+		return err
+	}
+
+	span.SetStatusOk()
+	return nil
+}
+
+// GetDueEmails returns pending notification emails whose next attempt is due, with
+// their recipient loaded.
+func (r *notificationRepository) GetDueEmails(ctx context.Context, now time.Time, limit int) ([]domain.NotificationEmail, error) {
+	ctx, span := otel.StartRepositorySpan(ctx, "GetDueNotificationEmails",
+		attribute.String("db.operation", "select"),
+		attribute.String("db.table", "notification_emails"),
+		attribute.Int("batch.limit", limit),
+	)
+	defer span.End()
+
+	start := time.Now()
+	var emails []domain.NotificationEmail
+
+	err := r.db.WithContext(ctx).
+		Preload("User").
+		Where("status = ? AND next_attempt_at <= ?", constants.ReminderPending, now).
+		Order("next_attempt_at asc").
+		Limit(limit).
+		Find(&emails).Error
+	r.metrics.RecordDBOperation(ctx, "select", "notification_emails", time.Since(start), len(emails))
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("notification_email.count", len(emails)))
+	span.SetStatusOk()
+	return emails, nil
+}
+
+// ClaimEmail pushes a due email's next attempt to leaseUntil so other instances
+// skip it while it is being sent.
+func (r *notificationRepository) ClaimEmail(ctx context.Context, id uuid.UUID, dueAt time.Time, leaseUntil time.Time) (bool, error) {
+	ctx, span := otel.StartRepositorySpan(ctx, "ClaimNotificationEmail",
+		attribute.String("db.operation", "update"),
+		attribute.String("db.table", "notification_emails"),
+		attribute.String("notification_email.id", id.String()),
+	)
+	defer span.End()
+
+	start := time.Now()
+	result := r.db.WithContext(ctx).Model(&domain.NotificationEmail{}).
+		Where("id = ? AND status = ? AND next_attempt_at = ?", id, constants.ReminderPending, dueAt).
+		Update("next_attempt_at", leaseUntil)
+	r.metrics.RecordDBOperation(ctx, "update", "notification_emails", time.Since(start), int(result.RowsAffected))
+
+	if result.Error != nil {
+		_ = span.RecordErrorWithStatus(result.Error)
+		return false, result.Error
+	}
+
+	claimed := result.RowsAffected > 0
+	span.SetAttributes(attribute.Bool("notification_email.claimed", claimed))
+	span.SetStatusOk()
+	return claimed, nil
+}
+
+func (r *notificationRepository) UpdateEmail(ctx context.Context, email *domain.NotificationEmail) error {
+	ctx, span := otel.StartRepositorySpan(ctx, "UpdateNotificationEmail",
+		attribute.String("db.operation", "update"),
+		attribute.String("db.table", "notification_emails"),
+		attribute.String("notification_email.id", email.ID.String()),
+		attribute.String("notification_email.status", email.Status),
+	)
+	defer span.End()
+
+	start := time.Now()
+	err := r.db.WithContext(ctx).Model(email).
+		Select("status", "attempts", "next_attempt_at", "last_error", "sent_at", "updated_at").
+		Updates(email).Error
+	r.metrics.RecordDBOperation(ctx, "update", "notification_emails", time.Since(start), 1)
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+		return err
+	}
+
+	span.SetStatusOk()
+	return nil
+}
@@ -0,0 +1,359 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
+	repo "github.com/Ernestgio/Hangout-Planner/services/hangout/internal/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestNotificationCreateNotification_TableDriven(t *testing.T) {
+	ctx := context.Background()
+	dbErr := errors.New("db error")
+
+	tests := []struct {
+		name    string
+		execErr error
+	}{
+		{name: "success"},
+		{name: "db error", execErr: dbErr},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newDBWithRegexp(t)
+			r := repo.NewNotificationRepository(db, nil)
+			hangoutID := uuid.New()
+			notification := &domain.Notification{
+				Type:      constants.NotificationHangoutReminder,
+				Title:     "Reminder: Picnic is coming up",
+				HangoutID: &hangoutID,
+				UserID:    uuid.New(),
+			}
+
+			mock.ExpectBegin()
+			exec := mock.ExpectExec("INSERT INTO `notifications`")
+			if tt.execErr != nil {
+				exec.WillReturnError(tt.execErr)
+				mock.ExpectRollback()
+			} else {
+				exec.WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			}
+
+			err := r.CreateNotification(ctx, notification)
+			if tt.execErr != nil {
+				require.ErrorIs(t, err, tt.execErr)
+			} else {
+				require.NoError(t, err)
+				require.NotEqual(t, uuid.Nil, notification.ID)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestNotificationGetNotificationsByUserID_TableDriven(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	tests := []struct {
+		name       string
+		unreadOnly bool
+		query      string
+	}{
+		{
+			name:  "all notifications",
+			query: "SELECT \\* FROM `notifications` WHERE user_id = \\? ORDER BY created_at desc, id desc LIMIT \\?",
+		},
+		{
+			name:       "unread only",
+			unreadOnly: true,
+			query:      "SELECT \\* FROM `notifications` WHERE user_id = \\? AND read_at IS NULL ORDER BY created_at desc, id desc LIMIT \\?",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newDBWithRegexp(t)
+			r := repo.NewNotificationRepository(db, nil)
+
+			mock.ExpectQuery(tt.query).
+				WithArgs(userID, 3).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "type", "user_id"}).AddRow(uuid.New(), constants.NotificationStatusChanged, userID))
+
+			notifications, err := r.GetNotificationsByUserID(ctx, userID, tt.unreadOnly, &dto.CursorPagination{Limit: 2})
+			require.NoError(t, err)
+			require.Len(t, notifications, 1)
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestNotificationGetNotificationsByUserID_Cursor(t *testing.T) {
+	ctx := context.Background()
+	db, mock := newDBWithRegexp(t)
+	r := repo.NewNotificationRepository(db, nil)
+	userID, afterID := uuid.New(), uuid.New()
+	createdAt := time.Now()
+
+	mock.ExpectQuery("SELECT \\* FROM `notifications` WHERE id = \\? AND user_id = \\?").
+		WithArgs(afterID, userID, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "user_id"}).AddRow(afterID, createdAt, userID))
+	mock.ExpectQuery("SELECT \\* FROM `notifications` WHERE user_id = \\? AND \\(\\(created_at < \\?\\) OR \\(created_at = \\? AND id < \\?\\)\\) ORDER BY created_at desc, id desc LIMIT \\?").
+		WithArgs(userID, createdAt, createdAt, afterID, constants.DefaultLimit+1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	notifications, err := r.GetNotificationsByUserID(ctx, userID, false, &dto.CursorPagination{AfterID: &afterID})
+	require.NoError(t, err)
+	require.Empty(t, notifications)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestNotificationGetNotificationsByUserID_InvalidCursor(t *testing.T) {
+	ctx := context.Background()
+	db, mock := newDBWithRegexp(t)
+	r := repo.NewNotificationRepository(db, nil)
+	userID, afterID := uuid.New(), uuid.New()
+
+	mock.ExpectQuery("SELECT \\* FROM `notifications` WHERE id = \\? AND user_id = \\?").
+		WithArgs(afterID, userID, 1).
+		WillReturnError(gorm.ErrRecordNotFound)
+
+	_, err := r.GetNotificationsByUserID(ctx, userID, false, &dto.CursorPagination{AfterID: &afterID})
+	require.ErrorIs(t, err, apperrors.ErrInvalidCursorPagination)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestNotificationGetNotificationByID(t *testing.T) {
+	ctx := context.Background()
+	db, mock := newDBWithRegexp(t)
+	r := repo.NewNotificationRepository(db, nil)
+	id, userID := uuid.New(), uuid.New()
+
+	mock.ExpectQuery("SELECT \\* FROM `notifications` WHERE id = \\? AND user_id = \\?").
+		WithArgs(id, userID, 1).
+		WillReturnError(gorm.ErrRecordNotFound)
+
+	_, err := r.GetNotificationByID(ctx, id, userID)
+	require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestNotificationSetReadAt_TableDriven(t *testing.T) {
+	ctx := context.Background()
+	readAt := time.Now()
+
+	tests := []struct {
+		name   string
+		readAt *time.Time
+	}{
+		{name: "mark read", readAt: &readAt},
+		{name: "mark unread"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newDBWithRegexp(t)
+			r := repo.NewNotificationRepository(db, nil)
+			id := uuid.New()
+
+			mock.ExpectBegin()
+			mock.ExpectExec("UPDATE `notifications` SET `read_at`=\\? WHERE id = \\?").
+				WithArgs(tt.readAt, id).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectCommit()
+
+			require.NoError(t, r.SetReadAt(ctx, id, tt.readAt))
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestNotificationMarkAllRead(t *testing.T) {
+	ctx := context.Background()
+	db, mock := newDBWithRegexp(t)
+	r := repo.NewNotificationRepository(db, nil)
+	userID := uuid.New()
+	readAt := time.Now()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE `notifications` SET `read_at`=\\? WHERE user_id = \\? AND read_at IS NULL").
+		WithArgs(readAt, userID).
+		WillReturnResult(sqlmock.NewResult(0, 4))
+	mock.ExpectCommit()
+
+	updated, err := r.MarkAllRead(ctx, userID, readAt)
+	require.NoError(t, err)
+	require.Equal(t, int64(4), updated)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestNotificationCountUnread(t *testing.T) {
+	ctx := context.Background()
+	db, mock := newDBWithRegexp(t)
+	r := repo.NewNotificationRepository(db, nil)
+	userID := uuid.New()
+
+	mock.ExpectQuery("SELECT count\\(\\*\\) FROM `notifications` WHERE user_id = \\? AND read_at IS NULL").
+		WithArgs(userID).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(7))
+
+	count, err := r.CountUnread(ctx, userID)
+	require.NoError(t, err)
+	require.Equal(t, int64(7), count)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestNotificationGetPreferences(t *testing.T) {
+	ctx := context.Background()
+	db, mock := newDBWithRegexp(t)
+	r := repo.NewNotificationRepository(db, nil)
+	userID := uuid.New()
+
+	mock.ExpectQuery("SELECT \\* FROM `notification_preferences` WHERE user_id = \\?").
+		WithArgs(userID).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "type", "email", "inbox"}).AddRow(userID, constants.NotificationMemoryCreated, true, false))
+	mock.ExpectQuery("SELECT \\* FROM `notification_preferences` WHERE user_id = \\? AND type = \\?").
+		WithArgs(userID, constants.NotificationHangoutReminder, 1).
+		WillReturnError(gorm.ErrRecordNotFound)
+
+	preferences, err := r.GetPreferencesByUserID(ctx, userID)
+	require.NoError(t, err)
+	require.Equal(t, []domain.NotificationPreference{{UserID: userID, Type: constants.NotificationMemoryCreated, Email: true}}, preferences)
+
+	_, err = r.GetPreference(ctx, userID, constants.NotificationHangoutReminder)
+	require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestNotificationSavePreferences(t *testing.T) {
+	ctx := context.Background()
+	db, mock := newDBWithRegexp(t)
+	r := repo.NewNotificationRepository(db, nil)
+	userID := uuid.New()
+	preferences := []domain.NotificationPreference{
+		{UserID: userID, Type: constants.NotificationMemoryCreated, Email: false, Inbox: true},
+		{UserID: userID, Type: constants.NotificationStatusChanged, Email: true, Inbox: false},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO `notification_preferences` \\(`user_id`,`type`,`email`,`inbox`,`updated_at`\\) VALUES \\(\\?,\\?,\\?,\\?,\\?\\),\\(\\?,\\?,\\?,\\?,\\?\\) ON DUPLICATE KEY UPDATE `email`=VALUES\\(`email`\\),`inbox`=VALUES\\(`inbox`\\),`updated_at`=VALUES\\(`updated_at`\\)").
+		WithArgs(userID, constants.NotificationMemoryCreated, false, true, AnyTime{}, userID, constants.NotificationStatusChanged, true, false, AnyTime{}).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	require.NoError(t, r.SavePreferences(ctx, preferences))
+	require.NoError(t, r.SavePreferences(ctx, nil))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestNotificationQueueEmail(t *testing.T) {
+	ctx := context.Background()
+	db, mock := newDBWithRegexp(t)
+	r := repo.NewNotificationRepository(db, nil)
+	now := time.Now()
+	email := &domain.NotificationEmail{
+		Type:          constants.NotificationMemoryCreated,
+		Subject:       "New memory in Picnic",
+		Body:          "beach.jpg was added to Picnic.",
+		Status:        constants.ReminderPending,
+		NextAttemptAt: &now,
+		UserID:        uuid.New(),
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO `notification_emails`").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	require.NoError(t, r.QueueEmail(ctx, email))
+	require.NotEqual(t, uuid.Nil, email.ID)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestNotificationGetDueEmails(t *testing.T) {
+	ctx := context.Background()
+	db, mock := newDBWithRegexp(t)
+	r := repo.NewNotificationRepository(db, nil)
+	now := time.Now()
+	userID := uuid.New()
+
+	mock.ExpectQuery("SELECT \\* FROM `notification_emails` WHERE status = \\? AND next_attempt_at <= \\? ORDER BY next_attempt_at asc LIMIT \\?").
+		WithArgs(constants.ReminderPending, now, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "status", "user_id"}).AddRow(uuid.New(), constants.ReminderPending, userID))
+	mock.ExpectQuery("SELECT \\* FROM `users` WHERE `users`.`id` = \\? AND `users`.`deleted_at` IS NULL").
+		WithArgs(userID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "email"}).AddRow(userID, "ann@example.com"))
+
+	emails, err := r.GetDueEmails(ctx, now, 10)
+	require.NoError(t, err)
+	require.Len(t, emails, 1)
+	require.Equal(t, "ann@example.com", emails[0].User.Email)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestNotificationClaimEmail_TableDriven(t *testing.T) {
+	ctx := context.Background()
+	dueAt := time.Now()
+	leaseUntil := dueAt.Add(time.Minute)
+
+	tests := []struct {
+		name        string
+		rows        int64
+		wantClaimed bool
+	}{
+		{name: "claimed", rows: 1, wantClaimed: true},
+		{name: "claimed by another instance", rows: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newDBWithRegexp(t)
+			r := repo.NewNotificationRepository(db, nil)
+			id := uuid.New()
+
+			mock.ExpectBegin()
+			mock.ExpectExec("UPDATE `notification_emails` SET `next_attempt_at`=\\?,`updated_at`=\\? WHERE id = \\? AND status = \\? AND next_attempt_at = \\?").
+				WithArgs(leaseUntil, AnyTime{}, id, constants.ReminderPending, dueAt).
+				WillReturnResult(sqlmock.NewResult(0, tt.rows))
+			mock.ExpectCommit()
+
+			claimed, err := r.ClaimEmail(ctx, id, dueAt, leaseUntil)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantClaimed, claimed)
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestNotificationUpdateEmail(t *testing.T) {
+	ctx := context.Background()
+	db, mock := newDBWithRegexp(t)
+	r := repo.NewNotificationRepository(db, nil)
+	now := time.Now()
+	email := &domain.NotificationEmail{
+		ID:       uuid.New(),
+		Status:   constants.ReminderSent,
+		Attempts: 1,
+		SentAt:   &now,
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE `notification_emails` SET `status`=\\?,`attempts`=\\?,`next_attempt_at`=\\?,`last_error`=\\?,`sent_at`=\\?,`updated_at`=\\? WHERE `id` = \\?").
+		WithArgs(constants.ReminderSent, 1, nil, "", now, AnyTime{}, email.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	require.NoError(t, r.UpdateEmail(ctx, email))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/otel"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"gorm.io/gorm"
+)
+
+type PersonalAccessTokenRepository interface {
+	Create(ctx context.Context, token *domain.PersonalAccessToken) error
+	GetByHash(ctx context.Context, tokenHash string) (*domain.PersonalAccessToken, error)
+	ListByUserID(ctx context.Context, userID uuid.UUID) ([]domain.PersonalAccessToken, error)
+	CountByUserID(ctx context.Context, userID uuid.UUID) (int64, error)
+	Touch(ctx context.Context, id uuid.UUID, at time.Time) error
+	DeleteByUserAndID(ctx context.Context, userID uuid.UUID, id uuid.UUID) (int64, error)
+}
+
+type personalAccessTokenRepository struct {
+	db      *gorm.DB
+	metrics *otel.MetricsRecorder
+}
+
+func NewPersonalAccessTokenRepository(db *gorm.DB, metrics *otel.MetricsRecorder) PersonalAccessTokenRepository {
+	return &personalAccessTokenRepository{db: db, metrics: metrics}
+}
+
+func (r *personalAccessTokenRepository) Create(ctx context.Context, token *domain.PersonalAccessToken) error {
+	ctx, span := otel.StartRepositorySpan(ctx, "Create",
+		attribute.String("db.operation", "insert"),
+		attribute.String("db.table", "personal_access_tokens"),
+		attribute.String("user.id", token.UserID.String()),
+	)
+	defer span.End()
+
+	start := time.Now()
+	err := r.db.WithContext(ctx).Create(token).Error
+	r.metrics.RecordDBOperation(ctx, "insert", "personal_access_tokens", time.Since(start), 1)
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+	} else {
+		span.SetStatusOk()
+	}
+	return err
+}
+
+// GetByHash loads the token with its user, so callers can check that the
+// account is still enabled.
+func (r *personalAccessTokenRepository) GetByHash(ctx context.Context, tokenHash string) (*domain.PersonalAccessToken, error) {
+	ctx, span := otel.StartRepositorySpan(ctx, "GetByHash",
+		attribute.String("db.operation", "select"),
+		attribute.String("db.table", "personal_access_tokens"),
+	)
+	defer span.End()
+
+	start := time.Now()
+	var token domain.PersonalAccessToken
+	err := r.db.WithContext(ctx).Preload("User").Where("token_hash = ?", tokenHash).First(&token).Error
+	r.metrics.RecordDBOperation(ctx, "select", "personal_access_tokens", time.Since(start), 1)
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetStatusOk()
+	return &token, nil
+}
+
+// ListByUserID returns the user's tokens, newest first.
+func (r *personalAccessTokenRepository) ListByUserID(ctx context.Context, userID uuid.UUID) ([]domain.PersonalAccessToken, error) {
+	ctx, span := otel.StartRepositorySpan(ctx, "ListByUserID",
+		attribute.String("db.operation", "select"),
+		attribute.String("db.table", "personal_access_tokens"),
+		attribute.String("user.id", userID.String()),
+	)
+	defer span.End()
+
+	start := time.Now()
+	var tokens []domain.PersonalAccessToken
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Find(&tokens).Error
+	r.metrics.RecordDBOperation(ctx, "select", "personal_access_tokens", time.Since(start), len(tokens))
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetStatusOk()
+	return tokens, nil
+}
+
+func (r *personalAccessTokenRepository) CountByUserID(ctx context.Context, userID uuid.UUID) (int64, error) {
+	ctx, span := otel.StartRepositorySpan(ctx, "CountByUserID",
+		attribute.String("db.operation", "select"),
+		attribute.String("db.table", "personal_access_tokens"),
+		attribute.String("user.id", userID.String()),
+	)
+	defer span.End()
+
+	var count int64
+
+	start := time.Now()
+	err := r.db.WithContext(ctx).Model(&domain.PersonalAccessToken{}).Where("user_id = ?", userID).Count(&count).Error
+	r.metrics.RecordDBOperation(ctx, "select", "personal_access_tokens", time.Since(start), 1)
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+		return 0, err
+	}
+
+	span.SetStatusOk()
+	return count, nil
+}
+
+func (r *personalAccessTokenRepository) Touch(ctx context.Context, id uuid.UUID, at time.Time) error {
+	ctx, span := otel.StartRepositorySpan(ctx, "Touch",
+		attribute.String("db.operation", "update"),
+		attribute.String("db.table", "personal_access_tokens"),
+		attribute.String("token.id", id.String()),
+	)
+	defer span.End()
+
+	start := time.Now()
+	result := r.db.WithContext(ctx).Model(&domain.PersonalAccessToken{}).
+		Where("id = ?", id).
+		Update("last_used_at", at)
+	r.metrics.RecordDBOperation(ctx, "update", "personal_access_tokens", time.Since(start), int(result.RowsAffected))
+
+	if result.Error != nil {
+		_ = span.RecordErrorWithStatus(result.Error)
+		return result.Error
+	}
+
+	span.SetStatusOk()
+	return nil
+}
+
+func (r *personalAccessTokenRepository) DeleteByUserAndID(ctx context.Context, userID uuid.UUID, id uuid.UUID) (int64, error) {
+	ctx, span := otel.StartRepositorySpan(ctx, "DeleteByUserAndID",
+		attribute.String("db.operation", "delete"),
+		attribute.String("db.table", "personal_access_tokens"),
+		attribute.String("user.id", userID.String()),
+		attribute.String("token.id", id.String()),
+	)
+	defer span.End()
+
+	start := time.Now()
+	result := r.db.WithContext(ctx).
+		Where("id = ? AND user_id = ?", id, userID).
+		Delete(&domain.PersonalAccessToken{})
+	r.metrics.RecordDBOperation(ctx, "delete", "personal_access_tokens", time.Since(start), int(result.RowsAffected))
+
+	if result.Error != nil {
+		_ = span.RecordErrorWithStatus(result.Error)
+		return 0, result.Error
+	}
+
+	span.SetStatusOk()
+	return result.RowsAffected, nil
+}
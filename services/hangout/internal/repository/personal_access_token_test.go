@@ -0,0 +1,315 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	repo "github.com/Ernestgio/Hangout-Planner/services/hangout/internal/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestPersonalAccessTokenCreate_TableDriven(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		prepare   func(sqlmock.Sqlmock)
+		wantError bool
+	}{
+		{
+			name: "success",
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("INSERT INTO `personal_access_tokens`").WillReturnResult(sqlmock.NewResult(1, 1))
+				m.ExpectCommit()
+			},
+		},
+		{
+			name: "db error",
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("INSERT INTO `personal_access_tokens`").WillReturnError(errors.New("db error"))
+				m.ExpectRollback()
+			},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newDBWithRegexp(t)
+			r := repo.NewPersonalAccessTokenRepository(db, nil)
+			tt.prepare(mock)
+
+			token := &domain.PersonalAccessToken{UserID: uuid.New(), Name: "bot", TokenHash: "hash", Scopes: "hangouts:read"}
+			err := r.Create(ctx, token)
+			if tt.wantError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.NotEqual(t, uuid.Nil, token.ID)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPersonalAccessTokenGetByHash_TableDriven(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	tests := []struct {
+		name      string
+		prepare   func(sqlmock.Sqlmock)
+		wantError error
+	}{
+		{
+			name: "found",
+			prepare: func(m sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "name", "token_hash", "scopes", "user_id"}).
+					AddRow(uuid.New().String(), "bot", "hash", "hangouts:read,hangouts:write", userID.String())
+				m.ExpectQuery("SELECT \\* FROM `personal_access_tokens` WHERE token_hash = \\? ORDER BY `personal_access_tokens`.`id` LIMIT \\?").
+					WithArgs("hash", 1).
+					WillReturnRows(rows)
+				m.ExpectQuery("SELECT \\* FROM `users` WHERE `users`.`id` = \\? AND `users`.`deleted_at` IS NULL").
+					WithArgs(userID.String()).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "email"}).AddRow(userID.String(), "ernest@example.com"))
+			},
+		},
+		{
+			name: "not found",
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT \\* FROM `personal_access_tokens`").WillReturnError(gorm.ErrRecordNotFound)
+			},
+			wantError: gorm.ErrRecordNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newDBWithRegexp(t)
+			r := repo.NewPersonalAccessTokenRepository(db, nil)
+			tt.prepare(mock)
+
+			token, err := r.GetByHash(ctx, "hash")
+			if tt.wantError != nil {
+				require.ErrorIs(t, err, tt.wantError)
+				require.Nil(t, token)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, userID, token.UserID)
+				require.Equal(t, userID, token.User.ID)
+				require.Equal(t, []string{"hangouts:read", "hangouts:write"}, token.ScopeList())
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPersonalAccessTokenListByUserID_TableDriven(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	tests := []struct {
+		name      string
+		prepare   func(sqlmock.Sqlmock)
+		wantCount int
+		wantError bool
+	}{
+		{
+			name: "tokens",
+			prepare: func(m sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "name", "user_id"}).
+					AddRow(uuid.New().String(), "bot", userID.String()).
+					AddRow(uuid.New().String(), "ci", userID.String())
+				m.ExpectQuery("SELECT \\* FROM `personal_access_tokens` WHERE user_id = \\? ORDER BY created_at DESC").
+					WithArgs(userID).
+					WillReturnRows(rows)
+			},
+			wantCount: 2,
+		},
+		{
+			name: "db error",
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT \\* FROM `personal_access_tokens`").WillReturnError(errors.New("db error"))
+			},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newDBWithRegexp(t)
+			r := repo.NewPersonalAccessTokenRepository(db, nil)
+			tt.prepare(mock)
+
+			tokens, err := r.ListByUserID(ctx, userID)
+			if tt.wantError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, tokens, tt.wantCount)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPersonalAccessTokenCountByUserID_TableDriven(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	tests := []struct {
+		name      string
+		prepare   func(sqlmock.Sqlmock)
+		wantCount int64
+		wantError bool
+	}{
+		{
+			name: "count",
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT count\\(\\*\\) FROM `personal_access_tokens` WHERE user_id = \\?").
+					WithArgs(userID).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+			},
+			wantCount: 3,
+		},
+		{
+			name: "db error",
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT count").WillReturnError(errors.New("db error"))
+			},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newDBWithRegexp(t)
+			r := repo.NewPersonalAccessTokenRepository(db, nil)
+			tt.prepare(mock)
+
+			count, err := r.CountByUserID(ctx, userID)
+			if tt.wantError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.wantCount, count)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPersonalAccessTokenTouch_TableDriven(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.New()
+	at := time.Now()
+
+	tests := []struct {
+		name      string
+		prepare   func(sqlmock.Sqlmock)
+		wantError bool
+	}{
+		{
+			name: "success",
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("UPDATE `personal_access_tokens` SET `last_used_at`=\\? WHERE id = \\?").
+					WithArgs(at, id).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectCommit()
+			},
+		},
+		{
+			name: "db error",
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("UPDATE `personal_access_tokens`").WillReturnError(errors.New("db error"))
+				m.ExpectRollback()
+			},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newDBWithRegexp(t)
+			r := repo.NewPersonalAccessTokenRepository(db, nil)
+			tt.prepare(mock)
+
+			err := r.Touch(ctx, id, at)
+			if tt.wantError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPersonalAccessTokenDeleteByUserAndID_TableDriven(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.New()
+	userID := uuid.New()
+
+	tests := []struct {
+		name        string
+		prepare     func(sqlmock.Sqlmock)
+		wantDeleted int64
+		wantError   bool
+	}{
+		{
+			name: "deleted",
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("DELETE FROM `personal_access_tokens` WHERE id = \\? AND user_id = \\?").
+					WithArgs(id, userID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectCommit()
+			},
+			wantDeleted: 1,
+		},
+		{
+			name: "not found",
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("DELETE FROM `personal_access_tokens`").WillReturnResult(sqlmock.NewResult(0, 0))
+				m.ExpectCommit()
+			},
+		},
+		{
+			name: "db error",
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("DELETE FROM `personal_access_tokens`").WillReturnError(errors.New("db error"))
+				m.ExpectRollback()
+			},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newDBWithRegexp(t)
+			r := repo.NewPersonalAccessTokenRepository(db, nil)
+			tt.prepare(mock)
+
+			deleted, err := r.DeleteByUserAndID(ctx, userID, id)
+			if tt.wantError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.wantDeleted, deleted)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/otel"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ReminderRepository interface {
+	CreateReminders(ctx context.Context, reminders []domain.Reminder) (int64, error)
+	GetDueReminders(ctx context.Context, now time.Time, limit int) ([]domain.Reminder, error)
+	ClaimReminder(ctx context.Context, id uuid.UUID, dueAt time.Time, leaseUntil time.Time) (bool, error)
+	UpdateReminder(ctx context.Context, reminder *domain.Reminder) error
+}
+
+type reminderRepository struct {
+	db      *gorm.DB
+	metrics *otel.MetricsRecorder
+}
+
+func NewReminderRepository(db *gorm.DB, metrics *otel.MetricsRecorder) ReminderRepository {
+	return &reminderRepository{db: db, metrics: metrics}
+}
+
+// CreateReminders inserts the reminders, skipping any that are already scheduled,
+// and returns how many were new.
+func (r *reminderRepository) CreateReminders(ctx context.Context, reminders []domain.Reminder) (int64, error) {
+	ctx, span := otel.StartRepositorySpan(ctx, "CreateReminders",
+		attribute.String("db.operation", "insert"),
+		attribute.String("db.table", "reminders"),
+		attribute.Int("reminder.count", len(reminders)),
+	)
+	defer span.End()
+
+	if len(reminders) == 0 {
+		span.SetStatusOk()
+		return 0, nil
+	}
+
+	start := time.Now()
+	result := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&reminders)
+	r.metrics.RecordDBOperation(ctx, "insert", "reminders", time.Since(start), int(result.RowsAffected))
+
+	if result.Error != nil {
+		_ = span.RecordErrorWithStatus(result.Error)
+		return 0, result.Error
+	}
+
+	span.SetAttributes(attribute.Int64("reminder.scheduled", result.RowsAffected))
+	span.SetStatusOk()
+	return result.RowsAffected, nil
+}
+
+// GetDueReminders returns pending reminders whose next attempt is due, with their
+// hangout and recipient loaded.
+func (r *reminderRepository) GetDueReminders(ctx context.Context, now time.Time, limit int) ([]domain.Reminder, error) {
+	ctx, span := otel.StartRepositorySpan(ctx, "GetDueReminders",
+		attribute.String("db.operation", "select"),
+		attribute.String("db.table", "reminders"),
+		attribute.Int("batch.limit", limit),
+	)
+	defer span.End()
+
+	start := time.Now()
+	var reminders []domain.Reminder
+
+	err := r.db.WithContext(ctx).
+		Preload("Hangout").
+		Preload("User").
+		Where("status = ? AND next_attempt_at <= ?", constants.ReminderPending, now).
+		Order("next_attempt_at asc").
+		Limit(limit).
+		Find(&reminders).Error
+	r.metrics.RecordDBOperation(ctx, "select", "reminders", time.Since(start), len(reminders))
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("reminder.count", len(reminders)))
+	span.SetStatusOk()
+	return reminders, nil
+}
+
+// ClaimReminder pushes a due reminder's next attempt to leaseUntil so other
+// instances skip it while it is being sent.
+func (r *reminderRepository) ClaimReminder(ctx context.Context, id uuid.UUID, dueAt time.Time, leaseUntil time.Time) (bool, error) {
+	ctx, span := otel.StartRepositorySpan(ctx, "ClaimReminder",
+		attribute.String("db.operation", "update"),
+		attribute.String("db.table", "reminders"),
+		attribute.String("reminder.id", id.String()),
+	)
+	defer span.End()
+
+	start := time.Now()
+	result := r.db.WithContext(ctx).Model(&domain.Reminder{}).
+		Where("id = ? AND status = ? AND next_attempt_at = ?", id, constants.ReminderPending, dueAt).
+		Update("next_attempt_at", leaseUntil)
+	r.metrics.RecordDBOperation(ctx, "update", "reminders", time.Since(start), int(result.RowsAffected))
+
+	if result.Error != nil {
+		_ = span.RecordErrorWithStatus(result.Error)
+		return false, result.Error
+	}
+
+	claimed := result.RowsAffected > 0
+	span.SetAttributes(attribute.Bool("reminder.claimed", claimed))
+	span.SetStatusOk()
+	return claimed, nil
+}
+
+func (r *reminderRepository) UpdateReminder(ctx context.Context, reminder *domain.Reminder) error {
+	ctx, span := otel.StartRepositorySpan(ctx, "UpdateReminder",
+		attribute.String("db.operation", "update"),
+		attribute.String("db.table", "reminders"),
+		attribute.String("reminder.id", reminder.ID.String()),
+		attribute.String("reminder.status", reminder.Status),
+	)
+	defer span.End()
+
+	start := time.Now()
+	err := r.db.WithContext(ctx).Model(reminder).
+		Select("status", "attempts", "next_attempt_at", "delivered_channels", "last_error", "sent_at", "updated_at").
+		Updates(reminder).Error
+	r.metrics.RecordDBOperation(ctx, "update", "reminders", time.Since(start), 1)
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+		return err
+	}
+
+	span.SetStatusOk()
+	return nil
+}
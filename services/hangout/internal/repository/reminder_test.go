@@ -0,0 +1,160 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	repo "github.com/Ernestgio/Hangout-Planner/services/hangout/internal/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReminderCreateReminders_TableDriven(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	tests := []struct {
+		name      string
+		reminders []domain.Reminder
+		setup     func(mock sqlmock.Sqlmock)
+		want      int64
+		wantErr   bool
+	}{
+		{
+			name:      "nothing to schedule",
+			reminders: nil,
+			setup:     func(mock sqlmock.Sqlmock) {},
+		},
+		{
+			name: "duplicates are skipped",
+			reminders: []domain.Reminder{
+				{Kind: constants.ReminderKindHangoutStart, OffsetMinutes: 60, DueAt: now, Status: constants.ReminderPending, NextAttemptAt: &now, HangoutID: uuid.New(), UserID: uuid.New()},
+				{Kind: constants.ReminderKindRSVPDeadline, OffsetMinutes: 1440, DueAt: now, Status: constants.ReminderPending, NextAttemptAt: &now, HangoutID: uuid.New(), UserID: uuid.New()},
+			},
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO `reminders` .* ON DUPLICATE KEY UPDATE `id`=`id`").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			want: 1,
+		},
+		{
+			name: "db error",
+			reminders: []domain.Reminder{
+				{Kind: constants.ReminderKindHangoutStart, OffsetMinutes: 60, DueAt: now, Status: constants.ReminderPending, HangoutID: uuid.New(), UserID: uuid.New()},
+			},
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO `reminders`").WillReturnError(errors.New("db error"))
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newDBWithRegexp(t)
+			r := repo.NewReminderRepository(db, nil)
+			tt.setup(mock)
+
+			scheduled, err := r.CreateReminders(ctx, tt.reminders)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.want, scheduled)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestReminderGetDueReminders(t *testing.T) {
+	ctx := context.Background()
+	db, mock := newDBWithRegexp(t)
+	r := repo.NewReminderRepository(db, nil)
+	now := time.Now()
+	hangoutID := uuid.New()
+	userID := uuid.New()
+
+	mock.ExpectQuery("SELECT \\* FROM `reminders` WHERE status = \\? AND next_attempt_at <= \\? ORDER BY next_attempt_at asc LIMIT \\?").
+		WithArgs(constants.ReminderPending, now, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "status", "hangout_id", "user_id"}).AddRow(uuid.New(), constants.ReminderPending, hangoutID, userID))
+	mock.ExpectQuery("SELECT \\* FROM `hangouts` WHERE `hangouts`.`id` = \\? AND `hangouts`.`deleted_at` IS NULL").
+		WithArgs(hangoutID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title"}).AddRow(hangoutID, "Picnic"))
+	mock.ExpectQuery("SELECT \\* FROM `users` WHERE `users`.`id` = \\? AND `users`.`deleted_at` IS NULL").
+		WithArgs(userID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "email"}).AddRow(userID, "ann@example.com"))
+
+	reminders, err := r.GetDueReminders(ctx, now, 10)
+	require.NoError(t, err)
+	require.Len(t, reminders, 1)
+	require.Equal(t, "Picnic", reminders[0].Hangout.Title)
+	require.Equal(t, "ann@example.com", reminders[0].User.Email)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReminderClaimReminder_TableDriven(t *testing.T) {
+	ctx := context.Background()
+	dueAt := time.Now()
+	leaseUntil := dueAt.Add(time.Minute)
+
+	tests := []struct {
+		name        string
+		rows        int64
+		wantClaimed bool
+	}{
+		{name: "claimed", rows: 1, wantClaimed: true},
+		{name: "claimed by another instance", rows: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newDBWithRegexp(t)
+			r := repo.NewReminderRepository(db, nil)
+			id := uuid.New()
+
+			mock.ExpectBegin()
+			mock.ExpectExec("UPDATE `reminders` SET `next_attempt_at`=\\?,`updated_at`=\\? WHERE id = \\? AND status = \\? AND next_attempt_at = \\?").
+				WithArgs(leaseUntil, AnyTime{}, id, constants.ReminderPending, dueAt).
+				WillReturnResult(sqlmock.NewResult(0, tt.rows))
+			mock.ExpectCommit()
+
+			claimed, err := r.ClaimReminder(ctx, id, dueAt, leaseUntil)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantClaimed, claimed)
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestReminderUpdateReminder(t *testing.T) {
+	ctx := context.Background()
+	db, mock := newDBWithRegexp(t)
+	r := repo.NewReminderRepository(db, nil)
+	now := time.Now()
+	reminder := &domain.Reminder{
+		ID:                uuid.New(),
+		Status:            constants.ReminderSent,
+		Attempts:          1,
+		DeliveredChannels: "email,inbox",
+		SentAt:            &now,
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE `reminders` SET `status`=\\?,`attempts`=\\?,`next_attempt_at`=\\?,`delivered_channels`=\\?,`last_error`=\\?,`sent_at`=\\?,`updated_at`=\\? WHERE `id` = \\?").
+		WithArgs(constants.ReminderSent, 1, nil, "email,inbox", "", now, AnyTime{}, reminder.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	require.NoError(t, r.UpdateReminder(ctx, reminder))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,193 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/otel"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"gorm.io/gorm"
+)
+
+type SessionRepository interface {
+	Create(ctx context.Context, session *domain.Session) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Session, error)
+	ListActiveByUserID(ctx context.Context, userID uuid.UUID, now time.Time) ([]domain.Session, error)
+	Touch(ctx context.Context, id uuid.UUID, at time.Time) error
+	Revoke(ctx context.Context, userID uuid.UUID, id uuid.UUID, at time.Time) (int64, error)
+	RevokeAllByUserID(ctx context.Context, userID uuid.UUID, at time.Time) (int64, error)
+	DeleteExpired(ctx context.Context, before time.Time) (int64, error)
+}
+
+type sessionRepository struct {
+	db      *gorm.DB
+	metrics *otel.MetricsRecorder
+}
+
+func NewSessionRepository(db *gorm.DB, metrics *otel.MetricsRecorder) SessionRepository {
+	return &sessionRepository{db: db, metrics: metrics}
+}
+
+func (r *sessionRepository) Create(ctx context.Context, session *domain.Session) error {
+	ctx, span := otel.StartRepositorySpan(ctx, "Create",
+		attribute.String("db.operation", "insert"),
+		attribute.String("db.table", "sessions"),
+		attribute.String("user.id", session.UserID.String()),
+	)
+	defer span.End()
+
+	start := time.Now()
+	err := r.db.WithContext(ctx).Create(session).Error
+	r.metrics.RecordDBOperation(ctx, "insert", "sessions", time.Since(start), 1)
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+	} else {
+		span.SetStatusOk()
+	}
+	return err
+}
+
+func (r *sessionRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Session, error) {
+	ctx, span := otel.StartRepositorySpan(ctx, "GetByID",
+		attribute.String("db.operation", "select"),
+		attribute.String("db.table", "sessions"),
+		attribute.String("session.id", id.String()),
+	)
+	defer span.End()
+
+	start := time.Now()
+	var session domain.Session
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&session).Error
+	r.metrics.RecordDBOperation(ctx, "select", "sessions", time.Since(start), 1)
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetStatusOk()
+	return &session, nil
+}
+
+// ListActiveByUserID returns the sessions that are neither revoked nor
+// expired, most recently used first.
+func (r *sessionRepository) ListActiveByUserID(ctx context.Context, userID uuid.UUID, now time.Time) ([]domain.Session, error) {
+	ctx, span := otel.StartRepositorySpan(ctx, "ListActiveByUserID",
+		attribute.String("db.operation", "select"),
+		attribute.String("db.table", "sessions"),
+		attribute.String("user.id", userID.String()),
+	)
+	defer span.End()
+
+	start := time.Now()
+	var sessions []domain.Session
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, now).
+		Order("last_used_at DESC").
+		Find(&sessions).Error
+	r.metrics.RecordDBOperation(ctx, "select", "sessions", time.Since(start), len(sessions))
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetStatusOk()
+	return sessions, nil
+}
+
+func (r *sessionRepository) Touch(ctx context.Context, id uuid.UUID, at time.Time) error {
+	ctx, span := otel.StartRepositorySpan(ctx, "Touch",
+		attribute.String("db.operation", "update"),
+		attribute.String("db.table", "sessions"),
+		attribute.String("session.id", id.String()),
+	)
+	defer span.End()
+
+	start := time.Now()
+	result := r.db.WithContext(ctx).Model(&domain.Session{}).
+		Where("id = ?", id).
+		Update("last_used_at", at)
+	r.metrics.RecordDBOperation(ctx, "update", "sessions", time.Since(start), int(result.RowsAffected))
+
+	if result.Error != nil {
+		_ = span.RecordErrorWithStatus(result.Error)
+		return result.Error
+	}
+
+	span.SetStatusOk()
+	return nil
+}
+
+// Revoke revokes one of the user's sessions.
+func (r *sessionRepository) Revoke(ctx context.Context, userID uuid.UUID, id uuid.UUID, at time.Time) (int64, error) {
+	ctx, span := otel.StartRepositorySpan(ctx, "Revoke",
+		attribute.String("db.operation", "update"),
+		attribute.String("db.table", "sessions"),
+		attribute.String("user.id", userID.String()),
+		attribute.String("session.id", id.String()),
+	)
+	defer span.End()
+
+	start := time.Now()
+	result := r.db.WithContext(ctx).Model(&domain.Session{}).
+		Where("id = ? AND user_id = ? AND revoked_at IS NULL", id, userID).
+		Update("revoked_at", at)
+	r.metrics.RecordDBOperation(ctx, "update", "sessions", time.Since(start), int(result.RowsAffected))
+
+	if result.Error != nil {
+		_ = span.RecordErrorWithStatus(result.Error)
+		return 0, result.Error
+	}
+
+	span.SetStatusOk()
+	return result.RowsAffected, nil
+}
+
+func (r *sessionRepository) RevokeAllByUserID(ctx context.Context, userID uuid.UUID, at time.Time) (int64, error) {
+	ctx, span := otel.StartRepositorySpan(ctx, "RevokeAllByUserID",
+		attribute.String("db.operation", "update"),
+		attribute.String("db.table", "sessions"),
+		attribute.String("user.id", userID.String()),
+	)
+	defer span.End()
+
+	start := time.Now()
+	result := r.db.WithContext(ctx).Model(&domain.Session{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", at)
+	r.metrics.RecordDBOperation(ctx, "update", "sessions", time.Since(start), int(result.RowsAffected))
+
+	if result.Error != nil {
+		_ = span.RecordErrorWithStatus(result.Error)
+		return 0, result.Error
+	}
+
+	span.SetStatusOk()
+	return result.RowsAffected, nil
+}
+
+// DeleteExpired removes sessions that expired before the given time,
+// revoked or not. Expired tokens fail verification on their own.
+func (r *sessionRepository) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	ctx, span := otel.StartRepositorySpan(ctx, "DeleteExpired",
+		attribute.String("db.operation", "delete"),
+		attribute.String("db.table", "sessions"),
+	)
+	defer span.End()
+
+	start := time.Now()
+	result := r.db.WithContext(ctx).Where("expires_at < ?", before).Delete(&domain.Session{})
+	r.metrics.RecordDBOperation(ctx, "delete", "sessions", time.Since(start), int(result.RowsAffected))
+
+	if result.Error != nil {
+		_ = span.RecordErrorWithStatus(result.Error)
+		return 0, result.Error
+	}
+
+	span.SetStatusOk()
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,370 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	repo "github.com/Ernestgio/Hangout-Planner/services/hangout/internal/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestSessionCreate_TableDriven(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		prepare   func(sqlmock.Sqlmock)
+		wantError bool
+	}{
+		{
+			name: "success",
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("INSERT INTO `sessions`").WillReturnResult(sqlmock.NewResult(1, 1))
+				m.ExpectCommit()
+			},
+		},
+		{
+			name: "db error",
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("INSERT INTO `sessions`").WillReturnError(errors.New("db error"))
+				m.ExpectRollback()
+			},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newDBWithRegexp(t)
+			r := repo.NewSessionRepository(db, nil)
+			tt.prepare(mock)
+
+			now := time.Now()
+			session := &domain.Session{UserID: uuid.New(), UserAgent: "curl/8.0", IPAddress: "203.0.113.7", LastUsedAt: now, ExpiresAt: now.Add(time.Hour)}
+			err := r.Create(ctx, session)
+			if tt.wantError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.NotEqual(t, uuid.Nil, session.ID)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestSessionGetByID_TableDriven(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.New()
+	userID := uuid.New()
+
+	tests := []struct {
+		name      string
+		prepare   func(sqlmock.Sqlmock)
+		wantError error
+	}{
+		{
+			name: "found",
+			prepare: func(m sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "user_agent", "ip_address", "user_id"}).
+					AddRow(id.String(), "curl/8.0", "203.0.113.7", userID.String())
+				m.ExpectQuery("SELECT \\* FROM `sessions` WHERE id = \\? ORDER BY `sessions`.`id` LIMIT \\?").
+					WithArgs(id, 1).
+					WillReturnRows(rows)
+			},
+		},
+		{
+			name: "not found",
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT \\* FROM `sessions`").WillReturnError(gorm.ErrRecordNotFound)
+			},
+			wantError: gorm.ErrRecordNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newDBWithRegexp(t)
+			r := repo.NewSessionRepository(db, nil)
+			tt.prepare(mock)
+
+			session, err := r.GetByID(ctx, id)
+			if tt.wantError != nil {
+				require.ErrorIs(t, err, tt.wantError)
+				require.Nil(t, session)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, userID, session.UserID)
+				require.Equal(t, "curl/8.0", session.UserAgent)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestSessionListActiveByUserID_TableDriven(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	now := time.Now()
+
+	tests := []struct {
+		name      string
+		prepare   func(sqlmock.Sqlmock)
+		wantCount int
+		wantError bool
+	}{
+		{
+			name: "active sessions",
+			prepare: func(m sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "user_id"}).
+					AddRow(uuid.New().String(), userID.String()).
+					AddRow(uuid.New().String(), userID.String())
+				m.ExpectQuery("SELECT \\* FROM `sessions` WHERE user_id = \\? AND revoked_at IS NULL AND expires_at > \\? ORDER BY last_used_at DESC").
+					WithArgs(userID, now).
+					WillReturnRows(rows)
+			},
+			wantCount: 2,
+		},
+		{
+			name: "db error",
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT \\* FROM `sessions`").WillReturnError(errors.New("db error"))
+			},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newDBWithRegexp(t)
+			r := repo.NewSessionRepository(db, nil)
+			tt.prepare(mock)
+
+			sessions, err := r.ListActiveByUserID(ctx, userID, now)
+			if tt.wantError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, sessions, tt.wantCount)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestSessionTouch_TableDriven(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.New()
+	at := time.Now()
+
+	tests := []struct {
+		name      string
+		prepare   func(sqlmock.Sqlmock)
+		wantError bool
+	}{
+		{
+			name: "success",
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("UPDATE `sessions` SET `last_used_at`=\\? WHERE id = \\?").
+					WithArgs(at, id).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectCommit()
+			},
+		},
+		{
+			name: "db error",
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("UPDATE `sessions`").WillReturnError(errors.New("db error"))
+				m.ExpectRollback()
+			},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newDBWithRegexp(t)
+			r := repo.NewSessionRepository(db, nil)
+			tt.prepare(mock)
+
+			err := r.Touch(ctx, id, at)
+			if tt.wantError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestSessionRevoke_TableDriven(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.New()
+	userID := uuid.New()
+	at := time.Now()
+
+	tests := []struct {
+		name        string
+		prepare     func(sqlmock.Sqlmock)
+		wantRevoked int64
+		wantError   bool
+	}{
+		{
+			name: "revoked",
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("UPDATE `sessions` SET `revoked_at`=\\? WHERE id = \\? AND user_id = \\? AND revoked_at IS NULL").
+					WithArgs(at, id, userID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectCommit()
+			},
+			wantRevoked: 1,
+		},
+		{
+			name: "not found or already revoked",
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("UPDATE `sessions`").WillReturnResult(sqlmock.NewResult(0, 0))
+				m.ExpectCommit()
+			},
+		},
+		{
+			name: "db error",
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("UPDATE `sessions`").WillReturnError(errors.New("db error"))
+				m.ExpectRollback()
+			},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newDBWithRegexp(t)
+			r := repo.NewSessionRepository(db, nil)
+			tt.prepare(mock)
+
+			revoked, err := r.Revoke(ctx, userID, id, at)
+			if tt.wantError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.wantRevoked, revoked)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestSessionRevokeAllByUserID_TableDriven(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	at := time.Now()
+
+	tests := []struct {
+		name        string
+		prepare     func(sqlmock.Sqlmock)
+		wantRevoked int64
+		wantError   bool
+	}{
+		{
+			name: "revoked",
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("UPDATE `sessions` SET `revoked_at`=\\? WHERE user_id = \\? AND revoked_at IS NULL").
+					WithArgs(at, userID).
+					WillReturnResult(sqlmock.NewResult(0, 3))
+				m.ExpectCommit()
+			},
+			wantRevoked: 3,
+		},
+		{
+			name: "db error",
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("UPDATE `sessions`").WillReturnError(errors.New("db error"))
+				m.ExpectRollback()
+			},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newDBWithRegexp(t)
+			r := repo.NewSessionRepository(db, nil)
+			tt.prepare(mock)
+
+			revoked, err := r.RevokeAllByUserID(ctx, userID, at)
+			if tt.wantError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.wantRevoked, revoked)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestSessionDeleteExpired_TableDriven(t *testing.T) {
+	ctx := context.Background()
+	before := time.Now()
+
+	tests := []struct {
+		name        string
+		prepare     func(sqlmock.Sqlmock)
+		wantDeleted int64
+		wantError   bool
+	}{
+		{
+			name: "deleted",
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("DELETE FROM `sessions` WHERE expires_at < \\?").
+					WithArgs(before).
+					WillReturnResult(sqlmock.NewResult(0, 4))
+				m.ExpectCommit()
+			},
+			wantDeleted: 4,
+		},
+		{
+			name: "db error",
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("DELETE FROM `sessions`").WillReturnError(errors.New("db error"))
+				m.ExpectRollback()
+			},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newDBWithRegexp(t)
+			r := repo.NewSessionRepository(db, nil)
+			tt.prepare(mock)
+
+			deleted, err := r.DeleteExpired(ctx, before)
+			if tt.wantError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.wantDeleted, deleted)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,262 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/otel"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"gorm.io/gorm"
+)
+
+type ShareLinkRepository interface {
+	CreateShareLink(ctx context.Context, link *domain.ShareLink) error
+	CountActiveShareLinksByHangoutID(ctx context.Context, hangoutID uuid.UUID, now time.Time) (int64, error)
+	GetShareLinkByID(ctx context.Context, id uuid.UUID) (*domain.ShareLink, error)
+	GetShareLinkByTokenHash(ctx context.Context, tokenHash string) (*domain.ShareLink, error)
+	GetShareLinksByHangoutID(ctx context.Context, hangoutID uuid.UUID) ([]domain.ShareLink, error)
+	RevokeShareLink(ctx context.Context, id uuid.UUID, revokedAt time.Time) error
+	RecordAccess(ctx context.Context, access *domain.ShareLinkAccess) error
+	GetAccessesByShareLinkID(ctx context.Context, shareLinkID uuid.UUID, pagination *dto.CursorPagination) ([]domain.ShareLinkAccess, error)
+}
+
+type shareLinkRepository struct {
+	db      *gorm.DB
+	metrics *otel.MetricsRecorder
+}
+
+func NewShareLinkRepository(db *gorm.DB, metrics *otel.MetricsRecorder) ShareLinkRepository {
+	return &shareLinkRepository{db: db, metrics: metrics}
+}
+
+func (r *shareLinkRepository) CreateShareLink(ctx context.Context, link *domain.ShareLink) error {
+	ctx, span := otel.StartRepositorySpan(ctx, "CreateShareLink",
+		attribute.String("db.operation", "insert"),
+		attribute.String("db.table", "share_links"),
+		attribute.String("hangout.id", link.HangoutID.String()),
+	)
+	defer span.End()
+
+	start := time.Now()
+	err := r.db.WithContext(ctx).Omit("Hangout", "Album", "CreatedBy").Create(link).Error
+	r.metrics.RecordDBOperation(ctx, "insert", "share_links", time.Since(start), 1)
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+		return err
+	}
+
+	span.SetAttributes(attribute.String("share_link.id", link.ID.String()))
+	span.SetStatusOk()
+	return nil
+}
+
+// CountActiveShareLinksByHangoutID counts the links of a hangout that are
+// neither revoked nor expired at now.
+func (r *shareLinkRepository) CountActiveShareLinksByHangoutID(ctx context.Context, hangoutID uuid.UUID, now time.Time) (int64, error) {
+	ctx, span := otel.StartRepositorySpan(ctx, "CountActiveShareLinksByHangoutID",
+		attribute.String("db.operation", "select"),
+		attribute.String("db.table", "share_links"),
+		attribute.String("hangout.id", hangoutID.String()),
+	)
+	defer span.End()
+
+	var count int64
+
+	start := time.Now()
+	err := r.db.WithContext(ctx).Model(&domain.ShareLink{}).
+		Where("hangout_id = ? AND revoked_at IS NULL AND (expires_at IS NULL OR expires_at > ?)", hangoutID, now).
+		Count(&count).Error
+	r.metrics.RecordDBOperation(ctx, "select", "share_links", time.Since(start), 1)
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+		return 0, err
+	}
+
+	span.SetStatusOk()
+	return count, nil
+}
+
+func (r *shareLinkRepository) GetShareLinkByID(ctx context.Context, id uuid.UUID) (*domain.ShareLink, error) {
+	ctx, span := otel.StartRepositorySpan(ctx, "GetShareLinkByID",
+		attribute.String("db.operation", "select"),
+		attribute.String("db.table", "share_links"),
+		attribute.String("share_link.id", id.String()),
+	)
+	defer span.End()
+
+	var link domain.ShareLink
+
+	start := time.Now()
+	err := r.db.WithContext(ctx).First(&link, "id = ?", id).Error
+	r.metrics.RecordDBOperation(ctx, "select", "share_links", time.Since(start), 1)
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetStatusOk()
+	return &link, nil
+}
+
+// GetShareLinkByTokenHash looks a link up by the hash of its token, whether
+// or not it is still valid.
+func (r *shareLinkRepository) GetShareLinkByTokenHash(ctx context.Context, tokenHash string) (*domain.ShareLink, error) {
+	ctx, span := otel.StartRepositorySpan(ctx, "GetShareLinkByTokenHash",
+		attribute.String("db.operation", "select"),
+		attribute.String("db.table", "share_links"),
+	)
+	defer span.End()
+
+	var link domain.ShareLink
+
+	start := time.Now()
+	err := r.db.WithContext(ctx).First(&link, "token_hash = ?", tokenHash).Error
+	r.metrics.RecordDBOperation(ctx, "select", "share_links", time.Since(start), 1)
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.String("share_link.id", link.ID.String()))
+	span.SetStatusOk()
+	return &link, nil
+}
+
+// GetShareLinksByHangoutID returns every link of a hangout, including revoked
+// and expired ones, newest first.
+func (r *shareLinkRepository) GetShareLinksByHangoutID(ctx context.Context, hangoutID uuid.UUID) ([]domain.ShareLink, error) {
+	ctx, span := otel.StartRepositorySpan(ctx, "GetShareLinksByHangoutID",
+		attribute.String("db.operation", "select"),
+		attribute.String("db.table", "share_links"),
+		attribute.String("hangout.id", hangoutID.String()),
+	)
+	defer span.End()
+
+	var links []domain.ShareLink
+
+	start := time.Now()
+	err := r.db.WithContext(ctx).
+		Where("hangout_id = ?", hangoutID).
+		Order("created_at desc, id desc").
+		Find(&links).Error
+	r.metrics.RecordDBOperation(ctx, "select", "share_links", time.Since(start), len(links))
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("share_link.count", len(links)))
+	span.SetStatusOk()
+	return links, nil
+}
+
+// RevokeShareLink marks the link as revoked.
+func (r *shareLinkRepository) RevokeShareLink(ctx context.Context, id uuid.UUID, revokedAt time.Time) error {
+	ctx, span := otel.StartRepositorySpan(ctx, "RevokeShareLink",
+		attribute.String("db.operation", "update"),
+		attribute.String("db.table", "share_links"),
+		attribute.String("share_link.id", id.String()),
+	)
+	defer span.End()
+
+	start := time.Now()
+	err := r.db.WithContext(ctx).Model(&domain.ShareLink{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", revokedAt).Error
+	r.metrics.RecordDBOperation(ctx, "update", "share_links", time.Since(start), 1)
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+	} else {
+		span.SetStatusOk()
+	}
+	return err
+}
+
+// RecordAccess stores an audit entry for a request made with a link.
+func (r *shareLinkRepository) RecordAccess(ctx context.Context, access *domain.ShareLinkAccess) error {
+	ctx, span := otel.StartRepositorySpan(ctx, "RecordAccess",
+		attribute.String("db.operation", "insert"),
+		attribute.String("db.table", "share_link_accesses"),
+		attribute.String("share_link.id", access.ShareLinkID.String()),
+		attribute.String("share_link.outcome", access.Outcome),
+	)
+	defer span.End()
+
+	start := time.Now()
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("ShareLink").Create(access).Error; err != nil {
+			return err
+		}
+		if access.Outcome != constants.ShareAccessGranted {
+			return nil
+		}
+		return tx.Model(&domain.ShareLink{}).Where("id = ?", access.ShareLinkID).Updates(map[string]any{
+			"access_count":     gorm.Expr("access_count + 1"),
+			"last_accessed_at": access.CreatedAt,
+		}).Error
+	})
+	r.metrics.RecordDBOperation(ctx, "insert", "share_link_accesses", time.Since(start), 1)
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+		return err
+	}
+
+	span.SetStatusOk()
+	return nil
+}
+
+// GetAccessesByShareLinkID returns the audit log of a link, newest first.
+func (r *shareLinkRepository) GetAccessesByShareLinkID(ctx context.Context, shareLinkID uuid.UUID, pagination *dto.CursorPagination) ([]domain.ShareLinkAccess, error) {
+	ctx, span := otel.StartRepositorySpan(ctx, "GetAccessesByShareLinkID",
+		attribute.String("db.operation", "select"),
+		attribute.String("db.table", "share_link_accesses"),
+		attribute.String("share_link.id", shareLinkID.String()),
+		attribute.Int("pagination.limit", pagination.GetLimit()),
+	)
+	defer span.End()
+
+	start := time.Now()
+	var accesses []domain.ShareLinkAccess
+
+	query := r.db.WithContext(ctx).Model(&domain.ShareLinkAccess{}).
+		Where("share_link_id = ?", shareLinkID)
+
+	if pagination.AfterID != nil {
+		var cursorItem domain.ShareLinkAccess
+		if err := r.db.WithContext(ctx).First(&cursorItem, "id = ? AND share_link_id = ?", *pagination.AfterID, shareLinkID).Error; err != nil {
+			return nil, apperrors.ErrInvalidCursorPagination
+		}
+
+		query = query.Where(
+			"(created_at < ?) OR (created_at = ? AND id < ?)",
+			cursorItem.CreatedAt, cursorItem.CreatedAt, cursorItem.ID,
+		)
+	}
+
+	err := query.
+		Order("created_at desc, id desc").
+		Limit(pagination.GetLimit() + 1).
+		Find(&accesses).Error
+	r.metrics.RecordDBOperation(ctx, "select", "share_link_accesses", time.Since(start), len(accesses))
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("share_link.access_count", len(accesses)))
+	span.SetStatusOk()
+	return accesses, nil
+}
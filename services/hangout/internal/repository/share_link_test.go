@@ -0,0 +1,208 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
+	repo "github.com/Ernestgio/Hangout-Planner/services/hangout/internal/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestShareLinkCreateShareLink(t *testing.T) {
+	ctx := context.Background()
+	db, mock := newDBWithRegexp(t)
+	r := repo.NewShareLinkRepository(db, nil)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO `share_links`").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	link := &domain.ShareLink{TokenHash: "hash", Scope: constants.ShareScopeHangout, HangoutID: uuid.New(), CreatedByID: uuid.New()}
+	require.NoError(t, r.CreateShareLink(ctx, link))
+	require.NotEqual(t, uuid.Nil, link.ID)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestShareLinkCountActiveShareLinksByHangoutID(t *testing.T) {
+	ctx := context.Background()
+	db, mock := newDBWithRegexp(t)
+	r := repo.NewShareLinkRepository(db, nil)
+	hangoutID := uuid.New()
+
+	mock.ExpectQuery("SELECT count\\(\\*\\) FROM `share_links` WHERE hangout_id = \\? AND revoked_at IS NULL AND \\(expires_at IS NULL OR expires_at > \\?\\)").
+		WithArgs(hangoutID, AnyTime{}).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+	count, err := r.CountActiveShareLinksByHangoutID(ctx, hangoutID, time.Now())
+	require.NoError(t, err)
+	require.EqualValues(t, 3, count)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestShareLinkGetShareLinkByTokenHash(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("found", func(t *testing.T) {
+		db, mock := newDBWithRegexp(t)
+		r := repo.NewShareLinkRepository(db, nil)
+		id := uuid.New()
+
+		mock.ExpectQuery("SELECT \\* FROM `share_links` WHERE token_hash = \\?").
+			WithArgs("hash", 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "token_hash", "scope"}).AddRow(id, "hash", constants.ShareScopeHangout))
+
+		link, err := r.GetShareLinkByTokenHash(ctx, "hash")
+		require.NoError(t, err)
+		require.Equal(t, id, link.ID)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		db, mock := newDBWithRegexp(t)
+		r := repo.NewShareLinkRepository(db, nil)
+
+		mock.ExpectQuery("SELECT \\* FROM `share_links` WHERE token_hash = \\?").
+			WithArgs("hash", 1).
+			WillReturnError(gorm.ErrRecordNotFound)
+
+		_, err := r.GetShareLinkByTokenHash(ctx, "hash")
+		require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestShareLinkGetShareLinksByHangoutID(t *testing.T) {
+	ctx := context.Background()
+	db, mock := newDBWithRegexp(t)
+	r := repo.NewShareLinkRepository(db, nil)
+	hangoutID := uuid.New()
+
+	mock.ExpectQuery("SELECT \\* FROM `share_links` WHERE hangout_id = \\? ORDER BY created_at desc, id desc").
+		WithArgs(hangoutID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()).AddRow(uuid.New()))
+
+	links, err := r.GetShareLinksByHangoutID(ctx, hangoutID)
+	require.NoError(t, err)
+	require.Len(t, links, 2)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestShareLinkRevokeShareLink(t *testing.T) {
+	ctx := context.Background()
+	db, mock := newDBWithRegexp(t)
+	r := repo.NewShareLinkRepository(db, nil)
+	id := uuid.New()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE `share_links` SET `revoked_at`=\\?,`updated_at`=\\? WHERE id = \\? AND revoked_at IS NULL").
+		WithArgs(AnyTime{}, AnyTime{}, id).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	require.NoError(t, r.RevokeShareLink(ctx, id, time.Now()))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestShareLinkRecordAccess_TableDriven(t *testing.T) {
+	ctx := context.Background()
+	dbErr := errors.New("db error")
+
+	tests := []struct {
+		name    string
+		outcome string
+		prepare func(sqlmock.Sqlmock, uuid.UUID)
+		wantErr error
+	}{
+		{
+			name:    "granted access bumps the counter",
+			outcome: constants.ShareAccessGranted,
+			prepare: func(m sqlmock.Sqlmock, linkID uuid.UUID) {
+				m.ExpectBegin()
+				m.ExpectExec("INSERT INTO `share_link_accesses`").WillReturnResult(sqlmock.NewResult(1, 1))
+				m.ExpectExec("UPDATE `share_links` SET `access_count`=access_count \\+ 1,`last_accessed_at`=\\?,`updated_at`=\\? WHERE id = \\?").
+					WithArgs(AnyTime{}, AnyTime{}, linkID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectCommit()
+			},
+		},
+		{
+			name:    "denied access is only logged",
+			outcome: constants.ShareAccessPasswordInvalid,
+			prepare: func(m sqlmock.Sqlmock, linkID uuid.UUID) {
+				m.ExpectBegin()
+				m.ExpectExec("INSERT INTO `share_link_accesses`").WillReturnResult(sqlmock.NewResult(1, 1))
+				m.ExpectCommit()
+			},
+		},
+		{
+			name:    "insert error rolls back",
+			outcome: constants.ShareAccessGranted,
+			prepare: func(m sqlmock.Sqlmock, linkID uuid.UUID) {
+				m.ExpectBegin()
+				m.ExpectExec("INSERT INTO `share_link_accesses`").WillReturnError(dbErr)
+				m.ExpectRollback()
+			},
+			wantErr: dbErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newDBWithRegexp(t)
+			r := repo.NewShareLinkRepository(db, nil)
+			linkID := uuid.New()
+			tt.prepare(mock, linkID)
+
+			access := &domain.ShareLinkAccess{ShareLinkID: linkID, Outcome: tt.outcome, IPAddress: "203.0.113.7", UserAgent: "curl/8.0"}
+			err := r.RecordAccess(ctx, access)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestShareLinkGetAccessesByShareLinkID(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("first page", func(t *testing.T) {
+		db, mock := newDBWithRegexp(t)
+		r := repo.NewShareLinkRepository(db, nil)
+		linkID := uuid.New()
+
+		mock.ExpectQuery("SELECT \\* FROM `share_link_accesses` WHERE share_link_id = \\? ORDER BY created_at desc, id desc LIMIT \\?").
+			WithArgs(linkID, 3).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "outcome"}).AddRow(uuid.New(), constants.ShareAccessGranted))
+
+		accesses, err := r.GetAccessesByShareLinkID(ctx, linkID, &dto.CursorPagination{Limit: 2})
+		require.NoError(t, err)
+		require.Len(t, accesses, 1)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		db, mock := newDBWithRegexp(t)
+		r := repo.NewShareLinkRepository(db, nil)
+		linkID, afterID := uuid.New(), uuid.New()
+
+		mock.ExpectQuery("SELECT \\* FROM `share_link_accesses` WHERE id = \\? AND share_link_id = \\?").
+			WithArgs(afterID, linkID, 1).
+			WillReturnError(gorm.ErrRecordNotFound)
+
+		_, err := r.GetAccessesByShareLinkID(ctx, linkID, &dto.CursorPagination{AfterID: &afterID})
+		require.ErrorIs(t, err, apperrors.ErrInvalidCursorPagination)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
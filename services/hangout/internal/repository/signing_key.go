@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/otel"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"gorm.io/gorm"
+)
+
+type SigningKeyRepository interface {
+	Create(ctx context.Context, key *domain.SigningKey) error
+	ListUnretired(ctx context.Context, now time.Time) ([]domain.SigningKey, error)
+	SetRetiresAt(ctx context.Context, id uuid.UUID, retiresAt time.Time) error
+	DeleteRetired(ctx context.Context, before time.Time) (int64, error)
+}
+
+type signingKeyRepository struct {
+	db      *gorm.DB
+	metrics *otel.MetricsRecorder
+}
+
+func NewSigningKeyRepository(db *gorm.DB, metrics *otel.MetricsRecorder) SigningKeyRepository {
+	return &signingKeyRepository{db: db, metrics: metrics}
+}
+
+func (r *signingKeyRepository) Create(ctx context.Context, key *domain.SigningKey) error {
+	ctx, span := otel.StartRepositorySpan(ctx, "Create",
+		attribute.String("db.operation", "insert"),
+		attribute.String("db.table", "signing_keys"),
+		attribute.String("signing_key.algorithm", key.Algorithm),
+	)
+	defer span.End()
+
+	start := time.Now()
+	err := r.db.WithContext(ctx).Create(key).Error
+	r.metrics.RecordDBOperation(ctx, "insert", "signing_keys", time.Since(start), 1)
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+	} else {
+		span.SetStatusOk()
+	}
+	return err
+}
+
+// ListUnretired returns the keys still published at now, oldest activation first.
+func (r *signingKeyRepository) ListUnretired(ctx context.Context, now time.Time) ([]domain.SigningKey, error) {
+	ctx, span := otel.StartRepositorySpan(ctx, "ListUnretired",
+		attribute.String("db.operation", "select"),
+		attribute.String("db.table", "signing_keys"),
+	)
+	defer span.End()
+
+	var keys []domain.SigningKey
+
+	start := time.Now()
+	err := r.db.WithContext(ctx).
+		Where("retires_at IS NULL OR retires_at > ?", now).
+		Order("activates_at ASC, created_at ASC").
+		Find(&keys).Error
+	r.metrics.RecordDBOperation(ctx, "select", "signing_keys", time.Since(start), len(keys))
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetStatusOk()
+	return keys, nil
+}
+
+func (r *signingKeyRepository) SetRetiresAt(ctx context.Context, id uuid.UUID, retiresAt time.Time) error {
+	ctx, span := otel.StartRepositorySpan(ctx, "SetRetiresAt",
+		attribute.String("db.operation", "update"),
+		attribute.String("db.table", "signing_keys"),
+		attribute.String("signing_key.id", id.String()),
+	)
+	defer span.End()
+
+	start := time.Now()
+	err := r.db.WithContext(ctx).Model(&domain.SigningKey{}).
+		Where("id = ?", id).
+		Update("retires_at", retiresAt).Error
+	r.metrics.RecordDBOperation(ctx, "update", "signing_keys", time.Since(start), 1)
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+	} else {
+		span.SetStatusOk()
+	}
+	return err
+}
+
+func (r *signingKeyRepository) DeleteRetired(ctx context.Context, before time.Time) (int64, error) {
+	ctx, span := otel.StartRepositorySpan(ctx, "DeleteRetired",
+		attribute.String("db.operation", "delete"),
+		attribute.String("db.table", "signing_keys"),
+	)
+	defer span.End()
+
+	start := time.Now()
+	result := r.db.WithContext(ctx).Where("retires_at <= ?", before).Delete(&domain.SigningKey{})
+	r.metrics.RecordDBOperation(ctx, "delete", "signing_keys", time.Since(start), int(result.RowsAffected))
+
+	if result.Error != nil {
+		_ = span.RecordErrorWithStatus(result.Error)
+		return 0, result.Error
+	}
+
+	span.SetAttributes(attribute.Int64("signing_key.deleted", result.RowsAffected))
+	span.SetStatusOk()
+	return result.RowsAffected, nil
+}
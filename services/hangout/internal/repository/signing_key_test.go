@@ -0,0 +1,207 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	repo "github.com/Ernestgio/Hangout-Planner/services/hangout/internal/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSigningKeyCreate_TableDriven(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		prepare   func(sqlmock.Sqlmock)
+		wantError bool
+	}{
+		{
+			name: "success",
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("INSERT INTO `signing_keys`").WillReturnResult(sqlmock.NewResult(1, 1))
+				m.ExpectCommit()
+			},
+		},
+		{
+			name: "db error",
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("INSERT INTO `signing_keys`").WillReturnError(errors.New("db error"))
+				m.ExpectRollback()
+			},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newDBWithRegexp(t)
+			r := repo.NewSigningKeyRepository(db, nil)
+			tt.prepare(mock)
+
+			key := &domain.SigningKey{Algorithm: "EdDSA", PublicKey: []byte("pub"), PrivateKey: []byte("priv"), ActivatesAt: time.Now()}
+			err := r.Create(ctx, key)
+			if tt.wantError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.NotEqual(t, uuid.Nil, key.ID)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestSigningKeyListUnretired_TableDriven(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	tests := []struct {
+		name      string
+		prepare   func(sqlmock.Sqlmock)
+		wantCount int
+		wantError bool
+	}{
+		{
+			name: "found",
+			prepare: func(m sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "algorithm", "activates_at"}).
+					AddRow(uuid.New().String(), "RS256", now.Add(-time.Hour)).
+					AddRow(uuid.New().String(), "RS256", now)
+				m.ExpectQuery("SELECT \\* FROM `signing_keys` WHERE retires_at IS NULL OR retires_at > \\? ORDER BY activates_at ASC, created_at ASC").
+					WithArgs(now).
+					WillReturnRows(rows)
+			},
+			wantCount: 2,
+		},
+		{
+			name: "db error",
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT \\* FROM `signing_keys`").WillReturnError(errors.New("db error"))
+			},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newDBWithRegexp(t)
+			r := repo.NewSigningKeyRepository(db, nil)
+			tt.prepare(mock)
+
+			keys, err := r.ListUnretired(ctx, now)
+			if tt.wantError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, keys, tt.wantCount)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestSigningKeySetRetiresAt_TableDriven(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.New()
+	retiresAt := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name      string
+		prepare   func(sqlmock.Sqlmock)
+		wantError bool
+	}{
+		{
+			name: "success",
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("UPDATE `signing_keys` SET `retires_at`=\\? WHERE id = \\?").
+					WithArgs(retiresAt, id).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectCommit()
+			},
+		},
+		{
+			name: "db error",
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("UPDATE `signing_keys`").WillReturnError(errors.New("db error"))
+				m.ExpectRollback()
+			},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newDBWithRegexp(t)
+			r := repo.NewSigningKeyRepository(db, nil)
+			tt.prepare(mock)
+
+			err := r.SetRetiresAt(ctx, id, retiresAt)
+			if tt.wantError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestSigningKeyDeleteRetired_TableDriven(t *testing.T) {
+	ctx := context.Background()
+	before := time.Now()
+
+	tests := []struct {
+		name        string
+		prepare     func(sqlmock.Sqlmock)
+		wantDeleted int64
+		wantError   bool
+	}{
+		{
+			name: "deleted",
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("DELETE FROM `signing_keys` WHERE retires_at <= \\?").
+					WithArgs(before).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				m.ExpectCommit()
+			},
+			wantDeleted: 2,
+		},
+		{
+			name: "db error",
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("DELETE FROM `signing_keys`").WillReturnError(errors.New("db error"))
+				m.ExpectRollback()
+			},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newDBWithRegexp(t)
+			r := repo.NewSigningKeyRepository(db, nil)
+			tt.prepare(mock)
+
+			deleted, err := r.DeleteRetired(ctx, before)
+			if tt.wantError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.wantDeleted, deleted)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
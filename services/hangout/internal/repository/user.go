@@ -2,10 +2,16 @@ package repository
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
 	domain "github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/otel"
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
@@ -13,6 +19,16 @@ type UserRepository interface {
 	WithTx(tx *gorm.DB) UserRepository
 	CreateUser(context context.Context, user *domain.User) error
 	GetUserByEmail(context context.Context, email string) (*domain.User, error)
+	GetUserByID(context context.Context, id uuid.UUID) (*domain.User, error)
+	UpdatePassword(context context.Context, id uuid.UUID, passwordHash string) error
+	MarkEmailVerified(context context.Context, id uuid.UUID, verifiedAt time.Time) error
+	UpdateName(context context.Context, id uuid.UUID, name string) error
+	SetPendingEmail(context context.Context, id uuid.UUID, email string) error
+	ConfirmPendingEmail(context context.Context, id uuid.UUID, email string, verifiedAt time.Time) error
+	DeleteAccount(context context.Context, id uuid.UUID, at time.Time) error
+	SearchUsers(context context.Context, query string, pagination *dto.CursorPagination) ([]domain.User, error)
+	SetDisabledAt(context context.Context, id uuid.UUID, disabledAt *time.Time) error
+	PromoteToAdmin(context context.Context, emails []string) (int64, error)
 }
 
 type userRepository struct {
@@ -45,3 +61,144 @@ func (r *userRepository) GetUserByEmail(ctx context.Context, email string) (*dom
 	}
 	return &user, nil
 }
+
+func (r *userRepository) GetUserByID(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	start := time.Now()
+	var user domain.User
+	result := r.db.WithContext(ctx).Where("id = ?", id).First(&user)
+	r.metrics.RecordDBOperation(ctx, "select", "users", time.Since(start), 1)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &user, nil
+}
+
+func (r *userRepository) UpdatePassword(ctx context.Context, id uuid.UUID, passwordHash string) error {
+	start := time.Now()
+	err := r.db.WithContext(ctx).Model(&domain.User{}).Where("id = ?", id).Update("password", passwordHash).Error
+	r.metrics.RecordDBOperation(ctx, "update", "users", time.Since(start), 1)
+	return err
+}
+
+// MarkEmailVerified records when the email was verified.
+func (r *userRepository) MarkEmailVerified(ctx context.Context, id uuid.UUID, verifiedAt time.Time) error {
+	start := time.Now()
+	err := r.db.WithContext(ctx).Model(&domain.User{}).
+		Where("id = ? AND email_verified_at IS NULL", id).
+		Update("email_verified_at", verifiedAt).Error
+	r.metrics.RecordDBOperation(ctx, "update", "users", time.Since(start), 1)
+	return err
+}
+
+func (r *userRepository) UpdateName(ctx context.Context, id uuid.UUID, name string) error {
+	start := time.Now()
+	err := r.db.WithContext(ctx).Model(&domain.User{}).Where("id = ?", id).Update("name", name).Error
+	r.metrics.RecordDBOperation(ctx, "update", "users", time.Since(start), 1)
+	return err
+}
+
+// SetPendingEmail records the address the user wants to change to.
+func (r *userRepository) SetPendingEmail(ctx context.Context, id uuid.UUID, email string) error {
+	start := time.Now()
+	err := r.db.WithContext(ctx).Model(&domain.User{}).Where("id = ?", id).Update("pending_email", email).Error
+	r.metrics.RecordDBOperation(ctx, "update", "users", time.Since(start), 1)
+	return err
+}
+
+// ConfirmPendingEmail makes the pending email the verified email, unless a
+// newer change has replaced it.
+func (r *userRepository) ConfirmPendingEmail(ctx context.Context, id uuid.UUID, email string, verifiedAt time.Time) error {
+	start := time.Now()
+	err := r.db.WithContext(ctx).Model(&domain.User{}).Where("id = ? AND pending_email = ?", id, email).
+		Updates(map[string]any{"email": email, "pending_email": nil, "email_verified_at": verifiedAt}).Error
+	r.metrics.RecordDBOperation(ctx, "update", "users", time.Since(start), 1)
+	return err
+}
+
+// DeleteAccount soft-deletes the user with their hangouts, activities and memories
+// at the given time, so the trash purge removes them and their files once the
+// retention has passed.
+func (r *userRepository) DeleteAccount(ctx context.Context, id uuid.UUID, at time.Time) error {
+	start := time.Now()
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, table := range []string{"hangouts", "activities", "memories"} {
+			if err := tx.Exec("UPDATE `"+table+"` SET `deleted_at` = ? WHERE `user_id` = ? AND `deleted_at` IS NULL", at, id).Error; err != nil {
+				return err
+			}
+		}
+		// deliveries reference the subscriptions deleted below
+		if err := tx.Exec("DELETE FROM `webhook_deliveries` WHERE `subscription_id` IN (SELECT `id` FROM `webhook_subscriptions` WHERE `user_id` = ?)", id).Error; err != nil {
+			return err
+		}
+		for _, table := range []string{"personal_access_tokens", "user_identities", "totp_credentials", "recovery_codes", "webhook_subscriptions", "data_exports"} {
+			if err := tx.Exec("DELETE FROM `"+table+"` WHERE `user_id` = ?", id).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Exec("UPDATE `users` SET `email` = ?, `password` = '', `deleted_at` = ? WHERE `id` = ?",
+			fmt.Sprintf(constants.DeletedUserEmailFormat, id), at, id).Error
+	})
+	r.metrics.RecordDBOperation(ctx, "delete", "users", time.Since(start), 1)
+	return err
+}
+
+// SearchUsers returns the users whose email or name contains query, newest first,
+// fetching one extra row so the caller can tell whether there are more.
+func (r *userRepository) SearchUsers(ctx context.Context, query string, pagination *dto.CursorPagination) ([]domain.User, error) {
+	start := time.Now()
+	var users []domain.User
+
+	db := r.db.WithContext(ctx).Model(&domain.User{})
+	if query != "" {
+		pattern := "%" + likeEscaper.Replace(query) + "%"
+		db = db.Where("email LIKE ? OR name LIKE ?", pattern, pattern)
+	}
+
+	if pagination.AfterID != nil {
+		var cursorItem domain.User
+		if err := r.db.WithContext(ctx).First(&cursorItem, "id = ?", *pagination.AfterID).Error; err != nil {
+			return nil, apperrors.ErrInvalidCursorPagination
+		}
+
+		db = db.Where(
+			"(created_at < ?) OR (created_at = ? AND id < ?)",
+			cursorItem.CreatedAt, cursorItem.CreatedAt, cursorItem.ID,
+		)
+	}
+
+	err := db.
+		Order("created_at desc, id desc").
+		Limit(pagination.GetLimit() + 1).
+		Find(&users).Error
+	r.metrics.RecordDBOperation(ctx, "select", "users", time.Since(start), len(users))
+	if err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// SetDisabledAt disables the user at disabledAt, or enables them again when
+// disabledAt is nil.
+func (r *userRepository) SetDisabledAt(ctx context.Context, id uuid.UUID, disabledAt *time.Time) error {
+	start := time.Now()
+	err := r.db.WithContext(ctx).Model(&domain.User{}).Where("id = ?", id).Update("disabled_at", disabledAt).Error
+	r.metrics.RecordDBOperation(ctx, "update", "users", time.Since(start), 1)
+	return err
+}
+
+// PromoteToAdmin gives the admin role to the users with the given emails and
+// returns how many were promoted. Unknown emails are ignored.
+func (r *userRepository) PromoteToAdmin(ctx context.Context, emails []string) (int64, error) {
+	if len(emails) == 0 {
+		return 0, nil
+	}
+	start := time.Now()
+	result := r.db.WithContext(ctx).Model(&domain.User{}).
+		Where("email IN ? AND role <> ?", emails, constants.RoleAdmin).
+		Update("role", constants.RoleAdmin)
+	r.metrics.RecordDBOperation(ctx, "update", "users", time.Since(start), int(result.RowsAffected))
+	return result.RowsAffected, result.Error
+}
+
+// likeEscaper escapes the LIKE wildcards, so a search matches them literally.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
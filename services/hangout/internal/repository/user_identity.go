@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/otel"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"gorm.io/gorm"
+)
+
+type UserIdentityRepository interface {
+	WithTx(tx *gorm.DB) UserIdentityRepository
+	Create(ctx context.Context, identity *domain.UserIdentity) error
+	GetByProviderSubject(ctx context.Context, provider string, subject string) (*domain.UserIdentity, error)
+	ListByUserID(ctx context.Context, userID uuid.UUID) ([]domain.UserIdentity, error)
+	DeleteByUserAndProvider(ctx context.Context, userID uuid.UUID, provider string) (int64, error)
+}
+
+type userIdentityRepository struct {
+	db      *gorm.DB
+	metrics *otel.MetricsRecorder
+}
+
+func NewUserIdentityRepository(db *gorm.DB, metrics *otel.MetricsRecorder) UserIdentityRepository {
+	return &userIdentityRepository{db: db, metrics: metrics}
+}
+
+func (r *userIdentityRepository) WithTx(tx *gorm.DB) UserIdentityRepository {
+	return &userIdentityRepository{db: tx, metrics: r.metrics}
+}
+
+func (r *userIdentityRepository) Create(ctx context.Context, identity *domain.UserIdentity) error {
+	ctx, span := otel.StartRepositorySpan(ctx, "Create",
+		attribute.String("db.operation", "insert"),
+		attribute.String("db.table", "user_identities"),
+		attribute.String("identity.provider", identity.Provider),
+	)
+	defer span.End()
+
+	start := time.Now()
+	err := r.db.WithContext(ctx).Create(identity).Error
+	r.metrics.RecordDBOperation(ctx, "insert", "user_identities", time.Since(start), 1)
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+	} else {
+		span.SetStatusOk()
+	}
+	return err
+}
+
+func (r *userIdentityRepository) GetByProviderSubject(ctx context.Context, provider string, subject string) (*domain.UserIdentity, error) {
+	ctx, span := otel.StartRepositorySpan(ctx, "GetByProviderSubject",
+		attribute.String("db.operation", "select"),
+		attribute.String("db.table", "user_identities"),
+		attribute.String("identity.provider", provider),
+	)
+	defer span.End()
+
+	start := time.Now()
+	var identity domain.UserIdentity
+	err := r.db.WithContext(ctx).
+		Where("provider = ? AND subject = ?", provider, subject).
+		First(&identity).Error
+	r.metrics.RecordDBOperation(ctx, "select", "user_identities", time.Since(start), 1)
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetStatusOk()
+	return &identity, nil
+}
+
+// ListByUserID returns the user's identities ordered by provider.
+func (r *userIdentityRepository) ListByUserID(ctx context.Context, userID uuid.UUID) ([]domain.UserIdentity, error) {
+	ctx, span := otel.StartRepositorySpan(ctx, "ListByUserID",
+		attribute.String("db.operation", "select"),
+		attribute.String("db.table", "user_identities"),
+		attribute.String("user.id", userID.String()),
+	)
+	defer span.End()
+
+	start := time.Now()
+	var identities []domain.UserIdentity
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("provider ASC").
+		Find(&identities).Error
+	r.metrics.RecordDBOperation(ctx, "select", "user_identities", time.Since(start), len(identities))
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetStatusOk()
+	return identities, nil
+}
+
+func (r *userIdentityRepository) DeleteByUserAndProvider(ctx context.Context, userID uuid.UUID, provider string) (int64, error) {
+	ctx, span := otel.StartRepositorySpan(ctx, "DeleteByUserAndProvider",
+		attribute.String("db.operation", "delete"),
+		attribute.String("db.table", "user_identities"),
+		attribute.String("user.id", userID.String()),
+		attribute.String("identity.provider", provider),
+	)
+	defer span.End()
+
+	start := time.Now()
+	result := r.db.WithContext(ctx).
+		Where("user_id = ? AND provider = ?", userID, provider).
+		Delete(&domain.UserIdentity{})
+	r.metrics.RecordDBOperation(ctx, "delete", "user_identities", time.Since(start), int(result.RowsAffected))
+
+	if result.Error != nil {
+		_ = span.RecordErrorWithStatus(result.Error)
+		return 0, result.Error
+	}
+
+	span.SetStatusOk()
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,215 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	repo "github.com/Ernestgio/Hangout-Planner/services/hangout/internal/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestUserIdentityRepository_WithTx(t *testing.T) {
+	db, _ := newDBWithRegexp(t)
+	r := repo.NewUserIdentityRepository(db, nil)
+
+	txRepo := r.WithTx(db.Begin())
+	require.NotNil(t, txRepo)
+	require.NotEqual(t, r, txRepo)
+}
+
+func TestUserIdentityCreate_TableDriven(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		prepare   func(sqlmock.Sqlmock)
+		wantError bool
+	}{
+		{
+			name: "success",
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("INSERT INTO `user_identities`").WillReturnResult(sqlmock.NewResult(1, 1))
+				m.ExpectCommit()
+			},
+		},
+		{
+			name: "duplicate",
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("INSERT INTO `user_identities`").WillReturnError(errors.New("Duplicate entry"))
+				m.ExpectRollback()
+			},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newDBWithRegexp(t)
+			r := repo.NewUserIdentityRepository(db, nil)
+			tt.prepare(mock)
+
+			identity := &domain.UserIdentity{UserID: uuid.New(), Provider: "google", Subject: "123", Email: "ada@example.com"}
+			err := r.Create(ctx, identity)
+			if tt.wantError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.NotEqual(t, uuid.Nil, identity.ID)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUserIdentityGetByProviderSubject_TableDriven(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	tests := []struct {
+		name      string
+		prepare   func(sqlmock.Sqlmock)
+		wantError error
+	}{
+		{
+			name: "found",
+			prepare: func(m sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "provider", "subject", "user_id"}).
+					AddRow(uuid.New().String(), "google", "123", userID.String())
+				m.ExpectQuery("SELECT \\* FROM `user_identities` WHERE provider = \\? AND subject = \\? ORDER BY `user_identities`.`id` LIMIT \\?").
+					WithArgs("google", "123", 1).
+					WillReturnRows(rows)
+			},
+		},
+		{
+			name: "not found",
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT \\* FROM `user_identities`").WillReturnError(gorm.ErrRecordNotFound)
+			},
+			wantError: gorm.ErrRecordNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newDBWithRegexp(t)
+			r := repo.NewUserIdentityRepository(db, nil)
+			tt.prepare(mock)
+
+			identity, err := r.GetByProviderSubject(ctx, "google", "123")
+			if tt.wantError != nil {
+				require.ErrorIs(t, err, tt.wantError)
+				require.Nil(t, identity)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, userID, identity.UserID)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUserIdentityListByUserID_TableDriven(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	tests := []struct {
+		name      string
+		prepare   func(sqlmock.Sqlmock)
+		wantCount int
+		wantError bool
+	}{
+		{
+			name: "found",
+			prepare: func(m sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "provider", "subject", "user_id"}).
+					AddRow(uuid.New().String(), "github", "1", userID.String()).
+					AddRow(uuid.New().String(), "google", "2", userID.String())
+				m.ExpectQuery("SELECT \\* FROM `user_identities` WHERE user_id = \\? ORDER BY provider ASC").
+					WithArgs(userID).
+					WillReturnRows(rows)
+			},
+			wantCount: 2,
+		},
+		{
+			name: "db error",
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT \\* FROM `user_identities`").WillReturnError(errors.New("db error"))
+			},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newDBWithRegexp(t)
+			r := repo.NewUserIdentityRepository(db, nil)
+			tt.prepare(mock)
+
+			identities, err := r.ListByUserID(ctx, userID)
+			if tt.wantError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, identities, tt.wantCount)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUserIdentityDeleteByUserAndProvider_TableDriven(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	tests := []struct {
+		name        string
+		prepare     func(sqlmock.Sqlmock)
+		wantDeleted int64
+		wantError   bool
+	}{
+		{
+			name: "deleted",
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("DELETE FROM `user_identities` WHERE user_id = \\? AND provider = \\?").
+					WithArgs(userID, "google").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectCommit()
+			},
+			wantDeleted: 1,
+		},
+		{
+			name: "db error",
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("DELETE FROM `user_identities`").WillReturnError(errors.New("db error"))
+				m.ExpectRollback()
+			},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newDBWithRegexp(t)
+			r := repo.NewUserIdentityRepository(db, nil)
+			tt.prepare(mock)
+
+			deleted, err := r.DeleteByUserAndProvider(ctx, userID, "google")
+			if tt.wantError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.wantDeleted, deleted)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -2,6 +2,7 @@ package repository_test
 
 import (
 	"context"
+	"database/sql/driver"
 	"errors"
 	"testing"
 	"time"
@@ -11,7 +12,10 @@ import (
 	"github.com/stretchr/testify/require"
 	"gorm.io/gorm"
 
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/repository"
 )
 
@@ -46,8 +50,8 @@ func TestCreateUser_Success(t *testing.T) {
 	}
 
 	mock.ExpectBegin()
-	mock.ExpectExec("INSERT INTO `users` (`id`,`name`,`email`,`password`,`created_at`,`updated_at`,`deleted_at`) VALUES (?,?,?,?,?,?,?)").
-		WithArgs(sqlmock.AnyArg(), user.Name, user.Email, user.Password, sqlmock.AnyArg(), sqlmock.AnyArg(), nil).
+	mock.ExpectExec("INSERT INTO `users` (`id`,`name`,`email`,`password`,`email_verified_at`,`pending_email`,`role`,`disabled_at`,`created_at`,`updated_at`,`deleted_at`) VALUES (?,?,?,?,?,?,?,?,?,?,?)").
+		WithArgs(sqlmock.AnyArg(), user.Name, user.Email, user.Password, nil, nil, constants.RoleUser, nil, sqlmock.AnyArg(), sqlmock.AnyArg(), nil).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
 
@@ -69,8 +73,8 @@ func TestCreateUser_Error(t *testing.T) {
 	}
 
 	mock.ExpectBegin()
-	mock.ExpectExec("INSERT INTO `users` (`id`,`name`,`email`,`password`,`created_at`,`updated_at`,`deleted_at`) VALUES (?,?,?,?,?,?,?)").
-		WithArgs(sqlmock.AnyArg(), user.Name, user.Email, user.Password, sqlmock.AnyArg(), sqlmock.AnyArg(), nil).
+	mock.ExpectExec("INSERT INTO `users` (`id`,`name`,`email`,`password`,`email_verified_at`,`pending_email`,`role`,`disabled_at`,`created_at`,`updated_at`,`deleted_at`) VALUES (?,?,?,?,?,?,?,?,?,?,?)").
+		WithArgs(sqlmock.AnyArg(), user.Name, user.Email, user.Password, nil, nil, constants.RoleUser, nil, sqlmock.AnyArg(), sqlmock.AnyArg(), nil).
 		WillReturnError(dbError)
 	mock.ExpectRollback()
 
@@ -134,3 +138,336 @@ func TestGetUserByEmail_DBError(t *testing.T) {
 	require.Nil(t, user)
 	require.NoError(t, mock.ExpectationsWereMet())
 }
+
+func TestGetUserByID(t *testing.T) {
+	db, mock := setupDB(t)
+	repo := repository.NewUserRepository(db, nil)
+	ctx := context.Background()
+	id := uuid.New()
+
+	rows := sqlmock.NewRows([]string{"id", "name", "email"}).AddRow(id, "Found User", "found@example.com")
+	expectedSQL := "SELECT * FROM `users` WHERE id = ? AND `users`.`deleted_at` IS NULL ORDER BY `users`.`id` LIMIT ?"
+	mock.ExpectQuery(expectedSQL).WithArgs(id, 1).WillReturnRows(rows)
+	mock.ExpectQuery(expectedSQL).WithArgs(id, 1).WillReturnError(gorm.ErrRecordNotFound)
+
+	user, err := repo.GetUserByID(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, "found@example.com", user.Email)
+
+	user, err = repo.GetUserByID(ctx, id)
+	require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	require.Nil(t, user)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdatePassword(t *testing.T) {
+	db, mock := setupDB(t)
+	repo := repository.NewUserRepository(db, nil)
+	ctx := context.Background()
+	id := uuid.New()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE `users` SET `password`=?,`updated_at`=? WHERE id = ? AND `users`.`deleted_at` IS NULL").
+		WithArgs("new_hash", sqlmock.AnyArg(), id).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	require.NoError(t, repo.UpdatePassword(ctx, id, "new_hash"))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMarkEmailVerified(t *testing.T) {
+	db, mock := setupDB(t)
+	repo := repository.NewUserRepository(db, nil)
+	ctx := context.Background()
+	id := uuid.New()
+	verifiedAt := time.Now()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE `users` SET `email_verified_at`=?,`updated_at`=? WHERE (id = ? AND email_verified_at IS NULL) AND `users`.`deleted_at` IS NULL").
+		WithArgs(verifiedAt, sqlmock.AnyArg(), id).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	require.NoError(t, repo.MarkEmailVerified(ctx, id, verifiedAt))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateName(t *testing.T) {
+	db, mock := setupDB(t)
+	repo := repository.NewUserRepository(db, nil)
+	ctx := context.Background()
+	id := uuid.New()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE `users` SET `name`=?,`updated_at`=? WHERE id = ? AND `users`.`deleted_at` IS NULL").
+		WithArgs("Ernest G", sqlmock.AnyArg(), id).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	require.NoError(t, repo.UpdateName(ctx, id, "Ernest G"))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSetPendingEmail(t *testing.T) {
+	db, mock := setupDB(t)
+	repo := repository.NewUserRepository(db, nil)
+	ctx := context.Background()
+	id := uuid.New()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE `users` SET `pending_email`=?,`updated_at`=? WHERE id = ? AND `users`.`deleted_at` IS NULL").
+		WithArgs("new@example.com", sqlmock.AnyArg(), id).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	require.NoError(t, repo.SetPendingEmail(ctx, id, "new@example.com"))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestConfirmPendingEmail(t *testing.T) {
+	db, mock := setupDB(t)
+	repo := repository.NewUserRepository(db, nil)
+	ctx := context.Background()
+	id := uuid.New()
+	verifiedAt := time.Now()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE `users` SET `email`=?,`email_verified_at`=?,`pending_email`=?,`updated_at`=? WHERE (id = ? AND pending_email = ?) AND `users`.`deleted_at` IS NULL").
+		WithArgs("new@example.com", verifiedAt, nil, sqlmock.AnyArg(), id, "new@example.com").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	require.NoError(t, repo.ConfirmPendingEmail(ctx, id, "new@example.com", verifiedAt))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteAccount_TableDriven(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.New()
+	at := time.Now()
+	dbErr := errors.New("db error")
+
+	tests := []struct {
+		name      string
+		prepare   func(sqlmock.Sqlmock)
+		wantError bool
+	}{
+		{
+			name: "success",
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				for _, table := range []string{"hangouts", "activities", "memories"} {
+					m.ExpectExec("UPDATE `"+table+"` SET `deleted_at` = ? WHERE `user_id` = ? AND `deleted_at` IS NULL").
+						WithArgs(at, id).
+						WillReturnResult(sqlmock.NewResult(0, 2))
+				}
+				m.ExpectExec("DELETE FROM `webhook_deliveries` WHERE `subscription_id` IN (SELECT `id` FROM `webhook_subscriptions` WHERE `user_id` = ?)").
+					WithArgs(id).
+					WillReturnResult(sqlmock.NewResult(0, 3))
+				// credentials and subscriptions would keep the email and
+				// provider accounts tied to the deleted user
+				for _, table := range []string{"personal_access_tokens", "user_identities", "totp_credentials", "recovery_codes", "webhook_subscriptions", "data_exports"} {
+					m.ExpectExec("DELETE FROM `" + table + "` WHERE `user_id` = ?").
+						WithArgs(id).
+						WillReturnResult(sqlmock.NewResult(0, 1))
+				}
+				m.ExpectExec("UPDATE `users` SET `email` = ?, `password` = '', `deleted_at` = ? WHERE `id` = ?").
+					WithArgs("deleted-"+id.String()+"@deleted.invalid", at, id).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectCommit()
+			},
+		},
+		{
+			name: "db error rolls back",
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("UPDATE `hangouts` SET `deleted_at` = ? WHERE `user_id` = ? AND `deleted_at` IS NULL").
+					WithArgs(at, id).
+					WillReturnError(dbErr)
+				m.ExpectRollback()
+			},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := setupDB(t)
+			repo := repository.NewUserRepository(db, nil)
+			tt.prepare(mock)
+
+			err := repo.DeleteAccount(ctx, id, at)
+			if tt.wantError {
+				require.ErrorIs(t, err, dbErr)
+			} else {
+				require.NoError(t, err)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestSearchUsers_TableDriven(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name  string
+		query string
+		sql   string
+		args  []driver.Value
+	}{
+		{
+			name: "all users",
+			sql:  "SELECT * FROM `users` WHERE `users`.`deleted_at` IS NULL ORDER BY created_at desc, id desc LIMIT ?",
+			args: []driver.Value{3},
+		},
+		{
+			name:  "escapes wildcards",
+			query: "50%_off",
+			sql:   "SELECT * FROM `users` WHERE (email LIKE ? OR name LIKE ?) AND `users`.`deleted_at` IS NULL ORDER BY created_at desc, id desc LIMIT ?",
+			args:  []driver.Value{`%50\%\_off%`, `%50\%\_off%`, 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := setupDB(t)
+			repo := repository.NewUserRepository(db, nil)
+
+			mock.ExpectQuery(tt.sql).
+				WithArgs(tt.args...).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "email"}).AddRow(uuid.New(), "ernest@example.com"))
+
+			users, err := repo.SearchUsers(ctx, tt.query, &dto.CursorPagination{Limit: 2})
+			require.NoError(t, err)
+			require.Len(t, users, 1)
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestSearchUsers_Cursor(t *testing.T) {
+	db, mock := setupDB(t)
+	repo := repository.NewUserRepository(db, nil)
+	ctx := context.Background()
+	afterID := uuid.New()
+	createdAt := time.Now()
+
+	mock.ExpectQuery("SELECT * FROM `users` WHERE id = ? AND `users`.`deleted_at` IS NULL ORDER BY `users`.`id` LIMIT ?").
+		WithArgs(afterID, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(afterID, createdAt))
+	mock.ExpectQuery("SELECT * FROM `users` WHERE ((created_at < ?) OR (created_at = ? AND id < ?)) AND `users`.`deleted_at` IS NULL ORDER BY created_at desc, id desc LIMIT ?").
+		WithArgs(createdAt, createdAt, afterID, constants.DefaultLimit+1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	users, err := repo.SearchUsers(ctx, "", &dto.CursorPagination{AfterID: &afterID})
+	require.NoError(t, err)
+	require.Empty(t, users)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSearchUsers_InvalidCursor(t *testing.T) {
+	db, mock := setupDB(t)
+	repo := repository.NewUserRepository(db, nil)
+	ctx := context.Background()
+	afterID := uuid.New()
+
+	mock.ExpectQuery("SELECT * FROM `users` WHERE id = ? AND `users`.`deleted_at` IS NULL ORDER BY `users`.`id` LIMIT ?").
+		WithArgs(afterID, 1).
+		WillReturnError(gorm.ErrRecordNotFound)
+
+	_, err := repo.SearchUsers(ctx, "", &dto.CursorPagination{AfterID: &afterID})
+	require.ErrorIs(t, err, apperrors.ErrInvalidCursorPagination)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSetDisabledAt(t *testing.T) {
+	ctx := context.Background()
+	disabledAt := time.Now()
+
+	tests := []struct {
+		name       string
+		disabledAt *time.Time
+		arg        driver.Value
+	}{
+		{name: "disable", disabledAt: &disabledAt, arg: disabledAt},
+		{name: "enable", disabledAt: nil, arg: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := setupDB(t)
+			repo := repository.NewUserRepository(db, nil)
+			id := uuid.New()
+
+			mock.ExpectBegin()
+			mock.ExpectExec("UPDATE `users` SET `disabled_at`=?,`updated_at`=? WHERE id = ? AND `users`.`deleted_at` IS NULL").
+				WithArgs(tt.arg, sqlmock.AnyArg(), id).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectCommit()
+
+			require.NoError(t, repo.SetDisabledAt(ctx, id, tt.disabledAt))
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPromoteToAdmin_TableDriven(t *testing.T) {
+	ctx := context.Background()
+	dbErr := errors.New("db error")
+
+	tests := []struct {
+		name      string
+		emails    []string
+		prepare   func(sqlmock.Sqlmock)
+		want      int64
+		wantError bool
+	}{
+		{
+			name:    "no emails",
+			prepare: func(m sqlmock.Sqlmock) {},
+		},
+		{
+			name:   "promotes",
+			emails: []string{"a@example.com", "b@example.com"},
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("UPDATE `users` SET `role`=?,`updated_at`=? WHERE (email IN (?,?) AND role <> ?) AND `users`.`deleted_at` IS NULL").
+					WithArgs(constants.RoleAdmin, sqlmock.AnyArg(), "a@example.com", "b@example.com", constants.RoleAdmin).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectCommit()
+			},
+			want: 1,
+		},
+		{
+			name:   "db error",
+			emails: []string{"a@example.com"},
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("UPDATE `users` SET `role`=?,`updated_at`=? WHERE (email IN (?) AND role <> ?) AND `users`.`deleted_at` IS NULL").
+					WithArgs(constants.RoleAdmin, sqlmock.AnyArg(), "a@example.com", constants.RoleAdmin).
+					WillReturnError(dbErr)
+				m.ExpectRollback()
+			},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := setupDB(t)
+			repo := repository.NewUserRepository(db, nil)
+			tt.prepare(mock)
+
+			promoted, err := repo.PromoteToAdmin(ctx, tt.emails)
+			if tt.wantError {
+				require.ErrorIs(t, err, dbErr)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.want, promoted)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,303 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/otel"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"gorm.io/gorm"
+)
+
+type WebhookRepository interface {
+	CreateSubscription(ctx context.Context, subscription *domain.WebhookSubscription) error
+	CountSubscriptionsByUserID(ctx context.Context, userID uuid.UUID) (int64, error)
+	GetSubscriptionByID(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*domain.WebhookSubscription, error)
+	GetSubscriptionsByUserID(ctx context.Context, userID uuid.UUID) ([]domain.WebhookSubscription, error)
+	DeleteSubscription(ctx context.Context, id uuid.UUID) error
+	CreateDeliveries(ctx context.Context, deliveries []domain.WebhookDelivery) error
+	GetDeliveriesBySubscriptionID(ctx context.Context, subscriptionID uuid.UUID, pagination *dto.CursorPagination) ([]domain.WebhookDelivery, error)
+	GetDueDeliveries(ctx context.Context, now time.Time, limit int) ([]domain.WebhookDelivery, error)
+	ClaimDelivery(ctx context.Context, id uuid.UUID, dueAt time.Time, leaseUntil time.Time) (bool, error)
+	UpdateDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error
+}
+
+type webhookRepository struct {
+	db      *gorm.DB
+	metrics *otel.MetricsRecorder
+}
+
+func NewWebhookRepository(db *gorm.DB, metrics *otel.MetricsRecorder) WebhookRepository {
+	return &webhookRepository{db: db, metrics: metrics}
+}
+
+func (r *webhookRepository) CreateSubscription(ctx context.Context, subscription *domain.WebhookSubscription) error {
+	ctx, span := otel.StartRepositorySpan(ctx, "CreateSubscription",
+		attribute.String("db.operation", "insert"),
+		attribute.String("db.table", "webhook_subscriptions"),
+		attribute.String("user.id", subscription.UserID.String()),
+	)
+	defer span.End()
+
+	start := time.Now()
+	err := r.db.WithContext(ctx).Create(subscription).Error
+	r.metrics.RecordDBOperation(ctx, "insert", "webhook_subscriptions", time.Since(start), 1)
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+		return err
+	}
+
+	span.SetStatusOk()
+	return nil
+}
+
+func (r *webhookRepository) CountSubscriptionsByUserID(ctx context.Context, userID uuid.UUID) (int64, error) {
+	ctx, span := otel.StartRepositorySpan(ctx, "CountSubscriptionsByUserID",
+		attribute.String("db.operation", "select"),
+		attribute.String("db.table", "webhook_subscriptions"),
+		attribute.String("user.id", userID.String()),
+	)
+	defer span.End()
+
+	var count int64
+
+	start := time.Now()
+	err := r.db.WithContext(ctx).Model(&domain.WebhookSubscription{}).Where("user_id = ?", userID).Count(&count).Error
+	r.metrics.RecordDBOperation(ctx, "select", "webhook_subscriptions", time.Since(start), 1)
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+		return 0, err
+	}
+
+	span.SetStatusOk()
+	return count, nil
+}
+
+func (r *webhookRepository) GetSubscriptionByID(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*domain.WebhookSubscription, error) {
+	ctx, span := otel.StartRepositorySpan(ctx, "GetSubscriptionByID",
+		attribute.String("db.operation", "select"),
+		attribute.String("db.table", "webhook_subscriptions"),
+		attribute.String("webhook.id", id.String()),
+		attribute.String("user.id", userID.String()),
+	)
+	defer span.End()
+
+	var subscription domain.WebhookSubscription
+
+	start := time.Now()
+	err := r.db.WithContext(ctx).First(&subscription, "id = ? AND user_id = ?", id, userID).Error
+	r.metrics.RecordDBOperation(ctx, "select", "webhook_subscriptions", time.Since(start), 1)
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetStatusOk()
+	return &subscription, nil
+}
+
+func (r *webhookRepository) GetSubscriptionsByUserID(ctx context.Context, userID uuid.UUID) ([]domain.WebhookSubscription, error) {
+	ctx, span := otel.StartRepositorySpan(ctx, "GetSubscriptionsByUserID",
+		attribute.String("db.operation", "select"),
+		attribute.String("db.table", "webhook_subscriptions"),
+		attribute.String("user.id", userID.String()),
+	)
+	defer span.End()
+
+	var subscriptions []domain.WebhookSubscription
+
+	start := time.Now()
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("created_at asc, id asc").Find(&subscriptions).Error
+	r.metrics.RecordDBOperation(ctx, "select", "webhook_subscriptions", time.Since(start), len(subscriptions))
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("webhook.count", len(subscriptions)))
+	span.SetStatusOk()
+	return subscriptions, nil
+}
+
+// DeleteSubscription removes the subscription together with its delivery log,
+// including deliveries that are still pending.
+func (r *webhookRepository) DeleteSubscription(ctx context.Context, id uuid.UUID) error {
+	ctx, span := otel.StartRepositorySpan(ctx, "DeleteSubscription",
+		attribute.String("db.operation", "delete"),
+		attribute.String("db.table", "webhook_subscriptions"),
+		attribute.String("webhook.id", id.String()),
+	)
+	defer span.End()
+
+	start := time.Now()
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("subscription_id = ?", id).Delete(&domain.WebhookDelivery{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&domain.WebhookSubscription{}, "id = ?", id).Error
+	})
+	r.metrics.RecordDBOperation(ctx, "delete", "webhook_subscriptions", time.Since(start), 1)
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+		return err
+	}
+
+	span.SetStatusOk()
+	return nil
+}
+
+func (r *webhookRepository) CreateDeliveries(ctx context.Context, deliveries []domain.WebhookDelivery) error {
+	ctx, span := otel.StartRepositorySpan(ctx, "CreateDeliveries",
+		attribute.String("db.operation", "insert"),
+		attribute.String("db.table", "webhook_deliveries"),
+		attribute.Int("webhook.delivery_count", len(deliveries)),
+	)
+	defer span.End()
+
+	start := time.Now()
+	err := r.db.WithContext(ctx).Create(&deliveries).Error
+	r.metrics.RecordDBOperation(ctx, "insert", "webhook_deliveries", time.Since(start), len(deliveries))
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+		return err
+	}
+
+	span.SetStatusOk()
+	return nil
+}
+
+func (r *webhookRepository) GetDeliveriesBySubscriptionID(ctx context.Context, subscriptionID uuid.UUID, pagination *dto.CursorPagination) ([]domain.WebhookDelivery, error) {
+	ctx, span := otel.StartRepositorySpan(ctx, "GetDeliveriesBySubscriptionID",
+		attribute.String("db.operation", "select"),
+		attribute.String("db.table", "webhook_deliveries"),
+		attribute.String("webhook.id", subscriptionID.String()),
+		attribute.Int("pagination.limit", pagination.GetLimit()),
+	)
+	defer span.End()
+
+	start := time.Now()
+	var deliveries []domain.WebhookDelivery
+
+	query := r.db.WithContext(ctx).Model(&domain.WebhookDelivery{}).
+		Where("subscription_id = ?", subscriptionID)
+
+	if pagination.AfterID != nil {
+		var cursorItem domain.WebhookDelivery
+		if err := r.db.WithContext(ctx).First(&cursorItem, "id = ? AND subscription_id = ?", *pagination.AfterID, subscriptionID).Error; err != nil {
+			return nil, apperrors.ErrInvalidCursorPagination
+		}
+
+		query = query.Where(
+			"(created_at < ?) OR (created_at = ? AND id < ?)",
+			cursorItem.CreatedAt, cursorItem.CreatedAt, cursorItem.ID,
+		)
+	}
+
+	err := query.
+		Order("created_at desc, id desc").
+		Limit(pagination.GetLimit() + 1).
+		Find(&deliveries).Error
+	r.metrics.RecordDBOperation(ctx, "select", "webhook_deliveries", time.Since(start), len(deliveries))
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("webhook.delivery_count", len(deliveries)))
+	span.SetStatusOk()
+	return deliveries, nil
+}
+
+// GetDueDeliveries returns pending deliveries whose next attempt is due, with
+// their subscription loaded.
+func (r *webhookRepository) GetDueDeliveries(ctx context.Context, now time.Time, limit int) ([]domain.WebhookDelivery, error) {
+	ctx, span := otel.StartRepositorySpan(ctx, "GetDueDeliveries",
+		attribute.String("db.operation", "select"),
+		attribute.String("db.table", "webhook_deliveries"),
+		attribute.Int("batch.limit", limit),
+	)
+	defer span.End()
+
+	start := time.Now()
+	var deliveries []domain.WebhookDelivery
+
+	err := r.db.WithContext(ctx).
+		Preload("Subscription").
+		Where("status = ? AND next_attempt_at <= ?", constants.WebhookDeliveryPending, now).
+		Order("next_attempt_at asc").
+		Limit(limit).
+		Find(&deliveries).Error
+	r.metrics.RecordDBOperation(ctx, "select", "webhook_deliveries", time.Since(start), len(deliveries))
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("webhook.delivery_count", len(deliveries)))
+	span.SetStatusOk()
+	return deliveries, nil
+}
+
+// ClaimDelivery pushes a due delivery's next attempt to leaseUntil so other
+// instances skip it while it is being sent.
+func (r *webhookRepository) ClaimDelivery(ctx context.Context, id uuid.UUID, dueAt time.Time, leaseUntil time.Time) (bool, error) {
+	ctx, span := otel.StartRepositorySpan(ctx, "ClaimDelivery",
+		attribute.String("db.operation", "update"),
+		attribute.String("db.table", "webhook_deliveries"),
+		attribute.String("webhook.delivery_id", id.String()),
+	)
+	defer span.End()
+
+	start := time.Now()
+	result := r.db.WithContext(ctx).Model(&domain.WebhookDelivery{}).
+		Where("id = ? AND status = ? AND next_attempt_at = ?", id, constants.WebhookDeliveryPending, dueAt).
+		Update("next_attempt_at", leaseUntil)
+	r.metrics.RecordDBOperation(ctx, "update", "webhook_deliveries", time.Since(start), int(result.RowsAffected))
+
+	if result.Error != nil {
+		_ = span.RecordErrorWithStatus(result.Error)
+		return false, result.Error
+	}
+
+	claimed := result.RowsAffected > 0
+	span.SetAttributes(attribute.Bool("webhook.claimed", claimed))
+	span.SetStatusOk()
+	return claimed, nil
+}
+
+func (r *webhookRepository) UpdateDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	ctx, span := otel.StartRepositorySpan(ctx, "UpdateDelivery",
+		attribute.String("db.operation", "update"),
+		attribute.String("db.table", "webhook_deliveries"),
+		attribute.String("webhook.delivery_id", delivery.ID.String()),
+		attribute.String("webhook.delivery_status", delivery.Status),
+	)
+	defer span.End()
+
+	start := time.Now()
+	err := r.db.WithContext(ctx).Model(delivery).
+		Select("status", "attempts", "next_attempt_at", "last_attempt_at", "response_status", "last_error", "updated_at").
+		Updates(delivery).Error
+	r.metrics.RecordDBOperation(ctx, "update", "webhook_deliveries", time.Since(start), 1)
+
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+		return err
+	}
+
+	span.SetStatusOk()
+	return nil
+}
@@ -0,0 +1,217 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
+	repo "github.com/Ernestgio/Hangout-Planner/services/hangout/internal/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestWebhookCreateSubscription_TableDriven(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		prepare   func(sqlmock.Sqlmock)
+		wantError bool
+	}{
+		{
+			name: "success",
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("INSERT INTO `webhook_subscriptions`").WillReturnResult(sqlmock.NewResult(1, 1))
+				m.ExpectCommit()
+			},
+		},
+		{
+			name: "db error",
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("INSERT INTO `webhook_subscriptions`").WillReturnError(errors.New("db error"))
+				m.ExpectRollback()
+			},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newDBWithRegexp(t)
+			r := repo.NewWebhookRepository(db, nil)
+			tt.prepare(mock)
+			subscription := &domain.WebhookSubscription{URL: "https://hooks.example.com", Secret: "s", EventTypes: "memory.created", UserID: uuid.New()}
+			err := r.CreateSubscription(ctx, subscription)
+			if tt.wantError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.NotEqual(t, uuid.Nil, subscription.ID)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestWebhookGetSubscriptionByID(t *testing.T) {
+	ctx := context.Background()
+	db, mock := newDBWithRegexp(t)
+	r := repo.NewWebhookRepository(db, nil)
+	id, userID := uuid.New(), uuid.New()
+
+	mock.ExpectQuery("SELECT \\* FROM `webhook_subscriptions` WHERE id = \\? AND user_id = \\?").
+		WithArgs(id, userID, 1).
+		WillReturnError(gorm.ErrRecordNotFound)
+
+	_, err := r.GetSubscriptionByID(ctx, id, userID)
+	require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWebhookDeleteSubscription_TableDriven(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		prepare   func(sqlmock.Sqlmock, uuid.UUID)
+		wantError bool
+	}{
+		{
+			name: "removes deliveries then subscription",
+			prepare: func(m sqlmock.Sqlmock, id uuid.UUID) {
+				m.ExpectBegin()
+				m.ExpectExec("DELETE FROM `webhook_deliveries` WHERE subscription_id = \\?").WithArgs(id).WillReturnResult(sqlmock.NewResult(0, 3))
+				m.ExpectExec("DELETE FROM `webhook_subscriptions` WHERE id = \\?").WithArgs(id).WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectCommit()
+			},
+		},
+		{
+			name: "db error rolls back",
+			prepare: func(m sqlmock.Sqlmock, id uuid.UUID) {
+				m.ExpectBegin()
+				m.ExpectExec("DELETE FROM `webhook_deliveries`").WithArgs(id).WillReturnError(errors.New("db error"))
+				m.ExpectRollback()
+			},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newDBWithRegexp(t)
+			r := repo.NewWebhookRepository(db, nil)
+			id := uuid.New()
+			tt.prepare(mock, id)
+			err := r.DeleteSubscription(ctx, id)
+			if tt.wantError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestWebhookGetDeliveriesBySubscriptionID_InvalidCursor(t *testing.T) {
+	ctx := context.Background()
+	db, mock := newDBWithRegexp(t)
+	r := repo.NewWebhookRepository(db, nil)
+	subscriptionID, afterID := uuid.New(), uuid.New()
+
+	mock.ExpectQuery("SELECT \\* FROM `webhook_deliveries` WHERE id = \\? AND subscription_id = \\?").
+		WithArgs(afterID, subscriptionID, 1).
+		WillReturnError(gorm.ErrRecordNotFound)
+
+	_, err := r.GetDeliveriesBySubscriptionID(ctx, subscriptionID, &dto.CursorPagination{AfterID: &afterID})
+	require.ErrorIs(t, err, apperrors.ErrInvalidCursorPagination)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWebhookGetDueDeliveries(t *testing.T) {
+	ctx := context.Background()
+	db, mock := newDBWithRegexp(t)
+	r := repo.NewWebhookRepository(db, nil)
+	now := time.Now()
+	subscriptionID := uuid.New()
+
+	mock.ExpectQuery("SELECT \\* FROM `webhook_deliveries` WHERE status = \\? AND next_attempt_at <= \\? ORDER BY next_attempt_at asc LIMIT \\?").
+		WithArgs(constants.WebhookDeliveryPending, now, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "status", "subscription_id"}).AddRow(uuid.New(), constants.WebhookDeliveryPending, subscriptionID))
+	mock.ExpectQuery("SELECT \\* FROM `webhook_subscriptions` WHERE `webhook_subscriptions`.`id` = \\?").
+		WithArgs(subscriptionID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "url"}).AddRow(subscriptionID, "https://hooks.example.com"))
+
+	deliveries, err := r.GetDueDeliveries(ctx, now, 10)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	require.Equal(t, "https://hooks.example.com", deliveries[0].Subscription.URL)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWebhookClaimDelivery_TableDriven(t *testing.T) {
+	ctx := context.Background()
+	dueAt := time.Now()
+	leaseUntil := dueAt.Add(time.Minute)
+
+	tests := []struct {
+		name        string
+		rows        int64
+		wantClaimed bool
+	}{
+		{name: "claimed", rows: 1, wantClaimed: true},
+		{name: "claimed by another instance", rows: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newDBWithRegexp(t)
+			r := repo.NewWebhookRepository(db, nil)
+			id := uuid.New()
+
+			mock.ExpectBegin()
+			mock.ExpectExec("UPDATE `webhook_deliveries` SET `next_attempt_at`=\\?,`updated_at`=\\? WHERE id = \\? AND status = \\? AND next_attempt_at = \\?").
+				WithArgs(leaseUntil, AnyTime{}, id, constants.WebhookDeliveryPending, dueAt).
+				WillReturnResult(sqlmock.NewResult(0, tt.rows))
+			mock.ExpectCommit()
+
+			claimed, err := r.ClaimDelivery(ctx, id, dueAt, leaseUntil)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantClaimed, claimed)
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestWebhookUpdateDelivery(t *testing.T) {
+	ctx := context.Background()
+	db, mock := newDBWithRegexp(t)
+	r := repo.NewWebhookRepository(db, nil)
+	now := time.Now()
+	status := 204
+	delivery := &domain.WebhookDelivery{
+		ID:             uuid.New(),
+		Status:         constants.WebhookDeliverySucceeded,
+		Attempts:       1,
+		LastAttemptAt:  &now,
+		ResponseStatus: &status,
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE `webhook_deliveries` SET `status`=\\?,`attempts`=\\?,`next_attempt_at`=\\?,`last_attempt_at`=\\?,`response_status`=\\?,`last_error`=\\?,`updated_at`=\\? WHERE `id` = \\?").
+		WithArgs(constants.WebhookDeliverySucceeded, 1, nil, now, status, "", AnyTime{}, delivery.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	require.NoError(t, r.UpdateDelivery(ctx, delivery))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -9,51 +9,200 @@ import (
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/handlers"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/http/response"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/middlewares"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/otel"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/ratelimit"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/services"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/utils"
 	"github.com/labstack/echo/v4"
 	echoSwagger "github.com/swaggo/echo-swagger"
 )
 
-func NewRouter(e *echo.Echo, cfg *config.Config, responseBuilder *response.Builder, authHandler handlers.AuthHandler, hangoutHandler handlers.HangoutHandler, activityHandler handlers.ActivityHandler, memoryHandler handlers.MemoryHandler) {
+func NewRouter(e *echo.Echo, cfg *config.Config, responseBuilder *response.Builder, authHandler handlers.AuthHandler, mfaHandler handlers.MFAHandler, hangoutHandler handlers.HangoutHandler, activityHandler handlers.ActivityHandler, memoryHandler handlers.MemoryHandler, trashHandler handlers.TrashHandler, eventsHandler handlers.EventsHandler, webhookHandler handlers.WebhookHandler, notificationHandler handlers.NotificationHandler, commentHandler handlers.CommentHandler, albumHandler handlers.AlbumHandler, shareLinkHandler handlers.ShareLinkHandler, jwksHandler handlers.JWKSHandler, sessionHandler handlers.SessionHandler, tokenHandler handlers.PersonalAccessTokenHandler, accountHandler handlers.AccountHandler, exportHandler handlers.DataExportHandler, adminHandler handlers.AdminHandler, jwtUtils utils.JWTUtils, idempotencyService services.IdempotencyService, sessionService services.SessionService, tokenService services.PersonalAccessTokenService, adminService services.AdminService, authGuard *ratelimit.Guard, metricsRecorder *otel.MetricsRecorder) {
 	e.GET(constants.HealthCheckRoute, func(c echo.Context) error {
 		return c.String(http.StatusOK, "OK")
 	})
 
 	e.GET(constants.SwaggerRoute, echoSwagger.WrapHandler)
+	e.GET(constants.JWKSRoute, jwksHandler.GetJWKS)
+
+	idempotency := middlewares.Idempotency(idempotencyService, responseBuilder)
+	requireJWT := middlewares.JWT(jwtUtils, responseBuilder)
+	userContext := middlewares.UserContextMiddleware(sessionService, responseBuilder)
+	// tokenAuth accepts personal access tokens holding the scopes next to
+	// signed in users.
+	tokenAuth := func(readScope string, writeScope string) []echo.MiddlewareFunc {
+		return []echo.MiddlewareFunc{
+			middlewares.PersonalAccessToken(tokenService, responseBuilder, readScope, writeScope),
+			requireJWT,
+			userContext,
+		}
+	}
 
 	// Auth routes
 	authRoutes := e.Group(constants.AuthRoutes)
+	authRoutes.Use(middlewares.AuthRateLimit(authGuard, responseBuilder, metricsRecorder))
 	authRoutes.POST("/signup", authHandler.SignUp)
-	authRoutes.POST("/signin", authHandler.SignIn)
+	authRoutes.POST("/signin", authHandler.SignIn, middlewares.SignInLockout(authGuard, responseBuilder, metricsRecorder))
+	authRoutes.POST("/verify-email", authHandler.VerifyEmail)
+	authRoutes.POST("/forgot-password", authHandler.ForgotPassword)
+	authRoutes.POST("/reset-password", authHandler.ResetPassword)
+	authRoutes.POST("/resend-verification", authHandler.ResendVerification, requireJWT, userContext)
+	authRoutes.GET("/oidc/providers", authHandler.ListOIDCProviders)
+	authRoutes.GET("/oidc/:provider/authorize", authHandler.OIDCAuthorize)
+	authRoutes.POST("/oidc/callback", authHandler.OIDCCallback)
+
+	authRoutes.POST("/mfa/challenge", authHandler.MFAChallenge)
+
+	mfaRoutes := authRoutes.Group("/mfa", requireJWT, userContext)
+	mfaRoutes.GET("", mfaHandler.GetStatus)
+	mfaRoutes.POST("/totp", mfaHandler.EnrollTOTP)
+	mfaRoutes.POST("/totp/confirm", mfaHandler.ConfirmTOTP)
+	mfaRoutes.POST("/totp/disable", mfaHandler.DisableTOTP)
+	mfaRoutes.POST("/recovery-codes", mfaHandler.RegenerateRecoveryCodes)
+
+	identityRoutes := authRoutes.Group("/identities", requireJWT, userContext)
+	identityRoutes.GET("", authHandler.ListIdentities)
+	identityRoutes.GET("/:provider/authorize", authHandler.LinkIdentityAuthorize)
+	identityRoutes.POST("/callback", authHandler.LinkIdentity)
+	identityRoutes.DELETE("/:provider", authHandler.UnlinkIdentity)
+
+	// signed in user routes
+	meRoutes := e.Group(constants.MeRoutes)
+	meRoutes.Use(requireJWT)
+	meRoutes.Use(userContext)
+	meRoutes.GET("", accountHandler.GetProfile)
+	meRoutes.PUT("", accountHandler.UpdateProfile)
+	meRoutes.DELETE("", accountHandler.DeleteAccount)
+	meRoutes.POST("/password", accountHandler.ChangePassword)
+	meRoutes.POST("/email", accountHandler.ChangeEmail)
+	meRoutes.GET("/sessions", sessionHandler.ListSessions)
+	meRoutes.DELETE("/sessions", sessionHandler.RevokeAllSessions)
+	meRoutes.DELETE("/sessions/:session_id", sessionHandler.RevokeSession)
+	meRoutes.GET("/tokens", tokenHandler.ListTokens)
+	meRoutes.POST("/tokens", tokenHandler.CreateToken)
+	meRoutes.DELETE("/tokens/:token_id", tokenHandler.RevokeToken)
+	meRoutes.POST("/exports", exportHandler.RequestExport)
+	meRoutes.GET("/exports/:export_id", exportHandler.GetExport)
+
+	// admin routes, signed in admins only; personal access tokens are not accepted
+	adminRoutes := e.Group(constants.AdminRoutes)
+	adminRoutes.Use(requireJWT)
+	adminRoutes.Use(userContext)
+	adminRoutes.Use(middlewares.RequireAdmin(adminService, responseBuilder))
+	adminRoutes.GET("/users", adminHandler.SearchUsers)
+	adminRoutes.POST("/users/:user_id/disable", adminHandler.DisableUser)
+	adminRoutes.POST("/users/:user_id/enable", adminHandler.EnableUser)
+	adminRoutes.POST("/users/:user_id/password-reset", adminHandler.ForcePasswordReset)
+	adminRoutes.GET("/hangouts/:hangout_id", adminHandler.GetHangout)
+	adminRoutes.POST("/memories/:memory_id/hide", adminHandler.HideMemory)
+	adminRoutes.POST("/memories/:memory_id/unhide", adminHandler.UnhideMemory)
+	adminRoutes.DELETE("/memories/:memory_id", adminHandler.RemoveMemory)
+	adminRoutes.GET("/audit-logs", adminHandler.ListAuditLogs)
 
 	// hangout routes
-	hangoutRoutes := e.Group(constants.HangoutRoutes)
-	hangoutRoutes.Use(middlewares.JWT(cfg, responseBuilder))
-	hangoutRoutes.Use(middlewares.UserContextMiddleware)
-	hangoutRoutes.POST("/", hangoutHandler.CreateHangout)
+	hangoutRoutes := e.Group(constants.HangoutRoutes, tokenAuth(constants.ScopeHangoutsRead, constants.ScopeHangoutsWrite)...)
+	hangoutRoutes.POST("/", hangoutHandler.CreateHangout, idempotency)
 	hangoutRoutes.PUT("/:hangout_id", hangoutHandler.UpdateHangout)
+	hangoutRoutes.PATCH("/:hangout_id", hangoutHandler.PatchHangout)
 	hangoutRoutes.GET("/:hangout_id", hangoutHandler.GetHangoutByID)
 	hangoutRoutes.DELETE("/:hangout_id", hangoutHandler.DeleteHangout)
 	hangoutRoutes.POST("/list", hangoutHandler.GetHangoutsByUserID)
+	hangoutRoutes.POST("/batch", hangoutHandler.BatchHangouts, idempotency)
+	hangoutRoutes.POST("/:hangout_id/restore", trashHandler.RestoreHangout)
+	hangoutRoutes.GET("/:hangout_id/events", eventsHandler.StreamHangoutEvents)
 
 	// activity routes
-	activityRoutes := e.Group(constants.ActivityRoutes)
-	activityRoutes.Use(middlewares.JWT(cfg, responseBuilder))
-	activityRoutes.Use(middlewares.UserContextMiddleware)
+	activityRoutes := e.Group(constants.ActivityRoutes, tokenAuth(constants.ScopeActivitiesRead, constants.ScopeActivitiesWrite)...)
 	activityRoutes.POST("/", activityHandler.CreateActivity)
 	activityRoutes.PUT("/:activity_id", activityHandler.UpdateActivity)
 	activityRoutes.GET("/:activity_id", activityHandler.GetActivityByID)
 	activityRoutes.DELETE("/:activity_id", activityHandler.DeleteActivity)
 	activityRoutes.GET("/", activityHandler.GetAllActivities)
 
-	// memory routes (nested under hangouts for create/list)
-	hangoutRoutes.POST("/:hangout_id/memories/upload-urls", memoryHandler.GenerateUploadURLs)
-	hangoutRoutes.POST("/:hangout_id/memories/confirm-upload", memoryHandler.ConfirmUpload)
-	hangoutRoutes.GET("/:hangout_id/memories", memoryHandler.ListMemories)
+	// memory routes (nested under hangouts for create/list), albums and
+	// archives share the memory scopes
+	hangoutMemoryRoutes := e.Group(constants.HangoutRoutes, tokenAuth(constants.ScopeMemoriesRead, constants.ScopeMemoriesWrite)...)
+	hangoutMemoryRoutes.POST("/:hangout_id/memories/upload-urls", memoryHandler.GenerateUploadURLs, idempotency)
+	hangoutMemoryRoutes.POST("/:hangout_id/memories/confirm-upload", memoryHandler.ConfirmUpload)
+	hangoutMemoryRoutes.GET("/:hangout_id/memories", memoryHandler.ListMemories)
+	hangoutMemoryRoutes.POST("/:hangout_id/archives", memoryHandler.CreateArchive)
+	hangoutMemoryRoutes.GET("/:hangout_id/archives/:archive_id", memoryHandler.GetArchive)
 
 	// memory routes (flat for single resource operations)
-	memoryRoutes := e.Group(constants.MemoryRoutes)
-	memoryRoutes.Use(middlewares.JWT(cfg, responseBuilder))
-	memoryRoutes.Use(middlewares.UserContextMiddleware)
+	memoryRoutes := e.Group(constants.MemoryRoutes, tokenAuth(constants.ScopeMemoriesRead, constants.ScopeMemoriesWrite)...)
 	memoryRoutes.GET("/:memory_id", memoryHandler.GetMemory)
+	memoryRoutes.PATCH("/:memory_id", memoryHandler.PatchMemory)
+	memoryRoutes.POST("/:memory_id/reactions", memoryHandler.AddReaction)
+	memoryRoutes.DELETE("/:memory_id/reactions", memoryHandler.RemoveReaction)
 	memoryRoutes.DELETE("/:memory_id", memoryHandler.DeleteMemory)
+	memoryRoutes.POST("/:memory_id/restore", trashHandler.RestoreMemory)
+	memoryRoutes.POST("/:memory_id/move", albumHandler.MoveMemory)
+
+	// album routes (nested under hangouts for create/list)
+	hangoutMemoryRoutes.GET("/:hangout_id/albums", albumHandler.ListAlbums)
+	hangoutMemoryRoutes.POST("/:hangout_id/albums", albumHandler.CreateAlbum)
+
+	// album routes (flat for single resource operations)
+	albumRoutes := e.Group(constants.AlbumRoutes, tokenAuth(constants.ScopeMemoriesRead, constants.ScopeMemoriesWrite)...)
+	albumRoutes.PATCH("/:album_id", albumHandler.PatchAlbum)
+	albumRoutes.DELETE("/:album_id", albumHandler.DeleteAlbum)
+
+	// share link routes (nested under hangouts for create/list), signed in
+	// users only
+	hangoutShareLinkRoutes := e.Group(constants.HangoutRoutes, requireJWT, userContext)
+	hangoutShareLinkRoutes.GET("/:hangout_id/share-links", shareLinkHandler.ListShareLinks)
+	hangoutShareLinkRoutes.POST("/:hangout_id/share-links", shareLinkHandler.CreateShareLink)
+
+	// share link routes (flat for single resource operations)
+	shareLinkRoutes := e.Group(constants.ShareLinkRoutes)
+	shareLinkRoutes.Use(requireJWT)
+	shareLinkRoutes.Use(userContext)
+	shareLinkRoutes.DELETE("/:share_link_id", shareLinkHandler.RevokeShareLink)
+	shareLinkRoutes.GET("/:share_link_id/accesses", shareLinkHandler.ListAccesses)
+
+	// public share routes, no account needed
+	publicShareRoutes := e.Group(constants.PublicShareRoutes)
+	publicShareRoutes.Use(middlewares.ShareRateLimit(cfg.ShareConfig, responseBuilder))
+	publicShareRoutes.GET("/:token", shareLinkHandler.GetSharedHangout)
+	publicShareRoutes.GET("/:token/memories", shareLinkHandler.ListSharedMemories)
+
+	// comment routes (nested under hangouts for create/list)
+	hangoutCommentRoutes := e.Group(constants.HangoutRoutes, tokenAuth(constants.ScopeCommentsRead, constants.ScopeCommentsWrite)...)
+	hangoutCommentRoutes.GET("/:hangout_id/comments", commentHandler.ListComments)
+	hangoutCommentRoutes.POST("/:hangout_id/comments", commentHandler.CreateComment)
+
+	// comment routes (flat for single resource operations)
+	commentRoutes := e.Group(constants.CommentRoutes, tokenAuth(constants.ScopeCommentsRead, constants.ScopeCommentsWrite)...)
+	commentRoutes.PUT("/:comment_id", commentHandler.UpdateComment)
+	commentRoutes.DELETE("/:comment_id", commentHandler.DeleteComment)
+
+	// trash routes
+	trashRoutes := e.Group(constants.TrashRoutes)
+	trashRoutes.Use(requireJWT)
+	trashRoutes.Use(userContext)
+	trashRoutes.GET("/hangouts", trashHandler.ListDeletedHangouts)
+	trashRoutes.GET("/memories", trashHandler.ListDeletedMemories)
+
+	// webhook routes
+	webhookRoutes := e.Group(constants.WebhookRoutes)
+	webhookRoutes.Use(requireJWT)
+	webhookRoutes.Use(userContext)
+	webhookRoutes.POST("/", webhookHandler.CreateWebhook)
+	webhookRoutes.GET("/", webhookHandler.ListWebhooks)
+	webhookRoutes.GET("/:webhook_id", webhookHandler.GetWebhook)
+	webhookRoutes.DELETE("/:webhook_id", webhookHandler.DeleteWebhook)
+	webhookRoutes.GET("/:webhook_id/deliveries", webhookHandler.ListDeliveries)
+	webhookRoutes.POST("/:webhook_id/test", webhookHandler.SendTestEvent)
+
+	// notification routes
+	notificationRoutes := e.Group(constants.NotificationRoutes)
+	notificationRoutes.Use(requireJWT)
+	notificationRoutes.Use(userContext)
+	notificationRoutes.GET("/", notificationHandler.ListNotifications)
+	notificationRoutes.GET("/unread-count", notificationHandler.GetUnreadCount)
+	notificationRoutes.POST("/read-all", notificationHandler.MarkAllRead)
+	notificationRoutes.POST("/:notification_id/read", notificationHandler.MarkRead)
+	notificationRoutes.POST("/:notification_id/unread", notificationHandler.MarkUnread)
+	notificationRoutes.GET("/preferences", notificationHandler.GetPreferences)
+	notificationRoutes.PUT("/preferences", notificationHandler.UpdatePreferences)
 }
@@ -0,0 +1,272 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants/logmsg"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/mailer"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/mapper"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/otel"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/repository"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/utils"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"gorm.io/gorm"
+)
+
+const (
+	emailChangedSubject  = "Your email address is being changed"
+	emailChangedTemplate = "Hi %s,\n\nA change of the email address of your account to %s was requested. It takes effect once the link sent to the new address is followed. If you did not do this, reset your password and contact support.\n"
+)
+
+// AccountService lets signed in users manage their own profile and
+// credentials, and delete their account.
+type AccountService interface {
+	GetProfile(ctx context.Context, userID uuid.UUID) (*dto.UserResponse, error)
+	UpdateProfile(ctx context.Context, userID uuid.UUID, req *dto.UpdateProfileRequest) (*dto.UserResponse, error)
+	// ChangePassword signs out every session and returns an access token
+	// for a new one, so only the calling device stays signed in.
+	ChangePassword(ctx context.Context, userID uuid.UUID, req *dto.ChangePasswordRequest) (*dto.SignInResponse, error)
+	// ChangeEmail stores the new email as pending, sends it a verification link
+	// and lets the old address know.
+	ChangeEmail(ctx context.Context, userID uuid.UUID, req *dto.ChangeEmailRequest) (*dto.UserResponse, error)
+	// DeleteAccount moves the account with its hangouts, activities and
+	// memories to the trash, which purges them and their files once the trash
+	// retention has passed, and signs the user out.
+	DeleteAccount(ctx context.Context, userID uuid.UUID, req *dto.DeleteAccountRequest) error
+}
+
+type accountService struct {
+	userRepo       repository.UserRepository
+	userService    UserService
+	authService    AuthService
+	sessions       SessionService
+	bcryptUtils    utils.BcryptUtils
+	passwordPolicy utils.PasswordPolicy
+	mailSender     mailer.Sender
+	metrics        *otel.MetricsRecorder
+}
+
+func NewAccountService(userRepo repository.UserRepository, userService UserService, authService AuthService, sessions SessionService, bcryptUtils utils.BcryptUtils, passwordPolicy utils.PasswordPolicy, mailSender mailer.Sender, metrics *otel.MetricsRecorder) AccountService {
+	return &accountService{
+		userRepo:       userRepo,
+		userService:    userService,
+		authService:    authService,
+		sessions:       sessions,
+		bcryptUtils:    bcryptUtils,
+		passwordPolicy: passwordPolicy,
+		mailSender:     mailSender,
+		metrics:        metrics,
+	}
+}
+
+func (s *accountService) GetProfile(ctx context.Context, userID uuid.UUID) (*dto.UserResponse, error) {
+	recordMetrics := s.metrics.StartRequest(ctx, "account", "get_profile")
+
+	ctx, span := otel.StartServiceSpan(ctx, "GetProfile",
+		attribute.String("user.id", userID.String()),
+	)
+	defer span.End()
+
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetStatusOk()
+	recordMetrics("success")
+	return mapper.UserToResponseDTO(user), nil
+}
+
+func (s *accountService) UpdateProfile(ctx context.Context, userID uuid.UUID, req *dto.UpdateProfileRequest) (*dto.UserResponse, error) {
+	recordMetrics := s.metrics.StartRequest(ctx, "account", "update_profile")
+
+	ctx, span := otel.StartServiceSpan(ctx, "UpdateProfile",
+		attribute.String("user.id", userID.String()),
+	)
+	defer span.End()
+
+	user, err := s.getUser(ctx, userID)
+	if err == nil {
+		err = s.userRepo.UpdateName(ctx, userID, req.Name)
+	}
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+	user.Name = req.Name
+
+	span.SetStatusOk()
+	recordMetrics("success")
+	return mapper.UserToResponseDTO(user), nil
+}
+
+func (s *accountService) ChangePassword(ctx context.Context, userID uuid.UUID, req *dto.ChangePasswordRequest) (*dto.SignInResponse, error) {
+	recordMetrics := s.metrics.StartRequest(ctx, "account", "change_password")
+
+	ctx, span := otel.StartServiceSpan(ctx, "ChangePassword",
+		attribute.String("user.id", userID.String()),
+	)
+	defer span.End()
+
+	user, err := s.getUser(ctx, userID)
+	// accounts created through a provider set their first password with a reset
+	if err == nil && user.Password == "" {
+		err = apperrors.ErrInvalidCredentials
+	}
+	if err == nil {
+		err = s.bcryptUtils.CompareHashAndPassword(user.Password, req.CurrentPassword)
+	}
+	if err == nil {
+		err = s.passwordPolicy.Validate(req.NewPassword)
+	}
+	if err == nil {
+		err = s.userService.ChangePassword(ctx, userID, req.NewPassword)
+	}
+	if err == nil {
+		_, err = s.sessions.RevokeAllSessions(ctx, userID)
+	}
+	var token string
+	if err == nil {
+		token, err = s.sessions.Start(ctx, user, req.Client)
+	}
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetStatusOk()
+	recordMetrics("success")
+	return &dto.SignInResponse{Token: token}, nil
+}
+
+func (s *accountService) ChangeEmail(ctx context.Context, userID uuid.UUID, req *dto.ChangeEmailRequest) (*dto.UserResponse, error) {
+	recordMetrics := s.metrics.StartRequest(ctx, "account", "change_email")
+
+	ctx, span := otel.StartServiceSpan(ctx, "ChangeEmail",
+		attribute.String("user.id", userID.String()),
+	)
+	defer span.End()
+
+	user, err := s.getUser(ctx, userID)
+	if err == nil {
+		err = s.confirmIdentity(ctx, user, req.Password, req.SessionID)
+	}
+	if err == nil && strings.EqualFold(user.Email, req.Email) {
+		err = apperrors.ErrEmailUnchanged
+	}
+	if err == nil {
+		err = s.checkEmailAvailable(ctx, req.Email)
+	}
+	if err == nil {
+		err = s.userRepo.SetPendingEmail(ctx, userID, req.Email)
+	}
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	// The change is stored at this point; a failed email can be resent later.
+	if err := s.authService.ResendVerification(ctx, userID); err != nil {
+		log.Printf(logmsg.VerificationEmailFailed, userID, err)
+	}
+	if err := s.sendEmailChangedNotice(ctx, user, req.Email); err != nil {
+		log.Printf(logmsg.EmailChangeNoticeFailed, userID, err)
+	}
+
+	user.PendingEmail = &req.Email
+
+	span.SetStatusOk()
+	recordMetrics("success")
+	return mapper.UserToResponseDTO(user), nil
+}
+
+func (s *accountService) DeleteAccount(ctx context.Context, userID uuid.UUID, req *dto.DeleteAccountRequest) error {
+	recordMetrics := s.metrics.StartRequest(ctx, "account", "delete")
+
+	ctx, span := otel.StartServiceSpan(ctx, "DeleteAccount",
+		attribute.String("user.id", userID.String()),
+	)
+	defer span.End()
+
+	user, err := s.getUser(ctx, userID)
+	if err == nil {
+		err = s.confirmIdentity(ctx, user, req.Password, req.SessionID)
+	}
+	if err == nil {
+		err = s.userRepo.DeleteAccount(ctx, userID, time.Now())
+	}
+	if err == nil {
+		_, err = s.sessions.RevokeAllSessions(ctx, userID)
+	}
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return err
+	}
+
+	span.SetStatusOk()
+	recordMetrics("success")
+	return nil
+}
+
+func (s *accountService) getUser(ctx context.Context, userID uuid.UUID) (*domain.User, error) {
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, apperrors.ErrUserNotFound
+	}
+	return user, err
+}
+
+// confirmIdentity checks the current password.
+func (s *accountService) confirmIdentity(ctx context.Context, user *domain.User, password string, sessionID uuid.UUID) error {
+	if user.Password != "" {
+		return s.bcryptUtils.CompareHashAndPassword(user.Password, password)
+	}
+	if sessionID == uuid.Nil {
+		return apperrors.ErrRecentSignInRequired
+	}
+
+	startedAt, err := s.sessions.StartedAt(ctx, user.ID, sessionID)
+	if errors.Is(err, apperrors.ErrSessionRevoked) {
+		return apperrors.ErrRecentSignInRequired
+	}
+	if err == nil && time.Since(startedAt) > time.Duration(constants.RecentSignInMinutes)*time.Minute {
+		return apperrors.ErrRecentSignInRequired
+	}
+	return err
+}
+
+func (s *accountService) checkEmailAvailable(ctx context.Context, email string) error {
+	_, err := s.userRepo.GetUserByEmail(ctx, email)
+	if err == nil {
+		return apperrors.ErrUserAlreadyExists
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	return err
+}
+
+func (s *accountService) sendEmailChangedNotice(ctx context.Context, user *domain.User, newEmail string) error {
+	return s.mailSender.Send(ctx, &mailer.Message{
+		ToName:  user.Name,
+		ToEmail: user.Email,
+		Subject: emailChangedSubject,
+		Body:    fmt.Sprintf(emailChangedTemplate, user.Name, newEmail),
+	})
+}
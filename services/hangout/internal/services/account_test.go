@@ -0,0 +1,413 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/config"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/mailer"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/services"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/utils"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+type accountMocks struct {
+	repo     *MockUserRepository
+	users    *MockUserService
+	auth     *MockAuthService
+	sessions *MockSessionService
+	bcrypt   *MockBcryptUtils
+	sender   *MockMailSender
+}
+
+func newAccountService(t *testing.T) (services.AccountService, *accountMocks) {
+	t.Helper()
+	m := &accountMocks{
+		repo:     new(MockUserRepository),
+		users:    new(MockUserService),
+		auth:     new(MockAuthService),
+		sessions: new(MockSessionService),
+		bcrypt:   new(MockBcryptUtils),
+		sender:   new(MockMailSender),
+	}
+	policy := utils.NewPasswordPolicy(&config.PasswordPolicyConfig{MinLength: 10})
+	svc := services.NewAccountService(m.repo, m.users, m.auth, m.sessions, m.bcrypt, policy, m.sender, nil)
+	return svc, m
+}
+
+func (m *accountMocks) assertExpectations(t *testing.T) {
+	m.repo.AssertExpectations(t)
+	m.users.AssertExpectations(t)
+	m.auth.AssertExpectations(t)
+	m.sessions.AssertExpectations(t)
+	m.bcrypt.AssertExpectations(t)
+	m.sender.AssertExpectations(t)
+}
+
+func TestAccountService_GetProfile(t *testing.T) {
+	ctx := context.Background()
+	verifiedAt := time.Now()
+	user := &domain.User{ID: uuid.New(), Name: "Alice", Email: "alice@example.com", EmailVerifiedAt: &verifiedAt}
+
+	tests := map[string]struct {
+		setup   func(m *accountMocks)
+		wantErr error
+	}{
+		"Success": {
+			setup: func(m *accountMocks) {
+				m.repo.On("GetUserByID", mock.Anything, user.ID).Return(user, nil)
+			},
+		},
+		"User not found": {
+			setup: func(m *accountMocks) {
+				m.repo.On("GetUserByID", mock.Anything, user.ID).Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantErr: apperrors.ErrUserNotFound,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			svc, m := newAccountService(t)
+			tt.setup(m)
+
+			res, err := svc.GetProfile(ctx, user.ID)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				require.Nil(t, res)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, &dto.UserResponse{ID: user.ID, Name: "Alice", Email: "alice@example.com", EmailVerified: true}, res)
+			}
+			m.assertExpectations(t)
+		})
+	}
+}
+
+func TestAccountService_UpdateProfile(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	dbErr := errors.New("db error")
+
+	tests := map[string]struct {
+		setup   func(m *accountMocks)
+		wantErr error
+	}{
+		"Success": {
+			setup: func(m *accountMocks) {
+				m.repo.On("GetUserByID", mock.Anything, userID).Return(&domain.User{ID: userID, Name: "Alice"}, nil)
+				m.repo.On("UpdateName", mock.Anything, userID, "Alice Smith").Return(nil)
+			},
+		},
+		"Update error": {
+			setup: func(m *accountMocks) {
+				m.repo.On("GetUserByID", mock.Anything, userID).Return(&domain.User{ID: userID, Name: "Alice"}, nil)
+				m.repo.On("UpdateName", mock.Anything, userID, "Alice Smith").Return(dbErr)
+			},
+			wantErr: dbErr,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			svc, m := newAccountService(t)
+			tt.setup(m)
+
+			res, err := svc.UpdateProfile(ctx, userID, &dto.UpdateProfileRequest{Name: "Alice Smith"})
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				require.Nil(t, res)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, "Alice Smith", res.Name)
+			}
+			m.assertExpectations(t)
+		})
+	}
+}
+
+func TestAccountService_ChangePassword(t *testing.T) {
+	ctx := context.Background()
+	user := &domain.User{ID: uuid.New(), Email: "alice@example.com", Password: "hashed"}
+	client := dto.SessionClient{IPAddress: "203.0.113.7", UserAgent: "curl/8"}
+	newPassword := "N3w-Passphrase!"
+	dbErr := errors.New("db error")
+
+	tests := map[string]struct {
+		user      *domain.User
+		current   string
+		password  string
+		setup     func(m *accountMocks)
+		wantToken string
+		wantErr   error
+	}{
+		"Success": {
+			user:     user,
+			current:  "old",
+			password: newPassword,
+			setup: func(m *accountMocks) {
+				m.bcrypt.On("CompareHashAndPassword", "hashed", "old").Return(nil)
+				m.users.On("ChangePassword", mock.Anything, user.ID, newPassword).Return(nil)
+				m.sessions.On("RevokeAllSessions", mock.Anything, user.ID).Return(int64(2), nil)
+				m.sessions.On("Start", mock.Anything, user, client).Return("signed.jwt.token", nil)
+			},
+			wantToken: "signed.jwt.token",
+		},
+		"Wrong current password": {
+			user:     user,
+			current:  "wrong",
+			password: newPassword,
+			setup: func(m *accountMocks) {
+				m.bcrypt.On("CompareHashAndPassword", "hashed", "wrong").Return(apperrors.ErrInvalidCredentials)
+			},
+			wantErr: apperrors.ErrInvalidCredentials,
+		},
+		"Account without password": {
+			user:     &domain.User{ID: user.ID},
+			current:  "anything",
+			password: newPassword,
+			setup:    func(m *accountMocks) {},
+			wantErr:  apperrors.ErrInvalidCredentials,
+		},
+		"Weak new password": {
+			user:     user,
+			current:  "old",
+			password: "short",
+			setup: func(m *accountMocks) {
+				m.bcrypt.On("CompareHashAndPassword", "hashed", "old").Return(nil)
+			},
+			wantErr: apperrors.ErrWeakPassword,
+		},
+		"Revoke error": {
+			user:     user,
+			current:  "old",
+			password: newPassword,
+			setup: func(m *accountMocks) {
+				m.bcrypt.On("CompareHashAndPassword", "hashed", "old").Return(nil)
+				m.users.On("ChangePassword", mock.Anything, user.ID, newPassword).Return(nil)
+				m.sessions.On("RevokeAllSessions", mock.Anything, user.ID).Return(int64(0), dbErr)
+			},
+			wantErr: dbErr,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			svc, m := newAccountService(t)
+			m.repo.On("GetUserByID", mock.Anything, user.ID).Return(tt.user, nil)
+			tt.setup(m)
+
+			res, err := svc.ChangePassword(ctx, user.ID, &dto.ChangePasswordRequest{CurrentPassword: tt.current, NewPassword: tt.password, Client: client})
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				require.Nil(t, res)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.wantToken, res.Token)
+			}
+			m.assertExpectations(t)
+		})
+	}
+}
+
+func TestAccountService_ChangeEmail(t *testing.T) {
+	ctx := context.Background()
+	verifiedAt := time.Now()
+	userID := uuid.New()
+	sessionID := uuid.New()
+	dbErr := errors.New("db error")
+	newUser := func() *domain.User {
+		return &domain.User{ID: userID, Name: "Alice", Email: "alice@example.com", Password: "hashed", EmailVerifiedAt: &verifiedAt}
+	}
+
+	tests := map[string]struct {
+		req     *dto.ChangeEmailRequest
+		user    *domain.User
+		setup   func(m *accountMocks)
+		wantErr error
+	}{
+		"Success": {
+			req:  &dto.ChangeEmailRequest{Email: "alice@new.example.com", Password: "secret"},
+			user: newUser(),
+			setup: func(m *accountMocks) {
+				m.bcrypt.On("CompareHashAndPassword", "hashed", "secret").Return(nil)
+				m.repo.On("GetUserByEmail", mock.Anything, "alice@new.example.com").Return(nil, gorm.ErrRecordNotFound)
+				m.repo.On("SetPendingEmail", mock.Anything, userID, "alice@new.example.com").Return(nil)
+				m.auth.On("ResendVerification", mock.Anything, userID).Return(nil)
+				m.sender.On("Send", mock.Anything, mock.MatchedBy(func(msg *mailer.Message) bool {
+					return msg.ToEmail == "alice@example.com"
+				})).Return(nil)
+			},
+		},
+		"Emails failing does not fail the change": {
+			req:  &dto.ChangeEmailRequest{Email: "alice@new.example.com", Password: "secret"},
+			user: newUser(),
+			setup: func(m *accountMocks) {
+				m.bcrypt.On("CompareHashAndPassword", "hashed", "secret").Return(nil)
+				m.repo.On("GetUserByEmail", mock.Anything, "alice@new.example.com").Return(nil, gorm.ErrRecordNotFound)
+				m.repo.On("SetPendingEmail", mock.Anything, userID, "alice@new.example.com").Return(nil)
+				m.auth.On("ResendVerification", mock.Anything, userID).Return(errors.New("smtp down"))
+				m.sender.On("Send", mock.Anything, mock.Anything).Return(errors.New("smtp down"))
+			},
+		},
+		"Provider account signed in recently": {
+			req:  &dto.ChangeEmailRequest{Email: "alice@new.example.com", SessionID: sessionID},
+			user: &domain.User{ID: userID, Name: "Alice", Email: "alice@example.com"},
+			setup: func(m *accountMocks) {
+				m.sessions.On("StartedAt", mock.Anything, userID, sessionID).Return(time.Now().Add(-time.Minute), nil)
+				m.repo.On("GetUserByEmail", mock.Anything, "alice@new.example.com").Return(nil, gorm.ErrRecordNotFound)
+				m.repo.On("SetPendingEmail", mock.Anything, userID, "alice@new.example.com").Return(nil)
+				m.auth.On("ResendVerification", mock.Anything, userID).Return(nil)
+				m.sender.On("Send", mock.Anything, mock.Anything).Return(nil)
+			},
+		},
+		"Provider account signed in long ago": {
+			req:  &dto.ChangeEmailRequest{Email: "alice@new.example.com", SessionID: sessionID},
+			user: &domain.User{ID: userID, Name: "Alice", Email: "alice@example.com"},
+			setup: func(m *accountMocks) {
+				m.sessions.On("StartedAt", mock.Anything, userID, sessionID).Return(time.Now().Add(-time.Hour), nil)
+			},
+			wantErr: apperrors.ErrRecentSignInRequired,
+		},
+		"Provider account without session": {
+			req:     &dto.ChangeEmailRequest{Email: "alice@new.example.com"},
+			user:    &domain.User{ID: userID, Name: "Alice", Email: "alice@example.com"},
+			setup:   func(m *accountMocks) {},
+			wantErr: apperrors.ErrRecentSignInRequired,
+		},
+		"Wrong password": {
+			req:  &dto.ChangeEmailRequest{Email: "alice@new.example.com", Password: "wrong"},
+			user: newUser(),
+			setup: func(m *accountMocks) {
+				m.bcrypt.On("CompareHashAndPassword", "hashed", "wrong").Return(apperrors.ErrInvalidCredentials)
+			},
+			wantErr: apperrors.ErrInvalidCredentials,
+		},
+		"Same email": {
+			req:  &dto.ChangeEmailRequest{Email: "Alice@Example.com", Password: "secret"},
+			user: newUser(),
+			setup: func(m *accountMocks) {
+				m.bcrypt.On("CompareHashAndPassword", "hashed", "secret").Return(nil)
+			},
+			wantErr: apperrors.ErrEmailUnchanged,
+		},
+		"Email taken": {
+			req:  &dto.ChangeEmailRequest{Email: "bob@example.com", Password: "secret"},
+			user: newUser(),
+			setup: func(m *accountMocks) {
+				m.bcrypt.On("CompareHashAndPassword", "hashed", "secret").Return(nil)
+				m.repo.On("GetUserByEmail", mock.Anything, "bob@example.com").Return(&domain.User{ID: uuid.New()}, nil)
+			},
+			wantErr: apperrors.ErrUserAlreadyExists,
+		},
+		"Update error": {
+			req:  &dto.ChangeEmailRequest{Email: "alice@new.example.com", Password: "secret"},
+			user: newUser(),
+			setup: func(m *accountMocks) {
+				m.bcrypt.On("CompareHashAndPassword", "hashed", "secret").Return(nil)
+				m.repo.On("GetUserByEmail", mock.Anything, "alice@new.example.com").Return(nil, gorm.ErrRecordNotFound)
+				m.repo.On("SetPendingEmail", mock.Anything, userID, "alice@new.example.com").Return(dbErr)
+			},
+			wantErr: dbErr,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			svc, m := newAccountService(t)
+			m.repo.On("GetUserByID", mock.Anything, userID).Return(tt.user, nil)
+			tt.setup(m)
+
+			res, err := svc.ChangeEmail(ctx, userID, tt.req)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				require.Nil(t, res)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.user.Email, res.Email)
+				require.Equal(t, &tt.req.Email, res.PendingEmail)
+			}
+			m.assertExpectations(t)
+		})
+	}
+}
+
+func TestAccountService_DeleteAccount(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	sessionID := uuid.New()
+	dbErr := errors.New("db error")
+
+	tests := map[string]struct {
+		user     *domain.User
+		password string
+		setup    func(m *accountMocks)
+		wantErr  error
+	}{
+		"Success": {
+			user:     &domain.User{ID: userID, Password: "hashed"},
+			password: "secret",
+			setup: func(m *accountMocks) {
+				m.bcrypt.On("CompareHashAndPassword", "hashed", "secret").Return(nil)
+				m.repo.On("DeleteAccount", mock.Anything, userID, mock.Anything).Return(nil)
+				m.sessions.On("RevokeAllSessions", mock.Anything, userID).Return(int64(1), nil)
+			},
+		},
+		"Provider account signed in recently": {
+			user: &domain.User{ID: userID},
+			setup: func(m *accountMocks) {
+				m.sessions.On("StartedAt", mock.Anything, userID, sessionID).Return(time.Now().Add(-time.Minute), nil)
+				m.repo.On("DeleteAccount", mock.Anything, userID, mock.Anything).Return(nil)
+				m.sessions.On("RevokeAllSessions", mock.Anything, userID).Return(int64(1), nil)
+			},
+		},
+		"Provider account with signed out session": {
+			user: &domain.User{ID: userID},
+			setup: func(m *accountMocks) {
+				m.sessions.On("StartedAt", mock.Anything, userID, sessionID).Return(time.Time{}, apperrors.ErrSessionRevoked)
+			},
+			wantErr: apperrors.ErrRecentSignInRequired,
+		},
+		"Wrong password": {
+			user:     &domain.User{ID: userID, Password: "hashed"},
+			password: "wrong",
+			setup: func(m *accountMocks) {
+				m.bcrypt.On("CompareHashAndPassword", "hashed", "wrong").Return(apperrors.ErrInvalidCredentials)
+			},
+			wantErr: apperrors.ErrInvalidCredentials,
+		},
+		"Delete error": {
+			user:     &domain.User{ID: userID, Password: "hashed"},
+			password: "secret",
+			setup: func(m *accountMocks) {
+				m.bcrypt.On("CompareHashAndPassword", "hashed", "secret").Return(nil)
+				m.repo.On("DeleteAccount", mock.Anything, userID, mock.Anything).Return(dbErr)
+			},
+			wantErr: dbErr,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			svc, m := newAccountService(t)
+			m.repo.On("GetUserByID", mock.Anything, userID).Return(tt.user, nil)
+			tt.setup(m)
+
+			err := svc.DeleteAccount(ctx, userID, &dto.DeleteAccountRequest{Password: tt.password, SessionID: sessionID})
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			m.assertExpectations(t)
+		})
+	}
+}
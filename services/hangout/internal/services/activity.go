@@ -18,8 +18,8 @@ type ActivityService interface {
 	CreateActivity(ctx context.Context, userID uuid.UUID, req *dto.CreateActivityRequest) (*dto.ActivityDetailResponse, error)
 	GetActivityByID(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*dto.ActivityDetailResponse, error)
 	GetAllActivities(ctx context.Context, userID uuid.UUID) ([]dto.ActivityListItemResponse, error)
-	UpdateActivity(ctx context.Context, id uuid.UUID, userID uuid.UUID, req *dto.UpdateActivityRequest) (*dto.ActivityDetailResponse, error)
-	DeleteActivity(ctx context.Context, id uuid.UUID, userID uuid.UUID) error
+	UpdateActivity(ctx context.Context, id uuid.UUID, userID uuid.UUID, version int64, req *dto.UpdateActivityRequest) (*dto.ActivityDetailResponse, error)
+	DeleteActivity(ctx context.Context, id uuid.UUID, userID uuid.UUID, version int64) error
 }
 
 type activityService struct {
@@ -83,7 +83,7 @@ func (s *activityService) GetAllActivities(ctx context.Context, userID uuid.UUID
 	return mapper.ActivityToListItemResponseDTO(activitiesWithCount), nil
 }
 
-func (s *activityService) UpdateActivity(ctx context.Context, id uuid.UUID, userID uuid.UUID, req *dto.UpdateActivityRequest) (*dto.ActivityDetailResponse, error) {
+func (s *activityService) UpdateActivity(ctx context.Context, id uuid.UUID, userID uuid.UUID, version int64, req *dto.UpdateActivityRequest) (*dto.ActivityDetailResponse, error) {
 	recordMetrics := s.metrics.StartRequest(ctx, "activity", "update")
 
 	var updatedActivity *domain.Activity
@@ -100,6 +100,10 @@ func (s *activityService) UpdateActivity(ctx context.Context, id uuid.UUID, user
 			return err
 		}
 
+		if existingActivity.Version != version {
+			return apperrors.ErrPreconditionFailed
+		}
+
 		mapper.ApplyUpdateToActivity(existingActivity, req)
 		updatedActivityCount = existingCount
 		updatedActivity, err = txRepo.UpdateActivity(ctx, existingActivity)
@@ -118,19 +122,22 @@ func (s *activityService) UpdateActivity(ctx context.Context, id uuid.UUID, user
 	return mapper.ActivitytoDetailResponseDTO(updatedActivity, updatedActivityCount), nil
 }
 
-func (s *activityService) DeleteActivity(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
+func (s *activityService) DeleteActivity(ctx context.Context, id uuid.UUID, userID uuid.UUID, version int64) error {
 	recordMetrics := s.metrics.StartRequest(ctx, "activity", "delete")
 
 	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		txRepo := s.activityRepo.WithTx(tx)
-		_, _, err := txRepo.GetActivityByID(ctx, id, userID)
+		existingActivity, _, err := txRepo.GetActivityByID(ctx, id, userID)
 		if err != nil {
 			if errors.Is(err, gorm.ErrRecordNotFound) {
 				return apperrors.ErrNotFound
 			}
 			return err
 		}
-		return txRepo.DeleteActivity(ctx, id)
+		if existingActivity.Version != version {
+			return apperrors.ErrPreconditionFailed
+		}
+		return txRepo.DeleteActivity(ctx, id, version)
 	})
 
 	if err != nil {
@@ -66,8 +66,8 @@ func (m *MockActivityRepository) UpdateActivity(ctx context.Context, activity *d
 	return args.Get(0).(*domain.Activity), args.Error(1)
 }
 
-func (m *MockActivityRepository) DeleteActivity(ctx context.Context, id uuid.UUID) error {
-	args := m.Called(ctx, id)
+func (m *MockActivityRepository) DeleteActivity(ctx context.Context, id uuid.UUID, version int64) error {
+	args := m.Called(ctx, id, version)
 	return args.Error(0)
 }
 
@@ -264,7 +264,7 @@ func TestActivityService_UpdateActivity(t *testing.T) {
 				repo.On("WithTx", mock.Anything).Return(repo).Once()
 
 				repo.On("GetActivityByID", ctx, activityID, userID).
-					Return(&domain.Activity{ID: activityID, Name: "Old Name"}, int64(2), nil).Once()
+					Return(&domain.Activity{ID: activityID, Name: "Old Name", Version: 1}, int64(2), nil).Once()
 
 				repo.On("UpdateActivity", ctx, mock.MatchedBy(func(act *domain.Activity) bool {
 					return act.ID == activityID && act.Name == "Updated Name"
@@ -321,7 +321,7 @@ func TestActivityService_UpdateActivity(t *testing.T) {
 				repo.On("WithTx", mock.Anything).Return(repo).Once()
 
 				repo.On("GetActivityByID", ctx, activityID, userID).
-					Return(&domain.Activity{ID: activityID, Name: "Old Name"}, int64(3), nil).Once()
+					Return(&domain.Activity{ID: activityID, Name: "Old Name", Version: 1}, int64(3), nil).Once()
 
 				repo.On("UpdateActivity", ctx, mock.AnythingOfType("*domain.Activity")).
 					Return(nil, dbError).Once()
@@ -334,6 +334,22 @@ func TestActivityService_UpdateActivity(t *testing.T) {
 				require.Nil(t, res)
 			},
 		},
+		{
+			name: "version mismatch",
+			setupMock: func(repo *MockActivityRepository, sqlMock sqlmock.Sqlmock) {
+				sqlMock.ExpectBegin()
+				repo.On("WithTx", mock.Anything).Return(repo).Once()
+
+				repo.On("GetActivityByID", ctx, activityID, userID).
+					Return(&domain.Activity{ID: activityID, Name: "Old Name", Version: 4}, int64(0), nil).Once()
+
+				sqlMock.ExpectRollback()
+			},
+			checkResult: func(t *testing.T, res *dto.ActivityDetailResponse, err error) {
+				require.ErrorIs(t, err, apperrors.ErrPreconditionFailed)
+				require.Nil(t, res)
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -343,7 +359,7 @@ func TestActivityService_UpdateActivity(t *testing.T) {
 			service := services.NewActivityService(db, mockRepo, nil)
 
 			tc.setupMock(mockRepo, sqlMock)
-			result, err := service.UpdateActivity(ctx, activityID, userID, req)
+			result, err := service.UpdateActivity(ctx, activityID, userID, 1, req)
 
 			tc.checkResult(t, result, err)
 			mockRepo.AssertExpectations(t)
@@ -370,9 +386,9 @@ func TestActivityService_DeleteActivity(t *testing.T) {
 				repo.On("WithTx", mock.Anything).Return(repo).Once()
 
 				repo.On("GetActivityByID", ctx, activityID, userID).
-					Return(&domain.Activity{ID: activityID}, int64(0), nil).Once()
+					Return(&domain.Activity{ID: activityID, Version: 1}, int64(0), nil).Once()
 
-				repo.On("DeleteActivity", ctx, activityID).
+				repo.On("DeleteActivity", ctx, activityID, int64(1)).
 					Return(nil).Once()
 
 				sqlMock.ExpectCommit()
@@ -412,15 +428,28 @@ func TestActivityService_DeleteActivity(t *testing.T) {
 				repo.On("WithTx", mock.Anything).Return(repo).Once()
 
 				repo.On("GetActivityByID", ctx, activityID, userID).
-					Return(&domain.Activity{ID: activityID}, int64(0), nil).Once()
+					Return(&domain.Activity{ID: activityID, Version: 1}, int64(0), nil).Once()
 
-				repo.On("DeleteActivity", ctx, activityID).
+				repo.On("DeleteActivity", ctx, activityID, int64(1)).
 					Return(dbError).Once()
 
 				sqlMock.ExpectRollback()
 			},
 			expectedErr: dbError,
 		},
+		{
+			name: "version mismatch",
+			setupMock: func(repo *MockActivityRepository, sqlMock sqlmock.Sqlmock) {
+				sqlMock.ExpectBegin()
+				repo.On("WithTx", mock.Anything).Return(repo).Once()
+
+				repo.On("GetActivityByID", ctx, activityID, userID).
+					Return(&domain.Activity{ID: activityID, Version: 2}, int64(0), nil).Once()
+
+				sqlMock.ExpectRollback()
+			},
+			expectedErr: apperrors.ErrPreconditionFailed,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -430,7 +459,7 @@ func TestActivityService_DeleteActivity(t *testing.T) {
 			service := services.NewActivityService(db, mockRepo, nil)
 
 			tc.setupMock(mockRepo, sqlMock)
-			err := service.DeleteActivity(ctx, activityID, userID)
+			err := service.DeleteActivity(ctx, activityID, userID, 1)
 
 			if tc.expectedErr != nil {
 				require.Error(t, err)
@@ -0,0 +1,418 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/mapper"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/otel"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/repository"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"gorm.io/gorm"
+)
+
+// AdminService backs the /admin routes.
+type AdminService interface {
+	// RequireAdmin returns ErrAdminRequired unless the user is an enabled admin.
+	RequireAdmin(ctx context.Context, userID uuid.UUID) error
+	// PromoteAdmins gives the admin role to the accounts with the given emails.
+	PromoteAdmins(ctx context.Context, emails []string) (int64, error)
+
+	SearchUsers(ctx context.Context, actorID uuid.UUID, query string, reason string, pagination *dto.CursorPagination) (*dto.PaginatedAdminUsers, error)
+	DisableUser(ctx context.Context, actorID uuid.UUID, userID uuid.UUID, reason string) (*dto.AdminUserResponse, error)
+	EnableUser(ctx context.Context, actorID uuid.UUID, userID uuid.UUID, reason string) (*dto.AdminUserResponse, error)
+	ForcePasswordReset(ctx context.Context, actorID uuid.UUID, userID uuid.UUID, reason string) error
+	GetHangout(ctx context.Context, actorID uuid.UUID, hangoutID uuid.UUID, reason string) (*dto.HangoutDetailResponse, error)
+	HideMemory(ctx context.Context, actorID uuid.UUID, memoryID uuid.UUID, reason string) error
+	UnhideMemory(ctx context.Context, actorID uuid.UUID, memoryID uuid.UUID, reason string) error
+	RemoveMemory(ctx context.Context, actorID uuid.UUID, memoryID uuid.UUID, reason string) error
+	ListAuditLogs(ctx context.Context, filter *dto.AdminAuditLogFilter, pagination *dto.CursorPagination) (*dto.PaginatedAdminAuditLogs, error)
+}
+
+type adminService struct {
+	db          *gorm.DB
+	userRepo    repository.UserRepository
+	hangoutRepo repository.HangoutRepository
+	memoryRepo  repository.MemoryRepository
+	auditRepo   repository.AdminAuditLogRepository
+	sessions    SessionService
+	authService AuthService
+	metrics     *otel.MetricsRecorder
+}
+
+func NewAdminService(db *gorm.DB, userRepo repository.UserRepository, hangoutRepo repository.HangoutRepository, memoryRepo repository.MemoryRepository, auditRepo repository.AdminAuditLogRepository, sessions SessionService, authService AuthService, metrics *otel.MetricsRecorder) AdminService {
+	return &adminService{
+		db:          db,
+		userRepo:    userRepo,
+		hangoutRepo: hangoutRepo,
+		memoryRepo:  memoryRepo,
+		auditRepo:   auditRepo,
+		sessions:    sessions,
+		authService: authService,
+		metrics:     metrics,
+	}
+}
+
+func (s *adminService) RequireAdmin(ctx context.Context, userID uuid.UUID) error {
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return apperrors.ErrAdminRequired
+	}
+	if err != nil {
+		return err
+	}
+	if user.Role != constants.RoleAdmin || user.DisabledAt != nil {
+		return apperrors.ErrAdminRequired
+	}
+	return nil
+}
+
+func (s *adminService) PromoteAdmins(ctx context.Context, emails []string) (int64, error) {
+	recordMetrics := s.metrics.StartRequest(ctx, "admin", "promote")
+
+	ctx, span := otel.StartServiceSpan(ctx, "PromoteAdmins",
+		attribute.Int("admin.email_count", len(emails)),
+	)
+	defer span.End()
+
+	promoted, err := s.userRepo.PromoteToAdmin(ctx, emails)
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return 0, err
+	}
+
+	span.SetAttributes(attribute.Int64("admin.promoted", promoted))
+	span.SetStatusOk()
+	recordMetrics("success")
+	return promoted, nil
+}
+
+func (s *adminService) SearchUsers(ctx context.Context, actorID uuid.UUID, query string, reason string, pagination *dto.CursorPagination) (*dto.PaginatedAdminUsers, error) {
+	recordMetrics := s.metrics.StartRequest(ctx, "admin", "search_users")
+
+	ctx, span := otel.StartServiceSpan(ctx, "AdminSearchUsers",
+		attribute.String("user.id", actorID.String()),
+		attribute.Int("pagination.limit", pagination.GetLimit()),
+	)
+	defer span.End()
+
+	query = truncateRunes(strings.TrimSpace(query), constants.MaxAdminSearchQueryLength)
+	reason, err := adminReason(reason)
+	if err == nil {
+		err = s.auditRepo.Create(ctx, newAdminAuditLog(actorID, constants.AuditActionSearchUsers, constants.AuditTargetUser, query, reason))
+	}
+	var users []domain.User
+	if err == nil {
+		users, err = s.userRepo.SearchUsers(ctx, query, pagination)
+	}
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	var nextCursor *uuid.UUID
+	limit := pagination.GetLimit()
+	hasMore := len(users) > limit
+	if hasMore {
+		nextCursor = &users[limit-1].ID
+		users = users[:limit]
+	}
+
+	span.SetAttributes(
+		attribute.Int("user.count", len(users)),
+		attribute.Bool("pagination.has_more", hasMore),
+	)
+	span.SetStatusOk()
+	recordMetrics("success")
+	return &dto.PaginatedAdminUsers{
+		Data:       mapper.UsersToAdminResponseDTOs(users),
+		NextCursor: nextCursor,
+		HasMore:    hasMore,
+	}, nil
+}
+
+// DisableUser blocks the user from signing in and signs them out on every device.
+func (s *adminService) DisableUser(ctx context.Context, actorID uuid.UUID, userID uuid.UUID, reason string) (*dto.AdminUserResponse, error) {
+	recordMetrics := s.metrics.StartRequest(ctx, "admin", "disable_user")
+
+	ctx, span := otel.StartServiceSpan(ctx, "AdminDisableUser",
+		attribute.String("user.id", actorID.String()),
+		attribute.String("target.user.id", userID.String()),
+	)
+	defer span.End()
+
+	user, reason, err := s.moderatedUser(ctx, actorID, userID, reason)
+	if err == nil && user.DisabledAt == nil {
+		now := time.Now()
+		user.DisabledAt = &now
+	}
+	if err == nil {
+		err = s.withAudit(ctx, newAdminAuditLog(actorID, constants.AuditActionDisableUser, constants.AuditTargetUser, userID.String(), reason), func(tx *gorm.DB) error {
+			return s.userRepo.WithTx(tx).SetDisabledAt(ctx, userID, user.DisabledAt)
+		})
+	}
+	if err == nil {
+		_, err = s.sessions.RevokeAllSessions(ctx, userID)
+	}
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetStatusOk()
+	recordMetrics("success")
+	return mapper.UserToAdminResponseDTO(user), nil
+}
+
+func (s *adminService) EnableUser(ctx context.Context, actorID uuid.UUID, userID uuid.UUID, reason string) (*dto.AdminUserResponse, error) {
+	recordMetrics := s.metrics.StartRequest(ctx, "admin", "enable_user")
+
+	ctx, span := otel.StartServiceSpan(ctx, "AdminEnableUser",
+		attribute.String("user.id", actorID.String()),
+		attribute.String("target.user.id", userID.String()),
+	)
+	defer span.End()
+
+	user, reason, err := s.moderatedUser(ctx, actorID, userID, reason)
+	if err == nil {
+		user.DisabledAt = nil
+		err = s.withAudit(ctx, newAdminAuditLog(actorID, constants.AuditActionEnableUser, constants.AuditTargetUser, userID.String(), reason), func(tx *gorm.DB) error {
+			return s.userRepo.WithTx(tx).SetDisabledAt(ctx, userID, nil)
+		})
+	}
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetStatusOk()
+	recordMetrics("success")
+	return mapper.UserToAdminResponseDTO(user), nil
+}
+
+// ForcePasswordReset clears the user's password, signs them out everywhere and
+// emails them a reset link.
+func (s *adminService) ForcePasswordReset(ctx context.Context, actorID uuid.UUID, userID uuid.UUID, reason string) error {
+	recordMetrics := s.metrics.StartRequest(ctx, "admin", "force_password_reset")
+
+	ctx, span := otel.StartServiceSpan(ctx, "AdminForcePasswordReset",
+		attribute.String("user.id", actorID.String()),
+		attribute.String("target.user.id", userID.String()),
+	)
+	defer span.End()
+
+	user, reason, err := s.moderatedUser(ctx, actorID, userID, reason)
+	if err == nil {
+		err = s.withAudit(ctx, newAdminAuditLog(actorID, constants.AuditActionResetPassword, constants.AuditTargetUser, userID.String(), reason), func(tx *gorm.DB) error {
+			return s.userRepo.WithTx(tx).UpdatePassword(ctx, userID, "")
+		})
+	}
+	if err == nil {
+		_, err = s.sessions.RevokeAllSessions(ctx, userID)
+	}
+	if err == nil {
+		err = s.authService.ForgotPassword(ctx, user.Email)
+	}
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return err
+	}
+
+	span.SetStatusOk()
+	recordMetrics("success")
+	return nil
+}
+
+func (s *adminService) GetHangout(ctx context.Context, actorID uuid.UUID, hangoutID uuid.UUID, reason string) (*dto.HangoutDetailResponse, error) {
+	recordMetrics := s.metrics.StartRequest(ctx, "admin", "get_hangout")
+
+	ctx, span := otel.StartServiceSpan(ctx, "AdminGetHangout",
+		attribute.String("user.id", actorID.String()),
+		attribute.String("hangout.id", hangoutID.String()),
+	)
+	defer span.End()
+
+	reason, err := adminReason(reason)
+	if err == nil {
+		err = s.auditRepo.Create(ctx, newAdminAuditLog(actorID, constants.AuditActionViewHangout, constants.AuditTargetHangout, hangoutID.String(), reason))
+	}
+	var hangout *domain.Hangout
+	if err == nil {
+		hangout, err = s.hangoutRepo.GetHangoutByIDAnyOwner(ctx, hangoutID)
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		err = apperrors.ErrNotFound
+	}
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetStatusOk()
+	recordMetrics("success")
+	return mapper.HangoutToDetailResponseDTO(hangout), nil
+}
+
+// HideMemory leaves the memory out of its hangout's gallery, archives and
+// share links while keeping it for review.
+func (s *adminService) HideMemory(ctx context.Context, actorID uuid.UUID, memoryID uuid.UUID, reason string) error {
+	return s.moderateMemory(ctx, "hide_memory", actorID, memoryID, reason, constants.AuditActionHideMemory, func(repo repository.MemoryRepository, memory *domain.Memory) error {
+		if memory.HiddenAt != nil {
+			return nil
+		}
+		now := time.Now()
+		return repo.SetHiddenAt(ctx, memory.ID, &now)
+	})
+}
+
+func (s *adminService) UnhideMemory(ctx context.Context, actorID uuid.UUID, memoryID uuid.UUID, reason string) error {
+	return s.moderateMemory(ctx, "unhide_memory", actorID, memoryID, reason, constants.AuditActionUnhideMemory, func(repo repository.MemoryRepository, memory *domain.Memory) error {
+		return repo.SetHiddenAt(ctx, memory.ID, nil)
+	})
+}
+
+// RemoveMemory moves the memory to the trash, where it is purged with its file
+// once the retention has passed.
+func (s *adminService) RemoveMemory(ctx context.Context, actorID uuid.UUID, memoryID uuid.UUID, reason string) error {
+	return s.moderateMemory(ctx, "remove_memory", actorID, memoryID, reason, constants.AuditActionRemoveMemory, func(repo repository.MemoryRepository, memory *domain.Memory) error {
+		if memory.HiddenAt == nil {
+			now := time.Now()
+			if err := repo.SetHiddenAt(ctx, memory.ID, &now); err != nil {
+				return err
+			}
+		}
+		return repo.DeleteMemory(ctx, memory.ID)
+	})
+}
+
+func (s *adminService) ListAuditLogs(ctx context.Context, filter *dto.AdminAuditLogFilter, pagination *dto.CursorPagination) (*dto.PaginatedAdminAuditLogs, error) {
+	recordMetrics := s.metrics.StartRequest(ctx, "admin", "list_audit_logs")
+
+	ctx, span := otel.StartServiceSpan(ctx, "ListAdminAuditLogs",
+		attribute.Int("pagination.limit", pagination.GetLimit()),
+	)
+	defer span.End()
+
+	entries, err := s.auditRepo.List(ctx, filter, pagination)
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	var nextCursor *uuid.UUID
+	limit := pagination.GetLimit()
+	hasMore := len(entries) > limit
+	if hasMore {
+		nextCursor = &entries[limit-1].ID
+		entries = entries[:limit]
+	}
+
+	span.SetAttributes(
+		attribute.Int("audit.count", len(entries)),
+		attribute.Bool("pagination.has_more", hasMore),
+	)
+	span.SetStatusOk()
+	recordMetrics("success")
+	return &dto.PaginatedAdminAuditLogs{
+		Data:       mapper.AdminAuditLogsToResponseDTOs(entries),
+		NextCursor: nextCursor,
+		HasMore:    hasMore,
+	}, nil
+}
+
+// moderatedUser checks the reason and loads the target user.
+func (s *adminService) moderatedUser(ctx context.Context, actorID uuid.UUID, userID uuid.UUID, reason string) (*domain.User, string, error) {
+	reason, err := adminReason(reason)
+	if err != nil {
+		return nil, "", err
+	}
+	if actorID == userID {
+		return nil, "", apperrors.ErrCannotModerateSelf
+	}
+
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, "", apperrors.ErrUserNotFound
+		}
+		return nil, "", err
+	}
+	return user, reason, nil
+}
+
+func (s *adminService) moderateMemory(ctx context.Context, op string, actorID uuid.UUID, memoryID uuid.UUID, reason string, action string, apply func(repo repository.MemoryRepository, memory *domain.Memory) error) error {
+	recordMetrics := s.metrics.StartRequest(ctx, "admin", op)
+
+	ctx, span := otel.StartServiceSpan(ctx, "AdminModerateMemory",
+		attribute.String("user.id", actorID.String()),
+		attribute.String("memory.id", memoryID.String()),
+		attribute.String("audit.action", action),
+	)
+	defer span.End()
+
+	reason, err := adminReason(reason)
+	var memory *domain.Memory
+	if err == nil {
+		memory, err = s.memoryRepo.GetMemoryByIDAnyOwner(ctx, memoryID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			err = apperrors.ErrMemoryNotFound
+		}
+	}
+	if err == nil {
+		err = s.withAudit(ctx, newAdminAuditLog(actorID, action, constants.AuditTargetMemory, memoryID.String(), reason), func(tx *gorm.DB) error {
+			return apply(s.memoryRepo.WithTx(tx), memory)
+		})
+	}
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return err
+	}
+
+	span.SetStatusOk()
+	recordMetrics("success")
+	return nil
+}
+
+// withAudit runs change and records entry in one transaction.
+func (s *adminService) withAudit(ctx context.Context, entry *domain.AdminAuditLog, change func(tx *gorm.DB) error) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := change(tx); err != nil {
+			return err
+		}
+		return s.auditRepo.WithTx(tx).Create(ctx, entry)
+	})
+}
+
+func newAdminAuditLog(actorID uuid.UUID, action string, targetType string, target string, reason string) *domain.AdminAuditLog {
+	return &domain.AdminAuditLog{
+		ActorID:    actorID,
+		Action:     action,
+		TargetType: targetType,
+		Target:     target,
+		Reason:     reason,
+	}
+}
+
+func adminReason(reason string) (string, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" || utf8.RuneCountInString(reason) > constants.MaxAdminReasonLength {
+		return "", apperrors.ErrAdminReasonRequired
+	}
+	return reason, nil
+}
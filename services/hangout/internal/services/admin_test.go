@@ -0,0 +1,581 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/services"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+type adminMocks struct {
+	users    *MockUserRepository
+	hangouts *MockHangoutRepository
+	memories *MockMemoryRepository
+	audit    *MockAdminAuditLogRepository
+	sessions *MockSessionService
+	auth     *MockAuthService
+	sql      sqlmock.Sqlmock
+}
+
+func newAdminService(t *testing.T) (services.AdminService, *adminMocks) {
+	t.Helper()
+	db, sqlMock := setupDB(t)
+	m := &adminMocks{
+		users:    new(MockUserRepository),
+		hangouts: new(MockHangoutRepository),
+		memories: new(MockMemoryRepository),
+		audit:    new(MockAdminAuditLogRepository),
+		sessions: new(MockSessionService),
+		auth:     new(MockAuthService),
+		sql:      sqlMock,
+	}
+	m.users.On("WithTx", mock.Anything).Return(m.users).Maybe()
+	m.memories.On("WithTx", mock.Anything).Return(m.memories).Maybe()
+	m.audit.On("WithTx", mock.Anything).Return(m.audit).Maybe()
+	svc := services.NewAdminService(db, m.users, m.hangouts, m.memories, m.audit, m.sessions, m.auth, nil)
+	return svc, m
+}
+
+func (m *adminMocks) assertExpectations(t *testing.T) {
+	m.users.AssertExpectations(t)
+	m.hangouts.AssertExpectations(t)
+	m.memories.AssertExpectations(t)
+	m.audit.AssertExpectations(t)
+	m.sessions.AssertExpectations(t)
+	m.auth.AssertExpectations(t)
+	require.NoError(t, m.sql.ExpectationsWereMet())
+}
+
+// auditEntry matches an audit log entry for the given actor, action and
+// target.
+func auditEntry(actorID uuid.UUID, action string, target string) interface{} {
+	return mock.MatchedBy(func(entry *domain.AdminAuditLog) bool {
+		return entry.ActorID == actorID && entry.Action == action && entry.Target == target && entry.Reason == "spam report"
+	})
+}
+
+func TestAdminService_RequireAdmin(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	disabledAt := time.Now()
+	dbErr := errors.New("db error")
+
+	tests := map[string]struct {
+		user    *domain.User
+		err     error
+		wantErr error
+	}{
+		"Admin":         {user: &domain.User{ID: userID, Role: constants.RoleAdmin}},
+		"RegularUser":   {user: &domain.User{ID: userID, Role: constants.RoleUser}, wantErr: apperrors.ErrAdminRequired},
+		"DisabledAdmin": {user: &domain.User{ID: userID, Role: constants.RoleAdmin, DisabledAt: &disabledAt}, wantErr: apperrors.ErrAdminRequired},
+		"NotFound":      {err: gorm.ErrRecordNotFound, wantErr: apperrors.ErrAdminRequired},
+		"DBError":       {err: dbErr, wantErr: dbErr},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			svc, m := newAdminService(t)
+			m.users.On("GetUserByID", ctx, userID).Return(tc.user, tc.err).Once()
+
+			err := svc.RequireAdmin(ctx, userID)
+
+			require.ErrorIs(t, err, tc.wantErr)
+			m.assertExpectations(t)
+		})
+	}
+}
+
+func TestAdminService_PromoteAdmins(t *testing.T) {
+	ctx := context.Background()
+	emails := []string{"admin@example.com"}
+
+	t.Run("Success", func(t *testing.T) {
+		svc, m := newAdminService(t)
+		m.users.On("PromoteToAdmin", mock.Anything, emails).Return(int64(1), nil).Once()
+
+		promoted, err := svc.PromoteAdmins(ctx, emails)
+
+		require.NoError(t, err)
+		require.Equal(t, int64(1), promoted)
+		m.assertExpectations(t)
+	})
+
+	t.Run("DBError", func(t *testing.T) {
+		svc, m := newAdminService(t)
+		dbErr := errors.New("db error")
+		m.users.On("PromoteToAdmin", mock.Anything, emails).Return(int64(0), dbErr).Once()
+
+		_, err := svc.PromoteAdmins(ctx, emails)
+
+		require.ErrorIs(t, err, dbErr)
+		m.assertExpectations(t)
+	})
+}
+
+func TestAdminService_SearchUsers(t *testing.T) {
+	ctx := context.Background()
+	actorID := uuid.New()
+	users := []domain.User{{ID: uuid.New()}, {ID: uuid.New()}, {ID: uuid.New()}}
+	dbErr := errors.New("db error")
+
+	tests := map[string]struct {
+		query       string
+		reason      string
+		setup       func(m *adminMocks)
+		wantErr     error
+		wantCount   int
+		wantHasMore bool
+	}{
+		"Success": {
+			query:  "  alice ",
+			reason: "spam report",
+			setup: func(m *adminMocks) {
+				m.audit.On("Create", mock.Anything, auditEntry(actorID, constants.AuditActionSearchUsers, "alice")).Return(nil).Once()
+				m.users.On("SearchUsers", mock.Anything, "alice", mock.Anything).Return(users[:2], nil).Once()
+			},
+			wantCount: 2,
+		},
+		"HasMore": {
+			query:  "alice",
+			reason: "spam report",
+			setup: func(m *adminMocks) {
+				m.audit.On("Create", mock.Anything, mock.Anything).Return(nil).Once()
+				m.users.On("SearchUsers", mock.Anything, "alice", mock.Anything).Return(users, nil).Once()
+			},
+			wantCount:   2,
+			wantHasMore: true,
+		},
+		"MissingReason": {
+			query:   "alice",
+			reason:  "   ",
+			setup:   func(m *adminMocks) {},
+			wantErr: apperrors.ErrAdminReasonRequired,
+		},
+		"AuditFails": {
+			query:  "alice",
+			reason: "spam report",
+			setup: func(m *adminMocks) {
+				m.audit.On("Create", mock.Anything, mock.Anything).Return(dbErr).Once()
+			},
+			wantErr: dbErr,
+		},
+		"SearchFails": {
+			query:  "alice",
+			reason: "spam report",
+			setup: func(m *adminMocks) {
+				m.audit.On("Create", mock.Anything, mock.Anything).Return(nil).Once()
+				m.users.On("SearchUsers", mock.Anything, "alice", mock.Anything).Return(nil, dbErr).Once()
+			},
+			wantErr: dbErr,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			svc, m := newAdminService(t)
+			tc.setup(m)
+
+			limit := 2
+			res, err := svc.SearchUsers(ctx, actorID, tc.query, tc.reason, &dto.CursorPagination{Limit: limit})
+
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+				require.Nil(t, res)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, res.Data, tc.wantCount)
+				require.Equal(t, tc.wantHasMore, res.HasMore)
+				if tc.wantHasMore {
+					require.Equal(t, users[1].ID, *res.NextCursor)
+				}
+			}
+			m.assertExpectations(t)
+		})
+	}
+}
+
+func TestAdminService_DisableUser(t *testing.T) {
+	ctx := context.Background()
+	actorID := uuid.New()
+	userID := uuid.New()
+	dbErr := errors.New("db error")
+
+	tests := map[string]struct {
+		actorID uuid.UUID
+		reason  string
+		setup   func(m *adminMocks)
+		wantErr error
+	}{
+		"Success": {
+			actorID: actorID,
+			reason:  "spam report",
+			setup: func(m *adminMocks) {
+				m.users.On("GetUserByID", mock.Anything, userID).Return(&domain.User{ID: userID}, nil).Once()
+				m.sql.ExpectBegin()
+				m.users.On("SetDisabledAt", mock.Anything, userID, mock.AnythingOfType("*time.Time")).Return(nil).Once()
+				m.audit.On("Create", mock.Anything, auditEntry(actorID, constants.AuditActionDisableUser, userID.String())).Return(nil).Once()
+				m.sql.ExpectCommit()
+				m.sessions.On("RevokeAllSessions", mock.Anything, userID).Return(int64(2), nil).Once()
+			},
+		},
+		"MissingReason": {
+			actorID: actorID,
+			setup:   func(m *adminMocks) {},
+			wantErr: apperrors.ErrAdminReasonRequired,
+		},
+		"ReasonTooLong": {
+			actorID: actorID,
+			reason:  strings.Repeat("a", constants.MaxAdminReasonLength+1),
+			setup:   func(m *adminMocks) {},
+			wantErr: apperrors.ErrAdminReasonRequired,
+		},
+		"Self": {
+			actorID: userID,
+			reason:  "spam report",
+			setup:   func(m *adminMocks) {},
+			wantErr: apperrors.ErrCannotModerateSelf,
+		},
+		"UserNotFound": {
+			actorID: actorID,
+			reason:  "spam report",
+			setup: func(m *adminMocks) {
+				m.users.On("GetUserByID", mock.Anything, userID).Return(nil, gorm.ErrRecordNotFound).Once()
+			},
+			wantErr: apperrors.ErrUserNotFound,
+		},
+		"AuditFails": {
+			actorID: actorID,
+			reason:  "spam report",
+			setup: func(m *adminMocks) {
+				m.users.On("GetUserByID", mock.Anything, userID).Return(&domain.User{ID: userID}, nil).Once()
+				m.sql.ExpectBegin()
+				m.users.On("SetDisabledAt", mock.Anything, userID, mock.Anything).Return(nil).Once()
+				m.audit.On("Create", mock.Anything, mock.Anything).Return(dbErr).Once()
+				m.sql.ExpectRollback()
+			},
+			wantErr: dbErr,
+		},
+		"RevokeFails": {
+			actorID: actorID,
+			reason:  "spam report",
+			setup: func(m *adminMocks) {
+				m.users.On("GetUserByID", mock.Anything, userID).Return(&domain.User{ID: userID}, nil).Once()
+				m.sql.ExpectBegin()
+				m.users.On("SetDisabledAt", mock.Anything, userID, mock.Anything).Return(nil).Once()
+				m.audit.On("Create", mock.Anything, mock.Anything).Return(nil).Once()
+				m.sql.ExpectCommit()
+				m.sessions.On("RevokeAllSessions", mock.Anything, userID).Return(int64(0), dbErr).Once()
+			},
+			wantErr: dbErr,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			svc, m := newAdminService(t)
+			tc.setup(m)
+
+			res, err := svc.DisableUser(ctx, tc.actorID, userID, tc.reason)
+
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+				require.Nil(t, res)
+			} else {
+				require.NoError(t, err)
+				require.True(t, res.Disabled)
+			}
+			m.assertExpectations(t)
+		})
+	}
+}
+
+func TestAdminService_EnableUser(t *testing.T) {
+	ctx := context.Background()
+	actorID := uuid.New()
+	userID := uuid.New()
+	disabledAt := time.Now()
+	dbErr := errors.New("db error")
+
+	t.Run("Success", func(t *testing.T) {
+		svc, m := newAdminService(t)
+		m.users.On("GetUserByID", mock.Anything, userID).Return(&domain.User{ID: userID, DisabledAt: &disabledAt}, nil).Once()
+		m.sql.ExpectBegin()
+		m.users.On("SetDisabledAt", mock.Anything, userID, (*time.Time)(nil)).Return(nil).Once()
+		m.audit.On("Create", mock.Anything, auditEntry(actorID, constants.AuditActionEnableUser, userID.String())).Return(nil).Once()
+		m.sql.ExpectCommit()
+
+		res, err := svc.EnableUser(ctx, actorID, userID, "spam report")
+
+		require.NoError(t, err)
+		require.False(t, res.Disabled)
+		m.assertExpectations(t)
+	})
+
+	t.Run("UpdateFails", func(t *testing.T) {
+		svc, m := newAdminService(t)
+		m.users.On("GetUserByID", mock.Anything, userID).Return(&domain.User{ID: userID, DisabledAt: &disabledAt}, nil).Once()
+		m.sql.ExpectBegin()
+		m.users.On("SetDisabledAt", mock.Anything, userID, (*time.Time)(nil)).Return(dbErr).Once()
+		m.sql.ExpectRollback()
+
+		res, err := svc.EnableUser(ctx, actorID, userID, "spam report")
+
+		require.ErrorIs(t, err, dbErr)
+		require.Nil(t, res)
+		m.assertExpectations(t)
+	})
+}
+
+func TestAdminService_ForcePasswordReset(t *testing.T) {
+	ctx := context.Background()
+	actorID := uuid.New()
+	userID := uuid.New()
+	user := &domain.User{ID: userID, Email: "alice@example.com"}
+	dbErr := errors.New("db error")
+
+	tests := map[string]struct {
+		setup   func(m *adminMocks)
+		wantErr error
+	}{
+		"Success": {
+			setup: func(m *adminMocks) {
+				m.users.On("GetUserByID", mock.Anything, userID).Return(user, nil).Once()
+				m.sql.ExpectBegin()
+				m.users.On("UpdatePassword", mock.Anything, userID, "").Return(nil).Once()
+				m.audit.On("Create", mock.Anything, auditEntry(actorID, constants.AuditActionResetPassword, userID.String())).Return(nil).Once()
+				m.sql.ExpectCommit()
+				m.sessions.On("RevokeAllSessions", mock.Anything, userID).Return(int64(1), nil).Once()
+				m.auth.On("ForgotPassword", mock.Anything, user.Email).Return(nil).Once()
+			},
+		},
+		"UpdateFails": {
+			setup: func(m *adminMocks) {
+				m.users.On("GetUserByID", mock.Anything, userID).Return(user, nil).Once()
+				m.sql.ExpectBegin()
+				m.users.On("UpdatePassword", mock.Anything, userID, "").Return(dbErr).Once()
+				m.sql.ExpectRollback()
+			},
+			wantErr: dbErr,
+		},
+		"EmailFails": {
+			setup: func(m *adminMocks) {
+				m.users.On("GetUserByID", mock.Anything, userID).Return(user, nil).Once()
+				m.sql.ExpectBegin()
+				m.users.On("UpdatePassword", mock.Anything, userID, "").Return(nil).Once()
+				m.audit.On("Create", mock.Anything, mock.Anything).Return(nil).Once()
+				m.sql.ExpectCommit()
+				m.sessions.On("RevokeAllSessions", mock.Anything, userID).Return(int64(1), nil).Once()
+				m.auth.On("ForgotPassword", mock.Anything, user.Email).Return(dbErr).Once()
+			},
+			wantErr: dbErr,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			svc, m := newAdminService(t)
+			tc.setup(m)
+
+			err := svc.ForcePasswordReset(ctx, actorID, userID, "spam report")
+
+			require.ErrorIs(t, err, tc.wantErr)
+			m.assertExpectations(t)
+		})
+	}
+}
+
+func TestAdminService_GetHangout(t *testing.T) {
+	ctx := context.Background()
+	actorID := uuid.New()
+	hangoutID := uuid.New()
+
+	tests := map[string]struct {
+		reason  string
+		setup   func(m *adminMocks)
+		wantErr error
+	}{
+		"Success": {
+			reason: "spam report",
+			setup: func(m *adminMocks) {
+				m.audit.On("Create", mock.Anything, auditEntry(actorID, constants.AuditActionViewHangout, hangoutID.String())).Return(nil).Once()
+				m.hangouts.On("GetHangoutByIDAnyOwner", mock.Anything, hangoutID).Return(&domain.Hangout{ID: hangoutID, Title: "Trip"}, nil).Once()
+			},
+		},
+		"MissingReason": {
+			setup:   func(m *adminMocks) {},
+			wantErr: apperrors.ErrAdminReasonRequired,
+		},
+		"NotFound": {
+			reason: "spam report",
+			setup: func(m *adminMocks) {
+				m.audit.On("Create", mock.Anything, mock.Anything).Return(nil).Once()
+				m.hangouts.On("GetHangoutByIDAnyOwner", mock.Anything, hangoutID).Return(nil, gorm.ErrRecordNotFound).Once()
+			},
+			wantErr: apperrors.ErrNotFound,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			svc, m := newAdminService(t)
+			tc.setup(m)
+
+			res, err := svc.GetHangout(ctx, actorID, hangoutID, tc.reason)
+
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+				require.Nil(t, res)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, hangoutID, res.ID)
+			}
+			m.assertExpectations(t)
+		})
+	}
+}
+
+func TestAdminService_ModerateMemory(t *testing.T) {
+	ctx := context.Background()
+	actorID := uuid.New()
+	memoryID := uuid.New()
+	hiddenAt := time.Now()
+	dbErr := errors.New("db error")
+
+	tests := map[string]struct {
+		call    func(svc services.AdminService) error
+		setup   func(m *adminMocks)
+		wantErr error
+	}{
+		"Hide": {
+			call: func(svc services.AdminService) error {
+				return svc.HideMemory(ctx, actorID, memoryID, "spam report")
+			},
+			setup: func(m *adminMocks) {
+				m.memories.On("GetMemoryByIDAnyOwner", mock.Anything, memoryID).Return(&domain.Memory{ID: memoryID}, nil).Once()
+				m.sql.ExpectBegin()
+				m.memories.On("SetHiddenAt", mock.Anything, memoryID, mock.AnythingOfType("*time.Time")).Return(nil).Once()
+				m.audit.On("Create", mock.Anything, auditEntry(actorID, constants.AuditActionHideMemory, memoryID.String())).Return(nil).Once()
+				m.sql.ExpectCommit()
+			},
+		},
+		"HideAlreadyHidden": {
+			call: func(svc services.AdminService) error {
+				return svc.HideMemory(ctx, actorID, memoryID, "spam report")
+			},
+			setup: func(m *adminMocks) {
+				m.memories.On("GetMemoryByIDAnyOwner", mock.Anything, memoryID).Return(&domain.Memory{ID: memoryID, HiddenAt: &hiddenAt}, nil).Once()
+				m.sql.ExpectBegin()
+				m.audit.On("Create", mock.Anything, mock.Anything).Return(nil).Once()
+				m.sql.ExpectCommit()
+			},
+		},
+		"Unhide": {
+			call: func(svc services.AdminService) error {
+				return svc.UnhideMemory(ctx, actorID, memoryID, "spam report")
+			},
+			setup: func(m *adminMocks) {
+				m.memories.On("GetMemoryByIDAnyOwner", mock.Anything, memoryID).Return(&domain.Memory{ID: memoryID, HiddenAt: &hiddenAt}, nil).Once()
+				m.sql.ExpectBegin()
+				m.memories.On("SetHiddenAt", mock.Anything, memoryID, (*time.Time)(nil)).Return(nil).Once()
+				m.audit.On("Create", mock.Anything, auditEntry(actorID, constants.AuditActionUnhideMemory, memoryID.String())).Return(nil).Once()
+				m.sql.ExpectCommit()
+			},
+		},
+		"Remove": {
+			call: func(svc services.AdminService) error {
+				return svc.RemoveMemory(ctx, actorID, memoryID, "spam report")
+			},
+			setup: func(m *adminMocks) {
+				m.memories.On("GetMemoryByIDAnyOwner", mock.Anything, memoryID).Return(&domain.Memory{ID: memoryID}, nil).Once()
+				m.sql.ExpectBegin()
+				m.memories.On("SetHiddenAt", mock.Anything, memoryID, mock.AnythingOfType("*time.Time")).Return(nil).Once()
+				m.memories.On("DeleteMemory", mock.Anything, memoryID).Return(nil).Once()
+				m.audit.On("Create", mock.Anything, auditEntry(actorID, constants.AuditActionRemoveMemory, memoryID.String())).Return(nil).Once()
+				m.sql.ExpectCommit()
+			},
+		},
+		"RemoveDeleteFails": {
+			call: func(svc services.AdminService) error {
+				return svc.RemoveMemory(ctx, actorID, memoryID, "spam report")
+			},
+			setup: func(m *adminMocks) {
+				m.memories.On("GetMemoryByIDAnyOwner", mock.Anything, memoryID).Return(&domain.Memory{ID: memoryID, HiddenAt: &hiddenAt}, nil).Once()
+				m.sql.ExpectBegin()
+				m.memories.On("DeleteMemory", mock.Anything, memoryID).Return(dbErr).Once()
+				m.sql.ExpectRollback()
+			},
+			wantErr: dbErr,
+		},
+		"MissingReason": {
+			call: func(svc services.AdminService) error {
+				return svc.HideMemory(ctx, actorID, memoryID, "")
+			},
+			setup:   func(m *adminMocks) {},
+			wantErr: apperrors.ErrAdminReasonRequired,
+		},
+		"NotFound": {
+			call: func(svc services.AdminService) error {
+				return svc.HideMemory(ctx, actorID, memoryID, "spam report")
+			},
+			setup: func(m *adminMocks) {
+				m.memories.On("GetMemoryByIDAnyOwner", mock.Anything, memoryID).Return(nil, gorm.ErrRecordNotFound).Once()
+			},
+			wantErr: apperrors.ErrMemoryNotFound,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			svc, m := newAdminService(t)
+			tc.setup(m)
+
+			err := tc.call(svc)
+
+			require.ErrorIs(t, err, tc.wantErr)
+			m.assertExpectations(t)
+		})
+	}
+}
+
+func TestAdminService_ListAuditLogs(t *testing.T) {
+	ctx := context.Background()
+	filter := &dto.AdminAuditLogFilter{Target: "target"}
+	entries := []domain.AdminAuditLog{{ID: uuid.New()}, {ID: uuid.New()}}
+
+	t.Run("HasMore", func(t *testing.T) {
+		svc, m := newAdminService(t)
+		m.audit.On("List", mock.Anything, filter, mock.Anything).Return(entries, nil).Once()
+
+		res, err := svc.ListAuditLogs(ctx, filter, &dto.CursorPagination{Limit: 1})
+
+		require.NoError(t, err)
+		require.Len(t, res.Data, 1)
+		require.True(t, res.HasMore)
+		require.Equal(t, entries[0].ID, *res.NextCursor)
+		m.assertExpectations(t)
+	})
+
+	t.Run("DBError", func(t *testing.T) {
+		svc, m := newAdminService(t)
+		dbErr := errors.New("db error")
+		m.audit.On("List", mock.Anything, filter, mock.Anything).Return(nil, dbErr).Once()
+
+		res, err := svc.ListAuditLogs(ctx, filter, &dto.CursorPagination{Limit: 1})
+
+		require.ErrorIs(t, err, dbErr)
+		require.Nil(t, res)
+		m.assertExpectations(t)
+	})
+}
@@ -0,0 +1,397 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/mapper"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/ordering"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/otel"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/repository"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"gorm.io/gorm"
+)
+
+// AlbumService groups the memories of a hangout into named albums and keeps their
+// manual order.
+type AlbumService interface {
+	ListAlbums(ctx context.Context, userID uuid.UUID, hangoutID uuid.UUID) ([]dto.AlbumResponse, error)
+	CreateAlbum(ctx context.Context, userID uuid.UUID, hangoutID uuid.UUID, req *dto.CreateAlbumRequest) (*dto.AlbumResponse, error)
+	PatchAlbum(ctx context.Context, userID uuid.UUID, albumID uuid.UUID, req *dto.PatchAlbumRequest) (*dto.AlbumResponse, error)
+	DeleteAlbum(ctx context.Context, userID uuid.UUID, albumID uuid.UUID) error
+	MoveMemory(ctx context.Context, userID uuid.UUID, memoryID uuid.UUID, req *dto.MoveMemoryRequest) (*dto.MemoryPlacementResponse, error)
+}
+
+type albumService struct {
+	db          *gorm.DB
+	repo        repository.AlbumRepository
+	memoryRepo  repository.MemoryRepository
+	hangoutRepo repository.HangoutRepository
+	metrics     *otel.MetricsRecorder
+}
+
+func NewAlbumService(db *gorm.DB, repo repository.AlbumRepository, memoryRepo repository.MemoryRepository, hangoutRepo repository.HangoutRepository, metrics *otel.MetricsRecorder) AlbumService {
+	return &albumService{
+		db:          db,
+		repo:        repo,
+		memoryRepo:  memoryRepo,
+		hangoutRepo: hangoutRepo,
+		metrics:     metrics,
+	}
+}
+
+func (s *albumService) ListAlbums(ctx context.Context, userID uuid.UUID, hangoutID uuid.UUID) ([]dto.AlbumResponse, error) {
+	recordMetrics := s.metrics.StartRequest(ctx, "album", "list")
+
+	ctx, span := otel.StartServiceSpan(ctx, "ListAlbums",
+		attribute.String("user.id", userID.String()),
+		attribute.String("hangout.id", hangoutID.String()),
+	)
+	defer span.End()
+
+	if _, err := s.hangoutRepo.GetHangoutByID(ctx, hangoutID, userID); err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	albums, err := s.repo.GetAlbumsByHangoutID(ctx, hangoutID)
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("album.count", len(albums)))
+	span.SetStatusOk()
+	recordMetrics("success")
+	return mapper.AlbumsToResponseDTOs(albums), nil
+}
+
+func (s *albumService) CreateAlbum(ctx context.Context, userID uuid.UUID, hangoutID uuid.UUID, req *dto.CreateAlbumRequest) (*dto.AlbumResponse, error) {
+	recordMetrics := s.metrics.StartRequest(ctx, "album", "create")
+
+	ctx, span := otel.StartServiceSpan(ctx, "CreateAlbum",
+		attribute.String("user.id", userID.String()),
+		attribute.String("hangout.id", hangoutID.String()),
+	)
+	defer span.End()
+
+	if _, err := s.getHangoutForOrganizer(ctx, userID, hangoutID); err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	if err := s.checkNameAvailable(ctx, hangoutID, uuid.Nil, req.Name); err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	album := &domain.Album{Name: req.Name, HangoutID: hangoutID}
+	if err := s.repo.CreateAlbum(ctx, album); err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.String("album.id", album.ID.String()))
+	span.SetStatusOk()
+	recordMetrics("success")
+	return mapper.AlbumToResponseDTO(album), nil
+}
+
+// PatchAlbum renames the album or changes its cover.
+func (s *albumService) PatchAlbum(ctx context.Context, userID uuid.UUID, albumID uuid.UUID, req *dto.PatchAlbumRequest) (*dto.AlbumResponse, error) {
+	recordMetrics := s.metrics.StartRequest(ctx, "album", "patch")
+
+	ctx, span := otel.StartServiceSpan(ctx, "PatchAlbum",
+		attribute.String("user.id", userID.String()),
+		attribute.String("album.id", albumID.String()),
+	)
+	defer span.End()
+
+	album, err := s.getAlbumForOrganizer(ctx, userID, albumID)
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	if req.Name.Present() && req.Name.Value != album.Name {
+		if err := s.checkNameAvailable(ctx, album.HangoutID, album.ID, req.Name.Value); err != nil {
+			recordMetrics("error")
+			_ = span.RecordErrorWithStatus(err)
+			return nil, err
+		}
+		album.Name = req.Name.Value
+	}
+
+	if req.CoverMemoryID.Set {
+		album.CoverMemoryID = nil
+		if !req.CoverMemoryID.Null {
+			memory, err := s.memoryRepo.GetMemoryByIDAnyOwner(ctx, req.CoverMemoryID.Value)
+			if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && (memory.AlbumID == nil || *memory.AlbumID != album.ID)) {
+				err = apperrors.ErrInvalidAlbumCover
+			}
+			if err != nil {
+				recordMetrics("error")
+				_ = span.RecordErrorWithStatus(err)
+				return nil, err
+			}
+			album.CoverMemoryID = &memory.ID
+		}
+	}
+
+	if err := s.repo.UpdateAlbum(ctx, album); err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	updated, err := s.repo.GetAlbumByID(ctx, album.ID)
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetStatusOk()
+	recordMetrics("success")
+	return mapper.AlbumToResponseDTO(updated), nil
+}
+
+// DeleteAlbum removes the album, revokes its share links and appends its
+// memories, in album order, to the loose memories of the hangout.
+func (s *albumService) DeleteAlbum(ctx context.Context, userID uuid.UUID, albumID uuid.UUID) error {
+	recordMetrics := s.metrics.StartRequest(ctx, "album", "delete")
+
+	ctx, span := otel.StartServiceSpan(ctx, "DeleteAlbum",
+		attribute.String("user.id", userID.String()),
+		attribute.String("album.id", albumID.String()),
+	)
+	defer span.End()
+
+	album, err := s.getAlbumForOrganizer(ctx, userID, albumID)
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return err
+	}
+
+	var moved int
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		memoryRepo := s.memoryRepo.WithTx(tx)
+
+		memories, err := memoryRepo.GetMemoriesByAlbumID(ctx, album.ID)
+		if err != nil {
+			return err
+		}
+		moved = len(memories)
+
+		last, err := memoryRepo.GetNeighbourPosition(ctx, album.HangoutID, nil, "", false)
+		if err != nil {
+			return err
+		}
+		positions := make(map[uuid.UUID]string, len(memories))
+		for _, memory := range memories {
+			if last, err = ordering.KeyBetween(last, ""); err != nil {
+				return err
+			}
+			positions[memory.ID] = last
+		}
+
+		if err := memoryRepo.UpdatePlacements(ctx, nil, positions); err != nil {
+			return err
+		}
+		return s.repo.WithTx(tx).DeleteAlbum(ctx, album.ID, time.Now())
+	})
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return err
+	}
+
+	span.SetAttributes(attribute.Int("memory.moved_count", moved))
+	span.SetStatusOk()
+	recordMetrics("success")
+	return nil
+}
+
+// MoveMemory puts a memory into an album, or back among the loose memories,
+// between two of its memories. Only the moved memory gets a new position.
+func (s *albumService) MoveMemory(ctx context.Context, userID uuid.UUID, memoryID uuid.UUID, req *dto.MoveMemoryRequest) (*dto.MemoryPlacementResponse, error) {
+	recordMetrics := s.metrics.StartRequest(ctx, "album", "move_memory")
+
+	ctx, span := otel.StartServiceSpan(ctx, "MoveMemory",
+		attribute.String("user.id", userID.String()),
+		attribute.String("memory.id", memoryID.String()),
+	)
+	defer span.End()
+
+	memory, err := s.memoryRepo.GetMemoryByIDAnyOwner(ctx, memoryID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		err = apperrors.ErrMemoryNotFound
+	}
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	if _, err := s.getHangoutForOrganizer(ctx, userID, memory.HangoutID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			err = apperrors.ErrMemoryNotFound
+		}
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	if req.AlbumID != nil {
+		album, err := s.repo.GetAlbumByID(ctx, *req.AlbumID)
+		if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && album.HangoutID != memory.HangoutID) {
+			err = apperrors.ErrInvalidAlbumID
+		}
+		if err != nil {
+			recordMetrics("error")
+			_ = span.RecordErrorWithStatus(err)
+			return nil, err
+		}
+	}
+
+	var after, before string
+	if req.AfterID != nil {
+		if after, err = s.neighbourPosition(ctx, memory, req.AlbumID, *req.AfterID); err != nil {
+			recordMetrics("error")
+			_ = span.RecordErrorWithStatus(err)
+			return nil, err
+		}
+	}
+	if req.BeforeID != nil {
+		if before, err = s.neighbourPosition(ctx, memory, req.AlbumID, *req.BeforeID); err != nil {
+			recordMetrics("error")
+			_ = span.RecordErrorWithStatus(err)
+			return nil, err
+		}
+	}
+
+	switch {
+	case req.AfterID != nil && req.BeforeID == nil:
+		before, err = s.memoryRepo.GetNeighbourPosition(ctx, memory.HangoutID, req.AlbumID, after, true)
+	case req.AfterID == nil && req.BeforeID != nil:
+		after, err = s.memoryRepo.GetNeighbourPosition(ctx, memory.HangoutID, req.AlbumID, before, false)
+	case req.AfterID == nil && req.BeforeID == nil:
+		after, err = s.memoryRepo.GetNeighbourPosition(ctx, memory.HangoutID, req.AlbumID, "", false)
+	}
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	// The neighbour lookups can return the memory itself when it already
+	// sits next to the requested spot; it is then already in place.
+	if after == memory.Position && req.AfterID == nil && sameAlbum(memory.AlbumID, req.AlbumID) {
+		after, err = s.memoryRepo.GetNeighbourPosition(ctx, memory.HangoutID, req.AlbumID, after, false)
+	} else if before == memory.Position && req.BeforeID == nil && sameAlbum(memory.AlbumID, req.AlbumID) {
+		before, err = s.memoryRepo.GetNeighbourPosition(ctx, memory.HangoutID, req.AlbumID, before, true)
+	}
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	position, err := ordering.KeyBetween(after, before)
+	if errors.Is(err, apperrors.ErrInvalidOrderKey) {
+		err = apperrors.ErrInvalidMemoryPlacement
+	}
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	if err := s.memoryRepo.UpdatePlacements(ctx, req.AlbumID, map[uuid.UUID]string{memory.ID: position}); err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+	memory.AlbumID = req.AlbumID
+	memory.Position = position
+
+	span.SetAttributes(attribute.String("memory.position", position))
+	span.SetStatusOk()
+	recordMetrics("success")
+	return mapper.MemoryToPlacementDTO(memory), nil
+}
+
+// getHangoutForOrganizer loads a hangout the user organizes.
+func (s *albumService) getHangoutForOrganizer(ctx context.Context, userID uuid.UUID, hangoutID uuid.UUID) (*domain.Hangout, error) {
+	hangout, err := s.hangoutRepo.GetHangoutByID(ctx, hangoutID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if hangout.UserID == nil || *hangout.UserID != userID {
+		return nil, apperrors.ErrForbidden
+	}
+	return hangout, nil
+}
+
+// getAlbumForOrganizer loads an album of a hangout the user organizes.
+func (s *albumService) getAlbumForOrganizer(ctx context.Context, userID uuid.UUID, albumID uuid.UUID) (*domain.Album, error) {
+	album, err := s.repo.GetAlbumByID(ctx, albumID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.getHangoutForOrganizer(ctx, userID, album.HangoutID); err != nil {
+		return nil, err
+	}
+	return album, nil
+}
+
+// checkNameAvailable reports apperrors.ErrAlbumNameTaken when another album of
+// the hangout already has the name, ignoring case.
+func (s *albumService) checkNameAvailable(ctx context.Context, hangoutID uuid.UUID, albumID uuid.UUID, name string) error {
+	albums, err := s.repo.GetAlbumsByHangoutID(ctx, hangoutID)
+	if err != nil {
+		return err
+	}
+	for _, album := range albums {
+		if album.ID != albumID && strings.EqualFold(album.Name, name) {
+			return apperrors.ErrAlbumNameTaken
+		}
+	}
+	return nil
+}
+
+// neighbourPosition returns the position of a memory the moved memory is
+// placed next to. It must be another memory of the target album.
+func (s *albumService) neighbourPosition(ctx context.Context, memory *domain.Memory, albumID *uuid.UUID, neighbourID uuid.UUID) (string, error) {
+	if neighbourID == memory.ID {
+		return "", apperrors.ErrInvalidMemoryPlacement
+	}
+	neighbour, err := s.memoryRepo.GetMemoryByIDAnyOwner(ctx, neighbourID)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && (neighbour.HangoutID != memory.HangoutID || !sameAlbum(neighbour.AlbumID, albumID))) {
+		return "", apperrors.ErrInvalidMemoryPlacement
+	}
+	if err != nil {
+		return "", err
+	}
+	return neighbour.Position, nil
+}
+
+func sameAlbum(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,380 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/services"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestAlbumService_ListAlbums(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	hangout := &domain.Hangout{ID: uuid.New(), UserID: &userID}
+
+	t.Run("success", func(t *testing.T) {
+		repo := new(MockAlbumRepository)
+		hangoutRepo := new(MockHangoutRepository)
+		svc := services.NewAlbumService(nil, repo, nil, hangoutRepo, nil)
+		hangoutRepo.On("GetHangoutByID", mock.Anything, hangout.ID, userID).Return(hangout, nil)
+		repo.On("GetAlbumsByHangoutID", mock.Anything, hangout.ID).Return([]domain.Album{{ID: uuid.New(), Name: "Day 1"}}, nil)
+
+		res, err := svc.ListAlbums(ctx, userID, hangout.ID)
+		require.NoError(t, err)
+		require.Len(t, res, 1)
+		require.Equal(t, "Day 1", res[0].Name)
+	})
+
+	t.Run("non participant", func(t *testing.T) {
+		repo := new(MockAlbumRepository)
+		hangoutRepo := new(MockHangoutRepository)
+		svc := services.NewAlbumService(nil, repo, nil, hangoutRepo, nil)
+		hangoutRepo.On("GetHangoutByID", mock.Anything, hangout.ID, userID).Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := svc.ListAlbums(ctx, userID, hangout.ID)
+		require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+		repo.AssertNotCalled(t, "GetAlbumsByHangoutID", mock.Anything, mock.Anything)
+	})
+}
+
+func TestAlbumService_CreateAlbum(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	otherID := uuid.New()
+	hangout := &domain.Hangout{ID: uuid.New(), UserID: &userID}
+	dbError := errors.New("db error")
+
+	tests := []struct {
+		name      string
+		userID    uuid.UUID
+		setup     func(*MockAlbumRepository, *MockHangoutRepository)
+		wantError error
+	}{
+		{
+			name:   "success",
+			userID: userID,
+			setup: func(repo *MockAlbumRepository, hangoutRepo *MockHangoutRepository) {
+				hangoutRepo.On("GetHangoutByID", mock.Anything, hangout.ID, userID).Return(hangout, nil)
+				repo.On("GetAlbumsByHangoutID", mock.Anything, hangout.ID).Return([]domain.Album{{ID: uuid.New(), Name: "Beach"}}, nil)
+				repo.On("CreateAlbum", mock.Anything, mock.MatchedBy(func(a *domain.Album) bool {
+					return a.Name == "Day 1" && a.HangoutID == hangout.ID
+				})).Return(nil)
+			},
+		},
+		{
+			name:   "not organizer",
+			userID: otherID,
+			setup: func(repo *MockAlbumRepository, hangoutRepo *MockHangoutRepository) {
+				hangoutRepo.On("GetHangoutByID", mock.Anything, hangout.ID, otherID).Return(hangout, nil)
+			},
+			wantError: apperrors.ErrForbidden,
+		},
+		{
+			name:   "name taken ignoring case",
+			userID: userID,
+			setup: func(repo *MockAlbumRepository, hangoutRepo *MockHangoutRepository) {
+				hangoutRepo.On("GetHangoutByID", mock.Anything, hangout.ID, userID).Return(hangout, nil)
+				repo.On("GetAlbumsByHangoutID", mock.Anything, hangout.ID).Return([]domain.Album{{ID: uuid.New(), Name: "DAY 1"}}, nil)
+			},
+			wantError: apperrors.ErrAlbumNameTaken,
+		},
+		{
+			name:   "create error",
+			userID: userID,
+			setup: func(repo *MockAlbumRepository, hangoutRepo *MockHangoutRepository) {
+				hangoutRepo.On("GetHangoutByID", mock.Anything, hangout.ID, userID).Return(hangout, nil)
+				repo.On("GetAlbumsByHangoutID", mock.Anything, hangout.ID).Return([]domain.Album{}, nil)
+				repo.On("CreateAlbum", mock.Anything, mock.Anything).Return(dbError)
+			},
+			wantError: dbError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockAlbumRepository)
+			hangoutRepo := new(MockHangoutRepository)
+			tt.setup(repo, hangoutRepo)
+			svc := services.NewAlbumService(nil, repo, nil, hangoutRepo, nil)
+
+			res, err := svc.CreateAlbum(ctx, tt.userID, hangout.ID, &dto.CreateAlbumRequest{Name: "Day 1"})
+			if tt.wantError != nil {
+				require.ErrorIs(t, err, tt.wantError)
+				require.Nil(t, res)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, "Day 1", res.Name)
+			}
+			repo.AssertExpectations(t)
+			hangoutRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAlbumService_PatchAlbum(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	hangout := &domain.Hangout{ID: uuid.New(), UserID: &userID}
+	albumID := uuid.New()
+	coverID := uuid.New()
+
+	tests := []struct {
+		name      string
+		req       *dto.PatchAlbumRequest
+		setup     func(*MockAlbumRepository, *MockMemoryRepository)
+		wantError error
+		wantCover *uuid.UUID
+	}{
+		{
+			name: "rename and set cover",
+			req: &dto.PatchAlbumRequest{
+				Name:          dto.Nullable[string]{Set: true, Value: "Day 2"},
+				CoverMemoryID: dto.Nullable[uuid.UUID]{Set: true, Value: coverID},
+			},
+			setup: func(repo *MockAlbumRepository, memRepo *MockMemoryRepository) {
+				repo.On("GetAlbumsByHangoutID", mock.Anything, hangout.ID).Return([]domain.Album{{ID: albumID, Name: "Day 1"}}, nil)
+				memRepo.On("GetMemoryByIDAnyOwner", mock.Anything, coverID).Return(&domain.Memory{ID: coverID, AlbumID: &albumID}, nil)
+				repo.On("UpdateAlbum", mock.Anything, mock.MatchedBy(func(a *domain.Album) bool {
+					return a.Name == "Day 2" && a.CoverMemoryID != nil && *a.CoverMemoryID == coverID
+				})).Return(nil)
+			},
+			wantCover: &coverID,
+		},
+		{
+			name: "remove cover",
+			req:  &dto.PatchAlbumRequest{CoverMemoryID: dto.Nullable[uuid.UUID]{Set: true, Null: true}},
+			setup: func(repo *MockAlbumRepository, memRepo *MockMemoryRepository) {
+				repo.On("UpdateAlbum", mock.Anything, mock.MatchedBy(func(a *domain.Album) bool {
+					return a.CoverMemoryID == nil
+				})).Return(nil)
+			},
+		},
+		{
+			name: "cover outside album",
+			req:  &dto.PatchAlbumRequest{CoverMemoryID: dto.Nullable[uuid.UUID]{Set: true, Value: coverID}},
+			setup: func(repo *MockAlbumRepository, memRepo *MockMemoryRepository) {
+				memRepo.On("GetMemoryByIDAnyOwner", mock.Anything, coverID).Return(&domain.Memory{ID: coverID}, nil)
+			},
+			wantError: apperrors.ErrInvalidAlbumCover,
+		},
+		{
+			name: "cover not found",
+			req:  &dto.PatchAlbumRequest{CoverMemoryID: dto.Nullable[uuid.UUID]{Set: true, Value: coverID}},
+			setup: func(repo *MockAlbumRepository, memRepo *MockMemoryRepository) {
+				memRepo.On("GetMemoryByIDAnyOwner", mock.Anything, coverID).Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantError: apperrors.ErrInvalidAlbumCover,
+		},
+		{
+			name: "name taken",
+			req:  &dto.PatchAlbumRequest{Name: dto.Nullable[string]{Set: true, Value: "Beach"}},
+			setup: func(repo *MockAlbumRepository, memRepo *MockMemoryRepository) {
+				repo.On("GetAlbumsByHangoutID", mock.Anything, hangout.ID).Return([]domain.Album{{ID: albumID, Name: "Day 1"}, {ID: uuid.New(), Name: "beach"}}, nil)
+			},
+			wantError: apperrors.ErrAlbumNameTaken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockAlbumRepository)
+			memRepo := new(MockMemoryRepository)
+			hangoutRepo := new(MockHangoutRepository)
+			album := &domain.Album{ID: albumID, HangoutID: hangout.ID, Name: "Day 1", CoverMemoryID: &coverID}
+			repo.On("GetAlbumByID", mock.Anything, albumID).Return(album, nil)
+			hangoutRepo.On("GetHangoutByID", mock.Anything, hangout.ID, userID).Return(hangout, nil)
+			tt.setup(repo, memRepo)
+			svc := services.NewAlbumService(nil, repo, memRepo, hangoutRepo, nil)
+
+			res, err := svc.PatchAlbum(ctx, userID, albumID, tt.req)
+			if tt.wantError != nil {
+				require.ErrorIs(t, err, tt.wantError)
+				repo.AssertNotCalled(t, "UpdateAlbum", mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.wantCover, res.CoverMemoryID)
+			repo.AssertExpectations(t)
+			memRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAlbumService_DeleteAlbum(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	hangout := &domain.Hangout{ID: uuid.New(), UserID: &userID}
+	album := &domain.Album{ID: uuid.New(), HangoutID: hangout.ID}
+
+	t.Run("appends memories to loose memories", func(t *testing.T) {
+		db, sqlMock := setupDB(t)
+		repo := new(MockAlbumRepository)
+		memRepo := new(MockMemoryRepository)
+		hangoutRepo := new(MockHangoutRepository)
+		svc := services.NewAlbumService(db, repo, memRepo, hangoutRepo, nil)
+
+		memories := []domain.Memory{{ID: uuid.New()}, {ID: uuid.New()}}
+		repo.On("GetAlbumByID", mock.Anything, album.ID).Return(album, nil)
+		hangoutRepo.On("GetHangoutByID", mock.Anything, hangout.ID, userID).Return(hangout, nil)
+		sqlMock.ExpectBegin()
+		memRepo.On("WithTx", mock.Anything).Return(memRepo)
+		repo.On("WithTx", mock.Anything).Return(repo)
+		memRepo.On("GetMemoriesByAlbumID", mock.Anything, album.ID).Return(memories, nil)
+		memRepo.On("GetNeighbourPosition", mock.Anything, hangout.ID, (*uuid.UUID)(nil), "", false).Return("a3", nil)
+		memRepo.On("UpdatePlacements", mock.Anything, (*uuid.UUID)(nil), map[uuid.UUID]string{memories[0].ID: "a4", memories[1].ID: "a5"}).Return(nil)
+		repo.On("DeleteAlbum", mock.Anything, album.ID, mock.AnythingOfType("time.Time")).Return(nil)
+		sqlMock.ExpectCommit()
+
+		require.NoError(t, svc.DeleteAlbum(ctx, userID, album.ID))
+		repo.AssertExpectations(t)
+		memRepo.AssertExpectations(t)
+		require.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("not organizer", func(t *testing.T) {
+		otherID := uuid.New()
+		repo := new(MockAlbumRepository)
+		hangoutRepo := new(MockHangoutRepository)
+		svc := services.NewAlbumService(nil, repo, nil, hangoutRepo, nil)
+		repo.On("GetAlbumByID", mock.Anything, album.ID).Return(album, nil)
+		hangoutRepo.On("GetHangoutByID", mock.Anything, hangout.ID, otherID).Return(hangout, nil)
+
+		require.ErrorIs(t, svc.DeleteAlbum(ctx, otherID, album.ID), apperrors.ErrForbidden)
+		repo.AssertNotCalled(t, "DeleteAlbum", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestAlbumService_MoveMemory(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	hangout := &domain.Hangout{ID: uuid.New(), UserID: &userID}
+	albumID := uuid.New()
+	otherAlbumID := uuid.New()
+	memoryID := uuid.New()
+	afterID := uuid.New()
+	beforeID := uuid.New()
+
+	tests := []struct {
+		name         string
+		req          *dto.MoveMemoryRequest
+		setup        func(*MockAlbumRepository, *MockMemoryRepository)
+		wantError    error
+		wantPosition string
+	}{
+		{
+			name: "between two memories of an album",
+			req:  &dto.MoveMemoryRequest{AlbumID: &albumID, AfterID: &afterID, BeforeID: &beforeID},
+			setup: func(repo *MockAlbumRepository, memRepo *MockMemoryRepository) {
+				repo.On("GetAlbumByID", mock.Anything, albumID).Return(&domain.Album{ID: albumID, HangoutID: hangout.ID}, nil)
+				memRepo.On("GetMemoryByIDAnyOwner", mock.Anything, afterID).Return(&domain.Memory{ID: afterID, HangoutID: hangout.ID, AlbumID: &albumID, Position: "a0"}, nil)
+				memRepo.On("GetMemoryByIDAnyOwner", mock.Anything, beforeID).Return(&domain.Memory{ID: beforeID, HangoutID: hangout.ID, AlbumID: &albumID, Position: "a1"}, nil)
+				memRepo.On("UpdatePlacements", mock.Anything, &albumID, map[uuid.UUID]string{memoryID: "a0V"}).Return(nil)
+			},
+			wantPosition: "a0V",
+		},
+		{
+			name: "after a memory fills the upper bound",
+			req:  &dto.MoveMemoryRequest{AfterID: &afterID},
+			setup: func(repo *MockAlbumRepository, memRepo *MockMemoryRepository) {
+				memRepo.On("GetMemoryByIDAnyOwner", mock.Anything, afterID).Return(&domain.Memory{ID: afterID, HangoutID: hangout.ID, Position: "a0"}, nil)
+				memRepo.On("GetNeighbourPosition", mock.Anything, hangout.ID, (*uuid.UUID)(nil), "a0", true).Return("a2", nil)
+				memRepo.On("UpdatePlacements", mock.Anything, (*uuid.UUID)(nil), map[uuid.UUID]string{memoryID: "a1"}).Return(nil)
+			},
+			wantPosition: "a1",
+		},
+		{
+			name: "after the memory that already follows it",
+			req:  &dto.MoveMemoryRequest{AfterID: &afterID},
+			setup: func(repo *MockAlbumRepository, memRepo *MockMemoryRepository) {
+				memRepo.On("GetMemoryByIDAnyOwner", mock.Anything, afterID).Return(&domain.Memory{ID: afterID, HangoutID: hangout.ID, Position: "a4"}, nil)
+				memRepo.On("GetNeighbourPosition", mock.Anything, hangout.ID, (*uuid.UUID)(nil), "a4", true).Return("a5", nil)
+				memRepo.On("GetNeighbourPosition", mock.Anything, hangout.ID, (*uuid.UUID)(nil), "a5", true).Return("", nil)
+				memRepo.On("UpdatePlacements", mock.Anything, (*uuid.UUID)(nil), map[uuid.UUID]string{memoryID: "a5"}).Return(nil)
+			},
+			wantPosition: "a5",
+		},
+		{
+			name: "to the end of an empty album",
+			req:  &dto.MoveMemoryRequest{AlbumID: &albumID},
+			setup: func(repo *MockAlbumRepository, memRepo *MockMemoryRepository) {
+				repo.On("GetAlbumByID", mock.Anything, albumID).Return(&domain.Album{ID: albumID, HangoutID: hangout.ID}, nil)
+				memRepo.On("GetNeighbourPosition", mock.Anything, hangout.ID, &albumID, "", false).Return("", nil)
+				memRepo.On("UpdatePlacements", mock.Anything, &albumID, map[uuid.UUID]string{memoryID: "a0"}).Return(nil)
+			},
+			wantPosition: "a0",
+		},
+		{
+			name: "album of another hangout",
+			req:  &dto.MoveMemoryRequest{AlbumID: &otherAlbumID},
+			setup: func(repo *MockAlbumRepository, memRepo *MockMemoryRepository) {
+				repo.On("GetAlbumByID", mock.Anything, otherAlbumID).Return(&domain.Album{ID: otherAlbumID, HangoutID: uuid.New()}, nil)
+			},
+			wantError: apperrors.ErrInvalidAlbumID,
+		},
+		{
+			name: "neighbour in another album",
+			req:  &dto.MoveMemoryRequest{AlbumID: &albumID, AfterID: &afterID},
+			setup: func(repo *MockAlbumRepository, memRepo *MockMemoryRepository) {
+				repo.On("GetAlbumByID", mock.Anything, albumID).Return(&domain.Album{ID: albumID, HangoutID: hangout.ID}, nil)
+				memRepo.On("GetMemoryByIDAnyOwner", mock.Anything, afterID).Return(&domain.Memory{ID: afterID, HangoutID: hangout.ID, AlbumID: &otherAlbumID, Position: "a0"}, nil)
+			},
+			wantError: apperrors.ErrInvalidMemoryPlacement,
+		},
+		{
+			name:      "next to itself",
+			req:       &dto.MoveMemoryRequest{BeforeID: &memoryID},
+			setup:     func(repo *MockAlbumRepository, memRepo *MockMemoryRepository) {},
+			wantError: apperrors.ErrInvalidMemoryPlacement,
+		},
+		{
+			name: "neighbours out of order",
+			req:  &dto.MoveMemoryRequest{AfterID: &afterID, BeforeID: &beforeID},
+			setup: func(repo *MockAlbumRepository, memRepo *MockMemoryRepository) {
+				memRepo.On("GetMemoryByIDAnyOwner", mock.Anything, afterID).Return(&domain.Memory{ID: afterID, HangoutID: hangout.ID, Position: "a3"}, nil)
+				memRepo.On("GetMemoryByIDAnyOwner", mock.Anything, beforeID).Return(&domain.Memory{ID: beforeID, HangoutID: hangout.ID, Position: "a1"}, nil)
+			},
+			wantError: apperrors.ErrInvalidMemoryPlacement,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockAlbumRepository)
+			memRepo := new(MockMemoryRepository)
+			hangoutRepo := new(MockHangoutRepository)
+			memRepo.On("GetMemoryByIDAnyOwner", mock.Anything, memoryID).Return(&domain.Memory{ID: memoryID, HangoutID: hangout.ID, Position: "a5"}, nil)
+			hangoutRepo.On("GetHangoutByID", mock.Anything, hangout.ID, userID).Return(hangout, nil)
+			tt.setup(repo, memRepo)
+			svc := services.NewAlbumService(nil, repo, memRepo, hangoutRepo, nil)
+
+			res, err := svc.MoveMemory(ctx, userID, memoryID, tt.req)
+			if tt.wantError != nil {
+				require.ErrorIs(t, err, tt.wantError)
+				memRepo.AssertNotCalled(t, "UpdatePlacements", mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.wantPosition, res.Position)
+			require.Equal(t, tt.req.AlbumID, res.AlbumID)
+			memRepo.AssertExpectations(t)
+			repo.AssertExpectations(t)
+		})
+	}
+
+	t.Run("memory not found", func(t *testing.T) {
+		memRepo := new(MockMemoryRepository)
+		svc := services.NewAlbumService(nil, nil, memRepo, nil, nil)
+		memRepo.On("GetMemoryByIDAnyOwner", mock.Anything, memoryID).Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := svc.MoveMemory(ctx, userID, memoryID, &dto.MoveMemoryRequest{})
+		require.ErrorIs(t, err, apperrors.ErrMemoryNotFound)
+	})
+}
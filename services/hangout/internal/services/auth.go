@@ -2,39 +2,82 @@ package services
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"log"
 	"time"
 
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/config"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants/logmsg"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/mailer"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/otel"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/utils"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	verificationEmailSubject  = "Confirm your email address"
+	verificationEmailTemplate = "Hi %s,\n\nConfirm your email address by opening this link:\n%s\n\nThe link expires in %d hours. If you did not sign up, you can ignore this email.\n"
+	passwordResetEmailSubject = "Reset your password"
+	passwordResetTemplate     = "Hi %s,\n\nSomeone asked to reset the password of your account. Choose a new password by opening this link:\n%s\n\nThe link expires in %d minutes and works once. If you did not ask for this, you can ignore this email.\n"
+	fingerprintLength         = 16
 )
 
 type AuthService interface {
 	SignUser(ctx context.Context, request *dto.SignUpRequest) (*domain.User, error)
+	// SignInUser checks the password.
 	SignInUser(ctx context.Context, request *dto.SignInRequest) (*dto.SignInResponse, error)
+	// CompleteSignIn signs in a user whose first factor was checked elsewhere,
+	// such as by a sign in provider.
+	CompleteSignIn(ctx context.Context, user *domain.User, client dto.SessionClient) (*dto.SignInResponse, error)
+	VerifyMFAChallenge(ctx context.Context, request *dto.MFAChallengeRequest) (*dto.SignInResponse, error)
+	VerifyEmail(ctx context.Context, token string) error
+	ResendVerification(ctx context.Context, userID uuid.UUID) error
+	ForgotPassword(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, request *dto.ResetPasswordRequest) error
 }
 
 type authService struct {
-	userService UserService
-	jwtUtils    utils.JWTUtils
-	bcrytpUtils utils.BcryptUtils
-	metrics     *otel.MetricsRecorder
+	userService    UserService
+	mfaService     MFAService
+	sessions       SessionService
+	bcrytpUtils    utils.BcryptUtils
+	passwordPolicy utils.PasswordPolicy
+	actionTokens   utils.ActionTokenUtils
+	mailSender     mailer.Sender
+	accountCfg     *config.AccountConfig
+	mfaCfg         *config.MFAConfig
+	metrics        *otel.MetricsRecorder
 }
 
-func NewAuthService(userService UserService, jwtUtils utils.JWTUtils, bcrytpUtils utils.BcryptUtils, metrics *otel.MetricsRecorder) AuthService {
+func NewAuthService(userService UserService, mfaService MFAService, sessions SessionService, bcrytpUtils utils.BcryptUtils, passwordPolicy utils.PasswordPolicy, actionTokens utils.ActionTokenUtils, mailSender mailer.Sender, accountCfg *config.AccountConfig, mfaCfg *config.MFAConfig, metrics *otel.MetricsRecorder) AuthService {
 	return &authService{
-		userService: userService,
-		jwtUtils:    jwtUtils,
-		bcrytpUtils: bcrytpUtils,
-		metrics:     metrics,
+		userService:    userService,
+		mfaService:     mfaService,
+		sessions:       sessions,
+		bcrytpUtils:    bcrytpUtils,
+		passwordPolicy: passwordPolicy,
+		actionTokens:   actionTokens,
+		mailSender:     mailSender,
+		accountCfg:     accountCfg,
+		mfaCfg:         mfaCfg,
+		metrics:        metrics,
 	}
 }
 
 func (s *authService) SignUser(ctx context.Context, request *dto.SignUpRequest) (*domain.User, error) {
 	start := time.Now()
 
+	if err := s.passwordPolicy.Validate(request.Password); err != nil {
+		s.metrics.RecordAuth(ctx, "signup", "error", time.Since(start))
+		return nil, err
+	}
+
 	user, err := s.userService.CreateUser(ctx, dto.CreateUserRequest{
 		Name:     request.Name,
 		Email:    request.Email,
@@ -46,6 +89,11 @@ func (s *authService) SignUser(ctx context.Context, request *dto.SignUpRequest)
 		return nil, err
 	}
 
+	// The account exists at this point; a failed email can be resent later.
+	if err := s.sendVerificationEmail(ctx, user); err != nil {
+		log.Printf(logmsg.VerificationEmailFailed, user.ID, err)
+	}
+
 	s.metrics.RecordAuth(ctx, "signup", "success", time.Since(start))
 	return user, nil
 }
@@ -58,7 +106,8 @@ func (s *authService) SignInUser(ctx context.Context, request *dto.SignInRequest
 		s.metrics.RecordAuth(ctx, "signin", "error", time.Since(start))
 		return nil, err
 	}
-	if user == nil {
+	// users created through a sign in provider have no password until they reset it
+	if user == nil || user.Password == "" {
 		s.metrics.RecordAuth(ctx, "signin", "error", time.Since(start))
 		return nil, apperrors.ErrInvalidCredentials
 	}
@@ -69,14 +118,202 @@ func (s *authService) SignInUser(ctx context.Context, request *dto.SignInRequest
 		return nil, err
 	}
 
-	token, err := s.jwtUtils.Generate(user)
+	response, err := s.CompleteSignIn(ctx, user, request.Client)
 	if err != nil {
 		s.metrics.RecordAuth(ctx, "signin", "error", time.Since(start))
 		return nil, err
 	}
+	status := "success"
+	if response.MFARequired {
+		status = "mfa_required"
+	}
+	s.metrics.RecordAuth(ctx, "signin", status, time.Since(start))
+	return response, nil
+}
+
+func (s *authService) CompleteSignIn(ctx context.Context, user *domain.User, client dto.SessionClient) (*dto.SignInResponse, error) {
+	mfaEnabled, err := s.mfaService.Enabled(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if mfaEnabled {
+		purpose := constants.ActionTokenMFAChallenge
+		challenge, err := s.actionTokens.Generate(purpose, user.ID, actionTokenFingerprint(purpose, user), s.mfaCfg.GetChallengeTTL())
+		if err != nil {
+			return nil, err
+		}
+		return &dto.SignInResponse{MFARequired: true, MFAToken: challenge}, nil
+	}
+
+	token, err := s.sessions.Start(ctx, user, client)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.SignInResponse{Token: token}, nil
+}
+
+// VerifyMFAChallenge finishes a two-step sign in.
+func (s *authService) VerifyMFAChallenge(ctx context.Context, request *dto.MFAChallengeRequest) (*dto.SignInResponse, error) {
+	start := time.Now()
+
+	user, err := s.userFromActionToken(ctx, constants.ActionTokenMFAChallenge, request.MFAToken)
+	if err == nil {
+		err = s.mfaService.VerifyCode(ctx, user.ID, request.Code)
+	}
+	var token string
+	if err == nil {
+		token, err = s.sessions.Start(ctx, user, request.Client)
+	}
+
+	s.metrics.RecordAuth(ctx, "mfa_challenge", getStatus(err), time.Since(start))
+	if err != nil {
+		return nil, err
+	}
+	return &dto.SignInResponse{Token: token}, nil
+}
+
+// VerifyEmail marks the token's user as verified, or swaps in their pending email.
+func (s *authService) VerifyEmail(ctx context.Context, token string) error {
+	start := time.Now()
+
+	user, err := s.userFromActionToken(ctx, constants.ActionTokenEmailVerification, token)
+	if err == nil && user.PendingEmail != nil {
+		err = s.userService.ConfirmEmailChange(ctx, user.ID, *user.PendingEmail)
+	} else if err == nil && user.EmailVerifiedAt == nil {
+		err = s.userService.MarkEmailVerified(ctx, user.ID)
+	}
+
+	s.metrics.RecordAuth(ctx, "verify_email", getStatus(err), time.Since(start))
+	return err
+}
+
+func (s *authService) ResendVerification(ctx context.Context, userID uuid.UUID) error {
+	start := time.Now()
+
+	user, err := s.userService.GetUserByID(ctx, userID)
+	if err == nil && user.EmailVerifiedAt != nil && user.PendingEmail == nil {
+		err = apperrors.ErrEmailAlreadyVerified
+	}
+	if err == nil {
+		err = s.sendVerificationEmail(ctx, user)
+	}
+
+	s.metrics.RecordAuth(ctx, "resend_verification", getStatus(err), time.Since(start))
+	return err
+}
+
+// ForgotPassword emails a reset link.
+func (s *authService) ForgotPassword(ctx context.Context, email string) error {
+	start := time.Now()
+
+	user, err := s.userService.GetUserByEmail(ctx, email)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && user == nil) {
+		s.metrics.RecordAuth(ctx, "forgot_password", "success", time.Since(start))
+		return nil
+	}
+	if err != nil {
+		s.metrics.RecordAuth(ctx, "forgot_password", "error", time.Since(start))
+		return err
+	}
+
+	if err := s.sendPasswordResetEmail(ctx, user); err != nil {
+		log.Printf(logmsg.PasswordResetEmailFailed, user.ID, err)
+	}
+
+	s.metrics.RecordAuth(ctx, "forgot_password", "success", time.Since(start))
+	return nil
+}
+
+// ResetPassword sets a new password.
+func (s *authService) ResetPassword(ctx context.Context, request *dto.ResetPasswordRequest) error {
+	start := time.Now()
+
+	user, err := s.userFromActionToken(ctx, constants.ActionTokenPasswordReset, request.Token)
+	if err == nil {
+		err = s.passwordPolicy.Validate(request.Password)
+	}
+	if err == nil {
+		err = s.userService.ChangePassword(ctx, user.ID, request.Password)
+	}
+	// whoever knew the old password may still hold a session
+	if err == nil {
+		_, err = s.sessions.RevokeAllSessions(ctx, user.ID)
+	}
+
+	s.metrics.RecordAuth(ctx, "reset_password", getStatus(err), time.Since(start))
+	return err
+}
+
+// userFromActionToken verifies the token and checks that the fingerprint
+// still matches the user it was issued for.
+func (s *authService) userFromActionToken(ctx context.Context, purpose string, token string) (*domain.User, error) {
+	claims, err := s.actionTokens.Verify(purpose, token)
+	if err != nil {
+		return nil, err
+	}
 
-	s.metrics.RecordAuth(ctx, "signin", "success", time.Since(start))
-	return &dto.SignInResponse{
-		Token: token,
-	}, nil
+	user, err := s.userService.GetUserByID(ctx, claims.UserID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, apperrors.ErrInvalidActionToken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if claims.Fingerprint != actionTokenFingerprint(purpose, user) {
+		return nil, apperrors.ErrInvalidActionToken
+	}
+	return user, nil
+}
+
+func (s *authService) sendVerificationEmail(ctx context.Context, user *domain.User) error {
+	ttl := s.accountCfg.GetEmailVerificationTTL()
+	purpose := constants.ActionTokenEmailVerification
+	token, err := s.actionTokens.Generate(purpose, user.ID, actionTokenFingerprint(purpose, user), ttl)
+	if err != nil {
+		return err
+	}
+
+	return s.mailSender.Send(ctx, &mailer.Message{
+		ToName:  user.Name,
+		ToEmail: emailToVerify(user),
+		Subject: verificationEmailSubject,
+		Body:    fmt.Sprintf(verificationEmailTemplate, user.Name, s.accountCfg.VerifyEmailLink(token), int(ttl.Hours())),
+	})
+}
+
+func (s *authService) sendPasswordResetEmail(ctx context.Context, user *domain.User) error {
+	ttl := s.accountCfg.GetPasswordResetTTL()
+	purpose := constants.ActionTokenPasswordReset
+	token, err := s.actionTokens.Generate(purpose, user.ID, actionTokenFingerprint(purpose, user), ttl)
+	if err != nil {
+		return err
+	}
+
+	return s.mailSender.Send(ctx, &mailer.Message{
+		ToName:  user.Name,
+		ToEmail: user.Email,
+		Subject: passwordResetEmailSubject,
+		Body:    fmt.Sprintf(passwordResetTemplate, user.Name, s.accountCfg.ResetPasswordLink(token), int(ttl.Minutes())),
+	})
+}
+
+// actionTokenFingerprint ties a token to the user's state: verification
+// tokens to the email they were sent to, reset and MFA challenge tokens to
+// the password hash.
+func actionTokenFingerprint(purpose string, user *domain.User) string {
+	value := emailToVerify(user)
+	if purpose == constants.ActionTokenPasswordReset || purpose == constants.ActionTokenMFAChallenge {
+		value = user.Password
+	}
+	return utils.HashToken(value)[:fingerprintLength]
+}
+
+// emailToVerify is the pending email while a change is waiting for
+// confirmation, and the current email otherwise.
+func emailToVerify(user *domain.User) string {
+	if user.PendingEmail != nil {
+		return *user.PendingEmail
+	}
+	return user.Email
 }
@@ -3,19 +3,44 @@ package services_test
 import (
 	"context"
 	"errors"
+	"net/url"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/config"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/mailer"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/services"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/utils"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
 )
 
+var (
+	testJwtConfig     = &config.JwtConfig{JWTSecret: "test-secret", JWTExpirationHours: 1}
+	testAccountConfig = &config.AccountConfig{
+		EmailVerificationTTLHours: 48,
+		PasswordResetTTLMinutes:   30,
+		VerifyEmailURL:            "http://localhost:3000/verify-email",
+		ResetPasswordURL:          "http://localhost:3000/reset-password",
+	}
+	testMFAConfig    = &config.MFAConfig{TOTPIssuer: "Hangout Planner", ChallengeTTLMinutes: 5, RecoveryCodeCount: 10}
+	testActionTokens = utils.NewActionTokenUtils(testJwtConfig)
+)
+
+func newTestAuthService(userSvc *MockUserService, mfaSvc *MockMFAService, sessions *MockSessionService, bcryptUtils *MockBcryptUtils, sender *MockMailSender) services.AuthService {
+	policy := utils.NewPasswordPolicy(&config.PasswordPolicyConfig{MinLength: 10, CheckBreached: true})
+	return services.NewAuthService(userSvc, mfaSvc, sessions, bcryptUtils, policy, testActionTokens, sender, testAccountConfig, testMFAConfig, nil)
+}
+
 func TestAuthService_SignUser(t *testing.T) {
-	mockJwtSvc := new(MockJWTUtils)
+	mockSessions := new(MockSessionService)
 	mockBcrypt := new(MockBcryptUtils)
 	newUserID := uuid.New()
 	ctx := context.Background()
@@ -23,15 +48,28 @@ func TestAuthService_SignUser(t *testing.T) {
 	tests := map[string]struct {
 		setupMock func(m *MockUserService)
 		input     *dto.SignUpRequest
+		sendErr   error
 		wantErr   string
 		wantUser  *domain.User
 	}{
+		"Weak password": {
+			setupMock: func(m *MockUserService) {},
+			input:     &dto.SignUpRequest{Name: "Alice", Email: "alice@example.com", Password: "short"},
+			wantErr:   apperrors.ErrWeakPassword.Error(),
+			wantUser:  nil,
+		},
+		"Breached password": {
+			setupMock: func(m *MockUserService) {},
+			input:     &dto.SignUpRequest{Name: "Alice", Email: "alice@example.com", Password: "password123"},
+			wantErr:   apperrors.ErrWeakPassword.Error(),
+			wantUser:  nil,
+		},
 		"User creation fails": {
 			setupMock: func(m *MockUserService) {
 				m.On("CreateUser", ctx, mock.Anything).
 					Return(nil, errors.New("db error"))
 			},
-			input:    &dto.SignUpRequest{Name: "Alice", Email: "alice@example.com", Password: "secret"},
+			input:    &dto.SignUpRequest{Name: "Alice", Email: "alice@example.com", Password: "correct horse battery"},
 			wantErr:  "db error",
 			wantUser: nil,
 		},
@@ -40,7 +78,17 @@ func TestAuthService_SignUser(t *testing.T) {
 				m.On("CreateUser", ctx, mock.Anything).
 					Return(&domain.User{ID: newUserID, Email: "bob@example.com"}, nil)
 			},
-			input:    &dto.SignUpRequest{Name: "Bob", Email: "bob@example.com", Password: "password"},
+			input:    &dto.SignUpRequest{Name: "Bob", Email: "bob@example.com", Password: "correct horse battery"},
+			wantErr:  "",
+			wantUser: &domain.User{ID: newUserID, Email: "bob@example.com"},
+		},
+		"Verification email fails but sign up succeeds": {
+			setupMock: func(m *MockUserService) {
+				m.On("CreateUser", ctx, mock.Anything).
+					Return(&domain.User{ID: newUserID, Email: "bob@example.com"}, nil)
+			},
+			input:    &dto.SignUpRequest{Name: "Bob", Email: "bob@example.com", Password: "correct horse battery"},
+			sendErr:  errors.New("smtp down"),
 			wantErr:  "",
 			wantUser: &domain.User{ID: newUserID, Email: "bob@example.com"},
 		},
@@ -51,7 +99,14 @@ func TestAuthService_SignUser(t *testing.T) {
 			mockUserSvc := new(MockUserService)
 			tt.setupMock(mockUserSvc)
 
-			authSvc := services.NewAuthService(mockUserSvc, mockJwtSvc, mockBcrypt, nil)
+			mockSender := new(MockMailSender)
+			if tt.wantErr == "" {
+				mockSender.On("Send", ctx, mock.MatchedBy(func(msg *mailer.Message) bool {
+					return msg.ToEmail == tt.input.Email && strings.Contains(msg.Body, testAccountConfig.VerifyEmailURL+"?token=")
+				})).Return(tt.sendErr)
+			}
+
+			authSvc := newTestAuthService(mockUserSvc, new(MockMFAService), mockSessions, mockBcrypt, mockSender)
 			user, err := authSvc.SignUser(ctx, tt.input)
 
 			if tt.wantErr != "" {
@@ -66,6 +121,7 @@ func TestAuthService_SignUser(t *testing.T) {
 			}
 
 			mockUserSvc.AssertExpectations(t)
+			mockSender.AssertExpectations(t)
 		})
 	}
 }
@@ -84,32 +140,34 @@ func TestAuthService_SignInUser(t *testing.T) {
 	ctx := context.Background()
 
 	tests := map[string]struct {
-		setupUserMock   func(m *MockUserService)
-		setupBcryptMock func(m *MockBcryptUtils)
-		setupJWTMock    func(m *MockJWTUtils)
-		input           *dto.SignInRequest
-		wantErr         error
-		wantToken       string
+		setupUserMock    func(m *MockUserService)
+		setupBcryptMock  func(m *MockBcryptUtils)
+		setupSessionMock func(m *MockSessionService)
+		setupMFAMock     func(m *MockMFAService)
+		input            *dto.SignInRequest
+		wantErr          error
+		wantToken        string
+		wantMFA          bool
 	}{
 		"Failure_UserNotFound": {
 			setupUserMock: func(m *MockUserService) {
 				m.On("GetUserByEmail", ctx, "notfound@email.com").Return(nil, nil)
 			},
-			setupBcryptMock: func(m *MockBcryptUtils) {},
-			setupJWTMock:    func(m *MockJWTUtils) {},
-			input:           &dto.SignInRequest{Email: "notfound@email.com", Password: "any"},
-			wantErr:         apperrors.ErrInvalidCredentials,
-			wantToken:       "",
+			setupBcryptMock:  func(m *MockBcryptUtils) {},
+			setupSessionMock: func(m *MockSessionService) {},
+			input:            &dto.SignInRequest{Email: "notfound@email.com", Password: "any"},
+			wantErr:          apperrors.ErrInvalidCredentials,
+			wantToken:        "",
 		},
 		"Failure_UserServiceError": {
 			setupUserMock: func(m *MockUserService) {
 				m.On("GetUserByEmail", ctx, correctEmail).Return(nil, errors.New("db connection error"))
 			},
-			setupBcryptMock: func(m *MockBcryptUtils) {},
-			setupJWTMock:    func(m *MockJWTUtils) {},
-			input:           &dto.SignInRequest{Email: correctEmail, Password: "any"},
-			wantErr:         errors.New("db connection error"),
-			wantToken:       "",
+			setupBcryptMock:  func(m *MockBcryptUtils) {},
+			setupSessionMock: func(m *MockSessionService) {},
+			input:            &dto.SignInRequest{Email: correctEmail, Password: "any"},
+			wantErr:          errors.New("db connection error"),
+			wantToken:        "",
 		},
 		"Failure_PasswordMismatch": {
 			setupUserMock: func(m *MockUserService) {
@@ -119,10 +177,10 @@ func TestAuthService_SignInUser(t *testing.T) {
 				m.On("CompareHashAndPassword", validUser.Password, "WrongPassword").
 					Return(apperrors.ErrInvalidCredentials)
 			},
-			setupJWTMock: func(m *MockJWTUtils) {},
-			input:        &dto.SignInRequest{Email: correctEmail, Password: "WrongPassword"},
-			wantErr:      apperrors.ErrInvalidCredentials,
-			wantToken:    "",
+			setupSessionMock: func(m *MockSessionService) {},
+			input:            &dto.SignInRequest{Email: correctEmail, Password: "WrongPassword"},
+			wantErr:          apperrors.ErrInvalidCredentials,
+			wantToken:        "",
 		},
 		"Failure_JWTGenerationError": {
 			setupUserMock: func(m *MockUserService) {
@@ -131,8 +189,11 @@ func TestAuthService_SignInUser(t *testing.T) {
 			setupBcryptMock: func(m *MockBcryptUtils) {
 				m.On("CompareHashAndPassword", validUser.Password, correctPassword).Return(nil)
 			},
-			setupJWTMock: func(m *MockJWTUtils) {
-				m.On("Generate", validUser).Return("", errors.New("jwt signing failed"))
+			setupSessionMock: func(m *MockSessionService) {
+				m.On("Start", mock.Anything, validUser, mock.Anything).Return("", errors.New("jwt signing failed"))
+			},
+			setupMFAMock: func(m *MockMFAService) {
+				m.On("Enabled", ctx, validUserID).Return(false, nil)
 			},
 			input:     &dto.SignInRequest{Email: correctEmail, Password: correctPassword},
 			wantErr:   errors.New("jwt signing failed"),
@@ -145,26 +206,61 @@ func TestAuthService_SignInUser(t *testing.T) {
 			setupBcryptMock: func(m *MockBcryptUtils) {
 				m.On("CompareHashAndPassword", validUser.Password, correctPassword).Return(nil)
 			},
-			setupJWTMock: func(m *MockJWTUtils) {
-				m.On("Generate", validUser).Return(mockToken, nil)
+			setupSessionMock: func(m *MockSessionService) {
+				m.On("Start", mock.Anything, validUser, mock.Anything).Return(mockToken, nil)
+			},
+			setupMFAMock: func(m *MockMFAService) {
+				m.On("Enabled", ctx, validUserID).Return(false, nil)
 			},
 			input:     &dto.SignInRequest{Email: correctEmail, Password: correctPassword},
 			wantErr:   nil,
 			wantToken: mockToken,
 		},
+		"Success_MFARequired": {
+			setupUserMock: func(m *MockUserService) {
+				m.On("GetUserByEmail", ctx, correctEmail).Return(validUser, nil)
+			},
+			setupBcryptMock: func(m *MockBcryptUtils) {
+				m.On("CompareHashAndPassword", validUser.Password, correctPassword).Return(nil)
+			},
+			setupSessionMock: func(m *MockSessionService) {},
+			setupMFAMock: func(m *MockMFAService) {
+				m.On("Enabled", ctx, validUserID).Return(true, nil)
+			},
+			input:   &dto.SignInRequest{Email: correctEmail, Password: correctPassword},
+			wantMFA: true,
+		},
+		"Failure_MFALookupError": {
+			setupUserMock: func(m *MockUserService) {
+				m.On("GetUserByEmail", ctx, correctEmail).Return(validUser, nil)
+			},
+			setupBcryptMock: func(m *MockBcryptUtils) {
+				m.On("CompareHashAndPassword", validUser.Password, correctPassword).Return(nil)
+			},
+			setupSessionMock: func(m *MockSessionService) {},
+			setupMFAMock: func(m *MockMFAService) {
+				m.On("Enabled", ctx, validUserID).Return(false, errors.New("db connection error"))
+			},
+			input:   &dto.SignInRequest{Email: correctEmail, Password: correctPassword},
+			wantErr: errors.New("db connection error"),
+		},
 	}
 
 	for name, tt := range tests {
 		t.Run(name, func(t *testing.T) {
 			mockUserSvc := new(MockUserService)
-			mockJwtSvc := new(MockJWTUtils)
+			mockSessions := new(MockSessionService)
 			mockBcrypt := new(MockBcryptUtils)
+			mockMFA := new(MockMFAService)
 
 			tt.setupUserMock(mockUserSvc)
 			tt.setupBcryptMock(mockBcrypt)
-			tt.setupJWTMock(mockJwtSvc)
+			tt.setupSessionMock(mockSessions)
+			if tt.setupMFAMock != nil {
+				tt.setupMFAMock(mockMFA)
+			}
 
-			authSvc := services.NewAuthService(mockUserSvc, mockJwtSvc, mockBcrypt, nil)
+			authSvc := newTestAuthService(mockUserSvc, mockMFA, mockSessions, mockBcrypt, new(MockMailSender))
 			response, err := authSvc.SignInUser(ctx, tt.input)
 
 			if tt.wantErr != nil {
@@ -175,15 +271,437 @@ func TestAuthService_SignInUser(t *testing.T) {
 					require.EqualError(t, err, tt.wantErr.Error())
 				}
 				require.Nil(t, response)
+			} else if tt.wantMFA {
+				require.NoError(t, err)
+				require.True(t, response.MFARequired)
+				require.Empty(t, response.Token)
+				claims, err := testActionTokens.Verify(constants.ActionTokenMFAChallenge, response.MFAToken)
+				require.NoError(t, err)
+				require.Equal(t, validUserID, claims.UserID)
 			} else {
 				require.NoError(t, err)
 				require.NotNil(t, response)
 				require.Equal(t, tt.wantToken, response.Token)
+				require.False(t, response.MFARequired)
 			}
 
 			mockUserSvc.AssertExpectations(t)
-			mockJwtSvc.AssertExpectations(t)
+			mockMFA.AssertExpectations(t)
+			mockSessions.AssertExpectations(t)
 			mockBcrypt.AssertExpectations(t)
 		})
 	}
 }
+
+func TestAuthService_VerifyMFAChallenge(t *testing.T) {
+	ctx := context.Background()
+	user := &domain.User{ID: uuid.New(), Email: "user@valid.com", Password: "hashed-password"}
+	validToken := actionToken(t, constants.ActionTokenMFAChallenge, user.ID, user.Password, time.Minute)
+
+	tests := map[string]struct {
+		token     string
+		setup     func(userSvc *MockUserService, mfaSvc *MockMFAService, sessions *MockSessionService)
+		wantErr   error
+		wantToken string
+	}{
+		"Success": {
+			token: validToken,
+			setup: func(userSvc *MockUserService, mfaSvc *MockMFAService, sessions *MockSessionService) {
+				userSvc.On("GetUserByID", ctx, user.ID).Return(user, nil)
+				mfaSvc.On("VerifyCode", ctx, user.ID, "123456").Return(nil)
+				sessions.On("Start", mock.Anything, user, mock.Anything).Return("signed.jwt.token", nil)
+			},
+			wantToken: "signed.jwt.token",
+		},
+		"Failure_InvalidCode": {
+			token: validToken,
+			setup: func(userSvc *MockUserService, mfaSvc *MockMFAService, sessions *MockSessionService) {
+				userSvc.On("GetUserByID", ctx, user.ID).Return(user, nil)
+				mfaSvc.On("VerifyCode", ctx, user.ID, "123456").Return(apperrors.ErrInvalidMFACode)
+			},
+			wantErr: apperrors.ErrInvalidMFACode,
+		},
+		"Failure_PasswordChanged": {
+			token: actionToken(t, constants.ActionTokenMFAChallenge, user.ID, "old-hash", time.Minute),
+			setup: func(userSvc *MockUserService, mfaSvc *MockMFAService, sessions *MockSessionService) {
+				userSvc.On("GetUserByID", ctx, user.ID).Return(user, nil)
+			},
+			wantErr: apperrors.ErrInvalidActionToken,
+		},
+		"Failure_Expired": {
+			token:   actionToken(t, constants.ActionTokenMFAChallenge, user.ID, user.Password, -time.Minute),
+			setup:   func(userSvc *MockUserService, mfaSvc *MockMFAService, sessions *MockSessionService) {},
+			wantErr: apperrors.ErrInvalidActionToken,
+		},
+		"Failure_OtherPurpose": {
+			token:   actionToken(t, constants.ActionTokenPasswordReset, user.ID, user.Password, time.Minute),
+			setup:   func(userSvc *MockUserService, mfaSvc *MockMFAService, sessions *MockSessionService) {},
+			wantErr: apperrors.ErrInvalidActionToken,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockUserSvc := new(MockUserService)
+			mockMFA := new(MockMFAService)
+			mockSessions := new(MockSessionService)
+			tt.setup(mockUserSvc, mockMFA, mockSessions)
+
+			authSvc := newTestAuthService(mockUserSvc, mockMFA, mockSessions, new(MockBcryptUtils), new(MockMailSender))
+			response, err := authSvc.VerifyMFAChallenge(ctx, &dto.MFAChallengeRequest{MFAToken: tt.token, Code: "123456"})
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				require.Nil(t, response)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.wantToken, response.Token)
+			}
+			mockUserSvc.AssertExpectations(t)
+			mockMFA.AssertExpectations(t)
+			mockSessions.AssertExpectations(t)
+		})
+	}
+}
+
+func actionToken(t *testing.T, purpose string, userID uuid.UUID, fingerprintOf string, ttl time.Duration) string {
+	t.Helper()
+	token, err := testActionTokens.Generate(purpose, userID, utils.HashToken(fingerprintOf)[:16], ttl)
+	require.NoError(t, err)
+	return token
+}
+
+// tokenFromMessage pulls the token out of the link in an account email.
+func tokenFromMessage(t *testing.T, msg *mailer.Message) string {
+	t.Helper()
+	for _, field := range strings.Fields(msg.Body) {
+		if u, err := url.Parse(field); err == nil && u.Query().Get(constants.ActionTokenQueryParam) != "" {
+			return u.Query().Get(constants.ActionTokenQueryParam)
+		}
+	}
+	t.Fatalf("no token in message body %q", msg.Body)
+	return ""
+}
+
+func TestAuthService_VerifyEmail(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	verifiedAt := time.Now()
+	user := &domain.User{ID: userID, Email: "alice@example.com"}
+	pendingEmail := "alice@new.example.com"
+
+	tests := map[string]struct {
+		token     func(t *testing.T) string
+		setupMock func(m *MockUserService)
+		wantErr   error
+	}{
+		"Success": {
+			token: func(t *testing.T) string {
+				return actionToken(t, constants.ActionTokenEmailVerification, userID, user.Email, time.Hour)
+			},
+			setupMock: func(m *MockUserService) {
+				m.On("GetUserByID", ctx, userID).Return(user, nil)
+				m.On("MarkEmailVerified", ctx, userID).Return(nil)
+			},
+		},
+		"Already verified is a no-op": {
+			token: func(t *testing.T) string {
+				return actionToken(t, constants.ActionTokenEmailVerification, userID, user.Email, time.Hour)
+			},
+			setupMock: func(m *MockUserService) {
+				m.On("GetUserByID", ctx, userID).
+					Return(&domain.User{ID: userID, Email: user.Email, EmailVerifiedAt: &verifiedAt}, nil)
+			},
+		},
+		"Pending email is swapped in": {
+			token: func(t *testing.T) string {
+				return actionToken(t, constants.ActionTokenEmailVerification, userID, pendingEmail, time.Hour)
+			},
+			setupMock: func(m *MockUserService) {
+				m.On("GetUserByID", ctx, userID).
+					Return(&domain.User{ID: userID, Email: user.Email, EmailVerifiedAt: &verifiedAt, PendingEmail: &pendingEmail}, nil)
+				m.On("ConfirmEmailChange", ctx, userID, pendingEmail).Return(nil)
+			},
+		},
+		"Old email cannot confirm a pending change": {
+			token: func(t *testing.T) string {
+				return actionToken(t, constants.ActionTokenEmailVerification, userID, user.Email, time.Hour)
+			},
+			setupMock: func(m *MockUserService) {
+				m.On("GetUserByID", ctx, userID).
+					Return(&domain.User{ID: userID, Email: user.Email, PendingEmail: &pendingEmail}, nil)
+			},
+			wantErr: apperrors.ErrInvalidActionToken,
+		},
+		"Expired token": {
+			token: func(t *testing.T) string {
+				return actionToken(t, constants.ActionTokenEmailVerification, userID, user.Email, -time.Minute)
+			},
+			setupMock: func(m *MockUserService) {},
+			wantErr:   apperrors.ErrInvalidActionToken,
+		},
+		"Reset token cannot verify email": {
+			token: func(t *testing.T) string {
+				return actionToken(t, constants.ActionTokenPasswordReset, userID, user.Email, time.Hour)
+			},
+			setupMock: func(m *MockUserService) {},
+			wantErr:   apperrors.ErrInvalidActionToken,
+		},
+		"Email changed since token was sent": {
+			token: func(t *testing.T) string {
+				return actionToken(t, constants.ActionTokenEmailVerification, userID, "old@example.com", time.Hour)
+			},
+			setupMock: func(m *MockUserService) {
+				m.On("GetUserByID", ctx, userID).Return(user, nil)
+			},
+			wantErr: apperrors.ErrInvalidActionToken,
+		},
+		"User deleted": {
+			token: func(t *testing.T) string {
+				return actionToken(t, constants.ActionTokenEmailVerification, userID, user.Email, time.Hour)
+			},
+			setupMock: func(m *MockUserService) {
+				m.On("GetUserByID", ctx, userID).Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantErr: apperrors.ErrInvalidActionToken,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockUserSvc := new(MockUserService)
+			tt.setupMock(mockUserSvc)
+
+			authSvc := newTestAuthService(mockUserSvc, new(MockMFAService), new(MockSessionService), new(MockBcryptUtils), new(MockMailSender))
+			err := authSvc.VerifyEmail(ctx, tt.token(t))
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			mockUserSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuthService_ResendVerification(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	verifiedAt := time.Now()
+
+	t.Run("sends a token that verifies the email", func(t *testing.T) {
+		user := &domain.User{ID: userID, Name: "Alice", Email: "alice@example.com"}
+		mockUserSvc := new(MockUserService)
+		mockUserSvc.On("GetUserByID", ctx, userID).Return(user, nil)
+		mockUserSvc.On("MarkEmailVerified", ctx, userID).Return(nil)
+
+		var sent *mailer.Message
+		mockSender := new(MockMailSender)
+		mockSender.On("Send", ctx, mock.Anything).Run(func(args mock.Arguments) {
+			sent = args.Get(1).(*mailer.Message)
+		}).Return(nil)
+
+		authSvc := newTestAuthService(mockUserSvc, new(MockMFAService), new(MockSessionService), new(MockBcryptUtils), mockSender)
+		require.NoError(t, authSvc.ResendVerification(ctx, userID))
+		require.Equal(t, user.Email, sent.ToEmail)
+		require.Contains(t, sent.Body, "48 hours")
+
+		require.NoError(t, authSvc.VerifyEmail(ctx, tokenFromMessage(t, sent)))
+		mockUserSvc.AssertExpectations(t)
+	})
+
+	t.Run("already verified", func(t *testing.T) {
+		mockUserSvc := new(MockUserService)
+		mockUserSvc.On("GetUserByID", ctx, userID).
+			Return(&domain.User{ID: userID, EmailVerifiedAt: &verifiedAt}, nil)
+		mockSender := new(MockMailSender)
+
+		authSvc := newTestAuthService(mockUserSvc, new(MockMFAService), new(MockSessionService), new(MockBcryptUtils), mockSender)
+		err := authSvc.ResendVerification(ctx, userID)
+
+		require.ErrorIs(t, err, apperrors.ErrEmailAlreadyVerified)
+		mockSender.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+	})
+
+	t.Run("sends a pending email change to the new address", func(t *testing.T) {
+		pendingEmail := "alice@new.example.com"
+		mockUserSvc := new(MockUserService)
+		mockUserSvc.On("GetUserByID", ctx, userID).
+			Return(&domain.User{ID: userID, Email: "alice@example.com", EmailVerifiedAt: &verifiedAt, PendingEmail: &pendingEmail}, nil)
+		mockSender := new(MockMailSender)
+		mockSender.On("Send", ctx, mock.MatchedBy(func(msg *mailer.Message) bool {
+			return msg.ToEmail == pendingEmail
+		})).Return(nil)
+
+		authSvc := newTestAuthService(mockUserSvc, new(MockMFAService), new(MockSessionService), new(MockBcryptUtils), mockSender)
+		require.NoError(t, authSvc.ResendVerification(ctx, userID))
+		mockSender.AssertExpectations(t)
+	})
+
+	t.Run("send error", func(t *testing.T) {
+		mockUserSvc := new(MockUserService)
+		mockUserSvc.On("GetUserByID", ctx, userID).Return(&domain.User{ID: userID, Email: "a@example.com"}, nil)
+		mockSender := new(MockMailSender)
+		mockSender.On("Send", ctx, mock.Anything).Return(errors.New("smtp down"))
+
+		authSvc := newTestAuthService(mockUserSvc, new(MockMFAService), new(MockSessionService), new(MockBcryptUtils), mockSender)
+		require.EqualError(t, authSvc.ResendVerification(ctx, userID), "smtp down")
+	})
+}
+
+func TestAuthService_ForgotPassword(t *testing.T) {
+	ctx := context.Background()
+	user := &domain.User{ID: uuid.New(), Name: "Alice", Email: "alice@example.com", Password: "hashed"}
+
+	tests := map[string]struct {
+		setupUserMock   func(m *MockUserService)
+		setupSenderMock func(m *MockMailSender)
+		wantErr         string
+	}{
+		"Sends reset email": {
+			setupUserMock: func(m *MockUserService) {
+				m.On("GetUserByEmail", ctx, user.Email).Return(user, nil)
+			},
+			setupSenderMock: func(m *MockMailSender) {
+				m.On("Send", ctx, mock.MatchedBy(func(msg *mailer.Message) bool {
+					return msg.ToEmail == user.Email &&
+						strings.Contains(msg.Body, testAccountConfig.ResetPasswordURL+"?token=") &&
+						strings.Contains(msg.Body, "30 minutes")
+				})).Return(nil)
+			},
+		},
+		"Unknown email does not error": {
+			setupUserMock: func(m *MockUserService) {
+				m.On("GetUserByEmail", ctx, user.Email).Return(nil, gorm.ErrRecordNotFound)
+			},
+			setupSenderMock: func(m *MockMailSender) {},
+		},
+		"Send failure is not reported": {
+			setupUserMock: func(m *MockUserService) {
+				m.On("GetUserByEmail", ctx, user.Email).Return(user, nil)
+			},
+			setupSenderMock: func(m *MockMailSender) {
+				m.On("Send", ctx, mock.Anything).Return(errors.New("smtp down"))
+			},
+		},
+		"Lookup error": {
+			setupUserMock: func(m *MockUserService) {
+				m.On("GetUserByEmail", ctx, user.Email).Return(nil, errors.New("db error"))
+			},
+			setupSenderMock: func(m *MockMailSender) {},
+			wantErr:         "db error",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockUserSvc := new(MockUserService)
+			mockSender := new(MockMailSender)
+			tt.setupUserMock(mockUserSvc)
+			tt.setupSenderMock(mockSender)
+
+			authSvc := newTestAuthService(mockUserSvc, new(MockMFAService), new(MockSessionService), new(MockBcryptUtils), mockSender)
+			err := authSvc.ForgotPassword(ctx, user.Email)
+
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			mockUserSvc.AssertExpectations(t)
+			mockSender.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuthService_ResetPassword(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	user := &domain.User{ID: userID, Email: "alice@example.com", Password: "current-hash"}
+	const newPassword = "correct horse battery"
+	dbErr := errors.New("db error")
+
+	validToken := func(t *testing.T) string {
+		return actionToken(t, constants.ActionTokenPasswordReset, userID, user.Password, time.Hour)
+	}
+
+	tests := map[string]struct {
+		token     func(t *testing.T) string
+		password  string
+		setupMock func(m *MockUserService)
+		sessions  func(m *MockSessionService)
+		wantErr   error
+	}{
+		"Success revokes sessions": {
+			token:    validToken,
+			password: newPassword,
+			setupMock: func(m *MockUserService) {
+				m.On("GetUserByID", ctx, userID).Return(user, nil)
+				m.On("ChangePassword", ctx, userID, newPassword).Return(nil)
+			},
+			sessions: func(m *MockSessionService) {
+				m.On("RevokeAllSessions", ctx, userID).Return(int64(2), nil)
+			},
+		},
+		"Session revoke error": {
+			token:    validToken,
+			password: newPassword,
+			setupMock: func(m *MockUserService) {
+				m.On("GetUserByID", ctx, userID).Return(user, nil)
+				m.On("ChangePassword", ctx, userID, newPassword).Return(nil)
+			},
+			sessions: func(m *MockSessionService) {
+				m.On("RevokeAllSessions", ctx, userID).Return(int64(0), dbErr)
+			},
+			wantErr: dbErr,
+		},
+		"Token already used": {
+			token:    validToken,
+			password: newPassword,
+			setupMock: func(m *MockUserService) {
+				m.On("GetUserByID", ctx, userID).
+					Return(&domain.User{ID: userID, Email: user.Email, Password: "changed-hash"}, nil)
+			},
+			wantErr: apperrors.ErrInvalidActionToken,
+		},
+		"Verification token cannot reset password": {
+			token: func(t *testing.T) string {
+				return actionToken(t, constants.ActionTokenEmailVerification, userID, user.Password, time.Hour)
+			},
+			password:  newPassword,
+			setupMock: func(m *MockUserService) {},
+			wantErr:   apperrors.ErrInvalidActionToken,
+		},
+		"Weak password": {
+			token:    validToken,
+			password: "short",
+			setupMock: func(m *MockUserService) {
+				m.On("GetUserByID", ctx, userID).Return(user, nil)
+			},
+			wantErr: apperrors.ErrWeakPassword,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockUserSvc := new(MockUserService)
+			tt.setupMock(mockUserSvc)
+			mockSessions := new(MockSessionService)
+			if tt.sessions != nil {
+				tt.sessions(mockSessions)
+			}
+
+			authSvc := newTestAuthService(mockUserSvc, new(MockMFAService), mockSessions, new(MockBcryptUtils), new(MockMailSender))
+			err := authSvc.ResetPassword(ctx, &dto.ResetPasswordRequest{Token: tt.token(t), Password: tt.password})
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			mockUserSvc.AssertExpectations(t)
+			mockSessions.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,284 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/mapper"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/otel"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/pubsub"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/repository"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"gorm.io/gorm"
+)
+
+// CommentService manages the comments of a hangout.
+type CommentService interface {
+	ListComments(ctx context.Context, userID uuid.UUID, hangoutID uuid.UUID, pagination *dto.CursorPagination) (*dto.PaginatedComments, error)
+	CreateComment(ctx context.Context, userID uuid.UUID, hangoutID uuid.UUID, req *dto.CreateCommentRequest) (*dto.CommentResponse, error)
+	UpdateComment(ctx context.Context, userID uuid.UUID, commentID uuid.UUID, req *dto.UpdateCommentRequest) (*dto.CommentResponse, error)
+	DeleteComment(ctx context.Context, userID uuid.UUID, commentID uuid.UUID) error
+}
+
+type commentService struct {
+	repo        repository.CommentRepository
+	hangoutRepo repository.HangoutRepository
+	metrics     *otel.MetricsRecorder
+	events      pubsub.Publisher
+}
+
+func NewCommentService(repo repository.CommentRepository, hangoutRepo repository.HangoutRepository, metrics *otel.MetricsRecorder, events pubsub.Publisher) CommentService {
+	return &commentService{
+		repo:        repo,
+		hangoutRepo: hangoutRepo,
+		metrics:     metrics,
+		events:      events,
+	}
+}
+
+// ListComments returns a page of top-level comments, oldest first, each with
+// all of its replies.
+func (s *commentService) ListComments(ctx context.Context, userID uuid.UUID, hangoutID uuid.UUID, pagination *dto.CursorPagination) (*dto.PaginatedComments, error) {
+	recordMetrics := s.metrics.StartRequest(ctx, "comment", "list")
+
+	ctx, span := otel.StartServiceSpan(ctx, "ListComments",
+		attribute.String("user.id", userID.String()),
+		attribute.String("hangout.id", hangoutID.String()),
+		attribute.Int("pagination.limit", pagination.GetLimit()),
+	)
+	defer span.End()
+
+	if _, err := s.hangoutRepo.GetHangoutByID(ctx, hangoutID, userID); err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	comments, err := s.repo.GetCommentsByHangoutID(ctx, hangoutID, pagination)
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	var nextCursor *uuid.UUID
+	limit := pagination.GetLimit()
+	hasMore := len(comments) > limit
+	if hasMore {
+		nextCursor = &comments[limit-1].ID
+		comments = comments[:limit]
+	}
+
+	parentIDs := make([]uuid.UUID, len(comments))
+	for i := range comments {
+		parentIDs[i] = comments[i].ID
+	}
+	replies, err := s.repo.GetRepliesByParentIDs(ctx, parentIDs)
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetAttributes(
+		attribute.Int("comment.count", len(comments)),
+		attribute.Int("comment.reply_count", len(replies)),
+		attribute.Bool("pagination.has_more", hasMore),
+	)
+	span.SetStatusOk()
+	recordMetrics("success")
+	return &dto.PaginatedComments{
+		Data:       mapper.CommentsToResponseDTOs(comments, replies),
+		NextCursor: nextCursor,
+		HasMore:    hasMore,
+	}, nil
+}
+
+func (s *commentService) CreateComment(ctx context.Context, userID uuid.UUID, hangoutID uuid.UUID, req *dto.CreateCommentRequest) (*dto.CommentResponse, error) {
+	recordMetrics := s.metrics.StartRequest(ctx, "comment", "create")
+
+	ctx, span := otel.StartServiceSpan(ctx, "CreateComment",
+		attribute.String("user.id", userID.String()),
+		attribute.String("hangout.id", hangoutID.String()),
+		attribute.Bool("comment.is_reply", req.ParentID != nil),
+		attribute.Int("comment.mention_count", len(req.Mentions)),
+	)
+	defer span.End()
+
+	hangout, err := s.hangoutRepo.GetHangoutByID(ctx, hangoutID, userID)
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	if req.ParentID != nil {
+		parent, err := s.repo.GetCommentByID(ctx, *req.ParentID)
+		if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && (parent.HangoutID != hangoutID || parent.ParentID != nil)) {
+			err = apperrors.ErrInvalidCommentParent
+		}
+		if err != nil {
+			recordMetrics("error")
+			_ = span.RecordErrorWithStatus(err)
+			return nil, err
+		}
+	}
+
+	mentions, err := commentMentions(hangout, req.Mentions)
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	comment := &domain.Comment{
+		Body:      req.Body,
+		ParentID:  req.ParentID,
+		HangoutID: hangoutID,
+		UserID:    userID,
+		Mentions:  mentions,
+	}
+	if err := s.repo.CreateComment(ctx, comment); err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	created, err := s.repo.GetCommentByID(ctx, comment.ID)
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	publishHangoutEvent(ctx, s.events, hangoutID, userID, constants.EventCommentCreated, mapper.CommentToCreatedEventDTO(created))
+
+	span.SetAttributes(attribute.String("comment.id", created.ID.String()))
+	span.SetStatusOk()
+	recordMetrics("success")
+	return mapper.CommentToResponseDTO(created), nil
+}
+
+// UpdateComment lets the author change the body and mentions of a comment.
+func (s *commentService) UpdateComment(ctx context.Context, userID uuid.UUID, commentID uuid.UUID, req *dto.UpdateCommentRequest) (*dto.CommentResponse, error) {
+	recordMetrics := s.metrics.StartRequest(ctx, "comment", "update")
+
+	ctx, span := otel.StartServiceSpan(ctx, "UpdateComment",
+		attribute.String("user.id", userID.String()),
+		attribute.String("comment.id", commentID.String()),
+		attribute.Int("comment.mention_count", len(req.Mentions)),
+	)
+	defer span.End()
+
+	comment, hangout, err := s.getCommentForParticipant(ctx, userID, commentID)
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	if comment.UserID != userID {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(apperrors.ErrForbidden)
+		return nil, apperrors.ErrForbidden
+	}
+
+	mentions, err := commentMentions(hangout, req.Mentions)
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+	for i := range mentions {
+		mentions[i].CommentID = comment.ID
+	}
+
+	now := time.Now()
+	comment.Body = req.Body
+	comment.EditedAt = &now
+	comment.Mentions = mentions
+	if err := s.repo.UpdateComment(ctx, comment); err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetStatusOk()
+	recordMetrics("success")
+	return mapper.CommentToResponseDTO(comment), nil
+}
+
+// DeleteComment removes a comment and its replies.
+func (s *commentService) DeleteComment(ctx context.Context, userID uuid.UUID, commentID uuid.UUID) error {
+	recordMetrics := s.metrics.StartRequest(ctx, "comment", "delete")
+
+	ctx, span := otel.StartServiceSpan(ctx, "DeleteComment",
+		attribute.String("user.id", userID.String()),
+		attribute.String("comment.id", commentID.String()),
+	)
+	defer span.End()
+
+	comment, hangout, err := s.getCommentForParticipant(ctx, userID, commentID)
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return err
+	}
+
+	isOwner := hangout.UserID != nil && *hangout.UserID == userID
+	if comment.UserID != userID && !isOwner {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(apperrors.ErrForbidden)
+		return apperrors.ErrForbidden
+	}
+
+	if err := s.repo.DeleteComment(ctx, comment.ID); err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return err
+	}
+
+	span.SetStatusOk()
+	recordMetrics("success")
+	return nil
+}
+
+// getCommentForParticipant loads a comment and its hangout.
+func (s *commentService) getCommentForParticipant(ctx context.Context, userID uuid.UUID, commentID uuid.UUID) (*domain.Comment, *domain.Hangout, error) {
+	comment, err := s.repo.GetCommentByID(ctx, commentID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	hangout, err := s.hangoutRepo.GetHangoutByID(ctx, comment.HangoutID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return comment, hangout, nil
+}
+
+// commentMentions turns the mentioned user IDs into mention rows, dropping
+// duplicates. Every mentioned user must take part in the hangout.
+func commentMentions(hangout *domain.Hangout, userIDs []uuid.UUID) ([]domain.CommentMention, error) {
+	participants := hangoutParticipants(hangout)
+	mentions := make([]domain.CommentMention, 0, len(userIDs))
+	seen := make(map[uuid.UUID]bool, len(userIDs))
+	for _, userID := range userIDs {
+		if !slices.Contains(participants, userID) {
+			return nil, apperrors.ErrInvalidMention
+		}
+		if seen[userID] {
+			continue
+		}
+		seen[userID] = true
+		mentions = append(mentions, domain.CommentMention{UserID: userID})
+	}
+	return mentions, nil
+}
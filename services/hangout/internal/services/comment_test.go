@@ -0,0 +1,234 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/pubsub"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/services"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestCommentService_ListComments(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	hangout := &domain.Hangout{ID: uuid.New(), UserID: &userID}
+
+	t.Run("nests replies and paginates top-level comments", func(t *testing.T) {
+		repo := new(MockCommentRepository)
+		hangoutRepo := new(MockHangoutRepository)
+		svc := services.NewCommentService(repo, hangoutRepo, nil, nil)
+
+		comments := []domain.Comment{{ID: uuid.New()}, {ID: uuid.New()}, {ID: uuid.New()}}
+		replies := []domain.Comment{{ID: uuid.New(), ParentID: &comments[0].ID}}
+		pagination := &dto.CursorPagination{Limit: 2}
+		hangoutRepo.On("GetHangoutByID", mock.Anything, hangout.ID, userID).Return(hangout, nil)
+		repo.On("GetCommentsByHangoutID", mock.Anything, hangout.ID, pagination).Return(comments, nil)
+		repo.On("GetRepliesByParentIDs", mock.Anything, []uuid.UUID{comments[0].ID, comments[1].ID}).Return(replies, nil)
+
+		res, err := svc.ListComments(ctx, userID, hangout.ID, pagination)
+		require.NoError(t, err)
+		require.Len(t, res.Data, 2)
+		require.True(t, res.HasMore)
+		require.Equal(t, comments[1].ID, *res.NextCursor)
+		require.Len(t, res.Data[0].Replies, 1)
+		require.Empty(t, res.Data[1].Replies)
+	})
+
+	t.Run("non participant", func(t *testing.T) {
+		repo := new(MockCommentRepository)
+		hangoutRepo := new(MockHangoutRepository)
+		svc := services.NewCommentService(repo, hangoutRepo, nil, nil)
+		hangoutRepo.On("GetHangoutByID", mock.Anything, hangout.ID, userID).Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := svc.ListComments(ctx, userID, hangout.ID, &dto.CursorPagination{})
+		require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+		repo.AssertNotCalled(t, "GetCommentsByHangoutID", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestCommentService_CreateComment(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	hangout := &domain.Hangout{ID: uuid.New(), Title: "Picnic", UserID: &userID}
+	topLevel := &domain.Comment{ID: uuid.New(), HangoutID: hangout.ID}
+	reply := &domain.Comment{ID: uuid.New(), HangoutID: hangout.ID, ParentID: &topLevel.ID}
+	otherHangout := &domain.Comment{ID: uuid.New(), HangoutID: uuid.New()}
+	dbErr := errors.New("db error")
+
+	tests := []struct {
+		name         string
+		parent       *domain.Comment
+		parentErr    error
+		mentions     []uuid.UUID
+		wantMentions int
+		createErr    error
+		wantErr      error
+	}{
+		{name: "top-level comment with a duplicate mention", mentions: []uuid.UUID{userID, userID}, wantMentions: 1},
+		{name: "reply", parent: topLevel},
+		{name: "reply to a reply", parent: reply, wantErr: apperrors.ErrInvalidCommentParent},
+		{name: "parent on another hangout", parent: otherHangout, wantErr: apperrors.ErrInvalidCommentParent},
+		{name: "parent not found", parent: &domain.Comment{ID: uuid.New()}, parentErr: gorm.ErrRecordNotFound, wantErr: apperrors.ErrInvalidCommentParent},
+		{name: "mention of a non participant", mentions: []uuid.UUID{uuid.New()}, wantErr: apperrors.ErrInvalidMention},
+		{name: "create error", createErr: dbErr, wantErr: dbErr},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockCommentRepository)
+			hangoutRepo := new(MockHangoutRepository)
+			broker := pubsub.NewInMemoryBroker(4)
+			events, cancel, err := broker.Subscribe(ctx, pubsub.HangoutTopic(hangout.ID))
+			require.NoError(t, err)
+			defer cancel()
+			svc := services.NewCommentService(repo, hangoutRepo, nil, broker)
+
+			req := &dto.CreateCommentRequest{Body: "<p>Bring snacks</p>", Mentions: tt.mentions}
+			hangoutRepo.On("GetHangoutByID", mock.Anything, hangout.ID, userID).Return(hangout, nil)
+			if tt.parent != nil {
+				req.ParentID = &tt.parent.ID
+				if tt.parentErr != nil {
+					repo.On("GetCommentByID", mock.Anything, tt.parent.ID).Return(nil, tt.parentErr)
+				} else {
+					repo.On("GetCommentByID", mock.Anything, tt.parent.ID).Return(tt.parent, nil)
+				}
+			}
+
+			// the reloaded comment is filled in once the comment is created
+			created := &domain.Comment{}
+			repo.On("CreateComment", mock.Anything, mock.AnythingOfType("*domain.Comment")).Run(func(args mock.Arguments) {
+				comment := args.Get(1).(*domain.Comment)
+				comment.ID = uuid.New()
+				comment.CreatedAt = time.Now()
+				*created = *comment
+				created.User = domain.User{ID: userID, Name: "Ana"}
+			}).Return(tt.createErr).Maybe()
+			repo.On("GetCommentByID", mock.Anything, mock.MatchedBy(func(id uuid.UUID) bool {
+				return id == created.ID
+			})).Return(created, nil).Maybe()
+
+			res, err := svc.CreateComment(ctx, userID, hangout.ID, req)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				require.Len(t, events, 0)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, created.ID, res.ID)
+			require.Equal(t, "Ana", res.Author.Name)
+			require.Equal(t, req.ParentID, res.ParentID)
+			require.Len(t, created.Mentions, tt.wantMentions)
+
+			event := <-events
+			require.Equal(t, constants.EventCommentCreated, event.Type)
+			require.Equal(t, userID, event.UserID)
+			data := event.Data.(*dto.CommentCreatedEvent)
+			require.Equal(t, created.ID, data.ID)
+			require.Equal(t, "Ana", data.AuthorName)
+		})
+	}
+}
+
+func TestCommentService_UpdateComment(t *testing.T) {
+	ctx := context.Background()
+	ownerID := uuid.New()
+	hangout := &domain.Hangout{ID: uuid.New(), UserID: &ownerID}
+	dbErr := errors.New("db error")
+
+	tests := []struct {
+		name       string
+		authorID   uuid.UUID
+		hangoutErr error
+		updateErr  error
+		wantErr    error
+	}{
+		{name: "author edits", authorID: ownerID},
+		{name: "other user cannot edit", authorID: uuid.New(), wantErr: apperrors.ErrForbidden},
+		{name: "non participant", authorID: ownerID, hangoutErr: gorm.ErrRecordNotFound, wantErr: gorm.ErrRecordNotFound},
+		{name: "update error", authorID: ownerID, updateErr: dbErr, wantErr: dbErr},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockCommentRepository)
+			hangoutRepo := new(MockHangoutRepository)
+			svc := services.NewCommentService(repo, hangoutRepo, nil, nil)
+			comment := &domain.Comment{ID: uuid.New(), HangoutID: hangout.ID, UserID: tt.authorID, Body: "<p>old</p>"}
+
+			repo.On("GetCommentByID", mock.Anything, comment.ID).Return(comment, nil)
+			if tt.hangoutErr != nil {
+				hangoutRepo.On("GetHangoutByID", mock.Anything, hangout.ID, ownerID).Return(nil, tt.hangoutErr)
+			} else {
+				hangoutRepo.On("GetHangoutByID", mock.Anything, hangout.ID, ownerID).Return(hangout, nil)
+			}
+			repo.On("UpdateComment", mock.Anything, comment).Return(tt.updateErr).Maybe()
+
+			res, err := svc.UpdateComment(ctx, ownerID, comment.ID, &dto.UpdateCommentRequest{Body: "<p>new</p>", Mentions: []uuid.UUID{ownerID}})
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, "<p>new</p>", res.Body)
+			require.True(t, res.Edited)
+			require.Equal(t, []uuid.UUID{ownerID}, res.Mentions)
+			require.Equal(t, comment.ID, comment.Mentions[0].CommentID)
+		})
+	}
+}
+
+func TestCommentService_DeleteComment(t *testing.T) {
+	ctx := context.Background()
+	ownerID := uuid.New()
+	hangout := &domain.Hangout{ID: uuid.New(), UserID: &ownerID}
+	dbErr := errors.New("db error")
+
+	tests := []struct {
+		name      string
+		authorID  uuid.UUID
+		deleteErr error
+		wantErr   error
+	}{
+		{name: "author deletes", authorID: ownerID},
+		{name: "hangout owner deletes another user's comment", authorID: uuid.New()},
+		{name: "comment not found", wantErr: gorm.ErrRecordNotFound},
+		{name: "delete error", authorID: ownerID, deleteErr: dbErr, wantErr: dbErr},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockCommentRepository)
+			hangoutRepo := new(MockHangoutRepository)
+			svc := services.NewCommentService(repo, hangoutRepo, nil, nil)
+			comment := &domain.Comment{ID: uuid.New(), HangoutID: hangout.ID, UserID: tt.authorID}
+
+			if tt.authorID == uuid.Nil {
+				repo.On("GetCommentByID", mock.Anything, comment.ID).Return(nil, gorm.ErrRecordNotFound)
+			} else {
+				repo.On("GetCommentByID", mock.Anything, comment.ID).Return(comment, nil)
+				hangoutRepo.On("GetHangoutByID", mock.Anything, hangout.ID, ownerID).Return(hangout, nil)
+				repo.On("DeleteComment", mock.Anything, comment.ID).Return(tt.deleteErr)
+			}
+
+			err := svc.DeleteComment(ctx, ownerID, comment.ID)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			repo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,325 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	filepb "github.com/Ernestgio/Hangout-Planner/pkg/shared/proto/gen/go/file"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/config"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants/logmsg"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/grpc"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/mapper"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/otel"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/repository"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// Failures are recorded on the export with these fixed messages, since the
+// export's error is shown to the user; the underlying cause is only logged.
+var (
+	errDataExportBuildFailed   = errors.New("export could not be built, request a new one")
+	errDataExportArchiveFailed = errors.New("archive could not be built, request a new export")
+	errDataExportArchiveGone   = errors.New("archive is no longer available")
+)
+
+// DataExportService builds copies of a user's data.
+type DataExportService interface {
+	RequestExport(ctx context.Context, userID uuid.UUID) (*dto.DataExportResponse, error)
+	GetExport(ctx context.Context, userID uuid.UUID, exportID uuid.UUID) (*dto.DataExportResponse, error)
+	// ProcessPending builds one batch of requested exports.
+	ProcessPending(ctx context.Context) (built int, failed int, err error)
+	// PurgeExpired removes exports past their retention.
+	PurgeExpired(ctx context.Context) (int64, error)
+}
+
+type dataExportService struct {
+	exportRepo   repository.DataExportRepository
+	userRepo     repository.UserRepository
+	hangoutRepo  repository.HangoutRepository
+	activityRepo repository.ActivityRepository
+	memoryRepo   repository.MemoryRepository
+	fileService  grpc.FileService
+	cfg          *config.DataExportConfig
+	metrics      *otel.MetricsRecorder
+}
+
+func NewDataExportService(exportRepo repository.DataExportRepository, userRepo repository.UserRepository, hangoutRepo repository.HangoutRepository, activityRepo repository.ActivityRepository, memoryRepo repository.MemoryRepository, fileService grpc.FileService, cfg *config.DataExportConfig, metrics *otel.MetricsRecorder) DataExportService {
+	return &dataExportService{
+		exportRepo:   exportRepo,
+		userRepo:     userRepo,
+		hangoutRepo:  hangoutRepo,
+		activityRepo: activityRepo,
+		memoryRepo:   memoryRepo,
+		fileService:  fileService,
+		cfg:          cfg,
+		metrics:      metrics,
+	}
+}
+
+// RequestExport queues a new export.
+func (s *dataExportService) RequestExport(ctx context.Context, userID uuid.UUID) (*dto.DataExportResponse, error) {
+	recordMetrics := s.metrics.StartRequest(ctx, "data_export", "request")
+
+	ctx, span := otel.StartServiceSpan(ctx, "RequestDataExport",
+		attribute.String("user.id", userID.String()),
+	)
+	defer span.End()
+
+	active, err := s.exportRepo.GetActiveByUserID(ctx, userID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+	if active != nil {
+		// an export whose archive finished since it was last checked no
+		// longer blocks a new one
+		if _, err := s.syncArchive(ctx, active); err != nil {
+			recordMetrics("error")
+			_ = span.RecordErrorWithStatus(err)
+			return nil, err
+		}
+		if active.Status == constants.DataExportPending || active.Status == constants.DataExportProcessing {
+			recordMetrics("error")
+			_ = span.RecordErrorWithStatus(apperrors.ErrDataExportInProgress)
+			return nil, apperrors.ErrDataExportInProgress
+		}
+	}
+
+	export := &domain.DataExport{
+		UserID:    userID,
+		Status:    constants.DataExportPending,
+		ExpiresAt: time.Now().Add(s.cfg.GetRetention()),
+	}
+	if err := s.exportRepo.Create(ctx, export); err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.String("export.id", export.ID.String()))
+	span.SetStatusOk()
+	recordMetrics("success")
+	return mapper.DataExportToResponseDTO(export, nil), nil
+}
+
+// GetExport returns the export with the progress of its media archive,
+// recording the outcome once the archive has finished.
+func (s *dataExportService) GetExport(ctx context.Context, userID uuid.UUID, exportID uuid.UUID) (*dto.DataExportResponse, error) {
+	recordMetrics := s.metrics.StartRequest(ctx, "data_export", "get")
+
+	ctx, span := otel.StartServiceSpan(ctx, "GetDataExport",
+		attribute.String("user.id", userID.String()),
+		attribute.String("export.id", exportID.String()),
+	)
+	defer span.End()
+
+	export, err := s.getExport(ctx, userID, exportID)
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	archive, err := s.syncArchive(ctx, export)
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.String("export.status", export.Status))
+	span.SetStatusOk()
+	recordMetrics("success")
+	return mapper.DataExportToResponseDTO(export, archive), nil
+}
+
+func (s *dataExportService) ProcessPending(ctx context.Context) (int, int, error) {
+	recordMetrics := s.metrics.StartRequest(ctx, "data_export", "process")
+
+	ctx, span := otel.StartServiceSpan(ctx, "ProcessPendingDataExports",
+		attribute.Int("batch.size", s.cfg.BatchSize),
+	)
+	defer span.End()
+
+	due, err := s.exportRepo.GetDueExports(ctx, time.Now(), s.cfg.BatchSize)
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return 0, 0, err
+	}
+
+	built, failed := 0, 0
+	for i := range due {
+		export := &due[i]
+
+		// the lease starts at the claim, not at the batch start, so earlier
+		// slow builds do not eat into it
+		claimedAt := time.Now()
+		claimed, err := s.exportRepo.ClaimExport(ctx, export.ID, claimedAt, claimedAt.Add(constants.DataExportClaimLeaseSeconds*time.Second))
+		if err != nil {
+			recordMetrics("error")
+			_ = span.RecordErrorWithStatus(err)
+			return built, failed, err
+		}
+		if !claimed {
+			continue
+		}
+
+		buildErr := s.build(ctx, export)
+		if buildErr != nil {
+			export.Status = constants.DataExportFailed
+			export.LastError = errDataExportBuildFailed.Error()
+			log.Printf(logmsg.DataExportBuildFailed, export.ID, buildErr)
+		}
+		export.ClaimedUntil = nil
+
+		if err := s.exportRepo.Update(ctx, export); err != nil {
+			recordMetrics("error")
+			_ = span.RecordErrorWithStatus(err)
+			return built, failed, err
+		}
+
+		if buildErr != nil {
+			failed++
+		} else {
+			built++
+		}
+	}
+
+	span.SetAttributes(
+		attribute.Int("export.built", built),
+		attribute.Int("export.failed", failed),
+	)
+	span.SetStatusOk()
+	recordMetrics("success")
+	return built, failed, nil
+}
+
+func (s *dataExportService) PurgeExpired(ctx context.Context) (int64, error) {
+	recordMetrics := s.metrics.StartRequest(ctx, "data_export", "purge")
+
+	ctx, span := otel.StartServiceSpan(ctx, "PurgeExpiredDataExports")
+	defer span.End()
+
+	deleted, err := s.exportRepo.DeleteExpired(ctx, time.Now())
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return 0, err
+	}
+
+	span.SetAttributes(attribute.Int64("export.deleted", deleted))
+	span.SetStatusOk()
+	recordMetrics("success")
+	return deleted, nil
+}
+
+// build writes the export's JSON document and requests the archive that
+// holds it together with the memory files.
+func (s *dataExportService) build(ctx context.Context, export *domain.DataExport) error {
+	user, err := s.userRepo.GetUserByID(ctx, export.UserID)
+	if err != nil {
+		return err
+	}
+	hangouts, err := s.hangoutRepo.GetAllHangoutsByUserID(ctx, export.UserID)
+	if err != nil {
+		return err
+	}
+	activities, err := s.activityRepo.GetAllActivities(ctx, export.UserID)
+	if err != nil {
+		return err
+	}
+	memories, err := s.memoryRepo.GetAllMemoriesByUserID(ctx, export.UserID)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(mapper.ToDataExportDocument(user, hangouts, activities, memories, time.Now()))
+	if err != nil {
+		return err
+	}
+
+	var memoryIDs []string
+	for _, memory := range memories {
+		if memory.FileID != nil {
+			memoryIDs = append(memoryIDs, memory.ID.String())
+		}
+	}
+
+	documents := []*filepb.ArchiveDocument{{
+		Name:    fmt.Sprintf(constants.DataExportFileName, export.ID),
+		Content: data,
+	}}
+	archive, err := s.fileService.CreateArchive(ctx, dataExportStoragePath(export.UserID), fmt.Sprintf(constants.DataExportArchiveName, export.ID), memoryIDs, documents)
+	if err != nil {
+		return err
+	}
+	export.ArchiveID = &archive.Id
+	export.Status = constants.DataExportProcessing
+	return nil
+}
+
+// syncArchive fetches the export's archive and moves a processing export to
+// completed or failed once the archive has finished.
+func (s *dataExportService) syncArchive(ctx context.Context, export *domain.DataExport) (*filepb.Archive, error) {
+	if export.ArchiveID == nil {
+		return nil, nil
+	}
+
+	archive, err := s.fileService.GetArchiveStatus(ctx, *export.ArchiveID, dataExportStoragePath(export.UserID))
+	if code := status.Code(err); code == codes.NotFound || code == codes.InvalidArgument {
+		archive, err = nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if export.Status != constants.DataExportProcessing {
+		return archive, nil
+	}
+
+	switch {
+	case archive == nil:
+		export.Status = constants.DataExportFailed
+		export.LastError = errDataExportArchiveGone.Error()
+	case archive.Status == filepb.ArchiveStatus_ARCHIVE_STATUS_COMPLETED:
+		now := time.Now()
+		export.Status = constants.DataExportCompleted
+		export.CompletedAt = &now
+	case archive.Status == filepb.ArchiveStatus_ARCHIVE_STATUS_FAILED:
+		export.Status = constants.DataExportFailed
+		export.LastError = errDataExportArchiveFailed.Error()
+		log.Printf(logmsg.DataExportArchiveFailed, export.ID, archive.Error)
+	default:
+		return archive, nil
+	}
+
+	if err := s.exportRepo.Update(ctx, export); err != nil {
+		return nil, err
+	}
+	return archive, nil
+}
+
+func (s *dataExportService) getExport(ctx context.Context, userID uuid.UUID, exportID uuid.UUID) (*domain.DataExport, error) {
+	export, err := s.exportRepo.GetByID(ctx, exportID, userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, apperrors.ErrDataExportNotFound
+	}
+	return export, err
+}
+
+func dataExportStoragePath(userID uuid.UUID) string {
+	return fmt.Sprintf(constants.DataExportStoragePath, userID)
+}
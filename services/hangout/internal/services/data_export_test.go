@@ -0,0 +1,413 @@
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	filepb "github.com/Ernestgio/Hangout-Planner/pkg/shared/proto/gen/go/file"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/config"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/repository"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/services"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+type dataExportMocks struct {
+	exports    *MockDataExportRepository
+	users      *MockUserRepository
+	hangouts   *MockHangoutRepository
+	activities *MockActivityRepository
+	memories   *MockMemoryRepository
+	files      *MockFileService
+}
+
+func newDataExportService(t *testing.T) (services.DataExportService, *dataExportMocks) {
+	t.Helper()
+	m := &dataExportMocks{
+		exports:    new(MockDataExportRepository),
+		users:      new(MockUserRepository),
+		hangouts:   new(MockHangoutRepository),
+		activities: new(MockActivityRepository),
+		memories:   new(MockMemoryRepository),
+		files:      new(MockFileService),
+	}
+	cfg := &config.DataExportConfig{BatchSize: 5, RetentionHours: 24}
+	svc := services.NewDataExportService(m.exports, m.users, m.hangouts, m.activities, m.memories, m.files, cfg, nil)
+	return svc, m
+}
+
+func (m *dataExportMocks) assertExpectations(t *testing.T) {
+	m.exports.AssertExpectations(t)
+	m.users.AssertExpectations(t)
+	m.hangouts.AssertExpectations(t)
+	m.activities.AssertExpectations(t)
+	m.memories.AssertExpectations(t)
+	m.files.AssertExpectations(t)
+}
+
+func TestDataExportService_RequestExport(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	archiveID := uuid.NewString()
+	storagePath := "users/" + userID.String() + "/exports"
+	dbErr := errors.New("db error")
+
+	tests := map[string]struct {
+		setup   func(m *dataExportMocks)
+		wantErr error
+	}{
+		"Success": {
+			setup: func(m *dataExportMocks) {
+				m.exports.On("GetActiveByUserID", mock.Anything, userID).Return(nil, gorm.ErrRecordNotFound)
+				m.exports.On("Create", mock.Anything, mock.MatchedBy(func(export *domain.DataExport) bool {
+					return export.UserID == userID &&
+						export.Status == constants.DataExportPending &&
+						export.ExpiresAt.After(time.Now().Add(23*time.Hour))
+				})).Return(nil)
+			},
+		},
+		"Pending export in progress": {
+			setup: func(m *dataExportMocks) {
+				m.exports.On("GetActiveByUserID", mock.Anything, userID).Return(&domain.DataExport{UserID: userID, Status: constants.DataExportPending}, nil)
+			},
+			wantErr: apperrors.ErrDataExportInProgress,
+		},
+		"Archive still building": {
+			setup: func(m *dataExportMocks) {
+				m.exports.On("GetActiveByUserID", mock.Anything, userID).Return(&domain.DataExport{UserID: userID, Status: constants.DataExportProcessing, ArchiveID: &archiveID}, nil)
+				m.files.On("GetArchiveStatus", mock.Anything, archiveID, storagePath).Return(&filepb.Archive{Id: archiveID, Status: filepb.ArchiveStatus_ARCHIVE_STATUS_PROCESSING}, nil)
+			},
+			wantErr: apperrors.ErrDataExportInProgress,
+		},
+		"Archive finished since last check": {
+			setup: func(m *dataExportMocks) {
+				m.exports.On("GetActiveByUserID", mock.Anything, userID).Return(&domain.DataExport{UserID: userID, Status: constants.DataExportProcessing, ArchiveID: &archiveID}, nil)
+				m.files.On("GetArchiveStatus", mock.Anything, archiveID, storagePath).Return(&filepb.Archive{Id: archiveID, Status: filepb.ArchiveStatus_ARCHIVE_STATUS_COMPLETED}, nil)
+				m.exports.On("Update", mock.Anything, mock.MatchedBy(func(export *domain.DataExport) bool {
+					return export.Status == constants.DataExportCompleted && export.CompletedAt != nil
+				})).Return(nil)
+				m.exports.On("Create", mock.Anything, mock.Anything).Return(nil)
+			},
+		},
+		"Lookup error": {
+			setup: func(m *dataExportMocks) {
+				m.exports.On("GetActiveByUserID", mock.Anything, userID).Return(nil, dbErr)
+			},
+			wantErr: dbErr,
+		},
+		"Create error": {
+			setup: func(m *dataExportMocks) {
+				m.exports.On("GetActiveByUserID", mock.Anything, userID).Return(nil, gorm.ErrRecordNotFound)
+				m.exports.On("Create", mock.Anything, mock.Anything).Return(dbErr)
+			},
+			wantErr: dbErr,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			svc, m := newDataExportService(t)
+			tt.setup(m)
+
+			res, err := svc.RequestExport(ctx, userID)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				require.Nil(t, res)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, constants.DataExportPending, res.Status)
+				require.Nil(t, res.Archive)
+			}
+			m.assertExpectations(t)
+		})
+	}
+}
+
+func TestDataExportService_GetExport(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	exportID := uuid.New()
+	archiveID := uuid.NewString()
+	storagePath := "users/" + userID.String() + "/exports"
+	rpcErr := status.Error(codes.Unavailable, "file service down")
+
+	processing := func() *domain.DataExport {
+		return &domain.DataExport{ID: exportID, UserID: userID, Status: constants.DataExportProcessing, ArchiveID: &archiveID}
+	}
+
+	tests := map[string]struct {
+		setup       func(m *dataExportMocks)
+		wantStatus  string
+		wantArchive bool
+		wantErr     error
+	}{
+		"Pending without archive": {
+			setup: func(m *dataExportMocks) {
+				m.exports.On("GetByID", mock.Anything, exportID, userID).Return(&domain.DataExport{ID: exportID, UserID: userID, Status: constants.DataExportPending}, nil)
+			},
+			wantStatus: constants.DataExportPending,
+		},
+		"Archive in progress": {
+			setup: func(m *dataExportMocks) {
+				m.exports.On("GetByID", mock.Anything, exportID, userID).Return(processing(), nil)
+				m.files.On("GetArchiveStatus", mock.Anything, archiveID, storagePath).Return(&filepb.Archive{Id: archiveID, Status: filepb.ArchiveStatus_ARCHIVE_STATUS_PROCESSING}, nil)
+			},
+			wantStatus:  constants.DataExportProcessing,
+			wantArchive: true,
+		},
+		"Archive completed": {
+			setup: func(m *dataExportMocks) {
+				m.exports.On("GetByID", mock.Anything, exportID, userID).Return(processing(), nil)
+				m.files.On("GetArchiveStatus", mock.Anything, archiveID, storagePath).Return(&filepb.Archive{Id: archiveID, Status: filepb.ArchiveStatus_ARCHIVE_STATUS_COMPLETED, DownloadUrl: "https://s3/export.zip"}, nil)
+				m.exports.On("Update", mock.Anything, mock.MatchedBy(func(export *domain.DataExport) bool {
+					return export.Status == constants.DataExportCompleted && export.CompletedAt != nil
+				})).Return(nil)
+			},
+			wantStatus:  constants.DataExportCompleted,
+			wantArchive: true,
+		},
+		"Archive failed": {
+			setup: func(m *dataExportMocks) {
+				m.exports.On("GetByID", mock.Anything, exportID, userID).Return(processing(), nil)
+				m.files.On("GetArchiveStatus", mock.Anything, archiveID, storagePath).Return(&filepb.Archive{Id: archiveID, Status: filepb.ArchiveStatus_ARCHIVE_STATUS_FAILED, Error: "disk full"}, nil)
+				m.exports.On("Update", mock.Anything, mock.MatchedBy(func(export *domain.DataExport) bool {
+					return export.Status == constants.DataExportFailed && export.LastError == "archive could not be built, request a new export"
+				})).Return(nil)
+			},
+			wantStatus:  constants.DataExportFailed,
+			wantArchive: true,
+		},
+		"Archive gone while processing": {
+			setup: func(m *dataExportMocks) {
+				m.exports.On("GetByID", mock.Anything, exportID, userID).Return(processing(), nil)
+				m.files.On("GetArchiveStatus", mock.Anything, archiveID, storagePath).Return(nil, status.Error(codes.NotFound, "archive not found"))
+				m.exports.On("Update", mock.Anything, mock.MatchedBy(func(export *domain.DataExport) bool {
+					return export.Status == constants.DataExportFailed && export.LastError != ""
+				})).Return(nil)
+			},
+			wantStatus: constants.DataExportFailed,
+		},
+		"Archive expired after completion": {
+			setup: func(m *dataExportMocks) {
+				export := processing()
+				export.Status = constants.DataExportCompleted
+				m.exports.On("GetByID", mock.Anything, exportID, userID).Return(export, nil)
+				m.files.On("GetArchiveStatus", mock.Anything, archiveID, storagePath).Return(nil, status.Error(codes.NotFound, "archive not found"))
+			},
+			wantStatus: constants.DataExportCompleted,
+		},
+		"Not found": {
+			setup: func(m *dataExportMocks) {
+				m.exports.On("GetByID", mock.Anything, exportID, userID).Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantErr: apperrors.ErrDataExportNotFound,
+		},
+		"File service error": {
+			setup: func(m *dataExportMocks) {
+				m.exports.On("GetByID", mock.Anything, exportID, userID).Return(processing(), nil)
+				m.files.On("GetArchiveStatus", mock.Anything, archiveID, storagePath).Return(nil, rpcErr)
+			},
+			wantErr: rpcErr,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			svc, m := newDataExportService(t)
+			tt.setup(m)
+
+			res, err := svc.GetExport(ctx, userID, exportID)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				require.Nil(t, res)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.wantStatus, res.Status)
+				require.Equal(t, tt.wantArchive, res.Archive != nil)
+			}
+			m.assertExpectations(t)
+		})
+	}
+}
+
+func TestDataExportService_ProcessPending(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	exportID := uuid.New()
+	fileID := uuid.New()
+	withFile := domain.Memory{ID: uuid.New(), Name: "beach.jpg", UserID: userID, FileID: &fileID}
+	withoutFile := domain.Memory{ID: uuid.New(), Name: "pending.jpg", UserID: userID}
+	storagePath := "users/" + userID.String() + "/exports"
+	archiveName := "hangout-planner-export-" + exportID.String()
+	documentName := archiveName + ".json"
+	dbErr := errors.New("db error")
+
+	snapshot := func(m *dataExportMocks, memories []domain.Memory) {
+		m.users.On("GetUserByID", mock.Anything, userID).Return(&domain.User{ID: userID, Name: "Ann", Email: "ann@example.com"}, nil)
+		m.hangouts.On("GetAllHangoutsByUserID", mock.Anything, userID).Return([]domain.Hangout{{ID: uuid.New(), Title: "Picnic"}}, nil)
+		m.activities.On("GetAllActivities", mock.Anything, userID).Return([]repository.ActivityWithCount{}, nil)
+		m.memories.On("GetAllMemoriesByUserID", mock.Anything, userID).Return(memories, nil)
+	}
+
+	tests := map[string]struct {
+		setup      func(m *dataExportMocks)
+		lost       bool
+		wantBuilt  int
+		wantFailed int
+		wantErr    error
+	}{
+		"Requests archive with document": {
+			setup: func(m *dataExportMocks) {
+				snapshot(m, []domain.Memory{withFile, withoutFile})
+				m.files.On("CreateArchive", mock.Anything, storagePath, archiveName, []string{withFile.ID.String()}, mock.MatchedBy(func(documents []*filepb.ArchiveDocument) bool {
+					var doc dto.DataExportDocument
+					return len(documents) == 1 &&
+						documents[0].Name == documentName &&
+						json.Unmarshal(documents[0].Content, &doc) == nil &&
+						doc.Profile.Email == "ann@example.com" &&
+						len(doc.Hangouts) == 1 &&
+						len(doc.Memories) == 2
+				})).Return(&filepb.Archive{Id: "archive-1", Status: filepb.ArchiveStatus_ARCHIVE_STATUS_PENDING}, nil)
+				m.exports.On("Update", mock.Anything, mock.MatchedBy(func(export *domain.DataExport) bool {
+					return export.Status == constants.DataExportProcessing &&
+						*export.ArchiveID == "archive-1" &&
+						export.ClaimedUntil == nil
+				})).Return(nil)
+			},
+			wantBuilt: 1,
+		},
+		"Requests archive without media": {
+			setup: func(m *dataExportMocks) {
+				snapshot(m, []domain.Memory{withoutFile})
+				m.files.On("CreateArchive", mock.Anything, storagePath, archiveName, []string(nil), mock.AnythingOfType("[]*filepb.ArchiveDocument")).
+					Return(&filepb.Archive{Id: "archive-1", Status: filepb.ArchiveStatus_ARCHIVE_STATUS_PENDING}, nil)
+				m.exports.On("Update", mock.Anything, mock.MatchedBy(func(export *domain.DataExport) bool {
+					return export.Status == constants.DataExportProcessing && *export.ArchiveID == "archive-1"
+				})).Return(nil)
+			},
+			wantBuilt: 1,
+		},
+		"Fails on archive error": {
+			setup: func(m *dataExportMocks) {
+				snapshot(m, []domain.Memory{withFile})
+				m.files.On("CreateArchive", mock.Anything, storagePath, archiveName, []string{withFile.ID.String()}, mock.Anything).
+					Return(nil, status.Error(codes.Unavailable, "file service down"))
+				m.exports.On("Update", mock.Anything, mock.MatchedBy(func(export *domain.DataExport) bool {
+					return export.Status == constants.DataExportFailed && export.LastError == "export could not be built, request a new one"
+				})).Return(nil)
+			},
+			wantFailed: 1,
+		},
+		"Fails on snapshot error": {
+			setup: func(m *dataExportMocks) {
+				m.users.On("GetUserByID", mock.Anything, userID).Return(nil, dbErr)
+				m.exports.On("Update", mock.Anything, mock.MatchedBy(func(export *domain.DataExport) bool {
+					return export.Status == constants.DataExportFailed && export.LastError == "export could not be built, request a new one"
+				})).Return(nil)
+			},
+			wantFailed: 1,
+		},
+		"Claimed by another instance": {
+			setup: func(m *dataExportMocks) {},
+			lost:  true,
+		},
+		"Update error": {
+			setup: func(m *dataExportMocks) {
+				snapshot(m, nil)
+				m.files.On("CreateArchive", mock.Anything, storagePath, archiveName, []string(nil), mock.Anything).
+					Return(&filepb.Archive{Id: "archive-1", Status: filepb.ArchiveStatus_ARCHIVE_STATUS_PENDING}, nil)
+				m.exports.On("Update", mock.Anything, mock.Anything).Return(dbErr)
+			},
+			wantErr: dbErr,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			svc, m := newDataExportService(t)
+			m.exports.On("GetDueExports", mock.Anything, mock.Anything, 5).Return([]domain.DataExport{{ID: exportID, UserID: userID, Status: constants.DataExportPending}}, nil)
+			m.exports.On("ClaimExport", mock.Anything, exportID, mock.Anything, mock.Anything).Return(!tt.lost, nil)
+			tt.setup(m)
+
+			built, failed, err := svc.ProcessPending(ctx)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tt.wantBuilt, built)
+			require.Equal(t, tt.wantFailed, failed)
+			m.assertExpectations(t)
+		})
+	}
+}
+
+func TestDataExportService_ProcessPending_LeaseStartsAtClaim(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	buildTime := 50 * time.Millisecond
+
+	svc, m := newDataExportService(t)
+	due := []domain.DataExport{
+		{ID: uuid.New(), UserID: userID, Status: constants.DataExportPending},
+		{ID: uuid.New(), UserID: userID, Status: constants.DataExportPending},
+	}
+	var leases []time.Time
+	m.exports.On("GetDueExports", mock.Anything, mock.Anything, 5).Return(due, nil)
+	m.exports.On("ClaimExport", mock.Anything, mock.Anything, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
+		Run(func(args mock.Arguments) { leases = append(leases, args.Get(3).(time.Time)) }).
+		Return(true, nil)
+	// every build takes a while, as a slow snapshot query would
+	m.users.On("GetUserByID", mock.Anything, userID).
+		Run(func(mock.Arguments) { time.Sleep(buildTime) }).
+		Return(&domain.User{ID: userID, Name: "Ann", Email: "ann@example.com"}, nil)
+	m.hangouts.On("GetAllHangoutsByUserID", mock.Anything, userID).Return([]domain.Hangout{}, nil)
+	m.activities.On("GetAllActivities", mock.Anything, userID).Return([]repository.ActivityWithCount{}, nil)
+	m.memories.On("GetAllMemoriesByUserID", mock.Anything, userID).Return([]domain.Memory{}, nil)
+	m.files.On("CreateArchive", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(&filepb.Archive{Id: "archive-1", Status: filepb.ArchiveStatus_ARCHIVE_STATUS_PENDING}, nil)
+	m.exports.On("Update", mock.Anything, mock.Anything).Return(nil)
+
+	built, _, err := svc.ProcessPending(ctx)
+
+	require.NoError(t, err)
+	require.Equal(t, 2, built)
+	require.Len(t, leases, 2)
+	require.GreaterOrEqual(t, leases[1].Sub(leases[0]), buildTime)
+}
+
+func TestDataExportService_PurgeExpired(t *testing.T) {
+	ctx := context.Background()
+	dbErr := errors.New("db error")
+
+	tests := map[string]struct {
+		deleted int64
+		err     error
+	}{
+		"Success":          {deleted: 3},
+		"Repository error": {err: dbErr},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			svc, m := newDataExportService(t)
+			m.exports.On("DeleteExpired", mock.Anything, mock.Anything).Return(tt.deleted, tt.err)
+
+			deleted, err := svc.PurgeExpired(ctx)
+			require.ErrorIs(t, err, tt.err)
+			require.Equal(t, tt.deleted, deleted)
+			m.assertExpectations(t)
+		})
+	}
+}
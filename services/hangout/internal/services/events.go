@@ -0,0 +1,22 @@
+package services
+
+import (
+	"context"
+	"log"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants/logmsg"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/pubsub"
+	"github.com/google/uuid"
+)
+
+// publishHangoutEvent notifies subscribers of a hangout.
+func publishHangoutEvent(ctx context.Context, publisher pubsub.Publisher, hangoutID uuid.UUID, userID uuid.UUID, eventType string, data any) {
+	if publisher == nil {
+		return
+	}
+	event := pubsub.NewHangoutEvent(hangoutID, eventType, data)
+	event.UserID = userID
+	if err := publisher.Publish(ctx, pubsub.HangoutTopic(hangoutID), event); err != nil {
+		log.Printf(logmsg.EventPublishFailed, eventType, hangoutID, err)
+	}
+}
@@ -0,0 +1,225 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/pkg/shared/enums"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/pubsub"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/services"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func collectEvents(t *testing.T, events <-chan pubsub.Event, n int) []pubsub.Event {
+	t.Helper()
+	var got []pubsub.Event
+	for len(got) < n {
+		select {
+		case event := <-events:
+			got = append(got, event)
+		case <-time.After(time.Second):
+			t.Fatalf("expected %d events, got %d", n, len(got))
+		}
+	}
+	require.Len(t, events, 0)
+	return got
+}
+
+func TestHangoutService_PublishesEvents(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	hangoutID := uuid.New()
+
+	t.Run("patch_with_status_change", func(t *testing.T) {
+		broker := pubsub.NewInMemoryBroker(8)
+		events, cancel, err := broker.Subscribe(ctx, pubsub.HangoutTopic(hangoutID))
+		require.NoError(t, err)
+		defer cancel()
+
+		db, sqlMock := setupDB(t)
+		hRepo := new(MockHangoutRepository)
+		aRepo := new(MockActivityRepository)
+		service := services.NewHangoutService(db, hRepo, aRepo, nil, broker)
+
+		sqlMock.ExpectBegin()
+		hRepo.On("WithTx", mock.Anything).Return(hRepo)
+		aRepo.On("WithTx", mock.Anything).Return(aRepo)
+		existing := &domain.Hangout{ID: hangoutID, UserID: &userID, Version: 1, Status: enums.StatusPlanning}
+		hRepo.On("GetHangoutByID", mock.Anything, hangoutID, userID).Return(existing, nil).Once()
+		hRepo.On("UpdateHangout", mock.Anything, mock.Anything).Return(existing, nil).Once()
+		updated := &domain.Hangout{ID: hangoutID, UserID: &userID, Version: 2, Status: enums.StatusConfirmed}
+		hRepo.On("GetHangoutByID", mock.Anything, hangoutID, userID).Return(updated, nil).Once()
+		sqlMock.ExpectCommit()
+
+		_, err = service.PatchHangout(ctx, hangoutID, userID, 1, &dto.PatchHangoutRequest{
+			Status: dto.Nullable[enums.HangoutStatus]{Set: true, Value: enums.StatusConfirmed},
+		})
+		require.NoError(t, err)
+
+		got := collectEvents(t, events, 2)
+		require.Equal(t, constants.EventHangoutUpdated, got[0].Type)
+		require.Equal(t, userID, got[0].UserID)
+		require.Equal(t, int64(2), got[0].Data.(*dto.HangoutDetailResponse).Version)
+		require.Equal(t, constants.EventHangoutStatusChanged, got[1].Type)
+		require.Equal(t, dto.HangoutStatusChangedEvent{From: enums.StatusPlanning, To: enums.StatusConfirmed}, got[1].Data)
+		require.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("failed_update_publishes_nothing", func(t *testing.T) {
+		broker := pubsub.NewInMemoryBroker(8)
+		events, cancel, err := broker.Subscribe(ctx, pubsub.HangoutTopic(hangoutID))
+		require.NoError(t, err)
+		defer cancel()
+
+		db, sqlMock := setupDB(t)
+		hRepo := new(MockHangoutRepository)
+		aRepo := new(MockActivityRepository)
+		service := services.NewHangoutService(db, hRepo, aRepo, nil, broker)
+
+		sqlMock.ExpectBegin()
+		hRepo.On("WithTx", mock.Anything).Return(hRepo)
+		aRepo.On("WithTx", mock.Anything).Return(aRepo)
+		hRepo.On("GetHangoutByID", mock.Anything, hangoutID, userID).Return(&domain.Hangout{ID: hangoutID, Version: 3}, nil).Once()
+		sqlMock.ExpectRollback()
+
+		_, err = service.PatchHangout(ctx, hangoutID, userID, 1, &dto.PatchHangoutRequest{})
+		require.Error(t, err)
+		require.Len(t, events, 0)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		broker := pubsub.NewInMemoryBroker(8)
+		events, cancel, err := broker.Subscribe(ctx, pubsub.HangoutTopic(hangoutID))
+		require.NoError(t, err)
+		defer cancel()
+
+		db, sqlMock := setupDB(t)
+		hRepo := new(MockHangoutRepository)
+		service := services.NewHangoutService(db, hRepo, new(MockActivityRepository), nil, broker)
+
+		sqlMock.ExpectBegin()
+		hRepo.On("WithTx", mock.Anything).Return(hRepo)
+		hRepo.On("GetHangoutByID", mock.Anything, hangoutID, userID).Return(&domain.Hangout{ID: hangoutID, Version: 1}, nil).Once()
+		hRepo.On("DeleteHangout", mock.Anything, hangoutID, int64(1)).Return(nil).Once()
+		sqlMock.ExpectCommit()
+
+		require.NoError(t, service.DeleteHangout(ctx, hangoutID, userID, 1))
+
+		got := collectEvents(t, events, 1)
+		require.Equal(t, constants.EventHangoutDeleted, got[0].Type)
+		require.Equal(t, hangoutID, got[0].HangoutID)
+		require.Equal(t, userID, got[0].UserID)
+	})
+}
+
+func TestMemoryService_ConfirmUploadPublishesEvents(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	hangoutID := uuid.New()
+	memoryID := uuid.New()
+	fileID := uuid.New()
+
+	broker := pubsub.NewInMemoryBroker(8)
+	events, cancel, err := broker.Subscribe(ctx, pubsub.HangoutTopic(hangoutID))
+	require.NoError(t, err)
+	defer cancel()
+
+	db, _ := setupDB(t)
+	memRepo := new(MockMemoryRepository)
+	fileService := new(MockFileService)
+	svc := services.NewMemoryService(db, memRepo, nil, fileService, nil, broker)
+
+	memRepo.On("GetMemoriesByIDs", mock.Anything, []uuid.UUID{memoryID}, userID).Return([]domain.Memory{
+		{ID: memoryID, Name: "photo.jpg", HangoutID: hangoutID, FileID: &fileID},
+	}, nil)
+	fileService.On("ConfirmUpload", mock.Anything, []string{fileID.String()}).Return(nil)
+
+	require.NoError(t, svc.ConfirmUpload(ctx, userID, &dto.ConfirmUploadRequest{MemoryIDs: []uuid.UUID{memoryID}}))
+
+	got := collectEvents(t, events, 1)
+	require.Equal(t, constants.EventMemoryCreated, got[0].Type)
+	require.Equal(t, memoryID, got[0].Data.(*dto.MemoryCreatedEvent).ID)
+	require.Equal(t, "photo.jpg", got[0].Data.(*dto.MemoryCreatedEvent).Name)
+}
+
+func TestHangoutService_CreatePublishesEvent(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	hangoutID := uuid.New()
+
+	broker := pubsub.NewInMemoryBroker(8)
+	events, cancel, err := broker.Subscribe(ctx, pubsub.HangoutTopic(hangoutID))
+	require.NoError(t, err)
+	defer cancel()
+
+	db, sqlMock := setupDB(t)
+	hRepo := new(MockHangoutRepository)
+	aRepo := new(MockActivityRepository)
+	service := services.NewHangoutService(db, hRepo, aRepo, nil, broker)
+
+	created := &domain.Hangout{ID: hangoutID, UserID: &userID, Title: "Picnic", Status: enums.StatusPlanning}
+	sqlMock.ExpectBegin()
+	hRepo.On("WithTx", mock.Anything).Return(hRepo)
+	aRepo.On("WithTx", mock.Anything).Return(aRepo)
+	hRepo.On("CreateHangout", mock.Anything, mock.Anything).Return(created, nil)
+	hRepo.On("GetHangoutByID", mock.Anything, hangoutID, userID).Return(created, nil)
+	sqlMock.ExpectCommit()
+
+	_, err = service.CreateHangout(ctx, userID, &dto.CreateHangoutRequest{Title: "Picnic", Date: "2025-10-05 15:00:00.000", Status: enums.StatusPlanning})
+	require.NoError(t, err)
+
+	got := collectEvents(t, events, 1)
+	require.Equal(t, constants.EventHangoutCreated, got[0].Type)
+	require.Equal(t, userID, got[0].UserID)
+	require.Equal(t, "Picnic", got[0].Data.(*dto.HangoutDetailResponse).Title)
+}
+
+func TestMemoryService_HandleUploadProcessed(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	hangoutID := uuid.New()
+	memoryID := uuid.New()
+
+	t.Run("relays_to_hangout_subscribers", func(t *testing.T) {
+		broker := pubsub.NewInMemoryBroker(8)
+		events, cancel, err := broker.Subscribe(ctx, pubsub.HangoutTopic(hangoutID))
+		require.NoError(t, err)
+		defer cancel()
+
+		memRepo := new(MockMemoryRepository)
+		svc := services.NewMemoryService(nil, memRepo, nil, nil, nil, broker)
+		memRepo.On("GetMemoryByIDAnyOwner", mock.Anything, memoryID).Return(&domain.Memory{ID: memoryID, Name: "photo.jpg", HangoutID: hangoutID, UserID: userID}, nil)
+
+		require.NoError(t, svc.HandleUploadProcessed(ctx, memoryID, 2048, "image/jpeg"))
+
+		got := collectEvents(t, events, 1)
+		require.Equal(t, constants.EventMemoryProcessed, got[0].Type)
+		require.Equal(t, userID, got[0].UserID)
+		require.Equal(t, &dto.MemoryProcessedEvent{ID: memoryID, Name: "photo.jpg", HangoutID: hangoutID, FileSize: 2048, MimeType: "image/jpeg"}, got[0].Data)
+	})
+
+	t.Run("deleted_memory_is_skipped", func(t *testing.T) {
+		memRepo := new(MockMemoryRepository)
+		svc := services.NewMemoryService(nil, memRepo, nil, nil, nil, nil)
+		memRepo.On("GetMemoryByIDAnyOwner", mock.Anything, memoryID).Return(nil, gorm.ErrRecordNotFound)
+
+		require.NoError(t, svc.HandleUploadProcessed(ctx, memoryID, 2048, "image/jpeg"))
+	})
+
+	t.Run("lookup_error", func(t *testing.T) {
+		memRepo := new(MockMemoryRepository)
+		svc := services.NewMemoryService(nil, memRepo, nil, nil, nil, nil)
+		dbErr := errors.New("db error")
+		memRepo.On("GetMemoryByIDAnyOwner", mock.Anything, memoryID).Return(nil, dbErr)
+
+		require.ErrorIs(t, svc.HandleUploadProcessed(ctx, memoryID, 2048, "image/jpeg"), dbErr)
+	})
+}
@@ -3,11 +3,14 @@ package services
 import (
 	"context"
 
+	"github.com/Ernestgio/Hangout-Planner/pkg/shared/enums"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/mapper"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/otel"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/pubsub"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/repository"
 	"github.com/google/uuid"
 	"go.opentelemetry.io/otel/attribute"
@@ -17,9 +20,11 @@ import (
 type HangoutService interface {
 	CreateHangout(ctx context.Context, userID uuid.UUID, req *dto.CreateHangoutRequest) (*dto.HangoutDetailResponse, error)
 	GetHangoutByID(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*dto.HangoutDetailResponse, error)
-	UpdateHangout(ctx context.Context, id uuid.UUID, userID uuid.UUID, req *dto.UpdateHangoutRequest) (*dto.HangoutDetailResponse, error)
-	DeleteHangout(ctx context.Context, id uuid.UUID, userID uuid.UUID) error
+	UpdateHangout(ctx context.Context, id uuid.UUID, userID uuid.UUID, version int64, req *dto.UpdateHangoutRequest) (*dto.HangoutDetailResponse, error)
+	PatchHangout(ctx context.Context, id uuid.UUID, userID uuid.UUID, version int64, req *dto.PatchHangoutRequest) (*dto.HangoutDetailResponse, error)
+	DeleteHangout(ctx context.Context, id uuid.UUID, userID uuid.UUID, version int64) error
 	GetHangoutsByUserID(ctx context.Context, userID uuid.UUID, pagination *dto.CursorPagination) (*dto.PaginatedHangouts, error)
+	BatchHangouts(ctx context.Context, userID uuid.UUID, req *dto.BatchHangoutRequest) (*dto.BatchHangoutResponse, error)
 }
 
 type hangoutService struct {
@@ -27,14 +32,16 @@ type hangoutService struct {
 	hangoutRepo  repository.HangoutRepository
 	activityRepo repository.ActivityRepository
 	metrics      *otel.MetricsRecorder
+	events       pubsub.Publisher
 }
 
-func NewHangoutService(db *gorm.DB, hangoutRepo repository.HangoutRepository, activityRepo repository.ActivityRepository, metrics *otel.MetricsRecorder) HangoutService {
+func NewHangoutService(db *gorm.DB, hangoutRepo repository.HangoutRepository, activityRepo repository.ActivityRepository, metrics *otel.MetricsRecorder, events pubsub.Publisher) HangoutService {
 	return &hangoutService{
 		db:           db,
 		hangoutRepo:  hangoutRepo,
 		activityRepo: activityRepo,
 		metrics:      metrics,
+		events:       events,
 	}
 }
 
@@ -56,49 +63,51 @@ func (s *hangoutService) CreateHangout(ctx context.Context, userID uuid.UUID, re
 
 	var created *domain.Hangout
 	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		txHangoutRepo := s.hangoutRepo.WithTx(tx)
-		txActivityRepo := s.activityRepo.WithTx(tx)
+		created, err = s.createHangoutInTx(ctx, tx, userID, hangoutModel, req.ActivityIDs)
+		return err
+	})
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
 
-		if len(req.ActivityIDs) > 0 {
-			acts, err := txActivityRepo.GetActivitiesByIDs(ctx, req.ActivityIDs)
-			if err != nil {
-				return err
-			}
+	res := mapper.HangoutToDetailResponseDTO(created)
+	publishHangoutEvent(ctx, s.events, created.ID, userID, constants.EventHangoutCreated, res)
 
-			if len(acts) != len(req.ActivityIDs) {
-				return apperrors.ErrInvalidActivityIDs
-			}
-		}
+	span.SetAttributes(attribute.String("hangout.id", created.ID.String()))
+	span.SetStatusOk()
+	recordMetrics("success")
+	return res, nil
+}
 
-		h, err := txHangoutRepo.CreateHangout(ctx, hangoutModel)
-		if err != nil {
-			return err
-		}
-		created = h
+func (s *hangoutService) createHangoutInTx(ctx context.Context, tx *gorm.DB, userID uuid.UUID, hangoutModel *domain.Hangout, activityIDs []uuid.UUID) (*domain.Hangout, error) {
+	txHangoutRepo := s.hangoutRepo.WithTx(tx)
+	txActivityRepo := s.activityRepo.WithTx(tx)
 
-		if len(req.ActivityIDs) > 0 {
-			if err := txHangoutRepo.AddHangoutActivities(ctx, created.ID, req.ActivityIDs); err != nil {
-				return err
-			}
+	if len(activityIDs) > 0 {
+		acts, err := txActivityRepo.GetActivitiesByIDs(ctx, activityIDs)
+		if err != nil {
+			return nil, err
 		}
 
-		created, err = txHangoutRepo.GetHangoutByID(ctx, created.ID, userID)
-		if err != nil {
-			return err
+		if len(acts) != len(activityIDs) {
+			return nil, apperrors.ErrInvalidActivityIDs
 		}
+	}
 
-		return nil
-	})
+	created, err := txHangoutRepo.CreateHangout(ctx, hangoutModel)
 	if err != nil {
-		recordMetrics("error")
-		_ = span.RecordErrorWithStatus(err)
 		return nil, err
 	}
 
-	span.SetAttributes(attribute.String("hangout.id", created.ID.String()))
-	span.SetStatusOk()
-	recordMetrics("success")
-	return mapper.HangoutToDetailResponseDTO(created), nil
+	if len(activityIDs) > 0 {
+		if err := txHangoutRepo.AddHangoutActivities(ctx, created.ID, activityIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	return txHangoutRepo.GetHangoutByID(ctx, created.ID, userID)
 }
 
 func (s *hangoutService) GetHangoutByID(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*dto.HangoutDetailResponse, error) {
@@ -122,7 +131,7 @@ func (s *hangoutService) GetHangoutByID(ctx context.Context, id uuid.UUID, userI
 	return mapper.HangoutToDetailResponseDTO(hangout), nil
 }
 
-func (s *hangoutService) UpdateHangout(ctx context.Context, id uuid.UUID, userID uuid.UUID, req *dto.UpdateHangoutRequest) (*dto.HangoutDetailResponse, error) {
+func (s *hangoutService) UpdateHangout(ctx context.Context, id uuid.UUID, userID uuid.UUID, version int64, req *dto.UpdateHangoutRequest) (*dto.HangoutDetailResponse, error) {
 	recordMetrics := s.metrics.StartRequest(ctx, "hangout", "update")
 
 	ctx, span := otel.StartServiceSpan(ctx, "UpdateHangout",
@@ -131,7 +140,66 @@ func (s *hangoutService) UpdateHangout(ctx context.Context, id uuid.UUID, userID
 	)
 	defer span.End()
 
+	updatedHangout, previousStatus, err := s.applyHangoutChanges(ctx, id, userID, version, req.ActivityIDs, func(hangout *domain.Hangout) error {
+		return mapper.ApplyUpdateToHangout(hangout, req)
+	})
+
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	res := mapper.HangoutToDetailResponseDTO(updatedHangout)
+	s.publishHangoutUpdated(ctx, res, userID, previousStatus)
+
+	span.SetStatusOk()
+	recordMetrics("success")
+	return res, nil
+}
+
+func (s *hangoutService) PatchHangout(ctx context.Context, id uuid.UUID, userID uuid.UUID, version int64, req *dto.PatchHangoutRequest) (*dto.HangoutDetailResponse, error) {
+	recordMetrics := s.metrics.StartRequest(ctx, "hangout", "patch")
+
+	ctx, span := otel.StartServiceSpan(ctx, "PatchHangout",
+		attribute.String("hangout.id", id.String()),
+		attribute.String("user.id", userID.String()),
+	)
+	defer span.End()
+
+	// a nil slice leaves the activity set alone; null or [] clears it
+	var activityIDs []uuid.UUID
+	if req.ActivityIDs.Set {
+		activityIDs = []uuid.UUID{}
+		if req.ActivityIDs.Present() && req.ActivityIDs.Value != nil {
+			activityIDs = req.ActivityIDs.Value
+		}
+	}
+
+	updatedHangout, previousStatus, err := s.applyHangoutChanges(ctx, id, userID, version, activityIDs, func(hangout *domain.Hangout) error {
+		return mapper.ApplyPatchToHangout(hangout, req)
+	})
+
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	res := mapper.HangoutToDetailResponseDTO(updatedHangout)
+	s.publishHangoutUpdated(ctx, res, userID, previousStatus)
+
+	span.SetStatusOk()
+	recordMetrics("success")
+	return res, nil
+}
+
+// applyHangoutChanges runs apply against the stored hangout and, when activityIDs
+// is non-nil, replaces the activity set with it, all inside a single transaction
+// guarded by the expected version.
+func (s *hangoutService) applyHangoutChanges(ctx context.Context, id uuid.UUID, userID uuid.UUID, version int64, activityIDs []uuid.UUID, apply func(*domain.Hangout) error) (*domain.Hangout, enums.HangoutStatus, error) {
 	var updatedHangout *domain.Hangout
+	var previousStatus enums.HangoutStatus
 
 	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		txHangoutRepo := s.hangoutRepo.WithTx(tx)
@@ -142,18 +210,23 @@ func (s *hangoutService) UpdateHangout(ctx context.Context, id uuid.UUID, userID
 			return err
 		}
 
-		err = mapper.ApplyUpdateToHangout(existingHangout, req)
+		if existingHangout.Version != version {
+			return apperrors.ErrPreconditionFailed
+		}
+
+		previousStatus = existingHangout.Status
+		err = apply(existingHangout)
 		if err != nil {
 			return err
 		}
 
-		if req.ActivityIDs != nil {
-			acts, err := txActivityRepo.GetActivitiesByIDs(ctx, req.ActivityIDs)
+		if len(activityIDs) > 0 {
+			acts, err := txActivityRepo.GetActivitiesByIDs(ctx, activityIDs)
 			if err != nil {
 				return err
 			}
 
-			if len(acts) != len(req.ActivityIDs) {
+			if len(acts) != len(activityIDs) {
 				return apperrors.ErrInvalidActivityIDs
 			}
 		}
@@ -163,16 +236,14 @@ func (s *hangoutService) UpdateHangout(ctx context.Context, id uuid.UUID, userID
 			return err
 		}
 
-		if req.ActivityIDs != nil {
-			newIDs := req.ActivityIDs
-
+		if activityIDs != nil {
 			currentMap := make(map[uuid.UUID]bool)
 			for _, act := range existingHangout.Activities {
 				currentMap[act.ID] = true
 			}
 
 			newMap := make(map[uuid.UUID]bool)
-			for _, id := range newIDs {
+			for _, id := range activityIDs {
 				newMap[id] = true
 			}
 
@@ -211,18 +282,20 @@ func (s *hangoutService) UpdateHangout(ctx context.Context, id uuid.UUID, userID
 		return nil
 	})
 
-	if err != nil {
-		recordMetrics("error")
-		_ = span.RecordErrorWithStatus(err)
-		return nil, err
-	}
+	return updatedHangout, previousStatus, err
+}
 
-	span.SetStatusOk()
-	recordMetrics("success")
-	return mapper.HangoutToDetailResponseDTO(updatedHangout), nil
+func (s *hangoutService) publishHangoutUpdated(ctx context.Context, hangout *dto.HangoutDetailResponse, userID uuid.UUID, previousStatus enums.HangoutStatus) {
+	publishHangoutEvent(ctx, s.events, hangout.ID, userID, constants.EventHangoutUpdated, hangout)
+	if previousStatus != hangout.Status {
+		publishHangoutEvent(ctx, s.events, hangout.ID, userID, constants.EventHangoutStatusChanged, dto.HangoutStatusChangedEvent{
+			From: previousStatus,
+			To:   hangout.Status,
+		})
+	}
 }
 
-func (s *hangoutService) DeleteHangout(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
+func (s *hangoutService) DeleteHangout(ctx context.Context, id uuid.UUID, userID uuid.UUID, version int64) error {
 	recordMetrics := s.metrics.StartRequest(ctx, "hangout", "delete")
 
 	ctx, span := otel.StartServiceSpan(ctx, "DeleteHangout",
@@ -233,17 +306,21 @@ func (s *hangoutService) DeleteHangout(ctx context.Context, id uuid.UUID, userID
 
 	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		txRepo := s.hangoutRepo.WithTx(tx)
-		_, err := txRepo.GetHangoutByID(ctx, id, userID)
+		existingHangout, err := txRepo.GetHangoutByID(ctx, id, userID)
 		if err != nil {
 			return err
 		}
-		return txRepo.DeleteHangout(ctx, id)
+		if existingHangout.Version != version {
+			return apperrors.ErrPreconditionFailed
+		}
+		return txRepo.DeleteHangout(ctx, id, version)
 	})
 
 	if err != nil {
 		recordMetrics("error")
 		_ = span.RecordErrorWithStatus(err)
 	} else {
+		publishHangoutEvent(ctx, s.events, id, userID, constants.EventHangoutDeleted, dto.HangoutDeletedEvent{ID: id})
 		span.SetStatusOk()
 		recordMetrics("success")
 	}
@@ -0,0 +1,267 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Ernestgio/Hangout-Planner/pkg/shared/enums"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/mapper"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/otel"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"gorm.io/gorm"
+)
+
+func (s *hangoutService) BatchHangouts(ctx context.Context, userID uuid.UUID, req *dto.BatchHangoutRequest) (*dto.BatchHangoutResponse, error) {
+	recordMetrics := s.metrics.StartRequest(ctx, "hangout", "batch")
+
+	ctx, span := otel.StartServiceSpan(ctx, "BatchHangouts",
+		attribute.String("user.id", userID.String()),
+		attribute.Int("batch.size", len(req.Operations)),
+		attribute.Bool("batch.atomic", req.Atomic),
+	)
+	defer span.End()
+
+	if len(req.Operations) > constants.MaxHangoutBatchSize {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(apperrors.ErrBatchTooLarge)
+		return nil, apperrors.ErrBatchTooLarge
+	}
+
+	res := &dto.BatchHangoutResponse{
+		Atomic:  req.Atomic,
+		Results: make([]dto.BatchHangoutResult, 0, len(req.Operations)),
+	}
+
+	// events are only published once the owning transaction has committed
+	var committed []batchOutcome
+
+	if req.Atomic {
+		var pending []batchOutcome
+		err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			for i := range req.Operations {
+				op := &req.Operations[i]
+				hangout, previousStatus, err := s.runBatchOperation(ctx, tx, userID, op)
+				if err != nil {
+					return fmt.Errorf("operation %d (%s): %w", i, op.Op, err)
+				}
+				pending = append(pending, batchOutcome{op: op, hangout: hangout, previousStatus: previousStatus})
+				res.Results = append(res.Results, batchSuccessResult(i, op, hangout))
+			}
+			return nil
+		})
+		if err != nil {
+			recordMetrics("error")
+			_ = span.RecordErrorWithStatus(err)
+			return nil, err
+		}
+		committed = pending
+		res.Succeeded = len(res.Results)
+	} else {
+		for i := range req.Operations {
+			op := &req.Operations[i]
+
+			var hangout *domain.Hangout
+			var previousStatus enums.HangoutStatus
+			err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+				var err error
+				hangout, previousStatus, err = s.runBatchOperation(ctx, tx, userID, op)
+				return err
+			})
+
+			if err != nil {
+				res.Failed++
+				res.Results = append(res.Results, dto.BatchHangoutResult{
+					Index:     i,
+					Op:        op.Op,
+					HangoutID: op.HangoutID,
+					Status:    constants.BatchResultFailed,
+					Error:     batchErrorMessage(err),
+				})
+				continue
+			}
+			res.Succeeded++
+			committed = append(committed, batchOutcome{op: op, hangout: hangout, previousStatus: previousStatus})
+			res.Results = append(res.Results, batchSuccessResult(i, op, hangout))
+		}
+	}
+
+	for _, outcome := range committed {
+		switch {
+		case outcome.op.Op == constants.BatchOpCreate:
+			publishHangoutEvent(ctx, s.events, outcome.hangout.ID, userID, constants.EventHangoutCreated, mapper.HangoutToDetailResponseDTO(outcome.hangout))
+		case outcome.hangout == nil:
+			id := *outcome.op.HangoutID
+			publishHangoutEvent(ctx, s.events, id, userID, constants.EventHangoutDeleted, dto.HangoutDeletedEvent{ID: id})
+		default:
+			s.publishHangoutUpdated(ctx, mapper.HangoutToDetailResponseDTO(outcome.hangout), userID, outcome.previousStatus)
+		}
+	}
+
+	span.SetAttributes(
+		attribute.Int("batch.succeeded", res.Succeeded),
+		attribute.Int("batch.failed", res.Failed),
+	)
+	span.SetStatusOk()
+	recordMetrics("success")
+	return res, nil
+}
+
+type batchOutcome struct {
+	op             *dto.BatchHangoutOperation
+	hangout        *domain.Hangout
+	previousStatus enums.HangoutStatus
+}
+
+// runBatchOperation applies a single batch operation inside tx.
+func (s *hangoutService) runBatchOperation(ctx context.Context, tx *gorm.DB, userID uuid.UUID, op *dto.BatchHangoutOperation) (*domain.Hangout, enums.HangoutStatus, error) {
+	if op.Op == constants.BatchOpCreate {
+		if op.Hangout == nil {
+			return nil, "", apperrors.ErrInvalidBatchOperation
+		}
+		hangoutModel, err := mapper.HangoutCreateRequestToModel(op.Hangout)
+		if err != nil {
+			if err != apperrors.ErrInvalidRSVPDeadline {
+				err = apperrors.ErrInvalidHangoutDate
+			}
+			return nil, "", err
+		}
+		hangoutModel.UserID = &userID
+		created, err := s.createHangoutInTx(ctx, tx, userID, hangoutModel, op.Hangout.ActivityIDs)
+		if err != nil {
+			return nil, "", err
+		}
+		return created, created.Status, nil
+	}
+
+	if op.HangoutID == nil {
+		return nil, "", apperrors.ErrInvalidBatchOperation
+	}
+
+	txHangoutRepo := s.hangoutRepo.WithTx(tx)
+	txActivityRepo := s.activityRepo.WithTx(tx)
+
+	hangout, err := txHangoutRepo.GetHangoutByID(ctx, *op.HangoutID, userID)
+	if err != nil {
+		return nil, "", err
+	}
+	previousStatus := hangout.Status
+
+	if op.Version != nil && *op.Version != hangout.Version {
+		return nil, previousStatus, apperrors.ErrPreconditionFailed
+	}
+
+	switch op.Op {
+	case constants.BatchOpDelete:
+		return nil, previousStatus, txHangoutRepo.DeleteHangout(ctx, hangout.ID, hangout.Version)
+
+	case constants.BatchOpUpdateStatus:
+		if err := mapper.ApplyStatusToHangout(hangout, op.Status); err != nil {
+			return nil, previousStatus, err
+		}
+
+	case constants.BatchOpAddActivities:
+		if len(op.ActivityIDs) == 0 {
+			return nil, "", apperrors.ErrInvalidBatchOperation
+		}
+		acts, err := txActivityRepo.GetActivitiesByIDs(ctx, op.ActivityIDs)
+		if err != nil {
+			return nil, previousStatus, err
+		}
+		if len(acts) != len(op.ActivityIDs) {
+			return nil, previousStatus, apperrors.ErrInvalidActivityIDs
+		}
+
+		toAdd := diffActivityIDs(op.ActivityIDs, hangout.Activities, false)
+		if len(toAdd) == 0 {
+			return hangout, previousStatus, nil
+		}
+		if err := txHangoutRepo.AddHangoutActivities(ctx, hangout.ID, toAdd); err != nil {
+			return nil, previousStatus, err
+		}
+
+	case constants.BatchOpRemoveActivities:
+		if len(op.ActivityIDs) == 0 {
+			return nil, "", apperrors.ErrInvalidBatchOperation
+		}
+
+		toRemove := diffActivityIDs(op.ActivityIDs, hangout.Activities, true)
+		if len(toRemove) == 0 {
+			return hangout, previousStatus, nil
+		}
+		if err := txHangoutRepo.RemoveHangoutActivities(ctx, hangout.ID, toRemove); err != nil {
+			return nil, previousStatus, err
+		}
+
+	default:
+		return nil, "", apperrors.ErrInvalidBatchOperation
+	}
+
+	// bump the version so ETags handed out before the batch stop matching
+	if _, err := txHangoutRepo.UpdateHangout(ctx, hangout); err != nil {
+		return nil, previousStatus, err
+	}
+
+	updated, err := txHangoutRepo.GetHangoutByID(ctx, hangout.ID, userID)
+	if err != nil {
+		return nil, previousStatus, err
+	}
+	return updated, previousStatus, nil
+}
+
+// diffActivityIDs returns the unique ids that are (attached == true) or are not
+// (attached == false) already linked to the hangout.
+func diffActivityIDs(ids []uuid.UUID, current []*domain.Activity, attached bool) []uuid.UUID {
+	currentMap := make(map[uuid.UUID]bool, len(current))
+	for _, act := range current {
+		currentMap[act.ID] = true
+	}
+
+	seen := make(map[uuid.UUID]bool, len(ids))
+	var result []uuid.UUID
+	for _, id := range ids {
+		if seen[id] || currentMap[id] != attached {
+			continue
+		}
+		seen[id] = true
+		result = append(result, id)
+	}
+	return result
+}
+
+func batchSuccessResult(index int, op *dto.BatchHangoutOperation, hangout *domain.Hangout) dto.BatchHangoutResult {
+	result := dto.BatchHangoutResult{
+		Index:     index,
+		Op:        op.Op,
+		HangoutID: op.HangoutID,
+		Status:    constants.BatchResultSucceeded,
+	}
+	if hangout != nil {
+		result.HangoutID = &hangout.ID
+		result.Hangout = mapper.HangoutToDetailResponseDTO(hangout)
+	}
+	return result
+}
+
+// batchErrorMessage only exposes messages of known application errors so that
+// per-item results don't leak database details.
+func batchErrorMessage(err error) string {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return apperrors.ErrNotFound.Error()
+	case errors.Is(err, apperrors.ErrPreconditionFailed),
+		errors.Is(err, apperrors.ErrInvalidActivityIDs),
+		errors.Is(err, apperrors.ErrInvalidBatchOperation),
+		errors.Is(err, apperrors.ErrInvalidHangoutStatus),
+		errors.Is(err, apperrors.ErrInvalidHangoutDate),
+		errors.Is(err, apperrors.ErrInvalidRSVPDeadline):
+		return err.Error()
+	default:
+		return constants.ProdErrorMessage
+	}
+}
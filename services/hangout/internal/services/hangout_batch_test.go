@@ -0,0 +1,269 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Ernestgio/Hangout-Planner/pkg/shared/enums"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/services"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestHangoutService_BatchHangouts(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	hangoutID := uuid.New()
+	otherHangoutID := uuid.New()
+	activityID1 := uuid.New()
+	activityID2 := uuid.New()
+	dbError := errors.New("db error")
+	staleVersion := int64(7)
+	bumpedVersion := int64(2)
+
+	newExisting := func(id uuid.UUID) *domain.Hangout {
+		return &domain.Hangout{ID: id, UserID: &userID, Version: 1, Title: "Existing", Status: enums.StatusPlanning, Activities: []*domain.Activity{{ID: activityID1}}}
+	}
+
+	testCases := []struct {
+		name      string
+		req       *dto.BatchHangoutRequest
+		setupMock func(hRepo *MockHangoutRepository, aRepo *MockActivityRepository, sqlMock sqlmock.Sqlmock)
+		check     func(t *testing.T, res *dto.BatchHangoutResponse, err error)
+	}{
+		{
+			name: "batch_too_large",
+			req: &dto.BatchHangoutRequest{
+				Operations: make([]dto.BatchHangoutOperation, constants.MaxHangoutBatchSize+1),
+			},
+			setupMock: func(hRepo *MockHangoutRepository, aRepo *MockActivityRepository, sqlMock sqlmock.Sqlmock) {},
+			check: func(t *testing.T, res *dto.BatchHangoutResponse, err error) {
+				require.ErrorIs(t, err, apperrors.ErrBatchTooLarge)
+				require.Nil(t, res)
+			},
+		},
+		{
+			name: "per_item_reports_each_result",
+			req: &dto.BatchHangoutRequest{
+				Operations: []dto.BatchHangoutOperation{
+					{Op: constants.BatchOpUpdateStatus, HangoutID: &hangoutID, Status: enums.StatusConfirmed},
+					{Op: constants.BatchOpDelete, HangoutID: &otherHangoutID},
+					{Op: constants.BatchOpCreate},
+				},
+			},
+			setupMock: func(hRepo *MockHangoutRepository, aRepo *MockActivityRepository, sqlMock sqlmock.Sqlmock) {
+				hRepo.On("WithTx", mock.Anything).Return(hRepo)
+				aRepo.On("WithTx", mock.Anything).Return(aRepo)
+
+				sqlMock.ExpectBegin()
+				existing := newExisting(hangoutID)
+				hRepo.On("GetHangoutByID", mock.Anything, hangoutID, userID).Return(existing, nil).Once()
+				hRepo.On("UpdateHangout", mock.Anything, mock.MatchedBy(func(h *domain.Hangout) bool {
+					return h.Status == enums.StatusConfirmed
+				})).Return(existing, nil).Once()
+				updated := newExisting(hangoutID)
+				updated.Status = enums.StatusConfirmed
+				updated.Version = 2
+				hRepo.On("GetHangoutByID", mock.Anything, hangoutID, userID).Return(updated, nil).Once()
+				sqlMock.ExpectCommit()
+
+				sqlMock.ExpectBegin()
+				hRepo.On("GetHangoutByID", mock.Anything, otherHangoutID, userID).Return(nil, gorm.ErrRecordNotFound).Once()
+				sqlMock.ExpectRollback()
+
+				sqlMock.ExpectBegin()
+				sqlMock.ExpectRollback()
+			},
+			check: func(t *testing.T, res *dto.BatchHangoutResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, 1, res.Succeeded)
+				require.Equal(t, 2, res.Failed)
+				require.Len(t, res.Results, 3)
+
+				require.Equal(t, constants.BatchResultSucceeded, res.Results[0].Status)
+				require.Equal(t, enums.StatusConfirmed, res.Results[0].Hangout.Status)
+				require.Equal(t, int64(2), res.Results[0].Hangout.Version)
+
+				require.Equal(t, constants.BatchResultFailed, res.Results[1].Status)
+				require.Equal(t, apperrors.ErrNotFound.Error(), res.Results[1].Error)
+				require.Equal(t, &otherHangoutID, res.Results[1].HangoutID)
+
+				require.Equal(t, constants.BatchResultFailed, res.Results[2].Status)
+				require.Equal(t, apperrors.ErrInvalidBatchOperation.Error(), res.Results[2].Error)
+			},
+		},
+		{
+			name: "per_item_hides_unexpected_errors",
+			req: &dto.BatchHangoutRequest{
+				Operations: []dto.BatchHangoutOperation{
+					{Op: constants.BatchOpDelete, HangoutID: &hangoutID},
+				},
+			},
+			setupMock: func(hRepo *MockHangoutRepository, aRepo *MockActivityRepository, sqlMock sqlmock.Sqlmock) {
+				hRepo.On("WithTx", mock.Anything).Return(hRepo)
+				aRepo.On("WithTx", mock.Anything).Return(aRepo)
+
+				sqlMock.ExpectBegin()
+				hRepo.On("GetHangoutByID", mock.Anything, hangoutID, userID).Return(newExisting(hangoutID), nil).Once()
+				hRepo.On("DeleteHangout", mock.Anything, hangoutID, int64(1)).Return(dbError).Once()
+				sqlMock.ExpectRollback()
+			},
+			check: func(t *testing.T, res *dto.BatchHangoutResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, 1, res.Failed)
+				require.Equal(t, constants.ProdErrorMessage, res.Results[0].Error)
+			},
+		},
+		{
+			name: "atomic_success",
+			req: &dto.BatchHangoutRequest{
+				Atomic: true,
+				Operations: []dto.BatchHangoutOperation{
+					{Op: constants.BatchOpAddActivities, HangoutID: &hangoutID, ActivityIDs: []uuid.UUID{activityID1, activityID2, activityID2}},
+					{Op: constants.BatchOpRemoveActivities, HangoutID: &otherHangoutID, ActivityIDs: []uuid.UUID{activityID1, activityID2}},
+					{Op: constants.BatchOpDelete, HangoutID: &otherHangoutID, Version: &bumpedVersion},
+				},
+			},
+			setupMock: func(hRepo *MockHangoutRepository, aRepo *MockActivityRepository, sqlMock sqlmock.Sqlmock) {
+				hRepo.On("WithTx", mock.Anything).Return(hRepo)
+				aRepo.On("WithTx", mock.Anything).Return(aRepo)
+
+				sqlMock.ExpectBegin()
+
+				existing := newExisting(hangoutID)
+				hRepo.On("GetHangoutByID", mock.Anything, hangoutID, userID).Return(existing, nil).Once()
+				aRepo.On("GetActivitiesByIDs", mock.Anything, []uuid.UUID{activityID1, activityID2, activityID2}).
+					Return([]*domain.Activity{{ID: activityID1}, {ID: activityID2}, {ID: activityID2}}, nil).Once()
+				hRepo.On("AddHangoutActivities", mock.Anything, hangoutID, []uuid.UUID{activityID2}).Return(nil).Once()
+				hRepo.On("UpdateHangout", mock.Anything, existing).Return(existing, nil).Once()
+				hRepo.On("GetHangoutByID", mock.Anything, hangoutID, userID).Return(existing, nil).Once()
+
+				other := newExisting(otherHangoutID)
+				hRepo.On("GetHangoutByID", mock.Anything, otherHangoutID, userID).Return(other, nil).Once()
+				hRepo.On("RemoveHangoutActivities", mock.Anything, otherHangoutID, []uuid.UUID{activityID1}).Return(nil).Once()
+				hRepo.On("UpdateHangout", mock.Anything, other).Return(other, nil).Once()
+				removed := newExisting(otherHangoutID)
+				removed.Version = bumpedVersion
+				removed.Activities = nil
+				hRepo.On("GetHangoutByID", mock.Anything, otherHangoutID, userID).Return(removed, nil).Once()
+
+				hRepo.On("GetHangoutByID", mock.Anything, otherHangoutID, userID).Return(removed, nil).Once()
+				hRepo.On("DeleteHangout", mock.Anything, otherHangoutID, bumpedVersion).Return(nil).Once()
+
+				sqlMock.ExpectCommit()
+			},
+			check: func(t *testing.T, res *dto.BatchHangoutResponse, err error) {
+				require.NoError(t, err)
+				require.True(t, res.Atomic)
+				require.Equal(t, 3, res.Succeeded)
+				require.Equal(t, 0, res.Failed)
+				require.Empty(t, res.Results[1].Hangout.Activities)
+				require.Nil(t, res.Results[2].Hangout)
+				require.Equal(t, &otherHangoutID, res.Results[2].HangoutID)
+			},
+		},
+		{
+			name: "atomic_create",
+			req: &dto.BatchHangoutRequest{
+				Atomic: true,
+				Operations: []dto.BatchHangoutOperation{
+					{Op: constants.BatchOpCreate, Hangout: &dto.CreateHangoutRequest{Title: "Picnic", Date: "2025-12-01 18:30:00.000", Status: enums.StatusPlanning}},
+				},
+			},
+			setupMock: func(hRepo *MockHangoutRepository, aRepo *MockActivityRepository, sqlMock sqlmock.Sqlmock) {
+				hRepo.On("WithTx", mock.Anything).Return(hRepo)
+				aRepo.On("WithTx", mock.Anything).Return(aRepo)
+
+				sqlMock.ExpectBegin()
+				created := &domain.Hangout{ID: hangoutID, UserID: &userID, Title: "Picnic", Version: 1}
+				hRepo.On("CreateHangout", mock.Anything, mock.MatchedBy(func(h *domain.Hangout) bool {
+					return h.Title == "Picnic" && *h.UserID == userID
+				})).Return(created, nil).Once()
+				hRepo.On("GetHangoutByID", mock.Anything, hangoutID, userID).Return(created, nil).Once()
+				sqlMock.ExpectCommit()
+			},
+			check: func(t *testing.T, res *dto.BatchHangoutResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, 1, res.Succeeded)
+				require.Equal(t, &hangoutID, res.Results[0].HangoutID)
+				require.Equal(t, "Picnic", res.Results[0].Hangout.Title)
+			},
+		},
+		{
+			name: "atomic_rolls_back_on_first_failure",
+			req: &dto.BatchHangoutRequest{
+				Atomic: true,
+				Operations: []dto.BatchHangoutOperation{
+					{Op: constants.BatchOpUpdateStatus, HangoutID: &hangoutID, Status: enums.StatusCancelled},
+					{Op: constants.BatchOpDelete, HangoutID: &otherHangoutID, Version: &staleVersion},
+					{Op: constants.BatchOpDelete, HangoutID: &hangoutID},
+				},
+			},
+			setupMock: func(hRepo *MockHangoutRepository, aRepo *MockActivityRepository, sqlMock sqlmock.Sqlmock) {
+				hRepo.On("WithTx", mock.Anything).Return(hRepo)
+				aRepo.On("WithTx", mock.Anything).Return(aRepo)
+
+				sqlMock.ExpectBegin()
+				existing := newExisting(hangoutID)
+				hRepo.On("GetHangoutByID", mock.Anything, hangoutID, userID).Return(existing, nil).Once()
+				hRepo.On("UpdateHangout", mock.Anything, existing).Return(existing, nil).Once()
+				hRepo.On("GetHangoutByID", mock.Anything, hangoutID, userID).Return(existing, nil).Once()
+
+				hRepo.On("GetHangoutByID", mock.Anything, otherHangoutID, userID).Return(newExisting(otherHangoutID), nil).Once()
+				sqlMock.ExpectRollback()
+			},
+			check: func(t *testing.T, res *dto.BatchHangoutResponse, err error) {
+				require.ErrorIs(t, err, apperrors.ErrPreconditionFailed)
+				require.Contains(t, err.Error(), "operation 1 (delete)")
+				require.Nil(t, res)
+			},
+		},
+		{
+			name: "invalid_status_is_rejected",
+			req: &dto.BatchHangoutRequest{
+				Atomic: true,
+				Operations: []dto.BatchHangoutOperation{
+					{Op: constants.BatchOpUpdateStatus, HangoutID: &hangoutID, Status: "POSTPONED"},
+				},
+			},
+			setupMock: func(hRepo *MockHangoutRepository, aRepo *MockActivityRepository, sqlMock sqlmock.Sqlmock) {
+				hRepo.On("WithTx", mock.Anything).Return(hRepo)
+				aRepo.On("WithTx", mock.Anything).Return(aRepo)
+
+				sqlMock.ExpectBegin()
+				hRepo.On("GetHangoutByID", mock.Anything, hangoutID, userID).Return(newExisting(hangoutID), nil).Once()
+				sqlMock.ExpectRollback()
+			},
+			check: func(t *testing.T, res *dto.BatchHangoutResponse, err error) {
+				require.ErrorIs(t, err, apperrors.ErrInvalidHangoutStatus)
+				require.Nil(t, res)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, sqlMock := setupDB(t)
+			mockHangoutRepo := new(MockHangoutRepository)
+			mockActivityRepo := new(MockActivityRepository)
+			service := services.NewHangoutService(db, mockHangoutRepo, mockActivityRepo, nil, nil)
+
+			tc.setupMock(mockHangoutRepo, mockActivityRepo, sqlMock)
+
+			res, err := service.BatchHangouts(ctx, userID, tc.req)
+			tc.check(t, res, err)
+
+			mockHangoutRepo.AssertExpectations(t)
+			mockActivityRepo.AssertExpectations(t)
+			require.NoError(t, sqlMock.ExpectationsWereMet())
+		})
+	}
+}
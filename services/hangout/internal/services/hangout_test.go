@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/Ernestgio/Hangout-Planner/pkg/shared/enums"
@@ -52,6 +53,14 @@ func (m *MockHangoutRepository) GetHangoutByID(ctx context.Context, id uuid.UUID
 	return args.Get(0).(*domain.Hangout), args.Error(1)
 }
 
+func (m *MockHangoutRepository) GetHangoutByIDAnyOwner(ctx context.Context, id uuid.UUID) (*domain.Hangout, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Hangout), args.Error(1)
+}
+
 func (m *MockHangoutRepository) UpdateHangout(ctx context.Context, hangout *domain.Hangout) (*domain.Hangout, error) {
 	args := m.Called(ctx, hangout)
 	if args.Get(0) == nil {
@@ -60,8 +69,8 @@ func (m *MockHangoutRepository) UpdateHangout(ctx context.Context, hangout *doma
 	return args.Get(0).(*domain.Hangout), args.Error(1)
 }
 
-func (m *MockHangoutRepository) DeleteHangout(ctx context.Context, id uuid.UUID) error {
-	args := m.Called(ctx, id)
+func (m *MockHangoutRepository) DeleteHangout(ctx context.Context, id uuid.UUID, version int64) error {
+	args := m.Called(ctx, id, version)
 	return args.Error(0)
 }
 
@@ -91,6 +100,64 @@ func (m *MockHangoutRepository) RemoveHangoutActivities(ctx context.Context, han
 	return args.Error(0)
 }
 
+func (m *MockHangoutRepository) GetDeletedHangoutByID(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*domain.Hangout, error) {
+	args := m.Called(ctx, id, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Hangout), args.Error(1)
+}
+
+func (m *MockHangoutRepository) GetDeletedHangoutsByUserID(ctx context.Context, userID uuid.UUID, pagination *dto.CursorPagination) ([]domain.Hangout, error) {
+	args := m.Called(ctx, userID, pagination)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Hangout), args.Error(1)
+}
+
+func (m *MockHangoutRepository) RestoreHangout(ctx context.Context, id uuid.UUID, deletedAt time.Time) error {
+	args := m.Called(ctx, id, deletedAt)
+	return args.Error(0)
+}
+
+func (m *MockHangoutRepository) GetHangoutsDeletedBefore(ctx context.Context, before time.Time, limit int) ([]domain.Hangout, error) {
+	args := m.Called(ctx, before, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Hangout), args.Error(1)
+}
+
+func (m *MockHangoutRepository) PurgeHangout(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockHangoutRepository) GetHangoutsStartingBetween(ctx context.Context, from time.Time, to time.Time) ([]domain.Hangout, error) {
+	args := m.Called(ctx, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Hangout), args.Error(1)
+}
+
+func (m *MockHangoutRepository) GetHangoutsWithRSVPDeadlineBetween(ctx context.Context, from time.Time, to time.Time) ([]domain.Hangout, error) {
+	args := m.Called(ctx, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Hangout), args.Error(1)
+}
+
+func (m *MockHangoutRepository) GetAllHangoutsByUserID(ctx context.Context, userID uuid.UUID) ([]domain.Hangout, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Hangout), args.Error(1)
+}
+
 func TestHangoutService_CreateHangout(t *testing.T) {
 	ctx := context.Background()
 	userID := uuid.New()
@@ -331,7 +398,7 @@ func TestHangoutService_CreateHangout(t *testing.T) {
 			db, sqlMock := setupDB(t)
 			mockHangoutRepo := new(MockHangoutRepository)
 			mockActivityRepo := new(MockActivityRepository)
-			service := services.NewHangoutService(db, mockHangoutRepo, mockActivityRepo, nil)
+			service := services.NewHangoutService(db, mockHangoutRepo, mockActivityRepo, nil, nil)
 
 			tc.setupMock(mockHangoutRepo, mockActivityRepo, sqlMock)
 
@@ -384,7 +451,7 @@ func TestHangoutService_GetHangoutByID(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			mockHangoutRepo := new(MockHangoutRepository)
 			mockActivityRepo := new(MockActivityRepository)
-			hangoutService := services.NewHangoutService(nil, mockHangoutRepo, mockActivityRepo, nil)
+			hangoutService := services.NewHangoutService(nil, mockHangoutRepo, mockActivityRepo, nil, nil)
 			tc.setupMock(mockHangoutRepo)
 
 			result, err := hangoutService.GetHangoutByID(ctx, hangoutID, tc.userID)
@@ -410,8 +477,8 @@ func TestHangoutService_DeleteHangout(t *testing.T) {
 			setupMock: func(repo *MockHangoutRepository, sqlMock sqlmock.Sqlmock) {
 				sqlMock.ExpectBegin()
 				repo.On("WithTx", mock.Anything).Return(repo).Once()
-				repo.On("GetHangoutByID", mock.Anything, hangoutID, userID).Return(&domain.Hangout{ID: hangoutID, UserID: &userID}, nil).Once()
-				repo.On("DeleteHangout", mock.Anything, hangoutID).Return(nil).Once()
+				repo.On("GetHangoutByID", mock.Anything, hangoutID, userID).Return(&domain.Hangout{ID: hangoutID, UserID: &userID, Version: 1}, nil).Once()
+				repo.On("DeleteHangout", mock.Anything, hangoutID, int64(1)).Return(nil).Once()
 				sqlMock.ExpectCommit()
 			},
 			expectedErr: nil,
@@ -431,12 +498,22 @@ func TestHangoutService_DeleteHangout(t *testing.T) {
 			setupMock: func(repo *MockHangoutRepository, sqlMock sqlmock.Sqlmock) {
 				sqlMock.ExpectBegin()
 				repo.On("WithTx", mock.Anything).Return(repo).Once()
-				repo.On("GetHangoutByID", mock.Anything, hangoutID, userID).Return(&domain.Hangout{ID: hangoutID, UserID: &userID}, nil).Once()
-				repo.On("DeleteHangout", mock.Anything, hangoutID).Return(dbError).Once()
+				repo.On("GetHangoutByID", mock.Anything, hangoutID, userID).Return(&domain.Hangout{ID: hangoutID, UserID: &userID, Version: 1}, nil).Once()
+				repo.On("DeleteHangout", mock.Anything, hangoutID, int64(1)).Return(dbError).Once()
 				sqlMock.ExpectRollback()
 			},
 			expectedErr: dbError,
 		},
+		{
+			name: "version mismatch",
+			setupMock: func(repo *MockHangoutRepository, sqlMock sqlmock.Sqlmock) {
+				sqlMock.ExpectBegin()
+				repo.On("WithTx", mock.Anything).Return(repo).Once()
+				repo.On("GetHangoutByID", mock.Anything, hangoutID, userID).Return(&domain.Hangout{ID: hangoutID, UserID: &userID, Version: 2}, nil).Once()
+				sqlMock.ExpectRollback()
+			},
+			expectedErr: apperrors.ErrPreconditionFailed,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -444,10 +521,10 @@ func TestHangoutService_DeleteHangout(t *testing.T) {
 			db, sqlMock := setupDB(t)
 			mockRepo := new(MockHangoutRepository)
 			mockActivityRepo := new(MockActivityRepository)
-			service := services.NewHangoutService(db, mockRepo, mockActivityRepo, nil)
+			service := services.NewHangoutService(db, mockRepo, mockActivityRepo, nil, nil)
 			tc.setupMock(mockRepo, sqlMock)
 
-			err := service.DeleteHangout(ctx, hangoutID, userID)
+			err := service.DeleteHangout(ctx, hangoutID, userID, 1)
 
 			if tc.expectedErr != nil {
 				require.Error(t, err)
@@ -555,7 +632,7 @@ func TestHangoutService_GetHangoutsByUserID(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			mockHangoutRepo := new(MockHangoutRepository)
 			mockActivityRepo := new(MockActivityRepository)
-			hangoutService := services.NewHangoutService(nil, mockHangoutRepo, mockActivityRepo, nil)
+			hangoutService := services.NewHangoutService(nil, mockHangoutRepo, mockActivityRepo, nil, nil)
 			tc.setupMock(mockHangoutRepo)
 
 			result, err := hangoutService.GetHangoutsByUserID(ctx, userID, tc.pagination)
@@ -593,7 +670,7 @@ func TestHangoutService_UpdateHangout(t *testing.T) {
 				hRepo.On("WithTx", mock.Anything).Return(hRepo).Once()
 				aRepo.On("WithTx", mock.Anything).Return(aRepo).Once()
 
-				existing := &domain.Hangout{ID: hangoutID, UserID: &userID, Title: "Old", Activities: []*domain.Activity{{ID: activityID1}}}
+				existing := &domain.Hangout{ID: hangoutID, UserID: &userID, Version: 1, Title: "Old", Activities: []*domain.Activity{{ID: activityID1}}}
 				hRepo.On("GetHangoutByID", mock.Anything, hangoutID, userID).Return(existing, nil).Once()
 
 				aRepo.On("GetActivitiesByIDs", mock.Anything, []uuid.UUID{activityID1}).Return([]*domain.Activity{{ID: activityID1}}, nil).Once()
@@ -625,7 +702,7 @@ func TestHangoutService_UpdateHangout(t *testing.T) {
 				hRepo.On("WithTx", mock.Anything).Return(hRepo).Once()
 				aRepo.On("WithTx", mock.Anything).Return(aRepo).Once()
 
-				existing := &domain.Hangout{ID: hangoutID, UserID: &userID, Title: "Old", Activities: []*domain.Activity{{ID: activityID1}}}
+				existing := &domain.Hangout{ID: hangoutID, UserID: &userID, Version: 1, Title: "Old", Activities: []*domain.Activity{{ID: activityID1}}}
 				hRepo.On("GetHangoutByID", mock.Anything, hangoutID, userID).Return(existing, nil).Once()
 
 				aRepo.On("GetActivitiesByIDs", mock.Anything, []uuid.UUID{activityID1, activityID2}).Return([]*domain.Activity{{ID: activityID1}, {ID: activityID2}}, nil).Once()
@@ -656,7 +733,7 @@ func TestHangoutService_UpdateHangout(t *testing.T) {
 				hRepo.On("WithTx", mock.Anything).Return(hRepo).Once()
 				aRepo.On("WithTx", mock.Anything).Return(aRepo).Once()
 
-				existing := &domain.Hangout{ID: hangoutID, UserID: &userID, Title: "Old", Activities: []*domain.Activity{{ID: activityID1}}}
+				existing := &domain.Hangout{ID: hangoutID, UserID: &userID, Version: 1, Title: "Old", Activities: []*domain.Activity{{ID: activityID1}}}
 				hRepo.On("GetHangoutByID", mock.Anything, hangoutID, userID).Return(existing, nil).Once()
 
 				aRepo.On("GetActivitiesByIDs", mock.Anything, []uuid.UUID{activityID1, activityID2}).Return([]*domain.Activity{{ID: activityID1}}, nil).Once()
@@ -702,7 +779,7 @@ func TestHangoutService_UpdateHangout(t *testing.T) {
 				hRepo.On("WithTx", mock.Anything).Return(hRepo).Once()
 				aRepo.On("WithTx", mock.Anything).Return(aRepo).Once()
 
-				existing := &domain.Hangout{ID: hangoutID, UserID: &userID, Title: "Old", Activities: []*domain.Activity{{ID: activityID1}, {ID: activityID2}}}
+				existing := &domain.Hangout{ID: hangoutID, UserID: &userID, Version: 1, Title: "Old", Activities: []*domain.Activity{{ID: activityID1}, {ID: activityID2}}}
 				hRepo.On("GetHangoutByID", mock.Anything, hangoutID, userID).Return(existing, nil).Once()
 
 				aRepo.On("GetActivitiesByIDs", mock.Anything, []uuid.UUID{activityID1}).Return([]*domain.Activity{{ID: activityID1}}, nil).Once()
@@ -733,7 +810,7 @@ func TestHangoutService_UpdateHangout(t *testing.T) {
 				hRepo.On("WithTx", mock.Anything).Return(hRepo).Once()
 				aRepo.On("WithTx", mock.Anything).Return(aRepo).Once()
 
-				existing := &domain.Hangout{ID: hangoutID, UserID: &userID, Title: "Old", Activities: []*domain.Activity{{ID: activityID1}}}
+				existing := &domain.Hangout{ID: hangoutID, UserID: &userID, Version: 1, Title: "Old", Activities: []*domain.Activity{{ID: activityID1}}}
 				hRepo.On("GetHangoutByID", mock.Anything, hangoutID, userID).Return(existing, nil).Once()
 
 				aRepo.On("GetActivitiesByIDs", mock.Anything, []uuid.UUID{activityID1}).Return([]*domain.Activity{{ID: activityID1}}, nil).Once()
@@ -759,7 +836,7 @@ func TestHangoutService_UpdateHangout(t *testing.T) {
 				hRepo.On("WithTx", mock.Anything).Return(hRepo).Once()
 				aRepo.On("WithTx", mock.Anything).Return(aRepo).Once()
 
-				existing := &domain.Hangout{ID: hangoutID, UserID: &userID, Title: "Old", Activities: []*domain.Activity{{ID: activityID1}, {ID: activityID2}}}
+				existing := &domain.Hangout{ID: hangoutID, UserID: &userID, Version: 1, Title: "Old", Activities: []*domain.Activity{{ID: activityID1}, {ID: activityID2}}}
 				hRepo.On("GetHangoutByID", mock.Anything, hangoutID, userID).Return(existing, nil).Once()
 
 				aRepo.On("GetActivitiesByIDs", mock.Anything, []uuid.UUID{activityID1}).Return([]*domain.Activity{{ID: activityID1}}, nil).Once()
@@ -786,7 +863,7 @@ func TestHangoutService_UpdateHangout(t *testing.T) {
 				hRepo.On("WithTx", mock.Anything).Return(hRepo).Once()
 				aRepo.On("WithTx", mock.Anything).Return(aRepo).Once()
 
-				existing := &domain.Hangout{ID: hangoutID, UserID: &userID, Title: "Old", Activities: []*domain.Activity{{ID: activityID1}}}
+				existing := &domain.Hangout{ID: hangoutID, UserID: &userID, Version: 1, Title: "Old", Activities: []*domain.Activity{{ID: activityID1}}}
 				hRepo.On("GetHangoutByID", mock.Anything, hangoutID, userID).Return(existing, nil).Once()
 
 				aRepo.On("GetActivitiesByIDs", mock.Anything, []uuid.UUID{activityID1, activityID2}).Return([]*domain.Activity{{ID: activityID1}, {ID: activityID2}}, nil).Once()
@@ -813,7 +890,7 @@ func TestHangoutService_UpdateHangout(t *testing.T) {
 				hRepo.On("WithTx", mock.Anything).Return(hRepo).Once()
 				aRepo.On("WithTx", mock.Anything).Return(aRepo).Once()
 
-				existing := &domain.Hangout{ID: hangoutID, UserID: &userID, Title: "Old", Activities: []*domain.Activity{{ID: activityID1}}}
+				existing := &domain.Hangout{ID: hangoutID, UserID: &userID, Version: 1, Title: "Old", Activities: []*domain.Activity{{ID: activityID1}}}
 				hRepo.On("GetHangoutByID", mock.Anything, hangoutID, userID).Return(existing, nil).Once()
 				aRepo.On("GetActivitiesByIDs", mock.Anything, []uuid.UUID{activityID1}).Return([]*domain.Activity{{ID: activityID1}}, nil).Once()
 				hRepo.On("UpdateHangout", mock.Anything, mock.Anything).Return(existing, nil).Once()
@@ -838,7 +915,7 @@ func TestHangoutService_UpdateHangout(t *testing.T) {
 				hRepo.On("WithTx", mock.Anything).Return(hRepo).Once()
 				aRepo.On("WithTx", mock.Anything).Return(aRepo).Once()
 
-				existing := &domain.Hangout{ID: hangoutID, UserID: &userID, Title: "Old", Activities: []*domain.Activity{{ID: activityID1}}}
+				existing := &domain.Hangout{ID: hangoutID, UserID: &userID, Version: 1, Title: "Old", Activities: []*domain.Activity{{ID: activityID1}}}
 				hRepo.On("GetHangoutByID", mock.Anything, hangoutID, userID).Return(existing, nil).Once()
 
 				aRepo.On("GetActivitiesByIDs", mock.Anything, []uuid.UUID{activityID1}).Return(nil, dbError).Once()
@@ -851,6 +928,28 @@ func TestHangoutService_UpdateHangout(t *testing.T) {
 				require.Nil(t, res)
 			},
 		},
+		{
+			name: "version_mismatch",
+			req: &dto.UpdateHangoutRequest{
+				Title:       "Updated Title",
+				ActivityIDs: []uuid.UUID{activityID1},
+				Date:        date,
+			},
+			setupMock: func(hRepo *MockHangoutRepository, aRepo *MockActivityRepository, sqlMock sqlmock.Sqlmock) {
+				sqlMock.ExpectBegin()
+				hRepo.On("WithTx", mock.Anything).Return(hRepo).Once()
+				aRepo.On("WithTx", mock.Anything).Return(aRepo).Once()
+
+				existing := &domain.Hangout{ID: hangoutID, UserID: &userID, Version: 2, Title: "Old", Activities: []*domain.Activity{{ID: activityID1}}}
+				hRepo.On("GetHangoutByID", mock.Anything, hangoutID, userID).Return(existing, nil).Once()
+
+				sqlMock.ExpectRollback()
+			},
+			check: func(t *testing.T, res *dto.HangoutDetailResponse, err error) {
+				require.ErrorIs(t, err, apperrors.ErrPreconditionFailed)
+				require.Nil(t, res)
+			},
+		},
 		{
 			name: "mapper_fails_on_invalid_date",
 			req: &dto.UpdateHangoutRequest{
@@ -863,7 +962,7 @@ func TestHangoutService_UpdateHangout(t *testing.T) {
 				hRepo.On("WithTx", mock.Anything).Return(hRepo).Once()
 				aRepo.On("WithTx", mock.Anything).Return(aRepo).Once()
 
-				existing := &domain.Hangout{ID: hangoutID, UserID: &userID, Title: "Old", Activities: []*domain.Activity{{ID: activityID1}}}
+				existing := &domain.Hangout{ID: hangoutID, UserID: &userID, Version: 1, Title: "Old", Activities: []*domain.Activity{{ID: activityID1}}}
 				hRepo.On("GetHangoutByID", mock.Anything, hangoutID, userID).Return(existing, nil).Once()
 
 				sqlMock.ExpectRollback()
@@ -880,11 +979,206 @@ func TestHangoutService_UpdateHangout(t *testing.T) {
 			db, sqlMock := setupDB(t)
 			mockHangoutRepo := new(MockHangoutRepository)
 			mockActivityRepo := new(MockActivityRepository)
-			service := services.NewHangoutService(db, mockHangoutRepo, mockActivityRepo, nil)
+			service := services.NewHangoutService(db, mockHangoutRepo, mockActivityRepo, nil, nil)
+
+			tc.setupMock(mockHangoutRepo, mockActivityRepo, sqlMock)
+
+			res, err := service.UpdateHangout(ctx, hangoutID, userID, 1, tc.req)
+			tc.check(t, res, err)
+
+			mockHangoutRepo.AssertExpectations(t)
+			mockActivityRepo.AssertExpectations(t)
+			require.NoError(t, sqlMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestHangoutService_PatchHangout(t *testing.T) {
+	ctx := context.Background()
+	hangoutID := uuid.New()
+	userID := uuid.New()
+	dbError := errors.New("db error")
+
+	activityID1 := uuid.New()
+	activityID2 := uuid.New()
+	description := "Old description"
+
+	newExisting := func() *domain.Hangout {
+		return &domain.Hangout{ID: hangoutID, UserID: &userID, Version: 1, Title: "Old", Description: &description, Activities: []*domain.Activity{{ID: activityID1}}}
+	}
+
+	testCases := []struct {
+		name      string
+		req       *dto.PatchHangoutRequest
+		setupMock func(hRepo *MockHangoutRepository, aRepo *MockActivityRepository, sqlMock sqlmock.Sqlmock)
+		check     func(t *testing.T, res *dto.HangoutDetailResponse, err error)
+	}{
+		{
+			name: "success_description_only_keeps_activities",
+			req: &dto.PatchHangoutRequest{
+				Description: dto.Nullable[string]{Set: true, Value: "New description"},
+			},
+			setupMock: func(hRepo *MockHangoutRepository, aRepo *MockActivityRepository, sqlMock sqlmock.Sqlmock) {
+				sqlMock.ExpectBegin()
+				hRepo.On("WithTx", mock.Anything).Return(hRepo).Once()
+				aRepo.On("WithTx", mock.Anything).Return(aRepo).Once()
+
+				existing := newExisting()
+				hRepo.On("GetHangoutByID", mock.Anything, hangoutID, userID).Return(existing, nil).Once()
+				hRepo.On("UpdateHangout", mock.Anything, mock.MatchedBy(func(h *domain.Hangout) bool {
+					return h.Title == "Old" && h.Description != nil && *h.Description == "New description"
+				})).Return(existing, nil).Once()
+
+				final := &domain.Hangout{ID: hangoutID, UserID: &userID, Version: 2, Title: "Old", Activities: []*domain.Activity{{ID: activityID1}}}
+				hRepo.On("GetHangoutByID", mock.Anything, hangoutID, userID).Return(final, nil).Once()
+
+				sqlMock.ExpectCommit()
+			},
+			check: func(t *testing.T, res *dto.HangoutDetailResponse, err error) {
+				require.NoError(t, err)
+				require.Len(t, res.Activities, 1)
+				require.Equal(t, int64(2), res.Version)
+			},
+		},
+		{
+			name: "success_null_clears_description_and_activities",
+			req: &dto.PatchHangoutRequest{
+				Description: dto.Nullable[string]{Set: true, Null: true},
+				ActivityIDs: dto.Nullable[[]uuid.UUID]{Set: true, Null: true},
+			},
+			setupMock: func(hRepo *MockHangoutRepository, aRepo *MockActivityRepository, sqlMock sqlmock.Sqlmock) {
+				sqlMock.ExpectBegin()
+				hRepo.On("WithTx", mock.Anything).Return(hRepo).Once()
+				aRepo.On("WithTx", mock.Anything).Return(aRepo).Once()
+
+				existing := newExisting()
+				hRepo.On("GetHangoutByID", mock.Anything, hangoutID, userID).Return(existing, nil).Once()
+				hRepo.On("UpdateHangout", mock.Anything, mock.MatchedBy(func(h *domain.Hangout) bool {
+					return h.Description == nil
+				})).Return(existing, nil).Once()
+				hRepo.On("RemoveHangoutActivities", mock.Anything, hangoutID, []uuid.UUID{activityID1}).Return(nil).Once()
+
+				final := &domain.Hangout{ID: hangoutID, UserID: &userID, Version: 2, Title: "Old"}
+				hRepo.On("GetHangoutByID", mock.Anything, hangoutID, userID).Return(final, nil).Once()
+
+				sqlMock.ExpectCommit()
+			},
+			check: func(t *testing.T, res *dto.HangoutDetailResponse, err error) {
+				require.NoError(t, err)
+				require.Nil(t, res.Description)
+				require.Empty(t, res.Activities)
+			},
+		},
+		{
+			name: "success_replaces_activity_set",
+			req: &dto.PatchHangoutRequest{
+				ActivityIDs: dto.Nullable[[]uuid.UUID]{Set: true, Value: []uuid.UUID{activityID2}},
+			},
+			setupMock: func(hRepo *MockHangoutRepository, aRepo *MockActivityRepository, sqlMock sqlmock.Sqlmock) {
+				sqlMock.ExpectBegin()
+				hRepo.On("WithTx", mock.Anything).Return(hRepo).Once()
+				aRepo.On("WithTx", mock.Anything).Return(aRepo).Once()
+
+				existing := newExisting()
+				hRepo.On("GetHangoutByID", mock.Anything, hangoutID, userID).Return(existing, nil).Once()
+				aRepo.On("GetActivitiesByIDs", mock.Anything, []uuid.UUID{activityID2}).Return([]*domain.Activity{{ID: activityID2}}, nil).Once()
+				hRepo.On("UpdateHangout", mock.Anything, mock.Anything).Return(existing, nil).Once()
+				hRepo.On("RemoveHangoutActivities", mock.Anything, hangoutID, []uuid.UUID{activityID1}).Return(nil).Once()
+				hRepo.On("AddHangoutActivities", mock.Anything, hangoutID, []uuid.UUID{activityID2}).Return(nil).Once()
+
+				final := &domain.Hangout{ID: hangoutID, UserID: &userID, Version: 2, Title: "Old", Activities: []*domain.Activity{{ID: activityID2}}}
+				hRepo.On("GetHangoutByID", mock.Anything, hangoutID, userID).Return(final, nil).Once()
+
+				sqlMock.ExpectCommit()
+			},
+			check: func(t *testing.T, res *dto.HangoutDetailResponse, err error) {
+				require.NoError(t, err)
+				require.Len(t, res.Activities, 1)
+				require.Equal(t, activityID2, res.Activities[0].ID)
+			},
+		},
+		{
+			name: "null_title_is_rejected",
+			req: &dto.PatchHangoutRequest{
+				Title: dto.Nullable[string]{Set: true, Null: true},
+			},
+			setupMock: func(hRepo *MockHangoutRepository, aRepo *MockActivityRepository, sqlMock sqlmock.Sqlmock) {
+				sqlMock.ExpectBegin()
+				hRepo.On("WithTx", mock.Anything).Return(hRepo).Once()
+				aRepo.On("WithTx", mock.Anything).Return(aRepo).Once()
+				hRepo.On("GetHangoutByID", mock.Anything, hangoutID, userID).Return(newExisting(), nil).Once()
+				sqlMock.ExpectRollback()
+			},
+			check: func(t *testing.T, res *dto.HangoutDetailResponse, err error) {
+				require.ErrorIs(t, err, apperrors.ErrHangoutFieldRequired)
+				require.Nil(t, res)
+			},
+		},
+		{
+			name: "unknown_activity_is_rejected",
+			req: &dto.PatchHangoutRequest{
+				ActivityIDs: dto.Nullable[[]uuid.UUID]{Set: true, Value: []uuid.UUID{activityID2}},
+			},
+			setupMock: func(hRepo *MockHangoutRepository, aRepo *MockActivityRepository, sqlMock sqlmock.Sqlmock) {
+				sqlMock.ExpectBegin()
+				hRepo.On("WithTx", mock.Anything).Return(hRepo).Once()
+				aRepo.On("WithTx", mock.Anything).Return(aRepo).Once()
+				hRepo.On("GetHangoutByID", mock.Anything, hangoutID, userID).Return(newExisting(), nil).Once()
+				aRepo.On("GetActivitiesByIDs", mock.Anything, []uuid.UUID{activityID2}).Return([]*domain.Activity{}, nil).Once()
+				sqlMock.ExpectRollback()
+			},
+			check: func(t *testing.T, res *dto.HangoutDetailResponse, err error) {
+				require.ErrorIs(t, err, apperrors.ErrInvalidActivityIDs)
+				require.Nil(t, res)
+			},
+		},
+		{
+			name: "version_mismatch",
+			req:  &dto.PatchHangoutRequest{},
+			setupMock: func(hRepo *MockHangoutRepository, aRepo *MockActivityRepository, sqlMock sqlmock.Sqlmock) {
+				sqlMock.ExpectBegin()
+				hRepo.On("WithTx", mock.Anything).Return(hRepo).Once()
+				aRepo.On("WithTx", mock.Anything).Return(aRepo).Once()
+				existing := newExisting()
+				existing.Version = 5
+				hRepo.On("GetHangoutByID", mock.Anything, hangoutID, userID).Return(existing, nil).Once()
+				sqlMock.ExpectRollback()
+			},
+			check: func(t *testing.T, res *dto.HangoutDetailResponse, err error) {
+				require.ErrorIs(t, err, apperrors.ErrPreconditionFailed)
+				require.Nil(t, res)
+			},
+		},
+		{
+			name: "update_fails",
+			req: &dto.PatchHangoutRequest{
+				Title: dto.Nullable[string]{Set: true, Value: "New"},
+			},
+			setupMock: func(hRepo *MockHangoutRepository, aRepo *MockActivityRepository, sqlMock sqlmock.Sqlmock) {
+				sqlMock.ExpectBegin()
+				hRepo.On("WithTx", mock.Anything).Return(hRepo).Once()
+				aRepo.On("WithTx", mock.Anything).Return(aRepo).Once()
+				hRepo.On("GetHangoutByID", mock.Anything, hangoutID, userID).Return(newExisting(), nil).Once()
+				hRepo.On("UpdateHangout", mock.Anything, mock.Anything).Return(nil, dbError).Once()
+				sqlMock.ExpectRollback()
+			},
+			check: func(t *testing.T, res *dto.HangoutDetailResponse, err error) {
+				require.Equal(t, dbError, err)
+				require.Nil(t, res)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, sqlMock := setupDB(t)
+			mockHangoutRepo := new(MockHangoutRepository)
+			mockActivityRepo := new(MockActivityRepository)
+			service := services.NewHangoutService(db, mockHangoutRepo, mockActivityRepo, nil, nil)
 
 			tc.setupMock(mockHangoutRepo, mockActivityRepo, sqlMock)
 
-			res, err := service.UpdateHangout(ctx, hangoutID, userID, tc.req)
+			res, err := service.PatchHangout(ctx, hangoutID, userID, 1, tc.req)
 			tc.check(t, res, err)
 
 			mockHangoutRepo.AssertExpectations(t)
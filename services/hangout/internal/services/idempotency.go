@@ -0,0 +1,169 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/config"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/otel"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/repository"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"gorm.io/gorm"
+)
+
+type IdempotencyService interface {
+	Begin(ctx context.Context, userID uuid.UUID, key string, requestHash string) (*domain.IdempotencyKey, error)
+	Complete(ctx context.Context, id uuid.UUID, status int, headers map[string]string, body []byte) error
+	Release(ctx context.Context, id uuid.UUID) error
+	PurgeExpired(ctx context.Context) (int64, error)
+}
+
+type idempotencyService struct {
+	repo    repository.IdempotencyKeyRepository
+	cfg     *config.IdempotencyConfig
+	metrics *otel.MetricsRecorder
+}
+
+func NewIdempotencyService(repo repository.IdempotencyKeyRepository, cfg *config.IdempotencyConfig, metrics *otel.MetricsRecorder) IdempotencyService {
+	return &idempotencyService{
+		repo:    repo,
+		cfg:     cfg,
+		metrics: metrics,
+	}
+}
+
+// Begin reserves the key for the current request.
+func (s *idempotencyService) Begin(ctx context.Context, userID uuid.UUID, key string, requestHash string) (*domain.IdempotencyKey, error) {
+	recordMetrics := s.metrics.StartRequest(ctx, "idempotency", "begin")
+
+	ctx, span := otel.StartServiceSpan(ctx, "Begin",
+		attribute.String("user.id", userID.String()),
+	)
+	defer span.End()
+
+	// Two attempts: the second one runs after an expired key has been removed.
+	for attempt := 0; attempt < 2; attempt++ {
+		record := &domain.IdempotencyKey{
+			Key:         key,
+			UserID:      userID,
+			RequestHash: requestHash,
+			ExpiresAt:   time.Now().Add(s.cfg.GetKeyTTL()),
+		}
+
+		created, err := s.repo.CreateIfAbsent(ctx, record)
+		if err != nil {
+			recordMetrics("error")
+			_ = span.RecordErrorWithStatus(err)
+			return nil, err
+		}
+		if created {
+			span.SetAttributes(attribute.Bool("idempotency.replayed", false))
+			span.SetStatusOk()
+			recordMetrics("success")
+			return record, nil
+		}
+
+		existing, err := s.repo.GetByKey(ctx, userID, key)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			continue
+		}
+		if err != nil {
+			recordMetrics("error")
+			_ = span.RecordErrorWithStatus(err)
+			return nil, err
+		}
+
+		if existing.ExpiresAt.Before(time.Now()) {
+			if err := s.repo.Delete(ctx, existing.ID); err != nil {
+				recordMetrics("error")
+				_ = span.RecordErrorWithStatus(err)
+				return nil, err
+			}
+			continue
+		}
+
+		if existing.RequestHash != requestHash {
+			recordMetrics("error")
+			_ = span.RecordErrorWithStatus(apperrors.ErrIdempotencyKeyReused)
+			return nil, apperrors.ErrIdempotencyKeyReused
+		}
+
+		if !existing.IsCompleted() {
+			recordMetrics("error")
+			_ = span.RecordErrorWithStatus(apperrors.ErrIdempotencyKeyInProgress)
+			return nil, apperrors.ErrIdempotencyKeyInProgress
+		}
+
+		span.SetAttributes(attribute.Bool("idempotency.replayed", true))
+		span.SetStatusOk()
+		recordMetrics("success")
+		return existing, nil
+	}
+
+	recordMetrics("error")
+	_ = span.RecordErrorWithStatus(apperrors.ErrIdempotencyKeyInProgress)
+	return nil, apperrors.ErrIdempotencyKeyInProgress
+}
+
+func (s *idempotencyService) Complete(ctx context.Context, id uuid.UUID, status int, headers map[string]string, body []byte) error {
+	recordMetrics := s.metrics.StartRequest(ctx, "idempotency", "complete")
+
+	ctx, span := otel.StartServiceSpan(ctx, "Complete",
+		attribute.String("idempotency.id", id.String()),
+		attribute.Int("http.status_code", status),
+	)
+	defer span.End()
+
+	if err := s.repo.SaveResponse(ctx, id, status, headers, body); err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return err
+	}
+
+	span.SetStatusOk()
+	recordMetrics("success")
+	return nil
+}
+
+// Release drops a reservation whose request failed, so the client can retry with the same key.
+func (s *idempotencyService) Release(ctx context.Context, id uuid.UUID) error {
+	recordMetrics := s.metrics.StartRequest(ctx, "idempotency", "release")
+
+	ctx, span := otel.StartServiceSpan(ctx, "Release",
+		attribute.String("idempotency.id", id.String()),
+	)
+	defer span.End()
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return err
+	}
+
+	span.SetStatusOk()
+	recordMetrics("success")
+	return nil
+}
+
+func (s *idempotencyService) PurgeExpired(ctx context.Context) (int64, error) {
+	recordMetrics := s.metrics.StartRequest(ctx, "idempotency", "purge")
+
+	ctx, span := otel.StartServiceSpan(ctx, "PurgeExpired")
+	defer span.End()
+
+	deleted, err := s.repo.DeleteExpired(ctx, time.Now())
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return 0, err
+	}
+
+	span.SetAttributes(attribute.Int64("idempotency.deleted", deleted))
+	span.SetStatusOk()
+	recordMetrics("success")
+	return deleted, nil
+}
@@ -0,0 +1,176 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/config"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/services"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestIdempotencyService_Begin(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	key := "retry-key"
+	hash := "hash"
+	dbError := errors.New("db error")
+	cfg := &config.IdempotencyConfig{KeyTTLHours: 24}
+
+	completed := &domain.IdempotencyKey{ID: uuid.New(), Key: key, UserID: userID, RequestHash: hash, ResponseStatus: 201, ResponseBody: []byte(`{}`), ExpiresAt: time.Now().Add(time.Hour)}
+	inFlight := &domain.IdempotencyKey{ID: uuid.New(), Key: key, UserID: userID, RequestHash: hash, ExpiresAt: time.Now().Add(time.Hour)}
+	expired := &domain.IdempotencyKey{ID: uuid.New(), Key: key, UserID: userID, RequestHash: "old", ResponseStatus: 201, ExpiresAt: time.Now().Add(-time.Hour)}
+
+	tests := []struct {
+		name          string
+		setup         func(*MockIdempotencyKeyRepository)
+		wantCompleted bool
+		wantError     error
+	}{
+		{
+			name: "new key is reserved",
+			setup: func(repo *MockIdempotencyKeyRepository) {
+				repo.On("CreateIfAbsent", mock.Anything, mock.MatchedBy(func(k *domain.IdempotencyKey) bool {
+					return k.Key == key && k.UserID == userID && k.RequestHash == hash && k.ExpiresAt.After(time.Now())
+				})).Return(true, nil)
+			},
+		},
+		{
+			name: "completed key is replayed",
+			setup: func(repo *MockIdempotencyKeyRepository) {
+				repo.On("CreateIfAbsent", mock.Anything, mock.Anything).Return(false, nil)
+				repo.On("GetByKey", mock.Anything, userID, key).Return(completed, nil)
+			},
+			wantCompleted: true,
+		},
+		{
+			name: "key reused with different payload",
+			setup: func(repo *MockIdempotencyKeyRepository) {
+				repo.On("CreateIfAbsent", mock.Anything, mock.Anything).Return(false, nil)
+				repo.On("GetByKey", mock.Anything, userID, key).Return(&domain.IdempotencyKey{RequestHash: "other", ExpiresAt: time.Now().Add(time.Hour)}, nil)
+			},
+			wantError: apperrors.ErrIdempotencyKeyReused,
+		},
+		{
+			name: "key still in progress",
+			setup: func(repo *MockIdempotencyKeyRepository) {
+				repo.On("CreateIfAbsent", mock.Anything, mock.Anything).Return(false, nil)
+				repo.On("GetByKey", mock.Anything, userID, key).Return(inFlight, nil)
+			},
+			wantError: apperrors.ErrIdempotencyKeyInProgress,
+		},
+		{
+			name: "expired key is replaced",
+			setup: func(repo *MockIdempotencyKeyRepository) {
+				repo.On("CreateIfAbsent", mock.Anything, mock.Anything).Return(false, nil).Once()
+				repo.On("GetByKey", mock.Anything, userID, key).Return(expired, nil).Once()
+				repo.On("Delete", mock.Anything, expired.ID).Return(nil)
+				repo.On("CreateIfAbsent", mock.Anything, mock.Anything).Return(true, nil).Once()
+			},
+		},
+		{
+			name: "key removed concurrently is reserved again",
+			setup: func(repo *MockIdempotencyKeyRepository) {
+				repo.On("CreateIfAbsent", mock.Anything, mock.Anything).Return(false, nil).Once()
+				repo.On("GetByKey", mock.Anything, userID, key).Return(nil, gorm.ErrRecordNotFound).Once()
+				repo.On("CreateIfAbsent", mock.Anything, mock.Anything).Return(true, nil).Once()
+			},
+		},
+		{
+			name: "create error",
+			setup: func(repo *MockIdempotencyKeyRepository) {
+				repo.On("CreateIfAbsent", mock.Anything, mock.Anything).Return(false, dbError)
+			},
+			wantError: dbError,
+		},
+		{
+			name: "lookup error",
+			setup: func(repo *MockIdempotencyKeyRepository) {
+				repo.On("CreateIfAbsent", mock.Anything, mock.Anything).Return(false, nil)
+				repo.On("GetByKey", mock.Anything, userID, key).Return(nil, dbError)
+			},
+			wantError: dbError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockIdempotencyKeyRepository)
+			tt.setup(repo)
+			svc := services.NewIdempotencyService(repo, cfg, nil)
+			record, err := svc.Begin(ctx, userID, key, hash)
+			if tt.wantError != nil {
+				require.ErrorIs(t, err, tt.wantError)
+				require.Nil(t, record)
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, record)
+				require.Equal(t, tt.wantCompleted, record.IsCompleted())
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestIdempotencyService_CompleteAndRelease(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.New()
+	headers := map[string]string{"ETag": `"3"`}
+	body := []byte(`{"status":"success"}`)
+	dbError := errors.New("db error")
+
+	t.Run("complete stores response", func(t *testing.T) {
+		repo := new(MockIdempotencyKeyRepository)
+		repo.On("SaveResponse", mock.Anything, id, 201, headers, body).Return(nil)
+		svc := services.NewIdempotencyService(repo, &config.IdempotencyConfig{}, nil)
+		require.NoError(t, svc.Complete(ctx, id, 201, headers, body))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("complete error", func(t *testing.T) {
+		repo := new(MockIdempotencyKeyRepository)
+		repo.On("SaveResponse", mock.Anything, id, 201, headers, body).Return(dbError)
+		svc := services.NewIdempotencyService(repo, &config.IdempotencyConfig{}, nil)
+		require.ErrorIs(t, svc.Complete(ctx, id, 201, headers, body), dbError)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("release deletes reservation", func(t *testing.T) {
+		repo := new(MockIdempotencyKeyRepository)
+		repo.On("Delete", mock.Anything, id).Return(nil)
+		svc := services.NewIdempotencyService(repo, &config.IdempotencyConfig{}, nil)
+		require.NoError(t, svc.Release(ctx, id))
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestIdempotencyService_PurgeExpired(t *testing.T) {
+	ctx := context.Background()
+	dbError := errors.New("db error")
+
+	t.Run("success", func(t *testing.T) {
+		repo := new(MockIdempotencyKeyRepository)
+		repo.On("DeleteExpired", mock.Anything, mock.Anything).Return(int64(4), nil)
+		svc := services.NewIdempotencyService(repo, &config.IdempotencyConfig{}, nil)
+		deleted, err := svc.PurgeExpired(ctx)
+		require.NoError(t, err)
+		require.Equal(t, int64(4), deleted)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		repo := new(MockIdempotencyKeyRepository)
+		repo.On("DeleteExpired", mock.Anything, mock.Anything).Return(int64(0), dbError)
+		svc := services.NewIdempotencyService(repo, &config.IdempotencyConfig{}, nil)
+		_, err := svc.PurgeExpired(ctx)
+		require.ErrorIs(t, err, dbError)
+		repo.AssertExpectations(t)
+	})
+}
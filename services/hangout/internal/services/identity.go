@@ -0,0 +1,360 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/mapper"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/oidc"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/otel"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/repository"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"gorm.io/gorm"
+)
+
+// IdentityService signs users in with external providers and manages the
+// provider accounts linked to a user.
+type IdentityService interface {
+	Providers() []string
+	// Authorize starts a flow with provider.
+	Authorize(ctx context.Context, provider string, userID *uuid.UUID) (*dto.OIDCAuthorizeResponse, error)
+	SignIn(ctx context.Context, request *dto.OIDCCallbackRequest) (*dto.SignInResponse, error)
+	LinkIdentity(ctx context.Context, userID uuid.UUID, request *dto.OIDCCallbackRequest) (*dto.IdentityResponse, error)
+	ListIdentities(ctx context.Context, userID uuid.UUID) ([]*dto.IdentityResponse, error)
+	UnlinkIdentity(ctx context.Context, userID uuid.UUID, provider string) error
+}
+
+type identityService struct {
+	db           *gorm.DB
+	identityRepo repository.UserIdentityRepository
+	userRepo     repository.UserRepository
+	providers    *oidc.Registry
+	states       *oidc.StateCodec
+	auth         AuthService
+	metrics      *otel.MetricsRecorder
+}
+
+func NewIdentityService(db *gorm.DB, identityRepo repository.UserIdentityRepository, userRepo repository.UserRepository, providers *oidc.Registry, states *oidc.StateCodec, auth AuthService, metrics *otel.MetricsRecorder) IdentityService {
+	return &identityService{
+		db:           db,
+		identityRepo: identityRepo,
+		userRepo:     userRepo,
+		providers:    providers,
+		states:       states,
+		auth:         auth,
+		metrics:      metrics,
+	}
+}
+
+func (s *identityService) Providers() []string {
+	return s.providers.Names()
+}
+
+func (s *identityService) Authorize(ctx context.Context, providerName string, userID *uuid.UUID) (*dto.OIDCAuthorizeResponse, error) {
+	recordMetrics := s.metrics.StartRequest(ctx, "identity", "authorize")
+
+	ctx, span := otel.StartServiceSpan(ctx, "AuthorizeIdentity",
+		attribute.String("identity.provider", providerName),
+		attribute.Bool("identity.link", userID != nil),
+	)
+	defer span.End()
+
+	response, err := s.authorize(ctx, providerName, userID)
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetStatusOk()
+	recordMetrics("success")
+	return response, nil
+}
+
+func (s *identityService) authorize(ctx context.Context, providerName string, userID *uuid.UUID) (*dto.OIDCAuthorizeResponse, error) {
+	provider, err := s.providers.Get(providerName)
+	if err != nil {
+		return nil, err
+	}
+
+	state := s.states.New(providerName, userID)
+	encoded, err := s.states.Encode(state)
+	if err != nil {
+		return nil, err
+	}
+	authURL, err := provider.AuthCodeURL(ctx, encoded, state.Verifier, state.Nonce)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.OIDCAuthorizeResponse{AuthorizationURL: authURL, State: encoded, Binding: state.Binding}, nil
+}
+
+// SignIn finishes a sign in flow.
+func (s *identityService) SignIn(ctx context.Context, request *dto.OIDCCallbackRequest) (*dto.SignInResponse, error) {
+	start := time.Now()
+	ctx, span := otel.StartServiceSpan(ctx, "SignInWithIdentity")
+	defer span.End()
+
+	response, err := s.signIn(ctx, request)
+	s.metrics.RecordAuth(ctx, "oidc_signin", getStatus(err), time.Since(start))
+	if err != nil {
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetStatusOk()
+	return response, nil
+}
+
+func (s *identityService) signIn(ctx context.Context, request *dto.OIDCCallbackRequest) (*dto.SignInResponse, error) {
+	state, identity, err := s.exchange(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	if state.UserID != nil {
+		return nil, apperrors.ErrInvalidOIDCState
+	}
+
+	user, err := s.userForIdentity(ctx, state.Provider, identity)
+	if err != nil {
+		return nil, err
+	}
+
+	// the provider stands in for the password, the second factor still applies
+	return s.auth.CompleteSignIn(ctx, user, request.Client)
+}
+
+func (s *identityService) userForIdentity(ctx context.Context, provider string, identity *oidc.Identity) (*domain.User, error) {
+	linked, err := s.identityRepo.GetByProviderSubject(ctx, provider, identity.Subject)
+	switch {
+	case err == nil:
+		user, err := s.userRepo.GetUserByID(ctx, linked.UserID)
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return user, err
+		}
+		// the identity outlived its account, which was deleted before
+		// account deletion removed identities; sign up afresh
+		if _, err := s.identityRepo.DeleteByUserAndProvider(ctx, linked.UserID, provider); err != nil {
+			return nil, err
+		}
+	case !errors.Is(err, gorm.ErrRecordNotFound):
+		return nil, err
+	}
+	if identity.Email == "" {
+		return nil, apperrors.ErrOIDCEmailRequired
+	}
+
+	var user *domain.User
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		txUserRepo := s.userRepo.WithTx(tx)
+
+		existing, err := txUserRepo.GetUserByEmail(ctx, identity.Email)
+		switch {
+		case err == nil:
+			// linking on an unverified email would hand the account to
+			// whoever registered that email first, on either side
+			if !identity.EmailVerified || existing.EmailVerifiedAt == nil {
+				return apperrors.ErrIdentityEmailInUse
+			}
+			user = existing
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			user = &domain.User{Name: identityName(identity), Email: identity.Email}
+			if identity.EmailVerified {
+				verifiedAt := time.Now()
+				user.EmailVerifiedAt = &verifiedAt
+			}
+			if err := txUserRepo.CreateUser(ctx, user); err != nil {
+				return err
+			}
+		default:
+			return err
+		}
+
+		return s.identityRepo.WithTx(tx).Create(ctx, &domain.UserIdentity{
+			UserID:   user.ID,
+			Provider: provider,
+			Subject:  identity.Subject,
+			Email:    identity.Email,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (s *identityService) LinkIdentity(ctx context.Context, userID uuid.UUID, request *dto.OIDCCallbackRequest) (*dto.IdentityResponse, error) {
+	recordMetrics := s.metrics.StartRequest(ctx, "identity", "link")
+
+	ctx, span := otel.StartServiceSpan(ctx, "LinkIdentity",
+		attribute.String("user.id", userID.String()),
+	)
+	defer span.End()
+
+	identity, err := s.linkIdentity(ctx, userID, request)
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.String("identity.provider", identity.Provider))
+	span.SetStatusOk()
+	recordMetrics("success")
+	return mapper.IdentityToResponseDTO(identity), nil
+}
+
+func (s *identityService) linkIdentity(ctx context.Context, userID uuid.UUID, request *dto.OIDCCallbackRequest) (*domain.UserIdentity, error) {
+	state, identity, err := s.exchange(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	// the state must come from a link flow started by this user, otherwise
+	// a link could be slipped into someone else's session
+	if state.UserID == nil || *state.UserID != userID {
+		return nil, apperrors.ErrInvalidOIDCState
+	}
+
+	linked, err := s.identityRepo.GetByProviderSubject(ctx, state.Provider, identity.Subject)
+	if err == nil {
+		if linked.UserID != userID {
+			return nil, apperrors.ErrIdentityAlreadyLinked
+		}
+		return linked, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	identities, err := s.identityRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, existing := range identities {
+		if existing.Provider == state.Provider {
+			return nil, apperrors.ErrIdentityAlreadyLinked
+		}
+	}
+
+	created := &domain.UserIdentity{
+		UserID:   userID,
+		Provider: state.Provider,
+		Subject:  identity.Subject,
+		Email:    identity.Email,
+	}
+	if err := s.identityRepo.Create(ctx, created); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+func (s *identityService) ListIdentities(ctx context.Context, userID uuid.UUID) ([]*dto.IdentityResponse, error) {
+	recordMetrics := s.metrics.StartRequest(ctx, "identity", "list")
+
+	ctx, span := otel.StartServiceSpan(ctx, "ListIdentities",
+		attribute.String("user.id", userID.String()),
+	)
+	defer span.End()
+
+	identities, err := s.identityRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetStatusOk()
+	recordMetrics("success")
+	return mapper.IdentitiesToResponseDTOs(identities), nil
+}
+
+// UnlinkIdentity removes the provider account from the user, unless it is
+// the only way left to sign in.
+func (s *identityService) UnlinkIdentity(ctx context.Context, userID uuid.UUID, provider string) error {
+	recordMetrics := s.metrics.StartRequest(ctx, "identity", "unlink")
+
+	ctx, span := otel.StartServiceSpan(ctx, "UnlinkIdentity",
+		attribute.String("user.id", userID.String()),
+		attribute.String("identity.provider", provider),
+	)
+	defer span.End()
+
+	err := s.unlinkIdentity(ctx, userID, provider)
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return err
+	}
+
+	span.SetStatusOk()
+	recordMetrics("success")
+	return nil
+}
+
+func (s *identityService) unlinkIdentity(ctx context.Context, userID uuid.UUID, provider string) error {
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	identities, err := s.identityRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	found := false
+	for _, identity := range identities {
+		if identity.Provider == provider {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return apperrors.ErrIdentityNotFound
+	}
+	if user.Password == "" && len(identities) == 1 {
+		return apperrors.ErrLastSignInMethod
+	}
+
+	deleted, err := s.identityRepo.DeleteByUserAndProvider(ctx, userID, provider)
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return apperrors.ErrIdentityNotFound
+	}
+	return nil
+}
+
+// exchange opens the state and redeems the code at the provider the state
+// was issued for.
+func (s *identityService) exchange(ctx context.Context, request *dto.OIDCCallbackRequest) (*oidc.State, *oidc.Identity, error) {
+	state, err := s.states.Decode(request.State, request.Binding)
+	if err != nil {
+		return nil, nil, err
+	}
+	provider, err := s.providers.Get(state.Provider)
+	if err != nil {
+		return nil, nil, err
+	}
+	identity, err := provider.Exchange(ctx, request.Code, state.Verifier, state.Nonce)
+	if err != nil {
+		return nil, nil, err
+	}
+	return state, identity, nil
+}
+
+// identityName is the provider's display name, or the local part of the
+// email when the provider has none.
+func identityName(identity *oidc.Identity) string {
+	if name := strings.TrimSpace(identity.Name); name != "" {
+		return name
+	}
+	name, _, _ := strings.Cut(identity.Email, "@")
+	return name
+}
@@ -0,0 +1,608 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/config"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/oidc"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/services"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/signing"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+const testProviderName = "mock"
+
+type identityMocks struct {
+	identityRepo *MockUserIdentityRepository
+	userRepo     *MockUserRepository
+	provider     *MockOIDCProvider
+	sessions     *MockSessionService
+	mfa          *MockMFAService
+	sql          sqlmock.Sqlmock
+	states       *oidc.StateCodec
+}
+
+func newIdentityService(t *testing.T) (services.IdentityService, *identityMocks) {
+	t.Helper()
+	db, sqlMock := setupDB(t)
+	sealer, err := signing.NewSealer(signingTestSecret, constants.OIDCStateSealPurpose)
+	require.NoError(t, err)
+	registry, err := oidc.NewRegistry(&config.OIDCConfig{})
+	require.NoError(t, err)
+
+	m := &identityMocks{
+		identityRepo: new(MockUserIdentityRepository),
+		userRepo:     new(MockUserRepository),
+		provider:     new(MockOIDCProvider),
+		sessions:     new(MockSessionService),
+		mfa:          new(MockMFAService),
+		sql:          sqlMock,
+		states:       oidc.NewStateCodec(sealer, 10*time.Minute),
+	}
+	registry.Register(testProviderName, m.provider)
+	// sign in goes through the real auth service, so the MFA step is covered
+	auth := newTestAuthService(new(MockUserService), m.mfa, m.sessions, new(MockBcryptUtils), new(MockMailSender))
+	svc := services.NewIdentityService(db, m.identityRepo, m.userRepo, registry, m.states, auth, nil)
+	return svc, m
+}
+
+// callback returns a callback request whose state was issued for userID,
+// and expects the provider to exchange its code for identity.
+func (m *identityMocks) callback(t *testing.T, userID *uuid.UUID, identity *oidc.Identity, exchangeErr error) *dto.OIDCCallbackRequest {
+	t.Helper()
+	state := m.states.New(testProviderName, userID)
+	encoded, err := m.states.Encode(state)
+	require.NoError(t, err)
+	m.provider.On("Exchange", mock.Anything, "code", state.Verifier, state.Nonce).Return(identity, exchangeErr).Maybe()
+	return &dto.OIDCCallbackRequest{Code: "code", State: encoded, Binding: state.Binding}
+}
+
+func TestIdentityService_Providers(t *testing.T) {
+	svc, _ := newIdentityService(t)
+	require.Equal(t, []string{testProviderName}, svc.Providers())
+}
+
+func TestIdentityService_Authorize(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	tests := []struct {
+		name     string
+		provider string
+		userID   *uuid.UUID
+		setup    func(m *identityMocks)
+		wantErr  error
+	}{
+		{
+			name:     "SignIn",
+			provider: testProviderName,
+			setup: func(m *identityMocks) {
+				m.provider.On("AuthCodeURL", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return("https://idp.example.com/authorize?x=1", nil)
+			},
+		},
+		{
+			name:     "Link",
+			provider: testProviderName,
+			userID:   &userID,
+			setup: func(m *identityMocks) {
+				m.provider.On("AuthCodeURL", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return("https://idp.example.com/authorize?x=1", nil)
+			},
+		},
+		{
+			name:     "UnknownProvider",
+			provider: "myspace",
+			setup:    func(m *identityMocks) {},
+			wantErr:  apperrors.ErrUnknownOIDCProvider,
+		},
+		{
+			name:     "ProviderUnavailable",
+			provider: testProviderName,
+			setup: func(m *identityMocks) {
+				m.provider.On("AuthCodeURL", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return("", apperrors.ErrOIDCProviderUnavailable)
+			},
+			wantErr: apperrors.ErrOIDCProviderUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, m := newIdentityService(t)
+			tt.setup(m)
+
+			resp, err := svc.Authorize(ctx, tt.provider, tt.userID)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				require.Nil(t, resp)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "https://idp.example.com/authorize?x=1", resp.AuthorizationURL)
+
+			state, err := m.states.Decode(resp.State, resp.Binding)
+			require.NoError(t, err)
+			require.Equal(t, tt.provider, state.Provider)
+			require.Equal(t, tt.userID, state.UserID)
+			m.provider.AssertCalled(t, "AuthCodeURL", mock.Anything, resp.State, state.Verifier, state.Nonce)
+		})
+	}
+}
+
+func TestIdentityService_SignIn(t *testing.T) {
+	ctx := context.Background()
+	verifiedAt := time.Now()
+	existingUser := &domain.User{ID: uuid.New(), Email: "ada@example.com", Password: "hash", EmailVerifiedAt: &verifiedAt}
+	unverifiedUser := &domain.User{ID: uuid.New(), Email: "ada@example.com", Password: "hash"}
+	verified := &oidc.Identity{Subject: "sub-1", Email: "ada@example.com", EmailVerified: true, Name: "Ada Lovelace"}
+	unverified := &oidc.Identity{Subject: "sub-1", Email: "ada@example.com"}
+	dbError := errors.New("db error")
+
+	tests := []struct {
+		name        string
+		identity    *oidc.Identity
+		exchangeErr error
+		linkState   bool
+		badState    bool
+		otherClient bool
+		setup       func(m *identityMocks)
+		checkUser   func(t *testing.T, user *domain.User)
+		wantErr     error
+	}{
+		{
+			name:     "LinkedIdentity",
+			identity: verified,
+			setup: func(m *identityMocks) {
+				m.identityRepo.On("GetByProviderSubject", mock.Anything, testProviderName, "sub-1").Return(&domain.UserIdentity{UserID: existingUser.ID}, nil)
+				m.userRepo.On("GetUserByID", mock.Anything, existingUser.ID).Return(existingUser, nil)
+			},
+			checkUser: func(t *testing.T, user *domain.User) {
+				require.Equal(t, existingUser, user)
+			},
+		},
+		{
+			name:     "IdentityOfDeletedUser",
+			identity: verified,
+			setup: func(m *identityMocks) {
+				deletedID := uuid.New()
+				m.identityRepo.On("GetByProviderSubject", mock.Anything, testProviderName, "sub-1").Return(&domain.UserIdentity{UserID: deletedID}, nil)
+				m.userRepo.On("GetUserByID", mock.Anything, deletedID).Return(nil, gorm.ErrRecordNotFound)
+				m.identityRepo.On("DeleteByUserAndProvider", mock.Anything, deletedID, testProviderName).Return(int64(1), nil)
+				m.sql.ExpectBegin()
+				m.userRepo.On("WithTx", mock.Anything).Return(m.userRepo)
+				m.userRepo.On("GetUserByEmail", mock.Anything, "ada@example.com").Return(nil, gorm.ErrRecordNotFound)
+				m.userRepo.On("CreateUser", mock.Anything, mock.AnythingOfType("*domain.User")).Run(func(args mock.Arguments) {
+					args.Get(1).(*domain.User).ID = uuid.New()
+				}).Return(nil)
+				m.identityRepo.On("WithTx", mock.Anything).Return(m.identityRepo)
+				m.identityRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.UserIdentity")).Return(nil)
+				m.sql.ExpectCommit()
+			},
+			checkUser: func(t *testing.T, user *domain.User) {
+				require.Equal(t, "ada@example.com", user.Email)
+				require.NotEqual(t, uuid.Nil, user.ID)
+			},
+		},
+		{
+			name:     "NewUser",
+			identity: verified,
+			setup: func(m *identityMocks) {
+				m.identityRepo.On("GetByProviderSubject", mock.Anything, testProviderName, "sub-1").Return(nil, gorm.ErrRecordNotFound)
+				m.sql.ExpectBegin()
+				m.userRepo.On("WithTx", mock.Anything).Return(m.userRepo)
+				m.userRepo.On("GetUserByEmail", mock.Anything, "ada@example.com").Return(nil, gorm.ErrRecordNotFound)
+				m.userRepo.On("CreateUser", mock.Anything, mock.AnythingOfType("*domain.User")).Run(func(args mock.Arguments) {
+					args.Get(1).(*domain.User).ID = uuid.New()
+				}).Return(nil)
+				m.identityRepo.On("WithTx", mock.Anything).Return(m.identityRepo)
+				m.identityRepo.On("Create", mock.Anything, mock.MatchedBy(func(identity *domain.UserIdentity) bool {
+					return identity.Provider == testProviderName && identity.Subject == "sub-1" && identity.UserID != uuid.Nil
+				})).Return(nil)
+				m.sql.ExpectCommit()
+			},
+			checkUser: func(t *testing.T, user *domain.User) {
+				require.Equal(t, "Ada Lovelace", user.Name)
+				require.Equal(t, "ada@example.com", user.Email)
+				require.Empty(t, user.Password)
+				require.NotNil(t, user.EmailVerifiedAt)
+			},
+		},
+		{
+			name:     "NewUserUnverifiedEmailWithoutName",
+			identity: unverified,
+			setup: func(m *identityMocks) {
+				m.identityRepo.On("GetByProviderSubject", mock.Anything, testProviderName, "sub-1").Return(nil, gorm.ErrRecordNotFound)
+				m.sql.ExpectBegin()
+				m.userRepo.On("WithTx", mock.Anything).Return(m.userRepo)
+				m.userRepo.On("GetUserByEmail", mock.Anything, "ada@example.com").Return(nil, gorm.ErrRecordNotFound)
+				m.userRepo.On("CreateUser", mock.Anything, mock.AnythingOfType("*domain.User")).Return(nil)
+				m.identityRepo.On("WithTx", mock.Anything).Return(m.identityRepo)
+				m.identityRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.UserIdentity")).Return(nil)
+				m.sql.ExpectCommit()
+			},
+			checkUser: func(t *testing.T, user *domain.User) {
+				require.Equal(t, "ada", user.Name)
+				require.Nil(t, user.EmailVerifiedAt)
+			},
+		},
+		{
+			name:     "LinksVerifiedEmail",
+			identity: verified,
+			setup: func(m *identityMocks) {
+				m.identityRepo.On("GetByProviderSubject", mock.Anything, testProviderName, "sub-1").Return(nil, gorm.ErrRecordNotFound)
+				m.sql.ExpectBegin()
+				m.userRepo.On("WithTx", mock.Anything).Return(m.userRepo)
+				m.userRepo.On("GetUserByEmail", mock.Anything, "ada@example.com").Return(existingUser, nil)
+				m.identityRepo.On("WithTx", mock.Anything).Return(m.identityRepo)
+				m.identityRepo.On("Create", mock.Anything, mock.MatchedBy(func(identity *domain.UserIdentity) bool {
+					return identity.UserID == existingUser.ID
+				})).Return(nil)
+				m.sql.ExpectCommit()
+			},
+			checkUser: func(t *testing.T, user *domain.User) {
+				require.Equal(t, existingUser, user)
+			},
+		},
+		{
+			name:     "UnverifiedProviderEmailInUse",
+			identity: unverified,
+			setup: func(m *identityMocks) {
+				m.identityRepo.On("GetByProviderSubject", mock.Anything, testProviderName, "sub-1").Return(nil, gorm.ErrRecordNotFound)
+				m.sql.ExpectBegin()
+				m.userRepo.On("WithTx", mock.Anything).Return(m.userRepo)
+				m.userRepo.On("GetUserByEmail", mock.Anything, "ada@example.com").Return(existingUser, nil)
+				m.sql.ExpectRollback()
+			},
+			wantErr: apperrors.ErrIdentityEmailInUse,
+		},
+		{
+			name:     "UnverifiedLocalEmailInUse",
+			identity: verified,
+			setup: func(m *identityMocks) {
+				m.identityRepo.On("GetByProviderSubject", mock.Anything, testProviderName, "sub-1").Return(nil, gorm.ErrRecordNotFound)
+				m.sql.ExpectBegin()
+				m.userRepo.On("WithTx", mock.Anything).Return(m.userRepo)
+				m.userRepo.On("GetUserByEmail", mock.Anything, "ada@example.com").Return(unverifiedUser, nil)
+				m.sql.ExpectRollback()
+			},
+			wantErr: apperrors.ErrIdentityEmailInUse,
+		},
+		{
+			name:     "NoEmail",
+			identity: &oidc.Identity{Subject: "sub-1"},
+			setup: func(m *identityMocks) {
+				m.identityRepo.On("GetByProviderSubject", mock.Anything, testProviderName, "sub-1").Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantErr: apperrors.ErrOIDCEmailRequired,
+		},
+		{
+			name:     "CreateIdentityFails",
+			identity: verified,
+			setup: func(m *identityMocks) {
+				m.identityRepo.On("GetByProviderSubject", mock.Anything, testProviderName, "sub-1").Return(nil, gorm.ErrRecordNotFound)
+				m.sql.ExpectBegin()
+				m.userRepo.On("WithTx", mock.Anything).Return(m.userRepo)
+				m.userRepo.On("GetUserByEmail", mock.Anything, "ada@example.com").Return(nil, gorm.ErrRecordNotFound)
+				m.userRepo.On("CreateUser", mock.Anything, mock.AnythingOfType("*domain.User")).Return(nil)
+				m.identityRepo.On("WithTx", mock.Anything).Return(m.identityRepo)
+				m.identityRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.UserIdentity")).Return(dbError)
+				m.sql.ExpectRollback()
+			},
+			wantErr: dbError,
+		},
+		{
+			name:     "LookupFails",
+			identity: verified,
+			setup: func(m *identityMocks) {
+				m.identityRepo.On("GetByProviderSubject", mock.Anything, testProviderName, "sub-1").Return(nil, dbError)
+			},
+			wantErr: dbError,
+		},
+		{
+			name:        "ExchangeFails",
+			exchangeErr: apperrors.ErrOIDCExchangeFailed,
+			setup:       func(m *identityMocks) {},
+			wantErr:     apperrors.ErrOIDCExchangeFailed,
+		},
+		{
+			name:      "LinkState",
+			identity:  verified,
+			linkState: true,
+			setup:     func(m *identityMocks) {},
+			wantErr:   apperrors.ErrInvalidOIDCState,
+		},
+		{
+			name:     "InvalidState",
+			badState: true,
+			setup:    func(m *identityMocks) {},
+			wantErr:  apperrors.ErrInvalidOIDCState,
+		},
+		{
+			// a state from someone else's flow, replayed from this browser
+			name:        "OtherClient",
+			identity:    verified,
+			otherClient: true,
+			setup:       func(m *identityMocks) {},
+			wantErr:     apperrors.ErrInvalidOIDCState,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, m := newIdentityService(t)
+			var stateUser *uuid.UUID
+			if tt.linkState {
+				id := uuid.New()
+				stateUser = &id
+			}
+			req := m.callback(t, stateUser, tt.identity, tt.exchangeErr)
+			if tt.badState {
+				req.State = "garbage"
+			}
+			if tt.otherClient {
+				req.Binding = m.states.New(testProviderName, nil).Binding
+			}
+			tt.setup(m)
+
+			var signedIn *domain.User
+			m.mfa.On("Enabled", mock.Anything, mock.Anything).Return(false, nil).Maybe()
+			m.sessions.On("Start", mock.Anything, mock.AnythingOfType("*domain.User"), mock.Anything).Run(func(args mock.Arguments) {
+				signedIn = args.Get(1).(*domain.User)
+			}).Return("jwt-token", nil).Maybe()
+
+			resp, err := svc.SignIn(ctx, req)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				require.Nil(t, resp)
+				m.sessions.AssertNotCalled(t, "Start", mock.Anything, mock.Anything, mock.Anything)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, "jwt-token", resp.Token)
+				tt.checkUser(t, signedIn)
+			}
+			require.NoError(t, m.sql.ExpectationsWereMet())
+			m.identityRepo.AssertExpectations(t)
+			m.userRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestIdentityService_SignIn_MFARequired(t *testing.T) {
+	ctx := context.Background()
+	user := &domain.User{ID: uuid.New(), Email: "ada@example.com", Password: "hash"}
+	identity := &oidc.Identity{Subject: "sub-1", Email: "ada@example.com", EmailVerified: true}
+
+	svc, m := newIdentityService(t)
+	req := m.callback(t, nil, identity, nil)
+	m.identityRepo.On("GetByProviderSubject", mock.Anything, testProviderName, "sub-1").Return(&domain.UserIdentity{UserID: user.ID}, nil)
+	m.userRepo.On("GetUserByID", mock.Anything, user.ID).Return(user, nil)
+	m.mfa.On("Enabled", mock.Anything, user.ID).Return(true, nil)
+
+	resp, err := svc.SignIn(ctx, req)
+
+	require.NoError(t, err)
+	require.True(t, resp.MFARequired)
+	require.Empty(t, resp.Token)
+	claims, err := testActionTokens.Verify(constants.ActionTokenMFAChallenge, resp.MFAToken)
+	require.NoError(t, err)
+	require.Equal(t, user.ID, claims.UserID)
+	m.sessions.AssertNotCalled(t, "Start", mock.Anything, mock.Anything, mock.Anything)
+	m.mfa.AssertExpectations(t)
+}
+
+func TestIdentityService_LinkIdentity(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	otherID := uuid.New()
+	identity := &oidc.Identity{Subject: "sub-1", Email: "ada@example.com", EmailVerified: true}
+	dbError := errors.New("db error")
+
+	tests := []struct {
+		name       string
+		stateUser  *uuid.UUID
+		setup      func(m *identityMocks)
+		wantErr    error
+		wantCreate bool
+	}{
+		{
+			name:      "Success",
+			stateUser: &userID,
+			setup: func(m *identityMocks) {
+				m.identityRepo.On("GetByProviderSubject", mock.Anything, testProviderName, "sub-1").Return(nil, gorm.ErrRecordNotFound)
+				m.identityRepo.On("ListByUserID", mock.Anything, userID).Return([]domain.UserIdentity{{Provider: "github"}}, nil)
+				m.identityRepo.On("Create", mock.Anything, mock.MatchedBy(func(created *domain.UserIdentity) bool {
+					return created.UserID == userID && created.Provider == testProviderName && created.Subject == "sub-1" && created.Email == "ada@example.com"
+				})).Return(nil)
+			},
+			wantCreate: true,
+		},
+		{
+			name:      "AlreadyLinkedToSameUser",
+			stateUser: &userID,
+			setup: func(m *identityMocks) {
+				m.identityRepo.On("GetByProviderSubject", mock.Anything, testProviderName, "sub-1").Return(&domain.UserIdentity{UserID: userID, Provider: testProviderName}, nil)
+			},
+		},
+		{
+			name:      "LinkedToOtherUser",
+			stateUser: &userID,
+			setup: func(m *identityMocks) {
+				m.identityRepo.On("GetByProviderSubject", mock.Anything, testProviderName, "sub-1").Return(&domain.UserIdentity{UserID: otherID}, nil)
+			},
+			wantErr: apperrors.ErrIdentityAlreadyLinked,
+		},
+		{
+			name:      "OtherAccountOfProviderLinked",
+			stateUser: &userID,
+			setup: func(m *identityMocks) {
+				m.identityRepo.On("GetByProviderSubject", mock.Anything, testProviderName, "sub-1").Return(nil, gorm.ErrRecordNotFound)
+				m.identityRepo.On("ListByUserID", mock.Anything, userID).Return([]domain.UserIdentity{{Provider: testProviderName, Subject: "sub-2"}}, nil)
+			},
+			wantErr: apperrors.ErrIdentityAlreadyLinked,
+		},
+		{
+			name:      "CreateFails",
+			stateUser: &userID,
+			setup: func(m *identityMocks) {
+				m.identityRepo.On("GetByProviderSubject", mock.Anything, testProviderName, "sub-1").Return(nil, gorm.ErrRecordNotFound)
+				m.identityRepo.On("ListByUserID", mock.Anything, userID).Return([]domain.UserIdentity{}, nil)
+				m.identityRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.UserIdentity")).Return(dbError)
+			},
+			wantErr: dbError,
+		},
+		{
+			name:      "StateOfOtherUser",
+			stateUser: &otherID,
+			setup:     func(m *identityMocks) {},
+			wantErr:   apperrors.ErrInvalidOIDCState,
+		},
+		{
+			name:    "SignInState",
+			setup:   func(m *identityMocks) {},
+			wantErr: apperrors.ErrInvalidOIDCState,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, m := newIdentityService(t)
+			req := m.callback(t, tt.stateUser, identity, nil)
+			tt.setup(m)
+
+			resp, err := svc.LinkIdentity(ctx, userID, req)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				require.Nil(t, resp)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, testProviderName, resp.Provider)
+			}
+			if !tt.wantCreate && tt.wantErr != dbError {
+				m.identityRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+			}
+			m.identityRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestIdentityService_ListIdentities(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	t.Run("Success", func(t *testing.T) {
+		svc, m := newIdentityService(t)
+		m.identityRepo.On("ListByUserID", mock.Anything, userID).Return([]domain.UserIdentity{{Provider: "github"}, {Provider: "google"}}, nil)
+
+		resp, err := svc.ListIdentities(ctx, userID)
+
+		require.NoError(t, err)
+		require.Len(t, resp, 2)
+		require.Equal(t, "github", resp[0].Provider)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		svc, m := newIdentityService(t)
+		dbError := errors.New("db error")
+		m.identityRepo.On("ListByUserID", mock.Anything, userID).Return(nil, dbError)
+
+		resp, err := svc.ListIdentities(ctx, userID)
+
+		require.ErrorIs(t, err, dbError)
+		require.Nil(t, resp)
+	})
+}
+
+func TestIdentityService_UnlinkIdentity(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	withPassword := &domain.User{ID: userID, Password: "hash"}
+	withoutPassword := &domain.User{ID: userID}
+	dbError := errors.New("db error")
+
+	tests := []struct {
+		name       string
+		user       *domain.User
+		identities []domain.UserIdentity
+		deleteErr  error
+		deleted    int64
+		wantErr    error
+		wantDelete bool
+	}{
+		{
+			name:       "WithPassword",
+			user:       withPassword,
+			identities: []domain.UserIdentity{{Provider: testProviderName}},
+			deleted:    1,
+			wantDelete: true,
+		},
+		{
+			name:       "WithOtherIdentity",
+			user:       withoutPassword,
+			identities: []domain.UserIdentity{{Provider: "github"}, {Provider: testProviderName}},
+			deleted:    1,
+			wantDelete: true,
+		},
+		{
+			name:       "LastSignInMethod",
+			user:       withoutPassword,
+			identities: []domain.UserIdentity{{Provider: testProviderName}},
+			wantErr:    apperrors.ErrLastSignInMethod,
+		},
+		{
+			name:       "NotLinked",
+			user:       withPassword,
+			identities: []domain.UserIdentity{{Provider: "github"}},
+			wantErr:    apperrors.ErrIdentityNotFound,
+		},
+		{
+			name:       "DeletedConcurrently",
+			user:       withPassword,
+			identities: []domain.UserIdentity{{Provider: testProviderName}},
+			wantErr:    apperrors.ErrIdentityNotFound,
+			wantDelete: true,
+		},
+		{
+			name:       "DeleteFails",
+			user:       withPassword,
+			identities: []domain.UserIdentity{{Provider: testProviderName}},
+			deleteErr:  dbError,
+			wantErr:    dbError,
+			wantDelete: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, m := newIdentityService(t)
+			m.userRepo.On("GetUserByID", mock.Anything, userID).Return(tt.user, nil)
+			m.identityRepo.On("ListByUserID", mock.Anything, userID).Return(tt.identities, nil)
+			if tt.wantDelete {
+				m.identityRepo.On("DeleteByUserAndProvider", mock.Anything, userID, testProviderName).Return(tt.deleted, tt.deleteErr)
+			}
+
+			err := svc.UnlinkIdentity(ctx, userID, testProviderName)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			if !tt.wantDelete {
+				m.identityRepo.AssertNotCalled(t, "DeleteByUserAndProvider", mock.Anything, mock.Anything, mock.Anything)
+			}
+			m.identityRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	filepb "github.com/Ernestgio/Hangout-Planner/pkg/shared/proto/gen/go/file"
@@ -11,7 +12,9 @@ import (
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/grpc"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/mapper"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/ordering"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/otel"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/pubsub"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/repository"
 	"github.com/google/uuid"
 	"go.opentelemetry.io/otel/attribute"
@@ -22,8 +25,14 @@ type MemoryService interface {
 	GenerateUploadURLs(ctx context.Context, userID uuid.UUID, hangoutID uuid.UUID, req *dto.GenerateUploadURLsRequest) (*dto.MemoryUploadResponse, error)
 	ConfirmUpload(ctx context.Context, userID uuid.UUID, req *dto.ConfirmUploadRequest) error
 	GetMemory(ctx context.Context, userID uuid.UUID, memoryID uuid.UUID) (*dto.MemoryResponse, error)
-	ListMemories(ctx context.Context, userID uuid.UUID, hangoutID uuid.UUID, pagination *dto.CursorPagination) (*dto.PaginatedMemories, error)
+	ListMemories(ctx context.Context, userID uuid.UUID, hangoutID uuid.UUID, filter *dto.MemoryFilter, pagination *dto.CursorPagination) (*dto.PaginatedMemories, error)
+	PatchMemory(ctx context.Context, userID uuid.UUID, memoryID uuid.UUID, req *dto.PatchMemoryRequest) (*dto.MemoryResponse, error)
+	AddReaction(ctx context.Context, userID uuid.UUID, memoryID uuid.UUID, emoji string) ([]dto.MemoryReactionResponse, error)
+	RemoveReaction(ctx context.Context, userID uuid.UUID, memoryID uuid.UUID, emoji string) ([]dto.MemoryReactionResponse, error)
 	DeleteMemory(ctx context.Context, userID uuid.UUID, memoryID uuid.UUID) error
+	CreateArchive(ctx context.Context, userID uuid.UUID, hangoutID uuid.UUID) (*dto.ArchiveResponse, error)
+	GetArchive(ctx context.Context, userID uuid.UUID, hangoutID uuid.UUID, archiveID uuid.UUID) (*dto.ArchiveResponse, error)
+	HandleUploadProcessed(ctx context.Context, memoryID uuid.UUID, fileSize int64, mimeType string) error
 }
 
 type memoryService struct {
@@ -32,9 +41,10 @@ type memoryService struct {
 	hangoutRepo repository.HangoutRepository
 	fileService grpc.FileService
 	metrics     *otel.MetricsRecorder
+	events      pubsub.Publisher
 }
 
-func NewMemoryService(db *gorm.DB, memoryRepo repository.MemoryRepository, hangoutRepo repository.HangoutRepository, fileService grpc.FileService, metrics *otel.MetricsRecorder,
+func NewMemoryService(db *gorm.DB, memoryRepo repository.MemoryRepository, hangoutRepo repository.HangoutRepository, fileService grpc.FileService, metrics *otel.MetricsRecorder, events pubsub.Publisher,
 ) MemoryService {
 	return &memoryService{
 		db:          db,
@@ -42,6 +52,7 @@ func NewMemoryService(db *gorm.DB, memoryRepo repository.MemoryRepository, hango
 		hangoutRepo: hangoutRepo,
 		fileService: fileService,
 		metrics:     metrics,
+		events:      events,
 	}
 }
 
@@ -83,7 +94,21 @@ func (s *memoryService) GenerateUploadURLs(ctx context.Context, userID uuid.UUID
 	var uploadURLsResp *filepb.GenerateUploadURLsResponse
 
 	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		if err := s.memoryRepo.WithTx(tx).CreateMemoriesBatch(ctx, memories); err != nil {
+		txRepo := s.memoryRepo.WithTx(tx)
+
+		// New uploads go to the end of the loose memories of the hangout.
+		last, err := txRepo.GetNeighbourPosition(ctx, hangoutID, nil, "", false)
+		if err != nil {
+			return err
+		}
+		for _, memory := range memories {
+			if last, err = ordering.KeyBetween(last, ""); err != nil {
+				return err
+			}
+			memory.Position = last
+		}
+
+		if err := txRepo.CreateMemoriesBatch(ctx, memories); err != nil {
 			return err
 		}
 
@@ -98,7 +123,6 @@ func (s *memoryService) GenerateUploadURLs(ctx context.Context, userID uuid.UUID
 		}
 
 		baseStoragePath := "hangouts/" + hangoutID.String() + "/memories"
-		var err error
 
 		grpcStart := time.Now()
 		uploadURLsResp, err = s.fileService.GenerateUploadURLs(ctx, baseStoragePath, fileIntents)
@@ -119,7 +143,7 @@ func (s *memoryService) GenerateUploadURLs(ctx context.Context, userID uuid.UUID
 			fileIDUpdates[memoryID] = fileID
 		}
 
-		if err := s.memoryRepo.WithTx(tx).UpdateFileIDs(ctx, fileIDUpdates); err != nil {
+		if err := txRepo.UpdateFileIDs(ctx, fileIDUpdates); err != nil {
 			return err
 		}
 
@@ -181,6 +205,9 @@ func (s *memoryService) ConfirmUpload(ctx context.Context, userID uuid.UUID, req
 		recordMetrics("error")
 		_ = span.RecordErrorWithStatus(err)
 	} else {
+		for _, memory := range memories {
+			publishHangoutEvent(ctx, s.events, memory.HangoutID, userID, constants.EventMemoryCreated, mapper.MemoryToCreatedEventDTO(&memory))
+		}
 		span.SetStatusOk()
 		recordMetrics("success")
 	}
@@ -196,23 +223,13 @@ func (s *memoryService) GetMemory(ctx context.Context, userID uuid.UUID, memoryI
 	)
 	defer span.End()
 
-	memory, err := s.memoryRepo.GetMemoryByID(ctx, memoryID, userID)
+	memory, _, err := s.getMemoryForParticipant(ctx, userID, memoryID)
 	if err != nil {
 		recordMetrics("error")
-		if err == gorm.ErrRecordNotFound {
-			return nil, apperrors.ErrMemoryNotFound
-		}
 		return nil, err
 	}
 
-	grpcStart := time.Now()
-	fileWithURL, err := s.fileService.GetFileByMemoryID(ctx, memoryID.String())
-	grpcStatus := "success"
-	if err != nil {
-		grpcStatus = "error"
-	}
-	s.metrics.RecordGRPCCall(ctx, "file", "GetFileByMemoryID", grpcStatus, time.Since(grpcStart))
-
+	resp, err := s.memoryResponse(ctx, userID, memory)
 	if err != nil {
 		recordMetrics("error")
 		_ = span.RecordErrorWithStatus(err)
@@ -221,10 +238,10 @@ func (s *memoryService) GetMemory(ctx context.Context, userID uuid.UUID, memoryI
 
 	span.SetStatusOk()
 	recordMetrics("success")
-	return mapper.MemoryToResponseDTO(memory, fileWithURL.DownloadUrl, fileWithURL.FileSize, fileWithURL.MimeType), nil
+	return resp, nil
 }
 
-func (s *memoryService) ListMemories(ctx context.Context, userID uuid.UUID, hangoutID uuid.UUID, pagination *dto.CursorPagination) (*dto.PaginatedMemories, error) {
+func (s *memoryService) ListMemories(ctx context.Context, userID uuid.UUID, hangoutID uuid.UUID, filter *dto.MemoryFilter, pagination *dto.CursorPagination) (*dto.PaginatedMemories, error) {
 	recordMetrics := s.metrics.StartRequest(ctx, "memory", "list")
 
 	ctx, span := otel.StartServiceSpan(ctx, "ListMemories",
@@ -244,7 +261,11 @@ func (s *memoryService) ListMemories(ctx context.Context, userID uuid.UUID, hang
 		return nil, err
 	}
 
-	memories, err := s.memoryRepo.GetMemoriesByHangoutID(ctx, hangoutID, pagination)
+	if filter != nil {
+		filter.Tag = normalizeMemoryTag(filter.Tag)
+	}
+
+	memories, err := s.memoryRepo.GetMemoriesByHangoutID(ctx, hangoutID, filter, pagination)
 	if err != nil {
 		recordMetrics("error")
 		_ = span.RecordErrorWithStatus(err)
@@ -280,12 +301,14 @@ func (s *memoryService) ListMemories(ctx context.Context, userID uuid.UUID, hang
 	for _, memory := range memories {
 		fileWithURL := filesMap[memory.ID.String()]
 		if fileWithURL != nil {
-			responses = append(responses, *mapper.MemoryToResponseDTO(
+			resp := mapper.MemoryToResponseDTO(
 				&memory,
 				fileWithURL.DownloadUrl,
 				fileWithURL.FileSize,
 				fileWithURL.MimeType,
-			))
+			)
+			resp.Reactions = mapper.MemoryReactionsToResponseDTOs(memory.Reactions, userID)
+			responses = append(responses, *resp)
 		}
 	}
 
@@ -326,18 +349,7 @@ func (s *memoryService) DeleteMemory(ctx context.Context, userID uuid.UUID, memo
 			return err
 		}
 
-		grpcStart := time.Now()
-		deleteErr := s.fileService.DeleteFile(ctx, memoryID.String())
-		grpcStatus := "success"
-		if deleteErr != nil {
-			grpcStatus = "error"
-		}
-		s.metrics.RecordGRPCCall(ctx, "file", "DeleteFile", grpcStatus, time.Since(grpcStart))
-
-		if deleteErr != nil {
-			return deleteErr
-		}
-
+		// The file is kept until the trash purge job removes the memory for good.
 		if err := s.memoryRepo.WithTx(tx).DeleteMemory(ctx, memoryID); err != nil {
 			return err
 		}
@@ -354,3 +366,32 @@ func (s *memoryService) DeleteMemory(ctx context.Context, userID uuid.UUID, memo
 	}
 	return err
 }
+
+// HandleUploadProcessed relays the file service's upload-processed event to the
+// memory's hangout subscribers. Memories deleted in the meantime are skipped.
+func (s *memoryService) HandleUploadProcessed(ctx context.Context, memoryID uuid.UUID, fileSize int64, mimeType string) error {
+	recordMetrics := s.metrics.StartRequest(ctx, "memory", "upload_processed")
+
+	ctx, span := otel.StartServiceSpan(ctx, "HandleUploadProcessed",
+		attribute.String("memory.id", memoryID.String()),
+	)
+	defer span.End()
+
+	memory, err := s.memoryRepo.GetMemoryByIDAnyOwner(ctx, memoryID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			span.SetStatusOk()
+			recordMetrics("success")
+			return nil
+		}
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return err
+	}
+
+	publishHangoutEvent(ctx, s.events, memory.HangoutID, memory.UserID, constants.EventMemoryProcessed, mapper.MemoryToProcessedEventDTO(memory, fileSize, mimeType))
+
+	span.SetStatusOk()
+	recordMetrics("success")
+	return nil
+}
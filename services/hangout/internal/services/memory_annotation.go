@@ -0,0 +1,300 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/mapper"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/otel"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"gorm.io/gorm"
+)
+
+// PatchMemory changes the caption, tags and people of a memory.
+func (s *memoryService) PatchMemory(ctx context.Context, userID uuid.UUID, memoryID uuid.UUID, req *dto.PatchMemoryRequest) (*dto.MemoryResponse, error) {
+	recordMetrics := s.metrics.StartRequest(ctx, "memory", "patch")
+
+	ctx, span := otel.StartServiceSpan(ctx, "PatchMemory",
+		attribute.String("user.id", userID.String()),
+		attribute.String("memory.id", memoryID.String()),
+	)
+	defer span.End()
+
+	memory, hangout, err := s.getMemoryForParticipant(ctx, userID, memoryID)
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	isOwner := hangout.UserID != nil && *hangout.UserID == userID
+	if memory.UserID != userID && !isOwner {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(apperrors.ErrForbidden)
+		return nil, apperrors.ErrForbidden
+	}
+
+	if err := applyMemoryPatch(memory, hangout, req); err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	if err := s.memoryRepo.UpdateMemoryAnnotations(ctx, memory); err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	resp, err := s.memoryResponse(ctx, userID, memory)
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetAttributes(
+		attribute.Int("memory.tag_count", len(memory.Tags)),
+		attribute.Int("memory.person_count", len(memory.PersonTags)),
+	)
+	span.SetStatusOk()
+	recordMetrics("success")
+	return resp, nil
+}
+
+// AddReaction reacts to a memory with an emoji and returns the updated
+// reaction counts. Any participant of the hangout can react.
+func (s *memoryService) AddReaction(ctx context.Context, userID uuid.UUID, memoryID uuid.UUID, emoji string) ([]dto.MemoryReactionResponse, error) {
+	recordMetrics := s.metrics.StartRequest(ctx, "memory", "add_reaction")
+
+	ctx, span := otel.StartServiceSpan(ctx, "AddReaction",
+		attribute.String("user.id", userID.String()),
+		attribute.String("memory.id", memoryID.String()),
+	)
+	defer span.End()
+
+	if !isReactionEmoji(emoji) {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(apperrors.ErrInvalidReaction)
+		return nil, apperrors.ErrInvalidReaction
+	}
+
+	if _, _, err := s.getMemoryForParticipant(ctx, userID, memoryID); err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	reaction := &domain.MemoryReaction{
+		MemoryID:  memoryID,
+		UserID:    userID,
+		Emoji:     emoji,
+		CreatedAt: time.Now(),
+	}
+	if err := s.memoryRepo.AddReaction(ctx, reaction); err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	reactions, err := s.memoryReactions(ctx, userID, memoryID)
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetStatusOk()
+	recordMetrics("success")
+	return reactions, nil
+}
+
+// RemoveReaction takes back the user's reaction with the emoji.
+func (s *memoryService) RemoveReaction(ctx context.Context, userID uuid.UUID, memoryID uuid.UUID, emoji string) ([]dto.MemoryReactionResponse, error) {
+	recordMetrics := s.metrics.StartRequest(ctx, "memory", "remove_reaction")
+
+	ctx, span := otel.StartServiceSpan(ctx, "RemoveReaction",
+		attribute.String("user.id", userID.String()),
+		attribute.String("memory.id", memoryID.String()),
+	)
+	defer span.End()
+
+	if _, _, err := s.getMemoryForParticipant(ctx, userID, memoryID); err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	if err := s.memoryRepo.RemoveReaction(ctx, memoryID, userID, emoji); err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	reactions, err := s.memoryReactions(ctx, userID, memoryID)
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetStatusOk()
+	recordMetrics("success")
+	return reactions, nil
+}
+
+// getMemoryForParticipant loads a memory with its annotations and its hangout.
+func (s *memoryService) getMemoryForParticipant(ctx context.Context, userID uuid.UUID, memoryID uuid.UUID) (*domain.Memory, *domain.Hangout, error) {
+	memory, err := s.memoryRepo.GetMemoryWithAnnotations(ctx, memoryID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, apperrors.ErrMemoryNotFound
+		}
+		return nil, nil, err
+	}
+
+	hangout, err := s.hangoutRepo.GetHangoutByID(ctx, memory.HangoutID, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, apperrors.ErrMemoryNotFound
+		}
+		return nil, nil, err
+	}
+	return memory, hangout, nil
+}
+
+// memoryResponse adds the download URL and file details from the file
+// service to the memory.
+func (s *memoryService) memoryResponse(ctx context.Context, viewerID uuid.UUID, memory *domain.Memory) (*dto.MemoryResponse, error) {
+	grpcStart := time.Now()
+	fileWithURL, err := s.fileService.GetFileByMemoryID(ctx, memory.ID.String())
+	grpcStatus := "success"
+	if err != nil {
+		grpcStatus = "error"
+	}
+	s.metrics.RecordGRPCCall(ctx, "file", "GetFileByMemoryID", grpcStatus, time.Since(grpcStart))
+
+	if err != nil {
+		return nil, err
+	}
+
+	resp := mapper.MemoryToResponseDTO(memory, fileWithURL.DownloadUrl, fileWithURL.FileSize, fileWithURL.MimeType)
+	resp.Reactions = mapper.MemoryReactionsToResponseDTOs(memory.Reactions, viewerID)
+	return resp, nil
+}
+
+func (s *memoryService) memoryReactions(ctx context.Context, viewerID uuid.UUID, memoryID uuid.UUID) ([]dto.MemoryReactionResponse, error) {
+	memory, err := s.memoryRepo.GetMemoryWithAnnotations(ctx, memoryID)
+	if err != nil {
+		return nil, err
+	}
+	return mapper.MemoryReactionsToResponseDTOs(memory.Reactions, viewerID), nil
+}
+
+// applyMemoryPatch validates the patch and applies it to the memory.
+func applyMemoryPatch(memory *domain.Memory, hangout *domain.Hangout, req *dto.PatchMemoryRequest) error {
+	if req.Caption.Set {
+		if req.Caption.Null || req.Caption.Value == "" {
+			memory.Caption = nil
+		} else if utf8.RuneCountInString(req.Caption.Value) > constants.MaxMemoryCaptionLength {
+			return apperrors.ErrInvalidMemoryCaption
+		} else {
+			caption := req.Caption.Value
+			memory.Caption = &caption
+		}
+	}
+
+	if req.Tags.Set {
+		tags, err := normalizeMemoryTags(req.Tags.Value)
+		if err != nil {
+			return err
+		}
+		memory.Tags = make([]domain.MemoryTag, len(tags))
+		for i, tag := range tags {
+			memory.Tags[i] = domain.MemoryTag{MemoryID: memory.ID, Tag: tag}
+		}
+	}
+
+	if req.People.Set {
+		people, err := memoryPersonTags(hangout, memory.ID, req.People.Value)
+		if err != nil {
+			return err
+		}
+		memory.PersonTags = people
+	}
+	return nil
+}
+
+// normalizeMemoryTag lowercases a tag and strips surrounding whitespace and a
+// leading '#', so "#Beach " and "beach" are the same tag.
+func normalizeMemoryTag(tag string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(tag), "#"))
+}
+
+func normalizeMemoryTags(raw []string) ([]string, error) {
+	tags := make([]string, 0, len(raw))
+	for _, t := range raw {
+		tag := normalizeMemoryTag(t)
+		if tag == "" || utf8.RuneCountInString(tag) > constants.MaxMemoryTagLength {
+			return nil, apperrors.ErrInvalidMemoryTags
+		}
+		if !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	if len(tags) > constants.MaxMemoryTags {
+		return nil, apperrors.ErrInvalidMemoryTags
+	}
+	return tags, nil
+}
+
+// memoryPersonTags turns the tagged user IDs into rows, dropping duplicates.
+func memoryPersonTags(hangout *domain.Hangout, memoryID uuid.UUID, userIDs []uuid.UUID) ([]domain.MemoryPersonTag, error) {
+	participants := hangoutParticipants(hangout)
+	people := make([]domain.MemoryPersonTag, 0, len(userIDs))
+	seen := make(map[uuid.UUID]bool, len(userIDs))
+	for _, userID := range userIDs {
+		if !slices.Contains(participants, userID) {
+			return nil, apperrors.ErrInvalidPersonTag
+		}
+		if seen[userID] {
+			continue
+		}
+		seen[userID] = true
+		people = append(people, domain.MemoryPersonTag{MemoryID: memoryID, UserID: userID})
+	}
+	if len(people) > constants.MaxMemoryPersonTags {
+		return nil, apperrors.ErrInvalidPersonTag
+	}
+	return people, nil
+}
+
+// isReactionEmoji accepts a single emoji, including skin tone modifiers,
+// variation selectors, keycaps, flags and ZWJ sequences.
+func isReactionEmoji(emoji string) bool {
+	if emoji == "" || utf8.RuneCountInString(emoji) > constants.MaxReactionEmojiRunes {
+		return false
+	}
+	hasSymbol := false
+	for _, r := range emoji {
+		switch {
+		case unicode.Is(unicode.So, r), unicode.Is(unicode.Sk, r), unicode.Is(unicode.Regional_Indicator, r), r == '\u20e3':
+			hasSymbol = true
+		case r == '\u200d', r == '\ufe0f', r >= 0xe0020 && r <= 0xe007f:
+		case r == '#', r == '*', r >= '0' && r <= '9':
+		default:
+			return false
+		}
+	}
+	return hasSymbol
+}
@@ -0,0 +1,315 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	filepb "github.com/Ernestgio/Hangout-Planner/pkg/shared/proto/gen/go/file"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/services"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestMemoryService_PatchMemory(t *testing.T) {
+	ctx := context.Background()
+	ownerID := uuid.New()
+	uploaderID := uuid.New()
+	strangerID := uuid.New()
+	hangoutID := uuid.New()
+	memoryID := uuid.New()
+	dbError := errors.New("db error")
+
+	tooManyTags := make([]string, 21)
+	for i := range tooManyTags {
+		tooManyTags[i] = "tag" + string(rune('a'+i))
+	}
+
+	tests := []struct {
+		name       string
+		userID     uuid.UUID
+		req        *dto.PatchMemoryRequest
+		setup      func(*MockMemoryRepository, *MockFileService)
+		wantError  error
+		wantMemory func(*testing.T, *domain.Memory)
+	}{
+		{
+			name:   "owner sets caption, tags and people",
+			userID: ownerID,
+			req: &dto.PatchMemoryRequest{
+				Caption: dto.Nullable[string]{Set: true, Value: "Sunset at the pier"},
+				Tags:    dto.Nullable[[]string]{Set: true, Value: []string{"#Beach", " sunset ", "beach"}},
+				People:  dto.Nullable[[]uuid.UUID]{Set: true, Value: []uuid.UUID{ownerID, ownerID}},
+			},
+			setup: func(memRepo *MockMemoryRepository, fileService *MockFileService) {
+				memRepo.On("UpdateMemoryAnnotations", mock.Anything, mock.Anything).Return(nil)
+				fileService.On("GetFileByMemoryID", mock.Anything, memoryID.String()).Return(&filepb.FileWithURL{DownloadUrl: "https://s3/download"}, nil)
+			},
+			wantMemory: func(t *testing.T, memory *domain.Memory) {
+				require.Equal(t, "Sunset at the pier", *memory.Caption)
+				require.Equal(t, []domain.MemoryTag{{MemoryID: memoryID, Tag: "beach"}, {MemoryID: memoryID, Tag: "sunset"}}, memory.Tags)
+				require.Equal(t, []domain.MemoryPersonTag{{MemoryID: memoryID, UserID: ownerID}}, memory.PersonTags)
+			},
+		},
+		{
+			name:   "null caption clears it and keeps tags",
+			userID: uploaderID,
+			req:    &dto.PatchMemoryRequest{Caption: dto.Nullable[string]{Set: true, Null: true}},
+			setup: func(memRepo *MockMemoryRepository, fileService *MockFileService) {
+				memRepo.On("UpdateMemoryAnnotations", mock.Anything, mock.Anything).Return(nil)
+				fileService.On("GetFileByMemoryID", mock.Anything, memoryID.String()).Return(&filepb.FileWithURL{}, nil)
+			},
+			wantMemory: func(t *testing.T, memory *domain.Memory) {
+				require.Nil(t, memory.Caption)
+				require.Equal(t, []domain.MemoryTag{{MemoryID: memoryID, Tag: "old"}}, memory.Tags)
+			},
+		},
+		{
+			name:      "caption too long",
+			userID:    ownerID,
+			req:       &dto.PatchMemoryRequest{Caption: dto.Nullable[string]{Set: true, Value: strings.Repeat("a", 501)}},
+			setup:     func(memRepo *MockMemoryRepository, fileService *MockFileService) {},
+			wantError: apperrors.ErrInvalidMemoryCaption,
+		},
+		{
+			name:      "empty tag",
+			userID:    ownerID,
+			req:       &dto.PatchMemoryRequest{Tags: dto.Nullable[[]string]{Set: true, Value: []string{"#"}}},
+			setup:     func(memRepo *MockMemoryRepository, fileService *MockFileService) {},
+			wantError: apperrors.ErrInvalidMemoryTags,
+		},
+		{
+			name:      "too many tags",
+			userID:    ownerID,
+			req:       &dto.PatchMemoryRequest{Tags: dto.Nullable[[]string]{Set: true, Value: tooManyTags}},
+			setup:     func(memRepo *MockMemoryRepository, fileService *MockFileService) {},
+			wantError: apperrors.ErrInvalidMemoryTags,
+		},
+		{
+			name:      "tagged person is not a participant",
+			userID:    ownerID,
+			req:       &dto.PatchMemoryRequest{People: dto.Nullable[[]uuid.UUID]{Set: true, Value: []uuid.UUID{strangerID}}},
+			setup:     func(memRepo *MockMemoryRepository, fileService *MockFileService) {},
+			wantError: apperrors.ErrInvalidPersonTag,
+		},
+		{
+			name:   "repo error",
+			userID: ownerID,
+			req:    &dto.PatchMemoryRequest{Caption: dto.Nullable[string]{Set: true, Value: "x"}},
+			setup: func(memRepo *MockMemoryRepository, fileService *MockFileService) {
+				memRepo.On("UpdateMemoryAnnotations", mock.Anything, mock.Anything).Return(dbError)
+			},
+			wantError: dbError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, _ := setupDB(t)
+			memRepo := new(MockMemoryRepository)
+			hangoutRepo := new(MockHangoutRepository)
+			fileService := new(MockFileService)
+
+			memory := &domain.Memory{
+				ID:        memoryID,
+				HangoutID: hangoutID,
+				UserID:    uploaderID,
+				Tags:      []domain.MemoryTag{{MemoryID: memoryID, Tag: "old"}},
+			}
+			memRepo.On("GetMemoryWithAnnotations", mock.Anything, memoryID).Return(memory, nil)
+			hangoutRepo.On("GetHangoutByID", mock.Anything, hangoutID, tt.userID).Return(&domain.Hangout{ID: hangoutID, UserID: &ownerID}, nil)
+			tt.setup(memRepo, fileService)
+
+			svc := services.NewMemoryService(db, memRepo, hangoutRepo, fileService, nil, nil)
+			resp, err := svc.PatchMemory(ctx, tt.userID, memoryID, tt.req)
+			if tt.wantError != nil {
+				require.ErrorIs(t, err, tt.wantError)
+				require.Nil(t, resp)
+				if tt.wantError != dbError {
+					memRepo.AssertNotCalled(t, "UpdateMemoryAnnotations", mock.Anything, mock.Anything)
+				}
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, resp)
+				tt.wantMemory(t, memory)
+			}
+			memRepo.AssertExpectations(t)
+			fileService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMemoryService_PatchMemoryForbidden(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	ownerID := uuid.New()
+	hangoutID := uuid.New()
+	memoryID := uuid.New()
+
+	db, _ := setupDB(t)
+	memRepo := new(MockMemoryRepository)
+	hangoutRepo := new(MockHangoutRepository)
+	memRepo.On("GetMemoryWithAnnotations", mock.Anything, memoryID).Return(&domain.Memory{ID: memoryID, HangoutID: hangoutID, UserID: ownerID}, nil)
+	hangoutRepo.On("GetHangoutByID", mock.Anything, hangoutID, userID).Return(&domain.Hangout{ID: hangoutID, UserID: &ownerID}, nil)
+
+	svc := services.NewMemoryService(db, memRepo, hangoutRepo, nil, nil, nil)
+	_, err := svc.PatchMemory(ctx, userID, memoryID, &dto.PatchMemoryRequest{})
+	require.ErrorIs(t, err, apperrors.ErrForbidden)
+}
+
+func TestMemoryService_AddReaction(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	otherID := uuid.New()
+	hangoutID := uuid.New()
+	memoryID := uuid.New()
+	dbError := errors.New("db error")
+	memory := &domain.Memory{ID: memoryID, HangoutID: hangoutID, UserID: userID}
+
+	tests := []struct {
+		name      string
+		emoji     string
+		setup     func(*MockMemoryRepository, *MockHangoutRepository)
+		wantError error
+	}{
+		{
+			name:  "success",
+			emoji: "🔥",
+			setup: func(memRepo *MockMemoryRepository, hangoutRepo *MockHangoutRepository) {
+				hangoutRepo.On("GetHangoutByID", mock.Anything, hangoutID, userID).Return(&domain.Hangout{ID: hangoutID}, nil)
+				memRepo.On("GetMemoryWithAnnotations", mock.Anything, memoryID).Return(memory, nil).Once()
+				memRepo.On("AddReaction", mock.Anything, mock.MatchedBy(func(r *domain.MemoryReaction) bool {
+					return r.MemoryID == memoryID && r.UserID == userID && r.Emoji == "🔥"
+				})).Return(nil)
+				memRepo.On("GetMemoryWithAnnotations", mock.Anything, memoryID).Return(&domain.Memory{ID: memoryID, Reactions: []domain.MemoryReaction{
+					{UserID: userID, Emoji: "🔥"},
+					{UserID: otherID, Emoji: "🔥"},
+				}}, nil).Once()
+			},
+		},
+		{name: "zwj sequence", emoji: "👩‍👩‍👧", setup: successfulReactionSetup(memoryID, hangoutID, userID, memory)},
+		{name: "skin tone", emoji: "👍🏽", setup: successfulReactionSetup(memoryID, hangoutID, userID, memory)},
+		{name: "flag", emoji: "🇮🇩", setup: successfulReactionSetup(memoryID, hangoutID, userID, memory)},
+		{name: "keycap", emoji: "1️⃣", setup: successfulReactionSetup(memoryID, hangoutID, userID, memory)},
+		{name: "plain text", emoji: "lol", setup: func(*MockMemoryRepository, *MockHangoutRepository) {}, wantError: apperrors.ErrInvalidReaction},
+		{name: "digit only", emoji: "1", setup: func(*MockMemoryRepository, *MockHangoutRepository) {}, wantError: apperrors.ErrInvalidReaction},
+		{name: "too long", emoji: "🔥🔥🔥🔥🔥🔥🔥🔥🔥", setup: func(*MockMemoryRepository, *MockHangoutRepository) {}, wantError: apperrors.ErrInvalidReaction},
+		{
+			name:  "not a participant",
+			emoji: "🔥",
+			setup: func(memRepo *MockMemoryRepository, hangoutRepo *MockHangoutRepository) {
+				memRepo.On("GetMemoryWithAnnotations", mock.Anything, memoryID).Return(memory, nil)
+				hangoutRepo.On("GetHangoutByID", mock.Anything, hangoutID, userID).Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantError: apperrors.ErrMemoryNotFound,
+		},
+		{
+			name:  "repo error",
+			emoji: "🔥",
+			setup: func(memRepo *MockMemoryRepository, hangoutRepo *MockHangoutRepository) {
+				memRepo.On("GetMemoryWithAnnotations", mock.Anything, memoryID).Return(memory, nil)
+				hangoutRepo.On("GetHangoutByID", mock.Anything, hangoutID, userID).Return(&domain.Hangout{ID: hangoutID}, nil)
+				memRepo.On("AddReaction", mock.Anything, mock.Anything).Return(dbError)
+			},
+			wantError: dbError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, _ := setupDB(t)
+			memRepo := new(MockMemoryRepository)
+			hangoutRepo := new(MockHangoutRepository)
+			tt.setup(memRepo, hangoutRepo)
+
+			svc := services.NewMemoryService(db, memRepo, hangoutRepo, nil, nil, nil)
+			reactions, err := svc.AddReaction(ctx, userID, memoryID, tt.emoji)
+			if tt.wantError != nil {
+				require.ErrorIs(t, err, tt.wantError)
+				require.Nil(t, reactions)
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, reactions)
+			}
+			if tt.name == "success" {
+				require.Equal(t, []dto.MemoryReactionResponse{{Emoji: "🔥", Count: 2, Reacted: true}}, reactions)
+			}
+			memRepo.AssertExpectations(t)
+			hangoutRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func successfulReactionSetup(memoryID, hangoutID, userID uuid.UUID, memory *domain.Memory) func(*MockMemoryRepository, *MockHangoutRepository) {
+	return func(memRepo *MockMemoryRepository, hangoutRepo *MockHangoutRepository) {
+		memRepo.On("GetMemoryWithAnnotations", mock.Anything, memoryID).Return(memory, nil)
+		hangoutRepo.On("GetHangoutByID", mock.Anything, hangoutID, userID).Return(&domain.Hangout{ID: hangoutID}, nil)
+		memRepo.On("AddReaction", mock.Anything, mock.Anything).Return(nil)
+	}
+}
+
+func TestMemoryService_RemoveReaction(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	hangoutID := uuid.New()
+	memoryID := uuid.New()
+	dbError := errors.New("db error")
+	memory := &domain.Memory{ID: memoryID, HangoutID: hangoutID, UserID: userID}
+
+	tests := []struct {
+		name      string
+		setup     func(*MockMemoryRepository, *MockHangoutRepository)
+		wantError error
+	}{
+		{
+			name: "success",
+			setup: func(memRepo *MockMemoryRepository, hangoutRepo *MockHangoutRepository) {
+				memRepo.On("GetMemoryWithAnnotations", mock.Anything, memoryID).Return(memory, nil)
+				hangoutRepo.On("GetHangoutByID", mock.Anything, hangoutID, userID).Return(&domain.Hangout{ID: hangoutID}, nil)
+				memRepo.On("RemoveReaction", mock.Anything, memoryID, userID, "🔥").Return(nil)
+			},
+		},
+		{
+			name: "memory not found",
+			setup: func(memRepo *MockMemoryRepository, hangoutRepo *MockHangoutRepository) {
+				memRepo.On("GetMemoryWithAnnotations", mock.Anything, memoryID).Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantError: apperrors.ErrMemoryNotFound,
+		},
+		{
+			name: "repo error",
+			setup: func(memRepo *MockMemoryRepository, hangoutRepo *MockHangoutRepository) {
+				memRepo.On("GetMemoryWithAnnotations", mock.Anything, memoryID).Return(memory, nil)
+				hangoutRepo.On("GetHangoutByID", mock.Anything, hangoutID, userID).Return(&domain.Hangout{ID: hangoutID}, nil)
+				memRepo.On("RemoveReaction", mock.Anything, memoryID, userID, "🔥").Return(dbError)
+			},
+			wantError: dbError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, _ := setupDB(t)
+			memRepo := new(MockMemoryRepository)
+			hangoutRepo := new(MockHangoutRepository)
+			tt.setup(memRepo, hangoutRepo)
+
+			svc := services.NewMemoryService(db, memRepo, hangoutRepo, nil, nil, nil)
+			reactions, err := svc.RemoveReaction(ctx, userID, memoryID, "🔥")
+			if tt.wantError != nil {
+				require.ErrorIs(t, err, tt.wantError)
+			} else {
+				require.NoError(t, err)
+				require.Empty(t, reactions)
+			}
+			memRepo.AssertExpectations(t)
+			hangoutRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package services
+
+import (
+	"context"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/mapper"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/otel"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CreateArchive asks the file service to build a ZIP of every memory of the
+// hangout that is not in the trash.
+func (s *memoryService) CreateArchive(ctx context.Context, userID uuid.UUID, hangoutID uuid.UUID) (*dto.ArchiveResponse, error) {
+	recordMetrics := s.metrics.StartRequest(ctx, "memory", "create_archive")
+
+	ctx, span := otel.StartServiceSpan(ctx, "CreateArchive",
+		attribute.String("user.id", userID.String()),
+		attribute.String("hangout.id", hangoutID.String()),
+	)
+	defer span.End()
+
+	hangout, err := s.hangoutRepo.GetHangoutByID(ctx, hangoutID, userID)
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	memoryIDs, err := s.memoryRepo.GetMemoryIDsByHangoutID(ctx, hangoutID)
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+	if len(memoryIDs) == 0 {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(apperrors.ErrNoMemoriesToArchive)
+		return nil, apperrors.ErrNoMemoriesToArchive
+	}
+
+	ids := make([]string, len(memoryIDs))
+	for i, id := range memoryIDs {
+		ids[i] = id.String()
+	}
+
+	archive, err := s.fileService.CreateArchive(ctx, archiveStoragePath(hangoutID), hangout.Title, ids, nil)
+	if err != nil {
+		if status.Code(err) == codes.InvalidArgument {
+			// Every memory is still waiting for its upload to be confirmed.
+			err = apperrors.ErrNoMemoriesToArchive
+		}
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetAttributes(
+		attribute.String("archive.id", archive.Id),
+		attribute.Int("archive.total_files", int(archive.TotalFiles)),
+	)
+	span.SetStatusOk()
+	recordMetrics("success")
+	return mapper.ArchiveToResponseDTO(archive), nil
+}
+
+// GetArchive returns the progress of an archive of the hangout.
+func (s *memoryService) GetArchive(ctx context.Context, userID uuid.UUID, hangoutID uuid.UUID, archiveID uuid.UUID) (*dto.ArchiveResponse, error) {
+	recordMetrics := s.metrics.StartRequest(ctx, "memory", "get_archive")
+
+	ctx, span := otel.StartServiceSpan(ctx, "GetArchive",
+		attribute.String("user.id", userID.String()),
+		attribute.String("hangout.id", hangoutID.String()),
+		attribute.String("archive.id", archiveID.String()),
+	)
+	defer span.End()
+
+	if _, err := s.hangoutRepo.GetHangoutByID(ctx, hangoutID, userID); err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	archive, err := s.fileService.GetArchiveStatus(ctx, archiveID.String(), archiveStoragePath(hangoutID))
+	if err != nil {
+		if code := status.Code(err); code == codes.NotFound || code == codes.InvalidArgument {
+			err = apperrors.ErrArchiveNotFound
+		}
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.String("archive.status", archive.Status.String()))
+	span.SetStatusOk()
+	recordMetrics("success")
+	return mapper.ArchiveToResponseDTO(archive), nil
+}
+
+func archiveStoragePath(hangoutID uuid.UUID) string {
+	return "hangouts/" + hangoutID.String() + "/archives"
+}
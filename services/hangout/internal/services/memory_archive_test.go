@@ -0,0 +1,168 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	filepb "github.com/Ernestgio/Hangout-Planner/pkg/shared/proto/gen/go/file"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/services"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+func TestMemoryService_CreateArchive(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	hangoutID := uuid.New()
+	basePath := "hangouts/" + hangoutID.String() + "/archives"
+	hangout := &domain.Hangout{ID: hangoutID, Title: "Beach Trip"}
+	memA, memB := uuid.New(), uuid.New()
+	archive := &filepb.Archive{Id: uuid.New().String(), Status: filepb.ArchiveStatus_ARCHIVE_STATUS_PENDING, TotalFiles: 2}
+	dbError := errors.New("db error")
+
+	tests := []struct {
+		name      string
+		setup     func(*MockHangoutRepository, *MockMemoryRepository, *MockFileService)
+		wantError error
+	}{
+		{
+			name: "success",
+			setup: func(hangoutRepo *MockHangoutRepository, memRepo *MockMemoryRepository, fileService *MockFileService) {
+				hangoutRepo.On("GetHangoutByID", mock.Anything, hangoutID, userID).Return(hangout, nil)
+				memRepo.On("GetMemoryIDsByHangoutID", mock.Anything, hangoutID).Return([]uuid.UUID{memA, memB}, nil)
+				fileService.On("CreateArchive", mock.Anything, basePath, "Beach Trip", []string{memA.String(), memB.String()}, []*filepb.ArchiveDocument(nil)).Return(archive, nil)
+			},
+		},
+		{
+			name: "hangout not found",
+			setup: func(hangoutRepo *MockHangoutRepository, memRepo *MockMemoryRepository, fileService *MockFileService) {
+				hangoutRepo.On("GetHangoutByID", mock.Anything, hangoutID, userID).Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantError: gorm.ErrRecordNotFound,
+		},
+		{
+			name: "no memories",
+			setup: func(hangoutRepo *MockHangoutRepository, memRepo *MockMemoryRepository, fileService *MockFileService) {
+				hangoutRepo.On("GetHangoutByID", mock.Anything, hangoutID, userID).Return(hangout, nil)
+				memRepo.On("GetMemoryIDsByHangoutID", mock.Anything, hangoutID).Return([]uuid.UUID{}, nil)
+			},
+			wantError: apperrors.ErrNoMemoriesToArchive,
+		},
+		{
+			name: "no uploaded files",
+			setup: func(hangoutRepo *MockHangoutRepository, memRepo *MockMemoryRepository, fileService *MockFileService) {
+				hangoutRepo.On("GetHangoutByID", mock.Anything, hangoutID, userID).Return(hangout, nil)
+				memRepo.On("GetMemoryIDsByHangoutID", mock.Anything, hangoutID).Return([]uuid.UUID{memA}, nil)
+				fileService.On("CreateArchive", mock.Anything, basePath, "Beach Trip", []string{memA.String()}, []*filepb.ArchiveDocument(nil)).Return(nil, status.Error(codes.InvalidArgument, "no uploaded files to archive"))
+			},
+			wantError: apperrors.ErrNoMemoriesToArchive,
+		},
+		{
+			name: "memory lookup error",
+			setup: func(hangoutRepo *MockHangoutRepository, memRepo *MockMemoryRepository, fileService *MockFileService) {
+				hangoutRepo.On("GetHangoutByID", mock.Anything, hangoutID, userID).Return(hangout, nil)
+				memRepo.On("GetMemoryIDsByHangoutID", mock.Anything, hangoutID).Return(nil, dbError)
+			},
+			wantError: dbError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, _ := setupDB(t)
+			hangoutRepo := new(MockHangoutRepository)
+			memRepo := new(MockMemoryRepository)
+			fileService := new(MockFileService)
+			tt.setup(hangoutRepo, memRepo, fileService)
+			svc := services.NewMemoryService(db, memRepo, hangoutRepo, fileService, nil, nil)
+			res, err := svc.CreateArchive(ctx, userID, hangoutID)
+			if tt.wantError != nil {
+				require.ErrorIs(t, err, tt.wantError)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, "pending", res.Status)
+				require.Equal(t, 2, res.TotalFiles)
+			}
+			hangoutRepo.AssertExpectations(t)
+			memRepo.AssertExpectations(t)
+			fileService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMemoryService_GetArchive(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	hangoutID := uuid.New()
+	archiveID := uuid.New()
+	basePath := "hangouts/" + hangoutID.String() + "/archives"
+	hangout := &domain.Hangout{ID: hangoutID}
+	grpcError := status.Error(codes.Unavailable, "unavailable")
+
+	tests := []struct {
+		name      string
+		setup     func(*MockHangoutRepository, *MockFileService)
+		wantError error
+	}{
+		{
+			name: "completed",
+			setup: func(hangoutRepo *MockHangoutRepository, fileService *MockFileService) {
+				hangoutRepo.On("GetHangoutByID", mock.Anything, hangoutID, userID).Return(hangout, nil)
+				fileService.On("GetArchiveStatus", mock.Anything, archiveID.String(), basePath).Return(&filepb.Archive{
+					Id: archiveID.String(), Status: filepb.ArchiveStatus_ARCHIVE_STATUS_COMPLETED, TotalFiles: 1, ProcessedFiles: 1, DownloadUrl: "https://s3/a.zip",
+				}, nil)
+			},
+		},
+		{
+			name: "hangout not found",
+			setup: func(hangoutRepo *MockHangoutRepository, fileService *MockFileService) {
+				hangoutRepo.On("GetHangoutByID", mock.Anything, hangoutID, userID).Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantError: gorm.ErrRecordNotFound,
+		},
+		{
+			name: "archive not found",
+			setup: func(hangoutRepo *MockHangoutRepository, fileService *MockFileService) {
+				hangoutRepo.On("GetHangoutByID", mock.Anything, hangoutID, userID).Return(hangout, nil)
+				fileService.On("GetArchiveStatus", mock.Anything, archiveID.String(), basePath).Return(nil, status.Error(codes.NotFound, "archive has expired"))
+			},
+			wantError: apperrors.ErrArchiveNotFound,
+		},
+		{
+			name: "file service error",
+			setup: func(hangoutRepo *MockHangoutRepository, fileService *MockFileService) {
+				hangoutRepo.On("GetHangoutByID", mock.Anything, hangoutID, userID).Return(hangout, nil)
+				fileService.On("GetArchiveStatus", mock.Anything, archiveID.String(), basePath).Return(nil, grpcError)
+			},
+			wantError: grpcError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, _ := setupDB(t)
+			hangoutRepo := new(MockHangoutRepository)
+			fileService := new(MockFileService)
+			tt.setup(hangoutRepo, fileService)
+			svc := services.NewMemoryService(db, nil, hangoutRepo, fileService, nil, nil)
+			res, err := svc.GetArchive(ctx, userID, hangoutID, archiveID)
+			if tt.wantError != nil {
+				require.ErrorIs(t, err, tt.wantError)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, "completed", res.Status)
+				require.Equal(t, 100, res.Progress)
+				require.Equal(t, "https://s3/a.zip", *res.DownloadURL)
+			}
+			hangoutRepo.AssertExpectations(t)
+			fileService.AssertExpectations(t)
+		})
+	}
+}
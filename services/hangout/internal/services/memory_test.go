@@ -40,7 +40,10 @@ func TestMemoryService_GenerateUploadURLs(t *testing.T) {
 				hangoutRepo.On("GetHangoutByID", mock.Anything, hangoutID, userID).Return(&domain.Hangout{ID: hangoutID}, nil)
 				sqlMock.ExpectBegin()
 				memRepo.On("WithTx", mock.Anything).Return(memRepo)
-				memRepo.On("CreateMemoriesBatch", mock.Anything, mock.Anything).Return(nil)
+				memRepo.On("GetNeighbourPosition", mock.Anything, hangoutID, (*uuid.UUID)(nil), "", false).Return("a5", nil)
+				memRepo.On("CreateMemoriesBatch", mock.Anything, mock.MatchedBy(func(memories []*domain.Memory) bool {
+					return len(memories) == 1 && memories[0].Position == "a6"
+				})).Return(nil)
 				fileService.On("GenerateUploadURLs", mock.Anything, "hangouts/"+hangoutID.String()+"/memories", mock.Anything).Return(&filepb.GenerateUploadURLsResponse{
 					Urls: []*filepb.PresignedUploadURL{
 						{FileId: uuid.New().String(), MemoryId: uuid.New().String(), Filename: "photo.jpg", UploadUrl: "https://s3/upload", ExpiresAt: 123456789},
@@ -83,6 +86,22 @@ func TestMemoryService_GenerateUploadURLs(t *testing.T) {
 			},
 			wantError: dbError,
 		},
+		{
+			name: "last position error",
+			req: &dto.GenerateUploadURLsRequest{
+				Files: []dto.FileUploadIntent{
+					{Filename: "photo.jpg", Size: 1024, MimeType: "image/jpeg"},
+				},
+			},
+			setup: func(memRepo *MockMemoryRepository, hangoutRepo *MockHangoutRepository, fileService *MockFileService, sqlMock sqlmock.Sqlmock) {
+				hangoutRepo.On("GetHangoutByID", mock.Anything, hangoutID, userID).Return(&domain.Hangout{ID: hangoutID}, nil)
+				sqlMock.ExpectBegin()
+				memRepo.On("WithTx", mock.Anything).Return(memRepo)
+				memRepo.On("GetNeighbourPosition", mock.Anything, hangoutID, (*uuid.UUID)(nil), "", false).Return("", dbError)
+				sqlMock.ExpectRollback()
+			},
+			wantError: dbError,
+		},
 		{
 			name: "create memories batch error",
 			req: &dto.GenerateUploadURLsRequest{
@@ -94,6 +113,7 @@ func TestMemoryService_GenerateUploadURLs(t *testing.T) {
 				hangoutRepo.On("GetHangoutByID", mock.Anything, hangoutID, userID).Return(&domain.Hangout{ID: hangoutID}, nil)
 				sqlMock.ExpectBegin()
 				memRepo.On("WithTx", mock.Anything).Return(memRepo)
+				memRepo.On("GetNeighbourPosition", mock.Anything, hangoutID, (*uuid.UUID)(nil), "", false).Return("", nil)
 				memRepo.On("CreateMemoriesBatch", mock.Anything, mock.Anything).Return(dbError)
 				sqlMock.ExpectRollback()
 			},
@@ -110,6 +130,7 @@ func TestMemoryService_GenerateUploadURLs(t *testing.T) {
 				hangoutRepo.On("GetHangoutByID", mock.Anything, hangoutID, userID).Return(&domain.Hangout{ID: hangoutID}, nil)
 				sqlMock.ExpectBegin()
 				memRepo.On("WithTx", mock.Anything).Return(memRepo)
+				memRepo.On("GetNeighbourPosition", mock.Anything, hangoutID, (*uuid.UUID)(nil), "", false).Return("", nil)
 				memRepo.On("CreateMemoriesBatch", mock.Anything, mock.Anything).Return(nil)
 				fileService.On("GenerateUploadURLs", mock.Anything, "hangouts/"+hangoutID.String()+"/memories", mock.Anything).Return(nil, dbError)
 				sqlMock.ExpectRollback()
@@ -127,6 +148,7 @@ func TestMemoryService_GenerateUploadURLs(t *testing.T) {
 				hangoutRepo.On("GetHangoutByID", mock.Anything, hangoutID, userID).Return(&domain.Hangout{ID: hangoutID}, nil)
 				sqlMock.ExpectBegin()
 				memRepo.On("WithTx", mock.Anything).Return(memRepo)
+				memRepo.On("GetNeighbourPosition", mock.Anything, hangoutID, (*uuid.UUID)(nil), "", false).Return("", nil)
 				memRepo.On("CreateMemoriesBatch", mock.Anything, mock.Anything).Return(nil)
 				fileService.On("GenerateUploadURLs", mock.Anything, "hangouts/"+hangoutID.String()+"/memories", mock.Anything).Return(&filepb.GenerateUploadURLsResponse{
 					Urls: []*filepb.PresignedUploadURL{
@@ -147,7 +169,7 @@ func TestMemoryService_GenerateUploadURLs(t *testing.T) {
 			hangoutRepo := new(MockHangoutRepository)
 			fileService := new(MockFileService)
 			tt.setup(memRepo, hangoutRepo, fileService, sqlMock)
-			svc := services.NewMemoryService(db, memRepo, hangoutRepo, fileService, nil)
+			svc := services.NewMemoryService(db, memRepo, hangoutRepo, fileService, nil, nil)
 			resp, err := svc.GenerateUploadURLs(ctx, userID, hangoutID, tt.req)
 			if tt.wantError != nil {
 				require.Error(t, err)
@@ -247,7 +269,7 @@ func TestMemoryService_ConfirmUpload(t *testing.T) {
 			memRepo := new(MockMemoryRepository)
 			fileService := new(MockFileService)
 			tt.setup(memRepo, fileService)
-			svc := services.NewMemoryService(db, memRepo, nil, fileService, nil)
+			svc := services.NewMemoryService(db, memRepo, nil, fileService, nil, nil)
 			err := svc.ConfirmUpload(ctx, userID, tt.req)
 			if tt.wantError != nil {
 				require.Error(t, err)
@@ -265,17 +287,20 @@ func TestMemoryService_GetMemory(t *testing.T) {
 	ctx := context.Background()
 	userID := uuid.New()
 	memoryID := uuid.New()
+	hangoutID := uuid.New()
 	dbError := errors.New("db error")
+	memory := &domain.Memory{ID: memoryID, Name: "photo.jpg", HangoutID: hangoutID, UserID: userID}
 
 	tests := []struct {
 		name      string
-		setup     func(*MockMemoryRepository, *MockFileService)
+		setup     func(*MockMemoryRepository, *MockHangoutRepository, *MockFileService)
 		wantError error
 	}{
 		{
 			name: "success",
-			setup: func(memRepo *MockMemoryRepository, fileService *MockFileService) {
-				memRepo.On("GetMemoryByID", mock.Anything, memoryID, userID).Return(&domain.Memory{ID: memoryID, Name: "photo.jpg"}, nil)
+			setup: func(memRepo *MockMemoryRepository, hangoutRepo *MockHangoutRepository, fileService *MockFileService) {
+				memRepo.On("GetMemoryWithAnnotations", mock.Anything, memoryID).Return(memory, nil)
+				hangoutRepo.On("GetHangoutByID", mock.Anything, hangoutID, userID).Return(&domain.Hangout{ID: hangoutID, UserID: &userID}, nil)
 				fileService.On("GetFileByMemoryID", mock.Anything, memoryID.String()).Return(&filepb.FileWithURL{
 					DownloadUrl: "https://s3/download",
 					FileSize:    1024,
@@ -285,22 +310,31 @@ func TestMemoryService_GetMemory(t *testing.T) {
 		},
 		{
 			name: "memory not found",
-			setup: func(memRepo *MockMemoryRepository, fileService *MockFileService) {
-				memRepo.On("GetMemoryByID", mock.Anything, memoryID, userID).Return(nil, gorm.ErrRecordNotFound)
+			setup: func(memRepo *MockMemoryRepository, hangoutRepo *MockHangoutRepository, fileService *MockFileService) {
+				memRepo.On("GetMemoryWithAnnotations", mock.Anything, memoryID).Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantError: apperrors.ErrMemoryNotFound,
+		},
+		{
+			name: "not a participant of the hangout",
+			setup: func(memRepo *MockMemoryRepository, hangoutRepo *MockHangoutRepository, fileService *MockFileService) {
+				memRepo.On("GetMemoryWithAnnotations", mock.Anything, memoryID).Return(memory, nil)
+				hangoutRepo.On("GetHangoutByID", mock.Anything, hangoutID, userID).Return(nil, gorm.ErrRecordNotFound)
 			},
 			wantError: apperrors.ErrMemoryNotFound,
 		},
 		{
 			name: "memory repo error",
-			setup: func(memRepo *MockMemoryRepository, fileService *MockFileService) {
-				memRepo.On("GetMemoryByID", mock.Anything, memoryID, userID).Return(nil, dbError)
+			setup: func(memRepo *MockMemoryRepository, hangoutRepo *MockHangoutRepository, fileService *MockFileService) {
+				memRepo.On("GetMemoryWithAnnotations", mock.Anything, memoryID).Return(nil, dbError)
 			},
 			wantError: dbError,
 		},
 		{
 			name: "file service error",
-			setup: func(memRepo *MockMemoryRepository, fileService *MockFileService) {
-				memRepo.On("GetMemoryByID", mock.Anything, memoryID, userID).Return(&domain.Memory{ID: memoryID, Name: "photo.jpg"}, nil)
+			setup: func(memRepo *MockMemoryRepository, hangoutRepo *MockHangoutRepository, fileService *MockFileService) {
+				memRepo.On("GetMemoryWithAnnotations", mock.Anything, memoryID).Return(memory, nil)
+				hangoutRepo.On("GetHangoutByID", mock.Anything, hangoutID, userID).Return(&domain.Hangout{ID: hangoutID, UserID: &userID}, nil)
 				fileService.On("GetFileByMemoryID", mock.Anything, memoryID.String()).Return(nil, dbError)
 			},
 			wantError: dbError,
@@ -311,9 +345,10 @@ func TestMemoryService_GetMemory(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			db, _ := setupDB(t)
 			memRepo := new(MockMemoryRepository)
+			hangoutRepo := new(MockHangoutRepository)
 			fileService := new(MockFileService)
-			tt.setup(memRepo, fileService)
-			svc := services.NewMemoryService(db, memRepo, nil, fileService, nil)
+			tt.setup(memRepo, hangoutRepo, fileService)
+			svc := services.NewMemoryService(db, memRepo, hangoutRepo, fileService, nil, nil)
 			resp, err := svc.GetMemory(ctx, userID, memoryID)
 			if tt.wantError != nil {
 				require.Error(t, err)
@@ -324,6 +359,7 @@ func TestMemoryService_GetMemory(t *testing.T) {
 				require.NotNil(t, resp)
 			}
 			memRepo.AssertExpectations(t)
+			hangoutRepo.AssertExpectations(t)
 			fileService.AssertExpectations(t)
 		})
 	}
@@ -339,6 +375,7 @@ func TestMemoryService_ListMemories(t *testing.T) {
 
 	tests := []struct {
 		name       string
+		filter     *dto.MemoryFilter
 		pagination *dto.CursorPagination
 		setup      func(*MockMemoryRepository, *MockHangoutRepository, *MockFileService)
 		wantError  error
@@ -349,7 +386,7 @@ func TestMemoryService_ListMemories(t *testing.T) {
 			pagination: &dto.CursorPagination{Limit: 2},
 			setup: func(memRepo *MockMemoryRepository, hangoutRepo *MockHangoutRepository, fileService *MockFileService) {
 				hangoutRepo.On("GetHangoutByID", mock.Anything, hangoutID, userID).Return(&domain.Hangout{ID: hangoutID}, nil)
-				memRepo.On("GetMemoriesByHangoutID", mock.Anything, hangoutID, mock.Anything).Return([]domain.Memory{
+				memRepo.On("GetMemoriesByHangoutID", mock.Anything, hangoutID, mock.Anything, mock.Anything).Return([]domain.Memory{
 					{ID: memoryID1, Name: "photo1.jpg"},
 					{ID: memoryID2, Name: "photo2.jpg"},
 				}, nil)
@@ -364,7 +401,7 @@ func TestMemoryService_ListMemories(t *testing.T) {
 			pagination: &dto.CursorPagination{Limit: 1},
 			setup: func(memRepo *MockMemoryRepository, hangoutRepo *MockHangoutRepository, fileService *MockFileService) {
 				hangoutRepo.On("GetHangoutByID", mock.Anything, hangoutID, userID).Return(&domain.Hangout{ID: hangoutID}, nil)
-				memRepo.On("GetMemoriesByHangoutID", mock.Anything, hangoutID, mock.Anything).Return([]domain.Memory{
+				memRepo.On("GetMemoriesByHangoutID", mock.Anything, hangoutID, mock.Anything, mock.Anything).Return([]domain.Memory{
 					{ID: memoryID1, Name: "photo1.jpg"},
 					{ID: memoryID2, Name: "photo2.jpg"},
 				}, nil)
@@ -374,6 +411,16 @@ func TestMemoryService_ListMemories(t *testing.T) {
 			},
 			wantMore: true,
 		},
+		{
+			name:       "normalizes tag filter",
+			filter:     &dto.MemoryFilter{Tag: " #Beach", UploaderID: &userID},
+			pagination: &dto.CursorPagination{Limit: 2},
+			setup: func(memRepo *MockMemoryRepository, hangoutRepo *MockHangoutRepository, fileService *MockFileService) {
+				hangoutRepo.On("GetHangoutByID", mock.Anything, hangoutID, userID).Return(&domain.Hangout{ID: hangoutID}, nil)
+				memRepo.On("GetMemoriesByHangoutID", mock.Anything, hangoutID, &dto.MemoryFilter{Tag: "beach", UploaderID: &userID}, mock.Anything).Return([]domain.Memory{}, nil)
+				fileService.On("GetFilesByMemoryIDs", mock.Anything, []string{}).Return(map[string]*filepb.FileWithURL{}, nil)
+			},
+		},
 		{
 			name:       "hangout not found",
 			pagination: &dto.CursorPagination{Limit: 2},
@@ -395,7 +442,7 @@ func TestMemoryService_ListMemories(t *testing.T) {
 			pagination: &dto.CursorPagination{Limit: 2},
 			setup: func(memRepo *MockMemoryRepository, hangoutRepo *MockHangoutRepository, fileService *MockFileService) {
 				hangoutRepo.On("GetHangoutByID", mock.Anything, hangoutID, userID).Return(&domain.Hangout{ID: hangoutID}, nil)
-				memRepo.On("GetMemoriesByHangoutID", mock.Anything, hangoutID, mock.Anything).Return(nil, dbError)
+				memRepo.On("GetMemoriesByHangoutID", mock.Anything, hangoutID, mock.Anything, mock.Anything).Return(nil, dbError)
 			},
 			wantError: dbError,
 		},
@@ -404,7 +451,7 @@ func TestMemoryService_ListMemories(t *testing.T) {
 			pagination: &dto.CursorPagination{Limit: 2},
 			setup: func(memRepo *MockMemoryRepository, hangoutRepo *MockHangoutRepository, fileService *MockFileService) {
 				hangoutRepo.On("GetHangoutByID", mock.Anything, hangoutID, userID).Return(&domain.Hangout{ID: hangoutID}, nil)
-				memRepo.On("GetMemoriesByHangoutID", mock.Anything, hangoutID, mock.Anything).Return([]domain.Memory{
+				memRepo.On("GetMemoriesByHangoutID", mock.Anything, hangoutID, mock.Anything, mock.Anything).Return([]domain.Memory{
 					{ID: memoryID1, Name: "photo1.jpg"},
 				}, nil)
 				fileService.On("GetFilesByMemoryIDs", mock.Anything, []string{memoryID1.String()}).Return(nil, dbError)
@@ -420,8 +467,8 @@ func TestMemoryService_ListMemories(t *testing.T) {
 			hangoutRepo := new(MockHangoutRepository)
 			fileService := new(MockFileService)
 			tt.setup(memRepo, hangoutRepo, fileService)
-			svc := services.NewMemoryService(db, memRepo, hangoutRepo, fileService, nil)
-			resp, err := svc.ListMemories(ctx, userID, hangoutID, tt.pagination)
+			svc := services.NewMemoryService(db, memRepo, hangoutRepo, fileService, nil, nil)
+			resp, err := svc.ListMemories(ctx, userID, hangoutID, tt.filter, tt.pagination)
 			if tt.wantError != nil {
 				require.Error(t, err)
 				require.ErrorIs(t, err, tt.wantError)
@@ -455,7 +502,6 @@ func TestMemoryService_DeleteMemory(t *testing.T) {
 				sqlMock.ExpectBegin()
 				memRepo.On("WithTx", mock.Anything).Return(memRepo)
 				memRepo.On("GetMemoryByID", mock.Anything, memoryID, userID).Return(&domain.Memory{ID: memoryID}, nil)
-				fileService.On("DeleteFile", mock.Anything, memoryID.String()).Return(nil)
 				memRepo.On("DeleteMemory", mock.Anything, memoryID).Return(nil)
 				sqlMock.ExpectCommit()
 			},
@@ -480,24 +526,12 @@ func TestMemoryService_DeleteMemory(t *testing.T) {
 			},
 			wantError: dbError,
 		},
-		{
-			name: "file service error",
-			setup: func(memRepo *MockMemoryRepository, fileService *MockFileService, sqlMock sqlmock.Sqlmock) {
-				sqlMock.ExpectBegin()
-				memRepo.On("WithTx", mock.Anything).Return(memRepo)
-				memRepo.On("GetMemoryByID", mock.Anything, memoryID, userID).Return(&domain.Memory{ID: memoryID}, nil)
-				fileService.On("DeleteFile", mock.Anything, memoryID.String()).Return(dbError)
-				sqlMock.ExpectRollback()
-			},
-			wantError: dbError,
-		},
 		{
 			name: "delete memory error",
 			setup: func(memRepo *MockMemoryRepository, fileService *MockFileService, sqlMock sqlmock.Sqlmock) {
 				sqlMock.ExpectBegin()
 				memRepo.On("WithTx", mock.Anything).Return(memRepo)
 				memRepo.On("GetMemoryByID", mock.Anything, memoryID, userID).Return(&domain.Memory{ID: memoryID}, nil)
-				fileService.On("DeleteFile", mock.Anything, memoryID.String()).Return(nil)
 				memRepo.On("DeleteMemory", mock.Anything, memoryID).Return(dbError)
 				sqlMock.ExpectRollback()
 			},
@@ -511,7 +545,7 @@ func TestMemoryService_DeleteMemory(t *testing.T) {
 			memRepo := new(MockMemoryRepository)
 			fileService := new(MockFileService)
 			tt.setup(memRepo, fileService, sqlMock)
-			svc := services.NewMemoryService(db, memRepo, nil, fileService, nil)
+			svc := services.NewMemoryService(db, memRepo, nil, fileService, nil, nil)
 			err := svc.DeleteMemory(ctx, userID, memoryID)
 			if tt.wantError != nil {
 				require.Error(t, err)
@@ -0,0 +1,376 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/config"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants/logmsg"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/otel"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/ratelimit"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/repository"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/signing"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/utils"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"gorm.io/gorm"
+)
+
+// MFAService manages TOTP two-factor authentication and recovery codes.
+type MFAService interface {
+	Status(ctx context.Context, userID uuid.UUID) (*dto.MFAStatusResponse, error)
+	// EnrollTOTP starts an enrollment with a new secret.
+	EnrollTOTP(ctx context.Context, userID uuid.UUID) (*dto.TOTPEnrollmentResponse, error)
+	ConfirmTOTP(ctx context.Context, userID uuid.UUID, code string) (*dto.RecoveryCodesResponse, error)
+	DisableTOTP(ctx context.Context, userID uuid.UUID, code string) error
+	RegenerateRecoveryCodes(ctx context.Context, userID uuid.UUID, code string) (*dto.RecoveryCodesResponse, error)
+	Enabled(ctx context.Context, userID uuid.UUID) (bool, error)
+	// VerifyCode accepts a TOTP code or an unused recovery code.
+	VerifyCode(ctx context.Context, userID uuid.UUID, code string) error
+}
+
+type mfaService struct {
+	db       *gorm.DB
+	mfaRepo  repository.MFARepository
+	userRepo repository.UserRepository
+	sealer   *signing.Sealer
+	guard    *ratelimit.Guard
+	cfg      *config.MFAConfig
+	metrics  *otel.MetricsRecorder
+}
+
+func NewMFAService(db *gorm.DB, mfaRepo repository.MFARepository, userRepo repository.UserRepository, sealer *signing.Sealer, guard *ratelimit.Guard, cfg *config.MFAConfig, metrics *otel.MetricsRecorder) MFAService {
+	return &mfaService{
+		db:       db,
+		mfaRepo:  mfaRepo,
+		userRepo: userRepo,
+		sealer:   sealer,
+		guard:    guard,
+		cfg:      cfg,
+		metrics:  metrics,
+	}
+}
+
+func (s *mfaService) Status(ctx context.Context, userID uuid.UUID) (*dto.MFAStatusResponse, error) {
+	recordMetrics := s.metrics.StartRequest(ctx, "mfa", "status")
+
+	ctx, span := otel.StartServiceSpan(ctx, "GetMFAStatus",
+		attribute.String("user.id", userID.String()),
+	)
+	defer span.End()
+
+	enabled, err := s.Enabled(ctx, userID)
+	var remaining int64
+	if err == nil && enabled {
+		remaining, err = s.mfaRepo.CountUnusedRecoveryCodes(ctx, userID)
+	}
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetStatusOk()
+	recordMetrics("success")
+	return &dto.MFAStatusResponse{TOTPEnabled: enabled, RecoveryCodesRemaining: remaining}, nil
+}
+
+func (s *mfaService) EnrollTOTP(ctx context.Context, userID uuid.UUID) (*dto.TOTPEnrollmentResponse, error) {
+	recordMetrics := s.metrics.StartRequest(ctx, "mfa", "enroll_totp")
+
+	ctx, span := otel.StartServiceSpan(ctx, "EnrollTOTP",
+		attribute.String("user.id", userID.String()),
+	)
+	defer span.End()
+
+	response, err := s.enrollTOTP(ctx, userID)
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetStatusOk()
+	recordMetrics("success")
+	return response, nil
+}
+
+func (s *mfaService) enrollTOTP(ctx context.Context, userID uuid.UUID) (*dto.TOTPEnrollmentResponse, error) {
+	credential, err := s.mfaRepo.GetTOTP(ctx, userID)
+	switch {
+	case err == nil && credential.ConfirmedAt != nil:
+		return nil, apperrors.ErrMFAAlreadyEnabled
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		// an unfinished enrollment is replaced below, a new one starts here
+		credential = &domain.TOTPCredential{UserID: userID}
+	case err != nil:
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+	sealed, err := s.sealer.Seal([]byte(secret))
+	if err != nil {
+		return nil, err
+	}
+	credential.Secret = sealed
+	credential.LastUsedStep = 0
+	if err := s.mfaRepo.SaveTOTP(ctx, credential); err != nil {
+		return nil, err
+	}
+
+	return &dto.TOTPEnrollmentResponse{
+		Secret:          secret,
+		ProvisioningURI: utils.TOTPProvisioningURI(s.cfg.TOTPIssuer, user.Email, secret),
+	}, nil
+}
+
+func (s *mfaService) ConfirmTOTP(ctx context.Context, userID uuid.UUID, code string) (*dto.RecoveryCodesResponse, error) {
+	recordMetrics := s.metrics.StartRequest(ctx, "mfa", "confirm_totp")
+
+	ctx, span := otel.StartServiceSpan(ctx, "ConfirmTOTP",
+		attribute.String("user.id", userID.String()),
+	)
+	defer span.End()
+
+	response, err := s.confirmTOTP(ctx, userID, code)
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetStatusOk()
+	recordMetrics("success")
+	return response, nil
+}
+
+func (s *mfaService) confirmTOTP(ctx context.Context, userID uuid.UUID, code string) (*dto.RecoveryCodesResponse, error) {
+	credential, err := s.mfaRepo.GetTOTP(ctx, userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, apperrors.ErrMFAEnrollmentNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if credential.ConfirmedAt != nil {
+		return nil, apperrors.ErrMFAAlreadyEnabled
+	}
+
+	secret, err := s.sealer.Open(credential.Secret)
+	if err != nil {
+		return nil, err
+	}
+	step, ok := utils.ValidateTOTP(string(secret), code, time.Now(), credential.LastUsedStep)
+	if !ok {
+		return nil, apperrors.ErrInvalidMFACode
+	}
+
+	var codes []string
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		txRepo := s.mfaRepo.WithTx(tx)
+
+		confirmed, err := txRepo.ConfirmTOTP(ctx, credential.ID, step, time.Now())
+		if err != nil {
+			return err
+		}
+		// a concurrent request confirmed or replaced the enrollment first
+		if confirmed == 0 {
+			return apperrors.ErrMFAEnrollmentNotFound
+		}
+
+		codes, err = s.replaceRecoveryCodes(ctx, txRepo, userID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &dto.RecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+func (s *mfaService) DisableTOTP(ctx context.Context, userID uuid.UUID, code string) error {
+	recordMetrics := s.metrics.StartRequest(ctx, "mfa", "disable_totp")
+
+	ctx, span := otel.StartServiceSpan(ctx, "DisableTOTP",
+		attribute.String("user.id", userID.String()),
+	)
+	defer span.End()
+
+	err := s.VerifyCode(ctx, userID, code)
+	if err == nil {
+		err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			txRepo := s.mfaRepo.WithTx(tx)
+			if err := txRepo.DeleteRecoveryCodes(ctx, userID); err != nil {
+				return err
+			}
+			return txRepo.DeleteTOTP(ctx, userID)
+		})
+	}
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return err
+	}
+
+	span.SetStatusOk()
+	recordMetrics("success")
+	return nil
+}
+
+func (s *mfaService) RegenerateRecoveryCodes(ctx context.Context, userID uuid.UUID, code string) (*dto.RecoveryCodesResponse, error) {
+	recordMetrics := s.metrics.StartRequest(ctx, "mfa", "regenerate_recovery_codes")
+
+	ctx, span := otel.StartServiceSpan(ctx, "RegenerateRecoveryCodes",
+		attribute.String("user.id", userID.String()),
+	)
+	defer span.End()
+
+	var codes []string
+	err := s.VerifyCode(ctx, userID, code)
+	if err == nil {
+		err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			var err error
+			codes, err = s.replaceRecoveryCodes(ctx, s.mfaRepo.WithTx(tx), userID)
+			return err
+		})
+	}
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetStatusOk()
+	recordMetrics("success")
+	return &dto.RecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+func (s *mfaService) Enabled(ctx context.Context, userID uuid.UUID) (bool, error) {
+	credential, err := s.mfaRepo.GetTOTP(ctx, userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return credential.ConfirmedAt != nil, nil
+}
+
+func (s *mfaService) VerifyCode(ctx context.Context, userID uuid.UUID, code string) error {
+	start := time.Now()
+	account := constants.MFALockoutKeyPrefix + userID.String()
+
+	decision, err := s.guard.CheckLockout(ctx, account)
+	if err != nil {
+		log.Printf(logmsg.RateLimitCheckFailed, err)
+	} else if !decision.Allowed {
+		s.metrics.RecordAuth(ctx, "mfa_verify", "error", time.Since(start))
+		return apperrors.ErrAccountLocked
+	}
+
+	err = s.verifyCode(ctx, userID, code)
+	switch {
+	case errors.Is(err, apperrors.ErrInvalidMFACode):
+		lockout, recordErr := s.guard.RecordFailure(ctx, account)
+		if recordErr != nil {
+			log.Printf(logmsg.SignInAttemptRecordFailed, recordErr)
+		} else if lockout > 0 {
+			log.Printf(logmsg.AccountLockedOut, lockout)
+		}
+	case err == nil:
+		if recordErr := s.guard.RecordSuccess(ctx, account); recordErr != nil {
+			log.Printf(logmsg.SignInAttemptRecordFailed, recordErr)
+		}
+	}
+
+	s.metrics.RecordAuth(ctx, "mfa_verify", getStatus(err), time.Since(start))
+	return err
+}
+
+// verifyCode treats a code of TOTPDigits digits as a TOTP code and anything
+// else as a recovery code.
+func (s *mfaService) verifyCode(ctx context.Context, userID uuid.UUID, code string) error {
+	credential, err := s.mfaRepo.GetTOTP(ctx, userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && credential.ConfirmedAt == nil) {
+		return apperrors.ErrMFANotEnabled
+	}
+	if err != nil {
+		return err
+	}
+
+	code = strings.TrimSpace(code)
+	if isTOTPCode(code) {
+		secret, err := s.sealer.Open(credential.Secret)
+		if err != nil {
+			return err
+		}
+		step, ok := utils.ValidateTOTP(string(secret), code, time.Now(), credential.LastUsedStep)
+		if !ok {
+			return apperrors.ErrInvalidMFACode
+		}
+		used, err := s.mfaRepo.UseTOTPStep(ctx, credential.ID, step)
+		if err != nil {
+			return err
+		}
+		// the same code was accepted by a concurrent request
+		if used == 0 {
+			return apperrors.ErrInvalidMFACode
+		}
+		return nil
+	}
+
+	used, err := s.mfaRepo.UseRecoveryCode(ctx, userID, utils.HashToken(utils.NormalizeRecoveryCode(code)), time.Now())
+	if err != nil {
+		return err
+	}
+	if used == 0 {
+		return apperrors.ErrInvalidMFACode
+	}
+	return nil
+}
+
+// replaceRecoveryCodes stores new recovery codes for the user and returns
+// them in plain text.
+func (s *mfaService) replaceRecoveryCodes(ctx context.Context, mfaRepo repository.MFARepository, userID uuid.UUID) ([]string, error) {
+	codes := make([]string, s.cfg.RecoveryCodeCount)
+	records := make([]domain.RecoveryCode, s.cfg.RecoveryCodeCount)
+	for i := range codes {
+		code, err := utils.GenerateRecoveryCode()
+		if err != nil {
+			return nil, err
+		}
+		codes[i] = code
+		records[i] = domain.RecoveryCode{UserID: userID, CodeHash: utils.HashToken(utils.NormalizeRecoveryCode(code))}
+	}
+
+	if err := mfaRepo.ReplaceRecoveryCodes(ctx, userID, records); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+func isTOTPCode(code string) bool {
+	if len(code) != constants.TOTPDigits {
+		return false
+	}
+	for _, r := range code {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
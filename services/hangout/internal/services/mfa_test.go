@@ -0,0 +1,530 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/config"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/ratelimit"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/services"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/signing"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/utils"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+const testTOTPSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+type mfaMocks struct {
+	mfaRepo  *MockMFARepository
+	userRepo *MockUserRepository
+	sql      sqlmock.Sqlmock
+	sealer   *signing.Sealer
+}
+
+func newMFAService(t *testing.T) (services.MFAService, *mfaMocks) {
+	t.Helper()
+	db, sqlMock := setupDB(t)
+	sealer, err := signing.NewSealer(signingTestSecret, constants.TOTPSecretSealPurpose)
+	require.NoError(t, err)
+	guard := ratelimit.NewGuard(ratelimit.NewMemoryStore(time.Now), &config.AuthRateLimitConfig{
+		MaxFailedAttempts:    3,
+		FailureWindowMinutes: 15,
+		LockoutBaseSeconds:   60,
+		LockoutMaxMinutes:    60,
+	})
+
+	m := &mfaMocks{
+		mfaRepo:  new(MockMFARepository),
+		userRepo: new(MockUserRepository),
+		sql:      sqlMock,
+		sealer:   sealer,
+	}
+	m.mfaRepo.On("WithTx", mock.Anything).Return(m.mfaRepo).Maybe()
+	svc := services.NewMFAService(db, m.mfaRepo, m.userRepo, sealer, guard, testMFAConfig, nil)
+	return svc, m
+}
+
+// credential returns a TOTP credential for testTOTPSecret.
+func (m *mfaMocks) credential(t *testing.T, userID uuid.UUID, confirmed bool) *domain.TOTPCredential {
+	t.Helper()
+	sealed, err := m.sealer.Seal([]byte(testTOTPSecret))
+	require.NoError(t, err)
+	credential := &domain.TOTPCredential{ID: uuid.New(), UserID: userID, Secret: sealed}
+	if confirmed {
+		confirmedAt := time.Now()
+		credential.ConfirmedAt = &confirmedAt
+	}
+	return credential
+}
+
+func currentCode(t *testing.T) string {
+	t.Helper()
+	code, err := utils.TOTPCode(testTOTPSecret, utils.TOTPStep(time.Now()))
+	require.NoError(t, err)
+	return code
+}
+
+func TestMFAService_Status(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	t.Run("Enabled", func(t *testing.T) {
+		svc, m := newMFAService(t)
+		m.mfaRepo.On("GetTOTP", mock.Anything, userID).Return(m.credential(t, userID, true), nil)
+		m.mfaRepo.On("CountUnusedRecoveryCodes", mock.Anything, userID).Return(int64(7), nil)
+
+		status, err := svc.Status(ctx, userID)
+
+		require.NoError(t, err)
+		require.True(t, status.TOTPEnabled)
+		require.Equal(t, int64(7), status.RecoveryCodesRemaining)
+	})
+
+	t.Run("EnrollmentNotConfirmed", func(t *testing.T) {
+		svc, m := newMFAService(t)
+		m.mfaRepo.On("GetTOTP", mock.Anything, userID).Return(m.credential(t, userID, false), nil)
+
+		status, err := svc.Status(ctx, userID)
+
+		require.NoError(t, err)
+		require.False(t, status.TOTPEnabled)
+		m.mfaRepo.AssertNotCalled(t, "CountUnusedRecoveryCodes", mock.Anything, mock.Anything)
+	})
+
+	t.Run("NotEnrolled", func(t *testing.T) {
+		svc, m := newMFAService(t)
+		m.mfaRepo.On("GetTOTP", mock.Anything, userID).Return(nil, gorm.ErrRecordNotFound)
+
+		status, err := svc.Status(ctx, userID)
+
+		require.NoError(t, err)
+		require.False(t, status.TOTPEnabled)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		svc, m := newMFAService(t)
+		dbError := errors.New("db error")
+		m.mfaRepo.On("GetTOTP", mock.Anything, userID).Return(nil, dbError)
+
+		status, err := svc.Status(ctx, userID)
+
+		require.ErrorIs(t, err, dbError)
+		require.Nil(t, status)
+	})
+}
+
+func TestMFAService_EnrollTOTP(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	user := &domain.User{ID: userID, Email: "ada@example.com"}
+	dbError := errors.New("db error")
+
+	tests := []struct {
+		name    string
+		setup   func(t *testing.T, m *mfaMocks)
+		wantErr error
+	}{
+		{
+			name: "NewEnrollment",
+			setup: func(t *testing.T, m *mfaMocks) {
+				m.mfaRepo.On("GetTOTP", mock.Anything, userID).Return(nil, gorm.ErrRecordNotFound)
+				m.userRepo.On("GetUserByID", mock.Anything, userID).Return(user, nil)
+				m.mfaRepo.On("SaveTOTP", mock.Anything, mock.MatchedBy(func(c *domain.TOTPCredential) bool {
+					return c.UserID == userID && c.ID == uuid.Nil && c.ConfirmedAt == nil
+				})).Return(nil)
+			},
+		},
+		{
+			name: "ReplacesUnfinishedEnrollment",
+			setup: func(t *testing.T, m *mfaMocks) {
+				existing := m.credential(t, userID, false)
+				existing.LastUsedStep = 99
+				m.mfaRepo.On("GetTOTP", mock.Anything, userID).Return(existing, nil)
+				m.userRepo.On("GetUserByID", mock.Anything, userID).Return(user, nil)
+				m.mfaRepo.On("SaveTOTP", mock.Anything, mock.MatchedBy(func(c *domain.TOTPCredential) bool {
+					return c.ID == existing.ID && c.LastUsedStep == 0
+				})).Return(nil)
+			},
+		},
+		{
+			name: "AlreadyEnabled",
+			setup: func(t *testing.T, m *mfaMocks) {
+				m.mfaRepo.On("GetTOTP", mock.Anything, userID).Return(m.credential(t, userID, true), nil)
+			},
+			wantErr: apperrors.ErrMFAAlreadyEnabled,
+		},
+		{
+			name: "SaveFails",
+			setup: func(t *testing.T, m *mfaMocks) {
+				m.mfaRepo.On("GetTOTP", mock.Anything, userID).Return(nil, gorm.ErrRecordNotFound)
+				m.userRepo.On("GetUserByID", mock.Anything, userID).Return(user, nil)
+				m.mfaRepo.On("SaveTOTP", mock.Anything, mock.Anything).Return(dbError)
+			},
+			wantErr: dbError,
+		},
+		{
+			name: "LookupFails",
+			setup: func(t *testing.T, m *mfaMocks) {
+				m.mfaRepo.On("GetTOTP", mock.Anything, userID).Return(nil, dbError)
+			},
+			wantErr: dbError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, m := newMFAService(t)
+			tt.setup(t, m)
+
+			enrollment, err := svc.EnrollTOTP(ctx, userID)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				require.Nil(t, enrollment)
+				return
+			}
+			require.NoError(t, err)
+			uri, err := url.Parse(enrollment.ProvisioningURI)
+			require.NoError(t, err)
+			require.Equal(t, enrollment.Secret, uri.Query().Get("secret"))
+			require.Equal(t, "/Hangout Planner:ada@example.com", uri.Path)
+
+			// the stored secret is sealed, not the plain secret
+			saved := m.mfaRepo.Calls[len(m.mfaRepo.Calls)-1].Arguments.Get(1).(*domain.TOTPCredential)
+			require.NotEqual(t, []byte(enrollment.Secret), saved.Secret)
+			opened, err := m.sealer.Open(saved.Secret)
+			require.NoError(t, err)
+			require.Equal(t, enrollment.Secret, string(opened))
+		})
+	}
+}
+
+func TestMFAService_ConfirmTOTP(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	dbError := errors.New("db error")
+
+	tests := []struct {
+		name    string
+		code    func(t *testing.T) string
+		setup   func(t *testing.T, m *mfaMocks)
+		wantErr error
+	}{
+		{
+			name: "Success",
+			code: currentCode,
+			setup: func(t *testing.T, m *mfaMocks) {
+				credential := m.credential(t, userID, false)
+				m.mfaRepo.On("GetTOTP", mock.Anything, userID).Return(credential, nil)
+				m.sql.ExpectBegin()
+				m.mfaRepo.On("ConfirmTOTP", mock.Anything, credential.ID, mock.AnythingOfType("int64"), mock.AnythingOfType("time.Time")).Return(int64(1), nil)
+				m.mfaRepo.On("ReplaceRecoveryCodes", mock.Anything, userID, mock.MatchedBy(func(codes []domain.RecoveryCode) bool {
+					return len(codes) == testMFAConfig.RecoveryCodeCount && len(codes[0].CodeHash) == 64
+				})).Return(nil)
+				m.sql.ExpectCommit()
+			},
+		},
+		{
+			name: "WrongCode",
+			code: func(t *testing.T) string { return "000000" },
+			setup: func(t *testing.T, m *mfaMocks) {
+				m.mfaRepo.On("GetTOTP", mock.Anything, userID).Return(m.credential(t, userID, false), nil)
+			},
+			wantErr: apperrors.ErrInvalidMFACode,
+		},
+		{
+			name: "NoEnrollment",
+			code: currentCode,
+			setup: func(t *testing.T, m *mfaMocks) {
+				m.mfaRepo.On("GetTOTP", mock.Anything, userID).Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantErr: apperrors.ErrMFAEnrollmentNotFound,
+		},
+		{
+			name: "AlreadyEnabled",
+			code: currentCode,
+			setup: func(t *testing.T, m *mfaMocks) {
+				m.mfaRepo.On("GetTOTP", mock.Anything, userID).Return(m.credential(t, userID, true), nil)
+			},
+			wantErr: apperrors.ErrMFAAlreadyEnabled,
+		},
+		{
+			name: "ConfirmedConcurrently",
+			code: currentCode,
+			setup: func(t *testing.T, m *mfaMocks) {
+				m.mfaRepo.On("GetTOTP", mock.Anything, userID).Return(m.credential(t, userID, false), nil)
+				m.sql.ExpectBegin()
+				m.mfaRepo.On("ConfirmTOTP", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(int64(0), nil)
+				m.sql.ExpectRollback()
+			},
+			wantErr: apperrors.ErrMFAEnrollmentNotFound,
+		},
+		{
+			name: "RecoveryCodesFail",
+			code: currentCode,
+			setup: func(t *testing.T, m *mfaMocks) {
+				m.mfaRepo.On("GetTOTP", mock.Anything, userID).Return(m.credential(t, userID, false), nil)
+				m.sql.ExpectBegin()
+				m.mfaRepo.On("ConfirmTOTP", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(int64(1), nil)
+				m.mfaRepo.On("ReplaceRecoveryCodes", mock.Anything, userID, mock.Anything).Return(dbError)
+				m.sql.ExpectRollback()
+			},
+			wantErr: dbError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, m := newMFAService(t)
+			tt.setup(t, m)
+
+			codes, err := svc.ConfirmTOTP(ctx, userID, tt.code(t))
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				require.Nil(t, codes)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, codes.RecoveryCodes, testMFAConfig.RecoveryCodeCount)
+			}
+			require.NoError(t, m.sql.ExpectationsWereMet())
+			m.mfaRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMFAService_VerifyCode(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	dbError := errors.New("db error")
+
+	tests := []struct {
+		name    string
+		code    func(t *testing.T) string
+		setup   func(t *testing.T, m *mfaMocks)
+		wantErr error
+	}{
+		{
+			name: "TOTPCode",
+			code: currentCode,
+			setup: func(t *testing.T, m *mfaMocks) {
+				credential := m.credential(t, userID, true)
+				m.mfaRepo.On("GetTOTP", mock.Anything, userID).Return(credential, nil)
+				m.mfaRepo.On("UseTOTPStep", mock.Anything, credential.ID, mock.AnythingOfType("int64")).Return(int64(1), nil)
+			},
+		},
+		{
+			name: "TOTPCodeReplayed",
+			code: currentCode,
+			setup: func(t *testing.T, m *mfaMocks) {
+				credential := m.credential(t, userID, true)
+				credential.LastUsedStep = utils.TOTPStep(time.Now()) + 1
+				m.mfaRepo.On("GetTOTP", mock.Anything, userID).Return(credential, nil)
+			},
+			wantErr: apperrors.ErrInvalidMFACode,
+		},
+		{
+			name: "TOTPCodeUsedConcurrently",
+			code: currentCode,
+			setup: func(t *testing.T, m *mfaMocks) {
+				m.mfaRepo.On("GetTOTP", mock.Anything, userID).Return(m.credential(t, userID, true), nil)
+				m.mfaRepo.On("UseTOTPStep", mock.Anything, mock.Anything, mock.Anything).Return(int64(0), nil)
+			},
+			wantErr: apperrors.ErrInvalidMFACode,
+		},
+		{
+			name: "WrongTOTPCode",
+			code: func(t *testing.T) string { return "000000" },
+			setup: func(t *testing.T, m *mfaMocks) {
+				m.mfaRepo.On("GetTOTP", mock.Anything, userID).Return(m.credential(t, userID, true), nil)
+			},
+			wantErr: apperrors.ErrInvalidMFACode,
+		},
+		{
+			name: "RecoveryCode",
+			code: func(t *testing.T) string { return " ABCDE-23456 " },
+			setup: func(t *testing.T, m *mfaMocks) {
+				m.mfaRepo.On("GetTOTP", mock.Anything, userID).Return(m.credential(t, userID, true), nil)
+				m.mfaRepo.On("UseRecoveryCode", mock.Anything, userID, utils.HashToken("abcde23456"), mock.AnythingOfType("time.Time")).Return(int64(1), nil)
+			},
+		},
+		{
+			name: "UnknownRecoveryCode",
+			code: func(t *testing.T) string { return "abcde-23456" },
+			setup: func(t *testing.T, m *mfaMocks) {
+				m.mfaRepo.On("GetTOTP", mock.Anything, userID).Return(m.credential(t, userID, true), nil)
+				m.mfaRepo.On("UseRecoveryCode", mock.Anything, userID, mock.Anything, mock.Anything).Return(int64(0), nil)
+			},
+			wantErr: apperrors.ErrInvalidMFACode,
+		},
+		{
+			name: "NotConfirmed",
+			code: currentCode,
+			setup: func(t *testing.T, m *mfaMocks) {
+				m.mfaRepo.On("GetTOTP", mock.Anything, userID).Return(m.credential(t, userID, false), nil)
+			},
+			wantErr: apperrors.ErrMFANotEnabled,
+		},
+		{
+			name: "NotEnrolled",
+			code: currentCode,
+			setup: func(t *testing.T, m *mfaMocks) {
+				m.mfaRepo.On("GetTOTP", mock.Anything, userID).Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantErr: apperrors.ErrMFANotEnabled,
+		},
+		{
+			name: "LookupFails",
+			code: currentCode,
+			setup: func(t *testing.T, m *mfaMocks) {
+				m.mfaRepo.On("GetTOTP", mock.Anything, userID).Return(nil, dbError)
+			},
+			wantErr: dbError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, m := newMFAService(t)
+			tt.setup(t, m)
+
+			err := svc.VerifyCode(ctx, userID, tt.code(t))
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			m.mfaRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMFAService_VerifyCode_Lockout(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	svc, m := newMFAService(t)
+	m.mfaRepo.On("GetTOTP", mock.Anything, userID).Return(m.credential(t, userID, true), nil)
+
+	for range 3 {
+		require.ErrorIs(t, svc.VerifyCode(ctx, userID, "000000"), apperrors.ErrInvalidMFACode)
+	}
+
+	// even a valid code is rejected while the second factor is locked
+	require.ErrorIs(t, svc.VerifyCode(ctx, userID, currentCode(t)), apperrors.ErrAccountLocked)
+	m.mfaRepo.AssertNotCalled(t, "UseTOTPStep", mock.Anything, mock.Anything, mock.Anything)
+
+	// other users are not affected
+	otherID := uuid.New()
+	m.mfaRepo.On("GetTOTP", mock.Anything, otherID).Return(nil, gorm.ErrRecordNotFound)
+	require.ErrorIs(t, svc.VerifyCode(ctx, otherID, currentCode(t)), apperrors.ErrMFANotEnabled)
+}
+
+func TestMFAService_DisableTOTP(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	dbError := errors.New("db error")
+
+	tests := []struct {
+		name    string
+		code    string
+		setup   func(t *testing.T, m *mfaMocks)
+		wantErr error
+	}{
+		{
+			name: "Success",
+			code: "abcde-23456",
+			setup: func(t *testing.T, m *mfaMocks) {
+				m.mfaRepo.On("GetTOTP", mock.Anything, userID).Return(m.credential(t, userID, true), nil)
+				m.mfaRepo.On("UseRecoveryCode", mock.Anything, userID, mock.Anything, mock.Anything).Return(int64(1), nil)
+				m.sql.ExpectBegin()
+				m.mfaRepo.On("DeleteRecoveryCodes", mock.Anything, userID).Return(nil)
+				m.mfaRepo.On("DeleteTOTP", mock.Anything, userID).Return(nil)
+				m.sql.ExpectCommit()
+			},
+		},
+		{
+			name: "WrongCode",
+			code: "000000",
+			setup: func(t *testing.T, m *mfaMocks) {
+				m.mfaRepo.On("GetTOTP", mock.Anything, userID).Return(m.credential(t, userID, true), nil)
+			},
+			wantErr: apperrors.ErrInvalidMFACode,
+		},
+		{
+			name: "DeleteFails",
+			code: "abcde-23456",
+			setup: func(t *testing.T, m *mfaMocks) {
+				m.mfaRepo.On("GetTOTP", mock.Anything, userID).Return(m.credential(t, userID, true), nil)
+				m.mfaRepo.On("UseRecoveryCode", mock.Anything, userID, mock.Anything, mock.Anything).Return(int64(1), nil)
+				m.sql.ExpectBegin()
+				m.mfaRepo.On("DeleteRecoveryCodes", mock.Anything, userID).Return(nil)
+				m.mfaRepo.On("DeleteTOTP", mock.Anything, userID).Return(dbError)
+				m.sql.ExpectRollback()
+			},
+			wantErr: dbError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, m := newMFAService(t)
+			tt.setup(t, m)
+
+			err := svc.DisableTOTP(ctx, userID, tt.code)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			require.NoError(t, m.sql.ExpectationsWereMet())
+			m.mfaRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMFAService_RegenerateRecoveryCodes(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	t.Run("Success", func(t *testing.T) {
+		svc, m := newMFAService(t)
+		credential := m.credential(t, userID, true)
+		m.mfaRepo.On("GetTOTP", mock.Anything, userID).Return(credential, nil)
+		m.mfaRepo.On("UseTOTPStep", mock.Anything, credential.ID, mock.Anything).Return(int64(1), nil)
+		m.sql.ExpectBegin()
+		m.mfaRepo.On("ReplaceRecoveryCodes", mock.Anything, userID, mock.Anything).Return(nil)
+		m.sql.ExpectCommit()
+
+		codes, err := svc.RegenerateRecoveryCodes(ctx, userID, currentCode(t))
+
+		require.NoError(t, err)
+		require.Len(t, codes.RecoveryCodes, testMFAConfig.RecoveryCodeCount)
+		stored := m.mfaRepo.Calls[len(m.mfaRepo.Calls)-1].Arguments.Get(2).([]domain.RecoveryCode)
+		for i, code := range codes.RecoveryCodes {
+			require.Equal(t, utils.HashToken(utils.NormalizeRecoveryCode(code)), stored[i].CodeHash)
+		}
+		require.NoError(t, m.sql.ExpectationsWereMet())
+	})
+
+	t.Run("NotEnabled", func(t *testing.T) {
+		svc, m := newMFAService(t)
+		m.mfaRepo.On("GetTOTP", mock.Anything, userID).Return(nil, gorm.ErrRecordNotFound)
+
+		codes, err := svc.RegenerateRecoveryCodes(ctx, userID, currentCode(t))
+
+		require.ErrorIs(t, err, apperrors.ErrMFANotEnabled)
+		require.Nil(t, codes)
+	})
+}
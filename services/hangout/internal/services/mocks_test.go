@@ -2,11 +2,17 @@ package services_test
 
 import (
 	"context"
+	"time"
 
 	filepb "github.com/Ernestgio/Hangout-Planner/pkg/shared/proto/gen/go/file"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/mailer"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/notify"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/oidc"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/repository"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/webhook"
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
 	"gorm.io/gorm"
@@ -32,6 +38,85 @@ func (m *MockUserService) GetUserByEmail(ctx context.Context, email string) (*do
 	return nil, args.Error(1)
 }
 
+func (m *MockUserService) GetUserByID(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	args := m.Called(ctx, id)
+	if u, ok := args.Get(0).(*domain.User); ok {
+		return u, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockUserService) ChangePassword(ctx context.Context, id uuid.UUID, password string) error {
+	args := m.Called(ctx, id, password)
+	return args.Error(0)
+}
+
+func (m *MockUserService) MarkEmailVerified(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockUserService) ConfirmEmailChange(ctx context.Context, id uuid.UUID, email string) error {
+	args := m.Called(ctx, id, email)
+	return args.Error(0)
+}
+
+type MockAuthService struct {
+	mock.Mock
+}
+
+func (m *MockAuthService) SignUser(ctx context.Context, request *dto.SignUpRequest) (*domain.User, error) {
+	args := m.Called(ctx, request)
+	if user, ok := args.Get(0).(*domain.User); ok {
+		return user, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockAuthService) SignInUser(ctx context.Context, request *dto.SignInRequest) (*dto.SignInResponse, error) {
+	args := m.Called(ctx, request)
+	if res, ok := args.Get(0).(*dto.SignInResponse); ok {
+		return res, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockAuthService) CompleteSignIn(ctx context.Context, user *domain.User, client dto.SessionClient) (*dto.SignInResponse, error) {
+	args := m.Called(ctx, user, client)
+	if res, ok := args.Get(0).(*dto.SignInResponse); ok {
+		return res, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockAuthService) VerifyMFAChallenge(ctx context.Context, request *dto.MFAChallengeRequest) (*dto.SignInResponse, error) {
+	args := m.Called(ctx, request)
+	if res, ok := args.Get(0).(*dto.SignInResponse); ok {
+		return res, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockAuthService) VerifyEmail(ctx context.Context, token string) error {
+	args := m.Called(ctx, token)
+	return args.Error(0)
+}
+
+func (m *MockAuthService) ResendVerification(ctx context.Context, userID uuid.UUID) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *MockAuthService) ForgotPassword(ctx context.Context, email string) error {
+	args := m.Called(ctx, email)
+	return args.Error(0)
+}
+
+func (m *MockAuthService) ResetPassword(ctx context.Context, request *dto.ResetPasswordRequest) error {
+	args := m.Called(ctx, request)
+	return args.Error(0)
+}
+
 type MockBcryptUtils struct {
 	mock.Mock
 }
@@ -50,11 +135,25 @@ type MockJWTUtils struct {
 	mock.Mock
 }
 
-func (m *MockJWTUtils) Generate(user *domain.User) (string, error) {
-	args := m.Called(user)
+func (m *MockJWTUtils) Generate(user *domain.User, sessionID uuid.UUID) (string, error) {
+	args := m.Called(user, sessionID)
 	return args.String(0), args.Error(1)
 }
 
+func (m *MockJWTUtils) Keyfunc(token *jwt.Token) (any, error) {
+	args := m.Called(token)
+	return args.Get(0), args.Error(1)
+}
+
+type MockMailSender struct {
+	mock.Mock
+}
+
+func (m *MockMailSender) Send(ctx context.Context, msg *mailer.Message) error {
+	args := m.Called(ctx, msg)
+	return args.Error(0)
+}
+
 type MockUserRepository struct {
 	mock.Mock
 }
@@ -78,6 +177,62 @@ func (m *MockUserRepository) GetUserByEmail(ctx context.Context, email string) (
 	return args.Get(0).(*domain.User), args.Error(1)
 }
 
+func (m *MockUserRepository) GetUserByID(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	args := m.Called(ctx, id)
+	if u, ok := args.Get(0).(*domain.User); ok {
+		return u, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockUserRepository) UpdatePassword(ctx context.Context, id uuid.UUID, passwordHash string) error {
+	args := m.Called(ctx, id, passwordHash)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) MarkEmailVerified(ctx context.Context, id uuid.UUID, verifiedAt time.Time) error {
+	args := m.Called(ctx, id, verifiedAt)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) UpdateName(ctx context.Context, id uuid.UUID, name string) error {
+	args := m.Called(ctx, id, name)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) SetPendingEmail(ctx context.Context, id uuid.UUID, email string) error {
+	args := m.Called(ctx, id, email)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) ConfirmPendingEmail(ctx context.Context, id uuid.UUID, email string, verifiedAt time.Time) error {
+	args := m.Called(ctx, id, email, verifiedAt)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) DeleteAccount(ctx context.Context, id uuid.UUID, at time.Time) error {
+	args := m.Called(ctx, id, at)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) SearchUsers(ctx context.Context, query string, pagination *dto.CursorPagination) ([]domain.User, error) {
+	args := m.Called(ctx, query, pagination)
+	if users, ok := args.Get(0).([]domain.User); ok {
+		return users, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockUserRepository) SetDisabledAt(ctx context.Context, id uuid.UUID, disabledAt *time.Time) error {
+	args := m.Called(ctx, id, disabledAt)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) PromoteToAdmin(ctx context.Context, emails []string) (int64, error) {
+	args := m.Called(ctx, emails)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 type MockMemoryRepository struct {
 	mock.Mock
 }
@@ -113,6 +268,14 @@ func (m *MockMemoryRepository) GetMemoryByID(ctx context.Context, id uuid.UUID,
 	return args.Get(0).(*domain.Memory), args.Error(1)
 }
 
+func (m *MockMemoryRepository) GetMemoryByIDAnyOwner(ctx context.Context, id uuid.UUID) (*domain.Memory, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Memory), args.Error(1)
+}
+
 func (m *MockMemoryRepository) GetMemoriesByIDs(ctx context.Context, ids []uuid.UUID, userID uuid.UUID) ([]domain.Memory, error) {
 	args := m.Called(ctx, ids, userID)
 	if args.Get(0) == nil {
@@ -121,19 +284,123 @@ func (m *MockMemoryRepository) GetMemoriesByIDs(ctx context.Context, ids []uuid.
 	return args.Get(0).([]domain.Memory), args.Error(1)
 }
 
-func (m *MockMemoryRepository) GetMemoriesByHangoutID(ctx context.Context, hangoutID uuid.UUID, pagination *dto.CursorPagination) ([]domain.Memory, error) {
-	args := m.Called(ctx, hangoutID, pagination)
+func (m *MockMemoryRepository) GetMemoryWithAnnotations(ctx context.Context, id uuid.UUID) (*domain.Memory, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Memory), args.Error(1)
+}
+
+func (m *MockMemoryRepository) GetMemoriesByHangoutID(ctx context.Context, hangoutID uuid.UUID, filter *dto.MemoryFilter, pagination *dto.CursorPagination) ([]domain.Memory, error) {
+	args := m.Called(ctx, hangoutID, filter, pagination)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).([]domain.Memory), args.Error(1)
 }
 
+func (m *MockMemoryRepository) UpdateMemoryAnnotations(ctx context.Context, memory *domain.Memory) error {
+	args := m.Called(ctx, memory)
+	return args.Error(0)
+}
+
+func (m *MockMemoryRepository) GetNeighbourPosition(ctx context.Context, hangoutID uuid.UUID, albumID *uuid.UUID, position string, after bool) (string, error) {
+	args := m.Called(ctx, hangoutID, albumID, position, after)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockMemoryRepository) GetMemoriesByAlbumID(ctx context.Context, albumID uuid.UUID) ([]domain.Memory, error) {
+	args := m.Called(ctx, albumID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Memory), args.Error(1)
+}
+
+func (m *MockMemoryRepository) UpdatePlacements(ctx context.Context, albumID *uuid.UUID, positions map[uuid.UUID]string) error {
+	args := m.Called(ctx, albumID, positions)
+	return args.Error(0)
+}
+
+func (m *MockMemoryRepository) AddReaction(ctx context.Context, reaction *domain.MemoryReaction) error {
+	args := m.Called(ctx, reaction)
+	return args.Error(0)
+}
+
+func (m *MockMemoryRepository) RemoveReaction(ctx context.Context, memoryID uuid.UUID, userID uuid.UUID, emoji string) error {
+	args := m.Called(ctx, memoryID, userID, emoji)
+	return args.Error(0)
+}
+
 func (m *MockMemoryRepository) DeleteMemory(ctx context.Context, id uuid.UUID) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
 }
 
+func (m *MockMemoryRepository) GetDeletedMemoryByID(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*domain.Memory, error) {
+	args := m.Called(ctx, id, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Memory), args.Error(1)
+}
+
+func (m *MockMemoryRepository) GetDeletedMemoriesByUserID(ctx context.Context, userID uuid.UUID, pagination *dto.CursorPagination) ([]domain.Memory, error) {
+	args := m.Called(ctx, userID, pagination)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Memory), args.Error(1)
+}
+
+func (m *MockMemoryRepository) RestoreMemory(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockMemoryRepository) SetHiddenAt(ctx context.Context, id uuid.UUID, hiddenAt *time.Time) error {
+	args := m.Called(ctx, id, hiddenAt)
+	return args.Error(0)
+}
+
+func (m *MockMemoryRepository) GetMemoriesDeletedBefore(ctx context.Context, before time.Time, limit int) ([]domain.Memory, error) {
+	args := m.Called(ctx, before, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Memory), args.Error(1)
+}
+
+func (m *MockMemoryRepository) GetAllMemoriesByHangoutID(ctx context.Context, hangoutID uuid.UUID) ([]domain.Memory, error) {
+	args := m.Called(ctx, hangoutID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Memory), args.Error(1)
+}
+
+func (m *MockMemoryRepository) GetAllMemoriesByUserID(ctx context.Context, userID uuid.UUID) ([]domain.Memory, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Memory), args.Error(1)
+}
+
+func (m *MockMemoryRepository) GetMemoryIDsByHangoutID(ctx context.Context, hangoutID uuid.UUID) ([]uuid.UUID, error) {
+	args := m.Called(ctx, hangoutID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+func (m *MockMemoryRepository) PurgeMemories(ctx context.Context, ids []uuid.UUID) error {
+	args := m.Called(ctx, ids)
+	return args.Error(0)
+}
+
 type MockFileService struct {
 	mock.Mock
 }
@@ -172,7 +439,781 @@ func (m *MockFileService) DeleteFile(ctx context.Context, memoryID string) error
 	return args.Error(0)
 }
 
+func (m *MockFileService) CreateArchive(ctx context.Context, baseStoragePath string, archiveName string, memoryIDs []string, documents []*filepb.ArchiveDocument) (*filepb.Archive, error) {
+	args := m.Called(ctx, baseStoragePath, archiveName, memoryIDs, documents)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*filepb.Archive), args.Error(1)
+}
+
+func (m *MockFileService) GetArchiveStatus(ctx context.Context, archiveID string, baseStoragePath string) (*filepb.Archive, error) {
+	args := m.Called(ctx, archiveID, baseStoragePath)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*filepb.Archive), args.Error(1)
+}
+
 func (m *MockFileService) Close() error {
 	args := m.Called()
 	return args.Error(0)
 }
+
+type MockIdempotencyKeyRepository struct {
+	mock.Mock
+}
+
+func (m *MockIdempotencyKeyRepository) CreateIfAbsent(ctx context.Context, key *domain.IdempotencyKey) (bool, error) {
+	args := m.Called(ctx, key)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockIdempotencyKeyRepository) GetByKey(ctx context.Context, userID uuid.UUID, key string) (*domain.IdempotencyKey, error) {
+	args := m.Called(ctx, userID, key)
+	if record, ok := args.Get(0).(*domain.IdempotencyKey); ok {
+		return record, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockIdempotencyKeyRepository) SaveResponse(ctx context.Context, id uuid.UUID, status int, headers map[string]string, body []byte) error {
+	args := m.Called(ctx, id, status, headers, body)
+	return args.Error(0)
+}
+
+func (m *MockIdempotencyKeyRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockIdempotencyKeyRepository) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	args := m.Called(ctx, before)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+type MockWebhookRepository struct {
+	mock.Mock
+}
+
+func (m *MockWebhookRepository) CreateSubscription(ctx context.Context, subscription *domain.WebhookSubscription) error {
+	args := m.Called(ctx, subscription)
+	if args.Error(0) == nil {
+		subscription.ID = uuid.New()
+	}
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepository) CountSubscriptionsByUserID(ctx context.Context, userID uuid.UUID) (int64, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockWebhookRepository) GetSubscriptionByID(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*domain.WebhookSubscription, error) {
+	args := m.Called(ctx, id, userID)
+	if subscription, ok := args.Get(0).(*domain.WebhookSubscription); ok {
+		return subscription, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockWebhookRepository) GetSubscriptionsByUserID(ctx context.Context, userID uuid.UUID) ([]domain.WebhookSubscription, error) {
+	args := m.Called(ctx, userID)
+	if subscriptions, ok := args.Get(0).([]domain.WebhookSubscription); ok {
+		return subscriptions, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockWebhookRepository) DeleteSubscription(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepository) CreateDeliveries(ctx context.Context, deliveries []domain.WebhookDelivery) error {
+	args := m.Called(ctx, deliveries)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepository) GetDeliveriesBySubscriptionID(ctx context.Context, subscriptionID uuid.UUID, pagination *dto.CursorPagination) ([]domain.WebhookDelivery, error) {
+	args := m.Called(ctx, subscriptionID, pagination)
+	if deliveries, ok := args.Get(0).([]domain.WebhookDelivery); ok {
+		return deliveries, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockWebhookRepository) GetDueDeliveries(ctx context.Context, now time.Time, limit int) ([]domain.WebhookDelivery, error) {
+	args := m.Called(ctx, now, limit)
+	if deliveries, ok := args.Get(0).([]domain.WebhookDelivery); ok {
+		return deliveries, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockWebhookRepository) ClaimDelivery(ctx context.Context, id uuid.UUID, dueAt time.Time, leaseUntil time.Time) (bool, error) {
+	args := m.Called(ctx, id, dueAt, leaseUntil)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockWebhookRepository) UpdateDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	args := m.Called(ctx, delivery)
+	return args.Error(0)
+}
+
+type MockWebhookSender struct {
+	mock.Mock
+}
+
+func (m *MockWebhookSender) Send(ctx context.Context, req *webhook.Request) (int, error) {
+	args := m.Called(ctx, req)
+	return args.Int(0), args.Error(1)
+}
+
+type MockReminderRepository struct {
+	mock.Mock
+}
+
+func (m *MockReminderRepository) CreateReminders(ctx context.Context, reminders []domain.Reminder) (int64, error) {
+	args := m.Called(ctx, reminders)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockReminderRepository) GetDueReminders(ctx context.Context, now time.Time, limit int) ([]domain.Reminder, error) {
+	args := m.Called(ctx, now, limit)
+	if reminders, ok := args.Get(0).([]domain.Reminder); ok {
+		return reminders, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockReminderRepository) ClaimReminder(ctx context.Context, id uuid.UUID, dueAt time.Time, leaseUntil time.Time) (bool, error) {
+	args := m.Called(ctx, id, dueAt, leaseUntil)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockReminderRepository) UpdateReminder(ctx context.Context, reminder *domain.Reminder) error {
+	args := m.Called(ctx, reminder)
+	return args.Error(0)
+}
+
+type MockNotificationChannel struct {
+	mock.Mock
+	name string
+}
+
+func (m *MockNotificationChannel) Name() string {
+	return m.name
+}
+
+func (m *MockNotificationChannel) Send(ctx context.Context, msg *notify.Message) error {
+	args := m.Called(ctx, msg)
+	return args.Error(0)
+}
+
+type MockNotificationRepository struct {
+	mock.Mock
+}
+
+func (m *MockNotificationRepository) CreateNotification(ctx context.Context, notification *domain.Notification) error {
+	args := m.Called(ctx, notification)
+	return args.Error(0)
+}
+
+func (m *MockNotificationRepository) GetNotificationByID(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*domain.Notification, error) {
+	args := m.Called(ctx, id, userID)
+	if notification, ok := args.Get(0).(*domain.Notification); ok {
+		return notification, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockNotificationRepository) GetNotificationsByUserID(ctx context.Context, userID uuid.UUID, unreadOnly bool, pagination *dto.CursorPagination) ([]domain.Notification, error) {
+	args := m.Called(ctx, userID, unreadOnly, pagination)
+	if notifications, ok := args.Get(0).([]domain.Notification); ok {
+		return notifications, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockNotificationRepository) SetReadAt(ctx context.Context, id uuid.UUID, readAt *time.Time) error {
+	args := m.Called(ctx, id, readAt)
+	return args.Error(0)
+}
+
+func (m *MockNotificationRepository) MarkAllRead(ctx context.Context, userID uuid.UUID, readAt time.Time) (int64, error) {
+	args := m.Called(ctx, userID, readAt)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockNotificationRepository) CountUnread(ctx context.Context, userID uuid.UUID) (int64, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockNotificationRepository) GetPreferencesByUserID(ctx context.Context, userID uuid.UUID) ([]domain.NotificationPreference, error) {
+	args := m.Called(ctx, userID)
+	if preferences, ok := args.Get(0).([]domain.NotificationPreference); ok {
+		return preferences, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockNotificationRepository) GetPreference(ctx context.Context, userID uuid.UUID, notificationType string) (*domain.NotificationPreference, error) {
+	args := m.Called(ctx, userID, notificationType)
+	if preference, ok := args.Get(0).(*domain.NotificationPreference); ok {
+		return preference, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockNotificationRepository) SavePreferences(ctx context.Context, preferences []domain.NotificationPreference) error {
+	args := m.Called(ctx, preferences)
+	return args.Error(0)
+}
+
+func (m *MockNotificationRepository) QueueEmail(ctx context.Context, email *domain.NotificationEmail) error {
+	args := m.Called(ctx, email)
+	return args.Error(0)
+}
+
+func (m *MockNotificationRepository) GetDueEmails(ctx context.Context, now time.Time, limit int) ([]domain.NotificationEmail, error) {
+	args := m.Called(ctx, now, limit)
+	if emails, ok := args.Get(0).([]domain.NotificationEmail); ok {
+		return emails, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockNotificationRepository) ClaimEmail(ctx context.Context, id uuid.UUID, dueAt time.Time, leaseUntil time.Time) (bool, error) {
+	args := m.Called(ctx, id, dueAt, leaseUntil)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockNotificationRepository) UpdateEmail(ctx context.Context, email *domain.NotificationEmail) error {
+	args := m.Called(ctx, email)
+	return args.Error(0)
+}
+
+type MockCommentRepository struct {
+	mock.Mock
+}
+
+func (m *MockCommentRepository) CreateComment(ctx context.Context, comment *domain.Comment) error {
+	args := m.Called(ctx, comment)
+	return args.Error(0)
+}
+
+func (m *MockCommentRepository) GetCommentByID(ctx context.Context, id uuid.UUID) (*domain.Comment, error) {
+	args := m.Called(ctx, id)
+	if comment, ok := args.Get(0).(*domain.Comment); ok {
+		return comment, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockCommentRepository) GetCommentsByHangoutID(ctx context.Context, hangoutID uuid.UUID, pagination *dto.CursorPagination) ([]domain.Comment, error) {
+	args := m.Called(ctx, hangoutID, pagination)
+	if comments, ok := args.Get(0).([]domain.Comment); ok {
+		return comments, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockCommentRepository) GetRepliesByParentIDs(ctx context.Context, parentIDs []uuid.UUID) ([]domain.Comment, error) {
+	args := m.Called(ctx, parentIDs)
+	if replies, ok := args.Get(0).([]domain.Comment); ok {
+		return replies, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockCommentRepository) UpdateComment(ctx context.Context, comment *domain.Comment) error {
+	args := m.Called(ctx, comment)
+	return args.Error(0)
+}
+
+func (m *MockCommentRepository) DeleteComment(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+type MockAlbumRepository struct {
+	mock.Mock
+}
+
+func (m *MockAlbumRepository) WithTx(tx *gorm.DB) repository.AlbumRepository {
+	args := m.Called(tx)
+	return args.Get(0).(repository.AlbumRepository)
+}
+
+func (m *MockAlbumRepository) CreateAlbum(ctx context.Context, album *domain.Album) error {
+	args := m.Called(ctx, album)
+	return args.Error(0)
+}
+
+func (m *MockAlbumRepository) GetAlbumByID(ctx context.Context, id uuid.UUID) (*domain.Album, error) {
+	args := m.Called(ctx, id)
+	if album, ok := args.Get(0).(*domain.Album); ok {
+		return album, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockAlbumRepository) GetAlbumsByHangoutID(ctx context.Context, hangoutID uuid.UUID) ([]domain.Album, error) {
+	args := m.Called(ctx, hangoutID)
+	if albums, ok := args.Get(0).([]domain.Album); ok {
+		return albums, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockAlbumRepository) UpdateAlbum(ctx context.Context, album *domain.Album) error {
+	args := m.Called(ctx, album)
+	return args.Error(0)
+}
+
+func (m *MockAlbumRepository) DeleteAlbum(ctx context.Context, id uuid.UUID, at time.Time) error {
+	args := m.Called(ctx, id, at)
+	return args.Error(0)
+}
+
+type MockShareLinkRepository struct {
+	mock.Mock
+}
+
+func (m *MockShareLinkRepository) CreateShareLink(ctx context.Context, link *domain.ShareLink) error {
+	args := m.Called(ctx, link)
+	return args.Error(0)
+}
+
+func (m *MockShareLinkRepository) CountActiveShareLinksByHangoutID(ctx context.Context, hangoutID uuid.UUID, now time.Time) (int64, error) {
+	args := m.Called(ctx, hangoutID, now)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockShareLinkRepository) GetShareLinkByID(ctx context.Context, id uuid.UUID) (*domain.ShareLink, error) {
+	args := m.Called(ctx, id)
+	if link, ok := args.Get(0).(*domain.ShareLink); ok {
+		return link, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockShareLinkRepository) GetShareLinkByTokenHash(ctx context.Context, tokenHash string) (*domain.ShareLink, error) {
+	args := m.Called(ctx, tokenHash)
+	if link, ok := args.Get(0).(*domain.ShareLink); ok {
+		return link, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockShareLinkRepository) GetShareLinksByHangoutID(ctx context.Context, hangoutID uuid.UUID) ([]domain.ShareLink, error) {
+	args := m.Called(ctx, hangoutID)
+	if links, ok := args.Get(0).([]domain.ShareLink); ok {
+		return links, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockShareLinkRepository) RevokeShareLink(ctx context.Context, id uuid.UUID, revokedAt time.Time) error {
+	args := m.Called(ctx, id, revokedAt)
+	return args.Error(0)
+}
+
+func (m *MockShareLinkRepository) RecordAccess(ctx context.Context, access *domain.ShareLinkAccess) error {
+	args := m.Called(ctx, access)
+	return args.Error(0)
+}
+
+func (m *MockShareLinkRepository) GetAccessesByShareLinkID(ctx context.Context, shareLinkID uuid.UUID, pagination *dto.CursorPagination) ([]domain.ShareLinkAccess, error) {
+	args := m.Called(ctx, shareLinkID, pagination)
+	if accesses, ok := args.Get(0).([]domain.ShareLinkAccess); ok {
+		return accesses, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+type MockSigningKeyRepository struct {
+	mock.Mock
+}
+
+func (m *MockSigningKeyRepository) Create(ctx context.Context, key *domain.SigningKey) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
+
+func (m *MockSigningKeyRepository) ListUnretired(ctx context.Context, now time.Time) ([]domain.SigningKey, error) {
+	args := m.Called(ctx, now)
+	if keys, ok := args.Get(0).([]domain.SigningKey); ok {
+		return keys, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockSigningKeyRepository) SetRetiresAt(ctx context.Context, id uuid.UUID, retiresAt time.Time) error {
+	args := m.Called(ctx, id, retiresAt)
+	return args.Error(0)
+}
+
+func (m *MockSigningKeyRepository) DeleteRetired(ctx context.Context, before time.Time) (int64, error) {
+	args := m.Called(ctx, before)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+type MockUserIdentityRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserIdentityRepository) WithTx(tx *gorm.DB) repository.UserIdentityRepository {
+	args := m.Called(tx)
+	return args.Get(0).(repository.UserIdentityRepository)
+}
+
+func (m *MockUserIdentityRepository) Create(ctx context.Context, identity *domain.UserIdentity) error {
+	args := m.Called(ctx, identity)
+	return args.Error(0)
+}
+
+func (m *MockUserIdentityRepository) GetByProviderSubject(ctx context.Context, provider string, subject string) (*domain.UserIdentity, error) {
+	args := m.Called(ctx, provider, subject)
+	if identity, ok := args.Get(0).(*domain.UserIdentity); ok {
+		return identity, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockUserIdentityRepository) ListByUserID(ctx context.Context, userID uuid.UUID) ([]domain.UserIdentity, error) {
+	args := m.Called(ctx, userID)
+	if identities, ok := args.Get(0).([]domain.UserIdentity); ok {
+		return identities, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockUserIdentityRepository) DeleteByUserAndProvider(ctx context.Context, userID uuid.UUID, provider string) (int64, error) {
+	args := m.Called(ctx, userID, provider)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+type MockOIDCProvider struct {
+	mock.Mock
+}
+
+func (m *MockOIDCProvider) AuthCodeURL(ctx context.Context, state string, verifier string, nonce string) (string, error) {
+	args := m.Called(ctx, state, verifier, nonce)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockOIDCProvider) Exchange(ctx context.Context, code string, verifier string, nonce string) (*oidc.Identity, error) {
+	args := m.Called(ctx, code, verifier, nonce)
+	if identity, ok := args.Get(0).(*oidc.Identity); ok {
+		return identity, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+type MockMFAService struct {
+	mock.Mock
+}
+
+func (m *MockMFAService) Status(ctx context.Context, userID uuid.UUID) (*dto.MFAStatusResponse, error) {
+	args := m.Called(ctx, userID)
+	if status, ok := args.Get(0).(*dto.MFAStatusResponse); ok {
+		return status, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockMFAService) EnrollTOTP(ctx context.Context, userID uuid.UUID) (*dto.TOTPEnrollmentResponse, error) {
+	args := m.Called(ctx, userID)
+	if enrollment, ok := args.Get(0).(*dto.TOTPEnrollmentResponse); ok {
+		return enrollment, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockMFAService) ConfirmTOTP(ctx context.Context, userID uuid.UUID, code string) (*dto.RecoveryCodesResponse, error) {
+	args := m.Called(ctx, userID, code)
+	if codes, ok := args.Get(0).(*dto.RecoveryCodesResponse); ok {
+		return codes, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockMFAService) DisableTOTP(ctx context.Context, userID uuid.UUID, code string) error {
+	args := m.Called(ctx, userID, code)
+	return args.Error(0)
+}
+
+func (m *MockMFAService) RegenerateRecoveryCodes(ctx context.Context, userID uuid.UUID, code string) (*dto.RecoveryCodesResponse, error) {
+	args := m.Called(ctx, userID, code)
+	if codes, ok := args.Get(0).(*dto.RecoveryCodesResponse); ok {
+		return codes, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockMFAService) Enabled(ctx context.Context, userID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, userID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockMFAService) VerifyCode(ctx context.Context, userID uuid.UUID, code string) error {
+	args := m.Called(ctx, userID, code)
+	return args.Error(0)
+}
+
+type MockMFARepository struct {
+	mock.Mock
+}
+
+func (m *MockMFARepository) WithTx(tx *gorm.DB) repository.MFARepository {
+	args := m.Called(tx)
+	return args.Get(0).(repository.MFARepository)
+}
+
+func (m *MockMFARepository) GetTOTP(ctx context.Context, userID uuid.UUID) (*domain.TOTPCredential, error) {
+	args := m.Called(ctx, userID)
+	if credential, ok := args.Get(0).(*domain.TOTPCredential); ok {
+		return credential, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockMFARepository) SaveTOTP(ctx context.Context, credential *domain.TOTPCredential) error {
+	args := m.Called(ctx, credential)
+	return args.Error(0)
+}
+
+func (m *MockMFARepository) ConfirmTOTP(ctx context.Context, id uuid.UUID, step int64, confirmedAt time.Time) (int64, error) {
+	args := m.Called(ctx, id, step, confirmedAt)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockMFARepository) UseTOTPStep(ctx context.Context, id uuid.UUID, step int64) (int64, error) {
+	args := m.Called(ctx, id, step)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockMFARepository) DeleteTOTP(ctx context.Context, userID uuid.UUID) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *MockMFARepository) ReplaceRecoveryCodes(ctx context.Context, userID uuid.UUID, codes []domain.RecoveryCode) error {
+	args := m.Called(ctx, userID, codes)
+	return args.Error(0)
+}
+
+func (m *MockMFARepository) UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string, usedAt time.Time) (int64, error) {
+	args := m.Called(ctx, userID, codeHash, usedAt)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockMFARepository) CountUnusedRecoveryCodes(ctx context.Context, userID uuid.UUID) (int64, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockMFARepository) DeleteRecoveryCodes(ctx context.Context, userID uuid.UUID) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+type MockSessionService struct {
+	mock.Mock
+}
+
+func (m *MockSessionService) Start(ctx context.Context, user *domain.User, client dto.SessionClient) (string, error) {
+	args := m.Called(ctx, user, client)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockSessionService) Validate(ctx context.Context, userID uuid.UUID, sessionID uuid.UUID) error {
+	args := m.Called(ctx, userID, sessionID)
+	return args.Error(0)
+}
+
+func (m *MockSessionService) ListSessions(ctx context.Context, userID uuid.UUID, currentID uuid.UUID) ([]*dto.SessionResponse, error) {
+	args := m.Called(ctx, userID, currentID)
+	if sessions, ok := args.Get(0).([]*dto.SessionResponse); ok {
+		return sessions, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockSessionService) RevokeSession(ctx context.Context, userID uuid.UUID, sessionID uuid.UUID) error {
+	args := m.Called(ctx, userID, sessionID)
+	return args.Error(0)
+}
+
+func (m *MockSessionService) RevokeAllSessions(ctx context.Context, userID uuid.UUID) (int64, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockSessionService) StartedAt(ctx context.Context, userID uuid.UUID, sessionID uuid.UUID) (time.Time, error) {
+	args := m.Called(ctx, userID, sessionID)
+	return args.Get(0).(time.Time), args.Error(1)
+}
+
+func (m *MockSessionService) PurgeExpired(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+type MockSessionRepository struct {
+	mock.Mock
+}
+
+func (m *MockSessionRepository) Create(ctx context.Context, session *domain.Session) error {
+	args := m.Called(ctx, session)
+	return args.Error(0)
+}
+
+func (m *MockSessionRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Session, error) {
+	args := m.Called(ctx, id)
+	if session, ok := args.Get(0).(*domain.Session); ok {
+		return session, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockSessionRepository) ListActiveByUserID(ctx context.Context, userID uuid.UUID, now time.Time) ([]domain.Session, error) {
+	args := m.Called(ctx, userID, now)
+	if sessions, ok := args.Get(0).([]domain.Session); ok {
+		return sessions, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockSessionRepository) Touch(ctx context.Context, id uuid.UUID, at time.Time) error {
+	args := m.Called(ctx, id, at)
+	return args.Error(0)
+}
+
+func (m *MockSessionRepository) Revoke(ctx context.Context, userID uuid.UUID, id uuid.UUID, at time.Time) (int64, error) {
+	args := m.Called(ctx, userID, id, at)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockSessionRepository) RevokeAllByUserID(ctx context.Context, userID uuid.UUID, at time.Time) (int64, error) {
+	args := m.Called(ctx, userID, at)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockSessionRepository) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	args := m.Called(ctx, before)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+type MockPersonalAccessTokenRepository struct {
+	mock.Mock
+}
+
+func (m *MockPersonalAccessTokenRepository) Create(ctx context.Context, token *domain.PersonalAccessToken) error {
+	args := m.Called(ctx, token)
+	return args.Error(0)
+}
+
+func (m *MockPersonalAccessTokenRepository) GetByHash(ctx context.Context, hash string) (*domain.PersonalAccessToken, error) {
+	args := m.Called(ctx, hash)
+	if token, ok := args.Get(0).(*domain.PersonalAccessToken); ok {
+		return token, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockPersonalAccessTokenRepository) ListByUserID(ctx context.Context, userID uuid.UUID) ([]domain.PersonalAccessToken, error) {
+	args := m.Called(ctx, userID)
+	if tokens, ok := args.Get(0).([]domain.PersonalAccessToken); ok {
+		return tokens, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockPersonalAccessTokenRepository) CountByUserID(ctx context.Context, userID uuid.UUID) (int64, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockPersonalAccessTokenRepository) Touch(ctx context.Context, id uuid.UUID, at time.Time) error {
+	args := m.Called(ctx, id, at)
+	return args.Error(0)
+}
+
+func (m *MockPersonalAccessTokenRepository) DeleteByUserAndID(ctx context.Context, userID uuid.UUID, id uuid.UUID) (int64, error) {
+	args := m.Called(ctx, userID, id)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+type MockDataExportRepository struct {
+	mock.Mock
+}
+
+func (m *MockDataExportRepository) Create(ctx context.Context, export *domain.DataExport) error {
+	args := m.Called(ctx, export)
+	return args.Error(0)
+}
+
+func (m *MockDataExportRepository) GetByID(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*domain.DataExport, error) {
+	args := m.Called(ctx, id, userID)
+	if export, ok := args.Get(0).(*domain.DataExport); ok {
+		return export, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockDataExportRepository) GetActiveByUserID(ctx context.Context, userID uuid.UUID) (*domain.DataExport, error) {
+	args := m.Called(ctx, userID)
+	if export, ok := args.Get(0).(*domain.DataExport); ok {
+		return export, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockDataExportRepository) GetDueExports(ctx context.Context, now time.Time, limit int) ([]domain.DataExport, error) {
+	args := m.Called(ctx, now, limit)
+	if exports, ok := args.Get(0).([]domain.DataExport); ok {
+		return exports, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockDataExportRepository) ClaimExport(ctx context.Context, id uuid.UUID, now time.Time, leaseUntil time.Time) (bool, error) {
+	args := m.Called(ctx, id, now, leaseUntil)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockDataExportRepository) Update(ctx context.Context, export *domain.DataExport) error {
+	args := m.Called(ctx, export)
+	return args.Error(0)
+}
+
+func (m *MockDataExportRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	args := m.Called(ctx, now)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+type MockAdminAuditLogRepository struct {
+	mock.Mock
+}
+
+func (m *MockAdminAuditLogRepository) WithTx(tx *gorm.DB) repository.AdminAuditLogRepository {
+	args := m.Called(tx)
+	return args.Get(0).(repository.AdminAuditLogRepository)
+}
+
+func (m *MockAdminAuditLogRepository) Create(ctx context.Context, entry *domain.AdminAuditLog) error {
+	args := m.Called(ctx, entry)
+	return args.Error(0)
+}
+
+func (m *MockAdminAuditLogRepository) List(ctx context.Context, filter *dto.AdminAuditLogFilter, pagination *dto.CursorPagination) ([]domain.AdminAuditLog, error) {
+	args := m.Called(ctx, filter, pagination)
+	if entries, ok := args.Get(0).([]domain.AdminAuditLog); ok {
+		return entries, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
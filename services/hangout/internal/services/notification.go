@@ -0,0 +1,404 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/mapper"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/notify"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/otel"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/pubsub"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/repository"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"gorm.io/gorm"
+)
+
+type NotificationService interface {
+	// Publish turns hangout events into notifications for the people involved in
+	// the hangout, so the service can be plugged in next to the event broker.
+	pubsub.Publisher
+	// ChannelEnabled lets notification channels honor user preferences.
+	notify.Preferences
+
+	ListNotifications(ctx context.Context, userID uuid.UUID, unreadOnly bool, pagination *dto.CursorPagination) (*dto.PaginatedNotifications, error)
+	GetUnreadCount(ctx context.Context, userID uuid.UUID) (*dto.UnreadNotificationCountResponse, error)
+	MarkRead(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*dto.NotificationResponse, error)
+	MarkUnread(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*dto.NotificationResponse, error)
+	MarkAllRead(ctx context.Context, userID uuid.UUID) (*dto.MarkAllNotificationsReadResponse, error)
+	GetPreferences(ctx context.Context, userID uuid.UUID) ([]*dto.NotificationPreferenceResponse, error)
+	UpdatePreferences(ctx context.Context, userID uuid.UUID, req *dto.UpdateNotificationPreferencesRequest) ([]*dto.NotificationPreferenceResponse, error)
+}
+
+type notificationService struct {
+	repo        repository.NotificationRepository
+	hangoutRepo repository.HangoutRepository
+	userRepo    repository.UserRepository
+	channels    []notify.Channel
+	metrics     *otel.MetricsRecorder
+}
+
+// NewNotificationService delivers event notifications on channels, filtered
+// by each recipient's preferences.
+func NewNotificationService(repo repository.NotificationRepository, hangoutRepo repository.HangoutRepository, userRepo repository.UserRepository, channels []notify.Channel, metrics *otel.MetricsRecorder) NotificationService {
+	s := &notificationService{
+		repo:        repo,
+		hangoutRepo: hangoutRepo,
+		userRepo:    userRepo,
+		metrics:     metrics,
+	}
+	s.channels = notify.WithPreferences(s, channels...)
+	return s
+}
+
+func (s *notificationService) ListNotifications(ctx context.Context, userID uuid.UUID, unreadOnly bool, pagination *dto.CursorPagination) (*dto.PaginatedNotifications, error) {
+	recordMetrics := s.metrics.StartRequest(ctx, "notification", "list")
+
+	ctx, span := otel.StartServiceSpan(ctx, "ListNotifications",
+		attribute.String("user.id", userID.String()),
+		attribute.Bool("notification.unread_only", unreadOnly),
+		attribute.Int("pagination.limit", pagination.GetLimit()),
+	)
+	defer span.End()
+
+	notifications, err := s.repo.GetNotificationsByUserID(ctx, userID, unreadOnly, pagination)
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	var nextCursor *uuid.UUID
+	limit := pagination.GetLimit()
+	hasMore := len(notifications) > limit
+	if hasMore {
+		nextCursor = &notifications[limit-1].ID
+		notifications = notifications[:limit]
+	}
+
+	span.SetAttributes(
+		attribute.Int("notification.count", len(notifications)),
+		attribute.Bool("pagination.has_more", hasMore),
+	)
+	span.SetStatusOk()
+	recordMetrics("success")
+	return &dto.PaginatedNotifications{
+		Data:       mapper.NotificationsToResponseDTOs(notifications),
+		NextCursor: nextCursor,
+		HasMore:    hasMore,
+	}, nil
+}
+
+func (s *notificationService) GetUnreadCount(ctx context.Context, userID uuid.UUID) (*dto.UnreadNotificationCountResponse, error) {
+	recordMetrics := s.metrics.StartRequest(ctx, "notification", "unread_count")
+
+	ctx, span := otel.StartServiceSpan(ctx, "GetUnreadNotificationCount",
+		attribute.String("user.id", userID.String()),
+	)
+	defer span.End()
+
+	count, err := s.repo.CountUnread(ctx, userID)
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int64("notification.unread_count", count))
+	span.SetStatusOk()
+	recordMetrics("success")
+	return &dto.UnreadNotificationCountResponse{Unread: count}, nil
+}
+
+func (s *notificationService) MarkRead(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*dto.NotificationResponse, error) {
+	return s.setRead(ctx, id, userID, true)
+}
+
+func (s *notificationService) MarkUnread(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*dto.NotificationResponse, error) {
+	return s.setRead(ctx, id, userID, false)
+}
+
+// setRead keeps the original read time when a read notification is marked
+// read again.
+func (s *notificationService) setRead(ctx context.Context, id uuid.UUID, userID uuid.UUID, read bool) (*dto.NotificationResponse, error) {
+	operation := "mark_unread"
+	if read {
+		operation = "mark_read"
+	}
+	recordMetrics := s.metrics.StartRequest(ctx, "notification", operation)
+
+	ctx, span := otel.StartServiceSpan(ctx, "SetNotificationRead",
+		attribute.String("notification.id", id.String()),
+		attribute.String("user.id", userID.String()),
+		attribute.Bool("notification.read", read),
+	)
+	defer span.End()
+
+	notification, err := s.repo.GetNotificationByID(ctx, id, userID)
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	if read != (notification.ReadAt != nil) {
+		var readAt *time.Time
+		if read {
+			now := time.Now()
+			readAt = &now
+		}
+		if err := s.repo.SetReadAt(ctx, id, readAt); err != nil {
+			recordMetrics("error")
+			_ = span.RecordErrorWithStatus(err)
+			return nil, err
+		}
+		notification.ReadAt = readAt
+	}
+
+	span.SetStatusOk()
+	recordMetrics("success")
+	return mapper.NotificationToResponseDTO(notification), nil
+}
+
+func (s *notificationService) MarkAllRead(ctx context.Context, userID uuid.UUID) (*dto.MarkAllNotificationsReadResponse, error) {
+	recordMetrics := s.metrics.StartRequest(ctx, "notification", "mark_all_read")
+
+	ctx, span := otel.StartServiceSpan(ctx, "MarkAllNotificationsRead",
+		attribute.String("user.id", userID.String()),
+	)
+	defer span.End()
+
+	updated, err := s.repo.MarkAllRead(ctx, userID, time.Now())
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int64("notification.updated_count", updated))
+	span.SetStatusOk()
+	recordMetrics("success")
+	return &dto.MarkAllNotificationsReadResponse{Updated: updated}, nil
+}
+
+// GetPreferences returns the preferences for every notification type, using
+// the defaults for types the user has not changed.
+func (s *notificationService) GetPreferences(ctx context.Context, userID uuid.UUID) ([]*dto.NotificationPreferenceResponse, error) {
+	recordMetrics := s.metrics.StartRequest(ctx, "notification", "get_preferences")
+
+	ctx, span := otel.StartServiceSpan(ctx, "GetNotificationPreferences",
+		attribute.String("user.id", userID.String()),
+	)
+	defer span.End()
+
+	saved, err := s.repo.GetPreferencesByUserID(ctx, userID)
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetStatusOk()
+	recordMetrics("success")
+	return mapper.NotificationPreferencesToResponseDTOs(mergeNotificationPreferences(userID, saved)), nil
+}
+
+func (s *notificationService) UpdatePreferences(ctx context.Context, userID uuid.UUID, req *dto.UpdateNotificationPreferencesRequest) ([]*dto.NotificationPreferenceResponse, error) {
+	recordMetrics := s.metrics.StartRequest(ctx, "notification", "update_preferences")
+
+	ctx, span := otel.StartServiceSpan(ctx, "UpdateNotificationPreferences",
+		attribute.String("user.id", userID.String()),
+		attribute.Int("notification.preference_count", len(req.Preferences)),
+	)
+	defer span.End()
+
+	// a type listed twice keeps its last entry
+	updates := make([]domain.NotificationPreference, 0, len(req.Preferences))
+	for _, preference := range req.Preferences {
+		updates = slices.DeleteFunc(updates, func(p domain.NotificationPreference) bool {
+			return p.Type == preference.Type
+		})
+		updates = append(updates, domain.NotificationPreference{
+			UserID: userID,
+			Type:   preference.Type,
+			Email:  preference.Email,
+			Inbox:  preference.Inbox,
+		})
+	}
+
+	if err := s.repo.SavePreferences(ctx, updates); err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	saved, err := s.repo.GetPreferencesByUserID(ctx, userID)
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetStatusOk()
+	recordMetrics("success")
+	return mapper.NotificationPreferencesToResponseDTOs(mergeNotificationPreferences(userID, saved)), nil
+}
+
+func (s *notificationService) ChannelEnabled(ctx context.Context, userID uuid.UUID, notificationType string, channel string) (bool, error) {
+	preference, err := s.repo.GetPreference(ctx, userID, notificationType)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		defaults := defaultNotificationPreference(userID, notificationType)
+		preference, err = &defaults, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	switch channel {
+	case constants.NotificationChannelEmail:
+		return preference.Email, nil
+	case constants.NotificationChannelInbox:
+		return preference.Inbox, nil
+	default:
+		return true, nil
+	}
+}
+
+// Publish notifies the participants of a hangout of status changes, new memories
+// and new comments.
+func (s *notificationService) Publish(ctx context.Context, _ string, event pubsub.Event) error {
+	if event.UserID == uuid.Nil {
+		return nil
+	}
+
+	// build returns nil for recipients that should not be notified
+	var build func(hangout *domain.Hangout, recipientID uuid.UUID) *notify.Message
+	switch event.Type {
+	case constants.EventHangoutStatusChanged:
+		data, ok := event.Data.(dto.HangoutStatusChangedEvent)
+		if !ok {
+			return nil
+		}
+		build = func(hangout *domain.Hangout, recipientID uuid.UUID) *notify.Message {
+			if recipientID == event.UserID {
+				return nil
+			}
+			to := strings.ToLower(string(data.To))
+			return &notify.Message{
+				Type:    constants.NotificationStatusChanged,
+				Subject: fmt.Sprintf("%s is now %s", hangout.Title, to),
+				Body:    fmt.Sprintf("%s moved from %s to %s.", hangout.Title, strings.ToLower(string(data.From)), to),
+			}
+		}
+	case constants.EventMemoryCreated:
+		data, ok := event.Data.(*dto.MemoryCreatedEvent)
+		if !ok {
+			return nil
+		}
+		build = func(hangout *domain.Hangout, recipientID uuid.UUID) *notify.Message {
+			if recipientID == event.UserID {
+				return nil
+			}
+			return &notify.Message{
+				Type:    constants.NotificationMemoryCreated,
+				Subject: fmt.Sprintf("New memory in %s", hangout.Title),
+				Body:    fmt.Sprintf("%s was added to %s.", data.Name, hangout.Title),
+			}
+		}
+	case constants.EventCommentCreated:
+		data, ok := event.Data.(*dto.CommentCreatedEvent)
+		if !ok {
+			return nil
+		}
+		build = func(hangout *domain.Hangout, recipientID uuid.UUID) *notify.Message {
+			if recipientID == data.AuthorID {
+				return nil
+			}
+			if slices.Contains(data.Mentions, recipientID) {
+				return &notify.Message{
+					Type:    constants.NotificationCommentMention,
+					Subject: fmt.Sprintf("%s mentioned you in %s", data.AuthorName, hangout.Title),
+					Body:    fmt.Sprintf("%s mentioned you in a comment on %s.", data.AuthorName, hangout.Title),
+				}
+			}
+			return &notify.Message{
+				Type:    constants.NotificationCommentCreated,
+				Subject: fmt.Sprintf("New comment in %s", hangout.Title),
+				Body:    fmt.Sprintf("%s commented on %s.", data.AuthorName, hangout.Title),
+			}
+		}
+	default:
+		return nil
+	}
+
+	hangout, err := s.hangoutRepo.GetHangoutByID(ctx, event.HangoutID, event.UserID)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, recipientID := range hangoutParticipants(hangout) {
+		msg := build(hangout, recipientID)
+		if msg == nil {
+			continue
+		}
+
+		recipient, err := s.userRepo.GetUserByID(ctx, recipientID)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		msg.UserID = recipient.ID
+		msg.Name = recipient.Name
+		msg.Email = recipient.Email
+		msg.HangoutID = &hangout.ID
+		for _, channel := range s.channels {
+			if err := channel.Send(ctx, msg); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", channel.Name(), err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// hangoutParticipants returns the users taking part in a hangout.
+func hangoutParticipants(hangout *domain.Hangout) []uuid.UUID {
+	if hangout.UserID == nil {
+		return nil
+	}
+	return []uuid.UUID{*hangout.UserID}
+}
+
+// defaultNotificationPreference sends every type to the inbox and only
+// reminders by email.
+func defaultNotificationPreference(userID uuid.UUID, notificationType string) domain.NotificationPreference {
+	return domain.NotificationPreference{
+		UserID: userID,
+		Type:   notificationType,
+		Email:  slices.Contains(constants.EmailNotificationTypes, notificationType),
+		Inbox:  true,
+	}
+}
+
+// mergeNotificationPreferences returns one preference per known type, in the
+// order of constants.NotificationTypes.
+func mergeNotificationPreferences(userID uuid.UUID, saved []domain.NotificationPreference) []domain.NotificationPreference {
+	merged := make([]domain.NotificationPreference, len(constants.NotificationTypes))
+	for i, notificationType := range constants.NotificationTypes {
+		merged[i] = defaultNotificationPreference(userID, notificationType)
+		for _, preference := range saved {
+			if preference.Type == notificationType {
+				merged[i] = preference
+			}
+		}
+	}
+	return merged
+}
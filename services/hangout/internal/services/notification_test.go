@@ -0,0 +1,347 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/pkg/shared/enums"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/notify"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/pubsub"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/services"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestNotificationService_ListNotifications(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	repo := new(MockNotificationRepository)
+	svc := services.NewNotificationService(repo, nil, nil, nil, nil)
+
+	notifications := []domain.Notification{{ID: uuid.New()}, {ID: uuid.New()}, {ID: uuid.New()}}
+	pagination := &dto.CursorPagination{Limit: 2}
+	repo.On("GetNotificationsByUserID", mock.Anything, userID, true, pagination).Return(notifications, nil)
+
+	res, err := svc.ListNotifications(ctx, userID, true, pagination)
+	require.NoError(t, err)
+	require.Len(t, res.Data, 2)
+	require.True(t, res.HasMore)
+	require.Equal(t, notifications[1].ID, *res.NextCursor)
+}
+
+func TestNotificationService_SetRead(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	readAt := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name       string
+		read       bool
+		readAt     *time.Time
+		findErr    error
+		wantUpdate bool
+		wantRead   bool
+		wantErr    error
+	}{
+		{name: "mark unread notification read", read: true, wantUpdate: true, wantRead: true},
+		{name: "read notification keeps its read time", read: true, readAt: &readAt, wantRead: true},
+		{name: "mark read notification unread", readAt: &readAt, wantUpdate: true},
+		{name: "unread notification stays unread"},
+		{name: "not found", read: true, findErr: gorm.ErrRecordNotFound, wantErr: gorm.ErrRecordNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockNotificationRepository)
+			svc := services.NewNotificationService(repo, nil, nil, nil, nil)
+			notification := &domain.Notification{ID: uuid.New(), ReadAt: tt.readAt, UserID: userID}
+
+			if tt.findErr != nil {
+				repo.On("GetNotificationByID", mock.Anything, notification.ID, userID).Return(nil, tt.findErr)
+			} else {
+				repo.On("GetNotificationByID", mock.Anything, notification.ID, userID).Return(notification, nil)
+			}
+			if tt.wantUpdate {
+				repo.On("SetReadAt", mock.Anything, notification.ID, mock.MatchedBy(func(at *time.Time) bool {
+					return (at != nil) == tt.read
+				})).Return(nil)
+			}
+
+			var res *dto.NotificationResponse
+			var err error
+			if tt.read {
+				res, err = svc.MarkRead(ctx, notification.ID, userID)
+			} else {
+				res, err = svc.MarkUnread(ctx, notification.ID, userID)
+			}
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.wantRead, res.Read)
+			if tt.readAt != nil && tt.read {
+				require.Equal(t, readAt, time.Time(res.ReadAt))
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestNotificationService_MarkAllReadAndUnreadCount(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	repo := new(MockNotificationRepository)
+	svc := services.NewNotificationService(repo, nil, nil, nil, nil)
+
+	repo.On("MarkAllRead", mock.Anything, userID, mock.Anything).Return(int64(3), nil).Once()
+	repo.On("CountUnread", mock.Anything, userID).Return(int64(0), nil).Once()
+
+	marked, err := svc.MarkAllRead(ctx, userID)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), marked.Updated)
+
+	count, err := svc.GetUnreadCount(ctx, userID)
+	require.NoError(t, err)
+	require.Zero(t, count.Unread)
+
+	dbErr := errors.New("db error")
+	repo.On("CountUnread", mock.Anything, userID).Return(int64(0), dbErr)
+	_, err = svc.GetUnreadCount(ctx, userID)
+	require.ErrorIs(t, err, dbErr)
+}
+
+func TestNotificationService_Preferences(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	t.Run("defaults fill types without a saved preference", func(t *testing.T) {
+		repo := new(MockNotificationRepository)
+		svc := services.NewNotificationService(repo, nil, nil, nil, nil)
+		repo.On("GetPreferencesByUserID", mock.Anything, userID).Return([]domain.NotificationPreference{
+			{UserID: userID, Type: constants.NotificationHangoutReminder, Email: false, Inbox: true},
+		}, nil)
+
+		preferences, err := svc.GetPreferences(ctx, userID)
+		require.NoError(t, err)
+		require.Equal(t, []*dto.NotificationPreferenceResponse{
+			{Type: constants.NotificationHangoutReminder, Email: false, Inbox: true},
+			{Type: constants.NotificationRSVPDeadline, Email: true, Inbox: true},
+			{Type: constants.NotificationStatusChanged, Email: false, Inbox: true},
+			{Type: constants.NotificationMemoryCreated, Email: false, Inbox: true},
+			{Type: constants.NotificationCommentCreated, Email: false, Inbox: true},
+			{Type: constants.NotificationCommentMention, Email: false, Inbox: true},
+		}, preferences)
+	})
+
+	t.Run("update keeps the last entry per type", func(t *testing.T) {
+		repo := new(MockNotificationRepository)
+		svc := services.NewNotificationService(repo, nil, nil, nil, nil)
+		saved := []domain.NotificationPreference{
+			{UserID: userID, Type: constants.NotificationMemoryCreated, Email: true, Inbox: false},
+			{UserID: userID, Type: constants.NotificationStatusChanged, Email: false, Inbox: false},
+		}
+		repo.On("SavePreferences", mock.Anything, saved).Return(nil)
+		repo.On("GetPreferencesByUserID", mock.Anything, userID).Return(saved, nil)
+
+		preferences, err := svc.UpdatePreferences(ctx, userID, &dto.UpdateNotificationPreferencesRequest{
+			Preferences: []dto.NotificationPreferenceRequest{
+				{Type: constants.NotificationStatusChanged, Email: true, Inbox: true},
+				{Type: constants.NotificationMemoryCreated, Email: true},
+				{Type: constants.NotificationStatusChanged},
+			},
+		})
+		require.NoError(t, err)
+		require.Len(t, preferences, len(constants.NotificationTypes))
+		require.Equal(t, &dto.NotificationPreferenceResponse{Type: constants.NotificationMemoryCreated, Email: true}, preferences[3])
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestNotificationService_ChannelEnabled(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	dbErr := errors.New("db error")
+
+	tests := []struct {
+		name             string
+		notificationType string
+		channel          string
+		preference       *domain.NotificationPreference
+		err              error
+		want             bool
+		wantErr          error
+	}{
+		{name: "reminders are emailed by default", notificationType: constants.NotificationHangoutReminder, channel: constants.NotificationChannelEmail, err: gorm.ErrRecordNotFound, want: true},
+		{name: "other types are not emailed by default", notificationType: constants.NotificationMemoryCreated, channel: constants.NotificationChannelEmail, err: gorm.ErrRecordNotFound},
+		{name: "inbox is on by default", notificationType: constants.NotificationMemoryCreated, channel: constants.NotificationChannelInbox, err: gorm.ErrRecordNotFound, want: true},
+		{name: "saved opt out", notificationType: constants.NotificationHangoutReminder, channel: constants.NotificationChannelInbox, preference: &domain.NotificationPreference{Email: true}},
+		{name: "saved opt in", notificationType: constants.NotificationMemoryCreated, channel: constants.NotificationChannelEmail, preference: &domain.NotificationPreference{Email: true}, want: true},
+		{name: "lookup error", notificationType: constants.NotificationMemoryCreated, channel: constants.NotificationChannelEmail, err: dbErr, wantErr: dbErr},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockNotificationRepository)
+			svc := services.NewNotificationService(repo, nil, nil, nil, nil)
+			if tt.preference != nil {
+				repo.On("GetPreference", mock.Anything, userID, tt.notificationType).Return(tt.preference, nil)
+			} else {
+				repo.On("GetPreference", mock.Anything, userID, tt.notificationType).Return(nil, tt.err)
+			}
+
+			enabled, err := svc.ChannelEnabled(ctx, userID, tt.notificationType, tt.channel)
+			require.ErrorIs(t, err, tt.wantErr)
+			require.Equal(t, tt.want, enabled)
+		})
+	}
+}
+
+func TestNotificationService_Publish(t *testing.T) {
+	ctx := context.Background()
+	user := &domain.User{ID: uuid.New(), Name: "Ana", Email: "ana@example.com"}
+	hangout := &domain.Hangout{ID: uuid.New(), Title: "Picnic", UserID: &user.ID}
+	// someone other than the recipient caused the event
+	actorID := uuid.New()
+
+	tests := []struct {
+		name        string
+		event       pubsub.Event
+		wantType    string
+		wantSubject string
+		wantBody    string
+	}{
+		{
+			name:        "status change",
+			event:       pubsub.NewHangoutEvent(hangout.ID, constants.EventHangoutStatusChanged, dto.HangoutStatusChangedEvent{From: enums.StatusPlanning, To: enums.StatusConfirmed}),
+			wantType:    constants.NotificationStatusChanged,
+			wantSubject: "Picnic is now confirmed",
+			wantBody:    "Picnic moved from planning to confirmed.",
+		},
+		{
+			name:        "new memory",
+			event:       pubsub.NewHangoutEvent(hangout.ID, constants.EventMemoryCreated, &dto.MemoryCreatedEvent{ID: uuid.New(), Name: "beach.jpg", HangoutID: hangout.ID}),
+			wantType:    constants.NotificationMemoryCreated,
+			wantSubject: "New memory in Picnic",
+			wantBody:    "beach.jpg was added to Picnic.",
+		},
+		{
+			name:        "new comment",
+			event:       pubsub.NewHangoutEvent(hangout.ID, constants.EventCommentCreated, &dto.CommentCreatedEvent{ID: uuid.New(), HangoutID: hangout.ID, AuthorID: uuid.New(), AuthorName: "Ben"}),
+			wantType:    constants.NotificationCommentCreated,
+			wantSubject: "New comment in Picnic",
+			wantBody:    "Ben commented on Picnic.",
+		},
+		{
+			name:        "mention",
+			event:       pubsub.NewHangoutEvent(hangout.ID, constants.EventCommentCreated, &dto.CommentCreatedEvent{ID: uuid.New(), HangoutID: hangout.ID, AuthorID: uuid.New(), AuthorName: "Ben", Mentions: []uuid.UUID{user.ID}}),
+			wantType:    constants.NotificationCommentMention,
+			wantSubject: "Ben mentioned you in Picnic",
+			wantBody:    "Ben mentioned you in a comment on Picnic.",
+		},
+		{
+			name:  "other events are ignored",
+			event: pubsub.NewHangoutEvent(hangout.ID, constants.EventHangoutUpdated, hangout),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockNotificationRepository)
+			hangoutRepo := new(MockHangoutRepository)
+			userRepo := new(MockUserRepository)
+			email := &MockNotificationChannel{name: constants.NotificationChannelEmail}
+			inbox := &MockNotificationChannel{name: constants.NotificationChannelInbox}
+			svc := services.NewNotificationService(repo, hangoutRepo, userRepo, []notify.Channel{email, inbox}, nil)
+
+			tt.event.UserID = actorID
+			if tt.wantType != "" {
+				hangoutRepo.On("GetHangoutByID", mock.Anything, hangout.ID, actorID).Return(hangout, nil)
+				userRepo.On("GetUserByID", mock.Anything, user.ID).Return(user, nil)
+				repo.On("GetPreference", mock.Anything, user.ID, tt.wantType).Return(nil, gorm.ErrRecordNotFound)
+				inbox.On("Send", mock.Anything, mock.MatchedBy(func(msg *notify.Message) bool {
+					return msg.Type == tt.wantType && msg.Subject == tt.wantSubject && msg.Body == tt.wantBody &&
+						msg.UserID == user.ID && msg.Email == user.Email && *msg.HangoutID == hangout.ID
+				})).Return(nil)
+			}
+
+			require.NoError(t, svc.Publish(ctx, pubsub.HangoutTopic(hangout.ID), tt.event))
+			hangoutRepo.AssertExpectations(t)
+			inbox.AssertExpectations(t)
+			// email is off by default for these types
+			email.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestNotificationService_PublishErrors(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	hangoutID := uuid.New()
+	dbErr := errors.New("db error")
+	event := pubsub.NewHangoutEvent(hangoutID, constants.EventHangoutStatusChanged, dto.HangoutStatusChangedEvent{From: enums.StatusPlanning, To: enums.StatusCancelled})
+	event.UserID = userID
+
+	hangoutRepo := new(MockHangoutRepository)
+	svc := services.NewNotificationService(new(MockNotificationRepository), hangoutRepo, new(MockUserRepository), nil, nil)
+	hangoutRepo.On("GetHangoutByID", mock.Anything, hangoutID, userID).Return(nil, dbErr)
+
+	require.ErrorIs(t, svc.Publish(ctx, pubsub.HangoutTopic(hangoutID), event), dbErr)
+
+	event.UserID = uuid.Nil
+	require.NoError(t, svc.Publish(ctx, pubsub.HangoutTopic(hangoutID), event))
+}
+
+func TestNotificationService_PublishSkipsCommentAuthor(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	hangout := &domain.Hangout{ID: uuid.New(), Title: "Picnic", UserID: &userID}
+	event := pubsub.NewHangoutEvent(hangout.ID, constants.EventCommentCreated, &dto.CommentCreatedEvent{ID: uuid.New(), HangoutID: hangout.ID, AuthorID: userID, Mentions: []uuid.UUID{userID}})
+	event.UserID = userID
+
+	hangoutRepo := new(MockHangoutRepository)
+	userRepo := new(MockUserRepository)
+	inbox := &MockNotificationChannel{name: constants.NotificationChannelInbox}
+	svc := services.NewNotificationService(new(MockNotificationRepository), hangoutRepo, userRepo, []notify.Channel{inbox}, nil)
+	hangoutRepo.On("GetHangoutByID", mock.Anything, hangout.ID, userID).Return(hangout, nil)
+
+	require.NoError(t, svc.Publish(ctx, pubsub.HangoutTopic(hangout.ID), event))
+	userRepo.AssertNotCalled(t, "GetUserByID", mock.Anything, mock.Anything)
+	inbox.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+}
+
+func TestNotificationService_PublishSkipsActor(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	hangout := &domain.Hangout{ID: uuid.New(), Title: "Picnic", UserID: &userID}
+
+	tests := map[string]pubsub.Event{
+		"status change": pubsub.NewHangoutEvent(hangout.ID, constants.EventHangoutStatusChanged, dto.HangoutStatusChangedEvent{From: enums.StatusPlanning, To: enums.StatusConfirmed}),
+		"new memory":    pubsub.NewHangoutEvent(hangout.ID, constants.EventMemoryCreated, &dto.MemoryCreatedEvent{ID: uuid.New(), Name: "beach.jpg", HangoutID: hangout.ID}),
+	}
+
+	for name, event := range tests {
+		t.Run(name, func(t *testing.T) {
+			hangoutRepo := new(MockHangoutRepository)
+			userRepo := new(MockUserRepository)
+			email := &MockNotificationChannel{name: constants.NotificationChannelEmail}
+			inbox := &MockNotificationChannel{name: constants.NotificationChannelInbox}
+			svc := services.NewNotificationService(new(MockNotificationRepository), hangoutRepo, userRepo, []notify.Channel{email, inbox}, nil)
+			event.UserID = userID
+			hangoutRepo.On("GetHangoutByID", mock.Anything, hangout.ID, userID).Return(hangout, nil)
+
+			require.NoError(t, svc.Publish(ctx, pubsub.HangoutTopic(hangout.ID), event))
+			userRepo.AssertNotCalled(t, "GetUserByID", mock.Anything, mock.Anything)
+			email.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+			inbox.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+		})
+	}
+}
@@ -0,0 +1,188 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants/logmsg"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/mapper"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/otel"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/repository"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/utils"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"gorm.io/gorm"
+)
+
+// PersonalAccessTokenService manages the long lived tokens scripts use instead of
+// signing in.
+type PersonalAccessTokenService interface {
+	CreateToken(ctx context.Context, userID uuid.UUID, req *dto.CreatePersonalAccessTokenRequest) (*dto.PersonalAccessTokenCreatedResponse, error)
+	ListTokens(ctx context.Context, userID uuid.UUID) ([]*dto.PersonalAccessTokenResponse, error)
+	RevokeToken(ctx context.Context, userID uuid.UUID, id uuid.UUID) error
+	// Authenticate returns the stored token matching the plaintext token, or
+	// ErrInvalidPersonalAccessToken when it is unknown or expired and
+	// ErrAccountDisabled when its owner has been disabled.
+	Authenticate(ctx context.Context, token string) (*domain.PersonalAccessToken, error)
+}
+
+type personalAccessTokenService struct {
+	repo    repository.PersonalAccessTokenRepository
+	metrics *otel.MetricsRecorder
+}
+
+func NewPersonalAccessTokenService(repo repository.PersonalAccessTokenRepository, metrics *otel.MetricsRecorder) PersonalAccessTokenService {
+	return &personalAccessTokenService{
+		repo:    repo,
+		metrics: metrics,
+	}
+}
+
+func (s *personalAccessTokenService) CreateToken(ctx context.Context, userID uuid.UUID, req *dto.CreatePersonalAccessTokenRequest) (*dto.PersonalAccessTokenCreatedResponse, error) {
+	recordMetrics := s.metrics.StartRequest(ctx, "personal_access_token", "create")
+
+	ctx, span := otel.StartServiceSpan(ctx, "CreatePersonalAccessToken",
+		attribute.String("user.id", userID.String()),
+	)
+	defer span.End()
+
+	count, err := s.repo.CountByUserID(ctx, userID)
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+	if count >= constants.MaxPersonalAccessTokensPerUser {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(apperrors.ErrPersonalAccessTokenLimitReached)
+		return nil, apperrors.ErrPersonalAccessTokenLimitReached
+	}
+
+	plaintext, err := utils.GenerateToken(constants.PersonalAccessTokenPrefix)
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	scopes := slices.Clone(req.Scopes)
+	slices.Sort(scopes)
+	token := &domain.PersonalAccessToken{
+		Name:      req.Name,
+		TokenHash: utils.HashToken(plaintext),
+		Scopes:    strings.Join(slices.Compact(scopes), ","),
+		UserID:    userID,
+	}
+	if req.ExpiresInDays != nil {
+		expiresAt := time.Now().AddDate(0, 0, *req.ExpiresInDays)
+		token.ExpiresAt = &expiresAt
+	}
+	if err := s.repo.Create(ctx, token); err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.String("personal_access_token.id", token.ID.String()))
+	span.SetStatusOk()
+	recordMetrics("success")
+	return mapper.PersonalAccessTokenToCreatedResponseDTO(token, plaintext), nil
+}
+
+func (s *personalAccessTokenService) ListTokens(ctx context.Context, userID uuid.UUID) ([]*dto.PersonalAccessTokenResponse, error) {
+	recordMetrics := s.metrics.StartRequest(ctx, "personal_access_token", "list")
+
+	ctx, span := otel.StartServiceSpan(ctx, "ListPersonalAccessTokens",
+		attribute.String("user.id", userID.String()),
+	)
+	defer span.End()
+
+	tokens, err := s.repo.ListByUserID(ctx, userID)
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetStatusOk()
+	recordMetrics("success")
+	return mapper.PersonalAccessTokensToResponseDTOs(tokens), nil
+}
+
+func (s *personalAccessTokenService) RevokeToken(ctx context.Context, userID uuid.UUID, id uuid.UUID) error {
+	recordMetrics := s.metrics.StartRequest(ctx, "personal_access_token", "revoke")
+
+	ctx, span := otel.StartServiceSpan(ctx, "RevokePersonalAccessToken",
+		attribute.String("user.id", userID.String()),
+		attribute.String("personal_access_token.id", id.String()),
+	)
+	defer span.End()
+
+	deleted, err := s.repo.DeleteByUserAndID(ctx, userID, id)
+	if err == nil && deleted == 0 {
+		err = apperrors.ErrPersonalAccessTokenNotFound
+	}
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return err
+	}
+
+	span.SetStatusOk()
+	recordMetrics("success")
+	return nil
+}
+
+func (s *personalAccessTokenService) Authenticate(ctx context.Context, plaintext string) (*domain.PersonalAccessToken, error) {
+	recordMetrics := s.metrics.StartRequest(ctx, "personal_access_token", "authenticate")
+
+	ctx, span := otel.StartServiceSpan(ctx, "AuthenticatePersonalAccessToken")
+	defer span.End()
+
+	var token *domain.PersonalAccessToken
+	var err error
+	if strings.HasPrefix(plaintext, constants.PersonalAccessTokenPrefix) {
+		token, err = s.repo.GetByHash(ctx, utils.HashToken(plaintext))
+	} else {
+		err = apperrors.ErrInvalidPersonalAccessToken
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		err = apperrors.ErrInvalidPersonalAccessToken
+	}
+
+	now := time.Now()
+	if err == nil && token.Expired(now) {
+		err = apperrors.ErrInvalidPersonalAccessToken
+	}
+	if err == nil && token.User.DisabledAt != nil {
+		err = apperrors.ErrAccountDisabled
+	}
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	if token.LastUsedAt == nil || now.Sub(*token.LastUsedAt) >= time.Duration(constants.PersonalAccessTokenTouchSeconds)*time.Second {
+		// a stale last use is not worth failing the request over
+		if touchErr := s.repo.Touch(ctx, token.ID, now); touchErr != nil {
+			log.Printf(logmsg.PersonalAccessTokenTouchFailed, token.ID, touchErr)
+		}
+	}
+
+	span.SetAttributes(
+		attribute.String("user.id", token.UserID.String()),
+		attribute.String("personal_access_token.id", token.ID.String()),
+	)
+	span.SetStatusOk()
+	recordMetrics("success")
+	return token, nil
+}
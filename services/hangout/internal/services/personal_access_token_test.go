@@ -0,0 +1,280 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/services"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/utils"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func newPersonalAccessTokenService(t *testing.T) (services.PersonalAccessTokenService, *MockPersonalAccessTokenRepository) {
+	t.Helper()
+	repo := new(MockPersonalAccessTokenRepository)
+	return services.NewPersonalAccessTokenService(repo, nil), repo
+}
+
+func TestPersonalAccessTokenService_CreateToken(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	days := 30
+	dbErr := errors.New("db error")
+
+	tests := map[string]struct {
+		req     *dto.CreatePersonalAccessTokenRequest
+		setup   func(repo *MockPersonalAccessTokenRepository)
+		wantErr error
+	}{
+		"Success without expiry": {
+			req: &dto.CreatePersonalAccessTokenRequest{Name: "ci", Scopes: []string{constants.ScopeHangoutsWrite, constants.ScopeHangoutsRead, constants.ScopeHangoutsWrite}},
+			setup: func(repo *MockPersonalAccessTokenRepository) {
+				repo.On("CountByUserID", mock.Anything, userID).Return(int64(0), nil)
+				repo.On("Create", mock.Anything, mock.MatchedBy(func(token *domain.PersonalAccessToken) bool {
+					return token.UserID == userID &&
+						token.Name == "ci" &&
+						token.Scopes == "hangouts:read,hangouts:write" &&
+						len(token.TokenHash) == 64 &&
+						token.ExpiresAt == nil
+				})).Return(nil)
+			},
+		},
+		"Success with expiry": {
+			req: &dto.CreatePersonalAccessTokenRequest{Name: "ci", Scopes: []string{constants.ScopeMemoriesWrite}, ExpiresInDays: &days},
+			setup: func(repo *MockPersonalAccessTokenRepository) {
+				repo.On("CountByUserID", mock.Anything, userID).Return(int64(0), nil)
+				repo.On("Create", mock.Anything, mock.MatchedBy(func(token *domain.PersonalAccessToken) bool {
+					return token.ExpiresAt != nil && token.ExpiresAt.After(time.Now().AddDate(0, 0, days-1))
+				})).Return(nil)
+			},
+		},
+		"Limit reached": {
+			req: &dto.CreatePersonalAccessTokenRequest{Name: "ci", Scopes: []string{constants.ScopeHangoutsRead}},
+			setup: func(repo *MockPersonalAccessTokenRepository) {
+				repo.On("CountByUserID", mock.Anything, userID).Return(int64(constants.MaxPersonalAccessTokensPerUser), nil)
+			},
+			wantErr: apperrors.ErrPersonalAccessTokenLimitReached,
+		},
+		"Count error": {
+			req: &dto.CreatePersonalAccessTokenRequest{Name: "ci", Scopes: []string{constants.ScopeHangoutsRead}},
+			setup: func(repo *MockPersonalAccessTokenRepository) {
+				repo.On("CountByUserID", mock.Anything, userID).Return(int64(0), dbErr)
+			},
+			wantErr: dbErr,
+		},
+		"Create error": {
+			req: &dto.CreatePersonalAccessTokenRequest{Name: "ci", Scopes: []string{constants.ScopeHangoutsRead}},
+			setup: func(repo *MockPersonalAccessTokenRepository) {
+				repo.On("CountByUserID", mock.Anything, userID).Return(int64(0), nil)
+				repo.On("Create", mock.Anything, mock.Anything).Return(dbErr)
+			},
+			wantErr: dbErr,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			svc, repo := newPersonalAccessTokenService(t)
+			tt.setup(repo)
+
+			res, err := svc.CreateToken(ctx, userID, tt.req)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				require.Nil(t, res)
+			} else {
+				require.NoError(t, err)
+				require.True(t, strings.HasPrefix(res.Token, constants.PersonalAccessTokenPrefix))
+				created := repo.Calls[1].Arguments.Get(1).(*domain.PersonalAccessToken)
+				require.Equal(t, utils.HashToken(res.Token), created.TokenHash)
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPersonalAccessTokenService_ListTokens(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	dbErr := errors.New("db error")
+
+	tests := map[string]struct {
+		setup     func(repo *MockPersonalAccessTokenRepository)
+		wantCount int
+		wantErr   error
+	}{
+		"Success": {
+			setup: func(repo *MockPersonalAccessTokenRepository) {
+				repo.On("ListByUserID", mock.Anything, userID).Return([]domain.PersonalAccessToken{
+					{ID: uuid.New(), Name: "ci", Scopes: "hangouts:read"},
+					{ID: uuid.New(), Name: "backup", Scopes: "memories:read"},
+				}, nil)
+			},
+			wantCount: 2,
+		},
+		"Repository error": {
+			setup: func(repo *MockPersonalAccessTokenRepository) {
+				repo.On("ListByUserID", mock.Anything, userID).Return(nil, dbErr)
+			},
+			wantErr: dbErr,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			svc, repo := newPersonalAccessTokenService(t)
+			tt.setup(repo)
+
+			res, err := svc.ListTokens(ctx, userID)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, res, tt.wantCount)
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPersonalAccessTokenService_RevokeToken(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	tokenID := uuid.New()
+	dbErr := errors.New("db error")
+
+	tests := map[string]struct {
+		setup   func(repo *MockPersonalAccessTokenRepository)
+		wantErr error
+	}{
+		"Success": {
+			setup: func(repo *MockPersonalAccessTokenRepository) {
+				repo.On("DeleteByUserAndID", mock.Anything, userID, tokenID).Return(int64(1), nil)
+			},
+		},
+		"Not found": {
+			setup: func(repo *MockPersonalAccessTokenRepository) {
+				repo.On("DeleteByUserAndID", mock.Anything, userID, tokenID).Return(int64(0), nil)
+			},
+			wantErr: apperrors.ErrPersonalAccessTokenNotFound,
+		},
+		"Repository error": {
+			setup: func(repo *MockPersonalAccessTokenRepository) {
+				repo.On("DeleteByUserAndID", mock.Anything, userID, tokenID).Return(int64(0), dbErr)
+			},
+			wantErr: dbErr,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			svc, repo := newPersonalAccessTokenService(t)
+			tt.setup(repo)
+
+			err := svc.RevokeToken(ctx, userID, tokenID)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPersonalAccessTokenService_Authenticate(t *testing.T) {
+	ctx := context.Background()
+	plaintext := constants.PersonalAccessTokenPrefix + "secret"
+	hash := utils.HashToken(plaintext)
+	tokenID := uuid.New()
+	dbErr := errors.New("db error")
+	recent := time.Now()
+	stale := time.Now().Add(-time.Hour)
+	expired := time.Now().Add(-time.Minute)
+
+	tests := map[string]struct {
+		token   string
+		setup   func(repo *MockPersonalAccessTokenRepository)
+		wantErr error
+	}{
+		"Recently used": {
+			token: plaintext,
+			setup: func(repo *MockPersonalAccessTokenRepository) {
+				repo.On("GetByHash", mock.Anything, hash).Return(&domain.PersonalAccessToken{ID: tokenID, LastUsedAt: &recent}, nil)
+			},
+		},
+		"Stale last use is touched": {
+			token: plaintext,
+			setup: func(repo *MockPersonalAccessTokenRepository) {
+				repo.On("GetByHash", mock.Anything, hash).Return(&domain.PersonalAccessToken{ID: tokenID, LastUsedAt: &stale}, nil)
+				repo.On("Touch", mock.Anything, tokenID, mock.Anything).Return(nil)
+			},
+		},
+		"Touch failure is ignored": {
+			token: plaintext,
+			setup: func(repo *MockPersonalAccessTokenRepository) {
+				repo.On("GetByHash", mock.Anything, hash).Return(&domain.PersonalAccessToken{ID: tokenID}, nil)
+				repo.On("Touch", mock.Anything, tokenID, mock.Anything).Return(dbErr)
+			},
+		},
+		"Wrong prefix": {
+			token:   "not-a-token",
+			setup:   func(repo *MockPersonalAccessTokenRepository) {},
+			wantErr: apperrors.ErrInvalidPersonalAccessToken,
+		},
+		"Unknown token": {
+			token: plaintext,
+			setup: func(repo *MockPersonalAccessTokenRepository) {
+				repo.On("GetByHash", mock.Anything, hash).Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantErr: apperrors.ErrInvalidPersonalAccessToken,
+		},
+		"Expired token": {
+			token: plaintext,
+			setup: func(repo *MockPersonalAccessTokenRepository) {
+				repo.On("GetByHash", mock.Anything, hash).Return(&domain.PersonalAccessToken{ID: tokenID, ExpiresAt: &expired}, nil)
+			},
+			wantErr: apperrors.ErrInvalidPersonalAccessToken,
+		},
+		"Disabled user": {
+			token: plaintext,
+			setup: func(repo *MockPersonalAccessTokenRepository) {
+				repo.On("GetByHash", mock.Anything, hash).Return(&domain.PersonalAccessToken{ID: tokenID, User: domain.User{DisabledAt: &recent}}, nil)
+			},
+			wantErr: apperrors.ErrAccountDisabled,
+		},
+		"Repository error": {
+			token: plaintext,
+			setup: func(repo *MockPersonalAccessTokenRepository) {
+				repo.On("GetByHash", mock.Anything, hash).Return(nil, dbErr)
+			},
+			wantErr: dbErr,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			svc, repo := newPersonalAccessTokenService(t)
+			tt.setup(repo)
+
+			token, err := svc.Authenticate(ctx, tt.token)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				require.Nil(t, token)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tokenID, token.ID)
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,367 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/pkg/shared/enums"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/config"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/notify"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/otel"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/repository"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// reminderDateFormat is how hangout dates are written in reminder messages.
+const reminderDateFormat = "Mon, 02 Jan 2006 15:04 MST"
+
+type ReminderService interface {
+	// ScheduleDue records the reminders that have come due since the last run.
+	ScheduleDue(ctx context.Context) (int64, error)
+	// SendDue delivers one batch of scheduled reminders through the
+	// configured channels, retrying failures after the configured delay.
+	SendDue(ctx context.Context) (sent int, failed int, err error)
+	// SendQueuedEmails mails one batch of the event notifications queued by
+	// the email queue channel, with the same retries as reminders.
+	SendQueuedEmails(ctx context.Context) (sent int, failed int, err error)
+}
+
+type reminderService struct {
+	hangoutRepo      repository.HangoutRepository
+	reminderRepo     repository.ReminderRepository
+	notificationRepo repository.NotificationRepository
+	channels         []notify.Channel
+	email            notify.Channel
+	cfg              *config.ReminderConfig
+	metrics          *otel.MetricsRecorder
+}
+
+// NewReminderService sends reminders on channels and queued notification emails on
+// email.
+func NewReminderService(hangoutRepo repository.HangoutRepository, reminderRepo repository.ReminderRepository, notificationRepo repository.NotificationRepository, channels []notify.Channel, email notify.Channel, cfg *config.ReminderConfig, metrics *otel.MetricsRecorder) ReminderService {
+	return &reminderService{
+		hangoutRepo:      hangoutRepo,
+		reminderRepo:     reminderRepo,
+		notificationRepo: notificationRepo,
+		channels:         channels,
+		email:            email,
+		cfg:              cfg,
+		metrics:          metrics,
+	}
+}
+
+func (s *reminderService) ScheduleDue(ctx context.Context) (int64, error) {
+	recordMetrics := s.metrics.StartRequest(ctx, "reminder", "schedule")
+
+	ctx, span := otel.StartServiceSpan(ctx, "ScheduleDueReminders")
+	defer span.End()
+
+	now := time.Now()
+	var reminders []domain.Reminder
+
+	// offsets are sorted largest first
+	offsets := s.startOffsets()
+	if len(offsets) > 0 {
+		hangouts, err := s.hangoutRepo.GetHangoutsStartingBetween(ctx, now, now.Add(offsets[0]))
+		if err != nil {
+			recordMetrics("error")
+			_ = span.RecordErrorWithStatus(err)
+			return 0, err
+		}
+		for i := range hangouts {
+			offset := closestDueOffset(offsets, hangouts[i].Date.Sub(now))
+			reminders = append(reminders, newReminder(&hangouts[i], constants.ReminderKindHangoutStart, offset, hangouts[i].Date, now))
+		}
+	}
+
+	if s.cfg.RSVPOffsetMinutes > 0 {
+		offset := time.Duration(s.cfg.RSVPOffsetMinutes) * time.Minute
+		hangouts, err := s.hangoutRepo.GetHangoutsWithRSVPDeadlineBetween(ctx, now, now.Add(offset))
+		if err != nil {
+			recordMetrics("error")
+			_ = span.RecordErrorWithStatus(err)
+			return 0, err
+		}
+		for i := range hangouts {
+			reminders = append(reminders, newReminder(&hangouts[i], constants.ReminderKindRSVPDeadline, offset, *hangouts[i].RSVPDeadline, now))
+		}
+	}
+
+	scheduled, err := s.reminderRepo.CreateReminders(ctx, reminders)
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return 0, err
+	}
+
+	span.SetAttributes(attribute.Int64("reminder.scheduled", scheduled))
+	span.SetStatusOk()
+	recordMetrics("success")
+	return scheduled, nil
+}
+
+func (s *reminderService) SendDue(ctx context.Context) (int, int, error) {
+	recordMetrics := s.metrics.StartRequest(ctx, "reminder", "send")
+
+	ctx, span := otel.StartServiceSpan(ctx, "SendDueReminders")
+	defer span.End()
+
+	now := time.Now()
+	due, err := s.reminderRepo.GetDueReminders(ctx, now, s.cfg.BatchSize)
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return 0, 0, err
+	}
+
+	sent, failed := 0, 0
+	for i := range due {
+		reminder := &due[i]
+
+		// the lease starts at the claim, not at the batch start, so earlier
+		// slow deliveries do not eat into it
+		claimed, err := s.reminderRepo.ClaimReminder(ctx, reminder.ID, *reminder.NextAttemptAt, time.Now().Add(constants.ReminderClaimLeaseSeconds*time.Second))
+		if err != nil {
+			recordMetrics("error")
+			_ = span.RecordErrorWithStatus(err)
+			return sent, failed, err
+		}
+		if !claimed {
+			continue
+		}
+
+		var sendErr error
+		if reminderIsCurrent(reminder, now) {
+			sendErr = s.deliver(ctx, reminder, reminderMessage(reminder))
+			s.recordAttempt(reminder, sendErr, time.Now())
+		} else {
+			reminder.Status = constants.ReminderCancelled
+			reminder.NextAttemptAt = nil
+		}
+
+		if err := s.reminderRepo.UpdateReminder(ctx, reminder); err != nil {
+			recordMetrics("error")
+			_ = span.RecordErrorWithStatus(err)
+			return sent, failed, err
+		}
+
+		switch {
+		case reminder.Status == constants.ReminderCancelled:
+		case sendErr != nil:
+			failed++
+		default:
+			sent++
+		}
+	}
+
+	span.SetAttributes(
+		attribute.Int("reminder.sent", sent),
+		attribute.Int("reminder.failed", failed),
+	)
+	span.SetStatusOk()
+	recordMetrics("success")
+	return sent, failed, nil
+}
+
+// deliver sends the message on every channel the reminder has not reached
+// yet, so a retry after a partial failure does not repeat a delivery.
+func (s *reminderService) deliver(ctx context.Context, reminder *domain.Reminder, msg *notify.Message) error {
+	var errs []error
+	for _, channel := range s.channels {
+		if reminder.DeliveredTo(channel.Name()) {
+			continue
+		}
+		if err := channel.Send(ctx, msg); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", channel.Name(), err))
+			continue
+		}
+		reminder.MarkDelivered(channel.Name())
+	}
+	return errors.Join(errs...)
+}
+
+func (s *reminderService) SendQueuedEmails(ctx context.Context) (int, int, error) {
+	recordMetrics := s.metrics.StartRequest(ctx, "reminder", "send_emails")
+
+	ctx, span := otel.StartServiceSpan(ctx, "SendQueuedNotificationEmails")
+	defer span.End()
+
+	due, err := s.notificationRepo.GetDueEmails(ctx, time.Now(), s.cfg.BatchSize)
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return 0, 0, err
+	}
+
+	sent, failed := 0, 0
+	for i := range due {
+		email := &due[i]
+
+		claimed, err := s.notificationRepo.ClaimEmail(ctx, email.ID, *email.NextAttemptAt, time.Now().Add(constants.ReminderClaimLeaseSeconds*time.Second))
+		if err != nil {
+			recordMetrics("error")
+			_ = span.RecordErrorWithStatus(err)
+			return sent, failed, err
+		}
+		if !claimed {
+			continue
+		}
+
+		var sendErr error
+		if email.User.ID != uuid.Nil {
+			sendErr = s.email.Send(ctx, &notify.Message{
+				Type:      email.Type,
+				UserID:    email.UserID,
+				Name:      email.User.Name,
+				Email:     email.User.Email,
+				HangoutID: email.HangoutID,
+				Subject:   email.Subject,
+				Body:      email.Body,
+			})
+			email.Attempts++
+			email.Status, email.NextAttemptAt, email.SentAt, email.LastError = s.attemptOutcome(email.Attempts, sendErr, time.Now())
+		} else {
+			// the recipient deleted their account after the email was queued
+			email.Status = constants.ReminderCancelled
+			email.NextAttemptAt = nil
+		}
+
+		if err := s.notificationRepo.UpdateEmail(ctx, email); err != nil {
+			recordMetrics("error")
+			_ = span.RecordErrorWithStatus(err)
+			return sent, failed, err
+		}
+
+		switch {
+		case email.Status == constants.ReminderCancelled:
+		case sendErr != nil:
+			failed++
+		default:
+			sent++
+		}
+	}
+
+	span.SetAttributes(
+		attribute.Int("notification_email.sent", sent),
+		attribute.Int("notification_email.failed", failed),
+	)
+	span.SetStatusOk()
+	recordMetrics("success")
+	return sent, failed, nil
+}
+
+func (s *reminderService) recordAttempt(reminder *domain.Reminder, sendErr error, attemptedAt time.Time) {
+	reminder.Attempts++
+	reminder.Status, reminder.NextAttemptAt, reminder.SentAt, reminder.LastError = s.attemptOutcome(reminder.Attempts, sendErr, attemptedAt)
+}
+
+// attemptOutcome returns the state of a reminder or queued email after its
+// attempts-th attempt: sent, retried after the configured delay, or failed
+// once the attempts are used up.
+func (s *reminderService) attemptOutcome(attempts int, sendErr error, attemptedAt time.Time) (string, *time.Time, *time.Time, string) {
+	switch {
+	case sendErr == nil:
+		return constants.ReminderSent, nil, &attemptedAt, ""
+	case attempts >= s.cfg.MaxAttempts:
+		return constants.ReminderFailed, nil, nil, truncateReminderError(sendErr)
+	default:
+		next := attemptedAt.Add(s.cfg.GetRetryDelay())
+		return constants.ReminderPending, &next, nil, truncateReminderError(sendErr)
+	}
+}
+
+func (s *reminderService) startOffsets() []time.Duration {
+	offsets := make([]time.Duration, 0, len(s.cfg.OffsetsMinutes))
+	for _, minutes := range s.cfg.OffsetsMinutes {
+		if minutes > 0 {
+			offsets = append(offsets, time.Duration(minutes)*time.Minute)
+		}
+	}
+	slices.Sort(offsets)
+	slices.Reverse(offsets)
+	return slices.Compact(offsets)
+}
+
+// closestDueOffset picks the smallest offset that is already due for a hangout
+// starting in untilStart.
+func closestDueOffset(offsets []time.Duration, untilStart time.Duration) time.Duration {
+	closest := offsets[0]
+	for _, offset := range offsets {
+		if offset >= untilStart {
+			closest = offset
+		}
+	}
+	return closest
+}
+
+func newReminder(hangout *domain.Hangout, kind string, offset time.Duration, target time.Time, now time.Time) domain.Reminder {
+	return domain.Reminder{
+		Kind:          kind,
+		OffsetMinutes: int(offset / time.Minute),
+		DueAt:         target.Add(-offset),
+		Status:        constants.ReminderPending,
+		NextAttemptAt: &now,
+		HangoutID:     hangout.ID,
+		UserID:        *hangout.UserID,
+	}
+}
+
+// reminderIsCurrent reports whether the reminder still matches its hangout.
+func reminderIsCurrent(reminder *domain.Reminder, now time.Time) bool {
+	hangout := &reminder.Hangout
+	if hangout.ID == uuid.Nil || (hangout.Status != enums.StatusPlanning && hangout.Status != enums.StatusConfirmed) {
+		return false
+	}
+
+	target := reminderTarget(reminder)
+	if target == nil || !now.Before(*target) {
+		return false
+	}
+	offset := time.Duration(reminder.OffsetMinutes) * time.Minute
+	return target.Add(-offset).Equal(reminder.DueAt)
+}
+
+func reminderTarget(reminder *domain.Reminder) *time.Time {
+	if reminder.Kind == constants.ReminderKindRSVPDeadline {
+		return reminder.Hangout.RSVPDeadline
+	}
+	return &reminder.Hangout.Date
+}
+
+func reminderMessage(reminder *domain.Reminder) *notify.Message {
+	hangout := &reminder.Hangout
+	msg := &notify.Message{
+		UserID:    reminder.UserID,
+		Name:      reminder.User.Name,
+		Email:     reminder.User.Email,
+		HangoutID: &hangout.ID,
+	}
+
+	date := hangout.Date.Format(reminderDateFormat)
+	if reminder.Kind == constants.ReminderKindRSVPDeadline {
+		msg.Type = constants.NotificationRSVPDeadline
+		msg.Subject = fmt.Sprintf("RSVP deadline for %s is approaching", hangout.Title)
+		msg.Body = fmt.Sprintf("Hi %s,\n\nResponses for %s close on %s. The hangout takes place on %s.\n",
+			reminder.User.Name, hangout.Title, hangout.RSVPDeadline.Format(reminderDateFormat), date)
+		return msg
+	}
+
+	msg.Type = constants.NotificationHangoutReminder
+	msg.Subject = fmt.Sprintf("Reminder: %s is coming up", hangout.Title)
+	msg.Body = fmt.Sprintf("Hi %s,\n\n%s takes place on %s.\n", reminder.User.Name, hangout.Title, date)
+	return msg
+}
+
+func truncateReminderError(err error) string {
+	msg := err.Error()
+	if len(msg) > constants.MaxReminderErrorLength {
+		return msg[:constants.MaxReminderErrorLength]
+	}
+	return msg
+}
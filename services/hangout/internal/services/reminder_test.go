@@ -0,0 +1,331 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/pkg/shared/enums"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/config"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/notify"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/services"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newReminderTestConfig() *config.ReminderConfig {
+	return &config.ReminderConfig{
+		OffsetsMinutes:    []int{60, 24 * 60},
+		RSVPOffsetMinutes: 24 * 60,
+		BatchSize:         10,
+		MaxAttempts:       3,
+		RetryDelaySeconds: 300,
+	}
+}
+
+func TestReminderService_ScheduleDue(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	now := time.Now()
+	soon := domain.Hangout{ID: uuid.New(), Title: "Soon", Date: now.Add(30 * time.Minute), UserID: &userID}
+	tomorrow := domain.Hangout{ID: uuid.New(), Title: "Tomorrow", Date: now.Add(10 * time.Hour), UserID: &userID}
+	rsvpDeadline := now.Add(2 * time.Hour)
+	rsvp := domain.Hangout{ID: uuid.New(), Title: "RSVP", Date: now.Add(72 * time.Hour), RSVPDeadline: &rsvpDeadline, UserID: &userID}
+
+	t.Run("schedules the nearest due offset per hangout", func(t *testing.T) {
+		hangoutRepo := new(MockHangoutRepository)
+		reminderRepo := new(MockReminderRepository)
+		svc := services.NewReminderService(hangoutRepo, reminderRepo, nil, nil, nil, newReminderTestConfig(), nil)
+
+		hangoutRepo.On("GetHangoutsStartingBetween", mock.Anything, mock.Anything, mock.Anything).Return([]domain.Hangout{soon, tomorrow}, nil)
+		hangoutRepo.On("GetHangoutsWithRSVPDeadlineBetween", mock.Anything, mock.Anything, mock.Anything).Return([]domain.Hangout{rsvp}, nil)
+		reminderRepo.On("CreateReminders", mock.Anything, mock.MatchedBy(func(reminders []domain.Reminder) bool {
+			if len(reminders) != 3 {
+				return false
+			}
+			first, second, third := reminders[0], reminders[1], reminders[2]
+			return first.HangoutID == soon.ID && first.Kind == constants.ReminderKindHangoutStart && first.OffsetMinutes == 60 &&
+				first.DueAt.Equal(soon.Date.Add(-time.Hour)) && first.UserID == userID && first.Status == constants.ReminderPending && first.NextAttemptAt != nil &&
+				second.HangoutID == tomorrow.ID && second.OffsetMinutes == 24*60 &&
+				third.HangoutID == rsvp.ID && third.Kind == constants.ReminderKindRSVPDeadline && third.DueAt.Equal(rsvpDeadline.Add(-24*time.Hour))
+		})).Return(int64(3), nil)
+
+		scheduled, err := svc.ScheduleDue(ctx)
+		require.NoError(t, err)
+		require.Equal(t, int64(3), scheduled)
+
+		// the lookahead covers the largest offset
+		from := hangoutRepo.Calls[0].Arguments.Get(1).(time.Time)
+		to := hangoutRepo.Calls[0].Arguments.Get(2).(time.Time)
+		require.Equal(t, 24*time.Hour, to.Sub(from))
+		hangoutRepo.AssertExpectations(t)
+		reminderRepo.AssertExpectations(t)
+	})
+
+	t.Run("rsvp reminders disabled", func(t *testing.T) {
+		hangoutRepo := new(MockHangoutRepository)
+		reminderRepo := new(MockReminderRepository)
+		cfg := newReminderTestConfig()
+		cfg.RSVPOffsetMinutes = 0
+		svc := services.NewReminderService(hangoutRepo, reminderRepo, nil, nil, nil, cfg, nil)
+
+		hangoutRepo.On("GetHangoutsStartingBetween", mock.Anything, mock.Anything, mock.Anything).Return([]domain.Hangout{}, nil)
+		reminderRepo.On("CreateReminders", mock.Anything, []domain.Reminder(nil)).Return(int64(0), nil)
+
+		scheduled, err := svc.ScheduleDue(ctx)
+		require.NoError(t, err)
+		require.Zero(t, scheduled)
+		hangoutRepo.AssertNotCalled(t, "GetHangoutsWithRSVPDeadlineBetween", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		hangoutRepo := new(MockHangoutRepository)
+		reminderRepo := new(MockReminderRepository)
+		svc := services.NewReminderService(hangoutRepo, reminderRepo, nil, nil, nil, newReminderTestConfig(), nil)
+
+		hangoutRepo.On("GetHangoutsStartingBetween", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("db down"))
+
+		_, err := svc.ScheduleDue(ctx)
+		require.Error(t, err)
+		reminderRepo.AssertNotCalled(t, "CreateReminders", mock.Anything, mock.Anything)
+	})
+}
+
+func TestReminderService_SendDue(t *testing.T) {
+	ctx := context.Background()
+	dueAt := time.Now().Add(-time.Minute)
+	user := domain.User{ID: uuid.New(), Name: "Ana", Email: "ana@example.com"}
+	sendErr := errors.New("smtp unavailable")
+
+	tests := []struct {
+		name        string
+		attempts    int
+		delivered   string
+		status      enums.HangoutStatus
+		moved       bool
+		claimed     bool
+		emailErr    error
+		wantEmail   bool
+		wantInbox   bool
+		wantStatus  string
+		wantNext    bool
+		wantSent    int
+		wantFailed  int
+		wantChannel string
+	}{
+		{name: "sent on every channel", status: enums.StatusPlanning, claimed: true, wantEmail: true, wantInbox: true, wantStatus: constants.ReminderSent, wantSent: 1, wantChannel: "email,inbox"},
+		{name: "failure is retried later", status: enums.StatusConfirmed, claimed: true, emailErr: sendErr, wantEmail: true, wantInbox: true, wantStatus: constants.ReminderPending, wantNext: true, wantFailed: 1, wantChannel: "inbox"},
+		{name: "retry skips delivered channels", delivered: "inbox", status: enums.StatusPlanning, attempts: 1, claimed: true, wantEmail: true, wantStatus: constants.ReminderSent, wantSent: 1, wantChannel: "inbox,email"},
+		{name: "last attempt fails", attempts: 2, delivered: "inbox", status: enums.StatusPlanning, claimed: true, emailErr: sendErr, wantEmail: true, wantStatus: constants.ReminderFailed, wantFailed: 1, wantChannel: "inbox"},
+		{name: "cancelled hangout is skipped", status: enums.StatusCancelled, claimed: true, wantStatus: constants.ReminderCancelled},
+		{name: "moved hangout is skipped", status: enums.StatusPlanning, moved: true, claimed: true, wantStatus: constants.ReminderCancelled},
+		{name: "claimed by another instance", status: enums.StatusPlanning, claimed: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reminderRepo := new(MockReminderRepository)
+			email := &MockNotificationChannel{name: constants.NotificationChannelEmail}
+			inbox := &MockNotificationChannel{name: constants.NotificationChannelInbox}
+			cfg := newReminderTestConfig()
+			svc := services.NewReminderService(new(MockHangoutRepository), reminderRepo, new(MockNotificationRepository), []notify.Channel{email, inbox}, nil, cfg, nil)
+
+			date := time.Now().Add(time.Hour)
+			hangout := domain.Hangout{ID: uuid.New(), Title: "Picnic", Date: date, Status: tt.status, UserID: &user.ID}
+			reminder := domain.Reminder{
+				ID: uuid.New(), Kind: constants.ReminderKindHangoutStart, OffsetMinutes: 60, DueAt: date.Add(-time.Hour),
+				Status: constants.ReminderPending, Attempts: tt.attempts, NextAttemptAt: &dueAt, DeliveredChannels: tt.delivered,
+				HangoutID: hangout.ID, Hangout: hangout, UserID: user.ID, User: user,
+			}
+			if tt.moved {
+				reminder.Hangout.Date = date.Add(2 * time.Hour)
+			}
+
+			reminderRepo.On("GetDueReminders", mock.Anything, mock.Anything, cfg.BatchSize).Return([]domain.Reminder{reminder}, nil)
+			reminderRepo.On("ClaimReminder", mock.Anything, reminder.ID, dueAt, mock.Anything).Return(tt.claimed, nil)
+			isMessage := mock.MatchedBy(func(msg *notify.Message) bool {
+				return msg.UserID == user.ID && msg.Email == user.Email && *msg.HangoutID == hangout.ID &&
+					msg.Type == constants.NotificationHangoutReminder && msg.Subject == "Reminder: Picnic is coming up"
+			})
+			if tt.wantEmail {
+				email.On("Send", mock.Anything, isMessage).Return(tt.emailErr)
+			}
+			if tt.wantInbox {
+				inbox.On("Send", mock.Anything, isMessage).Return(nil)
+			}
+			if tt.claimed {
+				reminderRepo.On("UpdateReminder", mock.Anything, mock.MatchedBy(func(r *domain.Reminder) bool {
+					if r.Status != tt.wantStatus || r.DeliveredChannels != tt.wantChannel {
+						return false
+					}
+					if tt.wantStatus == constants.ReminderCancelled {
+						return r.Attempts == tt.attempts && r.NextAttemptAt == nil
+					}
+					if r.Attempts != tt.attempts+1 {
+						return false
+					}
+					if tt.wantNext {
+						return r.NextAttemptAt != nil && r.NextAttemptAt.After(time.Now().Add(cfg.GetRetryDelay()-time.Minute)) && r.LastError != ""
+					}
+					return r.NextAttemptAt == nil && (r.Status != constants.ReminderSent || r.SentAt != nil)
+				})).Return(nil)
+			}
+
+			sent, failed, err := svc.SendDue(ctx)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantSent, sent)
+			require.Equal(t, tt.wantFailed, failed)
+			reminderRepo.AssertExpectations(t)
+			email.AssertExpectations(t)
+			inbox.AssertExpectations(t)
+		})
+	}
+}
+
+func TestReminderService_SendDue_RSVPMessage(t *testing.T) {
+	ctx := context.Background()
+	dueAt := time.Now().Add(-time.Minute)
+	user := domain.User{ID: uuid.New(), Name: "Ana", Email: "ana@example.com"}
+	deadline := time.Now().Add(time.Hour)
+	hangout := domain.Hangout{ID: uuid.New(), Title: "Picnic", Date: deadline.Add(24 * time.Hour), RSVPDeadline: &deadline, Status: enums.StatusPlanning, UserID: &user.ID}
+	reminder := domain.Reminder{
+		ID: uuid.New(), Kind: constants.ReminderKindRSVPDeadline, OffsetMinutes: 120, DueAt: deadline.Add(-2 * time.Hour),
+		Status: constants.ReminderPending, NextAttemptAt: &dueAt, HangoutID: hangout.ID, Hangout: hangout, UserID: user.ID, User: user,
+	}
+
+	reminderRepo := new(MockReminderRepository)
+	inbox := &MockNotificationChannel{name: constants.NotificationChannelInbox}
+	svc := services.NewReminderService(new(MockHangoutRepository), reminderRepo, new(MockNotificationRepository), []notify.Channel{inbox}, nil, newReminderTestConfig(), nil)
+
+	reminderRepo.On("GetDueReminders", mock.Anything, mock.Anything, mock.Anything).Return([]domain.Reminder{reminder}, nil)
+	reminderRepo.On("ClaimReminder", mock.Anything, reminder.ID, dueAt, mock.Anything).Return(true, nil)
+	inbox.On("Send", mock.Anything, mock.MatchedBy(func(msg *notify.Message) bool {
+		return msg.Type == constants.NotificationRSVPDeadline && msg.Subject == "RSVP deadline for Picnic is approaching"
+	})).Return(nil)
+	reminderRepo.On("UpdateReminder", mock.Anything, mock.Anything).Return(nil)
+
+	sent, failed, err := svc.SendDue(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, sent)
+	require.Zero(t, failed)
+	inbox.AssertExpectations(t)
+}
+
+func TestReminderService_SendDue_LeaseStartsAtClaim(t *testing.T) {
+	ctx := context.Background()
+	dueAt := time.Now().Add(-time.Minute)
+	user := domain.User{ID: uuid.New(), Name: "Ana", Email: "ana@example.com"}
+	date := time.Now().Add(time.Hour)
+	hangout := domain.Hangout{ID: uuid.New(), Title: "Picnic", Date: date, Status: enums.StatusPlanning, UserID: &user.ID}
+	sendTime := 50 * time.Millisecond
+
+	reminderRepo := new(MockReminderRepository)
+	email := &MockNotificationChannel{name: constants.NotificationChannelEmail}
+	svc := services.NewReminderService(new(MockHangoutRepository), reminderRepo, new(MockNotificationRepository), []notify.Channel{email}, nil, newReminderTestConfig(), nil)
+
+	due := make([]domain.Reminder, 2)
+	for i := range due {
+		due[i] = domain.Reminder{
+			ID: uuid.New(), Kind: constants.ReminderKindHangoutStart, OffsetMinutes: 60, DueAt: date.Add(-time.Hour),
+			Status: constants.ReminderPending, NextAttemptAt: &dueAt, HangoutID: hangout.ID, Hangout: hangout, UserID: user.ID, User: user,
+		}
+	}
+	var leases []time.Time
+	reminderRepo.On("GetDueReminders", mock.Anything, mock.Anything, mock.Anything).Return(due, nil)
+	reminderRepo.On("ClaimReminder", mock.Anything, mock.Anything, dueAt, mock.AnythingOfType("time.Time")).
+		Run(func(args mock.Arguments) { leases = append(leases, args.Get(3).(time.Time)) }).
+		Return(true, nil)
+	// every send takes a while, as a stalled mail server would
+	email.On("Send", mock.Anything, mock.Anything).
+		Run(func(mock.Arguments) { time.Sleep(sendTime) }).
+		Return(nil)
+	reminderRepo.On("UpdateReminder", mock.Anything, mock.Anything).Return(nil)
+
+	sent, _, err := svc.SendDue(ctx)
+
+	require.NoError(t, err)
+	require.Equal(t, 2, sent)
+	require.Len(t, leases, 2)
+	require.GreaterOrEqual(t, leases[1].Sub(leases[0]), sendTime)
+}
+
+func TestReminderService_SendQueuedEmails(t *testing.T) {
+	ctx := context.Background()
+	dueAt := time.Now().Add(-time.Minute)
+	user := domain.User{ID: uuid.New(), Name: "Ana", Email: "ana@example.com"}
+	hangoutID := uuid.New()
+	sendErr := errors.New("smtp unavailable")
+
+	tests := []struct {
+		name       string
+		attempts   int
+		deleted    bool
+		claimed    bool
+		emailErr   error
+		wantSend   bool
+		wantStatus string
+		wantNext   bool
+		wantSent   int
+		wantFailed int
+	}{
+		{name: "sent", claimed: true, wantSend: true, wantStatus: constants.ReminderSent, wantSent: 1},
+		{name: "failure is retried later", claimed: true, emailErr: sendErr, wantSend: true, wantStatus: constants.ReminderPending, wantNext: true, wantFailed: 1},
+		{name: "last attempt fails", attempts: 2, claimed: true, emailErr: sendErr, wantSend: true, wantStatus: constants.ReminderFailed, wantFailed: 1},
+		{name: "deleted recipient is skipped", deleted: true, claimed: true, wantStatus: constants.ReminderCancelled},
+		{name: "claimed by another instance"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notificationRepo := new(MockNotificationRepository)
+			email := &MockNotificationChannel{name: constants.NotificationChannelEmail}
+			cfg := newReminderTestConfig()
+			svc := services.NewReminderService(new(MockHangoutRepository), new(MockReminderRepository), notificationRepo, nil, email, cfg, nil)
+
+			queued := domain.NotificationEmail{
+				ID: uuid.New(), Type: constants.NotificationMemoryCreated, Subject: "New memory in Picnic", Body: "beach.jpg was added to Picnic.",
+				Status: constants.ReminderPending, Attempts: tt.attempts, NextAttemptAt: &dueAt, HangoutID: &hangoutID, UserID: user.ID, User: user,
+			}
+			if tt.deleted {
+				queued.User = domain.User{}
+			}
+
+			notificationRepo.On("GetDueEmails", mock.Anything, mock.Anything, cfg.BatchSize).Return([]domain.NotificationEmail{queued}, nil)
+			notificationRepo.On("ClaimEmail", mock.Anything, queued.ID, dueAt, mock.Anything).Return(tt.claimed, nil)
+			if tt.wantSend {
+				email.On("Send", mock.Anything, mock.MatchedBy(func(msg *notify.Message) bool {
+					return msg.UserID == user.ID && msg.Name == user.Name && msg.Email == user.Email && *msg.HangoutID == hangoutID &&
+						msg.Type == queued.Type && msg.Subject == queued.Subject && msg.Body == queued.Body
+				})).Return(tt.emailErr)
+			}
+			if tt.claimed {
+				notificationRepo.On("UpdateEmail", mock.Anything, mock.MatchedBy(func(e *domain.NotificationEmail) bool {
+					if e.Status != tt.wantStatus {
+						return false
+					}
+					if tt.wantStatus == constants.ReminderCancelled {
+						return e.Attempts == tt.attempts && e.NextAttemptAt == nil
+					}
+					if e.Attempts != tt.attempts+1 {
+						return false
+					}
+					if tt.wantNext {
+						return e.NextAttemptAt != nil && e.LastError != ""
+					}
+					return e.NextAttemptAt == nil && (e.Status != constants.ReminderSent || e.SentAt != nil)
+				})).Return(nil)
+			}
+
+			sent, failed, err := svc.SendQueuedEmails(ctx)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantSent, sent)
+			require.Equal(t, tt.wantFailed, failed)
+			notificationRepo.AssertExpectations(t)
+			email.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,279 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/cache"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/config"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants/logmsg"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/mapper"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/otel"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/repository"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/utils"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"gorm.io/gorm"
+)
+
+// SessionService tracks the devices a user is signed in on.
+type SessionService interface {
+	// Start records a session for user and returns an access token bound to it.
+	Start(ctx context.Context, user *domain.User, client dto.SessionClient) (string, error)
+	// Validate returns ErrSessionRevoked unless the session belongs to userID and
+	// is neither revoked nor expired.
+	Validate(ctx context.Context, userID uuid.UUID, sessionID uuid.UUID) error
+	ListSessions(ctx context.Context, userID uuid.UUID, currentID uuid.UUID) ([]*dto.SessionResponse, error)
+	RevokeSession(ctx context.Context, userID uuid.UUID, sessionID uuid.UUID) error
+	// RevokeAllSessions signs the user out everywhere, the calling device
+	// included, and returns how many sessions were revoked.
+	RevokeAllSessions(ctx context.Context, userID uuid.UUID) (int64, error)
+	// StartedAt returns when the user signed in on the session, or
+	// ErrSessionRevoked unless the session is theirs and still active.
+	StartedAt(ctx context.Context, userID uuid.UUID, sessionID uuid.UUID) (time.Time, error)
+	PurgeExpired(ctx context.Context) (int64, error)
+}
+
+// cachedSession is what Validate remembers about a session.
+type cachedSession struct {
+	userID    uuid.UUID
+	expiresAt time.Time
+	revoked   bool
+}
+
+type sessionService struct {
+	sessionRepo repository.SessionRepository
+	jwtUtils    utils.JWTUtils
+	jwtCfg      *config.JwtConfig
+	cache       *cache.TTL[uuid.UUID, cachedSession]
+	metrics     *otel.MetricsRecorder
+}
+
+func NewSessionService(sessionRepo repository.SessionRepository, jwtUtils utils.JWTUtils, jwtCfg *config.JwtConfig, cfg *config.SessionConfig, metrics *otel.MetricsRecorder) SessionService {
+	return &sessionService{
+		sessionRepo: sessionRepo,
+		jwtUtils:    jwtUtils,
+		jwtCfg:      jwtCfg,
+		cache:       cache.NewTTL[uuid.UUID, cachedSession](cfg.GetCacheTTL(), time.Now),
+		metrics:     metrics,
+	}
+}
+
+func (s *sessionService) Start(ctx context.Context, user *domain.User, client dto.SessionClient) (string, error) {
+	recordMetrics := s.metrics.StartRequest(ctx, "session", "start")
+
+	ctx, span := otel.StartServiceSpan(ctx, "StartSession",
+		attribute.String("user.id", user.ID.String()),
+	)
+	defer span.End()
+
+	if user.DisabledAt != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(apperrors.ErrAccountDisabled)
+		return "", apperrors.ErrAccountDisabled
+	}
+
+	now := time.Now()
+	session := &domain.Session{
+		UserID:     user.ID,
+		UserAgent:  truncateRunes(client.UserAgent, constants.MaxSessionUserAgentLength),
+		IPAddress:  client.IPAddress,
+		LastUsedAt: now,
+		ExpiresAt:  now.Add(s.jwtCfg.GetExpiration()),
+	}
+	err := s.sessionRepo.Create(ctx, session)
+
+	var token string
+	if err == nil {
+		token, err = s.jwtUtils.Generate(user, session.ID)
+	}
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return "", err
+	}
+
+	span.SetAttributes(attribute.String("session.id", session.ID.String()))
+	span.SetStatusOk()
+	recordMetrics("success")
+	return token, nil
+}
+
+func (s *sessionService) Validate(ctx context.Context, userID uuid.UUID, sessionID uuid.UUID) error {
+	if cached, ok := s.cache.Get(sessionID); ok {
+		return cached.check(userID, time.Now())
+	}
+
+	recordMetrics := s.metrics.StartRequest(ctx, "session", "validate")
+
+	ctx, span := otel.StartServiceSpan(ctx, "ValidateSession",
+		attribute.String("user.id", userID.String()),
+		attribute.String("session.id", sessionID.String()),
+	)
+	defer span.End()
+
+	session, err := s.sessionRepo.GetByID(ctx, sessionID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return err
+	}
+
+	now := time.Now()
+	cached := cachedSession{}
+	if session != nil {
+		cached = cachedSession{userID: session.UserID, expiresAt: session.ExpiresAt, revoked: session.RevokedAt != nil}
+	}
+	s.cache.Set(sessionID, cached)
+
+	err = cached.check(userID, now)
+	if err == nil && now.Sub(session.LastUsedAt) >= time.Duration(constants.SessionTouchIntervalSeconds)*time.Second {
+		// a stale last use is not worth failing the request over
+		if touchErr := s.sessionRepo.Touch(ctx, sessionID, now); touchErr != nil {
+			log.Printf(logmsg.SessionTouchFailed, sessionID, touchErr)
+		}
+	}
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return err
+	}
+
+	span.SetStatusOk()
+	recordMetrics("success")
+	return nil
+}
+
+func (c cachedSession) check(userID uuid.UUID, now time.Time) error {
+	if c.userID != userID || c.revoked || !now.Before(c.expiresAt) {
+		return apperrors.ErrSessionRevoked
+	}
+	return nil
+}
+
+func (s *sessionService) ListSessions(ctx context.Context, userID uuid.UUID, currentID uuid.UUID) ([]*dto.SessionResponse, error) {
+	recordMetrics := s.metrics.StartRequest(ctx, "session", "list")
+
+	ctx, span := otel.StartServiceSpan(ctx, "ListSessions",
+		attribute.String("user.id", userID.String()),
+	)
+	defer span.End()
+
+	sessions, err := s.sessionRepo.ListActiveByUserID(ctx, userID, time.Now())
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetStatusOk()
+	recordMetrics("success")
+	return mapper.SessionsToResponseDTOs(sessions, currentID), nil
+}
+
+func (s *sessionService) RevokeSession(ctx context.Context, userID uuid.UUID, sessionID uuid.UUID) error {
+	recordMetrics := s.metrics.StartRequest(ctx, "session", "revoke")
+
+	ctx, span := otel.StartServiceSpan(ctx, "RevokeSession",
+		attribute.String("user.id", userID.String()),
+		attribute.String("session.id", sessionID.String()),
+	)
+	defer span.End()
+
+	revoked, err := s.sessionRepo.Revoke(ctx, userID, sessionID, time.Now())
+	if err == nil && revoked == 0 {
+		err = apperrors.ErrSessionNotFound
+	}
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return err
+	}
+	s.cache.Delete(sessionID)
+
+	span.SetStatusOk()
+	recordMetrics("success")
+	return nil
+}
+
+func (s *sessionService) RevokeAllSessions(ctx context.Context, userID uuid.UUID) (int64, error) {
+	recordMetrics := s.metrics.StartRequest(ctx, "session", "revoke_all")
+
+	ctx, span := otel.StartServiceSpan(ctx, "RevokeAllSessions",
+		attribute.String("user.id", userID.String()),
+	)
+	defer span.End()
+
+	now := time.Now()
+	// the IDs are only needed to drop cached checks on this instance
+	active, err := s.sessionRepo.ListActiveByUserID(ctx, userID, now)
+	var revoked int64
+	if err == nil {
+		revoked, err = s.sessionRepo.RevokeAllByUserID(ctx, userID, now)
+	}
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return 0, err
+	}
+	for i := range active {
+		s.cache.Delete(active[i].ID)
+	}
+
+	span.SetAttributes(attribute.Int64("session.revoked", revoked))
+	span.SetStatusOk()
+	recordMetrics("success")
+	return revoked, nil
+}
+
+func (s *sessionService) StartedAt(ctx context.Context, userID uuid.UUID, sessionID uuid.UUID) (time.Time, error) {
+	recordMetrics := s.metrics.StartRequest(ctx, "session", "started_at")
+
+	ctx, span := otel.StartServiceSpan(ctx, "SessionStartedAt",
+		attribute.String("user.id", userID.String()),
+		attribute.String("session.id", sessionID.String()),
+	)
+	defer span.End()
+
+	session, err := s.sessionRepo.GetByID(ctx, sessionID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		err = apperrors.ErrSessionRevoked
+	}
+	if err == nil {
+		err = cachedSession{userID: session.UserID, expiresAt: session.ExpiresAt, revoked: session.RevokedAt != nil}.check(userID, time.Now())
+	}
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return time.Time{}, err
+	}
+
+	span.SetStatusOk()
+	recordMetrics("success")
+	return session.CreatedAt, nil
+}
+
+func (s *sessionService) PurgeExpired(ctx context.Context) (int64, error) {
+	recordMetrics := s.metrics.StartRequest(ctx, "session", "purge")
+
+	ctx, span := otel.StartServiceSpan(ctx, "PurgeExpiredSessions")
+	defer span.End()
+
+	deleted, err := s.sessionRepo.DeleteExpired(ctx, time.Now())
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return 0, err
+	}
+
+	span.SetAttributes(attribute.Int64("session.deleted", deleted))
+	span.SetStatusOk()
+	recordMetrics("success")
+	return deleted, nil
+}
@@ -0,0 +1,426 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/config"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/services"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+type sessionMocks struct {
+	repo *MockSessionRepository
+	jwt  *MockJWTUtils
+}
+
+func newSessionService(t *testing.T) (services.SessionService, *sessionMocks) {
+	t.Helper()
+	m := &sessionMocks{
+		repo: new(MockSessionRepository),
+		jwt:  new(MockJWTUtils),
+	}
+	svc := services.NewSessionService(m.repo, m.jwt, &config.JwtConfig{JWTExpirationHours: 24}, &config.SessionConfig{CacheTTLSeconds: 30}, nil)
+	return svc, m
+}
+
+func TestSessionService_Start(t *testing.T) {
+	ctx := context.Background()
+	user := &domain.User{ID: uuid.New(), Email: "alice@example.com"}
+	sessionID := uuid.New()
+	longAgent := strings.Repeat("a", constants.MaxSessionUserAgentLength+10)
+	dbErr := errors.New("db error")
+
+	tests := map[string]struct {
+		disabled  bool
+		setup     func(m *sessionMocks)
+		wantToken string
+		wantErr   error
+	}{
+		"Success": {
+			setup: func(m *sessionMocks) {
+				m.repo.On("Create", mock.Anything, mock.MatchedBy(func(s *domain.Session) bool {
+					return s.UserID == user.ID &&
+						s.IPAddress == "203.0.113.7" &&
+						len(s.UserAgent) == constants.MaxSessionUserAgentLength &&
+						s.ExpiresAt.Sub(s.LastUsedAt) == 24*time.Hour
+				})).Run(func(args mock.Arguments) {
+					args.Get(1).(*domain.Session).ID = sessionID
+				}).Return(nil)
+				m.jwt.On("Generate", user, sessionID).Return("signed.jwt.token", nil)
+			},
+			wantToken: "signed.jwt.token",
+		},
+		"Create error": {
+			setup: func(m *sessionMocks) {
+				m.repo.On("Create", mock.Anything, mock.Anything).Return(dbErr)
+			},
+			wantErr: dbErr,
+		},
+		"Signing error": {
+			setup: func(m *sessionMocks) {
+				m.repo.On("Create", mock.Anything, mock.Anything).Return(nil)
+				m.jwt.On("Generate", user, mock.Anything).Return("", apperrors.ErrNoSigningKey)
+			},
+			wantErr: apperrors.ErrNoSigningKey,
+		},
+		"Disabled user": {
+			disabled: true,
+			setup:    func(m *sessionMocks) {},
+			wantErr:  apperrors.ErrAccountDisabled,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			svc, m := newSessionService(t)
+			tt.setup(m)
+
+			user := *user
+			if tt.disabled {
+				disabledAt := time.Now()
+				user.DisabledAt = &disabledAt
+			}
+			token, err := svc.Start(ctx, &user, dto.SessionClient{IPAddress: "203.0.113.7", UserAgent: longAgent})
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				require.Empty(t, token)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.wantToken, token)
+			}
+			m.repo.AssertExpectations(t)
+			m.jwt.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSessionService_Validate(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	sessionID := uuid.New()
+	dbErr := errors.New("db error")
+
+	active := func() *domain.Session {
+		now := time.Now()
+		return &domain.Session{ID: sessionID, UserID: userID, LastUsedAt: now, ExpiresAt: now.Add(time.Hour)}
+	}
+
+	tests := map[string]struct {
+		setup   func(m *sessionMocks)
+		userID  uuid.UUID
+		wantErr error
+	}{
+		"Active session": {
+			setup: func(m *sessionMocks) {
+				m.repo.On("GetByID", mock.Anything, sessionID).Return(active(), nil).Once()
+			},
+			userID: userID,
+		},
+		"Stale last use is touched": {
+			setup: func(m *sessionMocks) {
+				session := active()
+				session.LastUsedAt = time.Now().Add(-time.Hour)
+				m.repo.On("GetByID", mock.Anything, sessionID).Return(session, nil).Once()
+				m.repo.On("Touch", mock.Anything, sessionID, mock.AnythingOfType("time.Time")).Return(nil).Once()
+			},
+			userID: userID,
+		},
+		"Touch error does not fail the request": {
+			setup: func(m *sessionMocks) {
+				session := active()
+				session.LastUsedAt = time.Now().Add(-time.Hour)
+				m.repo.On("GetByID", mock.Anything, sessionID).Return(session, nil).Once()
+				m.repo.On("Touch", mock.Anything, sessionID, mock.Anything).Return(dbErr).Once()
+			},
+			userID: userID,
+		},
+		"Revoked session": {
+			setup: func(m *sessionMocks) {
+				session := active()
+				revokedAt := time.Now()
+				session.RevokedAt = &revokedAt
+				m.repo.On("GetByID", mock.Anything, sessionID).Return(session, nil).Once()
+			},
+			userID:  userID,
+			wantErr: apperrors.ErrSessionRevoked,
+		},
+		"Expired session": {
+			setup: func(m *sessionMocks) {
+				session := active()
+				session.ExpiresAt = time.Now().Add(-time.Minute)
+				m.repo.On("GetByID", mock.Anything, sessionID).Return(session, nil).Once()
+			},
+			userID:  userID,
+			wantErr: apperrors.ErrSessionRevoked,
+		},
+		"Session of another user": {
+			setup: func(m *sessionMocks) {
+				m.repo.On("GetByID", mock.Anything, sessionID).Return(active(), nil).Once()
+			},
+			userID:  uuid.New(),
+			wantErr: apperrors.ErrSessionRevoked,
+		},
+		"Unknown session": {
+			setup: func(m *sessionMocks) {
+				m.repo.On("GetByID", mock.Anything, sessionID).Return(nil, gorm.ErrRecordNotFound).Once()
+			},
+			userID:  userID,
+			wantErr: apperrors.ErrSessionRevoked,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			svc, m := newSessionService(t)
+			tt.setup(m)
+
+			// the second check is answered from the cache
+			for i := 0; i < 2; i++ {
+				err := svc.Validate(ctx, tt.userID, sessionID)
+				if tt.wantErr != nil {
+					require.ErrorIs(t, err, tt.wantErr)
+				} else {
+					require.NoError(t, err)
+				}
+			}
+			m.repo.AssertExpectations(t)
+		})
+	}
+
+	t.Run("Lookup errors are not cached", func(t *testing.T) {
+		svc, m := newSessionService(t)
+		m.repo.On("GetByID", mock.Anything, sessionID).Return(nil, dbErr).Once()
+		m.repo.On("GetByID", mock.Anything, sessionID).Return(active(), nil).Once()
+
+		require.ErrorIs(t, svc.Validate(ctx, userID, sessionID), dbErr)
+		require.NoError(t, svc.Validate(ctx, userID, sessionID))
+		m.repo.AssertExpectations(t)
+	})
+}
+
+func TestSessionService_ListSessions(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	currentID := uuid.New()
+	dbErr := errors.New("db error")
+
+	tests := map[string]struct {
+		setup     func(m *sessionMocks)
+		wantCount int
+		wantErr   error
+	}{
+		"Success": {
+			setup: func(m *sessionMocks) {
+				m.repo.On("ListActiveByUserID", mock.Anything, userID, mock.AnythingOfType("time.Time")).
+					Return([]domain.Session{{ID: currentID, UserID: userID}, {ID: uuid.New(), UserID: userID}}, nil)
+			},
+			wantCount: 2,
+		},
+		"Repository error": {
+			setup: func(m *sessionMocks) {
+				m.repo.On("ListActiveByUserID", mock.Anything, userID, mock.Anything).Return(nil, dbErr)
+			},
+			wantErr: dbErr,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			svc, m := newSessionService(t)
+			tt.setup(m)
+
+			sessions, err := svc.ListSessions(ctx, userID, currentID)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				require.Nil(t, sessions)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, sessions, tt.wantCount)
+				require.True(t, sessions[0].Current)
+				require.False(t, sessions[1].Current)
+			}
+			m.repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSessionService_RevokeSession(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	sessionID := uuid.New()
+	dbErr := errors.New("db error")
+
+	tests := map[string]struct {
+		setup   func(m *sessionMocks)
+		wantErr error
+	}{
+		"Success": {
+			setup: func(m *sessionMocks) {
+				m.repo.On("Revoke", mock.Anything, userID, sessionID, mock.AnythingOfType("time.Time")).Return(int64(1), nil)
+			},
+		},
+		"Not found": {
+			setup: func(m *sessionMocks) {
+				m.repo.On("Revoke", mock.Anything, userID, sessionID, mock.Anything).Return(int64(0), nil)
+			},
+			wantErr: apperrors.ErrSessionNotFound,
+		},
+		"Repository error": {
+			setup: func(m *sessionMocks) {
+				m.repo.On("Revoke", mock.Anything, userID, sessionID, mock.Anything).Return(int64(0), dbErr)
+			},
+			wantErr: dbErr,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			svc, m := newSessionService(t)
+			tt.setup(m)
+
+			err := svc.RevokeSession(ctx, userID, sessionID)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			m.repo.AssertExpectations(t)
+		})
+	}
+
+	t.Run("Revoking drops the cached check", func(t *testing.T) {
+		svc, m := newSessionService(t)
+		now := time.Now()
+		session := &domain.Session{ID: sessionID, UserID: userID, LastUsedAt: now, ExpiresAt: now.Add(time.Hour)}
+		m.repo.On("GetByID", mock.Anything, sessionID).Return(session, nil).Once()
+		m.repo.On("Revoke", mock.Anything, userID, sessionID, mock.Anything).Return(int64(1), nil)
+		revoked := *session
+		revoked.RevokedAt = &now
+		m.repo.On("GetByID", mock.Anything, sessionID).Return(&revoked, nil).Once()
+
+		require.NoError(t, svc.Validate(ctx, userID, sessionID))
+		require.NoError(t, svc.RevokeSession(ctx, userID, sessionID))
+		require.ErrorIs(t, svc.Validate(ctx, userID, sessionID), apperrors.ErrSessionRevoked)
+		m.repo.AssertExpectations(t)
+	})
+}
+
+func TestSessionService_RevokeAllSessions(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	dbErr := errors.New("db error")
+
+	tests := map[string]struct {
+		setup       func(m *sessionMocks)
+		wantRevoked int64
+		wantErr     error
+	}{
+		"Success": {
+			setup: func(m *sessionMocks) {
+				m.repo.On("ListActiveByUserID", mock.Anything, userID, mock.AnythingOfType("time.Time")).
+					Return([]domain.Session{{ID: uuid.New()}, {ID: uuid.New()}}, nil)
+				m.repo.On("RevokeAllByUserID", mock.Anything, userID, mock.AnythingOfType("time.Time")).Return(int64(2), nil)
+			},
+			wantRevoked: 2,
+		},
+		"List error": {
+			setup: func(m *sessionMocks) {
+				m.repo.On("ListActiveByUserID", mock.Anything, userID, mock.Anything).Return(nil, dbErr)
+			},
+			wantErr: dbErr,
+		},
+		"Revoke error": {
+			setup: func(m *sessionMocks) {
+				m.repo.On("ListActiveByUserID", mock.Anything, userID, mock.Anything).Return([]domain.Session{}, nil)
+				m.repo.On("RevokeAllByUserID", mock.Anything, userID, mock.Anything).Return(int64(0), dbErr)
+			},
+			wantErr: dbErr,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			svc, m := newSessionService(t)
+			tt.setup(m)
+
+			revoked, err := svc.RevokeAllSessions(ctx, userID)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.wantRevoked, revoked)
+			}
+			m.repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSessionService_StartedAt(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	sessionID := uuid.New()
+	now := time.Now()
+	createdAt := now.Add(-time.Minute)
+	active := &domain.Session{ID: sessionID, UserID: userID, ExpiresAt: now.Add(time.Hour), CreatedAt: createdAt}
+
+	tests := map[string]struct {
+		session *domain.Session
+		repoErr error
+		wantErr error
+	}{
+		"Success":          {session: active},
+		"Not found":        {repoErr: gorm.ErrRecordNotFound, wantErr: apperrors.ErrSessionRevoked},
+		"Revoked":          {session: &domain.Session{ID: sessionID, UserID: userID, ExpiresAt: now.Add(time.Hour), RevokedAt: &now}, wantErr: apperrors.ErrSessionRevoked},
+		"Another user's":   {session: &domain.Session{ID: sessionID, UserID: uuid.New(), ExpiresAt: now.Add(time.Hour)}, wantErr: apperrors.ErrSessionRevoked},
+		"Expired":          {session: &domain.Session{ID: sessionID, UserID: userID, ExpiresAt: now.Add(-time.Minute)}, wantErr: apperrors.ErrSessionRevoked},
+		"Repository error": {repoErr: errors.New("db error"), wantErr: errors.New("db error")},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			svc, m := newSessionService(t)
+			m.repo.On("GetByID", mock.Anything, sessionID).Return(tt.session, tt.repoErr)
+
+			startedAt, err := svc.StartedAt(ctx, userID, sessionID)
+			if tt.wantErr != nil {
+				require.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, createdAt, startedAt)
+			}
+			m.repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSessionService_PurgeExpired(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		svc, m := newSessionService(t)
+		m.repo.On("DeleteExpired", mock.Anything, mock.AnythingOfType("time.Time")).Return(int64(3), nil)
+
+		deleted, err := svc.PurgeExpired(ctx)
+		require.NoError(t, err)
+		require.Equal(t, int64(3), deleted)
+	})
+
+	t.Run("Repository error", func(t *testing.T) {
+		svc, m := newSessionService(t)
+		m.repo.On("DeleteExpired", mock.Anything, mock.Anything).Return(int64(0), errors.New("db error"))
+
+		_, err := svc.PurgeExpired(ctx)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,478 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/config"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants/logmsg"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/grpc"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/mapper"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/otel"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/repository"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/utils"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"gorm.io/gorm"
+)
+
+// ShareLinkService manages view-only links to a hangout or one of its albums for
+// people without an account.
+type ShareLinkService interface {
+	CreateShareLink(ctx context.Context, userID uuid.UUID, hangoutID uuid.UUID, req *dto.CreateShareLinkRequest) (*dto.ShareLinkCreatedResponse, error)
+	ListShareLinks(ctx context.Context, userID uuid.UUID, hangoutID uuid.UUID) ([]dto.ShareLinkResponse, error)
+	RevokeShareLink(ctx context.Context, userID uuid.UUID, shareLinkID uuid.UUID) error
+	ListAccesses(ctx context.Context, userID uuid.UUID, shareLinkID uuid.UUID, pagination *dto.CursorPagination) (*dto.PaginatedShareLinkAccesses, error)
+	GetSharedHangout(ctx context.Context, access *dto.ShareAccess) (*dto.SharedHangoutResponse, error)
+	ListSharedMemories(ctx context.Context, access *dto.ShareAccess, albumID *uuid.UUID, pagination *dto.CursorPagination) (*dto.PaginatedSharedMemories, error)
+}
+
+type shareLinkService struct {
+	repo        repository.ShareLinkRepository
+	hangoutRepo repository.HangoutRepository
+	albumRepo   repository.AlbumRepository
+	memoryRepo  repository.MemoryRepository
+	fileService grpc.FileService
+	bcrypt      utils.BcryptUtils
+	cfg         *config.ShareConfig
+	metrics     *otel.MetricsRecorder
+}
+
+func NewShareLinkService(repo repository.ShareLinkRepository, hangoutRepo repository.HangoutRepository, albumRepo repository.AlbumRepository, memoryRepo repository.MemoryRepository, fileService grpc.FileService, bcrypt utils.BcryptUtils, cfg *config.ShareConfig, metrics *otel.MetricsRecorder) ShareLinkService {
+	return &shareLinkService{
+		repo:        repo,
+		hangoutRepo: hangoutRepo,
+		albumRepo:   albumRepo,
+		memoryRepo:  memoryRepo,
+		fileService: fileService,
+		bcrypt:      bcrypt,
+		cfg:         cfg,
+		metrics:     metrics,
+	}
+}
+
+// CreateShareLink creates a link and returns its token.
+func (s *shareLinkService) CreateShareLink(ctx context.Context, userID uuid.UUID, hangoutID uuid.UUID, req *dto.CreateShareLinkRequest) (*dto.ShareLinkCreatedResponse, error) {
+	recordMetrics := s.metrics.StartRequest(ctx, "share_link", "create")
+
+	ctx, span := otel.StartServiceSpan(ctx, "CreateShareLink",
+		attribute.String("user.id", userID.String()),
+		attribute.String("hangout.id", hangoutID.String()),
+	)
+	defer span.End()
+
+	link, err := s.newShareLink(ctx, userID, hangoutID, req)
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	token, err := utils.GenerateToken(constants.ShareTokenPrefix)
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+	link.TokenHash = utils.HashToken(token)
+
+	if err := s.repo.CreateShareLink(ctx, link); err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetAttributes(
+		attribute.String("share_link.id", link.ID.String()),
+		attribute.String("share_link.scope", link.Scope),
+	)
+	span.SetStatusOk()
+	recordMetrics("success")
+	return mapper.ShareLinkToCreatedResponseDTO(link, token), nil
+}
+
+// newShareLink validates the request and builds the link without its token.
+func (s *shareLinkService) newShareLink(ctx context.Context, userID uuid.UUID, hangoutID uuid.UUID, req *dto.CreateShareLinkRequest) (*domain.ShareLink, error) {
+	if _, err := s.getHangoutForOrganizer(ctx, userID, hangoutID); err != nil {
+		return nil, err
+	}
+
+	link, err := mapper.ShareLinkCreateRequestToModel(req, hangoutID, userID)
+	if err != nil {
+		return nil, err
+	}
+	link.Scope = constants.ShareScopeHangout
+
+	now := time.Now()
+	if link.ExpiresAt != nil && (!link.ExpiresAt.After(now) || link.ExpiresAt.After(now.Add(s.cfg.GetMaxExpiry()))) {
+		return nil, apperrors.ErrInvalidShareExpiry
+	}
+
+	if req.AlbumID != nil {
+		album, err := s.albumRepo.GetAlbumByID(ctx, *req.AlbumID)
+		if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && album.HangoutID != hangoutID) {
+			return nil, apperrors.ErrInvalidAlbumID
+		}
+		if err != nil {
+			return nil, err
+		}
+		link.Scope = constants.ShareScopeAlbum
+		link.AlbumID = &album.ID
+	}
+
+	count, err := s.repo.CountActiveShareLinksByHangoutID(ctx, hangoutID, now)
+	if err != nil {
+		return nil, err
+	}
+	if count >= constants.MaxShareLinksPerHangout {
+		return nil, apperrors.ErrShareLinkLimitReached
+	}
+
+	if req.Password != nil {
+		passwordHash, err := s.bcrypt.GenerateFromPassword(*req.Password)
+		if err != nil {
+			return nil, err
+		}
+		link.PasswordHash = &passwordHash
+	}
+
+	return link, nil
+}
+
+func (s *shareLinkService) ListShareLinks(ctx context.Context, userID uuid.UUID, hangoutID uuid.UUID) ([]dto.ShareLinkResponse, error) {
+	recordMetrics := s.metrics.StartRequest(ctx, "share_link", "list")
+
+	ctx, span := otel.StartServiceSpan(ctx, "ListShareLinks",
+		attribute.String("user.id", userID.String()),
+		attribute.String("hangout.id", hangoutID.String()),
+	)
+	defer span.End()
+
+	if _, err := s.getHangoutForOrganizer(ctx, userID, hangoutID); err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	links, err := s.repo.GetShareLinksByHangoutID(ctx, hangoutID)
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("share_link.count", len(links)))
+	span.SetStatusOk()
+	recordMetrics("success")
+	return mapper.ShareLinksToResponseDTOs(links), nil
+}
+
+// RevokeShareLink stops the link from working.
+func (s *shareLinkService) RevokeShareLink(ctx context.Context, userID uuid.UUID, shareLinkID uuid.UUID) error {
+	recordMetrics := s.metrics.StartRequest(ctx, "share_link", "revoke")
+
+	ctx, span := otel.StartServiceSpan(ctx, "RevokeShareLink",
+		attribute.String("user.id", userID.String()),
+		attribute.String("share_link.id", shareLinkID.String()),
+	)
+	defer span.End()
+
+	link, err := s.getShareLinkForOrganizer(ctx, userID, shareLinkID)
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return err
+	}
+
+	if err := s.repo.RevokeShareLink(ctx, link.ID, time.Now()); err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return err
+	}
+
+	span.SetStatusOk()
+	recordMetrics("success")
+	return nil
+}
+
+func (s *shareLinkService) ListAccesses(ctx context.Context, userID uuid.UUID, shareLinkID uuid.UUID, pagination *dto.CursorPagination) (*dto.PaginatedShareLinkAccesses, error) {
+	recordMetrics := s.metrics.StartRequest(ctx, "share_link", "list_accesses")
+
+	ctx, span := otel.StartServiceSpan(ctx, "ListShareLinkAccesses",
+		attribute.String("user.id", userID.String()),
+		attribute.String("share_link.id", shareLinkID.String()),
+		attribute.Int("pagination.limit", pagination.GetLimit()),
+	)
+	defer span.End()
+
+	if _, err := s.getShareLinkForOrganizer(ctx, userID, shareLinkID); err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	accesses, err := s.repo.GetAccessesByShareLinkID(ctx, shareLinkID, pagination)
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	var nextCursor *uuid.UUID
+	limit := pagination.GetLimit()
+	hasMore := len(accesses) > limit
+	if hasMore {
+		nextCursor = &accesses[limit-1].ID
+		accesses = accesses[:limit]
+	}
+
+	span.SetAttributes(
+		attribute.Int("share_link.access_count", len(accesses)),
+		attribute.Bool("pagination.has_more", hasMore),
+	)
+	span.SetStatusOk()
+	recordMetrics("success")
+	return &dto.PaginatedShareLinkAccesses{
+		Data:       mapper.ShareLinkAccessesToResponseDTOs(accesses),
+		NextCursor: nextCursor,
+		HasMore:    hasMore,
+	}, nil
+}
+
+// GetSharedHangout returns the public summary of the hangout or album behind
+// a share link.
+func (s *shareLinkService) GetSharedHangout(ctx context.Context, access *dto.ShareAccess) (*dto.SharedHangoutResponse, error) {
+	recordMetrics := s.metrics.StartRequest(ctx, "share_link", "get_shared")
+
+	ctx, span := otel.StartServiceSpan(ctx, "GetSharedHangout")
+	defer span.End()
+
+	link, err := s.authorize(ctx, access)
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+	span.SetAttributes(attribute.String("share_link.id", link.ID.String()))
+
+	hangout, err := s.hangoutRepo.GetHangoutByIDAnyOwner(ctx, link.HangoutID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		err = apperrors.ErrShareLinkNotFound
+	}
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	var albums []domain.Album
+	if link.AlbumID != nil {
+		album, err := s.albumRepo.GetAlbumByID(ctx, *link.AlbumID)
+		if err != nil {
+			recordMetrics("error")
+			_ = span.RecordErrorWithStatus(err)
+			return nil, err
+		}
+		albums = []domain.Album{*album}
+	} else if albums, err = s.albumRepo.GetAlbumsByHangoutID(ctx, link.HangoutID); err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetStatusOk()
+	recordMetrics("success")
+	return mapper.SharedHangoutToResponseDTO(link, hangout, albums), nil
+}
+
+// ListSharedMemories lists the uploaded memories behind a share link in gallery
+// order, with download URLs that expire shortly.
+func (s *shareLinkService) ListSharedMemories(ctx context.Context, access *dto.ShareAccess, albumID *uuid.UUID, pagination *dto.CursorPagination) (*dto.PaginatedSharedMemories, error) {
+	recordMetrics := s.metrics.StartRequest(ctx, "share_link", "list_shared_memories")
+
+	ctx, span := otel.StartServiceSpan(ctx, "ListSharedMemories",
+		attribute.Int("pagination.limit", pagination.GetLimit()),
+	)
+	defer span.End()
+
+	link, err := s.authorize(ctx, access)
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+	span.SetAttributes(attribute.String("share_link.id", link.ID.String()))
+
+	if _, err := s.hangoutRepo.GetHangoutByIDAnyOwner(ctx, link.HangoutID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			err = apperrors.ErrShareLinkNotFound
+		}
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	filter := &dto.MemoryFilter{AlbumID: albumID}
+	if link.AlbumID != nil {
+		filter.AlbumID = link.AlbumID
+	}
+	pagination.SortBy = constants.SortByPosition
+	pagination.SortDir = constants.SortDirectionAsc
+
+	memories, err := s.memoryRepo.GetMemoriesByHangoutID(ctx, link.HangoutID, filter, pagination)
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	limit := pagination.GetLimit()
+	hasMore := len(memories) > limit
+	if hasMore {
+		memories = memories[:limit]
+	}
+
+	responses := make([]dto.SharedMemoryResponse, 0, len(memories))
+	if len(memories) > 0 {
+		memoryIDs := make([]string, len(memories))
+		for i, memory := range memories {
+			memoryIDs[i] = memory.ID.String()
+		}
+
+		grpcStart := time.Now()
+		filesMap, err := s.fileService.GetFilesByMemoryIDs(ctx, memoryIDs)
+		grpcStatus := "success"
+		if err != nil {
+			grpcStatus = "error"
+		}
+		s.metrics.RecordGRPCCall(ctx, "file", "GetFilesByMemoryIDs", grpcStatus, time.Since(grpcStart))
+
+		if err != nil {
+			recordMetrics("error")
+			_ = span.RecordErrorWithStatus(err)
+			return nil, err
+		}
+
+		for _, memory := range memories {
+			if file := filesMap[memory.ID.String()]; file != nil {
+				responses = append(responses, *mapper.MemoryToSharedResponseDTO(&memory, file.DownloadUrl, file.FileSize, file.MimeType))
+			}
+		}
+	}
+
+	var nextCursor *uuid.UUID
+	if hasMore {
+		lastID := memories[len(memories)-1].ID
+		nextCursor = &lastID
+	}
+
+	span.SetAttributes(
+		attribute.Int("memory.count", len(responses)),
+		attribute.Bool("pagination.has_more", hasMore),
+	)
+	span.SetStatusOk()
+	recordMetrics("success")
+	return &dto.PaginatedSharedMemories{
+		Data:       responses,
+		NextCursor: nextCursor,
+		HasMore:    hasMore,
+	}, nil
+}
+
+// authorize resolves the token of a share request and checks that the link can be
+// used.
+func (s *shareLinkService) authorize(ctx context.Context, access *dto.ShareAccess) (*domain.ShareLink, error) {
+	link, err := s.repo.GetShareLinkByTokenHash(ctx, utils.HashToken(access.Token))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, apperrors.ErrShareLinkNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	outcome, err := s.checkAccess(link, access.Password)
+	if err != nil && outcome == "" {
+		return nil, err
+	}
+	s.recordAccess(ctx, link, outcome, access)
+	if err != nil {
+		return nil, err
+	}
+	return link, nil
+}
+
+// checkAccess returns the audit outcome of using the link with password, and the
+// error to report when access is denied.
+func (s *shareLinkService) checkAccess(link *domain.ShareLink, password string) (string, error) {
+	switch {
+	case link.RevokedAt != nil:
+		return constants.ShareAccessRevoked, apperrors.ErrShareLinkNotFound
+	case link.IsExpired(time.Now()):
+		return constants.ShareAccessExpired, apperrors.ErrShareLinkExpired
+	case !link.HasPassword():
+		return constants.ShareAccessGranted, nil
+	case password == "":
+		return constants.ShareAccessPasswordMissing, apperrors.ErrSharePasswordRequired
+	}
+
+	err := s.bcrypt.CompareHashAndPassword(*link.PasswordHash, password)
+	switch {
+	case err == nil:
+		return constants.ShareAccessGranted, nil
+	case errors.Is(err, apperrors.ErrInvalidCredentials):
+		return constants.ShareAccessPasswordInvalid, apperrors.ErrInvalidSharePassword
+	default:
+		return "", err
+	}
+}
+
+// recordAccess writes the audit entry for a request.
+func (s *shareLinkService) recordAccess(ctx context.Context, link *domain.ShareLink, outcome string, access *dto.ShareAccess) {
+	entry := &domain.ShareLinkAccess{
+		ShareLinkID: link.ID,
+		Outcome:     outcome,
+		IPAddress:   access.IPAddress,
+		UserAgent:   truncateRunes(access.UserAgent, constants.MaxShareUserAgentLength),
+	}
+	if err := s.repo.RecordAccess(ctx, entry); err != nil {
+		log.Printf(logmsg.ShareAccessRecordFailed, link.ID, err)
+	}
+}
+
+// getHangoutForOrganizer loads a hangout the user organizes.
+func (s *shareLinkService) getHangoutForOrganizer(ctx context.Context, userID uuid.UUID, hangoutID uuid.UUID) (*domain.Hangout, error) {
+	hangout, err := s.hangoutRepo.GetHangoutByID(ctx, hangoutID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if hangout.UserID == nil || *hangout.UserID != userID {
+		return nil, apperrors.ErrForbidden
+	}
+	return hangout, nil
+}
+
+// getShareLinkForOrganizer loads a share link of a hangout the user organizes.
+func (s *shareLinkService) getShareLinkForOrganizer(ctx context.Context, userID uuid.UUID, shareLinkID uuid.UUID) (*domain.ShareLink, error) {
+	link, err := s.repo.GetShareLinkByID(ctx, shareLinkID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.getHangoutForOrganizer(ctx, userID, link.HangoutID); err != nil {
+		return nil, err
+	}
+	return link, nil
+}
+
+func truncateRunes(value string, max int) string {
+	if utf8.RuneCountInString(value) <= max {
+		return value
+	}
+	return string([]rune(value)[:max])
+}
@@ -0,0 +1,362 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	filepb "github.com/Ernestgio/Hangout-Planner/pkg/shared/proto/gen/go/file"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/config"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/services"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/utils"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+type shareLinkMocks struct {
+	repo        *MockShareLinkRepository
+	hangoutRepo *MockHangoutRepository
+	albumRepo   *MockAlbumRepository
+	memoryRepo  *MockMemoryRepository
+	fileService *MockFileService
+	bcrypt      *MockBcryptUtils
+}
+
+func newShareLinkService() (services.ShareLinkService, *shareLinkMocks) {
+	m := &shareLinkMocks{
+		repo:        new(MockShareLinkRepository),
+		hangoutRepo: new(MockHangoutRepository),
+		albumRepo:   new(MockAlbumRepository),
+		memoryRepo:  new(MockMemoryRepository),
+		fileService: new(MockFileService),
+		bcrypt:      new(MockBcryptUtils),
+	}
+	cfg := &config.ShareConfig{MaxExpiryDays: 30}
+	svc := services.NewShareLinkService(m.repo, m.hangoutRepo, m.albumRepo, m.memoryRepo, m.fileService, m.bcrypt, cfg, nil)
+	return svc, m
+}
+
+func TestShareLinkService_CreateShareLink(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	otherID := uuid.New()
+	hangout := &domain.Hangout{ID: uuid.New(), UserID: &userID}
+	albumID := uuid.New()
+	password := "secret-pass"
+	inAWeek := time.Now().Add(7 * 24 * time.Hour).UTC().Format("2006-01-02 15:04:05.000")
+	inAYear := time.Now().Add(365 * 24 * time.Hour).UTC().Format("2006-01-02 15:04:05.000")
+	dbError := errors.New("db error")
+
+	tests := []struct {
+		name      string
+		userID    uuid.UUID
+		req       *dto.CreateShareLinkRequest
+		setup     func(*shareLinkMocks)
+		wantScope string
+		wantError error
+	}{
+		{
+			name:   "hangout link",
+			userID: userID,
+			req:    &dto.CreateShareLinkRequest{ExpiresAt: &inAWeek},
+			setup: func(m *shareLinkMocks) {
+				m.hangoutRepo.On("GetHangoutByID", mock.Anything, hangout.ID, userID).Return(hangout, nil)
+				m.repo.On("CountActiveShareLinksByHangoutID", mock.Anything, hangout.ID, mock.Anything).Return(int64(0), nil)
+				m.repo.On("CreateShareLink", mock.Anything, mock.MatchedBy(func(l *domain.ShareLink) bool {
+					return l.Scope == constants.ShareScopeHangout && l.AlbumID == nil && l.PasswordHash == nil && len(l.TokenHash) == 64
+				})).Return(nil)
+			},
+			wantScope: constants.ShareScopeHangout,
+		},
+		{
+			name:   "password protected album link",
+			userID: userID,
+			req:    &dto.CreateShareLinkRequest{AlbumID: &albumID, Password: &password},
+			setup: func(m *shareLinkMocks) {
+				m.hangoutRepo.On("GetHangoutByID", mock.Anything, hangout.ID, userID).Return(hangout, nil)
+				m.albumRepo.On("GetAlbumByID", mock.Anything, albumID).Return(&domain.Album{ID: albumID, HangoutID: hangout.ID}, nil)
+				m.repo.On("CountActiveShareLinksByHangoutID", mock.Anything, hangout.ID, mock.Anything).Return(int64(3), nil)
+				m.bcrypt.On("GenerateFromPassword", password).Return("hashed", nil)
+				m.repo.On("CreateShareLink", mock.Anything, mock.MatchedBy(func(l *domain.ShareLink) bool {
+					return l.Scope == constants.ShareScopeAlbum && *l.AlbumID == albumID && *l.PasswordHash == "hashed"
+				})).Return(nil)
+			},
+			wantScope: constants.ShareScopeAlbum,
+		},
+		{
+			name:   "not organizer",
+			userID: otherID,
+			req:    &dto.CreateShareLinkRequest{},
+			setup: func(m *shareLinkMocks) {
+				m.hangoutRepo.On("GetHangoutByID", mock.Anything, hangout.ID, otherID).Return(hangout, nil)
+			},
+			wantError: apperrors.ErrForbidden,
+		},
+		{
+			name:   "expiry beyond the maximum",
+			userID: userID,
+			req:    &dto.CreateShareLinkRequest{ExpiresAt: &inAYear},
+			setup: func(m *shareLinkMocks) {
+				m.hangoutRepo.On("GetHangoutByID", mock.Anything, hangout.ID, userID).Return(hangout, nil)
+			},
+			wantError: apperrors.ErrInvalidShareExpiry,
+		},
+		{
+			name:   "album of another hangout",
+			userID: userID,
+			req:    &dto.CreateShareLinkRequest{AlbumID: &albumID},
+			setup: func(m *shareLinkMocks) {
+				m.hangoutRepo.On("GetHangoutByID", mock.Anything, hangout.ID, userID).Return(hangout, nil)
+				m.albumRepo.On("GetAlbumByID", mock.Anything, albumID).Return(&domain.Album{ID: albumID, HangoutID: uuid.New()}, nil)
+			},
+			wantError: apperrors.ErrInvalidAlbumID,
+		},
+		{
+			name:   "limit reached",
+			userID: userID,
+			req:    &dto.CreateShareLinkRequest{},
+			setup: func(m *shareLinkMocks) {
+				m.hangoutRepo.On("GetHangoutByID", mock.Anything, hangout.ID, userID).Return(hangout, nil)
+				m.repo.On("CountActiveShareLinksByHangoutID", mock.Anything, hangout.ID, mock.Anything).Return(int64(constants.MaxShareLinksPerHangout), nil)
+			},
+			wantError: apperrors.ErrShareLinkLimitReached,
+		},
+		{
+			name:   "create error",
+			userID: userID,
+			req:    &dto.CreateShareLinkRequest{},
+			setup: func(m *shareLinkMocks) {
+				m.hangoutRepo.On("GetHangoutByID", mock.Anything, hangout.ID, userID).Return(hangout, nil)
+				m.repo.On("CountActiveShareLinksByHangoutID", mock.Anything, hangout.ID, mock.Anything).Return(int64(0), nil)
+				m.repo.On("CreateShareLink", mock.Anything, mock.Anything).Return(dbError)
+			},
+			wantError: dbError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, m := newShareLinkService()
+			tt.setup(m)
+
+			res, err := svc.CreateShareLink(ctx, tt.userID, hangout.ID, tt.req)
+			if tt.wantError != nil {
+				require.ErrorIs(t, err, tt.wantError)
+				require.Nil(t, res)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.wantScope, res.Scope)
+				require.True(t, strings.HasPrefix(res.Token, constants.ShareTokenPrefix))
+			}
+			m.repo.AssertExpectations(t)
+			m.hangoutRepo.AssertExpectations(t)
+			m.albumRepo.AssertExpectations(t)
+			m.bcrypt.AssertExpectations(t)
+		})
+	}
+}
+
+func TestShareLinkService_RevokeShareLink(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	hangout := &domain.Hangout{ID: uuid.New(), UserID: &userID}
+	link := &domain.ShareLink{ID: uuid.New(), HangoutID: hangout.ID}
+
+	t.Run("success", func(t *testing.T) {
+		svc, m := newShareLinkService()
+		m.repo.On("GetShareLinkByID", mock.Anything, link.ID).Return(link, nil)
+		m.hangoutRepo.On("GetHangoutByID", mock.Anything, hangout.ID, userID).Return(hangout, nil)
+		m.repo.On("RevokeShareLink", mock.Anything, link.ID, mock.Anything).Return(nil)
+
+		require.NoError(t, svc.RevokeShareLink(ctx, userID, link.ID))
+		m.repo.AssertExpectations(t)
+	})
+
+	t.Run("non participant", func(t *testing.T) {
+		svc, m := newShareLinkService()
+		otherID := uuid.New()
+		m.repo.On("GetShareLinkByID", mock.Anything, link.ID).Return(link, nil)
+		m.hangoutRepo.On("GetHangoutByID", mock.Anything, hangout.ID, otherID).Return(nil, gorm.ErrRecordNotFound)
+
+		err := svc.RevokeShareLink(ctx, otherID, link.ID)
+		require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+		m.repo.AssertNotCalled(t, "RevokeShareLink", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestShareLinkService_GetSharedHangout(t *testing.T) {
+	ctx := context.Background()
+	token := "shr_token"
+	tokenHash := utils.HashToken(token)
+	passwordHash := "hashed"
+	past := time.Now().Add(-time.Hour)
+	hangout := &domain.Hangout{ID: uuid.New(), Title: "Beach day"}
+
+	tests := []struct {
+		name        string
+		link        *domain.ShareLink
+		password    string
+		setup       func(*shareLinkMocks, *domain.ShareLink)
+		wantOutcome string
+		wantError   error
+	}{
+		{
+			name: "open link",
+			link: &domain.ShareLink{ID: uuid.New(), HangoutID: hangout.ID, Scope: constants.ShareScopeHangout},
+			setup: func(m *shareLinkMocks, link *domain.ShareLink) {
+				m.hangoutRepo.On("GetHangoutByIDAnyOwner", mock.Anything, hangout.ID).Return(hangout, nil)
+				m.albumRepo.On("GetAlbumsByHangoutID", mock.Anything, hangout.ID).Return([]domain.Album{{ID: uuid.New(), Name: "Day 1"}}, nil)
+			},
+			wantOutcome: constants.ShareAccessGranted,
+		},
+		{
+			name:     "correct password",
+			link:     &domain.ShareLink{ID: uuid.New(), HangoutID: hangout.ID, Scope: constants.ShareScopeHangout, PasswordHash: &passwordHash},
+			password: "secret-pass",
+			setup: func(m *shareLinkMocks, link *domain.ShareLink) {
+				m.bcrypt.On("CompareHashAndPassword", passwordHash, "secret-pass").Return(nil)
+				m.hangoutRepo.On("GetHangoutByIDAnyOwner", mock.Anything, hangout.ID).Return(hangout, nil)
+				m.albumRepo.On("GetAlbumsByHangoutID", mock.Anything, hangout.ID).Return([]domain.Album{}, nil)
+			},
+			wantOutcome: constants.ShareAccessGranted,
+		},
+		{
+			name:        "missing password",
+			link:        &domain.ShareLink{ID: uuid.New(), HangoutID: hangout.ID, PasswordHash: &passwordHash},
+			setup:       func(m *shareLinkMocks, link *domain.ShareLink) {},
+			wantOutcome: constants.ShareAccessPasswordMissing,
+			wantError:   apperrors.ErrSharePasswordRequired,
+		},
+		{
+			name:     "wrong password",
+			link:     &domain.ShareLink{ID: uuid.New(), HangoutID: hangout.ID, PasswordHash: &passwordHash},
+			password: "guess",
+			setup: func(m *shareLinkMocks, link *domain.ShareLink) {
+				m.bcrypt.On("CompareHashAndPassword", passwordHash, "guess").Return(apperrors.ErrInvalidCredentials)
+			},
+			wantOutcome: constants.ShareAccessPasswordInvalid,
+			wantError:   apperrors.ErrInvalidSharePassword,
+		},
+		{
+			name:        "expired",
+			link:        &domain.ShareLink{ID: uuid.New(), HangoutID: hangout.ID, ExpiresAt: &past},
+			setup:       func(m *shareLinkMocks, link *domain.ShareLink) {},
+			wantOutcome: constants.ShareAccessExpired,
+			wantError:   apperrors.ErrShareLinkExpired,
+		},
+		{
+			name:        "revoked",
+			link:        &domain.ShareLink{ID: uuid.New(), HangoutID: hangout.ID, RevokedAt: &past},
+			setup:       func(m *shareLinkMocks, link *domain.ShareLink) {},
+			wantOutcome: constants.ShareAccessRevoked,
+			wantError:   apperrors.ErrShareLinkNotFound,
+		},
+		{
+			name: "hangout in trash",
+			link: &domain.ShareLink{ID: uuid.New(), HangoutID: hangout.ID},
+			setup: func(m *shareLinkMocks, link *domain.ShareLink) {
+				m.hangoutRepo.On("GetHangoutByIDAnyOwner", mock.Anything, hangout.ID).Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantOutcome: constants.ShareAccessGranted,
+			wantError:   apperrors.ErrShareLinkNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, m := newShareLinkService()
+			m.repo.On("GetShareLinkByTokenHash", mock.Anything, tokenHash).Return(tt.link, nil)
+			m.repo.On("RecordAccess", mock.Anything, mock.MatchedBy(func(a *domain.ShareLinkAccess) bool {
+				return a.ShareLinkID == tt.link.ID && a.Outcome == tt.wantOutcome && a.IPAddress == "203.0.113.7"
+			})).Return(nil)
+			tt.setup(m, tt.link)
+
+			res, err := svc.GetSharedHangout(ctx, &dto.ShareAccess{Token: token, Password: tt.password, IPAddress: "203.0.113.7"})
+			if tt.wantError != nil {
+				require.ErrorIs(t, err, tt.wantError)
+				require.Nil(t, res)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, "Beach day", res.Hangout.Title)
+			}
+			m.repo.AssertExpectations(t)
+			m.hangoutRepo.AssertExpectations(t)
+			m.bcrypt.AssertExpectations(t)
+		})
+	}
+
+	t.Run("unknown token is not audited", func(t *testing.T) {
+		svc, m := newShareLinkService()
+		m.repo.On("GetShareLinkByTokenHash", mock.Anything, tokenHash).Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := svc.GetSharedHangout(ctx, &dto.ShareAccess{Token: token})
+		require.ErrorIs(t, err, apperrors.ErrShareLinkNotFound)
+		m.repo.AssertNotCalled(t, "RecordAccess", mock.Anything, mock.Anything)
+	})
+
+	t.Run("audit failure does not deny access", func(t *testing.T) {
+		svc, m := newShareLinkService()
+		link := &domain.ShareLink{ID: uuid.New(), HangoutID: hangout.ID}
+		m.repo.On("GetShareLinkByTokenHash", mock.Anything, tokenHash).Return(link, nil)
+		m.repo.On("RecordAccess", mock.Anything, mock.Anything).Return(errors.New("db error"))
+		m.hangoutRepo.On("GetHangoutByIDAnyOwner", mock.Anything, hangout.ID).Return(hangout, nil)
+		m.albumRepo.On("GetAlbumsByHangoutID", mock.Anything, hangout.ID).Return([]domain.Album{}, nil)
+
+		_, err := svc.GetSharedHangout(ctx, &dto.ShareAccess{Token: token})
+		require.NoError(t, err)
+	})
+}
+
+func TestShareLinkService_ListSharedMemories(t *testing.T) {
+	ctx := context.Background()
+	token := "shr_token"
+	hangout := &domain.Hangout{ID: uuid.New()}
+	albumID := uuid.New()
+	memoryID := uuid.New()
+
+	t.Run("album link only lists its album", func(t *testing.T) {
+		svc, m := newShareLinkService()
+		link := &domain.ShareLink{ID: uuid.New(), HangoutID: hangout.ID, AlbumID: &albumID, Scope: constants.ShareScopeAlbum}
+		otherAlbumID := uuid.New()
+		m.repo.On("GetShareLinkByTokenHash", mock.Anything, utils.HashToken(token)).Return(link, nil)
+		m.repo.On("RecordAccess", mock.Anything, mock.Anything).Return(nil)
+		m.hangoutRepo.On("GetHangoutByIDAnyOwner", mock.Anything, hangout.ID).Return(hangout, nil)
+		m.memoryRepo.On("GetMemoriesByHangoutID", mock.Anything, hangout.ID, mock.MatchedBy(func(f *dto.MemoryFilter) bool {
+			return f.AlbumID != nil && *f.AlbumID == albumID
+		}), mock.MatchedBy(func(p *dto.CursorPagination) bool {
+			return p.SortBy == constants.SortByPosition && p.SortDir == constants.SortDirectionAsc
+		})).Return([]domain.Memory{{ID: memoryID, Name: "sunset.jpg", AlbumID: &albumID}}, nil)
+		m.fileService.On("GetFilesByMemoryIDs", mock.Anything, []string{memoryID.String()}).Return(map[string]*filepb.FileWithURL{
+			memoryID.String(): {DownloadUrl: "https://files.example/sunset.jpg", FileSize: 1024, MimeType: "image/jpeg"},
+		}, nil)
+
+		res, err := svc.ListSharedMemories(ctx, &dto.ShareAccess{Token: token}, &otherAlbumID, &dto.CursorPagination{Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, res.Data, 1)
+		require.Equal(t, "https://files.example/sunset.jpg", res.Data[0].FileURL)
+		require.False(t, res.HasMore)
+		m.memoryRepo.AssertExpectations(t)
+	})
+
+	t.Run("no memories skips the file service", func(t *testing.T) {
+		svc, m := newShareLinkService()
+		link := &domain.ShareLink{ID: uuid.New(), HangoutID: hangout.ID, Scope: constants.ShareScopeHangout}
+		m.repo.On("GetShareLinkByTokenHash", mock.Anything, utils.HashToken(token)).Return(link, nil)
+		m.repo.On("RecordAccess", mock.Anything, mock.Anything).Return(nil)
+		m.hangoutRepo.On("GetHangoutByIDAnyOwner", mock.Anything, hangout.ID).Return(hangout, nil)
+		m.memoryRepo.On("GetMemoriesByHangoutID", mock.Anything, hangout.ID, mock.Anything, mock.Anything).Return([]domain.Memory{}, nil)
+
+		res, err := svc.ListSharedMemories(ctx, &dto.ShareAccess{Token: token}, nil, &dto.CursorPagination{})
+		require.NoError(t, err)
+		require.Empty(t, res.Data)
+		m.fileService.AssertNotCalled(t, "GetFilesByMemoryIDs", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,210 @@
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/config"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants/logmsg"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/otel"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/repository"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/signing"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// SigningKeyService manages the key pairs that sign access tokens and keeps
+// the in-memory key ring in sync with the database.
+type SigningKeyService interface {
+	// Rotate deletes retired keys, creates the next key when the current one
+	// is due and reloads the key ring. It returns the key it created, if any.
+	Rotate(ctx context.Context) (*domain.SigningKey, error)
+}
+
+type signingKeyService struct {
+	repo    repository.SigningKeyRepository
+	ring    *signing.KeyRing
+	sealer  *signing.Sealer
+	cfg     *config.JwtConfig
+	metrics *otel.MetricsRecorder
+}
+
+func NewSigningKeyService(repo repository.SigningKeyRepository, ring *signing.KeyRing, sealer *signing.Sealer, cfg *config.JwtConfig, metrics *otel.MetricsRecorder) SigningKeyService {
+	return &signingKeyService{
+		repo:    repo,
+		ring:    ring,
+		sealer:  sealer,
+		cfg:     cfg,
+		metrics: metrics,
+	}
+}
+
+func (s *signingKeyService) Rotate(ctx context.Context) (*domain.SigningKey, error) {
+	recordMetrics := s.metrics.StartRequest(ctx, "signing_key", "rotate")
+
+	ctx, span := otel.StartServiceSpan(ctx, "RotateSigningKeys",
+		attribute.String("signing_key.algorithm", s.cfg.JWTAlgorithm),
+	)
+	defer span.End()
+
+	now := time.Now()
+	created, err := s.rotate(ctx, now)
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Bool("signing_key.created", created != nil))
+	span.SetStatusOk()
+	recordMetrics("success")
+	return created, nil
+}
+
+func (s *signingKeyService) rotate(ctx context.Context, now time.Time) (*domain.SigningKey, error) {
+	if _, err := s.repo.DeleteRetired(ctx, now); err != nil {
+		return nil, err
+	}
+	records, err := s.repo.ListUnretired(ctx, now)
+	if err != nil {
+		return nil, err
+	}
+	keys := s.parseKeys(records)
+
+	// with HS256 the stored keys are only kept to verify tokens issued
+	// before switching back
+	var created *domain.SigningKey
+	if s.cfg.UsesKeyPairs() && s.rotationDue(keys, now) {
+		activatesAt := now
+		if s.canSign(keys, now) {
+			activatesAt = now.Add(time.Duration(constants.SigningKeyPublishLeadMinutes) * time.Minute)
+		}
+
+		created, err = s.createKey(ctx, activatesAt)
+		if err != nil {
+			return nil, err
+		}
+
+		// superseded keys stay published until the last token they signed expires
+		retiresAt := activatesAt.Add(s.cfg.GetExpiration())
+		for _, record := range records {
+			if record.RetiresAt == nil {
+				if err := s.repo.SetRetiresAt(ctx, record.ID, retiresAt); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		key, err := s.parseKey(*created)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	s.ring.Replace(keys)
+	return created, nil
+}
+
+// rotationDue reports whether a new key is needed: there is none yet, the
+// newest cannot sign or uses another algorithm, or it is close to the end of
+// its rotation period. A key waiting to activate means rotation is done.
+func (s *signingKeyService) rotationDue(keys []signing.Key, now time.Time) bool {
+	if len(keys) == 0 {
+		return true
+	}
+	latest := keys[0]
+	for _, key := range keys[1:] {
+		if key.ActivatesAt.After(latest.ActivatesAt) {
+			latest = key
+		}
+	}
+
+	switch {
+	case latest.ActivatesAt.After(now):
+		return false
+	case latest.Private == nil, latest.Algorithm != s.cfg.JWTAlgorithm:
+		return true
+	}
+	lead := time.Duration(constants.SigningKeyPublishLeadMinutes) * time.Minute
+	return !now.Before(latest.ActivatesAt.Add(s.cfg.GetKeyRotation() - lead))
+}
+
+// canSign reports whether some key can sign right now.
+func (s *signingKeyService) canSign(keys []signing.Key, now time.Time) bool {
+	for _, key := range keys {
+		if key.Private != nil && !key.ActivatesAt.After(now) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *signingKeyService) createKey(ctx context.Context, activatesAt time.Time) (*domain.SigningKey, error) {
+	private, err := signing.GenerateKeyPair(s.cfg.JWTAlgorithm)
+	if err != nil {
+		return nil, err
+	}
+	publicDER, err := signing.MarshalPublicKey(private.Public())
+	if err != nil {
+		return nil, err
+	}
+	privateDER, err := signing.MarshalPrivateKey(private)
+	if err != nil {
+		return nil, err
+	}
+	sealed, err := s.sealer.Seal(privateDER)
+	if err != nil {
+		return nil, err
+	}
+
+	record := &domain.SigningKey{
+		Algorithm:   s.cfg.JWTAlgorithm,
+		PublicKey:   publicDER,
+		PrivateKey:  sealed,
+		ActivatesAt: activatesAt,
+	}
+	if err := s.repo.Create(ctx, record); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// parseKeys skips keys whose public key cannot be parsed.
+func (s *signingKeyService) parseKeys(records []domain.SigningKey) []signing.Key {
+	keys := make([]signing.Key, 0, len(records))
+	for _, record := range records {
+		key, err := s.parseKey(record)
+		if err != nil {
+			log.Printf(logmsg.SigningKeyInvalid, record.ID, err)
+			continue
+		}
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func (s *signingKeyService) parseKey(record domain.SigningKey) (signing.Key, error) {
+	public, err := signing.ParsePublicKey(record.PublicKey)
+	if err != nil {
+		return signing.Key{}, err
+	}
+	key := signing.Key{
+		ID:          record.ID.String(),
+		Algorithm:   record.Algorithm,
+		Public:      public,
+		ActivatesAt: record.ActivatesAt,
+	}
+
+	privateDER, err := s.sealer.Open(record.PrivateKey)
+	if err == nil {
+		key.Private, err = signing.ParsePrivateKey(privateDER)
+	}
+	if err != nil {
+		log.Printf(logmsg.SigningKeyVerifyOnly, record.ID, err)
+		key.Private = nil
+	}
+	return key, nil
+}
@@ -0,0 +1,278 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/config"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/services"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/signing"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const signingTestSecret = "a-very-long-and-secure-secret-key-for-testing"
+
+func newSigningKeyService(t *testing.T, alg string) (services.SigningKeyService, *MockSigningKeyRepository, *signing.KeyRing, *signing.Sealer) {
+	t.Helper()
+	repo := new(MockSigningKeyRepository)
+	ring := signing.NewKeyRing()
+	sealer, err := signing.NewSealer(signingTestSecret, constants.SigningKeySealPurpose)
+	require.NoError(t, err)
+	cfg := &config.JwtConfig{
+		JWTSecret:          signingTestSecret,
+		JWTExpirationHours: 1,
+		JWTAlgorithm:       alg,
+		JWTKeyRotationDays: 30,
+	}
+	return services.NewSigningKeyService(repo, ring, sealer, cfg, nil), repo, ring, sealer
+}
+
+func newStoredSigningKey(t *testing.T, sealer *signing.Sealer, alg string, activatesAt time.Time) domain.SigningKey {
+	t.Helper()
+	private, err := signing.GenerateKeyPair(alg)
+	require.NoError(t, err)
+	publicDER, err := signing.MarshalPublicKey(private.Public())
+	require.NoError(t, err)
+	privateDER, err := signing.MarshalPrivateKey(private)
+	require.NoError(t, err)
+	sealed, err := sealer.Seal(privateDER)
+	require.NoError(t, err)
+	return domain.SigningKey{
+		ID:          uuid.New(),
+		Algorithm:   alg,
+		PublicKey:   publicDER,
+		PrivateKey:  sealed,
+		ActivatesAt: activatesAt,
+	}
+}
+
+func TestSigningKeyService_Rotate(t *testing.T) {
+	ctx := context.Background()
+	lead := time.Duration(constants.SigningKeyPublishLeadMinutes) * time.Minute
+	dbError := errors.New("db error")
+
+	otherSealer, err := signing.NewSealer("a-different-secret-that-was-used-before", constants.SigningKeySealPurpose)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		alg         string
+		setup       func(t *testing.T, repo *MockSigningKeyRepository, sealer *signing.Sealer) []domain.SigningKey
+		wantCreated bool
+		// activation offset of the created key, relative to now
+		wantActivation time.Duration
+		wantRingKeys   int
+		wantCanSign    bool
+		wantErr        error
+	}{
+		{
+			name: "FirstKeyActivatesImmediately",
+			alg:  constants.JWTAlgorithmEdDSA,
+			setup: func(t *testing.T, repo *MockSigningKeyRepository, sealer *signing.Sealer) []domain.SigningKey {
+				repo.On("ListUnretired", mock.Anything, mock.Anything).Return([]domain.SigningKey{}, nil)
+				repo.On("Create", mock.Anything, mock.AnythingOfType("*domain.SigningKey")).Return(nil)
+				return nil
+			},
+			wantCreated:    true,
+			wantActivation: 0,
+			wantRingKeys:   1,
+			wantCanSign:    true,
+		},
+		{
+			name: "FirstRSAKey",
+			alg:  constants.JWTAlgorithmRS256,
+			setup: func(t *testing.T, repo *MockSigningKeyRepository, sealer *signing.Sealer) []domain.SigningKey {
+				repo.On("ListUnretired", mock.Anything, mock.Anything).Return([]domain.SigningKey{}, nil)
+				repo.On("Create", mock.Anything, mock.AnythingOfType("*domain.SigningKey")).Return(nil)
+				return nil
+			},
+			wantCreated:  true,
+			wantRingKeys: 1,
+			wantCanSign:  true,
+		},
+		{
+			name: "NotDue",
+			alg:  constants.JWTAlgorithmEdDSA,
+			setup: func(t *testing.T, repo *MockSigningKeyRepository, sealer *signing.Sealer) []domain.SigningKey {
+				keys := []domain.SigningKey{newStoredSigningKey(t, sealer, constants.JWTAlgorithmEdDSA, time.Now().Add(-24*time.Hour))}
+				repo.On("ListUnretired", mock.Anything, mock.Anything).Return(keys, nil)
+				return keys
+			},
+			wantRingKeys: 1,
+			wantCanSign:  true,
+		},
+		{
+			name: "DuePrepublishesAndRetiresCurrent",
+			alg:  constants.JWTAlgorithmEdDSA,
+			setup: func(t *testing.T, repo *MockSigningKeyRepository, sealer *signing.Sealer) []domain.SigningKey {
+				keys := []domain.SigningKey{newStoredSigningKey(t, sealer, constants.JWTAlgorithmEdDSA, time.Now().Add(-30*24*time.Hour))}
+				repo.On("ListUnretired", mock.Anything, mock.Anything).Return(keys, nil)
+				repo.On("Create", mock.Anything, mock.AnythingOfType("*domain.SigningKey")).Return(nil)
+				repo.On("SetRetiresAt", mock.Anything, keys[0].ID, mock.MatchedBy(func(retiresAt time.Time) bool {
+					want := time.Now().Add(lead + time.Hour)
+					return retiresAt.After(want.Add(-time.Minute)) && retiresAt.Before(want.Add(time.Minute))
+				})).Return(nil)
+				return keys
+			},
+			wantCreated:    true,
+			wantActivation: lead,
+			wantRingKeys:   2,
+			wantCanSign:    true,
+		},
+		{
+			name: "PendingKeyMeansRotationDone",
+			alg:  constants.JWTAlgorithmEdDSA,
+			setup: func(t *testing.T, repo *MockSigningKeyRepository, sealer *signing.Sealer) []domain.SigningKey {
+				keys := []domain.SigningKey{
+					newStoredSigningKey(t, sealer, constants.JWTAlgorithmEdDSA, time.Now().Add(-30*24*time.Hour)),
+					newStoredSigningKey(t, sealer, constants.JWTAlgorithmEdDSA, time.Now().Add(30*time.Minute)),
+				}
+				repo.On("ListUnretired", mock.Anything, mock.Anything).Return(keys, nil)
+				return keys
+			},
+			wantRingKeys: 2,
+			wantCanSign:  true,
+		},
+		{
+			name: "AlgorithmChangedRotatesWithLead",
+			alg:  constants.JWTAlgorithmRS256,
+			setup: func(t *testing.T, repo *MockSigningKeyRepository, sealer *signing.Sealer) []domain.SigningKey {
+				keys := []domain.SigningKey{newStoredSigningKey(t, sealer, constants.JWTAlgorithmEdDSA, time.Now().Add(-time.Hour))}
+				repo.On("ListUnretired", mock.Anything, mock.Anything).Return(keys, nil)
+				repo.On("Create", mock.Anything, mock.AnythingOfType("*domain.SigningKey")).Return(nil)
+				repo.On("SetRetiresAt", mock.Anything, keys[0].ID, mock.Anything).Return(nil)
+				return keys
+			},
+			wantCreated:    true,
+			wantActivation: lead,
+			wantRingKeys:   2,
+			wantCanSign:    true,
+		},
+		{
+			name: "UnreadablePrivateKeyActivatesImmediately",
+			alg:  constants.JWTAlgorithmEdDSA,
+			setup: func(t *testing.T, repo *MockSigningKeyRepository, sealer *signing.Sealer) []domain.SigningKey {
+				keys := []domain.SigningKey{newStoredSigningKey(t, otherSealer, constants.JWTAlgorithmEdDSA, time.Now().Add(-time.Hour))}
+				repo.On("ListUnretired", mock.Anything, mock.Anything).Return(keys, nil)
+				repo.On("Create", mock.Anything, mock.AnythingOfType("*domain.SigningKey")).Return(nil)
+				repo.On("SetRetiresAt", mock.Anything, keys[0].ID, mock.Anything).Return(nil)
+				return keys
+			},
+			wantCreated:  true,
+			wantRingKeys: 2,
+			wantCanSign:  true,
+		},
+		{
+			name: "InvalidPublicKeySkipped",
+			alg:  constants.JWTAlgorithmHS256,
+			setup: func(t *testing.T, repo *MockSigningKeyRepository, sealer *signing.Sealer) []domain.SigningKey {
+				broken := newStoredSigningKey(t, sealer, constants.JWTAlgorithmEdDSA, time.Now().Add(-time.Hour))
+				broken.PublicKey = []byte("not a key")
+				repo.On("ListUnretired", mock.Anything, mock.Anything).Return([]domain.SigningKey{broken}, nil)
+				return nil
+			},
+			wantRingKeys: 0,
+		},
+		{
+			name: "HS256KeepsExistingKeysForVerification",
+			alg:  constants.JWTAlgorithmHS256,
+			setup: func(t *testing.T, repo *MockSigningKeyRepository, sealer *signing.Sealer) []domain.SigningKey {
+				keys := []domain.SigningKey{newStoredSigningKey(t, sealer, constants.JWTAlgorithmEdDSA, time.Now().Add(-60*24*time.Hour))}
+				repo.On("ListUnretired", mock.Anything, mock.Anything).Return(keys, nil)
+				return keys
+			},
+			wantRingKeys: 1,
+			wantCanSign:  true,
+		},
+		{
+			name: "ListError",
+			alg:  constants.JWTAlgorithmEdDSA,
+			setup: func(t *testing.T, repo *MockSigningKeyRepository, sealer *signing.Sealer) []domain.SigningKey {
+				repo.On("ListUnretired", mock.Anything, mock.Anything).Return(nil, dbError)
+				return nil
+			},
+			wantErr: dbError,
+		},
+		{
+			name: "CreateError",
+			alg:  constants.JWTAlgorithmEdDSA,
+			setup: func(t *testing.T, repo *MockSigningKeyRepository, sealer *signing.Sealer) []domain.SigningKey {
+				repo.On("ListUnretired", mock.Anything, mock.Anything).Return([]domain.SigningKey{}, nil)
+				repo.On("Create", mock.Anything, mock.AnythingOfType("*domain.SigningKey")).Return(dbError)
+				return nil
+			},
+			wantErr: dbError,
+		},
+		{
+			name: "SetRetiresAtError",
+			alg:  constants.JWTAlgorithmEdDSA,
+			setup: func(t *testing.T, repo *MockSigningKeyRepository, sealer *signing.Sealer) []domain.SigningKey {
+				keys := []domain.SigningKey{newStoredSigningKey(t, sealer, constants.JWTAlgorithmEdDSA, time.Now().Add(-30*24*time.Hour))}
+				repo.On("ListUnretired", mock.Anything, mock.Anything).Return(keys, nil)
+				repo.On("Create", mock.Anything, mock.AnythingOfType("*domain.SigningKey")).Return(nil)
+				repo.On("SetRetiresAt", mock.Anything, keys[0].ID, mock.Anything).Return(dbError)
+				return keys
+			},
+			wantErr: dbError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, repo, ring, sealer := newSigningKeyService(t, tt.alg)
+			repo.On("DeleteRetired", mock.Anything, mock.Anything).Return(int64(0), nil)
+			tt.setup(t, repo, sealer)
+
+			created, err := svc.Rotate(ctx)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				require.Nil(t, created)
+				require.Empty(t, ring.JWKS().Keys)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, ring.JWKS().Keys, tt.wantRingKeys)
+			require.Equal(t, tt.wantCanSign, ring.Current(time.Now().Add(time.Second)) != nil)
+
+			if !tt.wantCreated {
+				require.Nil(t, created)
+				repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+				repo.AssertExpectations(t)
+				return
+			}
+			require.NotNil(t, created)
+			require.Equal(t, tt.alg, created.Algorithm)
+			require.WithinDuration(t, time.Now().Add(tt.wantActivation), created.ActivatesAt, time.Minute)
+
+			key, ok := ring.Lookup(created.ID.String())
+			require.True(t, ok)
+			require.NotNil(t, key.Private)
+
+			privateDER, err := sealer.Open(created.PrivateKey)
+			require.NoError(t, err)
+			_, err = signing.ParsePrivateKey(privateDER)
+			require.NoError(t, err)
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSigningKeyService_Rotate_DeleteRetiredError(t *testing.T) {
+	ctx := context.Background()
+	svc, repo, _, _ := newSigningKeyService(t, constants.JWTAlgorithmEdDSA)
+	dbError := errors.New("db error")
+	repo.On("DeleteRetired", mock.Anything, mock.Anything).Return(int64(0), dbError)
+
+	created, err := svc.Rotate(ctx)
+
+	require.ErrorIs(t, err, dbError)
+	require.Nil(t, created)
+	repo.AssertNotCalled(t, "ListUnretired", mock.Anything, mock.Anything)
+}
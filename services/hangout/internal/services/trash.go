@@ -0,0 +1,290 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/config"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/grpc"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/mapper"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/otel"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/repository"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+type TrashService interface {
+	ListDeletedHangouts(ctx context.Context, userID uuid.UUID, pagination *dto.CursorPagination) (*dto.PaginatedDeletedHangouts, error)
+	ListDeletedMemories(ctx context.Context, userID uuid.UUID, pagination *dto.CursorPagination) (*dto.PaginatedDeletedMemories, error)
+	RestoreHangout(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*dto.HangoutDetailResponse, error)
+	RestoreMemory(ctx context.Context, id uuid.UUID, userID uuid.UUID) error
+	PurgeExpired(ctx context.Context) (memoriesPurged int, hangoutsPurged int, err error)
+}
+
+type trashService struct {
+	db          *gorm.DB
+	hangoutRepo repository.HangoutRepository
+	memoryRepo  repository.MemoryRepository
+	fileService grpc.FileService
+	cfg         *config.TrashConfig
+	metrics     *otel.MetricsRecorder
+}
+
+func NewTrashService(db *gorm.DB, hangoutRepo repository.HangoutRepository, memoryRepo repository.MemoryRepository, fileService grpc.FileService, cfg *config.TrashConfig, metrics *otel.MetricsRecorder) TrashService {
+	return &trashService{
+		db:          db,
+		hangoutRepo: hangoutRepo,
+		memoryRepo:  memoryRepo,
+		fileService: fileService,
+		cfg:         cfg,
+		metrics:     metrics,
+	}
+}
+
+func (s *trashService) ListDeletedHangouts(ctx context.Context, userID uuid.UUID, pagination *dto.CursorPagination) (*dto.PaginatedDeletedHangouts, error) {
+	recordMetrics := s.metrics.StartRequest(ctx, "trash", "list_hangouts")
+
+	ctx, span := otel.StartServiceSpan(ctx, "ListDeletedHangouts",
+		attribute.String("user.id", userID.String()),
+		attribute.Int("pagination.limit", pagination.GetLimit()),
+	)
+	defer span.End()
+
+	hangouts, err := s.hangoutRepo.GetDeletedHangoutsByUserID(ctx, userID, pagination)
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	var nextCursor *uuid.UUID
+	limit := pagination.GetLimit()
+	hasMore := len(hangouts) > limit
+	if hasMore {
+		nextCursor = &hangouts[limit-1].ID
+		hangouts = hangouts[:limit]
+	}
+
+	span.SetAttributes(
+		attribute.Int("hangout.count", len(hangouts)),
+		attribute.Bool("pagination.has_more", hasMore),
+	)
+	span.SetStatusOk()
+	recordMetrics("success")
+	return &dto.PaginatedDeletedHangouts{
+		Data:       mapper.HangoutsToDeletedResponseDTOs(hangouts, s.cfg.GetRetention()),
+		NextCursor: nextCursor,
+		HasMore:    hasMore,
+	}, nil
+}
+
+func (s *trashService) ListDeletedMemories(ctx context.Context, userID uuid.UUID, pagination *dto.CursorPagination) (*dto.PaginatedDeletedMemories, error) {
+	recordMetrics := s.metrics.StartRequest(ctx, "trash", "list_memories")
+
+	ctx, span := otel.StartServiceSpan(ctx, "ListDeletedMemories",
+		attribute.String("user.id", userID.String()),
+		attribute.Int("pagination.limit", pagination.GetLimit()),
+	)
+	defer span.End()
+
+	memories, err := s.memoryRepo.GetDeletedMemoriesByUserID(ctx, userID, pagination)
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	var nextCursor *uuid.UUID
+	limit := pagination.GetLimit()
+	hasMore := len(memories) > limit
+	if hasMore {
+		nextCursor = &memories[limit-1].ID
+		memories = memories[:limit]
+	}
+
+	span.SetAttributes(
+		attribute.Int("memory.count", len(memories)),
+		attribute.Bool("pagination.has_more", hasMore),
+	)
+	span.SetStatusOk()
+	recordMetrics("success")
+	return &dto.PaginatedDeletedMemories{
+		Data:       mapper.MemoriesToDeletedResponseDTOs(memories, s.cfg.GetRetention()),
+		NextCursor: nextCursor,
+		HasMore:    hasMore,
+	}, nil
+}
+
+func (s *trashService) RestoreHangout(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*dto.HangoutDetailResponse, error) {
+	recordMetrics := s.metrics.StartRequest(ctx, "trash", "restore_hangout")
+
+	ctx, span := otel.StartServiceSpan(ctx, "RestoreHangout",
+		attribute.String("hangout.id", id.String()),
+		attribute.String("user.id", userID.String()),
+	)
+	defer span.End()
+
+	var restored *domain.Hangout
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		txHangoutRepo := s.hangoutRepo.WithTx(tx)
+
+		deleted, err := txHangoutRepo.GetDeletedHangoutByID(ctx, id, userID)
+		if err != nil {
+			return err
+		}
+
+		if err := txHangoutRepo.RestoreHangout(ctx, id, deleted.DeletedAt.Time); err != nil {
+			return err
+		}
+
+		restored, err = txHangoutRepo.GetHangoutByID(ctx, id, userID)
+		return err
+	})
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetStatusOk()
+	recordMetrics("success")
+	return mapper.HangoutToDetailResponseDTO(restored), nil
+}
+
+func (s *trashService) RestoreMemory(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
+	recordMetrics := s.metrics.StartRequest(ctx, "trash", "restore_memory")
+
+	ctx, span := otel.StartServiceSpan(ctx, "RestoreMemory",
+		attribute.String("memory.id", id.String()),
+		attribute.String("user.id", userID.String()),
+	)
+	defer span.End()
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		txMemoryRepo := s.memoryRepo.WithTx(tx)
+
+		memory, err := txMemoryRepo.GetDeletedMemoryByID(ctx, id, userID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return apperrors.ErrMemoryNotFound
+			}
+			return err
+		}
+
+		if _, err := s.hangoutRepo.WithTx(tx).GetHangoutByID(ctx, memory.HangoutID, userID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return apperrors.ErrHangoutDeleted
+			}
+			return err
+		}
+
+		return txMemoryRepo.RestoreMemory(ctx, id)
+	})
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return err
+	}
+
+	span.SetStatusOk()
+	recordMetrics("success")
+	return nil
+}
+
+// PurgeExpired permanently removes memories and hangouts that have been in the
+// trash longer than the retention window, asking the file service to drop the
+// backing files first. Items whose files cannot be removed are left for the next run.
+func (s *trashService) PurgeExpired(ctx context.Context) (int, int, error) {
+	recordMetrics := s.metrics.StartRequest(ctx, "trash", "purge")
+
+	ctx, span := otel.StartServiceSpan(ctx, "PurgeExpired",
+		attribute.Int("batch.size", s.cfg.PurgeBatchSize),
+	)
+	defer span.End()
+
+	before := time.Now().Add(-s.cfg.GetRetention())
+
+	memories, err := s.memoryRepo.GetMemoriesDeletedBefore(ctx, before, s.cfg.PurgeBatchSize)
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return 0, 0, err
+	}
+
+	memoryIDs := s.deleteFiles(ctx, memories)
+	if err := s.memoryRepo.PurgeMemories(ctx, memoryIDs); err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return 0, 0, err
+	}
+
+	hangouts, err := s.hangoutRepo.GetHangoutsDeletedBefore(ctx, before, s.cfg.PurgeBatchSize)
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return len(memoryIDs), 0, err
+	}
+
+	hangoutsPurged := 0
+	for _, hangout := range hangouts {
+		hangoutMemories, err := s.memoryRepo.GetAllMemoriesByHangoutID(ctx, hangout.ID)
+		if err != nil {
+			recordMetrics("error")
+			_ = span.RecordErrorWithStatus(err)
+			return len(memoryIDs), hangoutsPurged, err
+		}
+
+		if len(s.deleteFiles(ctx, hangoutMemories)) != len(hangoutMemories) {
+			continue
+		}
+
+		if err := s.hangoutRepo.PurgeHangout(ctx, hangout.ID); err != nil {
+			recordMetrics("error")
+			_ = span.RecordErrorWithStatus(err)
+			return len(memoryIDs), hangoutsPurged, err
+		}
+		hangoutsPurged++
+	}
+
+	span.SetAttributes(
+		attribute.Int("memory.purged", len(memoryIDs)),
+		attribute.Int("hangout.purged", hangoutsPurged),
+	)
+	span.SetStatusOk()
+	recordMetrics("success")
+	return len(memoryIDs), hangoutsPurged, nil
+}
+
+// deleteFiles removes the files backing the given memories and returns the IDs
+// of the memories that no longer have a file.
+func (s *trashService) deleteFiles(ctx context.Context, memories []domain.Memory) []uuid.UUID {
+	deleted := make([]uuid.UUID, 0, len(memories))
+	for _, memory := range memories {
+		if memory.FileID == nil {
+			deleted = append(deleted, memory.ID)
+			continue
+		}
+
+		grpcStart := time.Now()
+		err := s.fileService.DeleteFile(ctx, memory.ID.String())
+		grpcStatus := "success"
+		if err != nil {
+			grpcStatus = "error"
+		}
+		s.metrics.RecordGRPCCall(ctx, "file", "DeleteFile", grpcStatus, time.Since(grpcStart))
+
+		// The file service answers NotFound/InvalidArgument when the file is already gone.
+		if err != nil && status.Code(err) != codes.NotFound && status.Code(err) != codes.InvalidArgument {
+			continue
+		}
+		deleted = append(deleted, memory.ID)
+	}
+	return deleted
+}
@@ -0,0 +1,353 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/config"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/services"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+func newTrashConfig() *config.TrashConfig {
+	return &config.TrashConfig{RetentionDays: 30, PurgeIntervalMinutes: 60, PurgeBatchSize: 10}
+}
+
+func deletedAt(t time.Time) gorm.DeletedAt {
+	return gorm.DeletedAt{Time: t, Valid: true}
+}
+
+func TestTrashService_ListDeletedHangouts(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	dbError := errors.New("db error")
+	now := time.Now()
+
+	tests := []struct {
+		name        string
+		setup       func(*MockHangoutRepository)
+		wantLen     int
+		wantHasMore bool
+		wantError   error
+	}{
+		{
+			name: "has more",
+			setup: func(hangoutRepo *MockHangoutRepository) {
+				hangoutRepo.On("GetDeletedHangoutsByUserID", mock.Anything, userID, mock.Anything).Return([]domain.Hangout{
+					{ID: uuid.New(), DeletedAt: deletedAt(now)},
+					{ID: uuid.New(), DeletedAt: deletedAt(now)},
+				}, nil)
+			},
+			wantLen:     1,
+			wantHasMore: true,
+		},
+		{
+			name: "repository error",
+			setup: func(hangoutRepo *MockHangoutRepository) {
+				hangoutRepo.On("GetDeletedHangoutsByUserID", mock.Anything, userID, mock.Anything).Return(nil, dbError)
+			},
+			wantError: dbError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hangoutRepo := new(MockHangoutRepository)
+			tt.setup(hangoutRepo)
+			svc := services.NewTrashService(nil, hangoutRepo, nil, nil, newTrashConfig(), nil)
+			res, err := svc.ListDeletedHangouts(ctx, userID, &dto.CursorPagination{Limit: 1})
+			if tt.wantError != nil {
+				require.ErrorIs(t, err, tt.wantError)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, res.Data, tt.wantLen)
+				require.Equal(t, tt.wantHasMore, res.HasMore)
+				require.True(t, now.Add(30*24*time.Hour).Equal(time.Time(res.Data[0].PurgeAt)))
+			}
+			hangoutRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTrashService_ListDeletedMemories(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	dbError := errors.New("db error")
+
+	tests := []struct {
+		name      string
+		setup     func(*MockMemoryRepository)
+		wantLen   int
+		wantError error
+	}{
+		{
+			name: "success",
+			setup: func(memRepo *MockMemoryRepository) {
+				memRepo.On("GetDeletedMemoriesByUserID", mock.Anything, userID, mock.Anything).Return([]domain.Memory{
+					{ID: uuid.New(), DeletedAt: deletedAt(time.Now())},
+				}, nil)
+			},
+			wantLen: 1,
+		},
+		{
+			name: "repository error",
+			setup: func(memRepo *MockMemoryRepository) {
+				memRepo.On("GetDeletedMemoriesByUserID", mock.Anything, userID, mock.Anything).Return(nil, dbError)
+			},
+			wantError: dbError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			memRepo := new(MockMemoryRepository)
+			tt.setup(memRepo)
+			svc := services.NewTrashService(nil, nil, memRepo, nil, newTrashConfig(), nil)
+			res, err := svc.ListDeletedMemories(ctx, userID, &dto.CursorPagination{Limit: 10})
+			if tt.wantError != nil {
+				require.ErrorIs(t, err, tt.wantError)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, res.Data, tt.wantLen)
+				require.False(t, res.HasMore)
+			}
+			memRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTrashService_RestoreHangout(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	hangoutID := uuid.New()
+	trashedAt := time.Now().Add(-time.Hour)
+	dbError := errors.New("db error")
+
+	tests := []struct {
+		name      string
+		setup     func(*MockHangoutRepository, sqlmock.Sqlmock)
+		wantError error
+	}{
+		{
+			name: "success",
+			setup: func(hangoutRepo *MockHangoutRepository, sqlMock sqlmock.Sqlmock) {
+				sqlMock.ExpectBegin()
+				hangoutRepo.On("WithTx", mock.Anything).Return(hangoutRepo)
+				hangoutRepo.On("GetDeletedHangoutByID", mock.Anything, hangoutID, userID).Return(&domain.Hangout{ID: hangoutID, DeletedAt: deletedAt(trashedAt)}, nil)
+				hangoutRepo.On("RestoreHangout", mock.Anything, hangoutID, trashedAt).Return(nil)
+				hangoutRepo.On("GetHangoutByID", mock.Anything, hangoutID, userID).Return(&domain.Hangout{ID: hangoutID}, nil)
+				sqlMock.ExpectCommit()
+			},
+		},
+		{
+			name: "not in trash",
+			setup: func(hangoutRepo *MockHangoutRepository, sqlMock sqlmock.Sqlmock) {
+				sqlMock.ExpectBegin()
+				hangoutRepo.On("WithTx", mock.Anything).Return(hangoutRepo)
+				hangoutRepo.On("GetDeletedHangoutByID", mock.Anything, hangoutID, userID).Return(nil, gorm.ErrRecordNotFound)
+				sqlMock.ExpectRollback()
+			},
+			wantError: gorm.ErrRecordNotFound,
+		},
+		{
+			name: "restore error",
+			setup: func(hangoutRepo *MockHangoutRepository, sqlMock sqlmock.Sqlmock) {
+				sqlMock.ExpectBegin()
+				hangoutRepo.On("WithTx", mock.Anything).Return(hangoutRepo)
+				hangoutRepo.On("GetDeletedHangoutByID", mock.Anything, hangoutID, userID).Return(&domain.Hangout{ID: hangoutID, DeletedAt: deletedAt(trashedAt)}, nil)
+				hangoutRepo.On("RestoreHangout", mock.Anything, hangoutID, trashedAt).Return(dbError)
+				sqlMock.ExpectRollback()
+			},
+			wantError: dbError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, sqlMock := setupDB(t)
+			hangoutRepo := new(MockHangoutRepository)
+			tt.setup(hangoutRepo, sqlMock)
+			svc := services.NewTrashService(db, hangoutRepo, nil, nil, newTrashConfig(), nil)
+			res, err := svc.RestoreHangout(ctx, hangoutID, userID)
+			if tt.wantError != nil {
+				require.ErrorIs(t, err, tt.wantError)
+				require.Nil(t, res)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, hangoutID, res.ID)
+			}
+			hangoutRepo.AssertExpectations(t)
+			require.NoError(t, sqlMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTrashService_RestoreMemory(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	memoryID := uuid.New()
+	hangoutID := uuid.New()
+
+	tests := []struct {
+		name      string
+		setup     func(*MockMemoryRepository, *MockHangoutRepository, sqlmock.Sqlmock)
+		wantError error
+	}{
+		{
+			name: "success",
+			setup: func(memRepo *MockMemoryRepository, hangoutRepo *MockHangoutRepository, sqlMock sqlmock.Sqlmock) {
+				sqlMock.ExpectBegin()
+				memRepo.On("WithTx", mock.Anything).Return(memRepo)
+				hangoutRepo.On("WithTx", mock.Anything).Return(hangoutRepo)
+				memRepo.On("GetDeletedMemoryByID", mock.Anything, memoryID, userID).Return(&domain.Memory{ID: memoryID, HangoutID: hangoutID}, nil)
+				hangoutRepo.On("GetHangoutByID", mock.Anything, hangoutID, userID).Return(&domain.Hangout{ID: hangoutID}, nil)
+				memRepo.On("RestoreMemory", mock.Anything, memoryID).Return(nil)
+				sqlMock.ExpectCommit()
+			},
+		},
+		{
+			name: "memory not in trash",
+			setup: func(memRepo *MockMemoryRepository, hangoutRepo *MockHangoutRepository, sqlMock sqlmock.Sqlmock) {
+				sqlMock.ExpectBegin()
+				memRepo.On("WithTx", mock.Anything).Return(memRepo)
+				memRepo.On("GetDeletedMemoryByID", mock.Anything, memoryID, userID).Return(nil, gorm.ErrRecordNotFound)
+				sqlMock.ExpectRollback()
+			},
+			wantError: apperrors.ErrMemoryNotFound,
+		},
+		{
+			name: "hangout in trash",
+			setup: func(memRepo *MockMemoryRepository, hangoutRepo *MockHangoutRepository, sqlMock sqlmock.Sqlmock) {
+				sqlMock.ExpectBegin()
+				memRepo.On("WithTx", mock.Anything).Return(memRepo)
+				hangoutRepo.On("WithTx", mock.Anything).Return(hangoutRepo)
+				memRepo.On("GetDeletedMemoryByID", mock.Anything, memoryID, userID).Return(&domain.Memory{ID: memoryID, HangoutID: hangoutID}, nil)
+				hangoutRepo.On("GetHangoutByID", mock.Anything, hangoutID, userID).Return(nil, gorm.ErrRecordNotFound)
+				sqlMock.ExpectRollback()
+			},
+			wantError: apperrors.ErrHangoutDeleted,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, sqlMock := setupDB(t)
+			memRepo := new(MockMemoryRepository)
+			hangoutRepo := new(MockHangoutRepository)
+			tt.setup(memRepo, hangoutRepo, sqlMock)
+			svc := services.NewTrashService(db, hangoutRepo, memRepo, nil, newTrashConfig(), nil)
+			err := svc.RestoreMemory(ctx, memoryID, userID)
+			if tt.wantError != nil {
+				require.ErrorIs(t, err, tt.wantError)
+			} else {
+				require.NoError(t, err)
+			}
+			memRepo.AssertExpectations(t)
+			hangoutRepo.AssertExpectations(t)
+			require.NoError(t, sqlMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTrashService_PurgeExpired(t *testing.T) {
+	ctx := context.Background()
+	fileID := uuid.New()
+	dbError := errors.New("db error")
+	grpcError := status.Error(codes.Unavailable, "unavailable")
+
+	memoryWithFile := domain.Memory{ID: uuid.New(), FileID: &fileID}
+	memoryWithoutFile := domain.Memory{ID: uuid.New()}
+	hangoutID := uuid.New()
+
+	tests := []struct {
+		name         string
+		setup        func(*MockMemoryRepository, *MockHangoutRepository, *MockFileService)
+		wantMemories int
+		wantHangouts int
+		wantError    error
+	}{
+		{
+			name: "purges memories and hangouts",
+			setup: func(memRepo *MockMemoryRepository, hangoutRepo *MockHangoutRepository, fileService *MockFileService) {
+				memRepo.On("GetMemoriesDeletedBefore", mock.Anything, mock.Anything, 10).Return([]domain.Memory{memoryWithFile, memoryWithoutFile}, nil)
+				fileService.On("DeleteFile", mock.Anything, memoryWithFile.ID.String()).Return(nil).Once()
+				memRepo.On("PurgeMemories", mock.Anything, []uuid.UUID{memoryWithFile.ID, memoryWithoutFile.ID}).Return(nil)
+				hangoutRepo.On("GetHangoutsDeletedBefore", mock.Anything, mock.Anything, 10).Return([]domain.Hangout{{ID: hangoutID}}, nil)
+				memRepo.On("GetAllMemoriesByHangoutID", mock.Anything, hangoutID).Return([]domain.Memory{memoryWithoutFile}, nil)
+				hangoutRepo.On("PurgeHangout", mock.Anything, hangoutID).Return(nil)
+			},
+			wantMemories: 2,
+			wantHangouts: 1,
+		},
+		{
+			name: "file already gone counts as deleted",
+			setup: func(memRepo *MockMemoryRepository, hangoutRepo *MockHangoutRepository, fileService *MockFileService) {
+				memRepo.On("GetMemoriesDeletedBefore", mock.Anything, mock.Anything, 10).Return([]domain.Memory{memoryWithFile}, nil)
+				fileService.On("DeleteFile", mock.Anything, memoryWithFile.ID.String()).Return(status.Error(codes.NotFound, "not found"))
+				memRepo.On("PurgeMemories", mock.Anything, []uuid.UUID{memoryWithFile.ID}).Return(nil)
+				hangoutRepo.On("GetHangoutsDeletedBefore", mock.Anything, mock.Anything, 10).Return([]domain.Hangout{}, nil)
+			},
+			wantMemories: 1,
+		},
+		{
+			name: "file service failure keeps items for next run",
+			setup: func(memRepo *MockMemoryRepository, hangoutRepo *MockHangoutRepository, fileService *MockFileService) {
+				memRepo.On("GetMemoriesDeletedBefore", mock.Anything, mock.Anything, 10).Return([]domain.Memory{memoryWithFile}, nil)
+				fileService.On("DeleteFile", mock.Anything, memoryWithFile.ID.String()).Return(grpcError)
+				memRepo.On("PurgeMemories", mock.Anything, []uuid.UUID{}).Return(nil)
+				hangoutRepo.On("GetHangoutsDeletedBefore", mock.Anything, mock.Anything, 10).Return([]domain.Hangout{{ID: hangoutID}}, nil)
+				memRepo.On("GetAllMemoriesByHangoutID", mock.Anything, hangoutID).Return([]domain.Memory{memoryWithFile}, nil)
+			},
+		},
+		{
+			name: "list memories error",
+			setup: func(memRepo *MockMemoryRepository, hangoutRepo *MockHangoutRepository, fileService *MockFileService) {
+				memRepo.On("GetMemoriesDeletedBefore", mock.Anything, mock.Anything, 10).Return(nil, dbError)
+			},
+			wantError: dbError,
+		},
+		{
+			name: "purge hangout error",
+			setup: func(memRepo *MockMemoryRepository, hangoutRepo *MockHangoutRepository, fileService *MockFileService) {
+				memRepo.On("GetMemoriesDeletedBefore", mock.Anything, mock.Anything, 10).Return([]domain.Memory{}, nil)
+				memRepo.On("PurgeMemories", mock.Anything, []uuid.UUID{}).Return(nil)
+				hangoutRepo.On("GetHangoutsDeletedBefore", mock.Anything, mock.Anything, 10).Return([]domain.Hangout{{ID: hangoutID}}, nil)
+				memRepo.On("GetAllMemoriesByHangoutID", mock.Anything, hangoutID).Return([]domain.Memory{}, nil)
+				hangoutRepo.On("PurgeHangout", mock.Anything, hangoutID).Return(dbError)
+			},
+			wantError: dbError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			memRepo := new(MockMemoryRepository)
+			hangoutRepo := new(MockHangoutRepository)
+			fileService := new(MockFileService)
+			tt.setup(memRepo, hangoutRepo, fileService)
+			svc := services.NewTrashService(nil, hangoutRepo, memRepo, fileService, newTrashConfig(), nil)
+			memoriesPurged, hangoutsPurged, err := svc.PurgeExpired(ctx)
+			if tt.wantError != nil {
+				require.ErrorIs(t, err, tt.wantError)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.wantMemories, memoriesPurged)
+				require.Equal(t, tt.wantHangouts, hangoutsPurged)
+			}
+			memRepo.AssertExpectations(t)
+			hangoutRepo.AssertExpectations(t)
+			fileService.AssertExpectations(t)
+		})
+	}
+}
@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
@@ -11,12 +12,18 @@ import (
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/otel"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/repository"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/utils"
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
 type UserService interface {
 	CreateUser(ctx context.Context, request dto.CreateUserRequest) (*domain.User, error)
 	GetUserByEmail(ctx context.Context, email string) (*domain.User, error)
+	GetUserByID(ctx context.Context, id uuid.UUID) (*domain.User, error)
+	ChangePassword(ctx context.Context, id uuid.UUID, password string) error
+	MarkEmailVerified(ctx context.Context, id uuid.UUID) error
+	// ConfirmEmailChange swaps in the pending email.
+	ConfirmEmailChange(ctx context.Context, id uuid.UUID, email string) error
 }
 
 type userService struct {
@@ -77,6 +84,47 @@ func (s *userService) GetUserByEmail(ctx context.Context, email string) (*domain
 	return user, err
 }
 
+func (s *userService) GetUserByID(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	recordMetrics := s.metrics.StartRequest(ctx, "user", "get_by_id")
+	user, err := s.userRepo.GetUserByID(ctx, id)
+	recordMetrics(getStatus(err))
+	return user, err
+}
+
+// ChangePassword hashes and stores a new password.
+func (s *userService) ChangePassword(ctx context.Context, id uuid.UUID, password string) error {
+	recordMetrics := s.metrics.StartRequest(ctx, "user", "change_password")
+
+	hashedPassword, err := s.bcryptUtils.GenerateFromPassword(password)
+	if err == nil {
+		err = s.userRepo.UpdatePassword(ctx, id, hashedPassword)
+	}
+
+	recordMetrics(getStatus(err))
+	return err
+}
+
+func (s *userService) MarkEmailVerified(ctx context.Context, id uuid.UUID) error {
+	recordMetrics := s.metrics.StartRequest(ctx, "user", "mark_email_verified")
+	err := s.userRepo.MarkEmailVerified(ctx, id, time.Now())
+	recordMetrics(getStatus(err))
+	return err
+}
+
+func (s *userService) ConfirmEmailChange(ctx context.Context, id uuid.UUID, email string) error {
+	recordMetrics := s.metrics.StartRequest(ctx, "user", "confirm_email_change")
+
+	existing, err := s.userRepo.GetUserByEmail(ctx, email)
+	if err == nil && existing.ID != id {
+		err = apperrors.ErrUserAlreadyExists
+	} else if err == nil || errors.Is(err, gorm.ErrRecordNotFound) {
+		err = s.userRepo.ConfirmPendingEmail(ctx, id, email, time.Now())
+	}
+
+	recordMetrics(getStatus(err))
+	return err
+}
+
 func getStatus(err error) string {
 	if err != nil {
 		return "error"
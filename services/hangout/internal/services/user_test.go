@@ -169,3 +169,67 @@ func TestUserService_GetUserByEmail(t *testing.T) {
 		})
 	}
 }
+
+func TestUserService_ChangePassword(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.New()
+	bcryptError := errors.New("bcrypt error")
+
+	t.Run("success", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		mockBcrypt := new(MockBcryptUtils)
+		service := services.NewUserService(nil, mockRepo, mockBcrypt, nil)
+		mockBcrypt.On("GenerateFromPassword", "new password").Return("new_hash", nil).Once()
+		mockRepo.On("UpdatePassword", ctx, id, "new_hash").Return(nil).Once()
+
+		require.NoError(t, service.ChangePassword(ctx, id, "new password"))
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("hash error", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		mockBcrypt := new(MockBcryptUtils)
+		service := services.NewUserService(nil, mockRepo, mockBcrypt, nil)
+		mockBcrypt.On("GenerateFromPassword", "new password").Return("", bcryptError).Once()
+
+		require.ErrorIs(t, service.ChangePassword(ctx, id, "new password"), bcryptError)
+		mockRepo.AssertNotCalled(t, "UpdatePassword", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestUserService_MarkEmailVerified(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.New()
+
+	mockRepo := new(MockUserRepository)
+	service := services.NewUserService(nil, mockRepo, nil, nil)
+	mockRepo.On("MarkEmailVerified", ctx, id, mock.AnythingOfType("time.Time")).Return(nil).Once()
+
+	require.NoError(t, service.MarkEmailVerified(ctx, id))
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUserService_ConfirmEmailChange(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.New()
+	email := "new@example.com"
+
+	t.Run("Success", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		service := services.NewUserService(nil, mockRepo, nil, nil)
+		mockRepo.On("GetUserByEmail", ctx, email).Return(nil, gorm.ErrRecordNotFound).Once()
+		mockRepo.On("ConfirmPendingEmail", ctx, id, email, mock.AnythingOfType("time.Time")).Return(nil).Once()
+
+		require.NoError(t, service.ConfirmEmailChange(ctx, id, email))
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Email taken meanwhile", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		service := services.NewUserService(nil, mockRepo, nil, nil)
+		mockRepo.On("GetUserByEmail", ctx, email).Return(&domain.User{ID: uuid.New(), Email: email}, nil).Once()
+
+		require.ErrorIs(t, service.ConfirmEmailChange(ctx, id, email), apperrors.ErrUserAlreadyExists)
+		mockRepo.AssertNotCalled(t, "ConfirmPendingEmail", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,391 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/config"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/mapper"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/otel"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/pubsub"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/repository"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/webhook"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type WebhookService interface {
+	// Publish queues a delivery of the event for each of the owner's
+	// subscriptions that listen to its type, so the service can be plugged in
+	// next to the event broker.
+	pubsub.Publisher
+
+	CreateWebhook(ctx context.Context, userID uuid.UUID, req *dto.CreateWebhookRequest) (*dto.WebhookCreatedResponse, error)
+	GetWebhook(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*dto.WebhookResponse, error)
+	ListWebhooks(ctx context.Context, userID uuid.UUID) ([]*dto.WebhookResponse, error)
+	DeleteWebhook(ctx context.Context, id uuid.UUID, userID uuid.UUID) error
+	ListDeliveries(ctx context.Context, id uuid.UUID, userID uuid.UUID, pagination *dto.CursorPagination) (*dto.PaginatedWebhookDeliveries, error)
+	SendTestEvent(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*dto.WebhookDeliveryResponse, error)
+	DeliverDue(ctx context.Context) (succeeded int, failed int, err error)
+}
+
+type webhookService struct {
+	repo    repository.WebhookRepository
+	sender  webhook.Sender
+	cfg     *config.WebhookConfig
+	metrics *otel.MetricsRecorder
+}
+
+func NewWebhookService(repo repository.WebhookRepository, sender webhook.Sender, cfg *config.WebhookConfig, metrics *otel.MetricsRecorder) WebhookService {
+	return &webhookService{
+		repo:    repo,
+		sender:  sender,
+		cfg:     cfg,
+		metrics: metrics,
+	}
+}
+
+func (s *webhookService) CreateWebhook(ctx context.Context, userID uuid.UUID, req *dto.CreateWebhookRequest) (*dto.WebhookCreatedResponse, error) {
+	recordMetrics := s.metrics.StartRequest(ctx, "webhook", "create")
+
+	ctx, span := otel.StartServiceSpan(ctx, "CreateWebhook",
+		attribute.String("user.id", userID.String()),
+	)
+	defer span.End()
+
+	if err := webhook.ValidateURL(req.URL, s.cfg.AllowPrivateTargets); err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	count, err := s.repo.CountSubscriptionsByUserID(ctx, userID)
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+	if count >= constants.MaxWebhooksPerUser {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(apperrors.ErrWebhookLimitReached)
+		return nil, apperrors.ErrWebhookLimitReached
+	}
+
+	secret, err := webhook.GenerateSecret()
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	eventTypes := slices.Clone(req.EventTypes)
+	slices.Sort(eventTypes)
+	subscription := &domain.WebhookSubscription{
+		URL:        req.URL,
+		Secret:     secret,
+		EventTypes: strings.Join(slices.Compact(eventTypes), ","),
+		UserID:     userID,
+	}
+	if err := s.repo.CreateSubscription(ctx, subscription); err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.String("webhook.id", subscription.ID.String()))
+	span.SetStatusOk()
+	recordMetrics("success")
+	return mapper.WebhookToCreatedResponseDTO(subscription), nil
+}
+
+func (s *webhookService) GetWebhook(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*dto.WebhookResponse, error) {
+	recordMetrics := s.metrics.StartRequest(ctx, "webhook", "get")
+
+	ctx, span := otel.StartServiceSpan(ctx, "GetWebhook",
+		attribute.String("webhook.id", id.String()),
+		attribute.String("user.id", userID.String()),
+	)
+	defer span.End()
+
+	subscription, err := s.repo.GetSubscriptionByID(ctx, id, userID)
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetStatusOk()
+	recordMetrics("success")
+	return mapper.WebhookToResponseDTO(subscription), nil
+}
+
+func (s *webhookService) ListWebhooks(ctx context.Context, userID uuid.UUID) ([]*dto.WebhookResponse, error) {
+	recordMetrics := s.metrics.StartRequest(ctx, "webhook", "list")
+
+	ctx, span := otel.StartServiceSpan(ctx, "ListWebhooks",
+		attribute.String("user.id", userID.String()),
+	)
+	defer span.End()
+
+	subscriptions, err := s.repo.GetSubscriptionsByUserID(ctx, userID)
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("webhook.count", len(subscriptions)))
+	span.SetStatusOk()
+	recordMetrics("success")
+	return mapper.WebhooksToResponseDTOs(subscriptions), nil
+}
+
+func (s *webhookService) DeleteWebhook(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
+	recordMetrics := s.metrics.StartRequest(ctx, "webhook", "delete")
+
+	ctx, span := otel.StartServiceSpan(ctx, "DeleteWebhook",
+		attribute.String("webhook.id", id.String()),
+		attribute.String("user.id", userID.String()),
+	)
+	defer span.End()
+
+	if _, err := s.repo.GetSubscriptionByID(ctx, id, userID); err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return err
+	}
+
+	if err := s.repo.DeleteSubscription(ctx, id); err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return err
+	}
+
+	span.SetStatusOk()
+	recordMetrics("success")
+	return nil
+}
+
+func (s *webhookService) ListDeliveries(ctx context.Context, id uuid.UUID, userID uuid.UUID, pagination *dto.CursorPagination) (*dto.PaginatedWebhookDeliveries, error) {
+	recordMetrics := s.metrics.StartRequest(ctx, "webhook", "list_deliveries")
+
+	ctx, span := otel.StartServiceSpan(ctx, "ListDeliveries",
+		attribute.String("webhook.id", id.String()),
+		attribute.String("user.id", userID.String()),
+		attribute.Int("pagination.limit", pagination.GetLimit()),
+	)
+	defer span.End()
+
+	if _, err := s.repo.GetSubscriptionByID(ctx, id, userID); err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	deliveries, err := s.repo.GetDeliveriesBySubscriptionID(ctx, id, pagination)
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	var nextCursor *uuid.UUID
+	limit := pagination.GetLimit()
+	hasMore := len(deliveries) > limit
+	if hasMore {
+		nextCursor = &deliveries[limit-1].ID
+		deliveries = deliveries[:limit]
+	}
+
+	span.SetAttributes(
+		attribute.Int("webhook.delivery_count", len(deliveries)),
+		attribute.Bool("pagination.has_more", hasMore),
+	)
+	span.SetStatusOk()
+	recordMetrics("success")
+	return &dto.PaginatedWebhookDeliveries{
+		Data:       mapper.WebhookDeliveriesToResponseDTOs(deliveries),
+		NextCursor: nextCursor,
+		HasMore:    hasMore,
+	}, nil
+}
+
+// SendTestEvent queues a webhook.test event for the subscription regardless of
+// its event type filter. It is delivered by the regular dispatch job.
+func (s *webhookService) SendTestEvent(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*dto.WebhookDeliveryResponse, error) {
+	recordMetrics := s.metrics.StartRequest(ctx, "webhook", "test")
+
+	ctx, span := otel.StartServiceSpan(ctx, "SendTestEvent",
+		attribute.String("webhook.id", id.String()),
+		attribute.String("user.id", userID.String()),
+	)
+	defer span.End()
+
+	subscription, err := s.repo.GetSubscriptionByID(ctx, id, userID)
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	event := pubsub.NewHangoutEvent(uuid.Nil, constants.EventWebhookTest, dto.WebhookTestEvent{WebhookID: subscription.ID})
+	event.UserID = userID
+
+	deliveries, err := newWebhookDeliveries(event, []domain.WebhookSubscription{*subscription})
+	if err == nil {
+		err = s.repo.CreateDeliveries(ctx, deliveries)
+	}
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return nil, err
+	}
+
+	span.SetStatusOk()
+	recordMetrics("success")
+	return mapper.WebhookDeliveryToResponseDTO(&deliveries[0]), nil
+}
+
+func (s *webhookService) Publish(ctx context.Context, _ string, event pubsub.Event) error {
+	if event.UserID == uuid.Nil {
+		return nil
+	}
+
+	subscriptions, err := s.repo.GetSubscriptionsByUserID(ctx, event.UserID)
+	if err != nil {
+		return err
+	}
+
+	matching := slices.DeleteFunc(subscriptions, func(subscription domain.WebhookSubscription) bool {
+		return !subscription.Subscribes(event.Type)
+	})
+	if len(matching) == 0 {
+		return nil
+	}
+
+	deliveries, err := newWebhookDeliveries(event, matching)
+	if err != nil {
+		return err
+	}
+	return s.repo.CreateDeliveries(ctx, deliveries)
+}
+
+// DeliverDue sends one batch of due deliveries.
+func (s *webhookService) DeliverDue(ctx context.Context) (int, int, error) {
+	recordMetrics := s.metrics.StartRequest(ctx, "webhook", "deliver")
+
+	ctx, span := otel.StartServiceSpan(ctx, "DeliverDue")
+	defer span.End()
+
+	due, err := s.repo.GetDueDeliveries(ctx, time.Now(), s.cfg.DispatchBatchSize)
+	if err != nil {
+		recordMetrics("error")
+		_ = span.RecordErrorWithStatus(err)
+		return 0, 0, err
+	}
+
+	succeeded, failed := 0, 0
+	for i := range due {
+		delivery := &due[i]
+
+		// the lease outlives a send that runs into the request timeout.
+		claimed, err := s.repo.ClaimDelivery(ctx, delivery.ID, *delivery.NextAttemptAt, time.Now().Add(2*s.cfg.GetRequestTimeout()))
+		if err != nil {
+			recordMetrics("error")
+			_ = span.RecordErrorWithStatus(err)
+			return succeeded, failed, err
+		}
+		if !claimed {
+			continue
+		}
+
+		status, sendErr := s.sender.Send(ctx, &webhook.Request{
+			URL:        delivery.Subscription.URL,
+			Secret:     delivery.Subscription.Secret,
+			DeliveryID: delivery.ID.String(),
+			EventType:  delivery.EventType,
+			Payload:    delivery.Payload,
+		})
+		s.recordAttempt(delivery, status, sendErr, time.Now())
+
+		if err := s.repo.UpdateDelivery(ctx, delivery); err != nil {
+			recordMetrics("error")
+			_ = span.RecordErrorWithStatus(err)
+			return succeeded, failed, err
+		}
+
+		if sendErr != nil {
+			failed++
+		} else {
+			succeeded++
+		}
+	}
+
+	span.SetAttributes(
+		attribute.Int("webhook.succeeded", succeeded),
+		attribute.Int("webhook.failed", failed),
+	)
+	span.SetStatusOk()
+	recordMetrics("success")
+	return succeeded, failed, nil
+}
+
+func (s *webhookService) recordAttempt(delivery *domain.WebhookDelivery, status int, sendErr error, attemptedAt time.Time) {
+	delivery.Attempts++
+	delivery.LastAttemptAt = &attemptedAt
+	delivery.ResponseStatus = nil
+	if status != 0 {
+		delivery.ResponseStatus = &status
+	}
+
+	switch {
+	case sendErr == nil:
+		delivery.Status = constants.WebhookDeliverySucceeded
+		delivery.NextAttemptAt = nil
+		delivery.LastError = ""
+	case delivery.Attempts >= s.cfg.MaxAttempts:
+		delivery.Status = constants.WebhookDeliveryDeadLettered
+		delivery.NextAttemptAt = nil
+		delivery.LastError = truncateWebhookError(sendErr)
+	default:
+		next := attemptedAt.Add(s.cfg.GetBackoff(delivery.Attempts))
+		delivery.NextAttemptAt = &next
+		delivery.LastError = truncateWebhookError(sendErr)
+	}
+}
+
+func newWebhookDeliveries(event pubsub.Event, subscriptions []domain.WebhookSubscription) ([]domain.WebhookDelivery, error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	deliveries := make([]domain.WebhookDelivery, len(subscriptions))
+	for i := range subscriptions {
+		deliveries[i] = domain.WebhookDelivery{
+			EventID:        event.ID,
+			EventType:      event.Type,
+			Payload:        payload,
+			Status:         constants.WebhookDeliveryPending,
+			NextAttemptAt:  &now,
+			SubscriptionID: subscriptions[i].ID,
+		}
+	}
+	return deliveries, nil
+}
+
+func truncateWebhookError(err error) string {
+	msg := err.Error()
+	if len(msg) > constants.MaxWebhookErrorLength {
+		return msg[:constants.MaxWebhookErrorLength]
+	}
+	return msg
+}
@@ -0,0 +1,298 @@
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/config"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/dto"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/pubsub"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/services"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/webhook"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func newWebhookTestConfig() *config.WebhookConfig {
+	return &config.WebhookConfig{
+		DispatchBatchSize:     10,
+		MaxAttempts:           3,
+		BackoffBaseSeconds:    30,
+		BackoffMaxSeconds:     3600,
+		RequestTimeoutSeconds: 5,
+	}
+}
+
+func TestWebhookService_CreateWebhook(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	tests := []struct {
+		name      string
+		req       *dto.CreateWebhookRequest
+		setup     func(*MockWebhookRepository)
+		wantError error
+	}{
+		{
+			name: "success with sorted unique event types",
+			req:  &dto.CreateWebhookRequest{URL: "https://hooks.example.com/in", EventTypes: []string{"memory.created", "hangout.status_changed", "memory.created"}},
+			setup: func(repo *MockWebhookRepository) {
+				repo.On("CountSubscriptionsByUserID", mock.Anything, userID).Return(int64(0), nil)
+				repo.On("CreateSubscription", mock.Anything, mock.MatchedBy(func(s *domain.WebhookSubscription) bool {
+					return s.UserID == userID && s.EventTypes == "hangout.status_changed,memory.created" && len(s.Secret) > len(constants.WebhookSecretPrefix)
+				})).Return(nil)
+			},
+		},
+		{
+			name:      "private target refused",
+			req:       &dto.CreateWebhookRequest{URL: "http://127.0.0.1/in", EventTypes: []string{"memory.created"}},
+			setup:     func(repo *MockWebhookRepository) {},
+			wantError: apperrors.ErrWebhookTargetNotAllowed,
+		},
+		{
+			name:      "invalid url",
+			req:       &dto.CreateWebhookRequest{URL: "not a url", EventTypes: []string{"memory.created"}},
+			setup:     func(repo *MockWebhookRepository) {},
+			wantError: apperrors.ErrInvalidWebhookURL,
+		},
+		{
+			name: "limit reached",
+			req:  &dto.CreateWebhookRequest{URL: "https://hooks.example.com/in", EventTypes: []string{"memory.created"}},
+			setup: func(repo *MockWebhookRepository) {
+				repo.On("CountSubscriptionsByUserID", mock.Anything, userID).Return(int64(constants.MaxWebhooksPerUser), nil)
+			},
+			wantError: apperrors.ErrWebhookLimitReached,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockWebhookRepository)
+			tt.setup(repo)
+			svc := services.NewWebhookService(repo, new(MockWebhookSender), newWebhookTestConfig(), nil)
+
+			res, err := svc.CreateWebhook(ctx, userID, tt.req)
+			if tt.wantError != nil {
+				require.ErrorIs(t, err, tt.wantError)
+				require.Nil(t, res)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, []string{"hangout.status_changed", "memory.created"}, res.EventTypes)
+				require.NotEmpty(t, res.Secret)
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestWebhookService_Publish(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	hangoutID := uuid.New()
+	statusHook := domain.WebhookSubscription{ID: uuid.New(), EventTypes: "hangout.status_changed"}
+	memoryHook := domain.WebhookSubscription{ID: uuid.New(), EventTypes: "hangout.status_changed,memory.created"}
+
+	t.Run("queues a delivery per matching subscription", func(t *testing.T) {
+		repo := new(MockWebhookRepository)
+		svc := services.NewWebhookService(repo, new(MockWebhookSender), newWebhookTestConfig(), nil)
+
+		event := pubsub.NewHangoutEvent(hangoutID, constants.EventMemoryCreated, map[string]string{"name": "photo.jpg"})
+		event.UserID = userID
+
+		repo.On("GetSubscriptionsByUserID", mock.Anything, userID).Return([]domain.WebhookSubscription{statusHook, memoryHook}, nil)
+		repo.On("CreateDeliveries", mock.Anything, mock.MatchedBy(func(deliveries []domain.WebhookDelivery) bool {
+			if len(deliveries) != 1 {
+				return false
+			}
+			var payload map[string]any
+			d := deliveries[0]
+			return d.SubscriptionID == memoryHook.ID &&
+				d.EventID == event.ID &&
+				d.Status == constants.WebhookDeliveryPending &&
+				d.NextAttemptAt != nil &&
+				json.Unmarshal(d.Payload, &payload) == nil &&
+				payload["type"] == constants.EventMemoryCreated &&
+				payload["hangout_id"] == hangoutID.String() &&
+				payload["user_id"] == nil
+		})).Return(nil)
+
+		require.NoError(t, svc.Publish(ctx, pubsub.HangoutTopic(hangoutID), event))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("no matching subscription", func(t *testing.T) {
+		repo := new(MockWebhookRepository)
+		svc := services.NewWebhookService(repo, new(MockWebhookSender), newWebhookTestConfig(), nil)
+
+		event := pubsub.NewHangoutEvent(hangoutID, constants.EventHangoutDeleted, nil)
+		event.UserID = userID
+		repo.On("GetSubscriptionsByUserID", mock.Anything, userID).Return([]domain.WebhookSubscription{statusHook}, nil)
+
+		require.NoError(t, svc.Publish(ctx, pubsub.HangoutTopic(hangoutID), event))
+		repo.AssertNotCalled(t, "CreateDeliveries", mock.Anything, mock.Anything)
+	})
+
+	t.Run("event without owner is ignored", func(t *testing.T) {
+		repo := new(MockWebhookRepository)
+		svc := services.NewWebhookService(repo, new(MockWebhookSender), newWebhookTestConfig(), nil)
+
+		require.NoError(t, svc.Publish(ctx, "topic", pubsub.NewHangoutEvent(hangoutID, constants.EventHangoutUpdated, nil)))
+		repo.AssertNotCalled(t, "GetSubscriptionsByUserID", mock.Anything, mock.Anything)
+	})
+}
+
+func TestWebhookService_SendTestEvent(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	subscription := &domain.WebhookSubscription{ID: uuid.New(), EventTypes: "memory.created", UserID: userID}
+
+	t.Run("queues a test delivery", func(t *testing.T) {
+		repo := new(MockWebhookRepository)
+		svc := services.NewWebhookService(repo, new(MockWebhookSender), newWebhookTestConfig(), nil)
+
+		repo.On("GetSubscriptionByID", mock.Anything, subscription.ID, userID).Return(subscription, nil)
+		repo.On("CreateDeliveries", mock.Anything, mock.MatchedBy(func(deliveries []domain.WebhookDelivery) bool {
+			return len(deliveries) == 1 && deliveries[0].EventType == constants.EventWebhookTest && deliveries[0].SubscriptionID == subscription.ID
+		})).Return(nil)
+
+		res, err := svc.SendTestEvent(ctx, subscription.ID, userID)
+		require.NoError(t, err)
+		require.Equal(t, constants.EventWebhookTest, res.EventType)
+		require.Equal(t, constants.WebhookDeliveryPending, res.Status)
+	})
+
+	t.Run("unknown webhook", func(t *testing.T) {
+		repo := new(MockWebhookRepository)
+		svc := services.NewWebhookService(repo, new(MockWebhookSender), newWebhookTestConfig(), nil)
+
+		repo.On("GetSubscriptionByID", mock.Anything, subscription.ID, userID).Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := svc.SendTestEvent(ctx, subscription.ID, userID)
+		require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	})
+}
+
+func TestWebhookService_ListDeliveries(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	webhookID := uuid.New()
+	pagination := &dto.CursorPagination{Limit: 2}
+
+	repo := new(MockWebhookRepository)
+	svc := services.NewWebhookService(repo, new(MockWebhookSender), newWebhookTestConfig(), nil)
+
+	deliveries := []domain.WebhookDelivery{{ID: uuid.New()}, {ID: uuid.New()}, {ID: uuid.New()}}
+	repo.On("GetSubscriptionByID", mock.Anything, webhookID, userID).Return(&domain.WebhookSubscription{ID: webhookID}, nil)
+	repo.On("GetDeliveriesBySubscriptionID", mock.Anything, webhookID, pagination).Return(deliveries, nil)
+
+	res, err := svc.ListDeliveries(ctx, webhookID, userID, pagination)
+	require.NoError(t, err)
+	require.Len(t, res.Data, 2)
+	require.True(t, res.HasMore)
+	require.Equal(t, deliveries[1].ID, *res.NextCursor)
+}
+
+func TestWebhookService_DeliverDue(t *testing.T) {
+	ctx := context.Background()
+	dueAt := time.Now().Add(-time.Minute)
+	subscription := domain.WebhookSubscription{ID: uuid.New(), URL: "https://hooks.example.com/in", Secret: "whsec_s"}
+	sendErr := errors.New("connection refused")
+
+	tests := []struct {
+		name          string
+		attempts      int
+		status        int
+		sendErr       error
+		claimed       bool
+		wantStatus    string
+		wantNext      bool
+		wantSucceeded int
+		wantFailed    int
+	}{
+		{name: "delivered", status: 200, claimed: true, wantStatus: constants.WebhookDeliverySucceeded, wantSucceeded: 1},
+		{name: "failure is retried later", status: 500, sendErr: sendErr, claimed: true, wantStatus: constants.WebhookDeliveryPending, wantNext: true, wantFailed: 1},
+		{name: "last attempt is dead-lettered", attempts: 2, sendErr: sendErr, claimed: true, wantStatus: constants.WebhookDeliveryDeadLettered, wantFailed: 1},
+		{name: "claimed by another instance", claimed: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockWebhookRepository)
+			sender := new(MockWebhookSender)
+			cfg := newWebhookTestConfig()
+			svc := services.NewWebhookService(repo, sender, cfg, nil)
+
+			delivery := domain.WebhookDelivery{
+				ID: uuid.New(), EventType: constants.EventMemoryCreated, Payload: []byte(`{}`),
+				Status: constants.WebhookDeliveryPending, Attempts: tt.attempts, NextAttemptAt: &dueAt,
+				SubscriptionID: subscription.ID, Subscription: subscription,
+			}
+
+			repo.On("GetDueDeliveries", mock.Anything, mock.Anything, cfg.DispatchBatchSize).Return([]domain.WebhookDelivery{delivery}, nil)
+			repo.On("ClaimDelivery", mock.Anything, delivery.ID, dueAt, mock.Anything).Return(tt.claimed, nil)
+			if tt.claimed {
+				sender.On("Send", mock.Anything, mock.MatchedBy(func(req *webhook.Request) bool {
+					return req.URL == subscription.URL && req.Secret == subscription.Secret && req.DeliveryID == delivery.ID.String()
+				})).Return(tt.status, tt.sendErr)
+				repo.On("UpdateDelivery", mock.Anything, mock.MatchedBy(func(d *domain.WebhookDelivery) bool {
+					if d.Status != tt.wantStatus || d.Attempts != tt.attempts+1 || d.LastAttemptAt == nil {
+						return false
+					}
+					if tt.wantNext {
+						return d.NextAttemptAt != nil && d.NextAttemptAt.Sub(*d.LastAttemptAt) == cfg.GetBackoff(d.Attempts) && d.LastError != ""
+					}
+					return d.NextAttemptAt == nil
+				})).Return(nil)
+			}
+
+			succeeded, failed, err := svc.DeliverDue(ctx)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantSucceeded, succeeded)
+			require.Equal(t, tt.wantFailed, failed)
+			repo.AssertExpectations(t)
+			sender.AssertExpectations(t)
+		})
+	}
+}
+
+func TestWebhookService_DeliverDue_LeaseStartsAtClaim(t *testing.T) {
+	ctx := context.Background()
+	dueAt := time.Now().Add(-time.Minute)
+	subscription := domain.WebhookSubscription{ID: uuid.New(), URL: "https://hooks.example.com/in", Secret: "whsec_s"}
+	sendTime := 50 * time.Millisecond
+
+	repo := new(MockWebhookRepository)
+	sender := new(MockWebhookSender)
+	cfg := newWebhookTestConfig()
+	svc := services.NewWebhookService(repo, sender, cfg, nil)
+
+	due := []domain.WebhookDelivery{
+		{ID: uuid.New(), Status: constants.WebhookDeliveryPending, NextAttemptAt: &dueAt, Subscription: subscription},
+		{ID: uuid.New(), Status: constants.WebhookDeliveryPending, NextAttemptAt: &dueAt, Subscription: subscription},
+	}
+	var leases []time.Time
+	repo.On("GetDueDeliveries", mock.Anything, mock.Anything, cfg.DispatchBatchSize).Return(due, nil)
+	repo.On("ClaimDelivery", mock.Anything, mock.Anything, dueAt, mock.AnythingOfType("time.Time")).
+		Run(func(args mock.Arguments) { leases = append(leases, args.Get(3).(time.Time)) }).
+		Return(true, nil)
+	// every send takes a while, as a slow subscriber would
+	sender.On("Send", mock.Anything, mock.Anything).
+		Run(func(mock.Arguments) { time.Sleep(sendTime) }).
+		Return(200, nil)
+	repo.On("UpdateDelivery", mock.Anything, mock.Anything).Return(nil)
+
+	succeeded, _, err := svc.DeliverDue(ctx)
+
+	require.NoError(t, err)
+	require.Equal(t, 2, succeeded)
+	require.Len(t, leases, 2)
+	require.GreaterOrEqual(t, leases[1].Sub(leases[0]), sendTime)
+}
@@ -0,0 +1,76 @@
+package signing
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Key is a parsed signing key.
+type Key struct {
+	ID          string
+	Algorithm   string
+	Private     crypto.Signer
+	Public      crypto.PublicKey
+	ActivatesAt time.Time
+}
+
+// SigningMethod returns the JWT signing method for alg.
+func SigningMethod(alg string) (jwt.SigningMethod, error) {
+	switch alg {
+	case constants.JWTAlgorithmRS256:
+		return jwt.SigningMethodRS256, nil
+	case constants.JWTAlgorithmEdDSA:
+		return jwt.SigningMethodEdDSA, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", apperrors.ErrUnknownSigningAlgorithm, alg)
+	}
+}
+
+// GenerateKeyPair creates a new private key for alg.
+func GenerateKeyPair(alg string) (crypto.Signer, error) {
+	switch alg {
+	case constants.JWTAlgorithmRS256:
+		return rsa.GenerateKey(rand.Reader, constants.RSAKeyBits)
+	case constants.JWTAlgorithmEdDSA:
+		_, private, err := ed25519.GenerateKey(rand.Reader)
+		return private, err
+	default:
+		return nil, fmt.Errorf("%w: %q", apperrors.ErrUnknownSigningAlgorithm, alg)
+	}
+}
+
+// MarshalPublicKey encodes a public key as PKIX DER.
+func MarshalPublicKey(public crypto.PublicKey) ([]byte, error) {
+	return x509.MarshalPKIXPublicKey(public)
+}
+
+func ParsePublicKey(der []byte) (crypto.PublicKey, error) {
+	return x509.ParsePKIXPublicKey(der)
+}
+
+// MarshalPrivateKey encodes a private key as PKCS #8 DER.
+func MarshalPrivateKey(private crypto.Signer) ([]byte, error) {
+	return x509.MarshalPKCS8PrivateKey(private)
+}
+
+func ParsePrivateKey(der []byte) (crypto.Signer, error) {
+	parsed, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, err
+	}
+	private, ok := parsed.(crypto.Signer)
+	if !ok {
+		return nil, errors.New("private key cannot sign")
+	}
+	return private, nil
+}
@@ -0,0 +1,74 @@
+package signing
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// KeyRing holds the published signing keys in memory, so signing and verifying
+// tokens does not hit the database.
+type KeyRing struct {
+	mu   sync.RWMutex
+	keys []Key
+}
+
+func NewKeyRing() *KeyRing {
+	return &KeyRing{}
+}
+
+// Replace swaps in a new set of keys.
+func (r *KeyRing) Replace(keys []Key) {
+	sorted := make([]Key, len(keys))
+	copy(sorted, keys)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].ActivatesAt.Before(sorted[j].ActivatesAt)
+	})
+
+	r.mu.Lock()
+	r.keys = sorted
+	r.mu.Unlock()
+}
+
+// Current returns the key that signs new tokens at now: the most recently
+// activated key with a usable private key. It returns nil when there is none.
+func (r *KeyRing) Current(now time.Time) *Key {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for i := len(r.keys) - 1; i >= 0; i-- {
+		key := r.keys[i]
+		if key.Private != nil && !key.ActivatesAt.After(now) {
+			return &key
+		}
+	}
+	return nil
+}
+
+// Lookup returns the key with the given kid, including keys that are
+// published but not active yet.
+func (r *KeyRing) Lookup(kid string) (*Key, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, key := range r.keys {
+		if key.ID == kid {
+			return &key, true
+		}
+	}
+	return nil, false
+}
+
+// JWKS returns the public half of every key in the ring.
+func (r *KeyRing) JWKS() JWKSet {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	set := JWKSet{Keys: make([]JWK, 0, len(r.keys))}
+	for _, key := range r.keys {
+		if jwk, ok := toJWK(key); ok {
+			set.Keys = append(set.Keys, jwk)
+		}
+	}
+	return set
+}
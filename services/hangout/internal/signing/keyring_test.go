@@ -0,0 +1,142 @@
+package signing_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/signing"
+	"github.com/stretchr/testify/require"
+)
+
+func newKey(t *testing.T, id, alg string, activatesAt time.Time, canSign bool) signing.Key {
+	t.Helper()
+	private, err := signing.GenerateKeyPair(alg)
+	require.NoError(t, err)
+	key := signing.Key{ID: id, Algorithm: alg, Public: private.Public(), ActivatesAt: activatesAt}
+	if canSign {
+		key.Private = private
+	}
+	return key
+}
+
+func TestKeyRing_Current(t *testing.T) {
+	now := time.Now()
+	old := newKey(t, "old", constants.JWTAlgorithmEdDSA, now.Add(-48*time.Hour), true)
+	current := newKey(t, "current", constants.JWTAlgorithmEdDSA, now.Add(-time.Hour), true)
+	verifyOnly := newKey(t, "verify-only", constants.JWTAlgorithmEdDSA, now.Add(-time.Minute), false)
+	pending := newKey(t, "pending", constants.JWTAlgorithmEdDSA, now.Add(time.Hour), true)
+
+	tests := []struct {
+		name   string
+		keys   []signing.Key
+		wantID string
+	}{
+		{name: "Empty"},
+		{name: "LatestActivated", keys: []signing.Key{current, old}, wantID: "current"},
+		{name: "SkipsPending", keys: []signing.Key{pending, current, old}, wantID: "current"},
+		{name: "SkipsVerifyOnly", keys: []signing.Key{verifyOnly, current}, wantID: "current"},
+		{name: "OnlyPending", keys: []signing.Key{pending}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ring := signing.NewKeyRing()
+			ring.Replace(tt.keys)
+
+			key := ring.Current(now)
+			if tt.wantID == "" {
+				require.Nil(t, key)
+				return
+			}
+			require.NotNil(t, key)
+			require.Equal(t, tt.wantID, key.ID)
+		})
+	}
+}
+
+func TestKeyRing_Lookup(t *testing.T) {
+	now := time.Now()
+	ring := signing.NewKeyRing()
+	ring.Replace([]signing.Key{
+		newKey(t, "current", constants.JWTAlgorithmEdDSA, now.Add(-time.Hour), true),
+		newKey(t, "pending", constants.JWTAlgorithmRS256, now.Add(time.Hour), true),
+	})
+
+	key, ok := ring.Lookup("pending")
+	require.True(t, ok)
+	require.Equal(t, constants.JWTAlgorithmRS256, key.Algorithm)
+
+	_, ok = ring.Lookup("missing")
+	require.False(t, ok)
+}
+
+func TestKeyRing_JWKS(t *testing.T) {
+	now := time.Now()
+	rsaKey := newKey(t, "rsa", constants.JWTAlgorithmRS256, now.Add(-time.Hour), true)
+	edKey := newKey(t, "ed", constants.JWTAlgorithmEdDSA, now.Add(time.Hour), false)
+	ring := signing.NewKeyRing()
+	ring.Replace([]signing.Key{edKey, rsaKey})
+
+	set := ring.JWKS()
+
+	require.Len(t, set.Keys, 2)
+	rsaPublic := rsaKey.Public.(*rsa.PublicKey)
+	require.Equal(t, signing.JWK{
+		Kty: "RSA",
+		Use: "sig",
+		Alg: constants.JWTAlgorithmRS256,
+		Kid: "rsa",
+		N:   base64.RawURLEncoding.EncodeToString(rsaPublic.N.Bytes()),
+		E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(rsaPublic.E)).Bytes()),
+	}, set.Keys[0])
+	require.Equal(t, signing.JWK{
+		Kty: "OKP",
+		Use: "sig",
+		Alg: constants.JWTAlgorithmEdDSA,
+		Kid: "ed",
+		Crv: "Ed25519",
+		X:   base64.RawURLEncoding.EncodeToString(edKey.Public.(ed25519.PublicKey)),
+	}, set.Keys[1])
+	require.Equal(t, "AQAB", set.Keys[0].E)
+
+	require.NotNil(t, signing.NewKeyRing().JWKS().Keys, "an empty set still encodes as a list")
+}
+
+func TestKeyEncoding(t *testing.T) {
+	for _, alg := range []string{constants.JWTAlgorithmRS256, constants.JWTAlgorithmEdDSA} {
+		t.Run(alg, func(t *testing.T) {
+			private, err := signing.GenerateKeyPair(alg)
+			require.NoError(t, err)
+
+			publicDER, err := signing.MarshalPublicKey(private.Public())
+			require.NoError(t, err)
+			public, err := signing.ParsePublicKey(publicDER)
+			require.NoError(t, err)
+			require.Equal(t, private.Public(), public)
+
+			privateDER, err := signing.MarshalPrivateKey(private)
+			require.NoError(t, err)
+			parsed, err := signing.ParsePrivateKey(privateDER)
+			require.NoError(t, err)
+			require.Equal(t, private, parsed)
+
+			method, err := signing.SigningMethod(alg)
+			require.NoError(t, err)
+			require.Equal(t, alg, method.Alg())
+		})
+	}
+}
+
+func TestUnknownAlgorithm(t *testing.T) {
+	_, err := signing.GenerateKeyPair(constants.JWTAlgorithmHS256)
+	require.ErrorIs(t, err, apperrors.ErrUnknownSigningAlgorithm)
+
+	_, err = signing.SigningMethod("ES256")
+	require.ErrorIs(t, err, apperrors.ErrUnknownSigningAlgorithm)
+}
@@ -0,0 +1,59 @@
+package signing_test
+
+import (
+	"testing"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/signing"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSealer(t *testing.T) {
+	sealer, err := signing.NewSealer("a-very-long-and-secure-secret-key-for-testing", constants.SigningKeySealPurpose)
+	require.NoError(t, err)
+	plaintext := []byte("private key bytes")
+
+	sealed, err := sealer.Seal(plaintext)
+	require.NoError(t, err)
+	require.NotContains(t, string(sealed), string(plaintext))
+
+	opened, err := sealer.Open(sealed)
+	require.NoError(t, err)
+	require.Equal(t, plaintext, opened)
+
+	again, err := sealer.Seal(plaintext)
+	require.NoError(t, err)
+	require.NotEqual(t, sealed, again, "every seal uses a fresh nonce")
+}
+
+func TestSealer_Open_Errors(t *testing.T) {
+	sealer, err := signing.NewSealer("a-very-long-and-secure-secret-key-for-testing", constants.SigningKeySealPurpose)
+	require.NoError(t, err)
+	other, err := signing.NewSealer("another-secret", constants.SigningKeySealPurpose)
+	require.NoError(t, err)
+
+	sealed, err := sealer.Seal([]byte("private key bytes"))
+	require.NoError(t, err)
+	otherPurpose, err := signing.NewSealer("a-very-long-and-secure-secret-key-for-testing", "another-purpose")
+	require.NoError(t, err)
+	tampered := append([]byte{}, sealed...)
+	tampered[len(tampered)-1] ^= 0xff
+
+	tests := []struct {
+		name   string
+		sealer *signing.Sealer
+		sealed []byte
+	}{
+		{name: "WrongSecret", sealer: other, sealed: sealed},
+		{name: "WrongPurpose", sealer: otherPurpose, sealed: sealed},
+		{name: "Tampered", sealer: sealer, sealed: tampered},
+		{name: "TooShort", sealer: sealer, sealed: []byte("short")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.sealer.Open(tt.sealed)
+			require.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/auth"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/config"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+// ActionTokenUtils signs the expiring tokens sent in account emails.
+type ActionTokenUtils interface {
+	Generate(purpose string, userID uuid.UUID, fingerprint string, ttl time.Duration) (string, error)
+	Verify(purpose string, token string) (*auth.ActionTokenClaims, error)
+}
+
+type actionTokenUtils struct {
+	secret []byte
+}
+
+func NewActionTokenUtils(jwtConfig *config.JwtConfig) ActionTokenUtils {
+	return &actionTokenUtils{secret: []byte(jwtConfig.JWTSecret)}
+}
+
+func (a *actionTokenUtils) Generate(purpose string, userID uuid.UUID, fingerprint string, ttl time.Duration) (string, error) {
+	now := time.Now()
+	claims := &auth.ActionTokenClaims{
+		UserID:      userID,
+		Purpose:     purpose,
+		Fingerprint: fingerprint,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+			IssuedAt:  jwt.NewNumericDate(now),
+		},
+	}
+
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(a.key(purpose))
+}
+
+// Verify returns the claims of a valid, unexpired token issued for purpose.
+func (a *actionTokenUtils) Verify(purpose string, token string) (*auth.ActionTokenClaims, error) {
+	claims := &auth.ActionTokenClaims{}
+	_, err := jwt.ParseWithClaims(token, claims, func(*jwt.Token) (any, error) {
+		return a.key(purpose), nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithExpirationRequired())
+	if err != nil || claims.Purpose != purpose {
+		return nil, apperrors.ErrInvalidActionToken
+	}
+	return claims, nil
+}
+
+func (a *actionTokenUtils) key(purpose string) []byte {
+	mac := hmac.New(sha256.New, a.secret)
+	mac.Write([]byte("action-token:" + purpose))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,70 @@
+package utils_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/auth"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/config"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/signing"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/utils"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestActionTokenUtils(t *testing.T) {
+	cfg := &config.JwtConfig{JWTSecret: validSecret, JWTExpirationHours: 1}
+	tokens := utils.NewActionTokenUtils(cfg)
+	userID := uuid.New()
+
+	token, err := tokens.Generate(constants.ActionTokenPasswordReset, userID, "fp", time.Hour)
+	require.NoError(t, err)
+
+	t.Run("valid token", func(t *testing.T) {
+		claims, err := tokens.Verify(constants.ActionTokenPasswordReset, token)
+		require.NoError(t, err)
+		require.Equal(t, userID, claims.UserID)
+		require.Equal(t, "fp", claims.Fingerprint)
+	})
+
+	t.Run("other purpose", func(t *testing.T) {
+		_, err := tokens.Verify(constants.ActionTokenEmailVerification, token)
+		require.ErrorIs(t, err, apperrors.ErrInvalidActionToken)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		expired, err := tokens.Generate(constants.ActionTokenPasswordReset, userID, "fp", -time.Minute)
+		require.NoError(t, err)
+		_, err = tokens.Verify(constants.ActionTokenPasswordReset, expired)
+		require.ErrorIs(t, err, apperrors.ErrInvalidActionToken)
+	})
+
+	t.Run("other secret", func(t *testing.T) {
+		other := utils.NewActionTokenUtils(&config.JwtConfig{JWTSecret: "another-secret"})
+		_, err := other.Verify(constants.ActionTokenPasswordReset, token)
+		require.ErrorIs(t, err, apperrors.ErrInvalidActionToken)
+	})
+
+	t.Run("tampered", func(t *testing.T) {
+		_, err := tokens.Verify(constants.ActionTokenPasswordReset, token+"x")
+		require.ErrorIs(t, err, apperrors.ErrInvalidActionToken)
+	})
+
+	t.Run("not usable as an access token", func(t *testing.T) {
+		_, err := jwt.ParseWithClaims(token, &auth.TokenCustomClaims{}, func(*jwt.Token) (any, error) {
+			return []byte(validSecret), nil
+		})
+		require.Error(t, err)
+	})
+
+	t.Run("access token is not an action token", func(t *testing.T) {
+		access, err := utils.NewJWTUtils(cfg, signing.NewKeyRing()).Generate(&domain.User{ID: userID, Email: "a@example.com"}, uuid.New())
+		require.NoError(t, err)
+		_, err = tokens.Verify(constants.ActionTokenPasswordReset, access)
+		require.ErrorIs(t, err, apperrors.ErrInvalidActionToken)
+	})
+}
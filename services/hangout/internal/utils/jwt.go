@@ -1,44 +1,84 @@
 package utils
 
 import (
+	"errors"
+	"fmt"
 	"time"
 
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/auth"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/config"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/signing"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 )
 
 type JWTUtils interface {
-	Generate(user *domain.User) (string, error)
+	// Generate signs an access token for user, bound to the session with
+	// the given ID.
+	Generate(user *domain.User, sessionID uuid.UUID) (string, error)
+	// Keyfunc returns the key that verifies token, for use with jwt.Parse.
+	Keyfunc(token *jwt.Token) (any, error)
 }
 
 type jwtUtils struct {
-	Secret          string
-	ExpirationHours int
+	cfg  *config.JwtConfig
+	keys *signing.KeyRing
 }
 
-func NewJWTUtils(jWtConfig *config.JwtConfig) JWTUtils {
-	return &jwtUtils{Secret: jWtConfig.JWTSecret, ExpirationHours: jWtConfig.JWTExpirationHours}
+func NewJWTUtils(jwtConfig *config.JwtConfig, keys *signing.KeyRing) JWTUtils {
+	return &jwtUtils{cfg: jwtConfig, keys: keys}
 }
 
-func (j *jwtUtils) Generate(user *domain.User) (string, error) {
-	expirationTime := time.Now().Add(time.Duration(j.ExpirationHours) * time.Hour)
+func (j *jwtUtils) Generate(user *domain.User, sessionID uuid.UUID) (string, error) {
+	now := time.Now()
 	claims := &auth.TokenCustomClaims{
-		UserID: user.ID,
+		UserID:    user.ID,
+		SessionID: sessionID,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(expirationTime),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			ExpiresAt: jwt.NewNumericDate(now.Add(j.cfg.GetExpiration())),
+			IssuedAt:  jwt.NewNumericDate(now),
 			Subject:   user.Email,
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	if !j.cfg.UsesKeyPairs() {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+		return token.SignedString([]byte(j.cfg.JWTSecret))
+	}
 
-	tokenString, err := token.SignedString([]byte(j.Secret))
+	key := j.keys.Current(now)
+	if key == nil {
+		return "", apperrors.ErrNoSigningKey
+	}
+	method, err := signing.SigningMethod(key.Algorithm)
 	if err != nil {
 		return "", err
 	}
 
-	return tokenString, nil
+	token := jwt.NewWithClaims(method, claims)
+	token.Header["kid"] = key.ID
+	return token.SignedString(key.Private)
+}
+
+func (j *jwtUtils) Keyfunc(token *jwt.Token) (any, error) {
+	alg := token.Method.Alg()
+
+	if kid, ok := token.Header["kid"].(string); ok && kid != "" {
+		key, found := j.keys.Lookup(kid)
+		if !found {
+			return nil, fmt.Errorf("unknown signing key %q", kid)
+		}
+		if alg != key.Algorithm {
+			return nil, fmt.Errorf("signing key %q does not use %s", kid, alg)
+		}
+		return key.Public, nil
+	}
+
+	if alg == constants.JWTAlgorithmHS256 && (!j.cfg.UsesKeyPairs() || j.cfg.JWTAcceptHS256) {
+		return []byte(j.cfg.JWTSecret), nil
+	}
+	return nil, errors.New("token has no usable signing key")
 }
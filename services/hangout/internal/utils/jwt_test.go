@@ -5,9 +5,12 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/auth"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/config"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/domain"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/signing"
 	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/utils"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
@@ -21,7 +24,7 @@ func TestNewJWTUtils(t *testing.T) {
 		JWTExpirationHours: 24,
 	}
 
-	jwtUtil := utils.NewJWTUtils(cfg)
+	jwtUtil := utils.NewJWTUtils(cfg, signing.NewKeyRing())
 
 	if jwtUtil == nil {
 		t.Fatal("NewJWTUtils returned nil, expected JWTUtils implementation")
@@ -62,11 +65,12 @@ func TestJWTUtils_Generate(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			jwtUtil := utils.NewJWTUtils(tt.cfg)
+			jwtUtil := utils.NewJWTUtils(tt.cfg, signing.NewKeyRing())
 
 			timeBefore := time.Now().Add(-1 * time.Second)
 
-			tokenString, err := jwtUtil.Generate(tt.user)
+			sessionID := uuid.New()
+			tokenString, err := jwtUtil.Generate(tt.user, sessionID)
 			if err != nil {
 				t.Fatalf("Generate() returned unexpected error: %v", err)
 			}
@@ -95,6 +99,9 @@ func TestJWTUtils_Generate(t *testing.T) {
 				if claims.UserID != tt.user.ID {
 					t.Errorf("Claim UserID got = %v, want %v", claims.UserID, tt.user.ID)
 				}
+				if claims.SessionID != sessionID {
+					t.Errorf("Claim SessionID got = %v, want %v", claims.SessionID, sessionID)
+				}
 				if claims.Subject != tt.user.Email {
 					t.Errorf("Claim Subject got = %s, want %s", claims.Subject, tt.user.Email)
 				}
@@ -117,3 +124,144 @@ func TestJWTUtils_Generate(t *testing.T) {
 		})
 	}
 }
+
+func newTestKeyRing(t *testing.T, alg string, activatesAt time.Time) (*signing.KeyRing, signing.Key) {
+	t.Helper()
+	private, err := signing.GenerateKeyPair(alg)
+	if err != nil {
+		t.Fatalf("GenerateKeyPair(%s) returned error: %v", alg, err)
+	}
+	key := signing.Key{ID: uuid.NewString(), Algorithm: alg, Private: private, Public: private.Public(), ActivatesAt: activatesAt}
+	ring := signing.NewKeyRing()
+	ring.Replace([]signing.Key{key})
+	return ring, key
+}
+
+func TestJWTUtils_KeyPairs(t *testing.T) {
+	testUser := &domain.User{ID: uuid.New(), Email: "test@example.com"}
+
+	for _, alg := range []string{constants.JWTAlgorithmRS256, constants.JWTAlgorithmEdDSA} {
+		t.Run(alg, func(t *testing.T) {
+			cfg := &config.JwtConfig{JWTSecret: validSecret, JWTExpirationHours: 1, JWTAlgorithm: alg}
+			ring, key := newTestKeyRing(t, alg, time.Now().Add(-time.Minute))
+			jwtUtil := utils.NewJWTUtils(cfg, ring)
+
+			tokenString, err := jwtUtil.Generate(testUser, uuid.New())
+			if err != nil {
+				t.Fatalf("Generate() returned unexpected error: %v", err)
+			}
+
+			claims := &auth.TokenCustomClaims{}
+			token, err := jwt.ParseWithClaims(tokenString, claims, jwtUtil.Keyfunc)
+			if err != nil {
+				t.Fatalf("Failed to verify token: %v", err)
+			}
+			if token.Header["kid"] != key.ID {
+				t.Errorf("kid header got = %v, want %v", token.Header["kid"], key.ID)
+			}
+			if token.Method.Alg() != alg {
+				t.Errorf("alg got = %v, want %v", token.Method.Alg(), alg)
+			}
+			if claims.UserID != testUser.ID {
+				t.Errorf("Claim UserID got = %v, want %v", claims.UserID, testUser.ID)
+			}
+		})
+	}
+}
+
+func TestJWTUtils_Generate_NoActiveKey(t *testing.T) {
+	cfg := &config.JwtConfig{JWTSecret: validSecret, JWTExpirationHours: 1, JWTAlgorithm: constants.JWTAlgorithmEdDSA}
+	ring, _ := newTestKeyRing(t, constants.JWTAlgorithmEdDSA, time.Now().Add(time.Hour))
+
+	_, err := utils.NewJWTUtils(cfg, ring).Generate(&domain.User{ID: uuid.New()}, uuid.New())
+	if !errors.Is(err, apperrors.ErrNoSigningKey) {
+		t.Fatalf("Generate() error got = %v, want %v", err, apperrors.ErrNoSigningKey)
+	}
+}
+
+func TestJWTUtils_Keyfunc(t *testing.T) {
+	ring, key := newTestKeyRing(t, constants.JWTAlgorithmEdDSA, time.Now().Add(-time.Minute))
+	_, otherKey := newTestKeyRing(t, constants.JWTAlgorithmEdDSA, time.Now().Add(-time.Minute))
+	claims := &auth.TokenCustomClaims{
+		UserID:           uuid.New(),
+		RegisteredClaims: jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour))},
+	}
+
+	sign := func(method jwt.SigningMethod, kid string, signingKey any) string {
+		token := jwt.NewWithClaims(method, claims)
+		if kid != "" {
+			token.Header["kid"] = kid
+		}
+		s, err := token.SignedString(signingKey)
+		if err != nil {
+			t.Fatalf("failed to sign test token: %v", err)
+		}
+		return s
+	}
+
+	tests := []struct {
+		name      string
+		cfg       *config.JwtConfig
+		token     string
+		wantValid bool
+	}{
+		{
+			name:      "KeyPair_KnownKid",
+			cfg:       &config.JwtConfig{JWTSecret: validSecret, JWTAlgorithm: constants.JWTAlgorithmEdDSA},
+			token:     sign(jwt.SigningMethodEdDSA, key.ID, key.Private),
+			wantValid: true,
+		},
+		{
+			name:  "KeyPair_UnknownKid",
+			cfg:   &config.JwtConfig{JWTSecret: validSecret, JWTAlgorithm: constants.JWTAlgorithmEdDSA},
+			token: sign(jwt.SigningMethodEdDSA, otherKey.ID, otherKey.Private),
+		},
+		{
+			name:  "KeyPair_KidSignedByOtherKey",
+			cfg:   &config.JwtConfig{JWTSecret: validSecret, JWTAlgorithm: constants.JWTAlgorithmEdDSA},
+			token: sign(jwt.SigningMethodEdDSA, key.ID, otherKey.Private),
+		},
+		{
+			name:  "KeyPair_AlgorithmMismatch",
+			cfg:   &config.JwtConfig{JWTSecret: validSecret, JWTAlgorithm: constants.JWTAlgorithmEdDSA},
+			token: sign(jwt.SigningMethodHS256, key.ID, []byte(validSecret)),
+		},
+		{
+			name:      "KeyPair_AcceptsHS256",
+			cfg:       &config.JwtConfig{JWTSecret: validSecret, JWTAlgorithm: constants.JWTAlgorithmEdDSA, JWTAcceptHS256: true},
+			token:     sign(jwt.SigningMethodHS256, "", []byte(validSecret)),
+			wantValid: true,
+		},
+		{
+			name:  "KeyPair_RejectsHS256",
+			cfg:   &config.JwtConfig{JWTSecret: validSecret, JWTAlgorithm: constants.JWTAlgorithmEdDSA},
+			token: sign(jwt.SigningMethodHS256, "", []byte(validSecret)),
+		},
+		{
+			name:      "HS256_Secret",
+			cfg:       &config.JwtConfig{JWTSecret: validSecret, JWTAlgorithm: constants.JWTAlgorithmHS256},
+			token:     sign(jwt.SigningMethodHS256, "", []byte(validSecret)),
+			wantValid: true,
+		},
+		{
+			name:      "HS256_StillVerifiesKeyPairTokens",
+			cfg:       &config.JwtConfig{JWTSecret: validSecret, JWTAlgorithm: constants.JWTAlgorithmHS256},
+			token:     sign(jwt.SigningMethodEdDSA, key.ID, key.Private),
+			wantValid: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jwtUtil := utils.NewJWTUtils(tt.cfg, ring)
+
+			_, err := jwt.ParseWithClaims(tt.token, &auth.TokenCustomClaims{}, jwtUtil.Keyfunc)
+			if tt.wantValid && err != nil {
+				t.Fatalf("expected token to verify, got error: %v", err)
+			}
+			if !tt.wantValid && err == nil {
+				t.Fatal("expected token to be rejected")
+			}
+		})
+	}
+}
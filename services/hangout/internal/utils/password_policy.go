@@ -0,0 +1,87 @@
+package utils
+
+import (
+	_ "embed"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/config"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+)
+
+//go:embed breached_passwords.txt
+var breachedPasswordList string
+
+// PasswordPolicy checks new passwords.
+type PasswordPolicy interface {
+	Validate(password string) error
+}
+
+type passwordPolicy struct {
+	cfg      *config.PasswordPolicyConfig
+	breached map[string]struct{}
+}
+
+func NewPasswordPolicy(cfg *config.PasswordPolicyConfig) PasswordPolicy {
+	policy := &passwordPolicy{cfg: cfg}
+	if cfg.CheckBreached {
+		policy.breached = parseBreachedPasswords(breachedPasswordList)
+	}
+	return policy
+}
+
+func (p *passwordPolicy) Validate(password string) error {
+	if utf8.RuneCountInString(password) < p.cfg.MinLength {
+		return fmt.Errorf("%w: must be at least %d characters long", apperrors.ErrWeakPassword, p.cfg.MinLength)
+	}
+	// bcrypt only uses the first 72 bytes
+	if len(password) > constants.MaxPasswordBytes {
+		return fmt.Errorf("%w: must be at most %d bytes long", apperrors.ErrWeakPassword, constants.MaxPasswordBytes)
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r):
+			hasSymbol = true
+		}
+	}
+	switch {
+	case p.cfg.RequireUppercase && !hasUpper:
+		return fmt.Errorf("%w: must contain an uppercase letter", apperrors.ErrWeakPassword)
+	case p.cfg.RequireLowercase && !hasLower:
+		return fmt.Errorf("%w: must contain a lowercase letter", apperrors.ErrWeakPassword)
+	case p.cfg.RequireDigit && !hasDigit:
+		return fmt.Errorf("%w: must contain a digit", apperrors.ErrWeakPassword)
+	case p.cfg.RequireSymbol && !hasSymbol:
+		return fmt.Errorf("%w: must contain a symbol", apperrors.ErrWeakPassword)
+	}
+
+	if _, ok := p.breached[strings.ToLower(password)]; ok {
+		return fmt.Errorf("%w: appears in a list of breached passwords", apperrors.ErrWeakPassword)
+	}
+	return nil
+}
+
+// parseBreachedPasswords reads one password per line, skipping blank lines
+// and # comments.
+func parseBreachedPasswords(list string) map[string]struct{} {
+	breached := make(map[string]struct{})
+	for _, line := range strings.Split(list, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		breached[strings.ToLower(line)] = struct{}{}
+	}
+	return breached
+}
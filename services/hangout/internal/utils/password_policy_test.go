@@ -0,0 +1,53 @@
+package utils_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/config"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPasswordPolicy_Validate(t *testing.T) {
+	strict := &config.PasswordPolicyConfig{
+		MinLength:        10,
+		RequireUppercase: true,
+		RequireLowercase: true,
+		RequireDigit:     true,
+		RequireSymbol:    true,
+		CheckBreached:    true,
+	}
+
+	tests := []struct {
+		name       string
+		cfg        *config.PasswordPolicyConfig
+		password   string
+		wantReason string
+	}{
+		{name: "long passphrase", cfg: &config.PasswordPolicyConfig{MinLength: 10, CheckBreached: true}, password: "correct horse battery staple"},
+		{name: "length counts characters, not bytes", cfg: &config.PasswordPolicyConfig{MinLength: 10}, password: "ééééééééé", wantReason: "at least 10 characters"},
+		{name: "too short", cfg: strict, password: "Ab1!", wantReason: "at least 10 characters"},
+		{name: "too long for bcrypt", cfg: strict, password: "Ab1!" + strings.Repeat("x", 69), wantReason: "at most 72 bytes"},
+		{name: "missing uppercase", cfg: strict, password: "lowercase1!", wantReason: "uppercase letter"},
+		{name: "missing lowercase", cfg: strict, password: "UPPERCASE1!", wantReason: "lowercase letter"},
+		{name: "missing digit", cfg: strict, password: "NoDigitsHere!", wantReason: "digit"},
+		{name: "missing symbol", cfg: strict, password: "NoSymbols123", wantReason: "symbol"},
+		{name: "all classes", cfg: strict, password: "Tr0ub4dor&3x"},
+		{name: "breached ignoring case", cfg: &config.PasswordPolicyConfig{MinLength: 8, CheckBreached: true}, password: "QwertyUIOP123", wantReason: "breached"},
+		{name: "breached check disabled", cfg: &config.PasswordPolicyConfig{MinLength: 8}, password: "qwertyuiop123"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := utils.NewPasswordPolicy(tt.cfg).Validate(tt.password)
+			if tt.wantReason == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, apperrors.ErrWeakPassword)
+			require.Contains(t, err.Error(), tt.wantReason)
+		})
+	}
+}
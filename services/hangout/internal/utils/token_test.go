@@ -0,0 +1,28 @@
+package utils_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateToken(t *testing.T) {
+	token, err := utils.GenerateToken("shr_")
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(token, "shr_"))
+	require.Len(t, token, len("shr_")+43)
+	require.NotContains(t, token, "=")
+
+	other, err := utils.GenerateToken("shr_")
+	require.NoError(t, err)
+	require.NotEqual(t, token, other)
+}
+
+func TestHashToken(t *testing.T) {
+	hash := utils.HashToken("shr_token")
+	require.Len(t, hash, 64)
+	require.Equal(t, hash, utils.HashToken("shr_token"))
+	require.NotEqual(t, hash, utils.HashToken("shr_other"))
+}
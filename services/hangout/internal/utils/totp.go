@@ -0,0 +1,123 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+)
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateTOTPSecret returns a random secret as unpadded base32, the form
+// authenticator apps expect.
+func GenerateTOTPSecret() (string, error) {
+	b := make([]byte, constants.TOTPSecretBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return totpEncoding.EncodeToString(b), nil
+}
+
+// TOTPStep returns the RFC 6238 time step that t falls in.
+func TOTPStep(t time.Time) int64 {
+	return t.Unix() / constants.TOTPPeriodSeconds
+}
+
+// TOTPCode returns the code of secret for a time step: HMAC-SHA1 with
+// dynamic truncation to TOTPDigits digits, as RFC 4226 describes.
+func TOTPCode(secret string, step int64) (string, error) {
+	key, err := totpEncoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return "", err
+	}
+
+	var counter [8]byte
+	binary.BigEndian.PutUint64(counter[:], uint64(step))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	modulo := uint32(1)
+	for range constants.TOTPDigits {
+		modulo *= 10
+	}
+	return fmt.Sprintf("%0*d", constants.TOTPDigits, value%modulo), nil
+}
+
+// ValidateTOTP checks code against the steps around now, allowing
+// TOTPAllowedSkewSteps of clock drift either way, and returns the matching step.
+func ValidateTOTP(secret string, code string, now time.Time, lastStep int64) (int64, bool) {
+	code = strings.TrimSpace(code)
+	if len(code) != constants.TOTPDigits {
+		return 0, false
+	}
+
+	current := TOTPStep(now)
+	for step := current - constants.TOTPAllowedSkewSteps; step <= current+constants.TOTPAllowedSkewSteps; step++ {
+		if step <= lastStep {
+			continue
+		}
+		expected, err := TOTPCode(secret, step)
+		if err != nil {
+			return 0, false
+		}
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+// TOTPProvisioningURI returns the otpauth:// URI that authenticator apps
+// read from a QR code.
+func TOTPProvisioningURI(issuer string, account string, secret string) string {
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", fmt.Sprint(constants.TOTPDigits))
+	query.Set("period", fmt.Sprint(constants.TOTPPeriodSeconds))
+
+	u := url.URL{
+		Scheme:   "otpauth",
+		Host:     "totp",
+		Path:     "/" + issuer + ":" + account,
+		RawQuery: query.Encode(),
+	}
+	return u.String()
+}
+
+// GenerateRecoveryCode returns a random code of RecoveryCodeLength base32
+// characters, split in two halves for readability.
+func GenerateRecoveryCode() (string, error) {
+	b := make([]byte, constants.RecoveryCodeLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	code := strings.ToLower(totpEncoding.EncodeToString(b))[:constants.RecoveryCodeLength]
+	half := constants.RecoveryCodeLength / 2
+	return code[:half] + "-" + code[half:], nil
+}
+
+// NormalizeRecoveryCode drops separators, spaces and case, so a code is
+// accepted however the user typed it.
+func NormalizeRecoveryCode(code string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '-', ' ':
+			return -1
+		}
+		return r
+	}, strings.ToLower(strings.TrimSpace(code)))
+}
@@ -0,0 +1,116 @@
+package utils_test
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/utils"
+	"github.com/stretchr/testify/require"
+)
+
+// rfcSecret is the RFC 6238 test key "12345678901234567890" in base32.
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestTOTPCode_RFC6238Vectors(t *testing.T) {
+	tests := []struct {
+		unix     int64
+		expected string
+	}{
+		{unix: 59, expected: "287082"},
+		{unix: 1111111109, expected: "081804"},
+		{unix: 1234567890, expected: "005924"},
+		{unix: 2000000000, expected: "279037"},
+	}
+
+	for _, tt := range tests {
+		code, err := utils.TOTPCode(rfcSecret, utils.TOTPStep(time.Unix(tt.unix, 0)))
+		require.NoError(t, err)
+		require.Equal(t, tt.expected, code, "time %d", tt.unix)
+	}
+}
+
+func TestTOTPCode_InvalidSecret(t *testing.T) {
+	_, err := utils.TOTPCode("not base32!", 1)
+	require.Error(t, err)
+}
+
+func TestGenerateTOTPSecret(t *testing.T) {
+	secret, err := utils.GenerateTOTPSecret()
+	require.NoError(t, err)
+	require.Len(t, secret, 32)
+
+	_, err = utils.TOTPCode(secret, 1)
+	require.NoError(t, err)
+
+	other, err := utils.GenerateTOTPSecret()
+	require.NoError(t, err)
+	require.NotEqual(t, secret, other)
+}
+
+func TestValidateTOTP(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+	step := utils.TOTPStep(now)
+	codeAt := func(s int64) string {
+		code, err := utils.TOTPCode(rfcSecret, s)
+		require.NoError(t, err)
+		return code
+	}
+
+	tests := []struct {
+		name     string
+		code     string
+		lastStep int64
+		wantStep int64
+		wantOK   bool
+	}{
+		{name: "CurrentStep", code: codeAt(step), wantStep: step, wantOK: true},
+		{name: "PreviousStep", code: codeAt(step - 1), wantStep: step - 1, wantOK: true},
+		{name: "NextStep", code: codeAt(step + 1), wantStep: step + 1, wantOK: true},
+		{name: "SurroundingSpaces", code: " " + codeAt(step) + " ", wantStep: step, wantOK: true},
+		{name: "TooOld", code: codeAt(step - 2)},
+		{name: "TooNew", code: codeAt(step + 2)},
+		{name: "Replayed", code: codeAt(step), lastStep: step},
+		{name: "NewerThanLastUse", code: codeAt(step + 1), lastStep: step, wantStep: step + 1, wantOK: true},
+		{name: "WrongLength", code: "12345"},
+		{name: "Empty", code: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotStep, ok := utils.ValidateTOTP(rfcSecret, tt.code, now, tt.lastStep)
+			require.Equal(t, tt.wantOK, ok)
+			require.Equal(t, tt.wantStep, gotStep)
+		})
+	}
+}
+
+func TestTOTPProvisioningURI(t *testing.T) {
+	uri := utils.TOTPProvisioningURI("Hangout Planner", "ada@example.com", rfcSecret)
+
+	u, err := url.Parse(uri)
+	require.NoError(t, err)
+	require.Equal(t, "otpauth", u.Scheme)
+	require.Equal(t, "totp", u.Host)
+	require.Equal(t, "/Hangout Planner:ada@example.com", u.Path)
+	require.Equal(t, rfcSecret, u.Query().Get("secret"))
+	require.Equal(t, "Hangout Planner", u.Query().Get("issuer"))
+	require.Equal(t, "6", u.Query().Get("digits"))
+	require.Equal(t, "30", u.Query().Get("period"))
+}
+
+func TestGenerateRecoveryCode(t *testing.T) {
+	code, err := utils.GenerateRecoveryCode()
+	require.NoError(t, err)
+	require.Regexp(t, `^[a-z2-7]{5}-[a-z2-7]{5}$`, code)
+
+	other, err := utils.GenerateRecoveryCode()
+	require.NoError(t, err)
+	require.NotEqual(t, code, other)
+}
+
+func TestNormalizeRecoveryCode(t *testing.T) {
+	require.Equal(t, "abcde23456", utils.NormalizeRecoveryCode("abcde-23456"))
+	require.Equal(t, "abcde23456", utils.NormalizeRecoveryCode(" ABCDE 23456 "))
+	require.Equal(t, "abcde23456", utils.NormalizeRecoveryCode("abcde23456"))
+}
@@ -0,0 +1,128 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/apperrors"
+	"github.com/Ernestgio/Hangout-Planner/services/hangout/internal/constants"
+	"github.com/labstack/echo/v4"
+)
+
+// maxResponseBodyBytes bounds how much of a receiver's response is drained so
+// the connection can be reused.
+const maxResponseBodyBytes = 64 << 10
+
+type Request struct {
+	URL        string
+	Secret     string
+	DeliveryID string
+	EventType  string
+	Payload    []byte
+}
+
+type Sender interface {
+	// Send posts the signed payload and returns the response status code, or
+	// 0 when no response was received. Any non-2xx status is an error.
+	Send(ctx context.Context, req *Request) (int, error)
+}
+
+type httpSender struct {
+	client *http.Client
+}
+
+// NewSender returns a Sender that refuses to connect to private, loopback and
+// link-local addresses unless allowPrivateTargets is set.
+func NewSender(timeout time.Duration, allowPrivateTargets bool) Sender {
+	dialer := &net.Dialer{Timeout: timeout}
+	if !allowPrivateTargets {
+		dialer.Control = guardDial
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &httpSender{
+		client: &http.Client{
+			Timeout:   timeout,
+			Transport: transport,
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+func (s *httpSender) Send(ctx context.Context, req *Request) (int, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, req.URL, bytes.NewReader(req.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := time.Now().Unix()
+	httpReq.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	httpReq.Header.Set("User-Agent", constants.WebhookUserAgent)
+	httpReq.Header.Set(constants.WebhookEventHeader, req.EventType)
+	httpReq.Header.Set(constants.WebhookDeliveryHeader, req.DeliveryID)
+	httpReq.Header.Set(constants.WebhookTimestampHeader, strconv.FormatInt(timestamp, 10))
+	httpReq.Header.Set(constants.WebhookSignatureHeader, Sign(req.Secret, timestamp, req.Payload))
+
+	res, err := s.client.Do(httpReq)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, maxResponseBodyBytes))
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return res.StatusCode, fmt.Errorf("%w: %d", apperrors.ErrWebhookUnexpectedStatus, res.StatusCode)
+	}
+	return res.StatusCode, nil
+}
+
+// ValidateURL checks that raw is an absolute http(s) URL.
+func ValidateURL(raw string, allowPrivateTargets bool) error {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" || u.User != nil {
+		return apperrors.ErrInvalidWebhookURL
+	}
+	if allowPrivateTargets {
+		return nil
+	}
+
+	host := u.Hostname()
+	if host == "localhost" {
+		return apperrors.ErrWebhookTargetNotAllowed
+	}
+	if ip := net.ParseIP(host); ip != nil && isInternalIP(ip) {
+		return apperrors.ErrWebhookTargetNotAllowed
+	}
+	return nil
+}
+
+func guardDial(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || isInternalIP(ip) {
+		return apperrors.ErrWebhookTargetNotAllowed
+	}
+	return nil
+}
+
+func isInternalIP(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast()
+}
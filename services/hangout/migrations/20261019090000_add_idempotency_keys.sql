@@ -0,0 +1,15 @@
+-- Create "idempotency_keys" table
+CREATE TABLE `idempotency_keys` (
+  `id` char(36) NOT NULL,
+  `key` varchar(255) NOT NULL,
+  `request_hash` char(64) NOT NULL,
+  `response_status` bigint NOT NULL DEFAULT 0,
+  `response_body` mediumblob NULL,
+  `expires_at` datetime(3) NOT NULL,
+  `created_at` datetime(3) NULL,
+  `user_id` char(36) NOT NULL,
+  PRIMARY KEY (`id`),
+  INDEX `idx_idempotency_keys_expires_at` (`expires_at`),
+  UNIQUE INDEX `idx_idempotency_user_key` (`user_id`, `key`),
+  CONSTRAINT `fk_idempotency_keys_user` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON UPDATE NO ACTION ON DELETE NO ACTION
+) CHARSET utf8mb4 COLLATE utf8mb4_0900_ai_ci;
@@ -0,0 +1,4 @@
+-- Modify "activities" table
+ALTER TABLE `activities` ADD COLUMN `version` bigint NOT NULL DEFAULT 1 AFTER `name`;
+-- Modify "hangouts" table
+ALTER TABLE `hangouts` ADD COLUMN `version` bigint NOT NULL DEFAULT 1 AFTER `status`;
@@ -0,0 +1,33 @@
+-- Create "webhook_subscriptions" table
+CREATE TABLE `webhook_subscriptions` (
+  `id` char(36) NOT NULL,
+  `url` varchar(2048) NOT NULL,
+  `secret` varchar(255) NOT NULL,
+  `event_types` varchar(512) NOT NULL,
+  `created_at` datetime(3) NULL,
+  `updated_at` datetime(3) NULL,
+  `user_id` char(36) NOT NULL,
+  PRIMARY KEY (`id`),
+  INDEX `idx_webhook_subscriptions_user_id` (`user_id`),
+  CONSTRAINT `fk_webhook_subscriptions_user` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON UPDATE NO ACTION ON DELETE NO ACTION
+) CHARSET utf8mb4 COLLATE utf8mb4_0900_ai_ci;
+-- Create "webhook_deliveries" table
+CREATE TABLE `webhook_deliveries` (
+  `id` char(36) NOT NULL,
+  `event_id` char(36) NOT NULL,
+  `event_type` varchar(64) NOT NULL,
+  `payload` mediumblob NOT NULL,
+  `status` varchar(20) NOT NULL,
+  `attempts` bigint NOT NULL DEFAULT 0,
+  `next_attempt_at` datetime(3) NULL,
+  `last_attempt_at` datetime(3) NULL,
+  `response_status` bigint NULL,
+  `last_error` varchar(500) NULL,
+  `created_at` datetime(3) NULL,
+  `updated_at` datetime(3) NULL,
+  `subscription_id` char(36) NOT NULL,
+  PRIMARY KEY (`id`),
+  INDEX `idx_webhook_deliveries_due` (`status`, `next_attempt_at`),
+  INDEX `idx_webhook_deliveries_subscription_id` (`subscription_id`),
+  CONSTRAINT `fk_webhook_deliveries_subscription` FOREIGN KEY (`subscription_id`) REFERENCES `webhook_subscriptions` (`id`) ON UPDATE NO ACTION ON DELETE NO ACTION
+) CHARSET utf8mb4 COLLATE utf8mb4_0900_ai_ci;
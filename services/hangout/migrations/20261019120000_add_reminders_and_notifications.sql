@@ -0,0 +1,41 @@
+-- Modify "hangouts" table
+ALTER TABLE `hangouts` ADD COLUMN `rsvp_deadline` datetime(3) NULL AFTER `date`;
+-- Create "reminders" table
+CREATE TABLE `reminders` (
+  `id` char(36) NOT NULL,
+  `kind` varchar(32) NOT NULL,
+  `offset_minutes` bigint NOT NULL,
+  `due_at` datetime(3) NOT NULL,
+  `status` varchar(20) NOT NULL,
+  `attempts` bigint NOT NULL DEFAULT 0,
+  `next_attempt_at` datetime(3) NULL,
+  `delivered_channels` varchar(255) NOT NULL DEFAULT "",
+  `last_error` varchar(500) NULL,
+  `sent_at` datetime(3) NULL,
+  `created_at` datetime(3) NULL,
+  `updated_at` datetime(3) NULL,
+  `hangout_id` char(36) NOT NULL,
+  `user_id` char(36) NOT NULL,
+  PRIMARY KEY (`id`),
+  INDEX `fk_reminders_user` (`user_id`),
+  INDEX `idx_reminders_due` (`status`, `next_attempt_at`),
+  UNIQUE INDEX `idx_reminders_schedule` (`hangout_id`, `user_id`, `kind`, `offset_minutes`, `due_at`),
+  CONSTRAINT `fk_reminders_hangout` FOREIGN KEY (`hangout_id`) REFERENCES `hangouts` (`id`) ON UPDATE NO ACTION ON DELETE NO ACTION,
+  CONSTRAINT `fk_reminders_user` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON UPDATE NO ACTION ON DELETE NO ACTION
+) CHARSET utf8mb4 COLLATE utf8mb4_0900_ai_ci;
+-- Create "notifications" table
+CREATE TABLE `notifications` (
+  `id` char(36) NOT NULL,
+  `type` varchar(64) NOT NULL,
+  `title` varchar(255) NOT NULL,
+  `body` text NULL,
+  `read_at` datetime(3) NULL,
+  `created_at` datetime(3) NULL,
+  `hangout_id` char(36) NULL,
+  `user_id` char(36) NOT NULL,
+  PRIMARY KEY (`id`),
+  INDEX `idx_notifications_hangout_id` (`hangout_id`),
+  INDEX `idx_notifications_user_created` (`user_id`, `created_at`),
+  CONSTRAINT `fk_notifications_hangout` FOREIGN KEY (`hangout_id`) REFERENCES `hangouts` (`id`) ON UPDATE NO ACTION ON DELETE NO ACTION,
+  CONSTRAINT `fk_notifications_user` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON UPDATE NO ACTION ON DELETE NO ACTION
+) CHARSET utf8mb4 COLLATE utf8mb4_0900_ai_ci;
@@ -0,0 +1,10 @@
+-- Create "notification_preferences" table
+CREATE TABLE `notification_preferences` (
+  `user_id` char(36) NOT NULL,
+  `type` varchar(64) NOT NULL,
+  `email` bool NOT NULL,
+  `inbox` bool NOT NULL,
+  `updated_at` datetime(3) NULL,
+  PRIMARY KEY (`user_id`, `type`),
+  CONSTRAINT `fk_notification_preferences_user` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON UPDATE NO ACTION ON DELETE NO ACTION
+) CHARSET utf8mb4 COLLATE utf8mb4_0900_ai_ci;
@@ -0,0 +1,26 @@
+-- Create "comments" table
+CREATE TABLE `comments` (
+  `id` char(36) NOT NULL,
+  `body` text NOT NULL,
+  `parent_id` char(36) NULL,
+  `edited_at` datetime(3) NULL,
+  `created_at` datetime(3) NULL,
+  `updated_at` datetime(3) NULL,
+  `hangout_id` char(36) NOT NULL,
+  `user_id` char(36) NOT NULL,
+  PRIMARY KEY (`id`),
+  INDEX `fk_comments_user` (`user_id`),
+  INDEX `idx_comments_hangout_created` (`hangout_id`, `created_at`),
+  INDEX `idx_comments_parent_id` (`parent_id`),
+  CONSTRAINT `fk_comments_hangout` FOREIGN KEY (`hangout_id`) REFERENCES `hangouts` (`id`) ON UPDATE NO ACTION ON DELETE NO ACTION,
+  CONSTRAINT `fk_comments_user` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON UPDATE NO ACTION ON DELETE NO ACTION
+) CHARSET utf8mb4 COLLATE utf8mb4_0900_ai_ci;
+-- Create "comment_mentions" table
+CREATE TABLE `comment_mentions` (
+  `comment_id` char(36) NOT NULL,
+  `user_id` char(36) NOT NULL,
+  PRIMARY KEY (`comment_id`, `user_id`),
+  INDEX `idx_comment_mentions_user_id` (`user_id`),
+  CONSTRAINT `fk_comment_mentions_user` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON UPDATE NO ACTION ON DELETE NO ACTION,
+  CONSTRAINT `fk_comments_mentions` FOREIGN KEY (`comment_id`) REFERENCES `comments` (`id`) ON UPDATE NO ACTION ON DELETE NO ACTION
+) CHARSET utf8mb4 COLLATE utf8mb4_0900_ai_ci;
@@ -0,0 +1,30 @@
+-- Modify "memories" table
+ALTER TABLE `memories` ADD COLUMN `caption` varchar(500) NULL AFTER `name`;
+-- Create "memory_tags" table
+CREATE TABLE `memory_tags` (
+  `memory_id` char(36) NOT NULL,
+  `tag` varchar(50) NOT NULL,
+  PRIMARY KEY (`memory_id`, `tag`),
+  INDEX `idx_memory_tags_tag` (`tag`),
+  CONSTRAINT `fk_memories_tags` FOREIGN KEY (`memory_id`) REFERENCES `memories` (`id`) ON UPDATE NO ACTION ON DELETE NO ACTION
+) CHARSET utf8mb4 COLLATE utf8mb4_0900_ai_ci;
+-- Create "memory_person_tags" table
+CREATE TABLE `memory_person_tags` (
+  `memory_id` char(36) NOT NULL,
+  `user_id` char(36) NOT NULL,
+  PRIMARY KEY (`memory_id`, `user_id`),
+  INDEX `idx_memory_person_tags_user_id` (`user_id`),
+  CONSTRAINT `fk_memories_person_tags` FOREIGN KEY (`memory_id`) REFERENCES `memories` (`id`) ON UPDATE NO ACTION ON DELETE NO ACTION,
+  CONSTRAINT `fk_memory_person_tags_user` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON UPDATE NO ACTION ON DELETE NO ACTION
+) CHARSET utf8mb4 COLLATE utf8mb4_0900_ai_ci;
+-- Create "memory_reactions" table
+CREATE TABLE `memory_reactions` (
+  `memory_id` char(36) NOT NULL,
+  `user_id` char(36) NOT NULL,
+  `emoji` varchar(32) NOT NULL,
+  `created_at` datetime(3) NULL,
+  PRIMARY KEY (`memory_id`, `user_id`, `emoji`),
+  INDEX `idx_memory_reactions_user_id` (`user_id`),
+  CONSTRAINT `fk_memories_reactions` FOREIGN KEY (`memory_id`) REFERENCES `memories` (`id`) ON UPDATE NO ACTION ON DELETE NO ACTION,
+  CONSTRAINT `fk_memory_reactions_user` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON UPDATE NO ACTION ON DELETE NO ACTION
+) CHARSET utf8mb4 COLLATE utf8mb4_0900_ai_ci;
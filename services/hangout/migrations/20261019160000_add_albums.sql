@@ -0,0 +1,27 @@
+-- Create "albums" table
+CREATE TABLE `albums` (
+  `id` char(36) NOT NULL,
+  `name` varchar(100) NOT NULL,
+  `cover_memory_id` char(36) NULL,
+  `created_at` datetime(3) NULL,
+  `updated_at` datetime(3) NULL,
+  `hangout_id` char(36) NOT NULL,
+  PRIMARY KEY (`id`),
+  INDEX `idx_albums_hangout_id` (`hangout_id`),
+  CONSTRAINT `fk_albums_hangout` FOREIGN KEY (`hangout_id`) REFERENCES `hangouts` (`id`) ON UPDATE NO ACTION ON DELETE NO ACTION
+) CHARSET utf8mb4 COLLATE utf8mb4_0900_ai_ci;
+-- Modify "memories" table
+ALTER TABLE `memories` ADD COLUMN `album_id` char(36) NULL, ADD COLUMN `position` varchar(64) CHARACTER SET ascii COLLATE ascii_bin NOT NULL DEFAULT '', ADD INDEX `idx_memories_album_position` (`album_id`, `position`), ADD CONSTRAINT `fk_memories_album` FOREIGN KEY (`album_id`) REFERENCES `albums` (`id`) ON UPDATE NO ACTION ON DELETE NO ACTION;
+-- Backfill "memories"."position" with order keys "d0000", "d0001", ... in upload order per hangout
+UPDATE `memories` m JOIN (
+  SELECT `id`, ROW_NUMBER() OVER (PARTITION BY `hangout_id` ORDER BY `created_at`, `id`) - 1 AS `rn` FROM `memories`
+) r ON r.`id` = m.`id`
+SET m.`position` = CONCAT(
+  'd',
+  SUBSTRING('0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz', FLOOR(r.`rn` / 238328) % 62 + 1, 1),
+  SUBSTRING('0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz', FLOOR(r.`rn` / 3844) % 62 + 1, 1),
+  SUBSTRING('0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz', FLOOR(r.`rn` / 62) % 62 + 1, 1),
+  SUBSTRING('0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz', r.`rn` % 62 + 1, 1)
+);
+-- Drop the backfill default
+ALTER TABLE `memories` ALTER COLUMN `position` DROP DEFAULT;
@@ -0,0 +1,36 @@
+-- Create "share_links" table
+CREATE TABLE `share_links` (
+  `id` char(36) NOT NULL,
+  `token_hash` char(64) NOT NULL,
+  `scope` varchar(20) NOT NULL,
+  `password_hash` varchar(255) NULL,
+  `expires_at` datetime(3) NULL,
+  `revoked_at` datetime(3) NULL,
+  `access_count` bigint NOT NULL DEFAULT 0,
+  `last_accessed_at` datetime(3) NULL,
+  `created_at` datetime(3) NULL,
+  `updated_at` datetime(3) NULL,
+  `hangout_id` char(36) NOT NULL,
+  `album_id` char(36) NULL,
+  `created_by_id` char(36) NOT NULL,
+  PRIMARY KEY (`id`),
+  INDEX `fk_share_links_created_by` (`created_by_id`),
+  INDEX `idx_share_links_album_id` (`album_id`),
+  INDEX `idx_share_links_hangout_id` (`hangout_id`),
+  UNIQUE INDEX `idx_share_links_token_hash` (`token_hash`),
+  CONSTRAINT `fk_share_links_album` FOREIGN KEY (`album_id`) REFERENCES `albums` (`id`) ON UPDATE NO ACTION ON DELETE NO ACTION,
+  CONSTRAINT `fk_share_links_created_by` FOREIGN KEY (`created_by_id`) REFERENCES `users` (`id`) ON UPDATE NO ACTION ON DELETE NO ACTION,
+  CONSTRAINT `fk_share_links_hangout` FOREIGN KEY (`hangout_id`) REFERENCES `hangouts` (`id`) ON UPDATE NO ACTION ON DELETE NO ACTION
+) CHARSET utf8mb4 COLLATE utf8mb4_0900_ai_ci;
+-- Create "share_link_accesses" table
+CREATE TABLE `share_link_accesses` (
+  `id` char(36) NOT NULL,
+  `outcome` varchar(20) NOT NULL,
+  `ip_address` varchar(45) NOT NULL,
+  `user_agent` varchar(255) NOT NULL,
+  `created_at` datetime(3) NULL,
+  `share_link_id` char(36) NOT NULL,
+  PRIMARY KEY (`id`),
+  INDEX `idx_share_link_accesses_link_created` (`share_link_id`, `created_at`),
+  CONSTRAINT `fk_share_link_accesses_share_link` FOREIGN KEY (`share_link_id`) REFERENCES `share_links` (`id`) ON UPDATE NO ACTION ON DELETE NO ACTION
+) CHARSET utf8mb4 COLLATE utf8mb4_0900_ai_ci;
@@ -0,0 +1,2 @@
+-- Modify "users" table
+ALTER TABLE `users` ADD COLUMN `email_verified_at` datetime(3) NULL;
@@ -0,0 +1,13 @@
+-- Create "signing_keys" table
+CREATE TABLE `signing_keys` (
+  `id` char(36) NOT NULL,
+  `algorithm` varchar(16) NOT NULL,
+  `public_key` blob NOT NULL,
+  `private_key` blob NOT NULL,
+  `activates_at` datetime(3) NOT NULL,
+  `retires_at` datetime(3) NULL,
+  `created_at` datetime(3) NULL,
+  PRIMARY KEY (`id`),
+  INDEX `idx_signing_keys_activates_at` (`activates_at`),
+  INDEX `idx_signing_keys_retires_at` (`retires_at`)
+) CHARSET utf8mb4 COLLATE utf8mb4_0900_ai_ci;
@@ -0,0 +1,13 @@
+-- Create "user_identities" table
+CREATE TABLE `user_identities` (
+  `id` char(36) NOT NULL,
+  `provider` varchar(64) NOT NULL,
+  `subject` varchar(255) NOT NULL,
+  `email` varchar(255) NULL,
+  `created_at` datetime(3) NULL,
+  `user_id` char(36) NOT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE INDEX `idx_user_identities_provider_subject` (`provider`, `subject`),
+  UNIQUE INDEX `idx_user_identities_user_provider` (`user_id`, `provider`),
+  CONSTRAINT `fk_user_identities_user` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON UPDATE NO ACTION ON DELETE NO ACTION
+) CHARSET utf8mb4 COLLATE utf8mb4_0900_ai_ci;
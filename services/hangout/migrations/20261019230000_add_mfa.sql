@@ -0,0 +1,24 @@
+-- Create "totp_credentials" table
+CREATE TABLE `totp_credentials` (
+  `id` char(36) NOT NULL,
+  `secret` blob NOT NULL,
+  `confirmed_at` datetime(3) NULL,
+  `last_used_step` bigint NOT NULL DEFAULT 0,
+  `created_at` datetime(3) NULL,
+  `updated_at` datetime(3) NULL,
+  `user_id` char(36) NOT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE INDEX `idx_totp_credentials_user_id` (`user_id`),
+  CONSTRAINT `fk_totp_credentials_user` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON UPDATE NO ACTION ON DELETE NO ACTION
+) CHARSET utf8mb4 COLLATE utf8mb4_0900_ai_ci;
+-- Create "recovery_codes" table
+CREATE TABLE `recovery_codes` (
+  `id` char(36) NOT NULL,
+  `code_hash` char(64) NOT NULL,
+  `used_at` datetime(3) NULL,
+  `created_at` datetime(3) NULL,
+  `user_id` char(36) NOT NULL,
+  PRIMARY KEY (`id`),
+  INDEX `idx_recovery_codes_user_code` (`user_id`, `code_hash`),
+  CONSTRAINT `fk_recovery_codes_user` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON UPDATE NO ACTION ON DELETE NO ACTION
+) CHARSET utf8mb4 COLLATE utf8mb4_0900_ai_ci;
@@ -0,0 +1,15 @@
+-- Create "sessions" table
+CREATE TABLE `sessions` (
+  `id` char(36) NOT NULL,
+  `user_agent` varchar(255) NOT NULL,
+  `ip_address` varchar(45) NOT NULL,
+  `last_used_at` datetime(3) NOT NULL,
+  `expires_at` datetime(3) NOT NULL,
+  `revoked_at` datetime(3) NULL,
+  `created_at` datetime(3) NULL,
+  `user_id` char(36) NOT NULL,
+  PRIMARY KEY (`id`),
+  INDEX `idx_sessions_expires_at` (`expires_at`),
+  INDEX `idx_sessions_user_id` (`user_id`),
+  CONSTRAINT `fk_sessions_user` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON UPDATE NO ACTION ON DELETE NO ACTION
+) CHARSET utf8mb4 COLLATE utf8mb4_0900_ai_ci;
@@ -0,0 +1,15 @@
+-- Create "personal_access_tokens" table
+CREATE TABLE `personal_access_tokens` (
+  `id` char(36) NOT NULL,
+  `name` varchar(100) NOT NULL,
+  `token_hash` char(64) NOT NULL,
+  `scopes` varchar(255) NOT NULL,
+  `expires_at` datetime(3) NULL,
+  `last_used_at` datetime(3) NULL,
+  `created_at` datetime(3) NULL,
+  `user_id` char(36) NOT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE INDEX `idx_personal_access_tokens_token_hash` (`token_hash`),
+  INDEX `idx_personal_access_tokens_user_id` (`user_id`),
+  CONSTRAINT `fk_personal_access_tokens_user` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON UPDATE NO ACTION ON DELETE NO ACTION
+) CHARSET utf8mb4 COLLATE utf8mb4_0900_ai_ci;
@@ -0,0 +1,19 @@
+-- Create "data_exports" table
+CREATE TABLE `data_exports` (
+  `id` char(36) NOT NULL,
+  `status` varchar(20) NOT NULL,
+  `data` longblob NULL,
+  `archive_id` char(36) NULL,
+  `last_error` varchar(500) NULL,
+  `claimed_until` datetime(3) NULL,
+  `completed_at` datetime(3) NULL,
+  `expires_at` datetime(3) NOT NULL,
+  `created_at` datetime(3) NULL,
+  `updated_at` datetime(3) NULL,
+  `user_id` char(36) NOT NULL,
+  PRIMARY KEY (`id`),
+  INDEX `idx_data_exports_expires_at` (`expires_at`),
+  INDEX `idx_data_exports_status` (`status`),
+  INDEX `idx_data_exports_user_id` (`user_id`),
+  CONSTRAINT `fk_data_exports_user` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON UPDATE NO ACTION ON DELETE NO ACTION
+) CHARSET utf8mb4 COLLATE utf8mb4_0900_ai_ci;
@@ -0,0 +1,18 @@
+-- Modify "users" table
+ALTER TABLE `users` ADD COLUMN `role` varchar(20) NOT NULL DEFAULT "user", ADD COLUMN `disabled_at` datetime(3) NULL;
+-- Modify "memories" table
+ALTER TABLE `memories` ADD COLUMN `hidden_at` datetime(3) NULL;
+-- Create "admin_audit_logs" table
+CREATE TABLE `admin_audit_logs` (
+  `id` char(36) NOT NULL,
+  `action` varchar(50) NOT NULL,
+  `target_type` varchar(20) NOT NULL,
+  `target` varchar(255) NOT NULL,
+  `reason` varchar(500) NOT NULL,
+  `created_at` datetime(3) NULL,
+  `actor_id` char(36) NOT NULL,
+  PRIMARY KEY (`id`),
+  INDEX `idx_admin_audit_logs_actor_id` (`actor_id`),
+  INDEX `idx_admin_audit_logs_target` (`target`),
+  CONSTRAINT `fk_admin_audit_logs_actor` FOREIGN KEY (`actor_id`) REFERENCES `users` (`id`) ON UPDATE NO ACTION ON DELETE NO ACTION
+) CHARSET utf8mb4 COLLATE utf8mb4_0900_ai_ci;
@@ -0,0 +1,22 @@
+-- Create "notification_emails" table
+CREATE TABLE `notification_emails` (
+  `id` char(36) NOT NULL,
+  `type` varchar(64) NOT NULL,
+  `subject` varchar(255) NOT NULL,
+  `body` text NULL,
+  `status` varchar(20) NOT NULL,
+  `attempts` bigint NOT NULL DEFAULT 0,
+  `next_attempt_at` datetime(3) NULL,
+  `last_error` varchar(500) NULL,
+  `sent_at` datetime(3) NULL,
+  `created_at` datetime(3) NULL,
+  `updated_at` datetime(3) NULL,
+  `hangout_id` char(36) NULL,
+  `user_id` char(36) NOT NULL,
+  PRIMARY KEY (`id`),
+  INDEX `idx_notification_emails_due` (`status`, `next_attempt_at`),
+  INDEX `idx_notification_emails_hangout_id` (`hangout_id`),
+  INDEX `idx_notification_emails_user_id` (`user_id`),
+  CONSTRAINT `fk_notification_emails_hangout` FOREIGN KEY (`hangout_id`) REFERENCES `hangouts` (`id`) ON UPDATE NO ACTION ON DELETE NO ACTION,
+  CONSTRAINT `fk_notification_emails_user` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON UPDATE NO ACTION ON DELETE NO ACTION
+) CHARSET utf8mb4 COLLATE utf8mb4_0900_ai_ci;
@@ -0,0 +1,2 @@
+-- Modify "data_exports" table
+ALTER TABLE `data_exports` DROP COLUMN `data`;
@@ -0,0 +1,2 @@
+-- Modify "users" table
+ALTER TABLE `users` ADD COLUMN `pending_email` varchar(255) NULL;